@@ -0,0 +1,332 @@
+// completion.go는 셸 자동 완성 스크립트 생성(completion)과
+// 워크스페이스/에이전트/작업 템플릿 이름의 동적 완성을 구현합니다.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/apiclient"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionCacheTTL은 동적 완성 후보 캐시의 유효 기간입니다.
+	// 탭을 누를 때마다 새 프로세스가 백엔드를 호출하지 않도록 짧게 재사용합니다.
+	completionCacheTTL = 5 * time.Minute
+	// completionFetchTimeout은 완성 후보를 백엔드에서 가져올 때의 최대 대기 시간입니다.
+	// 초과하면 만료된 캐시라도 사용하고, 캐시가 없으면 후보 없이 끝냅니다.
+	completionFetchTimeout = 3 * time.Second
+	// completionMaxAgents는 완성 후보로 가져오는 최대 에이전트 수입니다.
+	completionMaxAgents = 500
+)
+
+var completionNoDescriptions bool
+
+// completionCmd는 셸 자동 완성 스크립트를 출력합니다.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "셸 자동 완성 스크립트를 생성합니다",
+	Long: `지정한 셸의 자동 완성 스크립트를 표준 출력으로 출력합니다.
+
+명령어와 플래그 외에 워크스페이스 slug/ID, 에이전트 ID/이름, 작업 템플릿 이름도 완성합니다.
+워크스페이스와 에이전트 목록은 로그인 정보로 백엔드에서 가져와 5분간 캐시합니다.
+
+설치 예시:
+  bash:       autopus completion bash > /etc/bash_completion.d/autopus
+              (현재 셸만: source <(autopus completion bash))
+  zsh:        autopus completion zsh > "${fpath[1]}/_autopus"
+              (compinit이 활성화되어 있어야 합니다)
+  fish:       autopus completion fish > ~/.config/fish/completions/autopus.fish
+  powershell: autopus completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletionScript(cmd.Root(), args[0], cmd.OutOrStdout(), !completionNoDescriptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+	completionCmd.Flags().BoolVar(&completionNoDescriptions, "no-descriptions", false, "완성 후보 설명을 출력하지 않습니다")
+}
+
+// writeCompletionScript는 shell용 자동 완성 스크립트를 out에 씁니다.
+func writeCompletionScript(root *cobra.Command, shell string, out io.Writer, descriptions bool) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(out, descriptions)
+	case "zsh":
+		if descriptions {
+			return root.GenZshCompletion(out)
+		}
+		return root.GenZshCompletionNoDesc(out)
+	case "fish":
+		return root.GenFishCompletion(out, descriptions)
+	case "powershell":
+		if descriptions {
+			return root.GenPowerShellCompletionWithDesc(out)
+		}
+		return root.GenPowerShellCompletion(out)
+	default:
+		return fmt.Errorf("지원하지 않는 셸입니다: %s (bash, zsh, fish, powershell)", shell)
+	}
+}
+
+// completionCacheEntry는 캐시 키 하나의 완성 후보 데이터입니다.
+type completionCacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// completionCachePath는 동적 완성 후보 캐시 파일 경로를 반환합니다 (테스트에서 교체).
+var completionCachePath = func() string {
+	path := config.DefaultConfigPath()
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(path), "completion-cache.json")
+}
+
+// readCompletionCache는 캐시 파일을 읽습니다. 없거나 손상되었으면 빈 캐시를 반환합니다.
+func readCompletionCache() map[string]completionCacheEntry {
+	cache := make(map[string]completionCacheEntry)
+	path := completionCachePath()
+	if path == "" {
+		return cache
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+// writeCompletionCache는 캐시 파일을 저장합니다. 완성은 보조 기능이므로 실패는 무시합니다.
+func writeCompletionCache(cache map[string]completionCacheEntry) {
+	path := completionCachePath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
+
+// cachedCompletionData는 key의 캐시가 유효하면 그대로, 아니면 fetch로 새로 가져와 캐시합니다.
+// fetch가 실패하면 만료된 캐시라도 반환하며, 그마저 없으면 nil을 반환합니다.
+func cachedCompletionData[T any](key string, fetch func(ctx context.Context) ([]T, error)) []T {
+	cache := readCompletionCache()
+	var cached []T
+	entry, ok := cache[key]
+	if ok && json.Unmarshal(entry.Data, &cached) == nil && time.Since(entry.FetchedAt) < completionCacheTTL {
+		return cached
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionFetchTimeout)
+	defer cancel()
+	fresh, err := fetch(ctx)
+	if err != nil {
+		return cached
+	}
+	if data, err := json.Marshal(fresh); err == nil {
+		cache[key] = completionCacheEntry{FetchedAt: time.Now(), Data: data}
+		writeCompletionCache(cache)
+	}
+	return fresh
+}
+
+// completionWorkspaces는 로그인한 사용자의 워크스페이스 목록을 반환합니다.
+func completionWorkspaces() []Workspace {
+	return cachedCompletionData("workspaces", func(ctx context.Context) ([]Workspace, error) {
+		client, err := newAPIClient()
+		if err != nil {
+			return nil, err
+		}
+		return apiclient.DoList[Workspace](client, ctx, "GET", "/api/v1/workspaces", nil)
+	})
+}
+
+// completionAgents는 활성 워크스페이스의 에이전트 목록을 반환합니다.
+// BackendClient의 list_agents 자동 페이지 조회를 사용하며, 워크스페이스별로 캐시합니다.
+func completionAgents() []mcpserver.AgentInfo {
+	backend, creds, _, err := newBackendClient()
+	if err != nil {
+		return nil
+	}
+	return cachedCompletionData("agents:"+creds.WorkspaceID, func(ctx context.Context) ([]mcpserver.AgentInfo, error) {
+		resp, err := backend.ListAllAgents(ctx, &mcpserver.ListAgentsRequest{WorkspaceID: creds.WorkspaceID}, completionMaxAgents)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Agents, nil
+	})
+}
+
+// filterCompletions는 toComplete로 시작하는 후보만 "값\t설명" 형식으로 반환합니다.
+func filterCompletions(toComplete string, values, descriptions []string) []string {
+	var out []string
+	for i, v := range values {
+		if v == "" || !strings.HasPrefix(v, toComplete) {
+			continue
+		}
+		if descriptions[i] != "" {
+			v += "\t" + descriptions[i]
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// completeWorkspaceSlugs는 워크스페이스 slug를 완성합니다 (workspace switch).
+func completeWorkspaceSlugs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	workspaces := completionWorkspaces()
+	slugs := make([]string, len(workspaces))
+	names := make([]string, len(workspaces))
+	for i, ws := range workspaces {
+		slugs[i], names[i] = ws.Slug, ws.Name
+	}
+	return filterCompletions(toComplete, slugs, names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkspaceIDs는 워크스페이스 ID를 완성합니다 (workspace show/members, --workspace-id).
+func completeWorkspaceIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	workspaces := completionWorkspaces()
+	ids := make([]string, len(workspaces))
+	names := make([]string, len(workspaces))
+	for i, ws := range workspaces {
+		ids[i], names[i] = ws.ID, ws.Name
+	}
+	return filterCompletions(toComplete, ids, names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkspaceIDFlag는 --workspace-id 플래그 값을 완성합니다.
+func completeWorkspaceIDFlag(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeWorkspaceIDs(cmd, nil, toComplete)
+}
+
+// completeAgentIDs는 에이전트 ID를 완성합니다 (agent show/update 등, --agent).
+func completeAgentIDs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	agents := completionAgents()
+	ids := make([]string, len(agents))
+	names := make([]string, len(agents))
+	for i, ag := range agents {
+		ids[i], names[i] = ag.ID, ag.Name
+	}
+	return filterCompletions(toComplete, ids, names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAgentIDFlag는 에이전트 ID를 받는 플래그 값을 완성합니다.
+func completeAgentIDFlag(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeAgentIDs(cmd, nil, toComplete)
+}
+
+// completeAgentNames는 에이전트 이름을 받는 플래그 값을 완성합니다 (execute/chat --agent 등).
+func completeAgentNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	agents := completionAgents()
+	names := make([]string, len(agents))
+	descriptions := make([]string, len(agents))
+	for i, ag := range agents {
+		names[i], descriptions[i] = ag.Name, ag.Description
+	}
+	return filterCompletions(toComplete, names, descriptions), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTemplateNames는 로컬 작업 템플릿 이름을 완성합니다 (exec --template).
+func completeTemplateNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	registry, err := loadTaskTemplates()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	templates := registry.List()
+	names := make([]string, len(templates))
+	descriptions := make([]string, len(templates))
+	for i, t := range templates {
+		names[i], descriptions[i] = t.Name, t.Description
+	}
+	return filterCompletions(toComplete, names, descriptions), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionFunc는 cobra의 인자/플래그 동적 완성 함수 시그니처입니다.
+type completionFunc = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// registerDynamicCompletions는 인자와 플래그별 동적 완성 함수를 등록합니다.
+// 플래그는 각 명령 파일의 init에서 정의되므로 모든 init이 끝난 뒤 Execute에서 호출합니다.
+func registerDynamicCompletions() {
+	args := map[*cobra.Command]completionFunc{
+		workspaceSwitchCmd:    completeWorkspaceSlugs,
+		workspaceShowCmd:      completeWorkspaceIDs,
+		workspaceMembersCmd:   completeWorkspaceIDs,
+		agentShowCmd:          completeAgentIDs,
+		agentActivityCmd:      completeAgentIDs,
+		agentPerformanceCmd:   completeAgentIDs,
+		agentUpdateCmd:        completeAgentIDs,
+		agentDeleteCmd:        completeAgentIDs,
+		agentToggleCmd:        completeAgentIDs,
+		agentProviderCmd:      completeAgentIDs,
+		agentSetProviderCmd:   completeAgentIDs,
+		observabilityAgentCmd: completeAgentIDs,
+	}
+	for cmd, fn := range args {
+		if cmd.ValidArgsFunction == nil {
+			cmd.ValidArgsFunction = fn
+		}
+	}
+
+	flags := []struct {
+		cmd  *cobra.Command
+		flag string
+		fn   completionFunc
+	}{
+		{execCmd, "agent", completeAgentIDFlag},
+		{execCmd, "agent-name", completeAgentNames},
+		{execCmd, "template", completeTemplateNames},
+		{execCmd, "workspace-id", completeWorkspaceIDFlag},
+		{executeCmd, "agent", completeAgentNames},
+		{executeCmd, "workspace-id", completeWorkspaceIDFlag},
+		{chatCmd, "agent", completeAgentNames},
+		{chatHistoryCmd, "agent", completeAgentNames},
+		{executionListCmd, "agent", completeAgentNames},
+		{executionStatsCmd, "agent", completeAgentNames},
+		{logsCmd, "agent", completeAgentNames},
+		{taskCreateCmd, "agent", completeAgentIDFlag},
+		{taskAssignCmd, "agent", completeAgentIDFlag},
+		{issueAssignCmd, "agent", completeAgentIDFlag},
+		{automationCreateCmd, "agent", completeAgentIDFlag},
+		{automationUpdateCmd, "agent", completeAgentIDFlag},
+		{scheduleCreateCmd, "agent", completeAgentIDFlag},
+		{scheduleUpdateCmd, "agent", completeAgentIDFlag},
+		{reportListCmd, "agent", completeAgentIDFlag},
+		{reportCreateCmd, "agent", completeAgentIDFlag},
+	}
+	for _, f := range flags {
+		if _, ok := f.cmd.GetFlagCompletionFunc(f.flag); ok {
+			continue
+		}
+		_ = f.cmd.RegisterFlagCompletionFunc(f.flag, f.fn)
+	}
+}
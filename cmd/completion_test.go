@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompletionCmd_GeneratesScripts(t *testing.T) {
+	markers := map[string]string{
+		"bash":       "__start_autopus",
+		"zsh":        "#compdef autopus",
+		"fish":       "complete -c autopus",
+		"powershell": "Register-ArgumentCompleter",
+	}
+	for shell, marker := range markers {
+		for _, desc := range []bool{true, false} {
+			var out bytes.Buffer
+			if err := writeCompletionScript(rootCmd, shell, &out, desc); err != nil {
+				t.Fatalf("writeCompletionScript(%s, %v) error: %v", shell, desc, err)
+			}
+			if !strings.Contains(out.String(), marker) {
+				t.Errorf("%s 스크립트에 %q가 없습니다", shell, marker)
+			}
+		}
+	}
+	if err := writeCompletionScript(rootCmd, "tcsh", &bytes.Buffer{}, true); err == nil {
+		t.Error("지원하지 않는 셸은 에러를 반환해야 합니다")
+	}
+}
+
+func TestFilterCompletions(t *testing.T) {
+	got := filterCompletions("de", []string{"dev", "design", "ops", "", "demo"}, []string{"개발", "", "운영", "빈 값", "데모"})
+	want := []string{"dev\t개발", "design", "demo\t데모"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("filterCompletions() = %q, want %q", got, want)
+	}
+}
+
+func TestCachedCompletionData_UsesCacheAndFallsBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "completion-cache.json")
+	orig := completionCachePath
+	completionCachePath = func() string { return path }
+	t.Cleanup(func() { completionCachePath = orig })
+
+	calls := 0
+	fetch := func(context.Context) ([]string, error) {
+		calls++
+		return []string{"a", "b"}, nil
+	}
+	for i := 0; i < 2; i++ {
+		if got := cachedCompletionData("k", fetch); len(got) != 2 {
+			t.Fatalf("cachedCompletionData() = %v, want [a b]", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch 호출 %d회, 캐시가 유효하면 1회여야 합니다", calls)
+	}
+
+	// 만료된 캐시는 다시 가져오고, 실패하면 만료된 값을 그대로 사용한다.
+	cache := readCompletionCache()
+	entry := cache["k"]
+	entry.FetchedAt = time.Now().Add(-2 * completionCacheTTL)
+	cache["k"] = entry
+	writeCompletionCache(cache)
+
+	failing := func(context.Context) ([]string, error) {
+		calls++
+		return nil, errors.New("offline")
+	}
+	if got := cachedCompletionData("k", failing); len(got) != 2 {
+		t.Errorf("fetch 실패 시 만료된 캐시를 사용해야 합니다: %v", got)
+	}
+	if calls != 2 {
+		t.Errorf("만료된 캐시는 다시 가져와야 합니다 (fetch 호출 %d회)", calls)
+	}
+	if got := cachedCompletionData("other", failing); got != nil {
+		t.Errorf("캐시가 없고 fetch가 실패하면 nil이어야 합니다: %v", got)
+	}
+}
+
+func TestRegisterDynamicCompletions(t *testing.T) {
+	registerDynamicCompletions()
+	registerDynamicCompletions()
+
+	if workspaceSwitchCmd.ValidArgsFunction == nil || agentShowCmd.ValidArgsFunction == nil {
+		t.Error("workspace switch/agent show 인자 완성이 등록되지 않았습니다")
+	}
+	for _, flag := range []string{"agent", "agent-name", "template", "workspace-id"} {
+		if _, ok := execCmd.GetFlagCompletionFunc(flag); !ok {
+			t.Errorf("exec --%s 완성이 등록되지 않았습니다", flag)
+		}
+	}
+}
+
+func TestCommandGroups_AllCommandsGrouped(t *testing.T) {
+	applyCommandGroups(rootCmd)
+	applyCommandGroups(rootCmd)
+
+	for _, c := range rootCmd.Commands() {
+		if c.Hidden || c.Name() == "help" {
+			continue
+		}
+		if c.GroupID == "" {
+			t.Errorf("%q 명령어가 도움말 그룹에 속하지 않습니다 (commandGroups에 추가하세요)", c.Name())
+		}
+	}
+	if got := len(rootCmd.Groups()); got != len(commandGroups) {
+		t.Errorf("그룹 수 = %d, want %d", got, len(commandGroups))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -27,6 +28,18 @@ var configCmd = &cobra.Command{
   - CLAUDE_API_KEY: Claude API 키
   - GEMINI_API_KEY: Gemini API 키
   - LAB_TOKEN: JWT 인증 토큰`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := initLanguage(); err != nil {
+			return err
+		}
+		// 설정 파일이 잘못되어 있어도 config 명령으로 고칠 수 있도록
+		// 설정 로드 실패 시 기본 로거로 계속 진행한다.
+		if err := initLogger(); err != nil {
+			logger.Setup(config.LoggingConfig{Level: "info", Format: "text"})
+		}
+		initCrashReporter()
+		return nil
+	},
 }
 
 // configSetCmd는 설정 값을 저장하는 명령어입니다.
@@ -35,22 +48,17 @@ var configSetCmd = &cobra.Command{
 	Short: "설정 값을 저장합니다",
 	Long: `설정 파일에 값을 저장합니다.
 
-키는 점(.)으로 구분된 경로를 사용합니다.
+키는 점(.)으로 구분된 경로를 사용합니다. 값은 스키마의 타입으로 변환되며
+타입이 맞지 않거나 허용되지 않은 값이면 저장하지 않습니다.
+문자열 목록은 쉼표로 구분합니다.
+
 예시:
-  autopus config set server.url wss://custom.server.io/ws/agent
+  autopus config set server.ws_url wss://custom.server.io/ws/agent
   autopus config set logging.level debug
   autopus config set reconnection.max_attempts 5
+  autopus config set security.sandbox.allowed_paths ~/projects,~/work
 
-지원하는 설정 키:
-  server.url              - WebSocket 서버 URL
-  server.timeout_seconds  - 연결 타임아웃(초)
-  logging.level           - 로그 레벨 (debug, info, warn, error)
-  logging.format          - 로그 포맷 (json, text)
-  logging.file            - 로그 파일 경로 (비어있으면 stdout)
-  reconnection.max_attempts     - 최대 재연결 시도 횟수 (최대 10)
-  reconnection.initial_delay_ms - 초기 재연결 지연(밀리초)
-  reconnection.max_delay_ms     - 최대 재연결 지연(밀리초)
-  reconnection.backoff_multiplier - 지수 백오프 배수`,
+지원하는 설정 키와 기본값은 autopus config schema로 확인할 수 있습니다.`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -63,7 +71,7 @@ var configGetCmd = &cobra.Command{
 
 키는 점(.)으로 구분된 경로를 사용합니다.
 예시:
-  autopus config get server.url
+  autopus config get server.ws_url
   autopus config get logging.level`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigGet,
@@ -117,44 +125,63 @@ func init() {
 	configInitCmd.Flags().BoolVar(&forceInit, "force", false, "기존 파일을 덮어씁니다")
 }
 
-// runConfigSet은 설정 값을 저장합니다.
+// runConfigSet은 설정 값을 스키마로 검사한 뒤 설정 파일에 저장합니다.
+// 설정 파일의 다른 값은 그대로 두고 지정한 키만 바꿉니다.
 func runConfigSet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	value := args[1]
 
-	// 유효한 키인지 확인
-	if !isValidConfigKey(key) {
-		return fmt.Errorf("알 수 없는 설정 키: %s", key)
+	// 이전 레이아웃의 키는 현재 키로 안내
+	if d, ok := config.LookupDeprecation(key); ok {
+		return deprecatedConfigKeyError(key, d)
+	}
+	field, ok := config.LookupField(key)
+	if !ok {
+		return fmt.Errorf("알 수 없는 설정 키: %s (지원하는 키: autopus config schema)", key)
 	}
 
-	// 값 변환 (숫자, 불리언 등)
-	parsedValue := parseConfigValue(value)
-
-	// viper에 설정
-	viper.Set(key, parsedValue)
-
-	// 설정 디렉토리 확인/생성
-	if err := config.EnsureConfigDir(); err != nil {
-		return fmt.Errorf("설정 디렉토리 생성 실패: %w", err)
+	// 스키마 타입으로 변환 (숫자, 불리언, 목록 등)
+	parsedValue, err := field.Parse(value)
+	if err != nil {
+		return err
 	}
 
-	// 설정 파일 저장
-	configPath := config.DefaultConfigPath()
-	if err := viper.WriteConfigAs(configPath); err != nil {
-		return fmt.Errorf("설정 파일 저장 실패: %w", err)
+	configPath := configFilePath()
+	doc, _, err := readConfigDocument(configPath)
+	if err != nil {
+		return err
+	}
+	config.SetDocumentValue(doc, field.Key, parsedValue)
+	if err := writeConfigDocument(configPath, doc); err != nil {
+		return err
 	}
 
-	fmt.Printf("%s = %v\n", key, parsedValue)
+	// 현재 프로세스에도 반영
+	viper.Set(field.Key, parsedValue)
+
+	fmt.Printf("%s = %v\n", field.Key, parsedValue)
 	fmt.Printf("설정이 저장되었습니다: %s\n", configPath)
 	return nil
 }
 
+// deprecatedConfigKeyError는 사용 중단된 키에 대한 에러를 만듭니다.
+func deprecatedConfigKeyError(key string, d config.Deprecation) error {
+	if d.Replacement == "" {
+		return fmt.Errorf("더 이상 사용되지 않는 설정 키: %s (%s)", key, d.Note)
+	}
+	replacement := d.Replacement + strings.TrimPrefix(strings.ToLower(key), d.Key)
+	return fmt.Errorf("더 이상 사용되지 않는 설정 키: %s (%s 사용)", key, replacement)
+}
+
 // runConfigGet은 설정 값을 조회합니다.
 func runConfigGet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 
 	value := viper.Get(key)
 	if value == nil {
+		if d, ok := config.LookupDeprecation(key); ok {
+			return deprecatedConfigKeyError(key, d)
+		}
 		return fmt.Errorf("설정 키를 찾을 수 없습니다: %s", key)
 	}
 
@@ -227,7 +254,7 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 # 생성됨: autopus config init
 
 server:
-  url: "wss://api.autopus.co/ws/agent"
+  ws_url: "wss://api.autopus.co/ws/agent"
   timeout_seconds: 30
 
 auth:
@@ -276,62 +303,6 @@ reconnection:
 	return nil
 }
 
-// isValidConfigKey는 유효한 설정 키인지 확인합니다.
-func isValidConfigKey(key string) bool {
-	validKeys := map[string]bool{
-		"server.url":                      true,
-		"server.timeout_seconds":          true,
-		"auth.token_file":                 true,
-		"providers.claude.api_key_env":    true,
-		"providers.claude.default_model":  true,
-		"providers.claude.mode":           true, // api, cli, hybrid
-		"providers.claude.cli_path":       true, // claude CLI 바이너리 경로
-		"providers.claude.cli_timeout":    true, // CLI 실행 타임아웃(초)
-		"providers.gemini.api_key_env":    true,
-		"providers.gemini.default_model":  true,
-		"providers.gemini.mode":           true,
-		"providers.gemini.cli_path":       true,
-		"providers.gemini.cli_timeout":    true,
-		"logging.level":                   true,
-		"logging.format":                  true,
-		"logging.file":                    true,
-		"reconnection.max_attempts":       true,
-		"reconnection.initial_delay_ms":   true,
-		"reconnection.max_delay_ms":       true,
-		"reconnection.backoff_multiplier": true,
-	}
-	return validKeys[key]
-}
-
-// parseConfigValue는 문자열 값을 적절한 타입으로 변환합니다.
-func parseConfigValue(value string) interface{} {
-	// 불리언
-	if value == "true" {
-		return true
-	}
-	if value == "false" {
-		return false
-	}
-
-	// 정수
-	var intVal int
-	if _, err := fmt.Sscanf(value, "%d", &intVal); err == nil {
-		// 소수점이 없으면 정수로 처리
-		if !strings.Contains(value, ".") {
-			return intVal
-		}
-	}
-
-	// 실수
-	var floatVal float64
-	if _, err := fmt.Sscanf(value, "%f", &floatVal); err == nil {
-		return floatVal
-	}
-
-	// 기본: 문자열
-	return value
-}
-
 // maskSensitiveValue는 민감한 값을 마스킹합니다.
 func maskSensitiveValue(value string) string {
 	if len(value) <= 8 {
@@ -0,0 +1,191 @@
+// config_reload.go는 connect 실행 중 설정 파일 변경을 감지해 안전한 설정을 재연결 없이 반영합니다.
+package cmd
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+	"github.com/spf13/viper"
+)
+
+// reloadRule은 실행 중 반영 가능한 설정 키와 적용 함수입니다.
+// key와 정확히 일치하거나 key로 시작하는 하위 키(key + ".")가 변경되면 apply가 호출됩니다.
+type reloadRule struct {
+	key   string
+	apply func(cfg *config.Config)
+}
+
+// matches는 변경된 설정 키가 규칙에 해당하는지 반환합니다.
+func (r reloadRule) matches(changedKey string) bool {
+	return changedKey == r.key || strings.HasPrefix(changedKey, r.key+".")
+}
+
+// configReloader는 설정 변경을 안전한 설정(즉시 반영)과 재시작이 필요한 설정으로 분류하여 처리합니다.
+type configReloader struct {
+	mu      sync.Mutex
+	current *config.Config
+	rules   []reloadRule
+}
+
+// newConfigReloader는 현재 설정과 반영 규칙으로 configReloader를 생성합니다.
+func newConfigReloader(current *config.Config, rules ...reloadRule) *configReloader {
+	return &configReloader{
+		current: current,
+		rules:   rules,
+	}
+}
+
+// Apply는 새 설정을 이전 설정과 비교하여 안전한 설정을 반영하고,
+// 반영된 키와 재시작이 필요한 키 목록을 반환합니다.
+func (r *configReloader) Apply(next *config.Config) (applied, restartRequired []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := config.ChangedKeys(r.current, next)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	triggered := make([]bool, len(r.rules))
+	for _, key := range changed {
+		matched := false
+		for i, rule := range r.rules {
+			if rule.matches(key) {
+				triggered[i] = true
+				matched = true
+				break
+			}
+		}
+		if matched {
+			applied = append(applied, key)
+		} else {
+			restartRequired = append(restartRequired, key)
+		}
+	}
+
+	// 같은 규칙에 해당하는 키가 여러 개 바뀌어도 한 번만 적용한다.
+	for i, rule := range r.rules {
+		if triggered[i] {
+			rule.apply(next)
+		}
+	}
+
+	r.current = next
+	return applied, restartRequired
+}
+
+// connectReloadRules는 connect 실행 중 재연결 없이 반영할 수 있는 설정 규칙을 구성합니다.
+// resultCache가 nil이면(캐시 비활성화) 캐시 설정 변경도 재시작이 필요한 변경으로 분류됩니다.
+// remote가 nil이 아니면 허용 목록과 로그 레벨은 서버가 변경한 값과 합쳐 반영합니다.
+func connectReloadRules(resultCache *websocket.ResultCache, actionGate *approval.ActionGate, remote *remoteSettings) []reloadRule {
+	rules := []reloadRule{
+		{
+			key: "logging.level",
+			apply: func(cfg *config.Config) {
+				if remote != nil {
+					remote.SetLocalLogLevel(cfg.Logging.Level)
+					return
+				}
+				logger.SetLevel(cfg.Logging.Level)
+			},
+		},
+	}
+
+	if resultCache != nil {
+		applyResultCache := func(cfg *config.Config) {
+			resultCache.Reconfigure(cfg.ResultCache.GetWindow(), cfg.ResultCache.MatchPromptHash)
+		}
+		for _, key := range []string{"window_seconds", "match_prompt_hash"} {
+			rules = append(rules, reloadRule{
+				key:   "result_cache." + key,
+				apply: applyResultCache,
+			})
+		}
+	}
+
+	if actionGate != nil {
+		applyAllowlist := func(cfg *config.Config) {
+			if remote != nil {
+				remote.SetLocalApproval(cfg.Security.ActionApproval)
+				return
+			}
+			actionGate.SetAllowlist(actionGateAllowlist(cfg.Security.ActionApproval))
+		}
+		for _, key := range []string{"allowed_commands", "allowed_services", "allowed_urls", "allowed_tools"} {
+			rules = append(rules, reloadRule{
+				key:   "security.action_approval." + key,
+				apply: applyAllowlist,
+			})
+		}
+	}
+
+	return rules
+}
+
+// replayGuardReloadRules는 재전송 방지 허용 범위, 처리 방식, 최대 개수를 재연결 없이 반영하는 규칙입니다.
+// guard가 nil이면(비활성화) 규칙이 없으므로 설정 변경은 재시작이 필요한 변경으로 분류됩니다.
+func replayGuardReloadRules(guard *websocket.ReplayGuard) []reloadRule {
+	if guard == nil {
+		return nil
+	}
+	apply := func(cfg *config.Config) {
+		guard.Reconfigure(replayGuardConfig(cfg.Security.ReplayProtection))
+	}
+	var rules []reloadRule
+	for _, key := range []string{"window_seconds", "action", "max_entries"} {
+		rules = append(rules, reloadRule{
+			key:   "security.replay_protection." + key,
+			apply: apply,
+		})
+	}
+	return rules
+}
+
+// uploadLimiterReloadRules는 업로드 최대 속도와 분류별 우선순위를 재연결 없이 반영하는 규칙입니다.
+func uploadLimiterReloadRules(limiter *websocket.UploadLimiter) []reloadRule {
+	if limiter == nil {
+		return nil
+	}
+	return []reloadRule{{
+		key: "upload",
+		apply: func(cfg *config.Config) {
+			limiter.Reconfigure(uploadLimiterConfig(cfg.Upload))
+		},
+	}}
+}
+
+// watchConfigFile은 viper WatchConfig로 설정 파일 변경을 감지하여 reloader에 전달합니다.
+// 설정 파일 없이 기본값으로 실행 중이면 감시하지 않습니다.
+func watchConfigFile(reloader *configReloader) {
+	configFile := viper.ConfigFileUsed()
+	if configFile == "" {
+		logger.Debug().Msg("설정 파일이 없어 설정 hot-reload를 비활성화합니다")
+		return
+	}
+
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		// 다시 읽은 설정 파일에도 이전 키의 별칭을 적용한다 (경고는 시작 시 한 번만 출력).
+		config.ApplyLegacyKeys(viper.GetViper())
+		next, err := config.Load()
+		if err != nil {
+			logger.Warn().Err(err).Str("file", event.Name).Msg("변경된 설정을 읽을 수 없어 기존 설정을 유지합니다")
+			return
+		}
+
+		applied, restartRequired := reloader.Apply(next)
+		if len(applied) > 0 {
+			logger.Info().Strs("settings", applied).Msg("설정 변경을 재시작 없이 반영했습니다")
+		}
+		if len(restartRequired) > 0 {
+			logger.Warn().Strs("settings", restartRequired).Msg("다음 설정 변경은 connect를 재시작해야 적용됩니다")
+		}
+	})
+	viper.WatchConfig()
+
+	logger.Info().Str("file", configFile).Msg("설정 파일 변경 감시 시작")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+	"github.com/rs/zerolog"
+)
+
+func TestConfigReloader_Apply(t *testing.T) {
+	original := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(original)
+
+	current := &config.Config{}
+	current.Logging.Level = "info"
+	current.ResultCache.Enabled = true
+	current.ResultCache.WindowSeconds = 600
+	current.Security.ActionApproval.CLIRequest = "deny"
+
+	resultCache := websocket.NewResultCache(current.ResultCache.GetWindow(), false)
+	gate := approval.NewActionGate(approval.ActionGateConfig{
+		Modes: map[string]approval.GateMode{approval.ActionCLIRequest: approval.GateModeDeny},
+	}, nil, zerolog.Nop())
+	reloader := newConfigReloader(current, connectReloadRules(resultCache, gate, nil)...)
+
+	next := *current
+	next.Logging.Level = "debug"
+	next.ResultCache.WindowSeconds = 60
+	next.Security.ActionApproval.AllowedCommands = []string{"go test *"}
+	next.Server.URL = "wss://example.com/ws/agent"
+
+	applied, restartRequired := reloader.Apply(&next)
+
+	wantApplied := []string{
+		"logging.level",
+		"result_cache.window_seconds",
+		"security.action_approval.allowed_commands",
+	}
+	if len(applied) != len(wantApplied) {
+		t.Fatalf("applied = %v, want %v", applied, wantApplied)
+	}
+	for i := range wantApplied {
+		if applied[i] != wantApplied[i] {
+			t.Errorf("applied[%d] = %q, want %q", i, applied[i], wantApplied[i])
+		}
+	}
+	if len(restartRequired) != 1 || restartRequired[0] != "server.ws_url" {
+		t.Errorf("restartRequired = %v, want [server.ws_url]", restartRequired)
+	}
+
+	if got := zerolog.GlobalLevel(); got != zerolog.DebugLevel {
+		t.Errorf("로그 레벨이 반영되지 않았습니다: %v", got)
+	}
+	if err := gate.Check(context.Background(), approval.LocalAction{Type: approval.ActionCLIRequest, Target: "go test ./..."}); err != nil {
+		t.Errorf("새 허용 목록이 반영되지 않았습니다: %v", err)
+	}
+
+	// 같은 설정을 다시 적용하면 변경 사항이 없어야 한다.
+	applied, restartRequired = reloader.Apply(&next)
+	if len(applied) != 0 || len(restartRequired) != 0 {
+		t.Errorf("변경 없는 재적용은 빈 결과여야 합니다: applied=%v restart=%v", applied, restartRequired)
+	}
+}
+
+func TestConnectReloadRules_DisabledResultCache(t *testing.T) {
+	current := &config.Config{}
+	reloader := newConfigReloader(current, connectReloadRules(nil, nil, nil)...)
+
+	next := *current
+	next.ResultCache.WindowSeconds = 60
+	next.Security.ActionApproval.AllowedURLs = []string{"https://example.com/*"}
+
+	applied, restartRequired := reloader.Apply(&next)
+	if len(applied) != 0 {
+		t.Errorf("적용 대상이 없으면 반영되면 안 됩니다: %v", applied)
+	}
+	if len(restartRequired) != 2 {
+		t.Errorf("restartRequired = %v, want 2 keys", restartRequired)
+	}
+}
+
+func TestReloadRule_Matches(t *testing.T) {
+	rule := reloadRule{key: "security.action_approval.allowed_commands"}
+	if !rule.matches("security.action_approval.allowed_commands") {
+		t.Error("정확히 일치하는 키는 매칭되어야 합니다")
+	}
+	if rule.matches("security.action_approval.allowed_commands_extra") {
+		t.Error("접두사만 같은 다른 키는 매칭되면 안 됩니다")
+	}
+
+	section := reloadRule{key: "logging"}
+	if !section.matches("logging.level") {
+		t.Error("하위 키는 매칭되어야 합니다")
+	}
+}
+
+func TestReplayGuardReloadRules(t *testing.T) {
+	if rules := replayGuardReloadRules(nil); rules != nil {
+		t.Errorf("비활성화된 재전송 방지는 규칙이 없어야 합니다: %v", rules)
+	}
+
+	current := &config.Config{}
+	current.Security.ReplayProtection.Enabled = true
+	guard := newReplayGuard(current.Security.ReplayProtection)
+	reloader := newConfigReloader(current, replayGuardReloadRules(guard)...)
+
+	next := *current
+	next.Security.ReplayProtection.Action = "warn"
+	next.Security.ReplayProtection.Enabled = false
+	applied, restartRequired := reloader.Apply(&next)
+	if len(applied) != 1 || applied[0] != "security.replay_protection.action" {
+		t.Errorf("action은 즉시 반영되어야 합니다: %v", applied)
+	}
+	if len(restartRequired) != 1 || restartRequired[0] != "security.replay_protection.enabled" {
+		t.Errorf("enabled 변경은 재시작이 필요합니다: %v", restartRequired)
+	}
+}
+
+func TestUploadLimiterReloadRules(t *testing.T) {
+	current := &config.Config{}
+	limiter := websocket.NewUploadLimiter(uploadLimiterConfig(current.Upload))
+	reloader := newConfigReloader(current, uploadLimiterReloadRules(limiter)...)
+
+	next := *current
+	next.Upload = config.UploadConfig{MaxKbps: 512, Priorities: map[string]int{"artifact": 0}}
+	applied, restartRequired := reloader.Apply(&next)
+	if len(applied) != 2 || len(restartRequired) != 0 {
+		t.Errorf("업로드 설정은 즉시 반영되어야 합니다: applied=%v restart=%v", applied, restartRequired)
+	}
+	if !limiter.Limited() {
+		t.Error("max_kbps 변경이 제한기에 반영되지 않았습니다")
+	}
+}
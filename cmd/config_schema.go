@@ -0,0 +1,429 @@
+// Package cmd는 Local Agent Bridge CLI의 명령어를 정의합니다.
+// config_schema.go는 설정 스키마 기반 명령(validate, migrate, edit, schema)을 구현합니다.
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configValidateCmd는 설정 파일을 스키마로 검사하는 명령어입니다.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "설정 파일을 스키마로 검사합니다",
+	Long: `설정 파일의 각 키를 스키마와 비교하여 타입, 허용 값을 검사합니다.
+
+알 수 없는 키와 이전 버전 레이아웃의 키는 경고로 표시합니다.
+--migrate를 지정하면 이전 레이아웃을 현재 구조로 변환하여 저장합니다.
+원본은 <설정 파일>.bak으로 백업됩니다.
+
+예시:
+  autopus config validate
+  autopus config validate --migrate`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+// configMigrateCmd는 이전 레이아웃의 키를 현재 키로 바꿔 저장하는 명령어입니다.
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "이전 설정 키를 현재 키로 변환합니다",
+	Long: `설정 파일의 이전 버전 키(mcpserver.*, server.url, computer_use.isolation_mode 등)를
+현재 키로 옮겨 저장합니다. 원본은 <설정 파일>.bak으로 백업됩니다.
+
+전환 기간 동안 connect, up, autopus-mcp-server는 이전 키도 현재 키로 읽고
+실행할 때마다 한 번 경고를 출력합니다. 현재 키에 이미 값이 있으면 현재 값을 유지합니다.
+현재 경로에 설정 파일이 없으면 이전 버전 경로(~/.config/local-agent-bridge)의 파일을 가져옵니다.
+
+예시:
+  autopus config migrate --dry-run
+  autopus config migrate`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrate,
+}
+
+// configEditCmd는 편집기로 설정 파일을 수정하는 명령어입니다.
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "편집기로 설정 파일을 수정합니다",
+	Long: `$VISUAL 또는 $EDITOR(기본값: vi)로 설정 파일의 복사본을 엽니다.
+
+편집기를 닫으면 스키마 검사를 실행하고, 에러가 없을 때만 설정 파일에 저장합니다.
+에러가 있으면 다시 편집하거나 변경을 취소할 수 있습니다.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigEdit,
+}
+
+// configSchemaCmd는 설정 키 목록과 기본값을 출력하는 명령어입니다.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "설정 키, 타입, 기본값을 출력합니다",
+	Long: `지원하는 모든 설정 키의 타입, 기본값, 허용 값을 출력합니다.
+
+키 목록은 설정 구조체에서, 기본값은 Bridge가 실제로 사용하는 기본값에서 만들어집니다.
+이전 버전에서 바뀐 키 목록도 함께 표시합니다.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigSchema,
+}
+
+var (
+	configValidateMigrate bool
+	configMigrateDryRun   bool
+)
+
+// runConfigEditor는 편집기를 실행합니다. 테스트에서 교체합니다.
+var runConfigEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configSchemaCmd)
+
+	configValidateCmd.Flags().BoolVar(&configValidateMigrate, "migrate", false, "이전 설정 레이아웃을 현재 구조로 변환하여 저장합니다")
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "저장하지 않고 바뀔 키만 출력합니다")
+}
+
+// configFilePath는 config 명령이 읽고 쓸 설정 파일 경로를 반환합니다.
+// --config 플래그 > 로드된 설정 파일 > 기본 경로 순입니다.
+func configFilePath() string {
+	if cfgFile != "" {
+		return cfgFile
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+	return config.DefaultConfigPath()
+}
+
+// readConfigDocument는 설정 파일을 YAML 문서로 읽습니다. 파일이 없으면 빈 문서와 false를 반환합니다.
+func readConfigDocument(path string) (map[string]any, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]any{}, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+	doc, err := parseConfigDocument(data)
+	if err != nil {
+		return nil, true, err
+	}
+	return doc, true, nil
+}
+
+// parseConfigDocument는 YAML 데이터를 설정 문서로 변환합니다.
+func parseConfigDocument(data []byte) (map[string]any, error) {
+	doc := map[string]any{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("YAML 파싱 실패: %w", err)
+	}
+	if doc == nil {
+		doc = map[string]any{}
+	}
+	return doc, nil
+}
+
+// writeConfigDocument는 설정 문서를 YAML로 저장합니다.
+func writeConfigDocument(path string, doc map[string]any) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("YAML 직렬화 실패: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("YAML 직렬화 실패: %w", err)
+	}
+	return writeConfigFile(path, buf.Bytes())
+}
+
+// writeConfigFile은 설정 디렉토리를 만들고 설정 파일을 0600 권한으로 저장합니다.
+func writeConfigFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("설정 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("설정 파일 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// runConfigValidate는 설정 파일을 검사하고, --migrate이면 먼저 이전 레이아웃을 변환합니다.
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	path := configFilePath()
+
+	doc, exists, err := readConfigDocument(path)
+	if err != nil {
+		return err
+	}
+
+	if configValidateMigrate {
+		if doc, exists, err = migrateConfigFile(out, path, doc, exists); err != nil {
+			return err
+		}
+	}
+
+	if !exists {
+		fmt.Fprintf(out, "설정 파일이 없습니다: %s (기본값 사용)\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(out, "설정 파일: %s\n", path)
+	issues := config.ValidateDocument(doc)
+	printConfigIssues(out, issues)
+	if countConfigIssues(issues, config.IssueError) > 0 {
+		return fmt.Errorf("설정 파일에 에러 %d개가 있습니다", countConfigIssues(issues, config.IssueError))
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(out, "  ✓ 문제가 없습니다")
+	}
+	return nil
+}
+
+// runConfigMigrate는 설정 파일의 이전 키를 현재 키로 변환하여 저장하고 남은 문제를 보고합니다.
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	path := configFilePath()
+
+	doc, exists, err := readConfigDocument(path)
+	if err != nil {
+		return err
+	}
+
+	if configMigrateDryRun {
+		if !exists {
+			fmt.Fprintf(out, "설정 파일이 없습니다: %s\n", path)
+			return nil
+		}
+		changes := config.MigrateDocument(doc)
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "변환할 항목이 없습니다")
+			return nil
+		}
+		fmt.Fprintf(out, "변환할 항목 (%s):\n", path)
+		for _, change := range changes {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+		return nil
+	}
+
+	doc, exists, err = migrateConfigFile(out, path, doc, exists)
+	if err != nil || !exists {
+		return err
+	}
+	// 자동으로 옮길 수 없는 키(평문 API 키 등)는 경고로 남긴다.
+	var remaining []config.Issue
+	for _, issue := range config.ValidateDocument(doc) {
+		if _, ok := config.LookupDeprecation(issue.Key); ok {
+			remaining = append(remaining, issue)
+		}
+	}
+	if len(remaining) > 0 {
+		fmt.Fprintln(out, "직접 수정해야 하는 항목:")
+		printConfigIssues(out, remaining)
+	}
+	return nil
+}
+
+// migrateConfigFile은 이전 레이아웃의 키를 현재 구조로 옮겨 저장합니다.
+// 현재 경로에 설정 파일이 없으면 이전 버전 경로(~/.config/local-agent-bridge)의 파일을 가져옵니다.
+func migrateConfigFile(out io.Writer, path string, doc map[string]any, exists bool) (map[string]any, bool, error) {
+	var source string
+	if !exists {
+		legacy := config.LegacyConfigPath()
+		legacyDoc, legacyExists, err := readConfigDocument(legacy)
+		if err != nil {
+			return nil, false, err
+		}
+		if !legacyExists {
+			fmt.Fprintln(out, "변환할 설정 파일이 없습니다")
+			return doc, false, nil
+		}
+		doc, source = legacyDoc, legacy
+		fmt.Fprintf(out, "이전 버전 설정 파일을 가져옵니다: %s\n", legacy)
+	}
+
+	changes := config.MigrateDocument(doc)
+	if len(changes) == 0 && source == "" {
+		fmt.Fprintln(out, "변환할 항목이 없습니다")
+		return doc, exists, nil
+	}
+
+	if exists {
+		backup := path + ".bak"
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, exists, fmt.Errorf("설정 파일 읽기 실패: %w", err)
+		}
+		if err := os.WriteFile(backup, data, 0600); err != nil {
+			return nil, exists, fmt.Errorf("설정 파일 백업 실패: %w", err)
+		}
+		fmt.Fprintf(out, "원본을 백업했습니다: %s\n", backup)
+	}
+	if err := writeConfigDocument(path, doc); err != nil {
+		return nil, exists, err
+	}
+
+	for _, change := range changes {
+		fmt.Fprintf(out, "  ✓ %s\n", change)
+	}
+	fmt.Fprintf(out, "변환된 설정을 저장했습니다: %s\n", path)
+	return doc, true, nil
+}
+
+// runConfigEdit는 설정 파일 복사본을 편집기로 열고, 검사를 통과하면 저장합니다.
+func runConfigEdit(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	path := configFilePath()
+
+	original, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "autopus-config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(original)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("임시 파일 쓰기 실패: %w", err)
+	}
+
+	scanner := bufio.NewScanner(cmd.InOrStdin())
+	for {
+		if err := runConfigEditor(tmp.Name()); err != nil {
+			return fmt.Errorf("편집기 실행 실패: %w", err)
+		}
+		edited, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return fmt.Errorf("임시 파일 읽기 실패: %w", err)
+		}
+		if bytes.Equal(edited, original) {
+			fmt.Fprintln(out, "변경 사항이 없습니다")
+			return nil
+		}
+
+		var issues []config.Issue
+		if doc, err := parseConfigDocument(edited); err != nil {
+			issues = []config.Issue{{Severity: config.IssueError, Message: err.Error()}}
+		} else {
+			issues = config.ValidateDocument(doc)
+		}
+		printConfigIssues(out, issues)
+
+		if countConfigIssues(issues, config.IssueError) == 0 {
+			// 편집한 내용을 그대로 저장하여 주석과 키 순서를 유지한다
+			if err := writeConfigFile(path, edited); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "설정이 저장되었습니다: %s\n", path)
+			return nil
+		}
+
+		fmt.Fprint(out, "다시 편집하시겠습니까? [Y/n]: ")
+		if !scanYesNoDefault(scanner, true) {
+			return fmt.Errorf("설정 파일에 에러가 있어 저장하지 않았습니다")
+		}
+	}
+}
+
+// runConfigSchema는 설정 키별 타입, 기본값, 허용 값과 사용 중단된 키 목록을 출력합니다.
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+
+	defaults := viper.New()
+	applyDefaults(defaults)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tTYPE\tDEFAULT\tVALUES")
+	for _, field := range config.Schema() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", field.Key, field.Type, formatSchemaDefault(defaults.Get(field.Key)), strings.Join(field.Enum, ", "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "사용 중단된 키:")
+	for _, d := range config.Deprecations() {
+		if d.Replacement != "" {
+			fmt.Fprintf(out, "  %s → %s (%s)\n", d.Key, d.Replacement, d.Note)
+		} else {
+			fmt.Fprintf(out, "  %s (%s)\n", d.Key, d.Note)
+		}
+	}
+	return nil
+}
+
+// formatSchemaDefault는 기본값을 한 줄로 표시합니다. 기본값이 없으면 "-"입니다.
+func formatSchemaDefault(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// printConfigIssues는 설정 검사 결과를 출력합니다. 에러는 ✗, 경고는 !로 표시합니다.
+func printConfigIssues(out io.Writer, issues []config.Issue) {
+	for _, issue := range issues {
+		mark := "!"
+		if issue.Severity == config.IssueError {
+			mark = "✗"
+		}
+		fmt.Fprintf(out, "  %s %s\n", mark, issue.Message)
+	}
+}
+
+// countConfigIssues는 심각도가 severity인 문제 수를 반환합니다.
+func countConfigIssues(issues []config.Issue, severity config.IssueSeverity) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
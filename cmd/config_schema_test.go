@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newConfigTestCommand는 출력과 입력을 버퍼로 연결한 테스트용 명령을 만듭니다.
+func newConfigTestCommand(input string) (*cobra.Command, *bytes.Buffer) {
+	var out bytes.Buffer
+	c := &cobra.Command{}
+	c.SetOut(&out)
+	c.SetIn(strings.NewReader(input))
+	return c, &out
+}
+
+// useConfigFile은 테스트 동안 --config 경로를 지정합니다.
+func useConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if content != "" {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	original := cfgFile
+	cfgFile = path
+	t.Cleanup(func() { cfgFile = original })
+	return path
+}
+
+func TestRunConfigEdit_RetriesUntilValid(t *testing.T) {
+	path := useConfigFile(t, "logging:\n  level: info\n")
+
+	// 첫 편집은 잘못된 값, 두 번째 편집은 올바른 값
+	edits := []string{
+		"logging:\n  level: loud\n",
+		"# 주석 유지\nlogging:\n  level: debug\n",
+	}
+	calls := 0
+	originalEditor := runConfigEditor
+	runConfigEditor = func(tmp string) error {
+		data := edits[calls]
+		calls++
+		return os.WriteFile(tmp, []byte(data), 0600)
+	}
+	defer func() { runConfigEditor = originalEditor }()
+
+	c, out := newConfigTestCommand("y\n")
+	if err := runConfigEdit(c, nil); err != nil {
+		t.Fatalf("runConfigEdit() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("편집기 호출 횟수 = %d, want 2", calls)
+	}
+	if !strings.Contains(out.String(), "logging.level") {
+		t.Errorf("첫 편집의 에러가 출력되어야 합니다: %s", out.String())
+	}
+
+	saved, _ := os.ReadFile(path)
+	if string(saved) != edits[1] {
+		t.Errorf("저장된 내용 = %q, want %q", saved, edits[1])
+	}
+}
+
+func TestRunConfigEdit_AbortKeepsOriginal(t *testing.T) {
+	const original = "logging:\n  level: info\n"
+	path := useConfigFile(t, original)
+
+	originalEditor := runConfigEditor
+	runConfigEditor = func(tmp string) error {
+		return os.WriteFile(tmp, []byte("logging: [broken\n"), 0600)
+	}
+	defer func() { runConfigEditor = originalEditor }()
+
+	c, _ := newConfigTestCommand("n\n")
+	if err := runConfigEdit(c, nil); err == nil {
+		t.Fatal("에러가 있는 편집을 취소하면 에러를 반환해야 합니다")
+	}
+	saved, _ := os.ReadFile(path)
+	if string(saved) != original {
+		t.Errorf("원본이 변경되었습니다: %q", saved)
+	}
+}
+
+func TestRunConfigValidate_Migrate(t *testing.T) {
+	path := useConfigFile(t, "log:\n  level: debug\nreconnect:\n  max_attempts: 3\n")
+
+	configValidateMigrate = true
+	defer func() { configValidateMigrate = false }()
+
+	c, out := newConfigTestCommand("")
+	if err := runConfigValidate(c, nil); err != nil {
+		t.Fatalf("runConfigValidate() error = %v\n%s", err, out.String())
+	}
+
+	doc, _, err := readConfigDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := doc["log"]; ok {
+		t.Errorf("log 섹션이 남아 있습니다: %v", doc)
+	}
+	if logging, _ := doc["logging"].(map[string]any); logging["level"] != "debug" {
+		t.Errorf("logging.level이 옮겨지지 않았습니다: %v", doc)
+	}
+	if backup, err := os.ReadFile(path + ".bak"); err != nil || !strings.Contains(string(backup), "reconnect:") {
+		t.Errorf("원본 백업이 없습니다: %v", err)
+	}
+	if !strings.Contains(out.String(), "문제가 없습니다") {
+		t.Errorf("변환 후 검사 결과가 출력되어야 합니다: %s", out.String())
+	}
+}
+
+func TestRunConfigMigrate(t *testing.T) {
+	const original = "server:\n  url: wss://legacy/ws\nmcpserver:\n  backend_url: https://legacy.example.com\nproviders:\n  claude:\n    api_key: sk-plain\n"
+	path := useConfigFile(t, original)
+
+	configMigrateDryRun = true
+	c, out := newConfigTestCommand("")
+	err := runConfigMigrate(c, nil)
+	configMigrateDryRun = false
+	if err != nil {
+		t.Fatalf("runConfigMigrate(--dry-run) error = %v", err)
+	}
+	if !strings.Contains(out.String(), "server.url → server.ws_url") || !strings.Contains(out.String(), "mcpserver → mcp_server") {
+		t.Errorf("dry-run 출력에 변환 항목이 없습니다: %s", out.String())
+	}
+	if saved, _ := os.ReadFile(path); string(saved) != original {
+		t.Errorf("dry-run이 설정 파일을 바꿨습니다: %q", saved)
+	}
+
+	c, out = newConfigTestCommand("")
+	if err := runConfigMigrate(c, nil); err != nil {
+		t.Fatalf("runConfigMigrate() error = %v\n%s", err, out.String())
+	}
+	doc, _, err := readConfigDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server, _ := doc["server"].(map[string]any); server["ws_url"] != "wss://legacy/ws" || server["url"] != nil {
+		t.Errorf("server.url이 옮겨지지 않았습니다: %v", doc)
+	}
+	if _, ok := doc["mcp_server"]; !ok {
+		t.Errorf("mcpserver 섹션이 옮겨지지 않았습니다: %v", doc)
+	}
+	if backup, err := os.ReadFile(path + ".bak"); err != nil || string(backup) != original {
+		t.Errorf("원본 백업이 없습니다: %v", err)
+	}
+	// 평문 API 키는 자동으로 옮기지 않고 안내만 한다.
+	if !strings.Contains(out.String(), "providers.claude.api_key") {
+		t.Errorf("직접 수정해야 하는 항목이 출력되어야 합니다: %s", out.String())
+	}
+}
+
+func TestWarnLegacyConfigKeys_OncePerRun(t *testing.T) {
+	legacyConfigWarned.Store(false)
+	t.Cleanup(func() { legacyConfigWarned.Store(false) })
+
+	var out bytes.Buffer
+	warnLegacyConfigKeys(&out, nil)
+	if out.Len() != 0 {
+		t.Fatalf("이전 키가 없으면 출력하지 않아야 합니다: %q", out.String())
+	}
+
+	keys := []config.LegacyKey{{Key: "server.url", Replacement: "server.ws_url"}}
+	warnLegacyConfigKeys(&out, keys)
+	if !strings.Contains(out.String(), "server.url → server.ws_url") || !strings.Contains(out.String(), "config migrate") {
+		t.Errorf("경고에 이름 변경과 안내가 있어야 합니다: %q", out.String())
+	}
+
+	printed := out.Len()
+	warnLegacyConfigKeys(&out, keys)
+	if out.Len() != printed {
+		t.Errorf("경고는 실행당 한 번만 출력되어야 합니다: %q", out.String())
+	}
+}
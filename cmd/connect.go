@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -21,17 +22,29 @@ import (
 
 	"github.com/insajin/autopus-agent-protocol"
 	embeddedDocker "github.com/insajin/autopus-bridge/docker"
+	"github.com/insajin/autopus-bridge/internal/approval"
 	"github.com/insajin/autopus-bridge/internal/auth"
 	"github.com/insajin/autopus-bridge/internal/authwatch"
 	"github.com/insajin/autopus-bridge/internal/bridgecontext"
+	"github.com/insajin/autopus-bridge/internal/codegen"
 	"github.com/insajin/autopus-bridge/internal/computeruse"
 	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/crash"
+	"github.com/insajin/autopus-bridge/internal/eventhook"
 	"github.com/insajin/autopus-bridge/internal/executor"
+	"github.com/insajin/autopus-bridge/internal/filesync"
+	"github.com/insajin/autopus-bridge/internal/identity"
 	"github.com/insajin/autopus-bridge/internal/logger"
 	"github.com/insajin/autopus-bridge/internal/mcp"
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 	"github.com/insajin/autopus-bridge/internal/project"
 	"github.com/insajin/autopus-bridge/internal/provider"
 	"github.com/insajin/autopus-bridge/internal/scheduler"
+	"github.com/insajin/autopus-bridge/internal/sizeguard"
+	"github.com/insajin/autopus-bridge/internal/store"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/insajin/autopus-bridge/internal/tracing"
 	"github.com/insajin/autopus-bridge/internal/websocket"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -44,6 +57,13 @@ var (
 	token          string
 	connectTimeout int
 	connectReplace bool
+	// connectSupervised는 감독 프로세스가 브리지를 실행하고 크래시 시 재시작하는 모드입니다.
+	connectSupervised bool
+	// connectReadOnly는 변경 요청을 모두 거절하는 읽기 전용 모드입니다 (설정의 read_only와 같음).
+	connectReadOnly bool
+	// connectName과 connectLabels는 서버에서 Bridge를 구분하는 이름과 라벨입니다 (설정의 identity보다 우선).
+	connectName   string
+	connectLabels []string
 
 	connectProcessRunningFn = isProcessRunning
 	connectStopProcessFn    = stopRunningConnectProcess
@@ -67,7 +87,11 @@ var connectCmd = &cobra.Command{
 연결이 수립되면 하트비트를 주기적으로 전송하고,
 서버로부터 작업 요청을 수신하여 로컬 AI 프로바이더를 통해 실행합니다.
 
-SIGINT(Ctrl+C) 또는 SIGTERM 시그널을 수신하면 정상적으로 연결을 종료합니다.`,
+SIGINT(Ctrl+C) 또는 SIGTERM 시그널을 수신하면 정상적으로 연결을 종료합니다.
+
+--supervised를 지정하면 감독 프로세스가 브리지를 실행하고, 브리지가 비정상 종료하면
+크래시 리포트를 남긴 뒤 백오프 간격으로 다시 시작합니다. 전송하지 못한 작업 결과는
+디스크에 보관되어 재시작 후 다시 전송됩니다.`,
 	RunE: runConnect,
 }
 
@@ -83,17 +107,37 @@ func init() {
 		"연결 타임아웃(초)")
 	connectCmd.Flags().BoolVar(&connectReplace, "replace", false,
 		"기존 bridge 연결 프로세스가 있으면 종료 후 새 세션으로 교체")
+	connectCmd.Flags().BoolVar(&connectSupervised, "supervised", false,
+		"감독 모드: 브리지가 비정상 종료하면 자동으로 재시작")
+	connectCmd.Flags().BoolVar(&connectReadOnly, "read-only", false,
+		"읽기 전용 모드: 조회 요청만 처리하고 작업 실행, CLI, 배포, 컴퓨터 사용은 READ_ONLY로 거절")
+	connectCmd.Flags().StringVar(&connectName, "name", "",
+		"서버에 표시할 Bridge 이름 (기본값: 설정의 identity.name 또는 호스트 이름)")
+	connectCmd.Flags().StringArrayVar(&connectLabels, "label", nil,
+		"Bridge 라벨 key=value (여러 번 지정 가능, 설정의 identity.labels에 추가)")
 }
 
 // runConnect는 connect 명령의 실행 로직입니다.
 func runConnect(cmd *cobra.Command, args []string) error {
+	if connectSupervised && !isSupervisedChild() {
+		return runSupervisedConnect(cmd.Context())
+	}
 	return runConnectWithOptions(cmd, args, connectRunOptions{
 		ReplaceExisting: connectReplace,
+		ReadOnly:        connectReadOnly,
+		Name:            connectName,
+		Labels:          connectLabels,
 	})
 }
 
 type connectRunOptions struct {
 	ReplaceExisting bool
+	// ReadOnly는 설정의 read_only와 함께 읽기 전용 모드를 켭니다 (둘 중 하나라도 true이면 적용).
+	ReadOnly bool
+	// Name은 설정의 identity.name 대신 사용할 Bridge 이름입니다.
+	Name string
+	// Labels는 설정의 identity.labels에 더할 key=value 라벨입니다 (같은 키는 덮어씀).
+	Labels []string
 }
 
 func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOptions) error {
@@ -111,11 +155,15 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	if err != nil {
 		return fmt.Errorf("설정 로드 실패: %w", err)
 	}
+	readOnly := cfg.ReadOnly || opts.ReadOnly
+	if readOnly {
+		logger.Info().Msg("읽기 전용 모드: 조회 요청만 처리하고 변경 요청은 READ_ONLY로 거절합니다")
+	}
 
 	// 서버 URL 결정 (플래그 > 환경변수 > 설정파일)
 	srvURL := serverURL
 	if srvURL == "" {
-		srvURL = viper.GetString("server.url")
+		srvURL = viper.GetString("server.ws_url")
 	}
 	if srvURL == "" {
 		srvURL = "wss://api.autopus.co/ws/agent"
@@ -182,10 +230,25 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 		return fmt.Errorf("설정 검증 실패: %w", err)
 	}
 
+	// 서버에서 Bridge를 구분하고 지정하는 식별 정보 (고정 ID, 이름, 라벨)
+	bridgeIdentity, err := newBridgeIdentity(cfg.Identity, opts.Name, opts.Labels)
+	if err != nil {
+		return err
+	}
+	logger.Info().
+		Str("bridge_id", bridgeIdentity.ID).
+		Str("name", bridgeIdentity.Name).
+		Strs("labels", identity.FormatLabels(bridgeIdentity.Labels)).
+		Msg("Bridge 식별 정보")
+
 	// 컨텍스트 생성 (graceful shutdown용)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// OpenTelemetry 트레이싱 (tracing.enabled)
+	shutdownTracing := startTracing(ctx, cfg.Tracing)
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	// REQ-E-09: SIGINT/SIGTERM 시그널 핸들링
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -213,9 +276,34 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 		Strs("providers", registry.List()).
 		Msg("AI 프로바이더 초기화 완료")
 
+	// 프로바이더 warm-up (선택): 첫 작업의 콜드 스타트 지연 제거
+	var providerReadiness map[string]bool
+	if cfg.Providers.Warmup.Enabled {
+		providerReadiness = warmupProviders(ctx, registry, cfg.Providers.Warmup.GetTimeout())
+	}
+
 	// SPEC-COMPUTER-USE-002: 컨테이너 풀 초기화 (Docker 사용 가능 시)
 	var containerPool *computeruse.ContainerPool
-	cuHandler := computeruse.NewHandler()
+	cuScheduler := computeruse.NewSessionScheduler(computeruse.SchedulerConfig{
+		MaxSessions:   cfg.ComputerUse.MaxSessions,
+		QueueTimeout:  cfg.ComputerUse.QueueTimeout,
+		SessionMemory: cfg.ComputerUse.SessionMemory,
+		SessionCPU:    cfg.ComputerUse.SessionCPU,
+	})
+	// persist_profile 세션의 브라우저 프로필(쿠키, localStorage)은 워크스페이스별로 로컬에 저장
+	var profileStoreOpts []computeruse.ProfileStoreOption
+	if key := cfg.ComputerUse.GetProfileEncryptionKey(); key != "" {
+		profileStoreOpts = append(profileStoreOpts, computeruse.WithProfileEncryptionKey(key))
+	} else if cfg.ComputerUse.ProfileEncryptionKeyEnv != "" {
+		logger.Warn().
+			Str("env", cfg.ComputerUse.ProfileEncryptionKeyEnv).
+			Msg("브라우저 프로필 암호화 키 환경변수가 비어 있어 평문으로 저장합니다")
+	}
+	cuProfileOpt := computeruse.WithProfileStore(
+		computeruse.NewProfileStore(cfg.ComputerUse.GetProfileDir(), profileStoreOpts...),
+		resolveCurrentWorkspaceScopeID(),
+	)
+	cuHandler := computeruse.NewHandler(computeruse.WithSessionScheduler(cuScheduler), cuProfileOpt)
 
 	if cfg.ComputerUse.IsContainerMode() {
 		poolCtx, poolCancel := context.WithTimeout(ctx, 60*time.Second)
@@ -223,6 +311,9 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 			MaxContainers:      cfg.ComputerUse.MaxContainers,
 			WarmPoolSize:       cfg.ComputerUse.WarmPoolSize,
 			Image:              cfg.ComputerUse.Image,
+			ImageVersion:       cfg.ComputerUse.ImageVersion,
+			ImageDigest:        cfg.ComputerUse.ImageDigest,
+			Platform:           cfg.ComputerUse.Platform,
 			ContainerMemory:    cfg.ComputerUse.ContainerMemory,
 			ContainerCPU:       cfg.ComputerUse.ContainerCPU,
 			IdleTimeout:        cfg.ComputerUse.IdleTimeout,
@@ -235,7 +326,11 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 			logger.Warn().Err(poolErr).Msg("컨테이너 풀 초기화 실패, 로컬 모드로 폴백")
 		} else {
 			containerPool = pool
-			cuHandler = computeruse.NewHandler(computeruse.WithContainerPool(pool))
+			cuHandler = computeruse.NewHandler(
+				computeruse.WithContainerPool(pool),
+				computeruse.WithSessionScheduler(cuScheduler),
+				cuProfileOpt,
+			)
 			logger.Info().
 				Int("max_containers", cfg.ComputerUse.MaxContainers).
 				Int("warm_pool_size", cfg.ComputerUse.WarmPoolSize).
@@ -279,18 +374,54 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 		}
 	}
 
+	// 사용자 정의 로컬 도구 (custom_tools) - agent_connect로 서버에 알림
+	customTools := newCustomToolExecutor(cfg.CustomTools)
+
+	// 수명 주기 이벤트 훅 (event_hooks, notifications) - 연결/작업/세션/승인 이벤트에 셸 명령, 웹훅, 데스크톱 알림 실행
+	eventHooks, err := newEventHookDispatcher(cfg.EventHooks, cfg.Notifications)
+	if err != nil {
+		return fmt.Errorf("event_hooks 설정 오류: %w", err)
+	}
+
 	// WebSocket 클라이언트 생성 (단일 인스턴스)
 	runtimeContext, runtimeRoot := loadBridgeRuntimeContext()
 	connectWorkspaceID := resolveCurrentWorkspaceScopeID()
+	stateStore, err := openStateStore(cfg.StateStore, connectWorkspaceID)
+	if err != nil {
+		logger.Warn().Err(err).Msg("상태 저장소 열기 실패, 파일 기반 저장으로 계속합니다")
+	}
+	if stateStore != nil {
+		defer stateStore.Close()
+	}
+	outbox, err := newConnectOutbox(stateStore, connectWorkspaceID)
+	if err != nil {
+		logger.Warn().Err(err).Msg("아웃박스 로드 실패, 보관된 메시지 없이 시작합니다")
+	}
+	if isSupervisedChild() {
+		logger.Info().Int("restarts", supervisorRestarts()).Int("queued", outbox.Len()).Msg("감독 모드로 실행 중")
+	}
+	uploadLimiter := websocket.NewUploadLimiter(uploadLimiterConfig(cfg.Upload))
 	client := websocket.NewClient(
 		srvURL,
 		authToken,
 		version,
 		websocket.WithCapabilities(cfg.GetAvailableProviders()),
 		websocket.WithProviderCapabilities(providerCaps),
+		websocket.WithProviderReadiness(providerReadiness),
 		websocket.WithWorkspaceID(connectWorkspaceID),
 		websocket.WithRuntimeContext(runtimeContext),
 		websocket.WithReconnectStrategy(reconnectStrategy),
+		websocket.WithCompression(cfg.Server.Compression.Enabled),
+		websocket.WithPayloadGzipThreshold(cfg.Server.Compression.GetGzipThreshold()),
+		websocket.WithTransport(cfg.Server.GetTransport()),
+		websocket.WithHTTPFallbackURL(cfg.Server.HTTPURL),
+		websocket.WithCustomTools(customTools.Definitions()),
+		websocket.WithOutbox(outbox),
+		websocket.WithReadOnlyMode(readOnly),
+		websocket.WithIdentity(bridgeIdentity),
+		websocket.WithUploadLimiter(uploadLimiter),
+		websocket.WithEventHooks(eventHooks),
+		websocket.WithIdleMode(idleModeOptions(ctx, cfg, registry, containerPool)),
 	)
 
 	// SPEC-HOTSWAP-001: authwatch 시작 - 인증 파일 변경 감지 및 hot-swap 지원
@@ -312,6 +443,7 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 			client.UpdateToken(newCreds.AccessToken)
 			fmt.Printf("  재인증 성공: %s\n", newCreds.UserEmail)
 			go func() {
+				defer crash.Recover("reconnect")
 				if connErr := client.Connect(ctx); connErr != nil {
 					logger.Error().Err(connErr).Msg("재인증 후 재연결 실패")
 					cancel()
@@ -331,12 +463,80 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 		connState: NewConnectionState(),
 	}
 	taskSender.connState.SetWorkspaceID(connectWorkspaceID)
+	taskSender.connState.SetIdentity(bridgeIdentity)
+
+	// 연결 품질이 바뀌면 상태 파일에 반영 (status 명령에서 표시)
+	client.SetOnQualityChange(func(q websocket.QualitySnapshot) {
+		taskSender.connState.SetQuality(newConnectionQualityStatus(q))
+		saveConnectionStatus(taskSender.connState)
+	})
+
+	// 설정 hot-reload 대상이므로 라우터 외부에서 생성
+	actionGate := newActionGate(cfg.Security.ActionApproval, eventHooks)
+	resultCache := newResultCache(cfg.ResultCache)
+	replayGuard := newReplayGuard(cfg.Security.ReplayProtection)
 
-	taskExecutor := executor.NewTaskExecutor(
-		registry,
-		taskSender,
+	// 작업 결과에 첨부할 환경 스냅샷 (도구/프로바이더 버전은 캐시, 시작 시 미리 수집)
+	envCollector := newEnvironmentCollector(cfg, version)
+	go envCollector.Warm(ctx)
+
+	executorOpts := []executor.TaskExecutorOption{
 		executor.WithLogger(log.Logger),
-	)
+		executor.WithEnvironmentCollector(envCollector),
+		executor.WithWorkDirPolicy(executor.NewWorkDirPolicy(cfg.WorkDir, cfg.WorkDirs)),
+	}
+	if isolationCfg := cfg.Security.WorkDirIsolation; isolationCfg.Enabled {
+		executorOpts = append(executorOpts, executor.WithWorkDirIsolation(executor.NewWorkDirIsolator(executor.IsolationConfig{
+			BaseDir: isolationCfg.GetBaseDir(),
+			Exclude: isolationCfg.Exclude,
+			MaxSize: isolationCfg.GetMaxSize(),
+		}, actionGate)))
+		logger.Info().
+			Str("apply_changes", cfg.Security.ActionApproval.ApplyChanges).
+			Msg("작업 디렉토리 격리 실행 활성화")
+	}
+	if store := newCheckpointStore(cfg.TaskCheckpoint, scopeWorkspaceID); store != nil {
+		executorOpts = append(executorOpts, executor.WithCheckpointStore(store))
+	}
+	if store := newTranscriptStore(cfg.Transcript); store != nil {
+		if removed, pruneErr := store.Prune(time.Now()); pruneErr != nil {
+			logger.Warn().Err(pruneErr).Msg("오래된 트랜스크립트 정리 실패")
+		} else if removed > 0 {
+			logger.Info().Int("removed", removed).Msg("오래된 트랜스크립트 정리")
+		}
+		executorOpts = append(executorOpts, executor.WithTranscriptStore(store))
+	}
+	if store, mode := newProviderReplay(cfg.ProviderReplay, stateStore); store != nil {
+		logger.Warn().Str("mode", string(mode)).Str("dir", store.Dir()).Msg("프로바이더 기록/재생 모드 활성화")
+		executorOpts = append(executorOpts, executor.WithProviderReplay(store, mode))
+	}
+	if cfg.History.Enabled {
+		history, err := newConnectHistory(stateStore, connectWorkspaceID, cfg.History.GetMaxEntries())
+		if err != nil {
+			logger.Warn().Err(err).Msg("실행 이력 로드 실패, 빈 이력으로 시작합니다")
+		}
+		if marked, err := history.MarkInterrupted(); err != nil {
+			logger.Warn().Err(err).Msg("중단된 실행 이력 표시 실패")
+		} else if marked > 0 {
+			logger.Info().Int("interrupted", marked).Msg("이전 프로세스에서 끝나지 않은 실행을 중단됨으로 표시")
+		}
+		executorOpts = append(executorOpts, executor.WithHistoryStore(history))
+	}
+	redactor, err := executor.NewRedactor(cfg.Security.Redaction)
+	if err != nil {
+		return fmt.Errorf("security.redaction 설정 오류: %w", err)
+	}
+	executorOpts = append(executorOpts, executor.WithRedactor(redactor))
+	if providerSandbox := executor.NewProviderSandbox(cfg.Security.ProviderSandbox); providerSandbox != nil {
+		executorOpts = append(executorOpts, executor.WithProviderSandbox(providerSandbox))
+		logger.Info().
+			Strs("allowed_write_roots", cfg.Security.ProviderSandbox.AllowedWriteRoots).
+			Msg("프로바이더 도구 호출 쓰기 범위 제한 활성화")
+	}
+	if cfg.Conversation.Enabled {
+		executorOpts = append(executorOpts, executor.WithConversationStore(executor.NewConversationStore(cfg.Conversation.GetTTL())))
+	}
+	taskExecutor := executor.NewTaskExecutor(registry, taskSender, executorOpts...)
 
 	// MCP 서버 관리자 초기화 (SPEC-SKILL-V2-001 Block D)
 	mcpConfig, err := mcp.LoadConfig("")
@@ -346,6 +546,21 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	}
 	mcpManager := mcp.NewManager(mcpConfig)
 	mcpAdapter := mcp.NewStarterAdapter(mcpManager)
+	if cfg.MCPNotifications.Enabled {
+		// MCP 서버의 로그, 리소스 변경, 예기치 않은 종료를 요약하여 백엔드에 전달
+		mcpEvents := newMCPEventForwarder(cfg.MCPNotifications, client)
+		mcpEvents.Start(ctx)
+		defer mcpEvents.Stop()
+		mcpManager.SetNotificationSink(mcpEvents.Handle)
+	}
+
+	// 서버 주도 설정 변경 (config_update): 로컬 허용 범위 안에서만 적용
+	var remote *remoteSettings
+	var configUpdater websocket.ConfigUpdater
+	if cfg.RemoteSettings.Enabled {
+		remote = newRemoteSettings(cfg, actionGate)
+		configUpdater = remote
+	}
 
 	// 메시지 라우터 설정 (동일한 client 인스턴스 사용)
 	router := websocket.NewRouter(
@@ -354,6 +569,27 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 		websocket.WithTaskMessageSender(taskSender),
 		websocket.WithMCPStarter(mcpAdapter),
 		websocket.WithComputerUseHandler(cuHandler),
+		websocket.WithActionGate(actionGate),
+		websocket.WithResultCache(resultCache),
+		websocket.WithReplayGuard(replayGuard),
+		websocket.WithDelegationPolicy(newDelegationPolicy(cfg.Delegation)),
+		websocket.WithConfigUpdater(configUpdater),
+		websocket.WithCustomToolExecutor(customTools),
+		websocket.WithEmbeddedMCPServer(newEmbeddedMCPFactory(readOnly, newMCPTimeoutPolicy(cfg.Timeouts))),
+		websocket.WithReadOnly(readOnly),
+		websocket.WithTimeoutPolicy(newTimeoutPolicy(cfg.Timeouts)),
+		websocket.WithSizeGuard(newSizeGuard(cfg.Limits)),
+		websocket.WithCodegenSandboxQuota(codegen.SandboxQuota{
+			MaxTotalBytes:   cfg.CodegenSandbox.GetMaxTotalBytes(),
+			MaxServiceBytes: cfg.CodegenSandbox.GetMaxServiceBytes(),
+			MaxAge:          cfg.CodegenSandbox.GetMaxAge(),
+		}),
+		websocket.WithGitRequestExecutor(executor.NewGitRequestExecutor(executor.GitRequestExecutorConfig{
+			WorkDir:      cfg.Git.GetWorkDir(),
+			GitUserName:  cfg.Git.CommitUserName,
+			GitUserEmail: cfg.Git.CommitUserEmail,
+			Credentials:  executor.NewEnvGitCredentialProvider(cfg.Git.TokenHosts),
+		})),
 		websocket.WithErrorHandler(func(err error) {
 			logger.Error().Err(err).Msg("메시지 처리 오류")
 		}),
@@ -362,14 +598,41 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 			updateStatusOAuthMode(taskSender.connState, payload)
 		}),
 	)
+	if remote != nil {
+		remote.setMaxConcurrentTasks = router.SetMaxConcurrentTasks
+	}
+	if cfg.Concurrency.PerWorkspace > 0 || len(cfg.Concurrency.Workspaces) > 0 {
+		router.SetWorkspaceConcurrency(cfg.Concurrency.PerWorkspace, cfg.Concurrency.Workspaces)
+		logger.Info().
+			Int("per_workspace", cfg.Concurrency.PerWorkspace).
+			Int("overrides", len(cfg.Concurrency.Workspaces)).
+			Msg("워크스페이스별 동시 실행 한도 적용")
+	}
 
 	// 동일한 client에 메시지 핸들러 등록 (재생성하지 않음)
 	client.SetMessageHandler(router)
 
+	// 설정 파일 변경 시 안전한 설정은 재연결 없이 반영
+	reloadRules := append(connectReloadRules(resultCache, actionGate, remote), replayGuardReloadRules(replayGuard)...)
+	reloadRules = append(reloadRules, uploadLimiterReloadRules(uploadLimiter)...)
+	watchConfigFile(newConfigReloader(cfg, reloadRules...))
+
 	// 토큰 자동 갱신 서비스 시작
 	creds, _ := auth.Load()
 	if creds != nil && creds.RefreshToken != "" {
 		tokenRefresher := auth.NewTokenRefresher(creds)
+		// refresh token 만료 감시: 경고, 하트비트 보고, (설정 시) 재인증까지 작업 수신 중지
+		watch := &credentialWatch{
+			ctx:         ctx,
+			refresher:   tokenRefresher,
+			client:      client,
+			router:      router,
+			pauseIntake: cfg.Auth.Expiry.PauseIntake,
+			webBaseURL:  getBaseURL(),
+			apiBaseURL:  getAPIBaseURL(),
+			out:         os.Stderr,
+		}
+		tokenRefresher.SetExpiryWatch(cfg.Auth.Expiry.GetWarnBefore(), watch.onStatus)
 		tokenRefresher.Start(ctx)
 		// 재연결 시 갱신된 토큰을 사용하도록 콜백 등록
 		client.SetTokenRefreshFunc(func() (string, error) {
@@ -390,8 +653,10 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	wg.Add(1)
 
 	go func() {
+		defer crash.Recover("event-loop")
 		defer wg.Done()
-		defer mcpManager.StopAll() // MCP 서버 정리 (SPEC-SKILL-V2-001 Block D)
+		defer shutdownChildProcesses() // MCP 서버 정리 후에도 남은 자식 프로세스 트리 정리
+		defer mcpManager.StopAll()     // MCP 서버 정리 (SPEC-SKILL-V2-001 Block D)
 		// SPEC-COMPUTER-USE-002: 컨테이너 풀 종료
 		if containerPool != nil {
 			defer func() {
@@ -402,7 +667,14 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 				}
 			}()
 		}
-		runEventLoop(ctx, cancel, client, taskExecutor, connState, sigCh)
+		shutdown := shutdownParams{
+			client:       client,
+			router:       router,
+			taskExecutor: taskExecutor,
+			eventHooks:   eventHooks,
+			gracePeriod:  cfg.Shutdown.GetGracePeriod(),
+		}
+		runEventLoop(ctx, cancel, shutdown, connState, sigCh)
 	}()
 
 	// 서버 연결 시도
@@ -425,8 +697,12 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	logger.Info().
 		Str("server", srvURL).
 		Str("state", client.State().String()).
+		Str("transport", client.TransportName()).
 		Msg("서버 연결 성공")
 
+	// 이전 프로세스에서 중단된 작업의 체크포인트를 서버에 알림 (task_resume offer)
+	router.SendTaskResumeOffers()
+
 	if runtimeRoot != "" {
 		if err := router.SendProjectContext(runtimeRoot); err != nil {
 			logger.Warn().Err(err).Str("workspace_root", runtimeRoot).Msg("project_context 전송 실패")
@@ -439,6 +715,9 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	// 하트비트 시작 (REQ-E-06)
 	client.StartHeartbeat(ctx)
 
+	// 이전 크래시 리포트 자동 전송 (crash_report.auto_upload 동의 시)
+	go uploadPendingCrashReports(ctx, cfg)
+
 	// SPEC-COMPUTER-USE-002: Computer Use 백그라운드 고루틴 시작
 	go cuHandler.SessionManager().StartCleanupLoop(ctx)
 	if containerPool != nil {
@@ -450,6 +729,13 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	networkMonitor := websocket.NewNetworkMonitor(client, 5*time.Second)
 	networkMonitor.Start(ctx)
 
+	// 워크스페이스 파일 동기화 시작 (opt-in)
+	if cfg.FileSync.Enabled {
+		if syncer := startFileSync(ctx, client, cfg.FileSync); syncer != nil {
+			defer syncer.Close()
+		}
+	}
+
 	// 작업 실행기 시작
 	taskExecutor.Start(ctx)
 
@@ -476,6 +762,420 @@ func runConnectWithOptions(cmd *cobra.Command, args []string, opts connectRunOpt
 	return nil
 }
 
+// newResultCache는 재전송된 작업 요청에 응답할 결과 캐시를 생성합니다.
+// 비활성화된 경우 nil을 반환하여 모든 요청을 다시 실행합니다.
+func newResultCache(cacheCfg config.ResultCacheConfig) *websocket.ResultCache {
+	if !cacheCfg.Enabled {
+		return nil
+	}
+	return websocket.NewResultCache(cacheCfg.GetWindow(), cacheCfg.MatchPromptHash)
+}
+
+// newReplayGuard는 서명된 수신 메시지의 재전송을 막는 검사기를 생성합니다.
+// 비활성화된 경우 nil을 반환하여 검사하지 않습니다.
+func newReplayGuard(replayCfg config.ReplayProtectionConfig) *websocket.ReplayGuard {
+	if !replayCfg.Enabled {
+		return nil
+	}
+	return websocket.NewReplayGuard(replayGuardConfig(replayCfg))
+}
+
+// replayGuardConfig는 설정 파일의 재전송 방지 설정을 ReplayGuardConfig로 변환합니다.
+func replayGuardConfig(replayCfg config.ReplayProtectionConfig) websocket.ReplayGuardConfig {
+	return websocket.ReplayGuardConfig{
+		Window:     replayCfg.GetWindow(),
+		Action:     replayCfg.Action,
+		MaxEntries: replayCfg.MaxEntries,
+	}
+}
+
+// uploadLimiterConfig는 설정 파일의 업로드 대역폭 설정을 UploadLimiterConfig로 변환합니다.
+func uploadLimiterConfig(uploadCfg config.UploadConfig) websocket.UploadLimiterConfig {
+	priorities := make(map[websocket.UploadCategory]int, len(uploadCfg.Priorities))
+	for category, priority := range uploadCfg.Priorities {
+		priorities[websocket.UploadCategory(category)] = priority
+	}
+	return websocket.UploadLimiterConfig{
+		MaxKbps:    uploadCfg.MaxKbps,
+		Priorities: priorities,
+	}
+}
+
+// newBridgeIdentity는 identity 설정과 --name, --label 플래그로 Bridge 식별 정보를 만듭니다.
+// Bridge ID는 처음 연결할 때 생성해 ~/.config/autopus/bridge-id에 보관하고 이후 재사용합니다.
+// 플래그 라벨은 설정 라벨에 더해지며 같은 키는 플래그 값이 우선합니다.
+func newBridgeIdentity(cfg config.IdentityConfig, name string, labelFlags []string) (*ws.BridgeIdentity, error) {
+	id, err := identity.LoadOrCreate(identity.DefaultPath())
+	if err != nil {
+		return nil, err
+	}
+	if err := identity.ValidateLabels(cfg.Labels); err != nil {
+		return nil, fmt.Errorf("identity.labels 설정 오류: %w", err)
+	}
+	flagLabels, err := identity.ParseLabels(labelFlags)
+	if err != nil {
+		return nil, fmt.Errorf("--label 플래그 오류: %w", err)
+	}
+	if name == "" {
+		name = cfg.Name
+	}
+	bridge := identity.New(id, name, cfg.Labels, flagLabels)
+	if err := identity.ValidateLabels(bridge.Labels); err != nil {
+		return nil, err
+	}
+	return bridge, nil
+}
+
+// newTimeoutPolicy는 timeouts 설정으로 task/build/test/QA/CLI 요청의 타임아웃 계층을 생성합니다.
+// 설정이 없으면 nil을 반환하며, 요청 값과 실행기 기본값만 사용합니다.
+func newTimeoutPolicy(cfg config.TimeoutsConfig) *timeouts.Policy {
+	return timeouts.FromSeconds(cfg.DefaultSeconds, cfg.MaxSeconds, cfg.MessageTypes)
+}
+
+// newSizeGuard는 limits 설정으로 작업 프롬프트와 결과 페이로드의 크기 제한을 생성하고,
+// 보관 기간이 지난 전체 내용 파일을 정리합니다.
+func newSizeGuard(cfg config.LimitsConfig) *sizeguard.Guard {
+	guard := sizeguard.New(sizeguard.Limits{
+		MaxPromptBytes: cfg.MaxPromptBytes,
+		MaxOutputBytes: cfg.GetMaxOutputBytes(),
+		MaxResultBytes: cfg.GetMaxResultBytes(),
+	}, cfg.GetOverflowDir(), cfg.GetOverflowMaxAge())
+	if removed, err := guard.Prune(time.Now()); err != nil {
+		logger.Warn().Err(err).Msg("오래된 결과 보관 파일 정리 실패")
+	} else if removed > 0 {
+		logger.Info().Int("removed", removed).Msg("오래된 결과 보관 파일 정리")
+	}
+	return guard
+}
+
+// newMCPTimeoutPolicy는 timeouts 설정으로 MCP 도구 호출의 타임아웃 계층을 생성합니다.
+// 도구별 값은 mcp_tools, 호출의 _meta.timeout_seconds 상한과 기본값은 max_seconds와 default_seconds를 사용합니다.
+func newMCPTimeoutPolicy(cfg config.TimeoutsConfig) *timeouts.Policy {
+	return timeouts.FromSeconds(cfg.DefaultSeconds, cfg.MaxSeconds, cfg.MCPTools)
+}
+
+// newEventHookDispatcher는 event_hooks와 notifications 설정으로 이벤트 훅 실행기를 생성합니다.
+// 데스크톱 알림은 notify 훅 하나로 추가됩니다. 훅이 없으면 nil을 반환합니다.
+func newEventHookDispatcher(hookCfgs []config.EventHookConfig, notifyCfg config.NotificationsConfig) (*eventhook.Dispatcher, error) {
+	hooks := make([]eventhook.Hook, 0, len(hookCfgs))
+	for _, h := range hookCfgs {
+		hooks = append(hooks, eventhook.Hook{
+			Name:    h.Name,
+			Events:  h.Events,
+			Command: h.Command,
+			URL:     h.URL,
+			Method:  h.Method,
+			Headers: h.Headers,
+			Payload: h.Payload,
+			Timeout: h.GetTimeout(),
+		})
+	}
+	if notifyCfg.Enabled {
+		events := notifyCfg.Events
+		if len(events) == 0 {
+			events = eventhook.NotifyEvents
+		}
+		hooks = append(hooks, eventhook.Hook{
+			Name:        "desktop-notification",
+			Events:      events,
+			Notify:      true,
+			DedupWindow: notifyCfg.GetDedupWindow(),
+		})
+	}
+	dispatcher, err := eventhook.New(hooks)
+	if err != nil {
+		return nil, err
+	}
+	if dispatcher != nil {
+		logger.Info().Int("hooks", len(hooks)).Msg("이벤트 훅 활성화")
+	}
+	return dispatcher, nil
+}
+
+// newDelegationPolicy는 Bridge 간 작업 위임 정책을 생성합니다.
+// 비활성화되었거나 규칙이 없으면 nil을 반환하여 모든 작업을 로컬에서 실행합니다.
+func newDelegationPolicy(delegationCfg config.DelegationConfig) *websocket.DelegationPolicy {
+	if !delegationCfg.Enabled || len(delegationCfg.Rules) == 0 {
+		return nil
+	}
+	policy := &websocket.DelegationPolicy{
+		FallbackLocal: delegationCfg.FallbackLocal,
+		Timeout:       delegationCfg.GetTimeout(),
+	}
+	for _, rule := range delegationCfg.Rules {
+		policy.Rules = append(policy.Rules, websocket.DelegationRule{
+			TaskTypes:      rule.TaskTypes,
+			Commands:       rule.Commands,
+			TargetPlatform: rule.TargetPlatform,
+		})
+	}
+	return policy
+}
+
+// newCheckpointStore는 설정에 따라 작업 체크포인트 저장소를 생성합니다.
+// 체크포인트는 워크스페이스별 하위 디렉토리에 저장되어 다른 워크스페이스 서버에 재개를 제안하지 않습니다.
+func newCheckpointStore(cpCfg config.TaskCheckpointConfig, workspaceID string) *executor.CheckpointStore {
+	if !cpCfg.Enabled {
+		return nil
+	}
+	dir := cpCfg.GetDir()
+	if dir == "" {
+		return nil
+	}
+	if workspaceID != "" {
+		dir = filepath.Join(dir, sanitizeWorkspaceScope(workspaceID))
+	}
+	return executor.NewCheckpointStore(executor.CheckpointConfig{
+		Dir:      dir,
+		Interval: cpCfg.GetInterval(),
+		MaxAge:   cpCfg.GetMaxAge(),
+	})
+}
+
+// newTranscriptStore는 설정에 따라 실행 트랜스크립트 저장소를 생성합니다. 비활성화된 경우 nil을 반환합니다.
+func newTranscriptStore(tCfg config.TranscriptConfig) *executor.TranscriptStore {
+	if !tCfg.Enabled {
+		return nil
+	}
+	dir := tCfg.GetDir()
+	if dir == "" {
+		return nil
+	}
+	return executor.NewTranscriptStore(dir, tCfg.GetMaxAge())
+}
+
+// newProviderReplay는 설정에 따라 프로바이더 기록/재생 저장소와 모드를 반환합니다. 꺼져 있으면 nil을 반환합니다.
+// 상태 저장소가 있으면 기록을 저장소 버킷에 보관합니다.
+func newProviderReplay(rCfg config.ProviderReplayConfig, stateStore *store.Store) (*provider.ReplayStore, provider.ReplayMode) {
+	mode, err := provider.ParseReplayMode(rCfg.GetMode())
+	if err != nil {
+		logger.Warn().Err(err).Msg("프로바이더 기록/재생 설정 무시")
+		return nil, provider.ReplayOff
+	}
+	if mode == provider.ReplayOff {
+		return nil, provider.ReplayOff
+	}
+	if stateStore != nil {
+		return provider.NewStoreReplayStore(stateStore.Bucket(replayBucket)), mode
+	}
+	dir := rCfg.GetDir()
+	if dir == "" {
+		return nil, provider.ReplayOff
+	}
+	return provider.NewReplayStore(dir), mode
+}
+
+// startTracing은 설정에 따라 OTLP 익스포터로 트레이싱을 시작합니다.
+// 실패 시 경고만 남기고 트레이싱 없이 계속 진행합니다.
+func startTracing(ctx context.Context, tracingCfg config.TracingConfig) tracing.ShutdownFunc {
+	version, _, _ := GetVersionInfo()
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		Enabled:        tracingCfg.Enabled,
+		Endpoint:       tracingCfg.Endpoint,
+		Insecure:       tracingCfg.Insecure,
+		Headers:        tracingCfg.Headers,
+		ServiceName:    tracingCfg.ServiceName,
+		ServiceVersion: version,
+		SampleRatio:    tracingCfg.SampleRatio,
+	})
+	if err != nil {
+		logger.Warn().Err(err).Msg("트레이싱 초기화 실패 - 트레이싱 없이 계속합니다")
+		return shutdown
+	}
+	if tracingCfg.Enabled {
+		logger.Info().
+			Str("endpoint", tracingCfg.Endpoint).
+			Float64("sample_ratio", tracingCfg.SampleRatio).
+			Msg("OpenTelemetry 트레이싱 활성화")
+	}
+	return shutdown
+}
+
+// newActionGate는 서버 주도 위험 작업의 로컬 승인 게이트를 생성합니다.
+// 표준 입력이 터미널이 아니면(헤드리스) prompt 모드 작업은 자동 거부됩니다.
+// 터미널에서 승인을 물을 때마다 action_approval_pending 이벤트를 발생시킵니다.
+func newActionGate(approvalCfg config.ActionApprovalConfig, eventHooks *eventhook.Dispatcher) *approval.ActionGate {
+	var prompter approval.Prompter
+	if approvalCfg.RequiresPrompt() {
+		if isTerminal(os.Stdin) {
+			prompter = hookedPrompter{
+				Prompter:   approval.NewTerminalPrompter(os.Stdin, os.Stderr, approvalCfg.GetPromptTimeout()),
+				eventHooks: eventHooks,
+			}
+		} else {
+			logger.Warn().Msg("터미널이 아닌 환경입니다 - 승인이 필요한 서버 작업은 자동 거부됩니다")
+		}
+	}
+
+	return approval.NewActionGate(approval.ActionGateConfig{
+		Modes: map[string]approval.GateMode{
+			approval.ActionCLIRequest:   approval.GateMode(approvalCfg.CLIRequest),
+			approval.ActionMCPDeploy:    approval.GateMode(approvalCfg.MCPDeploy),
+			approval.ActionComputerUse:  approval.GateMode(approvalCfg.ComputerUse),
+			approval.ActionApplyChanges: approval.GateMode(approvalCfg.ApplyChanges),
+			approval.ActionCustomTool:   approval.GateMode(approvalCfg.CustomTool),
+			approval.ActionGitRequest:   approval.GateMode(approvalCfg.GitRequest),
+		},
+		Allowlist: actionGateAllowlist(approvalCfg),
+	}, prompter, log.Logger)
+}
+
+// hookedPrompter는 터미널 승인을 묻기 전에 action_approval_pending 이벤트를 발생시켜
+// 다른 창에서 작업 중인 사용자가 데스크톱 알림 등으로 승인 대기를 알 수 있게 합니다.
+type hookedPrompter struct {
+	approval.Prompter
+	eventHooks *eventhook.Dispatcher
+}
+
+// Confirm은 이벤트를 발생시킨 뒤 터미널에서 승인을 묻습니다.
+func (p hookedPrompter) Confirm(ctx context.Context, action approval.LocalAction) (bool, error) {
+	p.eventHooks.Fire(eventhook.EventActionApprovalPending, map[string]string{
+		"action": action.Type,
+		"target": action.Target,
+	})
+	return p.Prompter.Confirm(ctx, action)
+}
+
+// newCustomToolExecutor는 custom_tools 설정으로 사용자 정의 도구 실행기를 생성합니다.
+// 설정이 잘못되었으면 경고를 남기고 도구 없이 계속 진행합니다.
+func newCustomToolExecutor(toolCfgs []config.CustomToolConfig) *executor.CustomToolExecutor {
+	tools := make([]executor.CustomTool, 0, len(toolCfgs))
+	for _, tc := range toolCfgs {
+		tools = append(tools, executor.CustomTool{
+			Name:        tc.Name,
+			Description: tc.Description,
+			Command:     tc.Command,
+			WorkDir:     tc.GetWorkDir(),
+			Env:         tc.Env,
+			Timeout:     tc.GetTimeout(),
+			InputSchema: tc.InputSchema,
+		})
+	}
+
+	customTools, err := executor.NewCustomToolExecutor(tools)
+	if err != nil {
+		logger.Warn().Err(err).Msg("custom_tools 설정 오류 - 사용자 정의 도구 비활성화")
+		customTools, _ = executor.NewCustomToolExecutor(nil)
+		return customTools
+	}
+	if len(tools) > 0 {
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.Name)
+		}
+		logger.Info().Strs("tools", names).Msg("사용자 정의 로컬 도구 등록")
+	}
+	return customTools
+}
+
+// newEmbeddedMCPFactory는 mcp_serve_start(embedded 모드) 요청 시 내장 MCP 서버 인스턴스를 생성하는 함수를 반환합니다.
+// 백엔드 URL이 비어 있으면 로그인한 서버 URL에서 HTTP API 주소를 유도하고,
+// 워크스페이스 스코프가 있으면 workspace_id를 생략한 도구 호출의 기본 워크스페이스로 지정합니다.
+// readOnly이면 모든 도구를 읽기 전용으로 제한하고, timeoutPolicy로 도구 호출 타임아웃을 적용합니다.
+func newEmbeddedMCPFactory(readOnly bool, timeoutPolicy *timeouts.Policy) websocket.EmbeddedMCPFactory {
+	return func(opts websocket.EmbeddedMCPOptions) (websocket.EmbeddedMCPServer, error) {
+		backendURL := opts.BackendURL
+		creds, err := auth.Load()
+		if err != nil {
+			return nil, fmt.Errorf("인증 정보를 읽을 수 없습니다: %w", err)
+		}
+		if creds == nil {
+			return nil, errors.New("인증 정보가 없습니다")
+		}
+		if backendURL == "" {
+			backendURL = serverURLToHTTPBase(creds.ServerURL)
+		}
+
+		mcpLogger := logger.WithContext(map[string]interface{}{"component": "mcp-serve", "instance": opts.Instance})
+		backend := mcpserver.NewBackendClient(backendURL, auth.NewTokenRefresher(creds), 60*time.Second, mcpLogger)
+		srv := mcpserver.NewServer(backend, mcpLogger)
+		if opts.WorkspaceID != "" {
+			srv.SetActiveWorkspace(opts.WorkspaceID)
+		}
+
+		// autopus-mcp-server와 같은 mcp_server.tools 설정으로 도구별 사용 권한 적용
+		var perms mcpserver.ToolPermissions
+		if err := viper.UnmarshalKey("mcp_server.tools", &perms); err != nil {
+			return nil, fmt.Errorf("mcp_server.tools 설정 파싱 실패: %w", err)
+		}
+		if readOnly {
+			perms = perms.ReadOnlyAll()
+		}
+		if err := srv.SetToolPermissions(perms); err != nil {
+			return nil, fmt.Errorf("mcp_server.tools 설정 오류: %w", err)
+		}
+		srv.SetListAgentsMaxResults(viper.GetInt("mcp_server.list_agents.max_results"))
+
+		// execute_task 프롬프트에 덧붙일 로컬 프로젝트 컨텍스트 (mcp_server.project_context)
+		projectCtx := mcpserver.DefaultProjectContextConfig()
+		if err := viper.UnmarshalKey("mcp_server.project_context", &projectCtx); err != nil {
+			return nil, fmt.Errorf("mcp_server.project_context 설정 파싱 실패: %w", err)
+		}
+		srv.SetProjectContext(projectCtx, project.NewAnalyzer())
+
+		// autopus-mcp-server와 같은 mcp_server.concurrency 설정으로 도구 호출 동시 실행 한도 적용
+		concurrency := mcpserver.DefaultConcurrencyConfig()
+		if err := viper.UnmarshalKey("mcp_server.concurrency", &concurrency); err != nil {
+			return nil, fmt.Errorf("mcp_server.concurrency 설정 파싱 실패: %w", err)
+		}
+		srv.SetConcurrencyLimits(concurrency)
+		srv.SetTimeoutPolicy(timeoutPolicy)
+
+		// exec --template과 같은 로컬 작업 템플릿 (list_templates/execute_template)
+		if registry, err := loadTaskTemplates(); err != nil {
+			mcpLogger.Warn().Err(err).Msg("작업 템플릿을 불러오지 못해 템플릿 없이 시작")
+		} else {
+			srv.SetTemplates(registry)
+		}
+
+		// autopus://bridge/config, autopus://bridge/capabilities 리소스
+		version, commit, buildDate := GetVersionInfo()
+		srv.SetBridgeInfo(mcpserver.BridgeInfo{
+			Version:    mcpserver.BridgeVersion{Bridge: version, Commit: commit, BuildDate: buildDate},
+			Settings:   viper.AllSettings,
+			LoadConfig: config.Load,
+		})
+		return srv, nil
+	}
+}
+
+// actionGateAllowlist는 승인 설정의 허용 목록을 작업 유형별 맵으로 변환합니다.
+func actionGateAllowlist(approvalCfg config.ActionApprovalConfig) map[string][]string {
+	return map[string][]string{
+		approval.ActionCLIRequest:  approvalCfg.AllowedCommands,
+		approval.ActionMCPDeploy:   approvalCfg.AllowedServices,
+		approval.ActionComputerUse: approvalCfg.AllowedURLs,
+		approval.ActionCustomTool:  approvalCfg.AllowedTools,
+		approval.ActionGitRequest:  approvalCfg.AllowedRepos,
+	}
+}
+
+// startFileSync는 설정된 디렉토리의 파일 변경을 백엔드로 동기화하는 Syncer를 시작합니다.
+// 실패 시 경고만 남기고 nil을 반환하여 연결 자체는 계속 유지합니다.
+func startFileSync(ctx context.Context, client *websocket.Client, fsCfg config.FileSyncConfig) *filesync.Syncer {
+	syncer, err := filesync.New(client, filesync.Options{
+		Directories:    fsCfg.Directories,
+		IgnorePatterns: fsCfg.IgnorePatterns,
+		UseGitignore:   fsCfg.UseGitignore,
+		MaxFileSize:    fsCfg.GetMaxFileSize(),
+		Extensions:     fsCfg.Extensions,
+		Debounce:       fsCfg.GetDebounce(),
+	})
+	if err != nil {
+		logger.Warn().Err(err).Msg("파일 동기화 초기화 실패 - 파일 동기화 비활성화")
+		return nil
+	}
+	if err := syncer.Start(ctx); err != nil {
+		logger.Warn().Err(err).Msg("파일 동기화 시작 실패 - 파일 동기화 비활성화")
+		return nil
+	}
+
+	logger.Info().
+		Strs("directories", fsCfg.Directories).
+		Msg("파일 동기화 시작")
+	return syncer
+}
+
 func loadBridgeRuntimeContext() (*websocket.BridgeRuntimeContext, string) {
 	root := resolveRuntimeWorkspaceRoot()
 	if root == "" {
@@ -541,16 +1241,18 @@ func resolveRuntimeWorkspaceRoot() string {
 func runEventLoop(
 	ctx context.Context,
 	cancel context.CancelFunc,
-	client *websocket.Client,
-	taskExecutor *executor.TaskExecutor,
+	shutdown shutdownParams,
 	connState *ConnectionState,
 	sigCh <-chan os.Signal,
 ) {
+	client := shutdown.client
+	taskExecutor := shutdown.taskExecutor
+
 	for {
 		select {
 		case <-ctx.Done():
 			// 컨텍스트 취소 - 정상 종료
-			gracefulShutdown(client, taskExecutor, "context_cancelled")
+			gracefulShutdown(shutdown, "context_cancelled")
 			return
 
 		case sig := <-sigCh:
@@ -558,7 +1260,7 @@ func runEventLoop(
 			logger.Info().
 				Str("signal", sig.String()).
 				Msg("종료 시그널 수신")
-			gracefulShutdown(client, taskExecutor, "user_initiated")
+			gracefulShutdown(shutdown, "user_initiated")
 			cancel()
 			return
 
@@ -578,11 +1280,11 @@ func runEventLoop(
 
 				select {
 				case <-ctx.Done():
-					gracefulShutdown(client, taskExecutor, "context_cancelled")
+					gracefulShutdown(shutdown, "context_cancelled")
 					return
 				case sig := <-sigCh:
 					logger.Info().Str("signal", sig.String()).Msg("종료 시그널 수신")
-					gracefulShutdown(client, taskExecutor, "user_initiated")
+					gracefulShutdown(shutdown, "user_initiated")
 					cancel()
 					return
 				default:
@@ -647,31 +1349,77 @@ func handleMessage(
 	}
 }
 
+// shutdownParams는 정상 종료에 필요한 구성 요소 묶음입니다.
+type shutdownParams struct {
+	client       *websocket.Client
+	router       *websocket.Router
+	taskExecutor *executor.TaskExecutor
+	// eventHooks는 종료 전에 disconnected 훅이 끝나기를 기다릴 이벤트 훅 실행기입니다.
+	eventHooks *eventhook.Dispatcher
+	// gracePeriod는 진행 중인 작업 완료를 기다리는 최대 시간입니다.
+	gracePeriod time.Duration
+}
+
 // gracefulShutdown는 정상적인 연결 종료를 수행합니다.
 // REQ-U-03: 종료 시 정상적인 연결 해제
-func gracefulShutdown(client *websocket.Client, taskExecutor *executor.TaskExecutor, reason string) {
+// 새 작업 수신을 중단하고 진행 중인 작업이 끝나기를 유예 시간만큼 기다린 뒤,
+// 끝나지 않은 작업은 에러로 보고하고 연결을 해제합니다.
+func gracefulShutdown(p shutdownParams, reason string) {
 	logger.Info().
 		Str("reason", reason).
+		Dur("grace_period", p.gracePeriod).
 		Msg("정상 종료 시작")
 
+	// 진행 중인 작업 드레이닝 (새 작업은 재시도 가능한 에러로 거절)
+	if p.router != nil {
+		if aborted := p.router.Drain(context.Background(), p.gracePeriod); len(aborted) > 0 {
+			logger.Warn().
+				Strs("execution_ids", aborted).
+				Msg("유예 시간 내 완료되지 않은 작업을 중단 보고")
+		}
+	}
+
 	// 작업 실행기 중지
-	taskExecutor.Stop()
+	p.taskExecutor.Stop()
 
 	// REQ-E-09: agent_disconnect 메시지 전송
-	if err := client.Disconnect(reason); err != nil {
+	if err := p.client.Disconnect(reason); err != nil {
 		logger.Error().
 			Err(err).
 			Msg("연결 종료 중 오류 발생")
 	}
 
+	// 마지막 이벤트 훅(task_failed, disconnected)이 끝날 때까지 잠시 대기
+	hookCtx, hookCancel := context.WithTimeout(context.Background(), eventhook.DefaultTimeout)
+	if err := p.eventHooks.Close(hookCtx); err != nil {
+		logger.Warn().Err(err).Msg("이벤트 훅 완료 대기 시간 초과")
+	}
+	hookCancel()
+
 	// 상태 파일 삭제
 	clearConnectionStatus()
 
 	logger.Info().Msg("정상 종료 완료")
 }
 
+// shutdownChildProcesses는 종료 시점까지 남아 있는 자식 프로세스 트리를 모두 종료하고 상태를 회수합니다.
+func shutdownChildProcesses() {
+	remaining := procgroup.Default().Len()
+	if remaining == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := procgroup.Shutdown(ctx); err != nil {
+		logger.Warn().Err(err).Int("processes", remaining).Msg("자식 프로세스 정리 시간 초과")
+		return
+	}
+	logger.Info().Int("processes", remaining).Msg("남은 자식 프로세스 정리 완료")
+}
+
 // initializeProviders는 설정에 따라 AI 프로바이더를 초기화합니다.
 func initializeProviders(ctx context.Context, cfg *config.Config) (*provider.Registry, error) {
+	codexTurnRetries := cfg.Providers.Codex.GetTurnRetries()
 	registryConfig := provider.RegistryConfig{
 		ClaudeEnabled:      cfg.Providers.Claude.Enabled,
 		ClaudeAPIKey:       cfg.Providers.Claude.GetAPIKey(),
@@ -695,11 +1443,81 @@ func initializeProviders(ctx context.Context, cfg *config.Config) (*provider.Reg
 		CodexCLITimeout:     cfg.Providers.Codex.GetCLITimeout(),
 		CodexApprovalPolicy: cfg.Providers.Codex.GetApprovalPolicy(),
 		CodexChatGPTAuthEnv: cfg.Providers.Codex.ChatGPTAuthEnv,
+		CodexTurnRetries:    &codexTurnRetries,
+		CodexFraming:        cfg.Providers.Codex.Framing,
+
+		OpenAICompatEnabled:      cfg.Providers.OpenAICompat.Enabled,
+		OpenAICompatName:         cfg.Providers.OpenAICompat.GetName(),
+		OpenAICompatBaseURL:      cfg.Providers.OpenAICompat.BaseURL,
+		OpenAICompatAPIKey:       cfg.Providers.OpenAICompat.GetAPIKey(),
+		OpenAICompatDefaultModel: cfg.Providers.OpenAICompat.DefaultModel,
+		OpenAICompatModels:       cfg.Providers.OpenAICompat.Models,
+		OpenAICompatTimeout:      int(cfg.Providers.OpenAICompat.GetTimeout().Seconds()),
 	}
 
 	return provider.InitializeRegistryWithLogger(ctx, registryConfig, log.Logger)
 }
 
+// warmupProviders는 등록된 프로바이더를 병렬로 warm-up하고
+// 정규 이름(anthropic/openai/google) 기준의 준비 상태 맵을 반환합니다.
+func warmupProviders(ctx context.Context, registry *provider.Registry, timeout time.Duration) map[string]bool {
+	logger.Info().
+		Dur("timeout", timeout).
+		Msg("프로바이더 warm-up 시작")
+
+	results := registry.WarmupAll(ctx, timeout)
+	readiness := make(map[string]bool, len(results))
+	for name, result := range results {
+		readiness[provider.ToCanonicalName(name)] = result.Ready
+		if result.Ready {
+			logger.Info().
+				Str("provider", name).
+				Dur("duration", result.Duration).
+				Msg("프로바이더 warm-up 완료")
+		} else {
+			logger.Warn().
+				Str("provider", name).
+				Dur("duration", result.Duration).
+				Err(result.Err).
+				Msg("프로바이더 warm-up 실패")
+		}
+	}
+	return readiness
+}
+
+// idleModeOptions는 idle_mode 설정으로 유휴 절전 옵션을 구성합니다.
+// 절전 시 프로바이더 상주 프로세스와 Computer Use 워밍 컨테이너를 내리고,
+// 기상 시 프로바이더를 다시 warm-up하고 워밍 풀 보충을 재개합니다.
+// idle_mode가 비활성화되어 있으면 빈 옵션을 반환합니다 (WithIdleMode가 무시).
+func idleModeOptions(ctx context.Context, cfg *config.Config, registry *provider.Registry, pool *computeruse.ContainerPool) websocket.IdleModeOptions {
+	if !cfg.IdleMode.Enabled {
+		return websocket.IdleModeOptions{}
+	}
+	warmupTimeout := cfg.Providers.Warmup.GetTimeout()
+	return websocket.IdleModeOptions{
+		After:             cfg.IdleMode.GetIdleAfter(),
+		HeartbeatInterval: cfg.IdleMode.GetHeartbeatInterval(),
+		OnIdle: func() {
+			logger.Info().
+				Dur("heartbeat_interval", cfg.IdleMode.GetHeartbeatInterval()).
+				Msg("유휴 절전 모드 진입")
+			for name, err := range registry.SuspendAll() {
+				logger.Warn().Err(err).Str("provider", name).Msg("프로바이더 절전 중단 실패")
+			}
+			if pool != nil {
+				pool.Suspend(ctx)
+			}
+		},
+		OnWake: func() {
+			logger.Info().Msg("유휴 절전 모드 해제")
+			if pool != nil {
+				pool.Resume()
+			}
+			warmupProviders(ctx, registry, warmupTimeout)
+		},
+	}
+}
+
 // startAuthWatcher는 authwatch 인스턴스를 시작합니다.
 // SPEC-HOTSWAP-001: 인증 파일 변경 감지로 연결 끊김 없이 capabilities 업데이트
 //
@@ -768,6 +1586,25 @@ func (a *registryProviderAdapter) ValidateConfig() error { return a.p.ValidateCo
 // connect.go에서 WithWatchDirs()로 직접 디렉토리를 지정하므로 이 메서드는 사용되지 않습니다.
 func (a *registryProviderAdapter) AuthFilePath() string { return "" }
 
+// newEnvironmentCollector는 작업 결과에 첨부할 환경 스냅샷 수집기를 생성합니다.
+// 활성화된 프로바이더의 CLI 버전을 함께 기록합니다.
+func newEnvironmentCollector(cfg *config.Config, version string) *executor.EnvironmentCollector {
+	providerCLIs := make(map[string]string)
+	if cfg.Providers.Claude.IsEnabled() {
+		providerCLIs["claude"] = cfg.Providers.Claude.GetCLIPath()
+	}
+	if cfg.Providers.Gemini.IsEnabled() {
+		providerCLIs["gemini"] = getProviderCLIPath(cfg.Providers.Gemini, "gemini")
+	}
+	if cfg.Providers.Codex.IsEnabled() {
+		providerCLIs["codex"] = getProviderCLIPath(cfg.Providers.Codex, "codex")
+	}
+	return executor.NewEnvironmentCollector(executor.EnvironmentCollectorConfig{
+		BridgeVersion: version,
+		ProviderCLIs:  providerCLIs,
+	})
+}
+
 // getProviderCLIPath는 프로바이더별 CLI 바이너리 경로를 반환합니다.
 // config.yaml에 cli_path가 명시적으로 설정된 경우 그 값을 사용하고,
 // 미설정 시 프로바이더별 기본 바이너리 이름을 반환합니다.
@@ -788,6 +1625,8 @@ type ConnectionState struct {
 	tasksCompleted int
 	tasksFailed    int
 	currentTaskID  string
+	quality        *ConnectionQualityStatus
+	identity       *ws.BridgeIdentity
 	mu             sync.RWMutex
 }
 
@@ -883,6 +1722,20 @@ func (s *ConnectionState) WorkspaceID() string {
 	return s.workspaceID
 }
 
+// SetIdentity는 Bridge 식별 정보를 설정합니다.
+func (s *ConnectionState) SetIdentity(identity *ws.BridgeIdentity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.identity = identity
+}
+
+// Identity는 Bridge 식별 정보를 반환합니다.
+func (s *ConnectionState) Identity() *ws.BridgeIdentity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.identity
+}
+
 // SetCurrentTaskID는 현재 작업 ID를 설정합니다.
 func (s *ConnectionState) SetCurrentTaskID(taskID string) {
 	s.mu.Lock()
@@ -897,6 +1750,32 @@ func (s *ConnectionState) CurrentTaskID() string {
 	return s.currentTaskID
 }
 
+// SetQuality는 최근 연결 품질을 설정합니다.
+func (s *ConnectionState) SetQuality(quality *ConnectionQualityStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quality = quality
+}
+
+// Quality는 최근 연결 품질을 반환합니다 (측정 전이면 nil).
+func (s *ConnectionState) Quality() *ConnectionQualityStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.quality
+}
+
+// newConnectionQualityStatus는 WebSocket 품질 스냅샷을 상태 파일 형식으로 변환합니다.
+func newConnectionQualityStatus(q websocket.QualitySnapshot) *ConnectionQualityStatus {
+	return &ConnectionQualityStatus{
+		Score:                    q.Score,
+		Level:                    string(q.Level),
+		AvgRTTMs:                 q.AvgRTT.Milliseconds(),
+		LossPercent:              int(math.Round(q.LossRate * 100)),
+		RecentReconnects:         q.RecentReconnects,
+		HeartbeatIntervalSeconds: int(q.HeartbeatInterval / time.Second),
+	}
+}
+
 // saveConnectionStatus는 연결 상태를 파일에 저장합니다.
 func saveConnectionStatus(connState *ConnectionState) {
 	startTime := connState.startTime
@@ -909,6 +1788,8 @@ func saveConnectionStatus(connState *ConnectionState) {
 		CurrentTask:    connState.CurrentTaskID(),
 		PID:            os.Getpid(),
 		WorkspaceID:    connState.WorkspaceID(),
+		Quality:        connState.Quality(),
+		Identity:       connState.Identity(),
 	}
 
 	if err := SaveStatus(status); err != nil {
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+	"github.com/spf13/viper"
+)
+
+// TestNewEmbeddedMCPFactory_ConfigKeys는 내장 MCP 서버가 mcp_server.tools와
+// mcp_server.list_agents.max_results를 적용하는지, 이전 mcpserver 키도 ApplyLegacyKeys로 적용되는지 검증합니다.
+func TestNewEmbeddedMCPFactory_ConfigKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+	}{
+		{name: "mcp_server 키", section: "mcp_server"},
+		{name: "이전 mcpserver 키", section: "mcpserver"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_CONFIG_HOME", "")
+			if err := auth.Save(&auth.Credentials{
+				AccessToken: "token",
+				ExpiresAt:   time.Now().Add(time.Hour),
+				ServerURL:   "wss://example.com/ws/agent",
+			}); err != nil {
+				t.Fatalf("인증 정보 저장 실패: %v", err)
+			}
+
+			viper.SetConfigType("yaml")
+			doc := tt.section + ":\n  tools:\n    manage_agent:\n      disabled: true\n  list_agents:\n    max_results: 7\n"
+			if err := viper.ReadConfig(strings.NewReader(doc)); err != nil {
+				t.Fatalf("설정 읽기 실패: %v", err)
+			}
+			config.ApplyLegacyKeys(viper.GetViper())
+
+			embedded, err := newEmbeddedMCPFactory(false, nil)(websocket.EmbeddedMCPOptions{})
+			if err != nil {
+				t.Fatalf("내장 MCP 서버 생성 실패: %v", err)
+			}
+			srv, ok := embedded.(*mcpserver.Server)
+			if !ok {
+				t.Fatalf("내장 MCP 서버 타입 = %T, want *mcpserver.Server", embedded)
+			}
+
+			if got := srv.ListAgentsMaxResults(); got != 7 {
+				t.Errorf("ListAgentsMaxResults() = %d, want 7", got)
+			}
+			resp, err := srv.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+			if err != nil {
+				t.Fatalf("tools/list 실패: %v", err)
+			}
+			var decoded struct {
+				Result struct {
+					Tools []struct {
+						Name string `json:"name"`
+					} `json:"tools"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(resp, &decoded); err != nil {
+				t.Fatalf("응답 파싱 실패: %v (%s)", err, resp)
+			}
+			if len(decoded.Result.Tools) == 0 {
+				t.Fatal("tools/list 응답에 도구가 없습니다")
+			}
+			for _, tool := range decoded.Result.Tools {
+				if tool.Name == "manage_agent" {
+					t.Error("비활성화한 manage_agent 도구가 등록되어 있습니다")
+				}
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/websocket"
 )
 
 type stubTaskSender struct {
@@ -273,3 +276,75 @@ func TestStatusTrackingTaskSender_TracksTaskFailure(t *testing.T) {
 		t.Fatalf("CurrentTask = %q, want empty", got.CurrentTask)
 	}
 }
+
+func TestSaveConnectionStatus_IncludesQuality(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	connState := NewConnectionState()
+	connState.SetConnected(true)
+	connState.SetWorkspaceID("ws-quality")
+	connState.SetQuality(newConnectionQualityStatus(websocket.QualitySnapshot{
+		Score:             60,
+		Level:             websocket.QualityFair,
+		AvgRTT:            420 * time.Millisecond,
+		LossRate:          0.15,
+		RecentReconnects:  1,
+		HeartbeatInterval: 15 * time.Second,
+	}))
+	saveConnectionStatus(connState)
+
+	data, _ := os.ReadFile(getScopedStatusFilePath("ws-quality"))
+	var got StatusInfo
+	_ = json.Unmarshal(data, &got)
+
+	want := ConnectionQualityStatus{Score: 60, Level: "fair", AvgRTTMs: 420, LossPercent: 15, RecentReconnects: 1, HeartbeatIntervalSeconds: 15}
+	if got.Quality == nil || *got.Quality != want {
+		t.Errorf("Quality = %+v, want %+v", got.Quality, want)
+	}
+}
+
+func TestSaveConnectionStatus_IncludesIdentity(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	connState := NewConnectionState()
+	connState.SetWorkspaceID("ws-identity")
+	connState.SetIdentity(&ws.BridgeIdentity{ID: "br-1", Name: "build-mac", Labels: map[string]string{"team": "backend"}})
+	saveConnectionStatus(connState)
+
+	data, _ := os.ReadFile(getScopedStatusFilePath("ws-identity"))
+	var got StatusInfo
+	_ = json.Unmarshal(data, &got)
+
+	if got.Identity == nil || got.Identity.ID != "br-1" || got.Identity.Name != "build-mac" || got.Identity.Labels["team"] != "backend" {
+		t.Errorf("Identity = %+v", got.Identity)
+	}
+}
+
+func TestNewBridgeIdentity(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := config.IdentityConfig{Name: "from-config", Labels: map[string]string{"team": "backend", "os": "linux"}}
+	first, err := newBridgeIdentity(cfg, "", []string{"os=mac"})
+	if err != nil {
+		t.Fatalf("newBridgeIdentity() error = %v", err)
+	}
+	if first.Name != "from-config" || first.Labels["os"] != "mac" || first.Labels["team"] != "backend" {
+		t.Errorf("identity = %+v, want 설정 이름과 플래그가 덮어쓴 os 라벨", first)
+	}
+
+	// 재실행해도 같은 ID를 유지하고 --name이 설정보다 우선
+	second, err := newBridgeIdentity(cfg, "flag-name", nil)
+	if err != nil {
+		t.Fatalf("newBridgeIdentity() error = %v", err)
+	}
+	if second.ID != first.ID || second.Name != "flag-name" {
+		t.Errorf("identity = %+v, want ID %q와 이름 flag-name", second, first.ID)
+	}
+
+	if _, err := newBridgeIdentity(cfg, "", []string{"team"}); err == nil {
+		t.Error("key=value 형식이 아닌 --label은 에러여야 합니다")
+	}
+	if _, err := newBridgeIdentity(config.IdentityConfig{Labels: map[string]string{"team": "back end"}}, "", nil); err == nil {
+		t.Error("유효하지 않은 identity.labels 값은 에러여야 합니다")
+	}
+}
@@ -0,0 +1,256 @@
+// crash.go는 크래시 리포트 관련 CLI 명령어를 구현합니다.
+// crash list/upload 서브커맨드
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/apiclient"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/crash"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// crashUploadPath는 크래시 번들 업로드 API 경로입니다.
+const crashUploadPath = "/api/v1/bridge/crash-reports"
+
+// crashUploader는 크래시 번들을 서버로 전송합니다. apiclient.Client가 구현합니다.
+type crashUploader interface {
+	Post(ctx context.Context, path string, body interface{}) (*apiclient.APIResponse, error)
+}
+
+var (
+	crashJSONOutput bool
+	crashUploadAll  bool
+	crashYes        bool
+)
+
+// crashCmd는 crash 서브커맨드의 루트입니다.
+var crashCmd = &cobra.Command{
+	Use:   "crash",
+	Short: "크래시 리포트 관리",
+	Long: `브리지가 비정상 종료될 때 저장된 크래시 리포트를 조회하고 서버로 전송합니다.
+
+크래시 리포트에는 최근 로그, 고루틴 덤프, 민감 정보를 제거한 설정, 버전 정보가 포함되며
+~/.config/autopus/crash/에 저장됩니다. 서버 전송은 사용자가 확인한 경우에만 수행합니다.
+
+설정 (config.yaml):
+  crash_report:
+    enabled: true        # 크래시 리포트 저장 여부
+    auto_upload: false   # connect 시작 시 전송되지 않은 리포트 자동 전송 (전송 동의)
+    max_reports: 20      # 보관할 최대 리포트 수`,
+}
+
+// crashListCmd는 저장된 크래시 리포트 목록을 조회합니다.
+var crashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "저장된 크래시 리포트 목록 조회",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jsonOut, _ := cmd.Flags().GetBool("json")
+		return runCrashList(cmd.OutOrStdout(), crashReportDir(), jsonOut)
+	},
+}
+
+// crashUploadCmd는 크래시 리포트를 서버로 전송합니다.
+var crashUploadCmd = &cobra.Command{
+	Use:   "upload [report-id]",
+	Short: "크래시 리포트를 서버로 전송",
+	Long: `크래시 리포트를 Autopus 서버로 전송합니다.
+ID를 지정하지 않으면 가장 최근 리포트를, --all을 지정하면 전송되지 않은 모든 리포트를 전송합니다.
+ID는 앞부분만 입력해도 됩니다.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bundles, err := selectCrashBundles(crashReportDir(), args, crashUploadAll)
+		if err != nil {
+			return err
+		}
+		if len(bundles) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "전송할 크래시 리포트가 없습니다")
+			return nil
+		}
+		client, err := newAPIClient()
+		if err != nil {
+			return err
+		}
+		return runCrashUpload(cmd.Context(), cmd.OutOrStdout(), bufio.NewScanner(os.Stdin), client, bundles, crashYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(crashCmd)
+	crashCmd.AddCommand(crashListCmd)
+	crashCmd.AddCommand(crashUploadCmd)
+
+	crashListCmd.Flags().BoolVar(&crashJSONOutput, "json", false, "JSON 형식으로 출력")
+	crashUploadCmd.Flags().BoolVar(&crashUploadAll, "all", false, "전송되지 않은 모든 리포트 전송")
+	crashUploadCmd.Flags().BoolVarP(&crashYes, "yes", "y", false, "확인 없이 전송")
+}
+
+// initCrashReporter는 설정에 따라 패닉 시 크래시 번들을 저장하는 리포터를 설치합니다.
+func initCrashReporter() {
+	cfg, err := config.Load()
+	if err != nil || !cfg.CrashReport.Enabled {
+		crash.Install(nil)
+		return
+	}
+
+	version, commit, buildDate := GetVersionInfo()
+	crash.Install(crash.NewReporter(crash.Config{
+		Dir:        cfg.CrashReport.GetDir(),
+		Version:    crash.VersionInfo{Version: version, Commit: commit, BuildDate: buildDate},
+		ConfigFile: viper.ConfigFileUsed(),
+		RecentLogs: logger.RecentLogs,
+		MaxReports: cfg.CrashReport.MaxReports,
+	}))
+}
+
+// crashReportDir는 크래시 번들 저장 디렉토리를 반환합니다.
+func crashReportDir() string {
+	if cfg, err := config.Load(); err == nil {
+		if dir := cfg.CrashReport.GetDir(); dir != "" {
+			return dir
+		}
+	}
+	return crash.DefaultDir()
+}
+
+// runCrashList는 저장된 크래시 리포트 목록을 출력합니다.
+func runCrashList(out io.Writer, dir string, jsonOut bool) error {
+	bundles, err := crash.List(dir)
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		return apiclient.PrintJSON(out, bundles)
+	}
+	if len(bundles) == 0 {
+		fmt.Fprintf(out, "저장된 크래시 리포트가 없습니다 (%s)\n", dir)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(bundles))
+	for _, b := range bundles {
+		uploaded := "-"
+		if b.Uploaded {
+			uploaded = "전송됨"
+		}
+		rows = append(rows, []string{
+			b.ID,
+			b.Time.Local().Format(time.DateTime),
+			b.Subsystem,
+			b.Version,
+			truncateContent(strings.ReplaceAll(b.Reason, "\n", " ")),
+			uploaded,
+		})
+	}
+	apiclient.PrintTable(out, []string{"ID", "TIME", "SUBSYSTEM", "VERSION", "REASON", "UPLOAD"}, rows)
+	return nil
+}
+
+// selectCrashBundles는 업로드 대상 번들을 선택합니다.
+// ID가 있으면 해당 번들, all이면 전송되지 않은 모든 번들, 둘 다 없으면 가장 최근 번들을 반환합니다.
+func selectCrashBundles(dir string, args []string, all bool) ([]crash.Bundle, error) {
+	if len(args) > 0 {
+		b, err := crash.Find(dir, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return []crash.Bundle{b}, nil
+	}
+
+	bundles, err := crash.List(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !all {
+		if len(bundles) == 0 {
+			return nil, nil
+		}
+		return bundles[:1], nil
+	}
+
+	pending := make([]crash.Bundle, 0, len(bundles))
+	for _, b := range bundles {
+		if !b.Uploaded {
+			pending = append(pending, b)
+		}
+	}
+	return pending, nil
+}
+
+// runCrashUpload는 사용자 확인 후 크래시 번들을 서버로 전송합니다.
+func runCrashUpload(ctx context.Context, out io.Writer, scanner *bufio.Scanner, uploader crashUploader, bundles []crash.Bundle, yes bool) error {
+	if !yes {
+		fmt.Fprintf(out, "크래시 리포트 %d개를 전송합니다.\n", len(bundles))
+		fmt.Fprintln(out, "리포트에는 최근 로그, 고루틴 덤프, 민감 정보를 제거한 설정, 버전 정보가 포함됩니다.")
+		fmt.Fprint(out, "계속하시겠습니까? [y/N]: ")
+		if !scanYesNo(scanner) {
+			fmt.Fprintln(out, "취소되었습니다")
+			return nil
+		}
+	}
+
+	uploaded, err := uploadCrashBundles(ctx, uploader, bundles)
+	for _, b := range uploaded {
+		fmt.Fprintf(out, "전송 완료: %s\n", b.ID)
+	}
+	return err
+}
+
+// uploadCrashBundles는 번들을 순서대로 전송하고, 전송에 성공한 번들을 반환합니다.
+// 전송에 실패하면 그 시점에서 중단합니다.
+func uploadCrashBundles(ctx context.Context, uploader crashUploader, bundles []crash.Bundle) ([]crash.Bundle, error) {
+	uploaded := make([]crash.Bundle, 0, len(bundles))
+	for _, b := range bundles {
+		payload, err := crash.NewUploadPayload(b)
+		if err != nil {
+			return uploaded, err
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+		_, err = uploader.Post(reqCtx, crashUploadPath, payload)
+		cancel()
+		if err != nil {
+			return uploaded, fmt.Errorf("크래시 리포트 전송 실패 (%s): %w", b.ID, err)
+		}
+
+		if err := crash.MarkUploaded(b); err != nil {
+			logger.Warn().Err(err).Str("report", b.ID).Msg("크래시 리포트 전송 기록 실패")
+		}
+		uploaded = append(uploaded, b)
+	}
+	return uploaded, nil
+}
+
+// uploadPendingCrashReports는 crash_report.auto_upload에 동의한 경우 전송되지 않은 번들을 백그라운드로 전송합니다.
+func uploadPendingCrashReports(ctx context.Context, cfg *config.Config) {
+	if !cfg.CrashReport.Enabled || !cfg.CrashReport.AutoUpload {
+		return
+	}
+
+	bundles, err := selectCrashBundles(crashReportDir(), nil, true)
+	if err != nil || len(bundles) == 0 {
+		return
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		logger.Debug().Err(err).Msg("크래시 리포트 자동 전송 건너뜀")
+		return
+	}
+
+	uploaded, err := uploadCrashBundles(ctx, client, bundles)
+	if err != nil {
+		logger.Warn().Err(err).Msg("크래시 리포트 자동 전송 실패")
+	}
+	if len(uploaded) > 0 {
+		logger.Info().Int("count", len(uploaded)).Msg("크래시 리포트 자동 전송 완료")
+	}
+}
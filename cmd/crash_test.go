@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/apiclient"
+	"github.com/insajin/autopus-bridge/internal/crash"
+)
+
+// fakeCrashUploader는 crash upload 테스트용 업로더입니다.
+type fakeCrashUploader struct {
+	err   error
+	paths []string
+	names []string
+}
+
+func (f *fakeCrashUploader) Post(_ context.Context, path string, body interface{}) (*apiclient.APIResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.paths = append(f.paths, path)
+	if p, ok := body.(crash.UploadPayload); ok {
+		f.names = append(f.names, p.Filename)
+	}
+	return &apiclient.APIResponse{Success: true}, nil
+}
+
+// captureTestCrashes는 임시 디렉토리에 크래시 번들을 n개 생성합니다.
+func captureTestCrashes(t *testing.T, n int) string {
+	t.Helper()
+	dir := t.TempDir()
+	r := crash.NewReporter(crash.Config{Dir: dir, Version: crash.VersionInfo{Version: "1.0.0"}})
+	for i := 0; i < n; i++ {
+		if _, err := r.Capture("event-loop", "boom", nil); err != nil {
+			t.Fatalf("Capture 실패: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestRunCrashList(t *testing.T) {
+	var out bytes.Buffer
+	if err := runCrashList(&out, t.TempDir(), false); err != nil {
+		t.Fatalf("runCrashList 실패: %v", err)
+	}
+	if !strings.Contains(out.String(), "저장된 크래시 리포트가 없습니다") {
+		t.Errorf("빈 목록 메시지가 없습니다: %q", out.String())
+	}
+
+	dir := captureTestCrashes(t, 1)
+	out.Reset()
+	if err := runCrashList(&out, dir, false); err != nil {
+		t.Fatalf("runCrashList 실패: %v", err)
+	}
+	for _, want := range []string{"SUBSYSTEM", "event-loop", "1.0.0", "boom"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("출력에 %q가 없습니다: %q", want, out.String())
+		}
+	}
+}
+
+func TestRunCrashUpload_RequiresConsent(t *testing.T) {
+	dir := captureTestCrashes(t, 1)
+	bundles, err := selectCrashBundles(dir, nil, false)
+	if err != nil {
+		t.Fatalf("selectCrashBundles 실패: %v", err)
+	}
+
+	uploader := &fakeCrashUploader{}
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(strings.NewReader("n\n"))
+	if err := runCrashUpload(context.Background(), &out, scanner, uploader, bundles, false); err != nil {
+		t.Fatalf("runCrashUpload 실패: %v", err)
+	}
+	if len(uploader.paths) != 0 {
+		t.Errorf("거절했는데 %d개가 전송되었습니다", len(uploader.paths))
+	}
+	if !strings.Contains(out.String(), "취소되었습니다") {
+		t.Errorf("취소 메시지가 없습니다: %q", out.String())
+	}
+}
+
+func TestRunCrashUpload_Yes(t *testing.T) {
+	dir := captureTestCrashes(t, 2)
+	bundles, err := selectCrashBundles(dir, nil, true)
+	if err != nil {
+		t.Fatalf("selectCrashBundles 실패: %v", err)
+	}
+	if len(bundles) != 2 {
+		t.Fatalf("전송 대상 = %d; want 2", len(bundles))
+	}
+
+	uploader := &fakeCrashUploader{}
+	var out bytes.Buffer
+	if err := runCrashUpload(context.Background(), &out, bufio.NewScanner(strings.NewReader("")), uploader, bundles, true); err != nil {
+		t.Fatalf("runCrashUpload 실패: %v", err)
+	}
+	if len(uploader.paths) != 2 || uploader.paths[0] != crashUploadPath {
+		t.Errorf("전송 경로 = %v; want 2 x %s", uploader.paths, crashUploadPath)
+	}
+
+	// 전송된 번들은 --all 대상에서 제외된다.
+	pending, err := selectCrashBundles(dir, nil, true)
+	if err != nil {
+		t.Fatalf("selectCrashBundles 실패: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("전송 후 남은 대상 = %d; want 0", len(pending))
+	}
+}
+
+func TestUploadCrashBundles_StopsOnError(t *testing.T) {
+	dir := captureTestCrashes(t, 1)
+	bundles, err := selectCrashBundles(dir, nil, true)
+	if err != nil {
+		t.Fatalf("selectCrashBundles 실패: %v", err)
+	}
+
+	uploaded, err := uploadCrashBundles(context.Background(), &fakeCrashUploader{err: errors.New("503")}, bundles)
+	if err == nil {
+		t.Fatal("전송 실패가 반환되지 않았습니다")
+	}
+	if len(uploaded) != 0 {
+		t.Errorf("uploaded = %d; want 0", len(uploaded))
+	}
+	if pending, _ := selectCrashBundles(dir, nil, true); len(pending) != 1 {
+		t.Errorf("실패한 번들이 전송됨으로 기록되었습니다")
+	}
+}
@@ -0,0 +1,182 @@
+// credential_watch.go는 connect 실행 중 refresh token 만료를 감시합니다.
+// 만료가 가까워지거나 만료되면 재인증 링크와 함께 경고하고 하트비트로 서버에 알리며,
+// 로컬 콜백으로 재인증이 끝나면 Bridge를 재시작하지 않고 자격 증명을 교체합니다.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+)
+
+// intakePausedReason은 재인증 대기 중 거절하는 작업에 보내는 메시지입니다.
+const intakePausedReason = "Bridge 로그인이 만료되어 재인증을 기다리는 중입니다"
+
+// credentialWatch는 TokenRefresher의 만료 상태 알림을 받아 경고, 하트비트, 작업 수신 중지를 처리합니다.
+type credentialWatch struct {
+	ctx         context.Context
+	refresher   *auth.TokenRefresher
+	client      *websocket.Client
+	router      *websocket.Router
+	pauseIntake bool
+	webBaseURL  string
+	apiBaseURL  string
+	out         io.Writer
+
+	mu      sync.Mutex
+	state   auth.ExpiryState
+	renewal *auth.Renewal
+}
+
+// onStatus는 만료 상태가 바뀔 때 TokenRefresher가 호출합니다.
+func (w *credentialWatch) onStatus(status auth.ExpiryStatus) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	prev := w.state
+	w.state = status.State
+	paused := false
+
+	switch status.State {
+	case auth.ExpiryValid:
+		w.closeRenewalLocked()
+		if w.pauseIntake {
+			w.router.ResumeIntake()
+		}
+		if prev == auth.ExpiryExpired || prev == auth.ExpiryExpiring {
+			logger.Info().Msg("Bridge 자격 증명이 갱신되었습니다")
+			_, _ = fmt.Fprintf(w.out, "\n  Bridge 로그인이 갱신되었습니다.\n\n")
+		}
+	case auth.ExpiryExpiring:
+		if prev == auth.ExpiryExpiring {
+			break // 만료 시각만 바뀐 경우 하트비트만 갱신
+		}
+		link := w.renewalLinkLocked()
+		logger.Warn().
+			Time("refresh_expires_at", status.RefreshExpiresAt).
+			Str("renew_url", link).
+			Msg("Bridge 로그인이 곧 만료됩니다")
+		_, _ = fmt.Fprintf(w.out, "\n  경고: Bridge 로그인이 %s 후 만료됩니다 (%s).\n",
+			time.Until(status.RefreshExpiresAt).Round(time.Minute), status.RefreshExpiresAt.Local().Format(time.RFC1123))
+		w.printRenewalLink(link)
+	case auth.ExpiryExpired:
+		if w.pauseIntake {
+			w.router.PauseIntake(intakePausedReason)
+			paused = true
+		}
+		if prev == auth.ExpiryExpired {
+			break
+		}
+		link := w.renewalLinkLocked()
+		logger.Error().
+			Str("renew_url", link).
+			Bool("intake_paused", paused).
+			Msg("Bridge 로그인이 만료되었습니다: 재인증 필요")
+		_, _ = fmt.Fprintf(w.out, "\n  경고: Bridge 로그인이 만료되었습니다.\n")
+		if paused {
+			_, _ = fmt.Fprintf(w.out, "  재인증이 끝날 때까지 새 작업을 받지 않습니다.\n")
+		}
+		w.printRenewalLink(link)
+	}
+
+	w.client.SetCredentialStatus(credentialStatusPayload(status, paused))
+}
+
+// printRenewalLink는 재인증 방법을 출력합니다. 링크가 없으면 autopus login을 안내합니다.
+func (w *credentialWatch) printRenewalLink(link string) {
+	if link == "" {
+		_, _ = fmt.Fprintf(w.out, "  다른 터미널에서 'autopus login'을 실행하면 재시작 없이 반영됩니다.\n\n")
+		return
+	}
+	_, _ = fmt.Fprintf(w.out, "  재시작 없이 갱신하려면 브라우저에서 다음 링크를 여세요:\n    %s\n\n", link)
+}
+
+// renewalLinkLocked는 진행 중인 재인증 링크를 반환하고, 없으면 새로 시작합니다.
+// 로컬 콜백 서버를 띄우지 못하면 빈 문자열을 반환합니다. w.mu를 보유한 상태에서 호출해야 합니다.
+func (w *credentialWatch) renewalLinkLocked() string {
+	if w.renewal != nil {
+		return w.renewal.URL()
+	}
+	renewal, err := auth.StartRenewal(w.webBaseURL, w.apiBaseURL)
+	if err != nil {
+		logger.Warn().Err(err).Msg("재인증 콜백 서버 시작 실패")
+		return ""
+	}
+	w.renewal = renewal
+	go w.awaitRenewal(renewal)
+	return renewal.URL()
+}
+
+// closeRenewalLocked는 진행 중인 재인증을 취소합니다. w.mu를 보유한 상태에서 호출해야 합니다.
+func (w *credentialWatch) closeRenewalLocked() {
+	if w.renewal == nil {
+		return
+	}
+	_ = w.renewal.Close()
+	w.renewal = nil
+}
+
+// awaitRenewal은 재인증 콜백을 기다렸다가 새 자격 증명을 저장하고 연결에 반영합니다.
+func (w *credentialWatch) awaitRenewal(renewal *auth.Renewal) {
+	tokenResp, err := renewal.Wait(w.ctx)
+
+	w.mu.Lock()
+	current := w.renewal == renewal
+	if current {
+		w.closeRenewalLocked()
+	}
+	w.mu.Unlock()
+	if !current || errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		logger.Warn().Err(err).Msg("재인증 실패")
+		w.retryRenewal()
+		return
+	}
+
+	prev, loadErr := auth.Load()
+	if loadErr != nil || prev == nil {
+		prev = &auth.Credentials{ServerURL: getServerURL()}
+	}
+	creds := auth.RenewedCredentials(prev, tokenResp)
+	if saveErr := auth.Save(creds); saveErr != nil {
+		logger.Warn().Err(saveErr).Msg("재인증 자격 증명 저장 실패")
+	}
+
+	w.client.UpdateToken(creds.AccessToken)
+	w.refresher.UpdateCredentials(creds)
+	logger.Info().Str("email", creds.UserEmail).Msg("재인증 완료")
+}
+
+// retryRenewal은 재인증이 실패했을 때 아직 재인증이 필요하면 새 링크를 출력합니다.
+func (w *credentialWatch) retryRenewal() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.state == auth.ExpiryValid {
+		return
+	}
+	_, _ = fmt.Fprintf(w.out, "\n  경고: Bridge 재인증에 실패했습니다.\n")
+	w.printRenewalLink(w.renewalLinkLocked())
+}
+
+// credentialStatusPayload는 만료 상태를 하트비트 페이로드로 변환합니다.
+func credentialStatusPayload(status auth.ExpiryStatus, intakePaused bool) *ws.CredentialStatus {
+	payload := &ws.CredentialStatus{
+		State:        string(status.State),
+		IntakePaused: intakePaused,
+	}
+	if !status.RefreshExpiresAt.IsZero() {
+		expiresAt := status.RefreshExpiresAt
+		payload.RefreshExpiresAt = &expiresAt
+	}
+	return payload
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/spf13/cobra"
+)
+
+// exec 명령 종료 코드입니다. 스크립트에서 실행 결과를 구분할 수 있도록 고정된 값을 사용합니다.
+const (
+	// execExitError는 제출/인증/네트워크 등 실행 이전 단계의 오류입니다.
+	execExitError = 1
+	// execExitFailed는 실행이 failed 상태로 끝난 경우입니다.
+	execExitFailed = 2
+	// execExitCancelled는 실행이 rejected 또는 cancelled 상태로 끝난 경우입니다.
+	execExitCancelled = 3
+	// execExitTimeout은 --wait-timeout 내에 실행이 끝나지 않은 경우입니다.
+	execExitTimeout = 4
+)
+
+var (
+	execPrompt       string
+	execTemplateName string
+	execTemplateVars []string
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec",
+	Short: "터미널에서 에이전트 작업을 단발성으로 실행합니다",
+	Long: `백엔드 REST API로 에이전트 작업을 직접 제출하고 결과를 출력합니다.
+스크립트에서 사용할 수 있도록 실행 결과를 종료 코드로 반환합니다.
+
+종료 코드:
+  0  성공 (--wait/--stream 없이 제출만 한 경우 포함)
+  1  제출/인증/네트워크 오류
+  2  실행 실패 (failed)
+  3  실행 거부 또는 취소 (rejected, cancelled)
+  4  대기 시간 초과 (--wait-timeout)
+
+예시:
+  autopus-bridge exec --agent <id> --prompt "README 요약" --wait
+  echo "테스트 실패 원인 분석" | autopus-bridge exec --agent <id> --prompt - --stream
+  autopus-bridge exec --template deploy-check --var env=prod --wait
+
+템플릿은 설정 파일의 templates 항목이나 ~/.config/autopus/templates/*.yaml에 정의합니다.
+템플릿의 에이전트/도구/모델/프로바이더는 명령줄 플래그로 덮어쓸 수 있습니다.`,
+	Args: cobra.NoArgs,
+	RunE: runExec,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	// execute 명령과 동일한 실행 옵션을 공유합니다.
+	execCmd.Flags().StringVar(&executeAgentID, "agent", "", "대상 에이전트 ID")
+	execCmd.Flags().StringVar(&executeAgentName, "agent-name", "", "대상 에이전트 이름 (--agent 미지정 시)")
+	execCmd.Flags().StringVarP(&execPrompt, "prompt", "p", "", "작업 프롬프트 (\"-\"이면 표준 입력에서 읽음)")
+	execCmd.Flags().StringVarP(&execTemplateName, "template", "t", "", "실행할 작업 템플릿 이름 (--prompt 대신 사용)")
+	execCmd.Flags().StringArrayVar(&execTemplateVars, "var", nil, "템플릿 변수 (이름=값, 반복 사용)")
+	execCmd.Flags().StringVar(&executeWorkspace, "workspace-id", "", "대상 워크스페이스 ID (기본값: 저장된 credentials)")
+	execCmd.Flags().StringVar(&executeModel, "model", "", "실행에 사용할 모델")
+	execCmd.Flags().StringVar(&executeProvider, "provider", "", "실행에 사용할 프로바이더")
+	execCmd.Flags().StringSliceVar(&executeTools, "tools", nil, "허용 도구 목록 (쉼표 구분 또는 반복 사용)")
+	execCmd.Flags().IntVar(&executeTimeoutSec, "timeout", 0, "실행 타임아웃(초)")
+	execCmd.Flags().IntVar(&executeMaxTokens, "max-tokens", 0, "최대 생성 토큰 수")
+	execCmd.Flags().BoolVar(&executeWait, "wait", false, "실행이 끝날 때까지 상태를 polling합니다")
+	execCmd.Flags().DurationVar(&executeWaitPoll, "poll-interval", 2*time.Second, "상태 polling 간격")
+	execCmd.Flags().DurationVar(&executeWaitLimit, "wait-timeout", 10*time.Minute, "최대 대기 시간")
+	execCmd.Flags().BoolVar(&executeStream, "stream", false, "Server-Sent Events로 실행 출력을 스트리밍합니다")
+	execCmd.Flags().BoolVar(&executeJSON, "json", false, "JSON 형식으로 출력")
+}
+
+func runExec(cmd *cobra.Command, _ []string) error {
+	var prompt string
+	var err error
+	switch {
+	case execTemplateName != "":
+		var registry *tasktemplate.Registry
+		if registry, err = loadTaskTemplates(); err == nil {
+			prompt, err = applyExecTemplate(cmd, registry)
+		}
+	case len(execTemplateVars) > 0:
+		err = errors.New("--var는 --template과 함께 사용해야 합니다")
+	default:
+		prompt, err = resolveExecPrompt(execPrompt, cmd.InOrStdin())
+	}
+	if err != nil {
+		return &exitCodeError{code: execExitError, err: err}
+	}
+
+	return execExitErrorFor(runExecute(cmd, []string{prompt}))
+}
+
+// resolveExecPrompt는 --prompt 값을 확인하고 "-"이면 표준 입력에서 프롬프트를 읽습니다.
+func resolveExecPrompt(prompt string, in io.Reader) (string, error) {
+	if prompt == "-" {
+		data, err := io.ReadAll(in)
+		if err != nil {
+			return "", fmt.Errorf("표준 입력 읽기 실패: %w", err)
+		}
+		prompt = string(data)
+	}
+
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return "", errors.New("--prompt가 필요합니다")
+	}
+	return prompt, nil
+}
+
+// loadTaskTemplates는 설정의 templates 항목과 템플릿 디렉토리에서 작업 템플릿을 읽습니다.
+func loadTaskTemplates() (*tasktemplate.Registry, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("설정 로드 실패: %w", err)
+	}
+	registry, err := tasktemplate.Load(cfg.Templates, cfg.GetTemplatesDir())
+	if err != nil {
+		return nil, fmt.Errorf("작업 템플릿 로드 실패: %w", err)
+	}
+	return registry, nil
+}
+
+// applyExecTemplate은 --template으로 지정한 템플릿의 프롬프트를 --var 값으로 렌더링하고,
+// 명령줄에서 지정하지 않은 에이전트/도구/모델/프로바이더 옵션을 템플릿 값으로 채웁니다.
+func applyExecTemplate(cmd *cobra.Command, registry *tasktemplate.Registry) (string, error) {
+	if execPrompt != "" {
+		return "", errors.New("--template과 --prompt는 함께 사용할 수 없습니다")
+	}
+
+	tmpl, err := registry.Get(execTemplateName)
+	if err != nil {
+		return "", err
+	}
+	vars, err := tasktemplate.ParseVars(execTemplateVars)
+	if err != nil {
+		return "", err
+	}
+	prompt, err := tmpl.Render(vars)
+	if err != nil {
+		return "", err
+	}
+
+	flags := cmd.Flags()
+	// 에이전트는 --agent 또는 --agent-name 중 하나라도 지정하면 템플릿 값을 사용하지 않습니다.
+	if !flags.Changed("agent") && !flags.Changed("agent-name") {
+		executeAgentID = tmpl.AgentID
+		executeAgentName = tmpl.Agent
+	}
+	if !flags.Changed("tools") && len(tmpl.Tools) > 0 {
+		executeTools = tmpl.Tools
+	}
+	if !flags.Changed("model") && tmpl.Model != "" {
+		executeModel = tmpl.Model
+	}
+	if !flags.Changed("provider") && tmpl.Provider != "" {
+		executeProvider = tmpl.Provider
+	}
+	return prompt, nil
+}
+
+// execExitErrorFor는 실행 오류를 exec 종료 코드가 지정된 에러로 변환합니다.
+func execExitErrorFor(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var outcome *executionOutcomeError
+	switch {
+	case errors.As(err, &outcome):
+		code := execExitFailed
+		switch strings.ToLower(outcome.Status) {
+		case "rejected", "cancelled":
+			code = execExitCancelled
+		}
+		return &exitCodeError{code: code, err: err}
+	case errors.Is(err, errExecutionWaitTimeout):
+		return &exitCodeError{code: execExitTimeout, err: err}
+	default:
+		return &exitCodeError{code: execExitError, err: err}
+	}
+}
+
+// exitCodeError는 프로세스 종료 코드를 지정하는 에러입니다.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+func (e *exitCodeError) ExitCode() int { return e.code }
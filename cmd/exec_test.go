@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveExecPrompt(t *testing.T) {
+	got, err := resolveExecPrompt("  요약해줘  ", strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "요약해줘" {
+		t.Fatalf("prompt = %q, want %q", got, "요약해줘")
+	}
+
+	got, err = resolveExecPrompt("-", strings.NewReader("stdin prompt\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "stdin prompt" {
+		t.Fatalf("prompt = %q, want %q", got, "stdin prompt")
+	}
+
+	if _, err := resolveExecPrompt("", strings.NewReader("")); err == nil {
+		t.Fatal("빈 프롬프트는 에러를 반환해야 합니다")
+	}
+}
+
+func TestExecExitErrorFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: 0},
+		{name: "generic error", err: errors.New("작업 제출 실패"), want: execExitError},
+		{
+			name: "failed execution",
+			err:  &executionOutcomeError{ExecutionID: "e1", Status: "failed", Message: "boom"},
+			want: execExitFailed,
+		},
+		{
+			name: "cancelled execution",
+			err:  &executionOutcomeError{ExecutionID: "e1", Status: "cancelled"},
+			want: execExitCancelled,
+		},
+		{
+			name: "rejected execution",
+			err:  &executionOutcomeError{ExecutionID: "e1", Status: "rejected"},
+			want: execExitCancelled,
+		},
+		{
+			name: "wait timeout",
+			err:  fmt.Errorf("%w: %w", errExecutionWaitTimeout, context.DeadlineExceeded),
+			want: execExitTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(execExitErrorFor(tt.err)); got != tt.want {
+				t.Fatalf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutionOutcomeErrorMessage(t *testing.T) {
+	err := &executionOutcomeError{ExecutionID: "e1", Status: "failed", Message: "boom"}
+	if got, want := err.Error(), "execution e1 finished with status failed: boom"; got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestApplyExecTemplate(t *testing.T) {
+	registry, err := tasktemplate.Load([]config.TemplateConfig{{
+		Name:   "deploy-check",
+		Agent:  "deployer",
+		Prompt: "{{env}} 배포 상태 확인",
+		Tools:  []string{"kubectl"},
+		Model:  "sonnet",
+	}}, "")
+	if err != nil {
+		t.Fatalf("tasktemplate.Load: %v", err)
+	}
+
+	origPrompt, origName, origVars := execPrompt, execTemplateName, execTemplateVars
+	origAgentID, origAgentName, origTools, origModel := executeAgentID, executeAgentName, executeTools, executeModel
+	t.Cleanup(func() {
+		execPrompt, execTemplateName, execTemplateVars = origPrompt, origName, origVars
+		executeAgentID, executeAgentName, executeTools, executeModel = origAgentID, origAgentName, origTools, origModel
+	})
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringVar(&executeAgentID, "agent", "", "")
+		cmd.Flags().StringVar(&executeAgentName, "agent-name", "", "")
+		cmd.Flags().StringSliceVar(&executeTools, "tools", nil, "")
+		cmd.Flags().StringVar(&executeModel, "model", "", "")
+		cmd.Flags().StringVar(&executeProvider, "provider", "", "")
+		return cmd
+	}
+
+	execPrompt, execTemplateName, execTemplateVars = "", "deploy-check", []string{"env=prod"}
+	cmd := newCmd()
+	if err := cmd.Flags().Parse([]string{"--model", "opus"}); err != nil {
+		t.Fatal(err)
+	}
+	prompt, err := applyExecTemplate(cmd, registry)
+	if err != nil {
+		t.Fatalf("applyExecTemplate: %v", err)
+	}
+	if prompt != "prod 배포 상태 확인" {
+		t.Errorf("prompt = %q", prompt)
+	}
+	if executeAgentName != "deployer" || executeModel != "opus" || strings.Join(executeTools, ",") != "kubectl" {
+		t.Errorf("agent=%q model=%q tools=%v: 템플릿 값을 쓰되 지정한 플래그가 우선해야 합니다",
+			executeAgentName, executeModel, executeTools)
+	}
+
+	execTemplateVars = nil
+	if _, err := applyExecTemplate(newCmd(), registry); err == nil {
+		t.Error("변수 값이 없으면 에러를 반환해야 합니다")
+	}
+
+	execPrompt = "직접 입력"
+	if _, err := applyExecTemplate(newCmd(), registry); err == nil {
+		t.Error("--template과 --prompt를 함께 쓰면 에러를 반환해야 합니다")
+	}
+}
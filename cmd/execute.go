@@ -205,10 +205,7 @@ func runExecute(cmd *cobra.Command, args []string) error {
 	}
 
 	if executeWait && isFailedExecutionStatus(output.Status) {
-		if output.Error != "" {
-			return fmt.Errorf("execution %s finished with status %s: %s", output.ExecutionID, output.Status, output.Error)
-		}
-		return fmt.Errorf("execution %s finished with status %s", output.ExecutionID, output.Status)
+		return newExecutionOutcomeError(output)
 	}
 
 	return nil
@@ -488,10 +485,7 @@ func streamExecution(
 		return err
 	}
 	if isFailedExecutionStatus(output.Status) {
-		if output.Error != "" {
-			return fmt.Errorf("execution %s finished with status %s: %s", output.ExecutionID, output.Status, output.Error)
-		}
-		return fmt.Errorf("execution %s finished with status %s", output.ExecutionID, output.Status)
+		return newExecutionOutcomeError(output)
 	}
 	return nil
 }
@@ -689,6 +683,9 @@ func chooseAgentInteractively(
 	return &agents[choice-1], nil
 }
 
+// errExecutionWaitTimeout은 --wait 대기 중 실행이 끝나지 않았음을 나타냅니다.
+var errExecutionWaitTimeout = errors.New("실행 대기 시간 초과")
+
 type executionStatusFetcher interface {
 	GetExecutionStatus(ctx context.Context, executionID string) (*mcpserver.ExecutionStatus, error)
 }
@@ -730,7 +727,7 @@ func waitForExecution(
 
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("실행 대기 시간 초과: %w", ctx.Err())
+			return nil, fmt.Errorf("%w: %w", errExecutionWaitTimeout, ctx.Err())
 		case <-ticker.C:
 		}
 	}
@@ -745,6 +742,29 @@ func isTerminalExecutionStatus(status string) bool {
 	}
 }
 
+// executionOutcomeError는 실행이 실패 상태(failed/rejected/cancelled)로 끝났음을 나타냅니다.
+// exec 명령은 Status로 종료 코드를 결정합니다.
+type executionOutcomeError struct {
+	ExecutionID string
+	Status      string
+	Message     string
+}
+
+func newExecutionOutcomeError(output executeOutput) *executionOutcomeError {
+	return &executionOutcomeError{
+		ExecutionID: output.ExecutionID,
+		Status:      output.Status,
+		Message:     output.Error,
+	}
+}
+
+func (e *executionOutcomeError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("execution %s finished with status %s: %s", e.ExecutionID, e.Status, e.Message)
+	}
+	return fmt.Sprintf("execution %s finished with status %s", e.ExecutionID, e.Status)
+}
+
 func isFailedExecutionStatus(status string) bool {
 	switch strings.ToLower(strings.TrimSpace(status)) {
 	case "failed", "rejected", "cancelled":
@@ -0,0 +1,66 @@
+// help_groups.go는 루트 도움말에서 명령어를 용도별 그룹으로 나누어 보여줍니다.
+package cmd
+
+import "github.com/spf13/cobra"
+
+// commandGroup은 루트 도움말의 명령어 그룹입니다.
+type commandGroup struct {
+	id       string
+	title    string
+	commands []string
+}
+
+// commandGroups는 루트 명령어의 그룹 배치입니다. 도움말은 이 순서대로 그룹을 출력합니다.
+// 새 최상위 명령어를 추가하면 여기에도 등록해야 합니다 (TestCommandGroups_AllCommandsGrouped).
+var commandGroups = []commandGroup{
+	{
+		id:       "bridge",
+		title:    "Bridge 관리:",
+		commands: []string{"up", "init-team", "setup", "login", "logout", "connect", "status", "config", "update", "version"},
+	},
+	{
+		id:       "tasks",
+		title:    "작업 실행:",
+		commands: []string{"exec", "execute", "chat", "execution", "history", "transcript", "task", "approval", "approval-chain", "autonomy"},
+	},
+	{
+		id:       "workspace",
+		title:    "워크스페이스와 에이전트:",
+		commands: []string{"workspace", "agent", "template", "skill", "tools", "knowledge", "attachment", "channel", "message", "label"},
+	},
+	{
+		id:       "planning",
+		title:    "프로젝트와 계획:",
+		commands: []string{"project", "issue", "sprint", "planning", "decision", "meeting", "content", "pipeline", "schedule", "automation", "rule", "report"},
+	},
+	{
+		id:       "monitoring",
+		title:    "모니터링:",
+		commands: []string{"dashboard", "logs", "metrics", "health", "observability", "anomaly"},
+	},
+	{
+		id:       "advanced",
+		title:    "진단과 고급 기능:",
+		commands: []string{"debug", "crash", "sandbox", "api", "completion"},
+	},
+}
+
+// applyCommandGroups는 root의 최상위 명령어를 commandGroups에 따라 그룹에 배치합니다.
+// 여러 번 호출해도 안전합니다.
+func applyCommandGroups(root *cobra.Command) {
+	groupOf := make(map[string]string)
+	for _, g := range commandGroups {
+		if !root.ContainsGroup(g.id) {
+			root.AddGroup(&cobra.Group{ID: g.id, Title: g.title})
+		}
+		for _, name := range g.commands {
+			groupOf[name] = g.id
+		}
+	}
+	for _, c := range root.Commands() {
+		if id, ok := groupOf[c.Name()]; ok && c.GroupID == "" {
+			c.GroupID = id
+		}
+	}
+	root.SetHelpCommandGroupID("advanced")
+}
@@ -14,13 +14,22 @@ import (
 // newAPIClient는 인증된 API 클라이언트를 생성합니다.
 // execute.go, status.go의 초기화 패턴을 공유합니다.
 func newAPIClient() (*apiclient.Client, error) {
+	backend, creds, tokenRefresher, err := newBackendClient()
+	if err != nil {
+		return nil, err
+	}
+	return apiclient.New(backend, creds, tokenRefresher), nil
+}
+
+// newBackendClient는 저장된 인증 정보로 BackendClient를 생성합니다.
+func newBackendClient() (*mcpserver.BackendClient, *auth.Credentials, *auth.TokenRefresher, error) {
 	// 저장된 인증 정보 로드
 	creds, err := auth.Load()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	if creds == nil {
-		return nil, errors.New("로그인이 필요합니다. 'autopus login'을 먼저 실행하세요")
+		return nil, nil, nil, errors.New("로그인이 필요합니다. 'autopus login'을 먼저 실행하세요")
 	}
 
 	// 서버 URL을 HTTP로 변환 (wss:// -> https://)
@@ -32,6 +41,5 @@ func newAPIClient() (*apiclient.Client, error) {
 	// TokenRefresher와 BackendClient 생성
 	tokenRefresher := auth.NewTokenRefresher(creds)
 	backend := mcpserver.NewBackendClient(baseURL, tokenRefresher, 60*time.Second, zerolog.Nop())
-
-	return apiclient.New(backend, creds, tokenRefresher), nil
+	return backend, creds, tokenRefresher, nil
 }
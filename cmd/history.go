@@ -0,0 +1,232 @@
+// history.go는 로컬 실행 이력 조회 명령어를 구현합니다.
+// 백엔드 연결 없이 이 Bridge가 실제로 실행한 작업을 확인할 수 있습니다.
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/apiclient"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/executor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyStatus string
+	historyAgent  string
+	historySince  string
+	historyLimit  int
+	historyJSON   bool
+)
+
+// historyStatuses는 --status로 지정할 수 있는 실행 상태입니다.
+var historyStatuses = []string{
+	executor.HistoryRunning,
+	executor.HistorySucceeded,
+	executor.HistoryFailed,
+	executor.HistoryCancelled,
+	executor.HistoryInterrupted,
+}
+
+// historyCmd는 최근 로컬 실행 목록을 출력합니다.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "로컬 실행 이력 조회",
+	Long: `이 Bridge가 로컬에서 실행한 최근 작업(실행 ID, 에이전트, 상태, 실행 시간, 시작 시각)을 출력합니다.
+
+이력은 connect 중 작업마다 기록되며 백엔드에 연결하지 않아도 조회할 수 있습니다.
+state_store가 활성화되어 있으면 상태 저장소에서, 아니면 ~/.config/autopus/history-<워크스페이스>.json에서 읽습니다.
+history.max_entries(기본값: 500)를 넘으면 오래된 기록부터 삭제됩니다.
+
+예시:
+  autopus-bridge history
+  autopus-bridge history --status failed --since 24h
+  autopus-bridge history --agent codex -n 50 --json
+  autopus-bridge history show exec-123`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := newHistoryFilter(historyStatus, historyAgent, historySince, historyLimit, time.Now())
+		if err != nil {
+			return err
+		}
+		history, closeHistory, err := openLocalHistory()
+		if err != nil {
+			return err
+		}
+		defer closeHistory()
+		return runHistoryList(cmd.OutOrStdout(), history, filter, historyJSON, time.Now())
+	},
+}
+
+// historyShowCmd는 실행 하나의 상세 기록을 출력합니다.
+var historyShowCmd = &cobra.Command{
+	Use:   "show <execution-id>",
+	Short: "로컬 실행 상세 조회",
+	Long: `실행 하나의 로컬 기록(모델, 작업 디렉토리, 프롬프트 앞부분, 에러, 토큰 사용량)을 출력합니다.
+프롬프트, 출력, 도구 호출 전체는 autopus-bridge transcript <execution-id>로 확인할 수 있습니다.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		history, closeHistory, err := openLocalHistory()
+		if err != nil {
+			return err
+		}
+		defer closeHistory()
+		return runHistoryShow(cmd.OutOrStdout(), history, args[0], historyJSON, time.Now())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyShowCmd)
+
+	historyCmd.Flags().StringVar(&historyStatus, "status", "", "상태 필터 ("+strings.Join(historyStatuses, ", ")+")")
+	historyCmd.Flags().StringVar(&historyAgent, "agent", "", "에이전트(프로바이더) 또는 모델 필터 (부분 일치)")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "조회 시작 시점 (예: 30m, 24h, 2026-01-02T15:04:05Z)")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "출력할 최근 실행 수 (0=전체)")
+	historyCmd.PersistentFlags().BoolVar(&historyJSON, "json", false, "JSON 형식으로 출력")
+}
+
+// newHistoryFilter는 명령 플래그로 실행 이력 조회 조건을 만듭니다.
+// since는 현재 시각 기준 기간(24h) 또는 RFC3339 시각입니다.
+func newHistoryFilter(status, agent, since string, limit int, now time.Time) (executor.HistoryFilter, error) {
+	filter := executor.HistoryFilter{Agent: agent, Limit: limit}
+	if status != "" {
+		status = strings.ToLower(status)
+		if !slices.Contains(historyStatuses, status) {
+			return filter, fmt.Errorf("유효하지 않은 --status 값: %s (%s)", status, strings.Join(historyStatuses, ", "))
+		}
+		filter.Status = status
+	}
+	if since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			filter.Since = now.Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+			filter.Since = t
+		} else {
+			return filter, fmt.Errorf("유효하지 않은 --since 값: %s (예: 30m, 24h, 2026-01-02T15:04:05Z)", since)
+		}
+	}
+	return filter, nil
+}
+
+// openLocalHistory는 현재 워크스페이스의 실행 이력을 조회용으로 엽니다.
+// 상태 저장소는 실행 중인 connect가 쓰고 있을 수 있으므로 읽기 전용으로 엽니다.
+func openLocalHistory() (*executor.HistoryStore, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("설정 로드 실패: %w", err)
+	}
+	workspaceID := resolveCurrentWorkspaceScopeID()
+	stateStore, err := openStateStoreReadOnly(cfg.StateStore, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("상태 저장소 열기 실패: %w", err)
+	}
+	if stateStore == nil {
+		history, err := executor.NewHistoryStore(getScopedHistoryFilePath(workspaceID), cfg.History.GetMaxEntries())
+		return history, func() {}, err
+	}
+	history, err := executor.NewStoreHistory(stateStore.Bucket(historyBucket), cfg.History.GetMaxEntries())
+	if err != nil {
+		_ = stateStore.Close()
+		return nil, nil, err
+	}
+	return history, func() { _ = stateStore.Close() }, nil
+}
+
+// runHistoryList는 조건에 맞는 실행 이력을 표 또는 JSON으로 출력합니다.
+func runHistoryList(out io.Writer, history *executor.HistoryStore, filter executor.HistoryFilter, jsonOutput bool, now time.Time) error {
+	entries := history.List(filter)
+	if jsonOutput {
+		if entries == nil {
+			entries = []executor.HistoryEntry{}
+		}
+		return apiclient.PrintJSON(out, entries)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "기록된 로컬 실행이 없습니다.")
+		return nil
+	}
+
+	headers := []string{"ID", "AGENT", "STATUS", "DURATION", "STARTED AT"}
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		rows[i] = []string{
+			entry.ExecutionID,
+			historyAgentLabel(entry),
+			entry.Status,
+			formatHistoryDuration(entry.Duration(now)),
+			entry.StartedAt.Local().Format("2006-01-02 15:04:05"),
+		}
+	}
+	apiclient.PrintTable(out, headers, rows)
+	return nil
+}
+
+// runHistoryShow는 실행 하나의 상세 기록을 출력합니다.
+func runHistoryShow(out io.Writer, history *executor.HistoryStore, executionID string, jsonOutput bool, now time.Time) error {
+	entry, ok := history.Get(executionID)
+	if !ok {
+		return fmt.Errorf("실행 %s의 로컬 기록이 없습니다", executionID)
+	}
+	if jsonOutput {
+		return apiclient.PrintJSON(out, entry)
+	}
+
+	finishedAt := "-"
+	if entry.FinishedAt != nil {
+		finishedAt = entry.FinishedAt.Local().Format(time.RFC3339)
+	}
+	details := []apiclient.KeyValue{
+		{Key: "ID", Value: entry.ExecutionID},
+		{Key: "Kind", Value: entry.Kind},
+		{Key: "Agent", Value: entry.Agent},
+		{Key: "Model", Value: entry.Model},
+		{Key: "Status", Value: entry.Status},
+		{Key: "StartedAt", Value: entry.StartedAt.Local().Format(time.RFC3339)},
+		{Key: "FinishedAt", Value: finishedAt},
+		{Key: "Duration", Value: formatHistoryDuration(entry.Duration(now))},
+		{Key: "WorkDir", Value: entry.WorkDir},
+		{Key: "ExitCode", Value: strconv.Itoa(entry.ExitCode)},
+	}
+	if entry.InputTokens > 0 || entry.OutputTokens > 0 {
+		details = append(details, apiclient.KeyValue{Key: "Tokens", Value: fmt.Sprintf("%d in / %d out", entry.InputTokens, entry.OutputTokens)})
+	}
+	if entry.Error != "" {
+		errText := entry.Error
+		if entry.ErrorCode != "" {
+			errText = entry.ErrorCode + ": " + errText
+		}
+		details = append(details, apiclient.KeyValue{Key: "Error", Value: errText})
+	}
+	details = append(details, apiclient.KeyValue{Key: "Prompt", Value: entry.Prompt})
+	apiclient.PrintDetail(out, details)
+	fmt.Fprintf(out, "\n전체 트랜스크립트: autopus-bridge transcript %s\n", entry.ExecutionID)
+	return nil
+}
+
+// historyAgentLabel은 목록에 표시할 에이전트 이름입니다. 모델이 있으면 함께 표시합니다.
+func historyAgentLabel(entry executor.HistoryEntry) string {
+	switch {
+	case entry.Agent != "" && entry.Model != "":
+		return entry.Agent + "/" + entry.Model
+	case entry.Agent != "":
+		return entry.Agent
+	case entry.Model != "":
+		return entry.Model
+	default:
+		return "-"
+	}
+}
+
+// formatHistoryDuration은 실행 시간을 초 단위로 반올림하여 출력합니다 (1초 미만은 밀리초).
+func formatHistoryDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/executor"
+)
+
+func newTestHistory(t *testing.T, now time.Time) *executor.HistoryStore {
+	t.Helper()
+	h, err := executor.NewHistoryStore(filepath.Join(t.TempDir(), "history.json"), 0)
+	if err != nil {
+		t.Fatalf("NewHistoryStore() error = %v", err)
+	}
+	_ = h.Start(executor.HistoryEntry{ExecutionID: "exec-1", Kind: executor.HistoryKindTask, Agent: "claude", Model: "claude-sonnet-4", Prompt: "Fix the build", StartedAt: now.Add(-time.Hour)})
+	_ = h.Finish("exec-1", now.Add(-time.Hour+90*time.Second), func(e *executor.HistoryEntry) {
+		e.Status = executor.HistoryFailed
+		e.ErrorCode = "PROVIDER_ERROR"
+		e.Error = "claude exited"
+	})
+	_ = h.Start(executor.HistoryEntry{ExecutionID: "exec-2", Kind: executor.HistoryKindTask, Agent: "codex", StartedAt: now.Add(-10 * time.Second)})
+	return h
+}
+
+func TestNewHistoryFilter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	f, err := newHistoryFilter("FAILED", "codex", "2h", 5, now)
+	if err != nil {
+		t.Fatalf("newHistoryFilter() error = %v", err)
+	}
+	if f.Status != executor.HistoryFailed || f.Agent != "codex" || !f.Since.Equal(now.Add(-2*time.Hour)) || f.Limit != 5 {
+		t.Errorf("filter = %+v", f)
+	}
+	if _, err := newHistoryFilter("done", "", "", 0, now); err == nil {
+		t.Error("알 수 없는 상태는 에러여야 합니다")
+	}
+	if _, err := newHistoryFilter("", "", "yesterday", 0, now); err == nil {
+		t.Error("유효하지 않은 --since는 에러여야 합니다")
+	}
+}
+
+func TestRunHistoryList(t *testing.T) {
+	now := time.Now()
+	h := newTestHistory(t, now)
+
+	var out bytes.Buffer
+	if err := runHistoryList(&out, h, executor.HistoryFilter{}, false, now); err != nil {
+		t.Fatalf("runHistoryList() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") {
+		t.Fatalf("출력 =\n%s", out.String())
+	}
+	if !strings.Contains(lines[1], "exec-2") || !strings.Contains(lines[1], "running") || !strings.Contains(lines[1], "10s") {
+		t.Errorf("실행 중인 작업 줄 = %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "claude/claude-sonnet-4") || !strings.Contains(lines[2], "failed") || !strings.Contains(lines[2], "1m30s") {
+		t.Errorf("실패한 작업 줄 = %q", lines[2])
+	}
+
+	out.Reset()
+	if err := runHistoryList(&out, h, executor.HistoryFilter{Status: executor.HistorySucceeded}, true, now); err != nil {
+		t.Fatalf("runHistoryList(json) error = %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("빈 JSON 목록 기대: %s", out.String())
+	}
+}
+
+func TestRunHistoryShow(t *testing.T) {
+	now := time.Now()
+	h := newTestHistory(t, now)
+
+	var out bytes.Buffer
+	if err := runHistoryShow(&out, h, "exec-1", false, now); err != nil {
+		t.Fatalf("runHistoryShow() error = %v", err)
+	}
+	for _, want := range []string{"claude-sonnet-4", "PROVIDER_ERROR: claude exited", "Fix the build", "autopus-bridge transcript exec-1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("출력에 %q가 없습니다:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runHistoryShow(&out, h, "exec-1", true, now); err != nil {
+		t.Fatalf("runHistoryShow(json) error = %v", err)
+	}
+	var entry executor.HistoryEntry
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil || entry.DurationMs != 90000 {
+		t.Errorf("JSON 출력 = %s (%v)", out.String(), err)
+	}
+
+	if err := runHistoryShow(&out, h, "missing", false, now); err == nil {
+		t.Error("없는 실행은 에러여야 합니다")
+	}
+}
@@ -0,0 +1,303 @@
+// init_team.go는 팀 설정 번들을 만드는 init-team 명령과 up --team-config 가져오기를 구현합니다.
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/insajin/autopus-bridge/internal/teamconfig"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// cliVersionTimeout은 프로바이더 CLI 버전 확인 타임아웃입니다.
+const cliVersionTimeout = 5 * time.Second
+
+var (
+	initTeamName   string
+	initTeamOutput string
+	initTeamKey    string
+	initTeamYes    bool
+)
+
+// initTeamCmd는 팀 설정 번들 생성 마법사입니다.
+var initTeamCmd = &cobra.Command{
+	Use:   "init-team",
+	Short: "팀원과 공유할 서명된 팀 설정 번들을 만듭니다",
+	Long: `현재 Bridge 설정에서 팀이 공유할 항목을 골라 서명된 팀 설정 파일을 만듭니다.
+
+포함할 수 있는 항목:
+  - 고정 도구 버전 (감지된 AI CLI와 비즈니스 도구)
+  - 보안 정책 (security.sandbox, action_approval, provider_sandbox, redaction)
+  - 작업 템플릿 (templates, 템플릿 디렉토리)
+  - MCP 도구 권한 (mcp_server.tools)
+
+번들은 ed25519 키(기본: ~/.config/autopus/team-signing.key)로 서명됩니다.
+팀원은 autopus-bridge up --team-config <파일>로 가져옵니다.
+처음 가져온 번들의 서명 키 지문을 기억하고, 이후 다른 키로 서명된 번들은 거부합니다.`,
+	Example: `  autopus-bridge init-team
+  autopus-bridge init-team --name platform --output team.json --yes
+  autopus-bridge up --team-config team.json --team-key <지문>`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		doc, _, err := readConfigDocument(configFilePath())
+		if err != nil {
+			return err
+		}
+		registry, err := loadTaskTemplates()
+		if err != nil {
+			return err
+		}
+		var perms mcpserver.ToolPermissions
+		if err := viper.UnmarshalKey("mcp_server.tools", &perms); err != nil {
+			return fmt.Errorf("mcp_server.tools 설정 파싱 실패: %w", err)
+		}
+
+		var createdBy string
+		if creds, err := auth.Load(); err == nil && creds != nil {
+			createdBy = creds.UserEmail
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), "도구 버전 감지 중...")
+		return runInitTeam(cmd.OutOrStdout(), bufio.NewScanner(os.Stdin), initTeamOptions{
+			name:   initTeamName,
+			output: initTeamOutput,
+			key:    initTeamKey,
+			yes:    initTeamYes,
+		}, teamBundleSources{
+			doc:          doc,
+			toolVersions: installedToolVersions(detectProviders(), detectBusinessTools()),
+			templates:    registry.List(),
+			permissions:  perms,
+			createdBy:    createdBy,
+		})
+	},
+}
+
+var (
+	upTeamConfig string
+	upTeamKey    string
+)
+
+func init() {
+	rootCmd.AddCommand(initTeamCmd)
+
+	initTeamCmd.Flags().StringVar(&initTeamName, "name", "", "팀 이름 (지정하지 않으면 입력을 요청합니다)")
+	initTeamCmd.Flags().StringVarP(&initTeamOutput, "output", "o", "autopus-team.json", "팀 설정 파일 저장 경로")
+	initTeamCmd.Flags().StringVar(&initTeamKey, "key", "", "서명 키 파일 경로 (기본: ~/.config/autopus/team-signing.key, 없으면 생성)")
+	initTeamCmd.Flags().BoolVarP(&initTeamYes, "yes", "y", false, "확인 없이 감지된 모든 항목을 포함합니다")
+
+	upCmd.Flags().StringVar(&upTeamConfig, "team-config", "", "init-team으로 만든 팀 설정 파일을 검증하고 설정에 반영합니다")
+	upCmd.Flags().StringVar(&upTeamKey, "team-key", "", "신뢰할 팀 설정 서명 키 지문 (기본: 이전에 가져온 키)")
+}
+
+// initTeamOptions는 init-team 명령 옵션입니다.
+type initTeamOptions struct {
+	name   string
+	output string
+	key    string
+	yes    bool
+}
+
+// teamBundleSources는 번들에 담을 수 있는 현재 환경의 값입니다.
+type teamBundleSources struct {
+	// doc은 현재 설정 문서입니다 (정책 섹션을 읽음).
+	doc map[string]any
+	// toolVersions는 설치된 도구 버전입니다.
+	toolVersions map[string]string
+	// templates는 로드된 작업 템플릿입니다.
+	templates []tasktemplate.Template
+	// permissions는 MCP 도구 권한입니다.
+	permissions mcpserver.ToolPermissions
+	// createdBy는 번들을 만든 사용자입니다.
+	createdBy string
+}
+
+// runInitTeam은 포함할 항목을 확인받아 팀 설정 번들을 만들고 서명하여 저장합니다.
+func runInitTeam(out io.Writer, scanner *bufio.Scanner, opts initTeamOptions, src teamBundleSources) error {
+	name := strings.TrimSpace(opts.name)
+	if name == "" && !opts.yes {
+		fmt.Fprint(out, "팀 이름: ")
+		if scanner.Scan() {
+			name = strings.TrimSpace(scanner.Text())
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("팀 이름이 필요합니다 (--name)")
+	}
+
+	confirm := func(question string) bool {
+		if opts.yes {
+			return true
+		}
+		fmt.Fprintf(out, "%s [Y/n]: ", question)
+		return scanYesNoDefault(scanner, true)
+	}
+
+	bundle := teamconfig.Bundle{
+		Format:    teamconfig.Format,
+		Team:      name,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: src.createdBy,
+	}
+
+	if len(src.toolVersions) > 0 {
+		fmt.Fprintln(out, "\n감지된 도구 버전:")
+		for _, tool := range sortedStringKeys(src.toolVersions) {
+			fmt.Fprintf(out, "  %-12s %s\n", tool, src.toolVersions[tool])
+		}
+		if confirm("이 버전을 팀 버전으로 고정하시겠습니까?") {
+			bundle.ToolVersions = src.toolVersions
+		}
+	}
+
+	policy := make(map[string]any)
+	for _, key := range teamconfig.PolicyKeys {
+		if value, ok := config.GetDocumentValue(src.doc, key); ok && value != nil {
+			policy[key] = value
+		}
+	}
+	if len(policy) > 0 {
+		fmt.Fprintf(out, "\n보안 정책: %s\n", strings.Join(sortedStringKeys(policy), ", "))
+		if confirm("보안 정책을 포함하시겠습니까?") {
+			bundle.Policy = policy
+		}
+	}
+
+	if len(src.templates) > 0 {
+		names := make([]string, 0, len(src.templates))
+		for _, t := range src.templates {
+			names = append(names, t.Name)
+		}
+		fmt.Fprintf(out, "\n작업 템플릿: %s\n", strings.Join(names, ", "))
+		if confirm("작업 템플릿을 포함하시겠습니까?") {
+			bundle.Templates = src.templates
+		}
+	}
+
+	if len(src.permissions) > 0 {
+		fmt.Fprintf(out, "\nMCP 도구 권한: %s\n", strings.Join(sortedStringKeys(src.permissions), ", "))
+		if confirm("MCP 도구 권한을 포함하시겠습니까?") {
+			bundle.MCPToolPermissions = src.permissions
+		}
+	}
+
+	keyPath := opts.key
+	if keyPath == "" {
+		keyPath = teamconfig.DefaultKeyPath()
+	}
+	key, created, err := teamconfig.LoadOrCreateKey(keyPath)
+	if err != nil {
+		return err
+	}
+	data, err := teamconfig.Sign(bundle, key)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(opts.output, data, 0644); err != nil {
+		return fmt.Errorf("팀 설정 파일 저장 실패: %w", err)
+	}
+
+	fingerprint := teamconfig.Fingerprint(key.Public().(ed25519.PublicKey))
+	fmt.Fprintln(out)
+	if created {
+		fmt.Fprintf(out, "서명 키를 만들었습니다: %s (안전하게 보관하세요)\n", keyPath)
+	}
+	fmt.Fprintf(out, "팀 설정 저장: %s\n", opts.output)
+	fmt.Fprintf(out, "서명 키 지문: %s\n", fingerprint)
+	fmt.Fprintln(out, "\n팀원은 다음 명령으로 가져옵니다:")
+	fmt.Fprintf(out, "  autopus-bridge up --team-config %s --team-key %s\n", opts.output, fingerprint)
+	return nil
+}
+
+// loadTeamBundle은 팀 설정 파일을 읽고 서명을 검증합니다.
+// pinned가 비어 있으면 이전에 가져온 번들의 서명 키 지문(team.key_fingerprint)을 신뢰합니다.
+func loadTeamBundle(path, pinned string) (*teamconfig.Bundle, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("팀 설정 파일 읽기 실패: %w", err)
+	}
+	if pinned == "" {
+		pinned = viper.GetString("team.key_fingerprint")
+	}
+	return teamconfig.Verify(data, pinned)
+}
+
+// applyTeamBundle은 검증된 번들을 설정 파일에 반영하고 설정을 다시 읽습니다.
+// 고정 버전과 다른 도구는 경고로 출력합니다.
+func applyTeamBundle(out io.Writer, configPath string, bundle *teamconfig.Bundle, fingerprint string, installed map[string]string) error {
+	doc, _, err := readConfigDocument(configPath)
+	if err != nil {
+		return err
+	}
+	changes, err := bundle.Apply(doc, fingerprint)
+	if err != nil {
+		return err
+	}
+	if err := writeConfigDocument(configPath, doc); err != nil {
+		return err
+	}
+
+	viper.SetConfigFile(configPath)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("설정 파일 재로드 실패: %w", err)
+	}
+
+	fmt.Fprintf(out, "  ✓ 팀 설정 반영 (%s): %s\n", bundle.Team, strings.Join(changes, ", "))
+	for _, mismatch := range bundle.VersionMismatches(installed) {
+		fmt.Fprintf(out, "  ! 팀 버전과 다른 도구: %s\n", mismatch)
+	}
+	return nil
+}
+
+// installedToolVersions는 감지된 프로바이더 CLI와 비즈니스 도구의 버전을 이름별로 모읍니다.
+// 프로바이더 이름은 소문자(claude, gemini, codex)를 사용합니다.
+func installedToolVersions(providers []providerInfo, tools []businessTool) map[string]string {
+	versions := make(map[string]string)
+	for _, p := range providers {
+		if p.CLIPath == "" {
+			continue
+		}
+		if version := providerCLIVersion(p.CLIPath); version != "" {
+			versions[strings.ToLower(p.Name)] = version
+		}
+	}
+	for _, t := range tools {
+		if t.Installed && t.Version != "" {
+			versions[t.Name] = t.Version
+		}
+	}
+	return versions
+}
+
+// providerCLIVersion은 CLI의 --version 출력 첫 줄을 반환합니다. 확인하지 못하면 빈 문자열입니다.
+func providerCLIVersion(path string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), cliVersionTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.Split(string(out), "\n")[0])
+}
+
+// sortedStringKeys는 맵의 키를 정렬하여 반환합니다.
+func sortedStringKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
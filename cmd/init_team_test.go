@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/insajin/autopus-bridge/internal/teamconfig"
+	"github.com/spf13/viper"
+)
+
+func TestInitTeam_ExportAndImport(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "team.json")
+
+	src := teamBundleSources{
+		doc: map[string]any{
+			"security": map[string]any{
+				"action_approval": map[string]any{"enabled": true},
+			},
+		},
+		toolVersions: map[string]string{"claude": "2.1.0", "jq": "jq-1.7"},
+		templates:    []tasktemplate.Template{{Name: "review", Prompt: "Review {{branch}}"}},
+		permissions:  mcpserver.ToolPermissions{"manage_workspace": {ReadOnly: true}},
+	}
+	// 팀 이름을 입력하고, 도구 버전은 고정하지 않고, 나머지는 기본값(예)으로 포함한다.
+	input := "platform\nn\n\n\n\n"
+	var out bytes.Buffer
+	err := runInitTeam(&out, bufio.NewScanner(strings.NewReader(input)), initTeamOptions{
+		output: bundlePath,
+		key:    filepath.Join(dir, "team.key"),
+	}, src)
+	if err != nil {
+		t.Fatalf("runInitTeam 실패: %v", err)
+	}
+	if !strings.Contains(out.String(), "--team-config "+bundlePath) {
+		t.Errorf("가져오기 안내가 없습니다:\n%s", out.String())
+	}
+
+	bundle, fingerprint, err := loadTeamBundle(bundlePath, "")
+	if err != nil {
+		t.Fatalf("loadTeamBundle 실패: %v", err)
+	}
+	if bundle.Team != "platform" || len(bundle.ToolVersions) != 0 || len(bundle.Templates) != 1 || bundle.Policy == nil {
+		t.Errorf("번들 = %+v", bundle)
+	}
+
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("server:\n  ws_url: wss://example.com/ws/agent\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := applyTeamBundle(&out, configPath, bundle, fingerprint, nil); err != nil {
+		t.Fatalf("applyTeamBundle 실패: %v", err)
+	}
+	if viper.GetString("team.name") != "platform" || viper.GetString("team.key_fingerprint") != fingerprint {
+		t.Errorf("team 섹션이 반영되지 않았습니다: %v", viper.Get("team"))
+	}
+	if !viper.GetBool("security.action_approval.enabled") || !viper.GetBool("mcp_server.tools.manage_workspace.read_only") {
+		t.Error("정책과 MCP 도구 권한이 반영되지 않았습니다")
+	}
+	if viper.GetString("server.ws_url") != "wss://example.com/ws/agent" {
+		t.Error("기존 설정은 유지해야 합니다")
+	}
+
+	// 기억한 키와 다른 키로 서명된 번들은 거부한다.
+	other := filepath.Join(dir, "other.json")
+	if err := runInitTeam(&bytes.Buffer{}, bufio.NewScanner(strings.NewReader("")), initTeamOptions{
+		name: "platform", output: other, key: filepath.Join(dir, "other.key"), yes: true,
+	}, src); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loadTeamBundle(other, ""); !errors.Is(err, teamconfig.ErrKeyMismatch) {
+		t.Errorf("다른 키의 번들은 거부해야 합니다: %v", err)
+	}
+}
+
+func TestInitTeam_RequiresName(t *testing.T) {
+	err := runInitTeam(&bytes.Buffer{}, bufio.NewScanner(strings.NewReader("")), initTeamOptions{
+		output: filepath.Join(t.TempDir(), "team.json"),
+		yes:    true,
+	}, teamBundleSources{})
+	if err == nil || !strings.Contains(err.Error(), "--name") {
+		t.Errorf("팀 이름이 없으면 에러여야 합니다: %v", err)
+	}
+}
@@ -35,7 +35,9 @@ var loginCmd = &cobra.Command{
 
 Device Code Flow를 사용하여 브라우저에서 인증합니다.
 토큰은 ~/.config/autopus/credentials.json에 저장됩니다.
-이후 'lab connect' 명령 시 저장된 토큰이 자동으로 사용됩니다.`,
+이후 'lab connect' 명령 시 저장된 토큰이 자동으로 사용됩니다.
+
+--sso <workspace> 또는 auth.sso.workspace 설정 시 워크스페이스 IdP(OIDC)로 SSO 로그인합니다.`,
 	RunE: runLogin,
 }
 
@@ -68,6 +70,9 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if ssoCfg, ok := resolveSSOLogin(); ok {
+		return performSSOLogin(cmd, ssoCfg)
+	}
 	return performDeviceCodeLogin(cmd)
 }
 
@@ -123,23 +128,15 @@ func performDeviceCodeLogin(cmd *cobra.Command) error {
 		return fmt.Errorf("인증 실패: %w", err)
 	}
 
-	// Step 6: 인증 정보 저장
-	tokenExpiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-	if jwtExpiry, parseErr := auth.ParseJWTExpiry(tokenResp.AccessToken); parseErr == nil {
-		tokenExpiresAt = jwtExpiry
-	}
+	return completeLogin(cmd, tokenResp)
+}
 
-	creds := &auth.Credentials{
-		AccessToken:   tokenResp.AccessToken,
-		RefreshToken:  tokenResp.RefreshToken,
-		ExpiresAt:     tokenExpiresAt,
-		ServerURL:     getServerURL(),
-		UserEmail:     tokenResp.UserEmail,
-		WorkspaceID:   tokenResp.WorkspaceID,
-		WorkspaceSlug: tokenResp.WorkspaceSlug,
-	}
+// completeLogin은 발급받은 토큰으로 인증 정보를 저장하고 서버에 자동 연결합니다 (Device Code/SSO 공통).
+func completeLogin(cmd *cobra.Command, tokenResp *auth.DeviceTokenResponse) error {
+	// 인증 정보 저장
+	creds := tokenResp.NewCredentials(getServerURL())
 
-	// Step 7: 워크스페이스 선택
+	// 워크스페이스 선택
 	if err := selectWorkspace(creds, tokenResp); err != nil {
 		logger.Warn().Err(err).Msg("워크스페이스 선택 실패")
 	}
@@ -293,7 +290,7 @@ func isTTY() bool {
 
 // getBaseURL returns the frontend base URL for constructing login URLs.
 func getBaseURL() string {
-	serverURL := viper.GetString("server.url")
+	serverURL := viper.GetString("server.ws_url")
 	if strings.Contains(serverURL, "localhost") || strings.Contains(serverURL, "127.0.0.1") {
 		return "http://localhost:3000"
 	}
@@ -302,7 +299,7 @@ func getBaseURL() string {
 
 // getAPIBaseURL returns the backend API base URL (without path)
 func getAPIBaseURL() string {
-	serverURL := viper.GetString("server.url")
+	serverURL := viper.GetString("server.ws_url")
 	if strings.Contains(serverURL, "localhost") || strings.Contains(serverURL, "127.0.0.1") {
 		return "http://127.0.0.1:8080"
 	}
@@ -311,7 +308,7 @@ func getAPIBaseURL() string {
 
 // getServerURL returns the WebSocket server URL
 func getServerURL() string {
-	serverURL := viper.GetString("server.url")
+	serverURL := viper.GetString("server.ws_url")
 	if serverURL != "" {
 		return serverURL
 	}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// SSO 인증 방식
+const (
+	ssoFlowAuto    = "auto"
+	ssoFlowBrowser = "browser"
+	ssoFlowDevice  = "device"
+)
+
+// SSO 로그인 플래그
+var (
+	loginSSOWorkspace string
+	loginSSOFlow      string
+)
+
+func init() {
+	loginCmd.Flags().StringVar(&loginSSOWorkspace, "sso", "", "워크스페이스 IdP(OIDC)로 SSO 로그인 (워크스페이스 slug)")
+	loginCmd.Flags().StringVar(&loginSSOFlow, "sso-flow", "", "SSO 인증 방식: auto, browser(PKCE), device (기본: auth.sso.flow)")
+}
+
+// resolveSSOLogin은 --sso/--sso-flow 플래그와 auth.sso 설정을 합쳐 SSO 로그인 설정을 반환합니다.
+// 워크스페이스가 지정되지 않았으면 false를 반환하며, 이 경우 기본 Device Code 로그인을 사용합니다.
+func resolveSSOLogin() (config.SSOConfig, bool) {
+	var ssoCfg config.SSOConfig
+	if cfg, err := config.Load(); err == nil {
+		ssoCfg = cfg.Auth.SSO
+	}
+	if loginSSOWorkspace != "" {
+		ssoCfg.Workspace = loginSSOWorkspace
+	}
+	if loginSSOFlow != "" {
+		ssoCfg.Flow = loginSSOFlow
+	}
+	return ssoCfg, strings.TrimSpace(ssoCfg.Workspace) != ""
+}
+
+// performSSOLogin은 워크스페이스 IdP(OIDC)로 인증한 뒤 IdP 토큰을 Autopus 인증 정보로 교환합니다.
+func performSSOLogin(cmd *cobra.Command, ssoCfg config.SSOConfig) error {
+	if loginSSOFlow != "" && ssoCfg.GetFlow() != loginSSOFlow {
+		return fmt.Errorf("알 수 없는 SSO 인증 방식: %s (auto, browser, device 중 하나)", loginSSOFlow)
+	}
+
+	apiBaseURL := getAPIBaseURL()
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+
+	// Step 1: 워크스페이스 IdP 설정 확인 (로컬 설정이 있으면 우선)
+	idp, err := resolveIdPConfig(ctx, apiBaseURL, ssoCfg)
+	if err != nil {
+		return err
+	}
+
+	// Step 2: OIDC issuer discovery
+	fmt.Printf("워크스페이스 '%s'의 SSO 로그인을 시작합니다...\n", idp.Workspace)
+	fmt.Printf("  IdP: %s\n\n", idp.Issuer)
+
+	meta, err := auth.DiscoverOIDC(ctx, idp.Issuer)
+	if err != nil {
+		return fmt.Errorf("IdP 설정 조회 실패: %w", err)
+	}
+
+	// Step 3: IdP 인증
+	flow, err := chooseSSOFlow(ssoCfg.GetFlow(), meta, canOpenLocalBrowser())
+	if err != nil {
+		return err
+	}
+
+	var idpToken *auth.OIDCTokenResponse
+	if flow == ssoFlowDevice {
+		idpToken, err = authorizeSSODevice(ctx, meta, idp)
+	} else {
+		idpToken, err = auth.AuthorizeOIDCBrowser(ctx, meta, idp, openBrowser, func(authURL string) {
+			fmt.Println("  브라우저에서 IdP 로그인을 완료하세요.")
+			fmt.Printf("  브라우저가 열리지 않으면 다음 URL을 직접 여세요:\n  %s\n\n", authURL)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("SSO 인증 실패: %w", err)
+	}
+
+	// Step 4: IdP 토큰을 Autopus 토큰으로 교환
+	tokenResp, err := auth.ExchangeSSOToken(ctx, apiBaseURL, idp, idpToken)
+	if err != nil {
+		return fmt.Errorf("SSO 토큰 교환 실패: %w", err)
+	}
+
+	return completeLogin(cmd, tokenResp)
+}
+
+// resolveIdPConfig는 로컬 auth.sso 설정에 issuer와 client_id가 모두 있으면 이를 사용하고,
+// 아니면 서버에 등록된 워크스페이스 SSO 설정을 조회합니다.
+func resolveIdPConfig(ctx context.Context, apiBaseURL string, ssoCfg config.SSOConfig) (*auth.SSOConfig, error) {
+	if ssoCfg.Issuer != "" && ssoCfg.ClientID != "" {
+		return &auth.SSOConfig{
+			Workspace: ssoCfg.Workspace,
+			Issuer:    ssoCfg.Issuer,
+			ClientID:  ssoCfg.ClientID,
+			Scopes:    ssoCfg.Scopes,
+			Audience:  ssoCfg.Audience,
+		}, nil
+	}
+
+	idp, err := auth.FetchSSOConfig(ctx, apiBaseURL, ssoCfg.Workspace)
+	if err != nil {
+		return nil, fmt.Errorf("워크스페이스 SSO 설정 조회 실패: %w", err)
+	}
+	if len(ssoCfg.Scopes) > 0 {
+		idp.Scopes = ssoCfg.Scopes
+	}
+	if ssoCfg.Audience != "" {
+		idp.Audience = ssoCfg.Audience
+	}
+	return idp, nil
+}
+
+// chooseSSOFlow는 설정된 방식과 IdP 지원 여부로 실제 인증 방식을 결정합니다.
+// auto는 로컬 브라우저를 열 수 있으면 browser, 아니면 device를 사용합니다.
+func chooseSSOFlow(flow string, meta *auth.OIDCProviderMetadata, browserAvailable bool) (string, error) {
+	switch flow {
+	case ssoFlowBrowser:
+		return ssoFlowBrowser, nil
+	case ssoFlowDevice:
+		if !meta.SupportsDeviceFlow() {
+			return "", fmt.Errorf("IdP가 디바이스 인증(device authorization grant)을 지원하지 않습니다")
+		}
+		return ssoFlowDevice, nil
+	}
+
+	if browserAvailable || !meta.SupportsDeviceFlow() {
+		return ssoFlowBrowser, nil
+	}
+	return ssoFlowDevice, nil
+}
+
+// canOpenLocalBrowser는 로컬 브라우저로 loopback 콜백을 받을 수 있는 환경인지 확인합니다.
+// SSH 세션이나 비대화형 환경에서는 브라우저 콜백을 받을 수 없으므로 false를 반환합니다.
+func canOpenLocalBrowser() bool {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return false
+	}
+	return isTTY()
+}
+
+// authorizeSSODevice는 IdP의 device authorization grant로 인증합니다.
+func authorizeSSODevice(ctx context.Context, meta *auth.OIDCProviderMetadata, idp *auth.SSOConfig) (*auth.OIDCTokenResponse, error) {
+	deviceResp, err := auth.RequestOIDCDeviceCode(ctx, meta, idp)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("  인증 코드: %s\n", deviceResp.UserCode)
+	fmt.Println()
+	fmt.Printf("  다음 URL에서 위 코드를 입력하세요:\n")
+	fmt.Printf("  %s\n", deviceResp.VerificationURI)
+	fmt.Println()
+
+	if deviceResp.VerificationURIComplete != "" && canOpenLocalBrowser() {
+		if browserErr := openBrowser(deviceResp.VerificationURIComplete); browserErr != nil {
+			logger.Warn().Err(browserErr).Msg("브라우저 자동 열기 실패")
+		}
+	}
+
+	expiresAt := time.Now().Add(time.Duration(deviceResp.ExpiresIn) * time.Second)
+	stopSpinner := make(chan struct{})
+	go spinnerLoop(stopSpinner, expiresAt)
+
+	tok, err := auth.PollOIDCDeviceToken(ctx, meta, idp, deviceResp.DeviceCode, deviceResp.Interval)
+	close(stopSpinner)
+	fmt.Printf("\r%s\r", strings.Repeat(" ", 50))
+	return tok, err
+}
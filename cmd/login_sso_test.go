@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/auth"
+)
+
+func TestChooseSSOFlow(t *testing.T) {
+	withDevice := &auth.OIDCProviderMetadata{DeviceAuthorizationEndpoint: "https://idp.example.com/device"}
+	noDevice := &auth.OIDCProviderMetadata{}
+
+	tests := []struct {
+		name    string
+		flow    string
+		meta    *auth.OIDCProviderMetadata
+		browser bool
+		want    string
+		wantErr bool
+	}{
+		{"auto, 브라우저 사용 가능", ssoFlowAuto, withDevice, true, ssoFlowBrowser, false},
+		{"auto, 헤드리스", ssoFlowAuto, withDevice, false, ssoFlowDevice, false},
+		{"auto, 헤드리스지만 디바이스 미지원", ssoFlowAuto, noDevice, false, ssoFlowBrowser, false},
+		{"device 강제", ssoFlowDevice, withDevice, true, ssoFlowDevice, false},
+		{"device 미지원", ssoFlowDevice, noDevice, true, "", true},
+		{"browser 강제", ssoFlowBrowser, withDevice, false, ssoFlowBrowser, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := chooseSSOFlow(tt.flow, tt.meta, tt.browser)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("chooseSSOFlow() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("chooseSSOFlow() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -54,7 +54,11 @@ var logsCmd = &cobra.Command{
   autopus logs
   autopus logs --agent my-agent
   autopus logs --type metric_update
-  autopus logs --tail 20`,
+  autopus logs --tail 20
+
+브리지 로컬 로그 파일은 하위 명령어로 조회합니다:
+  autopus logs local   - 로그 파일 필터링/실시간 추적
+  autopus logs bundle  - 지원 요청용 로그 아카이브 저장`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := newAPIClient()
 		if err != nil {
@@ -0,0 +1,489 @@
+// logs_local.go는 브리지 로컬 로그 파일 조회 명령어를 구현합니다.
+// logs local(필터링/실시간 추적), logs bundle(지원 요청용 로그 아카이브) 서브커맨드 제공
+package cmd
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/crash"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// logFollowInterval은 --follow 모드에서 로그 파일 추가분을 확인하는 간격입니다.
+const logFollowInterval = 500 * time.Millisecond
+
+// logLineMaxSize는 로그 한 줄의 최대 크기입니다 (긴 프롬프트/출력이 포함된 줄 대비).
+const logLineMaxSize = 4 * 1024 * 1024
+
+var (
+	logsLocalFile      string
+	logsLocalComponent string
+	logsLocalLevel     string
+	logsLocalSince     string
+	logsLocalExecID    string
+	logsLocalJSON      bool
+	logsLocalFollow    bool
+	logsLocalLines     int
+
+	logsBundleOutput string
+	logsBundleSince  string
+)
+
+// logsLocalCmd는 브리지 로그 파일을 필터링하여 출력합니다.
+var logsLocalCmd = &cobra.Command{
+	Use:   "local",
+	Short: "브리지 로컬 로그 파일 조회",
+	Long: `브리지의 구조화된 로그 파일(logging.file)을 필터링하여 출력합니다.
+
+로그 파일은 JSON 포맷(logging.format: json)이어야 필터가 적용됩니다.
+필터를 지정하면 JSON으로 파싱할 수 없는 줄은 제외됩니다.
+
+예시:
+  autopus-bridge logs local
+  autopus-bridge logs local --component websocket --level warn --since 1h
+  autopus-bridge logs local --exec-id exec-123 --json
+  autopus-bridge logs local -f --level error`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := resolveLogFile(logsLocalFile)
+		if err != nil {
+			return err
+		}
+		filter, err := newLogFilter(logsLocalComponent, logsLocalLevel, logsLocalSince, logsLocalExecID, time.Now())
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		defer cancel()
+		return runLogsLocal(ctx, cmd.OutOrStdout(), path, filter, logsLocalLines, logsLocalJSON, logsLocalFollow)
+	},
+}
+
+// logsBundleCmd는 최근 로그를 지원 요청용 아카이브로 묶습니다.
+var logsBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "최근 로그를 지원 요청용 아카이브로 저장",
+	Long: `최근 로그, 민감 정보를 제거한 설정, 버전 정보를 zip 아카이브로 저장합니다.
+로그와 설정의 API 키, 토큰 등 민감 정보는 마스킹됩니다. 아카이브는 서버로 전송되지 않습니다.
+
+예시:
+  autopus-bridge logs bundle
+  autopus-bridge logs bundle --since 6h -o support.zip`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := resolveLogFile(logsLocalFile)
+		if err != nil {
+			return err
+		}
+		filter, err := newLogFilter("", "", logsBundleSince, "", time.Now())
+		if err != nil {
+			return err
+		}
+		output := logsBundleOutput
+		if output == "" {
+			output = fmt.Sprintf("autopus-bridge-logs-%s.zip", time.Now().Format("20060102-150405"))
+		}
+		if err := writeLogBundle(output, path, viper.ConfigFileUsed(), filter); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "로그 아카이브를 저장했습니다: %s\n", output)
+		return nil
+	},
+}
+
+func init() {
+	logsCmd.AddCommand(logsLocalCmd)
+	logsCmd.AddCommand(logsBundleCmd)
+
+	logsCmd.PersistentFlags().StringVar(&logsLocalFile, "file", "", "로그 파일 경로 (기본값: logging.file 설정)")
+
+	logsLocalCmd.Flags().StringVar(&logsLocalComponent, "component", "", "컴포넌트 필터 (부분 일치, 예: websocket)")
+	logsLocalCmd.Flags().StringVar(&logsLocalLevel, "level", "", "최소 로그 레벨 (debug, info, warn, error)")
+	logsLocalCmd.Flags().StringVar(&logsLocalSince, "since", "", "조회 시작 시점 (예: 30m, 1h, 2026-01-02T15:04:05Z)")
+	logsLocalCmd.Flags().StringVar(&logsLocalExecID, "exec-id", "", "실행 ID 필터")
+	logsLocalCmd.Flags().BoolVar(&logsLocalJSON, "json", false, "원본 JSON 줄 그대로 출력")
+	logsLocalCmd.Flags().BoolVarP(&logsLocalFollow, "follow", "f", false, "새로 기록되는 로그를 계속 출력")
+	logsLocalCmd.Flags().IntVarP(&logsLocalLines, "lines", "n", 100, "출력할 최근 로그 수 (0=전체)")
+
+	logsBundleCmd.Flags().StringVarP(&logsBundleOutput, "output", "o", "", "아카이브 파일 경로 (기본값: ./autopus-bridge-logs-<시각>.zip)")
+	logsBundleCmd.Flags().StringVar(&logsBundleSince, "since", "24h", "포함할 로그 기간 (예: 6h, 24h)")
+}
+
+// resolveLogFile은 --file 또는 logging.file 설정에서 로그 파일 경로를 결정합니다.
+func resolveLogFile(flagPath string) (string, error) {
+	path := flagPath
+	if path == "" {
+		if cfg, err := config.Load(); err == nil {
+			path = cfg.Logging.File
+		}
+	}
+	if path == "" {
+		return "", errors.New("로그 파일이 설정되지 않았습니다 (config.yaml의 logging.file을 설정하거나 --file로 지정하세요)")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("로그 파일을 열 수 없습니다: %w", err)
+	}
+	return path, nil
+}
+
+// logLevelRank는 최소 레벨 필터를 위한 로그 레벨 순위입니다.
+var logLevelRank = map[string]int{
+	"trace": 0,
+	"debug": 1,
+	"info":  2,
+	"warn":  3,
+	"error": 4,
+	"fatal": 5,
+	"panic": 6,
+}
+
+// logFilter는 로그 줄 필터 조건입니다. 비어 있는 조건은 적용하지 않습니다.
+type logFilter struct {
+	component string
+	minLevel  int
+	since     time.Time
+	execID    string
+}
+
+// active는 구조화된 필드를 요구하는 조건이 하나라도 있는지 반환합니다.
+func (f logFilter) active() bool {
+	return f.component != "" || f.minLevel > 0 || !f.since.IsZero() || f.execID != ""
+}
+
+// newLogFilter는 CLI 플래그 값으로 필터를 생성합니다.
+// since는 현재 시각 기준 기간(1h) 또는 RFC3339 시각입니다.
+func newLogFilter(component, level, since, execID string, now time.Time) (logFilter, error) {
+	f := logFilter{
+		component: strings.ToLower(strings.TrimSpace(component)),
+		execID:    strings.TrimSpace(execID),
+	}
+	if level != "" {
+		lvl := strings.ToLower(level)
+		if lvl == "warning" {
+			lvl = "warn"
+		}
+		rank, ok := logLevelRank[lvl]
+		if !ok {
+			return f, fmt.Errorf("유효하지 않은 로그 레벨: %s (debug, info, warn, error 중 하나)", level)
+		}
+		f.minLevel = rank
+	}
+	if since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			f.since = now.Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, since); err == nil {
+			f.since = t
+		} else {
+			return f, fmt.Errorf("유효하지 않은 --since 값: %s (예: 30m, 1h, 2026-01-02T15:04:05Z)", since)
+		}
+	}
+	return f, nil
+}
+
+// logEntry는 파싱된 로그 한 줄입니다.
+type logEntry struct {
+	raw    string
+	fields map[string]interface{}
+}
+
+// parseLogLine은 로그 한 줄을 파싱합니다. JSON이 아니면 fields는 nil입니다.
+func parseLogLine(line string) logEntry {
+	entry := logEntry{raw: line}
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
+			entry.fields = fields
+		}
+	}
+	return entry
+}
+
+// str은 문자열 필드 값을 반환합니다.
+func (e logEntry) str(key string) string {
+	v, _ := e.fields[key].(string)
+	return v
+}
+
+// match는 로그 줄이 필터 조건을 만족하는지 확인합니다.
+func (f logFilter) match(e logEntry) bool {
+	if e.fields == nil {
+		return !f.active()
+	}
+	if f.component != "" && !strings.Contains(strings.ToLower(e.str("component")), f.component) {
+		return false
+	}
+	if f.minLevel > 0 {
+		rank, ok := logLevelRank[e.str("level")]
+		if !ok {
+			rank = logLevelRank["info"]
+		}
+		if rank < f.minLevel {
+			return false
+		}
+	}
+	if !f.since.IsZero() {
+		t, err := time.Parse(time.RFC3339, e.str("time"))
+		if err != nil || t.Before(f.since) {
+			return false
+		}
+	}
+	if f.execID != "" && e.str("execution_id") != f.execID {
+		return false
+	}
+	return true
+}
+
+// logBaseFields는 pretty 출력에서 별도 위치에 표시하는 필드입니다.
+var logBaseFields = map[string]bool{"time": true, "level": true, "component": true, "message": true}
+
+// formatLogEntry는 로그 줄을 사람이 읽기 쉬운 형식으로 변환합니다.
+// 형식: "2006-01-02 15:04:05 WRN [component] message key=value ..."
+func formatLogEntry(e logEntry) string {
+	if e.fields == nil {
+		return e.raw
+	}
+
+	var b strings.Builder
+	if t, err := time.Parse(time.RFC3339, e.str("time")); err == nil {
+		b.WriteString(t.Local().Format(time.DateTime))
+		b.WriteString(" ")
+	}
+	level := strings.ToUpper(e.str("level"))
+	if len(level) > 3 {
+		level = level[:3]
+	}
+	if level == "" {
+		level = "---"
+	}
+	b.WriteString(level)
+	if component := e.str("component"); component != "" {
+		fmt.Fprintf(&b, " [%s]", component)
+	}
+	if msg := e.str("message"); msg != "" {
+		b.WriteString(" ")
+		b.WriteString(msg)
+	}
+
+	keys := make([]string, 0, len(e.fields))
+	for k := range e.fields {
+		if !logBaseFields[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := e.fields[k]
+		if s, ok := v.(string); ok {
+			fmt.Fprintf(&b, " %s=%s", k, s)
+			continue
+		}
+		data, _ := json.Marshal(v)
+		fmt.Fprintf(&b, " %s=%s", k, data)
+	}
+	return b.String()
+}
+
+// writeLogEntry는 출력 형식에 맞게 로그 줄을 기록합니다.
+func writeLogEntry(out io.Writer, e logEntry, jsonOut bool) {
+	if jsonOut {
+		fmt.Fprintln(out, e.raw)
+		return
+	}
+	fmt.Fprintln(out, formatLogEntry(e))
+}
+
+// runLogsLocal은 로그 파일에서 필터에 맞는 최근 lines개 로그를 출력하고,
+// follow이면 이후 추가되는 로그를 ctx가 취소될 때까지 계속 출력합니다.
+func runLogsLocal(ctx context.Context, out io.Writer, path string, filter logFilter, lines int, jsonOut, follow bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("로그 파일을 열 수 없습니다: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReaderSize(f, 64*1024)
+	var recent []logEntry
+	offset, err := readLogLines(reader, func(e logEntry) {
+		if !filter.match(e) {
+			return
+		}
+		recent = append(recent, e)
+		if lines > 0 && len(recent) > lines {
+			recent = recent[1:]
+		}
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range recent {
+		writeLogEntry(out, e, jsonOut)
+	}
+	if !follow {
+		return nil
+	}
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := f.Stat()
+		if err != nil {
+			return fmt.Errorf("로그 파일 확인 실패: %w", err)
+		}
+		if info.Size() < offset {
+			// 로그 파일이 잘렸거나 교체됨: 처음부터 다시 읽는다.
+			offset = 0
+		}
+		if info.Size() == offset {
+			continue
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("로그 파일 탐색 실패: %w", err)
+		}
+		reader.Reset(f)
+		n, err := readLogLines(reader, func(e logEntry) {
+			if filter.match(e) {
+				writeLogEntry(out, e, jsonOut)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		offset += n
+	}
+}
+
+// readLogLines는 완전한(개행으로 끝나는) 줄만 읽어 fn에 전달하고 소비한 바이트 수를 반환합니다.
+// 아직 기록 중인 마지막 줄은 다음 읽기에서 처리합니다.
+func readLogLines(r *bufio.Reader, fn func(logEntry)) (int64, error) {
+	var consumed int64
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return consumed, nil
+		}
+		if err != nil {
+			return consumed, fmt.Errorf("로그 파일 읽기 실패: %w", err)
+		}
+		consumed += int64(len(line))
+		if len(line) > logLineMaxSize {
+			continue
+		}
+		text := strings.TrimRight(line, "\r\n")
+		if text == "" {
+			continue
+		}
+		fn(parseLogLine(text))
+	}
+}
+
+// logBundleVersion은 로그 아카이브의 version.json 내용입니다.
+type logBundleVersion struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	CreatedAt string `json:"created_at"`
+}
+
+// writeLogBundle은 필터에 맞는 로그(민감 정보 마스킹), 민감 정보를 제거한 설정,
+// 버전 정보를 zip 아카이브로 저장합니다.
+func writeLogBundle(output, logPath, configPath string, filter logFilter) (err error) {
+	logs, err := os.Open(logPath)
+	if err != nil {
+		return fmt.Errorf("로그 파일을 열 수 없습니다: %w", err)
+	}
+	defer logs.Close()
+
+	file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("아카이브 파일 생성 실패: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("아카이브 파일 저장 실패: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(output)
+		}
+	}()
+
+	zw := zip.NewWriter(file)
+
+	w, err := zw.Create("bridge.log")
+	if err != nil {
+		return fmt.Errorf("아카이브 항목 생성 실패: %w", err)
+	}
+	var writeErr error
+	if _, err := readLogLines(bufio.NewReaderSize(logs, 64*1024), func(e logEntry) {
+		if writeErr == nil && filter.match(e) {
+			_, writeErr = io.WriteString(w, logger.MaskSensitive(e.raw)+"\n")
+		}
+	}); err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("아카이브 로그 쓰기 실패: %w", writeErr)
+	}
+
+	if configPath != "" {
+		if raw, readErr := os.ReadFile(configPath); readErr == nil {
+			w, err := zw.Create("config.yaml")
+			if err != nil {
+				return fmt.Errorf("아카이브 항목 생성 실패: %w", err)
+			}
+			if _, err := w.Write(crash.RedactConfig(raw)); err != nil {
+				return fmt.Errorf("아카이브 설정 쓰기 실패: %w", err)
+			}
+		}
+	}
+
+	version, commit, buildDate := GetVersionInfo()
+	versionJSON, err := json.MarshalIndent(logBundleVersion{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("버전 정보 직렬화 실패: %w", err)
+	}
+	w, err = zw.Create("version.json")
+	if err != nil {
+		return fmt.Errorf("아카이브 항목 생성 실패: %w", err)
+	}
+	if _, err := w.Write(versionJSON); err != nil {
+		return fmt.Errorf("아카이브 버전 정보 쓰기 실패: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("아카이브 압축 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testLogLines는 필터 테스트용 구조화 로그입니다.
+var testLogLines = []string{
+	`{"level":"info","time":"2026-01-02T10:00:00Z","component":"websocket","message":"connected"}`,
+	`{"level":"warn","time":"2026-01-02T10:30:00Z","component":"websocket","execution_id":"exec-1","message":"slow response"}`,
+	`{"level":"error","time":"2026-01-02T11:00:00Z","component":"mcpserver.client","execution_id":"exec-2","message":"backend down","status":502}`,
+	`plain text line`,
+}
+
+func writeTestLogFile(t *testing.T, lines []string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "bridge.log")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		t.Fatalf("로그 파일 생성 실패: %v", err)
+	}
+	return path
+}
+
+func TestNewLogFilter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 11, 30, 0, 0, time.UTC)
+	f, err := newLogFilter("WebSocket", "warning", "1h", "exec-1", now)
+	if err != nil {
+		t.Fatalf("newLogFilter() error = %v", err)
+	}
+	if f.component != "websocket" || f.minLevel != logLevelRank["warn"] || !f.since.Equal(now.Add(-time.Hour)) {
+		t.Errorf("filter = %+v", f)
+	}
+
+	if _, err := newLogFilter("", "verbose", "", "", now); err == nil {
+		t.Error("잘못된 레벨은 에러를 반환해야 합니다")
+	}
+	if _, err := newLogFilter("", "", "yesterday", "", now); err == nil {
+		t.Error("잘못된 --since 값은 에러를 반환해야 합니다")
+	}
+}
+
+func TestRunLogsLocal_Filters(t *testing.T) {
+	path := writeTestLogFile(t, testLogLines)
+	now := time.Date(2026, 1, 2, 11, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		component string
+		level     string
+		since     string
+		execID    string
+		want      []string
+	}{
+		{name: "no filter", want: []string{"connected", "slow response", "backend down", "plain text line"}},
+		{name: "component", component: "websocket", want: []string{"connected", "slow response"}},
+		{name: "level", level: "warn", want: []string{"slow response", "backend down"}},
+		{name: "since", since: "45m", want: []string{"backend down"}},
+		{name: "exec id", execID: "exec-1", want: []string{"slow response"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newLogFilter(tt.component, tt.level, tt.since, tt.execID, now)
+			if err != nil {
+				t.Fatalf("newLogFilter() error = %v", err)
+			}
+			var out bytes.Buffer
+			if err := runLogsLocal(context.Background(), &out, path, filter, 0, true, false); err != nil {
+				t.Fatalf("runLogsLocal() error = %v", err)
+			}
+			got := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(got) != len(tt.want) {
+				t.Fatalf("출력 줄 수 = %d, want %d:\n%s", len(got), len(tt.want), out.String())
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("줄 %d = %q, %q 포함 기대", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunLogsLocal_PrettyAndLines(t *testing.T) {
+	path := writeTestLogFile(t, testLogLines)
+
+	var out bytes.Buffer
+	if err := runLogsLocal(context.Background(), &out, path, logFilter{}, 2, false, false); err != nil {
+		t.Fatalf("runLogsLocal() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("--lines 2 출력 = %q", out.String())
+	}
+	if !strings.Contains(lines[0], "ERR [mcpserver.client] backend down execution_id=exec-2 status=502") {
+		t.Errorf("pretty 출력 = %q", lines[0])
+	}
+	if lines[1] != "plain text line" {
+		t.Errorf("JSON이 아닌 줄은 그대로 출력되어야 합니다: %q", lines[1])
+	}
+}
+
+// syncBuffer는 follow 고루틴과 테스트가 함께 사용하는 동시성 안전 버퍼입니다.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunLogsLocal_Follow(t *testing.T) {
+	path := writeTestLogFile(t, testLogLines[:1])
+	filter, _ := newLogFilter("", "error", "", "", time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() { done <- runLogsLocal(ctx, out, path, filter, 10, true, true) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("로그 파일 열기 실패: %v", err)
+	}
+	// 개행 전까지는 출력되지 않아야 한다 (기록 중인 줄).
+	_, _ = io.WriteString(f, testLogLines[2][:20])
+	time.Sleep(2 * logFollowInterval)
+	_, _ = io.WriteString(f, testLogLines[2][20:]+"\n"+testLogLines[1]+"\n")
+	_ = f.Close()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) && !strings.Contains(out.String(), "backend down") {
+		time.Sleep(20 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("runLogsLocal() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != testLogLines[2] {
+		t.Errorf("follow 출력 = %q, want error 로그 한 줄", got)
+	}
+}
+
+func TestWriteLogBundle(t *testing.T) {
+	logPath := writeTestLogFile(t, []string{
+		`{"level":"info","time":"2020-01-01T00:00:00Z","message":"old"}`,
+		`{"level":"info","time":"` + time.Now().UTC().Format(time.RFC3339) + `","message":"token=abcdefghijklmnop"}`,
+	})
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("server:\n  url: wss://example.com\nauth:\n  api_key: sk-secret-value-123456\n"), 0600); err != nil {
+		t.Fatalf("설정 파일 생성 실패: %v", err)
+	}
+	output := filepath.Join(t.TempDir(), "bundle.zip")
+	filter, _ := newLogFilter("", "", "24h", "", time.Now())
+
+	if err := writeLogBundle(output, logPath, configPath, filter); err != nil {
+		t.Fatalf("writeLogBundle() error = %v", err)
+	}
+
+	zr, err := zip.OpenReader(output)
+	if err != nil {
+		t.Fatalf("아카이브 열기 실패: %v", err)
+	}
+	defer zr.Close()
+
+	entries := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("항목 열기 실패: %v", err)
+		}
+		data, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		entries[f.Name] = string(data)
+	}
+
+	logs := entries["bridge.log"]
+	if strings.Contains(logs, `"old"`) {
+		t.Error("--since 이전 로그는 포함되지 않아야 합니다")
+	}
+	if strings.Contains(logs, "abcdefghijklmnop") {
+		t.Errorf("로그의 민감 정보가 마스킹되지 않았습니다: %s", logs)
+	}
+	if strings.Contains(entries["config.yaml"], "sk-secret-value") {
+		t.Errorf("설정의 민감 정보가 제거되지 않았습니다: %s", entries["config.yaml"])
+	}
+	if !strings.Contains(entries["version.json"], `"go_version"`) {
+		t.Errorf("version.json = %s", entries["version.json"])
+	}
+}
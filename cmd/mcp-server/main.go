@@ -17,11 +17,19 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/i18n"
 	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/project"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/insajin/autopus-bridge/internal/tracing"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 )
@@ -78,9 +86,13 @@ func run() error {
 	defer cancel()
 	tokenRefresher.Start(ctx)
 
+	// 2-1. OpenTelemetry 트레이싱 (tracing.enabled)
+	shutdownTracing := startTracing(ctx, logger)
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	// 3. BackendClient 생성
-	backendURL := viper.GetString("mcpserver.backend_url")
-	timeoutStr := viper.GetString("mcpserver.timeout")
+	backendURL := viper.GetString("mcp_server.backend_url")
+	timeoutStr := viper.GetString("mcp_server.timeout")
 	timeout, err := time.ParseDuration(timeoutStr)
 	if err != nil {
 		timeout = 30 * time.Second
@@ -90,10 +102,12 @@ func run() error {
 			Msg("유효하지 않은 타임아웃 설정, 기본값 사용")
 	}
 
+	configureBackendTransport(logger)
 	client := mcpserver.NewBackendClient(backendURL, tokenRefresher, timeout, logger)
+	configureBackendResilience(client, logger)
 
 	// 4. MCP 서버 생성 (캐시 TTL 설정)
-	cacheTTLStr := viper.GetString("mcpserver.cache_ttl")
+	cacheTTLStr := viper.GetString("mcp_server.cache_ttl")
 	cacheTTL, cacheTTLErr := time.ParseDuration(cacheTTLStr)
 	if cacheTTLErr != nil {
 		cacheTTL = mcpserver.DefaultCacheTTL
@@ -103,6 +117,36 @@ func run() error {
 			Msg("유효하지 않은 캐시 TTL 설정, 기본값 사용")
 	}
 	srv := mcpserver.NewServer(client, logger, cacheTTL)
+	configureResourceCache(srv, cacheTTL, logger)
+	configureKnowledgeCache(srv, logger)
+	configureTemplates(srv, logger)
+	configureProjectContext(srv, logger)
+	srv.SetBridgeInfo(mcpserver.BridgeInfo{
+		Version:    mcpserver.BridgeVersion{Bridge: version, Commit: commit, BuildDate: buildDate},
+		Settings:   viper.AllSettings,
+		LoadConfig: config.Load,
+	})
+	srv.SetListAgentsMaxResults(viper.GetInt("mcp_server.list_agents.max_results"))
+	configureTimeouts(srv, logger)
+	configureConcurrency(srv, logger)
+
+	// 4-0. 도구별 사용 권한 (mcp_server.tools)
+	if err := configureToolPermissions(srv); err != nil {
+		return err
+	}
+
+	// 4-1. 로컬 프로바이더 기반 샘플링 (선택적)
+	if viper.GetBool("mcp_server.sampling.enabled") {
+		registry, regErr := initializeSamplingRegistry(ctx, logger)
+		if regErr != nil {
+			logger.Warn().Err(regErr).Msg("로컬 샘플링 비활성화: 프로바이더 초기화 실패")
+		} else {
+			srv.EnableLocalSampling(mcpserver.NewSamplingHandler(registry, logger))
+			logger.Info().
+				Strs("providers", registry.List()).
+				Msg("로컬 샘플링 활성화")
+		}
+	}
 
 	// 5. 시그널 핸들링 (graceful shutdown)
 	sigCh := make(chan os.Signal, 1)
@@ -133,19 +177,333 @@ func initConfig() {
 	viper.SetEnvPrefix("LAB")
 	viper.AutomaticEnv()
 
-	// 설정 파일 경로
+	// 설정 파일 경로 (Bridge 설정 파일 우선, 없으면 이전 버전 경로)
 	home, err := os.UserHomeDir()
 	if err == nil {
+		viper.AddConfigPath(filepath.Dir(config.DefaultConfigPath()))
 		viper.AddConfigPath(home + "/.config/local-agent-bridge")
 		viper.SetConfigName("config")
 		viper.SetConfigType("yaml")
 	}
 
 	// MCP 서버 기본 설정
-	viper.SetDefault("mcpserver.backend_url", "https://api.autopus.co")
-	viper.SetDefault("mcpserver.timeout", "30s")
-	viper.SetDefault("mcpserver.cache_ttl", "30s")
+	viper.SetDefault("mcp_server.backend_url", "https://api.autopus.co")
+	viper.SetDefault("mcp_server.timeout", "30s")
+	viper.SetDefault("mcp_server.cache_ttl", "30s")
+	viper.SetDefault("mcp_server.stale_while_revalidate", mcpserver.DefaultStaleWhileRevalidate.String())
+	viper.SetDefault("mcp_server.sampling.enabled", false)
+	viper.SetDefault("mcp_server.retry.max_attempts", 3)
+	viper.SetDefault("mcp_server.retry.initial_backoff", "200ms")
+	viper.SetDefault("mcp_server.retry.max_backoff", "2s")
+	viper.SetDefault("mcp_server.retry.max_retry_after", "30s")
+	concurrency := mcpserver.DefaultConcurrencyConfig()
+	viper.SetDefault("mcp_server.concurrency.max_concurrent", concurrency.MaxConcurrent)
+	viper.SetDefault("mcp_server.concurrency.per_tool", concurrency.PerTool)
+	viper.SetDefault("mcp_server.concurrency.queue_timeout", concurrency.QueueTimeout.String())
+	transport := mcpserver.DefaultTransportConfig()
+	viper.SetDefault("mcp_server.transport.max_idle_conns", transport.MaxIdleConns)
+	viper.SetDefault("mcp_server.transport.max_idle_conns_per_host", transport.MaxIdleConnsPerHost)
+	viper.SetDefault("mcp_server.transport.max_conns_per_host", transport.MaxConnsPerHost)
+	viper.SetDefault("mcp_server.transport.idle_conn_timeout", transport.IdleConnTimeout.String())
+	viper.SetDefault("mcp_server.transport.dial_timeout", transport.DialTimeout.String())
+	viper.SetDefault("mcp_server.transport.keep_alive", transport.KeepAlive.String())
+	viper.SetDefault("mcp_server.transport.tls_handshake_timeout", transport.TLSHandshakeTimeout.String())
+	viper.SetDefault("mcp_server.transport.disable_http2", false)
+	viper.SetDefault("mcp_server.circuit_breaker.enabled", true)
+	viper.SetDefault("mcp_server.circuit_breaker.failure_threshold", 5)
+	viper.SetDefault("mcp_server.circuit_breaker.open_timeout", "30s")
+	viper.SetDefault("mcp_server.knowledge_cache.enabled", true)
+	viper.SetDefault("mcp_server.knowledge_cache.path", "")
+	viper.SetDefault("mcp_server.knowledge_cache.max_queries", mcpserver.DefaultKnowledgeCacheQueries)
+	viper.SetDefault("mcp_server.knowledge_cache.max_documents", mcpserver.DefaultKnowledgeCacheDocuments)
+	viper.SetDefault("mcp_server.list_agents.max_results", mcpserver.DefaultListAgentsMaxResults)
+	viper.SetDefault("mcp_server.project_context.enabled", false)
+	viper.SetDefault("mcp_server.project_context.dir", "")
+	viper.SetDefault("mcp_server.project_context.max_files", mcpserver.DefaultProjectContextMaxFiles)
+	viper.SetDefault("mcp_server.project_context.max_commits", mcpserver.DefaultProjectContextMaxCommits)
+
+	// 트레이싱 기본 설정 (브릿지와 같은 tracing 섹션 사용)
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.endpoint", "localhost:4318")
+	viper.SetDefault("tracing.service_name", "autopus-mcp-server")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
 
 	// 설정 파일 읽기 (없어도 오류 아님)
 	_ = viper.ReadInConfig()
+
+	// 도구 에러 메시지 언어 (language 설정 > LC_ALL/LC_MESSAGES/LANG > 한국어)
+	if lang, err := i18n.Detect(viper.GetString("language")); err == nil {
+		i18n.SetLang(lang)
+	}
+
+	// 전환 기간 동안 이전 키(mcpserver.* 등)도 현재 키로 읽는다. stdout은 MCP stdio이므로 경고는 stderr로 출력한다.
+	if keys := config.ApplyLegacyKeys(viper.GetViper()); len(keys) > 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cmd.config.legacy_keys"))
+		for _, key := range keys {
+			fmt.Fprintf(os.Stderr, "  %s\n", key)
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("cmd.config.legacy_keys_hint"))
+	}
+}
+
+// startTracing은 tracing 설정에 따라 OTLP 익스포터로 도구 호출 트레이싱을 시작합니다.
+// 실패 시 경고만 남기고 트레이싱 없이 계속 진행합니다.
+func startTracing(ctx context.Context, logger zerolog.Logger) tracing.ShutdownFunc {
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		Enabled:        viper.GetBool("tracing.enabled"),
+		Endpoint:       viper.GetString("tracing.endpoint"),
+		Insecure:       viper.GetBool("tracing.insecure"),
+		Headers:        viper.GetStringMapString("tracing.headers"),
+		ServiceName:    viper.GetString("tracing.service_name"),
+		ServiceVersion: version,
+		SampleRatio:    viper.GetFloat64("tracing.sample_ratio"),
+	})
+	if err != nil {
+		logger.Warn().Err(err).Msg("트레이싱 초기화 실패 - 트레이싱 없이 계속합니다")
+	}
+	return shutdown
+}
+
+// configureToolPermissions는 mcp_server.tools 설정으로 도구별 사용 권한을 적용합니다.
+// 알 수 없는 도구/action이 있으면 의도한 제한이 빠지지 않도록 시작을 중단합니다.
+//
+//	mcp_server:
+//	  tools:
+//	    approve_execution:
+//	      disabled: true
+//	    manage_workspace:
+//	      disabled_actions: [delete]
+//	    execute_task:
+//	      read_only: true
+func configureToolPermissions(srv *mcpserver.Server) error {
+	var perms mcpserver.ToolPermissions
+	if err := viper.UnmarshalKey("mcp_server.tools", &perms); err != nil {
+		return fmt.Errorf("mcp_server.tools 설정 파싱 실패: %w", err)
+	}
+	if err := srv.SetToolPermissions(perms); err != nil {
+		return fmt.Errorf("mcp_server.tools 설정 오류: %w", err)
+	}
+	return nil
+}
+
+// configureResourceCache는 리소스별 캐시 정책을 설정합니다.
+// mcp_server.resource_ttl.{status,workspaces,agents}로 리소스별 TTL을 지정할 수 있으며,
+// 지정하지 않은 workspaces/agents는 cache_ttl을, status는 항상 백엔드 확인(TTL 0)을 사용합니다.
+// TTL이 지난 뒤 mcp_server.stale_while_revalidate 동안은 캐시를 반환하면서 백그라운드로 갱신합니다.
+func configureResourceCache(srv *mcpserver.Server, cacheTTL time.Duration, logger zerolog.Logger) {
+	staleStr := viper.GetString("mcp_server.stale_while_revalidate")
+	stale, err := time.ParseDuration(staleStr)
+	if err != nil || stale < 0 {
+		stale = mcpserver.DefaultStaleWhileRevalidate
+		logger.Warn().
+			Str("configured", staleStr).
+			Str("fallback", stale.String()).
+			Msg("유효하지 않은 stale-while-revalidate 설정, 기본값 사용")
+	}
+
+	for _, resource := range []string{mcpserver.ResourceStatus, mcpserver.ResourceWorkspaces, mcpserver.ResourceAgents} {
+		key := "mcp_server.resource_ttl." + resource
+		ttlStr := viper.GetString(key)
+
+		ttl := cacheTTL
+		if ttlStr == "" {
+			if resource == mcpserver.ResourceStatus {
+				continue
+			}
+		} else if parsed, parseErr := time.ParseDuration(ttlStr); parseErr == nil && parsed >= 0 {
+			ttl = parsed
+		} else {
+			logger.Warn().
+				Str("key", key).
+				Str("configured", ttlStr).
+				Str("fallback", cacheTTL.String()).
+				Msg("유효하지 않은 리소스 캐시 TTL 설정, 기본값 사용")
+		}
+
+		_ = srv.SetResourceCachePolicy(resource, mcpserver.CachePolicy{
+			TTL:                  ttl,
+			StaleWhileRevalidate: stale,
+		})
+	}
+}
+
+// configureKnowledgeCache는 search_knowledge 결과 캐시를 설정합니다.
+// mcp_server.knowledge_cache.enabled가 false이면 캐시와 오프라인 폴백을 끄고,
+// path가 비어 있으면 ~/.config/autopus/knowledge-cache.json에 저장합니다.
+// 캐시 파일을 읽지 못하면 경고를 남기고 빈 캐시로 시작합니다.
+func configureKnowledgeCache(srv *mcpserver.Server, logger zerolog.Logger) {
+	if !viper.GetBool("mcp_server.knowledge_cache.enabled") {
+		srv.SetKnowledgeCache(nil)
+		return
+	}
+
+	path := viper.GetString("mcp_server.knowledge_cache.path")
+	if path == "" {
+		if defaultPath := config.DefaultConfigPath(); defaultPath != "" {
+			path = filepath.Join(filepath.Dir(defaultPath), "knowledge-cache.json")
+		}
+	}
+	cache, err := mcpserver.NewKnowledgeCache(path,
+		viper.GetInt("mcp_server.knowledge_cache.max_queries"),
+		viper.GetInt("mcp_server.knowledge_cache.max_documents"))
+	if err != nil {
+		logger.Warn().Err(err).Str("path", path).Msg("지식 검색 캐시를 불러오지 못해 빈 캐시로 시작")
+	}
+	srv.SetKnowledgeCache(cache)
+}
+
+// configureTemplates는 list_templates/execute_template 도구가 사용할 로컬 작업 템플릿을 불러옵니다.
+// templates 항목과 templates_dir(기본값: ~/.config/autopus/templates)의 *.yaml 파일을 읽으며,
+// 템플릿에 오류가 있으면 경고를 남기고 템플릿 없이 시작합니다.
+func configureTemplates(srv *mcpserver.Server, logger zerolog.Logger) {
+	var cfgs []config.TemplateConfig
+	if err := viper.UnmarshalKey("templates", &cfgs); err != nil {
+		logger.Warn().Err(err).Msg("templates 설정 파싱 실패, 템플릿 없이 시작")
+		return
+	}
+	cfg := config.Config{TemplatesDir: viper.GetString("templates_dir")}
+
+	registry, err := tasktemplate.Load(cfgs, cfg.GetTemplatesDir())
+	if err != nil {
+		logger.Warn().Err(err).Msg("작업 템플릿을 불러오지 못해 템플릿 없이 시작")
+		return
+	}
+	srv.SetTemplates(registry)
+}
+
+// configureConcurrency는 mcp_server.concurrency 설정으로 도구 호출 동시 실행 한도를 설정합니다.
+// 설정이 유효하지 않으면 경고를 남기고 기본 한도를 사용합니다.
+func configureConcurrency(srv *mcpserver.Server, logger zerolog.Logger) {
+	cfg := mcpserver.DefaultConcurrencyConfig()
+	if err := viper.UnmarshalKey("mcp_server.concurrency", &cfg); err != nil {
+		logger.Warn().Err(err).Msg("유효하지 않은 mcp_server.concurrency 설정, 기본값 사용")
+		cfg = mcpserver.DefaultConcurrencyConfig()
+	}
+	srv.SetConcurrencyLimits(cfg)
+}
+
+// configureTimeouts는 timeouts 설정으로 도구 호출 타임아웃 계층을 설정합니다.
+// 도구별 값은 timeouts.mcp_tools, 기본값과 _meta.timeout_seconds 상한은 timeouts.default_seconds와 max_seconds를 사용합니다.
+// 설정이 유효하지 않으면 경고를 남기고 타임아웃 없이 시작합니다.
+func configureTimeouts(srv *mcpserver.Server, logger zerolog.Logger) {
+	var cfg config.TimeoutsConfig
+	if err := viper.UnmarshalKey("timeouts", &cfg); err != nil {
+		logger.Warn().Err(err).Msg("유효하지 않은 timeouts 설정, 도구 호출 타임아웃 없이 시작")
+		return
+	}
+	srv.SetTimeoutPolicy(timeouts.FromSeconds(cfg.DefaultSeconds, cfg.MaxSeconds, cfg.MCPTools))
+}
+
+// configureBackendResilience는 백엔드 클라이언트의 재시도 정책과 서킷 브레이커를 설정합니다.
+// mcp_server.retry.{max_attempts,initial_backoff,max_backoff}로 재시도를, max_retry_after로 따를 Retry-After 상한을,
+// mcp_server.circuit_breaker.{enabled,failure_threshold,open_timeout}로 서킷 브레이커를 조정합니다.
+func configureBackendResilience(client *mcpserver.BackendClient, logger zerolog.Logger) {
+	policy := mcpserver.DefaultRetryPolicy()
+	policy.MaxAttempts = viper.GetInt("mcp_server.retry.max_attempts")
+	policy.InitialBackoff = parseDurationSetting("mcp_server.retry.initial_backoff", policy.InitialBackoff, logger)
+	policy.MaxBackoff = parseDurationSetting("mcp_server.retry.max_backoff", policy.MaxBackoff, logger)
+	policy.MaxRetryAfter = parseDurationSetting("mcp_server.retry.max_retry_after", policy.MaxRetryAfter, logger)
+	client.SetRetryPolicy(policy)
+
+	if viper.GetBool("mcp_server.circuit_breaker.enabled") {
+		breaker := mcpserver.DefaultCircuitBreakerConfig()
+		if threshold := viper.GetInt("mcp_server.circuit_breaker.failure_threshold"); threshold > 0 {
+			breaker.FailureThreshold = threshold
+		}
+		breaker.OpenTimeout = parseDurationSetting("mcp_server.circuit_breaker.open_timeout", breaker.OpenTimeout, logger)
+		client.EnableCircuitBreaker(breaker)
+	}
+}
+
+// configureBackendTransport는 mcp_server.transport 설정으로 백엔드 HTTP 커넥션 풀을 설정합니다.
+// 설정이 유효하지 않으면 경고를 남기고 기본 커넥션 풀을 사용합니다.
+func configureBackendTransport(logger zerolog.Logger) {
+	var cfg mcpserver.TransportConfig
+	if err := viper.UnmarshalKey("mcp_server.transport", &cfg); err != nil {
+		logger.Warn().Err(err).Msg("유효하지 않은 mcp_server.transport 설정, 기본값 사용")
+		return
+	}
+	mcpserver.ConfigureTransport(cfg)
+}
+
+// parseDurationSetting은 viper 키의 기간 문자열을 파싱합니다.
+// 값이 유효하지 않으면 경고를 남기고 fallback을 반환합니다.
+func parseDurationSetting(key string, fallback time.Duration, logger zerolog.Logger) time.Duration {
+	configured := viper.GetString(key)
+	d, err := time.ParseDuration(configured)
+	if err != nil || d < 0 {
+		logger.Warn().
+			Str("key", key).
+			Str("configured", configured).
+			Str("fallback", fallback.String()).
+			Msg("유효하지 않은 기간 설정, 기본값 사용")
+		return fallback
+	}
+	return d
+}
+
+// initializeSamplingRegistry는 브릿지 설정의 providers 섹션으로 로컬 프로바이더 레지스트리를 초기화합니다.
+// 샘플링 요청은 이 레지스트리의 CLI/API 인증을 그대로 사용합니다.
+func initializeSamplingRegistry(ctx context.Context, logger zerolog.Logger) (*provider.Registry, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	claude, gemini, codex, compat := cfg.Providers.Claude, cfg.Providers.Gemini, cfg.Providers.Codex, cfg.Providers.OpenAICompat
+	codexTurnRetries := codex.GetTurnRetries()
+	return provider.InitializeRegistryWithLogger(ctx, provider.RegistryConfig{
+		ClaudeEnabled:      claude.Enabled,
+		ClaudeAPIKey:       claude.GetAPIKey(),
+		ClaudeDefaultModel: claude.DefaultModel,
+		ClaudeMode:         claude.GetMode(),
+		ClaudeCLIPath:      claude.GetCLIPath(),
+		ClaudeCLITimeout:   claude.GetCLITimeout(),
+
+		GeminiEnabled:      gemini.Enabled,
+		GeminiAPIKey:       gemini.GetAPIKey(),
+		GeminiDefaultModel: gemini.DefaultModel,
+		GeminiMode:         gemini.GetMode(),
+		GeminiCLIPath:      cliPathOrDefault(gemini.CLIPath, "gemini"),
+		GeminiCLITimeout:   gemini.GetCLITimeout(),
+
+		CodexEnabled:        codex.Enabled,
+		CodexAPIKey:         codex.GetAPIKey(),
+		CodexDefaultModel:   codex.DefaultModel,
+		CodexMode:           codex.GetMode(),
+		CodexCLIPath:        cliPathOrDefault(codex.CLIPath, "codex"),
+		CodexCLITimeout:     codex.GetCLITimeout(),
+		CodexApprovalPolicy: codex.GetApprovalPolicy(),
+		CodexChatGPTAuthEnv: codex.ChatGPTAuthEnv,
+		CodexTurnRetries:    &codexTurnRetries,
+		CodexFraming:        codex.Framing,
+
+		OpenAICompatEnabled:      compat.Enabled,
+		OpenAICompatName:         compat.GetName(),
+		OpenAICompatBaseURL:      compat.BaseURL,
+		OpenAICompatAPIKey:       compat.GetAPIKey(),
+		OpenAICompatDefaultModel: compat.DefaultModel,
+		OpenAICompatModels:       compat.Models,
+		OpenAICompatTimeout:      int(compat.GetTimeout().Seconds()),
+	}, logger)
+}
+
+// cliPathOrDefault는 설정된 CLI 경로가 없으면 기본 바이너리명을 반환합니다.
+func cliPathOrDefault(path, defaultBinary string) string {
+	if path != "" {
+		return path
+	}
+	return defaultBinary
+}
+
+// configureProjectContext는 execute_task 프롬프트에 덧붙일 로컬 프로젝트 컨텍스트를 설정합니다.
+// mcp_server.project_context.enabled는 include_local_context 인자를 생략했을 때의 기본값이며,
+// dir을 지정하지 않으면 MCP 서버를 실행한 디렉토리(보통 IDE의 프로젝트 루트)를 사용합니다.
+func configureProjectContext(srv *mcpserver.Server, logger zerolog.Logger) {
+	cfg := mcpserver.DefaultProjectContextConfig()
+	if err := viper.UnmarshalKey("mcp_server.project_context", &cfg); err != nil {
+		logger.Warn().Err(err).Msg("mcp_server.project_context 설정 파싱 실패, 기본값 사용")
+		cfg = mcpserver.DefaultProjectContextConfig()
+	}
+	srv.SetProjectContext(cfg, project.NewAnalyzer())
 }
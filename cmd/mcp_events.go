@@ -0,0 +1,54 @@
+// mcp_events.go는 Bridge가 관리하는 MCP 서버의 알림을 백엔드로 전달하는 연결부입니다.
+package cmd
+
+import (
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+)
+
+// mcpServerEventSender는 mcp_server_event 메시지를 보내는 인터페이스입니다.
+// websocket.Client가 구현합니다.
+type mcpServerEventSender interface {
+	SendMCPServerEvent(payload ws.MCPServerEventPayload) error
+}
+
+// newMCPEventForwarder는 MCP 서버 알림을 요약하여 sender로 보내는 전달기를 생성합니다.
+func newMCPEventForwarder(cfg config.MCPNotificationsConfig, sender mcpServerEventSender) *mcp.NotificationForwarder {
+	return mcp.NewNotificationForwarder(mcp.ForwarderConfig{
+		Interval:  cfg.GetInterval(),
+		MaxEvents: cfg.MaxEvents,
+		MinLevel:  cfg.MinLevel,
+	}, func(batch mcp.NotificationBatch) {
+		if err := sender.SendMCPServerEvent(mcpServerEventPayload(batch, time.Now())); err != nil {
+			logger.Debug().Err(err).Str("server", batch.Server).Msg("MCP 서버 알림 전송 실패")
+		}
+	})
+}
+
+// mcpServerEventPayload는 요약된 알림을 프로토콜 형식으로 변환합니다.
+func mcpServerEventPayload(batch mcp.NotificationBatch, now time.Time) ws.MCPServerEventPayload {
+	payload := ws.MCPServerEventPayload{
+		Server:     batch.Server,
+		Events:     make([]ws.MCPServerEvent, len(batch.Events)),
+		Dropped:    batch.Dropped,
+		ReportedAt: now.UTC().Format(time.RFC3339),
+	}
+	for i, ev := range batch.Events {
+		payload.Events[i] = ws.MCPServerEvent{
+			Kind:    ev.Kind,
+			Method:  ev.Method,
+			Level:   ev.Level,
+			Logger:  ev.Logger,
+			URI:     ev.URI,
+			Message: ev.Message,
+			Count:   ev.Count,
+			FirstAt: ev.FirstAt.UTC().Format(time.RFC3339),
+			LastAt:  ev.LastAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return payload
+}
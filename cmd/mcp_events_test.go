@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+)
+
+type fakeMCPEventSender struct {
+	payloads []ws.MCPServerEventPayload
+}
+
+func (s *fakeMCPEventSender) SendMCPServerEvent(payload ws.MCPServerEventPayload) error {
+	s.payloads = append(s.payloads, payload)
+	return nil
+}
+
+func TestMCPEventForwarder_SendsPayload(t *testing.T) {
+	sender := &fakeMCPEventSender{}
+	forwarder := newMCPEventForwarder(config.MCPNotificationsConfig{MaxEvents: 10}, sender)
+
+	at := time.Date(2026, 1, 2, 15, 0, 0, 0, time.FixedZone("KST", 9*60*60))
+	forwarder.Handle(mcp.Notification{Server: "weather", Kind: mcp.NotificationResourceUpdated, Method: "notifications/resources/updated", URI: "file:///tmp/a", At: at})
+	forwarder.Flush()
+
+	if len(sender.payloads) != 1 {
+		t.Fatalf("payloads = %+v", sender.payloads)
+	}
+	p := sender.payloads[0]
+	if p.Server != "weather" || len(p.Events) != 1 || p.ReportedAt == "" {
+		t.Fatalf("payload = %+v", p)
+	}
+	ev := p.Events[0]
+	if ev.Kind != ws.MCPServerEventResourceUpdated || ev.URI != "file:///tmp/a" || ev.Count != 1 || ev.FirstAt != "2026-01-02T06:00:00Z" {
+		t.Errorf("event = %+v", ev)
+	}
+}
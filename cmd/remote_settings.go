@@ -0,0 +1,167 @@
+// remote_settings.go는 서버 주도 설정 변경(config_update)을 로컬 허용 범위 안에서 적용합니다.
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/logger"
+)
+
+// remoteSettings는 websocket.ConfigUpdater 구현입니다.
+// 서버가 추가한 도구 허용 목록은 로컬 허용 목록에 더해지며, 설정 파일 hot-reload 후에도 유지됩니다.
+type remoteSettings struct {
+	mu     sync.Mutex
+	bounds config.RemoteSettingsConfig
+
+	// localApproval은 설정 파일의 승인 설정입니다 (hot-reload로 갱신).
+	localApproval config.ActionApprovalConfig
+	actionGate    *approval.ActionGate
+	// setMaxConcurrentTasks는 작업 동시 실행 한도를 적용합니다 (Router.SetMaxConcurrentTasks).
+	setMaxConcurrentTasks func(limit int)
+
+	maxConcurrentTasks int
+	allowedTools       []string
+	logLevel           string
+}
+
+// newRemoteSettings는 cfg의 허용 범위와 현재 값으로 remoteSettings를 생성합니다.
+func newRemoteSettings(cfg *config.Config, actionGate *approval.ActionGate) *remoteSettings {
+	return &remoteSettings{
+		bounds:        cfg.RemoteSettings,
+		localApproval: cfg.Security.ActionApproval,
+		actionGate:    actionGate,
+		logLevel:      cfg.Logging.Level,
+	}
+}
+
+// ApplyConfigUpdate는 허용 범위 안의 설정만 적용하고 적용/거부 결과를 반환합니다.
+func (s *remoteSettings) ApplyConfigUpdate(update ws.ConfigUpdatePayload) ws.ConfigUpdateAckPayload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ack ws.ConfigUpdateAckPayload
+	apply := func(setting string, err error) {
+		if err != nil {
+			ack.Rejected = append(ack.Rejected, ws.ConfigUpdateRejection{Setting: setting, Reason: err.Error()})
+			return
+		}
+		ack.Applied = append(ack.Applied, setting)
+	}
+
+	if update.MaxConcurrentTasks != nil {
+		apply(ws.ConfigSettingMaxConcurrentTasks, s.applyMaxConcurrentTasks(*update.MaxConcurrentTasks))
+	}
+	if update.AllowedTools != nil {
+		apply(ws.ConfigSettingAllowedTools, s.applyAllowedTools(update.AllowedTools))
+	}
+	if update.LogLevel != "" {
+		apply(ws.ConfigSettingLogLevel, s.applyLogLevel(update.LogLevel))
+	}
+
+	ack.Effective = s.effectiveLocked()
+	return ack
+}
+
+// applyMaxConcurrentTasks는 1 이상 상한 이하의 동시 작업 수를 적용합니다.
+func (s *remoteSettings) applyMaxConcurrentTasks(limit int) error {
+	upper := s.bounds.GetMaxConcurrentTasks()
+	if limit < 1 || limit > upper {
+		return fmt.Errorf("동시 작업 수 %d는 허용 범위(1-%d)를 벗어납니다", limit, upper)
+	}
+	if s.setMaxConcurrentTasks != nil {
+		s.setMaxConcurrentTasks(limit)
+	}
+	s.maxConcurrentTasks = limit
+	return nil
+}
+
+// applyAllowedTools는 모든 도구가 remote_settings.allowed_tools 패턴에 해당할 때만 적용합니다.
+func (s *remoteSettings) applyAllowedTools(tools []string) error {
+	var normalized []string
+	for _, tool := range tools {
+		tool = strings.TrimSpace(tool)
+		if tool == "" {
+			continue
+		}
+		if !toolPermitted(s.bounds.AllowedTools, tool) {
+			return fmt.Errorf("도구 %q는 remote_settings.allowed_tools에 없습니다", tool)
+		}
+		normalized = append(normalized, tool)
+	}
+	s.allowedTools = normalized
+	s.syncAllowlistLocked()
+	return nil
+}
+
+// applyLogLevel은 remote_settings.allowed_log_levels에 있는 로그 레벨을 적용합니다.
+func (s *remoteSettings) applyLogLevel(level string) error {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if !slices.Contains(s.bounds.GetAllowedLogLevels(), level) {
+		return fmt.Errorf("로그 레벨 %q는 허용되지 않습니다 (허용: %s)", level, strings.Join(s.bounds.GetAllowedLogLevels(), ", "))
+	}
+	logger.SetLevel(level)
+	s.logLevel = level
+	return nil
+}
+
+// SetLocalApproval은 설정 파일 hot-reload로 바뀐 승인 설정을 서버가 추가한 도구와 합쳐 적용합니다.
+func (s *remoteSettings) SetLocalApproval(approvalCfg config.ActionApprovalConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.localApproval = approvalCfg
+	s.syncAllowlistLocked()
+}
+
+// SetLocalLogLevel은 설정 파일 hot-reload로 바뀐 로그 레벨을 기록합니다.
+func (s *remoteSettings) SetLocalLogLevel(level string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logger.SetLevel(level)
+	s.logLevel = level
+}
+
+// syncAllowlistLocked는 로컬 허용 목록과 서버가 추가한 도구를 합쳐 승인 게이트에 적용합니다.
+func (s *remoteSettings) syncAllowlistLocked() {
+	if s.actionGate == nil {
+		return
+	}
+	allowlist := actionGateAllowlist(s.localApproval)
+	allowlist[approval.ActionCustomTool] = append(slices.Clone(allowlist[approval.ActionCustomTool]), s.allowedTools...)
+	s.actionGate.SetAllowlist(allowlist)
+}
+
+// effectiveLocked는 현재 유효한 설정 값을 반환합니다.
+func (s *remoteSettings) effectiveLocked() ws.ConfigSettings {
+	return ws.ConfigSettings{
+		MaxConcurrentTasks: s.maxConcurrentTasks,
+		AllowedTools:       slices.Clone(s.allowedTools),
+		LogLevel:           s.logLevel,
+	}
+}
+
+// toolPermitted는 tool이 patterns 중 하나와 일치하는지 반환합니다. "*"로 끝나는 패턴은 접두사 일치입니다.
+// tool 자체가 패턴이면("foo*") 더 넓은 패턴("f*")이나 같은 패턴에만 포함됩니다.
+func toolPermitted(patterns []string, tool string) bool {
+	toolPrefix, toolIsPattern := strings.CutSuffix(tool, "*")
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(toolPrefix, prefix) {
+				return true
+			}
+			continue
+		}
+		if !toolIsPattern && tool == pattern {
+			return true
+		}
+	}
+	return false
+}
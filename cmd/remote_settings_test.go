@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/rs/zerolog"
+)
+
+func TestRemoteSettings_ApplyConfigUpdate(t *testing.T) {
+	original := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(original)
+
+	cfg := &config.Config{}
+	cfg.Logging.Level = "info"
+	cfg.RemoteSettings = config.RemoteSettingsConfig{
+		Enabled:            true,
+		MaxConcurrentTasks: 4,
+		AllowedTools:       []string{"lint-*", "format"},
+		AllowedLogLevels:   []string{"info", "debug"},
+	}
+	cfg.Security.ActionApproval.AllowedTools = []string{"local-tool"}
+	gate := approval.NewActionGate(approval.ActionGateConfig{
+		Modes: map[string]approval.GateMode{approval.ActionCustomTool: approval.GateModeDeny},
+	}, nil, zerolog.Nop())
+	remote := newRemoteSettings(cfg, gate)
+	var limit int
+	remote.setMaxConcurrentTasks = func(n int) { limit = n }
+
+	checkTool := func(tool string) error {
+		return gate.Check(context.Background(), approval.LocalAction{Type: approval.ActionCustomTool, Target: tool})
+	}
+
+	three := 3
+	ack := remote.ApplyConfigUpdate(ws.ConfigUpdatePayload{
+		MaxConcurrentTasks: &three,
+		AllowedTools:       []string{"lint-go", "format"},
+		LogLevel:           "debug",
+	})
+	if len(ack.Applied) != 3 || len(ack.Rejected) != 0 {
+		t.Fatalf("ack = %+v, 모든 설정이 적용되어야 합니다", ack)
+	}
+	if limit != 3 || ack.Effective.MaxConcurrentTasks != 3 {
+		t.Errorf("동시 작업 수 = %d (effective %d), want 3", limit, ack.Effective.MaxConcurrentTasks)
+	}
+	if zerolog.GlobalLevel() != zerolog.DebugLevel || ack.Effective.LogLevel != "debug" {
+		t.Errorf("로그 레벨이 반영되지 않았습니다: %v", zerolog.GlobalLevel())
+	}
+	for _, tool := range []string{"lint-go", "format", "local-tool"} {
+		if err := checkTool(tool); err != nil {
+			t.Errorf("%s는 허용되어야 합니다: %v", tool, err)
+		}
+	}
+
+	// 허용 범위를 벗어난 변경은 거부되고 기존 값이 유지된다.
+	nine := 9
+	ack = remote.ApplyConfigUpdate(ws.ConfigUpdatePayload{
+		MaxConcurrentTasks: &nine,
+		AllowedTools:       []string{"rm-rf"},
+		LogLevel:           "error",
+	})
+	if len(ack.Applied) != 0 || len(ack.Rejected) != 3 {
+		t.Fatalf("ack = %+v, 모든 설정이 거부되어야 합니다", ack)
+	}
+	if limit != 3 || ack.Effective.MaxConcurrentTasks != 3 || ack.Effective.LogLevel != "debug" {
+		t.Errorf("거부된 변경이 반영되었습니다: limit=%d effective=%+v", limit, ack.Effective)
+	}
+	if err := checkTool("rm-rf"); err == nil {
+		t.Error("거부된 도구가 허용되었습니다")
+	}
+
+	// 설정 파일 hot-reload 후에도 서버가 추가한 도구가 유지된다.
+	next := *cfg
+	next.Security.ActionApproval.AllowedTools = nil
+	reloader := newConfigReloader(cfg, connectReloadRules(nil, gate, remote)...)
+	reloader.Apply(&next)
+	if err := checkTool("lint-go"); err != nil {
+		t.Errorf("hot-reload 후 서버가 추가한 도구가 사라졌습니다: %v", err)
+	}
+	if err := checkTool("local-tool"); err == nil {
+		t.Error("hot-reload로 제거한 로컬 도구가 허용되었습니다")
+	}
+
+	// 빈 배열은 서버가 추가한 도구를 모두 제거한다.
+	ack = remote.ApplyConfigUpdate(ws.ConfigUpdatePayload{AllowedTools: []string{}})
+	if len(ack.Applied) != 1 || len(ack.Effective.AllowedTools) != 0 {
+		t.Errorf("ack = %+v", ack)
+	}
+	if err := checkTool("lint-go"); err == nil {
+		t.Error("제거한 도구가 허용되었습니다")
+	}
+}
+
+func TestToolPermitted(t *testing.T) {
+	patterns := []string{"lint-*", "format"}
+	tests := []struct {
+		tool string
+		want bool
+	}{
+		{"lint-go", true},
+		{"lint-*", true},
+		{"lint-go*", true},
+		{"format", true},
+		{"format*", false},
+		{"l*", false},
+		{"deploy", false},
+	}
+	for _, tt := range tests {
+		if got := toolPermitted(patterns, tt.tool); got != tt.want {
+			t.Errorf("toolPermitted(%q) = %v, want %v", tt.tool, got, tt.want)
+		}
+	}
+}
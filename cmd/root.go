@@ -2,12 +2,17 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 
 	"github.com/insajin/autopus-bridge/internal/auth"
 	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/crash"
+	"github.com/insajin/autopus-bridge/internal/i18n"
 	"github.com/insajin/autopus-bridge/internal/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -17,6 +22,7 @@ var (
 	// 전역 플래그
 	cfgFile string
 	verbose bool
+	lang    string
 
 	// 버전 정보 (main에서 주입)
 	appVersion   string
@@ -34,16 +40,41 @@ var rootCmd = &cobra.Command{
 사용자의 Claude, Gemini, Codex 등의 API 키를 활용하여
 로컬에서 AI 작업을 실행합니다.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// 출력 언어 결정: --lang > 설정 파일 language > LANG 환경변수
+		if err := initLanguage(); err != nil {
+			return err
+		}
 		// 로거 초기화
-		return initLogger()
+		if err := initLogger(); err != nil {
+			return err
+		}
+		// 패닉 시 크래시 번들 저장
+		initCrashReporter()
+		return nil
 	},
 }
 
 // Execute는 루트 명령어를 실행합니다.
 func Execute() error {
+	defer crash.Recover("main")
+	applyCommandGroups(rootCmd)
+	registerDynamicCompletions()
 	return rootCmd.Execute()
 }
 
+// ExitCode는 명령 실행 결과 에러에 대응하는 프로세스 종료 코드를 반환합니다.
+// 종료 코드가 지정되지 않은 에러는 1을 반환합니다.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var coder interface{ ExitCode() int }
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return 1
+}
+
 // SetVersionInfo는 버전 정보를 설정합니다.
 func SetVersionInfo(version, commit, buildDate string) {
 	appVersion = version
@@ -66,11 +97,29 @@ func init() {
 		"설정 파일 경로 (기본값: ~/.config/autopus/config.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false,
 		"상세 로그 출력 (debug 레벨)")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "",
+		"출력 언어 (ko, en). 기본값: 설정 파일의 language 또는 LANG 환경변수")
+}
+
+// initLanguage는 CLI 출력과 작업 에러 메시지의 언어를 설정합니다.
+func initLanguage() error {
+	selected, err := i18n.Detect(lang, viper.GetString("language"))
+	if err != nil {
+		return err
+	}
+	i18n.SetLang(selected)
+	return nil
 }
 
 // initConfig는 설정 파일을 초기화합니다.
 // REQ-U-04: 설정 우선순위 - 환경변수 > 설정파일 > 기본값
 func initConfig() {
+	// 설정 파일을 읽기 전 메시지도 --lang/LANG을 따르도록 먼저 언어를 맞춘다.
+	// 지원하지 않는 값의 에러는 initLanguage에서 보고한다.
+	if selected, err := i18n.Detect(lang); err == nil {
+		i18n.SetLang(selected)
+	}
+
 	if cfgFile != "" {
 		// 명시적 설정 파일 사용
 		viper.SetConfigFile(cfgFile)
@@ -78,7 +127,7 @@ func initConfig() {
 		// 기본 설정 경로: ~/.config/autopus/config.yaml
 		home, err := os.UserHomeDir()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "홈 디렉토리를 찾을 수 없습니다: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.config.home_not_found", err))
 			os.Exit(1)
 		}
 
@@ -99,59 +148,238 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 			// 설정 파일이 있지만 읽기 실패한 경우만 오류
-			fmt.Fprintf(os.Stderr, "설정 파일 읽기 실패: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.config.read_failed", err))
 		}
 	}
+
+	// 전환 기간 동안 이전 레이아웃의 키도 현재 키로 읽고, 바뀐 이름을 한 번 안내한다.
+	warnLegacyConfigKeys(os.Stderr, config.ApplyLegacyKeys(viper.GetViper()))
+}
+
+// legacyConfigWarned는 이번 실행에서 이전 설정 키 경고를 이미 출력했는지 여부입니다.
+var legacyConfigWarned atomic.Bool
+
+// warnLegacyConfigKeys는 설정 파일의 이전 키와 현재 키 이름을 실행당 한 번만 출력합니다.
+func warnLegacyConfigKeys(w io.Writer, keys []config.LegacyKey) {
+	if len(keys) == 0 || !legacyConfigWarned.CompareAndSwap(false, true) {
+		return
+	}
+	fmt.Fprintln(w, i18n.T("cmd.config.legacy_keys"))
+	for _, key := range keys {
+		fmt.Fprintf(w, "  %s\n", key)
+	}
+	fmt.Fprintln(w, i18n.T("cmd.config.legacy_keys_hint"))
 }
 
 // setDefaults는 기본 설정값을 정의합니다.
 func setDefaults() {
+	applyDefaults(viper.GetViper())
+}
+
+// applyDefaults는 v에 기본 설정값을 등록합니다.
+// config schema 명령도 같은 함수로 기본값 문서를 만듭니다.
+func applyDefaults(v *viper.Viper) {
 	// 서버 설정
-	viper.SetDefault("server.url", "wss://api.autopus.co/ws/agent")
-	viper.SetDefault("server.timeout_seconds", 30)
+	v.SetDefault("server.ws_url", "wss://api.autopus.co/ws/agent")
+	v.SetDefault("server.timeout_seconds", 30)
+	v.SetDefault("server.compression.enabled", true)
+	v.SetDefault("server.compression.gzip_threshold_kb", 0)
+	v.SetDefault("server.transport", "auto")
 
 	// 인증 설정
 	home, _ := os.UserHomeDir()
-	viper.SetDefault("auth.token_file", filepath.Join(home, ".config", "autopus", "token"))
+	v.SetDefault("auth.token_file", filepath.Join(home, ".config", "autopus", "token"))
+	v.SetDefault("auth.sso.flow", "auto")
+	v.SetDefault("auth.expiry.warn_hours", 72)
+	v.SetDefault("auth.expiry.pause_intake", false)
 
 	// Claude 프로바이더 설정
-	viper.SetDefault("providers.claude.api_key_env", "CLAUDE_API_KEY")
-	viper.SetDefault("providers.claude.default_model", "claude-sonnet-4-20250514")
+	v.SetDefault("providers.claude.api_key_env", "CLAUDE_API_KEY")
+	v.SetDefault("providers.claude.default_model", "claude-sonnet-4-20250514")
 
 	// Gemini 프로바이더 설정
-	viper.SetDefault("providers.gemini.api_key_env", "GEMINI_API_KEY")
-	viper.SetDefault("providers.gemini.default_model", "gemini-2.0-flash")
+	v.SetDefault("providers.gemini.api_key_env", "GEMINI_API_KEY")
+	v.SetDefault("providers.gemini.default_model", "gemini-2.0-flash")
 
 	// Codex 프로바이더 설정
-	viper.SetDefault("providers.codex.api_key_env", "OPENAI_API_KEY")
-	viper.SetDefault("providers.codex.default_model", "gpt-5.4")
+	v.SetDefault("providers.codex.api_key_env", "OPENAI_API_KEY")
+	v.SetDefault("providers.codex.default_model", "gpt-5.4")
+
+	// OpenAI 호환 HTTP API 프로바이더 설정
+	v.SetDefault("providers.openai_compat.enabled", false)
+	v.SetDefault("providers.openai_compat.name", "openai-compat")
+	v.SetDefault("providers.openai_compat.timeout_seconds", 300)
+
+	// 프로바이더 warm-up 설정
+	v.SetDefault("providers.warmup.enabled", false)
+	v.SetDefault("providers.warmup.timeout_seconds", 30)
+
+	// 파일 동기화 설정
+	v.SetDefault("file_sync.enabled", false)
+	v.SetDefault("file_sync.use_gitignore", true)
+	v.SetDefault("file_sync.max_file_size_kb", 512)
+	v.SetDefault("file_sync.debounce_ms", 1000)
+
+	// 정상 종료 드레이닝 설정
+	v.SetDefault("shutdown.grace_period_seconds", 30)
+
+	// 유휴 절전 모드 설정
+	v.SetDefault("idle_mode.enabled", false)
+	v.SetDefault("idle_mode.idle_minutes", 15)
+	v.SetDefault("idle_mode.heartbeat_interval_seconds", 300)
+
+	// 작업 결과 캐시 설정
+	v.SetDefault("result_cache.enabled", true)
+	v.SetDefault("result_cache.window_seconds", 600)
+	v.SetDefault("result_cache.match_prompt_hash", true)
+
+	// Bridge 간 작업 위임 설정
+	v.SetDefault("delegation.enabled", false)
+	v.SetDefault("delegation.fallback_local", true)
+	v.SetDefault("delegation.timeout_seconds", 30)
+
+	// 작업 체크포인트 설정
+	v.SetDefault("task_checkpoint.enabled", true)
+	v.SetDefault("task_checkpoint.dir", "")
+	v.SetDefault("task_checkpoint.interval_seconds", 10)
+	v.SetDefault("task_checkpoint.max_age_hours", 24)
+	v.SetDefault("transcript.enabled", true)
+	v.SetDefault("transcript.dir", "")
+	v.SetDefault("transcript.max_age_days", 7)
+	v.SetDefault("history.enabled", true)
+	v.SetDefault("history.max_entries", 500)
+	v.SetDefault("work_dirs.allowed_roots", []string{})
+	v.SetDefault("work_dirs.per_execution", false)
+
+	// 로컬 상태 저장소 설정
+	v.SetDefault("state_store.enabled", false)
+	v.SetDefault("state_store.dir", "")
+	v.SetDefault("state_store.encryption", "keychain")
+	v.SetDefault("state_store.key_env", "AUTOPUS_STATE_KEY")
+
+	// 대화 연속성 설정
+	v.SetDefault("conversation.enabled", true)
+	v.SetDefault("conversation.ttl_minutes", 30)
+
+	// 서버 주도 설정 변경 허용 범위
+	v.SetDefault("remote_settings.enabled", true)
+	v.SetDefault("remote_settings.max_concurrent_tasks", 8)
+	v.SetDefault("remote_settings.allowed_tools", []string{})
+	v.SetDefault("remote_settings.allowed_log_levels", []string{"debug", "info", "warn", "error"})
+
+	// 크래시 리포트 설정
+	v.SetDefault("crash_report.enabled", true)
+	v.SetDefault("crash_report.dir", "")
+	v.SetDefault("crash_report.auto_upload", false)
+	v.SetDefault("crash_report.max_reports", 20)
+
+	// 코드 생성 샌드박스 할당량 설정
+	v.SetDefault("codegen_sandbox.max_total_mb", 1024)
+	v.SetDefault("codegen_sandbox.max_service_mb", 100)
+	v.SetDefault("codegen_sandbox.max_age_hours", 24)
+	v.SetDefault("mcp_notifications.enabled", true)
+	v.SetDefault("mcp_notifications.interval_seconds", 5)
+	v.SetDefault("mcp_notifications.max_events", 50)
+	v.SetDefault("mcp_notifications.min_level", "info")
+
+	// 트레이싱 설정
+	v.SetDefault("tracing.enabled", false)
+	v.SetDefault("tracing.endpoint", "localhost:4318")
+	v.SetDefault("tracing.insecure", false)
+	v.SetDefault("tracing.service_name", "autopus-bridge")
+	v.SetDefault("tracing.sample_ratio", 1.0)
 
 	// 로깅 설정
-	viper.SetDefault("logging.level", "info")
-	viper.SetDefault("logging.format", "json")
-	viper.SetDefault("logging.file", "")
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "json")
+	v.SetDefault("logging.file", "")
 
 	// 재연결 설정
-	viper.SetDefault("reconnection.max_attempts", 10)
-	viper.SetDefault("reconnection.initial_delay_ms", 1000)
-	viper.SetDefault("reconnection.max_delay_ms", 60000)
-	viper.SetDefault("reconnection.backoff_multiplier", 2.0)
+	v.SetDefault("reconnection.max_attempts", 10)
+	v.SetDefault("reconnection.initial_delay_ms", 1000)
+	v.SetDefault("reconnection.max_delay_ms", 60000)
+	v.SetDefault("reconnection.backoff_multiplier", 2.0)
 
 	// 보안 설정 - 샌드박스 (SEC-P2-03)
-	viper.SetDefault("security.sandbox.enabled", true)
-	viper.SetDefault("security.sandbox.allowed_paths", []string{"~/projects", "~/workspace"})
-	viper.SetDefault("security.sandbox.denied_paths", []string{"~/.ssh", "~/.gnupg", "~/.config", "~/.aws", "/etc", "/var"})
-	viper.SetDefault("security.sandbox.deny_hidden_dirs", true)
+	v.SetDefault("security.sandbox.enabled", true)
+	v.SetDefault("security.sandbox.allowed_paths", []string{"~/projects", "~/workspace"})
+	v.SetDefault("security.sandbox.denied_paths", []string{"~/.ssh", "~/.gnupg", "~/.config", "~/.aws", "/etc", "/var"})
+	v.SetDefault("security.sandbox.deny_hidden_dirs", true)
+	v.SetDefault("security.action_approval.cli_request", "auto")
+	v.SetDefault("security.action_approval.mcp_deploy", "auto")
+	v.SetDefault("security.action_approval.computer_use", "auto")
+	v.SetDefault("security.action_approval.apply_changes", "auto")
+	v.SetDefault("security.action_approval.custom_tool", "auto")
+	v.SetDefault("security.action_approval.git_request", "auto")
+	v.SetDefault("security.action_approval.prompt_timeout_seconds", 60)
+	v.SetDefault("security.workdir_isolation.enabled", false)
+	v.SetDefault("security.workdir_isolation.base_dir", "")
+	v.SetDefault("security.workdir_isolation.max_size_mb", 1024)
+	v.SetDefault("security.redaction.enabled", true)
+	v.SetDefault("security.redaction.emails", false)
+	v.SetDefault("security.redaction.entropy", true)
+	v.SetDefault("security.redaction.entropy_threshold", 4.2)
+	v.SetDefault("security.redaction.entropy_min_length", 24)
+	v.SetDefault("security.provider_sandbox.enabled", false)
+	v.SetDefault("security.provider_sandbox.allowed_write_roots", []string{})
+	v.SetDefault("security.replay_protection.enabled", true)
+	v.SetDefault("security.replay_protection.window_seconds", 300)
+	v.SetDefault("security.replay_protection.action", "reject")
+	v.SetDefault("security.replay_protection.max_entries", 10000)
+
+	// 읽기 전용 모드 기본값 (connect --read-only)
+	v.SetDefault("read_only", false)
+
+	// 프로바이더 기록/재생 기본값 (off = 항상 프로바이더 실행)
+	v.SetDefault("provider_replay.mode", "off")
+	v.SetDefault("provider_replay.dir", "")
+
+	// 데스크톱 알림 기본값 (기본 비활성)
+	v.SetDefault("notifications.enabled", false)
+	v.SetDefault("notifications.dedup_seconds", 60)
+
+	// 워크스페이스별 동시 실행 한도 기본값 (0 = 제한 없음)
+	v.SetDefault("concurrency.per_workspace", 0)
+
+	// 실행 타임아웃 계층 기본값 (0 = 실행기 기본값, 요청 값 제한 없음)
+	v.SetDefault("timeouts.default_seconds", 0)
+	v.SetDefault("timeouts.max_seconds", 0)
+
+	// Bridge 식별 정보 기본값 (빈 이름 = 호스트 이름)
+	v.SetDefault("identity.name", "")
+
+	// 작업 입력/결과 크기 제한 기본값 (프롬프트 0 = 제한 없음)
+	v.SetDefault("limits.max_prompt_bytes", 0)
+	v.SetDefault("limits.max_output_bytes", 256*1024)
+	v.SetDefault("limits.max_result_bytes", 900*1024)
+	v.SetDefault("limits.overflow_dir", "")
+	v.SetDefault("limits.overflow_max_age_days", 7)
+
+	// 업로드 대역폭 제한 기본값 (0 = 제한 없음)
+	v.SetDefault("upload.max_kbps", 0)
+	v.SetDefault("upload.priorities.screenshot", 0)
+	v.SetDefault("upload.priorities.result", 1)
+	v.SetDefault("upload.priorities.artifact", 2)
 
 	// Computer Use 기본값 (SPEC-COMPUTER-USE-002)
-	viper.SetDefault("computer_use.isolation", "auto")
-	viper.SetDefault("computer_use.max_containers", 5)
-	viper.SetDefault("computer_use.warm_pool_size", 2)
-	viper.SetDefault("computer_use.image", "autopus/chromium-sandbox:latest")
-	viper.SetDefault("computer_use.container_memory", "512m")
-	viper.SetDefault("computer_use.container_cpu", "1.0")
-	viper.SetDefault("computer_use.idle_timeout", "5m")
-	viper.SetDefault("computer_use.network", "autopus-sandbox-net")
+	v.SetDefault("computer_use.isolation", "auto")
+	v.SetDefault("computer_use.max_containers", 5)
+	v.SetDefault("computer_use.warm_pool_size", 2)
+	v.SetDefault("computer_use.image", "autopus/chromium-sandbox:latest")
+	v.SetDefault("computer_use.container_memory", "512m")
+	v.SetDefault("computer_use.container_cpu", "1.0")
+	v.SetDefault("computer_use.idle_timeout", "5m")
+	v.SetDefault("computer_use.network", "autopus-sandbox-net")
+	v.SetDefault("computer_use.image_version", "")
+	v.SetDefault("computer_use.image_digest", "")
+	v.SetDefault("computer_use.platform", "auto")
+	v.SetDefault("computer_use.update_check_interval", "24h")
+	v.SetDefault("computer_use.max_sessions", 2)
+	v.SetDefault("computer_use.queue_timeout", "2m")
+	v.SetDefault("computer_use.session_memory", "")
+	v.SetDefault("computer_use.session_cpu", "")
+	v.SetDefault("computer_use.profile_dir", "")
+	v.SetDefault("computer_use.profile_encryption_key_env", "")
 }
 
 // initLogger는 로거를 초기화합니다.
@@ -0,0 +1,299 @@
+// sandbox.go는 Computer Use 샌드박스 이미지와 전용 네트워크 관리 명령을 구현합니다.
+// sandbox update/prune 서브커맨드
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/computeruse"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	// sandboxCommandTimeout은 sandbox 명령 전체 타임아웃입니다 (이미지 풀 포함).
+	sandboxCommandTimeout = 15 * time.Minute
+	// sandboxLastCheckFile은 마지막 샌드박스 이미지 업데이트 확인 시간을 저장하는 파일명입니다.
+	sandboxLastCheckFile = ".last_sandbox_image_check"
+)
+
+// sandboxImageManager는 sandbox 명령이 사용하는 이미지 관리 기능입니다.
+// computeruse.ImageManager가 구현하며, 테스트에서 대체할 수 있습니다.
+type sandboxImageManager interface {
+	Pull(ctx context.Context, spec computeruse.ImageSpec) error
+	VerifyDigest(ctx context.Context, spec computeruse.ImageSpec) error
+	CheckUpdate(ctx context.Context, spec computeruse.ImageSpec) (bool, string, error)
+	EnsureNetwork(ctx context.Context, network string) (bool, error)
+	Prune(ctx context.Context, spec computeruse.ImageSpec, network string, removeNetwork bool) (*computeruse.PruneResult, error)
+}
+
+var newSandboxImageManager = func() sandboxImageManager {
+	return computeruse.NewImageManager(computeruse.NewCLIDockerClient(""))
+}
+
+var (
+	sandboxYes          bool
+	sandboxCheckOnly    bool
+	sandboxPruneNetwork bool
+)
+
+// sandboxCmd는 sandbox 서브커맨드의 루트입니다.
+var sandboxCmd = &cobra.Command{
+	Use:   "sandbox",
+	Short: "Computer Use 샌드박스 이미지 관리",
+	Long: `Computer Use에 사용하는 Chromium 샌드박스 Docker 이미지와 전용 네트워크를 관리합니다.
+
+이미지 버전 고정 설정 (config.yaml):
+  computer_use:
+    image: autopus/chromium-sandbox
+    image_version: "1.4.0"          # 고정할 태그 (비어 있으면 latest)
+    image_digest: "sha256:..."      # 설정 시 digest 검증
+    platform: auto                  # auto, linux/amd64, linux/arm64
+    update_check_interval: 24h      # autopus up 실행 시 업데이트 확인 주기 ("0"이면 비활성화)`,
+}
+
+var sandboxUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "샌드박스 이미지를 확인하고 업데이트합니다",
+	Long: `설정된 샌드박스 이미지의 새 버전을 확인하고, 확인 후 풀합니다.
+digest가 고정된 경우 해당 digest의 이미지를 설치하고 검증합니다.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, network, err := sandboxSettingsFromConfig()
+		if err != nil {
+			return err
+		}
+		if err := requireDocker(); err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), sandboxCommandTimeout)
+		defer cancel()
+		return runSandboxUpdate(ctx, cmd.OutOrStdout(), bufio.NewScanner(os.Stdin), newSandboxImageManager(), spec, network, sandboxUpdateOptions{
+			yes:       sandboxYes,
+			checkOnly: sandboxCheckOnly,
+		})
+	},
+}
+
+var sandboxPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "사용하지 않는 샌드박스 이미지와 컨테이너를 정리합니다",
+	Long: `종료된 샌드박스 컨테이너와 현재 설정에서 사용하지 않는 샌드박스 이미지를 삭제합니다.
+--network를 지정하면 연결된 컨테이너가 없는 전용 네트워크도 삭제합니다.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, network, err := sandboxSettingsFromConfig()
+		if err != nil {
+			return err
+		}
+		if err := requireDocker(); err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(cmd.Context(), sandboxCommandTimeout)
+		defer cancel()
+		return runSandboxPrune(ctx, cmd.OutOrStdout(), bufio.NewScanner(os.Stdin), newSandboxImageManager(), spec, network, sandboxPruneNetwork, sandboxYes)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sandboxCmd)
+	sandboxCmd.AddCommand(sandboxUpdateCmd)
+	sandboxCmd.AddCommand(sandboxPruneCmd)
+
+	for _, sub := range []*cobra.Command{sandboxUpdateCmd, sandboxPruneCmd} {
+		sub.Flags().BoolVarP(&sandboxYes, "yes", "y", false, "확인 없이 진행")
+	}
+	sandboxUpdateCmd.Flags().BoolVar(&sandboxCheckOnly, "check", false, "업데이트 여부만 확인하고 풀하지 않음")
+	sandboxPruneCmd.Flags().BoolVar(&sandboxPruneNetwork, "network", false, "사용 중이 아닌 전용 네트워크도 삭제")
+}
+
+// sandboxUpdateOptions는 sandbox update 실행 옵션입니다.
+type sandboxUpdateOptions struct {
+	yes       bool
+	checkOnly bool
+}
+
+// runSandboxUpdate는 샌드박스 이미지 업데이트를 확인하고, 사용자 확인 후 풀합니다.
+func runSandboxUpdate(ctx context.Context, out io.Writer, scanner *bufio.Scanner, mgr sandboxImageManager, spec computeruse.ImageSpec, network string, opts sandboxUpdateOptions) error {
+	fmt.Fprintf(out, "이미지:   %s\n", spec.Reference())
+	fmt.Fprintf(out, "플랫폼:   %s\n", spec.Platform)
+
+	if spec.Digest != "" {
+		// digest 고정: 업데이트 확인 없이 해당 digest를 설치/검증
+		if err := mgr.VerifyDigest(ctx, spec); err == nil {
+			fmt.Fprintln(out, "고정된 digest의 이미지가 이미 설치되어 있습니다")
+		} else if opts.checkOnly {
+			fmt.Fprintf(out, "고정된 digest의 이미지가 필요합니다: %v\n", err)
+			return nil
+		} else {
+			fmt.Fprintln(out, "고정된 digest의 이미지를 가져오는 중...")
+			if err := mgr.Pull(ctx, spec); err != nil {
+				return fmt.Errorf("샌드박스 이미지 설치 실패: %w", err)
+			}
+			fmt.Fprintln(out, "이미지 설치 및 digest 검증 완료")
+		}
+		return ensureSandboxNetwork(ctx, out, mgr, network, opts.checkOnly)
+	}
+
+	hasUpdate, remoteDigest, err := mgr.CheckUpdate(ctx, spec)
+	recordSandboxUpdateCheck()
+	if err != nil {
+		return fmt.Errorf("업데이트 확인 실패: %w", err)
+	}
+	if !hasUpdate {
+		fmt.Fprintln(out, "샌드박스 이미지가 최신입니다")
+		return ensureSandboxNetwork(ctx, out, mgr, network, opts.checkOnly)
+	}
+
+	fmt.Fprintf(out, "새 이미지가 있습니다: %s\n", remoteDigest)
+	if opts.checkOnly {
+		fmt.Fprintln(out, "'autopus-bridge sandbox update'로 업데이트하세요")
+		return nil
+	}
+	if !opts.yes {
+		fmt.Fprint(out, "지금 업데이트하시겠습니까? [Y/n]: ")
+		if !scanYesNoDefault(scanner, true) {
+			fmt.Fprintln(out, "업데이트를 건너뜁니다")
+			return nil
+		}
+	}
+
+	if err := mgr.Pull(ctx, spec); err != nil {
+		return fmt.Errorf("샌드박스 이미지 업데이트 실패: %w", err)
+	}
+	fmt.Fprintf(out, "이미지 업데이트 완료: %s\n", spec.TaggedReference())
+	fmt.Fprintf(out, "재현 가능한 실행을 위해 computer_use.image_digest: %s 로 고정할 수 있습니다\n", remoteDigest)
+	return ensureSandboxNetwork(ctx, out, mgr, network, false)
+}
+
+// ensureSandboxNetwork는 샌드박스 전용 네트워크를 확인하고 없으면 생성합니다.
+func ensureSandboxNetwork(ctx context.Context, out io.Writer, mgr sandboxImageManager, network string, checkOnly bool) error {
+	if network == "" || checkOnly {
+		return nil
+	}
+	created, err := mgr.EnsureNetwork(ctx, network)
+	if err != nil {
+		return err
+	}
+	if created {
+		fmt.Fprintf(out, "네트워크 생성됨: %s\n", network)
+	}
+	return nil
+}
+
+// runSandboxPrune은 사용하지 않는 샌드박스 리소스를 정리합니다.
+func runSandboxPrune(ctx context.Context, out io.Writer, scanner *bufio.Scanner, mgr sandboxImageManager, spec computeruse.ImageSpec, network string, removeNetwork, yes bool) error {
+	fmt.Fprintf(out, "유지할 이미지: %s\n", spec.TaggedReference())
+	if !yes {
+		target := "종료된 샌드박스 컨테이너와 사용하지 않는 샌드박스 이미지"
+		if removeNetwork {
+			target += ", 전용 네트워크(" + network + ")"
+		}
+		fmt.Fprintf(out, "%s를 삭제합니다. 계속하시겠습니까? [y/N]: ", target)
+		if !scanYesNo(scanner) {
+			fmt.Fprintln(out, "취소되었습니다")
+			return nil
+		}
+	}
+
+	result, err := mgr.Prune(ctx, spec, network, removeNetwork)
+	if err != nil {
+		return fmt.Errorf("샌드박스 정리 실패: %w", err)
+	}
+
+	fmt.Fprintf(out, "삭제된 컨테이너: %d개\n", len(result.RemovedContainers))
+	fmt.Fprintf(out, "삭제된 이미지:   %d개\n", len(result.RemovedImages))
+	if removeNetwork {
+		if result.NetworkRemoved {
+			fmt.Fprintf(out, "네트워크 삭제됨: %s\n", network)
+		} else {
+			fmt.Fprintf(out, "네트워크 유지됨: %s (사용 중이거나 존재하지 않음)\n", network)
+		}
+	}
+	return nil
+}
+
+// sandboxSettingsFromConfig는 설정에서 샌드박스 이미지 스펙과 네트워크 이름을 읽습니다.
+func sandboxSettingsFromConfig() (computeruse.ImageSpec, string, error) {
+	spec, err := computeruse.NewImageSpec(
+		viper.GetString("computer_use.image"),
+		viper.GetString("computer_use.image_version"),
+		viper.GetString("computer_use.image_digest"),
+		viper.GetString("computer_use.platform"),
+	)
+	if err != nil {
+		return computeruse.ImageSpec{}, "", fmt.Errorf("샌드박스 이미지 설정 오류: %w", err)
+	}
+	network := viper.GetString("computer_use.network")
+	if network == "" {
+		network = computeruse.DefaultNetworkName
+	}
+	return spec, network, nil
+}
+
+// requireDocker는 Docker CLI와 데몬을 사용할 수 있는지 확인합니다.
+func requireDocker() error {
+	dockerPath, err := exec.LookPath("docker")
+	if err != nil {
+		return fmt.Errorf("docker가 설치되어 있지 않습니다")
+	}
+	if err := exec.Command(dockerPath, "info").Run(); err != nil {
+		return fmt.Errorf("docker 데몬이 실행 중이 아닙니다: %w", err)
+	}
+	return nil
+}
+
+// sandboxUpdateCheckInterval은 설정된 샌드박스 이미지 업데이트 확인 주기를 반환합니다.
+func sandboxUpdateCheckInterval() time.Duration {
+	cu := config.ComputerUseConfig{UpdateCheckInterval: viper.GetString("computer_use.update_check_interval")}
+	return cu.GetUpdateCheckInterval()
+}
+
+// sandboxUpdateCheckDue는 마지막 확인 이후 interval이 지났는지 반환합니다.
+// interval이 0 이하이면 주기적 확인이 비활성화된 것으로 보고 false를 반환합니다.
+func sandboxUpdateCheckDue(interval time.Duration) bool {
+	if interval <= 0 {
+		return false
+	}
+	path, err := sandboxLastCheckPath()
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return true
+	}
+	return time.Since(last) >= interval
+}
+
+// recordSandboxUpdateCheck는 현재 시간을 마지막 업데이트 확인 시간으로 저장합니다.
+func recordSandboxUpdateCheck() {
+	path, err := sandboxLastCheckPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(time.Now().Format(time.RFC3339)), 0600)
+}
+
+// sandboxLastCheckPath는 마지막 확인 시간 파일 경로를 반환합니다.
+func sandboxLastCheckPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "autopus", sandboxLastCheckFile), nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/computeruse"
+)
+
+const sandboxTestDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// fakeSandboxImageManager는 sandbox 명령 테스트용 이미지 관리자입니다.
+type fakeSandboxImageManager struct {
+	hasUpdate    bool
+	remoteDigest string
+	verifyErr    error
+	pruneResult  *computeruse.PruneResult
+
+	pulled         bool
+	networkEnsured bool
+	pruned         bool
+}
+
+func (f *fakeSandboxImageManager) Pull(context.Context, computeruse.ImageSpec) error {
+	f.pulled = true
+	return nil
+}
+
+func (f *fakeSandboxImageManager) VerifyDigest(context.Context, computeruse.ImageSpec) error {
+	return f.verifyErr
+}
+
+func (f *fakeSandboxImageManager) CheckUpdate(context.Context, computeruse.ImageSpec) (bool, string, error) {
+	return f.hasUpdate, f.remoteDigest, nil
+}
+
+func (f *fakeSandboxImageManager) EnsureNetwork(context.Context, string) (bool, error) {
+	f.networkEnsured = true
+	return true, nil
+}
+
+func (f *fakeSandboxImageManager) Prune(context.Context, computeruse.ImageSpec, string, bool) (*computeruse.PruneResult, error) {
+	f.pruned = true
+	if f.pruneResult == nil {
+		return &computeruse.PruneResult{}, nil
+	}
+	return f.pruneResult, nil
+}
+
+func sandboxTestSpec(t *testing.T, digest string) computeruse.ImageSpec {
+	t.Helper()
+	spec, err := computeruse.NewImageSpec("", "", digest, "amd64")
+	if err != nil {
+		t.Fatalf("NewImageSpec 실패: %v", err)
+	}
+	return spec
+}
+
+func TestRunSandboxUpdate_PromptsBeforePull(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name       string
+		input      string
+		wantPulled bool
+	}{
+		{"기본값 수락", "\n", true},
+		{"거부", "n\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := &fakeSandboxImageManager{hasUpdate: true, remoteDigest: sandboxTestDigest}
+			var out bytes.Buffer
+			scanner := bufio.NewScanner(strings.NewReader(tt.input))
+
+			err := runSandboxUpdate(context.Background(), &out, scanner, mgr, sandboxTestSpec(t, ""), computeruse.DefaultNetworkName, sandboxUpdateOptions{})
+			if err != nil {
+				t.Fatalf("runSandboxUpdate 실패: %v", err)
+			}
+			if mgr.pulled != tt.wantPulled {
+				t.Errorf("pulled = %v, want %v\n출력:\n%s", mgr.pulled, tt.wantPulled, out.String())
+			}
+			if !strings.Contains(out.String(), "[Y/n]") {
+				t.Errorf("업데이트 확인 프롬프트가 출력되어야 합니다:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestRunSandboxUpdate_CheckOnly(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mgr := &fakeSandboxImageManager{hasUpdate: true, remoteDigest: sandboxTestDigest}
+	var out bytes.Buffer
+
+	err := runSandboxUpdate(context.Background(), &out, bufio.NewScanner(strings.NewReader("")), mgr, sandboxTestSpec(t, ""), computeruse.DefaultNetworkName, sandboxUpdateOptions{checkOnly: true})
+	if err != nil {
+		t.Fatalf("runSandboxUpdate 실패: %v", err)
+	}
+	if mgr.pulled || mgr.networkEnsured {
+		t.Error("--check는 이미지나 네트워크를 변경하지 않아야 합니다")
+	}
+	if !strings.Contains(out.String(), sandboxTestDigest) {
+		t.Errorf("새 이미지 digest가 출력되어야 합니다:\n%s", out.String())
+	}
+}
+
+func TestRunSandboxUpdate_PinnedDigest(t *testing.T) {
+	mgr := &fakeSandboxImageManager{verifyErr: computeruse.ErrDigestMismatch}
+	var out bytes.Buffer
+
+	err := runSandboxUpdate(context.Background(), &out, bufio.NewScanner(strings.NewReader("")), mgr, sandboxTestSpec(t, sandboxTestDigest), computeruse.DefaultNetworkName, sandboxUpdateOptions{})
+	if err != nil {
+		t.Fatalf("runSandboxUpdate 실패: %v", err)
+	}
+	if !mgr.pulled {
+		t.Error("digest가 일치하지 않으면 고정된 이미지를 풀해야 합니다")
+	}
+	if !mgr.networkEnsured {
+		t.Error("전용 네트워크를 확인해야 합니다")
+	}
+}
+
+func TestRunSandboxPrune(t *testing.T) {
+	t.Run("취소", func(t *testing.T) {
+		mgr := &fakeSandboxImageManager{}
+		var out bytes.Buffer
+		err := runSandboxPrune(context.Background(), &out, bufio.NewScanner(strings.NewReader("\n")), mgr, sandboxTestSpec(t, ""), computeruse.DefaultNetworkName, false, false)
+		if err != nil {
+			t.Fatalf("runSandboxPrune 실패: %v", err)
+		}
+		if mgr.pruned {
+			t.Error("확인하지 않으면 정리하지 않아야 합니다")
+		}
+	})
+
+	t.Run("--yes", func(t *testing.T) {
+		mgr := &fakeSandboxImageManager{pruneResult: &computeruse.PruneResult{
+			RemovedContainers: []string{"c1"},
+			RemovedImages:     []string{"sha256:old1", "sha256:old2"},
+			NetworkRemoved:    true,
+		}}
+		var out bytes.Buffer
+		err := runSandboxPrune(context.Background(), &out, bufio.NewScanner(strings.NewReader("")), mgr, sandboxTestSpec(t, ""), computeruse.DefaultNetworkName, true, true)
+		if err != nil {
+			t.Fatalf("runSandboxPrune 실패: %v", err)
+		}
+		got := out.String()
+		for _, want := range []string{"삭제된 컨테이너: 1개", "삭제된 이미지:   2개", "네트워크 삭제됨: " + computeruse.DefaultNetworkName} {
+			if !strings.Contains(got, want) {
+				t.Errorf("출력에 %q가 없습니다:\n%s", want, got)
+			}
+		}
+	})
+}
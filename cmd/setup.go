@@ -332,7 +332,7 @@ type setupConfig struct {
 }
 
 type setupServerConfig struct {
-	URL            string `yaml:"url"`
+	URL            string `yaml:"ws_url"`
 	TimeoutSeconds int    `yaml:"timeout_seconds"`
 }
 
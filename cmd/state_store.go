@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/executor"
+	"github.com/insajin/autopus-bridge/internal/store"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+)
+
+const (
+	// outboxBucket은 상태 저장소에서 아웃박스가 사용하는 버킷 이름입니다.
+	outboxBucket = "outbox"
+	// historyBucket은 상태 저장소에서 로컬 실행 이력이 사용하는 버킷 이름입니다.
+	historyBucket = "task_history"
+	// replayBucket은 상태 저장소에서 프로바이더 기록/재생이 사용하는 버킷 이름입니다.
+	replayBucket = "provider_replay"
+)
+
+// openStateStore는 설정에 따라 워크스페이스 범위의 상태 저장소를 엽니다. 비활성화된 경우 nil을 반환합니다.
+func openStateStore(cfg config.StateStoreConfig, workspaceID string) (*store.Store, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	key, err := stateStoreKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var opts []store.Option
+	if key != nil {
+		opts = append(opts, store.WithEncryptionKey(key))
+	}
+	return store.Open(getStateStorePath(cfg, workspaceID), opts...)
+}
+
+// openStateStoreReadOnly는 connect가 쓰고 있을 수 있는 상태 저장소를 조회용으로 엽니다.
+// 비활성화된 경우 nil을 반환합니다.
+func openStateStoreReadOnly(cfg config.StateStoreConfig, workspaceID string) (*store.Store, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	key, err := stateStoreKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var opts []store.Option
+	if key != nil {
+		opts = append(opts, store.WithEncryptionKey(key))
+	}
+	return store.OpenReadOnly(getStateStorePath(cfg, workspaceID), opts...)
+}
+
+// getStateStorePath는 워크스페이스 범위의 상태 저장소 파일 경로를 반환합니다.
+func getStateStorePath(cfg config.StateStoreConfig, workspaceID string) string {
+	name := "state.db"
+	if workspaceID != "" {
+		name = "state-" + sanitizeWorkspaceScope(workspaceID) + ".db"
+	}
+	return filepath.Join(cfg.GetDir(), name)
+}
+
+// stateStoreKey는 state_store.encryption 설정에 따라 암호화 키를 가져옵니다. "none"이면 nil을 반환합니다.
+func stateStoreKey(cfg config.StateStoreConfig) ([]byte, error) {
+	switch cfg.GetEncryption() {
+	case "none":
+		return nil, nil
+	case "env":
+		return store.KeyFromEnv(cfg.GetKeyEnv())
+	default:
+		return store.KeychainKey(store.KeychainService, store.KeychainAccount)
+	}
+}
+
+// newConnectOutbox는 상태 저장소가 있으면 저장소 버킷에, 없으면 JSON 파일에 아웃박스를 보관합니다.
+// 저장소로 처음 옮길 때는 기존 아웃박스 파일을 가져온 뒤 삭제합니다.
+func newConnectOutbox(stateStore *store.Store, workspaceID string) (*websocket.Outbox, error) {
+	path := getScopedOutboxFilePath(workspaceID)
+	if stateStore == nil {
+		return websocket.NewOutbox(path, 0, 0)
+	}
+	return websocket.NewStoreOutbox(stateStore.Bucket(outboxBucket), path, 0, 0)
+}
+
+// newConnectHistory는 상태 저장소가 있으면 저장소 버킷에, 없으면 JSON 파일에 실행 이력을 보관합니다.
+func newConnectHistory(stateStore *store.Store, workspaceID string, maxEntries int) (*executor.HistoryStore, error) {
+	if stateStore == nil {
+		return executor.NewHistoryStore(getScopedHistoryFilePath(workspaceID), maxEntries)
+	}
+	return executor.NewStoreHistory(stateStore.Bucket(historyBucket), maxEntries)
+}
+
+// getScopedHistoryFilePath는 워크스페이스 범위의 실행 이력 파일 경로를 반환합니다.
+func getScopedHistoryFilePath(workspaceID string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	name := "history.json"
+	if workspaceID != "" {
+		name = "history-" + sanitizeWorkspaceScope(workspaceID) + ".json"
+	}
+	return filepath.Join(home, ".config", "autopus", name)
+}
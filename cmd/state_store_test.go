@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+)
+
+func TestOpenStateStore_DisabledReturnsNil(t *testing.T) {
+	st, err := openStateStore(config.StateStoreConfig{}, "ws-1")
+	if st != nil || err != nil {
+		t.Fatalf("openStateStore() = %v, %v; want nil, nil", st, err)
+	}
+}
+
+func TestOpenStateStore_EnvKeyAndWorkspaceScope(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AUTOPUS_TEST_STATE_KEY", "passphrase")
+	cfg := config.StateStoreConfig{Enabled: true, Dir: dir, Encryption: "env", KeyEnv: "AUTOPUS_TEST_STATE_KEY"}
+
+	st, err := openStateStore(cfg, "ws/1")
+	if err != nil {
+		t.Fatalf("openStateStore() error = %v", err)
+	}
+	defer func() { _ = st.Close() }()
+
+	if !st.Encrypted() {
+		t.Error("env 암호화 설정인데 저장소가 암호화되지 않음")
+	}
+	if want := filepath.Join(dir, "state-"+sanitizeWorkspaceScope("ws/1")+".db"); st.Path() != want {
+		t.Errorf("Path() = %q, want %q", st.Path(), want)
+	}
+
+	t.Setenv("AUTOPUS_TEST_STATE_KEY", "")
+	if _, err := openStateStore(cfg, "ws-2"); err == nil {
+		t.Error("키 환경 변수가 없는데 에러가 없음")
+	}
+}
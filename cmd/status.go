@@ -4,6 +4,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,8 +12,12 @@ import (
 	"syscall"
 	"time"
 
+	ws "github.com/insajin/autopus-agent-protocol"
 	"github.com/insajin/autopus-bridge/internal/auth"
 	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/insajin/autopus-bridge/internal/identity"
+	"github.com/mattn/go-runewidth"
 	"github.com/spf13/cobra"
 )
 
@@ -41,6 +46,26 @@ type StatusInfo struct {
 	OAuthMode string `json:"oauth_mode,omitempty"`
 	// OAuthProviders는 연결된 OAuth 프로바이더 목록입니다 (예: ["openai", "google"]).
 	OAuthProviders []string `json:"oauth_providers,omitempty"`
+	// Quality는 하트비트로 측정한 연결 품질입니다 (측정 전이면 생략).
+	Quality *ConnectionQualityStatus `json:"connection_quality,omitempty"`
+	// Identity는 서버에서 이 Bridge를 구분하는 고정 ID, 이름, 라벨입니다.
+	Identity *ws.BridgeIdentity `json:"identity,omitempty"`
+}
+
+// ConnectionQualityStatus는 상태 파일에 기록되는 연결 품질입니다.
+type ConnectionQualityStatus struct {
+	// Score는 0~100 사이의 품질 점수입니다.
+	Score int `json:"score"`
+	// Level은 품질 등급입니다 ("good" | "fair" | "poor").
+	Level string `json:"level"`
+	// AvgRTTMs는 최근 하트비트 평균 왕복 시간(ms)입니다.
+	AvgRTTMs int64 `json:"avg_rtt_ms"`
+	// LossPercent는 최근 하트비트 응답 누락 비율(%)입니다.
+	LossPercent int `json:"loss_percent"`
+	// RecentReconnects는 최근 10분간의 재연결 횟수입니다.
+	RecentReconnects int `json:"recent_reconnects"`
+	// HeartbeatIntervalSeconds는 현재 하트비트 간격(초)입니다.
+	HeartbeatIntervalSeconds int `json:"heartbeat_interval_seconds"`
 }
 
 // statusCmd는 현재 연결 상태를 확인하는 명령어입니다.
@@ -51,8 +76,10 @@ var statusCmd = &cobra.Command{
 
 표시 항목:
   - 연결 상태 (연결됨/연결되지 않음)
+  - Bridge 이름, 고정 ID, 라벨
   - 서버 URL
   - 연결 유지 시간
+  - 연결 품질 (점수, 하트비트 왕복 시간, 응답 누락, 재연결 횟수)
   - 현재 실행 중인 작업
   - 완료/실패한 작업 수
 
@@ -79,7 +106,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	// 상태 정보 수집
 	status, err := collectStatus()
 	if err != nil {
-		return fmt.Errorf("상태 수집 실패: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("cmd.status.collect_failed"), err)
 	}
 
 	// 출력 형식에 따라 표시
@@ -130,6 +157,13 @@ func collectStatus() (*StatusInfo, error) {
 		status.ServerURL = cfg.Server.URL
 	}
 
+	// 실행 중인 Bridge가 기록한 식별 정보가 없으면 설정과 저장된 Bridge ID로 표시 (ID는 생성하지 않음)
+	if status.Identity == nil && cfg != nil {
+		if id, err := identity.Load(identity.DefaultPath()); err == nil {
+			status.Identity = identity.New(id, cfg.Identity.Name, cfg.Identity.Labels)
+		}
+	}
+
 	return status, nil
 }
 
@@ -137,7 +171,7 @@ func collectStatus() (*StatusInfo, error) {
 func printStatusJSON(status *StatusInfo) error {
 	data, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
-		return fmt.Errorf("JSON 직렬화 실패: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("cmd.status.json_failed"), err)
 	}
 	fmt.Println(string(data))
 	return nil
@@ -155,59 +189,77 @@ func printStatusSimple(status *StatusInfo) error {
 
 // printStatusFull는 전체 형식으로 상태를 출력합니다.
 func printStatusFull(status *StatusInfo) error {
-	fmt.Println("Autopus Local Bridge 상태")
-	fmt.Println("========================")
+	title := i18n.T("cmd.status.title")
+	fmt.Println(title)
+	fmt.Println(strings.Repeat("=", runewidth.StringWidth(title)))
 	fmt.Println()
 
 	// 연결 상태
 	if status.Connected {
-		fmt.Println("상태:        연결됨")
+		fmt.Println(i18n.T("cmd.status.connected"))
 		if status.PID > 0 {
-			fmt.Printf("프로세스 ID: %d\n", status.PID)
+			fmt.Println(i18n.T("cmd.status.pid", status.PID))
 		}
 	} else {
-		fmt.Println("상태:        연결되지 않음")
+		fmt.Println(i18n.T("cmd.status.disconnected"))
 	}
 	if status.WorkspaceID != "" {
-		fmt.Printf("워크스페이스: %s\n", status.WorkspaceID)
+		fmt.Println(i18n.T("cmd.status.workspace", status.WorkspaceID))
+	}
+
+	// Bridge 식별 정보
+	if id := status.Identity; id != nil {
+		bridgeID := id.ID
+		if bridgeID == "" {
+			bridgeID = i18n.T("cmd.status.bridge_id_pending")
+		}
+		fmt.Println(i18n.T("cmd.status.bridge", id.Name, bridgeID))
+		if len(id.Labels) > 0 {
+			fmt.Println(i18n.T("cmd.status.labels", strings.Join(identity.FormatLabels(id.Labels), ", ")))
+		}
 	}
 
 	// AI 실행 모드 (SPEC-DOMAIN-PARALLEL-001 AC-9)
 	if status.OAuthMode != "" {
-		fmt.Printf("AI 모드:     %s\n", formatAIMode(status.OAuthMode))
+		fmt.Println(i18n.T("cmd.status.ai_mode", formatAIMode(status.OAuthMode)))
 	}
 	if len(status.OAuthProviders) > 0 {
-		fmt.Printf("OAuth 연결:  %s\n", strings.Join(status.OAuthProviders, ", "))
+		fmt.Println(i18n.T("cmd.status.oauth_providers", strings.Join(status.OAuthProviders, ", ")))
 	}
 
 	// 서버 URL
 	if status.ServerURL != "" {
-		fmt.Printf("서버:        %s\n", status.ServerURL)
+		fmt.Println(i18n.T("cmd.status.server", status.ServerURL))
 	}
 
 	// 연결 시간
 	if status.Connected && status.Uptime != "" {
-		fmt.Printf("연결 시간:   %s\n", status.Uptime)
+		fmt.Println(i18n.T("cmd.status.uptime", status.Uptime))
+	}
+
+	// 연결 품질
+	if status.Connected && status.Quality != nil {
+		q := status.Quality
+		fmt.Println(i18n.T("cmd.status.quality", q.Score, i18n.T("cmd.status.quality_"+q.Level)))
+		fmt.Println(i18n.T("cmd.status.quality_detail", q.AvgRTTMs, q.LossPercent, q.RecentReconnects, q.HeartbeatIntervalSeconds))
 	}
 
 	fmt.Println()
 
 	// 작업 상태
-	fmt.Println("작업 통계")
-	fmt.Println("---------")
+	printSectionTitle(i18n.T("cmd.status.tasks_title"))
 	if status.CurrentTask != "" {
-		fmt.Printf("현재 작업:   %s (실행 중)\n", status.CurrentTask)
+		fmt.Println(i18n.T("cmd.status.current_task", status.CurrentTask))
 	} else {
-		fmt.Println("현재 작업:   없음")
+		fmt.Println(i18n.T("cmd.status.no_current_task"))
 	}
-	fmt.Printf("완료됨:      %d\n", status.TasksCompleted)
-	fmt.Printf("실패함:      %d\n", status.TasksFailed)
+	fmt.Println(i18n.T("cmd.status.tasks_completed", status.TasksCompleted))
+	fmt.Println(i18n.T("cmd.status.tasks_failed", status.TasksFailed))
 
 	fmt.Println()
 
 	// 환경변수 상태
-	fmt.Println("환경변수 상태")
-	fmt.Println("-------------")
+	printSectionTitle(i18n.T("cmd.status.env_title"))
 	printEnvStatusForStatus("CLAUDE_API_KEY")
 	printEnvStatusForStatus("GEMINI_API_KEY")
 	printEnvStatusForStatus("LAB_TOKEN")
@@ -216,33 +268,37 @@ func printStatusFull(status *StatusInfo) error {
 
 	// 안내 메시지
 	if !status.Connected {
-		fmt.Println("서버에 연결하려면:")
+		fmt.Println(i18n.T("cmd.status.connect_hint"))
 		fmt.Println("  autopus connect --token <JWT_TOKEN>")
 	} else if status.OAuthMode == "" || (status.OAuthMode != "oauth" && len(status.OAuthProviders) == 0) {
 		// SPEC-DOMAIN-PARALLEL-001 AC-9: OAuth 미연결 시 온보딩 가이드 표시
-		fmt.Println("OAuth 연결 안내")
-		fmt.Println("---------------")
-		fmt.Println("OpenAI(ChatGPT) 또는 Google(Gemini) 구독 계정을 연결하면")
-		fmt.Println("Bridge 없이 서버에서 직접 AI 추론이 가능합니다.")
+		printSectionTitle(i18n.T("cmd.status.oauth_title"))
+		fmt.Println(i18n.T("cmd.status.oauth_guide"))
 		fmt.Println()
-		fmt.Println("연결 방법: 워크스페이스 설정 > AI 프로바이더 > OAuth 연결")
+		fmt.Println(i18n.T("cmd.status.oauth_how"))
 	}
 
 	return nil
 }
 
+// printSectionTitle은 섹션 제목과 같은 너비의 밑줄을 출력합니다.
+func printSectionTitle(title string) {
+	fmt.Println(title)
+	fmt.Println(strings.Repeat("-", runewidth.StringWidth(title)))
+}
+
 // formatAIMode는 AI 실행 모드를 읽기 쉬운 형식으로 포맷합니다.
 // SPEC-DOMAIN-PARALLEL-001 AC-9
 func formatAIMode(mode string) string {
 	switch mode {
 	case "oauth":
-		return "OAuth (서버 직접 HTTP)"
+		return i18n.T("cmd.status.mode_oauth")
 	case "bridge":
-		return "Bridge (로컬 AI)"
+		return i18n.T("cmd.status.mode_bridge")
 	case "byok":
-		return "BYOK (API 키)"
+		return i18n.T("cmd.status.mode_byok")
 	case "platform":
-		return "Platform (Autopus 제공)"
+		return i18n.T("cmd.status.mode_platform")
 	default:
 		return mode
 	}
@@ -261,6 +317,19 @@ func getScopedStatusFilePath(workspaceID string) string {
 	return filepath.Join(home, ".config", "autopus", scopedStatusFileName(workspaceID))
 }
 
+// getScopedOutboxFilePath는 전송하지 못한 작업 결과를 보관하는 아웃박스 파일 경로를 반환합니다.
+func getScopedOutboxFilePath(workspaceID string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	name := "outbox.json"
+	if workspaceID != "" {
+		name = "outbox-" + sanitizeWorkspaceScope(workspaceID) + ".json"
+	}
+	return filepath.Join(home, ".config", "autopus", name)
+}
+
 func scopedStatusFileName(workspaceID string) string {
 	if workspaceID == "" {
 		return "status.json"
@@ -300,24 +369,24 @@ func formatDuration(d time.Duration) string {
 	seconds := int(d.Seconds()) % 60
 
 	if days > 0 {
-		return fmt.Sprintf("%d일 %d시간 %d분", days, hours, minutes)
+		return i18n.T("cmd.duration.days", days, hours, minutes)
 	}
 	if hours > 0 {
-		return fmt.Sprintf("%d시간 %d분 %d초", hours, minutes, seconds)
+		return i18n.T("cmd.duration.hours", hours, minutes, seconds)
 	}
 	if minutes > 0 {
-		return fmt.Sprintf("%d분 %d초", minutes, seconds)
+		return i18n.T("cmd.duration.minutes", minutes, seconds)
 	}
-	return fmt.Sprintf("%d초", seconds)
+	return i18n.T("cmd.duration.seconds", seconds)
 }
 
 // printEnvStatusForStatus는 환경변수 설정 상태를 출력합니다.
 func printEnvStatusForStatus(envVar string) {
 	value := os.Getenv(envVar)
 	if value != "" {
-		fmt.Printf("  %s: 설정됨\n", envVar)
+		fmt.Println(i18n.T("cmd.status.env_set", envVar))
 	} else {
-		fmt.Printf("  %s: 설정되지 않음\n", envVar)
+		fmt.Println(i18n.T("cmd.status.env_unset", envVar))
 	}
 }
 
@@ -326,21 +395,21 @@ func printEnvStatusForStatus(envVar string) {
 func SaveStatus(status *StatusInfo) error {
 	statusFile := getScopedStatusFilePath(status.WorkspaceID)
 	if statusFile == "" {
-		return fmt.Errorf("상태 파일 경로를 찾을 수 없습니다")
+		return errors.New(i18n.T("cmd.status.file_path_missing"))
 	}
 
 	// 디렉토리 확인/생성
 	if err := config.EnsureConfigDir(); err != nil {
-		return fmt.Errorf("설정 디렉토리 생성 실패: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("cmd.status.config_dir_failed"), err)
 	}
 
 	data, err := json.MarshalIndent(status, "", "  ")
 	if err != nil {
-		return fmt.Errorf("JSON 직렬화 실패: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("cmd.status.json_failed"), err)
 	}
 
 	if err := os.WriteFile(statusFile, data, 0600); err != nil {
-		return fmt.Errorf("상태 파일 저장 실패: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("cmd.status.file_write_failed"), err)
 	}
 
 	return nil
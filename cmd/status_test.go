@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/spf13/viper"
+)
+
+func TestInitLanguage(t *testing.T) {
+	originalLang := lang
+	original := i18n.Current()
+	defer func() {
+		lang = originalLang
+		i18n.SetLang(original)
+		viper.Set("language", "")
+	}()
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "ko_KR.UTF-8")
+
+	// 설정 파일의 language가 LANG보다 우선한다
+	lang = ""
+	viper.Set("language", "en")
+	if err := initLanguage(); err != nil {
+		t.Fatalf("initLanguage() error = %v", err)
+	}
+	if got := i18n.Current(); got != i18n.English {
+		t.Errorf("config language: got %q, want %q", got, i18n.English)
+	}
+
+	// --lang 플래그가 설정 파일보다 우선한다
+	lang = "ko"
+	if err := initLanguage(); err != nil {
+		t.Fatalf("initLanguage() error = %v", err)
+	}
+	if got := i18n.Current(); got != i18n.Korean {
+		t.Errorf("--lang: got %q, want %q", got, i18n.Korean)
+	}
+
+	lang = "fr"
+	if err := initLanguage(); err == nil {
+		t.Error("지원하지 않는 --lang 값은 에러여야 합니다")
+	}
+}
+
+func TestFormatDuration_Localized(t *testing.T) {
+	original := i18n.Current()
+	defer i18n.SetLang(original)
+
+	d := 26*time.Hour + 5*time.Minute
+	i18n.SetLang(i18n.Korean)
+	if got, want := formatDuration(d), "1일 2시간 5분"; got != want {
+		t.Errorf("ko: got %q, want %q", got, want)
+	}
+	i18n.SetLang(i18n.English)
+	if got, want := formatDuration(d), "1d 2h 5m"; got != want {
+		t.Errorf("en: got %q, want %q", got, want)
+	}
+}
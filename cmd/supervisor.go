@@ -0,0 +1,245 @@
+// supervisor.go는 connect --supervised 감독 모드를 구현합니다.
+// 부모(감독) 프로세스가 같은 명령으로 자식 브리지를 실행하고, 자식이 비정상 종료하면
+// 크래시 번들을 남긴 뒤 백오프 간격으로 다시 시작합니다.
+// 시스템 서비스를 설치하지 않아도 무인 환경에서 브리지를 계속 실행할 수 있습니다.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/crash"
+	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/spf13/viper"
+)
+
+const (
+	// supervisedChildEnv는 감독 프로세스가 실행한 자식 브리지임을 나타내는 환경 변수입니다.
+	// 설정되어 있으면 --supervised를 무시하고 일반 connect로 동작합니다.
+	supervisedChildEnv = "AUTOPUS_SUPERVISED_CHILD"
+	// supervisorRestartsEnv는 자식 브리지에 전달하는 재시작 횟수 환경 변수입니다.
+	supervisorRestartsEnv = "AUTOPUS_SUPERVISOR_RESTARTS"
+
+	// supervisorInitialBackoff는 첫 재시작 대기 시간입니다.
+	supervisorInitialBackoff = time.Second
+	// supervisorMaxBackoff는 재시작 대기 시간의 상한입니다.
+	supervisorMaxBackoff = time.Minute
+	// supervisorStableUptime 이상 실행된 뒤 종료되면 대기 시간을 처음부터 다시 늘립니다.
+	supervisorStableUptime = 5 * time.Minute
+	// supervisorStartupGrace 안에 종료되면 시작 실패로 셉니다.
+	supervisorStartupGrace = 10 * time.Second
+	// supervisorMaxStartupFailures번 연속 시작에 실패하면 감독을 중단합니다
+	// (인증 만료, 설정 오류처럼 재시작으로 해결되지 않는 경우).
+	supervisorMaxStartupFailures = 5
+	// supervisorOutputTail은 크래시 번들에 남길 자식 출력의 최대 크기(바이트)입니다.
+	supervisorOutputTail = 64 * 1024
+	// supervisorStopMargin은 종료 유예 시간 이후 자식을 강제 종료하기 전까지의 여유 시간입니다.
+	supervisorStopMargin = 15 * time.Second
+)
+
+// childExit는 자식 브리지 프로세스 한 번의 실행 결과입니다.
+type childExit struct {
+	// err는 종료 오류입니다. 정상 종료(exit 0)이면 nil입니다.
+	err error
+	// uptime은 실행 시간입니다.
+	uptime time.Duration
+	// output은 자식 프로세스의 마지막 출력입니다.
+	output []byte
+}
+
+// bridgeSupervisor는 자식 브리지를 실행하고 비정상 종료 시 재시작합니다.
+type bridgeSupervisor struct {
+	// runChild는 자식 브리지를 실행하고 종료될 때까지 기다립니다.
+	runChild func(ctx context.Context, restarts int) childExit
+	// reporter는 비정상 종료 시 크래시 번들을 저장합니다 (nil이면 저장하지 않음).
+	reporter *crash.Reporter
+	// sleep은 ctx가 취소될 때까지 d만큼 기다립니다 (테스트에서 주입).
+	sleep func(ctx context.Context, d time.Duration) error
+	// out은 감독 상태 메시지 출력 대상입니다.
+	out io.Writer
+}
+
+// run은 ctx가 취소되거나 자식이 정상 종료할 때까지 자식 브리지를 실행합니다.
+// 자식이 시작 직후 연속으로 종료되면 에러를 반환합니다.
+func (s *bridgeSupervisor) run(ctx context.Context) error {
+	backoff := supervisorInitialBackoff
+	startupFailures := 0
+
+	for restarts := 0; ; restarts++ {
+		exit := s.runChild(ctx, restarts)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if exit.err == nil {
+			fmt.Fprintln(s.out, "브리지가 정상 종료되어 감독을 마칩니다")
+			return nil
+		}
+
+		logger.Warn().
+			Err(exit.err).
+			Dur("uptime", exit.uptime).
+			Int("restarts", restarts).
+			Msg("브리지 프로세스 비정상 종료")
+		s.captureCrash(exit)
+
+		if exit.uptime < supervisorStartupGrace {
+			startupFailures++
+			if startupFailures >= supervisorMaxStartupFailures {
+				return fmt.Errorf("브리지가 시작 직후 %d회 연속 종료되어 감독을 중단합니다: %w", startupFailures, exit.err)
+			}
+		} else {
+			startupFailures = 0
+		}
+		if exit.uptime >= supervisorStableUptime {
+			backoff = supervisorInitialBackoff
+		}
+
+		fmt.Fprintf(s.out, "브리지가 종료되었습니다 (%v). %v 후 다시 시작합니다...\n", exit.err, backoff)
+		if err := s.sleep(ctx, backoff); err != nil {
+			return nil
+		}
+		backoff = min(backoff*2, supervisorMaxBackoff)
+	}
+}
+
+// captureCrash는 비정상 종료한 자식의 출력으로 크래시 번들을 저장합니다.
+// 오래된 번들은 crash_report.max_reports를 넘지 않도록 정리됩니다.
+func (s *bridgeSupervisor) captureCrash(exit childExit) {
+	if s.reporter == nil {
+		return
+	}
+	path, err := s.reporter.CaptureExit("connect", exit.err, exit.output)
+	if err != nil {
+		logger.Warn().Err(err).Msg("크래시 번들 저장 실패")
+		return
+	}
+	fmt.Fprintf(s.out, "크래시 리포트가 저장되었습니다: %s\n", path)
+}
+
+// runSupervisedConnect는 connect --supervised의 감독 프로세스를 실행합니다.
+// 자식은 같은 인자로 실행되며, 감독 프로세스가 SIGINT/SIGTERM을 받으면 자식에 SIGTERM을 보내고
+// 종료 유예 시간(shutdown.grace_period_seconds)이 지나도 끝나지 않으면 강제 종료합니다.
+func runSupervisedConnect(ctx context.Context) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("설정 로드 실패: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	s := &bridgeSupervisor{
+		runChild: func(ctx context.Context, restarts int) childExit {
+			return runBridgeChild(ctx, restarts, cfg.Shutdown.GetGracePeriod()+supervisorStopMargin)
+		},
+		reporter: newSupervisorCrashReporter(cfg),
+		sleep:    sleepContext,
+		out:      os.Stderr,
+	}
+
+	fmt.Fprintln(os.Stderr, "감독 모드로 브리지를 시작합니다 (비정상 종료 시 자동 재시작)")
+	return s.run(ctx)
+}
+
+// runBridgeChild는 현재 실행 파일을 같은 인자로 실행하고 종료될 때까지 기다립니다.
+// 자식의 표준 출력/에러는 그대로 전달하면서 마지막 출력을 크래시 번들용으로 보관합니다.
+func runBridgeChild(ctx context.Context, restarts int, stopTimeout time.Duration) childExit {
+	exe, err := os.Executable()
+	if err != nil {
+		return childExit{err: fmt.Errorf("실행 파일 경로 확인 실패: %w", err)}
+	}
+
+	tail := newTailBuffer(supervisorOutputTail)
+	child := exec.CommandContext(ctx, exe, os.Args[1:]...)
+	child.Env = append(os.Environ(),
+		supervisedChildEnv+"=1",
+		supervisorRestartsEnv+"="+strconv.Itoa(restarts),
+	)
+	child.Stdin = os.Stdin
+	child.Stdout = io.MultiWriter(os.Stdout, tail)
+	child.Stderr = io.MultiWriter(os.Stderr, tail)
+	// 컨텍스트 취소 시 바로 죽이지 않고 graceful shutdown 기회를 준다.
+	child.Cancel = func() error { return child.Process.Signal(syscall.SIGTERM) }
+	child.WaitDelay = stopTimeout
+
+	start := time.Now()
+	err = child.Run()
+	return childExit{err: err, uptime: time.Since(start), output: tail.Bytes()}
+}
+
+// newSupervisorCrashReporter는 crash_report 설정으로 자식 크래시 번들 리포터를 생성합니다.
+// 크래시 리포트가 비활성화되어 있으면 nil을 반환합니다.
+func newSupervisorCrashReporter(cfg *config.Config) *crash.Reporter {
+	if !cfg.CrashReport.Enabled {
+		return nil
+	}
+	version, commit, buildDate := GetVersionInfo()
+	return crash.NewReporter(crash.Config{
+		Dir:        cfg.CrashReport.GetDir(),
+		Version:    crash.VersionInfo{Version: version, Commit: commit, BuildDate: buildDate},
+		ConfigFile: viper.ConfigFileUsed(),
+		MaxReports: cfg.CrashReport.MaxReports,
+	})
+}
+
+// isSupervisedChild는 현재 프로세스가 감독 프로세스가 실행한 자식 브리지인지 반환합니다.
+func isSupervisedChild() bool {
+	return os.Getenv(supervisedChildEnv) != ""
+}
+
+// supervisorRestarts는 감독 프로세스가 전달한 재시작 횟수를 반환합니다.
+func supervisorRestarts() int {
+	n, _ := strconv.Atoi(os.Getenv(supervisorRestartsEnv))
+	return n
+}
+
+// sleepContext는 d만큼 기다립니다. ctx가 먼저 취소되면 ctx.Err()를 반환합니다.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tailBuffer는 마지막 limit 바이트만 보관하는 동시성 안전 버퍼입니다.
+type tailBuffer struct {
+	mu    sync.Mutex
+	buf   []byte
+	limit int
+}
+
+// newTailBuffer는 최대 limit 바이트를 보관하는 버퍼를 생성합니다.
+func newTailBuffer(limit int) *tailBuffer {
+	return &tailBuffer{limit: limit}
+}
+
+// Write는 p를 추가하고 limit을 넘는 앞부분을 버립니다.
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - b.limit; over > 0 {
+		b.buf = append(b.buf[:0], b.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+// Bytes는 보관 중인 내용의 복사본을 반환합니다.
+func (b *tailBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf...)
+}
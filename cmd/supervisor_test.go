@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/crash"
+)
+
+// fakeSupervisor는 미리 정한 종료 결과를 차례로 반환하는 감독자를 생성합니다.
+// 재시작 대기 시간은 sleeps에 기록됩니다.
+func fakeSupervisor(exits []childExit, sleeps *[]time.Duration) *bridgeSupervisor {
+	i := 0
+	return &bridgeSupervisor{
+		runChild: func(ctx context.Context, restarts int) childExit {
+			exit := exits[i]
+			i++
+			return exit
+		},
+		sleep: func(ctx context.Context, d time.Duration) error {
+			*sleeps = append(*sleeps, d)
+			return nil
+		},
+		out: io.Discard,
+	}
+}
+
+func TestBridgeSupervisor_RestartsWithBackoff(t *testing.T) {
+	crashed := childExit{err: errors.New("exit status 2"), uptime: time.Minute}
+	var sleeps []time.Duration
+	s := fakeSupervisor([]childExit{
+		crashed, crashed, crashed,
+		{err: errors.New("exit status 2"), uptime: supervisorStableUptime},
+		crashed,
+		{uptime: time.Hour},
+	}, &sleeps)
+
+	if err := s.run(context.Background()); err != nil {
+		t.Fatalf("정상 종료 시 nil이어야 합니다: %v", err)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Second, 2 * time.Second}
+	if len(sleeps) != len(want) {
+		t.Fatalf("재시작 대기 = %v, want %v", sleeps, want)
+	}
+	for i := range want {
+		if sleeps[i] != want[i] {
+			t.Errorf("대기[%d] = %v, want %v", i, sleeps[i], want[i])
+		}
+	}
+}
+
+func TestBridgeSupervisor_GivesUpOnStartupFailures(t *testing.T) {
+	var exits []childExit
+	for range supervisorMaxStartupFailures {
+		exits = append(exits, childExit{err: errors.New("exit status 1"), uptime: time.Second})
+	}
+	var sleeps []time.Duration
+	s := fakeSupervisor(exits, &sleeps)
+
+	err := s.run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "exit status 1") {
+		t.Fatalf("연속 시작 실패 시 에러여야 합니다: %v", err)
+	}
+	if len(sleeps) != supervisorMaxStartupFailures-1 {
+		t.Errorf("재시작 횟수 = %d, want %d", len(sleeps), supervisorMaxStartupFailures-1)
+	}
+}
+
+func TestBridgeSupervisor_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &bridgeSupervisor{
+		runChild: func(ctx context.Context, restarts int) childExit {
+			cancel()
+			return childExit{err: errors.New("signal: terminated")}
+		},
+		sleep: func(ctx context.Context, d time.Duration) error {
+			t.Fatal("취소된 뒤에는 재시작하지 않아야 합니다")
+			return nil
+		},
+		out: io.Discard,
+	}
+	if err := s.run(ctx); err != nil {
+		t.Fatalf("취소 시 nil이어야 합니다: %v", err)
+	}
+}
+
+func TestBridgeSupervisor_CapturesCrashBundles(t *testing.T) {
+	dir := t.TempDir()
+	var sleeps []time.Duration
+	crashed := childExit{err: errors.New("exit status 2"), uptime: time.Minute, output: []byte("log line\npanic: boom\n")}
+	s := fakeSupervisor([]childExit{crashed, crashed, crashed, {}}, &sleeps)
+	s.reporter = crash.NewReporter(crash.Config{Dir: dir, MaxReports: 2})
+
+	if err := s.run(context.Background()); err != nil {
+		t.Fatalf("run 에러: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("크래시 디렉토리 읽기 실패: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("크래시 번들 수 = %d, 최대 2개로 정리되어야 합니다", len(entries))
+	}
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".zip" {
+			t.Errorf("예상치 못한 파일: %s", e.Name())
+		}
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := newTailBuffer(8)
+	_, _ = b.Write([]byte("hello "))
+	_, _ = b.Write([]byte("world"))
+	if got := string(b.Bytes()); got != "lo world" {
+		t.Errorf("Bytes() = %q, want %q", got, "lo world")
+	}
+}
@@ -224,24 +224,29 @@ func getBusinessToolManifest() []businessTool {
 	}
 }
 
-// detectBusinessTools 비즈니스 도구 설치 상태를 감지합니다.
+// detectBusinessTools 비즈니스 도구 설치 상태를 감지합니다. 결과는 매니페스트 순서를 유지합니다.
 func detectBusinessTools() []businessTool {
 	tools := getBusinessToolManifest()
 
+	// 버전 확인은 도구마다 프로세스를 실행하므로 동시에 실행한다.
+	probes := make([]func(), 0, len(tools))
 	for i := range tools {
-		// csvkit는 csvstat 바이너리로 감지
-		checkName := tools[i].Name
-		if checkName == "csvkit" {
-			checkName = "csvstat"
-		}
+		probes = append(probes, func() {
+			// csvkit는 csvstat 바이너리로 감지
+			checkName := tools[i].Name
+			if checkName == "csvkit" {
+				checkName = "csvstat"
+			}
 
-		path, err := exec.LookPath(checkName)
-		if err == nil {
-			tools[i].Installed = true
-			tools[i].Path = path
-			tools[i].Version = getToolVersion(checkName)
-		}
+			path, err := exec.LookPath(checkName)
+			if err == nil {
+				tools[i].Installed = true
+				tools[i].Path = path
+				tools[i].Version = getToolVersion(checkName)
+			}
+		})
 	}
+	runBounded(upDetectWorkers, probes...)
 
 	return tools
 }
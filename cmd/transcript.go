@@ -0,0 +1,133 @@
+// transcript.go는 로컬에 기록된 실행 트랜스크립트 내보내기 명령어를 구현합니다.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/insajin/autopus-bridge/internal/apiclient"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/executor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	transcriptFormat string
+	transcriptOutput string
+	transcriptDir    string
+	transcriptUpload bool
+)
+
+// transcriptCmd는 실행 트랜스크립트를 Markdown 또는 JSON으로 내보냅니다.
+var transcriptCmd = &cobra.Command{
+	Use:   "transcript <execution-id>",
+	Short: "실행 트랜스크립트 내보내기",
+	Long: `Bridge가 로컬에 기록한 실행 트랜스크립트(프롬프트, 스트리밍 출력, 도구 호출/결과, 최종 결과)를 내보냅니다.
+
+트랜스크립트는 transcript.dir(기본값: ~/.config/autopus/transcripts)에 실행 ID별로 저장되며
+transcript.max_age_days(기본값: 7일)가 지나면 삭제됩니다.
+--upload를 지정하면 트랜스크립트를 백엔드의 실행 기록에 첨부합니다.
+
+예시:
+  autopus-bridge transcript exec-123
+  autopus-bridge transcript exec-123 --format json -o exec-123.json
+  autopus-bridge transcript exec-123 --upload`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := resolveTranscriptDir(transcriptDir)
+		if err != nil {
+			return err
+		}
+		transcript, err := executor.NewTranscriptStore(dir, 0).Load(args[0])
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("실행 %s의 트랜스크립트가 없습니다 (%s)", args[0], dir)
+			}
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if transcriptOutput != "" {
+			f, err := os.OpenFile(transcriptOutput, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return fmt.Errorf("출력 파일 생성 실패: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		if err := writeTranscript(out, transcript, transcriptFormat); err != nil {
+			return err
+		}
+
+		if transcriptUpload {
+			client, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+			if err := uploadTranscript(cmd.Context(), client, transcript); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "트랜스크립트를 실행 %s에 업로드했습니다\n", transcript.ExecutionID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(transcriptCmd)
+
+	transcriptCmd.Flags().StringVar(&transcriptFormat, "format", "md", "출력 형식 (md, json)")
+	transcriptCmd.Flags().StringVarP(&transcriptOutput, "output", "o", "", "출력 파일 경로 (기본값: 표준 출력)")
+	transcriptCmd.Flags().StringVar(&transcriptDir, "dir", "", "트랜스크립트 디렉토리 (기본값: transcript.dir 설정)")
+	transcriptCmd.Flags().BoolVar(&transcriptUpload, "upload", false, "트랜스크립트를 백엔드 실행 기록에 업로드")
+}
+
+// resolveTranscriptDir은 --dir 또는 transcript.dir 설정에서 트랜스크립트 디렉토리를 결정합니다.
+func resolveTranscriptDir(flagDir string) (string, error) {
+	if flagDir != "" {
+		return flagDir, nil
+	}
+	var tCfg config.TranscriptConfig
+	if cfg, err := config.Load(); err == nil {
+		tCfg = cfg.Transcript
+	}
+	dir := tCfg.GetDir()
+	if dir == "" {
+		return "", errors.New("트랜스크립트 디렉토리를 결정할 수 없습니다 (--dir로 지정하세요)")
+	}
+	return dir, nil
+}
+
+// writeTranscript는 트랜스크립트를 지정한 형식으로 출력합니다.
+func writeTranscript(out io.Writer, transcript *executor.Transcript, format string) error {
+	switch format {
+	case "md", "markdown":
+		_, err := io.WriteString(out, executor.MarkdownTranscript(transcript))
+		return err
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(transcript)
+	default:
+		return fmt.Errorf("지원하지 않는 형식입니다: %s (md, json)", format)
+	}
+}
+
+// uploadTranscript는 트랜스크립트를 백엔드 실행 기록에 첨부합니다.
+func uploadTranscript(ctx context.Context, client *apiclient.Client, transcript *executor.Transcript) error {
+	workspaceID := client.WorkspaceID()
+	if workspaceID == "" {
+		return fmt.Errorf("워크스페이스가 선택되지 않았습니다")
+	}
+	ctx, cancel := context.WithTimeout(ctx, apiclient.DefaultAPITimeout)
+	defer cancel()
+	path := "/api/v1/workspaces/" + workspaceID + "/executions/" + transcript.ExecutionID + "/transcript"
+	if _, err := client.Post(ctx, path, transcript); err != nil {
+		return fmt.Errorf("트랜스크립트 업로드 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/executor"
+)
+
+func TestWriteTranscript(t *testing.T) {
+	transcript := &executor.Transcript{
+		ExecutionID: "exec-1",
+		Entries:     []executor.TranscriptEntry{{Kind: executor.TranscriptPrompt, Text: "hello"}},
+	}
+
+	var md bytes.Buffer
+	if err := writeTranscript(&md, transcript, "md"); err != nil {
+		t.Fatalf("writeTranscript(md) error = %v", err)
+	}
+	if !strings.Contains(md.String(), "# Execution exec-1") || !strings.Contains(md.String(), "hello") {
+		t.Errorf("md = %q", md.String())
+	}
+
+	var out bytes.Buffer
+	if err := writeTranscript(&out, transcript, "json"); err != nil {
+		t.Fatalf("writeTranscript(json) error = %v", err)
+	}
+	var decoded executor.Transcript
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || decoded.ExecutionID != "exec-1" || len(decoded.Entries) != 1 {
+		t.Errorf("json = %q (%v)", out.String(), err)
+	}
+
+	if err := writeTranscript(&out, transcript, "html"); err == nil {
+		t.Error("지원하지 않는 형식은 에러여야 합니다")
+	}
+}
+
+func TestUploadTranscript(t *testing.T) {
+	var gotPath string
+	var got executor.Transcript
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+
+	client := makeAPITestClient(srv.URL, "ws-1")
+	transcript := &executor.Transcript{ExecutionID: "exec-1", Entries: []executor.TranscriptEntry{{Kind: executor.TranscriptResult, Text: "done"}}}
+	if err := uploadTranscript(context.Background(), client, transcript); err != nil {
+		t.Fatalf("uploadTranscript() error = %v", err)
+	}
+	if gotPath != "POST /api/v1/workspaces/ws-1/executions/exec-1/transcript" {
+		t.Errorf("요청 = %s", gotPath)
+	}
+	if got.ExecutionID != "exec-1" || len(got.Entries) != 1 {
+		t.Errorf("업로드된 트랜스크립트 = %+v", got)
+	}
+}
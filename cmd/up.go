@@ -20,8 +20,10 @@ import (
 	"github.com/insajin/autopus-bridge/internal/aitools"
 	"github.com/insajin/autopus-bridge/internal/auth"
 	"github.com/insajin/autopus-bridge/internal/branding"
+	"github.com/insajin/autopus-bridge/internal/computeruse"
 	"github.com/insajin/autopus-bridge/internal/config"
 	"github.com/insajin/autopus-bridge/internal/logger"
+	"github.com/insajin/autopus-bridge/internal/teamconfig"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -72,6 +74,9 @@ var upCmd = &cobra.Command{
   [11/12] 설정 파일 업데이트
   [12/12] 서버 연결
 
+--team-config로 init-team이 만든 팀 설정 파일을 지정하면 서명을 검증하고
+11단계에서 팀 정책, 템플릿, MCP 도구 권한을 설정 파일에 반영합니다.
+
 각 단계가 실패하면 구체적인 해결 방법을 안내합니다.
 재실행 시 완료된 단계는 자동으로 건너뜁니다.`,
 	RunE: runUp,
@@ -106,10 +111,26 @@ func runUp(cmd *cobra.Command, args []string) error {
 	}
 
 	scanner := bufio.NewScanner(os.Stdin)
+	var err error
+
+	// 팀 설정은 11단계에서 설정 파일을 다시 쓴 뒤 반영하지만, 서명 검증은
+	// 기존 설정의 신뢰 키 지문으로 먼저 수행하여 잘못된 번들이면 바로 중단한다.
+	var teamBundle *teamconfig.Bundle
+	var teamFingerprint string
+	if upTeamConfig != "" {
+		teamBundle, teamFingerprint, err = loadTeamBundle(upTeamConfig, upTeamKey)
+		if err != nil {
+			printError(fmt.Sprintf("팀 설정 검증 실패: %v", err))
+			return err
+		}
+		if upTeamKey == "" && viper.GetString("team.key_fingerprint") == "" {
+			fmt.Printf("  ! 처음 가져오는 팀 설정 서명 키입니다: %s (팀 관리자에게 지문을 확인하세요)\n", teamFingerprint)
+		}
+		printSuccess(fmt.Sprintf("팀 설정 서명 확인: %s", teamBundle.Team))
+	}
 
 	// ── Step 1: Auth Check ──
 	var creds *auth.Credentials
-	var err error
 
 	if isStepCompleted(progress, 1) {
 		printStep(1, totalUpSteps, "인증 확인 중...")
@@ -184,9 +205,13 @@ func runUp(cmd *cobra.Command, args []string) error {
 		saveUpProgress(progress, 0, "")
 	}
 
+	// 프로바이더, 비즈니스 도구, Docker, 샌드박스 이미지 감지는 서로 독립적이므로
+	// 대화형 단계(4~8) 전에 동시에 실행하고 결과를 각 단계에서 사용한다.
+	env := detectUpEnvironment()
+
 	// ── Step 4: Provider Detection + AI CLI Installation ──
 	printStep(4, totalUpSteps, "AI Provider 감지 및 설치 중...")
-	providers := detectProviders()
+	providers := env.providers
 	printProviderSummary(providers)
 	providers = stepInstallMissingAICLI(providers, scanner)
 	markStepCompleted(progress, 4)
@@ -200,20 +225,20 @@ func runUp(cmd *cobra.Command, args []string) error {
 
 	// ── Step 6: Business Tools Detection ──
 	printStep(6, totalUpSteps, "비즈니스 도구 감지 중...")
-	bizTools := detectBusinessTools()
+	bizTools := env.bizTools
 	printBusinessToolSummary(bizTools)
 	markStepCompleted(progress, 6)
 	saveUpProgress(progress, 0, "")
 
 	// ── Step 7: Docker Detection ──
 	printStep(7, totalUpSteps, "Docker 감지 및 설정 중...")
-	stepDockerDetection(scanner)
+	docker := stepDockerDetection(scanner, env.docker)
 	markStepCompleted(progress, 7)
 	saveUpProgress(progress, 0, "")
 
 	// ── Step 8: Chromium Sandbox Image Preparation ──
 	printStep(8, totalUpSteps, "Chromium Sandbox 이미지 준비 중...")
-	stepChromiumSandboxImage()
+	stepChromiumSandboxImage(scanner, docker, env.sandbox)
 	markStepCompleted(progress, 8)
 	saveUpProgress(progress, 0, "")
 
@@ -238,6 +263,14 @@ func runUp(cmd *cobra.Command, args []string) error {
 		printFixSuggestion("config", err)
 		return err
 	}
+	if teamBundle != nil {
+		installed := installedToolVersions(providers, bizTools)
+		if err := applyTeamBundle(os.Stdout, config.DefaultConfigPath(), teamBundle, teamFingerprint, installed); err != nil {
+			printError(fmt.Sprintf("팀 설정 반영 실패: %v", err))
+			saveUpProgress(progress, 11, err.Error())
+			return err
+		}
+	}
 	markStepCompleted(progress, 11)
 	saveUpProgress(progress, 0, "")
 
@@ -484,7 +517,7 @@ func stepConfigUpdate(providers []providerInfo, creds *auth.Credentials) error {
 	configPath := config.DefaultConfigPath()
 
 	// Determine server URL from existing config or default
-	srvURL := viper.GetString("server.url")
+	srvURL := viper.GetString("server.ws_url")
 	if srvURL == "" {
 		srvURL = "wss://api.autopus.co/ws/agent"
 	}
@@ -509,18 +542,16 @@ func stepConfigUpdate(providers []providerInfo, creds *auth.Credentials) error {
 	return nil
 }
 
-// stepDockerDetection은 Docker 설치 여부를 확인하고, 미설치 시 자동 설치를 제안한다.
-// Docker가 없어도 up 명령은 실패하지 않는다 (NON-BLOCKING).
+// stepDockerDetection은 감지된 Docker 상태를 보고하고, 미설치 시 자동 설치를, 데몬 미실행 시 시작을 제안한다.
+// 설치하거나 데몬을 시작했으면 다시 감지한 상태를 반환한다. Docker가 없어도 up 명령은 실패하지 않는다 (NON-BLOCKING).
 // SPEC-COMPUTER-USE-002 Phase 2.
-func stepDockerDetection(scanner *bufio.Scanner) {
+func stepDockerDetection(scanner *bufio.Scanner, status dockerStatus) dockerStatus {
 	isolation := viper.GetString("computer_use.isolation")
 	if isolation == "" {
 		isolation = "auto"
 	}
 
-	// Docker CLI 존재 여부 확인
-	dockerPath, err := exec.LookPath("docker")
-	if err != nil {
+	if status.path == "" {
 		// Docker가 설치되어 있지 않음 - 자동 설치 제안
 		fmt.Println("  Docker가 설치되어 있지 않습니다.")
 		fmt.Printf("  Docker를 설치하시겠습니까? (Y/n): ")
@@ -564,11 +595,10 @@ func stepDockerDetection(scanner *bufio.Scanner) {
 
 			if installed {
 				// 설치 후 Docker 데몬 시작 시도
-				dockerPath, _ = exec.LookPath("docker")
-				if dockerPath != "" {
+				if dockerPath, _ := exec.LookPath("docker"); dockerPath != "" {
 					startDockerDaemon(dockerPath)
 				}
-				return
+				return detectDocker()
 			}
 		} else {
 			if isolation == "container" {
@@ -577,34 +607,28 @@ func stepDockerDetection(scanner *bufio.Scanner) {
 				printSkip("Docker 설치 건너뜀 (컨테이너 격리 비활성화)")
 			}
 		}
-		return
+		return status
 	}
 
-	// Docker 데몬 실행 여부 확인
-	infoCmd := exec.Command(dockerPath, "info")
-	output, err := infoCmd.CombinedOutput()
-	if err != nil {
+	if !status.running {
 		// Docker 설치됨, 데몬 미실행 - 시작 제안
 		fmt.Println("  Docker가 설치되어 있지만 데몬이 실행되고 있지 않습니다.")
-		startDockerDaemon(dockerPath)
+		startDockerDaemon(status.path)
 
 		// 데몬 시작 후 재확인
-		recheckCmd := exec.Command(dockerPath, "info")
-		if recheckErr := recheckCmd.Run(); recheckErr != nil {
+		status = detectDocker()
+		if !status.running {
 			if isolation == "container" {
 				printError("Docker 데몬을 시작할 수 없습니다 (isolation=container 모드에 필요)")
 			} else {
 				fmt.Println("  ! Docker 데몬이 아직 실행되지 않았습니다 (컨테이너 격리 비활성화)")
 			}
-		} else {
-			printDockerVersion(dockerPath)
+			return status
 		}
-		return
 	}
 
-	// Docker 버전 정보 추출
-	_ = output
-	printDockerVersion(dockerPath)
+	printDockerVersion(status.version)
+	return status
 }
 
 // startDockerDaemon은 플랫폼에 맞게 Docker 데몬 시작을 시도한다.
@@ -640,11 +664,9 @@ func startDockerDaemon(dockerPath string) {
 }
 
 // printDockerVersion은 Docker 버전 정보를 출력한다.
-func printDockerVersion(dockerPath string) {
-	versionCmd := exec.Command(dockerPath, "version", "--format", "{{.Server.Version}}")
-	versionOutput, versionErr := versionCmd.Output()
-	if versionErr == nil {
-		printSuccess(fmt.Sprintf("Docker %s 감지됨", strings.TrimSpace(string(versionOutput))))
+func printDockerVersion(version string) {
+	if version != "" {
+		printSuccess(fmt.Sprintf("Docker %s 감지됨", version))
 	} else {
 		printSuccess("Docker 감지됨")
 	}
@@ -789,37 +811,58 @@ func cacheDockerfile(dockerfilePath string) {
 }
 
 // stepChromiumSandboxImage는 Chromium Sandbox Docker 이미지와 네트워크를 준비한다.
+// 설정된 버전/digest/플랫폼으로 이미지를 풀하고, 고정되지 않은 이미지는 주기적으로
+// 업데이트를 확인하여 사용자 확인 후 갱신한다.
+// 이미지/네트워크 존재 여부는 환경 감지 결과(status)를 사용한다.
 // Docker가 없으면 건너뛴다 (NON-BLOCKING).
 // SPEC-COMPUTER-USE-002 Phase 2.
-func stepChromiumSandboxImage() {
-	// Docker CLI 존재 여부 확인
-	dockerPath, err := exec.LookPath("docker")
-	if err != nil {
+func stepChromiumSandboxImage(scanner *bufio.Scanner, docker dockerStatus, status sandboxImageStatus) {
+	if docker.path == "" {
 		printSkip("Docker 미설치 - 이미지 준비 건너뜀")
 		return
 	}
-
-	// Docker 데몬 실행 여부 확인
-	infoCmd := exec.Command(dockerPath, "info")
-	if err := infoCmd.Run(); err != nil {
+	if !docker.running {
 		printSkip("Docker 데몬 미실행 - 이미지 준비 건너뜀")
 		return
 	}
+	dockerPath := docker.path
+	// 환경 감지 이후 Docker를 설치하거나 데몬을 시작했으면 이미지 상태를 지금 확인한다.
+	if !status.checked {
+		status = detectSandboxImage(dockerPath)
+	}
 
-	const imageName = "autopus/chromium-sandbox:latest"
-	const networkName = "autopus-sandbox-net"
+	spec, networkName, specErr := sandboxSettingsFromConfig()
+	if specErr != nil {
+		printError(specErr.Error())
+		fmt.Println("  Computer Use 없이 계속 진행합니다.")
+		return
+	}
+	// 로컬 빌드 태그와 존재 확인에는 digest를 제외한 참조를 사용한다.
+	imageName := spec.TaggedReference()
+	imageMgr := computeruse.NewImageManager(computeruse.NewCLIDockerClient(dockerPath))
+	ctx, cancel := context.WithTimeout(context.Background(), sandboxCommandTimeout)
+	defer cancel()
 
-	// 이미지 존재 여부 확인
-	imgCmd := exec.Command(dockerPath, "images", "-q", imageName)
-	imgOutput, imgErr := imgCmd.Output()
-	if imgErr != nil || strings.TrimSpace(string(imgOutput)) == "" {
+	// 이미지 존재 여부 확인 (digest가 고정되어 있으면 digest 일치까지 확인)
+	imagePresent := status.imagePresent
+	if imagePresent && spec.Digest != "" {
+		if verifyErr := imageMgr.VerifyDigest(ctx, spec); verifyErr != nil {
+			fmt.Printf("  ! %v\n", verifyErr)
+			imagePresent = false
+		}
+	}
+	if !imagePresent {
 		// 이미지가 없으면 풀 시도
-		fmt.Println("  이미지 가져오는 중:", imageName)
-		pullCmd := exec.Command(dockerPath, "pull", imageName)
-		pullOutput, pullErr := pullCmd.CombinedOutput()
-		if pullErr != nil {
+		fmt.Printf("  이미지 가져오는 중: %s (%s)\n", spec.Reference(), spec.Platform)
+		pullErr := imageMgr.Pull(ctx, spec)
+		if pullErr != nil && spec.Digest != "" {
+			// digest가 고정된 이미지는 로컬 빌드로 대체할 수 없다.
+			logger.Debug().Err(pullErr).Msg("이미지 풀 실패")
+			printError("고정된 digest의 이미지를 가져오지 못했습니다")
+			fmt.Println("  다시 시도: autopus-bridge sandbox update")
+		} else if pullErr != nil {
 			// REQ-UX-004: 풀 실패 시 로컬 Dockerfile에서 자동 빌드 시도
-			logger.Debug().Str("output", string(pullOutput)).Msg("이미지 풀 실패")
+			logger.Debug().Err(pullErr).Msg("이미지 풀 실패")
 			fmt.Println("  이미지 다운로드 실패. 로컬에서 빌드를 시도합니다...")
 
 			dockerfilePath := findDockerfileDir("chromium-sandbox")
@@ -864,16 +907,15 @@ func stepChromiumSandboxImage() {
 				}
 			}
 		} else {
-			printSuccess(fmt.Sprintf("이미지 준비 완료: %s", imageName))
+			printSuccess(fmt.Sprintf("이미지 준비 완료: %s", spec.Reference()))
 		}
 	} else {
-		printSuccess(fmt.Sprintf("이미지 확인됨: %s", imageName))
+		printSuccess(fmt.Sprintf("이미지 확인됨: %s", spec.Reference()))
+		checkSandboxImageUpdate(ctx, scanner, imageMgr, spec)
 	}
 
 	// 네트워크 존재 여부 확인
-	netCmd := exec.Command(dockerPath, "network", "ls", "--filter", fmt.Sprintf("name=%s", networkName), "-q")
-	netOutput, netErr := netCmd.Output()
-	if netErr != nil || strings.TrimSpace(string(netOutput)) == "" {
+	if !status.networkPresent {
 		// 네트워크 생성
 		createCmd := exec.Command(dockerPath, "network", "create", networkName)
 		if createErr := createCmd.Run(); createErr != nil {
@@ -886,6 +928,36 @@ func stepChromiumSandboxImage() {
 	}
 }
 
+// checkSandboxImageUpdate는 고정되지 않은 샌드박스 이미지의 새 버전을 주기적으로 확인하고
+// 사용자 확인 후 업데이트한다. 확인 주기는 computer_use.update_check_interval을 따른다.
+func checkSandboxImageUpdate(ctx context.Context, scanner *bufio.Scanner, mgr *computeruse.ImageManager, spec computeruse.ImageSpec) {
+	if spec.IsPinned() || !sandboxUpdateCheckDue(sandboxUpdateCheckInterval()) {
+		return
+	}
+
+	hasUpdate, remoteDigest, err := mgr.CheckUpdate(ctx, spec)
+	recordSandboxUpdateCheck()
+	if err != nil {
+		logger.Debug().Err(err).Msg("샌드박스 이미지 업데이트 확인 실패")
+		return
+	}
+	if !hasUpdate {
+		return
+	}
+
+	fmt.Printf("  새 샌드박스 이미지가 있습니다: %s\n", remoteDigest)
+	fmt.Print("  지금 업데이트하시겠습니까? [Y/n]: ")
+	if !scanYesNoDefault(scanner, true) {
+		fmt.Println("  나중에 업데이트하려면: autopus-bridge sandbox update")
+		return
+	}
+	if err := mgr.Pull(ctx, spec); err != nil {
+		printError(fmt.Sprintf("이미지 업데이트 실패: %v", err))
+		return
+	}
+	printSuccess(fmt.Sprintf("이미지 업데이트 완료: %s", spec.TaggedReference()))
+}
+
 // printBusinessToolSummary 비즈니스 도구 감지 결과를 요약 출력합니다.
 func printBusinessToolSummary(tools []businessTool) {
 	installed, total := countTools(tools)
@@ -1524,20 +1596,7 @@ func performDeviceAuthFlow() (*auth.Credentials, error) {
 	}
 
 	// Step 6: Save credentials
-	tokenExpiresAt := time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
-	if jwtExpiry, parseErr := auth.ParseJWTExpiry(tokenResp.AccessToken); parseErr == nil {
-		tokenExpiresAt = jwtExpiry
-	}
-
-	creds := &auth.Credentials{
-		AccessToken:   tokenResp.AccessToken,
-		RefreshToken:  tokenResp.RefreshToken,
-		ExpiresAt:     tokenExpiresAt,
-		ServerURL:     getServerURL(),
-		UserEmail:     tokenResp.UserEmail,
-		WorkspaceID:   tokenResp.WorkspaceID,
-		WorkspaceSlug: tokenResp.WorkspaceSlug,
-	}
+	creds := tokenResp.NewCredentials(getServerURL())
 
 	// 워크스페이스 자동 선택 (1개일 경우)
 	if creds.WorkspaceID == "" && len(tokenResp.Workspaces) == 1 {
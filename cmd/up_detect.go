@@ -0,0 +1,121 @@
+// up_detect.go는 up 명령의 환경 감지(프로바이더, 비즈니스 도구, Docker, 샌드박스 이미지)를 동시에 실행합니다.
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// upDetectWorkers는 환경 감지에서 동시에 실행할 최대 작업 수입니다.
+// 감지 작업은 대부분 외부 프로세스(--version, docker info)를 실행하므로 CPU 수와 무관하게 작게 유지합니다.
+const upDetectWorkers = 4
+
+// upEnvironment는 up 명령의 대화형 단계 전에 수집한 환경 감지 결과입니다.
+type upEnvironment struct {
+	providers []providerInfo
+	bizTools  []businessTool
+	docker    dockerStatus
+	sandbox   sandboxImageStatus
+}
+
+// dockerStatus는 Docker CLI와 데몬 감지 결과입니다.
+type dockerStatus struct {
+	// path는 docker CLI 경로입니다. 비어 있으면 미설치입니다.
+	path string
+	// running은 데몬이 응답하는지 여부입니다 (docker info 성공).
+	running bool
+	// version은 데몬 버전입니다. 확인하지 못하면 비어 있습니다.
+	version string
+}
+
+// sandboxImageStatus는 Chromium Sandbox 이미지와 네트워크 감지 결과입니다.
+type sandboxImageStatus struct {
+	// checked는 감지를 수행했는지 여부입니다. Docker 데몬이 실행 중이고 샌드박스 설정이 유효할 때만 감지합니다.
+	checked        bool
+	imagePresent   bool
+	networkPresent bool
+}
+
+// detectUpEnvironment는 서로 독립적인 감지 작업을 동시에 실행하고 결과를 모읍니다.
+// Docker 데몬 확인 후에만 이미지를 확인할 수 있으므로 Docker와 샌드박스 이미지 감지는 한 작업으로 실행합니다.
+func detectUpEnvironment() upEnvironment {
+	var env upEnvironment
+	runBounded(upDetectWorkers,
+		func() { env.providers = detectProviders() },
+		func() { env.bizTools = detectBusinessTools() },
+		func() {
+			env.docker = detectDocker()
+			if env.docker.running {
+				env.sandbox = detectSandboxImage(env.docker.path)
+			}
+		},
+	)
+	return env
+}
+
+// detectDocker는 docker CLI 설치 여부와 데몬 실행 여부, 버전을 확인합니다.
+func detectDocker() dockerStatus {
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return dockerStatus{}
+	}
+	status := dockerStatus{path: path}
+	if exec.Command(path, "info").Run() != nil {
+		return status
+	}
+	status.running = true
+	status.version = dockerServerVersion(path)
+	return status
+}
+
+// dockerServerVersion은 Docker 데몬 버전을 반환합니다. 확인하지 못하면 빈 문자열입니다.
+func dockerServerVersion(dockerPath string) string {
+	out, err := exec.Command(dockerPath, "version", "--format", "{{.Server.Version}}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// detectSandboxImage는 설정된 샌드박스 이미지와 네트워크가 로컬에 있는지 확인합니다.
+func detectSandboxImage(dockerPath string) sandboxImageStatus {
+	spec, networkName, err := sandboxSettingsFromConfig()
+	if err != nil {
+		return sandboxImageStatus{}
+	}
+	var status sandboxImageStatus
+	runBounded(2,
+		func() {
+			out, err := exec.Command(dockerPath, "images", "-q", spec.TaggedReference()).Output()
+			status.imagePresent = err == nil && strings.TrimSpace(string(out)) != ""
+		},
+		func() {
+			out, err := exec.Command(dockerPath, "network", "ls", "--filter", fmt.Sprintf("name=%s", networkName), "-q").Output()
+			status.networkPresent = err == nil && strings.TrimSpace(string(out)) != ""
+		},
+	)
+	status.checked = true
+	return status
+}
+
+// runBounded는 tasks를 최대 limit개씩 동시에 실행하고 모두 끝날 때까지 기다립니다.
+// 각 작업은 서로 다른 결과 변수에만 기록해야 합니다.
+func runBounded(limit int, tasks ...func()) {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBounded_RunsAllTasksWithinLimit(t *testing.T) {
+	const limit = 2
+	var (
+		mu      sync.Mutex
+		active  int
+		peak    int
+		counter atomic.Int32
+	)
+	task := func() {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		counter.Add(1)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}
+
+	runBounded(limit, task, task, task, task, task)
+
+	if got := counter.Load(); got != 5 {
+		t.Fatalf("실행된 작업 수 = %d, want 5", got)
+	}
+	if peak > limit {
+		t.Fatalf("최대 동시 실행 수 = %d, limit %d 초과", peak, limit)
+	}
+}
+
+func TestRunBounded_NonPositiveLimitRunsSerially(t *testing.T) {
+	var order []int
+	runBounded(0,
+		func() { order = append(order, 1) },
+		func() { order = append(order, 2) },
+	)
+	if len(order) != 2 {
+		t.Fatalf("order = %v, want 2개 작업", order)
+	}
+}
+
+func TestDetectDocker(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("셸 스크립트 기반 가짜 docker는 Windows에서 지원하지 않습니다")
+	}
+
+	t.Run("미설치", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		if status := detectDocker(); status.path != "" || status.running {
+			t.Fatalf("detectDocker() = %+v, want 빈 상태", status)
+		}
+	})
+
+	t.Run("데몬 중지", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFakeDocker(t, dir, "exit 1\n")
+		t.Setenv("PATH", dir)
+
+		status := detectDocker()
+		if status.path == "" || status.running || status.version != "" {
+			t.Fatalf("detectDocker() = %+v, want 설치됨/중지", status)
+		}
+	})
+
+	t.Run("실행 중", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFakeDocker(t, dir, "if [ \"$1\" = version ]; then echo 27.1.0; fi\nexit 0\n")
+		t.Setenv("PATH", dir)
+
+		status := detectDocker()
+		if !status.running || status.version != "27.1.0" {
+			t.Fatalf("detectDocker() = %+v, want 실행 중/27.1.0", status)
+		}
+	})
+}
+
+// writeFakeDocker는 dir에 body를 실행하는 가짜 docker 스크립트를 만듭니다.
+func writeFakeDocker(t *testing.T, dir, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatalf("가짜 docker 생성 실패: %v", err)
+	}
+}
@@ -35,15 +35,16 @@ type WorkspaceMember struct {
 }
 
 var (
-	workspaceJSONOutput   bool
-	workspaceCreateName   string
-	workspaceUpdateName   string
-	workspaceUpdateDesc   string
-	workspaceMissionText  string
-	workspaceVisionText   string
-	workspaceAddUserID    string
-	workspaceAddRole      string
-	workspaceUpdateRoleVal string
+	workspaceJSONOutput      bool
+	workspaceCreateName      string
+	workspaceUpdateName      string
+	workspaceUpdateDesc      string
+	workspaceMissionText     string
+	workspaceVisionText      string
+	workspaceAddUserID       string
+	workspaceAddRole         string
+	workspaceUpdateRoleVal   string
+	workspaceSwitchReconnect bool
 )
 
 // workspaceCmd는 workspace 서브커맨드의 루트입니다.
@@ -89,10 +90,17 @@ var workspaceShowCmd = &cobra.Command{
 	},
 }
 
-// workspaceSwitchCmd는 워크스페이스를 선택적으로 전환합니다.
+// workspaceSwitchCmd는 워크스페이스를 전환합니다.
+// slug(또는 ID)를 지정하면 바로 전환하고, 생략하면 번호로 선택합니다.
 var workspaceSwitchCmd = &cobra.Command{
-	Use:   "switch",
-	Short: "워크스페이스 전환 (인터랙티브)",
+	Use:   "switch [slug]",
+	Short: "워크스페이스 전환",
+	Long: `활성 워크스페이스를 전환하고 credentials에 저장합니다.
+slug(또는 ID)를 생략하면 목록에서 번호로 선택합니다.
+
+실행 중인 connect 프로세스는 기존 워크스페이스로 연결되어 있으므로,
+--reconnect를 지정하면 해당 프로세스를 새 워크스페이스로 다시 시작합니다.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client, err := newAPIClient()
 		if err != nil {
@@ -108,7 +116,22 @@ var workspaceSwitchCmd = &cobra.Command{
 			return errors.New("로그인이 필요합니다")
 		}
 
-		return runWorkspaceSwitch(cmd.Context(), client, creds, os.Stdin, os.Stdout)
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		// 상태/lock 파일은 워크스페이스별로 분리되므로 전환 전에 실행 중인 프로세스를 찾습니다.
+		runningProc, _ := detectRunningConnectProcess()
+
+		previousID := creds.WorkspaceID
+		if err := runWorkspaceSwitch(cmd.Context(), client, creds, target, os.Stdin, os.Stdout); err != nil {
+			return err
+		}
+		if creds.WorkspaceID == previousID {
+			return nil
+		}
+		return reconnectAfterWorkspaceSwitch(runningProc, workspaceSwitchReconnect, os.Stdout)
 	},
 }
 
@@ -269,6 +292,9 @@ func init() {
 	workspaceAddMemberCmd.MarkFlagRequired("user-id")
 	workspaceAddMemberCmd.MarkFlagRequired("role")
 
+	// workspace switch 전용 플래그
+	workspaceSwitchCmd.Flags().BoolVar(&workspaceSwitchReconnect, "reconnect", false, "실행 중인 connect 프로세스를 새 워크스페이스로 다시 시작")
+
 	// workspace update-role 전용 플래그
 	workspaceUpdateRoleCmd.Flags().StringVar(&workspaceUpdateRoleVal, "role", "", "새 역할 (필수)")
 	workspaceUpdateRoleCmd.MarkFlagRequired("role")
@@ -285,13 +311,25 @@ func runWorkspaceList(client *apiclient.Client, out io.Writer, jsonOutput bool)
 		return apiclient.PrintJSON(out, workspaces)
 	}
 
-	// 테이블 형식으로 출력
-	headers := []string{"ID", "NAME", "SLUG", "ROLE"}
+	// 테이블 형식으로 출력 (활성 워크스페이스는 * 표시)
+	activeID := client.WorkspaceID()
+	headers := []string{"", "ID", "NAME", "SLUG", "ROLE"}
 	rows := make([][]string, len(workspaces))
 	for i, ws := range workspaces {
-		rows[i] = []string{ws.ID, ws.Name, ws.Slug, ws.Role}
+		marker := ""
+		if ws.ID == activeID {
+			marker = "*"
+		}
+		rows[i] = []string{marker, ws.ID, ws.Name, ws.Slug, ws.Role}
 	}
 	apiclient.PrintTable(out, headers, rows)
+
+	for _, ws := range workspaces {
+		if ws.ID == activeID {
+			fmt.Fprintf(out, "\n활성 워크스페이스: %s (%s)\n", ws.Name, ws.Slug)
+			break
+		}
+	}
 	return nil
 }
 
@@ -325,9 +363,10 @@ func runWorkspaceShow(client *apiclient.Client, out io.Writer, workspaceID strin
 	return nil
 }
 
-// runWorkspaceSwitch는 인터랙티브 워크스페이스 전환을 수행합니다.
+// runWorkspaceSwitch는 워크스페이스 전환을 수행합니다.
+// target(slug 또는 ID)이 비어있으면 목록을 보여주고 번호를 입력받습니다.
 // 선택 후 credentials 파일을 업데이트합니다.
-func runWorkspaceSwitch(ctx context.Context, client *apiclient.Client, creds *auth.Credentials, in io.Reader, out io.Writer) error {
+func runWorkspaceSwitch(ctx context.Context, client *apiclient.Client, creds *auth.Credentials, target string, in io.Reader, out io.Writer) error {
 	workspaces, err := apiclient.DoList[Workspace](client, ctx, "GET", "/api/v1/workspaces", nil)
 	if err != nil {
 		return fmt.Errorf("워크스페이스 목록 조회 실패: %w", err)
@@ -336,11 +375,57 @@ func runWorkspaceSwitch(ctx context.Context, client *apiclient.Client, creds *au
 		return errors.New("사용 가능한 워크스페이스가 없습니다")
 	}
 
+	var selected Workspace
+	if target != "" {
+		found, ok := findWorkspace(workspaces, target)
+		if !ok {
+			return fmt.Errorf("워크스페이스를 찾을 수 없습니다: %s ('autopus workspace list'로 확인하세요)", target)
+		}
+		selected = found
+	} else {
+		selected, err = promptWorkspaceChoice(workspaces, creds.WorkspaceID, in, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	if selected.ID == creds.WorkspaceID {
+		fmt.Fprintf(out, "이미 활성 워크스페이스입니다: %s (%s)\n", selected.Name, selected.Slug)
+		return nil
+	}
+
+	// credentials 업데이트
+	creds.WorkspaceID = selected.ID
+	creds.WorkspaceSlug = selected.Slug
+	creds.WorkspaceName = selected.Name
+
+	if saveErr := auth.Save(creds); saveErr != nil {
+		return fmt.Errorf("credentials 저장 실패: %w", saveErr)
+	}
+
+	fmt.Fprintf(out, "워크스페이스 전환 완료: %s (%s)\n", selected.Name, selected.ID)
+	fmt.Fprintf(out, "활성 워크스페이스: %s (%s)\n", selected.Name, selected.Slug)
+	return nil
+}
+
+// findWorkspace는 slug 또는 ID가 target과 일치하는 워크스페이스를 찾습니다.
+func findWorkspace(workspaces []Workspace, target string) (Workspace, bool) {
+	target = strings.TrimSpace(target)
+	for _, ws := range workspaces {
+		if strings.EqualFold(ws.Slug, target) || ws.ID == target {
+			return ws, true
+		}
+	}
+	return Workspace{}, false
+}
+
+// promptWorkspaceChoice는 워크스페이스 목록을 출력하고 번호 입력으로 선택받습니다.
+func promptWorkspaceChoice(workspaces []Workspace, activeID string, in io.Reader, out io.Writer) (Workspace, error) {
 	// 현재 선택된 워크스페이스 표시
 	fmt.Fprintln(out, "워크스페이스를 선택하세요:")
 	for i, ws := range workspaces {
 		marker := " "
-		if ws.ID == creds.WorkspaceID {
+		if ws.ID == activeID {
 			marker = "*"
 		}
 		fmt.Fprintf(out, " %s %d. %s (%s)\n", marker, i+1, ws.Name, ws.Slug)
@@ -350,29 +435,40 @@ func runWorkspaceSwitch(ctx context.Context, client *apiclient.Client, creds *au
 	reader := bufio.NewReader(in)
 	line, readErr := reader.ReadString('\n')
 	if readErr != nil && readErr != io.EOF {
-		return fmt.Errorf("입력 읽기 실패: %w", readErr)
+		return Workspace{}, fmt.Errorf("입력 읽기 실패: %w", readErr)
 	}
 
 	choice, parseErr := strconv.Atoi(strings.TrimSpace(line))
 	if parseErr != nil {
-		return errors.New("유효한 번호를 입력하세요")
+		return Workspace{}, errors.New("유효한 번호를 입력하세요")
 	}
 	if choice < 1 || choice > len(workspaces) {
-		return fmt.Errorf("선택 범위를 벗어났습니다: %d", choice)
+		return Workspace{}, fmt.Errorf("선택 범위를 벗어났습니다: %d", choice)
 	}
+	return workspaces[choice-1], nil
+}
 
-	selected := workspaces[choice-1]
-
-	// credentials 업데이트
-	creds.WorkspaceID = selected.ID
-	creds.WorkspaceSlug = selected.Slug
-	creds.WorkspaceName = selected.Name
-
-	if saveErr := auth.Save(creds); saveErr != nil {
-		return fmt.Errorf("credentials 저장 실패: %w", saveErr)
+// reconnectAfterWorkspaceSwitch는 워크스페이스 전환 후 실행 중인 connect 프로세스를 처리합니다.
+// reconnect가 true이면 기존 프로세스를 종료하고 새 워크스페이스로 다시 시작하며,
+// false이면 재시작 방법을 안내만 합니다.
+func reconnectAfterWorkspaceSwitch(proc *runningConnectProcess, reconnect bool, out io.Writer) error {
+	if proc == nil {
+		return nil
+	}
+	if !reconnect {
+		fmt.Fprintf(out, "실행 중인 연결 프로세스(PID: %d)는 이전 워크스페이스에 연결되어 있습니다.\n", proc.PID)
+		fmt.Fprintln(out, "새 워크스페이스로 다시 연결하려면 --reconnect 옵션을 사용하거나 connect를 다시 실행하세요.")
+		return nil
 	}
 
-	fmt.Fprintf(out, "워크스페이스 전환 완료: %s (%s)\n", selected.Name, selected.ID)
+	fmt.Fprintf(out, "연결 프로세스(PID: %d)를 종료하는 중...\n", proc.PID)
+	if err := stopRunningConnectProcess(proc.PID); err != nil {
+		return fmt.Errorf("워크스페이스는 전환되었지만 연결 프로세스 종료 실패: %w", err)
+	}
+	if err := restartConnectProcess(proc); err != nil {
+		return fmt.Errorf("워크스페이스는 전환되었지만 연결 재시작 실패: %w", err)
+	}
+	fmt.Fprintln(out, "새 워크스페이스로 연결 프로세스를 다시 시작했습니다.")
 	return nil
 }
 
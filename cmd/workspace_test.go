@@ -414,7 +414,7 @@ func TestRunWorkspaceSwitch(t *testing.T) {
 	// 사용자 입력을 "2"로 시뮬레이션 (Beta 선택)
 	input := strings.NewReader("2\n")
 
-	err := runWorkspaceSwitch(context.Background(), client, creds, input, &buf)
+	err := runWorkspaceSwitch(context.Background(), client, creds, "", input, &buf)
 	if err != nil {
 		t.Fatalf("runWorkspaceSwitch 오류: %v", err)
 	}
@@ -424,3 +424,103 @@ func TestRunWorkspaceSwitch(t *testing.T) {
 		t.Errorf("WorkspaceID = %q, want %q", creds.WorkspaceID, "ws-2")
 	}
 }
+
+func TestRunWorkspaceSwitchBySlug(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	workspaces := []Workspace{
+		{ID: "ws-1", Name: "Alpha", Slug: "alpha"},
+		{ID: "ws-2", Name: "Beta", Slug: "beta"},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(makeAPIResponse(workspaces))
+	}))
+	defer srv.Close()
+
+	creds := &auth.Credentials{
+		AccessToken: "test-token",
+		ServerURL:   srv.URL,
+		WorkspaceID: "ws-1",
+		ExpiresAt:   time.Now().Add(1 * time.Hour),
+	}
+	client := makeWorkspaceTestClient(srv.URL, "ws-1")
+
+	var buf bytes.Buffer
+	// slug 지정 시 입력을 읽지 않아야 합니다
+	if err := runWorkspaceSwitch(context.Background(), client, creds, "beta", strings.NewReader(""), &buf); err != nil {
+		t.Fatalf("runWorkspaceSwitch 오류: %v", err)
+	}
+	if creds.WorkspaceID != "ws-2" || creds.WorkspaceSlug != "beta" || creds.WorkspaceName != "Beta" {
+		t.Errorf("credentials가 갱신되지 않았습니다: %+v", creds)
+	}
+	if !strings.Contains(buf.String(), "활성 워크스페이스: Beta (beta)") {
+		t.Errorf("활성 워크스페이스가 출력되지 않았습니다: %s", buf.String())
+	}
+
+	saved, err := auth.Load()
+	if err != nil || saved == nil {
+		t.Fatalf("저장된 credentials 로드 실패: %v", err)
+	}
+	if saved.WorkspaceID != "ws-2" {
+		t.Errorf("저장된 WorkspaceID = %q, want %q", saved.WorkspaceID, "ws-2")
+	}
+
+	err = runWorkspaceSwitch(context.Background(), client, creds, "missing", strings.NewReader(""), &buf)
+	if err == nil {
+		t.Fatal("존재하지 않는 slug에 대해 오류가 반환되어야 합니다")
+	}
+	if creds.WorkspaceID != "ws-2" {
+		t.Errorf("실패한 전환 후 WorkspaceID가 변경되었습니다: %q", creds.WorkspaceID)
+	}
+}
+
+func TestRunWorkspaceListMarksActive(t *testing.T) {
+	workspaces := []Workspace{
+		{ID: "ws-1", Name: "Alpha", Slug: "alpha"},
+		{ID: "ws-2", Name: "Beta", Slug: "beta"},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(makeAPIResponse(workspaces))
+	}))
+	defer srv.Close()
+
+	client := makeWorkspaceTestClient(srv.URL, "ws-2")
+	var buf bytes.Buffer
+	if err := runWorkspaceList(client, &buf, false); err != nil {
+		t.Fatalf("runWorkspaceList 오류: %v", err)
+	}
+
+	out := buf.String()
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "ws-2") && !strings.Contains(line, "*") {
+			t.Errorf("활성 워크스페이스 행에 * 표시가 없습니다: %q", line)
+		}
+		if strings.Contains(line, "ws-1") && strings.Contains(line, "*") {
+			t.Errorf("비활성 워크스페이스 행에 * 표시가 있습니다: %q", line)
+		}
+	}
+	if !strings.Contains(out, "활성 워크스페이스: Beta (beta)") {
+		t.Errorf("활성 워크스페이스가 출력되지 않았습니다: %s", out)
+	}
+}
+
+func TestReconnectAfterWorkspaceSwitchHint(t *testing.T) {
+	var buf bytes.Buffer
+	if err := reconnectAfterWorkspaceSwitch(nil, true, &buf); err != nil {
+		t.Fatalf("실행 중인 프로세스가 없을 때 오류: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("실행 중인 프로세스가 없으면 출력이 없어야 합니다: %s", buf.String())
+	}
+
+	if err := reconnectAfterWorkspaceSwitch(&runningConnectProcess{PID: 4242}, false, &buf); err != nil {
+		t.Fatalf("reconnectAfterWorkspaceSwitch 오류: %v", err)
+	}
+	if !strings.Contains(buf.String(), "4242") || !strings.Contains(buf.String(), "--reconnect") {
+		t.Errorf("재연결 안내가 출력되지 않았습니다: %s", buf.String())
+	}
+}
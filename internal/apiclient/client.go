@@ -12,6 +12,7 @@ import (
 
 	"github.com/insajin/autopus-bridge/internal/auth"
 	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/tracing"
 )
 
 // ValidateID는 ID 문자열이 허용된 형식인지 검사합니다.
@@ -74,7 +75,7 @@ func New(backend *mcpserver.BackendClient, creds *auth.Credentials, tokenRefresh
 		backend:        backend,
 		creds:          creds,
 		tokenRefresher: tokenRefresher,
-		httpClient:     &http.Client{Timeout: 30 * time.Second},
+		httpClient:     &http.Client{Timeout: 30 * time.Second, Transport: tracing.NewTransport(nil)},
 		workspaceID:    workspaceID,
 		baseURL:        baseURL,
 	}
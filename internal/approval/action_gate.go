@@ -0,0 +1,287 @@
+// Package approval provides a provider-agnostic approval relay system
+// for interactive execution with tool-level approval routing.
+package approval
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Server-initiated action types that can be gated locally before execution.
+const (
+	// ActionCLIRequest is a shell command requested by the server (cli_request).
+	ActionCLIRequest = "cli_request"
+	// ActionMCPDeploy is an MCP server deployment requested by the server (mcp_deploy).
+	ActionMCPDeploy = "mcp_deploy"
+	// ActionComputerUse is a Computer Use session requested by the server (computer_session_start).
+	ActionComputerUse = "computer_use"
+	// ActionApplyChanges copies changes from an isolated work_dir copy back into work_dir.
+	ActionApplyChanges = "apply_changes"
+	// ActionCustomTool is a user-defined local tool invoked by the server (custom_tool_request).
+	ActionCustomTool = "custom_tool"
+	// ActionGitRequest is a git commit or push requested by the server (git_request).
+	ActionGitRequest = "git_request"
+)
+
+// GateMode determines how a server-initiated action is handled locally.
+type GateMode string
+
+const (
+	// GateModeAuto executes the action without asking (backward compatible default).
+	GateModeAuto GateMode = "auto"
+	// GateModePrompt asks the user in the terminal. Headless sessions deny automatically.
+	GateModePrompt GateMode = "prompt"
+	// GateModeDeny rejects the action unless it matches the allowlist.
+	GateModeDeny GateMode = "deny"
+)
+
+// DefaultPromptTimeout is how long the terminal prompt waits before denying.
+const DefaultPromptTimeout = 60 * time.Second
+
+// ErrActionDenied is returned when a server-initiated action is not approved locally.
+var ErrActionDenied = errors.New("action denied by local approval policy")
+
+// LocalAction describes a server-initiated action awaiting local approval.
+type LocalAction struct {
+	// Type is one of ActionCLIRequest, ActionMCPDeploy, ActionComputerUse, ActionApplyChanges,
+	// ActionCustomTool, ActionGitRequest.
+	Type string
+	// Target is the value matched against the allowlist
+	// (command line for cli_request, service name for mcp_deploy, URL for computer_use,
+	// work_dir for apply_changes, tool name for custom_tool, repository for git_request).
+	Target string
+	// Detail is additional context shown in the prompt (e.g. working directory).
+	Detail string
+}
+
+// Prompter asks a human to approve or deny a local action.
+type Prompter interface {
+	Confirm(ctx context.Context, action LocalAction) (bool, error)
+}
+
+// ActionGateConfig configures an ActionGate.
+type ActionGateConfig struct {
+	// Modes maps action type to gate mode. Missing entries default to GateModeAuto.
+	Modes map[string]GateMode
+	// Allowlist maps action type to pre-approved targets.
+	// An entry ending in "*" matches any target with that prefix; otherwise it must match exactly.
+	Allowlist map[string][]string
+}
+
+// ActionGate decides whether server-initiated actions may run on this machine.
+// Allowlisted targets always pass; otherwise the per-type mode applies.
+type ActionGate struct {
+	modes    map[string]GateMode
+	prompter Prompter
+	logger   zerolog.Logger
+
+	// allowMu guards allowlist, which can be replaced at runtime by SetAllowlist.
+	allowMu   sync.RWMutex
+	allowlist map[string][]string
+
+	// promptMu serializes terminal prompts so concurrent requests do not interleave.
+	promptMu sync.Mutex
+}
+
+// NewActionGate creates a new ActionGate. prompter may be nil for headless
+// sessions, in which case GateModePrompt denies automatically.
+func NewActionGate(cfg ActionGateConfig, prompter Prompter, logger zerolog.Logger) *ActionGate {
+	g := &ActionGate{
+		modes:     make(map[string]GateMode, len(cfg.Modes)),
+		allowlist: normalizeAllowlist(cfg.Allowlist),
+		prompter:  prompter,
+		logger: logger.With().
+			Str("component", "action-gate").
+			Logger(),
+	}
+	for actionType, mode := range cfg.Modes {
+		g.modes[actionType] = normalizeGateMode(mode)
+	}
+	return g
+}
+
+// SetAllowlist replaces the pre-approved targets. Gate modes are unchanged.
+// It is safe to call while Check is running on other goroutines.
+func (g *ActionGate) SetAllowlist(allowlist map[string][]string) {
+	normalized := normalizeAllowlist(allowlist)
+
+	g.allowMu.Lock()
+	g.allowlist = normalized
+	g.allowMu.Unlock()
+}
+
+// normalizeAllowlist copies the allowlist, dropping blank entries.
+func normalizeAllowlist(allowlist map[string][]string) map[string][]string {
+	normalized := make(map[string][]string, len(allowlist))
+	for actionType, entries := range allowlist {
+		for _, entry := range entries {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				normalized[actionType] = append(normalized[actionType], entry)
+			}
+		}
+	}
+	return normalized
+}
+
+// Mode returns the effective gate mode for an action type.
+func (g *ActionGate) Mode(actionType string) GateMode {
+	if mode, ok := g.modes[actionType]; ok {
+		return mode
+	}
+	return GateModeAuto
+}
+
+// Check returns nil if the action may run, or an error wrapping ErrActionDenied.
+func (g *ActionGate) Check(ctx context.Context, action LocalAction) error {
+	if g.isAllowlisted(action) {
+		g.logger.Debug().Str("action", action.Type).Str("target", action.Target).Msg("allowlisted action approved")
+		return nil
+	}
+
+	switch g.Mode(action.Type) {
+	case GateModeAuto:
+		return nil
+	case GateModeDeny:
+		g.logger.Warn().Str("action", action.Type).Str("target", action.Target).Msg("action denied by policy")
+		return fmt.Errorf("%w: %s is not allowlisted", ErrActionDenied, action.Type)
+	}
+
+	if g.prompter == nil {
+		g.logger.Warn().Str("action", action.Type).Str("target", action.Target).Msg("action denied: no interactive terminal")
+		return fmt.Errorf("%w: approval required but no interactive terminal is available", ErrActionDenied)
+	}
+
+	g.promptMu.Lock()
+	approved, err := g.prompter.Confirm(ctx, action)
+	g.promptMu.Unlock()
+	if err != nil {
+		g.logger.Warn().Err(err).Str("action", action.Type).Msg("approval prompt failed")
+		return fmt.Errorf("%w: %v", ErrActionDenied, err)
+	}
+	if !approved {
+		g.logger.Info().Str("action", action.Type).Str("target", action.Target).Msg("action denied by user")
+		return fmt.Errorf("%w: denied by user", ErrActionDenied)
+	}
+
+	g.logger.Info().Str("action", action.Type).Str("target", action.Target).Msg("action approved by user")
+	return nil
+}
+
+// isAllowlisted reports whether the action target matches a pre-approved entry.
+func (g *ActionGate) isAllowlisted(action LocalAction) bool {
+	target := strings.TrimSpace(action.Target)
+	if target == "" {
+		return false
+	}
+	g.allowMu.RLock()
+	defer g.allowMu.RUnlock()
+	for _, entry := range g.allowlist[action.Type] {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(target, prefix) {
+				return true
+			}
+			continue
+		}
+		if target == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeGateMode maps unknown values to GateModeAuto.
+func normalizeGateMode(mode GateMode) GateMode {
+	switch GateMode(strings.ToLower(strings.TrimSpace(string(mode)))) {
+	case GateModePrompt:
+		return GateModePrompt
+	case GateModeDeny:
+		return GateModeDeny
+	default:
+		return GateModeAuto
+	}
+}
+
+// TerminalPrompter asks for approval on a terminal using line-based input.
+// Any answer other than "y"/"yes" denies; no answer within the timeout denies.
+type TerminalPrompter struct {
+	lines   chan string
+	out     io.Writer
+	timeout time.Duration
+}
+
+// NewTerminalPrompter creates a TerminalPrompter reading answers from in and writing prompts to out.
+// A timeout of zero or less uses DefaultPromptTimeout.
+func NewTerminalPrompter(in io.Reader, out io.Writer, timeout time.Duration) *TerminalPrompter {
+	if timeout <= 0 {
+		timeout = DefaultPromptTimeout
+	}
+	p := &TerminalPrompter{
+		lines:   make(chan string),
+		out:     out,
+		timeout: timeout,
+	}
+	// A single reader goroutine owns the input so a timed-out prompt does not
+	// leave a pending read that swallows the next answer.
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			p.lines <- scanner.Text()
+		}
+		close(p.lines)
+	}()
+	return p
+}
+
+// Confirm prints the action and waits for a yes/no answer.
+func (p *TerminalPrompter) Confirm(ctx context.Context, action LocalAction) (bool, error) {
+	// Drop any answer typed while no prompt was shown.
+drain:
+	for {
+		select {
+		case _, ok := <-p.lines:
+			if !ok {
+				return false, errors.New("approval input closed")
+			}
+		default:
+			break drain
+		}
+	}
+
+	fmt.Fprintf(p.out, "\n[승인 필요] 서버가 로컬 작업 실행을 요청했습니다\n")
+	fmt.Fprintf(p.out, "  유형:   %s\n", action.Type)
+	fmt.Fprintf(p.out, "  대상:   %s\n", action.Target)
+	if action.Detail != "" {
+		fmt.Fprintf(p.out, "  상세:   %s\n", action.Detail)
+	}
+	fmt.Fprintf(p.out, "실행을 허용하시겠습니까? [y/N] (%s 후 자동 거부): ", p.timeout)
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(p.out)
+		return false, ctx.Err()
+	case <-timer.C:
+		fmt.Fprintln(p.out, "\n응답 시간 초과 - 거부되었습니다")
+		return false, nil
+	case line, ok := <-p.lines:
+		if !ok {
+			return false, errors.New("approval input closed")
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return true, nil
+		default:
+			return false, nil
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// fakePrompter는 고정된 응답을 반환하는 테스트용 Prompter입니다.
+type fakePrompter struct {
+	approve bool
+	calls   int
+}
+
+func (p *fakePrompter) Confirm(_ context.Context, _ LocalAction) (bool, error) {
+	p.calls++
+	return p.approve, nil
+}
+
+// TestActionGate_Modes는 작업 유형별 모드에 따른 승인/거부를 검증합니다.
+func TestActionGate_Modes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mode     GateMode
+		prompter Prompter
+		wantErr  bool
+	}{
+		{name: "auto 모드는 자동 실행", mode: GateModeAuto, wantErr: false},
+		{name: "알 수 없는 모드는 auto로 처리", mode: "unknown", wantErr: false},
+		{name: "deny 모드는 거부", mode: GateModeDeny, wantErr: true},
+		{name: "prompt 모드 + 헤드리스는 자동 거부", mode: GateModePrompt, prompter: nil, wantErr: true},
+		{name: "prompt 모드 + 사용자 승인", mode: GateModePrompt, prompter: &fakePrompter{approve: true}, wantErr: false},
+		{name: "prompt 모드 + 사용자 거부", mode: GateModePrompt, prompter: &fakePrompter{approve: false}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gate := NewActionGate(ActionGateConfig{
+				Modes: map[string]GateMode{ActionCLIRequest: tt.mode},
+			}, tt.prompter, zerolog.Nop())
+
+			err := gate.Check(context.Background(), LocalAction{Type: ActionCLIRequest, Target: "rm -rf build"})
+			if tt.wantErr && !errors.Is(err, ErrActionDenied) {
+				t.Errorf("ErrActionDenied가 반환되어야 하나 %v입니다", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("승인되어야 하나 에러가 반환되었습니다: %v", err)
+			}
+		})
+	}
+}
+
+// TestActionGate_Allowlist는 허용 목록에 있는 대상은 모드와 무관하게 승인되는지 검증합니다.
+func TestActionGate_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	prompter := &fakePrompter{approve: false}
+	gate := NewActionGate(ActionGateConfig{
+		Modes: map[string]GateMode{
+			ActionCLIRequest: GateModeDeny,
+			ActionMCPDeploy:  GateModePrompt,
+		},
+		Allowlist: map[string][]string{
+			ActionCLIRequest: {"go test ./...", "npm run *"},
+			ActionMCPDeploy:  {"github-mcp"},
+		},
+	}, prompter, zerolog.Nop())
+
+	ctx := context.Background()
+	if err := gate.Check(ctx, LocalAction{Type: ActionCLIRequest, Target: "go test ./..."}); err != nil {
+		t.Errorf("정확히 일치하는 명령은 승인되어야 합니다: %v", err)
+	}
+	if err := gate.Check(ctx, LocalAction{Type: ActionCLIRequest, Target: "npm run lint"}); err != nil {
+		t.Errorf("접두사 패턴에 일치하는 명령은 승인되어야 합니다: %v", err)
+	}
+	if err := gate.Check(ctx, LocalAction{Type: ActionCLIRequest, Target: "go test ./... && rm -rf /"}); err == nil {
+		t.Error("정확히 일치하지 않는 명령은 거부되어야 합니다")
+	}
+	if err := gate.Check(ctx, LocalAction{Type: ActionMCPDeploy, Target: "github-mcp"}); err != nil {
+		t.Errorf("허용된 서비스는 프롬프트 없이 승인되어야 합니다: %v", err)
+	}
+	if prompter.calls != 0 {
+		t.Errorf("허용 목록 대상은 프롬프트를 띄우지 않아야 하나 %d회 호출되었습니다", prompter.calls)
+	}
+}
+
+// TestActionGate_SetAllowlist는 실행 중 허용 목록 교체가 모드를 유지한 채 반영되는지 검증합니다.
+func TestActionGate_SetAllowlist(t *testing.T) {
+	t.Parallel()
+
+	gate := NewActionGate(ActionGateConfig{
+		Modes:     map[string]GateMode{ActionCLIRequest: GateModeDeny},
+		Allowlist: map[string][]string{ActionCLIRequest: {"make build"}},
+	}, nil, zerolog.Nop())
+
+	ctx := context.Background()
+	gate.SetAllowlist(map[string][]string{ActionCLIRequest: {" go test * ", ""}})
+
+	if err := gate.Check(ctx, LocalAction{Type: ActionCLIRequest, Target: "go test ./..."}); err != nil {
+		t.Errorf("새 허용 목록의 명령은 승인되어야 합니다: %v", err)
+	}
+	if err := gate.Check(ctx, LocalAction{Type: ActionCLIRequest, Target: "make build"}); err == nil {
+		t.Error("교체 전 허용 목록의 명령은 거부되어야 합니다")
+	}
+	if mode := gate.Mode(ActionCLIRequest); mode != GateModeDeny {
+		t.Errorf("허용 목록 교체 후에도 모드는 유지되어야 합니다: %s", mode)
+	}
+}
+
+// TestTerminalPrompter는 터미널 입력에 따른 승인/거부/타임아웃을 검증합니다.
+func TestTerminalPrompter(t *testing.T) {
+	t.Parallel()
+
+	action := LocalAction{Type: ActionMCPDeploy, Target: "svc", Detail: "files=2"}
+
+	answer := func(t *testing.T, input string) (bool, string) {
+		t.Helper()
+		pr, pw := io.Pipe()
+		out := &bytes.Buffer{}
+		p := NewTerminalPrompter(pr, out, time.Second)
+
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_, _ = pw.Write([]byte(input))
+		}()
+		approved, err := p.Confirm(context.Background(), action)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return approved, out.String()
+	}
+
+	t.Run("y 입력은 승인", func(t *testing.T) {
+		t.Parallel()
+		approved, out := answer(t, "y\n")
+		if !approved {
+			t.Error("y 입력은 승인되어야 합니다")
+		}
+		if !strings.Contains(out, "svc") {
+			t.Errorf("프롬프트에 대상이 표시되어야 합니다: %q", out)
+		}
+	})
+
+	t.Run("그 외 입력은 거부", func(t *testing.T) {
+		t.Parallel()
+		if approved, _ := answer(t, "n\n"); approved {
+			t.Error("n 입력은 거부되어야 합니다")
+		}
+	})
+
+	t.Run("타임아웃은 거부", func(t *testing.T) {
+		t.Parallel()
+		pr, _ := io.Pipe()
+		p := NewTerminalPrompter(pr, io.Discard, 50*time.Millisecond)
+
+		approved, err := p.Confirm(context.Background(), action)
+		if err != nil || approved {
+			t.Errorf("타임아웃 시 거부되어야 합니다: approved=%v err=%v", approved, err)
+		}
+	})
+}
@@ -73,8 +73,27 @@ const (
 	// ApprovalPolicyHumanApprove means the user must explicitly approve
 	// or deny each tool call.
 	ApprovalPolicyHumanApprove ApprovalPolicy = "human-approve"
+	// ApprovalPolicyDenyAll means every tool call is denied without asking.
+	ApprovalPolicyDenyAll ApprovalPolicy = "deny-all"
 )
 
+// Valid reports whether p is a known approval policy.
+func (p ApprovalPolicy) Valid() bool {
+	switch p {
+	case ApprovalPolicyAutoExecute, ApprovalPolicyAutoApprove, ApprovalPolicyAgentApprove,
+		ApprovalPolicyHumanApprove, ApprovalPolicyDenyAll:
+		return true
+	}
+	return false
+}
+
+// RequiresRelay reports whether enforcing p needs a provider that routes tool
+// calls through an ApprovalRelay. Auto-execute and auto-approve allow every
+// tool call, so any provider satisfies them.
+func (p ApprovalPolicy) RequiresRelay() bool {
+	return p == ApprovalPolicyAgentApprove || p == ApprovalPolicyHumanApprove || p == ApprovalPolicyDenyAll
+}
+
 // ApprovalRouter is a common router for all providers that routes
 // tool approval requests based on the configured policy.
 type ApprovalRouter struct {
@@ -118,6 +137,16 @@ func (r *ApprovalRouter) HandleApproval(ctx context.Context, req ToolApprovalReq
 		}, nil
 	}
 
+	// Deny-all: deny immediately without asking anyone
+	if r.policy == ApprovalPolicyDenyAll {
+		return ToolApprovalDecision{
+			Decision:  "deny",
+			Reason:    "deny-all policy: tool calls are not allowed",
+			DecidedBy: "policy",
+			DecidedAt: now,
+		}, nil
+	}
+
 	// Agent-approve or human-approve: wait for decision
 	ch := make(chan ToolApprovalDecision, 1)
 	r.pendingApprovals.Store(req.ApprovalID, ch)
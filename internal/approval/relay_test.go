@@ -107,6 +107,49 @@ func TestApprovalRouter_AutoApprove(t *testing.T) {
 	}
 }
 
+// TestApprovalRouter_DenyAll은 deny-all 정책이 기다리지 않고 즉시 deny를 반환하는지 검증합니다.
+func TestApprovalRouter_DenyAll(t *testing.T) {
+	t.Parallel()
+
+	router := NewApprovalRouter(ApprovalPolicyDenyAll, 30*time.Second)
+	decision, err := router.HandleApproval(context.Background(), ToolApprovalRequest{
+		ApprovalID: "test-deny",
+		ToolName:   "Bash",
+	})
+	if err != nil {
+		t.Fatalf("HandleApproval 에러: %v", err)
+	}
+	if decision.Decision != "deny" || decision.DecidedBy != "policy" {
+		t.Errorf("decision = %+v, want deny by policy", decision)
+	}
+}
+
+// TestApprovalPolicy_ValidAndRequiresRelay는 정책 검증과 릴레이 필요 여부를 검증합니다.
+func TestApprovalPolicy_ValidAndRequiresRelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy ApprovalPolicy
+		valid  bool
+		relay  bool
+	}{
+		{ApprovalPolicyAutoExecute, true, false},
+		{ApprovalPolicyAutoApprove, true, false},
+		{ApprovalPolicyAgentApprove, true, true},
+		{ApprovalPolicyHumanApprove, true, true},
+		{ApprovalPolicyDenyAll, true, true},
+		{"allow-everything", false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Valid(); got != tt.valid {
+			t.Errorf("%q.Valid() = %v, want %v", tt.policy, got, tt.valid)
+		}
+		if got := tt.policy.RequiresRelay(); got != tt.relay {
+			t.Errorf("%q.RequiresRelay() = %v, want %v", tt.policy, got, tt.relay)
+		}
+	}
+}
+
 // TestApprovalRouter_HumanApprove_DeliverAllow는 human-approve 정책에서
 // allow 결정을 전달하면 올바르게 반환되는지 검증합니다.
 func TestApprovalRouter_HumanApprove_DeliverAllow(t *testing.T) {
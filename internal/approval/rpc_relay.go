@@ -3,6 +3,7 @@ package approval
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +13,7 @@ import (
 // approval protocol. It bridges Codex's CommandExecutionApproval and
 // FileChangeApproval notifications into the provider-agnostic approval flow.
 type RPCRelay struct {
+	mu           sync.RWMutex
 	handler      ApprovalHandler
 	providerName string
 }
@@ -28,8 +30,10 @@ func (r *RPCRelay) SupportsApproval() bool {
 	return true
 }
 
-// SetApprovalHandler registers the handler that processes approval requests.
+// SetApprovalHandler registers the default handler that processes approval requests.
 func (r *RPCRelay) SetApprovalHandler(handler ApprovalHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.handler = handler
 }
 
@@ -38,7 +42,18 @@ func (r *RPCRelay) SetApprovalHandler(handler ApprovalHandler) {
 // "command_execution" or "file_change". The toolInput is the raw JSON
 // params from the RPC notification.
 func (r *RPCRelay) HandleRPCApproval(ctx context.Context, executionID, toolName string, toolInput json.RawMessage) (ToolApprovalDecision, error) {
-	if r.handler == nil {
+	return r.HandleRPCApprovalWith(ctx, nil, executionID, toolName, toolInput)
+}
+
+// HandleRPCApprovalWith is HandleRPCApproval with a per-execution handler.
+// A nil handler falls back to the one registered with SetApprovalHandler.
+func (r *RPCRelay) HandleRPCApprovalWith(ctx context.Context, handler ApprovalHandler, executionID, toolName string, toolInput json.RawMessage) (ToolApprovalDecision, error) {
+	if handler == nil {
+		r.mu.RLock()
+		handler = r.handler
+		r.mu.RUnlock()
+	}
+	if handler == nil {
 		// No handler set: default to allow (backward-compatible auto-execute)
 		return ToolApprovalDecision{
 			Decision:  "allow",
@@ -57,5 +72,5 @@ func (r *RPCRelay) HandleRPCApproval(ctx context.Context, executionID, toolName
 		RequestedAt:  time.Now(),
 	}
 
-	return r.handler(ctx, req)
+	return handler(ctx, req)
 }
@@ -22,6 +22,8 @@ type Credentials struct {
 	WorkspaceID   string    `json:"workspace_id,omitempty"`
 	WorkspaceSlug string    `json:"workspace_slug,omitempty"`
 	WorkspaceName string    `json:"workspace_name,omitempty"`
+	// RefreshExpiresAt is when the refresh token expires; zero when the server did not report it.
+	RefreshExpiresAt time.Time `json:"refresh_expires_at,omitzero"`
 }
 
 // IsExpired checks if the access token has expired.
@@ -92,6 +94,22 @@ func (c *Credentials) ExpiresAtFromJWT() (time.Time, error) {
 	return ParseJWTExpiry(c.AccessToken)
 }
 
+// RefreshTokenExpiry returns when the refresh token expires.
+// It prefers the expiry reported by the server and falls back to the exp claim
+// of a JWT refresh token; ok is false when neither is known.
+func (c *Credentials) RefreshTokenExpiry() (expiry time.Time, ok bool) {
+	if !c.RefreshExpiresAt.IsZero() {
+		return c.RefreshExpiresAt, true
+	}
+	if c.RefreshToken == "" {
+		return time.Time{}, false
+	}
+	if jwtExpiry, err := ParseJWTExpiry(c.RefreshToken); err == nil {
+		return jwtExpiry, true
+	}
+	return time.Time{}, false
+}
+
 // credentialsDir returns the directory for storing credentials.
 // Uses ~/.config/autopus on Unix-like systems.
 func credentialsDir() (string, error) {
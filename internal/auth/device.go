@@ -53,6 +53,8 @@ type DeviceTokenResponse struct {
 	TokenType    string `json:"token_type,omitempty"`
 	ExpiresIn    int    `json:"expires_in,omitempty"`
 	Error        string `json:"error,omitempty"`
+	// RefreshExpiresIn은 refresh token의 수명(초)입니다. 0이면 서버가 알려주지 않은 것입니다.
+	RefreshExpiresIn int `json:"refresh_expires_in,omitempty"`
 	// 서버가 성공 시 사용자 정보를 함께 반환할 수 있음
 	UserEmail     string `json:"user_email,omitempty"`
 	WorkspaceID   string `json:"workspace_id,omitempty"`
@@ -62,6 +64,34 @@ type DeviceTokenResponse struct {
 	Workspaces []TokenWorkspace `json:"workspaces,omitempty"`
 }
 
+// RefreshExpiresAt는 RefreshExpiresIn으로 계산한 refresh token 만료 시각을 반환합니다.
+// 서버가 수명을 알려주지 않았으면 zero time을 반환합니다.
+func (t *DeviceTokenResponse) RefreshExpiresAt() time.Time {
+	if t.RefreshExpiresIn <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(t.RefreshExpiresIn) * time.Second)
+}
+
+// NewCredentials는 토큰 응답으로 serverURL에 대한 자격 증명을 만듭니다.
+// access token 만료 시각은 JWT exp 클레임이 있으면 그것을, 없으면 ExpiresIn을 사용합니다.
+func (t *DeviceTokenResponse) NewCredentials(serverURL string) *Credentials {
+	expiresAt := time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	if jwtExpiry, err := ParseJWTExpiry(t.AccessToken); err == nil {
+		expiresAt = jwtExpiry
+	}
+	return &Credentials{
+		AccessToken:      t.AccessToken,
+		RefreshToken:     t.RefreshToken,
+		ExpiresAt:        expiresAt,
+		ServerURL:        serverURL,
+		UserEmail:        t.UserEmail,
+		WorkspaceID:      t.WorkspaceID,
+		WorkspaceSlug:    t.WorkspaceSlug,
+		RefreshExpiresAt: t.RefreshExpiresAt(),
+	}
+}
+
 const (
 	// deviceHTTPTimeout은 개별 HTTP 요청의 타임아웃입니다.
 	deviceHTTPTimeout = 10 * time.Second
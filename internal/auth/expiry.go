@@ -0,0 +1,49 @@
+// expiry.go는 refresh token 만료 임박/만료 상태를 판정합니다.
+// refresh token이 만료되면 access token을 더 이상 갱신할 수 없으므로 사용자가 다시 인증해야 합니다.
+package auth
+
+import "time"
+
+// DefaultExpiryWarning은 refresh token 만료 경고를 시작하는 기본 시점(만료 전)입니다.
+const DefaultExpiryWarning = 72 * time.Hour
+
+// ExpiryState는 refresh token 만료 상태입니다.
+// 값은 프로토콜의 CredentialState* 상수와 같습니다.
+type ExpiryState string
+
+const (
+	// ExpiryValid는 refresh token이 유효하고 경고 기간 밖인 상태입니다 (만료 시각을 모르는 경우 포함).
+	ExpiryValid ExpiryState = "valid"
+	// ExpiryExpiring은 refresh token이 경고 기간 안에 만료되는 상태입니다.
+	ExpiryExpiring ExpiryState = "expiring"
+	// ExpiryExpired는 refresh token이 만료되었거나 서버가 거부한 상태입니다.
+	ExpiryExpired ExpiryState = "expired"
+)
+
+// ExpiryStatus는 refresh token 만료 판정 결과입니다.
+type ExpiryStatus struct {
+	State ExpiryState
+	// RefreshExpiresAt은 refresh token 만료 시각입니다. 알 수 없으면 zero time입니다.
+	RefreshExpiresAt time.Time
+}
+
+// CheckExpiry는 now 기준으로 refresh token 만료 상태를 판정합니다.
+// 만료까지 warnBefore 이하로 남았으면 ExpiryExpiring, 지났으면 ExpiryExpired를 반환합니다.
+func CheckExpiry(creds *Credentials, warnBefore time.Duration, now time.Time) ExpiryStatus {
+	if creds == nil {
+		return ExpiryStatus{State: ExpiryValid}
+	}
+	expiry, ok := creds.RefreshTokenExpiry()
+	if !ok {
+		return ExpiryStatus{State: ExpiryValid}
+	}
+
+	status := ExpiryStatus{State: ExpiryValid, RefreshExpiresAt: expiry}
+	switch {
+	case !now.Before(expiry):
+		status.State = ExpiryExpired
+	case expiry.Sub(now) <= warnBefore:
+		status.State = ExpiryExpiring
+	}
+	return status
+}
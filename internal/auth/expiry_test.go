@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testJWT는 exp 클레임만 있는 서명되지 않은 JWT를 만듭니다.
+func testJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJIUzI1NiJ9." + payload + ".sig"
+}
+
+func TestRefreshTokenExpiry(t *testing.T) {
+	reported := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	jwtExp := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name   string
+		creds  Credentials
+		want   time.Time
+		wantOK bool
+	}{
+		{"서버 보고 값 우선", Credentials{RefreshToken: testJWT(jwtExp), RefreshExpiresAt: reported}, reported, true},
+		{"JWT exp 폴백", Credentials{RefreshToken: testJWT(jwtExp)}, jwtExp, true},
+		{"불투명 토큰", Credentials{RefreshToken: "opaque"}, time.Time{}, false},
+		{"refresh token 없음", Credentials{}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.creds.RefreshTokenExpiry()
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("RefreshTokenExpiry() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestCheckExpiry(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      ExpiryState
+	}{
+		{"경고 기간 밖", now.Add(100 * time.Hour), ExpiryValid},
+		{"경고 기간 안", now.Add(10 * time.Hour), ExpiryExpiring},
+		{"만료", now.Add(-time.Minute), ExpiryExpired},
+		{"만료 시각 모름", time.Time{}, ExpiryValid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds := &Credentials{RefreshToken: "opaque", RefreshExpiresAt: tt.expiresAt}
+			got := CheckExpiry(creds, DefaultExpiryWarning, now)
+			if got.State != tt.want {
+				t.Errorf("CheckExpiry().State = %q; want %q", got.State, tt.want)
+			}
+			if !got.RefreshExpiresAt.Equal(tt.expiresAt) {
+				t.Errorf("CheckExpiry().RefreshExpiresAt = %v; want %v", got.RefreshExpiresAt, tt.expiresAt)
+			}
+		})
+	}
+
+	if got := CheckExpiry(nil, DefaultExpiryWarning, now); got.State != ExpiryValid {
+		t.Errorf("CheckExpiry(nil).State = %q; want valid", got.State)
+	}
+}
+
+func TestTokenRefresher_ExpiryWatch(t *testing.T) {
+	setupTestEnv(t)
+
+	creds := newTestCredentials(time.Now().Add(time.Hour))
+	creds.RefreshExpiresAt = time.Now().Add(time.Hour)
+	refresher := NewTokenRefresher(creds)
+
+	var got []ExpiryStatus
+	refresher.SetExpiryWatch(24*time.Hour, func(status ExpiryStatus) {
+		got = append(got, status)
+	})
+
+	refresher.checkExpiry()
+	refresher.checkExpiry() // 상태가 같으면 다시 알리지 않음
+	if len(got) != 1 || got[0].State != ExpiryExpiring {
+		t.Fatalf("notifications = %+v; want one expiring", got)
+	}
+
+	// 서버가 refresh token을 거부하면 만료 시각과 무관하게 expired
+	refresher.mu.Lock()
+	refresher.rejected = true
+	refresher.mu.Unlock()
+	refresher.checkExpiry()
+	if len(got) != 2 || got[1].State != ExpiryExpired {
+		t.Fatalf("notifications = %+v; want expired after rejection", got)
+	}
+
+	renewed := newTestCredentials(time.Now().Add(time.Hour))
+	renewed.AccessToken = "renewed-access-token"
+	renewed.RefreshExpiresAt = time.Now().Add(30 * 24 * time.Hour)
+	refresher.UpdateCredentials(renewed)
+	if len(got) != 3 || got[2].State != ExpiryValid {
+		t.Fatalf("notifications = %+v; want valid after renewal", got)
+	}
+	if token, err := refresher.GetToken(); err != nil || token != "renewed-access-token" {
+		t.Errorf("GetToken() = %q, %v; want renewed token", token, err)
+	}
+}
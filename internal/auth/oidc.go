@@ -0,0 +1,492 @@
+// oidc.go는 워크스페이스별 엔터프라이즈 SSO(OIDC) 로그인을 구현합니다.
+// 워크스페이스에 설정된 IdP를 OpenID Connect Discovery로 조회한 뒤
+// Device Authorization Grant(RFC 8628) 또는 PKCE 브라우저 플로우(RFC 7636)로 IdP 토큰을 받고,
+// POST /api/v1/auth/sso/exchange 로 Autopus 자격 증명과 교환합니다.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrSSONotConfigured는 워크스페이스에 SSO IdP가 등록되어 있지 않을 때 반환됩니다.
+var ErrSSONotConfigured = errors.New("SSO가 설정되어 있지 않습니다")
+
+// DefaultOIDCScopes는 IdP에 요청하는 기본 스코프입니다.
+var DefaultOIDCScopes = []string{"openid", "email", "profile", "offline_access"}
+
+// oidcCallbackPath는 브라우저 플로우의 루프백 리다이렉트 경로입니다.
+const oidcCallbackPath = "/callback"
+
+// SSOConfig는 워크스페이스에 설정된 OIDC IdP 정보입니다.
+// GET /api/v1/auth/sso/config?workspace=<slug> 에서 반환됩니다.
+type SSOConfig struct {
+	Workspace string   `json:"workspace"`
+	Issuer    string   `json:"issuer"`
+	ClientID  string   `json:"client_id"`
+	Scopes    []string `json:"scopes,omitempty"`
+	// Audience는 일부 IdP(Auth0 등)가 요구하는 audience 파라미터입니다.
+	Audience string `json:"audience,omitempty"`
+}
+
+// OIDCProviderMetadata는 OpenID Connect Discovery 문서의 필요한 필드입니다.
+type OIDCProviderMetadata struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint   string   `json:"device_authorization_endpoint,omitempty"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
+}
+
+// SupportsDeviceFlow는 IdP가 Device Authorization Grant를 지원하는지 확인합니다.
+func (m *OIDCProviderMetadata) SupportsDeviceFlow() bool {
+	return m.DeviceAuthorizationEndpoint != ""
+}
+
+// OIDCTokenResponse는 IdP 토큰 엔드포인트 응답입니다.
+type OIDCTokenResponse struct {
+	AccessToken      string `json:"access_token,omitempty"`
+	IDToken          string `json:"id_token,omitempty"`
+	RefreshToken     string `json:"refresh_token,omitempty"`
+	TokenType        string `json:"token_type,omitempty"`
+	ExpiresIn        int    `json:"expires_in,omitempty"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// ssoExchangeRequest는 IdP 토큰을 Autopus 자격 증명으로 교환하는 요청입니다.
+type ssoExchangeRequest struct {
+	Workspace   string `json:"workspace"`
+	Issuer      string `json:"issuer"`
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token,omitempty"`
+}
+
+// FetchSSOConfig는 워크스페이스에 설정된 SSO IdP 정보를 조회합니다.
+// 워크스페이스에 SSO가 설정되어 있지 않으면 에러를 반환합니다.
+func FetchSSOConfig(ctx context.Context, apiBaseURL, workspace string) (*SSOConfig, error) {
+	endpoint := apiBaseURL + "/api/v1/auth/sso/config?workspace=" + url.QueryEscape(workspace)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
+	}
+	setBridgeHeaders(req)
+
+	client := &http.Client{Timeout: deviceHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("SSO 설정 조회 실패: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("워크스페이스 '%s': %w", workspace, ErrSSONotConfigured)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SSO 설정 조회 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	var cfg SSOConfig
+	if err := decodeWrapped(resp.Body, &cfg); err != nil {
+		return nil, fmt.Errorf("SSO 설정 응답 파싱 실패: %w", err)
+	}
+	if cfg.Issuer == "" || cfg.ClientID == "" {
+		return nil, fmt.Errorf("워크스페이스 '%s'의 SSO 설정에 issuer 또는 client_id가 없습니다", workspace)
+	}
+	if cfg.Workspace == "" {
+		cfg.Workspace = workspace
+	}
+	return &cfg, nil
+}
+
+// DiscoverOIDC는 issuer의 OpenID Connect Discovery 문서를 조회합니다.
+// 문서의 issuer가 요청한 issuer와 다르면 거부합니다 (OIDC Discovery 4.3).
+func DiscoverOIDC(ctx context.Context, issuer string) (*OIDCProviderMetadata, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: deviceHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC Discovery 실패: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC Discovery 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	var meta OIDCProviderMetadata
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&meta); err != nil {
+		return nil, fmt.Errorf("OIDC Discovery 문서 파싱 실패: %w", err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC Discovery issuer 불일치: %s (예상: %s)", meta.Issuer, issuer)
+	}
+	if meta.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC Discovery 문서에 token_endpoint가 없습니다")
+	}
+	return &meta, nil
+}
+
+// RequestOIDCDeviceCode는 IdP에 디바이스 코드를 요청합니다 (RFC 8628 3.1).
+func RequestOIDCDeviceCode(ctx context.Context, meta *OIDCProviderMetadata, cfg *SSOConfig) (*DeviceCodeResponse, error) {
+	if !meta.SupportsDeviceFlow() {
+		return nil, fmt.Errorf("IdP가 Device Authorization Grant를 지원하지 않습니다")
+	}
+
+	form := url.Values{
+		"client_id": {cfg.ClientID},
+		"scope":     {strings.Join(ssoScopes(cfg), " ")},
+	}
+	if cfg.Audience != "" {
+		form.Set("audience", cfg.Audience)
+	}
+
+	body, status, err := postForm(ctx, meta.DeviceAuthorizationEndpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("IdP 디바이스 코드 요청 실패: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("IdP 디바이스 코드 요청 실패 (HTTP %d): %s", status, oidcErrorMessage(body))
+	}
+
+	// 일부 IdP(Google)는 verification_uri 대신 verification_url을 사용한다.
+	var deviceResp struct {
+		DeviceCodeResponse
+		VerificationURL string `json:"verification_url"`
+	}
+	if err := json.Unmarshal(body, &deviceResp); err != nil {
+		return nil, fmt.Errorf("IdP 디바이스 코드 응답 파싱 실패: %w", err)
+	}
+	result := deviceResp.DeviceCodeResponse
+	if result.VerificationURI == "" {
+		result.VerificationURI = deviceResp.VerificationURL
+	}
+	if result.DeviceCode == "" || result.UserCode == "" || result.VerificationURI == "" {
+		return nil, fmt.Errorf("IdP가 유효한 디바이스 코드를 반환하지 않았습니다")
+	}
+	if result.Interval <= 0 {
+		result.Interval = defaultPollInterval
+	}
+	return &result, nil
+}
+
+// PollOIDCDeviceToken은 IdP 토큰 엔드포인트를 폴링하여 사용자 인증 완료를 기다립니다 (RFC 8628 3.4).
+// ctx가 취소되면 폴링을 중단합니다.
+func PollOIDCDeviceToken(ctx context.Context, meta *OIDCProviderMetadata, cfg *SSOConfig, deviceCode string, interval int) (*OIDCTokenResponse, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	form := url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {deviceCode},
+		"client_id":   {cfg.ClientID},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(interval) * time.Second):
+			body, _, err := postForm(ctx, meta.TokenEndpoint, form)
+			if err != nil {
+				// 네트워크 오류 시 폴링 계속
+				continue
+			}
+
+			var tokenResp OIDCTokenResponse
+			if json.Unmarshal(body, &tokenResp) != nil {
+				continue
+			}
+
+			switch tokenResp.Error {
+			case "":
+				if tokenResp.IDToken != "" || tokenResp.AccessToken != "" {
+					return &tokenResp, nil
+				}
+				continue
+			case "authorization_pending":
+				continue
+			case "slow_down":
+				interval += slowDownIncrement
+				continue
+			case "expired_token":
+				return nil, fmt.Errorf("인증 코드가 만료되었습니다. 다시 시도하세요")
+			case "access_denied":
+				return nil, fmt.Errorf("IdP에서 인증이 거부되었습니다")
+			default:
+				return nil, fmt.Errorf("IdP 인증 오류: %s", oidcErrorMessage(body))
+			}
+		}
+	}
+}
+
+// AuthorizeOIDCBrowser는 PKCE 인가 코드 플로우로 IdP 토큰을 받습니다.
+// 127.0.0.1의 임의 포트에서 리다이렉트를 받고(RFC 8252 7.3), open으로 인가 URL을 엽니다.
+// 브라우저가 열리지 않는 경우를 대비해 인가 URL을 먼저 onURL로 전달합니다.
+func AuthorizeOIDCBrowser(ctx context.Context, meta *OIDCProviderMetadata, cfg *SSOConfig, open func(string) error, onURL func(string)) (*OIDCTokenResponse, error) {
+	if meta.AuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("OIDC Discovery 문서에 authorization_endpoint가 없습니다")
+	}
+
+	pkce, err := GeneratePKCE()
+	if err != nil {
+		return nil, fmt.Errorf("PKCE 생성 실패: %w", err)
+	}
+	state, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("state 생성 실패: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("로컬 콜백 서버 시작 실패: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), oidcCallbackPath)
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res callbackResult
+		switch {
+		case subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(state)) != 1:
+			res.err = fmt.Errorf("state 불일치: 다른 요청의 응답일 수 있습니다")
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("IdP 인증 오류: %s %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("code") == "":
+			res.err = fmt.Errorf("IdP가 인가 코드를 반환하지 않았습니다")
+		default:
+			res.code = q.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, "인증에 실패했습니다. 터미널을 확인하세요.", http.StatusBadRequest)
+		} else {
+			_, _ = io.WriteString(w, "인증이 완료되었습니다. 이 창을 닫고 터미널로 돌아가세요.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(listener) }()
+	defer func() { _ = srv.Close() }()
+
+	authURL, err := buildAuthorizationURL(meta.AuthorizationEndpoint, cfg, redirectURI, state, pkce)
+	if err != nil {
+		return nil, err
+	}
+	if onURL != nil {
+		onURL(authURL)
+	}
+	if open != nil {
+		_ = open(authURL)
+	}
+
+	var res callbackResult
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-results:
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	return exchangeAuthorizationCode(ctx, meta, cfg, res.code, redirectURI, pkce.CodeVerifier)
+}
+
+// buildAuthorizationURL은 PKCE 인가 요청 URL을 생성합니다.
+func buildAuthorizationURL(endpoint string, cfg *SSOConfig, redirectURI, state string, pkce *PKCEPair) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("authorization_endpoint 파싱 실패: %w", err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", strings.Join(ssoScopes(cfg), " "))
+	q.Set("state", state)
+	q.Set("code_challenge", pkce.CodeChallenge)
+	q.Set("code_challenge_method", pkce.Method)
+	if cfg.Audience != "" {
+		q.Set("audience", cfg.Audience)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// exchangeAuthorizationCode는 인가 코드를 IdP 토큰으로 교환합니다.
+func exchangeAuthorizationCode(ctx context.Context, meta *OIDCProviderMetadata, cfg *SSOConfig, code, redirectURI, codeVerifier string) (*OIDCTokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	body, status, err := postForm(ctx, meta.TokenEndpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("IdP 토큰 교환 실패: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("IdP 토큰 교환 실패 (HTTP %d): %s", status, oidcErrorMessage(body))
+	}
+
+	var tokenResp OIDCTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("IdP 토큰 응답 파싱 실패: %w", err)
+	}
+	if tokenResp.IDToken == "" && tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("IdP가 토큰을 반환하지 않았습니다")
+	}
+	return &tokenResp, nil
+}
+
+// ExchangeSSOToken은 IdP 토큰을 Autopus 자격 증명으로 교환합니다.
+// 서버는 워크스페이스에 설정된 IdP로 id_token을 검증한 뒤 CLI 토큰을 발급합니다.
+func ExchangeSSOToken(ctx context.Context, apiBaseURL string, cfg *SSOConfig, tok *OIDCTokenResponse) (*DeviceTokenResponse, error) {
+	if tok.IDToken == "" {
+		return nil, fmt.Errorf("IdP가 id_token을 반환하지 않았습니다 (openid 스코프를 확인하세요)")
+	}
+
+	body, err := json.Marshal(ssoExchangeRequest{
+		Workspace:   cfg.Workspace,
+		Issuer:      cfg.Issuer,
+		IDToken:     tok.IDToken,
+		AccessToken: tok.AccessToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("요청 생성 실패: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiBaseURL+"/api/v1/auth/sso/exchange", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
+	}
+	setBridgeHeaders(req)
+
+	client := &http.Client{Timeout: deviceHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("SSO 토큰 교환 실패: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("SSO 토큰 교환 거부 (HTTP %d): 워크스페이스 멤버십 또는 IdP 설정을 확인하세요", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SSO 토큰 교환 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	var tokenResp DeviceTokenResponse
+	if err := decodeWrapped(resp.Body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("SSO 토큰 교환 응답 파싱 실패: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("서버가 유효한 토큰을 반환하지 않았습니다")
+	}
+	if tokenResp.UserEmail == "" && tokenResp.User != nil {
+		tokenResp.UserEmail = tokenResp.User.Email
+	}
+	return &tokenResp, nil
+}
+
+// ssoScopes는 요청할 스코프를 반환합니다. openid는 항상 포함합니다.
+func ssoScopes(cfg *SSOConfig) []string {
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultOIDCScopes
+	}
+	for _, s := range scopes {
+		if s == "openid" {
+			return scopes
+		}
+	}
+	return append([]string{"openid"}, scopes...)
+}
+
+// postForm은 application/x-www-form-urlencoded POST 요청을 보내고 응답 본문과 상태 코드를 반환합니다.
+func postForm(ctx context.Context, endpoint string, form url.Values) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{Timeout: deviceHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	return body, resp.StatusCode, nil
+}
+
+// oidcErrorMessage는 OAuth 에러 응답에서 사람이 읽을 수 있는 메시지를 추출합니다.
+func oidcErrorMessage(body []byte) string {
+	var errResp OIDCTokenResponse
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		if errResp.ErrorDescription != "" {
+			return errResp.Error + ": " + errResp.ErrorDescription
+		}
+		return errResp.Error
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// decodeWrapped는 백엔드 표준 래핑 응답 {"success":true,"data":{...}} 또는 래핑되지 않은 응답을 파싱합니다.
+func decodeWrapped(r io.Reader, v interface{}) error {
+	body, err := io.ReadAll(io.LimitReader(r, 1<<20))
+	if err != nil {
+		return err
+	}
+	var wrapped struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(body, &wrapped) == nil && wrapped.Success && len(wrapped.Data) > 0 {
+		return json.Unmarshal(wrapped.Data, v)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// randomToken은 URL-safe 난수 문자열을 생성합니다.
+func randomToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
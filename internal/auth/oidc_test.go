@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestIdP는 discovery, device, token 엔드포인트를 제공하는 테스트 IdP를 생성합니다.
+// tokenHandler가 토큰 엔드포인트 응답을 결정합니다.
+func newTestIdP(t *testing.T, tokenHandler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(OIDCProviderMetadata{
+			Issuer:                      srv.URL,
+			AuthorizationEndpoint:       srv.URL + "/authorize",
+			TokenEndpoint:               srv.URL + "/token",
+			DeviceAuthorizationEndpoint: srv.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("form 파싱 실패: %v", err)
+		}
+		if r.Form.Get("client_id") != "bridge-cli" {
+			t.Errorf("client_id = %q", r.Form.Get("client_id"))
+		}
+		_, _ = w.Write([]byte(`{"device_code":"dev-1","user_code":"WXYZ-1234","verification_url":"https://idp.example.com/device","expires_in":300,"interval":1}`))
+	})
+	if tokenHandler != nil {
+		mux.HandleFunc("/token", tokenHandler)
+	}
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDiscoverOIDC(t *testing.T) {
+	idp := newTestIdP(t, nil)
+
+	meta, err := DiscoverOIDC(context.Background(), idp.URL+"/")
+	if err != nil {
+		t.Fatalf("DiscoverOIDC() = %v", err)
+	}
+	if meta.TokenEndpoint != idp.URL+"/token" {
+		t.Errorf("token_endpoint = %q", meta.TokenEndpoint)
+	}
+	if !meta.SupportsDeviceFlow() {
+		t.Error("SupportsDeviceFlow() = false; want true")
+	}
+}
+
+func TestDiscoverOIDC_IssuerMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(OIDCProviderMetadata{
+			Issuer:        "https://evil.example.com",
+			TokenEndpoint: "https://evil.example.com/token",
+		})
+	}))
+	defer srv.Close()
+
+	if _, err := DiscoverOIDC(context.Background(), srv.URL); err == nil {
+		t.Error("issuer 불일치인데 에러가 반환되지 않았습니다")
+	}
+}
+
+func TestOIDCDeviceFlow(t *testing.T) {
+	var polls int32
+	idp := newTestIdP(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("device_code") != "dev-1" {
+			t.Errorf("device_code = %q", r.Form.Get("device_code"))
+		}
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id_token":"idt","access_token":"at","token_type":"Bearer"}`))
+	})
+	cfg := &SSOConfig{Workspace: "acme", Issuer: idp.URL, ClientID: "bridge-cli"}
+
+	meta, err := DiscoverOIDC(context.Background(), idp.URL)
+	if err != nil {
+		t.Fatalf("DiscoverOIDC() = %v", err)
+	}
+	deviceResp, err := RequestOIDCDeviceCode(context.Background(), meta, cfg)
+	if err != nil {
+		t.Fatalf("RequestOIDCDeviceCode() = %v", err)
+	}
+	if deviceResp.VerificationURI != "https://idp.example.com/device" {
+		t.Errorf("verification_url이 verification_uri로 매핑되지 않았습니다: %q", deviceResp.VerificationURI)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tok, err := PollOIDCDeviceToken(ctx, meta, cfg, deviceResp.DeviceCode, deviceResp.Interval)
+	if err != nil {
+		t.Fatalf("PollOIDCDeviceToken() = %v", err)
+	}
+	if tok.IDToken != "idt" {
+		t.Errorf("id_token = %q", tok.IDToken)
+	}
+	if atomic.LoadInt32(&polls) != 2 {
+		t.Errorf("polls = %d; want 2", polls)
+	}
+}
+
+func TestAuthorizeOIDCBrowser(t *testing.T) {
+	var gotVerifier string
+	idp := newTestIdP(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != "auth-code" {
+			t.Errorf("unexpected token request: %v", r.Form)
+		}
+		gotVerifier = r.Form.Get("code_verifier")
+		_, _ = w.Write([]byte(`{"id_token":"idt","access_token":"at"}`))
+	})
+	cfg := &SSOConfig{Workspace: "acme", Issuer: idp.URL, ClientID: "bridge-cli", Scopes: []string{"email"}}
+	meta, err := DiscoverOIDC(context.Background(), idp.URL)
+	if err != nil {
+		t.Fatalf("DiscoverOIDC() = %v", err)
+	}
+
+	// 브라우저 대신 IdP가 리다이렉트하는 것처럼 콜백을 호출한다.
+	open := func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		q := u.Query()
+		if q.Get("scope") != "openid email" {
+			t.Errorf("scope = %q; want openid 포함", q.Get("scope"))
+		}
+		if q.Get("code_challenge_method") != "S256" {
+			t.Errorf("code_challenge_method = %q", q.Get("code_challenge_method"))
+		}
+		go func() {
+			resp, err := http.Get(q.Get("redirect_uri") + "?code=auth-code&state=" + url.QueryEscape(q.Get("state")))
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tok, err := AuthorizeOIDCBrowser(ctx, meta, cfg, open, nil)
+	if err != nil {
+		t.Fatalf("AuthorizeOIDCBrowser() = %v", err)
+	}
+	if tok.IDToken != "idt" {
+		t.Errorf("id_token = %q", tok.IDToken)
+	}
+	if gotVerifier == "" {
+		t.Error("code_verifier가 전송되지 않았습니다")
+	}
+}
+
+func TestAuthorizeOIDCBrowser_StateMismatch(t *testing.T) {
+	idp := newTestIdP(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("state 불일치인데 토큰 교환이 호출되었습니다")
+	})
+	meta, err := DiscoverOIDC(context.Background(), idp.URL)
+	if err != nil {
+		t.Fatalf("DiscoverOIDC() = %v", err)
+	}
+
+	open := func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		go func() {
+			resp, err := http.Get(u.Query().Get("redirect_uri") + "?code=auth-code&state=forged")
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := AuthorizeOIDCBrowser(ctx, meta, &SSOConfig{ClientID: "bridge-cli"}, open, nil); err == nil {
+		t.Error("state 불일치인데 에러가 반환되지 않았습니다")
+	}
+}
+
+func TestFetchSSOConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/sso/config" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("workspace") != "acme" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"workspace":"acme","issuer":"https://idp.example.com","client_id":"bridge-cli"}}`))
+	}))
+	defer srv.Close()
+
+	cfg, err := FetchSSOConfig(context.Background(), srv.URL, "acme")
+	if err != nil {
+		t.Fatalf("FetchSSOConfig() = %v", err)
+	}
+	if cfg.Issuer != "https://idp.example.com" || cfg.ClientID != "bridge-cli" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	if _, err := FetchSSOConfig(context.Background(), srv.URL, "other"); !errors.Is(err, ErrSSONotConfigured) {
+		t.Errorf("err = %v; want ErrSSONotConfigured", err)
+	}
+}
+
+func TestExchangeSSOToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ssoExchangeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("요청 파싱 실패: %v", err)
+		}
+		if req.Workspace != "acme" || req.IDToken != "idt" {
+			t.Errorf("req = %+v", req)
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"access_token":"autopus-at","refresh_token":"rt","expires_in":3600,"user":{"email":"dev@acme.com"}}}`))
+	}))
+	defer srv.Close()
+
+	cfg := &SSOConfig{Workspace: "acme", Issuer: "https://idp.example.com"}
+	resp, err := ExchangeSSOToken(context.Background(), srv.URL, cfg, &OIDCTokenResponse{IDToken: "idt"})
+	if err != nil {
+		t.Fatalf("ExchangeSSOToken() = %v", err)
+	}
+	if resp.AccessToken != "autopus-at" || resp.UserEmail != "dev@acme.com" {
+		t.Errorf("resp = %+v", resp)
+	}
+
+	if _, err := ExchangeSSOToken(context.Background(), srv.URL, cfg, &OIDCTokenResponse{AccessToken: "at"}); err == nil {
+		t.Error("id_token 없이 교환에 성공했습니다")
+	}
+}
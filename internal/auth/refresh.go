@@ -27,6 +27,8 @@ type cliRefreshResponse struct {
 		AccessToken  string `json:"access_token"`
 		RefreshToken string `json:"refresh_token"`
 		ExpiresIn    int64  `json:"expires_in"`
+		// RefreshExpiresIn은 새 refresh token의 수명(초)입니다. 0이면 서버가 알려주지 않은 것입니다.
+		RefreshExpiresIn int64 `json:"refresh_expires_in,omitempty"`
 	} `json:"data"`
 }
 
@@ -78,6 +80,11 @@ func RefreshAccessToken(creds *Credentials) error {
 	if jwtExpiry, err := ParseJWTExpiry(creds.AccessToken); err == nil {
 		creds.ExpiresAt = jwtExpiry
 	}
+	// refresh token 회전 시 이전 만료 시각이 남지 않도록 항상 다시 설정
+	creds.RefreshExpiresAt = time.Time{}
+	if refreshResp.Data.RefreshExpiresIn > 0 {
+		creds.RefreshExpiresAt = time.Now().Add(time.Duration(refreshResp.Data.RefreshExpiresIn) * time.Second)
+	}
 
 	// 파일에 저장
 	if err := Save(creds); err != nil {
@@ -95,6 +95,7 @@ func TestRefreshAccessToken_Success(t *testing.T) {
 		resp.Data.AccessToken = "new-access-token"
 		resp.Data.RefreshToken = "new-refresh-token"
 		resp.Data.ExpiresIn = 900
+		resp.Data.RefreshExpiresIn = 86400
 
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(resp)
@@ -123,6 +124,9 @@ func TestRefreshAccessToken_Success(t *testing.T) {
 	if time.Until(creds.ExpiresAt) < 890*time.Second || time.Until(creds.ExpiresAt) > 910*time.Second {
 		t.Errorf("ExpiresAt이 약 900초 후가 아닙니다: %v", time.Until(creds.ExpiresAt))
 	}
+	if d := time.Until(creds.RefreshExpiresAt); d < 86390*time.Second || d > 86410*time.Second {
+		t.Errorf("RefreshExpiresAt이 약 86400초 후가 아닙니다: %v", d)
+	}
 }
 
 // TestRefreshAccessToken_EmptyRefreshToken는 refresh token이 없을 때 에러를 반환하는지 테스트합니다.
@@ -0,0 +1,212 @@
+// renewal.go는 실행 중인 세션에서 Bridge를 재시작하지 않고 다시 인증하는 플로우를 구현합니다.
+// 127.0.0.1의 임의 포트에 콜백 서버를 띄우고(RFC 8252 7.3) 웹 앱의 CLI 인가 페이지 링크를 만든 뒤,
+// 사용자가 브라우저에서 승인하면 받은 인가 코드를 PKCE(RFC 7636) code_verifier와 함께
+// POST /api/v1/auth/cli-token 으로 교환합니다.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// renewalCallbackPath는 재인증 콜백 경로입니다.
+	renewalCallbackPath = "/renew"
+	// renewalAuthorizePath는 웹 앱의 CLI 인가 페이지 경로입니다.
+	renewalAuthorizePath = "/cli/authorize"
+)
+
+// cliTokenRequest는 재인증 인가 코드 교환 요청입니다.
+type cliTokenRequest struct {
+	Code         string `json:"code"`
+	CodeVerifier string `json:"code_verifier"`
+	RedirectURI  string `json:"redirect_uri"`
+}
+
+// Renewal은 진행 중인 재인증 요청 하나입니다.
+// StartRenewal로 만들고, URL을 사용자에게 보여준 뒤 Wait로 결과를 기다립니다.
+type Renewal struct {
+	apiBaseURL  string
+	redirectURI string
+	authURL     string
+	pkce        *PKCEPair
+
+	srv     *http.Server
+	results chan renewalResult
+}
+
+// renewalResult는 콜백으로 받은 인가 코드 또는 에러입니다.
+type renewalResult struct {
+	code string
+	err  error
+}
+
+// StartRenewal은 로컬 콜백 서버를 시작하고 재인증 링크를 준비합니다.
+// webBaseURL은 웹 앱 주소, apiBaseURL은 /api/v1 앞까지의 API 서버 주소입니다.
+// 사용이 끝나면 Close를 호출해야 합니다.
+func StartRenewal(webBaseURL, apiBaseURL string) (*Renewal, error) {
+	pkce, err := GeneratePKCE()
+	if err != nil {
+		return nil, fmt.Errorf("PKCE 생성 실패: %w", err)
+	}
+	state, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("state 생성 실패: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("로컬 콜백 서버 시작 실패: %w", err)
+	}
+
+	rn := &Renewal{
+		apiBaseURL:  strings.TrimSuffix(apiBaseURL, "/"),
+		redirectURI: fmt.Sprintf("http://%s%s", listener.Addr().String(), renewalCallbackPath),
+		pkce:        pkce,
+		results:     make(chan renewalResult, 1),
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(webBaseURL, "/") + renewalAuthorizePath)
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("재인증 URL 생성 실패: %w", err)
+	}
+	q := u.Query()
+	q.Set("redirect_uri", rn.redirectURI)
+	q.Set("state", state)
+	q.Set("code_challenge", pkce.CodeChallenge)
+	q.Set("code_challenge_method", pkce.Method)
+	u.RawQuery = q.Encode()
+	rn.authURL = u.String()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(renewalCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// state가 다른 요청은 이 재인증과 무관하므로 결과로 전달하지 않고 계속 기다립니다.
+		if subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(state)) != 1 {
+			http.Error(w, "알 수 없는 인증 요청입니다.", http.StatusBadRequest)
+			return
+		}
+
+		var res renewalResult
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("재인증 거부: %s %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("code") == "":
+			res.err = fmt.Errorf("서버가 인가 코드를 반환하지 않았습니다")
+		default:
+			res.code = q.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, "재인증에 실패했습니다. 터미널을 확인하세요.", http.StatusBadRequest)
+		} else {
+			_, _ = io.WriteString(w, "재인증이 완료되었습니다. 이 창을 닫아도 됩니다.")
+		}
+		// 결과를 받은 쪽이 곧바로 서버를 닫으므로 응답을 먼저 내보냅니다.
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		select {
+		case rn.results <- res:
+		default:
+		}
+	})
+	rn.srv = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = rn.srv.Serve(listener) }()
+
+	return rn, nil
+}
+
+// URL은 사용자가 브라우저에서 열어야 하는 재인증 링크입니다.
+func (rn *Renewal) URL() string {
+	return rn.authURL
+}
+
+// Wait는 콜백을 기다린 뒤 인가 코드를 새 토큰으로 교환합니다.
+// ctx가 취소되면 ctx.Err()를 반환합니다.
+func (rn *Renewal) Wait(ctx context.Context) (*DeviceTokenResponse, error) {
+	var res renewalResult
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-rn.results:
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+	return rn.exchange(ctx, res.code)
+}
+
+// Close는 로컬 콜백 서버를 종료합니다.
+func (rn *Renewal) Close() error {
+	return rn.srv.Close()
+}
+
+// exchange는 인가 코드를 Autopus 자격 증명으로 교환합니다.
+func (rn *Renewal) exchange(ctx context.Context, code string) (*DeviceTokenResponse, error) {
+	body, err := json.Marshal(cliTokenRequest{
+		Code:         code,
+		CodeVerifier: rn.pkce.CodeVerifier,
+		RedirectURI:  rn.redirectURI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("요청 생성 실패: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rn.apiBaseURL+"/api/v1/auth/cli-token", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
+	}
+	setBridgeHeaders(req)
+
+	client := &http.Client{Timeout: deviceHTTPTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("재인증 토큰 교환 실패: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("재인증 토큰 교환 거부 (HTTP %d): 링크를 다시 열어 주세요", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("재인증 토큰 교환 실패 (HTTP %d)", resp.StatusCode)
+	}
+
+	var tokenResp DeviceTokenResponse
+	if err := decodeWrapped(resp.Body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("재인증 응답 파싱 실패: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("서버가 유효한 토큰을 반환하지 않았습니다")
+	}
+	if tokenResp.UserEmail == "" && tokenResp.User != nil {
+		tokenResp.UserEmail = tokenResp.User.Email
+	}
+	return &tokenResp, nil
+}
+
+// RenewedCredentials는 재인증 토큰 응답으로 prev를 갱신한 새 자격 증명을 만듭니다.
+// 서버 주소와 워크스페이스 선택은 prev를 유지합니다.
+func RenewedCredentials(prev *Credentials, tokenResp *DeviceTokenResponse) *Credentials {
+	renewed := tokenResp.NewCredentials(prev.ServerURL)
+	creds := *prev
+	creds.AccessToken = renewed.AccessToken
+	creds.RefreshToken = renewed.RefreshToken
+	creds.ExpiresAt = renewed.ExpiresAt
+	creds.RefreshExpiresAt = renewed.RefreshExpiresAt
+	if renewed.UserEmail != "" {
+		creds.UserEmail = renewed.UserEmail
+	}
+	return &creds
+}
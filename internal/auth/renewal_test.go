@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenewal(t *testing.T) {
+	var gotReq cliTokenRequest
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/auth/cli-token" {
+			t.Errorf("Path = %q, want /api/v1/auth/cli-token", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_, _ = w.Write([]byte(`{"success":true,"data":{"access_token":"new-at","refresh_token":"new-rt","expires_in":900,"refresh_expires_in":86400,"user":{"email":"a@example.com"}}}`))
+	}))
+	defer api.Close()
+
+	renewal, err := StartRenewal("https://app.example.com/", api.URL)
+	if err != nil {
+		t.Fatalf("StartRenewal() = %v", err)
+	}
+	defer func() { _ = renewal.Close() }()
+
+	u, err := url.Parse(renewal.URL())
+	if err != nil {
+		t.Fatalf("URL 파싱 실패: %v", err)
+	}
+	if u.Host != "app.example.com" || u.Path != "/cli/authorize" {
+		t.Errorf("renewal URL = %q", renewal.URL())
+	}
+	q := u.Query()
+	redirectURI := q.Get("redirect_uri")
+	if !strings.HasPrefix(redirectURI, "http://127.0.0.1:") || q.Get("code_challenge") == "" {
+		t.Fatalf("renewal URL query = %v", q)
+	}
+
+	// 다른 state의 요청은 무시하고 계속 기다린다.
+	resp, err := http.Get(redirectURI + "?code=stray&state=other")
+	if err != nil {
+		t.Fatalf("콜백 요청 실패: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("state 불일치 응답 = %d; want 400", resp.StatusCode)
+	}
+
+	go func() {
+		resp, err := http.Get(redirectURI + "?code=auth-code&state=" + url.QueryEscape(q.Get("state")))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	tok, err := renewal.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait() = %v", err)
+	}
+	if gotReq.Code != "auth-code" || gotReq.CodeVerifier == "" || gotReq.RedirectURI != redirectURI {
+		t.Errorf("교환 요청 = %+v", gotReq)
+	}
+	if tok.AccessToken != "new-at" || tok.UserEmail != "a@example.com" {
+		t.Errorf("token = %+v", tok)
+	}
+
+	prev := newTestCredentials(time.Now().Add(-time.Hour))
+	creds := RenewedCredentials(prev, tok)
+	if creds.AccessToken != "new-at" || creds.RefreshToken != "new-rt" || creds.WorkspaceID != prev.WorkspaceID {
+		t.Errorf("RenewedCredentials() = %+v", creds)
+	}
+	if d := time.Until(creds.RefreshExpiresAt); d < 23*time.Hour || d > 25*time.Hour {
+		t.Errorf("RefreshExpiresAt이 약 24시간 후가 아닙니다: %v", d)
+	}
+	if prev.AccessToken != "test-access-token" {
+		t.Error("RenewedCredentials()가 prev를 수정했습니다")
+	}
+}
+
+func TestRenewal_Denied(t *testing.T) {
+	renewal, err := StartRenewal("https://app.example.com", "http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("StartRenewal() = %v", err)
+	}
+	defer func() { _ = renewal.Close() }()
+
+	u, _ := url.Parse(renewal.URL())
+	q := u.Query()
+	go func() {
+		resp, err := http.Get(q.Get("redirect_uri") + "?error=access_denied&state=" + url.QueryEscape(q.Get("state")))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := renewal.Wait(ctx); err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("Wait() = %v; want access_denied 에러", err)
+	}
+}
@@ -15,6 +15,8 @@ const (
 	refreshBeforeExpiry = 5 * time.Minute
 	// minRefreshInterval은 갱신 시도 간 최소 간격입니다.
 	minRefreshInterval = 30 * time.Second
+	// expiryCheckInterval은 만료 감시 중 refresh token 만료 상태를 다시 확인하는 최대 간격입니다.
+	expiryCheckInterval = time.Hour
 )
 
 // TokenRefresher는 토큰을 주기적으로 갱신하는 백그라운드 서비스입니다.
@@ -22,6 +24,17 @@ type TokenRefresher struct {
 	creds  *Credentials
 	mu     sync.RWMutex
 	logger *slog.Logger
+
+	// 만료 감시 (SetExpiryWatch로 설정, mu로 보호)
+	warnBefore time.Duration
+	onExpiry   func(ExpiryStatus)
+	// rejected는 서버가 refresh token을 거부했는지 여부입니다 (mu로 보호).
+	rejected bool
+
+	// statusMu는 만료 상태 알림을 직렬화합니다.
+	statusMu   sync.Mutex
+	notified   bool
+	lastStatus ExpiryStatus
 }
 
 // NewTokenRefresher는 새 TokenRefresher를 생성합니다.
@@ -38,6 +51,37 @@ func (r *TokenRefresher) Start(ctx context.Context) {
 	go r.run(ctx)
 }
 
+// SetExpiryWatch는 refresh token 만료 감시를 켭니다. Start 전에 호출해야 합니다.
+// onStatus는 감시 시작 시 한 번, 이후 상태(또는 만료 시각)가 바뀔 때마다 호출됩니다.
+// warnBefore가 0 이하이면 DefaultExpiryWarning을 사용합니다.
+// onStatus 안에서 UpdateCredentials를 호출하면 안 됩니다.
+func (r *TokenRefresher) SetExpiryWatch(warnBefore time.Duration, onStatus func(ExpiryStatus)) {
+	if warnBefore <= 0 {
+		warnBefore = DefaultExpiryWarning
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnBefore = warnBefore
+	r.onExpiry = onStatus
+}
+
+// ExpiryStatus는 현재 refresh token 만료 상태를 반환합니다.
+func (r *TokenRefresher) ExpiryStatus() ExpiryStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.expiryStatusLocked(time.Now())
+}
+
+// UpdateCredentials는 재인증으로 받은 새 자격 증명으로 교체하고 만료 상태를 다시 알립니다.
+func (r *TokenRefresher) UpdateCredentials(creds *Credentials) {
+	r.mu.Lock()
+	*r.creds = *creds
+	r.rejected = false
+	r.mu.Unlock()
+
+	r.checkExpiry()
+}
+
 // GetToken은 현재 유효한 access token을 반환합니다.
 // 만료되었으면 즉시 갱신을 시도합니다.
 func (r *TokenRefresher) GetToken() (string, error) {
@@ -49,7 +93,13 @@ func (r *TokenRefresher) GetToken() (string, error) {
 	}
 	r.mu.RUnlock()
 
-	// 만료된 토큰 즉시 갱신
+	token, err := r.refreshNow()
+	r.checkExpiry()
+	return token, err
+}
+
+// refreshNow는 만료된 토큰을 즉시 갱신합니다.
+func (r *TokenRefresher) refreshNow() (string, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -64,9 +114,11 @@ func (r *TokenRefresher) GetToken() (string, error) {
 			if reauthed := r.tryReloadCredentials(); reauthed {
 				return r.creds.AccessToken, nil
 			}
+			r.rejected = true
 		}
 		return "", fmt.Errorf("토큰 갱신 실패: %w", err)
 	}
+	r.rejected = false
 
 	r.logger.Info("토큰 즉시 갱신 성공",
 		"expires_at", r.creds.ExpiresAt.Format(time.RFC3339),
@@ -86,6 +138,7 @@ func (r *TokenRefresher) GetWorkspaceID() string {
 
 // run은 토큰 만료 전 자동 갱신을 수행하는 루프입니다.
 func (r *TokenRefresher) run(ctx context.Context) {
+	r.checkExpiry()
 	for {
 		sleepDuration := r.nextRefreshDuration()
 
@@ -99,10 +152,42 @@ func (r *TokenRefresher) run(ctx context.Context) {
 			return
 		case <-time.After(sleepDuration):
 			r.refreshToken()
+			r.checkExpiry()
 		}
 	}
 }
 
+// checkExpiry는 만료 상태가 마지막 알림과 달라졌으면 감시 콜백을 호출합니다.
+// r.mu를 보유하지 않은 상태에서 호출해야 합니다.
+func (r *TokenRefresher) checkExpiry() {
+	r.mu.RLock()
+	onExpiry := r.onExpiry
+	status := r.expiryStatusLocked(time.Now())
+	r.mu.RUnlock()
+	if onExpiry == nil {
+		return
+	}
+
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+	if r.notified && status.State == r.lastStatus.State && status.RefreshExpiresAt.Equal(r.lastStatus.RefreshExpiresAt) {
+		return
+	}
+	r.notified = true
+	r.lastStatus = status
+	onExpiry(status)
+}
+
+// expiryStatusLocked는 서버 거부 여부를 반영한 만료 상태를 반환합니다.
+// 호출자가 r.mu를 보유해야 합니다.
+func (r *TokenRefresher) expiryStatusLocked(now time.Time) ExpiryStatus {
+	status := CheckExpiry(r.creds, r.warnBefore, now)
+	if r.rejected {
+		status.State = ExpiryExpired
+	}
+	return status
+}
+
 // nextRefreshDuration은 다음 갱신까지 대기할 시간을 계산합니다.
 func (r *TokenRefresher) nextRefreshDuration() time.Duration {
 	r.mu.RLock()
@@ -123,6 +208,10 @@ func (r *TokenRefresher) nextRefreshDuration() time.Duration {
 	if refreshAt < minRefreshInterval {
 		return minRefreshInterval
 	}
+	// 만료 감시 중에는 경고 시점을 놓치지 않도록 주기적으로 깨어남
+	if r.onExpiry != nil && refreshAt > expiryCheckInterval {
+		return expiryCheckInterval
+	}
 	return refreshAt
 }
 
@@ -152,6 +241,7 @@ func (r *TokenRefresher) tryReloadCredentials() bool {
 		return false
 	}
 
+	r.rejected = false
 	r.logger.Info("디스크 credentials 기반 토큰 갱신 성공",
 		"expires_at", r.creds.ExpiresAt.Format(time.RFC3339),
 	)
@@ -184,12 +274,14 @@ func (r *TokenRefresher) refreshToken() {
 			if reauthed := r.tryReloadCredentials(); reauthed {
 				return
 			}
+			r.rejected = true
 			r.logger.Error("refresh token 만료: 수동 재로그인 필요 ('autopus login' 명령 실행)", "error", err)
 			return
 		}
 		r.logger.Error("백그라운드 토큰 갱신 실패", "error", err)
 		return
 	}
+	r.rejected = false
 
 	r.logger.Info("백그라운드 토큰 갱신 성공",
 		"expires_at", r.creds.ExpiresAt.Format(time.RFC3339),
@@ -4,13 +4,14 @@
 package cli
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"time"
 
 	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 	"github.com/rs/zerolog/log"
 )
 
@@ -18,6 +19,7 @@ const (
 	// DefaultTimeout은 CLI 명령어 실행 기본 타임아웃입니다 (120초).
 	DefaultTimeout = 120 * time.Second
 	// MaxOutputBytes는 stdout/stderr 최대 캡처 크기입니다 (1MB).
+	// 초과하면 앞부분과 뒷부분을 절반씩 남기고 가운데를 생략합니다.
 	MaxOutputBytes = 1 * 1024 * 1024
 )
 
@@ -38,6 +40,13 @@ func NewExecutor() *Executor {
 // Execute는 CLIRequestPayload로부터 CLI 명령어를 실행하고 결과를 반환합니다.
 // 보안 검증 -> 명령어 실행 -> 출력 파싱 순서로 처리됩니다.
 func (e *Executor) Execute(ctx context.Context, req *ws.CLIRequestPayload) *ws.CLIResultPayload {
+	return e.ExecuteStreaming(ctx, req, nil)
+}
+
+// ExecuteStreaming은 Execute와 같지만 실행 중 stdout/stderr 줄을 배치로 묶어
+// StreamFlushInterval마다 onOutput에 전달합니다. onOutput이 nil이면 스트리밍하지 않습니다.
+// 마지막 배치는 반환 전에 전달되며, 결과의 OutputMessages에 전달한 배치 수가 기록됩니다.
+func (e *Executor) ExecuteStreaming(ctx context.Context, req *ws.CLIRequestPayload, onOutput OutputFunc) *ws.CLIResultPayload {
 	start := time.Now()
 
 	// 1단계: 보안 검증
@@ -73,9 +82,20 @@ func (e *Executor) Execute(ctx context.Context, req *ws.CLIRequestPayload) *ws.C
 	}
 
 	// stdout/stderr 캡처 (메모리 보호를 위해 크기 제한 적용)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &limitedWriter{w: &stdout, limit: MaxOutputBytes}
-	cmd.Stderr = &limitedWriter{w: &stderr, limit: MaxOutputBytes}
+	stdout := newHeadTailBuffer(MaxOutputBytes)
+	stderr := newHeadTailBuffer(MaxOutputBytes)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	var streamer *outputStreamer
+	var stdoutLines, stderrLines *lineWriter
+	if onOutput != nil {
+		streamer = newOutputStreamer(onOutput, StreamFlushInterval)
+		stdoutLines = &lineWriter{stream: ws.CLIStreamStdout, streamer: streamer}
+		stderrLines = &lineWriter{stream: ws.CLIStreamStderr, streamer: streamer}
+		cmd.Stdout = io.MultiWriter(stdout, stdoutLines)
+		cmd.Stderr = io.MultiWriter(stderr, stderrLines)
+	}
 
 	log.Info().
 		Str("command", req.Command).
@@ -84,15 +104,20 @@ func (e *Executor) Execute(ctx context.Context, req *ws.CLIRequestPayload) *ws.C
 		Msg("[CLI] 명령어 실행 시작")
 
 	// 4단계: 명령어 실행
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 	duration := time.Since(start).Milliseconds()
 
 	result := &ws.CLIResultPayload{
 		Stdout:          stdout.String(),
 		Stderr:          stderr.String(),
 		DurationMs:      duration,
-		StdoutTruncated: stdout.Len() >= MaxOutputBytes,
-		StderrTruncated: stderr.Len() >= MaxOutputBytes,
+		StdoutTruncated: stdout.Truncated(),
+		StderrTruncated: stderr.Truncated(),
+	}
+	if streamer != nil {
+		stdoutLines.finish()
+		stderrLines.finish()
+		result.OutputMessages = streamer.close()
 	}
 
 	if err != nil {
@@ -131,23 +156,3 @@ func (e *Executor) Execute(ctx context.Context, req *ws.CLIRequestPayload) *ws.C
 
 	return result
 }
-
-// limitedWriter는 지정된 크기 제한까지만 쓰기를 허용하는 io.Writer 래퍼입니다.
-// 제한을 초과하는 데이터는 조용히 폐기됩니다.
-type limitedWriter struct {
-	w     *bytes.Buffer
-	limit int
-}
-
-// Write는 제한 내에서 데이터를 쓰고, 초과분은 폐기합니다.
-func (lw *limitedWriter) Write(p []byte) (int, error) {
-	remaining := lw.limit - lw.w.Len()
-	if remaining <= 0 {
-		// 제한 초과 - 데이터를 폐기하되 성공으로 보고
-		return len(p), nil
-	}
-	if len(p) > remaining {
-		p = p[:remaining]
-	}
-	return lw.w.Write(p)
-}
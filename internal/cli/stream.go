@@ -0,0 +1,233 @@
+// Package cli - CLI 실행 출력 스트리밍과 출력 절단 정책
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+const (
+	// StreamFlushInterval은 cli_output 메시지 전송 간격입니다 (초당 최대 4개).
+	StreamFlushInterval = 250 * time.Millisecond
+	// MaxStreamBatchBytes는 cli_output 메시지 하나에 담는 출력의 최대 크기입니다 (64KB).
+	// 한 전송 간격에 이보다 많은 출력이 나오면 초과한 줄은 스트리밍하지 않고 개수만 보고합니다.
+	MaxStreamBatchBytes = 64 * 1024
+	// MaxStreamLineBytes는 스트리밍하는 한 줄의 최대 크기입니다 (4KB). 긴 줄은 잘라서 전송합니다.
+	MaxStreamLineBytes = 4 * 1024
+	// MaxStreamBytes는 명령어 하나에서 스트리밍하는 출력의 최대 크기입니다 (4MB).
+	// 초과하면 스트리밍을 멈추고, 전체 출력은 cli_result의 절단 정책을 따릅니다.
+	MaxStreamBytes = 4 * 1024 * 1024
+)
+
+// OutputFunc는 스트리밍된 출력 배치를 전달받는 콜백입니다.
+type OutputFunc = func(payload ws.CLIOutputPayload)
+
+// outputStreamer는 출력 줄을 모아 StreamFlushInterval마다 배치로 전달합니다.
+type outputStreamer struct {
+	onOutput OutputFunc
+
+	mu           sync.Mutex
+	pending      []ws.CLIOutputLine
+	pendingBytes int
+	dropped      int
+	streamed     int
+	// stopped는 MaxStreamBytes에 도달해 스트리밍을 멈췄는지 여부입니다.
+	stopped bool
+	// stopReported는 스트리밍 중단(Truncated)을 보고했는지 여부입니다.
+	stopReported bool
+	// seq는 다음 cli_output 메시지 순번입니다. flush에서만 변경됩니다.
+	seq int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newOutputStreamer는 interval마다 출력 배치를 onOutput으로 전달하는 스트리머를 시작합니다.
+func newOutputStreamer(onOutput OutputFunc, interval time.Duration) *outputStreamer {
+	s := &outputStreamer{
+		onOutput: onOutput,
+		done:     make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.flush()
+			case <-s.done:
+				return
+			}
+		}
+	}()
+	return s
+}
+
+// addLine은 출력 한 줄을 다음 배치에 추가합니다.
+func (s *outputStreamer) addLine(stream, text string, truncated bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		s.dropped++
+		return
+	}
+	if s.streamed+len(text) > MaxStreamBytes {
+		s.stopped = true
+		s.dropped++
+		return
+	}
+	if s.pendingBytes+len(text) > MaxStreamBatchBytes {
+		s.dropped++
+		return
+	}
+	s.pending = append(s.pending, ws.CLIOutputLine{Stream: stream, Text: text, Truncated: truncated})
+	s.pendingBytes += len(text)
+	s.streamed += len(text)
+}
+
+// flush는 대기 중인 출력 배치를 전달합니다. 전달할 내용이 없으면 아무것도 하지 않습니다.
+// 전송 루프와 close에서만 호출되므로 콜백은 순서대로 호출됩니다.
+func (s *outputStreamer) flush() {
+	s.mu.Lock()
+	reportStop := s.stopped && !s.stopReported
+	if len(s.pending) == 0 && s.dropped == 0 && !reportStop {
+		s.mu.Unlock()
+		return
+	}
+	payload := ws.CLIOutputPayload{
+		Seq:          s.seq,
+		Lines:        s.pending,
+		DroppedLines: s.dropped,
+		Truncated:    reportStop,
+	}
+	s.seq++
+	s.pending = nil
+	s.pendingBytes = 0
+	s.dropped = 0
+	s.stopReported = s.stopped
+	s.mu.Unlock()
+
+	s.onOutput(payload)
+}
+
+// close는 전송 루프를 멈추고 남은 출력을 전달한 뒤 전송한 메시지 수를 반환합니다.
+func (s *outputStreamer) close() int {
+	close(s.done)
+	s.wg.Wait()
+	s.flush()
+	return s.seq
+}
+
+// lineWriter는 쓰기 데이터를 줄 단위로 나누어 outputStreamer에 전달하는 io.Writer입니다.
+// 한 스트림(stdout 또는 stderr)에 하나씩 사용하며, 동시에 호출되지 않습니다.
+type lineWriter struct {
+	stream   string
+	streamer *outputStreamer
+	buf      []byte
+	// skipping은 MaxStreamLineBytes를 넘은 줄의 나머지를 버리는 중인지 여부입니다.
+	skipping bool
+}
+
+// Write는 완성된 줄을 스트리머에 전달하고 미완성 줄은 버퍼에 보관합니다.
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	data := p
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			lw.appendPartial(data)
+			break
+		}
+		lw.appendPartial(data[:i])
+		if !lw.skipping {
+			lw.emit(lw.buf, false)
+		}
+		lw.buf = lw.buf[:0]
+		lw.skipping = false
+		data = data[i+1:]
+	}
+	return len(p), nil
+}
+
+// appendPartial은 줄의 일부를 버퍼에 추가합니다. 줄이 MaxStreamLineBytes를 넘으면
+// 잘린 줄을 바로 전달하고 나머지는 줄바꿈까지 버립니다.
+func (lw *lineWriter) appendPartial(data []byte) {
+	if lw.skipping {
+		return
+	}
+	lw.buf = append(lw.buf, data...)
+	if len(lw.buf) > MaxStreamLineBytes {
+		lw.emit(lw.buf[:MaxStreamLineBytes], true)
+		lw.buf = lw.buf[:0]
+		lw.skipping = true
+	}
+}
+
+// emit은 줄 끝의 \r을 제거하고 스트리머에 전달합니다.
+func (lw *lineWriter) emit(line []byte, truncated bool) {
+	text := strings.ToValidUTF8(strings.TrimSuffix(string(line), "\r"), "�")
+	lw.streamer.addLine(lw.stream, text, truncated)
+}
+
+// finish는 줄바꿈 없이 끝난 마지막 줄을 전달합니다.
+func (lw *lineWriter) finish() {
+	if len(lw.buf) > 0 && !lw.skipping {
+		lw.emit(lw.buf, false)
+	}
+	lw.buf = nil
+}
+
+// headTailBuffer는 출력의 앞부분과 뒷부분을 limit/2씩 보관하는 io.Writer입니다.
+// 대용량 로그는 보통 실패 원인이 끝에 있으므로 앞부분만 남기는 대신 양쪽을 보존합니다.
+type headTailBuffer struct {
+	half  int
+	head  []byte
+	tail  []byte
+	total int64
+}
+
+// newHeadTailBuffer는 최대 limit 바이트를 보관하는 버퍼를 생성합니다.
+func newHeadTailBuffer(limit int) *headTailBuffer {
+	return &headTailBuffer{half: limit / 2}
+}
+
+// Write는 앞부분이 찰 때까지 head에, 이후에는 tail에 기록하고 tail은 마지막 half 바이트만 유지합니다.
+func (b *headTailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	b.total += int64(n)
+	if room := b.half - len(b.head); room > 0 {
+		k := min(room, len(p))
+		b.head = append(b.head, p[:k]...)
+		p = p[k:]
+	}
+	if len(p) > 0 {
+		b.tail = append(b.tail, p...)
+		// 매번 복사하지 않도록 2배까지 허용한 뒤 한 번에 줄인다.
+		if len(b.tail) > 2*b.half {
+			b.tail = append([]byte(nil), b.tail[len(b.tail)-b.half:]...)
+		}
+	}
+	return n, nil
+}
+
+// Truncated는 출력이 보관 한도를 넘었는지 반환합니다.
+func (b *headTailBuffer) Truncated() bool {
+	return b.total > int64(2*b.half)
+}
+
+// String은 보관한 출력을 반환합니다. 한도를 넘었으면 생략된 크기를 표시합니다.
+func (b *headTailBuffer) String() string {
+	if !b.Truncated() {
+		return string(b.head) + string(b.tail)
+	}
+	tail := b.tail[len(b.tail)-b.half:]
+	omitted := b.total - int64(len(b.head)) - int64(len(tail))
+	return fmt.Sprintf("%s\n... [%d bytes truncated] ...\n%s", b.head, omitted, tail)
+}
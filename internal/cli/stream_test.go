@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+)
+
+var _ websocket.CLIStreamingExecutor = (*Executor)(nil)
+
+func TestExecuteStreaming_LinesAndResult(t *testing.T) {
+	var mu sync.Mutex
+	var outputs []ws.CLIOutputPayload
+	e := NewExecutor()
+	result := e.ExecuteStreaming(context.Background(), &ws.CLIRequestPayload{
+		Command:    "echo one; echo two 1>&2; sleep 0.4; printf 'three'; exit 3",
+		WorkingDir: t.TempDir(),
+	}, func(out ws.CLIOutputPayload) {
+		mu.Lock()
+		outputs = append(outputs, out)
+		mu.Unlock()
+	})
+
+	if result.ExitCode != 3 {
+		t.Fatalf("ExitCode = %d, want 3 (stderr=%q)", result.ExitCode, result.Stderr)
+	}
+	if result.Stdout != "one\nthree" || result.Stderr != "two\n" {
+		t.Errorf("Stdout = %q, Stderr = %q", result.Stdout, result.Stderr)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(outputs) < 2 {
+		t.Fatalf("cli_output 메시지 수 = %d, 실행 중 배치와 마지막 배치가 필요합니다", len(outputs))
+	}
+	if result.OutputMessages != len(outputs) {
+		t.Errorf("OutputMessages = %d, want %d", result.OutputMessages, len(outputs))
+	}
+	var got []string
+	for i, out := range outputs {
+		if out.Seq != i {
+			t.Errorf("outputs[%d].Seq = %d", i, out.Seq)
+		}
+		for _, line := range out.Lines {
+			got = append(got, line.Stream+":"+line.Text)
+		}
+	}
+	joined := strings.Join(got, ",")
+	for _, want := range []string{"stdout:one", "stderr:two", "stdout:three"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("스트리밍된 줄 %q에 %q가 없습니다", joined, want)
+		}
+	}
+}
+
+func TestOutputStreamer_Limits(t *testing.T) {
+	var outputs []ws.CLIOutputPayload
+	s := newOutputStreamer(func(out ws.CLIOutputPayload) { outputs = append(outputs, out) }, time.Hour)
+	lw := &lineWriter{stream: ws.CLIStreamStdout, streamer: s}
+
+	// 긴 줄은 잘라서 전송하고 나머지는 버린다
+	_, _ = lw.Write([]byte(strings.Repeat("x", MaxStreamLineBytes+10) + "\r\nshort\n"))
+	// 배치 한도를 넘는 줄은 개수만 보고한다
+	line := strings.Repeat("y", 1000) + "\n"
+	_, _ = lw.Write([]byte(strings.Repeat(line, MaxStreamBatchBytes/1000+5)))
+	lw.finish()
+	if n := s.close(); n != 1 {
+		t.Fatalf("close() = %d, want 1", n)
+	}
+
+	out := outputs[0]
+	if !out.Lines[0].Truncated || len(out.Lines[0].Text) != MaxStreamLineBytes {
+		t.Errorf("긴 줄: truncated=%v len=%d", out.Lines[0].Truncated, len(out.Lines[0].Text))
+	}
+	if out.Lines[1].Text != "short" {
+		t.Errorf("Lines[1] = %q, want %q", out.Lines[1].Text, "short")
+	}
+	if out.DroppedLines == 0 {
+		t.Error("배치 한도를 넘은 줄 수가 보고되지 않았습니다")
+	}
+}
+
+func TestHeadTailBuffer(t *testing.T) {
+	b := newHeadTailBuffer(10)
+	_, _ = b.Write([]byte("abcdefgh"))
+	if b.Truncated() || b.String() != "abcdefgh" {
+		t.Fatalf("한도 이내: %q truncated=%v", b.String(), b.Truncated())
+	}
+	for range 10 {
+		_, _ = b.Write([]byte("0123456789"))
+	}
+	_, _ = b.Write([]byte("END"))
+	if !b.Truncated() {
+		t.Fatal("한도 초과가 감지되지 않았습니다")
+	}
+	got := b.String()
+	if !strings.HasPrefix(got, "abcde\n") || !strings.HasSuffix(got, "\n89END") {
+		t.Errorf("String() = %q, 앞/뒤 5바이트를 보존해야 합니다", got)
+	}
+	if !strings.Contains(got, "[101 bytes truncated]") {
+		t.Errorf("String() = %q, 생략 크기가 표시되어야 합니다", got)
+	}
+}
@@ -59,6 +59,26 @@ type claudeCLIResponse struct {
 // ProgressFn은 코드 생성 진행 상황을 보고하는 콜백 함수입니다.
 type ProgressFn func(phase string, progress int, message string)
 
+// 코드 생성 단계 (ProgressFn의 phase)
+const (
+	PhaseTemplateLoading = "template_loading"
+	PhaseGenerating      = "generating"
+	PhaseCollecting      = "collecting"
+)
+
+// Phases는 Generate가 보고하는 단계를 진행 순서대로 나열합니다.
+var Phases = []string{PhaseTemplateLoading, PhaseGenerating, PhaseCollecting}
+
+// PhaseIndex는 Phases에서 phase의 순번을 반환합니다. 알 수 없는 단계면 -1입니다.
+func PhaseIndex(phase string) int {
+	for i, p := range Phases {
+		if p == phase {
+			return i
+		}
+	}
+	return -1
+}
+
 // NewGenerator는 새로운 Generator를 생성합니다.
 func NewGenerator(claudePath, workDir string, timeout time.Duration, logger *slog.Logger) *Generator {
 	if logger == nil {
@@ -98,7 +118,7 @@ func (g *Generator) Generate(ctx context.Context, req GenerateRequest, progressF
 
 	// Phase 1: 템플릿 로딩
 	if progressFn != nil {
-		progressFn("template_loading", 10, fmt.Sprintf("MCP 서버 '%s' 생성 준비 중", req.ServiceName))
+		progressFn(PhaseTemplateLoading, 10, fmt.Sprintf("MCP 서버 '%s' 생성 준비 중", req.ServiceName))
 	}
 
 	prompt := g.buildPrompt(req)
@@ -111,7 +131,7 @@ func (g *Generator) Generate(ctx context.Context, req GenerateRequest, progressF
 
 	// Phase 2: 코드 생성
 	if progressFn != nil {
-		progressFn("generating", 30, "Claude CLI로 코드 생성 중")
+		progressFn(PhaseGenerating, 30, "Claude CLI로 코드 생성 중")
 	}
 
 	// 타임아웃 컨텍스트 생성
@@ -136,7 +156,7 @@ func (g *Generator) Generate(ctx context.Context, req GenerateRequest, progressF
 	err := cmd.Run()
 
 	if progressFn != nil {
-		progressFn("generating", 70, "Claude CLI 실행 완료")
+		progressFn(PhaseGenerating, 70, "Claude CLI 실행 완료")
 	}
 
 	// CLI 실행 결과 처리
@@ -167,7 +187,7 @@ func (g *Generator) Generate(ctx context.Context, req GenerateRequest, progressF
 
 	// Phase 3: 파일 수집
 	if progressFn != nil {
-		progressFn("collecting", 90, "생성된 파일 수집 중")
+		progressFn(PhaseCollecting, 90, "생성된 파일 수집 중")
 	}
 
 	collector := NewCollector()
@@ -186,7 +206,7 @@ func (g *Generator) Generate(ctx context.Context, req GenerateRequest, progressF
 	}
 
 	if progressFn != nil {
-		progressFn("collecting", 100, fmt.Sprintf("완료: %d개 파일 생성", len(files)))
+		progressFn(PhaseCollecting, 100, fmt.Sprintf("완료: %d개 파일 생성", len(files)))
 	}
 
 	g.logger.Info("코드 생성 완료",
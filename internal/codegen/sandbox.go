@@ -6,13 +6,51 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/insajin/autopus-bridge/internal/diskquota"
 )
 
 // Sandbox는 코드 생성을 위한 격리된 디렉토리를 제공합니다.
 // 각 생성 요청은 독립적인 샌드박스 디렉토리에서 수행됩니다.
 type Sandbox struct {
 	baseDir string // 샌드박스 기본 디렉토리 (~/.acos/codegen-sandbox/)
+	quota   SandboxQuota
 	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// 샌드박스 할당량 기본값
+const (
+	DefaultSandboxMaxTotalBytes   int64 = 1 << 30   // 1GiB
+	DefaultSandboxMaxServiceBytes int64 = 100 << 20 // 100MiB
+	DefaultSandboxMaxAge                = 24 * time.Hour
+)
+
+// SandboxQuota는 샌드박스 디스크 사용량 제한입니다.
+// 각 값이 0이면 해당 제한을 적용하지 않습니다.
+type SandboxQuota struct {
+	MaxTotalBytes   int64         // 기본 디렉토리 전체 최대 크기
+	MaxServiceBytes int64         // 샌드박스(서비스) 하나의 최대 크기
+	MaxAge          time.Duration // 이보다 오래된 샌드박스는 Create 시 자동 삭제
+}
+
+// DefaultSandboxQuota는 기본 샌드박스 할당량을 반환합니다.
+func DefaultSandboxQuota() SandboxQuota {
+	return SandboxQuota{
+		MaxTotalBytes:   DefaultSandboxMaxTotalBytes,
+		MaxServiceBytes: DefaultSandboxMaxServiceBytes,
+		MaxAge:          DefaultSandboxMaxAge,
+	}
+}
+
+// SandboxOption은 Sandbox 설정 옵션입니다.
+type SandboxOption func(*Sandbox)
+
+// WithQuota는 샌드박스 할당량을 설정합니다.
+func WithQuota(quota SandboxQuota) SandboxOption {
+	return func(s *Sandbox) {
+		s.quota = quota
+	}
 }
 
 // 유효한 출력 진입점 파일 목록
@@ -29,14 +67,21 @@ var validEntryPoints = []string{
 
 // NewSandbox는 새로운 Sandbox를 생성합니다.
 // baseDir가 존재하지 않으면 생성을 시도합니다.
-func NewSandbox(baseDir string, logger *slog.Logger) *Sandbox {
+// 할당량을 지정하지 않으면 DefaultSandboxQuota를 사용합니다.
+func NewSandbox(baseDir string, logger *slog.Logger, opts ...SandboxOption) *Sandbox {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &Sandbox{
+	s := &Sandbox{
 		baseDir: baseDir,
+		quota:   DefaultSandboxQuota(),
 		logger:  logger,
+		now:     time.Now,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Create는 서비스 이름 기반의 격리된 디렉토리를 생성합니다.
@@ -51,6 +96,21 @@ func (s *Sandbox) Create(serviceName string) (string, func(), error) {
 		return "", nil, fmt.Errorf("샌드박스 기본 디렉토리 생성 실패: %w", err)
 	}
 
+	// 비정상 종료로 남은 오래된 샌드박스 정리 후 전체 할당량 확인
+	s.CleanupExpired()
+	used, err := diskquota.DirSize(s.baseDir)
+	if err != nil {
+		return "", nil, err
+	}
+	if s.quota.MaxTotalBytes > 0 && used >= s.quota.MaxTotalBytes {
+		return "", nil, &diskquota.ExceededError{
+			Scope: "샌드박스 전체",
+			Path:  s.baseDir,
+			Used:  used,
+			Limit: s.quota.MaxTotalBytes,
+		}
+	}
+
 	// 타임스탬프 기반 고유 디렉토리명 생성
 	timestamp := time.Now().Format("20060102-150405")
 	dirName := fmt.Sprintf("%s-%s", serviceName, timestamp)
@@ -82,6 +142,43 @@ func (s *Sandbox) Create(serviceName string) (string, func(), error) {
 	return dirPath, cleanup, nil
 }
 
+// CleanupExpired는 MaxAge보다 오래된 샌드박스 디렉토리를 삭제하고 삭제한 디렉토리 이름을 반환합니다.
+// 정상적인 생성 요청은 cleanup 함수로 정리되므로, 주로 비정상 종료로 남은 디렉토리가 대상입니다.
+func (s *Sandbox) CleanupExpired() []string {
+	removed, err := diskquota.RemoveExpired(s.baseDir, s.quota.MaxAge, s.now())
+	if err != nil {
+		s.logger.Warn("만료된 샌드박스 정리 실패",
+			slog.String("path", s.baseDir),
+			slog.String("error", err.Error()),
+		)
+	}
+	if len(removed) > 0 {
+		s.logger.Info("만료된 샌드박스 정리",
+			slog.Int("count", len(removed)),
+			slog.Duration("max_age", s.quota.MaxAge),
+		)
+	}
+	return removed
+}
+
+// CheckQuota는 생성된 샌드박스 디렉토리와 기본 디렉토리 전체가 할당량 이내인지 확인합니다.
+// 초과하면 diskquota.ErrQuotaExceeded를 감싼 에러를 반환합니다.
+func (s *Sandbox) CheckQuota(dir string) error {
+	serviceSize, err := diskquota.DirSize(dir)
+	if err != nil {
+		return err
+	}
+	if err := diskquota.Check("샌드박스", dir, serviceSize, s.quota.MaxServiceBytes); err != nil {
+		return err
+	}
+
+	totalSize, err := diskquota.DirSize(s.baseDir)
+	if err != nil {
+		return err
+	}
+	return diskquota.Check("샌드박스 전체", s.baseDir, totalSize, s.quota.MaxTotalBytes)
+}
+
 // ValidateOutput는 출력 디렉토리의 유효성을 검증합니다.
 //
 // 검증 조건:
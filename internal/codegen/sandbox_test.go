@@ -1,10 +1,14 @@
 package codegen
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/diskquota"
 )
 
 func TestNewSandbox(t *testing.T) {
@@ -243,3 +247,70 @@ func TestValidEntryPoints_Coverage(t *testing.T) {
 		})
 	}
 }
+
+func TestSandbox_Create_RemovesExpiredSandboxes(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), "sandbox")
+	stale := filepath.Join(baseDir, "old-svc-20240101-000000")
+	if err := os.MkdirAll(stale, 0750); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSandbox(baseDir, nil, WithQuota(SandboxQuota{MaxAge: 24 * time.Hour}))
+	dirPath, cleanup, err := s.Create("svc")
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	defer cleanup()
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("expired sandbox should be removed on Create()")
+	}
+	if _, err := os.Stat(dirPath); err != nil {
+		t.Errorf("new sandbox should exist: %v", err)
+	}
+}
+
+func TestSandbox_Create_TotalQuotaExceeded(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), "sandbox")
+	if err := os.MkdirAll(filepath.Join(baseDir, "leftover"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "leftover", "big.bin"), make([]byte, 2048), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSandbox(baseDir, nil, WithQuota(SandboxQuota{MaxTotalBytes: 1024}))
+	_, _, err := s.Create("svc")
+	if !errors.Is(err, diskquota.ErrQuotaExceeded) {
+		t.Fatalf("Create() error = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestSandbox_CheckQuota(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), "sandbox")
+	s := NewSandbox(baseDir, nil, WithQuota(SandboxQuota{MaxServiceBytes: 1024, MaxTotalBytes: 4096}))
+
+	dirPath, cleanup, err := s.Create("svc")
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(dirPath, "index.ts"), make([]byte, 512), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckQuota(dirPath); err != nil {
+		t.Errorf("CheckQuota() within limit = %v, want nil", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dirPath, "bundle.js"), make([]byte, 1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckQuota(dirPath); !errors.Is(err, diskquota.ErrQuotaExceeded) {
+		t.Errorf("CheckQuota() over service limit = %v, want ErrQuotaExceeded", err)
+	}
+}
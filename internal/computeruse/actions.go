@@ -8,6 +8,10 @@ import (
 	"image/jpeg"
 	"image/png"
 	"log"
+	"math"
+	"strings"
+
+	"github.com/insajin/autopus-agent-protocol"
 )
 
 // MaxScreenshotBytes is the maximum screenshot size before JPEG compression (2MB).
@@ -17,25 +21,71 @@ const MaxScreenshotBytes = 2 * 1024 * 1024
 type ActionExecutor struct {
 	backend  BrowserBackend
 	security *SecurityValidator
+
+	// maxCaptureBytes caps the total size of captures produced by one action.
+	maxCaptureBytes int
+	// annotate draws a marker for the performed action on the returned screenshot.
+	annotate bool
 }
 
 // NewActionExecutor creates a new ActionExecutor.
 func NewActionExecutor(backend BrowserBackend, security *SecurityValidator) *ActionExecutor {
 	return &ActionExecutor{
-		backend:  backend,
-		security: security,
+		backend:         backend,
+		security:        security,
+		maxCaptureBytes: MaxCaptureBytes,
 	}
 }
 
+// SetAnnotate enables drawing the performed action (click crosshair, typed text
+// region, scroll arrow) on the returned screenshot so humans can audit what the agent did.
+func (ae *ActionExecutor) SetAnnotate(enabled bool) {
+	ae.annotate = enabled
+}
+
+// Capture is a file produced by a capture action. The handler saves it as an artifact.
+type Capture struct {
+	// Type is ws.ComputerArtifactPDF or ws.ComputerArtifactFullPageScreenshot.
+	Type     string
+	MimeType string
+	Data     []byte
+	// Page and TotalPages locate a full-page screenshot segment (0 for PDFs).
+	Page       int
+	TotalPages int
+}
+
+// ActionResult is the outcome of a single action.
+type ActionResult struct {
+	// Screenshot is the base64-encoded viewport screenshot taken after the action.
+	Screenshot string
+	// Captures holds PDF or full-page captures produced by capture actions.
+	Captures []Capture
+}
+
 // Execute runs a single computer use action and returns a base64-encoded screenshot.
+// Captures produced by capture actions are discarded; use Run to receive them.
+func (ae *ActionExecutor) Execute(ctx context.Context, action string, params map[string]interface{}) (screenshot string, err error) {
+	result, err := ae.Run(ctx, action, params)
+	if err != nil {
+		return "", err
+	}
+	return result.Screenshot, nil
+}
+
+// Run runs a single computer use action and returns the viewport screenshot
+// together with any captures the action produced.
 // REQ-M2-01: Route computer_action messages to appropriate actions.
 // REQ-M2-02: Check browser instance state before actions.
-func (ae *ActionExecutor) Execute(ctx context.Context, action string, params map[string]interface{}) (screenshot string, err error) {
+func (ae *ActionExecutor) Run(ctx context.Context, action string, params map[string]interface{}) (*ActionResult, error) {
 	// 액션 실행 전 브라우저 상태 검증
 	if !ae.backend.IsActive() {
-		return "", fmt.Errorf("browser is not active")
+		return nil, fmt.Errorf("browser is not active")
 	}
 
+	result := &ActionResult{}
+	// marker is the action drawn on the screenshot when annotation is enabled.
+	var marker *actionMarker
+
 	// Dispatch to the appropriate action handler.
 	switch action {
 	case "screenshot":
@@ -44,82 +94,197 @@ func (ae *ActionExecutor) Execute(ctx context.Context, action string, params map
 	case "click":
 		x, y, parseErr := parseClickParams(params)
 		if parseErr != nil {
-			return "", fmt.Errorf("invalid click params: %w", parseErr)
+			return nil, fmt.Errorf("invalid click params: %w", parseErr)
 		}
 		if err := ae.backend.Click(ctx, x, y); err != nil {
-			return "", err
+			return nil, err
 		}
+		marker = &actionMarker{action: action, x: x, y: y}
 
 	case "type":
 		text, parseErr := parseTypeParams(params)
 		if parseErr != nil {
-			return "", fmt.Errorf("invalid type params: %w", parseErr)
+			return nil, fmt.Errorf("invalid type params: %w", parseErr)
 		}
 		if err := ae.backend.Type(ctx, text); err != nil {
-			return "", err
+			return nil, err
+		}
+		marker = &actionMarker{action: action}
+		if backend, ok := ae.backend.(FocusBoundsBackend); ok && ae.annotate {
+			if bounds, found, err := backend.FocusedElementBounds(ctx); err != nil {
+				log.Printf("[computer-use] annotation skipped: %v", err)
+			} else if found {
+				marker.region = bounds
+			}
 		}
 
 	case "scroll":
 		direction, amount, parseErr := parseScrollParams(params)
 		if parseErr != nil {
-			return "", fmt.Errorf("invalid scroll params: %w", parseErr)
+			return nil, fmt.Errorf("invalid scroll params: %w", parseErr)
 		}
 		if err := ae.backend.Scroll(ctx, direction, amount); err != nil {
-			return "", err
+			return nil, err
 		}
+		marker = &actionMarker{action: action, direction: direction}
 
 	case "navigate":
 		url, parseErr := parseNavigateParams(params)
 		if parseErr != nil {
-			return "", fmt.Errorf("invalid navigate params: %w", parseErr)
+			return nil, fmt.Errorf("invalid navigate params: %w", parseErr)
 		}
 		// Validate URL before navigating.
 		if err := ae.security.ValidateURL(url); err != nil {
-			return "", fmt.Errorf("URL blocked: %w", err)
+			return nil, fmt.Errorf("URL blocked: %w", err)
 		}
 		if err := ae.backend.Navigate(ctx, url); err != nil {
-			return "", err
+			return nil, err
 		}
 
+	case "full_page_screenshot":
+		backend, ok := ae.backend.(PageCaptureBackend)
+		if !ok {
+			return nil, fmt.Errorf("action %s is not supported by this browser backend", action)
+		}
+		startPage, parseErr := parsePageParam(params)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid full_page_screenshot params: %w", parseErr)
+		}
+		captures, err := captureFullPage(ctx, backend, startPage, ae.maxCaptureBytes)
+		if err != nil {
+			return nil, err
+		}
+		result.Captures = captures
+
+	case "capture_pdf":
+		backend, ok := ae.backend.(PageCaptureBackend)
+		if !ok {
+			return nil, fmt.Errorf("action %s is not supported by this browser backend", action)
+		}
+		pageRanges, parseErr := parsePDFParams(params)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid capture_pdf params: %w", parseErr)
+		}
+		pdf, err := backend.PrintPDF(ctx, pageRanges)
+		if err != nil {
+			return nil, err
+		}
+		if len(pdf) > ae.maxCaptureBytes {
+			return nil, fmt.Errorf("PDF is %d bytes, over the %d byte limit; print fewer pages with the 'pages' parameter (e.g. \"1-10\")", len(pdf), ae.maxCaptureBytes)
+		}
+		result.Captures = []Capture{{Type: ws.ComputerArtifactPDF, MimeType: "application/pdf", Data: pdf}}
+
 	default:
-		return "", fmt.Errorf("unknown action: %s", action)
+		return nil, fmt.Errorf("unknown action: %s", action)
 	}
 
 	// Capture screenshot after every action.
 	pngBytes, err := ae.backend.Screenshot(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to capture screenshot: %w", err)
+		return nil, fmt.Errorf("failed to capture screenshot: %w", err)
+	}
+
+	// The audit overlay is best-effort: an unannotated screenshot is still useful.
+	if ae.annotate && marker != nil {
+		if annotated, err := annotateScreenshot(pngBytes, *marker); err != nil {
+			log.Printf("[computer-use] annotation skipped: %v", err)
+		} else {
+			pngBytes = annotated
+		}
 	}
 
 	// Compress to JPEG if screenshot exceeds size limit.
 	encoded, err := encodeScreenshot(pngBytes)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode screenshot: %w", err)
+		return nil, fmt.Errorf("failed to encode screenshot: %w", err)
 	}
+	result.Screenshot = encoded
 
-	return encoded, nil
+	return result, nil
+}
+
+// captureFullPage captures the document in FullPageSegmentHeight segments starting
+// at startPage (1-based). At most MaxFullPageSegmentsPerAction segments are captured,
+// and capturing stops early once the total size would exceed maxBytes; the caller
+// requests the remaining segments with a later page number.
+func captureFullPage(ctx context.Context, backend PageCaptureBackend, startPage, maxBytes int) ([]Capture, error) {
+	width, height, err := backend.PageSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("page has no content to capture (%dx%d)", width, height)
+	}
+
+	totalPages := int(math.Ceil(float64(height) / FullPageSegmentHeight))
+	if startPage > totalPages {
+		return nil, fmt.Errorf("page %d is out of range; the page has %d segment(s)", startPage, totalPages)
+	}
+
+	var captures []Capture
+	total := 0
+	for p := startPage; p <= totalPages && len(captures) < MaxFullPageSegmentsPerAction; p++ {
+		y := (p - 1) * FullPageSegmentHeight
+		segmentHeight := min(FullPageSegmentHeight, height-y)
+
+		pngBytes, err := backend.CaptureRegion(ctx, width, y, segmentHeight)
+		if err != nil {
+			return nil, err
+		}
+		data, mimeType, err := compressScreenshot(pngBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode page segment %d: %w", p, err)
+		}
+		if len(data) > maxBytes {
+			return nil, fmt.Errorf("page segment %d is %d bytes, over the %d byte limit", p, len(data), maxBytes)
+		}
+		if total+len(data) > maxBytes {
+			// 나머지 구간은 다음 요청에서 이어서 캡처한다.
+			break
+		}
+		total += len(data)
+
+		captures = append(captures, Capture{
+			Type:       ws.ComputerArtifactFullPageScreenshot,
+			MimeType:   mimeType,
+			Data:       data,
+			Page:       p,
+			TotalPages: totalPages,
+		})
+	}
+	return captures, nil
 }
 
 // encodeScreenshot returns a base64-encoded screenshot.
 // If the PNG exceeds MaxScreenshotBytes, it compresses to JPEG at 80% quality.
 func encodeScreenshot(pngBytes []byte) (string, error) {
+	data, _, err := compressScreenshot(pngBytes)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// compressScreenshot returns the screenshot bytes and their MIME type.
+// If the PNG exceeds MaxScreenshotBytes, it compresses to JPEG at 80% quality.
+func compressScreenshot(pngBytes []byte) ([]byte, string, error) {
 	if len(pngBytes) <= MaxScreenshotBytes {
-		return base64.StdEncoding.EncodeToString(pngBytes), nil
+		return pngBytes, "image/png", nil
 	}
 
 	// Decode PNG to re-encode as JPEG.
 	img, err := png.Decode(bytes.NewReader(pngBytes))
 	if err != nil {
-		return "", fmt.Errorf("failed to decode PNG for compression: %w", err)
+		return nil, "", fmt.Errorf("failed to decode PNG for compression: %w", err)
 	}
 
 	var jpegBuf bytes.Buffer
 	if err := jpeg.Encode(&jpegBuf, img, &jpeg.Options{Quality: 80}); err != nil {
-		return "", fmt.Errorf("failed to encode JPEG: %w", err)
+		return nil, "", fmt.Errorf("failed to encode JPEG: %w", err)
 	}
 
 	log.Printf("[computer-use] screenshot compressed: PNG %d bytes -> JPEG %d bytes", len(pngBytes), jpegBuf.Len())
-	return base64.StdEncoding.EncodeToString(jpegBuf.Bytes()), nil
+	return jpegBuf.Bytes(), "image/jpeg", nil
 }
 
 // parseClickParams extracts x and y coordinates from action parameters.
@@ -187,6 +352,41 @@ func parseNavigateParams(params map[string]interface{}) (string, error) {
 	return urlStr, nil
 }
 
+// parsePageParam extracts the optional 1-based segment number for full_page_screenshot.
+func parsePageParam(params map[string]interface{}) (int, error) {
+	val, ok := params["page"]
+	if !ok {
+		return 1, nil
+	}
+	page, err := toFloat64Value(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid 'page': %w", err)
+	}
+	if page < 1 || page != math.Trunc(page) {
+		return 0, fmt.Errorf("'page' must be a positive integer, got %v", page)
+	}
+	return int(page), nil
+}
+
+// parsePDFParams extracts the optional page ranges (e.g. "1-5, 8") for capture_pdf.
+func parsePDFParams(params map[string]interface{}) (string, error) {
+	val, ok := params["pages"]
+	if !ok {
+		return "", nil
+	}
+	pages, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("'pages' parameter is not a string")
+	}
+	pages = strings.TrimSpace(pages)
+	for _, r := range pages {
+		if (r < '0' || r > '9') && r != '-' && r != ',' && r != ' ' {
+			return "", fmt.Errorf("'pages' must be page ranges like \"1-5, 8\", got %q", pages)
+		}
+	}
+	return pages, nil
+}
+
 // toFloat64 extracts a float64 value from a map by key.
 func toFloat64(m map[string]interface{}, key string) (float64, error) {
 	val, ok := m[key]
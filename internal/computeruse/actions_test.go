@@ -2,10 +2,14 @@ package computeruse
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"image"
 	"image/png"
+	"strings"
 	"testing"
+
+	"github.com/insajin/autopus-agent-protocol"
 )
 
 func TestParseClickParams(t *testing.T) {
@@ -340,3 +344,130 @@ func TestParseScrollParams_InvalidAmount(t *testing.T) {
 		t.Error("parseScrollParams(amount=string) = nil error; want error")
 	}
 }
+
+// mockCaptureBackend은 PageCaptureBackend을 구현하는 테스트용 mock이다.
+type mockCaptureBackend struct {
+	*mockBrowserBackend
+
+	width, height int
+	segmentBytes  int
+	regions       [][2]int // 캡처 요청 (y, height)
+	pdf           []byte
+	lastRanges    string
+}
+
+func newMockCaptureBackend(width, height int) *mockCaptureBackend {
+	base := newMockBrowserBackend()
+	base.active = true
+	return &mockCaptureBackend{mockBrowserBackend: base, width: width, height: height, segmentBytes: 16}
+}
+
+func (m *mockCaptureBackend) PageSize(ctx context.Context) (int, int, error) {
+	return m.width, m.height, nil
+}
+
+func (m *mockCaptureBackend) CaptureRegion(ctx context.Context, width, y, height int) ([]byte, error) {
+	m.regions = append(m.regions, [2]int{y, height})
+	return bytes.Repeat([]byte{'x'}, m.segmentBytes), nil
+}
+
+func (m *mockCaptureBackend) PrintPDF(ctx context.Context, pageRanges string) ([]byte, error) {
+	m.lastRanges = pageRanges
+	return m.pdf, nil
+}
+
+var _ PageCaptureBackend = (*mockCaptureBackend)(nil)
+
+func TestActionExecutor_Run_FullPageScreenshot_Pagination(t *testing.T) {
+	// 7.5 구간 높이의 긴 페이지: 첫 요청은 5구간, 두 번째 요청은 나머지 3구간
+	backend := newMockCaptureBackend(1280, FullPageSegmentHeight*7+FullPageSegmentHeight/2)
+	ae := NewActionExecutor(backend, NewSecurityValidator())
+
+	first, err := ae.Run(context.Background(), "full_page_screenshot", nil)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(first.Captures) != MaxFullPageSegmentsPerAction {
+		t.Fatalf("captures = %d; want %d", len(first.Captures), MaxFullPageSegmentsPerAction)
+	}
+	if c := first.Captures[0]; c.Page != 1 || c.TotalPages != 8 || c.Type != ws.ComputerArtifactFullPageScreenshot || c.MimeType != "image/png" {
+		t.Errorf("first capture = %+v", c)
+	}
+	if first.Screenshot == "" {
+		t.Error("viewport screenshot should still be returned")
+	}
+
+	rest, err := ae.Run(context.Background(), "full_page_screenshot", map[string]interface{}{"page": 6.0})
+	if err != nil {
+		t.Fatalf("Run(page=6) error: %v", err)
+	}
+	if len(rest.Captures) != 3 || rest.Captures[2].Page != 8 {
+		t.Fatalf("captures = %+v; want pages 6-8", rest.Captures)
+	}
+	last := backend.regions[len(backend.regions)-1]
+	if last != [2]int{FullPageSegmentHeight * 7, FullPageSegmentHeight / 2} {
+		t.Errorf("last region = %v; want the remaining half segment", last)
+	}
+
+	if _, err := ae.Run(context.Background(), "full_page_screenshot", map[string]interface{}{"page": 9.0}); err == nil {
+		t.Error("page beyond the last segment should fail")
+	}
+}
+
+func TestActionExecutor_Run_FullPageScreenshot_SizeCap(t *testing.T) {
+	backend := newMockCaptureBackend(1280, FullPageSegmentHeight*4)
+	ae := NewActionExecutor(backend, NewSecurityValidator())
+	ae.maxCaptureBytes = backend.segmentBytes*3 + 1
+
+	result, err := ae.Run(context.Background(), "full_page_screenshot", nil)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(result.Captures) != 3 || result.Captures[2].TotalPages != 4 {
+		t.Errorf("captures = %d; want 3 (stop before exceeding the size cap)", len(result.Captures))
+	}
+
+	// 한 구간만으로 상한을 넘으면 에러
+	ae.maxCaptureBytes = backend.segmentBytes - 1
+	if _, err := ae.Run(context.Background(), "full_page_screenshot", nil); err == nil {
+		t.Error("a segment over the size cap should fail")
+	}
+}
+
+func TestActionExecutor_Run_CapturePDF(t *testing.T) {
+	backend := newMockCaptureBackend(1280, 720)
+	backend.pdf = []byte("%PDF-1.7")
+	ae := NewActionExecutor(backend, NewSecurityValidator())
+
+	result, err := ae.Run(context.Background(), "capture_pdf", map[string]interface{}{"pages": " 1-3, 5 "})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if backend.lastRanges != "1-3, 5" {
+		t.Errorf("page ranges = %q; want %q", backend.lastRanges, "1-3, 5")
+	}
+	if len(result.Captures) != 1 || result.Captures[0].Type != ws.ComputerArtifactPDF || string(result.Captures[0].Data) != "%PDF-1.7" {
+		t.Errorf("captures = %+v", result.Captures)
+	}
+
+	if _, err := ae.Run(context.Background(), "capture_pdf", map[string]interface{}{"pages": "all"}); err == nil {
+		t.Error("invalid page ranges should fail")
+	}
+
+	ae.maxCaptureBytes = len(backend.pdf) - 1
+	if _, err := ae.Run(context.Background(), "capture_pdf", nil); err == nil || !strings.Contains(err.Error(), "'pages'") {
+		t.Errorf("oversized PDF error = %v; want a hint about 'pages'", err)
+	}
+}
+
+func TestActionExecutor_Run_CaptureUnsupportedBackend(t *testing.T) {
+	backend := newMockBrowserBackend()
+	backend.active = true
+	ae := NewActionExecutor(backend, NewSecurityValidator())
+
+	for _, action := range []string{"capture_pdf", "full_page_screenshot"} {
+		if _, err := ae.Run(context.Background(), action, nil); err == nil || !strings.Contains(err.Error(), "not supported") {
+			t.Errorf("%s error = %v; want not supported", action, err)
+		}
+	}
+}
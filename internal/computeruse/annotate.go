@@ -0,0 +1,213 @@
+package computeruse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/chromedp/chromedp"
+)
+
+// 액션 감사 오버레이 스타일
+const (
+	// annotationThickness는 마커 선 두께(px)이다.
+	annotationThickness = 3
+	// crosshairArm은 클릭 십자선 한쪽 팔 길이(px)이다.
+	crosshairArm = 18
+	// crosshairRing은 클릭 지점을 둘러싼 원의 반지름(px)이다.
+	crosshairRing = 12
+	// scrollArrowLength는 스크롤 화살표 전체 길이(px)이다.
+	scrollArrowLength = 120
+	// scrollArrowHead는 스크롤 화살표 머리 높이(px)이다.
+	scrollArrowHead = 24
+	// scrollArrowMargin은 스크롤 화살표와 화면 오른쪽 가장자리 사이 간격(px)이다.
+	scrollArrowMargin = 32
+)
+
+var (
+	// annotationColor는 마커 색상이다. 대부분의 웹 페이지 배경과 구분되도록 진한 자홍색을 사용한다.
+	annotationColor = color.RGBA{R: 0xE6, G: 0x00, B: 0x7E, A: 0xFF}
+	// annotationOutline은 밝은 배경과 어두운 배경 모두에서 보이도록 마커 주위에 그리는 테두리 색상이다.
+	annotationOutline = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+)
+
+// actionMarker는 스크린샷에 표시할 수행된 액션이다 (뷰포트 CSS px 좌표).
+type actionMarker struct {
+	// action은 click, type, scroll 중 하나이다.
+	action string
+	// x, y는 클릭 지점이다.
+	x, y float64
+	// region은 입력이 들어간 요소의 영역이다. 비어 있으면 표시하지 않는다.
+	region image.Rectangle
+	// direction은 스크롤 방향(up, down)이다.
+	direction string
+}
+
+// FocusBoundsBackend는 포커스된 요소의 위치를 조회할 수 있는 BrowserBackend이다.
+// 구현하지 않는 백엔드에서는 type 액션 마커를 그리지 않는다.
+type FocusBoundsBackend interface {
+	// FocusedElementBounds는 포커스된 요소의 뷰포트 영역을 반환한다. 포커스된 요소가 없으면 ok가 false이다.
+	FocusedElementBounds(ctx context.Context) (bounds image.Rectangle, ok bool, err error)
+}
+
+// 컴파일 타임 인터페이스 구현 확인
+var (
+	_ FocusBoundsBackend = (*BrowserManager)(nil)
+	_ FocusBoundsBackend = (*ContainerBrowserBackend)(nil)
+)
+
+// focusedBoundsScript는 포커스된 요소의 뷰포트 기준 영역을 [x, y, width, height]로 구한다.
+const focusedBoundsScript = `(() => {
+	const el = document.activeElement;
+	if (!el || el === document.body || el === document.documentElement) return [];
+	const r = el.getBoundingClientRect();
+	return [r.left, r.top, r.width, r.height];
+})()`
+
+// focusedBounds는 taskCtx 탭에서 포커스된 요소의 영역을 조회한다.
+func focusedBounds(taskCtx context.Context) (image.Rectangle, bool, error) {
+	var rect []float64
+	if err := chromedp.Run(taskCtx, chromedp.Evaluate(focusedBoundsScript, &rect)); err != nil {
+		return image.Rectangle{}, false, fmt.Errorf("failed to locate focused element: %w", err)
+	}
+	if len(rect) != 4 || rect[2] <= 0 || rect[3] <= 0 {
+		return image.Rectangle{}, false, nil
+	}
+	return image.Rect(int(rect[0]), int(rect[1]), int(rect[0]+rect[2]), int(rect[1]+rect[3])), true, nil
+}
+
+// FocusedElementBounds returns the viewport bounds of the focused element.
+func (bm *BrowserManager) FocusedElementBounds(ctx context.Context) (image.Rectangle, bool, error) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if !bm.active {
+		return image.Rectangle{}, false, fmt.Errorf("browser is not active")
+	}
+	return focusedBounds(bm.taskCtx)
+}
+
+// FocusedElementBounds는 포커스된 요소의 뷰포트 영역을 반환한다.
+func (cb *ContainerBrowserBackend) FocusedElementBounds(ctx context.Context) (image.Rectangle, bool, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.active {
+		return image.Rectangle{}, false, fmt.Errorf("browser is not active")
+	}
+	return focusedBounds(cb.taskCtx)
+}
+
+// annotateScreenshot은 PNG 스크린샷에 액션 마커를 그린 PNG를 반환한다.
+// 스크린샷은 뷰포트와 같은 배율(device scale factor 1)로 캡처된다고 가정한다.
+func annotateScreenshot(pngBytes []byte, marker actionMarker) ([]byte, error) {
+	src, err := png.Decode(bytes.NewReader(pngBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	switch marker.action {
+	case "click":
+		drawCrosshair(img, int(marker.x), int(marker.y))
+	case "type":
+		if marker.region.Empty() {
+			return pngBytes, nil
+		}
+		drawOutlinedRect(img, marker.region)
+	case "scroll":
+		drawScrollArrow(img, marker.direction)
+	default:
+		return pngBytes, nil
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode annotated screenshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawCrosshair는 클릭 지점에 십자선과 원을 그린다.
+func drawCrosshair(img *image.RGBA, x, y int) {
+	for _, c := range []struct {
+		col   color.RGBA
+		extra int
+	}{{annotationOutline, 1}, {annotationColor, 0}} {
+		half := annotationThickness/2 + c.extra
+		fillRect(img, image.Rect(x-crosshairArm-c.extra, y-half, x+crosshairArm+c.extra+1, y+half+1), c.col)
+		fillRect(img, image.Rect(x-half, y-crosshairArm-c.extra, x+half+1, y+crosshairArm+c.extra+1), c.col)
+		drawRing(img, x, y, crosshairRing, annotationThickness+2*c.extra, c.col)
+	}
+}
+
+// drawOutlinedRect는 입력 영역 주위에 사각형 테두리를 그린다.
+func drawOutlinedRect(img *image.RGBA, r image.Rectangle) {
+	r = r.Inset(-annotationThickness)
+	strokeRect(img, r.Inset(-1), annotationThickness+2, annotationOutline)
+	strokeRect(img, r, annotationThickness, annotationColor)
+}
+
+// drawScrollArrow는 화면 오른쪽 가운데에 스크롤 방향 화살표를 그린다.
+func drawScrollArrow(img *image.RGBA, direction string) {
+	b := img.Bounds()
+	x := b.Max.X - scrollArrowMargin
+	top := b.Min.Y + (b.Dy()-scrollArrowLength)/2
+	bottom := top + scrollArrowLength
+
+	for _, c := range []struct {
+		col   color.RGBA
+		extra int
+	}{{annotationOutline, 1}, {annotationColor, 0}} {
+		half := annotationThickness/2 + c.extra
+		fillRect(img, image.Rect(x-half, top, x+half+1, bottom), c.col)
+		// 화살표 머리: 끝점에서 멀어질수록 넓어지는 삼각형
+		for i := 0; i <= scrollArrowHead+c.extra; i++ {
+			y := bottom - scrollArrowHead - c.extra + i
+			width := scrollArrowHead + c.extra - i
+			if direction == "up" {
+				y = top + scrollArrowHead + c.extra - i
+			}
+			fillRect(img, image.Rect(x-width, y, x+width+1, y+1), c.col)
+		}
+	}
+}
+
+// strokeRect는 r 안쪽으로 thickness 두께의 테두리를 그린다.
+func strokeRect(img *image.RGBA, r image.Rectangle, thickness int, c color.RGBA) {
+	fillRect(img, image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+thickness), c)
+	fillRect(img, image.Rect(r.Min.X, r.Max.Y-thickness, r.Max.X, r.Max.Y), c)
+	fillRect(img, image.Rect(r.Min.X, r.Min.Y, r.Min.X+thickness, r.Max.Y), c)
+	fillRect(img, image.Rect(r.Max.X-thickness, r.Min.Y, r.Max.X, r.Max.Y), c)
+}
+
+// drawRing은 (cx, cy)를 중심으로 반지름 radius, 두께 thickness의 원을 그린다.
+func drawRing(img *image.RGBA, cx, cy, radius, thickness int, c color.RGBA) {
+	outer := radius + thickness/2
+	inner := radius - thickness/2
+	for dy := -outer; dy <= outer; dy++ {
+		for dx := -outer; dx <= outer; dx++ {
+			d := dx*dx + dy*dy
+			if d <= outer*outer && d >= inner*inner {
+				setPixel(img, cx+dx, cy+dy, c)
+			}
+		}
+	}
+}
+
+// fillRect는 이미지 범위 안의 r을 c로 채운다.
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r.Intersect(img.Bounds()), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// setPixel은 이미지 범위 안의 점만 칠한다.
+func setPixel(img *image.RGBA, x, y int, c color.RGBA) {
+	if image.Pt(x, y).In(img.Bounds()) {
+		img.SetRGBA(x, y, c)
+	}
+}
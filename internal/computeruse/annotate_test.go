@@ -0,0 +1,167 @@
+package computeruse
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// blankPNG는 w x h 크기의 흰색 PNG를 만든다.
+func blankPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// decodePNG는 PNG 바이트를 디코딩한다.
+func decodePNG(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode() error: %v", err)
+	}
+	return img
+}
+
+func isAnnotationColor(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	ar, ag, ab, _ := annotationColor.RGBA()
+	return r == ar && g == ag && b == ab
+}
+
+func TestAnnotateScreenshot_Markers(t *testing.T) {
+	tests := []struct {
+		name     string
+		marker   actionMarker
+		marked   []image.Point
+		unmarked []image.Point
+	}{
+		{
+			name:     "click crosshair",
+			marker:   actionMarker{action: "click", x: 100, y: 80},
+			marked:   []image.Point{{100, 80}, {100 + crosshairArm, 80}, {100, 80 - crosshairArm}, {100 + crosshairRing, 80}},
+			unmarked: []image.Point{{10, 10}, {190, 150}},
+		},
+		{
+			name:     "typed text region",
+			marker:   actionMarker{action: "type", region: image.Rect(40, 50, 140, 70)},
+			marked:   []image.Point{{40 - annotationThickness, 60}, {90, 70 + annotationThickness - 1}},
+			unmarked: []image.Point{{90, 60}},
+		},
+		{
+			name:     "scroll down arrow",
+			marker:   actionMarker{action: "scroll", direction: "down"},
+			marked:   []image.Point{{200 - scrollArrowMargin, 100}, {200 - scrollArrowMargin, (200+scrollArrowLength)/2 - 1}},
+			unmarked: []image.Point{{10, 100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := annotateScreenshot(blankPNG(t, 200, 200), tt.marker)
+			if err != nil {
+				t.Fatalf("annotateScreenshot() error: %v", err)
+			}
+			img := decodePNG(t, out)
+			for _, p := range tt.marked {
+				if !isAnnotationColor(img.At(p.X, p.Y)) {
+					t.Errorf("pixel %v = %v; want annotation color", p, img.At(p.X, p.Y))
+				}
+			}
+			for _, p := range tt.unmarked {
+				if isAnnotationColor(img.At(p.X, p.Y)) {
+					t.Errorf("pixel %v should not be annotated", p)
+				}
+			}
+		})
+	}
+}
+
+func TestAnnotateScreenshot_ScrollUpPointsUp(t *testing.T) {
+	out, err := annotateScreenshot(blankPNG(t, 200, 200), actionMarker{action: "scroll", direction: "up"})
+	if err != nil {
+		t.Fatalf("annotateScreenshot() error: %v", err)
+	}
+	img := decodePNG(t, out)
+	x := 200 - scrollArrowMargin
+	top := (200 - scrollArrowLength) / 2
+	bottom := top + scrollArrowLength
+	// 화살표 머리는 위쪽 끝에서 넓고, 아래쪽 끝은 축 두께만큼만 칠해진다.
+	if !isAnnotationColor(img.At(x-scrollArrowHead/2, top+scrollArrowHead-2)) {
+		t.Error("arrow head should be drawn near the top")
+	}
+	if isAnnotationColor(img.At(x-scrollArrowHead/2, bottom-2)) {
+		t.Error("arrow head should not be drawn near the bottom")
+	}
+}
+
+func TestAnnotateScreenshot_ClipsMarkersAtEdges(t *testing.T) {
+	if _, err := annotateScreenshot(blankPNG(t, 50, 50), actionMarker{action: "click", x: 0, y: 49}); err != nil {
+		t.Fatalf("annotateScreenshot() error: %v", err)
+	}
+}
+
+func TestAnnotateScreenshot_InvalidPNG(t *testing.T) {
+	if _, err := annotateScreenshot([]byte("not-a-png"), actionMarker{action: "click"}); err == nil {
+		t.Error("annotateScreenshot(invalid) = nil error; want error")
+	}
+}
+
+// mockFocusBackend은 FocusBoundsBackend을 구현하는 테스트용 mock이다.
+type mockFocusBackend struct {
+	*mockBrowserBackend
+	bounds image.Rectangle
+}
+
+func (m *mockFocusBackend) FocusedElementBounds(ctx context.Context) (image.Rectangle, bool, error) {
+	return m.bounds, !m.bounds.Empty(), nil
+}
+
+func TestActionExecutor_Run_Annotate(t *testing.T) {
+	base := newMockBrowserBackend()
+	base.active = true
+	base.screenshotData = blankPNG(t, 200, 200)
+	backend := &mockFocusBackend{mockBrowserBackend: base, bounds: image.Rect(20, 20, 120, 40)}
+	ae := NewActionExecutor(backend, NewSecurityValidator())
+
+	plain, err := ae.Run(context.Background(), "type", map[string]interface{}{"text": "hi"})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if plain.Screenshot != base64.StdEncoding.EncodeToString(base.screenshotData) {
+		t.Error("screenshot should be unchanged when annotation is disabled")
+	}
+
+	ae.SetAnnotate(true)
+	annotated, err := ae.Run(context.Background(), "type", map[string]interface{}{"text": "hi"})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(annotated.Screenshot)
+	if err != nil {
+		t.Fatalf("DecodeString() error: %v", err)
+	}
+	if !isAnnotationColor(decodePNG(t, data).At(20-annotationThickness, 30)) {
+		t.Error("typed text region should be outlined")
+	}
+
+	// screenshot 액션은 표시할 액션이 없으므로 원본을 반환한다.
+	shot, err := ae.Run(context.Background(), "screenshot", nil)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if shot.Screenshot != base64.StdEncoding.EncodeToString(base.screenshotData) {
+		t.Error("screenshot action should not be annotated")
+	}
+}
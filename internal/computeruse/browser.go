@@ -40,6 +40,9 @@ type BrowserManager struct {
 	taskCtx    context.Context
 	taskCancel context.CancelFunc
 
+	// origins records visited origins for profile export.
+	origins originTracker
+
 	active bool
 	mu     sync.Mutex
 }
@@ -162,6 +165,7 @@ func (bm *BrowserManager) Navigate(ctx context.Context, url string) error {
 	if err := chromedp.Run(bm.taskCtx, chromedp.Navigate(url)); err != nil {
 		return fmt.Errorf("failed to navigate to %s: %w", url, err)
 	}
+	bm.origins.add(url)
 
 	return nil
 }
@@ -224,3 +228,27 @@ func (bm *BrowserManager) Scroll(ctx context.Context, direction string, amount i
 
 	return nil
 }
+
+// ExportProfile exports cookies and localStorage of visited origins.
+func (bm *BrowserManager) ExportProfile(ctx context.Context) (*BrowserProfile, error) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if !bm.active {
+		return nil, fmt.Errorf("browser is not active")
+	}
+
+	return exportBrowserProfile(bm.taskCtx, bm.origins.list())
+}
+
+// ImportProfile restores a saved profile into the browser.
+func (bm *BrowserManager) ImportProfile(ctx context.Context, profile *BrowserProfile) error {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if !bm.active {
+		return fmt.Errorf("browser is not active")
+	}
+
+	return importBrowserProfile(bm.taskCtx, profile)
+}
@@ -0,0 +1,174 @@
+package computeruse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// 전체 페이지 캡처 상수
+const (
+	// FullPageSegmentHeight는 전체 페이지 스크린샷 한 구간의 높이(px)이다.
+	// Chromium의 캡처 텍스처 한도보다 충분히 작게 유지한다.
+	FullPageSegmentHeight = 4096
+	// MaxFullPageSegmentsPerAction은 한 번의 액션에서 캡처하는 최대 구간 수이다.
+	// 나머지 구간은 page 파라미터로 이어서 요청한다.
+	MaxFullPageSegmentsPerAction = 5
+	// MaxCaptureBytes는 한 번의 캡처 액션이 만드는 파일 크기 상한이다 (20MB).
+	MaxCaptureBytes = 20 * 1024 * 1024
+)
+
+// captureTimeout은 전체 페이지 캡처/PDF 생성 CDP 호출의 최대 실행 시간이다.
+const captureTimeout = 60 * time.Second
+
+// PageCaptureBackend는 뷰포트를 넘어선 전체 페이지 캡처를 지원하는 BrowserBackend이다.
+// 로컬 chromedp와 컨테이너 백엔드 모두 구현한다.
+type PageCaptureBackend interface {
+	// PageSize는 현재 문서의 전체 스크롤 크기(px)를 반환한다.
+	PageSize(ctx context.Context) (width, height int, err error)
+	// CaptureRegion은 문서 좌표 y부터 height만큼을 PNG로 캡처한다.
+	CaptureRegion(ctx context.Context, width, y, height int) ([]byte, error)
+	// PrintPDF는 현재 페이지를 PDF로 출력한다. pageRanges가 비어 있으면 전체 페이지를 출력한다.
+	PrintPDF(ctx context.Context, pageRanges string) ([]byte, error)
+}
+
+// 컴파일 타임 인터페이스 구현 확인
+var (
+	_ PageCaptureBackend = (*BrowserManager)(nil)
+	_ PageCaptureBackend = (*ContainerBrowserBackend)(nil)
+)
+
+// pageSizeScript는 문서 전체의 스크롤 너비/높이를 구한다.
+const pageSizeScript = `(() => {
+	const d = document.documentElement, b = document.body;
+	return [
+		Math.max(d.scrollWidth, b ? b.scrollWidth : 0, d.clientWidth),
+		Math.max(d.scrollHeight, b ? b.scrollHeight : 0, d.clientHeight)
+	];
+})()`
+
+// pageSize는 taskCtx 탭의 문서 크기를 조회한다.
+func pageSize(taskCtx context.Context) (int, int, error) {
+	ctx, cancel := context.WithTimeout(taskCtx, captureTimeout)
+	defer cancel()
+
+	var dims []float64
+	if err := chromedp.Run(ctx, chromedp.Evaluate(pageSizeScript, &dims)); err != nil {
+		return 0, 0, fmt.Errorf("failed to measure page size: %w", err)
+	}
+	if len(dims) != 2 {
+		return 0, 0, fmt.Errorf("failed to measure page size: unexpected result %v", dims)
+	}
+	return int(dims[0]), int(dims[1]), nil
+}
+
+// captureRegion은 taskCtx 탭의 문서 영역을 뷰포트 밖까지 포함해 PNG로 캡처한다.
+func captureRegion(taskCtx context.Context, width, y, height int) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(taskCtx, captureTimeout)
+	defer cancel()
+
+	var buf []byte
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		buf, err = page.CaptureScreenshot().
+			WithFormat(page.CaptureScreenshotFormatPng).
+			WithCaptureBeyondViewport(true).
+			WithClip(&page.Viewport{X: 0, Y: float64(y), Width: float64(width), Height: float64(height), Scale: 1}).
+			Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture page region (y=%d, height=%d): %w", y, height, err)
+	}
+	return buf, nil
+}
+
+// printPDF는 taskCtx 탭을 배경 포함 PDF로 출력한다.
+func printPDF(taskCtx context.Context, pageRanges string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(taskCtx, captureTimeout)
+	defer cancel()
+
+	var buf []byte
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		params := page.PrintToPDF().WithPrintBackground(true)
+		if pageRanges != "" {
+			params = params.WithPageRanges(pageRanges)
+		}
+		var err error
+		buf, _, err = params.Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to print PDF: %w", err)
+	}
+	return buf, nil
+}
+
+// PageSize returns the scrollable document size.
+func (bm *BrowserManager) PageSize(ctx context.Context) (int, int, error) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if !bm.active {
+		return 0, 0, fmt.Errorf("browser is not active")
+	}
+	return pageSize(bm.taskCtx)
+}
+
+// CaptureRegion captures part of the document beyond the viewport as PNG.
+func (bm *BrowserManager) CaptureRegion(ctx context.Context, width, y, height int) ([]byte, error) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if !bm.active {
+		return nil, fmt.Errorf("browser is not active")
+	}
+	return captureRegion(bm.taskCtx, width, y, height)
+}
+
+// PrintPDF prints the current page as PDF.
+func (bm *BrowserManager) PrintPDF(ctx context.Context, pageRanges string) ([]byte, error) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+
+	if !bm.active {
+		return nil, fmt.Errorf("browser is not active")
+	}
+	return printPDF(bm.taskCtx, pageRanges)
+}
+
+// PageSize는 문서 전체 스크롤 크기를 반환한다.
+func (cb *ContainerBrowserBackend) PageSize(ctx context.Context) (int, int, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.active {
+		return 0, 0, fmt.Errorf("browser is not active")
+	}
+	return pageSize(cb.taskCtx)
+}
+
+// CaptureRegion은 뷰포트 밖을 포함한 문서 영역을 PNG로 캡처한다.
+func (cb *ContainerBrowserBackend) CaptureRegion(ctx context.Context, width, y, height int) ([]byte, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.active {
+		return nil, fmt.Errorf("browser is not active")
+	}
+	return captureRegion(cb.taskCtx, width, y, height)
+}
+
+// PrintPDF는 현재 페이지를 PDF로 출력한다.
+func (cb *ContainerBrowserBackend) PrintPDF(ctx context.Context, pageRanges string) ([]byte, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.active {
+		return nil, fmt.Errorf("browser is not active")
+	}
+	return printPDF(cb.taskCtx, pageRanges)
+}
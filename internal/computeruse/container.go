@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,6 +15,7 @@ import (
 type ContainerConfig struct {
 	Image       string        // Docker 이미지 이름 (기본: "autopus/chromium-sandbox:latest")
 	Network     string        // Docker 네트워크 이름 (기본: "autopus-sandbox-net")
+	Platform    string        // 이미지 플랫폼 (예: "linux/arm64", 비어 있으면 Docker 기본값)
 	MemoryLimit int64         // 메모리 제한 (바이트, 기본: 512MB)
 	CPUQuota    int64         // CPU 할당량 (기본: 100000 = 1.0 CPU)
 	PIDLimit    int64         // PID 제한 (기본: 100)
@@ -60,6 +62,9 @@ type DockerClient interface {
 	// ContainerInspect는 컨테이너 상태 정보를 조회한다.
 	ContainerInspect(ctx context.Context, containerID string) (*ContainerInspectResult, error)
 
+	// ContainerUpdate는 실행 중인 컨테이너의 메모리/CPU 제한을 변경한다 (0이면 변경하지 않음).
+	ContainerUpdate(ctx context.Context, containerID string, memoryLimit, cpuQuota int64) error
+
 	// NetworkCreate는 Docker 네트워크를 생성한다.
 	NetworkCreate(ctx context.Context, name string) error
 
@@ -82,6 +87,11 @@ type DockerClient interface {
 	Close() error
 }
 
+// platformPuller는 플랫폼을 지정한 이미지 풀을 지원하는 DockerClient이다.
+type platformPuller interface {
+	ImagePullPlatform(ctx context.Context, imageRef, platform string) (io.ReadCloser, error)
+}
+
 // ContainerCreateConfig는 컨테이너 생성에 필요한 설정을 전달한다.
 type ContainerCreateConfig struct {
 	Image       string
@@ -93,6 +103,7 @@ type ContainerCreateConfig struct {
 	TmpfsSize   string
 	ReadOnly    bool   // 읽기 전용 루트 파일시스템
 	User        string // 실행 사용자
+	Platform    string // 이미지 플랫폼 (빈 문자열이면 Docker 기본값)
 }
 
 // ContainerInspectResult는 컨테이너 조회 결과를 담는다.
@@ -158,6 +169,7 @@ func (cm *ContainerManager) Create(ctx context.Context) (*ContainerInfo, error)
 		TmpfsSize:   cm.config.TmpfsSize,
 		ReadOnly:    true,
 		User:        "sandbox",
+		Platform:    cm.config.Platform,
 	}
 
 	// 컨테이너 생성
@@ -216,6 +228,18 @@ func (cm *ContainerManager) Remove(ctx context.Context, containerID string) erro
 	return nil
 }
 
+// UpdateResources는 실행 중인 컨테이너의 메모리/CPU 제한을 변경한다.
+// 워밍 풀 컨테이너는 기본 제한으로 생성되므로 세션 할당 시 세션별 제한을 적용하는 데 사용한다.
+func (cm *ContainerManager) UpdateResources(ctx context.Context, containerID string, limits SessionResources) error {
+	if limits.MemoryLimit <= 0 && limits.CPUQuota <= 0 {
+		return nil
+	}
+	if err := cm.client.ContainerUpdate(ctx, containerID, limits.MemoryLimit, limits.CPUQuota); err != nil {
+		return fmt.Errorf("컨테이너 리소스 제한 적용 실패: %w", err)
+	}
+	return nil
+}
+
 // HealthCheck는 컨테이너의 CDP 엔드포인트 접근 가능 여부를 확인한다.
 func (cm *ContainerManager) HealthCheck(ctx context.Context, containerID string) error {
 	// 컨테이너 상태 확인
@@ -272,9 +296,15 @@ func (cm *ContainerManager) EnsureImage(ctx context.Context) error {
 		return nil // 이미 존재
 	}
 
-	// 1차: pull 시도
+	// 1차: pull 시도 (플랫폼이 지정되고 클라이언트가 지원하면 해당 플랫폼으로)
 	log.Printf("[computer-use] 이미지 풀 시작: %s", cm.config.Image)
-	reader, pullErr := cm.client.ImagePull(ctx, cm.config.Image)
+	var reader io.ReadCloser
+	var pullErr error
+	if pp, ok := cm.client.(platformPuller); ok && cm.config.Platform != "" {
+		reader, pullErr = pp.ImagePullPlatform(ctx, cm.config.Image, cm.config.Platform)
+	} else {
+		reader, pullErr = cm.client.ImagePull(ctx, cm.config.Image)
+	}
 	if pullErr == nil {
 		defer reader.Close()
 		if _, err := io.Copy(io.Discard, reader); err == nil {
@@ -285,6 +315,10 @@ func (cm *ContainerManager) EnsureImage(ctx context.Context) error {
 	log.Printf("[computer-use] 이미지 풀 실패: %v", pullErr)
 
 	// 2차: 내장 Dockerfile로 로컬 빌드
+	// digest가 고정된 이미지는 로컬 빌드로 같은 digest를 만들 수 없으므로 폴백하지 않는다.
+	if strings.Contains(cm.config.Image, "@") {
+		return fmt.Errorf("이미지 풀 실패, digest가 고정된 이미지는 로컬 빌드로 대체할 수 없습니다: %w", pullErr)
+	}
 	if len(cm.embeddedDockerfile) == 0 {
 		return fmt.Errorf("이미지 풀 실패, 내장 Dockerfile 없음: %w", pullErr)
 	}
@@ -22,6 +22,7 @@ type ContainerBrowserBackend struct {
 	taskCancel  context.CancelFunc
 	viewportW   int
 	viewportH   int
+	origins     originTracker // 프로필 내보내기 대상 origin
 	active      bool
 	mu          sync.Mutex
 }
@@ -156,6 +157,7 @@ func (cb *ContainerBrowserBackend) Navigate(ctx context.Context, url string) err
 	if err := chromedp.Run(cb.taskCtx, chromedp.Navigate(url)); err != nil {
 		return fmt.Errorf("failed to navigate to %s: %w", url, err)
 	}
+	cb.origins.add(url)
 
 	return nil
 }
@@ -213,3 +215,28 @@ func (cb *ContainerBrowserBackend) Scroll(ctx context.Context, direction string,
 
 	return nil
 }
+
+// ExportProfile은 쿠키와 방문한 origin의 localStorage를 내보낸다.
+func (cb *ContainerBrowserBackend) ExportProfile(ctx context.Context) (*BrowserProfile, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.active {
+		return nil, fmt.Errorf("browser is not active")
+	}
+
+	return exportBrowserProfile(cb.taskCtx, cb.origins.list())
+}
+
+// ImportProfile은 저장된 프로필을 컨테이너 브라우저에 복원한다.
+// 컨테이너는 세션마다 재사용되므로 파일 시스템 대신 CDP로 상태를 주입한다.
+func (cb *ContainerBrowserBackend) ImportProfile(ctx context.Context, profile *BrowserProfile) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if !cb.active {
+		return fmt.Errorf("browser is not active")
+	}
+
+	return importBrowserProfile(cb.taskCtx, profile)
+}
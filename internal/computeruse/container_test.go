@@ -22,6 +22,7 @@ type mockDockerClient struct {
 	stopCalled           int
 	removeCalled         int
 	inspectCalled        int
+	updateCalled         int
 	networkCreateCalled  int
 	networkInspectCalled int
 	imageInspectCalled   int
@@ -37,6 +38,7 @@ type mockDockerClient struct {
 	removeErr         error
 	inspectResult     *ContainerInspectResult
 	inspectErr        error
+	updateErr         error
 	networkCreateErr  error
 	networkInspectErr error
 	imageInspectErr   error
@@ -48,6 +50,8 @@ type mockDockerClient struct {
 	lastCreateConfig *ContainerCreateConfig
 	lastStopTimeout  *time.Duration
 	lastRemoveForce  bool
+	lastUpdateMemory int64
+	lastUpdateCPU    int64
 }
 
 func newMockDockerClient() *mockDockerClient {
@@ -95,6 +99,13 @@ func (m *mockDockerClient) ContainerInspect(ctx context.Context, containerID str
 	return m.inspectResult, m.inspectErr
 }
 
+func (m *mockDockerClient) ContainerUpdate(ctx context.Context, containerID string, memoryLimit, cpuQuota int64) error {
+	m.updateCalled++
+	m.lastUpdateMemory = memoryLimit
+	m.lastUpdateCPU = cpuQuota
+	return m.updateErr
+}
+
 func (m *mockDockerClient) NetworkCreate(ctx context.Context, name string) error {
 	m.networkCreateCalled++
 	return m.networkCreateErr
@@ -102,6 +102,11 @@ func buildCreateArgs(config *ContainerCreateConfig) []string {
 		args = append(args, "--network", config.Network)
 	}
 
+	// 이미지 플랫폼 (arm64/amd64)
+	if config.Platform != "" {
+		args = append(args, "--platform", config.Platform)
+	}
+
 	// CDP 포트 매핑 (랜덤 호스트 포트 -> 컨테이너 9222)
 	args = append(args, "-p", "0:9222")
 
@@ -138,6 +143,28 @@ func (c *CLIDockerClient) ContainerStart(ctx context.Context, containerID string
 	return nil
 }
 
+// ContainerUpdate는 `docker update`로 실행 중인 컨테이너의 메모리/CPU 제한을 변경한다.
+// 메모리 제한 변경 시 swap 한도도 같은 값으로 맞춰 swap으로 제한을 우회하지 못하게 한다.
+func (c *CLIDockerClient) ContainerUpdate(ctx context.Context, containerID string, memoryLimit, cpuQuota int64) error {
+	args := []string{"update"}
+	if memoryLimit > 0 {
+		mem := strconv.FormatInt(memoryLimit, 10)
+		args = append(args, "--memory", mem, "--memory-swap", mem)
+	}
+	if cpuQuota > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(float64(cpuQuota)/100000.0, 'f', 2, 64))
+	}
+	if len(args) == 1 {
+		return nil
+	}
+	args = append(args, containerID)
+
+	if _, err := c.runCmd(ctx, args...); err != nil {
+		return fmt.Errorf("컨테이너 리소스 변경 실패 (id=%s): %w", containerID[:min(12, len(containerID))], err)
+	}
+	return nil
+}
+
 // ContainerStop은 컨테이너를 정지한다.
 // timeout이 nil이면 기본값 10초를 사용한다.
 func (c *CLIDockerClient) ContainerStop(ctx context.Context, containerID string, timeout *time.Duration) error {
@@ -247,7 +274,18 @@ func (c *CLIDockerClient) ImageInspect(ctx context.Context, imageRef string) err
 // ImagePull은 이미지를 풀하고 프로세스의 stdout을 ReadCloser로 반환한다.
 // 호출자는 반환된 ReadCloser를 닫아야 한다.
 func (c *CLIDockerClient) ImagePull(ctx context.Context, imageRef string) (io.ReadCloser, error) {
-	cmd := exec.CommandContext(ctx, c.dockerPath, "pull", imageRef)
+	return c.ImagePullPlatform(ctx, imageRef, "")
+}
+
+// ImagePullPlatform은 지정된 플랫폼으로 이미지를 풀한다.
+// platform이 빈 문자열이면 Docker 기본 플랫폼을 사용한다.
+func (c *CLIDockerClient) ImagePullPlatform(ctx context.Context, imageRef, platform string) (io.ReadCloser, error) {
+	args := []string{"pull"}
+	if platform != "" {
+		args = append(args, "--platform", platform)
+	}
+	args = append(args, imageRef)
+	cmd := exec.CommandContext(ctx, c.dockerPath, args...)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
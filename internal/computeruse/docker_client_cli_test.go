@@ -250,3 +250,25 @@ func TestCLIDockerClient_Close(t *testing.T) {
 		t.Errorf("Close() = error %v; want nil", err)
 	}
 }
+
+func TestBuildCreateArgs_Platform(t *testing.T) {
+	config := &ContainerCreateConfig{
+		Image:    "autopus/chromium-sandbox:1.4.0",
+		Network:  "autopus-sandbox-net",
+		Platform: PlatformARM64,
+	}
+
+	args := buildCreateArgs(config)
+
+	expected := []string{
+		"create",
+		"--network", "autopus-sandbox-net",
+		"--platform", "linux/arm64",
+		"-p", "0:9222",
+		"autopus/chromium-sandbox:1.4.0",
+	}
+
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("buildCreateArgs() =\n  %v\nwant:\n  %v", args, expected)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/insajin/autopus-agent-protocol"
@@ -19,23 +22,72 @@ func WithContainerPool(pool *ContainerPool) HandlerOption {
 	}
 }
 
+// WithSessionScheduler는 Handler에 세션 스케줄러를 설정한다.
+// 세션 매니저의 동시 세션 한도도 스케줄러의 최대 세션 수로 맞춘다.
+func WithSessionScheduler(scheduler *SessionScheduler) HandlerOption {
+	return func(h *Handler) {
+		h.scheduler = scheduler
+	}
+}
+
+// WithProfileStore는 persist_profile 세션의 브라우저 프로필을 store에 저장하도록 설정한다.
+// 프로필은 workspaceID 단위로 저장되어 같은 워크스페이스의 다음 세션에서 복원된다.
+func WithProfileStore(store *ProfileStore, workspaceID string) HandlerOption {
+	return func(h *Handler) {
+		h.profiles = store
+		h.profileWorkspaceID = workspaceID
+	}
+}
+
+// WithArtifactDir는 capture_pdf/full_page_screenshot 결과를 저장할 기본 디렉터리를 설정한다.
+// 세션마다 세션 ID 이름의 하위 디렉터리가 만들어진다.
+func WithArtifactDir(dir string) HandlerOption {
+	return func(h *Handler) {
+		if dir != "" {
+			h.artifactDir = dir
+		}
+	}
+}
+
+// DefaultArtifactDir는 페이지 캡처 아티팩트의 기본 저장 디렉터리를 반환한다.
+func DefaultArtifactDir() string {
+	return filepath.Join(os.TempDir(), "autopus-computer-use-artifacts")
+}
+
 // Handler handles computer use WebSocket messages.
 // REQ-M2-01: Route computer_action messages to appropriate actions.
 type Handler struct {
 	sessionMgr *SessionManager
 	security   *SecurityValidator
-	pool       *ContainerPool // 컨테이너 풀 (nil이면 로컬 모드)
+	pool       *ContainerPool    // 컨테이너 풀 (nil이면 로컬 모드)
+	scheduler  *SessionScheduler // 동시 세션 스케줄러
+
+	profiles           *ProfileStore // 브라우저 프로필 저장소 (nil이면 프로필 유지 비활성)
+	profileWorkspaceID string
+
+	artifactDir string // 페이지 캡처 아티팩트 저장 디렉터리
 }
 
 // NewHandler creates a new computer use Handler.
 func NewHandler(opts ...HandlerOption) *Handler {
 	h := &Handler{
-		sessionMgr: NewSessionManager(),
-		security:   NewSecurityValidator(),
+		sessionMgr:  NewSessionManager(),
+		security:    NewSecurityValidator(),
+		artifactDir: DefaultArtifactDir(),
 	}
 	for _, opt := range opts {
 		opt(h)
 	}
+	if h.scheduler == nil {
+		h.scheduler = NewSessionScheduler(DefaultSchedulerConfig())
+	}
+	// 세션이 어떤 경로로 종료되든 스케줄러 슬롯을 반환한다.
+	h.sessionMgr.SetMaxSessions(h.scheduler.MaxSessions())
+	h.sessionMgr.OnSessionEnd(h.scheduler.Release)
+	if h.profiles != nil {
+		// 명시적 종료뿐 아니라 유휴 만료/종료 시에도 프로필을 저장한다.
+		h.sessionMgr.OnSessionClosing(h.saveProfile)
+	}
 	return h
 }
 
@@ -50,12 +102,49 @@ func (h *Handler) GetActiveSessions() []*Session {
 	return h.sessionMgr.GetActiveSessions()
 }
 
+// Scheduler returns the handler's session scheduler.
+func (h *Handler) Scheduler() *SessionScheduler {
+	return h.scheduler
+}
+
 // HandleSessionStart processes computer_session_start messages.
 // It creates a new browser session and launches the browser.
 func (h *Handler) HandleSessionStart(ctx context.Context, payload ws.ComputerSessionPayload) error {
+	return h.HandleSessionStartQueued(ctx, payload, nil)
+}
+
+// HandleSessionStartQueued processes computer_session_start messages like
+// HandleSessionStart, but waits in the scheduler queue when the maximum number
+// of concurrent sessions is reached. onQueued receives the queue position
+// whenever it changes; it may be nil.
+func (h *Handler) HandleSessionStartQueued(ctx context.Context, payload ws.ComputerSessionPayload, onQueued QueueNotifier) error {
 	log.Printf("[computer-use] starting session %s (execution=%s, viewport=%dx%d, headless=%v)",
 		payload.SessionID, payload.ExecutionID, payload.ViewportW, payload.ViewportH, payload.Headless)
 
+	// 리소스 요청 검증은 대기열에 들어가기 전에 수행한다.
+	var limits SessionResources
+	if h.pool != nil {
+		var err error
+		if limits, err = h.scheduler.ResourceLimits(payload.MemoryLimit, payload.CPULimit); err != nil {
+			return err
+		}
+	}
+
+	// 동시 세션 슬롯 확보 (한도 초과 시 대기열에서 대기)
+	if err := h.scheduler.Acquire(ctx, payload.SessionID, onQueued); err != nil {
+		return fmt.Errorf("failed to schedule session: %w", err)
+	}
+
+	if err := h.startSession(ctx, payload, limits); err != nil {
+		// 슬롯 반환 (세션 정리 과정에서 이미 반환되었으면 무시됨)
+		h.scheduler.Release(payload.SessionID)
+		return err
+	}
+	return nil
+}
+
+// startSession은 스케줄러 슬롯을 확보한 뒤 세션을 생성하고 브라우저를 실행한다.
+func (h *Handler) startSession(ctx context.Context, payload ws.ComputerSessionPayload, limits SessionResources) error {
 	// 세션 생성 (컨테이너 모드 또는 로컬 모드)
 	var session *Session
 	var err error
@@ -67,6 +156,12 @@ func (h *Handler) HandleSessionStart(ctx context.Context, payload ws.ComputerSes
 			return fmt.Errorf("failed to acquire container: %w", acqErr)
 		}
 
+		// 세션별 메모리/CPU 상한 적용
+		if err := h.pool.ApplySessionResources(ctx, payload.SessionID, limits); err != nil {
+			_ = h.pool.Release(ctx, payload.SessionID)
+			return fmt.Errorf("failed to apply session resource limits: %w", err)
+		}
+
 		// 컨테이너 기반 세션 생성
 		session, err = h.sessionMgr.CreateContainerSession(
 			payload.ExecutionID,
@@ -104,6 +199,11 @@ func (h *Handler) HandleSessionStart(ctx context.Context, payload ws.ComputerSes
 		return fmt.Errorf("failed to launch browser: %w", err)
 	}
 
+	// 저장된 브라우저 프로필 복원 (초기 URL 이동 전에 쿠키가 설정되어야 함)
+	if payload.PersistProfile {
+		h.restoreProfile(ctx, session)
+	}
+
 	// 초기 URL이 지정되면 이동
 	if payload.URL != "" {
 		if err := h.security.ValidateURL(payload.URL); err != nil {
@@ -120,6 +220,78 @@ func (h *Handler) HandleSessionStart(ctx context.Context, payload ws.ComputerSes
 	return nil
 }
 
+// restoreProfile은 워크스페이스의 저장된 프로필을 세션 브라우저에 복원한다.
+// 복원 실패는 세션 시작을 막지 않으며, 종료 시 현재 상태로 다시 저장한다.
+func (h *Handler) restoreProfile(ctx context.Context, session *Session) {
+	if h.profiles == nil {
+		log.Printf("[computer-use] session %s requested persist_profile but profile persistence is not configured", session.ID)
+		return
+	}
+
+	profile, err := h.profiles.Load(h.profileWorkspaceID)
+	if err != nil {
+		log.Printf("[computer-use] failed to load browser profile for session %s: %v", session.ID, err)
+		profile = nil
+	}
+	h.sessionMgr.setPersistProfile(session.ID, true, profile)
+	if profile == nil {
+		return
+	}
+
+	backend, ok := session.Backend.(ProfileBackend)
+	if !ok {
+		return
+	}
+	if err := backend.ImportProfile(ctx, profile); err != nil {
+		log.Printf("[computer-use] failed to restore browser profile for session %s: %v", session.ID, err)
+		return
+	}
+	log.Printf("[computer-use] restored browser profile for session %s (%d cookies, %d origins)",
+		session.ID, len(profile.Cookies), len(profile.LocalStorage))
+}
+
+// saveProfile은 persist_profile 세션의 브라우저 상태를 저장한다.
+// 세션 매니저의 종료 콜백으로 호출되므로 세션 매니저 메서드를 호출하지 않는다.
+func (h *Handler) saveProfile(session *Session) {
+	if !session.PersistProfile || h.profiles == nil || !session.Backend.IsActive() {
+		return
+	}
+	backend, ok := session.Backend.(ProfileBackend)
+	if !ok {
+		return
+	}
+
+	profile, err := backend.ExportProfile(context.Background())
+	if err != nil {
+		log.Printf("[computer-use] failed to export browser profile for session %s: %v", session.ID, err)
+		return
+	}
+	mergeProfileLocalStorage(profile, session.restoredProfile)
+
+	if err := h.profiles.Save(h.profileWorkspaceID, profile); err != nil {
+		log.Printf("[computer-use] failed to save browser profile for session %s: %v", session.ID, err)
+		return
+	}
+	log.Printf("[computer-use] saved browser profile for session %s (%d cookies, %d origins)",
+		session.ID, len(profile.Cookies), len(profile.LocalStorage))
+}
+
+// mergeProfileLocalStorage는 이번 세션에서 방문하지 않은 origin의 localStorage를 이전 프로필에서 유지한다.
+func mergeProfileLocalStorage(profile, previous *BrowserProfile) {
+	if previous == nil {
+		return
+	}
+	for origin, items := range previous.LocalStorage {
+		if _, exists := profile.LocalStorage[origin]; exists {
+			continue
+		}
+		if profile.LocalStorage == nil {
+			profile.LocalStorage = make(map[string]map[string]string)
+		}
+		profile.LocalStorage[origin] = items
+	}
+}
+
 // HandleAction processes computer_action messages and returns the result.
 // REQ-M2-01: Route computer_action messages to appropriate actions.
 // REQ-M2-02: Check browser instance state before actions.
@@ -153,7 +325,11 @@ func (h *Handler) HandleAction(ctx context.Context, payload ws.ComputerActionPay
 
 	// 액션 실행기 생성 및 실행
 	executor := NewActionExecutor(session.Backend, h.security)
-	screenshot, err := executor.Execute(ctx, payload.Action, payload.Params)
+	executor.SetAnnotate(payload.Annotate)
+	actionResult, err := executor.Run(ctx, payload.Action, payload.Params)
+	if err == nil && len(actionResult.Captures) > 0 {
+		result.Artifacts, err = h.saveCaptures(payload.SessionID, payload.Action, actionResult.Captures)
+	}
 	if err != nil {
 		result.Success = false
 		result.Error = err.Error()
@@ -162,7 +338,7 @@ func (h *Handler) HandleAction(ctx context.Context, payload ws.ComputerActionPay
 	}
 
 	result.Success = true
-	result.Screenshot = screenshot
+	result.Screenshot = actionResult.Screenshot
 	result.DurationMs = time.Since(start).Milliseconds()
 
 	// SPEC-COMPUTER-USE-002: 컨테이너 모드일 때 컨테이너 ID 포함
@@ -176,6 +352,69 @@ func (h *Handler) HandleAction(ctx context.Context, payload ws.ComputerActionPay
 	return result, nil
 }
 
+// saveCaptures는 캡처 결과를 세션별 아티팩트 디렉터리에 저장한다.
+// 파일 이름은 액션, 캡처 시각, 구간 번호로 구성되어 같은 세션의 이전 캡처를 덮어쓰지 않는다.
+func (h *Handler) saveCaptures(sessionID, action string, captures []Capture) ([]ws.ComputerArtifact, error) {
+	dir := filepath.Join(h.artifactDir, sanitizeArtifactName(sessionID))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	stamp := strings.ReplaceAll(time.Now().UTC().Format("20060102T150405.000"), ".", "")
+	artifacts := make([]ws.ComputerArtifact, 0, len(captures))
+	for _, c := range captures {
+		name := action + "-" + stamp
+		if c.Page > 0 {
+			name += fmt.Sprintf("-p%03d", c.Page)
+		}
+		name += artifactExtension(c.MimeType)
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, c.Data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to save %s artifact: %w", c.Type, err)
+		}
+		artifacts = append(artifacts, ws.ComputerArtifact{
+			Name:       name,
+			Type:       c.Type,
+			Path:       path,
+			MimeType:   c.MimeType,
+			SizeBytes:  int64(len(c.Data)),
+			Page:       c.Page,
+			TotalPages: c.TotalPages,
+		})
+	}
+	log.Printf("[computer-use] saved %d %s artifact(s) for session %s in %s", len(artifacts), action, sessionID, dir)
+	return artifacts, nil
+}
+
+// artifactExtension은 MIME 타입에 맞는 파일 확장자를 반환한다.
+func artifactExtension(mimeType string) string {
+	switch mimeType {
+	case "application/pdf":
+		return ".pdf"
+	case "image/jpeg":
+		return ".jpg"
+	default:
+		return ".png"
+	}
+}
+
+// sanitizeArtifactName은 세션 ID를 디렉터리 이름으로 쓸 수 있게 정리한다.
+func sanitizeArtifactName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if name == "" {
+		return "session"
+	}
+	return name
+}
+
 // PoolStatus는 컨테이너 풀의 상태를 반환한다.
 // 풀이 설정되지 않았으면 nil을 반환한다.
 func (h *Handler) PoolStatus() *PoolStatus {
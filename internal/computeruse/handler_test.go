@@ -3,6 +3,8 @@ package computeruse
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -697,3 +699,47 @@ func TestHandler_HandleSessionStart_BlockedInitialURL(t *testing.T) {
 		t.Skip("Browser launched successfully; cannot test URL block path without real browser")
 	}
 }
+
+func TestHandler_HandleAction_CapturePDF_SavesArtifact(t *testing.T) {
+	dir := t.TempDir()
+	h := NewHandler(WithArtifactDir(dir))
+
+	session, err := h.SessionManager().CreateSession("exec-pdf", "sess/pdf", 1280, 720, true, "")
+	if err != nil {
+		t.Fatalf("CreateSession() error: %v", err)
+	}
+	backend := newMockCaptureBackend(1280, FullPageSegmentHeight+10)
+	backend.pdf = []byte("%PDF-1.7 test")
+	session.Backend = backend
+
+	result, _ := h.HandleAction(context.Background(), ws.ComputerActionPayload{
+		ExecutionID: "exec-pdf",
+		SessionID:   "sess/pdf",
+		Action:      "capture_pdf",
+	})
+	if !result.Success {
+		t.Fatalf("result.Success = false (error: %s)", result.Error)
+	}
+	if len(result.Artifacts) != 1 {
+		t.Fatalf("artifacts = %d; want 1", len(result.Artifacts))
+	}
+	artifact := result.Artifacts[0]
+	if filepath.Dir(artifact.Path) != filepath.Join(dir, "sess_pdf") || !strings.HasSuffix(artifact.Name, ".pdf") {
+		t.Errorf("artifact path = %s; want a .pdf file under the sanitized session directory", artifact.Path)
+	}
+	if data, err := os.ReadFile(artifact.Path); err != nil || string(data) != "%PDF-1.7 test" || artifact.SizeBytes != int64(len(data)) {
+		t.Errorf("saved artifact = %q (err %v), size %d", data, err, artifact.SizeBytes)
+	}
+
+	result, _ = h.HandleAction(context.Background(), ws.ComputerActionPayload{
+		ExecutionID: "exec-pdf",
+		SessionID:   "sess/pdf",
+		Action:      "full_page_screenshot",
+	})
+	if !result.Success || len(result.Artifacts) != 2 {
+		t.Fatalf("full_page_screenshot result = %+v", result)
+	}
+	if a := result.Artifacts[1]; a.Page != 2 || a.TotalPages != 2 || !strings.HasSuffix(a.Name, "-p002.png") {
+		t.Errorf("second segment artifact = %+v", a)
+	}
+}
@@ -0,0 +1,348 @@
+package computeruse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// 샌드박스 이미지/네트워크 기본값
+const (
+	// DefaultImageRepository는 Chromium 샌드박스 이미지 저장소이다.
+	DefaultImageRepository = "autopus/chromium-sandbox"
+	// DefaultImageTag는 버전이 고정되지 않았을 때 사용하는 태그이다.
+	DefaultImageTag = "latest"
+	// DefaultNetworkName은 샌드박스 전용 Docker 네트워크 이름이다.
+	DefaultNetworkName = "autopus-sandbox-net"
+)
+
+// 지원하는 샌드박스 이미지 플랫폼
+const (
+	PlatformAMD64 = "linux/amd64"
+	PlatformARM64 = "linux/arm64"
+)
+
+// ErrDigestMismatch는 로컬 이미지 digest가 고정된 digest와 다를 때 반환된다.
+var ErrDigestMismatch = errors.New("이미지 digest가 고정된 값과 일치하지 않습니다")
+
+// digestPattern은 허용하는 이미지 digest 형식이다.
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ImageSpec은 버전/digest/플랫폼이 고정된 샌드박스 이미지 참조이다.
+type ImageSpec struct {
+	Repository string // 예: "autopus/chromium-sandbox"
+	Tag        string // 예: "1.4.0", 비어 있으면 "latest"
+	Digest     string // 예: "sha256:...", 비어 있으면 검증하지 않음
+	Platform   string // "linux/amd64" 또는 "linux/arm64"
+}
+
+// NewImageSpec은 설정값으로 ImageSpec을 생성한다.
+// image는 "repo", "repo:tag", "repo:tag@sha256:..." 형식을 허용하며,
+// version과 digest가 지정되면 image에 포함된 태그/digest보다 우선한다.
+// platform이 비어 있거나 "auto"이면 현재 호스트 아키텍처를 사용한다.
+func NewImageSpec(image, version, digest, platform string) (ImageSpec, error) {
+	spec := parseImageRef(strings.TrimSpace(image))
+	if spec.Repository == "" {
+		spec.Repository = DefaultImageRepository
+	}
+	if v := strings.TrimSpace(version); v != "" {
+		spec.Tag = v
+	}
+	if spec.Tag == "" {
+		spec.Tag = DefaultImageTag
+	}
+	if d := strings.TrimSpace(digest); d != "" {
+		spec.Digest = d
+	}
+	if spec.Digest != "" && !digestPattern.MatchString(spec.Digest) {
+		return ImageSpec{}, fmt.Errorf("잘못된 이미지 digest 형식입니다 (sha256:<64 hex> 필요): %s", spec.Digest)
+	}
+
+	resolved, err := ResolvePlatform(platform, runtime.GOARCH)
+	if err != nil {
+		return ImageSpec{}, err
+	}
+	spec.Platform = resolved
+	return spec, nil
+}
+
+// parseImageRef는 이미지 참조를 저장소/태그/digest로 분리한다.
+func parseImageRef(ref string) ImageSpec {
+	var spec ImageSpec
+	if i := strings.Index(ref, "@"); i >= 0 {
+		spec.Digest = ref[i+1:]
+		ref = ref[:i]
+	}
+	// 레지스트리 포트(host:5000/repo)와 구분하기 위해 마지막 "/" 이후의 ":"만 태그로 본다.
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		spec.Tag = ref[i+1:]
+		ref = ref[:i]
+	}
+	spec.Repository = ref
+	return spec
+}
+
+// ResolvePlatform은 플랫폼 설정값을 Docker 플랫폼 문자열로 변환한다.
+// "", "auto"는 goarch 기준으로 선택하고, "amd64"/"arm64" 축약형도 허용한다.
+func ResolvePlatform(platform, goarch string) (string, error) {
+	p := strings.ToLower(strings.TrimSpace(platform))
+	if p == "" || p == "auto" {
+		p = goarch
+	}
+	switch strings.TrimPrefix(p, "linux/") {
+	case "amd64", "x86_64":
+		return PlatformAMD64, nil
+	case "arm64", "aarch64":
+		return PlatformARM64, nil
+	default:
+		return "", fmt.Errorf("지원하지 않는 샌드박스 플랫폼입니다: %s (linux/amd64, linux/arm64만 지원)", platform)
+	}
+}
+
+// TaggedReference는 digest를 제외한 "repo:tag" 참조를 반환한다.
+func (s ImageSpec) TaggedReference() string {
+	return s.Repository + ":" + s.Tag
+}
+
+// Reference는 컨테이너 생성과 풀에 사용할 참조를 반환한다.
+// digest가 고정되어 있으면 "repo:tag@sha256:..." 형식이다.
+func (s ImageSpec) Reference() string {
+	if s.Digest != "" {
+		return s.TaggedReference() + "@" + s.Digest
+	}
+	return s.TaggedReference()
+}
+
+// IsPinned는 이미지가 특정 버전 또는 digest로 고정되어 있는지 반환한다.
+func (s ImageSpec) IsPinned() bool {
+	return s.Digest != "" || s.Tag != DefaultImageTag
+}
+
+// SandboxImage는 로컬에 존재하는 샌드박스 이미지 정보이다.
+type SandboxImage struct {
+	ID      string
+	Tag     string
+	Digest  string
+	Created string
+	Size    string
+}
+
+// PruneResult는 Prune 실행 결과이다.
+type PruneResult struct {
+	RemovedContainers []string
+	RemovedImages     []string
+	NetworkRemoved    bool
+}
+
+// dockerRunner는 docker 명령을 실행하고 stdout을 반환한다.
+type dockerRunner func(ctx context.Context, args ...string) (string, error)
+
+// ImageManager는 샌드박스 이미지와 전용 네트워크의 설치/업데이트/정리를 담당한다.
+type ImageManager struct {
+	run dockerRunner
+}
+
+// NewImageManager는 CLIDockerClient로 docker 명령을 실행하는 ImageManager를 생성한다.
+func NewImageManager(client *CLIDockerClient) *ImageManager {
+	if client == nil {
+		client = NewCLIDockerClient("")
+	}
+	return &ImageManager{run: client.runCmd}
+}
+
+// Pull은 지정된 플랫폼으로 이미지를 풀한다.
+// digest가 고정된 경우 Docker가 풀 과정에서 내용을 검증하며, 이후 로컬 digest를 재확인한다.
+func (m *ImageManager) Pull(ctx context.Context, spec ImageSpec) error {
+	args := []string{"pull"}
+	if spec.Platform != "" {
+		args = append(args, "--platform", spec.Platform)
+	}
+	args = append(args, spec.Reference())
+
+	log.Printf("[computer-use] 이미지 풀 시작: %s (%s)", spec.Reference(), spec.Platform)
+	if _, err := m.run(ctx, args...); err != nil {
+		return fmt.Errorf("이미지 풀 실패 (ref=%s): %w", spec.Reference(), err)
+	}
+	if spec.Digest != "" {
+		// digest로 풀한 이미지에 태그를 붙여 TaggedReference로도 조회할 수 있게 한다.
+		if _, err := m.run(ctx, "tag", spec.Reference(), spec.TaggedReference()); err != nil {
+			return fmt.Errorf("이미지 태그 지정 실패: %w", err)
+		}
+	}
+	return m.VerifyDigest(ctx, spec)
+}
+
+// LocalDigest는 로컬 이미지의 저장소 digest를 반환한다.
+// 로컬 빌드 이미지처럼 저장소 digest가 없으면 빈 문자열을 반환한다.
+func (m *ImageManager) LocalDigest(ctx context.Context, spec ImageSpec) (string, error) {
+	out, err := m.run(ctx, "image", "inspect", "--format", `{{join .RepoDigests "\n"}}`, spec.TaggedReference())
+	if err != nil {
+		return "", fmt.Errorf("이미지 조회 실패 (ref=%s): %w", spec.TaggedReference(), err)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		repo, digest, ok := strings.Cut(strings.TrimSpace(line), "@")
+		if ok && repo == spec.Repository {
+			return digest, nil
+		}
+	}
+	return "", nil
+}
+
+// VerifyDigest는 로컬 이미지가 고정된 digest와 일치하는지 확인한다.
+// digest가 고정되지 않았으면 항상 nil을 반환한다.
+func (m *ImageManager) VerifyDigest(ctx context.Context, spec ImageSpec) error {
+	if spec.Digest == "" {
+		return nil
+	}
+	local, err := m.LocalDigest(ctx, spec)
+	if err != nil {
+		return err
+	}
+	if local != spec.Digest {
+		return fmt.Errorf("%w: 기대값=%s, 로컬=%s", ErrDigestMismatch, spec.Digest, valueOrNone(local))
+	}
+	return nil
+}
+
+// RemoteDigest는 레지스트리에 게시된 태그의 매니페스트 digest를 조회한다.
+// docker buildx가 필요하다.
+func (m *ImageManager) RemoteDigest(ctx context.Context, spec ImageSpec) (string, error) {
+	out, err := m.run(ctx, "buildx", "imagetools", "inspect", "--format", "{{.Manifest.Digest}}", spec.TaggedReference())
+	if err != nil {
+		return "", fmt.Errorf("원격 이미지 조회 실패 (ref=%s): %w", spec.TaggedReference(), err)
+	}
+	digest := strings.TrimSpace(out)
+	if !digestPattern.MatchString(digest) {
+		return "", fmt.Errorf("원격 digest 형식이 올바르지 않습니다: %q", digest)
+	}
+	return digest, nil
+}
+
+// CheckUpdate는 원격 태그가 로컬 이미지와 다른 digest를 가리키는지 확인한다.
+// digest로 고정된 이미지는 업데이트 대상이 아니므로 항상 false를 반환한다.
+func (m *ImageManager) CheckUpdate(ctx context.Context, spec ImageSpec) (bool, string, error) {
+	if spec.Digest != "" {
+		return false, "", nil
+	}
+	remote, err := m.RemoteDigest(ctx, spec)
+	if err != nil {
+		return false, "", err
+	}
+	local, err := m.LocalDigest(ctx, spec)
+	if err != nil {
+		// 로컬 이미지가 없으면 설치가 필요하다.
+		return true, remote, nil
+	}
+	return local != remote, remote, nil
+}
+
+// ListImages는 로컬에 있는 샌드박스 저장소 이미지 목록을 반환한다.
+func (m *ImageManager) ListImages(ctx context.Context, repository string) ([]SandboxImage, error) {
+	out, err := m.run(ctx, "images", "--no-trunc", "--format", "{{.ID}}|{{.Tag}}|{{.Digest}}|{{.CreatedSince}}|{{.Size}}", repository)
+	if err != nil {
+		return nil, fmt.Errorf("이미지 목록 조회 실패: %w", err)
+	}
+	return parseImageList(out), nil
+}
+
+// parseImageList는 "ID|Tag|Digest|Created|Size" 형식의 docker images 출력을 파싱한다.
+func parseImageList(out string) []SandboxImage {
+	var images []SandboxImage
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "|", 5)
+		if len(parts) != 5 || parts[0] == "" {
+			continue
+		}
+		images = append(images, SandboxImage{
+			ID:      parts[0],
+			Tag:     parts[1],
+			Digest:  parts[2],
+			Created: parts[3],
+			Size:    parts[4],
+		})
+	}
+	return images
+}
+
+// imageID는 참조가 가리키는 로컬 이미지 ID를 반환한다.
+func (m *ImageManager) imageID(ctx context.Context, ref string) (string, error) {
+	return m.run(ctx, "image", "inspect", "--format", "{{.Id}}", ref)
+}
+
+// Prune은 종료된 샌드박스 컨테이너와 현재 설정에서 사용하지 않는 샌드박스 이미지를 삭제한다.
+// removeNetwork가 true이면 연결된 컨테이너가 없는 전용 네트워크도 삭제한다.
+func (m *ImageManager) Prune(ctx context.Context, spec ImageSpec, network string, removeNetwork bool) (*PruneResult, error) {
+	result := &PruneResult{}
+
+	// 1. 종료된 샌드박스 컨테이너
+	images, err := m.ListImages(ctx, spec.Repository)
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range images {
+		out, psErr := m.run(ctx, "ps", "-a", "-q", "--filter", "ancestor="+img.ID, "--filter", "status=exited", "--filter", "status=created")
+		if psErr != nil {
+			continue
+		}
+		for _, id := range strings.Fields(out) {
+			if _, rmErr := m.run(ctx, "rm", id); rmErr == nil {
+				result.RemovedContainers = append(result.RemovedContainers, id)
+			}
+		}
+	}
+
+	// 2. 현재 설정이 사용하는 이미지를 제외한 샌드박스 이미지
+	currentID, _ := m.imageID(ctx, spec.TaggedReference())
+	for _, img := range images {
+		if img.ID == currentID {
+			continue
+		}
+		if _, rmiErr := m.run(ctx, "rmi", img.ID); rmiErr != nil {
+			// 실행 중인 컨테이너가 사용 중인 이미지는 남겨 둔다.
+			log.Printf("[computer-use] 이미지 삭제 건너뜀: id=%s, err=%v", shortImageID(img.ID), rmiErr)
+			continue
+		}
+		result.RemovedImages = append(result.RemovedImages, img.ID)
+	}
+
+	// 3. 전용 네트워크
+	if removeNetwork && network != "" {
+		count, inspectErr := m.run(ctx, "network", "inspect", "--format", "{{len .Containers}}", network)
+		if inspectErr == nil && strings.TrimSpace(count) == "0" {
+			if _, rmErr := m.run(ctx, "network", "rm", network); rmErr == nil {
+				result.NetworkRemoved = true
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// EnsureNetwork는 샌드박스 전용 네트워크가 없으면 생성한다. 새로 생성했으면 true를 반환한다.
+func (m *ImageManager) EnsureNetwork(ctx context.Context, network string) (bool, error) {
+	if _, err := m.run(ctx, "network", "inspect", network); err == nil {
+		return false, nil
+	}
+	if _, err := m.run(ctx, "network", "create", network); err != nil {
+		return false, fmt.Errorf("네트워크 생성 실패 (name=%s): %w", network, err)
+	}
+	return true, nil
+}
+
+// shortImageID는 "sha256:" 접두사를 제거한 12자리 이미지 ID를 반환한다.
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	return id[:min(12, len(id))]
+}
+
+// valueOrNone은 빈 문자열을 "(없음)"으로 표시한다.
+func valueOrNone(s string) string {
+	if s == "" {
+		return "(없음)"
+	}
+	return s
+}
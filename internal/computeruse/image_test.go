@@ -0,0 +1,296 @@
+package computeruse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// fakeDocker는 docker 명령 호출을 기록하고 미리 정의된 응답을 반환한다.
+type fakeDocker struct {
+	calls     [][]string
+	responses map[string]string
+	failures  map[string]error
+}
+
+func newFakeDocker() *fakeDocker {
+	return &fakeDocker{responses: map[string]string{}, failures: map[string]error{}}
+}
+
+func (f *fakeDocker) run(_ context.Context, args ...string) (string, error) {
+	f.calls = append(f.calls, args)
+	key := strings.Join(args, " ")
+	for prefix, err := range f.failures {
+		if strings.HasPrefix(key, prefix) {
+			return "", err
+		}
+	}
+	for prefix, out := range f.responses {
+		if strings.HasPrefix(key, prefix) {
+			return out, nil
+		}
+	}
+	return "", nil
+}
+
+func (f *fakeDocker) called(prefix string) bool {
+	for _, call := range f.calls {
+		if strings.HasPrefix(strings.Join(call, " "), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// --- ImageSpec 테스트 ---
+
+func TestNewImageSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   string
+		version string
+		digest  string
+		want    string
+	}{
+		{"기본값", "", "", "", "autopus/chromium-sandbox:latest"},
+		{"태그 포함 이미지", "autopus/chromium-sandbox:1.2.0", "", "", "autopus/chromium-sandbox:1.2.0"},
+		{"버전 우선", "autopus/chromium-sandbox:1.2.0", "1.4.0", "", "autopus/chromium-sandbox:1.4.0"},
+		{"레지스트리 포트", "localhost:5000/sandbox", "", "", "localhost:5000/sandbox:latest"},
+		{"digest 고정", "autopus/chromium-sandbox", "1.4.0", testDigest, "autopus/chromium-sandbox:1.4.0@" + testDigest},
+		{"이미지에 포함된 digest", "autopus/chromium-sandbox:1.4.0@" + testDigest, "", "", "autopus/chromium-sandbox:1.4.0@" + testDigest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := NewImageSpec(tt.image, tt.version, tt.digest, "amd64")
+			if err != nil {
+				t.Fatalf("NewImageSpec() error = %v", err)
+			}
+			if got := spec.Reference(); got != tt.want {
+				t.Errorf("Reference() = %q; want %q", got, tt.want)
+			}
+			if spec.Platform != PlatformAMD64 {
+				t.Errorf("Platform = %q; want %q", spec.Platform, PlatformAMD64)
+			}
+		})
+	}
+}
+
+func TestNewImageSpec_InvalidDigest(t *testing.T) {
+	if _, err := NewImageSpec("", "", "sha256:abc", "auto"); err == nil {
+		t.Fatal("잘못된 digest는 에러여야 한다")
+	}
+}
+
+func TestImageSpec_IsPinned(t *testing.T) {
+	latest, _ := NewImageSpec("", "", "", "amd64")
+	versioned, _ := NewImageSpec("", "1.4.0", "", "amd64")
+	digested, _ := NewImageSpec("", "", testDigest, "amd64")
+
+	if latest.IsPinned() {
+		t.Error("latest 이미지는 고정되지 않아야 한다")
+	}
+	if !versioned.IsPinned() || !digested.IsPinned() {
+		t.Error("버전 또는 digest가 지정되면 고정되어야 한다")
+	}
+}
+
+func TestResolvePlatform(t *testing.T) {
+	tests := []struct {
+		platform string
+		goarch   string
+		want     string
+		wantErr  bool
+	}{
+		{"", "amd64", PlatformAMD64, false},
+		{"auto", "arm64", PlatformARM64, false},
+		{"linux/arm64", "amd64", PlatformARM64, false},
+		{"x86_64", "arm64", PlatformAMD64, false},
+		{"aarch64", "amd64", PlatformARM64, false},
+		{"auto", "riscv64", "", true},
+		{"windows/amd64", "amd64", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolvePlatform(tt.platform, tt.goarch)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolvePlatform(%q, %q) error = %v; wantErr %v", tt.platform, tt.goarch, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolvePlatform(%q, %q) = %q; want %q", tt.platform, tt.goarch, got, tt.want)
+		}
+	}
+}
+
+// --- ImageManager 테스트 ---
+
+func TestImageManager_Pull_WithDigest(t *testing.T) {
+	fake := newFakeDocker()
+	fake.responses["image inspect"] = "autopus/chromium-sandbox@" + testDigest
+	mgr := &ImageManager{run: fake.run}
+
+	spec, _ := NewImageSpec("", "1.4.0", testDigest, "arm64")
+	if err := mgr.Pull(context.Background(), spec); err != nil {
+		t.Fatalf("Pull() error = %v", err)
+	}
+
+	wantPull := []string{"pull", "--platform", "linux/arm64", "autopus/chromium-sandbox:1.4.0@" + testDigest}
+	if !reflect.DeepEqual(fake.calls[0], wantPull) {
+		t.Errorf("pull 인자 = %v; want %v", fake.calls[0], wantPull)
+	}
+	if !fake.called("tag autopus/chromium-sandbox:1.4.0@" + testDigest + " autopus/chromium-sandbox:1.4.0") {
+		t.Error("digest로 풀한 이미지에 태그를 지정해야 한다")
+	}
+}
+
+func TestImageManager_VerifyDigest_Mismatch(t *testing.T) {
+	fake := newFakeDocker()
+	fake.responses["image inspect"] = "autopus/chromium-sandbox@sha256:" + strings.Repeat("f", 64)
+	mgr := &ImageManager{run: fake.run}
+
+	spec, _ := NewImageSpec("", "1.4.0", testDigest, "amd64")
+	err := mgr.VerifyDigest(context.Background(), spec)
+	if !errors.Is(err, ErrDigestMismatch) {
+		t.Fatalf("VerifyDigest() error = %v; want ErrDigestMismatch", err)
+	}
+}
+
+func TestImageManager_VerifyDigest_NotPinned(t *testing.T) {
+	fake := newFakeDocker()
+	mgr := &ImageManager{run: fake.run}
+
+	spec, _ := NewImageSpec("", "", "", "amd64")
+	if err := mgr.VerifyDigest(context.Background(), spec); err != nil {
+		t.Fatalf("VerifyDigest() error = %v", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("digest가 없으면 docker를 호출하지 않아야 한다: %v", fake.calls)
+	}
+}
+
+func TestImageManager_CheckUpdate(t *testing.T) {
+	remote := "sha256:" + strings.Repeat("a", 64)
+	spec, _ := NewImageSpec("", "", "", "amd64")
+
+	t.Run("새 버전 있음", func(t *testing.T) {
+		fake := newFakeDocker()
+		fake.responses["buildx imagetools inspect"] = remote + "\n"
+		fake.responses["image inspect"] = "autopus/chromium-sandbox@" + testDigest
+		mgr := &ImageManager{run: fake.run}
+
+		hasUpdate, digest, err := mgr.CheckUpdate(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("CheckUpdate() error = %v", err)
+		}
+		if !hasUpdate || digest != remote {
+			t.Errorf("CheckUpdate() = (%v, %q); want (true, %q)", hasUpdate, digest, remote)
+		}
+	})
+
+	t.Run("최신", func(t *testing.T) {
+		fake := newFakeDocker()
+		fake.responses["buildx imagetools inspect"] = remote
+		fake.responses["image inspect"] = "autopus/chromium-sandbox@" + remote
+		mgr := &ImageManager{run: fake.run}
+
+		hasUpdate, _, err := mgr.CheckUpdate(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("CheckUpdate() error = %v", err)
+		}
+		if hasUpdate {
+			t.Error("digest가 같으면 업데이트가 없어야 한다")
+		}
+	})
+
+	t.Run("digest 고정", func(t *testing.T) {
+		fake := newFakeDocker()
+		mgr := &ImageManager{run: fake.run}
+		pinned, _ := NewImageSpec("", "", testDigest, "amd64")
+
+		hasUpdate, _, err := mgr.CheckUpdate(context.Background(), pinned)
+		if err != nil || hasUpdate {
+			t.Errorf("CheckUpdate() = (%v, %v); want (false, nil)", hasUpdate, err)
+		}
+		if len(fake.calls) != 0 {
+			t.Errorf("digest 고정 시 원격 조회를 하지 않아야 한다: %v", fake.calls)
+		}
+	})
+}
+
+func TestImageManager_Prune(t *testing.T) {
+	fake := newFakeDocker()
+	fake.responses["images"] = "sha256:current|latest|<none>|2 days ago|1.2GB\nsha256:old|1.3.0|<none>|3 weeks ago|1.1GB\n"
+	fake.responses["ps -a -q --filter ancestor=sha256:old"] = "c1\nc2"
+	fake.responses["image inspect --format {{.Id}}"] = "sha256:current"
+	fake.responses["network inspect"] = "0"
+	mgr := &ImageManager{run: fake.run}
+
+	spec, _ := NewImageSpec("", "", "", "amd64")
+	result, err := mgr.Prune(context.Background(), spec, DefaultNetworkName, true)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(result.RemovedContainers, []string{"c1", "c2"}) {
+		t.Errorf("RemovedContainers = %v", result.RemovedContainers)
+	}
+	if !reflect.DeepEqual(result.RemovedImages, []string{"sha256:old"}) {
+		t.Errorf("RemovedImages = %v; 현재 이미지는 유지되어야 한다", result.RemovedImages)
+	}
+	if !result.NetworkRemoved {
+		t.Error("사용 중이 아닌 네트워크는 삭제되어야 한다")
+	}
+}
+
+func TestImageManager_Prune_ImageInUse(t *testing.T) {
+	fake := newFakeDocker()
+	fake.responses["images"] = "sha256:current|latest|<none>|2 days ago|1.2GB\nsha256:old|1.3.0|<none>|3 weeks ago|1.1GB\n"
+	fake.responses["image inspect --format {{.Id}}"] = "sha256:current"
+	fake.failures["rmi sha256:old"] = fmt.Errorf("image is being used by running container")
+	fake.responses["network inspect"] = "1"
+	mgr := &ImageManager{run: fake.run}
+
+	spec, _ := NewImageSpec("", "", "", "amd64")
+	result, err := mgr.Prune(context.Background(), spec, DefaultNetworkName, true)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if len(result.RemovedImages) != 0 {
+		t.Errorf("사용 중인 이미지는 삭제되지 않아야 한다: %v", result.RemovedImages)
+	}
+	if result.NetworkRemoved || fake.called("network rm") {
+		t.Error("컨테이너가 연결된 네트워크는 삭제되지 않아야 한다")
+	}
+}
+
+func TestImageManager_EnsureNetwork(t *testing.T) {
+	fake := newFakeDocker()
+	fake.failures["network inspect"] = fmt.Errorf("no such network")
+	mgr := &ImageManager{run: fake.run}
+
+	created, err := mgr.EnsureNetwork(context.Background(), DefaultNetworkName)
+	if err != nil || !created {
+		t.Fatalf("EnsureNetwork() = (%v, %v); want (true, nil)", created, err)
+	}
+	if !fake.called("network create " + DefaultNetworkName) {
+		t.Error("네트워크가 없으면 생성해야 한다")
+	}
+}
+
+func TestParseImageList(t *testing.T) {
+	out := "sha256:abc|1.4.0|" + testDigest + "|2 days ago|1.2GB\n\ninvalid line\n"
+	images := parseImageList(out)
+	if len(images) != 1 {
+		t.Fatalf("len(images) = %d; want 1", len(images))
+	}
+	want := SandboxImage{ID: "sha256:abc", Tag: "1.4.0", Digest: testDigest, Created: "2 days ago", Size: "1.2GB"}
+	if images[0] != want {
+		t.Errorf("images[0] = %+v; want %+v", images[0], want)
+	}
+}
@@ -61,8 +61,9 @@ type ContainerPool struct {
 	warmPool   []warmContainer           // 대기 중인 컨테이너 목록
 	activePool map[string]activeContainer // sessionID -> 활성 컨테이너
 
-	shutdown bool
-	mu       sync.Mutex
+	shutdown  bool
+	suspended bool // 유휴 절전 중에는 워밍 풀을 보충하지 않는다
+	mu        sync.Mutex
 }
 
 // NewContainerPool은 ContainerManager와 설정으로 새 ContainerPool을 생성한다.
@@ -178,6 +179,18 @@ func (p *ContainerPool) Release(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// ApplySessionResources는 세션에 할당된 컨테이너에 세션별 메모리/CPU 제한을 적용한다.
+func (p *ContainerPool) ApplySessionResources(ctx context.Context, sessionID string, limits SessionResources) error {
+	p.mu.Lock()
+	active, exists := p.activePool[sessionID]
+	p.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("세션 %s에 할당된 컨테이너가 없습니다", sessionID)
+	}
+	return p.manager.UpdateResources(ctx, active.info.ID, limits)
+}
+
 // StartReplenisher는 워밍 풀을 유지하는 백그라운드 고루틴을 시작한다.
 // 컨텍스트가 취소되면 종료된다.
 // 연속 실패 시 지수 백오프를 적용한다:
@@ -233,7 +246,7 @@ func (p *ContainerPool) StartReplenisher(ctx context.Context) {
 // 성공 시 true, 컨테이너 생성 실패 시 false를 반환한다.
 func (p *ContainerPool) replenishWarmPool(ctx context.Context) bool {
 	p.mu.Lock()
-	if p.shutdown {
+	if p.shutdown || p.suspended {
 		p.mu.Unlock()
 		return true
 	}
@@ -337,6 +350,38 @@ func (p *ContainerPool) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// Suspend는 워밍 컨테이너를 모두 삭제하고 Resume 전까지 워밍 풀 보충을 멈춘다.
+// 세션에 할당된 활성 컨테이너는 건드리지 않는다. 삭제한 컨테이너 수를 반환한다.
+func (p *ContainerPool) Suspend(ctx context.Context) int {
+	p.mu.Lock()
+	if p.shutdown || p.suspended {
+		p.mu.Unlock()
+		return 0
+	}
+	p.suspended = true
+	warm := p.warmPool
+	p.warmPool = nil
+	p.mu.Unlock()
+
+	for _, w := range warm {
+		if err := p.manager.Remove(ctx, w.info.ID); err != nil {
+			log.Printf("[computer-use] 절전 중 워밍 컨테이너 삭제 실패: container=%s, err=%v", w.info.ID[:min(12, len(w.info.ID))], err)
+		}
+	}
+	if len(warm) > 0 {
+		log.Printf("[computer-use] 절전 모드: 워밍 컨테이너 %d개 정리", len(warm))
+	}
+	return len(warm)
+}
+
+// Resume은 Suspend로 멈춘 워밍 풀 보충을 다시 허용한다.
+// 워밍 풀은 다음 보충 주기에 채워진다.
+func (p *ContainerPool) Resume() {
+	p.mu.Lock()
+	p.suspended = false
+	p.mu.Unlock()
+}
+
 // CleanupOrphaned는 시작 시 이전 실행에서 남은 orphaned 컨테이너를 정리한다.
 // containerIDs는 정리할 컨테이너 ID 목록이다.
 func (p *ContainerPool) CleanupOrphaned(ctx context.Context, containerIDs []string) error {
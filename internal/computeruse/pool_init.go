@@ -14,6 +14,9 @@ type ComputerUseConfigInput struct {
 	MaxContainers      int    // 최대 동시 컨테이너 수
 	WarmPoolSize       int    // 웜 풀 크기
 	Image              string // Docker 이미지 이름
+	ImageVersion       string // 고정할 이미지 버전 태그 (비어 있으면 Image의 태그 사용)
+	ImageDigest        string // 고정할 이미지 digest (sha256:..., 비어 있으면 검증 안 함)
+	Platform           string // 이미지 플랫폼 ("auto", "linux/amd64", "linux/arm64")
 	ContainerMemory    string // 메모리 제한 (예: "512m", "1g")
 	ContainerCPU       string // CPU 할당량 (예: "1.0", "0.5")
 	IdleTimeout        string // 유휴 타임아웃 (예: "5m", "30s")
@@ -40,8 +43,17 @@ func InitContainerPool(ctx context.Context, cuCfg ComputerUseConfigInput) (*Cont
 		StartTimeout: defaults.StartTimeout,
 	}
 
-	if cuCfg.Image != "" {
-		containerCfg.Image = cuCfg.Image
+	if cuCfg.Image != "" || cuCfg.ImageVersion != "" || cuCfg.ImageDigest != "" || cuCfg.Platform != "" {
+		image := cuCfg.Image
+		if image == "" {
+			image = defaults.Image
+		}
+		spec, err := NewImageSpec(image, cuCfg.ImageVersion, cuCfg.ImageDigest, cuCfg.Platform)
+		if err != nil {
+			return nil, fmt.Errorf("샌드박스 이미지 설정 오류: %w", err)
+		}
+		containerCfg.Image = spec.Reference()
+		containerCfg.Platform = spec.Platform
 	}
 	if cuCfg.Network != "" {
 		containerCfg.Network = cuCfg.Network
@@ -427,6 +427,53 @@ func TestContainerPool_Shutdown_WithRemoveErrors(t *testing.T) {
 	}
 }
 
+// --- Suspend/Resume 테스트 ---
+
+func TestContainerPool_SuspendResume(t *testing.T) {
+	pool, mock := newTestPool(t, PoolConfig{
+		MaxContainers: 5,
+		WarmPoolSize:  2,
+		IdleTimeout:   time.Minute,
+	})
+
+	ctx := context.Background()
+	pool.replenishWarmPool(ctx)
+	pool.mu.Lock()
+	pool.activePool["sess-1"] = activeContainer{
+		info:       &ContainerInfo{ID: "active-1"},
+		sessionID:  "sess-1",
+		assignedAt: time.Now(),
+	}
+	pool.mu.Unlock()
+	mock.removeCalled = 0
+	mock.createCalled = 0
+
+	if removed := pool.Suspend(ctx); removed != 2 {
+		t.Errorf("Suspend() = %d; want 2", removed)
+	}
+	if mock.removeCalled != 2 {
+		t.Errorf("ContainerRemove 호출 횟수 = %d; want 2", mock.removeCalled)
+	}
+	if pool.WarmCount() != 0 || pool.ActiveCount() != 1 {
+		t.Errorf("Suspend 후 warm=%d, active=%d; want 0, 1 (활성 컨테이너 유지)", pool.WarmCount(), pool.ActiveCount())
+	}
+	if removed := pool.Suspend(ctx); removed != 0 {
+		t.Errorf("두 번째 Suspend() = %d; want 0", removed)
+	}
+
+	// 절전 중에는 보충하지 않는다
+	pool.replenishWarmPool(ctx)
+	if mock.createCalled != 0 || pool.WarmCount() != 0 {
+		t.Errorf("절전 중 보충: create=%d, warm=%d; want 0, 0", mock.createCalled, pool.WarmCount())
+	}
+
+	pool.Resume()
+	pool.replenishWarmPool(ctx)
+	if pool.WarmCount() != 2 {
+		t.Errorf("Resume 후 WarmCount = %d; want 2", pool.WarmCount())
+	}
+}
+
 // --- Status 테스트 ---
 
 func TestContainerPool_Status(t *testing.T) {
@@ -1149,3 +1196,43 @@ func TestContainerPool_AcquireRelease_Cycle(t *testing.T) {
 		t.Errorf("ActiveCount after release = %d; want 0", pool.ActiveCount())
 	}
 }
+
+// --- ApplySessionResources 테스트 ---
+
+func TestContainerPool_ApplySessionResources(t *testing.T) {
+	pool, mock := newTestPool(t, DefaultPoolConfig())
+	ctx := context.Background()
+
+	if err := pool.ApplySessionResources(ctx, "sess-1", SessionResources{MemoryLimit: 1}); err == nil {
+		t.Error("ApplySessionResources() = nil; want error for unassigned session")
+	}
+
+	if _, err := pool.Acquire(ctx, "sess-1"); err != nil {
+		t.Fatalf("Acquire() = error %v", err)
+	}
+
+	// 제한이 없으면 docker update를 호출하지 않는다.
+	if err := pool.ApplySessionResources(ctx, "sess-1", SessionResources{}); err != nil {
+		t.Fatalf("ApplySessionResources() = error %v", err)
+	}
+	if mock.updateCalled != 0 {
+		t.Errorf("updateCalled = %d; want 0", mock.updateCalled)
+	}
+
+	limits := SessionResources{MemoryLimit: 256 * 1024 * 1024, CPUQuota: 50000}
+	if err := pool.ApplySessionResources(ctx, "sess-1", limits); err != nil {
+		t.Fatalf("ApplySessionResources() = error %v", err)
+	}
+	if mock.updateCalled != 1 {
+		t.Errorf("updateCalled = %d; want 1", mock.updateCalled)
+	}
+	if mock.lastUpdateMemory != limits.MemoryLimit || mock.lastUpdateCPU != limits.CPUQuota {
+		t.Errorf("update = (%d, %d); want (%d, %d)",
+			mock.lastUpdateMemory, mock.lastUpdateCPU, limits.MemoryLimit, limits.CPUQuota)
+	}
+
+	mock.updateErr = fmt.Errorf("docker update failed")
+	if err := pool.ApplySessionResources(ctx, "sess-1", limits); err == nil {
+		t.Error("ApplySessionResources() = nil; want error when docker update fails")
+	}
+}
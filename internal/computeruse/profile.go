@@ -0,0 +1,242 @@
+package computeruse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/domstorage"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/storage"
+	"github.com/chromedp/chromedp"
+)
+
+// profileIOTimeout은 프로필 내보내기/가져오기 CDP 호출의 최대 실행 시간이다.
+const profileIOTimeout = 10 * time.Second
+
+// BrowserProfile은 세션 간 유지되는 브라우저 상태(쿠키, localStorage)이다.
+type BrowserProfile struct {
+	Cookies []ProfileCookie `json:"cookies,omitempty"`
+	// LocalStorage는 origin별 localStorage 항목이다 (origin -> key -> value).
+	LocalStorage map[string]map[string]string `json:"local_storage,omitempty"`
+	SavedAt      time.Time                    `json:"saved_at"`
+}
+
+// ProfileCookie는 프로필에 저장되는 쿠키이다.
+// CDP 타입 변경이 저장 형식에 영향을 주지 않도록 필요한 필드만 별도로 정의한다.
+type ProfileCookie struct {
+	Name     string  `json:"name"`
+	Value    string  `json:"value"`
+	Domain   string  `json:"domain"`
+	Path     string  `json:"path"`
+	Expires  float64 `json:"expires,omitempty"` // UNIX 초, 0이면 세션 쿠키
+	HTTPOnly bool    `json:"http_only,omitempty"`
+	Secure   bool    `json:"secure,omitempty"`
+	SameSite string  `json:"same_site,omitempty"`
+}
+
+// ProfileBackend는 브라우저 프로필 내보내기/가져오기를 지원하는 BrowserBackend이다.
+// 로컬 chromedp와 컨테이너 백엔드 모두 구현한다.
+type ProfileBackend interface {
+	// ExportProfile은 현재 쿠키와 방문한 origin의 localStorage를 내보낸다.
+	ExportProfile(ctx context.Context) (*BrowserProfile, error)
+	// ImportProfile은 저장된 프로필을 브라우저에 복원한다. Launch 이후, 첫 이동 전에 호출한다.
+	ImportProfile(ctx context.Context, profile *BrowserProfile) error
+}
+
+// 컴파일 타임 인터페이스 구현 확인
+var (
+	_ ProfileBackend = (*BrowserManager)(nil)
+	_ ProfileBackend = (*ContainerBrowserBackend)(nil)
+)
+
+// originTracker는 세션 중 방문한 origin을 기록한다.
+// localStorage는 origin 단위로 저장되므로 내보낼 대상을 알기 위해 사용한다.
+type originTracker struct {
+	mu      sync.Mutex
+	origins map[string]struct{}
+}
+
+// add는 rawURL의 origin을 기록한다. http(s)가 아니면 무시한다.
+func (t *originTracker) add(rawURL string) {
+	origin := originOf(rawURL)
+	if origin == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.origins == nil {
+		t.origins = make(map[string]struct{})
+	}
+	t.origins[origin] = struct{}{}
+}
+
+// list는 기록된 origin을 정렬하여 반환한다.
+func (t *originTracker) list() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	origins := make([]string, 0, len(t.origins))
+	for origin := range t.origins {
+		origins = append(origins, origin)
+	}
+	sort.Strings(origins)
+	return origins
+}
+
+// originOf는 URL의 origin(scheme://host[:port])을 반환한다. http(s)가 아니면 빈 문자열이다.
+func originOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// exportBrowserProfile은 taskCtx의 브라우저에서 쿠키와 origin별 localStorage를 읽어온다.
+// 개별 origin의 localStorage 조회 실패는 무시한다.
+func exportBrowserProfile(taskCtx context.Context, origins []string) (*BrowserProfile, error) {
+	ctx, cancel := context.WithTimeout(taskCtx, profileIOTimeout)
+	defer cancel()
+
+	var cookies []*network.Cookie
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = storage.GetCookies().Do(ctx)
+		return err
+	})); err != nil {
+		return nil, fmt.Errorf("failed to read cookies: %w", err)
+	}
+
+	profile := &BrowserProfile{SavedAt: time.Now()}
+	for _, c := range cookies {
+		pc := ProfileCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			HTTPOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: string(c.SameSite),
+		}
+		if !c.Session {
+			pc.Expires = c.Expires
+		}
+		profile.Cookies = append(profile.Cookies, pc)
+	}
+
+	if len(origins) > 0 {
+		_ = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			return domstorage.Enable().Do(ctx)
+		}))
+	}
+	for _, origin := range origins {
+		var entries []domstorage.Item
+		err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			entries, err = domstorage.GetDOMStorageItems(&domstorage.StorageID{
+				SecurityOrigin: origin,
+				IsLocalStorage: true,
+			}).Do(ctx)
+			return err
+		}))
+		if err != nil {
+			log.Printf("[computer-use] localStorage 조회 실패 (origin=%s): %v", origin, err)
+			continue
+		}
+		for _, entry := range entries {
+			if len(entry) != 2 {
+				continue
+			}
+			if profile.LocalStorage == nil {
+				profile.LocalStorage = make(map[string]map[string]string)
+			}
+			if profile.LocalStorage[origin] == nil {
+				profile.LocalStorage[origin] = make(map[string]string)
+			}
+			profile.LocalStorage[origin][entry[0]] = entry[1]
+		}
+	}
+
+	return profile, nil
+}
+
+// importBrowserProfile은 taskCtx의 브라우저에 쿠키를 설정하고,
+// 새 문서마다 해당 origin의 localStorage를 채우는 스크립트를 등록한다.
+// 페이지가 이미 설정한 키는 덮어쓰지 않는다.
+func importBrowserProfile(taskCtx context.Context, profile *BrowserProfile) error {
+	if profile == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(taskCtx, profileIOTimeout)
+	defer cancel()
+
+	now := time.Now()
+	params := make([]*network.CookieParam, 0, len(profile.Cookies))
+	for _, c := range profile.Cookies {
+		param := &network.CookieParam{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			HTTPOnly: c.HTTPOnly,
+			Secure:   c.Secure,
+			SameSite: network.CookieSameSite(c.SameSite),
+		}
+		if c.Expires > 0 {
+			expires := time.Unix(int64(c.Expires), 0)
+			if expires.Before(now) {
+				continue // 만료된 쿠키는 복원하지 않음
+			}
+			t := cdp.TimeSinceEpoch(expires)
+			param.Expires = &t
+		}
+		params = append(params, param)
+	}
+
+	if len(params) > 0 {
+		if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			return storage.SetCookies(params).Do(ctx)
+		})); err != nil {
+			return fmt.Errorf("failed to restore cookies: %w", err)
+		}
+	}
+
+	if len(profile.LocalStorage) > 0 {
+		script, err := localStorageRestoreScript(profile.LocalStorage)
+		if err != nil {
+			return err
+		}
+		if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+			_, err := page.AddScriptToEvaluateOnNewDocument(script).Do(ctx)
+			return err
+		})); err != nil {
+			return fmt.Errorf("failed to register localStorage restore script: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// localStorageRestoreScript는 현재 origin에 해당하는 저장 항목을 localStorage에 채우는 스크립트를 생성한다.
+func localStorageRestoreScript(items map[string]map[string]string) (string, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode localStorage: %w", err)
+	}
+	return fmt.Sprintf(`(() => {
+	try {
+		const items = (%s)[location.origin];
+		if (!items) return;
+		for (const [k, v] of Object.entries(items)) {
+			if (localStorage.getItem(k) === null) localStorage.setItem(k, v);
+		}
+	} catch (e) {}
+})();`, data), nil
+}
@@ -0,0 +1,210 @@
+package computeruse
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// encryptedProfileMagic은 암호화된 프로필 파일의 시작 표식이다.
+var encryptedProfileMagic = []byte("APPROF1\n")
+
+// ProfileStoreOption은 ProfileStore 설정을 위한 함수형 옵션이다.
+type ProfileStoreOption func(*ProfileStore)
+
+// WithProfileEncryptionKey는 프로필을 AES-256-GCM으로 암호화하여 저장하도록 설정한다.
+// 키 문자열은 SHA-256으로 256비트 키로 변환한다. 빈 문자열이면 암호화하지 않는다.
+func WithProfileEncryptionKey(key string) ProfileStoreOption {
+	return func(s *ProfileStore) {
+		if key == "" {
+			return
+		}
+		sum := sha256.Sum256([]byte(key))
+		s.key = sum[:]
+	}
+}
+
+// ProfileStore는 워크스페이스별 브라우저 프로필을 로컬 디스크에 저장한다.
+// 파일은 0600 권한으로 저장되며, 암호화 키가 설정되면 암호화된 상태로 저장된다.
+type ProfileStore struct {
+	dir string
+	key []byte // nil이면 평문 저장
+	mu  sync.Mutex
+}
+
+// NewProfileStore는 dir에 프로필을 저장하는 ProfileStore를 생성한다.
+func NewProfileStore(dir string, opts ...ProfileStoreOption) *ProfileStore {
+	s := &ProfileStore{dir: dir}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Encrypted는 프로필을 암호화하여 저장하는지 여부를 반환한다.
+func (s *ProfileStore) Encrypted() bool {
+	return s.key != nil
+}
+
+// Load는 워크스페이스의 저장된 프로필을 반환한다. 저장된 프로필이 없으면 nil, nil이다.
+func (s *ProfileStore) Load(workspaceID string) (*BrowserProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(workspaceID, s.Encrypted()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read browser profile: %w", err)
+	}
+
+	if s.Encrypted() {
+		if data, err = s.decrypt(data); err != nil {
+			return nil, err
+		}
+	}
+
+	var profile BrowserProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to parse browser profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// Save는 워크스페이스 프로필을 저장한다.
+// 임시 파일에 쓴 뒤 rename하여 저장 도중 중단되어도 기존 프로필이 손상되지 않으며,
+// 암호화 설정이 바뀐 경우 반대쪽 형식의 이전 파일은 삭제한다.
+func (s *ProfileStore) Save(workspaceID string, profile *BrowserProfile) error {
+	if profile == nil {
+		return nil
+	}
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("failed to encode browser profile: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Encrypted() {
+		if data, err = s.encrypt(data); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	path := s.path(workspaceID, s.Encrypted())
+	tmp, err := os.CreateTemp(s.dir, ".profile-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp profile: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write browser profile: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write browser profile: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to save browser profile: %w", err)
+	}
+
+	_ = os.Remove(s.path(workspaceID, !s.Encrypted()))
+	return nil
+}
+
+// Delete는 워크스페이스의 저장된 프로필을 삭제한다.
+func (s *ProfileStore) Delete(workspaceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for _, encrypted := range []bool{false, true} {
+		if err := os.Remove(s.path(workspaceID, encrypted)); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// path는 워크스페이스 프로필 파일 경로를 반환한다.
+func (s *ProfileStore) path(workspaceID string, encrypted bool) string {
+	name := profileFileName(workspaceID)
+	if encrypted {
+		name += ".enc"
+	}
+	return filepath.Join(s.dir, name)
+}
+
+// profileFileName은 워크스페이스 ID를 파일 이름으로 쓸 수 있게 변환한다.
+func profileFileName(workspaceID string) string {
+	workspaceID = strings.TrimSpace(workspaceID)
+	if workspaceID == "" {
+		return "default.json"
+	}
+	replacer := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_", "..", "_")
+	return replacer.Replace(workspaceID) + ".json"
+}
+
+// encrypt는 data를 AES-256-GCM으로 암호화한다 (magic + nonce + ciphertext).
+func (s *ProfileStore) encrypt(data []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	out := append([]byte{}, encryptedProfileMagic...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, nil), nil
+}
+
+// decrypt는 encrypt로 암호화된 data를 복호화한다.
+func (s *ProfileStore) decrypt(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptedProfileMagic) {
+		return nil, errors.New("browser profile is not encrypted with a known format")
+	}
+	data = data[len(encryptedProfileMagic):]
+
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("browser profile is truncated")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt browser profile (encryption key changed?): %w", err)
+	}
+	return plain, nil
+}
+
+func (s *ProfileStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init profile cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,229 @@
+package computeruse
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// mockProfileBackend은 프로필 내보내기/가져오기를 지원하는 테스트용 백엔드이다.
+type mockProfileBackend struct {
+	*mockBrowserBackend
+
+	imported *BrowserProfile
+	exported *BrowserProfile
+}
+
+func (m *mockProfileBackend) ExportProfile(ctx context.Context) (*BrowserProfile, error) {
+	return m.exported, nil
+}
+
+func (m *mockProfileBackend) ImportProfile(ctx context.Context, profile *BrowserProfile) error {
+	m.imported = profile
+	return nil
+}
+
+func testProfile() *BrowserProfile {
+	return &BrowserProfile{
+		Cookies: []ProfileCookie{{Name: "sid", Value: "secret-session", Domain: ".example.com", Path: "/", Secure: true}},
+		LocalStorage: map[string]map[string]string{
+			"https://example.com": {"token": "abc"},
+		},
+		SavedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestProfileStore_RoundTrip(t *testing.T) {
+	for _, key := range []string{"", "passphrase"} {
+		t.Run("encrypted="+boolString(key != ""), func(t *testing.T) {
+			dir := t.TempDir()
+			store := NewProfileStore(dir, WithProfileEncryptionKey(key))
+
+			got, err := store.Load("ws-1")
+			if err != nil || got != nil {
+				t.Fatalf("Load() before save = %v, %v; want nil, nil", got, err)
+			}
+
+			if err := store.Save("ws-1", testProfile()); err != nil {
+				t.Fatalf("Save() error: %v", err)
+			}
+			got, err = store.Load("ws-1")
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if len(got.Cookies) != 1 || got.Cookies[0].Value != "secret-session" {
+				t.Errorf("Cookies = %+v", got.Cookies)
+			}
+			if got.LocalStorage["https://example.com"]["token"] != "abc" {
+				t.Errorf("LocalStorage = %+v", got.LocalStorage)
+			}
+
+			path := store.path("ws-1", key != "")
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("profile file missing: %v", err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("profile file mode = %v; want 0600", info.Mode().Perm())
+			}
+			data, _ := os.ReadFile(path)
+			if encrypted := !bytes.Contains(data, []byte("secret-session")); encrypted != (key != "") {
+				t.Errorf("plaintext cookie visible in file = %v; want %v", !encrypted, key == "")
+			}
+		})
+	}
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func TestProfileStore_WrongKey(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewProfileStore(dir, WithProfileEncryptionKey("right")).Save("ws-1", testProfile()); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	if _, err := NewProfileStore(dir, WithProfileEncryptionKey("wrong")).Load("ws-1"); err == nil {
+		t.Error("Load() with wrong key succeeded; want error")
+	}
+}
+
+func TestProfileStore_SwitchEncryptionRemovesOldFile(t *testing.T) {
+	dir := t.TempDir()
+	plain := NewProfileStore(dir)
+	if err := plain.Save("ws-1", testProfile()); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	encrypted := NewProfileStore(dir, WithProfileEncryptionKey("key"))
+	if err := encrypted.Save("ws-1", testProfile()); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := os.Stat(plain.path("ws-1", false)); !os.IsNotExist(err) {
+		t.Error("plaintext profile should be removed after saving encrypted")
+	}
+
+	if err := encrypted.Delete("ws-1"); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("profile directory not empty after Delete(): %v", entries)
+	}
+}
+
+func TestProfileFileName(t *testing.T) {
+	tests := map[string]string{
+		"":            "default.json",
+		"ws-1":        "ws-1.json",
+		"../etc/pass": "__etc_pass.json",
+	}
+	for in, want := range tests {
+		if got := profileFileName(in); got != want {
+			t.Errorf("profileFileName(%q) = %q; want %q", in, got, want)
+		}
+		if filepath.Base(profileFileName(in)) != profileFileName(in) {
+			t.Errorf("profileFileName(%q) escapes profile directory", in)
+		}
+	}
+}
+
+func TestOriginOf(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/login?next=/": "https://example.com",
+		"http://localhost:3000/app":        "http://localhost:3000",
+		"about:blank":                      "",
+		"file:///etc/passwd":               "",
+	}
+	for in, want := range tests {
+		if got := originOf(in); got != want {
+			t.Errorf("originOf(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
+
+func TestLocalStorageRestoreScript(t *testing.T) {
+	script, err := localStorageRestoreScript(map[string]map[string]string{
+		"https://example.com": {"token": "a\"b</script>"},
+	})
+	if err != nil {
+		t.Fatalf("localStorageRestoreScript() error: %v", err)
+	}
+	if !strings.Contains(script, "location.origin") || !strings.Contains(script, `"https://example.com"`) {
+		t.Errorf("script does not look up items by origin: %s", script)
+	}
+	if strings.Contains(script, "</script>") {
+		t.Error("script should JSON-escape stored values")
+	}
+}
+
+func TestHandler_ProfilePersistence(t *testing.T) {
+	store := NewProfileStore(t.TempDir())
+	if err := store.Save("ws-1", testProfile()); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	h := NewHandler(WithProfileStore(store, "ws-1"))
+
+	session, err := h.SessionManager().CreateSession("exec-1", "sess-1", 1280, 720, true, "")
+	if err != nil {
+		t.Fatalf("CreateSession() error: %v", err)
+	}
+	backend := &mockProfileBackend{mockBrowserBackend: newMockBrowserBackend()}
+	backend.active = true
+	session.Backend = backend
+
+	h.restoreProfile(context.Background(), session)
+	if backend.imported == nil || backend.imported.Cookies[0].Name != "sid" {
+		t.Fatalf("saved profile was not imported: %+v", backend.imported)
+	}
+
+	// 이번 세션에서는 다른 origin만 방문
+	backend.exported = &BrowserProfile{
+		Cookies:      []ProfileCookie{{Name: "sid", Value: "renewed", Domain: ".example.com", Path: "/"}},
+		LocalStorage: map[string]map[string]string{"https://other.example": {"k": "v"}},
+	}
+	if err := h.HandleSessionEnd(context.Background(), ws.ComputerSessionPayload{SessionID: "sess-1"}); err != nil {
+		t.Fatalf("HandleSessionEnd() error: %v", err)
+	}
+
+	saved, err := store.Load("ws-1")
+	if err != nil || saved == nil {
+		t.Fatalf("Load() = %v, %v", saved, err)
+	}
+	if saved.Cookies[0].Value != "renewed" {
+		t.Errorf("saved cookie = %q; want renewed", saved.Cookies[0].Value)
+	}
+	if saved.LocalStorage["https://example.com"]["token"] != "abc" {
+		t.Error("localStorage of unvisited origin should be kept")
+	}
+	if saved.LocalStorage["https://other.example"]["k"] != "v" {
+		t.Error("localStorage of visited origin should be saved")
+	}
+}
+
+func TestHandler_ProfileNotSavedWithoutPersistFlag(t *testing.T) {
+	store := NewProfileStore(t.TempDir())
+	h := NewHandler(WithProfileStore(store, "ws-1"))
+
+	session, _ := h.SessionManager().CreateSession("exec-1", "sess-1", 1280, 720, true, "")
+	backend := &mockProfileBackend{mockBrowserBackend: newMockBrowserBackend(), exported: testProfile()}
+	backend.active = true
+	session.Backend = backend
+
+	if err := h.HandleSessionEnd(context.Background(), ws.ComputerSessionPayload{SessionID: "sess-1"}); err != nil {
+		t.Fatalf("HandleSessionEnd() error: %v", err)
+	}
+	if saved, _ := store.Load("ws-1"); saved != nil {
+		t.Error("profile saved for session without persist_profile")
+	}
+}
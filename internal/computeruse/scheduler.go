@@ -0,0 +1,261 @@
+package computeruse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSessionQueueTimeout은 대기열에서 세션 시작 슬롯을 얻지 못하고 타임아웃되었을 때 반환된다.
+	ErrSessionQueueTimeout = errors.New("timed out waiting for a computer use session slot")
+)
+
+// SchedulerConfig는 세션 스케줄러 설정을 정의한다.
+// 문자열 값은 ComputerUseConfigInput과 같은 형식으로 파싱한다.
+type SchedulerConfig struct {
+	MaxSessions   int    // 최대 동시 세션 수 (기본: 2)
+	QueueTimeout  string // 세션 시작 대기 타임아웃 (예: "2m", 기본: 2분)
+	SessionMemory string // 세션별 컨테이너 메모리 상한 (예: "512m", 비어 있으면 컨테이너 기본값)
+	SessionCPU    string // 세션별 컨테이너 CPU 상한 (예: "0.5", 비어 있으면 컨테이너 기본값)
+}
+
+// DefaultSchedulerConfig는 기본 스케줄러 설정을 반환한다.
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		MaxSessions:  2,
+		QueueTimeout: "2m",
+	}
+}
+
+// SessionResources는 컨테이너 세션에 적용할 리소스 제한이다 (0이면 변경하지 않음).
+type SessionResources struct {
+	MemoryLimit int64 // 메모리 제한 (바이트)
+	CPUQuota    int64 // CPU 할당량 (100000 = 1.0 CPU)
+}
+
+// QueueNotifier는 대기 중인 세션 시작 요청의 대기 순번(1부터 시작)을 전달받는다.
+type QueueNotifier func(position int)
+
+// sessionWaiter는 대기열에서 슬롯을 기다리는 세션 시작 요청이다.
+type sessionWaiter struct {
+	sessionID string
+	ready     chan struct{} // 슬롯이 할당되면 닫힌다
+	notify    QueueNotifier
+}
+
+// queueUpdate는 잠금 해제 후 전달할 대기 순번 알림이다.
+type queueUpdate struct {
+	notify   QueueNotifier
+	position int
+}
+
+// SessionScheduler는 동시 세션 수를 제한하고 초과한 세션 시작 요청을 FIFO 대기열에 보관한다.
+// 세션이 종료되어 슬롯이 반환되면 대기열 맨 앞의 요청에 슬롯을 넘기고,
+// 남은 요청에는 바뀐 대기 순번을 알린다.
+type SessionScheduler struct {
+	maxSessions  int
+	queueTimeout time.Duration
+	limits       SessionResources
+
+	running map[string]struct{} // 슬롯을 점유한 세션 ID
+	queue   []*sessionWaiter
+	mu      sync.Mutex
+}
+
+// NewSessionScheduler는 설정으로 새 SessionScheduler를 생성한다.
+// 잘못된 값은 기본값으로 대체한다.
+func NewSessionScheduler(cfg SchedulerConfig) *SessionScheduler {
+	defaults := DefaultSchedulerConfig()
+
+	maxSessions := cfg.MaxSessions
+	if maxSessions <= 0 {
+		maxSessions = defaults.MaxSessions
+	}
+	queueTimeout := parseTimeout(cfg.QueueTimeout)
+	if queueTimeout <= 0 {
+		queueTimeout = parseTimeout(defaults.QueueTimeout)
+	}
+
+	return &SessionScheduler{
+		maxSessions:  maxSessions,
+		queueTimeout: queueTimeout,
+		limits: SessionResources{
+			MemoryLimit: parseMemory(cfg.SessionMemory),
+			CPUQuota:    parseCPU(cfg.SessionCPU),
+		},
+		running: make(map[string]struct{}),
+	}
+}
+
+// MaxSessions는 최대 동시 세션 수를 반환한다.
+func (s *SessionScheduler) MaxSessions() int {
+	return s.maxSessions
+}
+
+// Acquire는 세션에 실행 슬롯을 할당한다.
+// 슬롯이 없으면 대기열에 들어가 notify로 대기 순번을 알리고,
+// 슬롯이 생기거나 큐 타임아웃/컨텍스트 취소가 발생할 때까지 대기한다.
+func (s *SessionScheduler) Acquire(ctx context.Context, sessionID string, notify QueueNotifier) error {
+	s.mu.Lock()
+	if _, exists := s.running[sessionID]; exists {
+		s.mu.Unlock()
+		return fmt.Errorf("session %s is already scheduled", sessionID)
+	}
+	for _, w := range s.queue {
+		if w.sessionID == sessionID {
+			s.mu.Unlock()
+			return fmt.Errorf("session %s is already queued", sessionID)
+		}
+	}
+
+	if len(s.running) < s.maxSessions && len(s.queue) == 0 {
+		s.running[sessionID] = struct{}{}
+		s.mu.Unlock()
+		return nil
+	}
+
+	waiter := &sessionWaiter{
+		sessionID: sessionID,
+		ready:     make(chan struct{}),
+		notify:    notify,
+	}
+	s.queue = append(s.queue, waiter)
+	position := len(s.queue)
+	s.mu.Unlock()
+
+	log.Printf("[computer-use] session %s queued (position=%d, max_sessions=%d)", sessionID, position, s.maxSessions)
+	if notify != nil {
+		notify(position)
+	}
+
+	timer := time.NewTimer(s.queueTimeout)
+	defer timer.Stop()
+
+	var waitErr error
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-timer.C:
+		waitErr = ErrSessionQueueTimeout
+	case <-ctx.Done():
+		waitErr = ctx.Err()
+	}
+
+	s.mu.Lock()
+	// 타임아웃과 슬롯 할당이 동시에 일어난 경우 할당을 우선한다.
+	select {
+	case <-waiter.ready:
+		s.mu.Unlock()
+		return nil
+	default:
+	}
+	s.removeWaiterLocked(waiter)
+	updates := s.queueUpdatesLocked()
+	s.mu.Unlock()
+
+	dispatchQueueUpdates(updates)
+	log.Printf("[computer-use] session %s left the queue: %v", sessionID, waitErr)
+	return waitErr
+}
+
+// Release는 세션의 슬롯을 반환하고 대기열 맨 앞의 요청에 넘긴다.
+// 슬롯을 점유하지 않은 세션이면 아무 작업도 하지 않는다.
+func (s *SessionScheduler) Release(sessionID string) {
+	s.mu.Lock()
+	if _, exists := s.running[sessionID]; !exists {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.running, sessionID)
+
+	promoted := false
+	for len(s.running) < s.maxSessions && len(s.queue) > 0 {
+		next := s.queue[0]
+		s.queue = s.queue[1:]
+		s.running[next.sessionID] = struct{}{}
+		close(next.ready)
+		promoted = true
+	}
+
+	var updates []queueUpdate
+	if promoted {
+		updates = s.queueUpdatesLocked()
+	}
+	s.mu.Unlock()
+
+	dispatchQueueUpdates(updates)
+}
+
+// QueueLength는 슬롯을 기다리는 세션 시작 요청 수를 반환한다.
+func (s *SessionScheduler) QueueLength() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// RunningCount는 슬롯을 점유한 세션 수를 반환한다.
+func (s *SessionScheduler) RunningCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.running)
+}
+
+// ResourceLimits는 세션이 요청한 메모리/CPU 제한을 세션별 상한에 맞춰 반환한다.
+// 요청이 없으면 상한을 그대로 적용하고, 상한을 넘는 요청은 상한으로 낮춘다.
+func (s *SessionScheduler) ResourceLimits(memory, cpu string) (SessionResources, error) {
+	limits := s.limits
+
+	if memory != "" {
+		requested := parseMemory(memory)
+		if requested <= 0 {
+			return SessionResources{}, fmt.Errorf("invalid session memory limit: %q", memory)
+		}
+		if limits.MemoryLimit <= 0 || requested < limits.MemoryLimit {
+			limits.MemoryLimit = requested
+		}
+	}
+
+	if cpu != "" {
+		requested := parseCPU(cpu)
+		if requested <= 0 {
+			return SessionResources{}, fmt.Errorf("invalid session cpu limit: %q", cpu)
+		}
+		if limits.CPUQuota <= 0 || requested < limits.CPUQuota {
+			limits.CPUQuota = requested
+		}
+	}
+
+	return limits, nil
+}
+
+// removeWaiterLocked는 대기열에서 요청을 제거한다. s.mu를 잡은 상태에서 호출해야 한다.
+func (s *SessionScheduler) removeWaiterLocked(waiter *sessionWaiter) {
+	for i, w := range s.queue {
+		if w == waiter {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// queueUpdatesLocked는 남은 대기 요청의 현재 순번 알림 목록을 만든다. s.mu를 잡은 상태에서 호출해야 한다.
+func (s *SessionScheduler) queueUpdatesLocked() []queueUpdate {
+	updates := make([]queueUpdate, 0, len(s.queue))
+	for i, w := range s.queue {
+		if w.notify != nil {
+			updates = append(updates, queueUpdate{notify: w.notify, position: i + 1})
+		}
+	}
+	return updates
+}
+
+// dispatchQueueUpdates는 잠금 밖에서 대기 순번 알림을 전달한다.
+func dispatchQueueUpdates(updates []queueUpdate) {
+	for _, u := range updates {
+		u.notify(u.position)
+	}
+}
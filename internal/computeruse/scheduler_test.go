@@ -0,0 +1,258 @@
+package computeruse
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// positionRecorder는 QueueNotifier로 전달된 대기 순번을 기록한다.
+type positionRecorder struct {
+	mu        sync.Mutex
+	positions []int
+}
+
+func (r *positionRecorder) notify(position int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.positions = append(r.positions, position)
+}
+
+func (r *positionRecorder) last() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.positions) == 0 {
+		return 0
+	}
+	return r.positions[len(r.positions)-1]
+}
+
+// waitFor는 조건이 참이 될 때까지 최대 1초 대기한다.
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal(msg)
+}
+
+func TestNewSessionScheduler_Defaults(t *testing.T) {
+	s := NewSessionScheduler(SchedulerConfig{MaxSessions: -1, QueueTimeout: "invalid"})
+
+	if s.MaxSessions() != 2 {
+		t.Errorf("MaxSessions() = %d; want 2", s.MaxSessions())
+	}
+	if s.queueTimeout != 2*time.Minute {
+		t.Errorf("queueTimeout = %v; want 2m", s.queueTimeout)
+	}
+}
+
+func TestSessionScheduler_AcquireWithinLimit(t *testing.T) {
+	s := NewSessionScheduler(SchedulerConfig{MaxSessions: 2})
+	ctx := context.Background()
+
+	if err := s.Acquire(ctx, "sess-1", nil); err != nil {
+		t.Fatalf("Acquire(sess-1) = %v; want nil", err)
+	}
+	if err := s.Acquire(ctx, "sess-2", nil); err != nil {
+		t.Fatalf("Acquire(sess-2) = %v; want nil", err)
+	}
+	if err := s.Acquire(ctx, "sess-1", nil); err == nil {
+		t.Error("Acquire(sess-1) again = nil; want error for duplicate session")
+	}
+	if got := s.RunningCount(); got != 2 {
+		t.Errorf("RunningCount() = %d; want 2", got)
+	}
+}
+
+func TestSessionScheduler_QueueAndPromote(t *testing.T) {
+	s := NewSessionScheduler(SchedulerConfig{MaxSessions: 1, QueueTimeout: "5s"})
+	ctx := context.Background()
+
+	if err := s.Acquire(ctx, "sess-1", nil); err != nil {
+		t.Fatalf("Acquire(sess-1) = %v", err)
+	}
+
+	rec2, rec3 := &positionRecorder{}, &positionRecorder{}
+	done2, done3 := make(chan error, 1), make(chan error, 1)
+	go func() { done2 <- s.Acquire(ctx, "sess-2", rec2.notify) }()
+	waitFor(t, func() bool { return s.QueueLength() == 1 }, "sess-2 was not queued")
+	go func() { done3 <- s.Acquire(ctx, "sess-3", rec3.notify) }()
+	waitFor(t, func() bool { return s.QueueLength() == 2 }, "sess-3 was not queued")
+
+	if got := rec2.last(); got != 1 {
+		t.Errorf("sess-2 position = %d; want 1", got)
+	}
+	if got := rec3.last(); got != 2 {
+		t.Errorf("sess-3 position = %d; want 2", got)
+	}
+
+	// 슬롯 반환 시 맨 앞의 요청이 슬롯을 받고, 남은 요청의 순번이 당겨진다.
+	s.Release("sess-1")
+	if err := <-done2; err != nil {
+		t.Fatalf("Acquire(sess-2) = %v; want nil after release", err)
+	}
+	waitFor(t, func() bool { return rec3.last() == 1 }, "sess-3 position was not updated to 1")
+
+	s.Release("sess-2")
+	if err := <-done3; err != nil {
+		t.Fatalf("Acquire(sess-3) = %v; want nil after release", err)
+	}
+	if got := s.QueueLength(); got != 0 {
+		t.Errorf("QueueLength() = %d; want 0", got)
+	}
+}
+
+func TestSessionScheduler_QueueTimeout(t *testing.T) {
+	s := NewSessionScheduler(SchedulerConfig{MaxSessions: 1, QueueTimeout: "50ms"})
+	ctx := context.Background()
+
+	if err := s.Acquire(ctx, "sess-1", nil); err != nil {
+		t.Fatalf("Acquire(sess-1) = %v", err)
+	}
+
+	err := s.Acquire(ctx, "sess-2", nil)
+	if !errors.Is(err, ErrSessionQueueTimeout) {
+		t.Fatalf("Acquire(sess-2) = %v; want ErrSessionQueueTimeout", err)
+	}
+	if got := s.QueueLength(); got != 0 {
+		t.Errorf("QueueLength() = %d; want 0 after timeout", got)
+	}
+
+	// 타임아웃된 요청은 이후 반환된 슬롯을 가져가지 않아야 한다.
+	s.Release("sess-1")
+	if got := s.RunningCount(); got != 0 {
+		t.Errorf("RunningCount() = %d; want 0", got)
+	}
+}
+
+func TestSessionScheduler_ContextCancelled(t *testing.T) {
+	s := NewSessionScheduler(SchedulerConfig{MaxSessions: 1, QueueTimeout: "5s"})
+
+	if err := s.Acquire(context.Background(), "sess-1", nil); err != nil {
+		t.Fatalf("Acquire(sess-1) = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Acquire(ctx, "sess-2", nil) }()
+	waitFor(t, func() bool { return s.QueueLength() == 1 }, "sess-2 was not queued")
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire(sess-2) = %v; want context.Canceled", err)
+	}
+}
+
+func TestSessionScheduler_ReleaseUnknownSession(t *testing.T) {
+	s := NewSessionScheduler(DefaultSchedulerConfig())
+
+	// 점유하지 않은 세션 반환은 무시되어야 한다.
+	s.Release("unknown")
+	if got := s.RunningCount(); got != 0 {
+		t.Errorf("RunningCount() = %d; want 0", got)
+	}
+}
+
+func TestSessionScheduler_ResourceLimits(t *testing.T) {
+	s := NewSessionScheduler(SchedulerConfig{SessionMemory: "1g", SessionCPU: "1.0"})
+
+	tests := []struct {
+		name       string
+		memory     string
+		cpu        string
+		wantMemory int64
+		wantCPU    int64
+		wantErr    bool
+	}{
+		{"요청 없음은 상한 적용", "", "", 1024 * 1024 * 1024, 100000, false},
+		{"상한 이하 요청", "512m", "0.5", 512 * 1024 * 1024, 50000, false},
+		{"상한 초과 요청은 상한으로 제한", "4g", "2.0", 1024 * 1024 * 1024, 100000, false},
+		{"잘못된 메모리 형식", "lots", "", 0, 0, true},
+		{"잘못된 CPU 형식", "", "-1", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := s.ResourceLimits(tt.memory, tt.cpu)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ResourceLimits() = nil error; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResourceLimits() = %v", err)
+			}
+			if limits.MemoryLimit != tt.wantMemory {
+				t.Errorf("MemoryLimit = %d; want %d", limits.MemoryLimit, tt.wantMemory)
+			}
+			if limits.CPUQuota != tt.wantCPU {
+				t.Errorf("CPUQuota = %d; want %d", limits.CPUQuota, tt.wantCPU)
+			}
+		})
+	}
+
+	// 상한이 없으면 요청값을 그대로 사용한다.
+	unlimited := NewSessionScheduler(DefaultSchedulerConfig())
+	limits, err := unlimited.ResourceLimits("2g", "")
+	if err != nil {
+		t.Fatalf("ResourceLimits() = %v", err)
+	}
+	if limits.MemoryLimit != 2*1024*1024*1024 || limits.CPUQuota != 0 {
+		t.Errorf("limits = %+v; want 2g memory and no cpu quota", limits)
+	}
+}
+
+func TestHandler_SessionEndReleasesSchedulerSlot(t *testing.T) {
+	h := NewHandler(WithSessionScheduler(NewSessionScheduler(SchedulerConfig{MaxSessions: 1})))
+
+	if err := h.Scheduler().Acquire(context.Background(), "sess-1", nil); err != nil {
+		t.Fatalf("Acquire(sess-1) = %v", err)
+	}
+	if _, err := h.SessionManager().CreateSession("exec-1", "sess-1", 0, 0, true, ""); err != nil {
+		t.Fatalf("CreateSession() = %v", err)
+	}
+	if _, err := h.SessionManager().CreateSession("exec-2", "sess-2", 0, 0, true, ""); err == nil {
+		t.Error("CreateSession(sess-2) = nil; want error when session manager limit follows scheduler")
+	}
+
+	if err := h.SessionManager().EndSession("sess-1"); err != nil {
+		t.Fatalf("EndSession() = %v", err)
+	}
+	if got := h.Scheduler().RunningCount(); got != 0 {
+		t.Errorf("RunningCount() = %d; want 0 after session end", got)
+	}
+}
+
+func TestHandler_HandleSessionStartQueued_Timeout(t *testing.T) {
+	h := NewHandler(WithSessionScheduler(NewSessionScheduler(SchedulerConfig{MaxSessions: 1, QueueTimeout: "50ms"})))
+
+	if err := h.Scheduler().Acquire(context.Background(), "busy", nil); err != nil {
+		t.Fatalf("Acquire(busy) = %v", err)
+	}
+
+	rec := &positionRecorder{}
+	err := h.HandleSessionStartQueued(context.Background(), ws.ComputerSessionPayload{
+		ExecutionID: "exec-queued",
+		SessionID:   "sess-queued",
+		Headless:    true,
+	}, rec.notify)
+	if !errors.Is(err, ErrSessionQueueTimeout) {
+		t.Fatalf("HandleSessionStartQueued() = %v; want ErrSessionQueueTimeout", err)
+	}
+	if got := rec.last(); got != 1 {
+		t.Errorf("queue position = %d; want 1", got)
+	}
+	if _, exists := h.SessionManager().GetSession("sess-queued"); exists {
+		t.Error("queued session was created after timeout")
+	}
+}
@@ -28,6 +28,11 @@ type Session struct {
 	ViewportW    int
 	ViewportH    int
 	Headless     bool
+	// PersistProfile은 세션 종료 시 브라우저 프로필을 저장할지 여부이다.
+	PersistProfile bool
+
+	// restoredProfile은 세션 시작 시 복원한 프로필이다 (저장 시 방문하지 않은 origin의 localStorage 보존용).
+	restoredProfile *BrowserProfile
 
 	// pendingResults stores action results waiting for successful delivery (REQ-M3-04).
 	pendingResults []PendingResult
@@ -42,6 +47,11 @@ type SessionManager struct {
 	maxIdle         time.Duration // 30 minutes
 	maxActive       time.Duration // 2 hours
 	maxPerWorkspace int           // 2
+
+	// onSessionEnd is called after a session is removed (end, cleanup, or shutdown).
+	onSessionEnd func(sessionID string)
+	// onSessionClosing is called before a session's browser is closed.
+	onSessionClosing func(session *Session)
 }
 
 // NewSessionManager creates a new SessionManager with default timeouts.
@@ -54,6 +64,59 @@ func NewSessionManager() *SessionManager {
 	}
 }
 
+// SetMaxSessions changes the maximum number of concurrent sessions.
+// Values less than 1 are ignored.
+func (sm *SessionManager) SetMaxSessions(n int) {
+	if n < 1 {
+		return
+	}
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.maxPerWorkspace = n
+}
+
+// OnSessionEnd registers a callback invoked after a session is removed,
+// whether it was ended explicitly, expired, or closed on shutdown.
+// The callback runs without the manager lock held.
+func (sm *SessionManager) OnSessionEnd(fn func(sessionID string)) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.onSessionEnd = fn
+}
+
+// OnSessionClosing registers a callback invoked right before a session's
+// browser is closed, while the browser is still usable (e.g. to save its profile).
+// The callback runs with the manager lock held and must not call back into the manager.
+func (sm *SessionManager) OnSessionClosing(fn func(session *Session)) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.onSessionClosing = fn
+}
+
+// closeBackend invokes the closing callback and closes the session's browser.
+// Must be called with sm.mu held.
+func (sm *SessionManager) closeBackend(session *Session) {
+	if session.Backend == nil {
+		return
+	}
+	if sm.onSessionClosing != nil {
+		sm.onSessionClosing(session)
+	}
+	if err := session.Backend.Close(); err != nil {
+		log.Printf("[computer-use] failed to close browser for session %s: %v", session.ID, err)
+	}
+}
+
+// notifySessionsEnded invokes the session end callback for each removed session.
+func (sm *SessionManager) notifySessionsEnded(fn func(sessionID string), sessionIDs []string) {
+	if fn == nil {
+		return
+	}
+	for _, id := range sessionIDs {
+		fn(id)
+	}
+}
+
 // CreateSession creates a new browser session with the given parameters.
 // Returns an error if the maximum number of concurrent sessions is reached.
 func (sm *SessionManager) CreateSession(executionID, sessionID string, viewportW, viewportH int, headless bool, initialURL string) (*Session, error) {
@@ -151,21 +214,21 @@ func (sm *SessionManager) GetSession(sessionID string) (*Session, bool) {
 // EndSession terminates the session with the given ID and closes its browser.
 func (sm *SessionManager) EndSession(sessionID string) error {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
 
 	session, exists := sm.sessions[sessionID]
 	if !exists {
+		sm.mu.Unlock()
 		return fmt.Errorf("session %s not found", sessionID)
 	}
 
 	// 브라우저 종료
-	if session.Backend != nil {
-		if err := session.Backend.Close(); err != nil {
-			log.Printf("[computer-use] failed to close browser for session %s: %v", sessionID, err)
-		}
-	}
+	sm.closeBackend(session)
 
 	delete(sm.sessions, sessionID)
+	onEnd := sm.onSessionEnd
+	sm.mu.Unlock()
+
+	sm.notifySessionsEnded(onEnd, []string{sessionID})
 	return nil
 }
 
@@ -179,6 +242,17 @@ func (sm *SessionManager) TouchSession(sessionID string) {
 	}
 }
 
+// setPersistProfile marks whether the session's browser profile is saved on close.
+func (sm *SessionManager) setPersistProfile(sessionID string, persist bool, restored *BrowserProfile) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if session, exists := sm.sessions[sessionID]; exists {
+		session.PersistProfile = persist
+		session.restoredProfile = restored
+	}
+}
+
 // QueueResult stores an action result that needs to be sent over the
 // WebSocket connection. The result stays queued until DrainPendingResults
 // is called after a successful send (REQ-M3-04).
@@ -248,8 +322,8 @@ func (sm *SessionManager) StartCleanupLoop(ctx context.Context) {
 // cleanupExpiredSessions removes sessions that have exceeded idle or active timeouts.
 func (sm *SessionManager) cleanupExpiredSessions() {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
 
+	var ended []string
 	now := time.Now()
 	for id, session := range sm.sessions {
 		idleExpired := now.Sub(session.LastActiveAt) > sm.maxIdle
@@ -262,28 +336,30 @@ func (sm *SessionManager) cleanupExpiredSessions() {
 			}
 			log.Printf("[computer-use] closing session %s: %s", id, reason)
 
-			if session.Backend != nil {
-				if err := session.Backend.Close(); err != nil {
-					log.Printf("[computer-use] failed to close browser for session %s: %v", id, err)
-				}
-			}
+			sm.closeBackend(session)
 			delete(sm.sessions, id)
+			ended = append(ended, id)
 		}
 	}
+	onEnd := sm.onSessionEnd
+	sm.mu.Unlock()
+
+	sm.notifySessionsEnded(onEnd, ended)
 }
 
 // closeAllSessions closes all active sessions. Called during shutdown.
 func (sm *SessionManager) closeAllSessions() {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
 
+	ended := make([]string, 0, len(sm.sessions))
 	for id, session := range sm.sessions {
 		log.Printf("[computer-use] shutting down session %s", id)
-		if session.Backend != nil {
-			if err := session.Backend.Close(); err != nil {
-				log.Printf("[computer-use] failed to close browser for session %s: %v", id, err)
-			}
-		}
+		sm.closeBackend(session)
 		delete(sm.sessions, id)
+		ended = append(ended, id)
 	}
+	onEnd := sm.onSessionEnd
+	sm.mu.Unlock()
+
+	sm.notifySessionsEnded(onEnd, ended)
 }
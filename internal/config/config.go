@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -24,6 +26,771 @@ type Config struct {
 	ComputerUse  ComputerUseConfig  `mapstructure:"computer_use"`
 	Git          GitConfig          `mapstructure:"git"`
 	Reranker     RerankerConfig     `mapstructure:"reranker"`
+	FileSync     FileSyncConfig     `mapstructure:"file_sync"`
+	Shutdown     ShutdownConfig     `mapstructure:"shutdown"`
+	IdleMode     IdleModeConfig     `mapstructure:"idle_mode"`
+	ResultCache  ResultCacheConfig  `mapstructure:"result_cache"`
+	CrashReport  CrashReportConfig  `mapstructure:"crash_report"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
+	CustomTools  []CustomToolConfig `mapstructure:"custom_tools"`
+	// Templates는 이름으로 실행하는 작업 템플릿입니다 (exec --template, MCP execute_template).
+	Templates []TemplateConfig `mapstructure:"templates"`
+	// TemplatesDir은 템플릿 파일(*.yaml, *.yml)을 읽을 디렉토리입니다.
+	// 기본값: ~/.config/autopus/templates.
+	TemplatesDir string `mapstructure:"templates_dir"`
+	// CodegenSandbox는 MCP 코드 생성 샌드박스 디스크 할당량 설정입니다.
+	CodegenSandbox CodegenSandboxConfig `mapstructure:"codegen_sandbox"`
+	// MCPNotifications는 Bridge가 관리하는 MCP 서버 알림의 백엔드 전달 설정입니다.
+	MCPNotifications MCPNotificationsConfig `mapstructure:"mcp_notifications"`
+	// TaskCheckpoint는 장시간 작업 체크포인트(재시작 후 재개) 설정입니다.
+	TaskCheckpoint TaskCheckpointConfig `mapstructure:"task_checkpoint"`
+	// Transcript는 실행 단위 트랜스크립트(프롬프트, 출력, 도구 호출, 결과) 로컬 기록 설정입니다.
+	Transcript TranscriptConfig `mapstructure:"transcript"`
+	// History는 autopus-bridge history로 조회하는 로컬 실행 이력 설정입니다.
+	History HistoryConfig `mapstructure:"history"`
+	// WorkDir는 기본 작업 디렉토리입니다 (setup/up이 기록). 서버가 work_dir을 보내지 않은 작업과
+	// 상대 경로 work_dir의 기준으로 사용합니다. 비어 있으면 프로바이더의 기본 디렉토리를 사용합니다.
+	WorkDir string `mapstructure:"work_dir"`
+	// WorkDirs는 서버가 작업마다 지정하는 작업 디렉토리의 허용 루트와 실행별 디렉토리 설정입니다.
+	WorkDirs WorkDirsConfig `mapstructure:"work_dirs"`
+	// Conversation은 conversation_id로 묶인 작업의 프로바이더 세션 재사용 설정입니다.
+	Conversation ConversationConfig `mapstructure:"conversation"`
+	// Delegation은 로컬에서 실행할 수 없는 작업을 다른 Bridge로 위임하는 정책입니다.
+	Delegation DelegationConfig `mapstructure:"delegation"`
+	// RemoteSettings는 서버가 config_update로 변경할 수 있는 설정의 허용 범위입니다.
+	RemoteSettings RemoteSettingsConfig `mapstructure:"remote_settings"`
+	// EventHooks는 수명 주기 이벤트(연결, 작업 완료 등)에 실행할 셸 명령/웹훅입니다.
+	EventHooks []EventHookConfig `mapstructure:"event_hooks"`
+	// StateStore는 하위 시스템 상태(아웃박스 등)를 보관하는 통합 로컬 저장소 설정입니다.
+	StateStore StateStoreConfig `mapstructure:"state_store"`
+	// Language는 CLI 출력, MCP 에러, 작업 에러 메시지 언어입니다 ("ko", "en").
+	// 비어 있으면 LANG 환경변수를 따릅니다. --lang 플래그가 우선합니다.
+	Language string `mapstructure:"language"`
+	// Team은 up --team-config로 가져온 팀 설정 번들 정보입니다.
+	Team TeamConfig `mapstructure:"team"`
+	// Upload는 스크린샷, 아티팩트, 대용량 결과 전송의 공유 대역폭 제한 설정입니다.
+	Upload UploadConfig `mapstructure:"upload"`
+	// ReadOnly가 true이면 서버에 연결해 상태/컨텍스트/지식 조회는 처리하지만 작업 실행, CLI, 배포,
+	// 컴퓨터 사용 같은 변경 요청은 READ_ONLY로 거절합니다 (데모, 모니터링 전용 호스트). connect --read-only와 같습니다.
+	ReadOnly bool `mapstructure:"read_only"`
+	// ProviderReplay는 프로바이더 응답 기록/재생 설정입니다 (결정적 통합 테스트, 오프라인 데모).
+	ProviderReplay ProviderReplayConfig `mapstructure:"provider_replay"`
+	// Concurrency는 워크스페이스별 동시 실행 한도 설정입니다.
+	Concurrency ConcurrencyConfig `mapstructure:"concurrency"`
+	// Notifications는 승인 대기, 작업 실패처럼 사람이 확인해야 하는 이벤트의 데스크톱 알림 설정입니다.
+	Notifications NotificationsConfig `mapstructure:"notifications"`
+	// Timeouts는 task/build/test/QA/CLI 요청과 MCP 도구 호출의 실행 타임아웃 계층입니다.
+	Timeouts TimeoutsConfig `mapstructure:"timeouts"`
+	// Identity는 서버에서 여러 Bridge를 구분하고 지정할 때 쓰는 이름과 라벨입니다.
+	Identity IdentityConfig `mapstructure:"identity"`
+	// Limits는 작업 프롬프트와 결과 페이로드의 크기 제한입니다.
+	Limits LimitsConfig `mapstructure:"limits"`
+}
+
+// maxMessageBytes는 WebSocket 메시지 최대 크기입니다 (websocket.MaxMessageSize와 같음).
+const maxMessageBytes = 1024 * 1024
+
+// LimitsConfig는 작업 입력과 실행 결과의 크기 제한 설정입니다.
+// 한도를 넘는 프롬프트는 PROMPT_TOO_LARGE로 거절하고, 한도를 넘는 작업 출력과 CLI stdout/stderr는
+// 앞뒤를 남기고 줄여서 보내며 전체 내용은 overflow_dir에 보관해 결과에 그 경로를 함께 보냅니다.
+// 장황한 명령 하나가 WebSocket 메시지 한도(1MB)를 넘어 결과 전송이 실패하지 않도록 합니다.
+type LimitsConfig struct {
+	// MaxPromptBytes는 작업 프롬프트(시스템 프롬프트 포함)의 최대 크기(바이트)입니다. 0이면 제한하지 않습니다 (기본값).
+	MaxPromptBytes int `mapstructure:"max_prompt_bytes" yaml:"max_prompt_bytes"`
+	// MaxOutputBytes는 결과 필드(작업 출력, stdout, stderr) 하나의 최대 크기(바이트)입니다. 기본값: 256KB.
+	MaxOutputBytes int `mapstructure:"max_output_bytes" yaml:"max_output_bytes"`
+	// MaxResultBytes는 직렬화한 결과 페이로드의 최대 크기(바이트)입니다. 1MB 미만이어야 합니다. 기본값: 900KB.
+	MaxResultBytes int `mapstructure:"max_result_bytes" yaml:"max_result_bytes"`
+	// OverflowDir은 줄이기 전 전체 내용을 보관할 디렉토리입니다. 기본값: ~/.config/autopus/overflow.
+	OverflowDir string `mapstructure:"overflow_dir" yaml:"overflow_dir"`
+	// OverflowMaxAgeDays는 보관한 전체 내용의 보관 기간(일)입니다. 기본값: 7.
+	OverflowMaxAgeDays int `mapstructure:"overflow_max_age_days" yaml:"overflow_max_age_days"`
+}
+
+// GetMaxOutputBytes는 결과 필드 하나의 최대 크기를 반환합니다. 기본값: 256KB.
+func (c *LimitsConfig) GetMaxOutputBytes() int {
+	if c.MaxOutputBytes <= 0 {
+		return 256 * 1024
+	}
+	return c.MaxOutputBytes
+}
+
+// GetMaxResultBytes는 결과 페이로드의 최대 크기를 반환합니다. 기본값: 900KB.
+func (c *LimitsConfig) GetMaxResultBytes() int {
+	if c.MaxResultBytes <= 0 {
+		return 900 * 1024
+	}
+	return c.MaxResultBytes
+}
+
+// GetOverflowDir은 전체 내용 보관 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/overflow를 반환합니다.
+func (c *LimitsConfig) GetOverflowDir() string {
+	if c.OverflowDir != "" {
+		return expandPath(c.OverflowDir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "overflow")
+}
+
+// GetOverflowMaxAge는 전체 내용 보관 기간을 반환합니다. 기본값: 7일.
+func (c *LimitsConfig) GetOverflowMaxAge() time.Duration {
+	if c.OverflowMaxAgeDays <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.OverflowMaxAgeDays) * 24 * time.Hour
+}
+
+// IdentityConfig는 Bridge 식별 정보 설정입니다.
+// 고정 Bridge ID는 처음 연결할 때 생성되어 ~/.config/autopus/bridge-id에 보관되며,
+// 이름과 라벨과 함께 agent_connect와 하트비트로 서버에 전달됩니다.
+type IdentityConfig struct {
+	// Name은 서버에 표시할 Bridge 이름입니다. 비어 있으면 호스트 이름입니다. connect --name이 우선합니다.
+	Name string `mapstructure:"name" yaml:"name"`
+	// Labels는 사용자 정의 라벨입니다 (예: team: backend, os: mac). 키는 소문자로 정규화됩니다.
+	// connect --label key=value로 추가하거나 덮어쓸 수 있습니다.
+	Labels map[string]string `mapstructure:"labels" yaml:"labels"`
+}
+
+// TimeoutsConfig는 실행 타임아웃 계층 설정입니다.
+// 요청에 지정된 타임아웃(max_seconds로 제한), 메시지 타입/MCP 도구별 값, default_seconds 순으로 적용하며
+// 모두 없으면 각 실행기의 기본값을 사용합니다. 타임아웃으로 끝난 작업은 어느 단계가 만료되었는지 함께 보고합니다.
+type TimeoutsConfig struct {
+	// DefaultSeconds는 전역 기본 타임아웃(초)입니다. 0이면 실행기 기본값을 사용합니다.
+	DefaultSeconds int `mapstructure:"default_seconds" yaml:"default_seconds"`
+	// MaxSeconds는 요청에 지정된 타임아웃의 상한(초)입니다. 0이면 제한하지 않습니다.
+	MaxSeconds int `mapstructure:"max_seconds" yaml:"max_seconds"`
+	// MessageTypes는 메시지 타입(task_request, build_request, cli_request 등)별 타임아웃(초)입니다.
+	MessageTypes map[string]int `mapstructure:"message_types" yaml:"message_types"`
+	// MCPTools는 MCP 도구 이름별 타임아웃(초)입니다. 도구 호출의 _meta.timeout_seconds가 우선합니다.
+	MCPTools map[string]int `mapstructure:"mcp_tools" yaml:"mcp_tools"`
+}
+
+// ConcurrencyConfig는 워크스페이스별 동시 실행 한도 설정입니다.
+// 한 워크스페이스의 작업이 다른 워크스페이스를 굶기지 않도록 워크스페이스마다 동시에 실행할
+// task/agent_response/build/test 수를 제한합니다. 현재 한도와 사용량은 하트비트로 보고됩니다.
+type ConcurrencyConfig struct {
+	// PerWorkspace는 모든 워크스페이스의 기본 동시 실행 한도입니다. 0이면 제한하지 않습니다 (기본값).
+	PerWorkspace int `mapstructure:"per_workspace" yaml:"per_workspace"`
+	// Workspaces는 워크스페이스 ID별 한도 재정의입니다. 0이면 그 워크스페이스는 제한하지 않습니다.
+	Workspaces map[string]int `mapstructure:"workspaces" yaml:"workspaces"`
+}
+
+// ProviderReplayConfig는 프로바이더 실행 기록/재생 설정입니다.
+// record 모드는 성공한 응답을 요청(프로바이더, 모델, 프롬프트, 도구) 해시별로 저장하고
+// (상태 저장소가 활성화되어 있으면 저장소 버킷에, 아니면 Dir의 파일에),
+// replay 모드는 CLI를 실행하지 않고 저장된 응답을 반환합니다. LAB_PROVIDER_REPLAY_MODE로도 지정할 수 있습니다.
+type ProviderReplayConfig struct {
+	// Mode는 기록/재생 모드입니다 ("off", "record", "replay"). 기본값: off.
+	Mode string `mapstructure:"mode" yaml:"mode"`
+	// Dir은 상태 저장소가 없을 때 사용하는 기록 디렉토리입니다. 기본값: ~/.config/autopus/provider-replay.
+	Dir string `mapstructure:"dir" yaml:"dir"`
+}
+
+// GetMode는 기록/재생 모드를 반환합니다. 설정되지 않은 경우 "off"를 반환합니다.
+func (c *ProviderReplayConfig) GetMode() string {
+	if c.Mode == "" {
+		return "off"
+	}
+	return c.Mode
+}
+
+// GetDir은 기록 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/provider-replay를 반환합니다.
+func (c *ProviderReplayConfig) GetDir() string {
+	if c.Dir != "" {
+		return expandPath(c.Dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "provider-replay")
+}
+
+// UploadConfig는 서버로 보내는 큰 전송의 업로드 대역폭 제한 설정입니다.
+// Computer Use 스크린샷, 파일 동기화/빌드 아티팩트, 대용량 작업 결과가 하나의 토큰 버킷을 공유합니다.
+type UploadConfig struct {
+	// MaxKbps는 전체 업로드 최대 속도(킬로비트/초)입니다. 0이면 제한하지 않습니다. 기본값: 0.
+	MaxKbps int `mapstructure:"max_kbps" yaml:"max_kbps"`
+	// Priorities는 분류(screenshot, result, artifact)별 우선순위입니다. 값이 작을수록 먼저 전송됩니다.
+	// 기본값: screenshot 0, result 1, artifact 2.
+	Priorities map[string]int `mapstructure:"priorities" yaml:"priorities"`
+}
+
+// TeamConfig는 가져온 팀 설정 번들의 출처와 고정된 도구 버전입니다.
+// 번들의 정책, 템플릿, MCP 도구 권한은 각 설정 섹션에 직접 반영되고 여기에는 기록만 남습니다.
+type TeamConfig struct {
+	// Name은 팀 이름입니다.
+	Name string `mapstructure:"name" yaml:"name"`
+	// KeyFingerprint는 신뢰하는 번들 서명 키의 지문입니다.
+	// 처음 가져올 때 기록되며, 이후 다른 키로 서명된 번들은 거부합니다.
+	KeyFingerprint string `mapstructure:"key_fingerprint" yaml:"key_fingerprint"`
+	// ToolVersions는 팀이 고정한 도구 버전입니다 (도구 이름 → 버전 문자열).
+	ToolVersions map[string]string `mapstructure:"tool_versions" yaml:"tool_versions"`
+}
+
+// TaskCheckpointConfig는 작업 실행 체크포인트 설정입니다.
+// 실행 중인 작업의 프로바이더 세션 ID, 단계, 누적 출력을 디스크에 저장하여
+// Bridge 크래시/재시작 후 서버와 task_resume으로 조율해 마지막 체크포인트부터 재개합니다.
+type TaskCheckpointConfig struct {
+	// Enabled는 체크포인트 활성화 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Dir은 체크포인트 저장 디렉토리입니다. 기본값: ~/.config/autopus/checkpoints.
+	Dir string `mapstructure:"dir" yaml:"dir"`
+	// IntervalSeconds는 스트리밍 출력 저장 최소 간격(초)입니다. 기본값: 10.
+	IntervalSeconds int `mapstructure:"interval_seconds" yaml:"interval_seconds"`
+	// MaxAgeHours는 재개를 제안할 체크포인트의 최대 수명(시간)입니다. 기본값: 24.
+	MaxAgeHours int `mapstructure:"max_age_hours" yaml:"max_age_hours"`
+}
+
+// GetDir은 체크포인트 저장 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/checkpoints를 반환합니다.
+func (c *TaskCheckpointConfig) GetDir() string {
+	if c.Dir != "" {
+		return expandPath(c.Dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "checkpoints")
+}
+
+// GetInterval은 체크포인트 저장 최소 간격을 반환합니다. 기본값: 10초.
+func (c *TaskCheckpointConfig) GetInterval() time.Duration {
+	if c.IntervalSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// GetMaxAge는 체크포인트 최대 수명을 반환합니다. 기본값: 24시간.
+func (c *TaskCheckpointConfig) GetMaxAge() time.Duration {
+	if c.MaxAgeHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.MaxAgeHours) * time.Hour
+}
+
+// TranscriptConfig는 실행 단위 트랜스크립트 설정입니다.
+// 작업마다 프롬프트, 스트리밍 출력, 도구 호출/결과, 최종 결과를 실행 ID별 파일에 기록하며
+// autopus-bridge transcript <execution-id>로 내보낼 수 있습니다.
+type TranscriptConfig struct {
+	// Enabled는 트랜스크립트 기록 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Dir은 트랜스크립트 저장 디렉토리입니다. 기본값: ~/.config/autopus/transcripts.
+	Dir string `mapstructure:"dir" yaml:"dir"`
+	// MaxAgeDays는 트랜스크립트 보관 기간(일)입니다. 기본값: 7.
+	MaxAgeDays int `mapstructure:"max_age_days" yaml:"max_age_days"`
+}
+
+// GetDir은 트랜스크립트 저장 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/transcripts를 반환합니다.
+func (c *TranscriptConfig) GetDir() string {
+	if c.Dir != "" {
+		return expandPath(c.Dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "transcripts")
+}
+
+// GetMaxAge는 트랜스크립트 보관 기간을 반환합니다. 기본값: 7일.
+func (c *TranscriptConfig) GetMaxAge() time.Duration {
+	if c.MaxAgeDays <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return time.Duration(c.MaxAgeDays) * 24 * time.Hour
+}
+
+// HistoryConfig는 로컬 실행 이력 설정입니다.
+// 작업마다 실행 ID, 에이전트, 상태, 실행 시간을 기록하며 백엔드 없이 autopus-bridge history로 조회할 수 있습니다.
+// state_store가 활성화되어 있으면 상태 저장소에, 아니면 ~/.config/autopus의 JSON 파일에 보관합니다.
+type HistoryConfig struct {
+	// Enabled는 실행 이력 기록 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// MaxEntries는 보관할 최대 실행 수입니다. 넘으면 오래된 기록부터 삭제합니다. 기본값: 500.
+	MaxEntries int `mapstructure:"max_entries" yaml:"max_entries"`
+}
+
+// GetMaxEntries는 보관할 최대 실행 수를 반환합니다. 기본값: 500.
+func (c *HistoryConfig) GetMaxEntries() int {
+	if c.MaxEntries <= 0 {
+		return 500
+	}
+	return c.MaxEntries
+}
+
+// WorkDirsConfig는 작업별 작업 디렉토리 설정입니다.
+// 서버가 task_request의 work_dir로 요청한 경로는 AllowedRoots 안에 있어야 하며,
+// new_work_dir 요청이나 PerExecution 설정이면 실행 ID 이름의 하위 디렉토리를 만들어 실행합니다.
+type WorkDirsConfig struct {
+	// AllowedRoots는 서버가 요청할 수 있는 작업 디렉토리 루트 목록입니다. 기본 work_dir은 항상 허용됩니다.
+	// 비어 있으면 요청한 경로를 제한하지 않습니다.
+	AllowedRoots []string `mapstructure:"allowed_roots" yaml:"allowed_roots"`
+	// PerExecution은 모든 작업을 실행 ID 이름의 새 하위 디렉토리에서 실행할지 여부입니다. 기본값: false.
+	PerExecution bool `mapstructure:"per_execution" yaml:"per_execution"`
+}
+
+// ConversationConfig는 작업 간 대화 연속성 설정입니다.
+// 같은 conversation_id의 작업은 이전 작업의 프로바이더 세션(Claude 세션, Codex Thread)을 이어서 사용합니다.
+type ConversationConfig struct {
+	// Enabled는 대화 연속성 활성화 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// TTLMinutes는 마지막 사용 후 세션을 재사용할 수 있는 시간(분)입니다. 기본값: 30.
+	TTLMinutes int `mapstructure:"ttl_minutes" yaml:"ttl_minutes"`
+}
+
+// GetTTL은 대화 세션 유지 시간을 반환합니다. 기본값: 30분.
+func (c *ConversationConfig) GetTTL() time.Duration {
+	if c.TTLMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(c.TTLMinutes) * time.Minute
+}
+
+// StateStoreConfig는 통합 로컬 상태 저장소 설정입니다.
+// 활성화하면 아웃박스처럼 디스크에 남는 하위 시스템 상태를 워크스페이스별 저장소 파일의
+// 버킷에 보관하고, 선택적으로 OS 키체인의 키로 암호화합니다.
+type StateStoreConfig struct {
+	// Enabled는 상태 저장소 사용 여부입니다. 기본값: false (하위 시스템별 JSON 파일 사용).
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Dir은 저장소 파일 디렉토리입니다. 기본값: ~/.config/autopus/state.
+	Dir string `mapstructure:"dir" yaml:"dir"`
+	// Encryption은 암호화 키 출처입니다 (keychain, env, none). 기본값: keychain.
+	Encryption string `mapstructure:"encryption" yaml:"encryption"`
+	// KeyEnv는 encryption이 env일 때 키를 읽을 환경 변수입니다. 기본값: AUTOPUS_STATE_KEY.
+	KeyEnv string `mapstructure:"key_env" yaml:"key_env"`
+}
+
+// GetDir은 저장소 파일 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/state를 반환합니다.
+func (c *StateStoreConfig) GetDir() string {
+	if c.Dir != "" {
+		return expandPath(c.Dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "state")
+}
+
+// GetEncryption은 암호화 키 출처를 반환합니다. 설정되지 않은 경우 keychain입니다.
+func (c *StateStoreConfig) GetEncryption() string {
+	if c.Encryption == "" {
+		return "keychain"
+	}
+	return c.Encryption
+}
+
+// GetKeyEnv는 암호화 키 환경 변수 이름을 반환합니다. 설정되지 않은 경우 AUTOPUS_STATE_KEY입니다.
+func (c *StateStoreConfig) GetKeyEnv() string {
+	if c.KeyEnv == "" {
+		return "AUTOPUS_STATE_KEY"
+	}
+	return c.KeyEnv
+}
+
+// EventHookConfig는 수명 주기 이벤트 훅 하나의 설정입니다.
+// command와 url 중 하나만 지정합니다.
+type EventHookConfig struct {
+	// Name은 로그에 표시할 훅 이름입니다.
+	Name string `mapstructure:"name" yaml:"name"`
+	// Events는 반응할 이벤트입니다 (connected, disconnected, task_started, task_completed,
+	// task_failed, computer_session_started, approval_required, action_approval_pending,
+	// reconnect_exhausted). 비어 있으면 모든 이벤트입니다.
+	Events []string `mapstructure:"events" yaml:"events"`
+	// Command는 sh -c로 실행할 셸 명령입니다. 페이로드는 표준 입력, 이벤트 정보는 AUTOPUS_* 환경 변수로 전달됩니다.
+	Command string `mapstructure:"command" yaml:"command"`
+	// URL은 페이로드를 보낼 웹훅 주소입니다.
+	URL string `mapstructure:"url" yaml:"url"`
+	// Method는 웹훅 HTTP 메서드입니다. 기본값: POST.
+	Method string `mapstructure:"method" yaml:"method"`
+	// Headers는 웹훅 요청 헤더입니다. 값의 ${VAR}는 환경 변수로 치환됩니다.
+	Headers map[string]string `mapstructure:"headers" yaml:"headers"`
+	// Payload는 text/template 형식의 페이로드입니다 (예: {"text": {{json .Data.execution_id}}}).
+	// 비어 있으면 이벤트 전체를 JSON으로 보냅니다.
+	Payload string `mapstructure:"payload" yaml:"payload"`
+	// TimeoutSeconds는 훅 실행 제한 시간(초)입니다. 기본값: 10.
+	TimeoutSeconds int `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+}
+
+// GetTimeout은 훅 실행 제한 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 10초를 반환합니다.
+func (c *EventHookConfig) GetTimeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// NotificationsConfig는 데스크톱 알림(macOS osascript, Linux notify-send) 설정입니다.
+// 이벤트 훅으로 연결되므로 event_hooks와 같은 이벤트 이름을 사용합니다.
+type NotificationsConfig struct {
+	// Enabled는 데스크톱 알림 사용 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Events는 알릴 이벤트입니다. 비어 있으면 approval_required, action_approval_pending,
+	// task_failed, reconnect_exhausted입니다.
+	Events []string `mapstructure:"events" yaml:"events"`
+	// DedupSeconds는 같은 대상의 같은 이벤트를 한 번만 알리는 구간(초)입니다. 기본값: 60.
+	DedupSeconds int `mapstructure:"dedup_seconds" yaml:"dedup_seconds"`
+}
+
+// GetDedupWindow는 알림 중복 제거 구간을 반환합니다.
+// 설정되지 않은 경우 기본값 60초를 반환합니다.
+func (c *NotificationsConfig) GetDedupWindow() time.Duration {
+	if c.DedupSeconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(c.DedupSeconds) * time.Second
+}
+
+// DelegationConfig는 Bridge 간 작업 위임 설정입니다.
+// 규칙에 일치하는 작업(예: Linux Bridge로 온 macOS 전용 빌드)을 백엔드를 통해
+// 같은 워크스페이스의 다른 Bridge로 넘깁니다.
+type DelegationConfig struct {
+	// Enabled는 작업 위임 활성화 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Rules는 위임 규칙입니다. 처음 일치한 규칙을 사용합니다.
+	Rules []DelegationRuleConfig `mapstructure:"rules" yaml:"rules"`
+	// FallbackLocal은 위임이 거절되거나 시간 초과될 때 로컬에서 실행할지 여부입니다. 기본값: true.
+	FallbackLocal bool `mapstructure:"fallback_local" yaml:"fallback_local"`
+	// TimeoutSeconds는 서버가 위임을 수락할 때까지 기다리는 시간(초)입니다. 기본값: 30.
+	TimeoutSeconds int `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+}
+
+// DelegationRuleConfig는 위임 규칙 하나입니다.
+type DelegationRuleConfig struct {
+	// TaskTypes는 대상 작업 유형입니다 (task, build, test, qa, cli). 비어 있으면 모든 유형입니다.
+	TaskTypes []string `mapstructure:"task_types" yaml:"task_types"`
+	// Commands는 대상 명령 목록입니다. "*"로 끝나면 접두사 일치 (예: "xcodebuild*").
+	Commands []string `mapstructure:"commands" yaml:"commands"`
+	// TargetPlatform은 작업을 실행할 Bridge의 OS입니다 (darwin, linux, windows).
+	TargetPlatform string `mapstructure:"target_platform" yaml:"target_platform"`
+}
+
+// GetTimeout은 위임 수락 대기 시간을 반환합니다. 기본값: 30초.
+func (d *DelegationConfig) GetTimeout() time.Duration {
+	if d.TimeoutSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(d.TimeoutSeconds) * time.Second
+}
+
+// RemoteSettingsConfig는 서버 주도 설정 변경(config_update)의 로컬 허용 범위입니다.
+// 여러 Bridge를 서버에서 관리할 때, 범위를 벗어난 변경은 거부되고 로컬 설정이 유지됩니다.
+type RemoteSettingsConfig struct {
+	// Enabled는 서버 주도 설정 변경 허용 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// MaxConcurrentTasks는 서버가 설정할 수 있는 동시 작업 수의 상한입니다. 기본값: 8.
+	MaxConcurrentTasks int `mapstructure:"max_concurrent_tasks" yaml:"max_concurrent_tasks"`
+	// AllowedTools는 서버가 승인 없이 실행하도록 추가할 수 있는 사용자 정의 도구 패턴입니다.
+	// "*"로 끝나면 접두사 일치입니다. 비어 있으면 서버가 도구 허용 목록을 바꿀 수 없습니다.
+	AllowedTools []string `mapstructure:"allowed_tools" yaml:"allowed_tools"`
+	// AllowedLogLevels는 서버가 설정할 수 있는 로그 레벨입니다. 기본값: debug, info, warn, error.
+	AllowedLogLevels []string `mapstructure:"allowed_log_levels" yaml:"allowed_log_levels"`
+}
+
+// GetMaxConcurrentTasks는 서버가 설정할 수 있는 동시 작업 수의 상한을 반환합니다. 기본값: 8.
+func (c *RemoteSettingsConfig) GetMaxConcurrentTasks() int {
+	if c.MaxConcurrentTasks <= 0 {
+		return 8
+	}
+	return c.MaxConcurrentTasks
+}
+
+// GetAllowedLogLevels는 서버가 설정할 수 있는 로그 레벨을 반환합니다.
+func (c *RemoteSettingsConfig) GetAllowedLogLevels() []string {
+	if len(c.AllowedLogLevels) == 0 {
+		return []string{"debug", "info", "warn", "error"}
+	}
+	return c.AllowedLogLevels
+}
+
+// CodegenSandboxConfig는 MCP 코드 생성 샌드박스(~/.acos/codegen-sandbox) 디스크 할당량 설정입니다.
+// 할당량을 넘으면 코드 생성 결과에 QUOTA_EXCEEDED 에러 코드로 보고합니다.
+type CodegenSandboxConfig struct {
+	// MaxTotalMB는 샌드박스 디렉토리 전체 최대 크기(MB)입니다. 기본값: 1024.
+	MaxTotalMB int `mapstructure:"max_total_mb" yaml:"max_total_mb"`
+	// MaxServiceMB는 생성 요청 하나의 샌드박스 최대 크기(MB)입니다. 기본값: 100.
+	MaxServiceMB int `mapstructure:"max_service_mb" yaml:"max_service_mb"`
+	// MaxAgeHours는 샌드박스 보관 시간(시간)입니다. 더 오래된 샌드박스는 다음 생성 시 삭제됩니다. 기본값: 24.
+	MaxAgeHours int `mapstructure:"max_age_hours" yaml:"max_age_hours"`
+}
+
+// GetMaxTotalBytes는 샌드박스 전체 최대 크기(바이트)를 반환합니다.
+// 설정되지 않은 경우 기본값 1024MB를 반환합니다.
+func (c *CodegenSandboxConfig) GetMaxTotalBytes() int64 {
+	if c.MaxTotalMB <= 0 {
+		return 1024 << 20
+	}
+	return int64(c.MaxTotalMB) << 20
+}
+
+// GetMaxServiceBytes는 샌드박스 하나의 최대 크기(바이트)를 반환합니다.
+// 설정되지 않은 경우 기본값 100MB를 반환합니다.
+func (c *CodegenSandboxConfig) GetMaxServiceBytes() int64 {
+	if c.MaxServiceMB <= 0 {
+		return 100 << 20
+	}
+	return int64(c.MaxServiceMB) << 20
+}
+
+// GetMaxAge는 샌드박스 보관 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 24시간을 반환합니다.
+func (c *CodegenSandboxConfig) GetMaxAge() time.Duration {
+	if c.MaxAgeHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.MaxAgeHours) * time.Hour
+}
+
+// MCPNotificationsConfig는 Bridge가 실행한 MCP 서버의 알림(로그 메시지, 리소스 변경, 프로세스 종료)을
+// 요약하여 mcp_server_event로 백엔드에 전달하는 설정입니다.
+type MCPNotificationsConfig struct {
+	// Enabled는 알림 전달 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// IntervalSeconds는 요약한 알림을 전송하는 주기(초)입니다. 기본값: 5.
+	IntervalSeconds int `mapstructure:"interval_seconds" yaml:"interval_seconds"`
+	// MaxEvents는 서버별 한 번에 전송할 최대 요약 이벤트 수입니다. 기본값: 50.
+	MaxEvents int `mapstructure:"max_events" yaml:"max_events"`
+	// MinLevel은 전달할 최소 로그 레벨입니다 (debug, info, notice, warning, error, ...). 기본값: info.
+	MinLevel string `mapstructure:"min_level" yaml:"min_level"`
+}
+
+// GetInterval은 알림 전송 주기를 반환합니다.
+// 설정되지 않은 경우 기본값 5초를 반환합니다.
+func (c *MCPNotificationsConfig) GetInterval() time.Duration {
+	if c.IntervalSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// CustomToolConfig는 서버에 노출할 사용자 정의 로컬 도구 설정입니다.
+// 사내 스크립트를 Autopus 에이전트 워크플로우에서 호출할 수 있게 합니다.
+// 명령은 셸 없이 직접 실행되며, 각 인자는 Go 템플릿({{.branch}})으로 도구 인자를 치환합니다.
+// 실행 전 security.action_approval.custom_tool 정책으로 승인 여부를 확인합니다.
+type CustomToolConfig struct {
+	// Name은 도구 이름입니다 (소문자로 시작, 소문자/숫자/밑줄, 최대 64자).
+	Name string `mapstructure:"name" yaml:"name"`
+	// Description은 에이전트에게 보여줄 도구 설명입니다.
+	Description string `mapstructure:"description" yaml:"description"`
+	// Command는 실행할 명령과 인자 템플릿입니다 (예: ["./scripts/deploy.sh", "--branch", "{{.branch}}"]).
+	Command []string `mapstructure:"command" yaml:"command"`
+	// WorkDir는 명령 실행 디렉토리입니다. 비어있으면 브릿지의 현재 디렉토리를 사용합니다.
+	WorkDir string `mapstructure:"work_dir" yaml:"work_dir"`
+	// Env는 명령에 추가할 환경 변수입니다.
+	Env map[string]string `mapstructure:"env" yaml:"env"`
+	// TimeoutSeconds는 실행 타임아웃(초)입니다. 기본값: 120.
+	TimeoutSeconds int `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+	// InputSchema는 도구 인자의 JSON Schema(object)입니다.
+	// properties의 type/enum/pattern과 required를 검증하며, 선언되지 않은 인자는 거부합니다.
+	// 설정 키는 소문자로 정규화되므로 인자 이름은 소문자(snake_case)를 사용합니다.
+	InputSchema map[string]interface{} `mapstructure:"input_schema" yaml:"input_schema"`
+}
+
+// GetWorkDir는 ~를 확장한 실행 디렉토리를 반환합니다.
+func (c *CustomToolConfig) GetWorkDir() string {
+	return expandPath(c.WorkDir)
+}
+
+// GetTimeout은 실행 타임아웃을 반환합니다.
+// 설정되지 않은 경우 기본값 120초를 반환합니다.
+func (c *CustomToolConfig) GetTimeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return 120 * time.Second
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// TemplateConfig는 이름으로 반복 실행하는 작업 템플릿 설정입니다.
+// 프롬프트의 {{변수}} 자리표시자는 실행 시 --var 값이나 Vars 기본값으로 치환됩니다.
+type TemplateConfig struct {
+	// Name은 템플릿 이름입니다 (예: "deploy-check").
+	Name string `mapstructure:"name" yaml:"name"`
+	// Description은 list_templates에 표시할 설명입니다.
+	Description string `mapstructure:"description" yaml:"description"`
+	// AgentID는 작업을 실행할 에이전트 ID입니다.
+	AgentID string `mapstructure:"agent_id" yaml:"agent_id"`
+	// Agent는 AgentID가 없을 때 이름으로 찾을 에이전트입니다.
+	Agent string `mapstructure:"agent" yaml:"agent"`
+	// Prompt는 {{변수}} 자리표시자를 포함한 작업 프롬프트입니다.
+	Prompt string `mapstructure:"prompt" yaml:"prompt"`
+	// Tools는 허용 도구 목록입니다.
+	Tools []string `mapstructure:"tools" yaml:"tools"`
+	// Model은 실행에 사용할 모델입니다.
+	Model string `mapstructure:"model" yaml:"model"`
+	// Provider는 실행에 사용할 프로바이더입니다.
+	Provider string `mapstructure:"provider" yaml:"provider"`
+	// Vars는 자리표시자 기본값입니다. 설정 키는 소문자로 정규화되므로 변수 이름은 소문자를 사용합니다.
+	Vars map[string]string `mapstructure:"vars" yaml:"vars"`
+}
+
+// GetTemplatesDir은 템플릿 파일 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/templates를 반환합니다.
+func (c *Config) GetTemplatesDir() string {
+	if c.TemplatesDir != "" {
+		return expandPath(c.TemplatesDir)
+	}
+	return DefaultTemplatesDir()
+}
+
+// DefaultTemplatesDir은 기본 템플릿 파일 디렉토리(~/.config/autopus/templates)를 반환합니다.
+func DefaultTemplatesDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "templates")
+}
+
+// TracingConfig는 OpenTelemetry 트레이싱 설정입니다.
+// 활성화하면 WebSocket 메시지 처리, 작업 실행, 프로바이더 호출, 백엔드 HTTP 호출, MCP 도구 호출
+// 스팬을 OTLP/HTTP로 사용자의 컬렉터에 전송합니다.
+type TracingConfig struct {
+	// Enabled는 트레이싱 활성화 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Endpoint는 OTLP/HTTP 컬렉터 주소입니다 (예: "localhost:4318" 또는 "https://otel.example.com:4318").
+	Endpoint string `mapstructure:"endpoint" yaml:"endpoint"`
+	// Insecure는 TLS 없이 전송할지 여부입니다. 엔드포인트가 http://로 시작하면 자동으로 적용됩니다.
+	Insecure bool `mapstructure:"insecure" yaml:"insecure"`
+	// Headers는 컬렉터 요청에 추가할 헤더입니다 (예: 인증 헤더).
+	Headers map[string]string `mapstructure:"headers" yaml:"headers"`
+	// ServiceName은 리소스의 service.name 값입니다. 기본값: "autopus-bridge".
+	ServiceName string `mapstructure:"service_name" yaml:"service_name"`
+	// SampleRatio는 루트 스팬 샘플링 비율(0~1)입니다. 상위 컨텍스트의 샘플링 결정은 그대로 따릅니다. 기본값: 1.
+	SampleRatio float64 `mapstructure:"sample_ratio" yaml:"sample_ratio"`
+}
+
+// CrashReportConfig는 크래시 리포트 설정입니다.
+// 브리지가 패닉으로 종료되면 최근 로그, 고루틴 덤프, 민감 정보를 제거한 설정, 버전 정보를
+// 로컬 번들로 저장합니다. 서버 업로드는 사용자가 동의한 경우에만 수행합니다.
+type CrashReportConfig struct {
+	// Enabled는 크래시 번들 저장 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Dir은 크래시 번들 저장 디렉토리입니다. 비어있으면 ~/.config/autopus/crash를 사용합니다.
+	Dir string `mapstructure:"dir" yaml:"dir"`
+	// AutoUpload는 connect 시작 시 업로드되지 않은 번들을 서버로 자동 업로드할지 여부입니다 (업로드 동의). 기본값: false.
+	AutoUpload bool `mapstructure:"auto_upload" yaml:"auto_upload"`
+	// MaxReports는 보관할 최대 번들 수입니다. 초과 시 오래된 번들부터 삭제합니다. 기본값: 20.
+	MaxReports int `mapstructure:"max_reports" yaml:"max_reports"`
+}
+
+// GetDir는 크래시 번들 저장 디렉토리를 반환합니다.
+// 설정되지 않은 경우 빈 문자열을 반환하여 기본 디렉토리를 사용합니다.
+func (c *CrashReportConfig) GetDir() string {
+	return expandPath(c.Dir)
+}
+
+// ResultCacheConfig는 완료된 작업 결과 캐시 설정입니다.
+// 서버가 타임아웃 후 같은 task_request를 재전송하면 다시 실행하지 않고 캐시된 결과로 응답합니다.
+type ResultCacheConfig struct {
+	// Enabled는 결과 캐시 활성화 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// WindowSeconds는 완료된 결과를 보관하는 시간(초)입니다. 기본값: 600.
+	WindowSeconds int `mapstructure:"window_seconds" yaml:"window_seconds"`
+	// MatchPromptHash는 ExecutionID와 함께 프롬프트 해시까지 일치해야 캐시를 사용할지 여부입니다. 기본값: true.
+	MatchPromptHash bool `mapstructure:"match_prompt_hash" yaml:"match_prompt_hash"`
+}
+
+// GetWindow는 결과 보관 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 10분을 반환합니다.
+func (r *ResultCacheConfig) GetWindow() time.Duration {
+	if r.WindowSeconds <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// ShutdownConfig는 정상 종료(SIGINT/SIGTERM) 시 작업 드레이닝 설정입니다.
+type ShutdownConfig struct {
+	// GracePeriodSeconds는 진행 중인 작업 완료를 기다리는 최대 시간(초)입니다. 기본값: 30.
+	// 유예 시간이 지나도 끝나지 않은 작업은 재시도 가능한 에러로 서버에 보고됩니다.
+	GracePeriodSeconds int `mapstructure:"grace_period_seconds" yaml:"grace_period_seconds"`
+}
+
+// GetGracePeriod는 종료 유예 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 30초를 반환합니다.
+func (s *ShutdownConfig) GetGracePeriod() time.Duration {
+	if s.GracePeriodSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(s.GracePeriodSeconds) * time.Second
+}
+
+// IdleModeConfig는 유휴 절전 모드 설정입니다.
+// 작업 없이 IdleMinutes가 지나면 하트비트 간격을 늘리고 프로바이더 프로세스와
+// Computer Use 워밍 컨테이너를 내렸다가, 다음 수신 메시지에서 즉시 복귀합니다.
+type IdleModeConfig struct {
+	// Enabled는 유휴 절전 모드 활성화 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// IdleMinutes는 절전 모드로 전환하기까지의 무작업 시간(분)입니다. 기본값: 15.
+	IdleMinutes int `mapstructure:"idle_minutes" yaml:"idle_minutes"`
+	// HeartbeatIntervalSeconds는 절전 중 하트비트 간격(초)입니다. 기본값: 300.
+	HeartbeatIntervalSeconds int `mapstructure:"heartbeat_interval_seconds" yaml:"heartbeat_interval_seconds"`
+}
+
+// GetIdleAfter는 절전 모드 전환까지의 무작업 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 15분을 반환합니다.
+func (c *IdleModeConfig) GetIdleAfter() time.Duration {
+	if c.IdleMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.IdleMinutes) * time.Minute
+}
+
+// GetHeartbeatInterval은 절전 중 하트비트 간격을 반환합니다.
+// 설정되지 않은 경우 기본값 5분을 반환합니다.
+func (c *IdleModeConfig) GetHeartbeatInterval() time.Duration {
+	if c.HeartbeatIntervalSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.HeartbeatIntervalSeconds) * time.Second
+}
+
+// FileSyncConfig는 워크스페이스 파일 동기화 채널 설정입니다.
+// 활성화하면 지정한 디렉토리의 변경을 감지해 파일 diff를 백엔드로 전송합니다.
+type FileSyncConfig struct {
+	// Enabled는 파일 동기화 활성화 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Directories는 감시할 디렉토리 목록입니다.
+	Directories []string `mapstructure:"directories" yaml:"directories"`
+	// IgnorePatterns는 기본 제외 패턴에 추가할 패턴 목록입니다.
+	IgnorePatterns []string `mapstructure:"ignore_patterns" yaml:"ignore_patterns"`
+	// UseGitignore는 각 디렉토리의 .gitignore 적용 여부입니다. 기본값: true.
+	UseGitignore bool `mapstructure:"use_gitignore" yaml:"use_gitignore"`
+	// MaxFileSizeKB는 동기화할 최대 파일 크기(KB)입니다. 기본값: 512.
+	MaxFileSizeKB int `mapstructure:"max_file_size_kb" yaml:"max_file_size_kb"`
+	// Extensions는 허용할 확장자 목록입니다 (예: ".go", ".md"). 비어있으면 전체 허용.
+	Extensions []string `mapstructure:"extensions" yaml:"extensions"`
+	// DebounceMs는 변경 배치 전송 전 대기 시간(밀리초)입니다. 기본값: 1000.
+	DebounceMs int `mapstructure:"debounce_ms" yaml:"debounce_ms"`
+}
+
+// GetMaxFileSize는 최대 파일 크기를 바이트 단위로 반환합니다.
+// 설정되지 않은 경우 기본값 512KB를 반환합니다.
+func (f *FileSyncConfig) GetMaxFileSize() int64 {
+	if f.MaxFileSizeKB <= 0 {
+		return 512 * 1024
+	}
+	return int64(f.MaxFileSizeKB) * 1024
+}
+
+// GetDebounce는 디바운스 대기 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 1초를 반환합니다.
+func (f *FileSyncConfig) GetDebounce() time.Duration {
+	if f.DebounceMs <= 0 {
+		return time.Second
+	}
+	return time.Duration(f.DebounceMs) * time.Millisecond
 }
 
 // RerankerConfig는 ONNX 리랭커 서비스 설정입니다.
@@ -52,6 +819,24 @@ type GitConfig struct {
 	CloneTimeoutSeconds int `mapstructure:"clone_timeout_seconds" yaml:"clone_timeout_seconds"`
 	// PushTimeoutSeconds는 git push 타임아웃 (초)입니다. 기본값: 120 (2분).
 	PushTimeoutSeconds int `mapstructure:"push_timeout_seconds" yaml:"push_timeout_seconds"`
+	// WorkDir는 서버의 git_request 작업이 허용되는 루트 디렉토리입니다.
+	// 비어있으면 {workspaces_base_dir}/workspaces (기본: ~/.autopus/workspaces)를 사용합니다.
+	WorkDir string `mapstructure:"work_dir" yaml:"work_dir"`
+	// TokenHosts는 AUTOPUS_GIT_TOKEN을 자격 증명으로 전달할 원격 호스트 목록입니다.
+	// 비어있으면 AUTOPUS_GIT_TOKEN을 사용하지 않습니다 (github.com, gitlab.com 전용 토큰은 영향 없음).
+	TokenHosts []string `mapstructure:"token_hosts" yaml:"token_hosts"`
+}
+
+// GetWorkDir는 git_request 작업 디렉토리를 반환합니다.
+// work_dir과 workspaces_base_dir이 모두 비어있으면 빈 문자열을 반환하여 실행기 기본값을 사용합니다.
+func (g *GitConfig) GetWorkDir() string {
+	if g.WorkDir != "" {
+		return expandPath(g.WorkDir)
+	}
+	if g.WorkspacesBaseDir != "" {
+		return filepath.Join(expandPath(g.WorkspacesBaseDir), "workspaces")
+	}
+	return ""
 }
 
 // ComputerUseConfig는 Computer Use 컨테이너 격리 설정입니다.
@@ -73,12 +858,197 @@ type ComputerUseConfig struct {
 	IdleTimeout string `mapstructure:"idle_timeout"`
 	// Network는 Docker 네트워크 이름입니다.
 	Network string `mapstructure:"network"`
+	// ImageVersion은 고정할 이미지 버전 태그입니다 (비어 있으면 Image의 태그 사용).
+	ImageVersion string `mapstructure:"image_version"`
+	// ImageDigest는 고정할 이미지 digest입니다 (예: "sha256:...").
+	// 설정되면 풀한 이미지의 digest를 검증합니다.
+	ImageDigest string `mapstructure:"image_digest"`
+	// Platform은 이미지 플랫폼입니다 ("auto", "linux/amd64", "linux/arm64").
+	Platform string `mapstructure:"platform"`
+	// UpdateCheckInterval은 이미지 업데이트 확인 주기입니다 (예: "24h", "0"이면 비활성화).
+	UpdateCheckInterval string `mapstructure:"update_check_interval"`
+	// MaxSessions는 최대 동시 세션 수입니다. 초과한 세션 시작 요청은 대기열에서 기다립니다.
+	MaxSessions int `mapstructure:"max_sessions"`
+	// QueueTimeout은 세션 시작 요청의 최대 대기 시간입니다 (예: "2m").
+	QueueTimeout string `mapstructure:"queue_timeout"`
+	// SessionMemory는 컨테이너 세션별 메모리 상한입니다 (예: "512m", 비어 있으면 container_memory).
+	SessionMemory string `mapstructure:"session_memory"`
+	// SessionCPU는 컨테이너 세션별 CPU 상한입니다 (예: "0.5", 비어 있으면 container_cpu).
+	SessionCPU string `mapstructure:"session_cpu"`
+	// ProfileDir은 persist_profile 세션의 브라우저 프로필(쿠키, localStorage) 저장 디렉토리입니다.
+	// 비어 있으면 ~/.config/autopus/browser-profiles를 사용합니다.
+	ProfileDir string `mapstructure:"profile_dir"`
+	// ProfileEncryptionKeyEnv는 브라우저 프로필 암호화 키를 담은 환경변수 이름입니다.
+	// 설정된 환경변수에 값이 있으면 프로필을 AES-256-GCM으로 암호화하여 저장합니다.
+	ProfileEncryptionKeyEnv string `mapstructure:"profile_encryption_key_env"`
 }
 
 // SecurityConfig는 보안 관련 설정입니다.
 type SecurityConfig struct {
 	// Sandbox는 작업 디렉토리 샌드박스 설정입니다.
 	Sandbox SandboxConfig `yaml:"sandbox" mapstructure:"sandbox"`
+	// ActionApproval은 서버 주도 위험 작업의 로컬 승인 설정입니다.
+	ActionApproval ActionApprovalConfig `yaml:"action_approval" mapstructure:"action_approval"`
+	// WorkDirIsolation은 작업 디렉토리 격리 실행 설정입니다.
+	WorkDirIsolation WorkDirIsolationConfig `yaml:"workdir_isolation" mapstructure:"workdir_isolation"`
+	// Redaction은 로그에 남는 프롬프트와 서버로 보내는 작업 결과의 시크릿/개인정보 가림 설정입니다.
+	Redaction RedactionConfig `yaml:"redaction" mapstructure:"redaction"`
+	// ProviderSandbox는 프로바이더 도구 호출의 파일 쓰기 범위 제한 설정입니다.
+	ProviderSandbox ProviderSandboxConfig `yaml:"provider_sandbox" mapstructure:"provider_sandbox"`
+	// ReplayProtection은 수신 메시지 재전송(replay) 방지 설정입니다.
+	ReplayProtection ReplayProtectionConfig `yaml:"replay_protection" mapstructure:"replay_protection"`
+}
+
+// ReplayProtectionConfig는 서명된 수신 메시지의 재전송 방지 설정입니다.
+// HMAC 서명은 무결성만 보장하므로, 최근 처리한 메시지 ID와 타임스탬프로
+// 오래되었거나 이미 처리한 작업 요청이 다시 실행되지 않도록 합니다.
+type ReplayProtectionConfig struct {
+	// Enabled는 재전송 방지 활성화 여부입니다. 기본값: true.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// WindowSeconds는 메시지 타임스탬프 허용 범위(초)입니다. 메시지 ID는 그 두 배 동안 기억합니다. 기본값: 300.
+	// 서버와 Bridge의 시계 차이가 이 값보다 크면 정상 메시지도 거부됩니다.
+	WindowSeconds int `yaml:"window_seconds" mapstructure:"window_seconds"`
+	// Action은 재전송으로 판단한 메시지 처리 방식입니다: "reject"(기본값, 처리하지 않음), "warn"(경고만 기록하고 처리).
+	Action string `yaml:"action" mapstructure:"action"`
+	// MaxEntries는 기억할 메시지 ID 최대 개수입니다. 넘으면 오래된 ID부터 잊습니다. 기본값: 10000.
+	MaxEntries int `yaml:"max_entries" mapstructure:"max_entries"`
+}
+
+// GetWindow는 타임스탬프 허용 범위를 반환합니다.
+// 설정되지 않은 경우 기본값 5분을 반환합니다.
+func (r *ReplayProtectionConfig) GetWindow() time.Duration {
+	if r.WindowSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// ProviderSandboxConfig는 프로바이더가 실행하는 도구 호출(command_execution, file_change, Write, Edit 등)의
+// 파일 쓰기 범위 설정입니다. 작업 디렉토리와 시스템 임시 디렉토리는 항상 허용됩니다.
+// 파일 경로가 명확한 도구 호출은 범위를 벗어나면 거부하고, 셸 명령은 쓰기 대상을 추정하여 경고만 남깁니다.
+type ProviderSandboxConfig struct {
+	// Enabled는 쓰기 범위 제한 여부입니다. 기본값: false.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// AllowedWriteRoots는 작업 디렉토리 외에 쓰기를 허용할 디렉토리 목록입니다 (~ 확장 지원).
+	AllowedWriteRoots []string `yaml:"allowed_write_roots" mapstructure:"allowed_write_roots"`
+}
+
+// RedactionConfig는 작업 프롬프트 로그와 task_result 출력에 적용하는 가림 규칙 설정입니다.
+// 기본 규칙(API 키, 토큰, 개인 키 등)에 사용자 정규식과 엔트로피 기반 탐지를 더할 수 있으며,
+// 가려진 내용 대신 규칙별 가림 횟수만 서버에 보고합니다.
+type RedactionConfig struct {
+	// Enabled는 가림 적용 여부입니다. 기본값: true.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// Rules는 기본 규칙에 더할 사용자 정규식 규칙입니다.
+	Rules []RedactionRuleConfig `yaml:"rules" mapstructure:"rules"`
+	// Emails는 이메일 주소(개인정보)도 가릴지 여부입니다. 기본값: false.
+	Emails bool `yaml:"emails" mapstructure:"emails"`
+	// Entropy는 규칙에 없는 무작위 문자열(시크릿 추정)을 가릴지 여부입니다. 기본값: true.
+	Entropy bool `yaml:"entropy" mapstructure:"entropy"`
+	// EntropyThreshold는 시크릿으로 판단할 문자당 최소 섀넌 엔트로피(bit)입니다. 기본값: 4.2.
+	EntropyThreshold float64 `yaml:"entropy_threshold" mapstructure:"entropy_threshold"`
+	// EntropyMinLength는 엔트로피 검사 대상 문자열의 최소 길이입니다. 기본값: 24.
+	EntropyMinLength int `yaml:"entropy_min_length" mapstructure:"entropy_min_length"`
+}
+
+// RedactionRuleConfig는 사용자 정의 가림 규칙입니다.
+type RedactionRuleConfig struct {
+	// Name은 가림 횟수 보고와 치환 표시([REDACTED:name])에 쓰는 규칙 이름입니다.
+	Name string `yaml:"name" mapstructure:"name"`
+	// Pattern은 가릴 문자열의 정규식입니다 (Go RE2 문법).
+	Pattern string `yaml:"pattern" mapstructure:"pattern"`
+}
+
+// GetEntropyThreshold는 엔트로피 임계값을 반환합니다. 기본값: 4.2.
+func (r *RedactionConfig) GetEntropyThreshold() float64 {
+	if r.EntropyThreshold <= 0 {
+		return 4.2
+	}
+	return r.EntropyThreshold
+}
+
+// GetEntropyMinLength는 엔트로피 검사 최소 길이를 반환합니다. 기본값: 24.
+func (r *RedactionConfig) GetEntropyMinLength() int {
+	if r.EntropyMinLength <= 0 {
+		return 24
+	}
+	return r.EntropyMinLength
+}
+
+// ActionApprovalConfig는 서버가 요청한 위험 작업(cli_request, mcp_deploy, computer_use, custom_tool, git_request)을
+// 실행 전에 로컬에서 승인받도록 하는 설정입니다.
+// 모드 값: "auto"(자동 실행, 기본값), "prompt"(터미널에서 확인, 헤드리스면 자동 거부), "deny"(허용 목록 외 거부).
+type ActionApprovalConfig struct {
+	// CLIRequest는 cli_request 승인 모드입니다.
+	CLIRequest string `yaml:"cli_request" mapstructure:"cli_request"`
+	// MCPDeploy는 mcp_deploy 승인 모드입니다.
+	MCPDeploy string `yaml:"mcp_deploy" mapstructure:"mcp_deploy"`
+	// ComputerUse는 computer_use 세션 시작 승인 모드입니다.
+	ComputerUse string `yaml:"computer_use" mapstructure:"computer_use"`
+	// ApplyChanges는 격리 실행에서 생긴 변경을 원본 work_dir에 반영할 때의 승인 모드입니다.
+	ApplyChanges string `yaml:"apply_changes" mapstructure:"apply_changes"`
+	// CustomTool은 사용자 정의 로컬 도구(custom_tools) 실행 승인 모드입니다.
+	CustomTool string `yaml:"custom_tool" mapstructure:"custom_tool"`
+	// GitRequest는 git_request의 commit/push 승인 모드입니다.
+	GitRequest string `yaml:"git_request" mapstructure:"git_request"`
+	// AllowedCommands는 승인 없이 실행할 명령 목록입니다. "*"로 끝나면 접두사 일치.
+	AllowedCommands []string `yaml:"allowed_commands" mapstructure:"allowed_commands"`
+	// AllowedServices는 승인 없이 배포할 MCP 서비스 이름 목록입니다. "*"로 끝나면 접두사 일치.
+	AllowedServices []string `yaml:"allowed_services" mapstructure:"allowed_services"`
+	// AllowedURLs는 승인 없이 시작할 Computer Use 세션 URL 목록입니다. "*"로 끝나면 접두사 일치.
+	AllowedURLs []string `yaml:"allowed_urls" mapstructure:"allowed_urls"`
+	// AllowedTools는 승인 없이 실행할 사용자 정의 도구 이름 목록입니다. "*"로 끝나면 접두사 일치.
+	AllowedTools []string `yaml:"allowed_tools" mapstructure:"allowed_tools"`
+	// AllowedRepos는 승인 없이 commit/push할 레포 경로(git.work_dir 기준) 목록입니다. "*"로 끝나면 접두사 일치.
+	AllowedRepos []string `yaml:"allowed_repos" mapstructure:"allowed_repos"`
+	// PromptTimeoutSeconds는 터미널 승인 대기 시간(초)입니다. 기본값: 60.
+	PromptTimeoutSeconds int `yaml:"prompt_timeout_seconds" mapstructure:"prompt_timeout_seconds"`
+}
+
+// GetPromptTimeout은 터미널 승인 대기 시간을 반환합니다.
+// 설정되지 않은 경우 기본값 60초를 반환합니다.
+func (a *ActionApprovalConfig) GetPromptTimeout() time.Duration {
+	if a.PromptTimeoutSeconds <= 0 {
+		return 60 * time.Second
+	}
+	return time.Duration(a.PromptTimeoutSeconds) * time.Second
+}
+
+// RequiresPrompt는 터미널 승인이 필요한 작업 유형이 하나라도 있는지 반환합니다.
+func (a *ActionApprovalConfig) RequiresPrompt() bool {
+	for _, mode := range []string{a.CLIRequest, a.MCPDeploy, a.ComputerUse, a.ApplyChanges, a.CustomTool, a.GitRequest} {
+		if strings.EqualFold(strings.TrimSpace(mode), "prompt") {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkDirIsolationConfig는 작업을 work_dir의 임시 복사본에서 실행하는 격리 설정입니다.
+// 작업이 끝나면 변경 사항의 diff를 만들고, security.action_approval.apply_changes 정책에 따라
+// 원본에 반영합니다.
+type WorkDirIsolationConfig struct {
+	// Enabled는 격리 실행 활성화 여부입니다. 기본값: false.
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	// BaseDir는 복사본을 만들 디렉토리입니다. 비어있으면 시스템 임시 디렉토리를 사용합니다.
+	BaseDir string `yaml:"base_dir" mapstructure:"base_dir"`
+	// Exclude는 복사하지 않을 경로 패턴입니다 (예: "node_modules", "*.log").
+	Exclude []string `yaml:"exclude" mapstructure:"exclude"`
+	// MaxSizeMB는 복사할 수 있는 최대 크기(MB)입니다. 0이면 제한하지 않습니다.
+	MaxSizeMB int `yaml:"max_size_mb" mapstructure:"max_size_mb"`
+}
+
+// GetBaseDir는 ~를 확장한 복사본 디렉토리를 반환합니다.
+func (w *WorkDirIsolationConfig) GetBaseDir() string {
+	return expandPath(w.BaseDir)
+}
+
+// GetMaxSize는 복사 최대 크기를 바이트 단위로 반환합니다. 0이면 제한하지 않습니다.
+func (w *WorkDirIsolationConfig) GetMaxSize() int64 {
+	if w.MaxSizeMB <= 0 {
+		return 0
+	}
+	return int64(w.MaxSizeMB) * 1024 * 1024
 }
 
 // SandboxConfig는 파일시스템 샌드박스 설정입니다.
@@ -96,24 +1066,191 @@ type SandboxConfig struct {
 
 // ServerConfig는 서버 연결 설정입니다.
 type ServerConfig struct {
-	// URL은 WebSocket 서버 주소입니다.
-	URL string `mapstructure:"url"`
+	// URL은 WebSocket 서버 주소입니다 (server.ws_url, 이전 키: server.url).
+	URL string `mapstructure:"ws_url"`
 	// TimeoutSeconds는 연결 타임아웃(초)입니다.
 	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// Compression은 WebSocket 메시지 압축 설정입니다.
+	Compression CompressionConfig `mapstructure:"compression"`
+	// Transport는 전송 방식입니다: "auto"(기본, WebSocket이 차단되면 HTTP 롱폴링으로 전환),
+	// "websocket"(WebSocket만 사용), "http"(HTTP 롱폴링만 사용).
+	Transport string `mapstructure:"transport"`
+	// HTTPURL은 HTTP 롱폴링 엔드포인트 주소입니다. 비어 있으면 ws_url에서 유도합니다.
+	HTTPURL string `mapstructure:"http_url"`
+}
+
+// GetTransport는 전송 방식을 반환합니다. 알 수 없는 값이면 "auto"를 반환합니다.
+func (s *ServerConfig) GetTransport() string {
+	switch s.Transport {
+	case "websocket", "http":
+		return s.Transport
+	default:
+		return "auto"
+	}
+}
+
+// CompressionConfig는 WebSocket 메시지 압축 설정입니다.
+type CompressionConfig struct {
+	// Enabled는 permessage-deflate 협상 활성화 여부입니다. 기본값: true.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// GzipThresholdKB는 애플리케이션 레벨 gzip 압축 임계값(KB)입니다.
+	// 페이로드가 이 크기를 초과하면 gzip으로 압축하고 envelope에 encoding을 표시합니다.
+	// 0이면 비활성화됩니다. 기본값: 0.
+	GzipThresholdKB int `mapstructure:"gzip_threshold_kb" yaml:"gzip_threshold_kb"`
+}
+
+// GetGzipThreshold는 gzip 압축 임계값을 바이트 단위로 반환합니다.
+// 0이면 애플리케이션 레벨 압축이 비활성화됩니다.
+func (c *CompressionConfig) GetGzipThreshold() int {
+	if c.GzipThresholdKB <= 0 {
+		return 0
+	}
+	return c.GzipThresholdKB * 1024
 }
 
 // AuthConfig는 인증 설정입니다.
 type AuthConfig struct {
 	// TokenFile은 JWT 토큰을 저장할 파일 경로입니다.
 	TokenFile string `mapstructure:"token_file"`
+	// SSO는 엔터프라이즈 SSO(OIDC) 로그인 설정입니다.
+	SSO SSOConfig `mapstructure:"sso"`
+	// Expiry는 실행 중 refresh token 만료 경고와 재인증 설정입니다.
+	Expiry CredentialExpiryConfig `mapstructure:"expiry"`
+}
+
+// CredentialExpiryConfig는 connect 실행 중 refresh token 만료 처리 설정입니다.
+// 만료가 가까워지면 재인증 링크와 함께 경고하고, 만료되면 하트비트로 서버에 알립니다.
+type CredentialExpiryConfig struct {
+	// WarnHours는 refresh token 만료 몇 시간 전부터 경고할지입니다. 기본값: 72.
+	WarnHours int `mapstructure:"warn_hours" yaml:"warn_hours"`
+	// PauseIntake가 true이면 refresh token이 만료된 동안 새 작업을 재시도 가능한 에러로 거절하고,
+	// 재인증이 끝나면 다시 받습니다. 기본값: false.
+	PauseIntake bool `mapstructure:"pause_intake" yaml:"pause_intake"`
+}
+
+// GetWarnBefore는 만료 경고를 시작할 시점(만료 전)을 반환합니다.
+// 설정되지 않은 경우 기본값 72시간을 반환합니다.
+func (e *CredentialExpiryConfig) GetWarnBefore() time.Duration {
+	if e.WarnHours <= 0 {
+		return 72 * time.Hour
+	}
+	return time.Duration(e.WarnHours) * time.Hour
+}
+
+// SSOConfig는 워크스페이스 IdP를 통한 SSO 로그인 설정입니다.
+// Workspace가 설정되어 있으면 'login'은 기본적으로 SSO로 로그인합니다.
+// Issuer/ClientID를 비워두면 서버에 등록된 워크스페이스 SSO 설정을 사용합니다.
+type SSOConfig struct {
+	// Workspace는 SSO로 로그인할 워크스페이스 slug입니다.
+	Workspace string `mapstructure:"workspace" yaml:"workspace"`
+	// Issuer는 OIDC issuer URL입니다 (서버 설정 대신 사용할 때만 지정).
+	Issuer string `mapstructure:"issuer" yaml:"issuer"`
+	// ClientID는 IdP에 등록된 public client ID입니다 (서버 설정 대신 사용할 때만 지정).
+	ClientID string `mapstructure:"client_id" yaml:"client_id"`
+	// Scopes는 요청할 스코프입니다. 기본값: openid, email, profile, offline_access.
+	Scopes []string `mapstructure:"scopes" yaml:"scopes"`
+	// Audience는 IdP가 요구하는 경우의 audience 파라미터입니다.
+	Audience string `mapstructure:"audience" yaml:"audience"`
+	// Flow는 인증 방식입니다: "auto"(기본), "browser"(PKCE), "device"(디바이스 코드).
+	Flow string `mapstructure:"flow" yaml:"flow"`
+}
+
+// GetFlow는 SSO 인증 방식을 반환합니다. 알 수 없는 값이면 "auto"를 반환합니다.
+func (s *SSOConfig) GetFlow() string {
+	switch s.Flow {
+	case "browser", "device":
+		return s.Flow
+	default:
+		return "auto"
+	}
 }
 
 // ProvidersConfig는 AI 프로바이더 설정입니다.
 type ProvidersConfig struct {
-	Claude   ProviderConfig  `mapstructure:"claude"`
-	Gemini   ProviderConfig  `mapstructure:"gemini"`
-	Codex    ProviderConfig  `mapstructure:"codex"`
-	Override OverrideConfig  `mapstructure:"override"`
+	Claude   ProviderConfig `mapstructure:"claude"`
+	Gemini   ProviderConfig `mapstructure:"gemini"`
+	Codex    ProviderConfig `mapstructure:"codex"`
+	Override OverrideConfig `mapstructure:"override"`
+	Warmup   WarmupConfig   `mapstructure:"warmup"`
+	// OpenAICompat은 OpenAI 호환 HTTP API 프로바이더 설정입니다 (Ollama, vLLM 등).
+	OpenAICompat OpenAICompatConfig `mapstructure:"openai_compat"`
+}
+
+// OpenAICompatConfig는 OpenAI 호환 Chat Completions 엔드포인트 프로바이더 설정입니다.
+// 로컬 CLI 없이 호스팅 또는 자체 호스팅 모델로 작업을 실행할 때 사용합니다.
+type OpenAICompatConfig struct {
+	// Enabled는 프로바이더 활성화 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Name은 레지스트리에 등록할 프로바이더 이름입니다. 기본값: "openai-compat".
+	Name string `mapstructure:"name" yaml:"name"`
+	// BaseURL은 API 기본 URL입니다 (예: "http://localhost:11434/v1").
+	BaseURL string `mapstructure:"base_url" yaml:"base_url"`
+	// APIKeyEnv는 API 키를 가져올 환경변수 이름입니다. 비어 있으면 인증 없이 요청합니다.
+	// REQ-N-01: API 키를 평문으로 파일에 저장하지 않음
+	APIKeyEnv string `mapstructure:"api_key_env" yaml:"api_key_env"`
+	// DefaultModel은 모델이 지정되지 않은 요청에 사용할 모델입니다.
+	DefaultModel string `mapstructure:"default_model" yaml:"default_model"`
+	// Models는 이 프로바이더로 라우팅할 모델 목록입니다.
+	Models []string `mapstructure:"models" yaml:"models"`
+	// TimeoutSeconds는 요청 타임아웃(초)입니다. 기본값: 300.
+	TimeoutSeconds int `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+}
+
+// GetAPIKey는 환경변수에서 API 키를 가져옵니다.
+func (o *OpenAICompatConfig) GetAPIKey() string {
+	if o.APIKeyEnv == "" {
+		return ""
+	}
+	return os.Getenv(o.APIKeyEnv)
+}
+
+// GetName은 프로바이더 이름을 반환합니다.
+// 설정되지 않은 경우 기본값 "openai-compat"을 반환합니다.
+func (o *OpenAICompatConfig) GetName() string {
+	if o.Name == "" {
+		return "openai-compat"
+	}
+	return o.Name
+}
+
+// GetTimeout은 요청 타임아웃을 반환합니다.
+// 설정되지 않은 경우 기본값 300초를 반환합니다.
+func (o *OpenAICompatConfig) GetTimeout() time.Duration {
+	if o.TimeoutSeconds <= 0 {
+		return 300 * time.Second
+	}
+	return time.Duration(o.TimeoutSeconds) * time.Second
+}
+
+// IsAvailable은 OpenAI 호환 프로바이더를 사용할 수 있는지 확인합니다.
+// 활성화되어 있고 base_url과 모델이 설정되어 있어야 하며,
+// api_key_env가 지정된 경우 해당 환경변수도 설정되어 있어야 합니다.
+func (o *OpenAICompatConfig) IsAvailable() bool {
+	if !o.Enabled || o.BaseURL == "" {
+		return false
+	}
+	if o.DefaultModel == "" && len(o.Models) == 0 {
+		return false
+	}
+	return o.APIKeyEnv == "" || o.GetAPIKey() != ""
+}
+
+// WarmupConfig는 connect 시점의 프로바이더 사전 기동 설정입니다.
+// 활성화하면 설정된 프로바이더를 병렬로 미리 시작하여 첫 작업의 콜드 스타트 지연을 줄입니다.
+type WarmupConfig struct {
+	// Enabled는 warm-up 활성화 여부입니다. 기본값: false.
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// TimeoutSeconds는 전체 warm-up 타임아웃(초)입니다. 기본값: 30.
+	TimeoutSeconds int `mapstructure:"timeout_seconds" yaml:"timeout_seconds"`
+}
+
+// GetTimeout은 warm-up 타임아웃을 반환합니다.
+// 설정되지 않은 경우 기본값 30초를 반환합니다.
+func (w *WarmupConfig) GetTimeout() time.Duration {
+	if w.TimeoutSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(w.TimeoutSeconds) * time.Second
 }
 
 // OverrideConfig는 모든 task_request를 특정 프로바이더/모델로 강제하는 설정입니다.
@@ -166,6 +1303,12 @@ type ProviderConfig struct {
 	HookServerPort int `mapstructure:"hook_server_port"`
 	// ApprovalTimeout is the approval timeout in seconds (default: 300).
 	ApprovalTimeout int `mapstructure:"approval_timeout"`
+	// TurnRetries는 App Server 모드에서 턴 진행 중 스트림이 끊겼을 때 턴을 다시 시도하는 최대 횟수입니다.
+	// 기본값: 2. 0이면 재시도하지 않습니다.
+	TurnRetries *int `mapstructure:"turn_retries"`
+	// Framing은 App Server 모드의 JSON-RPC 메시지 프레이밍입니다.
+	// "line": 줄바꿈 구분 JSON (기본값), "content-length": LSP 방식 Content-Length 헤더
+	Framing string `mapstructure:"framing"`
 }
 
 // LoggingConfig는 로깅 설정입니다.
@@ -256,6 +1399,18 @@ func (p *ProviderConfig) GetApprovalPolicy() string {
 	return p.ApprovalPolicy
 }
 
+// GetTurnRetries는 App Server 턴 재시도 횟수를 반환합니다.
+// 설정되지 않았으면 기본값 2를, 음수이면 0을 반환합니다.
+func (p *ProviderConfig) GetTurnRetries() int {
+	if p.TurnRetries == nil {
+		return 2
+	}
+	if *p.TurnRetries < 0 {
+		return 0
+	}
+	return *p.TurnRetries
+}
+
 // GetExecutionMode returns the execution mode.
 // Returns "auto-execute" as default if not set.
 func (p *ProviderConfig) GetExecutionMode() string {
@@ -396,7 +1551,7 @@ func (c *Config) Validate() error {
 	// REQ-S-05: 설정된 AI 프로바이더가 없으면 연결 시도를 거부
 	// Claude: 모드에 따라 API 키 또는 CLI 가용성 확인
 	// Gemini: API 키만 확인
-	if !c.Providers.Claude.IsAvailable() && !c.Providers.Gemini.IsAvailable() && !c.Providers.Codex.IsAvailable() {
+	if !c.Providers.Claude.IsAvailable() && !c.Providers.Gemini.IsAvailable() && !c.Providers.Codex.IsAvailable() && !c.Providers.OpenAICompat.IsAvailable() {
 		return fmt.Errorf("AI 프로바이더가 설정되지 않았습니다. ANTHROPIC_API_KEY, GEMINI_API_KEY, 또는 OPENAI_API_KEY 환경변수를 설정하거나, claude/gemini/codex CLI를 설치하세요")
 	}
 
@@ -492,6 +1647,64 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("max_attempts는 0 이상이어야 합니다 (0 = 무제한)")
 	}
 
+	// 프로바이더 기록/재생 모드 검증
+	switch c.ProviderReplay.GetMode() {
+	case "off", "record", "replay":
+	default:
+		return fmt.Errorf("유효하지 않은 provider_replay.mode: %s (off, record, replay 중 하나)", c.ProviderReplay.Mode)
+	}
+
+	// 워크스페이스별 동시 실행 한도 검증 (0 = 무제한)
+	if c.Concurrency.PerWorkspace < 0 {
+		return fmt.Errorf("concurrency.per_workspace는 0 이상이어야 합니다 (0 = 무제한)")
+	}
+	for workspace, limit := range c.Concurrency.Workspaces {
+		if limit < 0 {
+			return fmt.Errorf("concurrency.workspaces.%s는 0 이상이어야 합니다 (0 = 무제한)", workspace)
+		}
+	}
+
+	// 타임아웃 계층 검증 (0 = 기본값 사용)
+	if c.Timeouts.DefaultSeconds < 0 {
+		return fmt.Errorf("timeouts.default_seconds는 0 이상이어야 합니다")
+	}
+	if c.Timeouts.MaxSeconds < 0 {
+		return fmt.Errorf("timeouts.max_seconds는 0 이상이어야 합니다 (0 = 무제한)")
+	}
+	for msgType, seconds := range c.Timeouts.MessageTypes {
+		if seconds < 0 {
+			return fmt.Errorf("timeouts.message_types.%s는 0 이상이어야 합니다", msgType)
+		}
+	}
+	for tool, seconds := range c.Timeouts.MCPTools {
+		if seconds < 0 {
+			return fmt.Errorf("timeouts.mcp_tools.%s는 0 이상이어야 합니다", tool)
+		}
+	}
+
+	// 크기 제한 검증 (0 = 기본값, max_prompt_bytes는 0 = 무제한)
+	if c.Limits.MaxPromptBytes < 0 {
+		return fmt.Errorf("limits.max_prompt_bytes는 0 이상이어야 합니다 (0 = 무제한)")
+	}
+	if c.Limits.MaxOutputBytes < 0 {
+		return fmt.Errorf("limits.max_output_bytes는 0 이상이어야 합니다")
+	}
+	if c.Limits.MaxResultBytes < 0 || c.Limits.MaxResultBytes >= maxMessageBytes {
+		return fmt.Errorf("limits.max_result_bytes는 0 이상 %d 미만이어야 합니다 (WebSocket 메시지 한도)", maxMessageBytes)
+	}
+	if c.Limits.OverflowMaxAgeDays < 0 {
+		return fmt.Errorf("limits.overflow_max_age_days는 0 이상이어야 합니다")
+	}
+
+	// 상태 저장소 암호화 방식 검증
+	if c.StateStore.Enabled {
+		switch c.StateStore.GetEncryption() {
+		case "keychain", "env", "none":
+		default:
+			return fmt.Errorf("유효하지 않은 state_store.encryption: %s (keychain, env, none 중 하나)", c.StateStore.Encryption)
+		}
+	}
+
 	return nil
 }
 
@@ -509,6 +1722,9 @@ func (c *Config) GetAvailableProviders() []string {
 	if c.Providers.Codex.IsAvailable() {
 		providers = append(providers, "openai")
 	}
+	if c.Providers.OpenAICompat.IsAvailable() {
+		providers = append(providers, c.Providers.OpenAICompat.GetName())
+	}
 	return providers
 }
 
@@ -539,6 +1755,44 @@ func (c *ComputerUseConfig) IsContainerMode() bool {
 	return mode == "container" || mode == "auto"
 }
 
+// GetProfileDir은 브라우저 프로필 저장 디렉토리를 반환합니다.
+// 설정되지 않은 경우 ~/.config/autopus/browser-profiles를 반환합니다.
+func (c *ComputerUseConfig) GetProfileDir() string {
+	if c.ProfileDir != "" {
+		return expandPath(c.ProfileDir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "browser-profiles")
+}
+
+// GetProfileEncryptionKey는 환경변수에서 브라우저 프로필 암호화 키를 반환합니다.
+// 환경변수 이름이 설정되지 않았거나 값이 없으면 빈 문자열(암호화 안 함)을 반환합니다.
+func (c *ComputerUseConfig) GetProfileEncryptionKey() string {
+	if c.ProfileEncryptionKeyEnv == "" {
+		return ""
+	}
+	return os.Getenv(c.ProfileEncryptionKeyEnv)
+}
+
+// GetUpdateCheckInterval은 샌드박스 이미지 업데이트 확인 주기를 반환합니다.
+// 설정되지 않았거나 파싱할 수 없으면 기본값 24시간, "0"이면 0(비활성화)을 반환합니다.
+func (c *ComputerUseConfig) GetUpdateCheckInterval() time.Duration {
+	switch strings.TrimSpace(c.UpdateCheckInterval) {
+	case "":
+		return 24 * time.Hour
+	case "0", "off", "false":
+		return 0
+	}
+	d, err := time.ParseDuration(c.UpdateCheckInterval)
+	if err != nil || d < 0 {
+		return 24 * time.Hour
+	}
+	return d
+}
+
 // expandPath는 ~를 홈 디렉토리로 확장합니다.
 func expandPath(path string) string {
 	if path == "" {
@@ -577,3 +1831,13 @@ func DefaultConfigPath() string {
 	}
 	return filepath.Join(home, ".config", "autopus", "config.yaml")
 }
+
+// LegacyConfigPath는 이전 버전(Local Agent Bridge)의 설정 파일 경로를 반환합니다.
+// autopus config validate --migrate가 현재 경로에 설정 파일이 없을 때 이 파일을 가져옵니다.
+func LegacyConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "local-agent-bridge", "config.yaml")
+}
@@ -3,7 +3,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 // TestProviderConfig_GetAPIKey는 환경변수에서 API 키를 가져오는 기능을 테스트합니다.
@@ -77,6 +81,30 @@ func TestProviderConfig_HasAPIKey(t *testing.T) {
 	}
 }
 
+// TestProviderConfig_GetTurnRetries는 App Server 턴 재시도 횟수 기본값을 테스트합니다.
+func TestProviderConfig_GetTurnRetries(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	tests := []struct {
+		name     string
+		retries  *int
+		expected int
+	}{
+		{name: "미설정 시 기본값", retries: nil, expected: 2},
+		{name: "0이면 재시도 안 함", retries: intPtr(0), expected: 0},
+		{name: "설정값 사용", retries: intPtr(5), expected: 5},
+		{name: "음수는 0", retries: intPtr(-1), expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ProviderConfig{TurnRetries: tt.retries}
+			if got := p.GetTurnRetries(); got != tt.expected {
+				t.Errorf("GetTurnRetries() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
 // TestConfig_Validate는 설정 검증을 테스트합니다.
 func TestConfig_Validate(t *testing.T) {
 	// Claude API 키 설정
@@ -253,6 +281,236 @@ func TestExpandPath(t *testing.T) {
 	}
 }
 
+// TestComputerUseConfig_GetUpdateCheckInterval은 샌드박스 이미지 업데이트 확인 주기 파싱을 테스트합니다.
+func TestComputerUseConfig_GetUpdateCheckInterval(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"", 24 * time.Hour},
+		{"12h", 12 * time.Hour},
+		{"0", 0},
+		{"off", 0},
+		{"invalid", 24 * time.Hour},
+		{"-1h", 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		cu := ComputerUseConfig{UpdateCheckInterval: tt.input}
+		if got := cu.GetUpdateCheckInterval(); got != tt.expected {
+			t.Errorf("GetUpdateCheckInterval(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestComputerUseConfig_Profile은 브라우저 프로필 저장 경로와 암호화 키 조회를 테스트합니다.
+func TestComputerUseConfig_Profile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cu := ComputerUseConfig{}
+	if got, want := cu.GetProfileDir(), filepath.Join(home, ".config", "autopus", "browser-profiles"); got != want {
+		t.Errorf("GetProfileDir() = %q, want %q", got, want)
+	}
+	if got := cu.GetProfileEncryptionKey(); got != "" {
+		t.Errorf("환경변수 미설정 시 GetProfileEncryptionKey() = %q, want 빈 문자열", got)
+	}
+
+	t.Setenv("TEST_AUTOPUS_PROFILE_KEY", "secret")
+	cu = ComputerUseConfig{ProfileDir: "~/profiles", ProfileEncryptionKeyEnv: "TEST_AUTOPUS_PROFILE_KEY"}
+	if got, want := cu.GetProfileDir(), filepath.Join(home, "profiles"); got != want {
+		t.Errorf("GetProfileDir() = %q, want %q", got, want)
+	}
+	if got := cu.GetProfileEncryptionKey(); got != "secret" {
+		t.Errorf("GetProfileEncryptionKey() = %q, want %q", got, "secret")
+	}
+}
+
+func TestTaskCheckpointConfig_Defaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cp := TaskCheckpointConfig{}
+	if got, want := cp.GetDir(), filepath.Join(home, ".config", "autopus", "checkpoints"); got != want {
+		t.Errorf("GetDir() = %q, want %q", got, want)
+	}
+	if got := cp.GetInterval(); got != 10*time.Second {
+		t.Errorf("GetInterval() = %v, want 10s", got)
+	}
+	if got := cp.GetMaxAge(); got != 24*time.Hour {
+		t.Errorf("GetMaxAge() = %v, want 24h", got)
+	}
+
+	cp = TaskCheckpointConfig{Dir: "~/cp", IntervalSeconds: 3, MaxAgeHours: 2}
+	if got, want := cp.GetDir(), filepath.Join(home, "cp"); got != want {
+		t.Errorf("GetDir() = %q, want %q", got, want)
+	}
+	if got := cp.GetInterval(); got != 3*time.Second {
+		t.Errorf("GetInterval() = %v, want 3s", got)
+	}
+	if got := cp.GetMaxAge(); got != 2*time.Hour {
+		t.Errorf("GetMaxAge() = %v, want 2h", got)
+	}
+}
+
+func TestTranscriptConfig_Defaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tc := TranscriptConfig{}
+	if got, want := tc.GetDir(), filepath.Join(home, ".config", "autopus", "transcripts"); got != want {
+		t.Errorf("GetDir() = %q, want %q", got, want)
+	}
+	if got := tc.GetMaxAge(); got != 7*24*time.Hour {
+		t.Errorf("GetMaxAge() = %v, want 168h", got)
+	}
+
+	tc = TranscriptConfig{Dir: "~/tr", MaxAgeDays: 2}
+	if got, want := tc.GetDir(), filepath.Join(home, "tr"); got != want {
+		t.Errorf("GetDir() = %q, want %q", got, want)
+	}
+	if got := tc.GetMaxAge(); got != 48*time.Hour {
+		t.Errorf("GetMaxAge() = %v, want 48h", got)
+	}
+}
+
+func TestLimitsConfig_Defaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	lc := LimitsConfig{}
+	if got := lc.GetMaxOutputBytes(); got != 256*1024 {
+		t.Errorf("GetMaxOutputBytes() = %d, want 262144", got)
+	}
+	if got := lc.GetMaxResultBytes(); got != 900*1024 {
+		t.Errorf("GetMaxResultBytes() = %d, want 921600", got)
+	}
+	if got, want := lc.GetOverflowDir(), filepath.Join(home, ".config", "autopus", "overflow"); got != want {
+		t.Errorf("GetOverflowDir() = %q, want %q", got, want)
+	}
+	if got := lc.GetOverflowMaxAge(); got != 7*24*time.Hour {
+		t.Errorf("GetOverflowMaxAge() = %v, want 168h", got)
+	}
+
+	t.Setenv("CLAUDE_API_KEY", "test-key")
+	cfg := &Config{
+		Providers: ProvidersConfig{Claude: ProviderConfig{APIKeyEnv: "CLAUDE_API_KEY"}},
+		Logging:   LoggingConfig{Level: "info", Format: "json"},
+		Limits:    LimitsConfig{MaxResultBytes: 1024 * 1024},
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("WebSocket 메시지 한도 이상의 max_result_bytes가 허용됨")
+	}
+	cfg.Limits.MaxResultBytes = 512 * 1024
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConversationConfig_GetTTL(t *testing.T) {
+	c := ConversationConfig{}
+	if got := c.GetTTL(); got != 30*time.Minute {
+		t.Errorf("GetTTL() = %v, want 30m", got)
+	}
+	c.TTLMinutes = 5
+	if got := c.GetTTL(); got != 5*time.Minute {
+		t.Errorf("GetTTL() = %v, want 5m", got)
+	}
+}
+
+func TestDelegationConfig_GetTimeout(t *testing.T) {
+	d := DelegationConfig{}
+	if got := d.GetTimeout(); got != 30*time.Second {
+		t.Errorf("GetTimeout() = %v, want 30s", got)
+	}
+	d.TimeoutSeconds = 5
+	if got := d.GetTimeout(); got != 5*time.Second {
+		t.Errorf("GetTimeout() = %v, want 5s", got)
+	}
+}
+
+func TestIdleModeConfig_Defaults(t *testing.T) {
+	c := IdleModeConfig{}
+	if got := c.GetIdleAfter(); got != 15*time.Minute {
+		t.Errorf("GetIdleAfter() = %v, want 15m", got)
+	}
+	if got := c.GetHeartbeatInterval(); got != 5*time.Minute {
+		t.Errorf("GetHeartbeatInterval() = %v, want 5m", got)
+	}
+	c.IdleMinutes = 3
+	c.HeartbeatIntervalSeconds = 120
+	if got := c.GetIdleAfter(); got != 3*time.Minute {
+		t.Errorf("GetIdleAfter() = %v, want 3m", got)
+	}
+	if got := c.GetHeartbeatInterval(); got != 2*time.Minute {
+		t.Errorf("GetHeartbeatInterval() = %v, want 2m", got)
+	}
+}
+
+func TestStateStoreConfig_Defaults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	sc := StateStoreConfig{}
+	if got, want := sc.GetDir(), filepath.Join(home, ".config", "autopus", "state"); got != want {
+		t.Errorf("GetDir() = %q, want %q", got, want)
+	}
+	if got := sc.GetEncryption(); got != "keychain" {
+		t.Errorf("GetEncryption() = %q, want keychain", got)
+	}
+	if got := sc.GetKeyEnv(); got != "AUTOPUS_STATE_KEY" {
+		t.Errorf("GetKeyEnv() = %q, want AUTOPUS_STATE_KEY", got)
+	}
+
+	sc = StateStoreConfig{Dir: "~/st", Encryption: "env", KeyEnv: "MY_KEY"}
+	if got, want := sc.GetDir(), filepath.Join(home, "st"); got != want {
+		t.Errorf("GetDir() = %q, want %q", got, want)
+	}
+	if got := sc.GetEncryption(); got != "env" {
+		t.Errorf("GetEncryption() = %q, want env", got)
+	}
+	if got := sc.GetKeyEnv(); got != "MY_KEY" {
+		t.Errorf("GetKeyEnv() = %q, want MY_KEY", got)
+	}
+}
+
+func TestEventHookConfig_GetTimeout(t *testing.T) {
+	h := EventHookConfig{}
+	if got := h.GetTimeout(); got != 10*time.Second {
+		t.Errorf("GetTimeout() = %v, want 10s", got)
+	}
+	h.TimeoutSeconds = 3
+	if got := h.GetTimeout(); got != 3*time.Second {
+		t.Errorf("GetTimeout() = %v, want 3s", got)
+	}
+}
+
+// TestOpenAICompatConfig_IsAvailable은 OpenAI 호환 프로바이더 가용성 판단을 테스트합니다.
+func TestOpenAICompatConfig_IsAvailable(t *testing.T) {
+	t.Setenv("AUTOPUS_TEST_COMPAT_KEY", "sk-test")
+
+	tests := []struct {
+		name     string
+		cfg      OpenAICompatConfig
+		expected bool
+	}{
+		{"비활성화", OpenAICompatConfig{BaseURL: "http://localhost:11434/v1", DefaultModel: "llama3.1"}, false},
+		{"base_url 없음", OpenAICompatConfig{Enabled: true, DefaultModel: "llama3.1"}, false},
+		{"모델 없음", OpenAICompatConfig{Enabled: true, BaseURL: "http://localhost:11434/v1"}, false},
+		{"API 키 없는 로컬 엔드포인트", OpenAICompatConfig{Enabled: true, BaseURL: "http://localhost:11434/v1", Models: []string{"llama3.1"}}, true},
+		{"API 키 환경변수 설정", OpenAICompatConfig{Enabled: true, BaseURL: "https://api.example.com/v1", DefaultModel: "m", APIKeyEnv: "AUTOPUS_TEST_COMPAT_KEY"}, true},
+		{"API 키 환경변수 미설정", OpenAICompatConfig{Enabled: true, BaseURL: "https://api.example.com/v1", DefaultModel: "m", APIKeyEnv: "AUTOPUS_TEST_COMPAT_KEY_UNSET"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.IsAvailable(); got != tt.expected {
+				t.Errorf("IsAvailable() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 // TestConfig_GetAvailableProviders는 사용 가능한 프로바이더 목록을 테스트합니다.
 func TestConfig_GetAvailableProviders(t *testing.T) {
 	t.Setenv("CLAUDE_API_KEY", "test-claude")
@@ -390,3 +648,128 @@ func TestConfig_Validate_CodexAppServerSavedAuth(t *testing.T) {
 		t.Fatalf("GetAvailableProviders() = %v, want [openai]", providers)
 	}
 }
+
+// TestResultCacheConfig_GetWindow는 결과 캐시 보관 시간 기본값을 테스트합니다.
+func TestResultCacheConfig_GetWindow(t *testing.T) {
+	tests := []struct {
+		seconds  int
+		expected time.Duration
+	}{
+		{0, 10 * time.Minute},
+		{-5, 10 * time.Minute},
+		{120, 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		rc := ResultCacheConfig{WindowSeconds: tt.seconds}
+		if got := rc.GetWindow(); got != tt.expected {
+			t.Errorf("GetWindow(%d) = %v, want %v", tt.seconds, got, tt.expected)
+		}
+	}
+}
+
+// TestCodegenSandboxConfig_Defaults는 샌드박스 할당량 기본값을 테스트합니다.
+func TestCodegenSandboxConfig_Defaults(t *testing.T) {
+	var empty CodegenSandboxConfig
+	if got := empty.GetMaxTotalBytes(); got != 1024<<20 {
+		t.Errorf("GetMaxTotalBytes() = %d, want %d", got, 1024<<20)
+	}
+	if got := empty.GetMaxServiceBytes(); got != 100<<20 {
+		t.Errorf("GetMaxServiceBytes() = %d, want %d", got, 100<<20)
+	}
+	if got := empty.GetMaxAge(); got != 24*time.Hour {
+		t.Errorf("GetMaxAge() = %v, want 24h", got)
+	}
+
+	cfg := CodegenSandboxConfig{MaxTotalMB: 2, MaxServiceMB: 1, MaxAgeHours: 3}
+	if got := cfg.GetMaxTotalBytes(); got != 2<<20 {
+		t.Errorf("GetMaxTotalBytes() = %d, want %d", got, 2<<20)
+	}
+	if got := cfg.GetMaxServiceBytes(); got != 1<<20 {
+		t.Errorf("GetMaxServiceBytes() = %d, want %d", got, 1<<20)
+	}
+	if got := cfg.GetMaxAge(); got != 3*time.Hour {
+		t.Errorf("GetMaxAge() = %v, want 3h", got)
+	}
+}
+
+// TestChangedKeys는 두 설정 사이의 변경 키 계산을 테스트합니다.
+func TestChangedKeys(t *testing.T) {
+	oldCfg := &Config{}
+	oldCfg.Logging.Level = "info"
+	oldCfg.Server.Compression.Enabled = true
+	oldCfg.Security.ActionApproval.AllowedCommands = []string{"go test ./..."}
+
+	newCfg := *oldCfg
+	newCfg.Logging.Level = "debug"
+	newCfg.Server.Compression.Enabled = false
+	newCfg.Security.ActionApproval.AllowedCommands = []string{"go test ./...", "make build"}
+
+	got := ChangedKeys(oldCfg, &newCfg)
+	want := []string{
+		"logging.level",
+		"security.action_approval.allowed_commands",
+		"server.compression.enabled",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ChangedKeys() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ChangedKeys()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if keys := ChangedKeys(oldCfg, oldCfg); len(keys) != 0 {
+		t.Errorf("같은 설정의 변경 키는 없어야 합니다: %v", keys)
+	}
+	if keys := ChangedKeys(nil, oldCfg); keys != nil {
+		t.Errorf("nil 설정은 nil을 반환해야 합니다: %v", keys)
+	}
+}
+
+func TestRedactionConfig_Defaults(t *testing.T) {
+	rc := RedactionConfig{}
+	if got := rc.GetEntropyThreshold(); got != 4.2 {
+		t.Errorf("GetEntropyThreshold() = %v, want 4.2", got)
+	}
+	if got := rc.GetEntropyMinLength(); got != 24 {
+		t.Errorf("GetEntropyMinLength() = %d, want 24", got)
+	}
+
+	rc = RedactionConfig{EntropyThreshold: 4.5, EntropyMinLength: 32}
+	if got := rc.GetEntropyThreshold(); got != 4.5 {
+		t.Errorf("GetEntropyThreshold() = %v, want 4.5", got)
+	}
+	if got := rc.GetEntropyMinLength(); got != 32 {
+		t.Errorf("GetEntropyMinLength() = %d, want 32", got)
+	}
+}
+
+// TestLoad_WorkDir는 setup/up이 기록한 work_dir 문자열과 work_dirs 섹션을 함께 읽는지 테스트합니다.
+func TestLoad_WorkDir(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+	viper.SetDefault("work_dirs.per_execution", false)
+	err := viper.ReadConfig(strings.NewReader(`
+work_dir: /home/me/project
+work_dirs:
+  allowed_roots: [/home/me/workspace]
+  per_execution: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.WorkDir != "/home/me/project" {
+		t.Errorf("WorkDir = %q", cfg.WorkDir)
+	}
+	if len(cfg.WorkDirs.AllowedRoots) != 1 || cfg.WorkDirs.AllowedRoots[0] != "/home/me/workspace" || !cfg.WorkDirs.PerExecution {
+		t.Errorf("WorkDirs = %+v", cfg.WorkDirs)
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangedKeys는 두 설정 사이에서 값이 달라진 설정 키 목록을 정렬하여 반환합니다.
+// 키는 설정 파일과 같은 점 표기법(예: "logging.level", "server.compression.enabled")을 사용합니다.
+func ChangedKeys(oldCfg, newCfg *Config) []string {
+	if oldCfg == nil || newCfg == nil {
+		return nil
+	}
+
+	var keys []string
+	collectChangedKeys("", reflect.ValueOf(*oldCfg), reflect.ValueOf(*newCfg), &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// collectChangedKeys는 구조체 필드를 재귀적으로 비교하여 달라진 리프 키를 수집합니다.
+func collectChangedKeys(prefix string, oldVal, newVal reflect.Value, keys *[]string) {
+	if oldVal.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			*keys = append(*keys, prefix)
+		}
+		return
+	}
+
+	t := oldVal.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := settingKeyName(field)
+		if name == "" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		collectChangedKeys(name, oldVal.Field(i), newVal.Field(i), keys)
+	}
+}
+
+// settingKeyName은 필드의 mapstructure 태그로 설정 키 이름을 결정합니다.
+// 태그가 "-"이면 빈 문자열을 반환하고, 태그가 없으면 소문자 필드 이름을 사용합니다.
+func settingKeyName(field reflect.StructField) string {
+	tag, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	switch tag {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	default:
+		return tag
+	}
+}
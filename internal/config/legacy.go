@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// LegacyKey는 설정 파일에서 발견한 이전 키와 그 값을 읽는 현재 키입니다.
+type LegacyKey struct {
+	// Key는 설정 파일에 있는 이전 키입니다 (예: "mcpserver.backend_url").
+	Key string
+	// Replacement는 값을 읽는 현재 키입니다 (예: "mcp_server.backend_url").
+	Replacement string
+}
+
+// String은 "이전 키 → 현재 키" 형식의 이름 변경 설명입니다.
+func (k LegacyKey) String() string {
+	return fmt.Sprintf("%s → %s", k.Key, k.Replacement)
+}
+
+// ApplyLegacyKeys는 v에 로드된 설정 파일에서 Alias가 지정된 이전 키를 찾아,
+// 같은 값을 현재 키로도 읽을 수 있도록 설정 파일 계층에 병합하고 적용한 이름 변경을 키 순서로 반환합니다.
+// 현재 키가 설정 파일에 이미 있으면 현재 값을 유지하고 이전 키는 무시합니다 (MigrateDocument와 같은 규칙).
+//
+// 설정 파일 계층에 병합하므로 설정 파일을 다시 읽으면(hot-reload 등) 다시 호출해야 합니다.
+func ApplyLegacyKeys(v *viper.Viper) []LegacyKey {
+	var applied []LegacyKey
+	merged := map[string]any{}
+	for _, key := range v.AllKeys() {
+		d, ok := LookupDeprecation(key)
+		if !ok || !d.Alias || !v.InConfig(key) {
+			continue
+		}
+		replacement := d.Replacement + strings.TrimPrefix(key, d.Key)
+		if v.InConfig(replacement) {
+			continue
+		}
+		setPath(merged, replacement, v.Get(key))
+		applied = append(applied, LegacyKey{Key: key, Replacement: replacement})
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+	_ = v.MergeConfigMap(merged)
+
+	sort.Slice(applied, func(i, j int) bool { return applied[i].Key < applied[j].Key })
+	return applied
+}
@@ -0,0 +1,563 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldType은 설정 값의 타입입니다.
+type FieldType string
+
+const (
+	FieldString     FieldType = "string"
+	FieldInt        FieldType = "int"
+	FieldFloat      FieldType = "float"
+	FieldBool       FieldType = "bool"
+	FieldStringList FieldType = "[]string"
+	FieldStringMap  FieldType = "map"
+	FieldObjectList FieldType = "[]object"
+)
+
+// SchemaField는 설정 파일의 키 하나에 대한 스키마입니다.
+type SchemaField struct {
+	// Key는 점 표기법 설정 키입니다 (예: "logging.level").
+	Key string
+	// Type은 값의 타입입니다.
+	Type FieldType
+	// Enum은 허용되는 값 목록입니다. 비어 있으면 제한이 없습니다.
+	Enum []string
+}
+
+// Deprecation은 이전 설정 레이아웃의 키와 현재 위치입니다.
+type Deprecation struct {
+	// Key는 더 이상 사용하지 않는 키(또는 섹션)입니다.
+	Key string
+	// Replacement는 현재 키입니다. 비어 있으면 자동으로 옮길 수 없습니다.
+	Replacement string
+	// Note는 사용자에게 보여줄 안내입니다.
+	Note string
+	// Alias는 전환 기간 동안 실행 시에도 이전 키의 값을 현재 키로 읽는지 여부입니다.
+	// 값의 형식이 그대로인 이름 변경에만 지정합니다 (ApplyLegacyKeys 참고).
+	Alias bool
+}
+
+// deprecations는 이전 버전 설정 레이아웃에서 바뀐 키 목록입니다.
+// 섹션 키는 하위 키 전체를 Replacement 아래로 옮깁니다.
+var deprecations = []Deprecation{
+	{Key: "claude", Replacement: "providers.claude", Note: "프로바이더 설정은 providers 섹션으로 이동했습니다", Alias: true},
+	{Key: "gemini", Replacement: "providers.gemini", Note: "프로바이더 설정은 providers 섹션으로 이동했습니다", Alias: true},
+	{Key: "codex", Replacement: "providers.codex", Note: "프로바이더 설정은 providers 섹션으로 이동했습니다", Alias: true},
+	{Key: "log", Replacement: "logging", Note: "log 섹션은 logging으로 이름이 바뀌었습니다", Alias: true},
+	{Key: "reconnect", Replacement: "reconnection", Note: "reconnect 섹션은 reconnection으로 이름이 바뀌었습니다", Alias: true},
+	{Key: "mcpserver", Replacement: "mcp_server", Note: "mcpserver 섹션은 mcp_server로 이름이 바뀌었습니다", Alias: true},
+	{Key: "server.url", Replacement: "server.ws_url", Note: "WebSocket 서버 주소는 server.ws_url로 지정합니다", Alias: true},
+	{Key: "server.timeout", Replacement: "server.timeout_seconds", Note: "타임아웃은 초 단위 정수로 지정합니다"},
+	{Key: "computer_use.isolation_mode", Replacement: "computer_use.isolation", Note: "isolation_mode는 isolation으로 이름이 바뀌었습니다", Alias: true},
+	{Key: "providers.claude.api_key", Note: "API 키는 파일에 저장하지 않습니다. 키를 삭제하고 api_key_env에 환경변수 이름을 지정하세요"},
+	{Key: "providers.gemini.api_key", Note: "API 키는 파일에 저장하지 않습니다. 키를 삭제하고 api_key_env에 환경변수 이름을 지정하세요"},
+	{Key: "providers.codex.api_key", Note: "API 키는 파일에 저장하지 않습니다. 키를 삭제하고 api_key_env에 환경변수 이름을 지정하세요"},
+}
+
+// externalSections는 Config 구조체 밖에서 읽는 설정 키입니다. 타입 검사 없이 허용합니다.
+// mcp_server는 autopus-mcp-server가 직접 읽습니다.
+var externalSections = []string{"mcp_server"}
+
+// enumValues는 허용 값이 정해진 설정 키입니다. Config.Validate의 검사와 같은 값을 사용합니다.
+var enumValues = map[string][]string{
+	"language":                               {"ko", "en"},
+	"logging.level":                          {"debug", "info", "warn", "error"},
+	"logging.format":                         {"json", "text"},
+	"auth.sso.flow":                          {"auto", "browser", "device"},
+	"server.transport":                       {"auto", "websocket", "http"},
+	"computer_use.isolation":                 {"auto", "container", "local"},
+	"providers.claude.mode":                  {"api", "cli", "hybrid"},
+	"providers.gemini.mode":                  {"api", "cli", "hybrid"},
+	"providers.codex.mode":                   {"api", "cli", "hybrid", "app-server"},
+	"providers.claude.execution_mode":        {"auto-execute", "interactive"},
+	"providers.claude.approval_policy":       {"auto-approve", "deny-all"},
+	"providers.codex.approval_policy":        {"auto-approve", "deny-all"},
+	"providers.codex.framing":                {"line", "content-length"},
+	"security.action_approval.cli_request":   {"auto", "prompt", "deny"},
+	"security.action_approval.mcp_deploy":    {"auto", "prompt", "deny"},
+	"security.action_approval.computer_use":  {"auto", "prompt", "deny"},
+	"security.action_approval.apply_changes": {"auto", "prompt", "deny"},
+	"security.action_approval.custom_tool":   {"auto", "prompt", "deny"},
+	"security.action_approval.git_request":   {"auto", "prompt", "deny"},
+	"security.replay_protection.action":      {"reject", "warn"},
+	"providers.codex.auth_method":            {"apikey", "chatgpt", "chatgptAuthTokens"},
+	"state_store.encryption":                 {"keychain", "env", "none"},
+	"provider_replay.mode":                   {"off", "record", "replay"},
+}
+
+var (
+	schemaOnce   sync.Once
+	schemaFields []SchemaField
+	schemaIndex  map[string]SchemaField
+)
+
+// Schema는 Config 구조체에서 만든 전체 설정 스키마를 구조체 선언 순서대로 반환합니다.
+func Schema() []SchemaField {
+	loadSchema()
+	return append([]SchemaField(nil), schemaFields...)
+}
+
+// LookupField는 설정 키의 스키마를 반환합니다. 키는 대소문자를 구분하지 않습니다.
+func LookupField(key string) (SchemaField, bool) {
+	loadSchema()
+	field, ok := schemaIndex[strings.ToLower(key)]
+	return field, ok
+}
+
+// Deprecations는 이전 설정 레이아웃에서 바뀐 키 목록을 반환합니다.
+func Deprecations() []Deprecation {
+	return append([]Deprecation(nil), deprecations...)
+}
+
+// LookupDeprecation은 키 자체 또는 상위 섹션이 더 이상 사용되지 않으면 해당 항목을 반환합니다.
+func LookupDeprecation(key string) (Deprecation, bool) {
+	key = strings.ToLower(key)
+	for _, d := range deprecations {
+		if key == d.Key || strings.HasPrefix(key, d.Key+".") {
+			return d, true
+		}
+	}
+	return Deprecation{}, false
+}
+
+// IsExternalKey는 Config 구조체 밖에서 읽는 설정 키인지 반환합니다.
+func IsExternalKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, section := range externalSections {
+		if key == section || strings.HasPrefix(key, section+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSchema는 Config 구조체를 한 번만 순회하여 스키마를 만듭니다.
+func loadSchema() {
+	schemaOnce.Do(func() {
+		collectSchemaFields("", reflect.TypeOf(Config{}), &schemaFields)
+		schemaIndex = make(map[string]SchemaField, len(schemaFields))
+		for _, field := range schemaFields {
+			schemaIndex[field.Key] = field
+		}
+	})
+}
+
+// collectSchemaFields는 구조체 필드를 재귀적으로 순회하여 리프 키의 스키마를 수집합니다.
+// 키 이름 규칙은 ChangedKeys와 같이 settingKeyName을 따릅니다.
+func collectSchemaFields(prefix string, t reflect.Type, fields *[]SchemaField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := settingKeyName(field)
+		if name == "" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			collectSchemaFields(name, ft, fields)
+			continue
+		}
+		*fields = append(*fields, SchemaField{Key: name, Type: fieldTypeOf(ft), Enum: enumValues[name]})
+	}
+}
+
+// fieldTypeOf는 Go 타입을 설정 값 타입으로 변환합니다.
+func fieldTypeOf(t reflect.Type) FieldType {
+	switch t.Kind() {
+	case reflect.Bool:
+		return FieldBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return FieldInt
+	case reflect.Float32, reflect.Float64:
+		return FieldFloat
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return FieldStringList
+		}
+		return FieldObjectList
+	case reflect.Map:
+		return FieldStringMap
+	default:
+		return FieldString
+	}
+}
+
+// Parse는 명령줄 문자열 값을 필드 타입으로 변환하고 허용 값을 검사합니다.
+// 문자열 목록은 쉼표로 구분합니다. 객체 목록과 맵은 명령줄에서 지정할 수 없습니다.
+func (f SchemaField) Parse(raw string) (any, error) {
+	var value any
+	switch f.Type {
+	case FieldString:
+		value = raw
+	case FieldInt:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%s: 정수가 필요합니다: %q", f.Key, raw)
+		}
+		value = n
+	case FieldFloat:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: 숫자가 필요합니다: %q", f.Key, raw)
+		}
+		value = n
+	case FieldBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("%s: true 또는 false가 필요합니다: %q", f.Key, raw)
+		}
+		value = b
+	case FieldStringList:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value = items
+	default:
+		return nil, fmt.Errorf("%s: %s 타입은 설정 파일에서 직접 편집하세요 (autopus config edit)", f.Key, f.Type)
+	}
+
+	if err := f.checkEnum(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Check는 YAML에서 읽은 값이 필드 타입에 맞는지 검사합니다.
+// viper의 약한 타입 변환과 같이 따옴표로 감싼 숫자, 불리언 문자열은 허용합니다.
+func (f SchemaField) Check(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	ok := false
+	switch f.Type {
+	case FieldString:
+		ok = isScalar(value)
+	case FieldInt:
+		switch v := value.(type) {
+		case int, int64, uint64:
+			ok = true
+		case float64:
+			ok = v == float64(int64(v))
+		case string:
+			_, err := strconv.Atoi(strings.TrimSpace(v))
+			ok = err == nil
+		}
+	case FieldFloat:
+		switch v := value.(type) {
+		case int, int64, uint64, float64:
+			ok = true
+		case string:
+			_, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			ok = err == nil
+		}
+	case FieldBool:
+		switch v := value.(type) {
+		case bool:
+			ok = true
+		case string:
+			_, err := strconv.ParseBool(strings.TrimSpace(v))
+			ok = err == nil
+		}
+	case FieldStringList:
+		if items, isList := value.([]any); isList {
+			ok = true
+			for _, item := range items {
+				ok = ok && isScalar(item)
+			}
+		}
+	case FieldStringMap:
+		_, ok = value.(map[string]any)
+	case FieldObjectList:
+		if items, isList := value.([]any); isList {
+			ok = true
+			for _, item := range items {
+				_, isMap := item.(map[string]any)
+				ok = ok && isMap
+			}
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%s: %s 타입이 필요하지만 %s 값입니다", f.Key, f.Type, describeValue(value))
+	}
+	return f.checkEnum(value)
+}
+
+// checkEnum은 허용 값 목록이 있으면 값이 그 안에 있는지 확인합니다.
+func (f SchemaField) checkEnum(value any) error {
+	if len(f.Enum) == 0 {
+		return nil
+	}
+	s := fmt.Sprint(value)
+	for _, allowed := range f.Enum {
+		if s == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: 유효하지 않은 값 %q (%s 중 하나)", f.Key, s, strings.Join(f.Enum, ", "))
+}
+
+// isScalar는 값이 문자열, 숫자, 불리언인지 반환합니다.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return true
+	}
+	return false
+}
+
+// describeValue는 타입 에러 메시지에 사용할 값의 종류를 반환합니다.
+func describeValue(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "섹션(맵)"
+	case []any:
+		return "목록"
+	case bool:
+		return "불리언"
+	case int, int64, uint64, float64:
+		return "숫자"
+	case string:
+		return "문자열"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// IssueSeverity는 설정 검사 결과의 심각도입니다.
+type IssueSeverity string
+
+const (
+	// IssueError는 설정을 적용할 수 없는 문제입니다.
+	IssueError IssueSeverity = "error"
+	// IssueWarning은 적용은 되지만 수정이 필요한 문제입니다 (알 수 없는 키, 사용 중단된 키).
+	IssueWarning IssueSeverity = "warning"
+)
+
+// Issue는 설정 파일 검사에서 발견한 문제입니다.
+type Issue struct {
+	Key      string
+	Severity IssueSeverity
+	Message  string
+}
+
+// ValidateDocument는 YAML에서 읽은 설정 문서를 스키마로 검사하고 키 순서로 정렬된 문제 목록을 반환합니다.
+// 타입이 맞지 않거나 허용되지 않은 값은 에러, 알 수 없는 키와 사용 중단된 키는 경고입니다.
+func ValidateDocument(doc map[string]any) []Issue {
+	loadSchema()
+	sections := make(map[string]bool)
+	for _, field := range schemaFields {
+		parts := strings.Split(field.Key, ".")
+		for i := 1; i < len(parts); i++ {
+			sections[strings.Join(parts[:i], ".")] = true
+		}
+	}
+
+	var issues []Issue
+	var walk func(prefix string, node map[string]any)
+	walk = func(prefix string, node map[string]any) {
+		for rawKey, value := range node {
+			key := strings.ToLower(rawKey)
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+
+			if d, ok := LookupDeprecation(key); ok {
+				issues = append(issues, deprecationIssue(key, d))
+				continue
+			}
+			if IsExternalKey(key) {
+				continue
+			}
+			if field, ok := schemaIndex[key]; ok {
+				if err := field.Check(value); err != nil {
+					issues = append(issues, Issue{Key: key, Severity: IssueError, Message: err.Error()})
+				}
+				continue
+			}
+			if sections[key] {
+				child, ok := value.(map[string]any)
+				if !ok {
+					if value != nil {
+						issues = append(issues, Issue{Key: key, Severity: IssueError, Message: fmt.Sprintf("%s: 섹션이어야 하지만 %s 값입니다", key, describeValue(value))})
+					}
+					continue
+				}
+				walk(key, child)
+				continue
+			}
+			issues = append(issues, Issue{Key: key, Severity: IssueWarning, Message: fmt.Sprintf("%s: 알 수 없는 설정 키입니다", key)})
+		}
+	}
+	walk("", doc)
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Key < issues[j].Key })
+	return issues
+}
+
+// deprecationIssue는 사용 중단된 키의 경고를 만듭니다.
+func deprecationIssue(key string, d Deprecation) Issue {
+	msg := fmt.Sprintf("%s: 더 이상 사용되지 않는 키입니다. %s", key, d.Note)
+	if d.Replacement != "" {
+		msg += fmt.Sprintf(" (%s 사용, autopus config migrate로 변환 가능)", d.Replacement+strings.TrimPrefix(key, d.Key))
+	}
+	return Issue{Key: key, Severity: IssueWarning, Message: msg}
+}
+
+// MigrateDocument는 사용 중단된 키를 현재 위치로 옮기고 변경 내용을 설명하는 목록을 반환합니다.
+// 현재 키에 이미 값이 있으면 현재 값을 유지하고 이전 키만 삭제합니다.
+// Replacement가 없는 키(평문 API 키 등)는 옮기지 않고 그대로 둡니다.
+func MigrateDocument(doc map[string]any) []string {
+	var changes []string
+	for _, d := range deprecations {
+		if d.Replacement == "" {
+			continue
+		}
+		value, ok := lookupPath(doc, d.Key)
+		if !ok {
+			continue
+		}
+		deletePath(doc, d.Key)
+
+		existing, exists := lookupPath(doc, d.Replacement)
+		switch {
+		case !exists:
+			setPath(doc, d.Replacement, value)
+			changes = append(changes, fmt.Sprintf("%s → %s", d.Key, d.Replacement))
+		case isMap(existing) && isMap(value):
+			mergeMissing(existing.(map[string]any), value.(map[string]any))
+			changes = append(changes, fmt.Sprintf("%s → %s (기존 값 우선 병합)", d.Key, d.Replacement))
+		default:
+			changes = append(changes, fmt.Sprintf("%s 삭제 (%s에 이미 값이 있음)", d.Key, d.Replacement))
+		}
+	}
+	return changes
+}
+
+// SetDocumentValue는 점 표기법 키 위치에 값을 설정하고, 필요하면 중간 섹션을 만듭니다.
+func SetDocumentValue(doc map[string]any, key string, value any) {
+	setPath(doc, strings.ToLower(key), value)
+}
+
+// GetDocumentValue는 점 표기법 키 위치의 값을 반환합니다. 키는 대소문자를 구분하지 않습니다.
+func GetDocumentValue(doc map[string]any, key string) (any, bool) {
+	return lookupPath(doc, strings.ToLower(key))
+}
+
+// isMap은 값이 YAML 맵인지 반환합니다.
+func isMap(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
+
+// mergeMissing은 src의 키 중 dst에 없는 것만 dst에 복사합니다. 하위 맵은 재귀적으로 병합합니다.
+func mergeMissing(dst, src map[string]any) {
+	for key, value := range src {
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+		if isMap(existing) && isMap(value) {
+			mergeMissing(existing.(map[string]any), value.(map[string]any))
+		}
+	}
+}
+
+// lookupPath는 점 표기법 경로의 값을 찾습니다. 키는 대소문자를 구분하지 않습니다.
+func lookupPath(doc map[string]any, path string) (any, bool) {
+	node := doc
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		key, ok := findKey(node, part)
+		if !ok {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return node[key], true
+		}
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		node = child
+	}
+	return nil, false
+}
+
+// setPath는 점 표기법 경로에 값을 설정합니다. 맵이 아닌 중간 값은 섹션으로 덮어씁니다.
+func setPath(doc map[string]any, path string, value any) {
+	node := doc
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		key, ok := findKey(node, part)
+		if !ok {
+			key = part
+		}
+		child, ok := node[key].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			node[key] = child
+		}
+		node = child
+	}
+	last := parts[len(parts)-1]
+	if key, ok := findKey(node, last); ok {
+		last = key
+	}
+	node[last] = value
+}
+
+// deletePath는 점 표기법 경로의 값을 삭제하고, 비게 된 상위 섹션도 정리합니다.
+func deletePath(doc map[string]any, path string) {
+	parts := strings.Split(path, ".")
+	key, ok := findKey(doc, parts[0])
+	if !ok {
+		return
+	}
+	if len(parts) == 1 {
+		delete(doc, key)
+		return
+	}
+	child, ok := doc[key].(map[string]any)
+	if !ok {
+		return
+	}
+	deletePath(child, strings.Join(parts[1:], "."))
+	if len(child) == 0 {
+		delete(doc, key)
+	}
+}
+
+// findKey는 맵에서 대소문자를 구분하지 않고 키를 찾습니다.
+func findKey(node map[string]any, name string) (string, bool) {
+	if _, ok := node[name]; ok {
+		return name, true
+	}
+	for key := range node {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
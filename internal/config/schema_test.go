@@ -0,0 +1,216 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestSchema_Fields는 Config 구조체에서 스키마 키와 타입을 올바르게 만드는지 테스트합니다.
+func TestSchema_Fields(t *testing.T) {
+	tests := []struct {
+		key      string
+		wantType FieldType
+	}{
+		{"server.ws_url", FieldString},
+		{"server.timeout_seconds", FieldInt},
+		{"server.compression.enabled", FieldBool},
+		{"reconnection.backoff_multiplier", FieldFloat},
+		{"security.sandbox.allowed_paths", FieldStringList},
+		{"custom_tools", FieldObjectList},
+		{"providers.claude.enabled", FieldBool},
+		{"language", FieldString},
+	}
+	for _, tt := range tests {
+		field, ok := LookupField(tt.key)
+		if !ok {
+			t.Errorf("%s: 스키마에 없습니다", tt.key)
+			continue
+		}
+		if field.Type != tt.wantType {
+			t.Errorf("%s: Type = %s, want %s", tt.key, field.Type, tt.wantType)
+		}
+	}
+
+	if field, _ := LookupField("Logging.Level"); len(field.Enum) == 0 {
+		t.Error("키는 대소문자를 구분하지 않고 허용 값이 있어야 합니다")
+	}
+	if _, ok := LookupField("logging"); ok {
+		t.Error("섹션은 스키마 필드가 아닙니다")
+	}
+}
+
+// TestSchemaField_Parse는 명령줄 값의 타입 변환과 허용 값 검사를 테스트합니다.
+func TestSchemaField_Parse(t *testing.T) {
+	tests := []struct {
+		key     string
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{"reconnection.max_attempts", "5", 5, false},
+		{"reconnection.max_attempts", "five", nil, true},
+		{"reconnection.backoff_multiplier", "1.5", 1.5, false},
+		{"server.compression.enabled", "false", false, false},
+		{"server.compression.enabled", "nope", nil, true},
+		{"logging.level", "debug", "debug", false},
+		{"logging.level", "verbose", nil, true},
+		{"security.sandbox.allowed_paths", "~/a, ~/b,", []string{"~/a", "~/b"}, false},
+		{"custom_tools", "x", nil, true},
+	}
+	for _, tt := range tests {
+		field, _ := LookupField(tt.key)
+		got, err := field.Parse(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s=%q: error = %v, wantErr %v", tt.key, tt.raw, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s=%q: got %#v, want %#v", tt.key, tt.raw, got, tt.want)
+		}
+	}
+}
+
+// TestValidateDocument는 타입 에러, 알 수 없는 키, 사용 중단된 키를 구분해 보고하는지 테스트합니다.
+func TestValidateDocument(t *testing.T) {
+	doc := map[string]any{
+		"server": map[string]any{
+			"ws_url":          "wss://example.com/ws",
+			"url":             "wss://example.com/ws",
+			"timeout_seconds": "30", // 따옴표로 감싼 숫자는 허용
+			"timeout":         30,
+		},
+		"logging":      map[string]any{"level": "verbose"},
+		"reconnection": "fast",
+		"security": map[string]any{
+			"sandbox": map[string]any{"allowed_paths": []any{"~/a", map[string]any{"x": 1}}},
+		},
+		"mcp_server": map[string]any{"backend_url": "https://api.example.com"},
+		"mcpserver":  map[string]any{"backend_url": "https://api.example.com"},
+		"bogus":      true,
+	}
+
+	got := make(map[string]IssueSeverity)
+	for _, issue := range ValidateDocument(doc) {
+		got[issue.Key] = issue.Severity
+	}
+	want := map[string]IssueSeverity{
+		"server.timeout":                 IssueWarning,
+		"server.url":                     IssueWarning,
+		"mcpserver":                      IssueWarning,
+		"logging.level":                  IssueError,
+		"reconnection":                   IssueError,
+		"security.sandbox.allowed_paths": IssueError,
+		"bogus":                          IssueWarning,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %v, want %v", got, want)
+	}
+}
+
+// TestMigrateDocument는 이전 레이아웃의 키를 현재 위치로 옮기고 기존 값을 우선하는지 테스트합니다.
+func TestMigrateDocument(t *testing.T) {
+	doc := map[string]any{
+		"claude": map[string]any{"mode": "cli", "default_model": "old"},
+		"providers": map[string]any{
+			"claude": map[string]any{"default_model": "current"},
+		},
+		"log":            map[string]any{"level": "debug"},
+		"server":         map[string]any{"timeout": 10, "url": "wss://example.com/ws"},
+		"mcpserver":      map[string]any{"backend_url": "https://api.example.com"},
+		"providers_note": "keep",
+	}
+
+	changes := MigrateDocument(doc)
+	if len(changes) != 5 {
+		t.Errorf("changes = %v, want 5", changes)
+	}
+
+	want := map[string]any{
+		"providers": map[string]any{
+			"claude": map[string]any{"mode": "cli", "default_model": "current"},
+		},
+		"logging":        map[string]any{"level": "debug"},
+		"server":         map[string]any{"timeout_seconds": 10, "ws_url": "wss://example.com/ws"},
+		"mcp_server":     map[string]any{"backend_url": "https://api.example.com"},
+		"providers_note": "keep",
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("migrated = %v, want %v", doc, want)
+	}
+
+	if changes := MigrateDocument(doc); len(changes) != 0 {
+		t.Errorf("이미 변환된 문서는 변경이 없어야 합니다: %v", changes)
+	}
+}
+
+// TestApplyLegacyKeys는 전환 기간 동안 이전 키의 값을 현재 키로 읽고, 현재 키의 값을 우선하는지 테스트합니다.
+func TestApplyLegacyKeys(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetDefault("server.ws_url", "wss://default/ws")
+	v.SetDefault("mcp_server.timeout", "30s")
+	err := v.ReadConfig(strings.NewReader(`
+server:
+  url: wss://legacy/ws
+  timeout: 10
+mcpserver:
+  backend_url: https://legacy.example.com
+  tools:
+    approve_execution:
+      disabled: true
+mcp_server:
+  backend_url: https://current.example.com
+computer_use:
+  isolation_mode: local
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied := ApplyLegacyKeys(v)
+
+	var got []string
+	for _, k := range applied {
+		got = append(got, k.String())
+	}
+	want := []string{
+		"computer_use.isolation_mode → computer_use.isolation",
+		"mcpserver.tools.approve_execution.disabled → mcp_server.tools.approve_execution.disabled",
+		"server.url → server.ws_url",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("applied = %v, want %v", got, want)
+	}
+
+	if url := v.GetString("server.ws_url"); url != "wss://legacy/ws" {
+		t.Errorf("server.ws_url = %q, want legacy value", url)
+	}
+	if url := v.GetString("mcp_server.backend_url"); url != "https://current.example.com" {
+		t.Errorf("mcp_server.backend_url = %q, want current value", url)
+	}
+	if !v.GetBool("mcp_server.tools.approve_execution.disabled") {
+		t.Error("mcp_server.tools.approve_execution.disabled가 적용되지 않았습니다")
+	}
+	if timeout := v.GetString("mcp_server.timeout"); timeout != "30s" {
+		t.Errorf("mcp_server.timeout = %q, want default", timeout)
+	}
+	// 형식이 바뀐 키(server.timeout)는 별칭으로 읽지 않는다.
+	if v.InConfig("server.timeout_seconds") {
+		t.Error("server.timeout은 server.timeout_seconds로 읽지 않아야 합니다")
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.URL != "wss://legacy/ws" || cfg.ComputerUse.Isolation != "local" {
+		t.Errorf("Unmarshal: server=%q isolation=%q", cfg.Server.URL, cfg.ComputerUse.Isolation)
+	}
+
+	if again := ApplyLegacyKeys(v); len(again) != 0 {
+		t.Errorf("이미 적용된 별칭을 다시 적용했습니다: %v", again)
+	}
+}
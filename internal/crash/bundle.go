@@ -0,0 +1,227 @@
+package crash
+
+import (
+	"archive/zip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue는 설정에서 제거된 민감 값을 대체하는 문자열입니다.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeyPattern은 값을 제거해야 하는 설정 키 이름 패턴입니다.
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[_-]?key|credential|private[_-]?key|auth)`)
+
+// Bundle은 저장된 크래시 번들 정보입니다.
+type Bundle struct {
+	Report
+	// Path는 번들 파일 경로입니다.
+	Path string `json:"path"`
+	// Size는 번들 파일 크기(바이트)입니다.
+	Size int64 `json:"size"`
+	// Uploaded는 서버 업로드 완료 여부입니다.
+	Uploaded bool `json:"uploaded"`
+}
+
+// UploadPayload는 서버로 전송하는 크래시 번들 요청 본문입니다.
+type UploadPayload struct {
+	Report
+	// Filename은 번들 파일 이름입니다.
+	Filename string `json:"filename"`
+	// Bundle은 base64로 인코딩된 번들 zip입니다.
+	Bundle string `json:"bundle"`
+}
+
+// List는 디렉토리의 크래시 번들을 최신순으로 반환합니다.
+// 디렉토리가 없으면 빈 목록을 반환합니다.
+func List(dir string) ([]Bundle, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Bundle{}, nil
+		}
+		return nil, fmt.Errorf("크래시 디렉토리 조회 실패: %w", err)
+	}
+
+	bundles := make([]Bundle, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, bundlePrefix) || !strings.HasSuffix(name, bundleExt) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		b, err := Open(path)
+		if err != nil {
+			// 손상된 번들도 목록에는 표시한다.
+			b = Bundle{Report: Report{ID: bundleID(name)}, Path: path}
+			if info, statErr := e.Info(); statErr == nil {
+				b.Size = info.Size()
+				b.Time = info.ModTime().UTC()
+			}
+		}
+		bundles = append(bundles, b)
+	}
+
+	sort.Slice(bundles, func(i, j int) bool {
+		return bundles[i].ID > bundles[j].ID
+	})
+	return bundles, nil
+}
+
+// Find는 ID(또는 ID 접두사)에 해당하는 번들을 찾습니다.
+func Find(dir, id string) (Bundle, error) {
+	bundles, err := List(dir)
+	if err != nil {
+		return Bundle{}, err
+	}
+	var matches []Bundle
+	for _, b := range bundles {
+		if b.ID == id {
+			return b, nil
+		}
+		if strings.HasPrefix(b.ID, id) {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Bundle{}, fmt.Errorf("크래시 리포트를 찾을 수 없습니다: %s", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return Bundle{}, fmt.Errorf("ID 접두사 %q와 일치하는 크래시 리포트가 %d개 있습니다", id, len(matches))
+	}
+}
+
+// Open은 번들 파일의 report.json을 읽어 번들 정보를 반환합니다.
+func Open(path string) (Bundle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("크래시 번들 조회 실패: %w", err)
+	}
+
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("크래시 번들 열기 실패: %w", err)
+	}
+	defer zr.Close()
+
+	var report Report
+	found := false
+	for _, f := range zr.File {
+		if f.Name != entryReport {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return Bundle{}, fmt.Errorf("크래시 리포트 읽기 실패: %w", err)
+		}
+		err = json.NewDecoder(io.LimitReader(rc, 1<<20)).Decode(&report)
+		rc.Close()
+		if err != nil {
+			return Bundle{}, fmt.Errorf("크래시 리포트 파싱 실패: %w", err)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return Bundle{}, fmt.Errorf("크래시 번들에 %s가 없습니다: %s", entryReport, path)
+	}
+
+	_, uploadedErr := os.Stat(path + uploadedSuffix)
+	return Bundle{
+		Report:   report,
+		Path:     path,
+		Size:     info.Size(),
+		Uploaded: uploadedErr == nil,
+	}, nil
+}
+
+// NewUploadPayload는 번들을 서버 업로드용 요청 본문으로 변환합니다.
+func NewUploadPayload(b Bundle) (UploadPayload, error) {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return UploadPayload{}, fmt.Errorf("크래시 번들 읽기 실패: %w", err)
+	}
+	return UploadPayload{
+		Report:   b.Report,
+		Filename: filepath.Base(b.Path),
+		Bundle:   base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+// MarkUploaded는 번들이 서버에 업로드되었음을 기록합니다.
+func MarkUploaded(b Bundle) error {
+	if err := os.WriteFile(b.Path+uploadedSuffix, nil, 0600); err != nil {
+		return fmt.Errorf("업로드 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// RedactConfig는 YAML 설정에서 민감한 키의 값을 제거합니다.
+// YAML로 파싱할 수 없으면 줄 단위로 "키: 값" 형태의 민감 값을 제거합니다.
+func RedactConfig(raw []byte) []byte {
+	var root yaml.Node
+	if err := yaml.Unmarshal(raw, &root); err != nil {
+		return redactLines(raw)
+	}
+	redactNode(&root)
+	out, err := yaml.Marshal(&root)
+	if err != nil {
+		return redactLines(raw)
+	}
+	return out
+}
+
+// redactNode는 매핑 노드에서 민감한 키의 스칼라 값을 재귀적으로 제거합니다.
+func redactNode(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, val := n.Content[i], n.Content[i+1]
+			if sensitiveKeyPattern.MatchString(key.Value) && val.Kind == yaml.ScalarNode && val.Value != "" && !isPathKey(key.Value) {
+				val.Value = redactedValue
+				val.Tag = "!!str"
+				val.Style = 0
+				continue
+			}
+			redactNode(val)
+		}
+		return
+	}
+	for _, c := range n.Content {
+		redactNode(c)
+	}
+}
+
+// isPathKey는 토큰 파일 경로, 환경 변수 이름처럼 비밀 값 자체가 아닌 키인지 확인합니다.
+func isPathKey(key string) bool {
+	lower := strings.ToLower(key)
+	return strings.HasSuffix(lower, "_file") || strings.HasSuffix(lower, "_env") || strings.HasSuffix(lower, "_path")
+}
+
+// redactLines는 YAML 파싱에 실패한 설정에서 줄 단위로 민감 값을 제거합니다.
+func redactLines(raw []byte) []byte {
+	lines := strings.Split(string(raw), "\n")
+	for i, line := range lines {
+		key, _, ok := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if ok && sensitiveKeyPattern.MatchString(key) && !isPathKey(key) {
+			lines[i] = line[:strings.Index(line, ":")+1] + " " + redactedValue
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// bundleID는 번들 파일 이름에서 ID를 추출합니다.
+func bundleID(name string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(name, bundlePrefix), bundleExt)
+}
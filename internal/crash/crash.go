@@ -0,0 +1,279 @@
+// Package crash는 브리지 패닉 시 진단 번들을 로컬에 저장하는 크래시 리포터를 제공합니다.
+// 번들은 최근 로그, 고루틴 덤프, 민감 정보를 제거한 설정, 버전 정보를 담은 zip 파일이며
+// ~/.config/autopus/crash/에 저장됩니다. 서버 업로드는 사용자 동의 후 별도로 수행합니다.
+package crash
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxReports는 보관할 기본 최대 번들 수입니다.
+const DefaultMaxReports = 20
+
+// 번들 파일 이름 규칙과 번들 내부 항목 이름.
+const (
+	bundlePrefix    = "crash-"
+	bundleExt       = ".zip"
+	uploadedSuffix  = ".uploaded"
+	entryReport     = "report.json"
+	entryStack      = "stack.txt"
+	entryGoroutines = "goroutines.txt"
+	entryLogs       = "logs.txt"
+	entryConfig     = "config.yaml"
+	entryOutput     = "output.txt"
+)
+
+// VersionInfo는 번들에 기록할 빌드 정보입니다.
+type VersionInfo struct {
+	Version   string
+	Commit    string
+	BuildDate string
+}
+
+// Report는 번들의 report.json 내용입니다.
+type Report struct {
+	ID        string    `json:"id"`
+	Subsystem string    `json:"subsystem"`
+	Reason    string    `json:"reason"`
+	Time      time.Time `json:"time"`
+	Version   string    `json:"version"`
+	Commit    string    `json:"commit"`
+	BuildDate string    `json:"build_date"`
+	GoVersion string    `json:"go_version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+}
+
+// Config는 Reporter 설정입니다.
+type Config struct {
+	// Dir은 번들 저장 디렉토리입니다. 비어있으면 DefaultDir()을 사용합니다.
+	Dir string
+	// Version은 번들에 기록할 빌드 정보입니다.
+	Version VersionInfo
+	// ConfigFile은 번들에 포함할 설정 파일 경로입니다 (민감 정보는 제거됩니다).
+	ConfigFile string
+	// RecentLogs는 번들에 포함할 최근 로그 줄을 반환합니다 (nil이면 로그 생략).
+	RecentLogs func() []string
+	// MaxReports는 보관할 최대 번들 수입니다. 0 이하이면 DefaultMaxReports를 사용합니다.
+	MaxReports int
+}
+
+// Reporter는 크래시 번들을 생성하고 보관 개수를 관리합니다.
+type Reporter struct {
+	cfg Config
+	now func() time.Time
+}
+
+// installed는 Recover가 사용하는 전역 Reporter입니다.
+var installed atomic.Pointer[Reporter]
+
+// NewReporter는 새로운 Reporter를 생성합니다.
+func NewReporter(cfg Config) *Reporter {
+	if cfg.Dir == "" {
+		cfg.Dir = DefaultDir()
+	}
+	if cfg.MaxReports <= 0 {
+		cfg.MaxReports = DefaultMaxReports
+	}
+	return &Reporter{cfg: cfg, now: time.Now}
+}
+
+// DefaultDir은 기본 번들 저장 디렉토리(~/.config/autopus/crash)를 반환합니다.
+// XDG_CONFIG_HOME이 설정되어 있으면 그 아래를 사용합니다.
+func DefaultDir() string {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = os.TempDir()
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "autopus", "crash")
+}
+
+// Dir은 번들 저장 디렉토리를 반환합니다.
+func (r *Reporter) Dir() string {
+	return r.cfg.Dir
+}
+
+// Install은 Recover가 사용할 전역 Reporter를 설정합니다. nil이면 크래시 리포트를 끕니다.
+func Install(r *Reporter) {
+	installed.Store(r)
+}
+
+// Recover는 defer로 호출하여 패닉 발생 시 크래시 번들을 저장한 뒤 패닉을 다시 발생시킵니다.
+// 프로세스 종료 동작은 바꾸지 않고 진단 정보만 남깁니다. 설치된 Reporter가 없으면 아무 작업도 하지 않습니다.
+//
+//	go func() {
+//		defer crash.Recover("event-loop")
+//		...
+//	}()
+func Recover(subsystem string) {
+	r := installed.Load()
+	if r == nil {
+		return
+	}
+	if v := recover(); v != nil {
+		if path, err := r.Capture(subsystem, v, debug.Stack()); err == nil {
+			fmt.Fprintf(os.Stderr, "크래시 리포트가 저장되었습니다: %s\n", path)
+			fmt.Fprintln(os.Stderr, "'autopus crash upload'로 개발팀에 전송할 수 있습니다.")
+		} else {
+			fmt.Fprintf(os.Stderr, "크래시 리포트 저장 실패: %v\n", err)
+		}
+		panic(v)
+	}
+}
+
+// Capture는 크래시 번들을 저장하고 파일 경로를 반환합니다.
+// reason은 패닉 값 또는 크래시 원인, stack은 크래시가 발생한 고루틴의 스택입니다.
+func (r *Reporter) Capture(subsystem string, reason interface{}, stack []byte) (string, error) {
+	var goroutines bytes.Buffer
+	if p := pprof.Lookup("goroutine"); p != nil {
+		_ = p.WriteTo(&goroutines, 2)
+	}
+
+	entries := []bundleEntry{
+		{entryStack, stack},
+		{entryGoroutines, goroutines.Bytes()},
+	}
+	if r.cfg.RecentLogs != nil {
+		entries = append(entries, bundleEntry{entryLogs, []byte(strings.Join(r.cfg.RecentLogs(), "\n") + "\n")})
+	}
+	return r.save(subsystem, reason, entries)
+}
+
+// CaptureExit는 비정상 종료한 자식 프로세스의 크래시 번들을 저장하고 파일 경로를 반환합니다.
+// output은 자식 프로세스의 마지막 출력이며, 패닉/치명적 오류 트레이스가 있으면 stack.txt로 분리합니다.
+// 감독 프로세스 자신의 고루틴과 로그는 포함하지 않습니다.
+func (r *Reporter) CaptureExit(subsystem string, reason interface{}, output []byte) (string, error) {
+	return r.save(subsystem, reason, []bundleEntry{
+		{entryStack, extractTrace(output)},
+		{entryOutput, output},
+	})
+}
+
+// save는 report.json과 entries, 설정 파일로 번들을 만들어 저장하고 오래된 번들을 정리합니다.
+func (r *Reporter) save(subsystem string, reason interface{}, entries []bundleEntry) (string, error) {
+	if err := os.MkdirAll(r.cfg.Dir, 0700); err != nil {
+		return "", fmt.Errorf("크래시 디렉토리 생성 실패: %w", err)
+	}
+
+	now := r.now().UTC()
+	report := Report{
+		ID:        newReportID(now),
+		Subsystem: subsystem,
+		Reason:    fmt.Sprint(reason),
+		Time:      now,
+		Version:   r.cfg.Version.Version,
+		Commit:    r.cfg.Version.Commit,
+		BuildDate: r.cfg.Version.BuildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	data, err := r.buildBundle(report, entries)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(r.cfg.Dir, bundlePrefix+report.ID+bundleExt)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("크래시 번들 저장 실패: %w", err)
+	}
+
+	r.prune()
+	return path, nil
+}
+
+// bundleEntry는 번들 zip의 항목입니다.
+type bundleEntry struct {
+	name string
+	data []byte
+}
+
+// buildBundle은 report.json, entries, 민감 정보를 제거한 설정으로 번들 zip 내용을 생성합니다.
+func (r *Reporter) buildBundle(report Report, entries []bundleEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("크래시 리포트 직렬화 실패: %w", err)
+	}
+
+	entries = append([]bundleEntry{{entryReport, reportJSON}}, entries...)
+	if r.cfg.ConfigFile != "" {
+		if raw, readErr := os.ReadFile(r.cfg.ConfigFile); readErr == nil {
+			entries = append(entries, bundleEntry{entryConfig, RedactConfig(raw)})
+		}
+	}
+
+	for _, e := range entries {
+		w, err := zw.Create(e.name)
+		if err != nil {
+			return nil, fmt.Errorf("번들 항목 생성 실패 (%s): %w", e.name, err)
+		}
+		if _, err := w.Write(e.data); err != nil {
+			return nil, fmt.Errorf("번들 항목 쓰기 실패 (%s): %w", e.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("번들 압축 실패: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// extractTrace는 프로세스 출력에서 Go 런타임의 패닉/치명적 오류 트레이스를 찾아 반환합니다.
+// 트레이스가 없으면 nil을 반환합니다.
+func extractTrace(output []byte) []byte {
+	idx := -1
+	for _, marker := range []string{"\npanic: ", "\nfatal error: "} {
+		if i := bytes.Index(output, []byte(marker)); i >= 0 && (idx < 0 || i < idx) {
+			idx = i + 1
+		}
+	}
+	if idx < 0 {
+		for _, prefix := range []string{"panic: ", "fatal error: "} {
+			if bytes.HasPrefix(output, []byte(prefix)) {
+				return output
+			}
+		}
+		return nil
+	}
+	return output[idx:]
+}
+
+// prune은 MaxReports를 넘는 오래된 번들을 삭제합니다.
+func (r *Reporter) prune() {
+	bundles, err := List(r.cfg.Dir)
+	if err != nil || len(bundles) <= r.cfg.MaxReports {
+		return
+	}
+	// List는 최신순으로 정렬되어 있다.
+	for _, b := range bundles[r.cfg.MaxReports:] {
+		_ = os.Remove(b.Path)
+		_ = os.Remove(b.Path + uploadedSuffix)
+	}
+}
+
+// newReportID는 시간 순으로 정렬되는 번들 ID를 생성합니다.
+func newReportID(t time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return t.Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
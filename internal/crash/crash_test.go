@@ -0,0 +1,277 @@
+package crash
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readEntry는 번들 zip에서 항목 내용을 읽는다.
+func readEntry(t *testing.T, path, name string) string {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("zip.OpenReader() = %v", err)
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s) = %v", name, err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("ReadAll(%s) = %v", name, err)
+		}
+		return string(data)
+	}
+	t.Fatalf("bundle has no %s entry", name)
+	return ""
+}
+
+func TestReporter_Capture(t *testing.T) {
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(cfgFile, []byte("auth:\n  token: secret-value\n  token_file: /tmp/token\nserver:\n  url: wss://example.com\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReporter(Config{
+		Dir:        filepath.Join(dir, "crash"),
+		Version:    VersionInfo{Version: "1.2.3", Commit: "abc123"},
+		ConfigFile: cfgFile,
+		RecentLogs: func() []string { return []string{"line one", "line two"} },
+	})
+
+	path, err := r.Capture("event-loop", errors.New("boom"), []byte("goroutine 1 [running]"))
+	if err != nil {
+		t.Fatalf("Capture() = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("bundle perm = %o; want 0600", perm)
+	}
+
+	b, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	if b.Subsystem != "event-loop" || b.Reason != "boom" || b.Version != "1.2.3" || b.Commit != "abc123" {
+		t.Errorf("report = %+v; want subsystem/reason/version recorded", b.Report)
+	}
+	if b.Uploaded {
+		t.Error("Uploaded = true; want false for a new bundle")
+	}
+
+	if got := readEntry(t, path, entryStack); got != "goroutine 1 [running]" {
+		t.Errorf("stack = %q", got)
+	}
+	if got := readEntry(t, path, entryLogs); got != "line one\nline two\n" {
+		t.Errorf("logs = %q", got)
+	}
+	if got := readEntry(t, path, entryGoroutines); !strings.Contains(got, "goroutine") {
+		t.Errorf("goroutine dump missing: %q", got)
+	}
+	cfg := readEntry(t, path, entryConfig)
+	if strings.Contains(cfg, "secret-value") {
+		t.Errorf("config was not redacted: %q", cfg)
+	}
+	if !strings.Contains(cfg, "/tmp/token") || !strings.Contains(cfg, "wss://example.com") {
+		t.Errorf("config lost non-sensitive values: %q", cfg)
+	}
+}
+
+func TestReporter_CaptureExit(t *testing.T) {
+	r := NewReporter(Config{
+		Dir:        t.TempDir(),
+		RecentLogs: func() []string { return []string{"supervisor log"} },
+	})
+
+	output := []byte("INF connected\npanic: runtime error: index out of range\n\ngoroutine 7 [running]:\nmain.run()\n")
+	path, err := r.CaptureExit("connect", "exit status 2", output)
+	if err != nil {
+		t.Fatalf("CaptureExit() = %v", err)
+	}
+
+	b, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	if b.Subsystem != "connect" || b.Reason != "exit status 2" {
+		t.Errorf("report = %+v", b.Report)
+	}
+	if got := readEntry(t, path, entryOutput); got != string(output) {
+		t.Errorf("output = %q", got)
+	}
+	if got := readEntry(t, path, entryStack); !strings.HasPrefix(got, "panic: runtime error") || !strings.Contains(got, "goroutine 7") {
+		t.Errorf("stack = %q; want the panic trace only", got)
+	}
+
+	// 트레이스가 없는 출력은 빈 stack.txt
+	path, err = r.CaptureExit("connect", "signal: killed", []byte("INF connected\n"))
+	if err != nil {
+		t.Fatalf("CaptureExit() = %v", err)
+	}
+	if got := readEntry(t, path, entryStack); got != "" {
+		t.Errorf("stack = %q; want empty", got)
+	}
+}
+
+func TestReporter_PruneKeepsNewest(t *testing.T) {
+	dir := t.TempDir()
+	r := NewReporter(Config{Dir: dir, MaxReports: 2})
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := 0; i < 3; i++ {
+		now := base.Add(time.Duration(i) * time.Minute)
+		r.now = func() time.Time { return now }
+		path, err := r.Capture("main", "panic", nil)
+		if err != nil {
+			t.Fatalf("Capture(%d) = %v", i, err)
+		}
+		paths = append(paths, path)
+	}
+
+	bundles, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	if len(bundles) != 2 {
+		t.Fatalf("len(List()) = %d; want 2", len(bundles))
+	}
+	if bundles[0].Path != paths[2] || bundles[1].Path != paths[1] {
+		t.Errorf("List() = [%s %s]; want newest two bundles", bundles[0].Path, bundles[1].Path)
+	}
+	if _, err := os.Stat(paths[0]); !os.IsNotExist(err) {
+		t.Errorf("oldest bundle still exists: %v", err)
+	}
+}
+
+func TestRecover_CapturesAndRepanics(t *testing.T) {
+	dir := t.TempDir()
+	Install(NewReporter(Config{Dir: dir}))
+	defer Install(nil)
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("recover() = %v; want re-panicked value", v)
+			}
+		}()
+		defer Recover("test")
+		panic("boom")
+	}()
+
+	bundles, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	if len(bundles) != 1 || bundles[0].Subsystem != "test" {
+		t.Errorf("List() = %+v; want one bundle from subsystem test", bundles)
+	}
+}
+
+func TestList_MissingDir(t *testing.T) {
+	bundles, err := List(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	if len(bundles) != 0 {
+		t.Errorf("len(List()) = %d; want 0", len(bundles))
+	}
+}
+
+func TestFindAndMarkUploaded(t *testing.T) {
+	dir := t.TempDir()
+	r := NewReporter(Config{Dir: dir})
+	path, err := r.Capture("main", "panic", nil)
+	if err != nil {
+		t.Fatalf("Capture() = %v", err)
+	}
+	id := bundleID(filepath.Base(path))
+
+	b, err := Find(dir, id[:8])
+	if err != nil {
+		t.Fatalf("Find(prefix) = %v", err)
+	}
+	if b.ID != id {
+		t.Errorf("Find() ID = %s; want %s", b.ID, id)
+	}
+	if _, err := Find(dir, "19990101"); err == nil {
+		t.Error("Find(unknown) = nil; want error")
+	}
+
+	if err := MarkUploaded(b); err != nil {
+		t.Fatalf("MarkUploaded() = %v", err)
+	}
+	b, err = Find(dir, id)
+	if err != nil {
+		t.Fatalf("Find() = %v", err)
+	}
+	if !b.Uploaded {
+		t.Error("Uploaded = false; want true after MarkUploaded")
+	}
+
+	payload, err := NewUploadPayload(b)
+	if err != nil {
+		t.Fatalf("NewUploadPayload() = %v", err)
+	}
+	if payload.Filename != filepath.Base(path) || payload.Bundle == "" {
+		t.Errorf("payload = {Filename: %s, Bundle len: %d}", payload.Filename, len(payload.Bundle))
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		redacted []string
+		kept     []string
+	}{
+		{
+			name:     "중첩된 민감 키",
+			input:    "auth:\n  token: abc\n  refresh_token: def\nproviders:\n  claude:\n    api_key: sk-123\n",
+			redacted: []string{"abc", "def", "sk-123"},
+		},
+		{
+			name:  "경로/환경 변수 키는 유지",
+			input: "auth:\n  token_file: /home/me/token\n  api_key_env: CLAUDE_API_KEY\n",
+			kept:  []string{"/home/me/token", "CLAUDE_API_KEY"},
+		},
+		{
+			name:     "YAML 파싱 실패 시 줄 단위 제거",
+			input:    "password: hunter2\n\t: [broken\n",
+			redacted: []string{"hunter2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(RedactConfig([]byte(tt.input)))
+			for _, s := range tt.redacted {
+				if strings.Contains(got, s) {
+					t.Errorf("RedactConfig() kept %q: %s", s, got)
+				}
+			}
+			for _, s := range tt.kept {
+				if !strings.Contains(got, s) {
+					t.Errorf("RedactConfig() removed %q: %s", s, got)
+				}
+			}
+		})
+	}
+}
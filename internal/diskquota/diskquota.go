@@ -0,0 +1,134 @@
+// Package diskquota는 코드 생성 샌드박스와 MCP 배포 디렉토리의 디스크 사용량 제한을 제공합니다.
+// 디렉토리 크기 계산, 할당량 검사, 기간이 지난 항목 정리(GC)를 담당합니다.
+package diskquota
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ErrQuotaExceeded는 디스크 할당량을 초과했음을 나타냅니다.
+// 결과 페이로드에는 QUOTA_EXCEEDED 에러 코드로 보고됩니다.
+var ErrQuotaExceeded = errors.New("디스크 할당량 초과")
+
+// ExceededError는 할당량 초과의 상세 정보입니다.
+// errors.Is(err, ErrQuotaExceeded)로 판별할 수 있습니다.
+type ExceededError struct {
+	// Scope는 초과한 할당량 종류입니다 (예: "샌드박스 전체", "서비스").
+	Scope string
+	// Path는 할당량이 적용된 디렉토리입니다.
+	Path string
+	// Used는 사용 중이거나 사용하려는 바이트 수입니다.
+	Used int64
+	// Limit는 허용된 최대 바이트 수입니다.
+	Limit int64
+}
+
+// Error는 사용량과 제한을 포함한 에러 메시지를 반환합니다.
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s 디스크 할당량 초과: %s (사용 %s / 제한 %s)",
+		e.Scope, e.Path, FormatBytes(e.Used), FormatBytes(e.Limit))
+}
+
+// Unwrap은 ErrQuotaExceeded를 반환합니다.
+func (e *ExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Check는 used가 limit를 넘으면 ExceededError를 반환합니다.
+// limit가 0 이하이면 제한하지 않습니다.
+func Check(scope, path string, used, limit int64) error {
+	if limit <= 0 || used <= limit {
+		return nil
+	}
+	return &ExceededError{Scope: scope, Path: path, Used: used, Limit: limit}
+}
+
+// DirSize는 dir 아래 일반 파일 크기의 합을 반환합니다.
+// 디렉토리가 없으면 0을 반환하며, 심볼릭 링크는 따라가지 않습니다.
+func DirSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // 계산 중 삭제된 파일
+			}
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("디렉토리 크기 계산 실패 %q: %w", dir, err)
+	}
+	return total, nil
+}
+
+// RemoveExpired는 baseDir 바로 아래 항목 중 수정 시각이 maxAge보다 오래된 것을 삭제하고
+// 삭제한 항목 이름을 이름순으로 반환합니다.
+// maxAge가 0 이하이거나 baseDir이 없으면 아무것도 삭제하지 않습니다.
+func RemoveExpired(baseDir string, maxAge time.Duration, now time.Time) ([]string, error) {
+	if maxAge <= 0 {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("디렉토리 읽기 실패 %q: %w", baseDir, err)
+	}
+
+	cutoff := now.Add(-maxAge)
+	var (
+		removed []string
+		errs    []error
+	)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue // 읽는 도중 삭제된 항목
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(baseDir, entry.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, entry.Name())
+	}
+	sort.Strings(removed)
+	return removed, errors.Join(errs...)
+}
+
+// FormatBytes는 바이트 수를 사람이 읽기 쉬운 단위(B, KiB, MiB, GiB)로 표시합니다.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value := float64(n)
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		value /= unit
+		if value < unit || suffix == "GiB" {
+			return fmt.Sprintf("%.1f%s", value, suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", n)
+}
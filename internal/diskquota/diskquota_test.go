@@ -0,0 +1,115 @@
+package diskquota
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path string, size int) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), 100)
+	writeFile(t, filepath.Join(dir, "sub", "b.txt"), 250)
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() error: %v", err)
+	}
+	if size != 350 {
+		t.Errorf("DirSize() = %d, want 350", size)
+	}
+
+	size, err = DirSize(filepath.Join(dir, "missing"))
+	if err != nil {
+		t.Fatalf("DirSize() on missing dir error: %v", err)
+	}
+	if size != 0 {
+		t.Errorf("DirSize() on missing dir = %d, want 0", size)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check("service", "/tmp/x", 100, 100); err != nil {
+		t.Errorf("Check() at limit = %v, want nil", err)
+	}
+	if err := Check("service", "/tmp/x", 500, 0); err != nil {
+		t.Errorf("Check() with no limit = %v, want nil", err)
+	}
+
+	err := Check("service", "/tmp/x", 2048, 1024)
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Check() over limit = %v, want ErrQuotaExceeded", err)
+	}
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("Check() error is not *ExceededError: %T", err)
+	}
+	if exceeded.Used != 2048 || exceeded.Limit != 1024 {
+		t.Errorf("ExceededError = %+v", exceeded)
+	}
+	if !strings.Contains(err.Error(), "2.0KiB") || !strings.Contains(err.Error(), "1.0KiB") {
+		t.Errorf("error message %q should include used and limit", err.Error())
+	}
+}
+
+func TestRemoveExpired(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Now()
+
+	oldDir := filepath.Join(baseDir, "svc-old")
+	writeFile(t, filepath.Join(oldDir, "index.ts"), 10)
+	if err := os.Chtimes(oldDir, now.Add(-48*time.Hour), now.Add(-48*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	newDir := filepath.Join(baseDir, "svc-new")
+	writeFile(t, filepath.Join(newDir, "index.ts"), 10)
+
+	removed, err := RemoveExpired(baseDir, 24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("RemoveExpired() error: %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "svc-old" {
+		t.Errorf("RemoveExpired() removed = %v, want [svc-old]", removed)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Error("expired directory should be removed")
+	}
+	if _, err := os.Stat(newDir); err != nil {
+		t.Errorf("recent directory should be kept: %v", err)
+	}
+
+	removed, err = RemoveExpired(filepath.Join(baseDir, "missing"), time.Hour, now)
+	if err != nil || removed != nil {
+		t.Errorf("RemoveExpired() on missing dir = %v, %v", removed, err)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{512, "512B"},
+		{1536, "1.5KiB"},
+		{100 << 20, "100.0MiB"},
+		{3 << 30, "3.0GiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
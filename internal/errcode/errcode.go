@@ -0,0 +1,246 @@
+// Package errcode는 Bridge가 서버와 MCP 클라이언트에 보고하는 에러 코드 카탈로그를 제공합니다.
+// 코드마다 심각도, 기본 재시도 가능 여부, 사용자에게 보여줄 해결 방법(힌트)을 한곳에서 관리하여
+// Router, 실행기, MCP 서버가 같은 코드와 힌트를 일관되게 보고하도록 합니다.
+package errcode
+
+import (
+	"sort"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+)
+
+// 요청 처리 에러 코드 (Router)
+const (
+	// InvalidPayload는 서버가 보낸 메시지 페이로드를 파싱할 수 없을 때 사용합니다.
+	InvalidPayload = "INVALID_PAYLOAD"
+	// NoExecutor는 요청을 처리할 실행기가 설정되지 않았을 때 사용합니다.
+	NoExecutor = "NO_EXECUTOR"
+	// NoHandler는 요청 유형의 핸들러가 설정되지 않았을 때 사용합니다.
+	NoHandler = "NO_HANDLER"
+	// ExecutionError는 구체적인 코드가 없는 실행 실패에 사용합니다.
+	ExecutionError = "EXECUTION_ERROR"
+	// BridgeShuttingDown은 종료 드레이닝 중 새 작업을 거절할 때 사용합니다.
+	BridgeShuttingDown = "BRIDGE_SHUTTING_DOWN"
+	// BridgeAuthRequired는 자격 증명 만료로 재인증을 기다리며 새 작업을 거절할 때 사용합니다.
+	BridgeAuthRequired = "BRIDGE_AUTH_REQUIRED"
+	// CheckpointNotFound는 재개할 작업의 체크포인트가 없을 때 사용합니다.
+	CheckpointNotFound = "CHECKPOINT_NOT_FOUND"
+	// DelegationRejected는 다른 Bridge로의 위임이 거절되었을 때 사용합니다.
+	DelegationRejected = "DELEGATION_REJECTED"
+	// ReplayedMessage는 오래되었거나 이미 처리한 서명 메시지(재전송)를 거절할 때 사용합니다.
+	ReplayedMessage = "REPLAYED_MESSAGE"
+)
+
+// 작업 실행 에러 코드 (실행기)
+const (
+	// ProviderNotFound는 모델의 프로바이더가 등록되지 않았을 때 사용합니다.
+	// 백엔드의 BridgeErrCodeProviderNotFound("provider_not_found")와 동일한 값입니다.
+	ProviderNotFound = "provider_not_found"
+	// ProviderError는 프로바이더 API 에러에 사용합니다.
+	ProviderError = "PROVIDER_ERROR"
+	// Timeout은 작업 타임아웃에 사용합니다.
+	Timeout = "TIMEOUT"
+	// Cancelled는 작업 취소에 사용합니다.
+	Cancelled = "CANCELLED"
+	// InternalError는 예상치 못한 에러에 사용합니다.
+	InternalError = "INTERNAL_ERROR"
+	// SandboxViolation은 작업 디렉토리 샌드박스 정책 위반에 사용합니다.
+	SandboxViolation = "SANDBOX_VIOLATION"
+	// UnsupportedModel은 서버가 고정한 provider/model/승인 정책을 로컬에서 만족할 수 없을 때 사용합니다.
+	UnsupportedModel = ws.TaskErrorUnsupportedModel
+	// IsolationFailed는 작업 디렉토리 격리 준비 실패에 사용합니다.
+	IsolationFailed = "ISOLATION_FAILED"
+	// CredentialsFailed는 작업 자격 증명 준비 실패에 사용합니다.
+	CredentialsFailed = "CREDENTIALS_FAILED"
+	// WorkDirNotAllowed는 서버가 요청한 작업 디렉토리가 허용된 루트 밖에 있을 때 사용합니다.
+	WorkDirNotAllowed = ws.TaskErrorWorkDirNotAllowed
+	// WorkDirInvalid는 요청한 작업 디렉토리가 없거나 만들 수 없을 때 사용합니다.
+	WorkDirInvalid = "WORK_DIR_INVALID"
+	// ReadOnly는 읽기 전용 모드의 Bridge가 변경 요청(작업 실행, CLI, 배포, 컴퓨터 사용 등)을 거절할 때 사용합니다.
+	ReadOnly = ws.TaskErrorReadOnly
+	// PromptTooLarge는 작업 프롬프트가 설정된 최대 크기(limits.max_prompt_bytes)를 넘을 때 사용합니다.
+	PromptTooLarge = ws.TaskErrorPromptTooLarge
+)
+
+// MCP 에러 코드 (MCP 서버, MCP 관리, 코드 생성/배포)
+const (
+	// PermissionDenied는 권한 설정으로 거부된 MCP 도구 호출에 사용합니다.
+	PermissionDenied = "PERMISSION_DENIED"
+	// ToolBusy는 동시 실행 한도로 대기하다 시간이 초과된 MCP 도구 호출에 사용합니다.
+	ToolBusy = "TOOL_BUSY"
+	// QuotaExceeded는 코드 생성 샌드박스나 MCP 배포 디렉토리의 디스크 할당량 초과에 사용합니다.
+	QuotaExceeded = ws.MCPErrorCodeQuotaExceeded
+	// MCPStartFailed는 MCP 서버 시작 실패에 사용합니다.
+	MCPStartFailed = "MCP_START_FAILED"
+	// MCPStopFailed는 MCP 서버 중지 실패에 사용합니다.
+	MCPStopFailed = "MCP_STOP_FAILED"
+)
+
+// 코딩 릴레이 에러 코드. 백엔드와 맞춘 기존 값이므로 소문자를 유지합니다.
+const (
+	// RelayRunnerNotConfigured는 코딩 릴레이 실행기가 설정되지 않았을 때 사용합니다.
+	RelayRunnerNotConfigured = "runner_not_configured"
+	// RelaySlotExhausted는 코딩 세션 슬롯을 얻지 못했을 때 사용합니다.
+	RelaySlotExhausted = "slot_exhausted"
+	// RelaySessionCreateFailed는 코딩 세션 생성 실패에 사용합니다.
+	RelaySessionCreateFailed = "session_create_failed"
+	// RelaySessionOpenFailed는 코딩 세션 열기 실패에 사용합니다.
+	RelaySessionOpenFailed = "session_open_failed"
+	// RelaySessionSendFailed는 코딩 세션 메시지 전송 실패에 사용합니다.
+	RelaySessionSendFailed = "session_send_failed"
+	// RelayContextCancelled는 릴레이 도중 컨텍스트가 취소되었을 때 사용합니다.
+	RelayContextCancelled = "context_cancelled"
+)
+
+// Info는 에러 코드 하나의 카탈로그 항목입니다.
+type Info struct {
+	// Code는 에러 코드입니다.
+	Code string
+	// Severity는 심각도입니다.
+	Severity ws.ErrorSeverity
+	// Retryable은 같은 요청을 다시 보내면 성공할 수 있는지의 기본값입니다.
+	// 호출자가 에러 원인을 더 잘 알면 페이로드에서 다르게 보고할 수 있습니다.
+	Retryable bool
+	// hintKey는 힌트 메시지의 i18n 키입니다.
+	hintKey string
+}
+
+// Hint는 현재 언어로 사용자에게 보여줄 해결 방법을 반환합니다.
+func (i Info) Hint() string {
+	return i18n.T(i.hintKey)
+}
+
+// catalog는 코드별 카탈로그 항목입니다.
+var catalog = map[string]Info{}
+
+// register는 카탈로그에 코드를 추가합니다. 힌트 키는 "errcode.hint.<code>"입니다.
+func register(code string, severity ws.ErrorSeverity, retryable bool, hint string) {
+	catalog[code] = Info{Code: code, Severity: severity, Retryable: retryable, hintKey: "errcode.hint." + hint}
+}
+
+func init() {
+	register(InvalidPayload, ws.ErrorSeverityError, false, "invalid_payload")
+	register(NoExecutor, ws.ErrorSeverityCritical, false, "no_executor")
+	register(NoHandler, ws.ErrorSeverityCritical, false, "no_handler")
+	register(ExecutionError, ws.ErrorSeverityError, false, "execution_error")
+	register(BridgeShuttingDown, ws.ErrorSeverityWarning, true, "bridge_shutting_down")
+	register(BridgeAuthRequired, ws.ErrorSeverityWarning, true, "bridge_auth_required")
+	register(CheckpointNotFound, ws.ErrorSeverityWarning, true, "checkpoint_not_found")
+	register(DelegationRejected, ws.ErrorSeverityWarning, true, "delegation_rejected")
+	register(ReplayedMessage, ws.ErrorSeverityWarning, false, "replayed_message")
+
+	register(ProviderNotFound, ws.ErrorSeverityError, false, "provider_not_found")
+	register(ProviderError, ws.ErrorSeverityError, true, "provider_error")
+	register(Timeout, ws.ErrorSeverityWarning, true, "timeout")
+	register(Cancelled, ws.ErrorSeverityWarning, false, "cancelled")
+	register(InternalError, ws.ErrorSeverityError, false, "internal_error")
+	register(SandboxViolation, ws.ErrorSeverityError, false, "sandbox_violation")
+	register(UnsupportedModel, ws.ErrorSeverityError, false, "unsupported_model")
+	register(IsolationFailed, ws.ErrorSeverityError, false, "isolation_failed")
+	register(CredentialsFailed, ws.ErrorSeverityError, false, "credentials_failed")
+	register(WorkDirNotAllowed, ws.ErrorSeverityError, false, "work_dir_not_allowed")
+	register(WorkDirInvalid, ws.ErrorSeverityError, false, "work_dir_invalid")
+	register(ReadOnly, ws.ErrorSeverityError, false, "read_only")
+	register(PromptTooLarge, ws.ErrorSeverityError, false, "prompt_too_large")
+
+	register(PermissionDenied, ws.ErrorSeverityError, false, "permission_denied")
+	register(ToolBusy, ws.ErrorSeverityWarning, true, "tool_busy")
+	register(QuotaExceeded, ws.ErrorSeverityError, false, "quota_exceeded")
+	register(MCPStartFailed, ws.ErrorSeverityError, false, "mcp_start_failed")
+	register(MCPStopFailed, ws.ErrorSeverityWarning, false, "mcp_stop_failed")
+
+	register(RelayRunnerNotConfigured, ws.ErrorSeverityCritical, false, "relay_runner_not_configured")
+	register(RelaySlotExhausted, ws.ErrorSeverityWarning, true, "relay_slot_exhausted")
+	register(RelaySessionCreateFailed, ws.ErrorSeverityError, true, "relay_session_failed")
+	register(RelaySessionOpenFailed, ws.ErrorSeverityError, true, "relay_session_failed")
+	register(RelaySessionSendFailed, ws.ErrorSeverityError, true, "relay_session_failed")
+	register(RelayContextCancelled, ws.ErrorSeverityWarning, false, "cancelled")
+}
+
+// Lookup은 코드의 카탈로그 항목을 반환합니다.
+// 등록되지 않은 코드는 재시도 불가 error 심각도와 일반 힌트로 처리하며 ok가 false입니다.
+func Lookup(code string) (info Info, ok bool) {
+	info, ok = catalog[code]
+	if !ok {
+		info = Info{Code: code, Severity: ws.ErrorSeverityError, hintKey: "errcode.hint.unknown"}
+	}
+	return info, ok
+}
+
+// Hint는 코드의 해결 방법을 현재 언어로 반환합니다.
+func Hint(code string) string {
+	info, _ := Lookup(code)
+	return info.Hint()
+}
+
+// Retryable은 코드의 기본 재시도 가능 여부를 반환합니다.
+func Retryable(code string) bool {
+	info, _ := Lookup(code)
+	return info.Retryable
+}
+
+// Codes는 등록된 모든 코드를 정렬하여 반환합니다.
+func Codes() []string {
+	codes := make([]string, 0, len(catalog))
+	for code := range catalog {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Format은 MCP 도구 결과처럼 텍스트로만 에러를 전달할 때 쓰는 "CODE: 메시지" 형식에 힌트 줄을 덧붙입니다.
+func Format(code, message string) string {
+	return code + ": " + message + "\n" + i18n.T("errcode.hint_line", Hint(code))
+}
+
+// annotate는 비어 있는 심각도와 힌트를 카탈로그 값으로 채웁니다.
+func annotate(code string, severity *ws.ErrorSeverity, hint *string) {
+	if code == "" {
+		return
+	}
+	info, _ := Lookup(code)
+	if *severity == "" {
+		*severity = info.Severity
+	}
+	if *hint == "" {
+		*hint = info.Hint()
+	}
+}
+
+// TaskError는 task_error 페이로드의 Severity와 Hint를 채웁니다. 이미 설정된 값은 유지합니다.
+func TaskError(p ws.TaskErrorPayload) ws.TaskErrorPayload {
+	annotate(p.Code, &p.Severity, &p.Hint)
+	return p
+}
+
+// AgentResponseError는 agent_response_error 페이로드의 Severity와 Hint를 채웁니다.
+func AgentResponseError(p ws.AgentResponseErrorPayload) ws.AgentResponseErrorPayload {
+	annotate(p.Code, &p.Severity, &p.Hint)
+	return p
+}
+
+// CodingRelayError는 coding_relay_error 페이로드의 Severity와 Hint를 채웁니다.
+func CodingRelayError(p ws.CodingRelayErrorPayload) ws.CodingRelayErrorPayload {
+	annotate(p.Code, &p.Severity, &p.Hint)
+	return p
+}
+
+// MCPError는 mcp_error 페이로드의 Severity와 Hint를 채웁니다.
+// IsFatal인 에러는 심각도를 critical로 보고합니다.
+func MCPError(p ws.MCPErrorPayload) ws.MCPErrorPayload {
+	if p.IsFatal && p.Severity == "" {
+		p.Severity = ws.ErrorSeverityCritical
+	}
+	annotate(p.Code, &p.Severity, &p.Hint)
+	return p
+}
+
+// ResultHint는 결과 페이로드의 error_code에 대한 힌트를 반환합니다. 코드가 없으면 빈 문자열입니다.
+func ResultHint(code string) string {
+	if code == "" {
+		return ""
+	}
+	return Hint(code)
+}
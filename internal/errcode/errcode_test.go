@@ -0,0 +1,100 @@
+package errcode
+
+import (
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+)
+
+func TestCatalog_EveryCodeHasHint(t *testing.T) {
+	for _, code := range Codes() {
+		info, ok := Lookup(code)
+		if !ok {
+			t.Fatalf("Lookup(%q) not found", code)
+		}
+		switch info.Severity {
+		case ws.ErrorSeverityWarning, ws.ErrorSeverityError, ws.ErrorSeverityCritical:
+		default:
+			t.Errorf("%s: unknown severity %q", code, info.Severity)
+		}
+		for _, lang := range i18n.Supported() {
+			if hint := i18n.Tl(lang, info.hintKey); hint == "" || hint == info.hintKey {
+				t.Errorf("%s: no %s hint for %q", code, lang, info.hintKey)
+			}
+		}
+	}
+}
+
+func TestLookup_UnknownCode(t *testing.T) {
+	info, ok := Lookup("SOMETHING_NEW")
+	if ok {
+		t.Fatal("unknown code must not be reported as registered")
+	}
+	if info.Code != "SOMETHING_NEW" || info.Severity != ws.ErrorSeverityError || info.Retryable {
+		t.Errorf("unknown code info = %+v", info)
+	}
+	if Hint("SOMETHING_NEW") != i18n.T("errcode.hint.unknown") {
+		t.Errorf("unknown code must use the generic hint, got %q", Hint("SOMETHING_NEW"))
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	if !Retryable(BridgeShuttingDown) || !Retryable(ToolBusy) {
+		t.Error("shutdown and busy errors must be retryable by default")
+	}
+	if Retryable(InvalidPayload) || Retryable(PermissionDenied) {
+		t.Error("invalid payload and permission errors must not be retryable by default")
+	}
+}
+
+func TestTaskError_FillsSeverityAndHint(t *testing.T) {
+	p := TaskError(ws.TaskErrorPayload{ExecutionID: "exec-1", Code: NoExecutor, Message: "no executor"})
+	if p.Severity != ws.ErrorSeverityCritical {
+		t.Errorf("Severity = %q, want critical", p.Severity)
+	}
+	if p.Hint != Hint(NoExecutor) {
+		t.Errorf("Hint = %q", p.Hint)
+	}
+
+	// 이미 설정된 값은 유지합니다.
+	p = TaskError(ws.TaskErrorPayload{Code: Timeout, Severity: ws.ErrorSeverityError, Hint: "custom"})
+	if p.Severity != ws.ErrorSeverityError || p.Hint != "custom" {
+		t.Errorf("explicit fields overwritten: %+v", p)
+	}
+
+	// 코드가 없으면 채우지 않습니다.
+	if p := TaskError(ws.TaskErrorPayload{Message: "x"}); p.Severity != "" || p.Hint != "" {
+		t.Errorf("payload without code annotated: %+v", p)
+	}
+}
+
+func TestMCPError_FatalIsCritical(t *testing.T) {
+	p := MCPError(ws.MCPErrorPayload{ServerName: "fs", Error: "boom", IsFatal: true, Code: MCPStartFailed})
+	if p.Severity != ws.ErrorSeverityCritical {
+		t.Errorf("Severity = %q, want critical", p.Severity)
+	}
+	if p.Hint != Hint(MCPStartFailed) {
+		t.Errorf("Hint = %q", p.Hint)
+	}
+	if p := MCPError(ws.MCPErrorPayload{Code: MCPStopFailed}); p.Severity != ws.ErrorSeverityWarning {
+		t.Errorf("non-fatal Severity = %q, want warning", p.Severity)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	i18n.SetLang(i18n.English)
+	defer i18n.SetLang(i18n.DefaultLang)
+
+	got := Format(ToolBusy, "tool 'x' is busy")
+	if !strings.HasPrefix(got, "TOOL_BUSY: tool 'x' is busy\n") {
+		t.Errorf("Format() = %q", got)
+	}
+	if !strings.HasSuffix(got, "Hint: "+Hint(ToolBusy)) {
+		t.Errorf("Format() must end with the hint line: %q", got)
+	}
+	if ResultHint("") != "" {
+		t.Error("ResultHint of an empty code must be empty")
+	}
+}
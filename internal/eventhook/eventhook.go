@@ -0,0 +1,363 @@
+// Package eventhook은 Bridge 수명 주기 이벤트(연결, 연결 끊김, 작업 시작/완료/실패,
+// Computer Use 세션 시작, 승인 대기, 재연결 포기)가 발생하면 설정된 셸 명령, 웹훅,
+// 데스크톱 알림을 실행합니다. 채팅 알림 같은 사용자 통합을 코드 변경 없이 설정만으로 연결합니다.
+package eventhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+	"github.com/rs/zerolog/log"
+)
+
+// 수명 주기 이벤트 종류입니다.
+const (
+	EventConnected              = "connected"
+	EventDisconnected           = "disconnected"
+	EventTaskStarted            = "task_started"
+	EventTaskCompleted          = "task_completed"
+	EventTaskFailed             = "task_failed"
+	EventComputerSessionStarted = "computer_session_started"
+	// EventApprovalRequired는 실행 중인 작업의 도구 호출이 서버 승인을 기다릴 때 발생합니다.
+	EventApprovalRequired = "approval_required"
+	// EventActionApprovalPending은 서버 주도 작업(CLI, 배포, Computer Use 등)이 로컬 터미널 승인을 기다릴 때 발생합니다.
+	EventActionApprovalPending = "action_approval_pending"
+	// EventReconnectExhausted는 최대 재연결 시도를 모두 실패해 연결을 포기했을 때 발생합니다.
+	EventReconnectExhausted = "reconnect_exhausted"
+)
+
+// Events는 지원하는 모든 이벤트 종류입니다.
+var Events = []string{
+	EventConnected,
+	EventDisconnected,
+	EventTaskStarted,
+	EventTaskCompleted,
+	EventTaskFailed,
+	EventComputerSessionStarted,
+	EventApprovalRequired,
+	EventActionApprovalPending,
+	EventReconnectExhausted,
+}
+
+// DefaultTimeout은 훅 하나의 기본 실행 제한 시간입니다.
+const DefaultTimeout = 10 * time.Second
+
+// Event는 훅에 전달되는 이벤트입니다. 페이로드 템플릿의 데이터이기도 합니다.
+type Event struct {
+	// Type은 이벤트 종류입니다 (Events 중 하나).
+	Type string `json:"event"`
+	// Timestamp는 이벤트 발생 시각입니다.
+	Timestamp time.Time `json:"timestamp"`
+	// Data는 이벤트별 추가 정보입니다 (예: execution_id, provider, error, reason).
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// Hook은 이벤트에 반응해 실행할 셸 명령 또는 웹훅 하나입니다.
+type Hook struct {
+	// Name은 로그에 표시할 훅 이름입니다.
+	Name string
+	// Events는 반응할 이벤트 종류입니다. 비어 있으면 모든 이벤트에 반응합니다.
+	Events []string
+	// Command는 sh -c로 실행할 셸 명령입니다.
+	// 렌더링된 페이로드를 표준 입력으로 받고, 이벤트 정보는 AUTOPUS_* 환경 변수로 받습니다.
+	Command string
+	// URL은 렌더링된 페이로드를 보낼 웹훅 주소입니다.
+	URL string
+	// Method는 웹훅 HTTP 메서드입니다. 기본값: POST.
+	Method string
+	// Headers는 웹훅 요청 헤더입니다. 값의 ${VAR}는 환경 변수로 치환합니다.
+	Headers map[string]string
+	// Payload는 text/template 형식의 페이로드입니다. 비어 있으면 Event를 JSON으로 보냅니다.
+	Payload string
+	// Notify가 true이면 셸 명령이나 웹훅 대신 데스크톱 알림(macOS, Linux)을 띄웁니다.
+	Notify bool
+	// DedupWindow는 데스크톱 알림의 중복 제거 구간입니다. 같은 대상(실행, 승인 요청 등)의
+	// 같은 이벤트는 이 구간 안에 한 번만 알립니다. 0이면 DefaultNotifyDedupWindow를 사용합니다.
+	DedupWindow time.Duration
+	// Timeout은 실행 제한 시간입니다. 0이면 DefaultTimeout을 사용합니다.
+	Timeout time.Duration
+}
+
+// compiledHook은 페이로드 템플릿을 미리 파싱한 훅입니다.
+type compiledHook struct {
+	Hook
+	payload *template.Template
+}
+
+// Dispatcher는 이벤트를 해당 훅들에 비동기로 전달합니다.
+// nil Dispatcher의 Fire는 아무 동작도 하지 않습니다.
+type Dispatcher struct {
+	hooks      []compiledHook
+	httpClient *http.Client
+	now        func() time.Time
+
+	// mu는 running, idle, closed 접근을 보호합니다.
+	// sync.WaitGroup은 Wait 중에 Add를 호출할 수 없으므로 실행 중인 훅 수를 직접 셉니다.
+	mu sync.Mutex
+	// running은 실행 중인 훅 수입니다.
+	running int
+	// idle은 running이 0이 되면 닫히는 채널입니다. running이 0이면 nil입니다.
+	idle chan struct{}
+	// closed가 true이면 Fire는 더 이상 훅을 시작하지 않습니다 (Close).
+	closed bool
+	// notify는 데스크톱 알림을 띄웁니다 (테스트에서 교체).
+	notify func(ctx context.Context, title, body string) error
+
+	// notifiedMu는 notified 접근을 보호합니다.
+	notifiedMu sync.Mutex
+	// notified는 중복 제거 키별 중복 제거 구간의 끝 시각입니다.
+	notified map[string]time.Time
+}
+
+// templateFuncs는 페이로드 템플릿에서 사용할 수 있는 함수입니다.
+var templateFuncs = template.FuncMap{
+	// json은 값을 JSON으로 인코딩합니다 (JSON 페이로드 안에 문자열을 안전하게 넣을 때 사용).
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// New는 훅 목록을 검증하고 Dispatcher를 생성합니다.
+// 훅이 하나도 없으면 nil을 반환합니다.
+func New(hooks []Hook) (*Dispatcher, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+	d := &Dispatcher{
+		httpClient: &http.Client{},
+		now:        time.Now,
+		notify:     sendDesktopNotification,
+		notified:   make(map[string]time.Time),
+	}
+	for i, h := range hooks {
+		if h.Name == "" {
+			h.Name = fmt.Sprintf("hook-%d", i+1)
+		}
+		if countSet(h.Command != "", h.URL != "", h.Notify) != 1 {
+			return nil, fmt.Errorf("훅 %q: command, url, notify 중 하나만 지정해야 합니다", h.Name)
+		}
+		for _, ev := range h.Events {
+			if !slices.Contains(Events, ev) {
+				return nil, fmt.Errorf("훅 %q: 알 수 없는 이벤트 %q (지원: %s)", h.Name, ev, strings.Join(Events, ", "))
+			}
+		}
+		if h.Method == "" {
+			h.Method = http.MethodPost
+		}
+		h.Method = strings.ToUpper(h.Method)
+		if h.Timeout <= 0 {
+			h.Timeout = DefaultTimeout
+		}
+		if h.DedupWindow <= 0 {
+			h.DedupWindow = DefaultNotifyDedupWindow
+		}
+		ch := compiledHook{Hook: h}
+		if h.Payload != "" {
+			tmpl, err := template.New(h.Name).Funcs(templateFuncs).Option("missingkey=zero").Parse(h.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("훅 %q: payload 템플릿 파싱 실패: %w", h.Name, err)
+			}
+			ch.payload = tmpl
+		}
+		d.hooks = append(d.hooks, ch)
+	}
+	return d, nil
+}
+
+// Fire는 eventType 이벤트를 구독하는 모든 훅을 백그라운드에서 실행합니다.
+// 훅 실패는 로그로만 남기며 호출자를 막지 않습니다.
+func (d *Dispatcher) Fire(eventType string, data map[string]string) {
+	if d == nil {
+		return
+	}
+	ev := Event{Type: eventType, Timestamp: d.now().UTC(), Data: data}
+	for _, h := range d.hooks {
+		if len(h.Events) > 0 && !slices.Contains(h.Events, eventType) {
+			continue
+		}
+		if h.Notify && !d.shouldNotify(h, ev) {
+			continue
+		}
+		if !d.begin() {
+			return
+		}
+		go func(h compiledHook) {
+			defer d.done()
+			if err := d.run(h, ev); err != nil {
+				log.Warn().Err(err).Str("hook", h.Name).Str("event", eventType).Msg("[event-hook] 훅 실행 실패")
+			}
+		}(h)
+	}
+}
+
+// Wait는 실행 중인 훅이 모두 끝나거나 ctx가 만료될 때까지 기다립니다.
+// 종료 시 마지막 이벤트(disconnected)의 훅이 끝날 시간을 주기 위해 사용합니다.
+func (d *Dispatcher) Wait(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	idle := d.idle
+	d.mu.Unlock()
+	if idle == nil {
+		return nil
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close는 새 훅 실행을 막고 실행 중인 훅이 모두 끝나거나 ctx가 만료될 때까지 기다립니다.
+// Close 이후의 Fire는 아무것도 하지 않습니다.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+	return d.Wait(ctx)
+}
+
+// begin은 훅 하나의 실행을 등록합니다. Close 이후면 false를 반환합니다.
+func (d *Dispatcher) begin() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return false
+	}
+	if d.running == 0 {
+		d.idle = make(chan struct{})
+	}
+	d.running++
+	return true
+}
+
+// done은 훅 하나의 실행 종료를 기록하고, 마지막 훅이면 기다리는 Wait를 깨웁니다.
+func (d *Dispatcher) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running--
+	if d.running == 0 {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// run은 훅 하나를 제한 시간 안에서 실행합니다.
+func (d *Dispatcher) run(h compiledHook, ev Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.Timeout)
+	defer cancel()
+	if h.Notify {
+		title, body := notification(ev)
+		return d.notify(ctx, title, body)
+	}
+	payload, err := renderPayload(h, ev)
+	if err != nil {
+		return err
+	}
+	if h.Command != "" {
+		return runCommand(ctx, h, ev, payload)
+	}
+	return d.postWebhook(ctx, h, payload)
+}
+
+// countSet은 true인 값의 개수를 반환합니다.
+func countSet(values ...bool) int {
+	n := 0
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+// renderPayload는 훅의 페이로드 템플릿을 이벤트로 렌더링합니다.
+func renderPayload(h compiledHook, ev Event) ([]byte, error) {
+	if h.payload == nil {
+		return json.Marshal(ev)
+	}
+	var buf bytes.Buffer
+	if err := h.payload.Execute(&buf, ev); err != nil {
+		return nil, fmt.Errorf("payload 템플릿 렌더링 실패: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// runCommand는 셸 명령을 실행합니다. 페이로드는 표준 입력과 AUTOPUS_EVENT_PAYLOAD로 전달합니다.
+// 이벤트 값은 명령 문자열에 직접 넣지 않고 환경 변수로만 전달하여 셸 주입을 막습니다.
+func runCommand(ctx context.Context, h compiledHook, ev Event, payload []byte) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command)
+	cmd.Env = append(os.Environ(), commandEnv(ev, payload)...)
+	cmd.Stdin = bytes.NewReader(payload)
+	out, err := procgroup.Output(cmd)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("명령 실패: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("명령 실패: %w", err)
+	}
+	if len(out) > 0 {
+		log.Debug().Str("hook", h.Name).Str("output", strings.TrimSpace(string(out))).Msg("[event-hook] 명령 출력")
+	}
+	return nil
+}
+
+// commandEnv는 셸 명령에 전달할 이벤트 환경 변수를 만듭니다.
+// Data의 키는 AUTOPUS_<대문자 키>로 전달합니다 (예: execution_id → AUTOPUS_EXECUTION_ID).
+func commandEnv(ev Event, payload []byte) []string {
+	env := []string{
+		"AUTOPUS_EVENT=" + ev.Type,
+		"AUTOPUS_EVENT_TIMESTAMP=" + ev.Timestamp.Format(time.RFC3339),
+		"AUTOPUS_EVENT_PAYLOAD=" + string(payload),
+	}
+	keys := make([]string, 0, len(ev.Data))
+	for k := range ev.Data {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		env = append(env, "AUTOPUS_"+strings.ToUpper(k)+"="+ev.Data[k])
+	}
+	return env
+}
+
+// postWebhook은 페이로드를 웹훅 URL로 전송합니다. 2xx가 아닌 응답은 실패로 처리합니다.
+func (d *Dispatcher) postWebhook(ctx context.Context, h compiledHook, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, h.Method, h.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("웹훅 요청 생성 실패: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "autopus-bridge-event-hook")
+	for k, v := range h.Headers {
+		req.Header.Set(k, os.ExpandEnv(v))
+	}
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("웹훅 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("웹훅 응답 상태 %d", resp.StatusCode)
+	}
+	return nil
+}
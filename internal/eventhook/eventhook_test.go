@@ -0,0 +1,196 @@
+package eventhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitHooks(t *testing.T, d *Dispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error: %v", err)
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		hook Hook
+	}{
+		{"command과 url 모두 없음", Hook{Name: "empty"}},
+		{"command과 url 모두 지정", Hook{Command: "true", URL: "http://localhost"}},
+		{"command과 notify 모두 지정", Hook{Command: "true", Notify: true}},
+		{"알 수 없는 이벤트", Hook{Command: "true", Events: []string{"task_exploded"}}},
+		{"잘못된 템플릿", Hook{Command: "true", Payload: "{{.Data"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New([]Hook{tt.hook}); err == nil {
+				t.Error("New() = nil error; want validation error")
+			}
+		})
+	}
+
+	d, err := New(nil)
+	if err != nil || d != nil {
+		t.Errorf("New(nil) = %v, %v; want nil, nil", d, err)
+	}
+	// nil Dispatcher는 아무 동작도 하지 않는다.
+	d.Fire(EventConnected, nil)
+	if err := d.Wait(context.Background()); err != nil {
+		t.Errorf("nil Wait() error: %v", err)
+	}
+}
+
+func TestFire_CommandReceivesEnvAndPayload(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.txt")
+	d, err := New([]Hook{{
+		Name:    "notify",
+		Events:  []string{EventTaskCompleted},
+		Command: `printf '%s|%s|' "$AUTOPUS_EVENT" "$AUTOPUS_EXECUTION_ID" > "$OUT"; cat >> "$OUT"`,
+		Payload: `done {{.Data.execution_id}} in {{.Data.duration_ms}}ms`,
+	}})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	t.Setenv("OUT", out)
+
+	d.Fire(EventTaskStarted, map[string]string{"execution_id": "exec-0"})
+	d.Fire(EventTaskCompleted, map[string]string{"execution_id": "exec-1", "duration_ms": "42"})
+	waitHooks(t, d)
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("훅 출력 읽기 실패: %v", err)
+	}
+	if want := "task_completed|exec-1|done exec-1 in 42ms"; string(data) != want {
+		t.Errorf("훅 출력 = %q, want %q", data, want)
+	}
+}
+
+func TestFire_WebhookTemplateAndHeaders(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		if len(bodies) == 0 {
+			auth = r.Header.Get("Authorization")
+		}
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_HOOK_TOKEN", "secret")
+	d, err := New([]Hook{
+		{
+			URL:     srv.URL,
+			Events:  []string{EventTaskFailed},
+			Headers: map[string]string{"Authorization": "Bearer ${TEST_HOOK_TOKEN}"},
+			Payload: `{"text": {{json .Data.error}}}`,
+		},
+		{URL: srv.URL, Events: []string{EventConnected}},
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	d.Fire(EventTaskFailed, map[string]string{"error": `quote " and newline` + "\n"})
+	waitHooks(t, d)
+	d.Fire(EventConnected, map[string]string{"server_url": "wss://example"})
+	waitHooks(t, d)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(bodies) != 2 {
+		t.Fatalf("웹훅 호출 %d회, want 2", len(bodies))
+	}
+	var text struct{ Text string }
+	if err := json.Unmarshal([]byte(bodies[0]), &text); err != nil || text.Text != "quote \" and newline\n" {
+		t.Errorf("템플릿 페이로드 = %s (err=%v)", bodies[0], err)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", auth, "Bearer secret")
+	}
+	var ev Event
+	if err := json.Unmarshal([]byte(bodies[1]), &ev); err != nil {
+		t.Fatalf("기본 페이로드 파싱 실패: %v", err)
+	}
+	if ev.Type != EventConnected || ev.Data["server_url"] != "wss://example" || ev.Timestamp.IsZero() {
+		t.Errorf("기본 페이로드 = %+v", ev)
+	}
+}
+
+func TestFire_TimeoutStopsSlowHook(t *testing.T) {
+	d, err := New([]Hook{{Command: "sleep 30", Timeout: 100 * time.Millisecond}})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	start := time.Now()
+	d.Fire(EventDisconnected, nil)
+	waitHooks(t, d)
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("느린 훅이 제한 시간 후에도 %s 동안 실행되었습니다", elapsed)
+	}
+}
+
+func TestCommandEnv(t *testing.T) {
+	ev := Event{Type: EventComputerSessionStarted, Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Data: map[string]string{"session_id": "s-1"}}
+	env := strings.Join(commandEnv(ev, []byte("{}")), "\n")
+	for _, want := range []string{"AUTOPUS_EVENT=computer_session_started", "AUTOPUS_EVENT_TIMESTAMP=2026-01-02T03:04:05Z", "AUTOPUS_EVENT_PAYLOAD={}", "AUTOPUS_SESSION_ID=s-1"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("환경 변수에 %q가 없습니다:\n%s", want, env)
+		}
+	}
+}
+
+func TestWait_ConcurrentFireAndClose(t *testing.T) {
+	d, err := New([]Hook{{Name: "desktop", Events: NotifyEvents, Notify: true}})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	bodies := recordNotifications(d)
+
+	// Wait 중에 Fire가 훅을 시작해도 경쟁 상태가 없어야 한다 (go test -race).
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			d.Fire(EventTaskFailed, map[string]string{"execution_id": fmt.Sprintf("exec-%d", i)})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = d.Wait(context.Background())
+		}()
+	}
+	wg.Wait()
+	waitHooks(t, d)
+	if got := len(bodies()); got != 20 {
+		t.Fatalf("알림 %d회, want 20", got)
+	}
+
+	// Close 이후의 Fire는 훅을 시작하지 않는다.
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	d.Fire(EventTaskFailed, map[string]string{"execution_id": "exec-late"})
+	waitHooks(t, d)
+	if got := len(bodies()); got != 20 {
+		t.Errorf("Close 이후 알림 %d회, want 20", got)
+	}
+}
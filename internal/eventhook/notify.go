@@ -0,0 +1,119 @@
+package eventhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+)
+
+// DefaultNotifyDedupWindow는 데스크톱 알림의 기본 중복 제거 구간입니다.
+const DefaultNotifyDedupWindow = time.Minute
+
+// NotifyEvents는 데스크톱 알림의 기본 이벤트입니다. 사람이 확인해야 하는 이벤트만 포함합니다.
+var NotifyEvents = []string{
+	EventApprovalRequired,
+	EventActionApprovalPending,
+	EventTaskFailed,
+	EventReconnectExhausted,
+}
+
+// notificationTitle은 데스크톱 알림 제목입니다.
+const notificationTitle = "Autopus Bridge"
+
+// dedupKeyFields는 중복 제거 키로 사용할 이벤트 정보입니다 (앞에서부터 처음 있는 값).
+// 한 실행이 도구 승인을 연달아 요청해도 알림은 한 번만 띄웁니다.
+var dedupKeyFields = []string{"execution_id", "target"}
+
+// shouldNotify는 같은 대상의 같은 이벤트를 중복 제거 구간 안에 이미 알렸는지 확인하고,
+// 알려야 하면 시각을 기록합니다. 대상 정보가 없는 이벤트는 이벤트 종류만으로 중복을 제거합니다.
+func (d *Dispatcher) shouldNotify(h compiledHook, ev Event) bool {
+	key := h.Name + "|" + ev.Type
+	for _, field := range dedupKeyFields {
+		if v := ev.Data[field]; v != "" {
+			key += "|" + v
+			break
+		}
+	}
+
+	d.notifiedMu.Lock()
+	defer d.notifiedMu.Unlock()
+	for k, until := range d.notified {
+		if !ev.Timestamp.Before(until) {
+			delete(d.notified, k)
+		}
+	}
+	if _, ok := d.notified[key]; ok {
+		return false
+	}
+	d.notified[key] = ev.Timestamp.Add(h.DedupWindow)
+	return true
+}
+
+// notification은 이벤트의 데스크톱 알림 제목과 본문을 현재 언어로 만듭니다.
+func notification(ev Event) (title, body string) {
+	switch ev.Type {
+	case EventApprovalRequired:
+		body = i18n.T("notify.approval_required", ev.Data["tool"])
+		if id := ev.Data["execution_id"]; id != "" {
+			body += " (" + id + ")"
+		}
+	case EventActionApprovalPending:
+		body = i18n.T("notify.action_approval_pending", ev.Data["action"])
+		if target := ev.Data["target"]; target != "" {
+			body += " - " + target
+		}
+	case EventTaskFailed:
+		body = i18n.T("notify.task_failed", ev.Data["execution_id"])
+		if msg := ev.Data["error"]; msg != "" {
+			body += " - " + msg
+		}
+	case EventReconnectExhausted:
+		body = i18n.T("notify.reconnect_exhausted")
+	default:
+		body = ev.Type
+	}
+	return notificationTitle, body
+}
+
+// errNotifyUnsupported는 데스크톱 알림을 지원하지 않는 OS에서 반환됩니다.
+var errNotifyUnsupported = errors.New("데스크톱 알림은 macOS와 Linux에서만 지원합니다")
+
+// desktopNotifyCommand는 OS별 데스크톱 알림 명령을 만듭니다.
+// macOS는 osascript, Linux는 notify-send(libnotify)를 사용합니다.
+func desktopNotifyCommand(ctx context.Context, goos, title, body string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		// 제목과 본문은 AppleScript 문자열 리터럴이 아닌 인자로 넘겨 스크립트 주입을 막습니다.
+		script := `on run argv
+display notification (item 2 of argv) with title (item 1 of argv)
+end run`
+		return exec.CommandContext(ctx, "osascript", "-e", script, title, body), nil
+	case "linux":
+		return exec.CommandContext(ctx, "notify-send", "--app-name="+notificationTitle, "--", title, body), nil
+	default:
+		return nil, fmt.Errorf("%w (%s)", errNotifyUnsupported, goos)
+	}
+}
+
+// sendDesktopNotification은 현재 OS의 데스크톱 알림을 띄웁니다.
+func sendDesktopNotification(ctx context.Context, title, body string) error {
+	cmd, err := desktopNotifyCommand(ctx, runtime.GOOS, title, body)
+	if err != nil {
+		return err
+	}
+	if _, err := procgroup.Output(cmd); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("데스크톱 알림 실패: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("데스크톱 알림 실패: %w", err)
+	}
+	return nil
+}
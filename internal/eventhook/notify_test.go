@@ -0,0 +1,126 @@
+package eventhook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+)
+
+// recordNotifications는 Dispatcher의 데스크톱 알림을 가로채 본문을 기록합니다.
+func recordNotifications(d *Dispatcher) func() []string {
+	var mu sync.Mutex
+	var bodies []string
+	d.notify = func(_ context.Context, title, body string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, title+": "+body)
+		return nil
+	}
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(bodies)
+	}
+}
+
+func TestFire_NotifyDeduplicates(t *testing.T) {
+	d, err := New([]Hook{{Name: "desktop", Events: NotifyEvents, Notify: true, DedupWindow: time.Minute}})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	bodies := recordNotifications(d)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	// 한 실행의 연속된 승인 요청은 한 번만 알린다.
+	d.Fire(EventApprovalRequired, map[string]string{"execution_id": "exec-1", "approval_id": "a-1", "tool": "Bash"})
+	d.Fire(EventApprovalRequired, map[string]string{"execution_id": "exec-1", "approval_id": "a-2", "tool": "Edit"})
+	// 다른 실행과 다른 이벤트는 따로 알린다.
+	d.Fire(EventApprovalRequired, map[string]string{"execution_id": "exec-2", "approval_id": "a-3", "tool": "Bash"})
+	d.Fire(EventTaskFailed, map[string]string{"execution_id": "exec-1", "error": "boom"})
+	// 구독하지 않은 이벤트는 알리지 않는다.
+	d.Fire(EventTaskCompleted, map[string]string{"execution_id": "exec-1"})
+	waitHooks(t, d)
+	if got := bodies(); len(got) != 3 {
+		t.Fatalf("알림 %d회 (%q), want 3", len(got), got)
+	}
+
+	// 중복 제거 구간이 지나면 다시 알린다.
+	now = now.Add(time.Minute)
+	d.Fire(EventApprovalRequired, map[string]string{"execution_id": "exec-1", "approval_id": "a-4", "tool": "Bash"})
+	waitHooks(t, d)
+	got := bodies()
+	if len(got) != 4 {
+		t.Fatalf("구간 경과 후 알림 %d회, want 4", len(got))
+	}
+	if want := "Autopus Bridge: 승인 대기 중: Bash (exec-1)"; !slices.Contains(got, want) {
+		t.Errorf("알림 %q에 %q가 없습니다", got, want)
+	}
+}
+
+func TestNotification(t *testing.T) {
+	tests := []struct {
+		ev   Event
+		want string
+	}{
+		{Event{Type: EventActionApprovalPending, Data: map[string]string{"action": "computer_use", "target": "https://example.com"}}, "터미널에서 승인이 필요합니다: computer_use - https://example.com"},
+		{Event{Type: EventTaskFailed, Data: map[string]string{"execution_id": "exec-1", "error": "timeout"}}, "작업 실패: exec-1 - timeout"},
+		{Event{Type: EventReconnectExhausted}, "서버 재연결을 포기했습니다. Bridge를 다시 시작하세요"},
+	}
+	for _, tt := range tests {
+		title, body := notification(tt.ev)
+		if title != notificationTitle || body != tt.want {
+			t.Errorf("notification(%s) = %q, %q; want %q", tt.ev.Type, title, body, tt.want)
+		}
+	}
+}
+
+func TestNotification_English(t *testing.T) {
+	defer i18n.SetLang(i18n.Current())
+	i18n.SetLang(i18n.English)
+
+	tests := []struct {
+		ev   Event
+		want string
+	}{
+		{Event{Type: EventApprovalRequired, Data: map[string]string{"execution_id": "exec-1", "tool": "Bash"}}, "Approval pending: Bash (exec-1)"},
+		{Event{Type: EventActionApprovalPending, Data: map[string]string{"action": "computer_use", "target": "https://example.com"}}, "Approval needed in the terminal: computer_use - https://example.com"},
+		{Event{Type: EventTaskFailed, Data: map[string]string{"execution_id": "exec-1", "error": "timeout"}}, "Task failed: exec-1 - timeout"},
+		{Event{Type: EventReconnectExhausted}, "Gave up reconnecting to the server. Restart the bridge"},
+	}
+	for _, tt := range tests {
+		if _, body := notification(tt.ev); body != tt.want {
+			t.Errorf("notification(%s) = %q; want %q", tt.ev.Type, body, tt.want)
+		}
+	}
+}
+
+func TestDesktopNotifyCommand(t *testing.T) {
+	title, body := "Autopus Bridge", `"; do shell script "rm -rf ~"`
+	cmd, err := desktopNotifyCommand(context.Background(), "darwin", title, body)
+	if err != nil {
+		t.Fatalf("darwin error: %v", err)
+	}
+	// 본문은 스크립트가 아닌 인자로 전달되어야 한다.
+	if args := cmd.Args; args[0] != "osascript" || args[len(args)-1] != body || strings.Contains(args[2], "rm -rf") {
+		t.Errorf("darwin args = %q", args)
+	}
+
+	cmd, err = desktopNotifyCommand(context.Background(), "linux", title, body)
+	if err != nil {
+		t.Fatalf("linux error: %v", err)
+	}
+	if args := cmd.Args; args[0] != "notify-send" || !slices.Equal(args[len(args)-3:], []string{"--", title, body}) {
+		t.Errorf("linux args = %q", args)
+	}
+
+	if _, err := desktopNotifyCommand(context.Background(), "windows", title, body); !errors.Is(err, errNotifyUnsupported) {
+		t.Errorf("windows error = %v, want errNotifyUnsupported", err)
+	}
+}
@@ -12,18 +12,78 @@ import (
 	"time"
 
 	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 )
 
 // BuildExecutor handles build command execution.
-type BuildExecutor struct{}
+type BuildExecutor struct {
+	// environment attaches an environment snapshot to results when set.
+	environment *EnvironmentCollector
+	// container runs builds in Docker containers when set (see ContainerIsolator).
+	container *ContainerIsolator
+}
+
+// BuildExecutorOption configures a BuildExecutor.
+type BuildExecutorOption func(*BuildExecutor)
+
+// WithBuildEnvironmentCollector attaches an environment snapshot to every build result.
+func WithBuildEnvironmentCollector(collector *EnvironmentCollector) BuildExecutorOption {
+	return func(e *BuildExecutor) {
+		e.environment = collector
+	}
+}
+
+// WithBuildContainerIsolation enables running builds inside Docker containers.
+// Requests choose with the isolation field; the isolator's Default applies when it is empty.
+func WithBuildContainerIsolation(isolator *ContainerIsolator) BuildExecutorOption {
+	return func(e *BuildExecutor) {
+		e.container = isolator
+	}
+}
 
 // NewBuildExecutor creates a new BuildExecutor.
-func NewBuildExecutor() *BuildExecutor {
-	return &BuildExecutor{}
+func NewBuildExecutor(opts ...BuildExecutorOption) *BuildExecutor {
+	e := &BuildExecutor{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // Execute runs a build command and returns the result.
 func (e *BuildExecutor) Execute(ctx context.Context, req ws.BuildRequestPayload) *ws.BuildResultPayload {
+	return e.ExecuteWithProgress(ctx, req, nil)
+}
+
+// ExecuteWithProgress runs the build like Execute and reports its steps to onStep:
+// prepare, build and environment. Matrix builds report one build step with a
+// nested event per target.
+func (e *BuildExecutor) ExecuteWithProgress(ctx context.Context, req ws.BuildRequestPayload, onStep StepFunc) *ws.BuildResultPayload {
+	names := []string{stepPrepare, stageBuild}
+	if len(req.Matrix) > 0 {
+		names = names[1:]
+	}
+	if e.environment != nil {
+		names = append(names, stepEnvironment)
+	}
+	steps := newStepReporter(onStep, names...)
+
+	result := e.execute(ctx, req, steps)
+	if e.environment != nil {
+		steps.begin(stepEnvironment)
+		result.Environment = e.environment.Snapshot(ctx, req.WorkDir)
+		steps.end(true, "")
+	}
+	steps.skipRest("")
+	return result
+}
+
+// execute runs the build command without attaching the environment snapshot.
+func (e *BuildExecutor) execute(ctx context.Context, req ws.BuildRequestPayload, steps *stepReporter) *ws.BuildResultPayload {
+	if len(req.Matrix) > 0 {
+		return e.executeMatrix(ctx, req, steps)
+	}
+
 	start := time.Now()
 
 	result := &ws.BuildResultPayload{
@@ -31,32 +91,65 @@ func (e *BuildExecutor) Execute(ctx context.Context, req ws.BuildRequestPayload)
 	}
 
 	// Validate work directory.
+	steps.begin(stepPrepare)
 	workDir, err := validateWorkDir(req.WorkDir)
 	if err != nil {
 		result.Success = false
 		result.Output = fmt.Sprintf("invalid work directory: %v", err)
 		result.ExitCode = 1
 		result.DurationMs = time.Since(start).Milliseconds()
+		steps.end(false, result.Output)
 		return result
 	}
 
-	// Set up timeout from request or use default (10 minutes).
-	timeout := time.Duration(req.Timeout) * time.Second
+	run, err := prepareContainerRun(e.container, req.ExecutionID, workDir, req.Isolation, req.Image)
+	if err != nil {
+		result.Success = false
+		result.Output = fmt.Sprintf("container isolation failed: %v", err)
+		result.ExitCode = 1
+		result.DurationMs = time.Since(start).Milliseconds()
+		steps.end(false, result.Output)
+		return result
+	}
+	if run != nil {
+		result.ContainerImage = run.image
+	}
+
+	steps.begin(stageBuild)
+	outcome := runBuildCommand(ctx, run, workDir, req.Command, req.Env, buildTimeout(req.Timeout))
+	steps.end(outcome.success, exitDetail(outcome.exitCode))
+
+	result.Success = outcome.success
+	result.Output = outcome.output
+	result.ExitCode = outcome.exitCode
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// buildOutcome is the result of a single build command run.
+type buildOutcome struct {
+	success  bool
+	output   string
+	exitCode int
+}
+
+// buildTimeout returns the request timeout or the default (10 minutes).
+func buildTimeout(seconds int) time.Duration {
+	timeout := time.Duration(seconds) * time.Second
 	if timeout <= 0 {
 		timeout = DefaultTimeout
 	}
+	return timeout
+}
+
+// runBuildCommand runs command in workDir with env merged into the process
+// environment, or inside the container when run is set.
+func runBuildCommand(ctx context.Context, run *containerRun, workDir, command string, env []string, timeout time.Duration) buildOutcome {
 	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Build the command using shell execution for pipeline support.
-	cmd := exec.CommandContext(execCtx, "sh", "-c", req.Command)
-	cmd.Dir = workDir
-
-	// Merge environment variables.
-	cmd.Env = os.Environ()
-	if len(req.Env) > 0 {
-		cmd.Env = append(cmd.Env, req.Env...)
-	}
+	cmd := shellCommand(execCtx, run, workDir, command, env)
 
 	// Capture stdout and stderr combined.
 	var output bytes.Buffer
@@ -64,26 +157,25 @@ func (e *BuildExecutor) Execute(ctx context.Context, req ws.BuildRequestPayload)
 	cmd.Stderr = &output
 
 	// Run the command.
-	err = cmd.Run()
-
-	result.Output = output.String()
-	result.DurationMs = time.Since(start).Milliseconds()
+	err := procgroup.Run(cmd)
+	if run != nil && execCtx.Err() != nil {
+		run.cleanup()
+	}
 
+	outcome := buildOutcome{output: output.String()}
 	if err != nil {
-		result.Success = false
 		if exitErr, ok := err.(*exec.ExitError); ok {
-			result.ExitCode = exitErr.ExitCode()
+			outcome.exitCode = exitErr.ExitCode()
 		} else {
-			result.ExitCode = 1
+			outcome.exitCode = 1
 			// Append the error message if it is not just an exit code issue.
-			result.Output = result.Output + "\n" + err.Error()
+			outcome.output = outcome.output + "\n" + err.Error()
 		}
-		return result
+		return outcome
 	}
 
-	result.Success = true
-	result.ExitCode = 0
-	return result
+	outcome.success = true
+	return outcome
 }
 
 // validateWorkDir validates and resolves the work directory path.
@@ -0,0 +1,180 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// executeMatrix runs every matrix target in parallel, bounded by the CPU count,
+// and aggregates the per-target results into a single build result.
+// Each target is reported as a nested event of the build step.
+func (e *BuildExecutor) executeMatrix(ctx context.Context, req ws.BuildRequestPayload, steps *stepReporter) *ws.BuildResultPayload {
+	start := time.Now()
+	parallelism := matrixParallelism(req.MaxParallel, len(req.Matrix))
+	steps.begin(stageBuild)
+
+	targets := make([]ws.BuildTargetResult, len(req.Matrix))
+	images := make([]string, len(req.Matrix))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, target := range req.Matrix {
+		wg.Add(1)
+		go func(i int, target ws.BuildTarget) {
+			defer wg.Done()
+			name := matrixTargetName(target, i)
+
+			// Wait for a free slot unless the build is cancelled first.
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				now := time.Now()
+				targets[i] = ws.BuildTargetResult{
+					Name:       name,
+					Output:     fmt.Sprintf("build cancelled before start: %v", ctx.Err()),
+					ExitCode:   1,
+					StartedAt:  now,
+					FinishedAt: now,
+				}
+				steps.tool(ws.ProgressToolEvent{ID: name, Name: name, Status: ws.ProgressStepSkipped, Detail: "cancelled before start"})
+				return
+			}
+			defer func() { <-sem }()
+
+			steps.tool(ws.ProgressToolEvent{ID: name, Name: name, Status: ws.ProgressStepRunning})
+			targets[i], images[i] = runMatrixTarget(ctx, e.container, req, target, name)
+			steps.tool(matrixTargetEvent(targets[i]))
+		}(i, target)
+	}
+	wg.Wait()
+
+	result := &ws.BuildResultPayload{
+		ExecutionID: req.ExecutionID,
+		Success:     true,
+		Targets:     targets,
+		Parallelism: parallelism,
+	}
+	// Report the container image when every containerized target used the same one.
+	for _, image := range images {
+		if image == "" {
+			continue
+		}
+		if result.ContainerImage != "" && result.ContainerImage != image {
+			result.ContainerImage = ""
+			break
+		}
+		result.ContainerImage = image
+	}
+
+	// The combined output lists targets in matrix order; the exit code is the
+	// first failing target's exit code.
+	var output strings.Builder
+	for _, target := range targets {
+		fmt.Fprintf(&output, "=== %s (exit %d, %dms) ===\n%s\n", target.Name, target.ExitCode, target.DurationMs, target.Output)
+		if !target.Success && result.Success {
+			result.Success = false
+			result.ExitCode = target.ExitCode
+		}
+	}
+	result.Output = output.String()
+	result.DurationMs = time.Since(start).Milliseconds()
+	steps.end(result.Success, exitDetail(result.ExitCode))
+	return result
+}
+
+// matrixTargetEvent reports a finished matrix target as a nested progress event.
+func matrixTargetEvent(target ws.BuildTargetResult) ws.ProgressToolEvent {
+	event := ws.ProgressToolEvent{ID: target.Name, Name: target.Name, Status: ws.ProgressStepCompleted, DurationMs: target.DurationMs}
+	if !target.Success {
+		event.Status = ws.ProgressStepFailed
+		event.Detail = exitDetail(target.ExitCode)
+	}
+	return event
+}
+
+// runMatrixTarget runs one matrix target with the request defaults applied.
+// It also returns the container image the target ran in, if any.
+func runMatrixTarget(ctx context.Context, container *ContainerIsolator, req ws.BuildRequestPayload, target ws.BuildTarget, name string) (ws.BuildTargetResult, string) {
+	result := ws.BuildTargetResult{Name: name, StartedAt: time.Now()}
+	finish := func() ws.BuildTargetResult {
+		result.FinishedAt = time.Now()
+		result.DurationMs = result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+		return result
+	}
+
+	dir := req.WorkDir
+	if target.WorkDir != "" {
+		dir = target.WorkDir
+		if !filepath.IsAbs(dir) && req.WorkDir != "" {
+			dir = filepath.Join(req.WorkDir, dir)
+		}
+	}
+	workDir, err := validateWorkDir(dir)
+	if err != nil {
+		result.Output = fmt.Sprintf("invalid work directory: %v", err)
+		result.ExitCode = 1
+		return finish(), ""
+	}
+
+	command := req.Command
+	if target.Command != "" {
+		command = target.Command
+	}
+	if command == "" {
+		result.Output = "build command is empty"
+		result.ExitCode = 1
+		return finish(), ""
+	}
+
+	// Target env is appended after the request env so it takes precedence.
+	env := make([]string, 0, len(req.Env)+len(target.Env))
+	env = append(env, req.Env...)
+	env = append(env, target.Env...)
+
+	run, err := prepareContainerRun(container, req.ExecutionID+"-"+name, workDir, req.Isolation, req.Image)
+	if err != nil {
+		result.Output = fmt.Sprintf("container isolation failed: %v", err)
+		result.ExitCode = 1
+		return finish(), ""
+	}
+	var image string
+	if run != nil {
+		image = run.image
+	}
+
+	outcome := runBuildCommand(ctx, run, workDir, command, env, buildTimeout(req.Timeout))
+	result.Success = outcome.success
+	result.Output = outcome.output
+	result.ExitCode = outcome.exitCode
+	return finish(), image
+}
+
+// matrixParallelism returns how many targets may run at once: the requested
+// limit capped at the CPU count and the number of targets.
+func matrixParallelism(requested, targets int) int {
+	limit := runtime.NumCPU()
+	if requested > 0 && requested < limit {
+		limit = requested
+	}
+	if targets < limit {
+		limit = targets
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// matrixTargetName returns the target name, falling back to its matrix position.
+func matrixTargetName(target ws.BuildTarget, index int) string {
+	if target.Name != "" {
+		return target.Name
+	}
+	return fmt.Sprintf("target-%d", index+1)
+}
@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildExecutor_Matrix(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "web"), 0755))
+
+	result := NewBuildExecutor().Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     dir,
+		Command:     `echo "$GOOS/$GOARCH"`,
+		Env:         []string{"GOOS=linux"},
+		Matrix: []ws.BuildTarget{
+			{Name: "linux/amd64", Env: []string{"GOARCH=amd64"}},
+			{Name: "darwin/arm64", Env: []string{"GOOS=darwin", "GOARCH=arm64"}},
+			{Name: "web", WorkDir: "web", Command: "basename $(pwd)"},
+		},
+	})
+
+	require.True(t, result.Success, result.Output)
+	assert.Equal(t, 0, result.ExitCode)
+	require.Len(t, result.Targets, 3)
+	assert.Equal(t, "linux/amd64", result.Targets[0].Name)
+	assert.Equal(t, "linux/amd64\n", result.Targets[0].Output)
+	assert.Equal(t, "darwin/arm64\n", result.Targets[1].Output, "target env overrides request env")
+	assert.Equal(t, "web\n", result.Targets[2].Output, "relative work dir resolves against the request work dir")
+	for _, target := range result.Targets {
+		assert.True(t, target.Success)
+		assert.False(t, target.StartedAt.IsZero())
+		assert.False(t, target.FinishedAt.Before(target.StartedAt))
+	}
+	assert.Contains(t, result.Output, "=== darwin/arm64 (exit 0")
+	assert.Equal(t, matrixParallelism(0, 3), result.Parallelism)
+}
+
+func TestBuildExecutor_MatrixFailure(t *testing.T) {
+	result := NewBuildExecutor().Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     t.TempDir(),
+		Command:     "true",
+		Matrix: []ws.BuildTarget{
+			{Name: "ok"},
+			{Name: "broken", Command: "exit 3"},
+			{Name: "missing", WorkDir: "does-not-exist"},
+		},
+	})
+
+	assert.False(t, result.Success)
+	assert.Equal(t, 3, result.ExitCode, "exit code of the first failing target")
+	require.Len(t, result.Targets, 3)
+	assert.True(t, result.Targets[0].Success)
+	assert.False(t, result.Targets[1].Success)
+	assert.Equal(t, 3, result.Targets[1].ExitCode)
+	assert.False(t, result.Targets[2].Success)
+	assert.Contains(t, result.Targets[2].Output, "invalid work directory")
+}
+
+func TestBuildExecutor_MatrixRunsInParallel(t *testing.T) {
+	if runtime.NumCPU() < 2 {
+		t.Skip("parallel execution needs at least 2 CPUs")
+	}
+
+	start := time.Now()
+	result := NewBuildExecutor().Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     t.TempDir(),
+		Command:     "sleep 0.3",
+		MaxParallel: 2,
+		Matrix:      []ws.BuildTarget{{Name: "a"}, {Name: "b"}},
+	})
+
+	require.True(t, result.Success, result.Output)
+	assert.Equal(t, 2, result.Parallelism)
+	assert.Less(t, time.Since(start), 550*time.Millisecond, "targets should run concurrently")
+}
+
+func TestMatrixParallelism(t *testing.T) {
+	cpus := runtime.NumCPU()
+	assert.Equal(t, 1, matrixParallelism(1, 4))
+	assert.Equal(t, 1, matrixParallelism(0, 1))
+	assert.Equal(t, min(cpus, 100), matrixParallelism(0, 100))
+	assert.Equal(t, min(cpus, 100), matrixParallelism(cpus+10, 100), "requested limit is capped at the CPU count")
+}
@@ -0,0 +1,378 @@
+// Package executor는 Local Agent Bridge의 작업 실행을 담당합니다.
+// 장시간 작업의 실행 상태를 체크포인트로 저장하여 Bridge 재시작 후 재개할 수 있게 합니다.
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+const (
+	// DefaultCheckpointInterval은 스트리밍 출력에 대한 체크포인트 저장 최소 간격입니다.
+	// 세션 ID 확인이나 턴 완료 시에는 간격과 무관하게 즉시 저장합니다.
+	DefaultCheckpointInterval = 10 * time.Second
+	// DefaultCheckpointMaxAge는 재개 대상으로 제안할 체크포인트의 최대 수명입니다.
+	DefaultCheckpointMaxAge = 24 * time.Hour
+	// resumeContinuationPrompt는 프로바이더 세션을 이어서 실행할 때 보내는 프롬프트입니다.
+	resumeContinuationPrompt = "The previous run of this task was interrupted before it finished. " +
+		"Continue from where you left off without repeating work that is already done, " +
+		"then give your final answer."
+)
+
+// TaskCheckpoint는 재개를 위해 저장하는 작업 실행 상태입니다.
+type TaskCheckpoint struct {
+	// ExecutionID는 작업 실행 ID입니다.
+	ExecutionID string `json:"execution_id"`
+	// Task는 원본 작업 요청입니다. 재개 시 이 요청을 다시 실행합니다.
+	Task ws.TaskRequestPayload `json:"task"`
+	// Provider는 작업을 실행한 프로바이더 이름입니다.
+	Provider string `json:"provider,omitempty"`
+	// Model은 작업을 실행한 모델입니다.
+	Model string `json:"model,omitempty"`
+	// SessionID는 프로바이더 세션/Thread ID입니다. 비어 있으면 처음부터 다시 실행합니다.
+	SessionID string `json:"session_id,omitempty"`
+	// StepIndex는 완료된 어시스턴트 턴 수입니다.
+	StepIndex int `json:"step_index"`
+	// AccumulatedOutput은 지금까지 누적된 스트리밍 출력입니다.
+	AccumulatedOutput string `json:"accumulated_output,omitempty"`
+	// StartedAt은 작업 최초 시작 시각입니다.
+	StartedAt time.Time `json:"started_at"`
+	// UpdatedAt은 마지막 저장 시각입니다.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ResumePayload는 체크포인트를 task_resume offer 메시지로 변환합니다.
+func (c *TaskCheckpoint) ResumePayload() ws.TaskResumePayload {
+	return ws.TaskResumePayload{
+		ExecutionID:       c.ExecutionID,
+		Action:            ws.TaskResumeActionOffer,
+		Provider:          c.Provider,
+		Model:             c.Model,
+		SessionID:         c.SessionID,
+		StepIndex:         c.StepIndex,
+		AccumulatedOutput: c.AccumulatedOutput,
+		CheckpointedAt:    c.UpdatedAt,
+	}
+}
+
+// CheckpointConfig는 작업 체크포인트 저장 설정입니다.
+type CheckpointConfig struct {
+	// Dir은 체크포인트 파일을 저장할 디렉토리입니다.
+	Dir string
+	// Interval은 스트리밍 출력 저장 최소 간격입니다. 0이면 DefaultCheckpointInterval을 사용합니다.
+	Interval time.Duration
+	// MaxAge는 재개를 제안할 체크포인트의 최대 수명입니다. 0이면 DefaultCheckpointMaxAge를 사용합니다.
+	MaxAge time.Duration
+}
+
+// CheckpointStore는 작업 체크포인트를 디렉토리에 실행 ID별 JSON 파일로 저장합니다.
+// 파일은 0600 권한으로 임시 파일에 쓴 뒤 rename하여 저장 도중 크래시가 나도 손상되지 않습니다.
+type CheckpointStore struct {
+	cfg CheckpointConfig
+	mu  sync.Mutex
+}
+
+// NewCheckpointStore는 새 CheckpointStore를 생성합니다.
+func NewCheckpointStore(cfg CheckpointConfig) *CheckpointStore {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultCheckpointInterval
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = DefaultCheckpointMaxAge
+	}
+	return &CheckpointStore{cfg: cfg}
+}
+
+// Save는 체크포인트를 저장합니다.
+func (s *CheckpointStore) Save(cp *TaskCheckpoint) error {
+	if cp == nil || cp.ExecutionID == "" {
+		return errors.New("체크포인트에 execution_id가 없습니다")
+	}
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("체크포인트 인코딩 실패: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.cfg.Dir, 0700); err != nil {
+		return fmt.Errorf("체크포인트 디렉토리 생성 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.cfg.Dir, ".checkpoint-*")
+	if err != nil {
+		return fmt.Errorf("체크포인트 임시 파일 생성 실패: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("체크포인트 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("체크포인트 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmpName, s.path(cp.ExecutionID)); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("체크포인트 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// Load는 실행 ID의 체크포인트를 반환합니다. 없으면 nil, nil입니다.
+func (s *CheckpointStore) Load(executionID string) (*TaskCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(s.path(executionID))
+}
+
+// Delete는 실행 ID의 체크포인트를 삭제합니다. 없으면 아무것도 하지 않습니다.
+func (s *CheckpointStore) Delete(executionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(executionID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("체크포인트 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// List는 저장된 체크포인트를 오래된 순으로 반환합니다.
+// MaxAge보다 오래된 체크포인트와 손상된 파일은 삭제합니다.
+func (s *CheckpointStore) List() ([]*TaskCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("체크포인트 디렉토리 조회 실패: %w", err)
+	}
+
+	var checkpoints []*TaskCheckpoint
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		path := filepath.Join(s.cfg.Dir, name)
+		cp, err := s.load(path)
+		if err != nil || cp == nil || time.Since(cp.UpdatedAt) > s.cfg.MaxAge {
+			_ = os.Remove(path)
+			continue
+		}
+		checkpoints = append(checkpoints, cp)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].UpdatedAt.Before(checkpoints[j].UpdatedAt)
+	})
+	return checkpoints, nil
+}
+
+// load는 path의 체크포인트를 읽습니다. 호출자가 s.mu를 보유해야 합니다.
+func (s *CheckpointStore) load(path string) (*TaskCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("체크포인트 읽기 실패: %w", err)
+	}
+	var cp TaskCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("체크포인트 파싱 실패: %w", err)
+	}
+	return &cp, nil
+}
+
+// path는 실행 ID의 체크포인트 파일 경로를 반환합니다.
+func (s *CheckpointStore) path(executionID string) string {
+	replacer := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_", "..", "_")
+	return filepath.Join(s.cfg.Dir, replacer.Replace(executionID)+".json")
+}
+
+// taskCheckpointer는 실행 중인 작업 하나의 체크포인트를 갱신합니다.
+// 프로바이더 콜백은 여러 고루틴에서 호출될 수 있으므로 mu로 보호합니다.
+type taskCheckpointer struct {
+	store   *CheckpointStore
+	onError func(error)
+
+	mu        sync.Mutex
+	cp        TaskCheckpoint
+	baseSteps int    // 재개 이전에 완료된 단계 수
+	baseText  string // 재개 이전에 누적된 출력
+	lastSave  time.Time
+}
+
+// save는 현재 상태를 저장합니다. 호출자가 t.mu를 보유해야 합니다.
+func (t *taskCheckpointer) save(now time.Time) {
+	t.cp.UpdatedAt = now
+	t.lastSave = now
+	if err := t.store.Save(&t.cp); err != nil && t.onError != nil {
+		t.onError(err)
+	}
+}
+
+// onSession은 프로바이더 세션 ID 확인/턴 완료 시 즉시 저장합니다.
+func (t *taskCheckpointer) onSession(sessionID string, turn int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if sessionID != "" {
+		t.cp.SessionID = sessionID
+	}
+	t.cp.StepIndex = t.baseSteps + turn
+	t.save(time.Now())
+}
+
+// onOutput은 누적 출력을 갱신하고 마지막 저장 후 interval이 지났으면 저장합니다.
+func (t *taskCheckpointer) onOutput(accumulated string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cp.AccumulatedOutput = t.baseText + accumulated
+	if now := time.Now(); now.Sub(t.lastSave) >= t.store.cfg.Interval {
+		t.save(now)
+	}
+}
+
+// withBase는 재개 이전 출력 앞부분을 붙인 누적 출력을 반환합니다.
+// baseText는 시작 후 바뀌지 않으므로 잠금 없이 읽습니다.
+func (t *taskCheckpointer) withBase(text string) string {
+	return t.baseText + text
+}
+
+// beginCheckpoint는 작업의 체크포인트 기록을 시작하고 최초 상태를 저장합니다.
+// 재개 요청이고 같은 프로바이더의 세션이 저장되어 있으면 req를 세션 이어서 실행으로 바꿉니다.
+// 체크포인트 저장소가 없으면 nil을 반환합니다.
+func (e *TaskExecutor) beginCheckpoint(task ws.TaskRequestPayload, providerName string, req *provider.ExecuteRequest) *taskCheckpointer {
+	if e.checkpoints == nil || task.ExecutionID == "" {
+		return nil
+	}
+
+	now := time.Now()
+	t := &taskCheckpointer{
+		store: e.checkpoints,
+		onError: func(err error) {
+			e.logger.Warn().Str("execution_id", task.ExecutionID).Err(err).Msg("작업 체크포인트 저장 실패")
+		},
+		cp: TaskCheckpoint{
+			ExecutionID: task.ExecutionID,
+			Task:        task,
+			Provider:    providerName,
+			Model:       req.Model,
+			StartedAt:   now,
+		},
+	}
+	t.cp.Task.ResumeFromCheckpoint = false
+	// 단기 자격 증명은 디스크에 남기지 않는다. 재개 시 서버가 새 자격 증명을 첨부한다.
+	t.cp.Task.Credentials = nil
+
+	if task.ResumeFromCheckpoint {
+		prev, err := e.checkpoints.Load(task.ExecutionID)
+		if err != nil {
+			e.logger.Warn().Str("execution_id", task.ExecutionID).Err(err).Msg("작업 체크포인트 로드 실패, 처음부터 실행")
+		}
+		if prev != nil {
+			t.cp.StartedAt = prev.StartedAt
+			// 세션을 이어서 실행할 수 있을 때만 이전 출력과 단계 수를 이어받는다.
+			// 그렇지 않으면 처음부터 다시 실행하므로 이전 출력이 중복된다.
+			if prev.SessionID != "" && prev.Provider == providerName {
+				t.cp.SessionID = prev.SessionID
+				t.baseSteps = prev.StepIndex
+				t.baseText = prev.AccumulatedOutput
+				t.cp.StepIndex = prev.StepIndex
+				t.cp.AccumulatedOutput = prev.AccumulatedOutput
+				req.ResumeSessionID = prev.SessionID
+				req.Prompt = resumeContinuationPrompt
+			}
+			e.logger.Info().
+				Str("execution_id", task.ExecutionID).
+				Str("session_id", prev.SessionID).
+				Int("step_index", prev.StepIndex).
+				Bool("resume_session", req.ResumeSessionID != "").
+				Msg("체크포인트에서 작업 재개")
+		}
+	}
+
+	e.activeCheckpoints.Store(task.ExecutionID, struct{}{})
+	t.mu.Lock()
+	t.save(now)
+	t.mu.Unlock()
+	return t
+}
+
+// finishCheckpoint는 작업이 끝나면 체크포인트를 삭제합니다.
+// 상위 컨텍스트가 취소되어(Bridge 종료, 연결 끊김, 리스 회수) 중단된 작업은
+// 재시작 후 서버와 재개 여부를 조율할 수 있도록 체크포인트를 남깁니다.
+func (e *TaskExecutor) finishCheckpoint(ctx context.Context, t *taskCheckpointer) {
+	if t == nil {
+		return
+	}
+	e.activeCheckpoints.Delete(t.cp.ExecutionID)
+	if ctx.Err() != nil {
+		t.mu.Lock()
+		t.save(time.Now())
+		t.mu.Unlock()
+		return
+	}
+	if err := e.checkpoints.Delete(t.cp.ExecutionID); err != nil {
+		e.logger.Warn().Str("execution_id", t.cp.ExecutionID).Err(err).Msg("작업 체크포인트 삭제 실패")
+	}
+}
+
+// PendingCheckpoints는 이 프로세스에서 실행 중이지 않은 체크포인트를 task_resume offer로 반환합니다.
+// websocket.TaskCheckpointer 인터페이스 구현.
+func (e *TaskExecutor) PendingCheckpoints() []ws.TaskResumePayload {
+	if e.checkpoints == nil {
+		return nil
+	}
+	checkpoints, err := e.checkpoints.List()
+	if err != nil {
+		e.logger.Warn().Err(err).Msg("작업 체크포인트 조회 실패")
+		return nil
+	}
+	var offers []ws.TaskResumePayload
+	for _, cp := range checkpoints {
+		if _, running := e.activeCheckpoints.Load(cp.ExecutionID); running {
+			continue
+		}
+		offers = append(offers, cp.ResumePayload())
+	}
+	return offers
+}
+
+// CheckpointedTask는 체크포인트에서 재개할 작업 요청을 반환합니다.
+// websocket.TaskCheckpointer 인터페이스 구현.
+func (e *TaskExecutor) CheckpointedTask(executionID string) (ws.TaskRequestPayload, bool) {
+	if e.checkpoints == nil {
+		return ws.TaskRequestPayload{}, false
+	}
+	cp, err := e.checkpoints.Load(executionID)
+	if err != nil || cp == nil {
+		return ws.TaskRequestPayload{}, false
+	}
+	task := cp.Task
+	task.ResumeFromCheckpoint = true
+	return task, true
+}
+
+// DiscardCheckpoint는 체크포인트를 삭제합니다.
+// websocket.TaskCheckpointer 인터페이스 구현.
+func (e *TaskExecutor) DiscardCheckpoint(executionID string) error {
+	if e.checkpoints == nil {
+		return nil
+	}
+	return e.checkpoints.Delete(executionID)
+}
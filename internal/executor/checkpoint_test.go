@@ -0,0 +1,184 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStore_SaveLoadDelete(t *testing.T) {
+	store := NewCheckpointStore(CheckpointConfig{Dir: t.TempDir()})
+
+	got, err := store.Load("exec-1")
+	require.NoError(t, err)
+	assert.Nil(t, got, "missing checkpoint should load as nil")
+
+	cp := &TaskCheckpoint{
+		ExecutionID:       "exec-1",
+		Task:              ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "do it"},
+		SessionID:         "sess-1",
+		StepIndex:         3,
+		AccumulatedOutput: "partial",
+		UpdatedAt:         time.Now(),
+	}
+	require.NoError(t, store.Save(cp))
+
+	info, err := os.Stat(store.path("exec-1"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	got, err = store.Load("exec-1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "sess-1", got.SessionID)
+	assert.Equal(t, 3, got.StepIndex)
+	assert.Equal(t, "do it", got.Task.Prompt)
+
+	require.NoError(t, store.Delete("exec-1"))
+	require.NoError(t, store.Delete("exec-1"), "deleting a missing checkpoint is not an error")
+	got, err = store.Load("exec-1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestCheckpointStore_ListSkipsExpiredAndCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	store := NewCheckpointStore(CheckpointConfig{Dir: dir, MaxAge: time.Hour})
+
+	now := time.Now()
+	require.NoError(t, store.Save(&TaskCheckpoint{ExecutionID: "newer", UpdatedAt: now}))
+	require.NoError(t, store.Save(&TaskCheckpoint{ExecutionID: "older", UpdatedAt: now.Add(-time.Minute)}))
+	require.NoError(t, store.Save(&TaskCheckpoint{ExecutionID: "expired", UpdatedAt: now.Add(-2 * time.Hour)}))
+	require.NoError(t, os.WriteFile(store.path("corrupt"), []byte("{"), 0600))
+
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "older", list[0].ExecutionID)
+	assert.Equal(t, "newer", list[1].ExecutionID)
+
+	_, err = os.Stat(store.path("expired"))
+	assert.True(t, os.IsNotExist(err), "expired checkpoint should be removed")
+	_, err = os.Stat(store.path("corrupt"))
+	assert.True(t, os.IsNotExist(err), "corrupt checkpoint should be removed")
+}
+
+func TestTaskExecutor_CheckpointDeletedOnCompletion(t *testing.T) {
+	store := NewCheckpointStore(CheckpointConfig{Dir: t.TempDir()})
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			require.NotNil(t, req.OnSession)
+			req.OnSession("sess-1", 1)
+
+			cp, err := store.Load("exec-1")
+			require.NoError(t, err)
+			require.NotNil(t, cp, "checkpoint should be saved while the task runs")
+			assert.Equal(t, "sess-1", cp.SessionID)
+			assert.Equal(t, 1, cp.StepIndex)
+			return &provider.ExecuteResponse{Output: "done"}, nil
+		},
+	})
+	e := NewTaskExecutor(registry, newMockSender(), WithCheckpointStore(store))
+
+	_, err := e.Execute(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "p", Model: "claude-sonnet"})
+	require.NoError(t, err)
+
+	cp, err := store.Load("exec-1")
+	require.NoError(t, err)
+	assert.Nil(t, cp, "checkpoint should be deleted after the task completes")
+}
+
+func TestTaskExecutor_ResumeFromCheckpoint(t *testing.T) {
+	store := NewCheckpointStore(CheckpointConfig{Dir: t.TempDir()})
+	registry := provider.NewRegistry()
+	var requests []provider.ExecuteRequest
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			requests = append(requests, req)
+			if req.ResumeSessionID == "" {
+				// 첫 실행: 세션을 기록한 뒤 Bridge 종료로 중단됨
+				req.OnSession("sess-1", 2)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			req.OnSession(req.ResumeSessionID, 1)
+			return &provider.ExecuteResponse{Output: "finished"}, nil
+		},
+	})
+	sender := newMockSender()
+	e := NewTaskExecutor(registry, sender, WithCheckpointStore(store))
+	task := ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "long task", Model: "claude-sonnet"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := e.Execute(ctx, task)
+	require.Error(t, err)
+
+	offers := e.PendingCheckpoints()
+	require.Len(t, offers, 1, "interrupted task should be offered for resume")
+	assert.Equal(t, ws.TaskResumeActionOffer, offers[0].Action)
+	assert.Equal(t, "sess-1", offers[0].SessionID)
+	assert.Equal(t, 2, offers[0].StepIndex)
+	assert.Equal(t, "claude", offers[0].Provider)
+
+	resumed, ok := e.CheckpointedTask("exec-1")
+	require.True(t, ok)
+	assert.True(t, resumed.ResumeFromCheckpoint)
+	assert.Equal(t, "long task", resumed.Prompt)
+
+	result, err := e.Execute(context.Background(), resumed)
+	require.NoError(t, err)
+	assert.Equal(t, "finished", result.Output)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "sess-1", requests[1].ResumeSessionID)
+	assert.Equal(t, resumeContinuationPrompt, requests[1].Prompt)
+	assert.Empty(t, e.PendingCheckpoints(), "checkpoint should be deleted after the resumed task completes")
+
+	_, ok = e.CheckpointedTask("exec-1")
+	assert.False(t, ok)
+}
+
+func TestTaskExecutor_ResumeWithoutSessionRestarts(t *testing.T) {
+	store := NewCheckpointStore(CheckpointConfig{Dir: t.TempDir()})
+	require.NoError(t, store.Save(&TaskCheckpoint{
+		ExecutionID:       "exec-1",
+		Task:              ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "original"},
+		Provider:          "claude",
+		AccumulatedOutput: "stale",
+		UpdatedAt:         time.Now(),
+	}))
+
+	registry := provider.NewRegistry()
+	var got provider.ExecuteRequest
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			got = req
+			return &provider.ExecuteResponse{Output: "ok"}, nil
+		},
+	})
+	sender := newMockSender()
+	e := NewTaskExecutor(registry, sender, WithCheckpointStore(store))
+
+	task, ok := e.CheckpointedTask("exec-1")
+	require.True(t, ok)
+	task.Model = "claude-sonnet"
+	_, err := e.Execute(context.Background(), task)
+	require.NoError(t, err)
+
+	assert.Empty(t, got.ResumeSessionID)
+	assert.Equal(t, "original", got.Prompt, "without a provider session the task restarts from the original prompt")
+	for _, p := range sender.GetProgressCalls() {
+		assert.NotContains(t, p.AccumulatedText, "stale", "output of a restarted task must not include the old output")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 	"github.com/insajin/autopus-codex-rpc/client"
 	"github.com/insajin/autopus-codex-rpc/protocol"
 	"github.com/rs/zerolog/log"
@@ -66,9 +67,11 @@ func newRPCClientAdapter(ctx context.Context) (*rpcClientAdapter, error) {
 		return nil, fmt.Errorf("stdout 파이프 생성 실패: %w", err)
 	}
 
-	if err := cmd.Start(); err != nil {
+	if err := procgroup.Start(cmd); err != nil {
 		return nil, fmt.Errorf("codex 프로세스 시작 실패: %w", err)
 	}
+	// 프로세스가 종료되면 상태를 회수해 좀비로 남지 않도록 한다.
+	go func() { _ = procgroup.Wait(cmd) }()
 
 	rpcClient := client.NewJSONRPCClient(stdinPipe, stdoutPipe, client.NopLogger())
 
@@ -78,13 +81,13 @@ func newRPCClientAdapter(ctx context.Context) (*rpcClientAdapter, error) {
 		Capabilities: protocol.Capabilities{ExperimentalApi: true},
 	}); err != nil {
 		_ = rpcClient.Close()
-		_ = cmd.Process.Kill()
+		_ = procgroup.Kill(cmd)
 		return nil, fmt.Errorf("초기화 핸드셰이크 실패: %w", err)
 	}
 
 	if err := rpcClient.Notify(protocol.MethodInitialized, nil); err != nil {
 		_ = rpcClient.Close()
-		_ = cmd.Process.Kill()
+		_ = procgroup.Kill(cmd)
 		return nil, fmt.Errorf("initialized 알림 전송 실패: %w", err)
 	}
 
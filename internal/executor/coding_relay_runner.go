@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
 	"github.com/rs/zerolog/log"
 )
 
@@ -48,14 +49,14 @@ func (r *CodingRelayRunner) RunRelay(
 
 	// 세션 슬롯 획득
 	if err := r.mgr.AcquireSlot(ctx); err != nil {
-		sendRelayError(sendMsg, req.RequestID, "", fmt.Sprintf("세션 슬롯 획득 실패: %v", err), "slot_exhausted")
+		sendRelayError(sendMsg, req.RequestID, "", fmt.Sprintf("세션 슬롯 획득 실패: %v", err), errcode.RelaySlotExhausted)
 		return
 	}
 	defer r.mgr.ReleaseSlot()
 
 	session, err := r.mgr.CreateSession(provider)
 	if err != nil {
-		sendRelayError(sendMsg, req.RequestID, "", fmt.Sprintf("세션 생성 실패: %v", err), "session_create_failed")
+		sendRelayError(sendMsg, req.RequestID, "", fmt.Sprintf("세션 생성 실패: %v", err), errcode.RelaySessionCreateFailed)
 		return
 	}
 
@@ -65,7 +66,7 @@ func (r *CodingRelayRunner) RunRelay(
 		MaxBudgetUSD:  req.MaxBudgetUSD,
 	}
 	if err := session.Open(ctx, openReq); err != nil {
-		sendRelayError(sendMsg, req.RequestID, "", fmt.Sprintf("세션 열기 실패: %v", err), "session_open_failed")
+		sendRelayError(sendMsg, req.RequestID, "", fmt.Sprintf("세션 열기 실패: %v", err), errcode.RelaySessionOpenFailed)
 		return
 	}
 	// 세션 등록 (CloseSession이 session.Close도 내부적으로 호출)
@@ -84,7 +85,7 @@ func (r *CodingRelayRunner) RunRelay(
 		resp, err := session.Send(ctx, message)
 		if err != nil {
 			sendRelayError(sendMsg, req.RequestID, session.SessionID(),
-				fmt.Sprintf("세션 Send 실패 (iter=%d): %v", iteration, err), "session_send_failed")
+				fmt.Sprintf("세션 Send 실패 (iter=%d): %v", iteration, err), errcode.RelaySessionSendFailed)
 			return
 		}
 		lastResp = resp
@@ -106,7 +107,7 @@ func (r *CodingRelayRunner) RunRelay(
 		// Worker 피드백 대기
 		select {
 		case <-ctx.Done():
-			sendRelayError(sendMsg, req.RequestID, session.SessionID(), "컨텍스트 취소됨", "context_cancelled")
+			sendRelayError(sendMsg, req.RequestID, session.SessionID(), "컨텍스트 취소됨", errcode.RelayContextCancelled)
 			return
 		case feedback, ok := <-feedbackCh:
 			if !ok {
@@ -170,12 +171,12 @@ func sendRelayComplete(sendMsg func(string, []byte) error, payload ws.CodingRela
 
 // sendRelayError는 릴레이 에러 페이로드를 직렬화하여 전송합니다.
 func sendRelayError(sendMsg func(string, []byte) error, requestID, sessionID, errMsg, code string) {
-	payload := ws.CodingRelayErrorPayload{
+	payload := errcode.CodingRelayError(ws.CodingRelayErrorPayload{
 		RequestID: requestID,
 		Error:     errMsg,
 		Code:      code,
 		SessionID: sessionID,
-	}
+	})
 	data, err := json.Marshal(payload)
 	if err != nil {
 		log.Error().Err(err).Msg("[coding-relay] error 직렬화 실패")
@@ -0,0 +1,233 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+	"github.com/insajin/autopus-bridge/internal/project"
+)
+
+// defaultContainerImages maps a detected project language to the image used
+// for build/test containers. Go projects use the toolchain version from go.mod.
+var defaultContainerImages = map[string]string{
+	"go":         "golang:1.25",
+	"javascript": "node:22",
+	"typescript": "node:22",
+	"python":     "python:3.12",
+}
+
+// containerCleanupTimeout bounds the best-effort removal of a cancelled container.
+const containerCleanupTimeout = 10 * time.Second
+
+// StackAnalyzer detects the technology stack of a project directory.
+// project.Analyzer satisfies this interface.
+type StackAnalyzer interface {
+	Analyze(rootDir string) (*ws.ProjectContextPayload, error)
+}
+
+// ContainerConfig configures running build/test commands inside Docker containers.
+type ContainerConfig struct {
+	// Default runs every build/test request in a container unless the request
+	// sets isolation to "host".
+	Default bool
+	// Runtime is the Docker-compatible CLI to use. Defaults to "docker".
+	Runtime string
+	// Images overrides the image per detected language ("go", "javascript",
+	// "typescript", "python", ...).
+	Images map[string]string
+	// CPUs limits the container CPUs (docker run --cpus, e.g. "2").
+	CPUs string
+	// Memory limits the container memory (docker run --memory, e.g. "4g").
+	Memory string
+	// PidsLimit limits the number of processes in the container. Zero means no limit.
+	PidsLimit int
+	// Network sets the container network (e.g. "none"). Empty uses the runtime default.
+	Network string
+}
+
+// ContainerIsolator runs build/test commands in a per-project container with
+// the work directory mounted read-write at the same path as on the host, so
+// paths in compiler and test output match the host checkout.
+type ContainerIsolator struct {
+	cfg      ContainerConfig
+	analyzer StackAnalyzer
+}
+
+// NewContainerIsolator creates a ContainerIsolator. A nil analyzer uses project.NewAnalyzer.
+func NewContainerIsolator(cfg ContainerConfig, analyzer StackAnalyzer) *ContainerIsolator {
+	if cfg.Runtime == "" {
+		cfg.Runtime = "docker"
+	}
+	if analyzer == nil {
+		analyzer = project.NewAnalyzer()
+	}
+	return &ContainerIsolator{cfg: cfg, analyzer: analyzer}
+}
+
+// useContainer reports whether a request with the given isolation mode runs in
+// a container. c may be nil when container isolation is not configured.
+func (c *ContainerIsolator) useContainer(isolation string) (bool, error) {
+	switch strings.ToLower(isolation) {
+	case "":
+		return c != nil && c.cfg.Default, nil
+	case ws.IsolationHost:
+		return false, nil
+	case ws.IsolationDocker:
+		if c == nil {
+			return false, fmt.Errorf("container isolation is not configured on this bridge")
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown isolation mode %q (supported: %s, %s)", isolation, ws.IsolationHost, ws.IsolationDocker)
+	}
+}
+
+// SelectImage returns override when set, otherwise the image for the first
+// detected language of the project in workDir that has one.
+func (c *ContainerIsolator) SelectImage(workDir, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	ctx, err := c.analyzer.Analyze(workDir)
+	if err != nil {
+		return "", fmt.Errorf("detect project stack: %w", err)
+	}
+	for _, lang := range ctx.TechStack.Languages {
+		if image := c.cfg.Images[lang]; image != "" {
+			return image, nil
+		}
+		if lang == "go" {
+			if version := goModVersion(filepath.Join(workDir, "go.mod")); version != "" {
+				return "golang:" + version, nil
+			}
+		}
+		if image := defaultContainerImages[lang]; image != "" {
+			return image, nil
+		}
+	}
+	return "", fmt.Errorf("no container image for the detected stack %v; set image in the request", ctx.TechStack.Languages)
+}
+
+// prepareContainerRun returns the container run for a request, or nil when the
+// request runs on the host. c may be nil when container isolation is not configured.
+func prepareContainerRun(c *ContainerIsolator, executionID, workDir, isolation, image string, extraMounts ...string) (*containerRun, error) {
+	use, err := c.useContainer(isolation)
+	if err != nil || !use {
+		return nil, err
+	}
+	return c.prepare(executionID, workDir, image, extraMounts...)
+}
+
+// containerRun is one container invocation prepared for a build/test request.
+type containerRun struct {
+	runtime string
+	name    string
+	image   string
+	workDir string
+	// args are the docker run options shared by every command of the run.
+	args []string
+}
+
+// prepare selects the image and builds the docker run options for workDir.
+// extraMounts are additional host directories mounted read-write at the same
+// path (e.g. a coverage report directory outside workDir).
+func (c *ContainerIsolator) prepare(executionID, workDir, imageOverride string, extraMounts ...string) (*containerRun, error) {
+	image, err := c.SelectImage(workDir, imageOverride)
+	if err != nil {
+		return nil, err
+	}
+	run := &containerRun{
+		runtime: c.cfg.Runtime,
+		name:    "autopus-" + sanitizeIsolationName(executionID) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36),
+		image:   image,
+		workDir: workDir,
+	}
+	args := []string{"run", "--rm", "--init", "--name", run.name, "-w", workDir, "-v", workDir + ":" + workDir}
+	for _, m := range extraMounts {
+		args = append(args, "-v", m+":"+m)
+	}
+	if runtime.GOOS == "linux" {
+		// Files created in the mounted work_dir stay owned by the bridge user.
+		// HOME points at a writable directory for tool caches (GOCACHE, npm cache).
+		args = append(args, "--user", strconv.Itoa(os.Getuid())+":"+strconv.Itoa(os.Getgid()), "-e", "HOME=/tmp")
+	}
+	if c.cfg.CPUs != "" {
+		args = append(args, "--cpus", c.cfg.CPUs)
+	}
+	if c.cfg.Memory != "" {
+		args = append(args, "--memory", c.cfg.Memory)
+	}
+	if c.cfg.PidsLimit > 0 {
+		args = append(args, "--pids-limit", strconv.Itoa(c.cfg.PidsLimit))
+	}
+	if c.cfg.Network != "" {
+		args = append(args, "--network", c.cfg.Network)
+	}
+	run.args = args
+	return run, nil
+}
+
+// command builds the docker run command for a shell command line. Only env is
+// passed into the container; the host environment is not inherited.
+func (r *containerRun) command(ctx context.Context, command string, env []string) *exec.Cmd {
+	args := append([]string{}, r.args...)
+	for _, kv := range env {
+		args = append(args, "-e", kv)
+	}
+	args = append(args, r.image, "sh", "-c", command)
+	cmd := exec.CommandContext(ctx, r.runtime, args...)
+	cmd.Dir = r.workDir
+	return cmd
+}
+
+// cleanup force-removes the container. Killing the docker CLI on timeout or
+// cancellation does not stop the container itself, so this runs after every
+// interrupted command.
+func (r *containerRun) cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), containerCleanupTimeout)
+	defer cancel()
+	_ = procgroup.Run(exec.CommandContext(ctx, r.runtime, "rm", "-f", r.name))
+}
+
+// shellCommand builds the command that runs command in workDir, either on the
+// host (run == nil, host environment plus env) or in the prepared container.
+func shellCommand(ctx context.Context, run *containerRun, workDir, command string, env []string) *exec.Cmd {
+	if run != nil {
+		return run.command(ctx, command, env)
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), env...)
+	return cmd
+}
+
+// goModVersion returns the major.minor Go version from the go directive of a go.mod file.
+func goModVersion(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "go" {
+			parts := strings.SplitN(fields[1], ".", 3)
+			if len(parts) >= 2 {
+				return parts[0] + "." + parts[1]
+			}
+			return fields[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContainerRuntime writes a docker stand-in that prints its arguments one per line.
+func fakeContainerRuntime(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	path := filepath.Join(t.TempDir(), "docker")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nprintf '%s\\n' \"$@\"\n"), 0755))
+	return path
+}
+
+func TestContainerIsolator_SelectImage(t *testing.T) {
+	isolator := NewContainerIsolator(ContainerConfig{Images: map[string]string{"python": "python:3.11-slim"}}, nil)
+
+	goDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(goDir, "go.mod"), []byte("module example.com/x\n\ngo 1.24.3\n"), 0644))
+	image, err := isolator.SelectImage(goDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "golang:1.24", image, "Go image follows the go.mod toolchain version")
+
+	nodeDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(nodeDir, "package.json"), []byte(`{"name":"x"}`), 0644))
+	image, err = isolator.SelectImage(nodeDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "node:22", image)
+
+	pyDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(pyDir, "requirements.txt"), []byte("pytest\n"), 0644))
+	image, err = isolator.SelectImage(pyDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "python:3.11-slim", image, "configured image overrides the default")
+
+	image, err = isolator.SelectImage(pyDir, "custom/ci:latest")
+	require.NoError(t, err)
+	assert.Equal(t, "custom/ci:latest", image, "request image overrides detection")
+
+	_, err = isolator.SelectImage(t.TempDir(), "")
+	assert.Error(t, err, "unknown stack needs an explicit image")
+}
+
+func TestContainerIsolator_UseContainer(t *testing.T) {
+	var none *ContainerIsolator
+	use, err := none.useContainer("")
+	require.NoError(t, err)
+	assert.False(t, use)
+	_, err = none.useContainer(ws.IsolationDocker)
+	assert.Error(t, err, "docker isolation requires a configured isolator")
+
+	isolator := NewContainerIsolator(ContainerConfig{Default: true}, nil)
+	use, err = isolator.useContainer("")
+	require.NoError(t, err)
+	assert.True(t, use)
+	use, err = isolator.useContainer(ws.IsolationHost)
+	require.NoError(t, err)
+	assert.False(t, use)
+	_, err = isolator.useContainer("vm")
+	assert.Error(t, err)
+}
+
+func TestBuildExecutor_ContainerIsolation(t *testing.T) {
+	isolator := NewContainerIsolator(ContainerConfig{
+		Runtime:   fakeContainerRuntime(t),
+		CPUs:      "2",
+		Memory:    "4g",
+		PidsLimit: 256,
+		Network:   "none",
+	}, nil)
+	dir := t.TempDir()
+
+	result := NewBuildExecutor(WithBuildContainerIsolation(isolator)).Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     dir,
+		Command:     "make build",
+		Env:         []string{"FOO=bar"},
+		Isolation:   ws.IsolationDocker,
+		Image:       "golang:1.25",
+	})
+
+	require.True(t, result.Success, result.Output)
+	assert.Equal(t, "golang:1.25", result.ContainerImage)
+	args := strings.Split(strings.TrimSpace(result.Output), "\n")
+	assert.Equal(t, []string{"run", "--rm", "--init"}, args[:3])
+	assert.Contains(t, result.Output, "-v\n"+dir+":"+dir+"\n")
+	assert.Contains(t, result.Output, "-w\n"+dir+"\n")
+	assert.Contains(t, result.Output, "--cpus\n2\n--memory\n4g\n--pids-limit\n256\n--network\nnone\n")
+	assert.Contains(t, result.Output, "-e\nFOO=bar\n")
+	assert.Equal(t, []string{"golang:1.25", "sh", "-c", "make build"}, args[len(args)-4:])
+
+	// isolation "host" bypasses the container even when it is configured.
+	result = NewBuildExecutor(WithBuildContainerIsolation(isolator)).Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-2",
+		WorkDir:     dir,
+		Command:     "echo host",
+		Isolation:   ws.IsolationHost,
+	})
+	require.True(t, result.Success, result.Output)
+	assert.Equal(t, "host\n", result.Output)
+	assert.Empty(t, result.ContainerImage)
+}
+
+func TestBuildExecutor_ContainerIsolationNotConfigured(t *testing.T) {
+	result := NewBuildExecutor().Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     t.TempDir(),
+		Command:     "true",
+		Isolation:   ws.IsolationDocker,
+	})
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Output, "container isolation failed")
+}
+
+func TestTestExecutor_ContainerIsolationMountsCoverageDir(t *testing.T) {
+	isolator := NewContainerIsolator(ContainerConfig{Runtime: fakeContainerRuntime(t), Default: true}, nil)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/x\n\ngo 1.25\n"), 0644))
+
+	result := NewTestExecutor(WithTestContainerIsolation(isolator)).Execute(context.Background(), ws.TestRequestPayload{
+		ExecutionID: "test-1",
+		WorkDir:     dir,
+		Command:     "go test ./...",
+		Coverage:    true,
+	})
+
+	assert.Equal(t, "golang:1.25", result.ContainerImage)
+	assert.Contains(t, result.Output, "-v\n"+dir+":"+dir+"\n")
+	assert.Contains(t, result.Output, "autopus-coverage-", "coverage report directory is mounted into the container")
+	assert.Contains(t, result.Output, "go test -coverprofile=")
+}
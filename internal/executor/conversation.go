@@ -0,0 +1,162 @@
+// Package executor는 Local Agent Bridge의 작업 실행 엔진을 제공합니다.
+// 대화 연속성: 같은 conversation_id의 작업이 이전 작업의 프로바이더 세션
+// (Claude 세션 ID, Codex Thread ID)을 이어서 사용하도록 세션 ID를 기억합니다.
+package executor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// DefaultConversationTTL은 마지막 사용 후 대화 세션을 재사용할 수 있는 기본 시간입니다.
+const DefaultConversationTTL = 30 * time.Minute
+
+// conversationEntry는 대화에 연결된 프로바이더 세션입니다.
+type conversationEntry struct {
+	provider  string
+	sessionID string
+	lastUsed  time.Time
+}
+
+// ConversationStore는 conversation_id별 프로바이더 세션을 메모리에 보관합니다.
+// TTL 동안 사용되지 않은 세션은 만료되어 다음 작업이 새 세션으로 시작합니다.
+type ConversationStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]conversationEntry
+	// now는 현재 시각을 반환합니다 (테스트에서 교체).
+	now func() time.Time
+}
+
+// NewConversationStore는 새 대화 세션 저장소를 생성합니다. ttl이 0 이하이면 DefaultConversationTTL을 사용합니다.
+func NewConversationStore(ttl time.Duration) *ConversationStore {
+	if ttl <= 0 {
+		ttl = DefaultConversationTTL
+	}
+	return &ConversationStore{
+		ttl:     ttl,
+		entries: make(map[string]conversationEntry),
+		now:     time.Now,
+	}
+}
+
+// Lookup은 대화에 연결된 providerName의 세션 ID를 반환합니다.
+// 세션이 없거나, 만료되었거나, 다른 프로바이더의 세션이면 빈 문자열을 반환합니다.
+func (s *ConversationStore) Lookup(conversationID, providerName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked()
+	entry, ok := s.entries[conversationID]
+	if !ok || entry.provider != providerName {
+		return ""
+	}
+	return entry.sessionID
+}
+
+// Record는 대화에 프로바이더 세션을 연결하고 마지막 사용 시각을 갱신합니다.
+func (s *ConversationStore) Record(conversationID, providerName, sessionID string) {
+	if conversationID == "" || sessionID == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[conversationID] = conversationEntry{
+		provider:  providerName,
+		sessionID: sessionID,
+		lastUsed:  s.now(),
+	}
+}
+
+// Reset은 대화에 연결된 세션을 삭제합니다. 삭제한 세션이 있으면 true를 반환합니다.
+func (s *ConversationStore) Reset(conversationID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.entries[conversationID]
+	delete(s.entries, conversationID)
+	return ok
+}
+
+// pruneLocked는 만료된 세션을 삭제합니다. 호출자가 mu를 보유해야 합니다.
+func (s *ConversationStore) pruneLocked() {
+	cutoff := s.now().Add(-s.ttl)
+	for id, entry := range s.entries {
+		if entry.lastUsed.Before(cutoff) {
+			delete(s.entries, id)
+		}
+	}
+}
+
+// taskConversation은 한 작업 실행의 대화 연속성 상태입니다.
+type taskConversation struct {
+	store          *ConversationStore
+	conversationID string
+	provider       string
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+// beginConversation은 작업의 conversation_id에 연결된 세션으로 req를 이어서 실행하도록 설정합니다.
+// 체크포인트 재개로 이미 세션이 지정되었으면 그 세션을 유지합니다.
+// 대화 연속성이 비활성화되었거나 conversation_id가 없으면 nil을 반환합니다.
+func (e *TaskExecutor) beginConversation(task ws.TaskRequestPayload, providerName string, req *provider.ExecuteRequest) *taskConversation {
+	if e.conversations == nil || task.ConversationID == "" {
+		return nil
+	}
+
+	c := &taskConversation{
+		store:          e.conversations,
+		conversationID: task.ConversationID,
+		provider:       providerName,
+	}
+	if task.ResetConversation && e.conversations.Reset(task.ConversationID) {
+		e.logger.Info().
+			Str("execution_id", task.ExecutionID).
+			Str("conversation_id", task.ConversationID).
+			Msg("대화 세션 초기화")
+	}
+	if req.ResumeSessionID == "" {
+		if sessionID := e.conversations.Lookup(task.ConversationID, providerName); sessionID != "" {
+			req.ResumeSessionID = sessionID
+			e.logger.Info().
+				Str("execution_id", task.ExecutionID).
+				Str("conversation_id", task.ConversationID).
+				Str("session_id", sessionID).
+				Msg("대화 세션 이어서 실행")
+		}
+	}
+
+	next := req.OnSession
+	req.OnSession = func(sessionID string, turn int) {
+		c.mu.Lock()
+		c.sessionID = sessionID
+		c.mu.Unlock()
+		if next != nil {
+			next(sessionID, turn)
+		}
+	}
+	return c
+}
+
+// finish는 실행 결과에 따라 대화 세션을 갱신합니다.
+// 성공하면 확인된 세션을 기록하고, 실패하면 (만료된 Thread 재개 실패 등) 다음 작업이
+// 새 세션으로 시작하도록 대화 세션을 삭제합니다.
+func (c *taskConversation) finish(err error) {
+	if c == nil {
+		return
+	}
+	if err != nil {
+		c.store.Reset(c.conversationID)
+		return
+	}
+	c.mu.Lock()
+	sessionID := c.sessionID
+	c.mu.Unlock()
+	c.store.Record(c.conversationID, c.provider, sessionID)
+}
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationStore_TTLAndProvider(t *testing.T) {
+	store := NewConversationStore(10 * time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	store.Record("conv-1", "codex", "thread-1")
+	assert.Equal(t, "thread-1", store.Lookup("conv-1", "codex"))
+	assert.Empty(t, store.Lookup("conv-1", "claude"), "sessions are not shared across providers")
+
+	now = now.Add(9 * time.Minute)
+	assert.Equal(t, "thread-1", store.Lookup("conv-1", "codex"))
+	store.Record("conv-1", "codex", "thread-1")
+
+	now = now.Add(11 * time.Minute)
+	assert.Empty(t, store.Lookup("conv-1", "codex"), "session should expire after the TTL")
+
+	store.Record("conv-2", "codex", "thread-2")
+	assert.True(t, store.Reset("conv-2"))
+	assert.False(t, store.Reset("conv-2"))
+	assert.Empty(t, store.Lookup("conv-2", "codex"))
+}
+
+func TestTaskExecutor_ConversationContinuity(t *testing.T) {
+	var mu sync.Mutex
+	var resumed []string
+	fail := false
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			mu.Lock()
+			resumed = append(resumed, req.ResumeSessionID)
+			mu.Unlock()
+			if fail {
+				return nil, errors.New("thread/resume 실패: thread not found")
+			}
+			sessionID := req.ResumeSessionID
+			if sessionID == "" {
+				sessionID = "session-" + req.Prompt
+			}
+			if req.OnSession != nil {
+				req.OnSession(sessionID, 1)
+			}
+			return &provider.ExecuteResponse{Output: "ok"}, nil
+		},
+	})
+	e := NewTaskExecutor(registry, newMockSender(), WithConversationStore(NewConversationStore(time.Hour)))
+
+	run := func(prompt string, reset bool) error {
+		_, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+			ExecutionID:       "exec-" + prompt,
+			Prompt:            prompt,
+			Model:             "claude-sonnet",
+			ConversationID:    "conv-1",
+			ResetConversation: reset,
+		})
+		return err
+	}
+
+	require.NoError(t, run("a", false))
+	require.NoError(t, run("b", false))
+	require.NoError(t, run("c", true))
+	require.NoError(t, run("d", false))
+	fail = true
+	require.Error(t, run("e", false))
+	fail = false
+	require.NoError(t, run("f", false))
+
+	_, err := e.Execute(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-g", Prompt: "g", Model: "claude-sonnet"})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"",          // a: 새 대화
+		"session-a", // b: 이어서 실행
+		"",          // c: 명시적 초기화
+		"session-c", // d: 초기화 후 새 세션을 이어서 실행
+		"session-c", // e: 재개 실패
+		"",          // f: 실패한 세션은 버리고 새로 시작
+		"",          // g: conversation_id 없음
+	}, resumed)
+}
@@ -0,0 +1,195 @@
+// Package executor는 Local Agent Bridge의 작업 실행 엔진을 제공합니다.
+// 실행 범위 자격 증명: 작업에 첨부된 단기 클라우드 자격 증명을 해당 실행의 프로세스에만
+// 환경 변수와 자격 증명 파일로 전달하고, 실행이 끝나면 지웁니다.
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+// ErrorCodeCredentialsFailed는 작업 자격 증명을 준비하지 못했을 때 사용됩니다.
+const ErrorCodeCredentialsFailed = errcode.CredentialsFailed
+
+// executionCredentials는 한 실행에 준비된 자격 증명입니다.
+// 브리지 프로세스의 환경 변수는 바꾸지 않으며, Env()를 프로바이더 프로세스에만 전달합니다.
+type executionCredentials struct {
+	// dir는 자격 증명 파일을 둔 실행 전용 디렉토리입니다 (0700).
+	dir string
+	// env는 프로바이더 프로세스에 추가할 환경 변수입니다 (KEY=VALUE).
+	env []string
+}
+
+// materializeCredentials는 작업 자격 증명을 실행 전용 임시 디렉토리에 기록하고 환경 변수를 구성합니다.
+// 자격 증명이 없으면 nil을 반환합니다. 만료되었거나 필수 값이 없으면 에러를 반환합니다.
+//
+//   - aws: AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN과 실행 전용
+//     credentials/config 파일(AWS_SHARED_CREDENTIALS_FILE, AWS_CONFIG_FILE)을 설정하여
+//     사용자의 ~/.aws 프로필 대신 전달된 자격 증명을 사용하게 합니다.
+//   - gcp: 액세스 토큰 파일(CLOUDSDK_AUTH_ACCESS_TOKEN_FILE)과 실행 전용 gcloud 설정 디렉토리
+//     (CLOUDSDK_CONFIG), GOOGLE_OAUTH_ACCESS_TOKEN을 설정합니다.
+func materializeCredentials(executionID string, creds []ws.TaskCredential, now time.Time) (*executionCredentials, error) {
+	if len(creds) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(creds))
+	for _, c := range creds {
+		if seen[c.Type] {
+			return nil, fmt.Errorf("같은 종류의 자격 증명이 중복되었습니다: %s", c.Type)
+		}
+		seen[c.Type] = true
+		if c.ExpiresAt != nil && !c.ExpiresAt.After(now) {
+			return nil, fmt.Errorf("%s 자격 증명이 만료되었습니다 (%s)", c.Type, c.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "autopus-creds-"+sanitizeCredentialDirName(executionID)+"-")
+	if err != nil {
+		return nil, fmt.Errorf("자격 증명 디렉토리 생성 실패: %w", err)
+	}
+	ec := &executionCredentials{dir: dir}
+
+	for _, c := range creds {
+		var err error
+		switch c.Type {
+		case ws.TaskCredentialAWS:
+			err = ec.addAWS(c)
+		case ws.TaskCredentialGCP:
+			err = ec.addGCP(c)
+		default:
+			err = fmt.Errorf("지원하지 않는 자격 증명 종류: %q", c.Type)
+		}
+		if err != nil {
+			ec.Wipe()
+			return nil, err
+		}
+	}
+	return ec, nil
+}
+
+// addAWS는 AWS 임시 자격 증명을 설정합니다.
+func (ec *executionCredentials) addAWS(c ws.TaskCredential) error {
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return fmt.Errorf("aws 자격 증명에 access_key_id/secret_access_key가 없습니다")
+	}
+
+	var creds strings.Builder
+	creds.WriteString("[default]\n")
+	fmt.Fprintf(&creds, "aws_access_key_id = %s\n", c.AccessKeyID)
+	fmt.Fprintf(&creds, "aws_secret_access_key = %s\n", c.SecretAccessKey)
+	if c.SessionToken != "" {
+		fmt.Fprintf(&creds, "aws_session_token = %s\n", c.SessionToken)
+	}
+	credsFile, err := ec.writeFile("aws/credentials", creds.String())
+	if err != nil {
+		return err
+	}
+	config := "[default]\n"
+	if c.Region != "" {
+		config += "region = " + c.Region + "\n"
+	}
+	configFile, err := ec.writeFile("aws/config", config)
+	if err != nil {
+		return err
+	}
+
+	ec.env = append(ec.env,
+		"AWS_ACCESS_KEY_ID="+c.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY="+c.SecretAccessKey,
+		"AWS_SESSION_TOKEN="+c.SessionToken,
+		"AWS_SHARED_CREDENTIALS_FILE="+credsFile,
+		"AWS_CONFIG_FILE="+configFile,
+		// 사용자 환경의 AWS_PROFILE이 실행 전용 파일에 없는 프로필을 가리키지 않도록 고정한다.
+		"AWS_PROFILE=default",
+	)
+	if c.Region != "" {
+		ec.env = append(ec.env, "AWS_REGION="+c.Region, "AWS_DEFAULT_REGION="+c.Region)
+	}
+	return nil
+}
+
+// addGCP는 GCP 액세스 토큰을 설정합니다.
+func (ec *executionCredentials) addGCP(c ws.TaskCredential) error {
+	if c.AccessToken == "" {
+		return fmt.Errorf("gcp 자격 증명에 access_token이 없습니다")
+	}
+
+	tokenFile, err := ec.writeFile("gcp/access_token", c.AccessToken)
+	if err != nil {
+		return err
+	}
+	configDir := filepath.Join(ec.dir, "gcloud")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return fmt.Errorf("gcloud 설정 디렉토리 생성 실패: %w", err)
+	}
+
+	ec.env = append(ec.env,
+		"CLOUDSDK_AUTH_ACCESS_TOKEN_FILE="+tokenFile,
+		// 사용자의 gcloud 계정/설정을 사용하거나 변경하지 않도록 실행 전용 설정 디렉토리를 쓴다.
+		"CLOUDSDK_CONFIG="+configDir,
+		"GOOGLE_OAUTH_ACCESS_TOKEN="+c.AccessToken,
+	)
+	if c.ProjectID != "" {
+		ec.env = append(ec.env, "CLOUDSDK_CORE_PROJECT="+c.ProjectID, "GOOGLE_CLOUD_PROJECT="+c.ProjectID)
+	}
+	return nil
+}
+
+// writeFile은 실행 전용 디렉토리 아래 rel 경로에 0600 권한으로 파일을 기록하고 절대 경로를 반환합니다.
+func (ec *executionCredentials) writeFile(rel, content string) (string, error) {
+	path := filepath.Join(ec.dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("자격 증명 디렉토리 생성 실패: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("자격 증명 파일 기록 실패: %w", err)
+	}
+	return path, nil
+}
+
+// Env는 프로바이더 프로세스에 추가할 환경 변수를 반환합니다. nil이면 nil을 반환합니다.
+func (ec *executionCredentials) Env() []string {
+	if ec == nil {
+		return nil
+	}
+	return ec.env
+}
+
+// Wipe는 자격 증명 파일 내용을 덮어쓴 뒤 실행 전용 디렉토리를 삭제합니다. nil이면 아무것도 하지 않습니다.
+func (ec *executionCredentials) Wipe() {
+	if ec == nil {
+		return
+	}
+	_ = filepath.WalkDir(ec.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			_ = os.WriteFile(path, make([]byte, info.Size()), 0600)
+		}
+		return nil
+	})
+	_ = os.RemoveAll(ec.dir)
+	ec.env = nil
+}
+
+// sanitizeCredentialDirName은 실행 ID를 임시 디렉토리 이름에 쓸 수 있게 정리합니다.
+func sanitizeCredentialDirName(executionID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, executionID)
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	return name
+}
@@ -0,0 +1,172 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envValue는 KEY=VALUE 목록에서 key의 값을 반환합니다.
+func envValue(env []string, key string) (string, bool) {
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func TestMaterializeCredentials(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(time.Hour)
+	creds, err := materializeCredentials("exec/1", []ws.TaskCredential{
+		{Type: ws.TaskCredentialAWS, AccessKeyID: "AKIA1", SecretAccessKey: "secret", SessionToken: "token", Region: "ap-northeast-2", ExpiresAt: &expires},
+		{Type: ws.TaskCredentialGCP, AccessToken: "ya29.token", ProjectID: "proj-1"},
+	}, now)
+	require.NoError(t, err)
+	require.NotNil(t, creds)
+
+	env := creds.Env()
+	v, _ := envValue(env, "AWS_ACCESS_KEY_ID")
+	assert.Equal(t, "AKIA1", v)
+	v, _ = envValue(env, "AWS_REGION")
+	assert.Equal(t, "ap-northeast-2", v)
+	v, _ = envValue(env, "CLOUDSDK_CORE_PROJECT")
+	assert.Equal(t, "proj-1", v)
+
+	credsFile, ok := envValue(env, "AWS_SHARED_CREDENTIALS_FILE")
+	require.True(t, ok)
+	data, err := os.ReadFile(credsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "aws_session_token = token")
+	info, err := os.Stat(credsFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	tokenFile, ok := envValue(env, "CLOUDSDK_AUTH_ACCESS_TOKEN_FILE")
+	require.True(t, ok)
+	data, err = os.ReadFile(tokenFile)
+	require.NoError(t, err)
+	assert.Equal(t, "ya29.token", string(data))
+
+	_, inProcess := os.LookupEnv("AWS_SHARED_CREDENTIALS_FILE")
+	assert.False(t, inProcess, "credentials must not leak into the bridge environment")
+
+	creds.Wipe()
+	_, err = os.Stat(filepath.Dir(filepath.Dir(credsFile)))
+	assert.True(t, os.IsNotExist(err), "credential directory should be removed")
+	assert.Nil(t, creds.Env())
+}
+
+func TestMaterializeCredentials_Rejects(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		creds []ws.TaskCredential
+	}{
+		{"expired", []ws.TaskCredential{{Type: ws.TaskCredentialGCP, AccessToken: "t", ExpiresAt: &expired}}},
+		{"missing secret", []ws.TaskCredential{{Type: ws.TaskCredentialAWS, AccessKeyID: "AKIA1"}}},
+		{"unknown type", []ws.TaskCredential{{Type: "azure", AccessToken: "t"}}},
+		{"duplicate", []ws.TaskCredential{{Type: ws.TaskCredentialGCP, AccessToken: "a"}, {Type: ws.TaskCredentialGCP, AccessToken: "b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds, err := materializeCredentials("exec-1", tt.creds, now)
+			assert.Error(t, err)
+			assert.Nil(t, creds)
+		})
+	}
+
+	creds, err := materializeCredentials("exec-1", nil, now)
+	assert.NoError(t, err)
+	assert.Nil(t, creds, "no credentials should not create a directory")
+	creds.Wipe()
+}
+
+// envMockProvider는 실행마다 프로세스를 띄워 Env를 전달하는 CLI 프로바이더를 흉내 냅니다.
+type envMockProvider struct {
+	mockProvider
+}
+
+func (p *envMockProvider) SupportsExecutionEnv() bool { return true }
+
+func TestTaskExecutor_ExecutionScopedCredentials(t *testing.T) {
+	store := NewCheckpointStore(CheckpointConfig{Dir: t.TempDir()})
+	var credsFile string
+	registry := provider.NewRegistry()
+	registry.Register(&envMockProvider{mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			var ok bool
+			credsFile, ok = envValue(req.Env, "AWS_SHARED_CREDENTIALS_FILE")
+			require.True(t, ok, "credentials should be passed to the provider process")
+			_, err := os.Stat(credsFile)
+			require.NoError(t, err)
+
+			cp, err := store.Load("exec-1")
+			require.NoError(t, err)
+			require.NotNil(t, cp)
+			assert.Empty(t, cp.Task.Credentials, "credentials must not be persisted in checkpoints")
+			return &provider.ExecuteResponse{Output: "done"}, nil
+		},
+	}})
+	e := NewTaskExecutor(registry, newMockSender(), WithCheckpointStore(store))
+
+	_, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-1",
+		Prompt:      "p",
+		Model:       "claude-sonnet",
+		Credentials: []ws.TaskCredential{{Type: ws.TaskCredentialAWS, AccessKeyID: "AKIA1", SecretAccessKey: "secret"}},
+	})
+	require.NoError(t, err)
+	_, err = os.Stat(credsFile)
+	assert.True(t, os.IsNotExist(err), "credentials should be wiped after the execution")
+
+	expired := time.Now().Add(-time.Minute)
+	_, err = e.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-2",
+		Prompt:      "p",
+		Model:       "claude-sonnet",
+		Credentials: []ws.TaskCredential{{Type: ws.TaskCredentialGCP, AccessToken: "t", ExpiresAt: &expired}},
+	})
+	var taskErr *TaskError
+	require.ErrorAs(t, err, &taskErr)
+	assert.Equal(t, ErrorCodeCredentialsFailed, taskErr.Code)
+}
+
+func TestTaskExecutor_CredentialsRequireProcessPerTask(t *testing.T) {
+	called := false
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			called = true
+			return &provider.ExecuteResponse{Output: "done"}, nil
+		},
+	})
+	e := NewTaskExecutor(registry, newMockSender())
+
+	_, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-1",
+		Prompt:      "p",
+		Model:       "claude-sonnet",
+		Credentials: []ws.TaskCredential{{Type: ws.TaskCredentialGCP, AccessToken: "t"}},
+	})
+	var taskErr *TaskError
+	require.ErrorAs(t, err, &taskErr)
+	assert.Equal(t, ErrorCodeCredentialsFailed, taskErr.Code)
+	assert.False(t, called, "a provider that ignores Env must not run without the task credentials")
+
+	_, err = e.Execute(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-2", Prompt: "p", Model: "claude-sonnet"})
+	assert.NoError(t, err, "tasks without credentials still run")
+}
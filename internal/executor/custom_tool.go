@@ -0,0 +1,387 @@
+// Package executor는 Local Agent Bridge의 작업 실행 엔진을 제공합니다.
+// custom_tool_request로 요청된 사용자 정의 로컬 도구를 실행합니다.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+)
+
+const (
+	// CustomToolDefaultTimeout은 사용자 정의 도구의 기본 실행 타임아웃입니다 (120초).
+	CustomToolDefaultTimeout = 120 * time.Second
+	// customToolMaxOutput은 stdout/stderr 최대 캡처 크기입니다 (1MB).
+	customToolMaxOutput = 1 * 1024 * 1024
+)
+
+// customToolNamePattern은 허용되는 도구 이름 형식입니다.
+var customToolNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ErrCustomToolNotFound는 설정되지 않은 도구 실행 요청 시 반환됩니다.
+var ErrCustomToolNotFound = errors.New("설정되지 않은 사용자 정의 도구입니다")
+
+// CustomTool은 사용자 정의 로컬 도구 선언입니다.
+type CustomTool struct {
+	// Name은 서버에 노출할 도구 이름입니다.
+	Name string
+	// Description은 도구 설명입니다.
+	Description string
+	// Command는 실행할 명령과 인자 템플릿입니다. 셸 없이 직접 실행되며,
+	// 각 항목은 text/template으로 도구 인자를 치환합니다 (예: "{{.branch}}").
+	Command []string
+	// WorkDir는 실행 디렉토리입니다 (비어있으면 현재 디렉토리).
+	WorkDir string
+	// Env는 추가 환경 변수입니다.
+	Env map[string]string
+	// Timeout은 실행 타임아웃입니다 (0이면 CustomToolDefaultTimeout).
+	Timeout time.Duration
+	// InputSchema는 도구 인자의 JSON Schema(object)입니다.
+	InputSchema map[string]interface{}
+}
+
+// customToolProperty는 InputSchema에서 검증에 사용하는 인자 선언입니다.
+type customToolProperty struct {
+	typ     string
+	enum    []interface{}
+	pattern *regexp.Regexp
+	def     interface{}
+}
+
+// preparedCustomTool은 템플릿과 스키마를 미리 파싱한 도구입니다.
+type preparedCustomTool struct {
+	def        CustomTool
+	argv       []*template.Template
+	properties map[string]customToolProperty
+	required   []string
+}
+
+// CustomToolExecutor는 설정된 사용자 정의 도구를 인자 검증 후 실행합니다.
+// 명령은 셸을 거치지 않으므로 인자 값이 셸 구문으로 해석되지 않으며,
+// 스키마에 선언되지 않은 인자는 거부됩니다.
+type CustomToolExecutor struct {
+	tools map[string]*preparedCustomTool
+	names []string
+}
+
+// NewCustomToolExecutor는 도구 선언을 검증하고 CustomToolExecutor를 생성합니다.
+// 이름 형식, 중복, 명령 템플릿, 스키마 오류가 있으면 에러를 반환합니다.
+func NewCustomToolExecutor(tools []CustomTool) (*CustomToolExecutor, error) {
+	e := &CustomToolExecutor{tools: make(map[string]*preparedCustomTool, len(tools))}
+	for _, tool := range tools {
+		prepared, err := prepareCustomTool(tool)
+		if err != nil {
+			return nil, fmt.Errorf("사용자 정의 도구 %q: %w", tool.Name, err)
+		}
+		if _, dup := e.tools[tool.Name]; dup {
+			return nil, fmt.Errorf("사용자 정의 도구 %q: 이름이 중복되었습니다", tool.Name)
+		}
+		e.tools[tool.Name] = prepared
+		e.names = append(e.names, tool.Name)
+	}
+	sort.Strings(e.names)
+	return e, nil
+}
+
+// prepareCustomTool은 도구 선언을 검증하고 명령 템플릿과 스키마를 파싱합니다.
+func prepareCustomTool(tool CustomTool) (*preparedCustomTool, error) {
+	if !customToolNamePattern.MatchString(tool.Name) {
+		return nil, fmt.Errorf("이름은 소문자로 시작하는 소문자/숫자/밑줄 64자 이내여야 합니다")
+	}
+	if len(tool.Command) == 0 || strings.TrimSpace(tool.Command[0]) == "" {
+		return nil, fmt.Errorf("command가 비어 있습니다")
+	}
+
+	prepared := &preparedCustomTool{def: tool}
+	for i, arg := range tool.Command {
+		tmpl, err := template.New(fmt.Sprintf("%s[%d]", tool.Name, i)).Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("command[%d] 템플릿 파싱 실패: %w", i, err)
+		}
+		prepared.argv = append(prepared.argv, tmpl)
+	}
+
+	properties, required, err := parseCustomToolSchema(tool.InputSchema)
+	if err != nil {
+		return nil, err
+	}
+	prepared.properties = properties
+	prepared.required = required
+	return prepared, nil
+}
+
+// parseCustomToolSchema는 InputSchema의 properties/required를 검증용 선언으로 변환합니다.
+// 명령 인자로 치환할 수 있도록 string, number, integer, boolean 타입만 지원합니다.
+func parseCustomToolSchema(schema map[string]interface{}) (map[string]customToolProperty, []string, error) {
+	properties := make(map[string]customToolProperty)
+	if schema == nil {
+		return properties, nil, nil
+	}
+
+	rawProps, _ := schema["properties"].(map[string]interface{})
+	for name, raw := range rawProps {
+		decl, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("input_schema.properties.%s는 객체여야 합니다", name)
+		}
+		prop := customToolProperty{def: decl["default"]}
+		prop.typ, _ = decl["type"].(string)
+		switch prop.typ {
+		case "string", "number", "integer", "boolean":
+		case "":
+			prop.typ = "string"
+		default:
+			return nil, nil, fmt.Errorf("input_schema.properties.%s: 지원하지 않는 타입 %q (string, number, integer, boolean)", name, prop.typ)
+		}
+		if enum, ok := decl["enum"].([]interface{}); ok {
+			prop.enum = enum
+		}
+		if pattern, ok := decl["pattern"].(string); ok && pattern != "" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, nil, fmt.Errorf("input_schema.properties.%s.pattern 컴파일 실패: %w", name, err)
+			}
+			prop.pattern = re
+		}
+		properties[name] = prop
+	}
+
+	var required []string
+	if rawRequired, ok := schema["required"].([]interface{}); ok {
+		for _, r := range rawRequired {
+			name, _ := r.(string)
+			if _, declared := properties[name]; !declared {
+				return nil, nil, fmt.Errorf("input_schema.required의 %q가 properties에 없습니다", name)
+			}
+			required = append(required, name)
+		}
+	}
+	return properties, required, nil
+}
+
+// Definitions는 agent_connect로 알릴 도구 선언 목록을 이름순으로 반환합니다.
+func (e *CustomToolExecutor) Definitions() []ws.CustomToolDefinition {
+	defs := make([]ws.CustomToolDefinition, 0, len(e.names))
+	for _, name := range e.names {
+		tool := e.tools[name].def
+		defs = append(defs, ws.CustomToolDefinition{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+		})
+	}
+	return defs
+}
+
+// Execute는 인자를 검증하고 도구 명령을 실행한 결과를 반환합니다.
+// 인자 검증이나 실행 준비에 실패하면 명령을 실행하지 않고 ExitCode -1과 Error를 채워 반환합니다.
+func (e *CustomToolExecutor) Execute(ctx context.Context, req ws.CustomToolRequestPayload) ws.CustomToolResultPayload {
+	start := time.Now()
+	result := ws.CustomToolResultPayload{
+		RequestID:     req.RequestID,
+		Tool:          req.Tool,
+		ExitCode:      -1,
+		CorrelationID: req.CorrelationID,
+	}
+
+	tool, ok := e.tools[req.Tool]
+	if !ok {
+		result.Error = fmt.Sprintf("%v: %s", ErrCustomToolNotFound, req.Tool)
+		return result
+	}
+
+	argv, err := tool.render(req.Arguments)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	timeout := tool.def.Timeout
+	if timeout <= 0 {
+		timeout = CustomToolDefaultTimeout
+	}
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, argv[0], argv[1:]...)
+	cmd.Dir = expandTilde(tool.def.WorkDir)
+	if len(tool.def.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range tool.def.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+
+	stdout := &cappedBuffer{limit: customToolMaxOutput}
+	stderr := &cappedBuffer{limit: customToolMaxOutput}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	runErr := procgroup.Run(cmd)
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.StdoutTruncated = stdout.truncated
+	result.StderrTruncated = stderr.truncated
+
+	var exitErr *exec.ExitError
+	switch {
+	case runErr == nil:
+		result.ExitCode = 0
+		result.Success = true
+	case cmdCtx.Err() == context.DeadlineExceeded:
+		result.Error = fmt.Sprintf("도구 실행 타임아웃 (%s 초과)", timeout)
+	case errors.As(runErr, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.Error = fmt.Sprintf("도구 실행 실패: %v", runErr)
+	}
+	return result
+}
+
+// render는 인자를 스키마로 검증한 뒤 명령 템플릿을 치환하여 argv를 만듭니다.
+// 선언되었지만 전달되지 않은 인자는 스키마의 default 또는 빈 문자열로 치환됩니다.
+func (t *preparedCustomTool) render(args map[string]interface{}) ([]string, error) {
+	if err := t.validate(args); err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{}, len(t.properties))
+	for name, prop := range t.properties {
+		switch {
+		case args[name] != nil:
+			data[name] = formatCustomToolArg(prop.typ, args[name])
+		case prop.def != nil:
+			data[name] = formatCustomToolArg(prop.typ, prop.def)
+		default:
+			data[name] = ""
+		}
+	}
+
+	argv := make([]string, 0, len(t.argv))
+	for i, tmpl := range t.argv {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("command[%d] 치환 실패: %w", i, err)
+		}
+		argv = append(argv, buf.String())
+	}
+	return argv, nil
+}
+
+// validate는 인자가 InputSchema의 required/type/enum/pattern을 만족하는지 검사합니다.
+func (t *preparedCustomTool) validate(args map[string]interface{}) error {
+	for _, name := range t.required {
+		if args[name] == nil {
+			return fmt.Errorf("필수 인자 누락: %s", name)
+		}
+	}
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := args[name]
+		prop, ok := t.properties[name]
+		if !ok {
+			return fmt.Errorf("선언되지 않은 인자: %s", name)
+		}
+		if value == nil {
+			continue
+		}
+		if !customToolTypeMatches(prop.typ, value) {
+			return fmt.Errorf("인자 %s는 %s 타입이어야 합니다", name, prop.typ)
+		}
+		if len(prop.enum) > 0 && !customToolEnumContains(prop.typ, prop.enum, value) {
+			return fmt.Errorf("인자 %s의 값 %v는 허용되지 않습니다", name, value)
+		}
+		if prop.pattern != nil && !prop.pattern.MatchString(formatCustomToolArg(prop.typ, value)) {
+			return fmt.Errorf("인자 %s의 값이 패턴 %s와 일치하지 않습니다", name, prop.pattern)
+		}
+	}
+	return nil
+}
+
+// customToolTypeMatches는 JSON 디코딩된 값이 스키마 타입과 일치하는지 확인합니다.
+func customToolTypeMatches(typ string, value interface{}) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	}
+	return false
+}
+
+// customToolEnumContains는 값이 enum 목록에 포함되는지 확인합니다.
+func customToolEnumContains(typ string, enum []interface{}, value interface{}) bool {
+	for _, candidate := range enum {
+		if formatCustomToolArg(typ, candidate) == formatCustomToolArg(typ, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatCustomToolArg는 인자 값을 명령 인자 문자열로 변환합니다.
+// JSON 숫자(float64)는 fmt.Sprint처럼 지수 표기("1e+06")가 되지 않도록 10진수로 적고,
+// integer 타입 인자는 정수로 적습니다.
+func formatCustomToolArg(typ string, value interface{}) string {
+	f, ok := value.(float64)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	if typ == "integer" && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// cappedBuffer는 limit까지만 저장하고 나머지는 버리는 출력 버퍼입니다.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write는 제한 내에서 데이터를 저장하고 초과분은 버립니다. 명령이 멈추지 않도록 항상 성공을 보고합니다.
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if remaining < len(p) {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// String은 저장된 출력을 반환합니다.
+func (b *cappedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,178 @@
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoTool은 인자를 그대로 출력하는 테스트용 사용자 정의 도구입니다.
+func echoTool() CustomTool {
+	return CustomTool{
+		Name:        "echo_args",
+		Description: "Echo arguments",
+		Command:     []string{"echo", "{{.message}}", "--env={{.env}}", "--count={{.count}}"},
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"message": map[string]interface{}{"type": "string"},
+				"env":     map[string]interface{}{"type": "string", "enum": []interface{}{"staging", "prod"}, "default": "staging"},
+				"count":   map[string]interface{}{"type": "integer"},
+				"ticket":  map[string]interface{}{"type": "string", "pattern": "^[A-Z]+-[0-9]+$"},
+			},
+			"required": []interface{}{"message"},
+		},
+	}
+}
+
+func TestNewCustomToolExecutor_RejectsInvalidTools(t *testing.T) {
+	tests := []struct {
+		name string
+		tool CustomTool
+	}{
+		{"invalid name", CustomTool{Name: "Deploy-Preview", Command: []string{"true"}}},
+		{"empty command", CustomTool{Name: "deploy"}},
+		{"bad template", CustomTool{Name: "deploy", Command: []string{"echo", "{{.branch"}}},
+		{"unsupported type", CustomTool{Name: "deploy", Command: []string{"true"}, InputSchema: map[string]interface{}{
+			"properties": map[string]interface{}{"files": map[string]interface{}{"type": "array"}},
+		}}},
+		{"undeclared required", CustomTool{Name: "deploy", Command: []string{"true"}, InputSchema: map[string]interface{}{
+			"required": []interface{}{"branch"},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCustomToolExecutor([]CustomTool{tt.tool})
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewCustomToolExecutor([]CustomTool{echoTool(), echoTool()})
+	assert.Error(t, err, "중복 이름은 거부되어야 합니다")
+}
+
+func TestCustomToolExecutor_Definitions(t *testing.T) {
+	e, err := NewCustomToolExecutor([]CustomTool{
+		{Name: "zeta", Command: []string{"true"}},
+		echoTool(),
+	})
+	require.NoError(t, err)
+
+	defs := e.Definitions()
+	require.Len(t, defs, 2)
+	assert.Equal(t, "echo_args", defs[0].Name)
+	assert.Equal(t, "Echo arguments", defs[0].Description)
+	assert.NotNil(t, defs[0].InputSchema)
+	assert.Equal(t, "zeta", defs[1].Name)
+}
+
+func TestCustomToolExecutor_ExecuteRendersArgsWithoutShell(t *testing.T) {
+	e, err := NewCustomToolExecutor([]CustomTool{echoTool()})
+	require.NoError(t, err)
+
+	result := e.Execute(context.Background(), ws.CustomToolRequestPayload{
+		RequestID: "req-1",
+		Tool:      "echo_args",
+		Arguments: map[string]interface{}{"message": "hi; echo pwned $(id)", "count": float64(3)},
+	})
+
+	assert.True(t, result.Success, result.Error)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, "req-1", result.RequestID)
+	assert.Equal(t, "hi; echo pwned $(id) --env=staging --count=3\n", result.Stdout, "인자는 셸로 해석되지 않아야 합니다")
+}
+
+func TestFormatCustomToolArg(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value interface{}
+		want  string
+	}{
+		{"integer", float64(1000000), "1000000"},
+		{"integer", float64(123456789), "123456789"},
+		{"integer", float64(-42), "-42"},
+		{"number", float64(1000000), "1000000"},
+		{"number", 123456789.5, "123456789.5"},
+		{"number", 1e21, "1000000000000000000000"},
+		{"number", 0.000001, "0.000001"},
+		{"string", "1e+06", "1e+06"},
+		{"boolean", true, "true"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, formatCustomToolArg(tt.typ, tt.value), "%s %v", tt.typ, tt.value)
+	}
+}
+
+func TestCustomToolExecutor_ExecuteRendersLargeNumbers(t *testing.T) {
+	tool := echoTool()
+	tool.InputSchema["properties"].(map[string]interface{})["count"] = map[string]interface{}{"type": "integer", "enum": []interface{}{float64(1000000), float64(2000000)}}
+	e, err := NewCustomToolExecutor([]CustomTool{tool})
+	require.NoError(t, err)
+
+	result := e.Execute(context.Background(), ws.CustomToolRequestPayload{
+		Tool:      "echo_args",
+		Arguments: map[string]interface{}{"message": "hi", "count": float64(1000000)},
+	})
+	assert.True(t, result.Success, result.Error)
+	assert.Equal(t, "hi --env=staging --count=1000000\n", result.Stdout, "숫자 인자가 지수 표기로 바뀌면 안 됩니다")
+}
+
+func TestCustomToolExecutor_ExecuteValidatesArgs(t *testing.T) {
+	e, err := NewCustomToolExecutor([]CustomTool{echoTool()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+	}{
+		{"missing required", map[string]interface{}{}},
+		{"undeclared", map[string]interface{}{"message": "hi", "extra": "x"}},
+		{"wrong type", map[string]interface{}{"message": 42.0}},
+		{"not integer", map[string]interface{}{"message": "hi", "count": 1.5}},
+		{"enum", map[string]interface{}{"message": "hi", "env": "dev"}},
+		{"pattern", map[string]interface{}{"message": "hi", "ticket": "--force"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := e.Execute(context.Background(), ws.CustomToolRequestPayload{Tool: "echo_args", Arguments: tt.args})
+			assert.False(t, result.Success)
+			assert.Equal(t, -1, result.ExitCode)
+			assert.NotEmpty(t, result.Error)
+			assert.Empty(t, result.Stdout, "검증 실패 시 명령이 실행되면 안 됩니다")
+		})
+	}
+}
+
+func TestCustomToolExecutor_ExecuteExitCodeAndTimeout(t *testing.T) {
+	e, err := NewCustomToolExecutor([]CustomTool{
+		{Name: "fail", Command: []string{"sh", "-c", "echo oops >&2; exit 3"}},
+		{Name: "slow", Command: []string{"sleep", "5"}, Timeout: 100 * time.Millisecond},
+	})
+	require.NoError(t, err)
+
+	result := e.Execute(context.Background(), ws.CustomToolRequestPayload{Tool: "fail"})
+	assert.False(t, result.Success)
+	assert.Equal(t, 3, result.ExitCode)
+	assert.Equal(t, "oops\n", result.Stderr)
+
+	result = e.Execute(context.Background(), ws.CustomToolRequestPayload{Tool: "slow"})
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "타임아웃")
+
+	result = e.Execute(context.Background(), ws.CustomToolRequestPayload{Tool: "missing"})
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, ErrCustomToolNotFound.Error())
+}
+
+func TestCappedBuffer(t *testing.T) {
+	b := &cappedBuffer{limit: 4}
+	n, err := b.Write([]byte("abcdef"))
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, "abcd", b.String())
+	assert.True(t, b.truncated)
+}
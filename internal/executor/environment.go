@@ -0,0 +1,308 @@
+// Package executor는 Local Agent Bridge의 작업 실행을 담당합니다.
+// 작업/빌드 결과에 첨부할 실행 환경 스냅샷을 수집합니다.
+package executor
+
+import (
+	"bufio"
+	"context"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+const (
+	// DefaultEnvironmentTTL은 정적 환경 정보(OS, 도구/프로바이더 버전) 캐시 유지 시간입니다.
+	DefaultEnvironmentTTL = 10 * time.Minute
+	// DefaultEnvironmentGitTTL은 work_dir별 git 상태 캐시 유지 시간입니다.
+	DefaultEnvironmentGitTTL = 5 * time.Second
+	// envCommandTimeout은 버전 확인 명령 하나의 최대 실행 시간입니다.
+	envCommandTimeout = 3 * time.Second
+)
+
+// DefaultEnvironmentTools는 버전을 기록할 기본 도구 목록입니다.
+var DefaultEnvironmentTools = []string{"git", "go", "node", "npm", "python3", "docker"}
+
+// EnvironmentCollectorConfig는 환경 스냅샷 수집 설정입니다.
+type EnvironmentCollectorConfig struct {
+	// BridgeVersion은 스냅샷에 기록할 Bridge 버전입니다.
+	BridgeVersion string
+	// Tools는 버전을 기록할 도구 목록입니다. nil이면 DefaultEnvironmentTools를 사용합니다.
+	Tools []string
+	// ProviderCLIs는 프로바이더 이름별 CLI 경로입니다 (예: "claude": "/usr/local/bin/claude").
+	ProviderCLIs map[string]string
+	// TTL은 정적 환경 정보 캐시 유지 시간입니다. 0이면 DefaultEnvironmentTTL을 사용합니다.
+	TTL time.Duration
+	// GitTTL은 git 상태 캐시 유지 시간입니다. 0이면 DefaultEnvironmentGitTTL을 사용합니다.
+	GitTTL time.Duration
+}
+
+// envCommandFunc는 명령을 실행하고 표준 출력을 반환합니다 (테스트에서 교체).
+type envCommandFunc func(ctx context.Context, dir, name string, args ...string) (string, error)
+
+// EnvironmentCollector는 작업 결과에 첨부할 환경 스냅샷을 수집합니다.
+// 도구/프로바이더 버전처럼 느리게 바뀌는 정보는 TTL 동안 캐시하고,
+// git 상태는 work_dir별로 짧게 캐시하여 작업마다 외부 명령을 반복 실행하지 않습니다.
+type EnvironmentCollector struct {
+	cfg EnvironmentCollectorConfig
+	run envCommandFunc
+	now func() time.Time
+
+	staticMu sync.Mutex
+	static   *ws.EnvironmentSnapshot
+
+	gitMu sync.Mutex
+	git   map[string]gitSnapshotEntry
+}
+
+// gitSnapshotEntry는 work_dir별 git 상태 캐시 항목입니다.
+type gitSnapshotEntry struct {
+	snapshot    *ws.GitSnapshot
+	collectedAt time.Time
+}
+
+// NewEnvironmentCollector는 새 환경 스냅샷 수집기를 생성합니다.
+func NewEnvironmentCollector(cfg EnvironmentCollectorConfig) *EnvironmentCollector {
+	if cfg.Tools == nil {
+		cfg.Tools = DefaultEnvironmentTools
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultEnvironmentTTL
+	}
+	if cfg.GitTTL <= 0 {
+		cfg.GitTTL = DefaultEnvironmentGitTTL
+	}
+	return &EnvironmentCollector{
+		cfg: cfg,
+		run: runEnvCommand,
+		now: time.Now,
+		git: make(map[string]gitSnapshotEntry),
+	}
+}
+
+// Warm은 정적 환경 정보를 미리 수집합니다.
+// 시작 시 백그라운드로 호출하면 첫 작업 결과 전송이 버전 확인으로 지연되지 않습니다.
+func (c *EnvironmentCollector) Warm(ctx context.Context) {
+	c.staticSnapshot(ctx)
+}
+
+// Snapshot은 workDir 기준 환경 스냅샷을 반환합니다.
+// 반환값은 호출자가 수정해도 캐시에 영향을 주지 않는 복사본입니다.
+func (c *EnvironmentCollector) Snapshot(ctx context.Context, workDir string) *ws.EnvironmentSnapshot {
+	static := c.staticSnapshot(ctx)
+
+	snapshot := *static
+	snapshot.Tools = maps.Clone(static.Tools)
+	snapshot.Providers = maps.Clone(static.Providers)
+	if workDir != "" {
+		snapshot.Git = c.gitSnapshot(ctx, workDir)
+	}
+	return &snapshot
+}
+
+// staticSnapshot은 캐시된 정적 환경 정보를 반환하고, 만료되었으면 다시 수집합니다.
+func (c *EnvironmentCollector) staticSnapshot(ctx context.Context) *ws.EnvironmentSnapshot {
+	c.staticMu.Lock()
+	defer c.staticMu.Unlock()
+
+	if c.static != nil && c.now().Sub(c.static.CollectedAt) < c.cfg.TTL {
+		return c.static
+	}
+
+	snapshot := &ws.EnvironmentSnapshot{
+		OS:            runtime.GOOS,
+		OSVersion:     c.osVersion(ctx),
+		Arch:          runtime.GOARCH,
+		CPUs:          runtime.NumCPU(),
+		MemoryTotalMB: c.memoryTotalMB(ctx),
+		BridgeVersion: c.cfg.BridgeVersion,
+		CollectedAt:   c.now(),
+	}
+
+	for _, tool := range c.cfg.Tools {
+		if version := c.commandVersion(ctx, tool); version != "" {
+			if snapshot.Tools == nil {
+				snapshot.Tools = make(map[string]string)
+			}
+			snapshot.Tools[tool] = version
+		}
+	}
+	for name, cliPath := range c.cfg.ProviderCLIs {
+		if version := c.commandVersion(ctx, cliPath); version != "" {
+			if snapshot.Providers == nil {
+				snapshot.Providers = make(map[string]string)
+			}
+			snapshot.Providers[name] = version
+		}
+	}
+
+	c.static = snapshot
+	return snapshot
+}
+
+// gitSnapshot은 workDir의 git 상태를 반환합니다. git 저장소가 아니면 nil입니다.
+func (c *EnvironmentCollector) gitSnapshot(ctx context.Context, workDir string) *ws.GitSnapshot {
+	c.gitMu.Lock()
+	entry, ok := c.git[workDir]
+	c.gitMu.Unlock()
+	if ok && c.now().Sub(entry.collectedAt) < c.cfg.GitTTL {
+		return cloneGitSnapshot(entry.snapshot)
+	}
+
+	snapshot := c.collectGit(ctx, workDir)
+
+	c.gitMu.Lock()
+	c.git[workDir] = gitSnapshotEntry{snapshot: snapshot, collectedAt: c.now()}
+	c.gitMu.Unlock()
+	return cloneGitSnapshot(snapshot)
+}
+
+// collectGit은 git 명령으로 HEAD SHA, 브랜치, 변경 여부를 확인합니다.
+// 추적되지 않은 파일은 빌드 산출물일 수 있으므로 dirty 판정에서 제외합니다.
+func (c *EnvironmentCollector) collectGit(ctx context.Context, workDir string) *ws.GitSnapshot {
+	sha, err := c.runWithTimeout(ctx, workDir, "git", "rev-parse", "HEAD")
+	if err != nil || sha == "" {
+		return nil
+	}
+	snapshot := &ws.GitSnapshot{SHA: sha}
+	if branch, err := c.runWithTimeout(ctx, workDir, "git", "rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		snapshot.Branch = branch
+	}
+	if status, err := c.runWithTimeout(ctx, workDir, "git", "status", "--porcelain", "--untracked-files=no"); err == nil {
+		snapshot.Dirty = status != ""
+	}
+	return snapshot
+}
+
+// commandVersion은 도구의 버전 문자열(첫 줄)을 반환합니다. 설치되지 않았으면 빈 문자열입니다.
+func (c *EnvironmentCollector) commandVersion(ctx context.Context, name string) string {
+	args := []string{"--version"}
+	if strings.TrimSuffix(baseCommandName(name), ".exe") == "go" {
+		args = []string{"version"} // go는 --version을 지원하지 않음
+	}
+	out, err := c.runWithTimeout(ctx, "", name, args...)
+	if err != nil {
+		return ""
+	}
+	return firstLine(out)
+}
+
+// osVersion은 OS 배포판/버전을 반환합니다. 확인할 수 없으면 빈 문자열입니다.
+func (c *EnvironmentCollector) osVersion(ctx context.Context) string {
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile("/etc/os-release")
+		if err != nil {
+			return ""
+		}
+		return parseOSRelease(string(data))
+	case "darwin":
+		out, err := c.runWithTimeout(ctx, "", "sw_vers", "-productVersion")
+		if err != nil || out == "" {
+			return ""
+		}
+		return "macOS " + out
+	}
+	return ""
+}
+
+// memoryTotalMB는 전체 메모리 크기(MB)를 반환합니다. 확인할 수 없으면 0입니다.
+func (c *EnvironmentCollector) memoryTotalMB(ctx context.Context) int64 {
+	switch runtime.GOOS {
+	case "linux":
+		data, err := os.ReadFile("/proc/meminfo")
+		if err != nil {
+			return 0
+		}
+		return parseMemInfoTotalMB(string(data))
+	case "darwin":
+		out, err := c.runWithTimeout(ctx, "", "sysctl", "-n", "hw.memsize")
+		if err != nil {
+			return 0
+		}
+		bytes, err := strconv.ParseInt(out, 10, 64)
+		if err != nil {
+			return 0
+		}
+		return bytes >> 20
+	}
+	return 0
+}
+
+// runWithTimeout은 envCommandTimeout 안에서 명령을 실행하고 앞뒤 공백을 제거한 출력을 반환합니다.
+func (c *EnvironmentCollector) runWithTimeout(ctx context.Context, dir, name string, args ...string) (string, error) {
+	cmdCtx, cancel := context.WithTimeout(ctx, envCommandTimeout)
+	defer cancel()
+	out, err := c.run(cmdCtx, dir, name, args...)
+	return strings.TrimSpace(out), err
+}
+
+// runEnvCommand는 셸 없이 명령을 실행하고 표준 출력을 반환합니다.
+func runEnvCommand(ctx context.Context, dir, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...) //nolint:gosec // 설정된 도구/CLI 경로만 실행
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// parseOSRelease는 /etc/os-release에서 PRETTY_NAME(없으면 NAME VERSION_ID)을 추출합니다.
+func parseOSRelease(content string) string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		values[key] = strings.Trim(value, `"'`)
+	}
+	if pretty := values["PRETTY_NAME"]; pretty != "" {
+		return pretty
+	}
+	return strings.TrimSpace(values["NAME"] + " " + values["VERSION_ID"])
+}
+
+// parseMemInfoTotalMB는 /proc/meminfo의 MemTotal(kB)을 MB로 변환합니다.
+func parseMemInfoTotalMB(content string) int64 {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kb >> 10
+		}
+	}
+	return 0
+}
+
+// firstLine은 출력의 첫 줄을 반환합니다.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(line)
+}
+
+// baseCommandName은 경로를 제외한 명령 이름을 반환합니다.
+func baseCommandName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// cloneGitSnapshot은 git 스냅샷 복사본을 반환합니다.
+func cloneGitSnapshot(s *ws.GitSnapshot) *ws.GitSnapshot {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	return &clone
+}
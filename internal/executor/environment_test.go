@@ -0,0 +1,144 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEnvCommands records invocations and returns canned outputs keyed by "name args...".
+type fakeEnvCommands struct {
+	mu      sync.Mutex
+	outputs map[string]string
+	calls   map[string]int
+}
+
+func (f *fakeEnvCommands) run(_ context.Context, _ string, name string, args ...string) (string, error) {
+	key := strings.Join(append([]string{name}, args...), " ")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[key]++
+	out, ok := f.outputs[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return out, nil
+}
+
+func (f *fakeEnvCommands) count(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[key]
+}
+
+func newTestEnvironmentCollector(outputs map[string]string) (*EnvironmentCollector, *fakeEnvCommands, *time.Time) {
+	fake := &fakeEnvCommands{outputs: outputs, calls: make(map[string]int)}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewEnvironmentCollector(EnvironmentCollectorConfig{
+		BridgeVersion: "1.2.3",
+		Tools:         []string{"go", "node", "rustc"},
+		ProviderCLIs:  map[string]string{"claude": "/opt/bin/claude"},
+		TTL:           time.Minute,
+		GitTTL:        time.Second,
+	})
+	c.run = fake.run
+	c.now = func() time.Time { return now }
+	return c, fake, &now
+}
+
+func TestEnvironmentCollector_Snapshot(t *testing.T) {
+	c, _, _ := newTestEnvironmentCollector(map[string]string{
+		"go version":                                  "go version go1.25.0 linux/amd64\n",
+		"node --version":                              "v22.1.0\n",
+		"/opt/bin/claude --version":                   "2.0.14 (Claude Code)\n",
+		"git rev-parse HEAD":                          "abc123\n",
+		"git rev-parse --abbrev-ref HEAD":             "main\n",
+		"git status --porcelain --untracked-files=no": " M main.go\n",
+	})
+
+	snap := c.Snapshot(context.Background(), "/repo")
+	require.NotNil(t, snap)
+	assert.Equal(t, "1.2.3", snap.BridgeVersion)
+	assert.NotEmpty(t, snap.OS)
+	assert.NotEmpty(t, snap.Arch)
+	assert.Positive(t, snap.CPUs)
+	assert.Equal(t, map[string]string{"go": "go version go1.25.0 linux/amd64", "node": "v22.1.0"}, snap.Tools,
+		"tools that are not installed should be omitted")
+	assert.Equal(t, map[string]string{"claude": "2.0.14 (Claude Code)"}, snap.Providers)
+	assert.Equal(t, &ws.GitSnapshot{SHA: "abc123", Branch: "main", Dirty: true}, snap.Git)
+}
+
+func TestEnvironmentCollector_NotAGitRepo(t *testing.T) {
+	c, _, _ := newTestEnvironmentCollector(nil)
+
+	snap := c.Snapshot(context.Background(), "/tmp/plain")
+	assert.Nil(t, snap.Git)
+	assert.Nil(t, snap.Tools)
+	assert.Nil(t, snap.Providers)
+}
+
+func TestEnvironmentCollector_Caching(t *testing.T) {
+	c, fake, now := newTestEnvironmentCollector(map[string]string{
+		"node --version":     "v22.1.0",
+		"git rev-parse HEAD": "abc123",
+	})
+	ctx := context.Background()
+
+	first := c.Snapshot(ctx, "/repo")
+	first.Tools["node"] = "mutated"
+	first.Git.SHA = "mutated"
+
+	second := c.Snapshot(ctx, "/repo")
+	assert.Equal(t, "v22.1.0", second.Tools["node"], "snapshots must not share cached maps")
+	assert.Equal(t, "abc123", second.Git.SHA, "snapshots must not share cached git state")
+	assert.Equal(t, 1, fake.count("node --version"), "static info should be cached within TTL")
+	assert.Equal(t, 1, fake.count("git rev-parse HEAD"), "git state should be cached within GitTTL")
+
+	*now = now.Add(2 * time.Second)
+	c.Snapshot(ctx, "/repo")
+	assert.Equal(t, 1, fake.count("node --version"))
+	assert.Equal(t, 2, fake.count("git rev-parse HEAD"), "git state should be refreshed after GitTTL")
+
+	*now = now.Add(time.Minute)
+	c.Snapshot(ctx, "/repo")
+	assert.Equal(t, 2, fake.count("node --version"), "static info should be refreshed after TTL")
+}
+
+func TestParseOSRelease(t *testing.T) {
+	assert.Equal(t, "Ubuntu 24.04.1 LTS", parseOSRelease("NAME=\"Ubuntu\"\nVERSION_ID=\"24.04\"\nPRETTY_NAME=\"Ubuntu 24.04.1 LTS\"\n"))
+	assert.Equal(t, "Alpine 3.20", parseOSRelease("NAME=Alpine\nVERSION_ID=3.20\n"))
+	assert.Empty(t, parseOSRelease(""))
+}
+
+func TestParseMemInfoTotalMB(t *testing.T) {
+	assert.Equal(t, int64(16000), parseMemInfoTotalMB("MemTotal:       16384000 kB\nMemFree:         1000 kB\n"))
+	assert.Zero(t, parseMemInfoTotalMB("MemFree: 1000 kB\n"))
+}
+
+func TestBuildExecutor_AttachesEnvironment(t *testing.T) {
+	c, _, _ := newTestEnvironmentCollector(map[string]string{"node --version": "v22.1.0"})
+	e := NewBuildExecutor(WithBuildEnvironmentCollector(c))
+
+	result := e.Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     t.TempDir(),
+		Command:     "true",
+	})
+	require.True(t, result.Success)
+	require.NotNil(t, result.Environment)
+	assert.Equal(t, "v22.1.0", result.Environment.Tools["node"])
+
+	result = NewBuildExecutor().Execute(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-2",
+		WorkDir:     t.TempDir(),
+		Command:     "true",
+	})
+	assert.Nil(t, result.Environment, "no snapshot without a collector")
+}
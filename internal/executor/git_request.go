@@ -0,0 +1,556 @@
+// Package executor는 Local Agent Bridge의 작업 실행 엔진을 제공합니다.
+// git_request 메시지로 요청된 git 작업을 설정된 작업 디렉토리 안에서 실행합니다.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+// GitFetchTimeout은 git fetch 작업의 타임아웃입니다 (2분).
+const GitFetchTimeout = 2 * time.Minute
+
+// git credential helper가 자격 증명을 읽는 환경 변수 이름.
+// 토큰이 프로세스 인자(ps)에 노출되지 않도록 환경 변수로만 전달합니다.
+const (
+	gitCredentialUsernameEnv = "AUTOPUS_GIT_USERNAME"
+	gitCredentialPasswordEnv = "AUTOPUS_GIT_PASSWORD"
+)
+
+// gitCredentialHelper는 환경 변수의 자격 증명을 git credential 프로토콜로 출력하는 helper입니다.
+const gitCredentialHelper = `!f() { test "$1" = get || exit 0; echo "username=$` + gitCredentialUsernameEnv + `"; echo "password=$` + gitCredentialPasswordEnv + `"; }; f`
+
+// GitCredential은 원격 저장소 인증 정보입니다.
+type GitCredential struct {
+	// Username은 사용자 이름입니다. 토큰 인증에서는 임의 값이어도 됩니다.
+	Username string
+	// Password는 비밀번호 또는 액세스 토큰입니다.
+	Password string
+}
+
+// GitCredentialProvider는 원격 호스트별 git 자격 증명을 제공하는 시크릿 공급자입니다.
+// 자격 증명이 없으면 ok=false를 반환합니다.
+type GitCredentialProvider interface {
+	GitCredential(ctx context.Context, host string) (cred GitCredential, ok bool, err error)
+}
+
+// EnvGitCredentialProvider는 환경 변수에서 git 자격 증명을 읽는 기본 공급자입니다.
+// github.com은 GITHUB_TOKEN/GH_TOKEN, gitlab.com은 GITLAB_TOKEN을 사용하고,
+// AUTOPUS_GIT_TOKEN은 tokenHosts에 나열된 호스트에만 전달합니다.
+type EnvGitCredentialProvider struct {
+	// tokenHosts는 AUTOPUS_GIT_TOKEN을 전달할 호스트 목록입니다.
+	tokenHosts []string
+	// lookupEnv는 테스트에서 환경 변수를 대체하기 위한 함수입니다.
+	lookupEnv func(string) string
+}
+
+// NewEnvGitCredentialProvider는 환경 변수 기반 자격 증명 공급자를 생성합니다.
+// tokenHosts가 비어있으면 AUTOPUS_GIT_TOKEN은 어떤 호스트에도 전달하지 않습니다.
+func NewEnvGitCredentialProvider(tokenHosts []string) *EnvGitCredentialProvider {
+	return &EnvGitCredentialProvider{tokenHosts: tokenHosts, lookupEnv: os.Getenv}
+}
+
+// GitCredential은 호스트에 맞는 환경 변수 토큰을 반환합니다.
+func (p *EnvGitCredentialProvider) GitCredential(_ context.Context, host string) (GitCredential, bool, error) {
+	var names []string
+	switch strings.ToLower(host) {
+	case "github.com":
+		names = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+	case "gitlab.com":
+		names = []string{"GITLAB_TOKEN"}
+	}
+	if slices.ContainsFunc(p.tokenHosts, func(h string) bool { return strings.EqualFold(h, host) }) {
+		names = append(names, "AUTOPUS_GIT_TOKEN")
+	}
+
+	for _, name := range names {
+		if token := p.lookupEnv(name); token != "" {
+			return GitCredential{Username: "x-access-token", Password: token}, true, nil
+		}
+	}
+	return GitCredential{}, false, nil
+}
+
+// GitRequestExecutorConfig는 GitRequestExecutor 설정입니다.
+type GitRequestExecutorConfig struct {
+	// WorkDir는 git 작업이 허용되는 루트 디렉토리입니다.
+	// 비어있으면 ~/.autopus/workspaces를 사용합니다.
+	WorkDir string
+	// GitUserName은 커밋 시 사용할 git 사용자 이름입니다.
+	GitUserName string
+	// GitUserEmail은 커밋 시 사용할 git 사용자 이메일입니다.
+	GitUserEmail string
+	// Credentials는 clone/fetch/push 인증에 사용할 자격 증명 공급자입니다 (nil이면 인증 없음).
+	Credentials GitCredentialProvider
+}
+
+// GitRequestExecutor는 서버의 git_request를 작업 디렉토리 안에서 실행합니다.
+// 레포 경로와 커밋 대상 파일은 작업 디렉토리 밖을 가리킬 수 없으며,
+// push는 GitExecutor와 동일한 브랜치 정책(agent/ 프리픽스)을 따릅니다.
+type GitRequestExecutor struct {
+	cfg     GitRequestExecutorConfig
+	policy  *GitExecutor
+	scanner *SecretScanner
+}
+
+// NewGitRequestExecutor는 새로운 GitRequestExecutor를 생성합니다.
+func NewGitRequestExecutor(cfg GitRequestExecutorConfig) *GitRequestExecutor {
+	if cfg.WorkDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = os.TempDir()
+		}
+		cfg.WorkDir = filepath.Join(home, ".autopus", "workspaces")
+	}
+	cfg.WorkDir = filepath.Clean(expandTilde(cfg.WorkDir))
+
+	return &GitRequestExecutor{
+		cfg: cfg,
+		policy: NewGitExecutor(GitExecutorConfig{
+			GitUserName:  cfg.GitUserName,
+			GitUserEmail: cfg.GitUserEmail,
+		}),
+		scanner: NewSecretScanner(),
+	}
+}
+
+// WorkDir는 git 작업이 허용되는 루트 디렉토리를 반환합니다.
+func (e *GitRequestExecutor) WorkDir() string {
+	return e.cfg.WorkDir
+}
+
+// Execute는 git 작업을 실행하고 구조화된 결과를 반환합니다.
+// 실패 시에도 result의 RequestID/Operation/Error가 채워진 상태로 반환됩니다.
+func (e *GitRequestExecutor) Execute(ctx context.Context, req ws.GitRequestPayload) (ws.GitResultPayload, error) {
+	result := ws.GitResultPayload{
+		RequestID:     req.RequestID,
+		Operation:     req.Operation,
+		Repo:          req.Repo,
+		CorrelationID: req.CorrelationID,
+	}
+
+	fail := func(err error) (ws.GitResultPayload, error) {
+		result.Success = false
+		result.Error = err.Error()
+		return result, err
+	}
+
+	if req.Operation == ws.GitOpClone && req.Repo == "" {
+		req.Repo = repoNameFromURL(req.RepoURL)
+		result.Repo = req.Repo
+	}
+
+	repoDir, err := e.resolveRepoDir(req.Repo)
+	if err != nil {
+		return fail(err)
+	}
+
+	switch req.Operation {
+	case ws.GitOpClone:
+		err = e.clone(ctx, repoDir, req)
+	case ws.GitOpFetch:
+		err = e.fetch(ctx, repoDir, req)
+	case ws.GitOpCheckout:
+		err = e.checkout(ctx, repoDir, req)
+	case ws.GitOpBranch:
+		err = e.branch(ctx, repoDir, req)
+	case ws.GitOpCommit:
+		result.ChangedFiles, err = e.commit(ctx, repoDir, req)
+	case ws.GitOpPush:
+		err = e.push(ctx, repoDir, req)
+	default:
+		err = fmt.Errorf("지원하지 않는 git 작업입니다: %q", req.Operation)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Success = true
+	if branch, branchErr := runGitCommandOutput(ctx, repoDir, "rev-parse", "--abbrev-ref", "HEAD"); branchErr == nil {
+		result.Branch = strings.TrimSpace(branch)
+	}
+	if sha, shaErr := runGitCommandOutput(ctx, repoDir, "rev-parse", "HEAD"); shaErr == nil {
+		result.CommitSHA = strings.TrimSpace(sha)
+	}
+	return result, nil
+}
+
+// clone은 원격 레포지토리를 작업 디렉토리 안에 clone합니다.
+func (e *GitRequestExecutor) clone(ctx context.Context, repoDir string, req ws.GitRequestPayload) error {
+	host, err := validateRemoteURL(req.RepoURL)
+	if err != nil {
+		return err
+	}
+	if _, statErr := os.Stat(repoDir); statErr == nil {
+		return fmt.Errorf("clone 대상 디렉토리가 이미 존재합니다: %s", req.Repo)
+	}
+	if err := os.MkdirAll(filepath.Dir(repoDir), 0750); err != nil {
+		return fmt.Errorf("clone 디렉토리 생성 실패: %w", err)
+	}
+
+	cloneCtx, cancel := context.WithTimeout(ctx, GitCloneTimeout)
+	defer cancel()
+
+	args := []string{"clone"}
+	if req.Branch != "" {
+		if err := validateRefName(req.Branch); err != nil {
+			return err
+		}
+		args = append(args, "--branch", req.Branch)
+	}
+	args = append(args, "--", req.RepoURL, repoDir)
+	if err := e.runAuthenticated(cloneCtx, "", host, args...); err != nil {
+		return fmt.Errorf("git clone 실패 (repo=%s): %w", req.RepoURL, err)
+	}
+	return nil
+}
+
+// fetch는 원격의 최신 참조를 가져옵니다.
+func (e *GitRequestExecutor) fetch(ctx context.Context, repoDir string, req ws.GitRequestPayload) error {
+	if err := requireGitRepo(repoDir, req.Repo); err != nil {
+		return err
+	}
+	remote := remoteName(req.Remote)
+	host, err := e.remoteHost(ctx, repoDir, remote)
+	if err != nil {
+		return err
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, GitFetchTimeout)
+	defer cancel()
+
+	if err := e.runAuthenticated(fetchCtx, repoDir, host, "fetch", "--prune", remote); err != nil {
+		return fmt.Errorf("git fetch 실패 (remote=%s): %w", remote, err)
+	}
+	return nil
+}
+
+// checkout은 기존 브랜치로 전환합니다.
+func (e *GitRequestExecutor) checkout(ctx context.Context, repoDir string, req ws.GitRequestPayload) error {
+	if err := requireGitRepo(repoDir, req.Repo); err != nil {
+		return err
+	}
+	if err := validateRefName(req.Branch); err != nil {
+		return err
+	}
+	if err := runGitCommand(ctx, repoDir, "checkout", req.Branch, "--"); err != nil {
+		return fmt.Errorf("git checkout 실패 (branch=%s): %w", req.Branch, err)
+	}
+	return nil
+}
+
+// branch는 새 브랜치를 생성하고 전환합니다.
+func (e *GitRequestExecutor) branch(ctx context.Context, repoDir string, req ws.GitRequestPayload) error {
+	if err := requireGitRepo(repoDir, req.Repo); err != nil {
+		return err
+	}
+	if err := validateRefName(req.Branch); err != nil {
+		return err
+	}
+
+	args := []string{"checkout", "-b", req.Branch}
+	if req.StartPoint != "" {
+		if err := validateRefName(req.StartPoint); err != nil {
+			return err
+		}
+		args = append(args, req.StartPoint)
+	}
+	args = append(args, "--")
+	if err := runGitCommand(ctx, repoDir, args...); err != nil {
+		return fmt.Errorf("브랜치 생성 실패 (branch=%s): %w", req.Branch, err)
+	}
+	return nil
+}
+
+// commit은 변경 파일을 스테이징하고 커밋한 뒤 커밋에 포함된 파일 목록을 반환합니다.
+func (e *GitRequestExecutor) commit(ctx context.Context, repoDir string, req ws.GitRequestPayload) ([]string, error) {
+	if err := requireGitRepo(repoDir, req.Repo); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Message) == "" {
+		return nil, fmt.Errorf("commit 메시지가 필요합니다")
+	}
+
+	addArgs := []string{"add", "--all", "--"}
+	if len(req.Files) == 0 {
+		addArgs = append(addArgs, ".")
+	} else {
+		for _, f := range req.Files {
+			rel, err := confineRelativePath(repoDir, f)
+			if err != nil {
+				return nil, err
+			}
+			addArgs = append(addArgs, rel)
+		}
+	}
+	if err := runGitCommand(ctx, repoDir, addArgs...); err != nil {
+		return nil, fmt.Errorf("git add 실패: %w", err)
+	}
+
+	staged, err := runGitCommandOutput(ctx, repoDir, "diff", "--cached", "--name-only")
+	if err != nil {
+		return nil, fmt.Errorf("스테이징된 파일 조회 실패: %w", err)
+	}
+	changed := splitLines(staged)
+	if len(changed) == 0 {
+		return nil, fmt.Errorf("커밋할 변경 사항이 없습니다")
+	}
+
+	// REQ-006.4: 커밋 전 시크릿 파일 검사
+	if violations := e.scanner.ScanFileList(changed); len(violations) > 0 {
+		paths := make([]string, 0, len(violations))
+		for _, v := range violations {
+			paths = append(paths, v.Path)
+		}
+		_ = runGitCommand(ctx, repoDir, append([]string{"reset", "--quiet", "--"}, changed...)...)
+		return nil, fmt.Errorf("보안 정책 위반 — 차단된 파일 포함: %s", strings.Join(paths, ", "))
+	}
+
+	name, email := e.commitIdentity()
+	if err := runGitCommand(ctx, repoDir,
+		"-c", "user.name="+name,
+		"-c", "user.email="+email,
+		"commit", "-m", req.Message,
+	); err != nil {
+		return nil, fmt.Errorf("git commit 실패: %w", err)
+	}
+	return changed, nil
+}
+
+// push는 브랜치 정책을 확인한 뒤 원격에 push합니다.
+func (e *GitRequestExecutor) push(ctx context.Context, repoDir string, req ws.GitRequestPayload) error {
+	if err := requireGitRepo(repoDir, req.Repo); err != nil {
+		return err
+	}
+
+	branch := req.Branch
+	if branch == "" {
+		current, err := runGitCommandOutput(ctx, repoDir, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return fmt.Errorf("현재 브랜치 확인 실패: %w", err)
+		}
+		branch = strings.TrimSpace(current)
+	}
+	if err := validateRefName(branch); err != nil {
+		return err
+	}
+	if err := e.policy.validatePushBranch(branch); err != nil {
+		return err
+	}
+
+	remote := remoteName(req.Remote)
+	host, err := e.remoteHost(ctx, repoDir, remote)
+	if err != nil {
+		return err
+	}
+
+	pushCtx, cancel := context.WithTimeout(ctx, GitPushTimeout)
+	defer cancel()
+
+	if err := e.runAuthenticated(pushCtx, repoDir, host, "push", "-u", remote, branch); err != nil {
+		return fmt.Errorf("git push 실패 (branch=%s): %w", branch, err)
+	}
+	return nil
+}
+
+// resolveRepoDir는 레포 상대 경로를 작업 디렉토리 안의 절대 경로로 변환합니다.
+// 절대 경로, 상위 디렉토리 이동, 작업 디렉토리 밖을 가리키는 심볼릭 링크는 거부합니다.
+func (e *GitRequestExecutor) resolveRepoDir(repo string) (string, error) {
+	if repo == "" {
+		return "", fmt.Errorf("repo 경로가 필요합니다")
+	}
+	rel, err := confineRelativePath(e.cfg.WorkDir, repo)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return "", fmt.Errorf("repo 경로는 작업 디렉토리 하위여야 합니다: %s", repo)
+	}
+	return filepath.Join(e.cfg.WorkDir, rel), nil
+}
+
+// runAuthenticated는 자격 증명 공급자의 자격 증명을 credential helper로 주입하여 git 명령을 실행합니다.
+// 자격 증명이 없으면 인증 없이 실행하며, 어떤 경우에도 대화형 프롬프트는 띄우지 않습니다.
+func (e *GitRequestExecutor) runAuthenticated(ctx context.Context, workDir, host string, args ...string) error {
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	// 사용자 전역 credential helper가 개입하지 않도록 초기화한다.
+	gitArgs := []string{"-c", "credential.helper="}
+
+	if e.cfg.Credentials != nil && host != "" {
+		cred, ok, err := e.cfg.Credentials.GitCredential(ctx, host)
+		if err != nil {
+			return fmt.Errorf("git 자격 증명 조회 실패 (host=%s): %w", host, err)
+		}
+		if ok {
+			gitArgs = append(gitArgs, "-c", "credential.helper="+gitCredentialHelper)
+			env = append(env,
+				gitCredentialUsernameEnv+"="+cred.Username,
+				gitCredentialPasswordEnv+"="+cred.Password,
+			)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "git", append(gitArgs, args...)...) //nolint:gosec // 인자는 검증된 값만 사용
+	cmd.Dir = workDir
+	cmd.Env = env
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s 실패: %w (stderr: %s)", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// remoteHost는 원격 URL의 호스트를 반환합니다.
+func (e *GitRequestExecutor) remoteHost(ctx context.Context, repoDir, remote string) (string, error) {
+	if strings.HasPrefix(remote, "-") {
+		return "", fmt.Errorf("유효하지 않은 원격 이름입니다: %q", remote)
+	}
+	rawURL, err := runGitCommandOutput(ctx, repoDir, "remote", "get-url", remote)
+	if err != nil {
+		return "", fmt.Errorf("원격 '%s' 조회 실패: %w", remote, err)
+	}
+	return validateRemoteURL(strings.TrimSpace(rawURL))
+}
+
+// commitIdentity는 커밋 작성자 이름과 이메일을 반환합니다.
+func (e *GitRequestExecutor) commitIdentity() (string, string) {
+	name := e.cfg.GitUserName
+	if name == "" {
+		name = "Autopus Agent"
+	}
+	email := e.cfg.GitUserEmail
+	if email == "" {
+		email = "agent@autopus.ai"
+	}
+	return name, email
+}
+
+// validateRemoteURL은 원격 URL이 https 또는 ssh 저장소인지 검증하고 호스트를 반환합니다.
+// file://, ext:: 같은 로컬/임의 명령 transport는 작업 디렉토리 제한을 우회할 수 있어 거부합니다.
+func validateRemoteURL(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", fmt.Errorf("repo_url이 필요합니다")
+	}
+	if strings.HasPrefix(rawURL, "-") {
+		return "", fmt.Errorf("유효하지 않은 원격 URL입니다: %s", rawURL)
+	}
+
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Scheme != "" {
+		switch parsed.Scheme {
+		case "https", "ssh":
+			if parsed.Host == "" {
+				return "", fmt.Errorf("원격 URL에 호스트가 없습니다: %s", rawURL)
+			}
+			return parsed.Hostname(), nil
+		default:
+			return "", fmt.Errorf("허용되지 않는 원격 URL 스킴입니다: %s", parsed.Scheme)
+		}
+	}
+
+	// scp 형식 (git@github.com:owner/repo.git)
+	if at := strings.Index(rawURL, "@"); at > 0 {
+		if host, _, ok := strings.Cut(rawURL[at+1:], ":"); ok && host != "" && !strings.Contains(host, "/") {
+			return host, nil
+		}
+	}
+	return "", fmt.Errorf("허용되지 않는 원격 URL입니다: %s", rawURL)
+}
+
+// validateRefName은 브랜치/시작 지점 이름이 옵션으로 해석되지 않는 유효한 이름인지 검증합니다.
+func validateRefName(name string) error {
+	if name == "" {
+		return fmt.Errorf("branch가 필요합니다")
+	}
+	if strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n~^:?*[\\") || strings.Contains(name, "..") {
+		return fmt.Errorf("유효하지 않은 브랜치 이름입니다: %q", name)
+	}
+	return nil
+}
+
+// confineRelativePath는 base 기준 상대 경로를 정규화하고 base 밖을 가리키면 에러를 반환합니다.
+// 이미 존재하는 경로는 심볼릭 링크를 해석한 실제 위치까지 검사합니다.
+func confineRelativePath(base, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("절대 경로는 허용되지 않습니다: %s", path)
+	}
+	cleaned := filepath.Clean(path)
+	if cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("작업 디렉토리 밖의 경로는 허용되지 않습니다: %s", path)
+	}
+
+	realBase, err := filepath.EvalSymlinks(base)
+	if err != nil {
+		// base가 아직 없으면 심볼릭 링크 검사 대상도 없다.
+		return cleaned, nil
+	}
+	if resolved, err := resolveExistingPrefix(filepath.Join(base, cleaned)); err == nil && !isSubPath(resolved, realBase) {
+		return "", fmt.Errorf("작업 디렉토리 밖을 가리키는 경로는 허용되지 않습니다: %s", path)
+	}
+	return cleaned, nil
+}
+
+// resolveExistingPrefix는 경로 중 실제로 존재하는 가장 긴 상위 경로의 심볼릭 링크를 해석하고
+// 나머지 경로를 이어붙여 반환합니다.
+func resolveExistingPrefix(path string) (string, error) {
+	rest := ""
+	current := path
+	for {
+		if resolved, err := filepath.EvalSymlinks(current); err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("경로를 해석할 수 없습니다: %s", path)
+		}
+		rest = filepath.Join(filepath.Base(current), rest)
+		current = parent
+	}
+}
+
+// requireGitRepo는 디렉토리가 git 레포지토리인지 확인합니다.
+func requireGitRepo(dir, repo string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return fmt.Errorf("git 레포지토리가 아닙니다: %s", repo)
+	}
+	return nil
+}
+
+// remoteName은 원격 이름 기본값(origin)을 적용합니다.
+func remoteName(remote string) string {
+	if remote == "" {
+		return "origin"
+	}
+	return remote
+}
+
+// repoNameFromURL은 원격 URL에서 레포 이름(마지막 경로 요소)을 추출합니다.
+func repoNameFromURL(rawURL string) string {
+	name := strings.TrimSuffix(strings.TrimRight(rawURL, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// splitLines는 git 출력의 비어있지 않은 줄을 반환합니다.
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
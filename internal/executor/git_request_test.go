@@ -0,0 +1,246 @@
+// Package executor - git_request 실행기 테스트
+package executor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGitRequestExecutor는 임시 작업 디렉토리에 초기화된 레포 "repo"를 가진 실행기를 생성합니다.
+func newTestGitRequestExecutor(t *testing.T) (*GitRequestExecutor, string) {
+	t.Helper()
+
+	workDir := t.TempDir()
+	repoDir := filepath.Join(workDir, "repo")
+	require.NoError(t, os.MkdirAll(repoDir, 0750))
+	if err := initTestGitRepo(repoDir); err != nil {
+		t.Skipf("git 초기화 실패: %v", err)
+	}
+
+	return NewGitRequestExecutor(GitRequestExecutorConfig{WorkDir: workDir}), repoDir
+}
+
+// TestGitRequestExecutor_CommitReturnsChangedFiles는 commit 결과에 변경 파일과 커밋 SHA가 포함되는지 검증합니다.
+func TestGitRequestExecutor_CommitReturnsChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	e, repoDir := newTestGitRequestExecutor(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# repo\n"), 0644))
+
+	result, err := e.Execute(context.Background(), ws.GitRequestPayload{
+		RequestID: "req-1",
+		Operation: ws.GitOpCommit,
+		Repo:      "repo",
+		Message:   "feat: add main",
+		Files:     []string{"main.go"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "req-1", result.RequestID)
+	assert.Equal(t, []string{"main.go"}, result.ChangedFiles, "지정한 파일만 커밋되어야 함")
+	assert.Len(t, result.CommitSHA, 40)
+
+	head, err := runGitCommandOutput(context.Background(), repoDir, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(head), result.CommitSHA)
+
+	// 변경 사항이 없으면 실패해야 한다.
+	result, err = e.Execute(context.Background(), ws.GitRequestPayload{
+		Operation: ws.GitOpCommit,
+		Repo:      "repo",
+		Message:   "empty",
+		Files:     []string{"main.go"},
+	})
+	assert.Error(t, err)
+	assert.False(t, result.Success)
+	assert.NotEmpty(t, result.Error)
+}
+
+// TestGitRequestExecutor_CommitBlocksSecretFiles는 차단 대상 파일이 커밋되지 않는지 검증합니다.
+func TestGitRequestExecutor_CommitBlocksSecretFiles(t *testing.T) {
+	t.Parallel()
+
+	e, repoDir := newTestGitRequestExecutor(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".env"), []byte("TOKEN=secret\n"), 0644))
+
+	_, err := e.Execute(context.Background(), ws.GitRequestPayload{
+		Operation: ws.GitOpCommit,
+		Repo:      "repo",
+		Message:   "add env",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ".env")
+
+	staged, err := runGitCommandOutput(context.Background(), repoDir, "diff", "--cached", "--name-only")
+	require.NoError(t, err)
+	assert.Empty(t, strings.TrimSpace(staged), "차단된 파일은 스테이징에서도 제거되어야 함")
+}
+
+// TestGitRequestExecutor_BranchAndCheckout은 브랜치 생성과 전환 결과를 검증합니다.
+func TestGitRequestExecutor_BranchAndCheckout(t *testing.T) {
+	t.Parallel()
+
+	e, repoDir := newTestGitRequestExecutor(t)
+	base, err := runGitCommandOutput(context.Background(), repoDir, "rev-parse", "--abbrev-ref", "HEAD")
+	require.NoError(t, err)
+	base = strings.TrimSpace(base)
+
+	result, err := e.Execute(context.Background(), ws.GitRequestPayload{
+		Operation: ws.GitOpBranch,
+		Repo:      "repo",
+		Branch:    "agent/ws/feature",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "agent/ws/feature", result.Branch)
+
+	result, err = e.Execute(context.Background(), ws.GitRequestPayload{
+		Operation: ws.GitOpCheckout,
+		Repo:      "repo",
+		Branch:    base,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, base, result.Branch)
+
+	_, err = e.Execute(context.Background(), ws.GitRequestPayload{
+		Operation: ws.GitOpCheckout,
+		Repo:      "repo",
+		Branch:    "--orphan",
+	})
+	assert.Error(t, err, "옵션 형태의 브랜치 이름은 거부되어야 함")
+}
+
+// TestGitRequestExecutor_PushBranchPolicy는 push가 브랜치 정책을 따르는지 검증합니다.
+func TestGitRequestExecutor_PushBranchPolicy(t *testing.T) {
+	t.Parallel()
+
+	e, _ := newTestGitRequestExecutor(t)
+	_, err := e.Execute(context.Background(), ws.GitRequestPayload{
+		Operation: ws.GitOpPush,
+		Repo:      "repo",
+		Branch:    "main",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "보호된 브랜치")
+}
+
+// TestGitRequestExecutor_PathConfinement는 작업 디렉토리 밖을 가리키는 레포/파일 경로가 거부되는지 검증합니다.
+func TestGitRequestExecutor_PathConfinement(t *testing.T) {
+	t.Parallel()
+
+	e, repoDir := newTestGitRequestExecutor(t)
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(e.WorkDir(), "escape")))
+
+	tests := []struct {
+		name string
+		req  ws.GitRequestPayload
+	}{
+		{"절대 경로 레포", ws.GitRequestPayload{Operation: ws.GitOpFetch, Repo: repoDir}},
+		{"상위 디렉토리 레포", ws.GitRequestPayload{Operation: ws.GitOpFetch, Repo: "../other"}},
+		{"작업 디렉토리 자체", ws.GitRequestPayload{Operation: ws.GitOpFetch, Repo: "."}},
+		{"심볼릭 링크 탈출", ws.GitRequestPayload{Operation: ws.GitOpFetch, Repo: "escape/repo"}},
+		{"레포 밖 커밋 파일", ws.GitRequestPayload{Operation: ws.GitOpCommit, Repo: "repo", Message: "m", Files: []string{"../../etc/passwd"}}},
+		{"지원하지 않는 작업", ws.GitRequestPayload{Operation: "reset", Repo: "repo"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := e.Execute(context.Background(), tc.req)
+			assert.Error(t, err)
+			assert.False(t, result.Success)
+		})
+	}
+}
+
+// TestValidateRemoteURL은 허용되는 원격 URL과 호스트 추출을 검증합니다.
+func TestValidateRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url      string
+		wantHost string
+		wantErr  bool
+	}{
+		{"https://github.com/owner/repo.git", "github.com", false},
+		{"ssh://git@gitlab.com:22/owner/repo.git", "gitlab.com", false},
+		{"git@github.com:owner/repo.git", "github.com", false},
+		{"file:///tmp/repo", "", true},
+		{"ext::sh -c touch% /tmp/pwned", "", true},
+		{"/tmp/repo", "", true},
+		{"--upload-pack=touch", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range tests {
+		host, err := validateRemoteURL(tc.url)
+		if tc.wantErr {
+			assert.Error(t, err, tc.url)
+			continue
+		}
+		assert.NoError(t, err, tc.url)
+		assert.Equal(t, tc.wantHost, host, tc.url)
+	}
+}
+
+// TestEnvGitCredentialProvider는 호스트별 토큰 환경 변수 우선순위를 검증합니다.
+func TestEnvGitCredentialProvider(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"GH_TOKEN":          "gh-token",
+		"AUTOPUS_GIT_TOKEN": "fallback-token",
+	}
+	p := &EnvGitCredentialProvider{
+		tokenHosts: []string{"Git.Example.com"},
+		lookupEnv:  func(name string) string { return env[name] },
+	}
+
+	cred, ok, err := p.GitCredential(context.Background(), "github.com")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "gh-token", cred.Password)
+
+	cred, ok, err = p.GitCredential(context.Background(), "git.example.com")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "fallback-token", cred.Password)
+
+	// token_hosts에 없는 호스트에는 AUTOPUS_GIT_TOKEN을 보내지 않는다.
+	_, ok, err = p.GitCredential(context.Background(), "evil.example.net")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	p = &EnvGitCredentialProvider{lookupEnv: func(string) string { return "" }}
+	_, ok, err = p.GitCredential(context.Background(), "github.com")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// TestGitCredentialHelper는 credential helper가 환경 변수의 자격 증명을 git에 전달하는지 검증합니다.
+func TestGitCredentialHelper(t *testing.T) {
+	t.Parallel()
+
+	cmd := exec.Command("git", "-c", "credential.helper=", "-c", "credential.helper="+gitCredentialHelper, "credential", "fill")
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		gitCredentialUsernameEnv+"=x-access-token",
+		gitCredentialPasswordEnv+"=s3cret",
+	)
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=github.com\n\n")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Skipf("git credential 실행 실패: %v", err)
+	}
+
+	assert.Contains(t, string(out), "username=x-access-token")
+	assert.Contains(t, string(out), "password=s3cret")
+}
@@ -0,0 +1,393 @@
+// Package executor - 로컬 실행 이력 기록 (autopus-bridge history)
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/store"
+)
+
+// DefaultHistoryMaxEntries는 보관할 실행 이력의 기본 최대 개수입니다.
+const DefaultHistoryMaxEntries = 500
+
+// 실행 이력 상태
+const (
+	HistoryRunning     = "running"
+	HistorySucceeded   = "succeeded"
+	HistoryFailed      = "failed"
+	HistoryCancelled   = "cancelled"
+	HistoryInterrupted = "interrupted"
+)
+
+// 실행 이력 종류
+const (
+	HistoryKindTask          = "task"
+	HistoryKindAgentResponse = "agent_response"
+)
+
+// HistoryEntry는 로컬 실행 하나의 기록입니다.
+type HistoryEntry struct {
+	ExecutionID string `json:"execution_id"`
+	Kind        string `json:"kind"`
+	// Agent는 작업을 실행한 로컬 AI 에이전트(프로바이더) 이름입니다.
+	Agent      string     `json:"agent,omitempty"`
+	Model      string     `json:"model,omitempty"`
+	Status     string     `json:"status"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms,omitempty"`
+	WorkDir    string     `json:"work_dir,omitempty"`
+	// Prompt는 시크릿을 가린 프롬프트 앞부분입니다.
+	Prompt       string `json:"prompt,omitempty"`
+	ExitCode     int    `json:"exit_code,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	Error        string `json:"error,omitempty"`
+	InputTokens  int    `json:"input_tokens,omitempty"`
+	OutputTokens int    `json:"output_tokens,omitempty"`
+}
+
+// Duration은 실행 시간을 반환합니다. 실행 중이면 now까지의 경과 시간입니다.
+func (h HistoryEntry) Duration(now time.Time) time.Duration {
+	if h.Status == HistoryRunning {
+		return now.Sub(h.StartedAt)
+	}
+	return time.Duration(h.DurationMs) * time.Millisecond
+}
+
+// HistoryFilter는 실행 이력 조회 조건입니다. 빈 필드는 조건에서 제외됩니다.
+type HistoryFilter struct {
+	Status string
+	// Agent는 에이전트(프로바이더) 또는 모델 이름의 부분 문자열입니다.
+	Agent string
+	Since time.Time
+	// Limit은 최대 반환 개수입니다. 0 이하이면 전체입니다.
+	Limit int
+}
+
+// match는 항목이 조건에 맞는지 반환합니다.
+func (f HistoryFilter) match(h HistoryEntry) bool {
+	if f.Status != "" && h.Status != f.Status {
+		return false
+	}
+	if f.Agent != "" {
+		agent := strings.ToLower(f.Agent)
+		if !strings.Contains(strings.ToLower(h.Agent), agent) && !strings.Contains(strings.ToLower(h.Model), agent) {
+			return false
+		}
+	}
+	return f.Since.IsZero() || !h.StartedAt.Before(f.Since)
+}
+
+// historyFile은 실행 이력 파일 형식입니다.
+type historyFile struct {
+	Entries []HistoryEntry `json:"entries"`
+}
+
+// HistoryStore는 최근 로컬 실행 기록을 보관합니다.
+// 상태 저장소 버킷(NewStoreHistory) 또는 JSON 파일(NewHistoryStore)에 저장하며,
+// maxEntries를 넘으면 가장 오래된 기록부터 삭제합니다.
+type HistoryStore struct {
+	mu sync.Mutex
+	// path는 이력 파일 경로입니다 (비어 있으면 메모리에만 보관).
+	path string
+	// bucket은 상태 저장소 버킷입니다. 설정되면 path 대신 이 버킷에 실행 ID별로 저장합니다.
+	bucket *store.Bucket
+	// entries는 실행 ID별 기록입니다.
+	entries    map[string]*HistoryEntry
+	maxEntries int
+}
+
+// NewHistoryStore는 path의 실행 이력 파일을 엽니다. 파일이 손상되었으면 빈 이력과 함께 에러를 반환합니다.
+// maxEntries가 0 이하이면 DefaultHistoryMaxEntries를 사용합니다.
+func NewHistoryStore(path string, maxEntries int) (*HistoryStore, error) {
+	h := newHistoryStore(maxEntries)
+	h.path = path
+	if path == "" {
+		return h, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return h, nil
+		}
+		return h, fmt.Errorf("실행 이력 읽기 실패: %w", err)
+	}
+	var file historyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return h, fmt.Errorf("실행 이력 파싱 실패: %w", err)
+	}
+	for i := range file.Entries {
+		h.entries[file.Entries[i].ExecutionID] = &file.Entries[i]
+	}
+	return h, nil
+}
+
+// NewStoreHistory는 상태 저장소 버킷에 실행 이력을 보관합니다.
+// 열 때는 읽기만 하므로 읽기 전용 저장소(store.OpenReadOnly)의 버킷으로도 조회할 수 있습니다.
+func NewStoreHistory(bucket *store.Bucket, maxEntries int) (*HistoryStore, error) {
+	h := newHistoryStore(maxEntries)
+	h.bucket = bucket
+	err := bucket.ForEach(func(_ string, value []byte) error {
+		var entry HistoryEntry
+		if err := json.Unmarshal(value, &entry); err != nil {
+			return fmt.Errorf("실행 이력 항목 파싱 실패: %w", err)
+		}
+		h.entries[entry.ExecutionID] = &entry
+		return nil
+	})
+	if err != nil {
+		h.entries = make(map[string]*HistoryEntry)
+		return h, err
+	}
+	return h, nil
+}
+
+// newHistoryStore는 메모리 실행 이력을 생성합니다.
+func newHistoryStore(maxEntries int) *HistoryStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultHistoryMaxEntries
+	}
+	return &HistoryStore{entries: make(map[string]*HistoryEntry), maxEntries: maxEntries}
+}
+
+// Start는 실행 시작을 기록합니다. 같은 실행 ID의 이전 기록(재개, 도구 루프 후속 요청)은 덮어씁니다.
+func (h *HistoryStore) Start(entry HistoryEntry) error {
+	entry.Status = HistoryRunning
+	entry.FinishedAt = nil
+	entry.DurationMs = 0
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[entry.ExecutionID] = &entry
+	removed := h.pruneLocked()
+	return h.saveLocked(&entry, removed)
+}
+
+// Resolve는 실행 중인 기록의 에이전트와 모델을 실제로 선택된 값으로 바꿉니다.
+// 디스크에는 Finish에서 함께 기록됩니다.
+func (h *HistoryStore) Resolve(executionID, agent, model string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if entry, ok := h.entries[executionID]; ok {
+		entry.Agent = agent
+		entry.Model = model
+	}
+}
+
+// Finish는 실행 종료를 기록합니다. update로 상태, 에러, 토큰 사용량을 채웁니다.
+func (h *HistoryStore) Finish(executionID string, finishedAt time.Time, update func(*HistoryEntry)) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entry, ok := h.entries[executionID]
+	if !ok {
+		return nil
+	}
+	entry.FinishedAt = &finishedAt
+	entry.DurationMs = finishedAt.Sub(entry.StartedAt).Milliseconds()
+	update(entry)
+	return h.saveLocked(entry, nil)
+}
+
+// MarkInterrupted는 이전 프로세스가 끝내지 못한 실행 기록을 interrupted로 표시하고 그 수를 반환합니다.
+// connect 시작 시 한 번 호출합니다.
+func (h *HistoryStore) MarkInterrupted() (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	marked := 0
+	var firstErr error
+	for _, entry := range h.entries {
+		if entry.Status != HistoryRunning {
+			continue
+		}
+		entry.Status = HistoryInterrupted
+		marked++
+		if h.bucket != nil {
+			if err := h.bucket.PutJSON(entry.ExecutionID, entry); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if marked > 0 && h.bucket == nil {
+		firstErr = h.saveLocked(nil, nil)
+	}
+	return marked, firstErr
+}
+
+// Get은 실행 ID의 기록을 반환합니다.
+func (h *HistoryStore) Get(executionID string) (HistoryEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entry, ok := h.entries[executionID]
+	if !ok {
+		return HistoryEntry{}, false
+	}
+	return *entry, true
+}
+
+// List는 조건에 맞는 기록을 최근 시작 순으로 반환합니다.
+func (h *HistoryStore) List(filter HistoryFilter) []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var result []HistoryEntry
+	for _, entry := range h.sortedLocked() {
+		if !filter.match(*entry) {
+			continue
+		}
+		result = append(result, *entry)
+		if filter.Limit > 0 && len(result) == filter.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// sortedLocked는 기록을 최근 시작 순으로 정렬하여 반환합니다. 호출자가 mu를 보유해야 합니다.
+func (h *HistoryStore) sortedLocked() []*HistoryEntry {
+	sorted := make([]*HistoryEntry, 0, len(h.entries))
+	for _, entry := range h.entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].StartedAt.Equal(sorted[j].StartedAt) {
+			return sorted[i].StartedAt.After(sorted[j].StartedAt)
+		}
+		return sorted[i].ExecutionID > sorted[j].ExecutionID
+	})
+	return sorted
+}
+
+// pruneLocked는 maxEntries를 넘는 오래된 기록을 삭제하고 삭제한 실행 ID를 반환합니다.
+func (h *HistoryStore) pruneLocked() []string {
+	if len(h.entries) <= h.maxEntries {
+		return nil
+	}
+	var removed []string
+	for _, entry := range h.sortedLocked()[h.maxEntries:] {
+		delete(h.entries, entry.ExecutionID)
+		removed = append(removed, entry.ExecutionID)
+	}
+	return removed
+}
+
+// saveLocked는 이력을 저장합니다. 호출자가 mu를 보유해야 합니다.
+// 상태 저장소를 사용하면 changed 항목과 removed 삭제만 버킷에 기록하고,
+// 파일을 사용하면 전체 이력을 원자적으로 다시 씁니다.
+func (h *HistoryStore) saveLocked(changed *HistoryEntry, removed []string) error {
+	if h.bucket != nil {
+		for _, id := range removed {
+			if err := h.bucket.Delete(id); err != nil {
+				return fmt.Errorf("실행 이력 저장 실패: %w", err)
+			}
+		}
+		if changed != nil {
+			if err := h.bucket.PutJSON(changed.ExecutionID, changed); err != nil {
+				return fmt.Errorf("실행 이력 저장 실패: %w", err)
+			}
+		}
+		return nil
+	}
+	if h.path == "" {
+		return nil
+	}
+
+	sorted := h.sortedLocked()
+	file := historyFile{Entries: make([]HistoryEntry, 0, len(sorted))}
+	for _, entry := range sorted {
+		file.Entries = append(file.Entries, *entry)
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("실행 이력 직렬화 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return fmt.Errorf("실행 이력 디렉토리 생성 실패: %w", err)
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("실행 이력 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("실행 이력 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// WithHistoryStore는 작업 실행 시작/종료를 로컬 실행 이력에 기록하도록 설정합니다.
+func WithHistoryStore(history *HistoryStore) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.history = history
+	}
+}
+
+// beginHistory는 실행 시작을 이력에 기록합니다. 이력이 비활성화되어 있으면 아무것도 하지 않습니다.
+func (e *TaskExecutor) beginHistory(entry HistoryEntry) {
+	if e.history == nil {
+		return
+	}
+	entry.StartedAt = time.Now()
+	entry.Prompt, _ = e.redactor.PromptPreview(entry.Prompt)
+	if err := e.history.Start(entry); err != nil {
+		e.logger.Warn().
+			Str("execution_id", entry.ExecutionID).
+			Err(err).
+			Msg("실행 이력 기록 실패")
+	}
+}
+
+// resolveHistory는 실제로 선택된 프로바이더와 모델을 이력에 반영합니다.
+func (e *TaskExecutor) resolveHistory(executionID, agent, model string) {
+	if e.history != nil {
+		e.history.Resolve(executionID, agent, model)
+	}
+}
+
+// finishHistory는 실행 결과를 이력에 기록합니다.
+func (e *TaskExecutor) finishHistory(executionID string, exitCode int, usage *ws.TokenUsage, err error) {
+	if e.history == nil {
+		return
+	}
+	finishErr := e.history.Finish(executionID, time.Now(), func(entry *HistoryEntry) {
+		entry.ExitCode = exitCode
+		if usage != nil {
+			entry.InputTokens = usage.InputTokens
+			entry.OutputTokens = usage.OutputTokens
+		}
+		var taskErr *TaskError
+		switch {
+		case err == nil && exitCode == 0:
+			entry.Status = HistorySucceeded
+		case err == nil:
+			entry.Status = HistoryFailed
+		case errors.As(err, &taskErr):
+			entry.Status = HistoryFailed
+			if taskErr.Code == ErrorCodeCancelled {
+				entry.Status = HistoryCancelled
+			}
+			entry.ErrorCode = taskErr.Code
+			entry.Error = taskErr.Message
+		case errors.Is(err, context.Canceled):
+			entry.Status = HistoryCancelled
+			entry.Error = err.Error()
+		default:
+			entry.Status = HistoryFailed
+			entry.Error = err.Error()
+		}
+	})
+	if finishErr != nil {
+		e.logger.Warn().
+			Str("execution_id", executionID).
+			Err(finishErr).
+			Msg("실행 이력 기록 실패")
+	}
+}
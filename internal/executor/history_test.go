@@ -0,0 +1,167 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/insajin/autopus-bridge/internal/store"
+)
+
+func TestHistoryStore_FilePersistsAndPrunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h, err := NewHistoryStore(path, 2)
+	if err != nil {
+		t.Fatalf("NewHistoryStore() error = %v", err)
+	}
+	base := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	for i, id := range []string{"exec-1", "exec-2", "exec-3"} {
+		if err := h.Start(HistoryEntry{ExecutionID: id, Agent: "claude", StartedAt: base.Add(time.Duration(i) * time.Minute)}); err != nil {
+			t.Fatalf("Start(%s) error = %v", id, err)
+		}
+	}
+	if err := h.Finish("exec-3", base.Add(2*time.Minute+1500*time.Millisecond), func(e *HistoryEntry) { e.Status = HistorySucceeded }); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+
+	reopened, err := NewHistoryStore(path, 2)
+	if err != nil {
+		t.Fatalf("NewHistoryStore() 다시 열기 error = %v", err)
+	}
+	entries := reopened.List(HistoryFilter{})
+	if len(entries) != 2 || entries[0].ExecutionID != "exec-3" || entries[1].ExecutionID != "exec-2" {
+		t.Fatalf("List() = %+v, 최근 2개(exec-3, exec-2) 기대", entries)
+	}
+	if entries[0].Status != HistorySucceeded || entries[0].DurationMs != 1500 {
+		t.Errorf("exec-3 = %+v", entries[0])
+	}
+
+	// 이전 프로세스가 끝내지 못한 실행은 interrupted로 표시한다.
+	if marked, err := reopened.MarkInterrupted(); err != nil || marked != 1 {
+		t.Fatalf("MarkInterrupted() = %d, %v", marked, err)
+	}
+	again, _ := NewHistoryStore(path, 2)
+	if got, _ := again.Get("exec-2"); got.Status != HistoryInterrupted {
+		t.Errorf("exec-2 상태 = %q, want interrupted", got.Status)
+	}
+}
+
+func TestHistoryStore_StateStoreBucket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("store.Open() error = %v", err)
+	}
+	h, err := NewStoreHistory(s.Bucket("task_history"), 1)
+	if err != nil {
+		t.Fatalf("NewStoreHistory() error = %v", err)
+	}
+	now := time.Now()
+	_ = h.Start(HistoryEntry{ExecutionID: "old", StartedAt: now.Add(-time.Hour)})
+	_ = h.Start(HistoryEntry{ExecutionID: "new", StartedAt: now})
+
+	// connect가 저장소를 연 채로 CLI가 읽기 전용으로 조회한다.
+	ro, err := store.OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("store.OpenReadOnly() error = %v", err)
+	}
+	defer ro.Close()
+	view, err := NewStoreHistory(ro.Bucket("task_history"), 1)
+	if err != nil {
+		t.Fatalf("NewStoreHistory(읽기 전용) error = %v", err)
+	}
+	if _, ok := view.Get("old"); ok {
+		t.Error("최대 개수를 넘은 기록이 버킷에 남아 있습니다")
+	}
+	if got, ok := view.Get("new"); !ok || got.Status != HistoryRunning {
+		t.Errorf("Get(new) = %+v, %v", got, ok)
+	}
+	_ = s.Close()
+}
+
+func TestHistoryFilter(t *testing.T) {
+	h := newHistoryStore(0)
+	now := time.Now()
+	h.entries = map[string]*HistoryEntry{
+		"a": {ExecutionID: "a", Agent: "claude", Model: "claude-sonnet-4", Status: HistorySucceeded, StartedAt: now.Add(-3 * time.Hour)},
+		"b": {ExecutionID: "b", Agent: "codex", Model: "gpt-5", Status: HistoryFailed, StartedAt: now.Add(-2 * time.Hour)},
+		"c": {ExecutionID: "c", Agent: "claude", Model: "claude-opus-4", Status: HistoryFailed, StartedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name   string
+		filter HistoryFilter
+		want   []string
+	}{
+		{name: "전체", filter: HistoryFilter{}, want: []string{"c", "b", "a"}},
+		{name: "상태", filter: HistoryFilter{Status: HistoryFailed}, want: []string{"c", "b"}},
+		{name: "에이전트", filter: HistoryFilter{Agent: "Claude"}, want: []string{"c", "a"}},
+		{name: "모델", filter: HistoryFilter{Agent: "gpt"}, want: []string{"b"}},
+		{name: "기간", filter: HistoryFilter{Since: now.Add(-150 * time.Minute)}, want: []string{"c", "b"}},
+		{name: "개수", filter: HistoryFilter{Limit: 1}, want: []string{"c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range h.List(tt.filter) {
+				got = append(got, e.ExecutionID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("List() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("List() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskExecutor_RecordsHistory(t *testing.T) {
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{name: "claude"})
+	registry.Register(&mockProvider{
+		name: "codex",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			return nil, errors.New("codex crashed")
+		},
+	})
+	history := newHistoryStore(0)
+	e := NewTaskExecutor(registry, newMockSender(), WithHistoryStore(history))
+
+	if _, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-ok",
+		Prompt:      "Hello",
+		Model:       "claude-sonnet",
+		WorkDir:     t.TempDir(),
+		Timeout:     60,
+	}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	ok, found := history.Get("exec-ok")
+	if !found || ok.Status != HistorySucceeded || ok.Agent != "claude" || ok.Kind != HistoryKindTask || ok.Prompt != "Hello" || ok.FinishedAt == nil {
+		t.Errorf("성공 기록 = %+v", ok)
+	}
+	if ok.InputTokens != 10 {
+		t.Errorf("InputTokens = %d, want 10", ok.InputTokens)
+	}
+
+	if _, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-fail",
+		Prompt:      "Hi",
+		Provider:    "codex",
+		Model:       "gpt-5",
+		Timeout:     60,
+	}); err == nil {
+		t.Fatal("실패하는 프로바이더인데 에러가 없습니다")
+	}
+	failed, _ := history.Get("exec-fail")
+	if failed.Status != HistoryFailed || failed.Agent != "codex" || failed.Error == "" {
+		t.Errorf("실패 기록 = %+v", failed)
+	}
+}
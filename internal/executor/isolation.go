@@ -0,0 +1,530 @@
+// Package executor는 Local Agent Bridge의 작업 실행 엔진을 제공합니다.
+// 작업 디렉토리 격리: 작업을 work_dir의 임시 복사본에서 실행하고 변경 사항을 diff로 보고합니다.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrorCodeIsolationFailed는 작업 디렉토리 격리 복사본을 만들지 못했을 때 사용됩니다.
+const ErrorCodeIsolationFailed = errcode.IsolationFailed
+
+// 격리 diff 관련 상수
+const (
+	// maxIsolationDiffBytes는 결과에 포함할 diff의 최대 크기입니다 (1MB).
+	maxIsolationDiffBytes = 1 << 20
+	// maxIsolationTextFileBytes는 텍스트 diff를 생성할 파일의 최대 크기입니다 (1MB).
+	maxIsolationTextFileBytes = 1 << 20
+	// binarySniffBytes는 바이너리 여부를 판단할 때 검사하는 앞부분 크기입니다.
+	binarySniffBytes = 8000
+)
+
+// ErrIsolationTooLarge는 work_dir이 격리 복사 최대 크기를 넘을 때 반환됩니다.
+var ErrIsolationTooLarge = errors.New("작업 디렉토리가 격리 복사 최대 크기를 초과합니다")
+
+// ChangeApprover는 격리 실행의 변경 사항을 원본에 반영해도 되는지 결정합니다.
+// approval.ActionGate가 이 인터페이스를 만족합니다.
+type ChangeApprover interface {
+	Check(ctx context.Context, action approval.LocalAction) error
+}
+
+// IsolationConfig는 WorkDirIsolator 설정입니다.
+type IsolationConfig struct {
+	// BaseDir는 복사본을 만들 디렉토리입니다. 비어있으면 os.TempDir()/autopus-isolation을 사용합니다.
+	BaseDir string
+	// Exclude는 복사와 변경 감지에서 제외할 패턴입니다.
+	// 경로 구성 요소 이름 또는 상대 경로에 filepath.Match로 비교합니다.
+	Exclude []string
+	// MaxSize는 복사할 수 있는 최대 바이트 수입니다. 0이면 제한하지 않습니다.
+	MaxSize int64
+}
+
+// WorkDirIsolator는 작업마다 work_dir의 복사본을 만들고, 완료 후 변경 사항을 원본에 반영합니다.
+// .git 디렉토리는 복사하지만 변경 감지와 반영 대상에서는 제외하므로,
+// 복사본에서 만든 커밋은 원본에 반영되지 않고 작업 트리 변경만 반영됩니다.
+type WorkDirIsolator struct {
+	cfg      IsolationConfig
+	approver ChangeApprover
+}
+
+// NewWorkDirIsolator는 새로운 WorkDirIsolator를 생성합니다.
+// approver가 nil이면 변경 사항을 항상 자동으로 반영합니다.
+func NewWorkDirIsolator(cfg IsolationConfig, approver ChangeApprover) *WorkDirIsolator {
+	if cfg.BaseDir == "" {
+		cfg.BaseDir = filepath.Join(os.TempDir(), "autopus-isolation")
+	}
+	return &WorkDirIsolator{cfg: cfg, approver: approver}
+}
+
+// isolatedFile은 복사 시점의 원본 파일 상태입니다.
+type isolatedFile struct {
+	mode fs.FileMode
+	hash string
+	// link는 심볼릭 링크의 대상입니다.
+	link string
+}
+
+// IsolatedWorkDir는 한 작업을 위한 work_dir 복사본입니다.
+type IsolatedWorkDir struct {
+	// Source는 원본 work_dir의 절대 경로입니다.
+	Source string
+	// Dir는 복사본의 절대 경로입니다. 프로바이더는 이 경로에서 실행됩니다.
+	Dir string
+
+	exclude  []string
+	snapshot map[string]isolatedFile
+}
+
+// Prepare는 workDir을 BaseDir 아래의 새 디렉토리로 복사합니다.
+func (w *WorkDirIsolator) Prepare(executionID, workDir string) (*IsolatedWorkDir, error) {
+	source, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("작업 디렉토리 경로 확인 실패: %w", err)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("작업 디렉토리 확인 실패: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("작업 디렉토리가 디렉토리가 아닙니다: %s", source)
+	}
+
+	if err := os.MkdirAll(w.cfg.BaseDir, 0o700); err != nil {
+		return nil, fmt.Errorf("격리 디렉토리 생성 실패: %w", err)
+	}
+	dir, err := os.MkdirTemp(w.cfg.BaseDir, sanitizeIsolationName(executionID)+"-")
+	if err != nil {
+		return nil, fmt.Errorf("격리 디렉토리 생성 실패: %w", err)
+	}
+
+	iso := &IsolatedWorkDir{
+		Source:   source,
+		Dir:      dir,
+		exclude:  w.cfg.Exclude,
+		snapshot: make(map[string]isolatedFile),
+	}
+	if err := iso.copyFromSource(w.cfg.MaxSize); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return iso, nil
+}
+
+// copyFromSource는 원본을 복사본으로 복사하면서 스냅샷을 기록합니다.
+func (d *IsolatedWorkDir) copyFromSource(maxSize int64) error {
+	var total int64
+	return filepath.WalkDir(d.Source, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(d.Source, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.excluded(rel) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(d.Dir, rel)
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0o700)
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if !isGitInternal(rel) {
+				d.snapshot[rel] = isolatedFile{mode: info.Mode(), link: link}
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			total += info.Size()
+			if maxSize > 0 && total > maxSize {
+				return fmt.Errorf("%w (%dMB)", ErrIsolationTooLarge, maxSize/(1024*1024))
+			}
+			hash, err := copyFileHashed(path, target, info.Mode().Perm())
+			if err != nil {
+				return err
+			}
+			if !isGitInternal(rel) {
+				d.snapshot[rel] = isolatedFile{mode: info.Mode(), hash: hash}
+			}
+			return nil
+		default:
+			// 소켓, 디바이스 파일 등은 복사하지 않는다.
+			return nil
+		}
+	})
+}
+
+// Changes는 복사본에서 추가/수정/삭제된 파일을 경로 순으로 반환합니다.
+func (d *IsolatedWorkDir) Changes() ([]ws.WorkspaceFileChange, error) {
+	seen := make(map[string]bool, len(d.snapshot))
+	var changes []ws.WorkspaceFileChange
+
+	err := filepath.WalkDir(d.Dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(d.Dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.excluded(rel) || isGitInternal(rel) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		current, ok, err := statIsolatedFile(path)
+		if err != nil || !ok {
+			return err
+		}
+		seen[rel] = true
+		before, existed := d.snapshot[rel]
+		switch {
+		case !existed:
+			changes = append(changes, ws.WorkspaceFileChange{Path: filepath.ToSlash(rel), Status: ws.WorkspaceFileAdded})
+		case before != current:
+			changes = append(changes, ws.WorkspaceFileChange{Path: filepath.ToSlash(rel), Status: ws.WorkspaceFileModified})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("변경 사항 확인 실패: %w", err)
+	}
+
+	for rel := range d.snapshot {
+		if !seen[rel] {
+			changes = append(changes, ws.WorkspaceFileChange{Path: filepath.ToSlash(rel), Status: ws.WorkspaceFileDeleted})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// Diff는 변경 사항의 unified diff를 생성합니다.
+// 전체 크기가 maxIsolationDiffBytes를 넘으면 잘라내고 truncated를 true로 반환합니다.
+func (d *IsolatedWorkDir) Diff(changes []ws.WorkspaceFileChange) (diff string, truncated bool) {
+	var b strings.Builder
+	for _, change := range changes {
+		rel := filepath.FromSlash(change.Path)
+		before, beforeOK := readDiffSide(filepath.Join(d.Source, rel), change.Status != ws.WorkspaceFileAdded)
+		after, afterOK := readDiffSide(filepath.Join(d.Dir, rel), change.Status != ws.WorkspaceFileDeleted)
+
+		fromFile, toFile := "a/"+change.Path, "b/"+change.Path
+		if change.Status == ws.WorkspaceFileAdded {
+			fromFile = "/dev/null"
+		}
+		if change.Status == ws.WorkspaceFileDeleted {
+			toFile = "/dev/null"
+		}
+
+		var section string
+		if !beforeOK || !afterOK {
+			section = fmt.Sprintf("Binary files %s and %s differ\n", fromFile, toFile)
+		} else {
+			text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(before),
+				B:        difflib.SplitLines(after),
+				FromFile: fromFile,
+				ToFile:   toFile,
+				Context:  3,
+			})
+			if err != nil || text == "" {
+				continue
+			}
+			section = text
+		}
+
+		if b.Len()+len(section) > maxIsolationDiffBytes {
+			return b.String(), true
+		}
+		b.WriteString(section)
+	}
+	return b.String(), false
+}
+
+// Apply는 변경 사항을 원본 work_dir에 반영합니다.
+// 실행 중 원본에서도 바뀐 파일은 덮어쓰지 않고 충돌 목록으로 반환합니다.
+func (d *IsolatedWorkDir) Apply(changes []ws.WorkspaceFileChange) (conflicts []string, err error) {
+	for _, change := range changes {
+		rel := filepath.FromSlash(change.Path)
+		src := filepath.Join(d.Dir, rel)
+		dst := filepath.Join(d.Source, rel)
+
+		if d.sourceChanged(rel, dst) {
+			conflicts = append(conflicts, change.Path)
+			continue
+		}
+
+		if change.Status == ws.WorkspaceFileDeleted {
+			if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+				return conflicts, fmt.Errorf("%s 삭제 실패: %w", change.Path, err)
+			}
+			continue
+		}
+		if err := copyIsolatedEntry(src, dst); err != nil {
+			return conflicts, fmt.Errorf("%s 반영 실패: %w", change.Path, err)
+		}
+	}
+	return conflicts, nil
+}
+
+// Cleanup은 복사본을 삭제합니다.
+func (d *IsolatedWorkDir) Cleanup() error {
+	return os.RemoveAll(d.Dir)
+}
+
+// sourceChanged는 원본 파일이 복사 시점 이후 바뀌었는지 확인합니다.
+func (d *IsolatedWorkDir) sourceChanged(rel, dst string) bool {
+	current, exists, err := statIsolatedFile(dst)
+	if err != nil {
+		return true
+	}
+	before, existed := d.snapshot[rel]
+	if !existed {
+		return exists
+	}
+	return !exists || current != before
+}
+
+// excluded는 상대 경로가 제외 패턴에 해당하는지 확인합니다.
+func (d *IsolatedWorkDir) excluded(rel string) bool {
+	slashed := filepath.ToSlash(rel)
+	base := filepath.Base(rel)
+	for _, pattern := range d.exclude {
+		pattern = strings.TrimSuffix(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, slashed); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Finish는 격리 실행을 마무리합니다.
+// 변경 사항과 diff를 계산하고, 승인되면 원본에 반영한 뒤 복사본을 삭제합니다.
+// 반영하지 않은 경우 복사본을 남겨두고 결과에 경로를 기록합니다.
+func (w *WorkDirIsolator) Finish(ctx context.Context, d *IsolatedWorkDir) (*ws.WorkspaceChanges, error) {
+	changes, err := d.Changes()
+	if err != nil {
+		return nil, err
+	}
+	result := &ws.WorkspaceChanges{Files: changes}
+	if len(changes) == 0 {
+		return result, d.Cleanup()
+	}
+	result.Diff, result.DiffTruncated = d.Diff(changes)
+
+	if w.approver != nil {
+		if err := w.approver.Check(ctx, approval.LocalAction{
+			Type:   approval.ActionApplyChanges,
+			Target: d.Source,
+			Detail: summarizeWorkspaceChanges(changes),
+		}); err != nil {
+			result.IsolatedDir = d.Dir
+			return result, nil
+		}
+	}
+
+	result.Conflicts, err = d.Apply(changes)
+	if err != nil {
+		result.IsolatedDir = d.Dir
+		return result, err
+	}
+	result.Applied = true
+	if len(result.Conflicts) > 0 {
+		// 충돌한 파일은 복사본에서만 확인할 수 있으므로 남겨둔다.
+		result.IsolatedDir = d.Dir
+		return result, nil
+	}
+	return result, d.Cleanup()
+}
+
+// summarizeWorkspaceChanges는 승인 프롬프트에 표시할 변경 요약을 만듭니다.
+func summarizeWorkspaceChanges(changes []ws.WorkspaceFileChange) string {
+	counts := make(map[string]int, 3)
+	for _, c := range changes {
+		counts[c.Status]++
+	}
+	return fmt.Sprintf("파일 %d개 변경 (추가 %d, 수정 %d, 삭제 %d)",
+		len(changes), counts[ws.WorkspaceFileAdded], counts[ws.WorkspaceFileModified], counts[ws.WorkspaceFileDeleted])
+}
+
+// isGitInternal은 경로가 .git 디렉토리 내부인지 확인합니다.
+func isGitInternal(rel string) bool {
+	first, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+	return first == ".git"
+}
+
+// sanitizeIsolationName은 실행 ID를 디렉토리 이름에 쓸 수 있게 정리합니다.
+func sanitizeIsolationName(executionID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, executionID)
+	if name == "" {
+		return "task"
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// statIsolatedFile은 파일의 현재 상태를 반환합니다. 파일이 없거나 일반 파일/링크가 아니면 ok가 false입니다.
+func statIsolatedFile(path string) (isolatedFile, bool, error) {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return isolatedFile{}, false, nil
+	}
+	if err != nil {
+		return isolatedFile{}, false, err
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(path)
+		if err != nil {
+			return isolatedFile{}, false, err
+		}
+		return isolatedFile{mode: info.Mode(), link: link}, true, nil
+	}
+	if !info.Mode().IsRegular() {
+		return isolatedFile{}, false, nil
+	}
+	hash, err := hashFile(path)
+	if err != nil {
+		return isolatedFile{}, false, err
+	}
+	return isolatedFile{mode: info.Mode(), hash: hash}, true, nil
+}
+
+// readDiffSide는 diff에 사용할 파일 내용을 읽습니다.
+// 바이너리이거나 너무 크면 ok가 false입니다. want가 false이면 빈 내용을 반환합니다.
+func readDiffSide(path string, want bool) (string, bool) {
+	if !want {
+		return "", true
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", true
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(path)
+		if err != nil {
+			return "", false
+		}
+		return link + "\n", true
+	}
+	if info.Size() > maxIsolationTextFileBytes {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	if bytes.IndexByte(data[:min(len(data), binarySniffBytes)], 0) >= 0 {
+		return "", false
+	}
+	return string(data), true
+}
+
+// copyIsolatedEntry는 복사본의 파일 또는 심볼릭 링크를 원본 경로로 복사합니다.
+func copyIsolatedEntry(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return os.Symlink(link, dst)
+	}
+	_, err = copyFileHashed(src, dst, info.Mode().Perm())
+	return err
+}
+
+// copyFileHashed는 파일을 복사하고 내용의 SHA-256 해시를 반환합니다.
+func copyFileHashed(src, dst string, perm fs.FileMode) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, h), in); err != nil {
+		_ = out.Close()
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+	// 기존 파일을 덮어쓴 경우 OpenFile은 권한을 바꾸지 않으므로 명시적으로 맞춘다.
+	if err := os.Chmod(dst, perm); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile은 파일 내용의 SHA-256 해시를 반환합니다.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
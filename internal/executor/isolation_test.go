@@ -0,0 +1,260 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChangeApprover는 격리 변경 반영 승인을 기록하는 테스트용 승인자입니다.
+type fakeChangeApprover struct {
+	deny    bool
+	actions []approval.LocalAction
+}
+
+func (f *fakeChangeApprover) Check(_ context.Context, action approval.LocalAction) error {
+	f.actions = append(f.actions, action)
+	if f.deny {
+		return approval.ErrActionDenied
+	}
+	return nil
+}
+
+// newIsolationWorkDir는 격리 테스트용 작업 디렉토리를 생성합니다.
+func newIsolationWorkDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc main() {}\n")
+	writeTestFile(t, filepath.Join(dir, "README.md"), "hello\n")
+	writeTestFile(t, filepath.Join(dir, "old.txt"), "remove me\n")
+	writeTestFile(t, filepath.Join(dir, "node_modules", "dep", "index.js"), "module.exports = 1\n")
+	writeTestFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/main\n")
+	return dir
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// editIsolatedCopy는 에이전트가 복사본에서 작업한 것처럼 파일을 변경합니다.
+func editIsolatedCopy(t *testing.T, dir string) {
+	t.Helper()
+	writeTestFile(t, filepath.Join(dir, "main.go"), "package main\n\nfunc main() { println(\"hi\") }\n")
+	writeTestFile(t, filepath.Join(dir, "pkg", "new.go"), "package pkg\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "old.txt")))
+	writeTestFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/feature\n")
+}
+
+func TestWorkDirIsolator_PrepareCopiesAndExcludes(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: t.TempDir(), Exclude: []string{"node_modules"}}, nil)
+
+	iso, err := isolator.Prepare("exec/1", src)
+	require.NoError(t, err)
+	defer func() { _ = iso.Cleanup() }()
+
+	assert.NotEqual(t, src, iso.Dir)
+	assert.FileExists(t, filepath.Join(iso.Dir, "main.go"))
+	assert.FileExists(t, filepath.Join(iso.Dir, ".git", "HEAD"))
+	assert.NoDirExists(t, filepath.Join(iso.Dir, "node_modules"))
+	assert.True(t, strings.HasPrefix(filepath.Base(iso.Dir), "exec_1-"), "실행 ID는 디렉토리 이름으로 정리되어야 합니다: %s", iso.Dir)
+
+	changes, err := iso.Changes()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestWorkDirIsolator_PrepareTooLarge(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	base := t.TempDir()
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: base, MaxSize: 10}, nil)
+
+	_, err := isolator.Prepare("exec-1", src)
+	require.ErrorIs(t, err, ErrIsolationTooLarge)
+
+	entries, err := os.ReadDir(base)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "실패한 복사본은 삭제되어야 합니다")
+}
+
+func TestWorkDirIsolator_FinishAppliesChanges(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	approver := &fakeChangeApprover{}
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: t.TempDir()}, approver)
+
+	iso, err := isolator.Prepare("exec-1", src)
+	require.NoError(t, err)
+	editIsolatedCopy(t, iso.Dir)
+
+	// 원본은 작업이 끝날 때까지 변경되지 않는다.
+	data, err := os.ReadFile(filepath.Join(src, "main.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "println")
+
+	changes, err := isolator.Finish(context.Background(), iso)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ws.WorkspaceFileChange{
+		{Path: "main.go", Status: ws.WorkspaceFileModified},
+		{Path: "old.txt", Status: ws.WorkspaceFileDeleted},
+		{Path: "pkg/new.go", Status: ws.WorkspaceFileAdded},
+	}, changes.Files)
+	assert.True(t, changes.Applied)
+	assert.Empty(t, changes.IsolatedDir)
+	assert.Contains(t, changes.Diff, "--- a/main.go")
+	assert.Contains(t, changes.Diff, "+func main() { println(\"hi\") }")
+	assert.Contains(t, changes.Diff, "--- /dev/null\n+++ b/pkg/new.go")
+
+	require.Len(t, approver.actions, 1)
+	assert.Equal(t, approval.ActionApplyChanges, approver.actions[0].Type)
+	assert.Equal(t, src, approver.actions[0].Target)
+
+	data, err = os.ReadFile(filepath.Join(src, "main.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "println")
+	assert.FileExists(t, filepath.Join(src, "pkg", "new.go"))
+	assert.NoFileExists(t, filepath.Join(src, "old.txt"))
+
+	// .git 내부 변경은 반영하지 않는다.
+	data, err = os.ReadFile(filepath.Join(src, ".git", "HEAD"))
+	require.NoError(t, err)
+	assert.Equal(t, "ref: refs/heads/main\n", string(data))
+
+	assert.NoDirExists(t, iso.Dir, "반영 후 복사본은 삭제되어야 합니다")
+}
+
+func TestWorkDirIsolator_FinishDenied(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: t.TempDir()}, &fakeChangeApprover{deny: true})
+
+	iso, err := isolator.Prepare("exec-1", src)
+	require.NoError(t, err)
+	editIsolatedCopy(t, iso.Dir)
+
+	changes, err := isolator.Finish(context.Background(), iso)
+	require.NoError(t, err)
+
+	assert.False(t, changes.Applied)
+	assert.Equal(t, iso.Dir, changes.IsolatedDir)
+	assert.NotEmpty(t, changes.Diff)
+	assert.FileExists(t, filepath.Join(src, "old.txt"))
+	assert.DirExists(t, iso.Dir, "거부된 변경은 복사본에 남아야 합니다")
+}
+
+func TestWorkDirIsolator_FinishReportsConflicts(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: t.TempDir()}, nil)
+
+	iso, err := isolator.Prepare("exec-1", src)
+	require.NoError(t, err)
+	editIsolatedCopy(t, iso.Dir)
+	// 실행 중 사용자가 원본 main.go를 수정했다.
+	writeTestFile(t, filepath.Join(src, "main.go"), "package main // edited by user\n")
+
+	changes, err := isolator.Finish(context.Background(), iso)
+	require.NoError(t, err)
+
+	assert.True(t, changes.Applied)
+	assert.Equal(t, []string{"main.go"}, changes.Conflicts)
+	assert.Equal(t, iso.Dir, changes.IsolatedDir)
+
+	data, err := os.ReadFile(filepath.Join(src, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main // edited by user\n", string(data), "충돌한 파일은 덮어쓰지 않아야 합니다")
+	assert.FileExists(t, filepath.Join(src, "pkg", "new.go"))
+}
+
+func TestWorkDirIsolator_DiffMarksBinary(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: t.TempDir()}, nil)
+
+	iso, err := isolator.Prepare("exec-1", src)
+	require.NoError(t, err)
+	defer func() { _ = iso.Cleanup() }()
+	require.NoError(t, os.WriteFile(filepath.Join(iso.Dir, "logo.png"), []byte{0x89, 'P', 'N', 'G', 0, 1}, 0o644))
+
+	changes, err := iso.Changes()
+	require.NoError(t, err)
+	diff, truncated := iso.Diff(changes)
+	assert.False(t, truncated)
+	assert.Equal(t, "Binary files /dev/null and b/logo.png differ\n", diff)
+}
+
+func TestTaskExecutor_WorkDirIsolation(t *testing.T) {
+	src := newIsolationWorkDir(t)
+
+	var gotWorkDir string
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(_ context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			gotWorkDir = req.WorkDir
+			writeTestFile(t, filepath.Join(req.WorkDir, "README.md"), "hello, isolated\n")
+			return &provider.ExecuteResponse{Output: "done"}, nil
+		},
+	})
+
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: t.TempDir()}, nil)
+	executor := NewTaskExecutor(registry, newMockSender(), WithWorkDirIsolation(isolator))
+
+	result, err := executor.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-iso",
+		Prompt:      "update readme",
+		Model:       "claude-sonnet-4-20250514",
+		WorkDir:     src,
+	})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, src, gotWorkDir, "프로바이더는 복사본에서 실행되어야 합니다")
+	require.NotNil(t, result.WorkspaceChanges)
+	assert.True(t, result.WorkspaceChanges.Applied)
+	assert.Contains(t, result.WorkspaceChanges.Diff, "+hello, isolated")
+
+	data, err := os.ReadFile(filepath.Join(src, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello, isolated\n", string(data))
+}
+
+func TestTaskExecutor_WorkDirIsolation_DiscardsOnError(t *testing.T) {
+	src := newIsolationWorkDir(t)
+	base := t.TempDir()
+
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(_ context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			writeTestFile(t, filepath.Join(req.WorkDir, "README.md"), "half done\n")
+			return nil, errors.New("provider crashed")
+		},
+	})
+
+	isolator := NewWorkDirIsolator(IsolationConfig{BaseDir: base}, nil)
+	executor := NewTaskExecutor(registry, newMockSender(), WithWorkDirIsolation(isolator))
+
+	_, err := executor.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-iso",
+		Prompt:      "update readme",
+		Model:       "claude-sonnet-4-20250514",
+		WorkDir:     src,
+	})
+	require.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join(src, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(data))
+
+	entries, err := os.ReadDir(base)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "실패한 작업의 복사본은 삭제되어야 합니다")
+}
@@ -0,0 +1,161 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// Step names shared by the executors; QA stages reuse the stage* names.
+const (
+	stepPrepare     = "prepare"
+	stepEnvironment = "environment"
+	stepRerun       = "rerun_failed"
+	stepCoverage    = "coverage"
+)
+
+// StepFunc receives structured step updates from the build, test and QA executors.
+// It is called from the executing goroutine (or several, for matrix builds) and
+// must not block for long. It is an alias so the websocket router can declare
+// the ExecuteWithProgress methods without importing this package.
+type StepFunc = func(step ws.ProgressStep)
+
+// stepReporter emits the steps of one execution in order. The step list is
+// fixed up front so every update carries the same Total; a nil reporter or a
+// nil StepFunc makes every method a no-op.
+type stepReporter struct {
+	fn    StepFunc
+	names []string
+
+	mu      sync.Mutex
+	index   int
+	current *ws.ProgressStep
+	started time.Time
+}
+
+// newStepReporter returns a reporter for the given step names, or nil when fn is nil.
+func newStepReporter(fn StepFunc, names ...string) *stepReporter {
+	if fn == nil {
+		return nil
+	}
+	return &stepReporter{fn: fn, names: names, index: -1}
+}
+
+// begin marks the named step as running. Steps that were skipped over since the
+// previous one are reported as skipped first.
+func (r *stepReporter) begin(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finishLocked(ws.ProgressStepCompleted, "")
+	next := r.find(name)
+	for i := r.index + 1; i < next; i++ {
+		r.emitLocked(ws.ProgressStep{Name: r.names[i], Index: i, Status: ws.ProgressStepSkipped})
+	}
+	r.index = next
+	r.started = time.Now()
+	r.current = &ws.ProgressStep{Name: name, Index: next, Status: ws.ProgressStepRunning}
+	r.emitLocked(*r.current)
+}
+
+// end finishes the running step as completed or failed with an optional detail.
+func (r *stepReporter) end(success bool, detail string) {
+	if r == nil {
+		return
+	}
+	status := ws.ProgressStepCompleted
+	if !success {
+		status = ws.ProgressStepFailed
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finishLocked(status, detail)
+}
+
+// tool records a nested event of the running step and re-emits the step.
+// An event with the same ID replaces the earlier one, so a tool can move from
+// running to completed.
+func (r *stepReporter) tool(event ws.ProgressToolEvent) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return
+	}
+	replaced := false
+	if event.ID != "" {
+		for i := range r.current.Tools {
+			if r.current.Tools[i].ID == event.ID {
+				r.current.Tools[i] = event
+				replaced = true
+				break
+			}
+		}
+	}
+	if !replaced {
+		r.current.Tools = append(r.current.Tools, event)
+	}
+	r.emitLocked(*r.current)
+}
+
+// skipRest reports every step after the last one as skipped.
+func (r *stepReporter) skipRest(detail string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finishLocked(ws.ProgressStepCompleted, "")
+	for i := r.index + 1; i < len(r.names); i++ {
+		r.emitLocked(ws.ProgressStep{Name: r.names[i], Index: i, Status: ws.ProgressStepSkipped, Detail: detail})
+	}
+	r.index = len(r.names)
+}
+
+// finishLocked ends the running step, if any, with status.
+func (r *stepReporter) finishLocked(status, detail string) {
+	if r.current == nil {
+		return
+	}
+	step := *r.current
+	step.Status = status
+	step.Detail = detail
+	step.DurationMs = time.Since(r.started).Milliseconds()
+	r.current = nil
+	r.emitLocked(step)
+}
+
+// find returns the index of name after the last reported step. Unknown names
+// are appended so a step is never dropped.
+func (r *stepReporter) find(name string) int {
+	for i := r.index + 1; i < len(r.names); i++ {
+		if r.names[i] == name {
+			return i
+		}
+	}
+	r.names = append(r.names, name)
+	return len(r.names) - 1
+}
+
+// emitLocked sends step with the current Total and a copy of its tool events.
+func (r *stepReporter) emitLocked(step ws.ProgressStep) {
+	step.Total = len(r.names)
+	if len(step.Tools) > 0 {
+		step.Tools = append([]ws.ProgressToolEvent(nil), step.Tools...)
+	}
+	r.fn(step)
+}
+
+// exitDetail describes a non-zero exit code for a failed step.
+func exitDetail(exitCode int) string {
+	if exitCode == 0 {
+		return ""
+	}
+	return fmt.Sprintf("exit code %d", exitCode)
+}
@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The websocket router reports steps for executors implementing these.
+var (
+	_ websocket.StepProgressBuildExecutor = (*BuildExecutor)(nil)
+	_ websocket.StepProgressTestExecutor  = (*TestExecutor)(nil)
+	_ websocket.StepProgressQAExecutor    = (*QAPipelineExecutor)(nil)
+)
+
+// stepRecorder collects reported steps.
+type stepRecorder struct {
+	mu    sync.Mutex
+	steps []ws.ProgressStep
+}
+
+func (r *stepRecorder) record(step ws.ProgressStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+// final returns the last reported state of every step, by name.
+func (r *stepRecorder) final() map[string]ws.ProgressStep {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	final := make(map[string]ws.ProgressStep)
+	for _, step := range r.steps {
+		final[step.Name] = step
+	}
+	return final
+}
+
+func TestStepReporter_SkipsAndTools(t *testing.T) {
+	rec := &stepRecorder{}
+	steps := newStepReporter(rec.record, "a", "b", "c", "d")
+
+	steps.begin("a")
+	steps.tool(ws.ProgressToolEvent{ID: "x", Name: "x", Status: ws.ProgressStepRunning})
+	steps.tool(ws.ProgressToolEvent{ID: "x", Name: "x", Status: ws.ProgressStepCompleted})
+	steps.begin("c") // finishes a, skips b
+	steps.end(false, "boom")
+	steps.skipRest("")
+
+	want := []struct {
+		name, status string
+		tools        int
+	}{
+		{"a", ws.ProgressStepRunning, 0},
+		{"a", ws.ProgressStepRunning, 1},
+		{"a", ws.ProgressStepRunning, 1},
+		{"a", ws.ProgressStepCompleted, 1},
+		{"b", ws.ProgressStepSkipped, 0},
+		{"c", ws.ProgressStepRunning, 0},
+		{"c", ws.ProgressStepFailed, 0},
+		{"d", ws.ProgressStepSkipped, 0},
+	}
+	require.Len(t, rec.steps, len(want))
+	for i, w := range want {
+		got := rec.steps[i]
+		assert.Equal(t, w.name, got.Name, "step %d", i)
+		assert.Equal(t, w.status, got.Status, "step %d", i)
+		assert.Len(t, got.Tools, w.tools, "step %d", i)
+		assert.Equal(t, 4, got.Total)
+	}
+	assert.Equal(t, ws.ProgressStepCompleted, rec.steps[2].Tools[0].Status, "same tool ID replaces the event")
+	assert.Equal(t, "boom", rec.steps[6].Detail)
+
+	// A nil reporter ignores every call.
+	var none *stepReporter
+	none.begin("a")
+	none.end(true, "")
+	none.skipRest("")
+	assert.Nil(t, newStepReporter(nil, "a"))
+}
+
+func TestBuildExecutor_ReportsSteps(t *testing.T) {
+	rec := &stepRecorder{}
+	result := NewBuildExecutor().ExecuteWithProgress(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     t.TempDir(),
+		Command:     "exit 2",
+	}, rec.record)
+
+	require.False(t, result.Success)
+	final := rec.final()
+	assert.Equal(t, ws.ProgressStepCompleted, final[stepPrepare].Status)
+	assert.Equal(t, ws.ProgressStepFailed, final[stageBuild].Status)
+	assert.Equal(t, "exit code 2", final[stageBuild].Detail)
+	assert.Equal(t, 100, final[stageBuild].Percent())
+}
+
+func TestBuildExecutor_MatrixReportsTargets(t *testing.T) {
+	rec := &stepRecorder{}
+	result := NewBuildExecutor().ExecuteWithProgress(context.Background(), ws.BuildRequestPayload{
+		ExecutionID: "build-1",
+		WorkDir:     t.TempDir(),
+		Command:     "true",
+		Matrix:      []ws.BuildTarget{{Name: "ok"}, {Name: "broken", Command: "exit 3"}},
+	}, rec.record)
+
+	require.False(t, result.Success)
+	build := rec.final()[stageBuild]
+	assert.Equal(t, ws.ProgressStepFailed, build.Status)
+	assert.Equal(t, 1, build.Total)
+	require.Len(t, build.Tools, 2)
+	status := map[string]string{}
+	for _, tool := range build.Tools {
+		status[tool.Name] = tool.Status
+	}
+	assert.Equal(t, map[string]string{"ok": ws.ProgressStepCompleted, "broken": ws.ProgressStepFailed}, status)
+}
+
+func TestQAExecutor_ReportsSkippedStages(t *testing.T) {
+	rec := &stepRecorder{}
+	result := NewQAPipelineExecutor(WithQAArtifactDir(t.TempDir())).ExecuteWithProgress(context.Background(), ws.QARequestPayload{
+		ExecutionID:  "qa-1",
+		WorkDir:      t.TempDir(),
+		BuildCommand: "exit 1",
+		TestCommand:  "echo never",
+		Timeout:      30,
+	}, rec.record)
+
+	require.False(t, result.Success)
+	final := rec.final()
+	assert.Equal(t, ws.ProgressStepFailed, final[stageBuild].Status)
+	assert.Equal(t, ws.ProgressStepSkipped, final[stageTest].Status)
+	assert.Equal(t, ws.ProgressStepCompleted, final[stageCleanup].Status)
+	assert.Equal(t, 3, final[stageCleanup].Total)
+}
@@ -0,0 +1,246 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/config"
+)
+
+// fileWriteTools는 입력에 쓰기 대상 파일 경로가 있는 도구 이름입니다 (Claude 훅, Codex 승인 요청).
+var fileWriteTools = map[string]bool{
+	"Write":        true,
+	"Edit":         true,
+	"MultiEdit":    true,
+	"NotebookEdit": true,
+	"file_change":  true,
+}
+
+// shellTools는 입력이 셸 명령인 도구 이름입니다.
+var shellTools = map[string]bool{
+	"Bash":              true,
+	"command_execution": true,
+}
+
+// ProviderSandbox는 프로바이더가 실행하는 도구 호출의 파일 쓰기를 작업 디렉토리와
+// 허용된 쓰기 루트로 제한합니다. 승인 핸들러를 감싸는 방식이므로 ApprovalRelay로
+// 도구 호출을 노출하는 프로바이더(claude interactive, codex app-server)에만 적용됩니다.
+type ProviderSandbox struct {
+	// roots는 설정으로 추가한 쓰기 허용 디렉토리입니다 (정규화된 절대 경로).
+	roots []string
+}
+
+// NewProviderSandbox는 설정에서 ProviderSandbox를 생성합니다. 비활성화되어 있으면 nil을 반환합니다.
+func NewProviderSandbox(cfg config.ProviderSandboxConfig) *ProviderSandbox {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &ProviderSandbox{roots: expandAndCleanPaths(cfg.AllowedWriteRoots)}
+}
+
+// Begin은 실행 하나의 쓰기 범위를 만듭니다. 작업 디렉토리(비어 있으면 현재 디렉토리)와
+// 시스템 임시 디렉토리는 항상 허용됩니다.
+func (s *ProviderSandbox) Begin(workDir string) *WriteScope {
+	if workDir == "" {
+		workDir, _ = os.Getwd()
+	}
+	workDir = expandAndCleanPaths([]string{workDir})[0]
+
+	roots := deduplicate(append([]string{workDir, filepath.Clean(os.TempDir())}, s.roots...))
+	resolved := make([]string, 0, len(roots))
+	for _, root := range roots {
+		resolved = append(resolved, resolveExistingPath(root))
+	}
+	return &WriteScope{workDir: workDir, roots: roots, resolvedRoots: deduplicate(resolved)}
+}
+
+// WriteScope는 실행 하나의 쓰기 허용 범위와 위반 기록입니다.
+type WriteScope struct {
+	// workDir는 상대 경로를 해석하는 기준 디렉토리입니다.
+	workDir string
+	// roots는 보고용 쓰기 허용 디렉토리입니다.
+	roots []string
+	// resolvedRoots는 심볼릭 링크를 해석한 쓰기 허용 디렉토리입니다.
+	resolvedRoots []string
+
+	mu         sync.Mutex
+	violations []ws.ProviderSandboxViolation
+}
+
+// Wrap은 도구 호출을 먼저 쓰기 범위로 검사한 뒤 next에 넘기는 승인 핸들러를 반환합니다.
+// 파일 쓰기 도구가 범위를 벗어나면 next를 호출하지 않고 거부합니다.
+// 셸 명령에서 추정한 범위 밖 쓰기는 경고로만 기록합니다. next가 nil이면 허용합니다.
+func (w *WriteScope) Wrap(next approval.ApprovalHandler) approval.ApprovalHandler {
+	return func(ctx context.Context, req approval.ToolApprovalRequest) (approval.ToolApprovalDecision, error) {
+		if path, ok := w.checkToolCall(req.ToolName, req.ToolInput); !ok {
+			return approval.ToolApprovalDecision{
+				Decision:  "deny",
+				Reason:    fmt.Sprintf("provider sandbox: %s is outside the allowed write roots", path),
+				DecidedBy: "policy",
+				DecidedAt: time.Now(),
+			}, nil
+		}
+		if next == nil {
+			return approval.ToolApprovalDecision{
+				Decision:  "allow",
+				Reason:    "provider sandbox: within the allowed write roots",
+				DecidedBy: "policy",
+				DecidedAt: time.Now(),
+			}, nil
+		}
+		return next(ctx, req)
+	}
+}
+
+// Report는 실행 결과에 첨부할 쓰기 범위 보고서를 반환합니다.
+// enforced는 프로바이더가 도구 호출을 노출하여 실제로 검사했는지 여부입니다.
+func (w *WriteScope) Report(enforced bool) *ws.ProviderSandboxReport {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return &ws.ProviderSandboxReport{
+		Enforced:   enforced,
+		Roots:      w.roots,
+		Violations: append([]ws.ProviderSandboxViolation(nil), w.violations...),
+	}
+}
+
+// checkToolCall은 도구 호출의 쓰기 대상을 검사합니다.
+// 거부해야 하면 범위를 벗어난 경로와 false를 반환합니다.
+func (w *WriteScope) checkToolCall(toolName string, input json.RawMessage) (string, bool) {
+	var fields map[string]any
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return "", true
+	}
+
+	if fileWriteTools[toolName] {
+		for _, key := range []string{"file_path", "notebook_path", "filePath", "path"} {
+			path, _ := fields[key].(string)
+			if path == "" {
+				continue
+			}
+			if !w.allows(path) {
+				w.record(ws.ProviderSandboxViolation{Tool: toolName, Path: path, Blocked: true})
+				return path, false
+			}
+		}
+		return "", true
+	}
+
+	if shellTools[toolName] {
+		command, _ := fields["command"].(string)
+		for _, path := range shellWriteTargets(command) {
+			if !w.allows(path) {
+				w.record(ws.ProviderSandboxViolation{Tool: toolName, Path: path, Command: command})
+			}
+		}
+	}
+	return "", true
+}
+
+// allows는 경로가 쓰기 허용 범위 안에 있는지 확인합니다.
+// 상대 경로는 작업 디렉토리 기준으로 해석하고, 존재하는 상위 디렉토리의 심볼릭 링크를 해석하여 탈출을 막습니다.
+func (w *WriteScope) allows(path string) bool {
+	path = expandTilde(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(w.workDir, path)
+	}
+	resolved := resolveExistingPath(filepath.Clean(path))
+	for _, root := range w.resolvedRoots {
+		if isSubPath(resolved, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// record는 위반을 기록합니다.
+func (w *WriteScope) record(v ws.ProviderSandboxViolation) {
+	v.At = time.Now()
+	w.mu.Lock()
+	w.violations = append(w.violations, v)
+	w.mu.Unlock()
+}
+
+// resolveExistingPath는 존재하는 가장 가까운 상위 디렉토리까지 심볼릭 링크를 해석하고 나머지 경로를 붙입니다.
+// 아직 없는 파일도 실제로 만들어질 위치로 비교할 수 있습니다.
+func resolveExistingPath(path string) string {
+	rest := ""
+	for current := path; ; current = filepath.Dir(current) {
+		if resolved, err := resolveSymlinks(current); err == nil {
+			return filepath.Join(resolved, rest)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path
+		}
+		rest = filepath.Join(filepath.Base(current), rest)
+	}
+}
+
+// shellWriteTargets는 셸 명령에서 쓰기 대상으로 보이는 경로를 추정합니다.
+// 리다이렉션(>, >>), tee, 그리고 cp/mv/install/ln의 대상, rm/mkdir/touch/rmdir의 인자를 찾습니다.
+// 변수나 명령 치환이 들어간 경로는 평가할 수 없으므로 건너뜁니다.
+func shellWriteTargets(command string) []string {
+	var targets []string
+	add := func(path string) {
+		path = strings.Trim(path, `"'`)
+		if path == "" || path == "/dev/null" || strings.HasPrefix(path, "&") || strings.ContainsAny(path, "$`*?") {
+			return
+		}
+		targets = append(targets, path)
+	}
+
+	for _, segment := range splitShellSegments(command) {
+		fields := strings.Fields(segment)
+		var args []string
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// 리다이렉션: "> file", ">file", "2>> file", "&> file"
+			if idx := strings.Index(field, ">"); idx >= 0 && strings.Trim(field[:idx], "0123456789&") == "" {
+				target := strings.TrimLeft(field[idx:], ">|")
+				if target == "" && i+1 < len(fields) {
+					i++
+					target = fields[i]
+				}
+				add(target)
+				continue
+			}
+			args = append(args, field)
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		operands := make([]string, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			if !strings.HasPrefix(arg, "-") {
+				operands = append(operands, arg)
+			}
+		}
+		switch filepath.Base(args[0]) {
+		case "tee", "rm", "mkdir", "touch", "rmdir":
+			for _, operand := range operands {
+				add(operand)
+			}
+		case "cp", "mv", "install", "ln":
+			if len(operands) >= 2 {
+				add(operands[len(operands)-1])
+			}
+		}
+	}
+	return targets
+}
+
+// splitShellSegments는 셸 명령을 ;, &&, ||, | 경계로 나눕니다.
+func splitShellSegments(command string) []string {
+	replacer := strings.NewReplacer("&&", "\n", "||", "\n", ";", "\n", "|", "\n")
+	return strings.Split(replacer.Replace(command), "\n")
+}
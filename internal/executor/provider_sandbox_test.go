@@ -0,0 +1,226 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// approve는 WriteScope로 감싼 핸들러에 도구 호출 하나를 보내고 결정을 반환합니다.
+func approve(t *testing.T, handler approval.ApprovalHandler, tool string, input map[string]any) string {
+	t.Helper()
+	raw, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decision, err := handler(context.Background(), approval.ToolApprovalRequest{ToolName: tool, ToolInput: raw})
+	if err != nil {
+		t.Fatalf("핸들러 에러: %v", err)
+	}
+	return decision.Decision
+}
+
+func TestNewProviderSandbox_Disabled(t *testing.T) {
+	if NewProviderSandbox(config.ProviderSandboxConfig{AllowedWriteRoots: []string{"/tmp"}}) != nil {
+		t.Error("비활성화된 설정은 nil을 반환해야 합니다")
+	}
+}
+
+func TestWriteScope_FileWritesEnforced(t *testing.T) {
+	workDir := t.TempDir()
+	extraRoot := t.TempDir()
+	outside := filepath.Join(string(filepath.Separator), "opt", "elsewhere", "file.txt")
+	scope := NewProviderSandbox(config.ProviderSandboxConfig{
+		Enabled:           true,
+		AllowedWriteRoots: []string{extraRoot},
+	}).Begin(workDir)
+
+	var nextCalls int
+	handler := scope.Wrap(func(context.Context, approval.ToolApprovalRequest) (approval.ToolApprovalDecision, error) {
+		nextCalls++
+		return approval.ToolApprovalDecision{Decision: "allow"}, nil
+	})
+
+	tests := []struct {
+		name  string
+		tool  string
+		input map[string]any
+		want  string
+	}{
+		{name: "작업 디렉토리 Write", tool: "Write", input: map[string]any{"file_path": filepath.Join(workDir, "main.go")}, want: "allow"},
+		{name: "상대 경로 Edit", tool: "Edit", input: map[string]any{"file_path": "pkg/a.go"}, want: "allow"},
+		{name: "추가 쓰기 루트", tool: "file_change", input: map[string]any{"filePath": filepath.Join(extraRoot, "out.json")}, want: "allow"},
+		{name: "범위 밖 Write", tool: "Write", input: map[string]any{"file_path": outside}, want: "deny"},
+		{name: "상위 디렉토리 탈출", tool: "Edit", input: map[string]any{"file_path": strings.Repeat("../", 16) + "opt/escape.txt"}, want: "deny"},
+		{name: "범위 밖 file_change", tool: "file_change", input: map[string]any{"filePath": outside}, want: "deny"},
+		{name: "읽기 도구", tool: "Read", input: map[string]any{"file_path": outside}, want: "allow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := approve(t, handler, tt.tool, tt.input); got != tt.want {
+				t.Errorf("결정 = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if nextCalls != 4 {
+		t.Errorf("허용 범위의 호출만 다음 핸들러로 전달해야 합니다: %d", nextCalls)
+	}
+
+	report := scope.Report(true)
+	if !report.Enforced || len(report.Roots) < 3 {
+		t.Errorf("보고서 = %+v", report)
+	}
+	if len(report.Violations) != 3 {
+		t.Fatalf("위반 %d개, want 3: %+v", len(report.Violations), report.Violations)
+	}
+	for _, v := range report.Violations {
+		if !v.Blocked || v.At.IsZero() {
+			t.Errorf("파일 쓰기 위반은 차단으로 기록해야 합니다: %+v", v)
+		}
+	}
+}
+
+func TestWriteScope_SymlinkEscape(t *testing.T) {
+	workDir := t.TempDir()
+	target := t.TempDir()
+	if err := os.Symlink(target, filepath.Join(workDir, "link")); err != nil {
+		t.Skipf("심볼릭 링크를 만들 수 없습니다: %v", err)
+	}
+	scope := (&ProviderSandbox{}).Begin(workDir)
+	// 두 디렉토리 모두 항상 허용되는 임시 디렉토리 아래에 있으므로 작업 디렉토리만 허용하도록 좁힌다.
+	scope.resolvedRoots = []string{resolveExistingPath(workDir)}
+
+	if got := approve(t, scope.Wrap(nil), "Write", map[string]any{"file_path": filepath.Join(workDir, "link", "x.txt")}); got != "deny" {
+		t.Errorf("심볼릭 링크로 범위를 벗어나는 쓰기는 거부해야 합니다: %s", got)
+	}
+}
+
+func TestWriteScope_ShellCommandsWarnOnly(t *testing.T) {
+	workDir := t.TempDir()
+	scope := (&ProviderSandbox{}).Begin(workDir)
+	handler := scope.Wrap(nil)
+
+	for _, command := range []string{
+		"go test ./... > /opt/report.txt 2>&1",
+		"echo hi | tee -a /opt/log.txt && rm -rf build",
+	} {
+		if got := approve(t, handler, "Bash", map[string]any{"command": command}); got != "allow" {
+			t.Errorf("셸 명령은 경고만 남기고 허용해야 합니다: %s", got)
+		}
+	}
+	approve(t, handler, "command_execution", map[string]any{"command": "cp main.go /opt/main.go"})
+
+	report := scope.Report(true)
+	var paths []string
+	for _, v := range report.Violations {
+		if v.Blocked || v.Command == "" {
+			t.Errorf("셸 명령 위반은 경고로 기록해야 합니다: %+v", v)
+		}
+		paths = append(paths, v.Path)
+	}
+	if want := []string{"/opt/report.txt", "/opt/log.txt", "/opt/main.go"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("위반 경로 = %v, want %v", paths, want)
+	}
+}
+
+func TestShellWriteTargets(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{command: "make build", want: nil},
+		{command: "echo x >out.txt; cat a >> /var/log/x 2>/dev/null", want: []string{"out.txt", "/var/log/x"}},
+		{command: "mkdir -p dist && touch dist/a dist/b", want: []string{"dist", "dist/a", "dist/b"}},
+		{command: "mv a.txt b.txt /srv/data/", want: []string{"/srv/data/"}},
+		{command: `echo "$HOME" > "$OUT"`, want: nil},
+		{command: "ls 2>&1 | grep x", want: nil},
+	}
+	for _, tt := range tests {
+		if got := shellWriteTargets(tt.command); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("shellWriteTargets(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+// approvalMockProvider는 ApprovalRelay를 지원하는 테스트용 프로바이더입니다.
+type approvalMockProvider struct {
+	mockProvider
+	setCalls atomic.Int32
+}
+
+func (p *approvalMockProvider) SupportsApproval() bool { return true }
+
+func (p *approvalMockProvider) SetApprovalHandler(approval.ApprovalHandler) { p.setCalls.Add(1) }
+
+// TestTaskExecutor_ProviderSandboxConcurrentTasks는 같은 프로바이더에서 동시에 실행되는 작업이
+// 각자의 작업 디렉토리로 쓰기 범위를 적용받는지 검증합니다.
+func TestTaskExecutor_ProviderSandboxConcurrentTasks(t *testing.T) {
+	dirA, dirB := t.TempDir(), t.TempDir()
+	other := map[string]string{dirA: dirB, dirB: dirA}
+	// 임시 디렉토리는 항상 쓰기가 허용되므로 두 작업 디렉토리를 포함하지 않는 곳으로 옮긴다.
+	t.Setenv("TMPDIR", filepath.Join(dirA, "tmp"))
+
+	var entered sync.WaitGroup
+	entered.Add(2)
+	var mu sync.Mutex
+	decisions := map[string][2]string{}
+	prov := &approvalMockProvider{mockProvider: mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			// 두 작업이 모두 실행 중일 때 승인을 요청한다.
+			entered.Done()
+			entered.Wait()
+			if req.ApprovalHandler == nil {
+				t.Error("실행별 승인 핸들러가 전달되지 않았습니다")
+				return &provider.ExecuteResponse{Output: "done"}, nil
+			}
+			own := approve(t, req.ApprovalHandler, "Write", map[string]any{"file_path": filepath.Join(req.WorkDir, "a.txt")})
+			foreign := approve(t, req.ApprovalHandler, "Write", map[string]any{"file_path": filepath.Join(other[req.WorkDir], "a.txt")})
+			mu.Lock()
+			decisions[req.WorkDir] = [2]string{own, foreign}
+			mu.Unlock()
+			return &provider.ExecuteResponse{Output: "done"}, nil
+		},
+	}}
+	registry := provider.NewRegistry()
+	registry.Register(prov)
+	e := NewTaskExecutor(registry, newMockSender(), WithProviderSandbox(NewProviderSandbox(config.ProviderSandboxConfig{Enabled: true})))
+
+	var wg sync.WaitGroup
+	for i, dir := range []string{dirA, dirB} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+				ExecutionID: fmt.Sprintf("exec-%d", i),
+				Prompt:      "p",
+				Model:       "claude-sonnet",
+				WorkDir:     dir,
+			}); err != nil {
+				t.Errorf("Execute(%s) error = %v", dir, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, dir := range []string{dirA, dirB} {
+		if got := decisions[dir]; got != [2]string{"allow", "deny"} {
+			t.Errorf("%s 결정 = %v, want 자기 디렉토리 allow, 다른 작업 디렉토리 deny", dir, got)
+		}
+	}
+	if n := prov.setCalls.Load(); n != 0 {
+		t.Errorf("공유 프로바이더의 SetApprovalHandler가 %d번 호출되었습니다", n)
+	}
+}
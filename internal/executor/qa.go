@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 )
 
 // Health check polling constants.
@@ -39,17 +41,38 @@ const (
 )
 
 // QAPipelineExecutor handles QA pipeline execution with sequential stages.
-type QAPipelineExecutor struct{}
+type QAPipelineExecutor struct {
+	// artifactDir is the base directory for failure artifacts.
+	artifactDir string
+	// serviceLogTail is the number of trailing service log bytes attached to failed stages.
+	serviceLogTail int
+}
 
 // NewQAPipelineExecutor creates a new QAPipelineExecutor.
-func NewQAPipelineExecutor() *QAPipelineExecutor {
-	return &QAPipelineExecutor{}
+func NewQAPipelineExecutor(opts ...QAOption) *QAPipelineExecutor {
+	e := &QAPipelineExecutor{
+		artifactDir:    DefaultQAArtifactDir(),
+		serviceLogTail: DefaultServiceLogTailBytes,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // Execute runs the QA pipeline and returns the result.
 // Stages run sequentially: build -> service start -> test -> browser QA -> cleanup.
 // If any stage fails, remaining stages are skipped (except cleanup).
+// On failure, the managed service's log tail is attached to the failed stage and
+// full logs plus browser test media are saved as artifacts.
 func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayload) *ws.QAResultPayload {
+	return e.ExecuteWithProgress(ctx, req, nil)
+}
+
+// ExecuteWithProgress runs the pipeline like Execute and reports each requested
+// stage to onStep. Stages skipped after a failure are reported as skipped.
+func (e *QAPipelineExecutor) ExecuteWithProgress(ctx context.Context, req ws.QARequestPayload, onStep StepFunc) *ws.QAResultPayload {
+	steps := newStepReporter(onStep, qaStageNames(req)...)
 	start := time.Now()
 
 	result := &ws.QAResultPayload{
@@ -69,6 +92,7 @@ func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayloa
 			DurationMs: time.Since(start).Milliseconds(),
 			Error:      err.Error(),
 		})
+		steps.skipRest(err.Error())
 		return result
 	}
 
@@ -80,13 +104,16 @@ func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayloa
 	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Track the service process for cleanup.
+	// Track the service process for cleanup and its output for failure reports.
 	var serviceCmd *exec.Cmd
+	var serviceLog *serviceLogBuffer
 	allPassed := true
 
 	// Stage 1: Build (optional).
 	if req.BuildCommand != "" {
+		steps.begin(stageBuild)
 		stageResult := e.runBuildStage(execCtx, workDir, req.BuildCommand)
+		steps.end(stageResult.Success, stageResult.Error)
 		result.Stages = append(result.Stages, stageResult)
 		if !stageResult.Success {
 			allPassed = false
@@ -96,7 +123,10 @@ func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayloa
 	// Stage 2: Service Start (optional).
 	if allPassed && req.ServiceConfig != nil {
 		var stageResult ws.QAStageResult
-		stageResult, serviceCmd = e.runServiceStage(execCtx, workDir, req.ServiceConfig)
+		serviceLog = &serviceLogBuffer{}
+		steps.begin(stageService)
+		stageResult, serviceCmd = e.runServiceStage(execCtx, workDir, req.ServiceConfig, serviceLog)
+		steps.end(stageResult.Success, stageResult.Error)
 		result.Stages = append(result.Stages, stageResult)
 		if !stageResult.Success {
 			allPassed = false
@@ -105,7 +135,9 @@ func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayloa
 
 	// Stage 3: Test (optional).
 	if allPassed && req.TestCommand != "" {
+		steps.begin(stageTest)
 		stageResult := e.runTestStage(execCtx, workDir, req.TestCommand)
+		steps.end(stageResult.Success, stageResult.Error)
 		result.Stages = append(result.Stages, stageResult)
 		if !stageResult.Success {
 			allPassed = false
@@ -113,8 +145,12 @@ func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayloa
 	}
 
 	// Stage 4: Browser QA (optional).
+	browserQARan := false
 	if allPassed && req.BrowserQA != nil {
+		browserQARan = true
+		steps.begin(stageBrowserQA)
 		stageResult, screenshots := e.runBrowserQAStage(execCtx, workDir, req.BrowserQA)
+		steps.end(stageResult.Success, stageResult.Error)
 		result.Stages = append(result.Stages, stageResult)
 		if len(screenshots) > 0 {
 			result.Screenshots = screenshots
@@ -125,15 +161,41 @@ func (e *QAPipelineExecutor) Execute(ctx context.Context, req ws.QARequestPayloa
 	}
 
 	// Stage 5: Cleanup (always runs).
+	steps.begin(stageCleanup)
 	cleanupResult := e.runCleanupStage(serviceCmd)
+	steps.end(cleanupResult.Success, cleanupResult.Error)
 	result.Stages = append(result.Stages, cleanupResult)
 
+	// Failure reporting runs after cleanup so the service log is complete.
+	if !allPassed {
+		e.attachServiceLog(result.Stages, serviceLog)
+		result.Artifacts = e.saveFailureArtifacts(req.ExecutionID, workDir, serviceLog, browserQARan)
+	}
+
 	result.Success = allPassed
 	result.DurationMs = time.Since(start).Milliseconds()
 
 	return result
 }
 
+// qaStageNames lists the stages a request runs, in pipeline order.
+func qaStageNames(req ws.QARequestPayload) []string {
+	var names []string
+	if req.BuildCommand != "" {
+		names = append(names, stageBuild)
+	}
+	if req.ServiceConfig != nil {
+		names = append(names, stageService)
+	}
+	if req.TestCommand != "" {
+		names = append(names, stageTest)
+	}
+	if req.BrowserQA != nil {
+		names = append(names, stageBrowserQA)
+	}
+	return append(names, stageCleanup)
+}
+
 // runBuildStage executes the build command.
 func (e *QAPipelineExecutor) runBuildStage(ctx context.Context, workDir, command string) ws.QAStageResult {
 	start := time.Now()
@@ -146,7 +208,7 @@ func (e *QAPipelineExecutor) runBuildStage(ctx context.Context, workDir, command
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 
 	stageResult := ws.QAStageResult{
 		Name:       stageBuild,
@@ -165,20 +227,19 @@ func (e *QAPipelineExecutor) runBuildStage(ctx context.Context, workDir, command
 }
 
 // runServiceStage starts a background service and waits for it to become ready.
+// Service stdout/stderr is written to output for the lifetime of the process.
 // Returns the stage result and the running command (for cleanup).
-func (e *QAPipelineExecutor) runServiceStage(ctx context.Context, workDir string, cfg *ws.ServiceConfig) (ws.QAStageResult, *exec.Cmd) {
+func (e *QAPipelineExecutor) runServiceStage(ctx context.Context, workDir string, cfg *ws.ServiceConfig, output *serviceLogBuffer) (ws.QAStageResult, *exec.Cmd) {
 	start := time.Now()
 
 	cmd := exec.CommandContext(ctx, "sh", "-c", cfg.Command)
 	cmd.Dir = workDir
 	cmd.Env = os.Environ()
-
-	var output bytes.Buffer
-	cmd.Stdout = &output
-	cmd.Stderr = &output
+	cmd.Stdout = output
+	cmd.Stderr = output
 
 	// Start the service as a background process.
-	if err := cmd.Start(); err != nil {
+	if err := procgroup.Start(cmd); err != nil {
 		return ws.QAStageResult{
 			Name:       stageService,
 			Success:    false,
@@ -199,8 +260,9 @@ func (e *QAPipelineExecutor) runServiceStage(ctx context.Context, workDir string
 
 	err := e.waitForHealthCheck(healthCtx, cfg.HealthCheck)
 	if err != nil {
-		// Wait for the subprocess to finish before reading the buffer to avoid race condition.
-		_ = cmd.Wait()
+		// Stop the service tree and wait for it before reading the buffer to avoid race condition.
+		_ = procgroup.Kill(cmd)
+		_ = procgroup.Wait(cmd)
 		return ws.QAStageResult{
 			Name:       stageService,
 			Success:    false,
@@ -259,7 +321,7 @@ func (e *QAPipelineExecutor) runTestStage(ctx context.Context, workDir, command
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 
 	stageResult := ws.QAStageResult{
 		Name:       stageTest,
@@ -308,7 +370,7 @@ func (e *QAPipelineExecutor) runBrowserQAStage(ctx context.Context, workDir stri
 	cmd.Stdout = &output
 	cmd.Stderr = &output
 
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 
 	stageResult := ws.QAStageResult{
 		Name:       stageBrowserQA,
@@ -370,14 +432,17 @@ func (e *QAPipelineExecutor) runCleanupStage(serviceCmd *exec.Cmd) ws.QAStageRes
 
 	var messages []string
 
-	if serviceCmd != nil && serviceCmd.Process != nil {
-		if err := serviceCmd.Process.Kill(); err != nil {
+	if serviceCmd != nil && serviceCmd.Process != nil && serviceCmd.ProcessState == nil {
+		// Kill the whole process group so children spawned by the service (e.g. npm -> node) do not leak.
+		if err := procgroup.Kill(serviceCmd); err != nil && !errors.Is(err, os.ErrProcessDone) {
 			messages = append(messages, fmt.Sprintf("failed to kill service process: %v", err))
 		} else {
 			messages = append(messages, "service process terminated")
 		}
 		// Wait for process to fully exit to avoid zombies.
-		_ = serviceCmd.Wait()
+		_ = procgroup.Wait(serviceCmd)
+	} else if serviceCmd != nil && serviceCmd.ProcessState != nil {
+		messages = append(messages, "service process already exited")
 	} else {
 		messages = append(messages, "no service process to clean up")
 	}
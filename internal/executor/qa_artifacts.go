@@ -0,0 +1,231 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// QA failure artifact constants.
+const (
+	// DefaultServiceLogTailBytes is the size of the service log tail attached to a failed stage (16 KB).
+	DefaultServiceLogTailBytes = 16 * 1024
+	// serviceLogFileName is the file name of the full service log artifact.
+	serviceLogFileName = "service.log"
+	// browserResultsDir is Playwright's default output directory for screenshots, videos and traces.
+	browserResultsDir = "test-results"
+)
+
+// DefaultQAArtifactDir returns the default base directory for QA failure artifacts.
+func DefaultQAArtifactDir() string {
+	return filepath.Join(os.TempDir(), "autopus-qa-artifacts")
+}
+
+// QAOption configures a QAPipelineExecutor.
+type QAOption func(*QAPipelineExecutor)
+
+// WithQAArtifactDir sets the base directory where failure artifacts are saved.
+// Each execution gets its own subdirectory named after the execution ID.
+func WithQAArtifactDir(dir string) QAOption {
+	return func(e *QAPipelineExecutor) {
+		if dir != "" {
+			e.artifactDir = dir
+		}
+	}
+}
+
+// WithServiceLogTail sets how many trailing bytes of the service log are attached to a failed stage.
+func WithServiceLogTail(n int) QAOption {
+	return func(e *QAPipelineExecutor) {
+		if n > 0 {
+			e.serviceLogTail = n
+		}
+	}
+}
+
+// serviceLogBuffer collects a managed service's stdout/stderr.
+// The service keeps writing while later stages run, so access is synchronized.
+type serviceLogBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write implements io.Writer.
+func (b *serviceLogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Bytes returns a copy of the collected log.
+func (b *serviceLogBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
+
+// String returns the collected log.
+func (b *serviceLogBuffer) String() string {
+	return string(b.Bytes())
+}
+
+// Tail returns the last n bytes of the log, trimmed to a valid UTF-8 boundary.
+func (b *serviceLogBuffer) Tail(n int) string {
+	data := b.Bytes()
+	if len(data) <= n {
+		return string(data)
+	}
+	data = data[len(data)-n:]
+	// Skip continuation bytes of a rune cut in half.
+	for len(data) > 0 && !utf8.RuneStart(data[0]) {
+		data = data[1:]
+	}
+	return string(data)
+}
+
+// attachServiceLog attaches the service log tail to every failed stage.
+func (e *QAPipelineExecutor) attachServiceLog(stages []ws.QAStageResult, serviceLog *serviceLogBuffer) {
+	if serviceLog == nil {
+		return
+	}
+	tail := serviceLog.Tail(e.serviceLogTail)
+	if tail == "" {
+		return
+	}
+	for i := range stages {
+		if !stages[i].Success {
+			stages[i].ServiceLog = tail
+		}
+	}
+}
+
+// saveFailureArtifacts writes the full service log and copies browser test media
+// into a per-execution artifact directory. Saving is best-effort: an artifact that
+// cannot be written is skipped, and the directory is only created when needed.
+func (e *QAPipelineExecutor) saveFailureArtifacts(executionID, workDir string, serviceLog *serviceLogBuffer, browserQA bool) []ws.QAArtifact {
+	dir := filepath.Join(e.artifactDir, sanitizeArtifactName(executionID))
+
+	var artifacts []ws.QAArtifact
+
+	if serviceLog != nil {
+		if data := serviceLog.Bytes(); len(data) > 0 {
+			path := filepath.Join(dir, serviceLogFileName)
+			if err := writeArtifactFile(path, data); err == nil {
+				artifacts = append(artifacts, ws.QAArtifact{
+					Name:      serviceLogFileName,
+					Type:      ws.QAArtifactServiceLog,
+					Path:      path,
+					SizeBytes: int64(len(data)),
+				})
+			}
+		}
+	}
+
+	if browserQA {
+		artifacts = append(artifacts, copyBrowserArtifacts(filepath.Join(workDir, browserResultsDir), filepath.Join(dir, browserResultsDir))...)
+	}
+
+	return artifacts
+}
+
+// copyBrowserArtifacts copies screenshots, videos and traces from srcDir, preserving relative paths.
+func copyBrowserArtifacts(srcDir, dstDir string) []ws.QAArtifact {
+	var artifacts []ws.QAArtifact
+
+	_ = filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		artifactType := browserArtifactType(path)
+		if artifactType == "" {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return nil
+		}
+		dst := filepath.Join(dstDir, rel)
+		size, err := copyFile(path, dst)
+		if err != nil {
+			return nil
+		}
+		artifacts = append(artifacts, ws.QAArtifact{
+			Name:      filepath.ToSlash(rel),
+			Type:      artifactType,
+			Path:      dst,
+			SizeBytes: size,
+		})
+		return nil
+	})
+
+	return artifacts
+}
+
+// browserArtifactType classifies a Playwright output file by extension.
+func browserArtifactType(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg":
+		return ws.QAArtifactScreenshot
+	case ".webm", ".mp4":
+		return ws.QAArtifactVideo
+	case ".zip":
+		return ws.QAArtifactTrace
+	default:
+		return ""
+	}
+}
+
+// copyFile copies src to dst, creating parent directories, and returns the number of bytes copied.
+func copyFile(src, dst string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return 0, err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("copy %s: %w", src, err)
+	}
+	return n, nil
+}
+
+// writeArtifactFile writes data to path, creating parent directories.
+func writeArtifactFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// sanitizeArtifactName makes an execution ID safe to use as a directory name.
+func sanitizeArtifactName(name string) string {
+	if name == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
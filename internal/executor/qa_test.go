@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -795,3 +796,151 @@ func TestQAExecutor_MultipleHealthCheckStatuses(t *testing.T) {
 		})
 	}
 }
+
+func TestQAExecutor_TestFailure_AttachesServiceLog(t *testing.T) {
+	workDir := t.TempDir()
+	artifactDir := t.TempDir()
+
+	healthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthServer.Close()
+
+	e := NewQAPipelineExecutor(WithQAArtifactDir(artifactDir))
+
+	req := ws.QARequestPayload{
+		ExecutionID: "qa-svclog-001",
+		WorkDir:     workDir,
+		ServiceConfig: &ws.ServiceConfig{
+			// exec so that killing the shell also closes the output pipe.
+			Command:      "echo service-booted; echo db-connection-refused >&2; exec sleep 30",
+			HealthCheck:  healthServer.URL,
+			ReadyTimeout: 5,
+		},
+		TestCommand: "echo test-failed; exit 1",
+		Timeout:     30,
+	}
+
+	result := e.Execute(context.Background(), req)
+
+	if result.Success {
+		t.Fatal("expected pipeline to fail")
+	}
+
+	var testStage *ws.QAStageResult
+	for i := range result.Stages {
+		if result.Stages[i].Name == stageTest {
+			testStage = &result.Stages[i]
+		}
+	}
+	if testStage == nil {
+		t.Fatal("test stage not found")
+	}
+	if !strings.Contains(testStage.ServiceLog, "db-connection-refused") {
+		t.Errorf("expected failed stage to include service stderr, got %q", testStage.ServiceLog)
+	}
+	if result.Stages[0].ServiceLog != "" {
+		t.Error("expected successful service stage to have no service log attached")
+	}
+
+	if len(result.Artifacts) != 1 {
+		t.Fatalf("expected 1 artifact, got %d: %+v", len(result.Artifacts), result.Artifacts)
+	}
+	artifact := result.Artifacts[0]
+	if artifact.Type != ws.QAArtifactServiceLog {
+		t.Errorf("artifact type mismatch: got %s", artifact.Type)
+	}
+	if filepath.Dir(artifact.Path) != filepath.Join(artifactDir, "qa-svclog-001") {
+		t.Errorf("unexpected artifact path: %s", artifact.Path)
+	}
+	data, err := os.ReadFile(artifact.Path)
+	if err != nil {
+		t.Fatalf("failed to read service log artifact: %v", err)
+	}
+	if !strings.Contains(string(data), "service-booted") || int64(len(data)) != artifact.SizeBytes {
+		t.Errorf("unexpected service log artifact content (size %d): %q", artifact.SizeBytes, data)
+	}
+}
+
+func TestQAExecutor_Success_NoArtifacts(t *testing.T) {
+	artifactDir := t.TempDir()
+	e := NewQAPipelineExecutor(WithQAArtifactDir(artifactDir))
+
+	result := e.Execute(context.Background(), ws.QARequestPayload{
+		ExecutionID:  "qa-ok-001",
+		WorkDir:      t.TempDir(),
+		BuildCommand: "echo ok",
+		Timeout:      10,
+	})
+
+	if !result.Success {
+		t.Fatal("expected pipeline to succeed")
+	}
+	if len(result.Artifacts) != 0 {
+		t.Errorf("expected no artifacts on success, got %+v", result.Artifacts)
+	}
+	if entries, _ := os.ReadDir(artifactDir); len(entries) != 0 {
+		t.Errorf("expected artifact dir to stay empty, got %d entries", len(entries))
+	}
+}
+
+func TestQAExecutor_SaveFailureArtifacts_BrowserMedia(t *testing.T) {
+	workDir := t.TempDir()
+	artifactDir := t.TempDir()
+
+	files := map[string]string{
+		"login-chromium/test-failed-1.png": "png",
+		"login-chromium/video.webm":        "webm",
+		"login-chromium/trace.zip":         "zip",
+		"login-chromium/error-context.md":  "ignored",
+	}
+	for name, content := range files {
+		path := filepath.Join(workDir, browserResultsDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := NewQAPipelineExecutor(WithQAArtifactDir(artifactDir))
+	artifacts := e.saveFailureArtifacts("../escape", workDir, nil, true)
+
+	types := make(map[string]string)
+	for _, a := range artifacts {
+		types[a.Name] = a.Type
+		if !strings.HasPrefix(a.Path, artifactDir) {
+			t.Errorf("artifact saved outside artifact dir: %s", a.Path)
+		}
+		if _, err := os.Stat(a.Path); err != nil {
+			t.Errorf("artifact file missing: %v", err)
+		}
+	}
+	want := map[string]string{
+		"login-chromium/test-failed-1.png": ws.QAArtifactScreenshot,
+		"login-chromium/video.webm":        ws.QAArtifactVideo,
+		"login-chromium/trace.zip":         ws.QAArtifactTrace,
+	}
+	if len(types) != len(want) {
+		t.Fatalf("expected %d artifacts, got %+v", len(want), artifacts)
+	}
+	for name, typ := range want {
+		if types[name] != typ {
+			t.Errorf("artifact %s: got type %q, want %q", name, types[name], typ)
+		}
+	}
+}
+
+func TestServiceLogBuffer_Tail(t *testing.T) {
+	var b serviceLogBuffer
+	_, _ = b.Write([]byte("abc한글"))
+
+	if got := b.Tail(100); got != "abc한글" {
+		t.Errorf("Tail larger than log: got %q", got)
+	}
+	// "글" is 3 bytes; cutting 4 bytes from the end splits "한" and must drop its partial bytes.
+	if got := b.Tail(4); got != "글" {
+		t.Errorf("Tail across rune boundary: got %q, want %q", got, "글")
+	}
+}
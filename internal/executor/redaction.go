@@ -0,0 +1,201 @@
+// Package executor - 프롬프트 로그와 작업 결과의 시크릿/개인정보 가림
+package executor
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+)
+
+// 가림 규칙 이름
+const (
+	RedactionPrivateKey  = "private_key"
+	RedactionAWSKey      = "aws_access_key"
+	RedactionGitHubToken = "github_token"
+	RedactionAPIKey      = "api_key"
+	RedactionJWT         = "jwt"
+	RedactionBearer      = "bearer_token"
+	RedactionAssignment  = "secret_assignment"
+	RedactionEmail       = "email"
+	RedactionHighEntropy = "high_entropy"
+)
+
+// promptLogPreviewLength는 로그에 남기는 프롬프트 미리보기 최대 길이(rune)입니다.
+const promptLogPreviewLength = 200
+
+// redactionRule은 이름 붙은 가림 정규식입니다.
+// group이 0보다 크면 해당 캡처 그룹만 가립니다 (예: "password=" 뒤의 값).
+type redactionRule struct {
+	name    string
+	pattern *regexp.Regexp
+	group   int
+}
+
+// builtinRedactionRules는 항상 적용하는 기본 규칙입니다. 개인 키처럼 긴 매치를 먼저 적용합니다.
+var builtinRedactionRules = []redactionRule{
+	{name: RedactionPrivateKey, pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{name: RedactionAWSKey, pattern: awsAccessKeyPattern},
+	{name: RedactionGitHubToken, pattern: regexp.MustCompile(`(?:gh[porsu]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})`)},
+	{name: RedactionAPIKey, pattern: regexp.MustCompile(`(?:sk-(?:ant-)?[A-Za-z0-9\-_]{20,}|AIza[A-Za-z0-9\-_]{30,}|xox[abpr]-[A-Za-z0-9\-]{10,})`)},
+	{name: RedactionJWT, pattern: regexp.MustCompile(`eyJ[A-Za-z0-9\-_]+\.eyJ[A-Za-z0-9\-_]+\.[A-Za-z0-9\-_]+`)},
+	{name: RedactionBearer, pattern: regexp.MustCompile(`Bearer\s+([A-Za-z0-9\-_\.=]{16,})`), group: 1},
+	{name: RedactionAssignment, pattern: regexp.MustCompile(`(?i)(?:password|passwd|secret|api[_-]?key|access[_-]?key|auth[_-]?token|access[_-]?token)["']?\s*[=:]\s*["']?([^\s"',;]{8,})`), group: 1},
+}
+
+// emailRedactionRule은 이메일 주소 규칙입니다 (security.redaction.emails).
+var emailRedactionRule = redactionRule{
+	name:    RedactionEmail,
+	pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+}
+
+// entropyCandidatePattern은 엔트로피 검사 대상 토큰입니다 (base64/hex/토큰 문자).
+var entropyCandidatePattern = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// Redactor는 텍스트에서 시크릿과 개인정보를 [REDACTED:규칙]으로 바꿉니다.
+// nil Redactor는 텍스트를 그대로 반환하므로 가림이 비활성화되어도 그대로 호출할 수 있습니다.
+type Redactor struct {
+	rules            []redactionRule
+	entropy          bool
+	entropyThreshold float64
+	entropyMinLength int
+}
+
+// NewRedactor는 설정으로 Redactor를 생성합니다. 비활성화되어 있으면 nil을 반환합니다.
+// 사용자 규칙의 정규식이 잘못되었으면 에러를 반환합니다.
+func NewRedactor(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	r := &Redactor{
+		rules:            append([]redactionRule(nil), builtinRedactionRules...),
+		entropy:          cfg.Entropy,
+		entropyThreshold: cfg.GetEntropyThreshold(),
+		entropyMinLength: cfg.GetEntropyMinLength(),
+	}
+	for _, rule := range cfg.Rules {
+		name := strings.TrimSpace(rule.Name)
+		if name == "" || rule.Pattern == "" {
+			return nil, fmt.Errorf("가림 규칙에는 name과 pattern이 필요합니다")
+		}
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("가림 규칙 %q 정규식 오류: %w", name, err)
+		}
+		r.rules = append(r.rules, redactionRule{name: name, pattern: pattern})
+	}
+	if cfg.Emails {
+		r.rules = append(r.rules, emailRedactionRule)
+	}
+	return r, nil
+}
+
+// Redact는 text의 시크릿/개인정보를 가린 결과와 규칙별 가림 횟수를 반환합니다.
+// 가린 것이 없으면 counts는 nil입니다.
+func (r *Redactor) Redact(text string) (string, map[string]int) {
+	if r == nil || text == "" {
+		return text, nil
+	}
+	var counts map[string]int
+	count := func(name string) {
+		if counts == nil {
+			counts = make(map[string]int)
+		}
+		counts[name]++
+	}
+
+	for _, rule := range r.rules {
+		text = replaceRule(text, rule, count)
+	}
+	if r.entropy {
+		text = entropyCandidatePattern.ReplaceAllStringFunc(text, func(token string) string {
+			if !r.looksLikeSecret(token) {
+				return token
+			}
+			count(RedactionHighEntropy)
+			return redactedMarker(RedactionHighEntropy)
+		})
+	}
+	return text, counts
+}
+
+// PromptPreview는 로그에 남길 가린 프롬프트 미리보기와 가림 횟수 합계를 반환합니다.
+func (r *Redactor) PromptPreview(prompt string) (string, int) {
+	redacted, counts := r.Redact(prompt)
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if runes := []rune(redacted); len(runes) > promptLogPreviewLength {
+		redacted = string(runes[:promptLogPreviewLength]) + "…"
+	}
+	return redacted, total
+}
+
+// replaceRule은 rule에 일치하는 부분(또는 캡처 그룹)을 가립니다.
+func replaceRule(text string, rule redactionRule, count func(string)) string {
+	matches := rule.pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if rule.group > 0 && len(m) > 2*rule.group+1 && m[2*rule.group] >= 0 {
+			start, end = m[2*rule.group], m[2*rule.group+1]
+		}
+		// 앞선 규칙이 이미 가린 부분은 다시 세지 않는다.
+		if strings.HasPrefix(text[start:end], "[REDACTED:") {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(redactedMarker(rule.name))
+		count(rule.name)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// looksLikeSecret은 token이 충분히 길고 문자와 숫자가 섞인 무작위 문자열인지 판단합니다.
+// 16진수 해시(git SHA 등)는 문자당 엔트로피가 최대 4bit이므로 기본 임계값에서 가리지 않습니다.
+func (r *Redactor) looksLikeSecret(token string) bool {
+	if len(token) < r.entropyMinLength {
+		return false
+	}
+	var hasLetter, hasDigit bool
+	for _, c := range token {
+		switch {
+		case unicode.IsLetter(c):
+			hasLetter = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		}
+	}
+	return hasLetter && hasDigit && shannonEntropy(token) >= r.entropyThreshold
+}
+
+// shannonEntropy는 문자당 섀넌 엔트로피(bit)를 계산합니다.
+func shannonEntropy(s string) float64 {
+	freq := make(map[rune]int)
+	n := 0
+	for _, c := range s {
+		freq[c]++
+		n++
+	}
+	var entropy float64
+	for _, f := range freq {
+		p := float64(f) / float64(n)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// redactedMarker는 가린 부분을 대신할 표시입니다.
+func redactedMarker(rule string) string {
+	return "[REDACTED:" + rule + "]"
+}
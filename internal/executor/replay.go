@@ -0,0 +1,65 @@
+// Package executor - 프로바이더 실행 기록/재생
+// 기록 모드에서는 성공한 프로바이더 응답을 요청 해시별로 저장하고,
+// 재생 모드에서는 CLI를 실행하지 않고 저장된 응답을 돌려줍니다 (결정적 통합 테스트, 오프라인 데모).
+package executor
+
+import (
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// WithProviderReplay는 프로바이더 실행 기록/재생 모드를 설정합니다.
+// mode가 provider.ReplayOff이거나 store가 nil이면 프로바이더를 그대로 실행합니다.
+func WithProviderReplay(store *provider.ReplayStore, mode provider.ReplayMode) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		if store == nil || mode == provider.ReplayOff {
+			return
+		}
+		e.replay = store
+		e.replayMode = mode
+	}
+}
+
+// executeWithReplay는 재생/기록 모드에 따라 execute를 대신하거나 감쌉니다.
+// 재생 모드에서 기록이 없으면 프로바이더를 실행하지 않고 provider.ErrReplayMiss를 반환합니다.
+func (e *TaskExecutor) executeWithReplay(executionID string, prov provider.Provider, req provider.ExecuteRequest, execute func() (*provider.ExecuteResponse, error)) (*provider.ExecuteResponse, error) {
+	if e.replay == nil {
+		return execute()
+	}
+
+	key := e.replay.Key(prov.Name(), req)
+	if e.replayMode == provider.ReplayReplay {
+		entry, err := e.replay.Load(key)
+		if err != nil {
+			e.logger.Warn().Err(err).Str("execution_id", executionID).Str("provider", prov.Name()).Msg("재생 모드: 기록된 응답 없음")
+			return nil, err
+		}
+		e.logger.Info().Str("execution_id", executionID).Str("provider", prov.Name()).Str("key", key).Msg("재생 모드: 기록된 응답 반환")
+		return entry.Response(), nil
+	}
+
+	resp, err := execute()
+	if err != nil || resp == nil || (resp.Output == "" && len(resp.ToolCalls) == 0) {
+		return resp, err
+	}
+	// 키는 원래 요청으로 계산하고, 저장하는 프롬프트와 출력은 가려서 시크릿이 기록에 남지 않게 한다.
+	saveReq, saveResp := e.redactReplayEntry(req, resp)
+	if saveErr := e.replay.Save(key, prov.Name(), saveReq, saveResp); saveErr != nil {
+		e.logger.Warn().Err(saveErr).Str("execution_id", executionID).Msg("프로바이더 응답 기록 실패")
+	} else {
+		e.logger.Debug().Str("execution_id", executionID).Str("key", key).Msg("프로바이더 응답 기록")
+	}
+	return resp, nil
+}
+
+// redactReplayEntry는 기록할 요청의 프롬프트와 응답 출력을 가린 복사본을 반환합니다.
+// 가림이 비활성화되어 있으면 그대로 반환합니다.
+func (e *TaskExecutor) redactReplayEntry(req provider.ExecuteRequest, resp *provider.ExecuteResponse) (provider.ExecuteRequest, *provider.ExecuteResponse) {
+	if e.redactor == nil {
+		return req, resp
+	}
+	req.Prompt, _ = e.redactor.Redact(req.Prompt)
+	req.SystemPrompt, _ = e.redactor.Redact(req.SystemPrompt)
+	redacted := *resp
+	redacted.Output, _ = e.redactor.Redact(resp.Output)
+	return req, &redacted
+}
@@ -9,12 +9,13 @@ import (
 	"strings"
 
 	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/errcode"
 )
 
 // 샌드박스 에러 코드
 const (
 	// ErrorCodeSandboxViolation은 샌드박스 정책 위반 시 사용됩니다.
-	ErrorCodeSandboxViolation = "SANDBOX_VIOLATION"
+	ErrorCodeSandboxViolation = errcode.SandboxViolation
 )
 
 // defaultDeniedPaths는 항상 거부되는 경로 목록입니다.
@@ -16,27 +16,35 @@ import (
 
 	"github.com/insajin/autopus-agent-protocol"
 	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/i18n"
 	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/insajin/autopus-bridge/internal/tracing"
 	"github.com/insajin/autopus-bridge/internal/websocket"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // 에러 코드 상수
 const (
 	// ErrorCodeProviderNotFound는 모델의 프로바이더가 등록되지 않았을 때 사용됩니다.
 	// 백엔드의 BridgeErrCodeProviderNotFound("provider_not_found")와 동일한 값 사용.
-	ErrorCodeProviderNotFound = "provider_not_found"
+	ErrorCodeProviderNotFound = errcode.ProviderNotFound
 	// ErrorCodeProviderError는 프로바이더 API 에러 시 사용됩니다.
-	ErrorCodeProviderError = "PROVIDER_ERROR"
+	ErrorCodeProviderError = errcode.ProviderError
 	// ErrorCodeTimeout은 작업 타임아웃 시 사용됩니다.
-	ErrorCodeTimeout = "TIMEOUT"
+	ErrorCodeTimeout = errcode.Timeout
 	// ErrorCodeCancelled는 컨텍스트 취소 시 사용됩니다.
-	ErrorCodeCancelled = "CANCELLED"
+	ErrorCodeCancelled = errcode.Cancelled
 	// ErrorCodeInternalError는 예상치 못한 에러 시 사용됩니다.
-	ErrorCodeInternalError = "INTERNAL_ERROR"
+	ErrorCodeInternalError = errcode.InternalError
 	// ErrorCodeSandboxViolationTask는 샌드박스 정책 위반 시 사용됩니다.
 	// SEC-P2-03: 작업 디렉토리 샌드박싱
-	ErrorCodeSandboxViolationTask = "SANDBOX_VIOLATION"
+	ErrorCodeSandboxViolationTask = errcode.SandboxViolation
+	// ErrorCodeUnsupportedModel은 서버가 고정(pinned)한 provider/model/승인 정책을 로컬에서 만족할 수 없을 때 사용됩니다.
+	ErrorCodeUnsupportedModel = errcode.UnsupportedModel
 )
 
 // 실행 관련 상수
@@ -84,6 +92,30 @@ type TaskExecutor struct {
 	// sandbox는 작업 디렉토리 샌드박스입니다.
 	// SEC-P2-03: 작업 디렉토리 샌드박싱
 	sandbox *Sandbox
+	// isolator는 작업 디렉토리 격리 실행기입니다. nil이면 work_dir에서 직접 실행합니다.
+	isolator *WorkDirIsolator
+	// environment는 결과에 첨부할 환경 스냅샷 수집기입니다. nil이면 첨부하지 않습니다.
+	environment *EnvironmentCollector
+	// checkpoints는 작업 체크포인트 저장소입니다. nil이면 체크포인트를 저장하지 않습니다.
+	checkpoints *CheckpointStore
+	// transcripts는 실행 단위 트랜스크립트 저장소입니다. nil이면 트랜스크립트를 기록하지 않습니다.
+	transcripts *TranscriptStore
+	// history는 로컬 실행 이력입니다. nil이면 이력을 기록하지 않습니다.
+	history *HistoryStore
+	// workDirs는 작업 디렉토리 허용 루트/기본값 정책입니다. nil이면 요청한 work_dir을 그대로 사용합니다.
+	workDirs *WorkDirPolicy
+	// conversations는 conversation_id별 프로바이더 세션 저장소입니다. nil이면 대화 연속성을 사용하지 않습니다.
+	conversations *ConversationStore
+	// redactor는 프롬프트 로그와 작업 결과의 시크릿/개인정보를 가립니다. nil이면 가리지 않습니다.
+	redactor *Redactor
+	// providerSandbox는 프로바이더 도구 호출의 파일 쓰기 범위를 제한합니다. nil이면 제한하지 않습니다.
+	providerSandbox *ProviderSandbox
+	// replay는 프로바이더 응답 기록/재생 저장소입니다. nil이면 프로바이더를 그대로 실행합니다.
+	replay *provider.ReplayStore
+	// replayMode는 replay 저장소의 사용 방식입니다 (record, replay).
+	replayMode provider.ReplayMode
+	// activeCheckpoints는 이 프로세스에서 실행 중인 작업의 실행 ID 집합입니다.
+	activeCheckpoints sync.Map // executionID -> struct{}
 	// logger는 로거입니다.
 	logger zerolog.Logger
 	// currentTask는 현재 실행 중인 작업입니다.
@@ -122,6 +154,56 @@ func WithSandbox(sandbox *Sandbox) TaskExecutorOption {
 	}
 }
 
+// WithWorkDirIsolation은 작업을 work_dir의 복사본에서 실행하도록 설정합니다.
+func WithWorkDirIsolation(isolator *WorkDirIsolator) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.isolator = isolator
+	}
+}
+
+// WithEnvironmentCollector는 작업 결과에 환경 스냅샷을 첨부하도록 설정합니다.
+func WithEnvironmentCollector(collector *EnvironmentCollector) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.environment = collector
+	}
+}
+
+// WithCheckpointStore는 장시간 작업의 실행 상태를 체크포인트로 저장하도록 설정합니다.
+// Bridge 재시작 후 task_resume으로 마지막 체크포인트부터 재개할 수 있습니다.
+func WithCheckpointStore(store *CheckpointStore) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.checkpoints = store
+	}
+}
+
+// WithTranscriptStore는 실행 단위 트랜스크립트 저장소를 설정합니다.
+func WithTranscriptStore(store *TranscriptStore) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.transcripts = store
+	}
+}
+
+// WithRedactor는 프롬프트 로그와 task_result 출력에 적용할 가림 규칙을 설정합니다.
+func WithRedactor(redactor *Redactor) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.redactor = redactor
+	}
+}
+
+// WithProviderSandbox는 프로바이더 도구 호출의 파일 쓰기를 작업 디렉토리와 허용된 쓰기 루트로 제한합니다.
+func WithProviderSandbox(sandbox *ProviderSandbox) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.providerSandbox = sandbox
+	}
+}
+
+// WithConversationStore는 같은 conversation_id의 작업이 프로바이더 세션을 이어서 사용하도록 설정합니다.
+func WithConversationStore(store *ConversationStore) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.conversations = store
+	}
+}
+
 // NewTaskExecutor는 새로운 작업 실행기를 생성합니다.
 func NewTaskExecutor(registry *provider.Registry, sender TaskSender, opts ...TaskExecutorOption) *TaskExecutor {
 	e := &TaskExecutor{
@@ -215,7 +297,7 @@ func (e *TaskExecutor) runLoop(ctx context.Context) {
 // REQ-S-03: 실행 중 새 요청 큐잉
 func (e *TaskExecutor) Submit(task ws.TaskRequestPayload) error {
 	if !e.running.Load() {
-		return errors.New("실행기가 중지된 상태입니다")
+		return errors.New(i18n.T("task.error.stopped"))
 	}
 
 	err := e.queue.Add(task)
@@ -239,13 +321,30 @@ func (e *TaskExecutor) Submit(task ws.TaskRequestPayload) error {
 // Execute는 작업을 즉시 실행합니다.
 // websocket.TaskExecutor 인터페이스 구현을 위해 제공됩니다.
 func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload) (ws.TaskResultPayload, error) {
-	// 타임아웃 설정 (REQ-N-03)
-	timeout := time.Duration(task.Timeout) * time.Second
-	if timeout <= 0 {
-		timeout = DefaultTimeout
-	}
+	ctx, span := tracing.Start(ctx, "task.execute", trace.WithAttributes(
+		attribute.String("autopus.execution_id", task.ExecutionID),
+		attribute.String("autopus.provider", task.Provider),
+		attribute.String("autopus.model", task.Model),
+	))
+	e.beginHistory(HistoryEntry{
+		ExecutionID: task.ExecutionID,
+		Kind:        HistoryKindTask,
+		Agent:       task.Provider,
+		Model:       task.Model,
+		WorkDir:     task.WorkDir,
+		Prompt:      task.Prompt,
+	})
+	result, err := e.execute(ctx, task)
+	e.finishHistory(task.ExecutionID, result.ExitCode, result.TokenUsage, err)
+	tracing.End(span, err)
+	return result, err
+}
 
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+// execute는 Execute의 실제 실행 로직입니다.
+func (e *TaskExecutor) execute(ctx context.Context, task ws.TaskRequestPayload) (ws.TaskResultPayload, error) {
+	// 타임아웃 설정 (REQ-N-03). 라우터가 타임아웃 계층으로 정한 값이 Timeout으로 전달된다.
+	timeout := timeouts.ForRequest(ws.AgentMsgTaskReq, task.Timeout, DefaultTimeout)
+	execCtx, cancel := timeouts.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// 현재 작업 등록
@@ -260,8 +359,23 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 	e.logger.Info().
 		Str("execution_id", task.ExecutionID).
 		Str("model", task.Model).
-		Dur("timeout", timeout).
+		Dur("timeout", timeout.Timeout).
 		Msg("작업 실행 시작")
+	if e.logger.Debug().Enabled() {
+		preview, redactions := e.redactor.PromptPreview(task.Prompt)
+		e.logger.Debug().
+			Str("execution_id", task.ExecutionID).
+			Str("prompt", preview).
+			Int("redactions", redactions).
+			Msg("작업 프롬프트")
+	}
+
+	// 작업 디렉토리 결정: 기본 디렉토리, 허용 루트 검증, 실행별 하위 디렉토리
+	resolvedWorkDir, workDirErr := e.resolveWorkDir(task.ExecutionID, task.WorkDir, task.NewWorkDir)
+	if workDirErr != nil {
+		return ws.TaskResultPayload{}, workDirErr
+	}
+	task.WorkDir = resolvedWorkDir
 
 	// SEC-P2-03: 샌드박스 검증 - WorkDir가 허용된 경로인지 확인
 	if e.sandbox != nil {
@@ -273,7 +387,7 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 				Msg("샌드박스 정책 위반")
 			return ws.TaskResultPayload{}, &TaskError{
 				Code:      ErrorCodeSandboxViolationTask,
-				Message:   fmt.Sprintf("작업 디렉토리 접근 거부: %v", err),
+				Message:   i18n.T("task.error.sandbox_denied", err),
 				Retryable: false,
 			}
 		}
@@ -286,7 +400,29 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 			Str("execution_id", task.ExecutionID).
 			Msg("서버에서 빈 provider/model 수신, 기본 프로바이더로 폴백")
 	}
-	resolution, err := e.registry.ResolveForTask(task.Provider, task.Model)
+	var resolution *provider.ModelResolution
+	var err error
+	if task.Pinned {
+		// 고정 실행: 요청한 provider/model/승인 정책을 만족하지 못하면 폴백하지 않고 실패
+		resolution, err = e.registry.ResolvePinned(task.Provider, task.Model, task.ApprovalPolicy)
+	} else {
+		resolution, err = e.registry.ResolveForTask(task.Provider, task.Model)
+	}
+	var pinErr *provider.PinError
+	if errors.As(err, &pinErr) {
+		e.logger.Warn().
+			Str("execution_id", task.ExecutionID).
+			Str("provider", task.Provider).
+			Str("model", task.Model).
+			Str("approval_policy", task.ApprovalPolicy).
+			Str("reason", pinErr.Reason).
+			Msg("고정 실행 조건을 만족하는 로컬 프로바이더 없음")
+		return ws.TaskResultPayload{}, &TaskError{
+			Code:         ErrorCodeUnsupportedModel,
+			Message:      i18n.T("task.error.unsupported_model", pinErr.Reason),
+			Alternatives: modelAlternatives(pinErr.Alternatives),
+		}
+	}
 	if err != nil {
 		e.logger.Error().
 			Str("execution_id", task.ExecutionID).
@@ -296,12 +432,13 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 			Msg("프로바이더 조회 실패")
 		return ws.TaskResultPayload{}, &TaskError{
 			Code:    ErrorCodeProviderNotFound,
-			Message: fmt.Sprintf("모델 '%s'에 대한 프로바이더를 찾을 수 없습니다: %v", task.Model, err),
+			Message: i18n.T("task.error.provider_not_found", task.Model, err),
 		}
 	}
 
 	prov := resolution.Provider
 	execModel := resolution.Model
+	e.resolveHistory(task.ExecutionID, prov.Name(), execModel)
 
 	// Resolution source별 로깅
 	switch resolution.Source {
@@ -321,16 +458,20 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 			Msg("오버라이드 미설정, 기본 프로바이더 폴백")
 	}
 
-	// SPEC-INTERACTIVE-CLI-001: ApprovalRelay 지원 프로바이더에 승인 핸들러 설정
+	// SPEC-INTERACTIVE-CLI-001: ApprovalRelay 지원 프로바이더의 실행별 승인 핸들러
+	// (프로바이더 샌드박스가 있으면 작업 디렉토리 확정 후 감싼다)
+	relay, hasRelay := prov.(approval.ApprovalRelay)
+	hasRelay = hasRelay && relay.SupportsApproval()
+	var approvalHandler approval.ApprovalHandler
 	if task.ApprovalPolicy != "" && task.ApprovalPolicy != string(approval.ApprovalPolicyAutoExecute) {
-		if relay, ok := prov.(approval.ApprovalRelay); ok && relay.SupportsApproval() {
+		if hasRelay {
 			policy := approval.ApprovalPolicy(task.ApprovalPolicy)
 			timeout := 5 * time.Minute
 			if task.Timeout > 0 {
 				timeout = time.Duration(task.Timeout) * time.Second
 			}
 			router := approval.NewApprovalRouter(policy, timeout)
-			relay.SetApprovalHandler(router.HandleApproval)
+			approvalHandler = router.HandleApproval
 
 			e.logger.Info().
 				Str("execution_id", task.ExecutionID).
@@ -340,6 +481,70 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 		}
 	}
 
+	// 실행 범위 자격 증명: 이 실행의 프로바이더 프로세스에만 전달하고 완료 시 지운다.
+	// 작업마다 프로세스를 띄우지 않는 프로바이더는 Env를 무시하므로 자격 증명 없이 실행하지 않고 거절한다.
+	if len(task.Credentials) > 0 && !provider.SupportsExecutionEnv(prov) {
+		e.logger.Error().
+			Str("execution_id", task.ExecutionID).
+			Str("provider", prov.Name()).
+			Msg("프로바이더가 실행 범위 자격 증명을 전달할 수 없습니다")
+		return ws.TaskResultPayload{}, &TaskError{
+			Code:    ErrorCodeCredentialsFailed,
+			Message: i18n.T("task.error.credentials_unsupported", prov.Name()),
+		}
+	}
+	creds, err := materializeCredentials(task.ExecutionID, task.Credentials, time.Now())
+	if err != nil {
+		e.logger.Error().
+			Str("execution_id", task.ExecutionID).
+			Err(err).
+			Msg("작업 자격 증명 준비 실패")
+		return ws.TaskResultPayload{}, &TaskError{
+			Code:    ErrorCodeCredentialsFailed,
+			Message: i18n.T("task.error.credentials_failed", err),
+		}
+	}
+	defer creds.Wipe()
+
+	// 작업 디렉토리 격리: 복사본에서 실행하고 완료 후 변경 사항을 반영한다.
+	workDir := task.WorkDir
+	var isolated *IsolatedWorkDir
+	if e.isolator != nil && task.WorkDir != "" {
+		isolated, err = e.isolator.Prepare(task.ExecutionID, task.WorkDir)
+		if err != nil {
+			e.logger.Error().
+				Str("execution_id", task.ExecutionID).
+				Str("work_dir", task.WorkDir).
+				Err(err).
+				Msg("작업 디렉토리 격리 실패")
+			return ws.TaskResultPayload{}, &TaskError{
+				Code:      ErrorCodeIsolationFailed,
+				Message:   i18n.T("task.error.isolation_failed", err),
+				Retryable: !errors.Is(err, ErrIsolationTooLarge),
+			}
+		}
+		workDir = isolated.Dir
+		e.logger.Info().
+			Str("execution_id", task.ExecutionID).
+			Str("work_dir", task.WorkDir).
+			Str("isolated_dir", isolated.Dir).
+			Msg("격리된 작업 디렉토리에서 실행")
+	}
+
+	// 프로바이더 샌드박스: 도구 호출의 파일 쓰기를 (격리된) 작업 디렉토리와 허용된 쓰기 루트로 제한한다.
+	var writeScope *WriteScope
+	if e.providerSandbox != nil {
+		writeScope = e.providerSandbox.Begin(workDir)
+		if hasRelay {
+			approvalHandler = writeScope.Wrap(approvalHandler)
+		} else {
+			e.logger.Warn().
+				Str("execution_id", task.ExecutionID).
+				Str("provider", prov.Name()).
+				Msg("프로바이더가 도구 호출을 노출하지 않아 파일 쓰기 범위를 강제할 수 없습니다")
+		}
+	}
+
 	// 진행 상황 보고 고루틴 시작
 	progressDone := make(chan struct{})
 	go e.reportProgress(execCtx, task.ExecutionID, progressDone)
@@ -349,40 +554,84 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 		Model:        execModel,
 		MaxTokens:    task.MaxTokens,
 		Tools:        task.Tools,
-		WorkDir:      task.WorkDir,
+		WorkDir:      workDir,
+		Env:          creds.Env(),
+		// 공유 프로바이더에 핸들러를 등록하면 동시 실행이 서로의 샌드박스를 덮어쓰므로 실행별로 전달한다.
+		ApprovalHandler: approvalHandler,
+	}
+
+	// 체크포인트: 세션 ID/단계/누적 출력을 저장하고, 재개 요청이면 저장된 세션을 이어서 실행한다.
+	checkpoint := e.beginCheckpoint(task, prov.Name(), &req)
+	defer e.finishCheckpoint(ctx, checkpoint)
+	if checkpoint != nil {
+		req.OnSession = checkpoint.onSession
+		if checkpoint.baseText != "" {
+			_ = e.sender.SendTaskProgress(ws.TaskProgressPayload{
+				ExecutionID:     task.ExecutionID,
+				Progress:        50,
+				Message:         i18n.T("task.progress.resumed"),
+				Type:            "text",
+				AccumulatedText: checkpoint.baseText,
+			})
+		}
 	}
 
+	// 대화 연속성: 같은 conversation_id의 이전 작업 세션(Claude 세션, Codex Thread)을 이어서 사용한다.
+	conversation := e.beginConversation(task, prov.Name(), &req)
+
+	transcript := e.beginTranscript(task.ExecutionID)
+	defer e.finishTranscript(task.ExecutionID, transcript)
+	transcript.Prompt(req.Prompt, req.SystemPrompt, prov.Name(), execModel)
+
 	// 스트리밍 지원 프로바이더인 경우 스트리밍 실행, 아니면 기존 방식
 	var resp *provider.ExecuteResponse
+	turnCtx, turnSpan := startProviderTurn(execCtx, prov, execModel)
 	streamCallback := func(textDelta, accumulatedText string) {
+		transcript.Delta(textDelta)
+		if checkpoint != nil {
+			checkpoint.onOutput(accumulatedText)
+			accumulatedText = checkpoint.withBase(accumulatedText)
+		}
 		_ = e.sender.SendTaskProgress(ws.TaskProgressPayload{
 			ExecutionID:     task.ExecutionID,
 			Progress:        50,
-			Message:         "스트리밍 중...",
+			Message:         i18n.T("task.progress.streaming"),
 			Type:            "text",
 			TextDelta:       textDelta,
 			AccumulatedText: accumulatedText,
 		})
 	}
-	if cliProv, ok := prov.(*provider.ClaudeCLIProvider); ok {
-		resp, err = cliProv.ExecuteStreaming(execCtx, req, streamCallback)
-	} else if appSrvProv, ok := prov.(*provider.CodexAppServerProvider); ok {
-		resp, err = appSrvProv.ExecuteStreaming(execCtx, req, streamCallback)
+	resp, err = e.executeWithReplay(task.ExecutionID, prov, req, func() (*provider.ExecuteResponse, error) {
+		if cliProv, ok := prov.(*provider.ClaudeCLIProvider); ok {
+			return cliProv.ExecuteStreaming(turnCtx, req, streamCallback)
+		} else if appSrvProv, ok := prov.(*provider.CodexAppServerProvider); ok {
+			return appSrvProv.ExecuteStreaming(turnCtx, req, streamCallback)
+		} else if compatProv, ok := prov.(*provider.OpenAICompatProvider); ok {
+			return compatProv.ExecuteStreaming(turnCtx, req, streamCallback)
+		}
+		return prov.Execute(turnCtx, req)
+	})
+	endProviderTurn(turnSpan, resp, err)
+	conversation.finish(err)
+	if err != nil {
+		transcript.Error(err)
 	} else {
-		resp, err = prov.Execute(execCtx, req)
+		transcript.Result(resp)
 	}
 
 	// 진행 상황 보고 중지
 	close(progressDone)
 
 	if err != nil {
+		e.discardIsolated(task.ExecutionID, isolated)
 		return ws.TaskResultPayload{}, e.classifyError(execCtx, err, task.ExecutionID)
 	}
 
 	// 프로바이더가 빈 응답을 반환한 경우 에러로 처리
 	// 사용량 한도 초과 등 프로바이더 오류 시 출력 없이 완료될 수 있음
 	if resp.Output == "" && len(resp.ToolCalls) == 0 {
-		errMsg := "AI 프로바이더가 빈 응답을 반환했습니다. 프로바이더 상태를 확인해주세요."
+		e.discardIsolated(task.ExecutionID, isolated)
+		errMsg := i18n.T("task.error.empty_response")
 		if resp.Error != "" {
 			errMsg = resp.Error
 		}
@@ -412,6 +661,51 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 		},
 	}
 
+	if isolated != nil {
+		// 승인 대기는 작업 타임아웃과 별개이므로 상위 컨텍스트를 사용한다.
+		changes, finishErr := e.isolator.Finish(ctx, isolated)
+		if finishErr != nil {
+			e.logger.Error().
+				Str("execution_id", task.ExecutionID).
+				Str("isolated_dir", isolated.Dir).
+				Err(finishErr).
+				Msg("격리 작업 디렉토리 변경 반영 실패")
+		}
+		if changes != nil {
+			e.logger.Info().
+				Str("execution_id", task.ExecutionID).
+				Int("changed_files", len(changes.Files)).
+				Bool("applied", changes.Applied).
+				Strs("conflicts", changes.Conflicts).
+				Str("isolated_dir", changes.IsolatedDir).
+				Msg("격리 작업 디렉토리 변경 사항")
+		}
+		result.WorkspaceChanges = changes
+	}
+
+	if e.environment != nil {
+		result.Environment = e.environment.Snapshot(ctx, task.WorkDir)
+	}
+
+	if writeScope != nil {
+		result.ProviderSandbox = writeScope.Report(hasRelay)
+		if n := len(result.ProviderSandbox.Violations); n > 0 {
+			e.logger.Warn().
+				Str("execution_id", task.ExecutionID).
+				Int("violations", n).
+				Msg("허용된 쓰기 범위 밖의 도구 호출이 있었습니다")
+		}
+	}
+
+	// 로컬 저장소의 시크릿/개인정보가 서버로 나가지 않도록 가리고, 규칙별 횟수만 보고한다.
+	result.Output, result.Redactions = e.redactor.Redact(result.Output)
+	if len(result.Redactions) > 0 {
+		e.logger.Warn().
+			Str("execution_id", task.ExecutionID).
+			Interface("redactions", result.Redactions).
+			Msg("작업 결과에서 시크릿/개인정보를 가렸습니다")
+	}
+
 	e.logger.Info().
 		Str("execution_id", task.ExecutionID).
 		Int64("duration_ms", resp.DurationMs).
@@ -422,15 +716,46 @@ func (e *TaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload)
 	return result, nil
 }
 
+// discardIsolated는 실패한 작업의 격리 복사본을 삭제합니다.
+func (e *TaskExecutor) discardIsolated(executionID string, isolated *IsolatedWorkDir) {
+	if isolated == nil {
+		return
+	}
+	if err := isolated.Cleanup(); err != nil {
+		e.logger.Warn().
+			Str("execution_id", executionID).
+			Str("isolated_dir", isolated.Dir).
+			Err(err).
+			Msg("격리 작업 디렉토리 삭제 실패")
+	}
+}
+
 // ExecuteAgentResponse executes the richer agent_response_request path.
 // It preserves native tool-loop metadata instead of coercing it into task_request.
 func (e *TaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentResponseRequestPayload) (ws.AgentResponseCompletePayload, error) {
-	timeout := time.Duration(req.Timeout) * time.Second
-	if timeout <= 0 {
-		timeout = DefaultTimeout
-	}
+	ctx, span := tracing.Start(ctx, "task.execute_agent_response", trace.WithAttributes(
+		attribute.String("autopus.execution_id", req.ExecutionID),
+		attribute.String("autopus.provider", req.Provider),
+		attribute.String("autopus.model", req.Model),
+	))
+	e.beginHistory(HistoryEntry{
+		ExecutionID: req.ExecutionID,
+		Kind:        HistoryKindAgentResponse,
+		Agent:       req.Provider,
+		Model:       req.Model,
+		WorkDir:     req.WorkDir,
+		Prompt:      req.Prompt,
+	})
+	result, err := e.executeAgentResponse(ctx, req)
+	e.finishHistory(req.ExecutionID, result.ExitCode, result.TokenUsage, err)
+	tracing.End(span, err)
+	return result, err
+}
 
-	execCtx, cancel := context.WithTimeout(ctx, timeout)
+// executeAgentResponse is the actual implementation behind ExecuteAgentResponse.
+func (e *TaskExecutor) executeAgentResponse(ctx context.Context, req ws.AgentResponseRequestPayload) (ws.AgentResponseCompletePayload, error) {
+	timeout := timeouts.ForRequest(ws.AgentMsgAgentResponseReq, req.Timeout, DefaultTimeout)
+	execCtx, cancel := timeouts.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	rt := &runningTask{
@@ -445,7 +770,7 @@ func (e *TaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentRes
 		if err := e.sandbox.ValidateWorkDir(req.WorkDir); err != nil {
 			return ws.AgentResponseCompletePayload{}, &TaskError{
 				Code:      ErrorCodeSandboxViolationTask,
-				Message:   fmt.Sprintf("작업 디렉토리 접근 거부: %v", err),
+				Message:   i18n.T("task.error.sandbox_denied", err),
 				Retryable: false,
 			}
 		}
@@ -455,19 +780,21 @@ func (e *TaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentRes
 	if err != nil {
 		return ws.AgentResponseCompletePayload{}, &TaskError{
 			Code:    ErrorCodeProviderNotFound,
-			Message: fmt.Sprintf("모델 '%s'에 대한 프로바이더를 찾을 수 없습니다: %v", req.Model, err),
+			Message: i18n.T("task.error.provider_not_found", req.Model, err),
 		}
 	}
 
 	prov := resolution.Provider
 	execModel := resolution.Model
+	e.resolveHistory(req.ExecutionID, prov.Name(), execModel)
 	log.Printf("[agent-response] 프로바이더 해석: provider=%s model=%s source=%s tool_defs=%d mode=%s", prov.Name(), execModel, resolution.Source, len(req.ToolDefinitions), req.ResponseMode)
 
+	var approvalHandler approval.ApprovalHandler
 	if req.ApprovalPolicy != "" && req.ApprovalPolicy != string(approval.ApprovalPolicyAutoExecute) {
 		if relay, ok := prov.(approval.ApprovalRelay); ok && relay.SupportsApproval() {
 			policy := approval.ApprovalPolicy(req.ApprovalPolicy)
-			router := approval.NewApprovalRouter(policy, timeout)
-			relay.SetApprovalHandler(router.HandleApproval)
+			router := approval.NewApprovalRouter(policy, timeout.Timeout)
+			approvalHandler = router.HandleApproval
 		}
 	}
 
@@ -481,9 +808,27 @@ func (e *TaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentRes
 		ResponseMode:     req.ResponseMode,
 		ToolLoopMessages: req.ToolLoopMessages,
 		ToolDefinitions:  req.ToolDefinitions,
+		ApprovalHandler:  approvalHandler,
 	}
 
-	resp, err := prov.Execute(execCtx, providerReq)
+	// 도구 루프의 후속 요청은 같은 트랜스크립트에 도구 실행 결과부터 이어서 기록한다.
+	transcript := e.beginTranscript(req.ExecutionID)
+	defer e.finishTranscript(req.ExecutionID, transcript)
+	if !transcript.Continued() {
+		transcript.Prompt(providerReq.Prompt, providerReq.SystemPrompt, prov.Name(), execModel)
+	}
+	transcript.ToolResults(pendingToolResults(req.ToolLoopMessages))
+
+	turnCtx, turnSpan := startProviderTurn(execCtx, prov, execModel)
+	resp, err := e.executeWithReplay(req.ExecutionID, prov, providerReq, func() (*provider.ExecuteResponse, error) {
+		return prov.Execute(turnCtx, providerReq)
+	})
+	endProviderTurn(turnSpan, resp, err)
+	if err != nil {
+		transcript.Error(err)
+	} else {
+		transcript.Result(resp)
+	}
 	if err != nil {
 		return ws.AgentResponseCompletePayload{}, e.classifyError(execCtx, err, req.ExecutionID)
 	}
@@ -491,7 +836,7 @@ func (e *TaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentRes
 	// 프로바이더가 빈 응답을 반환한 경우 에러로 처리
 	// 사용량 한도 초과 등 프로바이더 오류 시 출력 없이 완료될 수 있음
 	if resp.Output == "" && len(resp.ToolCalls) == 0 {
-		errMsg := "AI 프로바이더가 빈 응답을 반환했습니다. 프로바이더 상태를 확인해주세요."
+		errMsg := i18n.T("task.error.empty_response")
 		if resp.Error != "" {
 			errMsg = resp.Error
 		}
@@ -530,13 +875,36 @@ func (e *TaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentRes
 	}, nil
 }
 
+// startProviderTurn은 프로바이더 호출 1회(턴)에 대한 스팬을 시작합니다.
+func startProviderTurn(ctx context.Context, prov provider.Provider, model string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "provider.turn "+prov.Name(), trace.WithAttributes(
+		attribute.String("autopus.provider", prov.Name()),
+		attribute.String("autopus.model", model),
+	))
+}
+
+// endProviderTurn은 토큰 사용량을 기록하고 프로바이더 턴 스팬을 종료합니다.
+func endProviderTurn(span trace.Span, resp *provider.ExecuteResponse, err error) {
+	if resp != nil {
+		span.SetAttributes(
+			attribute.Int("autopus.tokens.input", resp.TokenUsage.InputTokens),
+			attribute.Int("autopus.tokens.output", resp.TokenUsage.OutputTokens),
+			attribute.Int64("autopus.duration_ms", resp.DurationMs),
+		)
+		if len(resp.Retries) > 0 {
+			span.SetAttributes(attribute.Int("autopus.provider.retries", len(resp.Retries)))
+		}
+	}
+	tracing.End(span, err)
+}
+
 // executeTask는 큐에서 가져온 작업을 실행하고 결과를 전송합니다.
 func (e *TaskExecutor) executeTask(ctx context.Context, task ws.TaskRequestPayload) {
 	// 시작 진행 상황 전송
 	_ = e.sender.SendTaskProgress(ws.TaskProgressPayload{
 		ExecutionID: task.ExecutionID,
 		Progress:    0,
-		Message:     "작업 시작",
+		Message:     i18n.T("task.progress.started"),
 		Type:        "text",
 	})
 
@@ -566,7 +934,7 @@ func (e *TaskExecutor) executeTask(ctx context.Context, task ws.TaskRequestPaylo
 	_ = e.sender.SendTaskProgress(ws.TaskProgressPayload{
 		ExecutionID: task.ExecutionID,
 		Progress:    100,
-		Message:     "작업 완료",
+		Message:     i18n.T("task.progress.completed"),
 		Type:        "text",
 	})
 
@@ -617,7 +985,7 @@ func (e *TaskExecutor) reportProgress(ctx context.Context, executionID string, d
 			payload := ws.TaskProgressPayload{
 				ExecutionID: executionID,
 				Progress:    progress,
-				Message:     "작업 실행 중...",
+				Message:     i18n.T("task.progress.running"),
 				Type:        "text",
 			}
 
@@ -637,16 +1005,22 @@ func (e *TaskExecutor) classifyError(ctx context.Context, err error, executionID
 	// 컨텍스트 관련 에러 확인
 	if ctx.Err() != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-			return &TaskError{
+			taskErr := &TaskError{
 				Code:      ErrorCodeTimeout,
-				Message:   "작업 실행 시간이 초과되었습니다",
+				Message:   i18n.T("task.error.timeout"),
 				Retryable: true,
 			}
+			// 어느 단계(요청, 메시지 타입, 기본값)의 타임아웃인지 함께 보고
+			if te, ok := timeouts.FromContext(ctx); ok {
+				taskErr.Message += ": " + te.Error()
+				taskErr.Timeout = te.Detail()
+			}
+			return taskErr
 		}
 		if errors.Is(ctx.Err(), context.Canceled) {
 			return &TaskError{
 				Code:      ErrorCodeCancelled,
-				Message:   "작업이 취소되었습니다",
+				Message:   i18n.T("task.error.cancelled"),
 				Retryable: false,
 			}
 		}
@@ -664,15 +1038,23 @@ func (e *TaskExecutor) classifyError(ctx context.Context, err error, executionID
 	if errors.Is(err, provider.ErrRateLimited) {
 		return &TaskError{
 			Code:      ErrorCodeProviderError,
-			Message:   "API 레이트 리밋 초과",
+			Message:   i18n.T("task.error.rate_limited"),
 			Retryable: true,
 		}
 	}
 
+	if errors.Is(err, provider.ErrReplayMiss) {
+		return &TaskError{
+			Code:      ErrorCodeProviderError,
+			Message:   err.Error(),
+			Retryable: false,
+		}
+	}
+
 	if errors.Is(err, provider.ErrNoAPIKey) {
 		return &TaskError{
 			Code:      ErrorCodeProviderNotFound,
-			Message:   "API 키가 설정되지 않았습니다",
+			Message:   i18n.T("task.error.no_api_key"),
 			Retryable: false,
 		}
 	}
@@ -680,7 +1062,7 @@ func (e *TaskExecutor) classifyError(ctx context.Context, err error, executionID
 	// 기타 프로바이더 에러
 	return &TaskError{
 		Code:      ErrorCodeInternalError,
-		Message:   fmt.Sprintf("작업 실행 중 오류 발생: %v", err),
+		Message:   i18n.T("task.error.internal", err),
 		Retryable: false,
 	}
 }
@@ -748,6 +1130,12 @@ type TaskError struct {
 	Message string
 	// Retryable은 재시도 가능 여부입니다.
 	Retryable bool
+	// Alternatives는 ErrorCodeUnsupportedModel일 때 로컬에서 사용 가능한 프로바이더/모델입니다.
+	Alternatives []ws.ModelAlternative
+	// AllowedWorkDirs는 ErrorCodeWorkDirNotAllowed일 때 허용된 작업 디렉토리 루트입니다.
+	AllowedWorkDirs []string
+	// Timeout은 ErrorCodeTimeout일 때 만료된 타임아웃과 그 출처입니다.
+	Timeout *ws.TimeoutDetail
 }
 
 // Error는 에러 메시지를 반환합니다.
@@ -772,5 +1160,36 @@ func (e *TaskError) IsRetryable() bool {
 	return e.Retryable
 }
 
+// ModelAlternatives는 고정 실행 실패 시 로컬 대체 프로바이더/모델 목록을 반환합니다.
+// websocket.alternativesError 인터페이스를 만족합니다.
+func (e *TaskError) ModelAlternatives() []ws.ModelAlternative {
+	return e.Alternatives
+}
+
+// WorkDirRoots는 작업 디렉토리 거부 시 허용된 루트 목록을 반환합니다.
+// websocket.workDirRootsError 인터페이스를 만족합니다.
+func (e *TaskError) WorkDirRoots() []string {
+	return e.AllowedWorkDirs
+}
+
+// TimeoutDetail은 타임아웃 에러일 때 만료된 타임아웃과 그 출처를 반환합니다.
+// websocket.timeoutDetailError 인터페이스를 만족합니다.
+func (e *TaskError) TimeoutDetail() *ws.TimeoutDetail {
+	return e.Timeout
+}
+
+// modelAlternatives는 프로바이더 대체 목록을 프로토콜 형식으로 변환합니다.
+func modelAlternatives(alts []provider.ModelAlternative) []ws.ModelAlternative {
+	out := make([]ws.ModelAlternative, 0, len(alts))
+	for _, a := range alts {
+		out = append(out, ws.ModelAlternative{
+			Provider:         a.Provider,
+			Models:           a.Models,
+			SupportsApproval: a.SupportsApproval,
+		})
+	}
+	return out
+}
+
 // Ensure TaskExecutor implements websocket.TaskExecutor interface.
 var _ websocket.TaskExecutor = (*TaskExecutor)(nil)
@@ -388,6 +388,44 @@ func TestTaskExecutor_Execute_ProviderNotFound(t *testing.T) {
 	}
 }
 
+func TestTaskExecutor_Execute_PinnedUnsupported(t *testing.T) {
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{name: "claude"})
+	registry.SetOverride(provider.OverrideConfig{ProviderName: "claude"})
+	sender := newMockSender()
+
+	executor := NewTaskExecutor(registry, sender, WithLogger(zerolog.Nop()))
+
+	task := ws.TaskRequestPayload{
+		ExecutionID: "test-exec-pinned",
+		Prompt:      "Hello",
+		Provider:    "codex",
+		Model:       "o4-mini",
+		Pinned:      true,
+		Timeout:     60,
+	}
+
+	_, err := executor.Execute(context.Background(), task)
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("TaskError가 아님: %v", err)
+	}
+	if taskErr.Code != ErrorCodeUnsupportedModel {
+		t.Errorf("에러 코드 오류: got %s, want %s", taskErr.Code, ErrorCodeUnsupportedModel)
+	}
+	alts := taskErr.ModelAlternatives()
+	if len(alts) != 1 || alts[0].Provider != "claude" {
+		t.Errorf("대체 목록 오류: %+v", alts)
+	}
+
+	// 고정하지 않으면 override 프로바이더로 폴백하여 실행된다
+	task.ExecutionID = "test-exec-unpinned"
+	task.Pinned = false
+	if _, err := executor.Execute(context.Background(), task); err != nil {
+		t.Fatalf("고정하지 않은 실행 실패: %v", err)
+	}
+}
+
 func TestTaskExecutor_Execute_Timeout(t *testing.T) {
 	registry := provider.NewRegistry()
 	registry.Register(&mockProvider{
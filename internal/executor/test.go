@@ -14,18 +14,58 @@ import (
 	"time"
 
 	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 )
 
 // TestExecutor handles test command execution.
-type TestExecutor struct{}
+type TestExecutor struct {
+	// container runs tests in Docker containers when set (see ContainerIsolator).
+	container *ContainerIsolator
+	// flakyRetries is the default number of reruns of failed tests (see WithFlakyRetries).
+	flakyRetries int
+	// flakeHistory records flaky tests per project; nil disables it.
+	flakeHistory *flakeHistory
+}
+
+// TestExecutorOption configures a TestExecutor.
+type TestExecutorOption func(*TestExecutor)
+
+// WithTestContainerIsolation enables running tests inside Docker containers.
+// Requests choose with the isolation field; the isolator's Default applies when it is empty.
+func WithTestContainerIsolation(isolator *ContainerIsolator) TestExecutorOption {
+	return func(e *TestExecutor) {
+		e.container = isolator
+	}
+}
 
 // NewTestExecutor creates a new TestExecutor.
-func NewTestExecutor() *TestExecutor {
-	return &TestExecutor{}
+func NewTestExecutor(opts ...TestExecutorOption) *TestExecutor {
+	e := &TestExecutor{flakeHistory: newFlakeHistory(defaultFlakeHistoryDir())}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // Execute runs a test command and returns the result with parsed summary.
 func (e *TestExecutor) Execute(ctx context.Context, req ws.TestRequestPayload) *ws.TestResultPayload {
+	return e.ExecuteWithProgress(ctx, req, nil)
+}
+
+// ExecuteWithProgress runs tests like Execute and reports its steps to onStep:
+// prepare, test, rerun_failed (when flaky retries are enabled, one nested event
+// per rerun) and coverage (when requested).
+func (e *TestExecutor) ExecuteWithProgress(ctx context.Context, req ws.TestRequestPayload, onStep StepFunc) *ws.TestResultPayload {
+	names := []string{stepPrepare, stageTest}
+	if e.flakyRetriesFor(req) > 0 {
+		names = append(names, stepRerun)
+	}
+	if req.Coverage || req.CoverageThreshold > 0 {
+		names = append(names, stepCoverage)
+	}
+	steps := newStepReporter(onStep, names...)
+	defer steps.skipRest("")
+
 	start := time.Now()
 
 	result := &ws.TestResultPayload{
@@ -33,17 +73,57 @@ func (e *TestExecutor) Execute(ctx context.Context, req ws.TestRequestPayload) *
 	}
 
 	// Validate work directory.
+	steps.begin(stepPrepare)
 	workDir, err := validateWorkDir(req.WorkDir)
 	if err != nil {
 		result.Success = false
 		result.Output = fmt.Sprintf("invalid work directory: %v", err)
 		result.ExitCode = 1
 		result.DurationMs = time.Since(start).Milliseconds()
+		steps.end(false, result.Output)
 		return result
 	}
 
-	// Build the test command, appending pattern filter if provided.
+	if req.CoverageThreshold < 0 || req.CoverageThreshold > 100 {
+		result.Success = false
+		result.Output = fmt.Sprintf("invalid coverage threshold %.2f: must be between 0 and 100", req.CoverageThreshold)
+		result.ExitCode = 1
+		result.DurationMs = time.Since(start).Milliseconds()
+		steps.end(false, result.Output)
+		return result
+	}
+
+	// Add coverage instrumentation for the detected stack if requested.
 	command := req.Command
+	var coverage *coverageRun
+	var coverageErr error
+	var mounts []string
+	if req.Coverage || req.CoverageThreshold > 0 {
+		reportDir, err := os.MkdirTemp("", "autopus-coverage-*")
+		if err != nil {
+			coverageErr = fmt.Errorf("create coverage report directory: %w", err)
+		} else {
+			defer os.RemoveAll(reportDir)
+			command, coverage, coverageErr = instrumentCoverage(command, reportDir, workDir)
+			// The report directory is outside work_dir, so a container needs it mounted too.
+			mounts = append(mounts, reportDir)
+		}
+	}
+
+	run, err := prepareContainerRun(e.container, req.ExecutionID, workDir, req.Isolation, req.Image, mounts...)
+	if err != nil {
+		result.Success = false
+		result.Output = fmt.Sprintf("container isolation failed: %v", err)
+		result.ExitCode = 1
+		result.DurationMs = time.Since(start).Milliseconds()
+		steps.end(false, result.Output)
+		return result
+	}
+	if run != nil {
+		result.ContainerImage = run.image
+	}
+
+	// Append pattern filter if provided.
 	if req.Pattern != "" {
 		command = command + " " + req.Pattern
 	}
@@ -57,9 +137,8 @@ func (e *TestExecutor) Execute(ctx context.Context, req ws.TestRequestPayload) *
 	defer cancel()
 
 	// Build the command using shell execution.
-	cmd := exec.CommandContext(execCtx, "sh", "-c", command)
-	cmd.Dir = workDir
-	cmd.Env = os.Environ()
+	steps.begin(stageTest)
+	cmd := shellCommand(execCtx, run, workDir, command, nil)
 
 	// Capture stdout and stderr combined.
 	var output bytes.Buffer
@@ -67,7 +146,10 @@ func (e *TestExecutor) Execute(ctx context.Context, req ws.TestRequestPayload) *
 	cmd.Stderr = &output
 
 	// Run the command.
-	err = cmd.Run()
+	err = procgroup.Run(cmd)
+	if run != nil && execCtx.Err() != nil {
+		run.cleanup()
+	}
 
 	result.Output = output.String()
 	result.DurationMs = time.Since(start).Milliseconds()
@@ -87,10 +169,84 @@ func (e *TestExecutor) Execute(ctx context.Context, req ws.TestRequestPayload) *
 
 	// Parse test output to extract summary counts.
 	result.Summary = parseTestOutput(result.Output, command)
+	steps.end(result.Success, testStepDetail(result.Summary, result.ExitCode))
+
+	// Rerun failed tests to detect flaky ones. Reruns use the uninstrumented
+	// command so they do not overwrite the coverage report of the full run.
+	if !result.Success && execCtx.Err() == nil {
+		if retries := e.flakyRetriesFor(req); retries > 0 {
+			rerunCommand := req.Command
+			if req.Pattern != "" {
+				rerunCommand = rerunCommand + " " + req.Pattern
+			}
+			steps.begin(stepRerun)
+			e.retryFailedTests(execCtx, result, run, workDir, rerunCommand, retries, steps)
+			result.DurationMs = time.Since(start).Milliseconds()
+			steps.end(result.Success, result.Flaky.Error)
+		}
+	}
+
+	if req.Coverage || req.CoverageThreshold > 0 {
+		steps.begin(stepCoverage)
+		applyCoverage(result, coverage, coverageErr, req.CoverageThreshold)
+		steps.end(result.Coverage.Error == "" && !result.Coverage.BelowThreshold, coverageStepDetail(result.Coverage))
+	}
 
 	return result
 }
 
+// testStepDetail summarizes a test run for its progress step.
+func testStepDetail(summary ws.TestSummary, exitCode int) string {
+	if summary.Total == 0 {
+		return exitDetail(exitCode)
+	}
+	return fmt.Sprintf("%d passed, %d failed, %d skipped", summary.Passed, summary.Failed, summary.Skipped)
+}
+
+// coverageStepDetail summarizes collected coverage for its progress step.
+func coverageStepDetail(coverage *ws.TestCoverage) string {
+	if coverage.Error != "" {
+		return coverage.Error
+	}
+	return fmt.Sprintf("%.2f%% total", coverage.TotalPercent)
+}
+
+// applyCoverage attaches collected coverage to result and fails the stage
+// when total coverage is below threshold. When a threshold is set and coverage
+// could not be collected, the stage also fails since it cannot be verified.
+func applyCoverage(result *ws.TestResultPayload, run *coverageRun, runErr error, threshold float64) {
+	var coverage *ws.TestCoverage
+	if runErr == nil {
+		coverage, runErr = run.collect()
+	}
+	if runErr != nil {
+		coverage = &ws.TestCoverage{Error: runErr.Error()}
+		if run != nil {
+			coverage.Tool = run.tool
+		}
+	}
+	coverage.Threshold = threshold
+	result.Coverage = coverage
+
+	if threshold <= 0 {
+		return
+	}
+	switch {
+	case coverage.Error != "":
+		coverage.BelowThreshold = true
+		result.Output += fmt.Sprintf("\ncoverage threshold %.2f%% not verified: %s", threshold, coverage.Error)
+	case coverage.TotalPercent < threshold:
+		coverage.BelowThreshold = true
+		result.Output += fmt.Sprintf("\ncoverage %.2f%% is below threshold %.2f%%", coverage.TotalPercent, threshold)
+	default:
+		return
+	}
+	result.Success = false
+	if result.ExitCode == 0 {
+		result.ExitCode = 1
+	}
+}
+
 // parseTestOutput attempts to extract test counts from command output.
 // It detects the test framework from the command and output, then applies
 // the appropriate parser.
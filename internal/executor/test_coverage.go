@@ -0,0 +1,307 @@
+// Package executor provides test execution for Local Agent Bridge.
+// Coverage instrumentation and report parsing for the test executor.
+package executor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// Coverage tools detected from the test command.
+const (
+	coverageToolGo     = "go"
+	coverageToolJest   = "jest"
+	coverageToolVitest = "vitest"
+	coverageToolPytest = "pytest"
+)
+
+// coverageRun describes how coverage is collected for one test run.
+type coverageRun struct {
+	// tool is the detected coverage tool.
+	tool string
+	// reportPath is the coverage report file written by the test command.
+	reportPath string
+	// workDir is used to relativize absolute file paths in JS/Python reports.
+	workDir string
+}
+
+// detectCoverageTool detects the coverage tool from the test command.
+// It mirrors the framework detection in parseTestOutput and returns ""
+// for commands that cannot be instrumented.
+func detectCoverageTool(command string) string {
+	cmdLower := strings.ToLower(command)
+	switch {
+	case strings.Contains(cmdLower, "go test"):
+		return coverageToolGo
+	case strings.Contains(cmdLower, "pytest"):
+		return coverageToolPytest
+	case strings.Contains(cmdLower, "vitest"):
+		return coverageToolVitest
+	case strings.Contains(cmdLower, "jest") ||
+		strings.Contains(cmdLower, "npm test") ||
+		strings.Contains(cmdLower, "npm run test") ||
+		strings.Contains(cmdLower, "yarn test") ||
+		strings.Contains(cmdLower, "pnpm test"):
+		return coverageToolJest
+	}
+	return ""
+}
+
+// instrumentCoverage adds coverage flags for the detected stack to command.
+// Reports are written under reportDir. It returns an error when the command's
+// stack does not support coverage collection.
+func instrumentCoverage(command, reportDir, workDir string) (string, *coverageRun, error) {
+	run := &coverageRun{tool: detectCoverageTool(command), workDir: workDir}
+
+	switch run.tool {
+	case coverageToolGo:
+		run.reportPath = filepath.Join(reportDir, "coverage.out")
+		flag := "go test -coverprofile=" + shellQuote(run.reportPath)
+		return strings.Replace(command, "go test", flag, 1), run, nil
+
+	case coverageToolPytest:
+		run.reportPath = filepath.Join(reportDir, "coverage.json")
+		return command + " --cov --cov-report=json:" + shellQuote(run.reportPath), run, nil
+
+	case coverageToolVitest:
+		run.reportPath = filepath.Join(reportDir, "coverage-summary.json")
+		return command + npmArgSeparator(command) +
+			" --coverage --coverage.reporter=json-summary --coverage.reportsDirectory=" + shellQuote(reportDir), run, nil
+
+	case coverageToolJest:
+		run.reportPath = filepath.Join(reportDir, "coverage-summary.json")
+		return command + npmArgSeparator(command) +
+			" --coverage --coverageReporters=json-summary --coverageDirectory=" + shellQuote(reportDir), run, nil
+	}
+
+	return command, nil, fmt.Errorf("coverage is not supported for test command %q", command)
+}
+
+// npmArgSeparator returns " --" when extra flags must be forwarded through an
+// npm script ("npm test", "npm run test") that does not already have one.
+func npmArgSeparator(command string) string {
+	trimmed := strings.TrimSpace(command)
+	if strings.HasPrefix(trimmed, "npm ") && !strings.Contains(trimmed, " -- ") && !strings.HasSuffix(trimmed, " --") {
+		return " --"
+	}
+	return ""
+}
+
+// shellQuote quotes s for use as a single sh argument.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// collect parses the coverage report written by the test command.
+func (r *coverageRun) collect() (*ws.TestCoverage, error) {
+	var (
+		packages []ws.PackageCoverage
+		err      error
+	)
+	switch r.tool {
+	case coverageToolGo:
+		packages, err = parseGoCoverProfile(r.reportPath)
+	case coverageToolPytest:
+		packages, err = parsePytestCoverageJSON(r.reportPath, r.workDir)
+	case coverageToolJest, coverageToolVitest:
+		packages, err = parseIstanbulSummary(r.reportPath, r.workDir)
+	default:
+		err = fmt.Errorf("unknown coverage tool %q", r.tool)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	coverage := &ws.TestCoverage{Tool: r.tool, Packages: packages}
+	var covered, statements int
+	for _, pkg := range packages {
+		covered += pkg.Covered
+		statements += pkg.Statements
+	}
+	coverage.TotalPercent = coveragePercent(covered, statements)
+	return coverage, nil
+}
+
+// coverageBlock accumulates statements for one package or directory.
+type coverageBlock struct {
+	covered    int
+	statements int
+}
+
+// buildPackageCoverage converts accumulated blocks into a sorted package list.
+func buildPackageCoverage(blocks map[string]*coverageBlock) []ws.PackageCoverage {
+	packages := make([]ws.PackageCoverage, 0, len(blocks))
+	for name, b := range blocks {
+		packages = append(packages, ws.PackageCoverage{
+			Package:    name,
+			Percent:    coveragePercent(b.covered, b.statements),
+			Covered:    b.covered,
+			Statements: b.statements,
+		})
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Package < packages[j].Package })
+	return packages
+}
+
+// coveragePercent returns covered/statements as a percentage rounded to two decimals.
+// A run without statements counts as fully covered.
+func coveragePercent(covered, statements int) float64 {
+	if statements == 0 {
+		return 100
+	}
+	return math.Round(float64(covered)/float64(statements)*10000) / 100
+}
+
+// parseGoCoverProfile parses a go test -coverprofile file:
+//
+//	mode: set
+//	example.com/pkg/file.go:10.2,12.3 2 1
+//
+// Each block is "file:start,end numStatements count". Blocks repeated across
+// test binaries are merged so a statement counts once.
+func parseGoCoverProfile(profilePath string) ([]ws.PackageCoverage, error) {
+	f, err := os.Open(profilePath)
+	if err != nil {
+		return nil, fmt.Errorf("open coverage profile: %w", err)
+	}
+	defer f.Close()
+
+	type block struct {
+		pkg        string
+		statements int
+		covered    bool
+	}
+	seen := make(map[string]*block)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed coverage profile line %q", line)
+		}
+		colon := strings.LastIndex(fields[0], ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("malformed coverage profile line %q", line)
+		}
+		statements, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed coverage profile line %q", line)
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("malformed coverage profile line %q", line)
+		}
+
+		b, ok := seen[fields[0]]
+		if !ok {
+			b = &block{pkg: path.Dir(fields[0][:colon]), statements: statements}
+			seen[fields[0]] = b
+		}
+		b.covered = b.covered || count > 0
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read coverage profile: %w", err)
+	}
+
+	blocks := make(map[string]*coverageBlock)
+	for _, b := range seen {
+		pkg, ok := blocks[b.pkg]
+		if !ok {
+			pkg = &coverageBlock{}
+			blocks[b.pkg] = pkg
+		}
+		pkg.statements += b.statements
+		if b.covered {
+			pkg.covered += b.statements
+		}
+	}
+	return buildPackageCoverage(blocks), nil
+}
+
+// istanbulMetric is one metric in an istanbul json-summary report.
+type istanbulMetric struct {
+	Total   int `json:"total"`
+	Covered int `json:"covered"`
+}
+
+// parseIstanbulSummary parses a jest/vitest coverage-summary.json report and
+// groups statement coverage by directory relative to workDir.
+func parseIstanbulSummary(reportPath, workDir string) ([]ws.PackageCoverage, error) {
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, fmt.Errorf("read coverage summary: %w", err)
+	}
+	var report map[string]struct {
+		Statements istanbulMetric `json:"statements"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse coverage summary: %w", err)
+	}
+
+	blocks := make(map[string]*coverageBlock)
+	for file, metrics := range report {
+		if file == "total" {
+			continue
+		}
+		addDirCoverage(blocks, file, workDir, metrics.Statements.Covered, metrics.Statements.Total)
+	}
+	return buildPackageCoverage(blocks), nil
+}
+
+// parsePytestCoverageJSON parses a coverage.py JSON report (pytest-cov
+// --cov-report=json) and groups statement coverage by directory.
+func parsePytestCoverageJSON(reportPath, workDir string) ([]ws.PackageCoverage, error) {
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return nil, fmt.Errorf("read coverage report: %w", err)
+	}
+	var report struct {
+		Files map[string]struct {
+			Summary struct {
+				CoveredLines  int `json:"covered_lines"`
+				NumStatements int `json:"num_statements"`
+			} `json:"summary"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse coverage report: %w", err)
+	}
+
+	blocks := make(map[string]*coverageBlock)
+	for file, f := range report.Files {
+		addDirCoverage(blocks, file, workDir, f.Summary.CoveredLines, f.Summary.NumStatements)
+	}
+	return buildPackageCoverage(blocks), nil
+}
+
+// addDirCoverage adds a file's statements to its directory's block.
+// Absolute paths are made relative to workDir when possible.
+func addDirCoverage(blocks map[string]*coverageBlock, file, workDir string, covered, statements int) {
+	if filepath.IsAbs(file) && workDir != "" {
+		if rel, err := filepath.Rel(workDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	dir := filepath.ToSlash(filepath.Dir(file))
+	b, ok := blocks[dir]
+	if !ok {
+		b = &coverageBlock{}
+		blocks[dir] = b
+	}
+	b.covered += covered
+	b.statements += statements
+}
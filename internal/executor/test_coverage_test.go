@@ -0,0 +1,191 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentCoverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		tool     string
+		contains []string
+	}{
+		{"go", "go test ./...", coverageToolGo, []string{"go test -coverprofile='/tmp/cov/coverage.out' ./..."}},
+		{"pytest", "python -m pytest -q", coverageToolPytest, []string{"python -m pytest -q --cov --cov-report=json:'/tmp/cov/coverage.json'"}},
+		{"jest", "npx jest", coverageToolJest, []string{"npx jest --coverage --coverageReporters=json-summary --coverageDirectory='/tmp/cov'"}},
+		{"npm test", "npm test", coverageToolJest, []string{"npm test -- --coverage"}},
+		{"vitest", "npx vitest run", coverageToolVitest, []string{"--coverage.reporter=json-summary", "--coverage.reportsDirectory='/tmp/cov'"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, run, err := instrumentCoverage(tt.command, "/tmp/cov", "/work")
+			require.NoError(t, err)
+			assert.Equal(t, tt.tool, run.tool)
+			for _, want := range tt.contains {
+				assert.Contains(t, command, want)
+			}
+		})
+	}
+
+	_, _, err := instrumentCoverage("make test", "/tmp/cov", "/work")
+	assert.Error(t, err)
+}
+
+func TestParseGoCoverProfile(t *testing.T) {
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	require.NoError(t, os.WriteFile(profile, []byte(`mode: set
+example.com/app/a/a.go:3.20,5.2 2 1
+example.com/app/a/a.go:7.20,9.2 2 0
+example.com/app/a/a.go:7.20,9.2 2 1
+example.com/app/b/b.go:3.20,5.2 4 0
+`), 0o644))
+
+	packages, err := parseGoCoverProfile(profile)
+	require.NoError(t, err)
+	assert.Equal(t, []ws.PackageCoverage{
+		{Package: "example.com/app/a", Percent: 100, Covered: 4, Statements: 4},
+		{Package: "example.com/app/b", Percent: 0, Covered: 0, Statements: 4},
+	}, packages, "duplicate blocks must be counted once")
+}
+
+func TestParseIstanbulSummary(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "coverage-summary.json")
+	require.NoError(t, os.WriteFile(report, []byte(`{
+  "total": {"statements": {"total": 10, "covered": 7, "pct": 70}},
+  "/work/src/a.js": {"statements": {"total": 4, "covered": 4, "pct": 100}},
+  "/work/src/b.js": {"statements": {"total": 2, "covered": 1, "pct": 50}},
+  "/work/lib/c.js": {"statements": {"total": 4, "covered": 2, "pct": 50}}
+}`), 0o644))
+
+	run := &coverageRun{tool: coverageToolJest, reportPath: report, workDir: "/work"}
+	coverage, err := run.collect()
+	require.NoError(t, err)
+	assert.Equal(t, 70.0, coverage.TotalPercent)
+	assert.Equal(t, []ws.PackageCoverage{
+		{Package: "lib", Percent: 50, Covered: 2, Statements: 4},
+		{Package: "src", Percent: 83.33, Covered: 5, Statements: 6},
+	}, coverage.Packages)
+}
+
+func TestParsePytestCoverageJSON(t *testing.T) {
+	report := filepath.Join(t.TempDir(), "coverage.json")
+	require.NoError(t, os.WriteFile(report, []byte(`{
+  "files": {
+    "app/models.py": {"summary": {"covered_lines": 9, "num_statements": 10}},
+    "app/views.py": {"summary": {"covered_lines": 1, "num_statements": 10}}
+  },
+  "totals": {"covered_lines": 10, "num_statements": 20, "percent_covered": 50.0}
+}`), 0o644))
+
+	run := &coverageRun{tool: coverageToolPytest, reportPath: report, workDir: "/work"}
+	coverage, err := run.collect()
+	require.NoError(t, err)
+	assert.Equal(t, coverageToolPytest, coverage.Tool)
+	assert.Equal(t, 50.0, coverage.TotalPercent)
+	require.Len(t, coverage.Packages, 1)
+	assert.Equal(t, "app", coverage.Packages[0].Package)
+}
+
+// writeCoverageModule creates a Go module with half of its statements covered.
+func writeCoverageModule(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/covdemo\n\ngo 1.21\n",
+		"calc.go": `package covdemo
+
+func Add(a, b int) int { return a + b }
+
+func Sub(a, b int) int { return a - b }
+`,
+		"calc_test.go": `package covdemo
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if Add(1, 2) != 3 {
+		t.Fatal("bad add")
+	}
+}
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestTestExecutor_CoverageThreshold(t *testing.T) {
+	dir := writeCoverageModule(t)
+	executor := NewTestExecutor()
+
+	result := executor.Execute(context.Background(), ws.TestRequestPayload{
+		ExecutionID:       "exec-cov-1",
+		WorkDir:           dir,
+		Command:           "go test",
+		Pattern:           "./...",
+		CoverageThreshold: 40,
+	})
+	require.NotNil(t, result.Coverage)
+	assert.True(t, result.Success, result.Output)
+	assert.Empty(t, result.Coverage.Error)
+	assert.Equal(t, coverageToolGo, result.Coverage.Tool)
+	assert.Equal(t, 50.0, result.Coverage.TotalPercent)
+	assert.False(t, result.Coverage.BelowThreshold)
+	require.Len(t, result.Coverage.Packages, 1)
+	assert.Equal(t, "example.com/covdemo", result.Coverage.Packages[0].Package)
+
+	result = executor.Execute(context.Background(), ws.TestRequestPayload{
+		ExecutionID:       "exec-cov-2",
+		WorkDir:           dir,
+		Command:           "go test ./...",
+		CoverageThreshold: 80,
+	})
+	assert.False(t, result.Success, "stage must fail below the coverage threshold")
+	assert.Equal(t, 1, result.ExitCode)
+	assert.True(t, result.Coverage.BelowThreshold)
+	assert.Contains(t, result.Output, "below threshold 80.00%")
+}
+
+func TestTestExecutor_CoverageUnsupportedCommand(t *testing.T) {
+	dir := t.TempDir()
+	executor := NewTestExecutor()
+
+	result := executor.Execute(context.Background(), ws.TestRequestPayload{
+		WorkDir:  dir,
+		Command:  "true",
+		Coverage: true,
+	})
+	assert.True(t, result.Success, "coverage errors must not fail the stage without a threshold")
+	require.NotNil(t, result.Coverage)
+	assert.NotEmpty(t, result.Coverage.Error)
+
+	result = executor.Execute(context.Background(), ws.TestRequestPayload{
+		WorkDir:           dir,
+		Command:           "true",
+		CoverageThreshold: 50,
+	})
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Output, "not verified")
+
+	result = executor.Execute(context.Background(), ws.TestRequestPayload{
+		WorkDir:           dir,
+		Command:           "true",
+		CoverageThreshold: 150,
+	})
+	assert.False(t, result.Success)
+	assert.Nil(t, result.Coverage)
+}
@@ -0,0 +1,360 @@
+// Package executor provides test execution for Local Agent Bridge.
+// Flaky-test detection: rerunning failed tests and the per-project flake history.
+package executor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+)
+
+// maxFlakyRetries caps the reruns a request can ask for.
+const maxFlakyRetries = 5
+
+// WithFlakyRetries sets how many times failed tests are rerun when a request
+// does not set flaky_retries. Zero (the default) disables reruns.
+func WithFlakyRetries(retries int) TestExecutorOption {
+	return func(e *TestExecutor) {
+		e.flakyRetries = retries
+	}
+}
+
+// WithFlakeHistoryDir sets the directory of the per-project flake history.
+// An empty dir disables the history; flaky tests are still reported.
+func WithFlakeHistoryDir(dir string) TestExecutorOption {
+	return func(e *TestExecutor) {
+		e.flakeHistory = newFlakeHistory(dir)
+	}
+}
+
+// defaultFlakeHistoryDir returns ~/.config/autopus/flaky-tests, or "" when the
+// home directory is unknown.
+func defaultFlakeHistoryDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "flaky-tests")
+}
+
+// flakyRetriesFor returns the reruns allowed for req, capped at maxFlakyRetries.
+func (e *TestExecutor) flakyRetriesFor(req ws.TestRequestPayload) int {
+	retries := e.flakyRetries
+	if req.FlakyRetries != 0 {
+		retries = req.FlakyRetries
+	}
+	return min(max(retries, 0), maxFlakyRetries)
+}
+
+// flakyRerun describes how one test framework reports and reruns failed tests.
+type flakyRerun struct {
+	// failed extracts failed test names from output. ok is false when the
+	// output shows failures the names do not cover (panics, build errors),
+	// so passing reruns would not prove the run is green.
+	failed func(output string) (names []string, ok bool)
+	// command returns a command that reruns only the given failed tests.
+	command func(command string, failed []string) string
+}
+
+var (
+	// goTestFailNameRe matches top-level failed tests; subtests are indented.
+	goTestFailNameRe = regexp.MustCompile(`(?m)^--- FAIL: (\S+)`)
+	// goTestUncoveredFailRe matches failures that are not reported per test.
+	goTestUncoveredFailRe = regexp.MustCompile(`(?m)^panic: |\[(build|setup) failed\]`)
+	// pytestFailedRe matches "FAILED tests/test_a.py::test_x - AssertionError" summary lines.
+	pytestFailedRe = regexp.MustCompile(`(?m)^FAILED (\S+)`)
+	// pytestErrorRe matches collection and fixture errors, which --lf cannot tell apart from test failures.
+	pytestErrorRe = regexp.MustCompile(`(?m)^ERROR `)
+	// jestFailedRe matches "● Suite › test name" failure headers.
+	jestFailedRe = regexp.MustCompile(`(?m)^\s*● (.+?)\s*$`)
+)
+
+// jestSuiteFailure is the jest header for a suite that failed before running its tests.
+const jestSuiteFailure = "Test suite failed to run"
+
+// flakyRerunFor returns the rerun strategy for the detected test framework.
+func flakyRerunFor(command string) (*flakyRerun, error) {
+	switch detectCoverageTool(command) {
+	case coverageToolGo:
+		return &flakyRerun{
+			failed: func(output string) ([]string, bool) {
+				return uniqueMatches(goTestFailNameRe, output), !goTestUncoveredFailRe.MatchString(output)
+			},
+			command: func(command string, failed []string) string {
+				quoted := make([]string, len(failed))
+				for i, name := range failed {
+					quoted[i] = regexp.QuoteMeta(name)
+				}
+				return command + " -run " + shellQuote("^("+strings.Join(quoted, "|")+")$")
+			},
+		}, nil
+
+	case coverageToolPytest:
+		return &flakyRerun{
+			failed: func(output string) ([]string, bool) {
+				return uniqueMatches(pytestFailedRe, output), !pytestErrorRe.MatchString(output)
+			},
+			command: func(command string, _ []string) string {
+				return command + " --lf"
+			},
+		}, nil
+
+	case coverageToolJest:
+		return &flakyRerun{
+			failed: func(output string) ([]string, bool) {
+				names := uniqueMatches(jestFailedRe, output)
+				for _, name := range names {
+					if name == jestSuiteFailure {
+						return nil, false
+					}
+				}
+				return names, true
+			},
+			command: func(command string, _ []string) string {
+				return command + npmArgSeparator(command) + " --onlyFailures"
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("flaky reruns are not supported for test command %q", command)
+}
+
+// uniqueMatches returns the first submatch of every match of re, without duplicates, in order.
+func uniqueMatches(re *regexp.Regexp, output string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range re.FindAllStringSubmatch(output, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// retryFailedTests reruns the failed tests of a finished run up to retries
+// times and records the outcome in result.Flaky. When every failed test passes
+// on a rerun, the run succeeds and the summary counts them as passed.
+// Rerun output is appended to result.Output and each rerun is reported as a
+// nested event of the current step.
+func (e *TestExecutor) retryFailedTests(ctx context.Context, result *ws.TestResultPayload, run *containerRun, workDir, command string, retries int, steps *stepReporter) {
+	report := &ws.FlakyTestReport{MaxRetries: retries}
+	result.Flaky = report
+
+	strategy, err := flakyRerunFor(command)
+	if err != nil {
+		report.Error = err.Error()
+		return
+	}
+	remaining, ok := strategy.failed(result.Output)
+	if !ok || len(remaining) == 0 {
+		report.Error = "failed tests could not be identified from the test output"
+		return
+	}
+
+	attempts := make(map[string]int, len(remaining))
+	var passed []string
+	for report.Reruns < retries && len(remaining) > 0 && ctx.Err() == nil {
+		report.Reruns++
+		for _, name := range remaining {
+			attempts[name]++
+		}
+
+		rerun := strategy.command(command, remaining)
+		event := ws.ProgressToolEvent{
+			ID:     fmt.Sprintf("rerun-%d", report.Reruns),
+			Name:   fmt.Sprintf("rerun %d/%d", report.Reruns, retries),
+			Status: ws.ProgressStepRunning,
+			Detail: fmt.Sprintf("%d failed tests", len(remaining)),
+		}
+		steps.tool(event)
+		rerunStart := time.Now()
+		var output bytes.Buffer
+		cmd := shellCommand(ctx, run, workDir, rerun, nil)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+		runErr := procgroup.Run(cmd)
+		if run != nil && ctx.Err() != nil {
+			run.cleanup()
+		}
+		result.Output += fmt.Sprintf("\n--- flaky rerun %d/%d: %s\n%s", report.Reruns, retries, rerun, output.String())
+		event.Status = ws.ProgressStepCompleted
+		if runErr != nil {
+			event.Status = ws.ProgressStepFailed
+		}
+		event.DurationMs = time.Since(rerunStart).Milliseconds()
+		steps.tool(event)
+
+		if runErr == nil {
+			passed = append(passed, remaining...)
+			remaining = nil
+			break
+		}
+		stillFailing, ok := strategy.failed(output.String())
+		if !ok || len(stillFailing) == 0 {
+			// The rerun failed without naming tests (e.g. it did not build); stop retrying.
+			break
+		}
+		failing := make(map[string]bool, len(stillFailing))
+		for _, name := range stillFailing {
+			failing[name] = true
+		}
+		var next, fixed []string
+		for _, name := range remaining {
+			if failing[name] {
+				next = append(next, name)
+			} else {
+				fixed = append(fixed, name)
+			}
+		}
+		if len(next) == 0 {
+			// The rerun failed but none of the rerun tests did; stop retrying.
+			break
+		}
+		passed = append(passed, fixed...)
+		remaining = next
+	}
+
+	now := time.Now()
+	history := e.flakeHistory.record(workDir, passed, remaining, now)
+	for _, name := range passed {
+		report.Flaky = append(report.Flaky, history.flakyTest(name, attempts[name]+1))
+	}
+	for _, name := range remaining {
+		report.Failed = append(report.Failed, history.flakyTest(name, attempts[name]+1))
+	}
+
+	if len(remaining) == 0 && len(passed) > 0 {
+		result.Success = true
+		result.ExitCode = 0
+		moved := min(len(passed), result.Summary.Failed)
+		result.Summary.Failed -= moved
+		result.Summary.Passed += moved
+	}
+}
+
+// flakeHistory keeps a JSON file per project (work directory) with how often
+// each test was flaky or failed on every rerun. Reports include these counts so
+// the backend can tell chronic flakes from new failures.
+type flakeHistory struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// newFlakeHistory returns a history stored under dir, or nil when dir is empty.
+func newFlakeHistory(dir string) *flakeHistory {
+	if dir == "" {
+		return nil
+	}
+	return &flakeHistory{dir: dir}
+}
+
+// projectFlakes is the stored history of one project.
+type projectFlakes struct {
+	Project string                 `json:"project"`
+	Tests   map[string]*testFlakes `json:"tests"`
+}
+
+// testFlakes is the stored history of one test.
+type testFlakes struct {
+	Flaky       int       `json:"flaky"`
+	Failed      int       `json:"failed"`
+	LastFlakyAt time.Time `json:"last_flaky_at,omitzero"`
+
+	// previousFlakyAt is LastFlakyAt before this run; it is not stored.
+	previousFlakyAt time.Time
+}
+
+// path returns the history file of the project at workDir.
+func (h *flakeHistory) path(workDir string) string {
+	sum := sha256.Sum256([]byte(workDir))
+	return filepath.Join(h.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// record adds this run's flaky and failing tests to the project history and
+// returns the updated history. A nil history or a history that cannot be
+// read or written yields counts for this run only.
+func (h *flakeHistory) record(workDir string, flaky, failed []string, now time.Time) *projectFlakes {
+	project := &projectFlakes{Project: workDir, Tests: make(map[string]*testFlakes)}
+	if h != nil {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if data, err := os.ReadFile(h.path(workDir)); err == nil {
+			_ = json.Unmarshal(data, project)
+			if project.Tests == nil {
+				project.Tests = make(map[string]*testFlakes)
+			}
+		}
+	}
+
+	for _, name := range flaky {
+		t := project.test(name)
+		t.Flaky++
+		t.previousFlakyAt = t.LastFlakyAt
+		t.LastFlakyAt = now
+	}
+	for _, name := range failed {
+		t := project.test(name)
+		t.Failed++
+		t.previousFlakyAt = t.LastFlakyAt
+	}
+
+	if h != nil {
+		_ = h.save(workDir, project)
+	}
+	return project
+}
+
+// save writes the project history atomically.
+func (h *flakeHistory) save(workDir string, project *projectFlakes) error {
+	if err := os.MkdirAll(h.dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(project, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := h.path(workDir) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path(workDir))
+}
+
+// test returns the history of name, creating it when missing.
+func (p *projectFlakes) test(name string) *testFlakes {
+	t, ok := p.Tests[name]
+	if !ok {
+		t = &testFlakes{}
+		p.Tests[name] = t
+	}
+	return t
+}
+
+// flakyTest builds the report entry for name.
+func (p *projectFlakes) flakyTest(name string, attempts int) ws.FlakyTest {
+	t := p.test(name)
+	ft := ws.FlakyTest{
+		Name:       name,
+		Attempts:   attempts,
+		FlakyCount: t.Flaky,
+		FailCount:  t.Failed,
+	}
+	if !t.previousFlakyAt.IsZero() {
+		last := t.previousFlakyAt
+		ft.LastFlakyAt = &last
+	}
+	return ft
+}
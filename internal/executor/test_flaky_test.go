@@ -0,0 +1,218 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFlakyModule writes a Go module with a test that fails on its first run
+// (it leaves a marker file), a test that always fails when broken is true,
+// and a passing test.
+func writeFlakyModule(t *testing.T, broken bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	brokenTest := ""
+	if broken {
+		brokenTest = `
+func TestBroken(t *testing.T) {
+	t.Fatal("always fails")
+}
+`
+	}
+	files := map[string]string{
+		"go.mod": "module example.com/flakydemo\n\ngo 1.21\n",
+		"flaky_test.go": `package flakydemo
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFlaky(t *testing.T) {
+	if _, err := os.Stat("ran-once"); err != nil {
+		_ = os.WriteFile("ran-once", nil, 0o644)
+		t.Fatal("first run fails")
+	}
+}
+
+func TestStable(t *testing.T) {}
+` + brokenTest,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestTestExecutor_FlakyRetries(t *testing.T) {
+	dir := writeFlakyModule(t, false)
+	historyDir := t.TempDir()
+	executor := NewTestExecutor(WithFlakyRetries(2), WithFlakeHistoryDir(historyDir))
+
+	result := executor.Execute(context.Background(), ws.TestRequestPayload{
+		ExecutionID: "exec-flaky-1",
+		WorkDir:     dir,
+		Command:     "go test -count=1",
+		Pattern:     "./...",
+	})
+	assert.True(t, result.Success, result.Output)
+	assert.Equal(t, 0, result.ExitCode)
+	assert.Equal(t, 0, result.Summary.Failed)
+	require.NotNil(t, result.Flaky)
+	assert.Empty(t, result.Flaky.Error)
+	assert.Equal(t, 1, result.Flaky.Reruns)
+	require.Len(t, result.Flaky.Flaky, 1)
+	assert.Equal(t, "TestFlaky", result.Flaky.Flaky[0].Name)
+	assert.Equal(t, 2, result.Flaky.Flaky[0].Attempts)
+	assert.Equal(t, 1, result.Flaky.Flaky[0].FlakyCount)
+	assert.Nil(t, result.Flaky.Flaky[0].LastFlakyAt)
+	assert.Contains(t, result.Output, "--- flaky rerun 1/2: go test -count=1 ./... -run '^(TestFlaky)$'")
+
+	// The history accumulates across runs of the same project.
+	require.NoError(t, os.Remove(filepath.Join(dir, "ran-once")))
+	result = executor.Execute(context.Background(), ws.TestRequestPayload{
+		ExecutionID: "exec-flaky-2",
+		WorkDir:     dir,
+		Command:     "go test -count=1 ./...",
+	})
+	require.NotNil(t, result.Flaky)
+	require.Len(t, result.Flaky.Flaky, 1)
+	assert.Equal(t, 2, result.Flaky.Flaky[0].FlakyCount)
+	assert.NotNil(t, result.Flaky.Flaky[0].LastFlakyAt)
+
+	// A negative request value disables reruns.
+	require.NoError(t, os.Remove(filepath.Join(dir, "ran-once")))
+	result = executor.Execute(context.Background(), ws.TestRequestPayload{
+		WorkDir:      dir,
+		Command:      "go test -count=1 ./...",
+		FlakyRetries: -1,
+	})
+	assert.False(t, result.Success)
+	assert.Nil(t, result.Flaky)
+}
+
+func TestTestExecutor_FlakyRetriesStillFailing(t *testing.T) {
+	dir := writeFlakyModule(t, true)
+	executor := NewTestExecutor(WithFlakeHistoryDir(""))
+
+	result := executor.Execute(context.Background(), ws.TestRequestPayload{
+		WorkDir:      dir,
+		Command:      "go test -count=1 ./...",
+		FlakyRetries: 2,
+	})
+	assert.False(t, result.Success, "a test failing on every attempt must fail the run")
+	assert.NotEqual(t, 0, result.ExitCode)
+	require.NotNil(t, result.Flaky)
+	assert.Equal(t, 2, result.Flaky.Reruns)
+	require.Len(t, result.Flaky.Flaky, 1)
+	assert.Equal(t, "TestFlaky", result.Flaky.Flaky[0].Name)
+	require.Len(t, result.Flaky.Failed, 1)
+	assert.Equal(t, "TestBroken", result.Flaky.Failed[0].Name)
+	assert.Equal(t, 3, result.Flaky.Failed[0].Attempts)
+	assert.Equal(t, 1, result.Flaky.Failed[0].FailCount)
+}
+
+func TestTestExecutor_FlakyRetriesUnsupported(t *testing.T) {
+	executor := NewTestExecutor(WithFlakeHistoryDir(""))
+
+	result := executor.Execute(context.Background(), ws.TestRequestPayload{
+		WorkDir:      t.TempDir(),
+		Command:      "false",
+		FlakyRetries: 3,
+	})
+	assert.False(t, result.Success)
+	require.NotNil(t, result.Flaky)
+	assert.Equal(t, 0, result.Flaky.Reruns)
+	assert.Contains(t, result.Flaky.Error, "not supported")
+}
+
+func TestFlakyRerunFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		output  string
+		failed  []string
+		ok      bool
+		rerun   string
+	}{
+		{
+			name:    "go",
+			command: "go test ./...",
+			output:  "--- FAIL: TestA (0.00s)\n    --- FAIL: TestA/sub (0.00s)\n--- FAIL: TestB.x (0.01s)\nFAIL\n",
+			failed:  []string{"TestA", "TestB.x"},
+			ok:      true,
+			rerun:   `go test ./... -run '^(TestA|TestB\.x)$'`,
+		},
+		{
+			name:    "go panic",
+			command: "go test ./...",
+			output:  "--- FAIL: TestA (0.00s)\npanic: boom\n",
+			failed:  []string{"TestA"},
+			ok:      false,
+		},
+		{
+			name:    "pytest",
+			command: "python -m pytest -q",
+			output:  "FAILED tests/test_a.py::test_x - AssertionError\nFAILED tests/test_a.py::test_y\n",
+			failed:  []string{"tests/test_a.py::test_x", "tests/test_a.py::test_y"},
+			ok:      true,
+			rerun:   "python -m pytest -q --lf",
+		},
+		{
+			name:    "npm jest",
+			command: "npm test",
+			output:  "  ● math › adds\n\n    expect(received)\n",
+			failed:  []string{"math › adds"},
+			ok:      true,
+			rerun:   "npm test -- --onlyFailures",
+		},
+		{
+			name:    "jest suite failure",
+			command: "npx jest",
+			output:  "  ● Test suite failed to run\n",
+			ok:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := flakyRerunFor(tt.command)
+			require.NoError(t, err)
+			failed, ok := strategy.failed(tt.output)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.failed, failed)
+				assert.Equal(t, tt.rerun, strategy.command(tt.command, failed))
+			}
+		})
+	}
+
+	_, err := flakyRerunFor("npx vitest run")
+	assert.Error(t, err)
+}
+
+func TestFlakeHistory_PerProject(t *testing.T) {
+	h := newFlakeHistory(t.TempDir())
+	first := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	h.record("/work/a", []string{"TestX"}, []string{"TestY"}, first)
+	project := h.record("/work/a", []string{"TestX"}, nil, first.Add(time.Hour))
+	x := project.flakyTest("TestX", 2)
+	assert.Equal(t, 2, x.FlakyCount)
+	require.NotNil(t, x.LastFlakyAt)
+	assert.True(t, x.LastFlakyAt.Equal(first))
+	assert.Equal(t, 1, project.flakyTest("TestY", 3).FailCount)
+
+	other := h.record("/work/b", []string{"TestX"}, nil, first)
+	assert.Equal(t, 1, other.flakyTest("TestX", 2).FlakyCount, "history is kept per project")
+
+	// A nil history reports counts for the current run only.
+	var none *flakeHistory
+	assert.Equal(t, 1, none.record("/work/a", []string{"TestX"}, nil, first).flakyTest("TestX", 2).FlakyCount)
+}
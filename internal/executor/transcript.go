@@ -0,0 +1,402 @@
+// Package executor - 실행 단위 트랜스크립트(프롬프트, 스트리밍 출력, 도구 호출, 결과) 기록
+package executor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// DefaultTranscriptMaxAge는 트랜스크립트 파일 보관 기간의 기본값입니다.
+const DefaultTranscriptMaxAge = 7 * 24 * time.Hour
+
+// transcriptDeltaFlushBytes는 스트리밍 출력을 한 항목으로 모으는 최대 크기입니다.
+const transcriptDeltaFlushBytes = 4 * 1024
+
+// 트랜스크립트 항목 종류
+const (
+	TranscriptPrompt     = "prompt"
+	TranscriptDelta      = "delta"
+	TranscriptToolCall   = "tool_call"
+	TranscriptToolResult = "tool_result"
+	TranscriptResult     = "result"
+	TranscriptError      = "error"
+)
+
+// TranscriptEntry는 트랜스크립트 한 항목입니다. 파일에는 한 줄에 하나씩 JSON으로 기록됩니다.
+type TranscriptEntry struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+	// Text는 프롬프트, 스트리밍 출력, 최종 출력, 에러 메시지입니다.
+	Text         string             `json:"text,omitempty"`
+	SystemPrompt string             `json:"system_prompt,omitempty"`
+	Provider     string             `json:"provider,omitempty"`
+	Model        string             `json:"model,omitempty"`
+	ToolCall     *ws.ToolLoopCall   `json:"tool_call,omitempty"`
+	ToolResult   *ws.ToolLoopResult `json:"tool_result,omitempty"`
+	TokenUsage   *ws.TokenUsage     `json:"token_usage,omitempty"`
+	DurationMs   int64              `json:"duration_ms,omitempty"`
+}
+
+// Transcript는 실행 하나의 트랜스크립트입니다.
+type Transcript struct {
+	ExecutionID string            `json:"execution_id"`
+	Entries     []TranscriptEntry `json:"entries"`
+}
+
+// TranscriptStore는 실행 ID별 트랜스크립트를 디렉토리에 JSON Lines 파일로 저장합니다.
+// 같은 실행 ID의 후속 요청(도구 루프, 재개)은 기존 파일에 이어서 기록합니다.
+type TranscriptStore struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// NewTranscriptStore는 새 TranscriptStore를 생성합니다. maxAge가 0이면 DefaultTranscriptMaxAge를 사용합니다.
+func NewTranscriptStore(dir string, maxAge time.Duration) *TranscriptStore {
+	if maxAge <= 0 {
+		maxAge = DefaultTranscriptMaxAge
+	}
+	return &TranscriptStore{dir: dir, maxAge: maxAge}
+}
+
+// path는 실행 ID의 트랜스크립트 파일 경로를 반환합니다.
+func (s *TranscriptStore) path(executionID string) string {
+	return filepath.Join(s.dir, sanitizeTranscriptName(executionID)+".jsonl")
+}
+
+// Open은 실행 ID의 트랜스크립트를 기록용으로 엽니다.
+func (s *TranscriptStore) Open(executionID string) (*TranscriptWriter, error) {
+	if executionID == "" {
+		return nil, errors.New("트랜스크립트에 execution_id가 없습니다")
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return nil, fmt.Errorf("트랜스크립트 디렉토리 생성 실패: %w", err)
+	}
+	path := s.path(executionID)
+	_, statErr := os.Stat(path)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("트랜스크립트 파일 열기 실패: %w", err)
+	}
+	return &TranscriptWriter{
+		file:      f,
+		buf:       bufio.NewWriter(f),
+		continued: statErr == nil,
+		now:       time.Now,
+	}, nil
+}
+
+// Load는 실행 ID의 트랜스크립트를 읽습니다. 없으면 os.ErrNotExist를 감싼 에러를 반환합니다.
+// 크래시로 마지막 줄이 잘린 경우 그 줄은 건너뜁니다.
+func (s *TranscriptStore) Load(executionID string) (*Transcript, error) {
+	f, err := os.Open(s.path(executionID))
+	if err != nil {
+		return nil, fmt.Errorf("트랜스크립트 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	t := &Transcript{ExecutionID: executionID}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry TranscriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		t.Entries = append(t.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("트랜스크립트 읽기 실패: %w", err)
+	}
+	return t, nil
+}
+
+// Prune은 maxAge보다 오래 수정되지 않은 트랜스크립트 파일을 삭제하고 삭제한 수를 반환합니다.
+func (s *TranscriptStore) Prune(now time.Time) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("트랜스크립트 디렉토리 읽기 실패: %w", err)
+	}
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) <= s.maxAge {
+			continue
+		}
+		if os.Remove(filepath.Join(s.dir, entry.Name())) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// sanitizeTranscriptName은 실행 ID를 파일 이름으로 쓸 수 있게 변환합니다.
+func sanitizeTranscriptName(executionID string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, executionID)
+}
+
+// TranscriptWriter는 실행 하나의 트랜스크립트에 항목을 추가합니다.
+// nil TranscriptWriter의 메서드는 아무것도 하지 않으므로 트랜스크립트가 비활성화되어도 그대로 호출할 수 있습니다.
+// 연속된 스트리밍 출력은 한 항목으로 모아 기록합니다.
+type TranscriptWriter struct {
+	mu   sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+	// continued는 같은 실행 ID의 기존 트랜스크립트에 이어서 기록하는지 여부입니다.
+	continued bool
+	// pendingDelta는 아직 기록하지 않은 스트리밍 출력입니다.
+	pendingDelta strings.Builder
+	deltaStarted time.Time
+	err          error
+	now          func() time.Time
+}
+
+// Continued는 기존 트랜스크립트에 이어서 기록하는지 반환합니다.
+func (w *TranscriptWriter) Continued() bool {
+	return w != nil && w.continued
+}
+
+// Prompt는 실행 프롬프트를 기록합니다.
+func (w *TranscriptWriter) Prompt(prompt, systemPrompt, providerName, model string) {
+	w.append(TranscriptEntry{Kind: TranscriptPrompt, Text: prompt, SystemPrompt: systemPrompt, Provider: providerName, Model: model})
+}
+
+// Delta는 프로바이더 스트리밍 출력을 기록합니다.
+func (w *TranscriptWriter) Delta(text string) {
+	if w == nil || text == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pendingDelta.Len() == 0 {
+		w.deltaStarted = w.now()
+	}
+	w.pendingDelta.WriteString(text)
+	if w.pendingDelta.Len() >= transcriptDeltaFlushBytes {
+		w.flushDeltaLocked()
+	}
+}
+
+// ToolResults는 서버가 전달한 도구 실행 결과를 기록합니다.
+func (w *TranscriptWriter) ToolResults(results []ws.ToolLoopResult) {
+	for i := range results {
+		w.append(TranscriptEntry{Kind: TranscriptToolResult, ToolResult: &results[i]})
+	}
+}
+
+// Result는 프로바이더 응답(최종 출력, 도구 호출, 토큰 사용량)을 기록합니다.
+func (w *TranscriptWriter) Result(resp *provider.ExecuteResponse) {
+	if resp == nil {
+		return
+	}
+	for _, call := range convertToolCalls(resp.ToolCalls) {
+		w.append(TranscriptEntry{Kind: TranscriptToolCall, ToolCall: &call})
+	}
+	w.append(TranscriptEntry{
+		Kind:       TranscriptResult,
+		Text:       resp.Output,
+		Provider:   resp.Provider,
+		Model:      resp.Model,
+		DurationMs: resp.DurationMs,
+		TokenUsage: &ws.TokenUsage{
+			InputTokens:   resp.TokenUsage.InputTokens,
+			OutputTokens:  resp.TokenUsage.OutputTokens,
+			TotalTokens:   resp.TokenUsage.TotalTokens,
+			CacheRead:     resp.TokenUsage.CacheRead,
+			CacheCreation: resp.TokenUsage.CacheCreation,
+		},
+	})
+}
+
+// Error는 실행 실패를 기록합니다.
+func (w *TranscriptWriter) Error(err error) {
+	if err == nil {
+		return
+	}
+	w.append(TranscriptEntry{Kind: TranscriptError, Text: err.Error()})
+}
+
+// Close는 남은 출력을 기록하고 파일을 닫습니다. 기록 중 발생한 첫 에러를 반환합니다.
+func (w *TranscriptWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushDeltaLocked()
+	if err := w.buf.Flush(); err != nil && w.err == nil {
+		w.err = err
+	}
+	if err := w.file.Close(); err != nil && w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// append는 모아 둔 스트리밍 출력을 먼저 기록한 뒤 항목을 기록합니다.
+func (w *TranscriptWriter) append(entry TranscriptEntry) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushDeltaLocked()
+	entry.Time = w.now()
+	w.writeLocked(entry)
+}
+
+// flushDeltaLocked는 모아 둔 스트리밍 출력을 한 항목으로 기록합니다. 호출자가 mu를 보유해야 합니다.
+func (w *TranscriptWriter) flushDeltaLocked() {
+	if w.pendingDelta.Len() == 0 {
+		return
+	}
+	w.writeLocked(TranscriptEntry{Time: w.deltaStarted, Kind: TranscriptDelta, Text: w.pendingDelta.String()})
+	w.pendingDelta.Reset()
+}
+
+// writeLocked는 항목을 JSON 한 줄로 기록합니다. 호출자가 mu를 보유해야 합니다.
+func (w *TranscriptWriter) writeLocked(entry TranscriptEntry) {
+	if w.err != nil {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		w.err = err
+		return
+	}
+	data = append(data, '\n')
+	if _, err := w.buf.Write(data); err != nil {
+		w.err = err
+	}
+}
+
+// beginTranscript는 작업의 트랜스크립트 기록을 시작합니다. 비활성화되었거나 실패하면 nil을 반환합니다.
+func (e *TaskExecutor) beginTranscript(executionID string) *TranscriptWriter {
+	if e.transcripts == nil {
+		return nil
+	}
+	w, err := e.transcripts.Open(executionID)
+	if err != nil {
+		e.logger.Warn().
+			Str("execution_id", executionID).
+			Err(err).
+			Msg("트랜스크립트 기록 시작 실패")
+		return nil
+	}
+	return w
+}
+
+// finishTranscript는 트랜스크립트 기록을 마칩니다.
+func (e *TaskExecutor) finishTranscript(executionID string, w *TranscriptWriter) {
+	if err := w.Close(); err != nil {
+		e.logger.Warn().
+			Str("execution_id", executionID).
+			Err(err).
+			Msg("트랜스크립트 기록 실패")
+	}
+}
+
+// pendingToolResults는 도구 루프 메시지 중 마지막 도구 호출 이후에 전달된 도구 실행 결과를 반환합니다.
+// 이전 요청에서 이미 기록한 결과를 중복 기록하지 않기 위해 사용합니다.
+func pendingToolResults(messages []ws.ToolLoopMessage) []ws.ToolLoopResult {
+	var results []ws.ToolLoopResult
+	for i := len(messages) - 1; i >= 0; i-- {
+		if len(messages[i].ToolCalls) > 0 {
+			break
+		}
+		results = slices.Concat(messages[i].ToolResults, results)
+	}
+	return results
+}
+
+// MarkdownTranscript는 트랜스크립트를 Markdown 문서로 변환합니다.
+func MarkdownTranscript(t *Transcript) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Execution %s\n", t.ExecutionID)
+
+	inOutput := false
+	for _, entry := range t.Entries {
+		if entry.Kind != TranscriptDelta {
+			inOutput = false
+		}
+		switch entry.Kind {
+		case TranscriptPrompt:
+			fmt.Fprintf(&b, "\n## Prompt\n\n_%s", entry.Time.Format(time.RFC3339))
+			if entry.Provider != "" || entry.Model != "" {
+				fmt.Fprintf(&b, " · %s", strings.Trim(entry.Provider+"/"+entry.Model, "/"))
+			}
+			b.WriteString("_\n\n")
+			if entry.SystemPrompt != "" {
+				fmt.Fprintf(&b, "**System prompt**\n\n%s\n\n", markdownFence(entry.SystemPrompt))
+			}
+			fmt.Fprintf(&b, "%s\n", markdownFence(entry.Text))
+		case TranscriptDelta:
+			if !inOutput {
+				fmt.Fprintf(&b, "\n## Output\n\n")
+				inOutput = true
+			}
+			b.WriteString(entry.Text)
+		case TranscriptToolCall:
+			if entry.ToolCall != nil {
+				fmt.Fprintf(&b, "\n## Tool call: %s\n\n_id: %s_\n\n%s\n", entry.ToolCall.Name, entry.ToolCall.ID, markdownFence(string(entry.ToolCall.Input)))
+			}
+		case TranscriptToolResult:
+			if entry.ToolResult != nil {
+				title := "Tool result"
+				if entry.ToolResult.IsError {
+					title = "Tool error"
+				}
+				fmt.Fprintf(&b, "\n## %s: %s\n\n_id: %s_\n\n%s\n", title, entry.ToolResult.ToolName, entry.ToolResult.ToolCallID, markdownFence(entry.ToolResult.Content))
+			}
+		case TranscriptResult:
+			fmt.Fprintf(&b, "\n## Result\n\n_%s", entry.Time.Format(time.RFC3339))
+			if entry.DurationMs > 0 {
+				fmt.Fprintf(&b, " · %dms", entry.DurationMs)
+			}
+			if entry.TokenUsage != nil {
+				fmt.Fprintf(&b, " · tokens in %d / out %d", entry.TokenUsage.InputTokens, entry.TokenUsage.OutputTokens)
+			}
+			b.WriteString("_\n\n")
+			if entry.Text != "" {
+				fmt.Fprintf(&b, "%s\n", entry.Text)
+			}
+		case TranscriptError:
+			fmt.Fprintf(&b, "\n## Error\n\n_%s_\n\n%s\n", entry.Time.Format(time.RFC3339), markdownFence(entry.Text))
+		}
+	}
+	if inOutput {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// markdownFence는 내용에 포함된 백틱보다 긴 펜스로 코드 블록을 만듭니다.
+func markdownFence(content string) string {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	return fence + "\n" + strings.TrimRight(content, "\n") + "\n" + fence
+}
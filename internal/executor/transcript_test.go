@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func transcriptKinds(t *Transcript) []string {
+	kinds := make([]string, 0, len(t.Entries))
+	for _, entry := range t.Entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	return kinds
+}
+
+func TestTranscriptStore_WriteLoadPrune(t *testing.T) {
+	store := NewTranscriptStore(t.TempDir(), time.Hour)
+
+	w, err := store.Open("exec/1")
+	require.NoError(t, err)
+	assert.False(t, w.Continued())
+	w.Prompt("hello", "be brief", "claude", "claude-sonnet")
+	w.Delta("Hel")
+	w.Delta("lo!")
+	w.Result(&provider.ExecuteResponse{
+		Output:    "Hello!",
+		ToolCalls: []provider.ToolCall{{ID: "call-1", Name: "search", Input: json.RawMessage(`{"q":"go"}`)}},
+	})
+	require.NoError(t, w.Close())
+
+	w, err = store.Open("exec/1")
+	require.NoError(t, err)
+	assert.True(t, w.Continued())
+	w.ToolResults([]ws.ToolLoopResult{{ToolCallID: "call-1", ToolName: "search", Content: "found"}})
+	w.Error(errors.New("boom"))
+	require.NoError(t, w.Close())
+
+	tr, err := store.Load("exec/1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		TranscriptPrompt, TranscriptDelta, TranscriptToolCall, TranscriptResult, TranscriptToolResult, TranscriptError,
+	}, transcriptKinds(tr))
+	assert.Equal(t, "Hello!", tr.Entries[1].Text, "연속된 스트리밍 출력은 한 항목으로 기록")
+	assert.Equal(t, "search", tr.Entries[2].ToolCall.Name)
+
+	_, err = store.Load("missing")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	removed, err := store.Prune(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestMarkdownTranscript(t *testing.T) {
+	md := MarkdownTranscript(&Transcript{
+		ExecutionID: "exec-1",
+		Entries: []TranscriptEntry{
+			{Kind: TranscriptPrompt, Text: "use ```code```", Provider: "codex", Model: "gpt-5"},
+			{Kind: TranscriptDelta, Text: "partial "},
+			{Kind: TranscriptDelta, Text: "output"},
+			{Kind: TranscriptToolResult, ToolResult: &ws.ToolLoopResult{ToolCallID: "c1", ToolName: "shell", Content: "denied", IsError: true}},
+			{Kind: TranscriptResult, Text: "done", DurationMs: 12, TokenUsage: &ws.TokenUsage{InputTokens: 3, OutputTokens: 4}},
+		},
+	})
+	assert.True(t, strings.HasPrefix(md, "# Execution exec-1\n"))
+	assert.Contains(t, md, "codex/gpt-5")
+	assert.Contains(t, md, "````\nuse ```code```\n````", "내용의 백틱보다 긴 펜스를 사용")
+	assert.Equal(t, 1, strings.Count(md, "## Output"))
+	assert.Contains(t, md, "partial output")
+	assert.Contains(t, md, "## Tool error: shell")
+	assert.Contains(t, md, "12ms · tokens in 3 / out 4")
+}
+
+func TestTaskExecutor_TranscriptToolLoop(t *testing.T) {
+	registry := provider.NewRegistry()
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			if len(req.ToolLoopMessages) == 0 {
+				return &provider.ExecuteResponse{
+					StopReason: "tool_use",
+					ToolCalls:  []provider.ToolCall{{ID: "call-1", Name: "search", Input: json.RawMessage(`{}`)}},
+				}, nil
+			}
+			return &provider.ExecuteResponse{Output: "final"}, nil
+		},
+	})
+	store := NewTranscriptStore(t.TempDir(), 0)
+	e := NewTaskExecutor(registry, newMockSender(), WithTranscriptStore(store))
+
+	req := ws.AgentResponseRequestPayload{ExecutionID: "exec-loop", Prompt: "find it", Model: "claude-sonnet"}
+	_, err := e.ExecuteAgentResponse(context.Background(), req)
+	require.NoError(t, err)
+
+	req.ToolLoopMessages = []ws.ToolLoopMessage{
+		{Role: "user", ToolResults: []ws.ToolLoopResult{{ToolCallID: "old", Content: "already recorded"}}},
+		{Role: "assistant", ToolCalls: []ws.ToolLoopCall{{ID: "call-1", Name: "search"}}},
+		{Role: "tool", ToolResults: []ws.ToolLoopResult{{ToolCallID: "call-1", ToolName: "search", Content: "result"}}},
+	}
+	_, err = e.ExecuteAgentResponse(context.Background(), req)
+	require.NoError(t, err)
+
+	tr, err := store.Load("exec-loop")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		TranscriptPrompt, TranscriptToolCall, TranscriptResult, TranscriptToolResult, TranscriptResult,
+	}, transcriptKinds(tr), "후속 요청은 프롬프트를 다시 기록하지 않고 새 도구 결과만 기록")
+	assert.Equal(t, "call-1", tr.Entries[3].ToolResult.ToolCallID)
+	assert.Equal(t, "final", tr.Entries[4].Text)
+
+	_, err = e.Execute(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-task", Prompt: "p", Model: "claude-sonnet"})
+	require.NoError(t, err)
+	tr, err = store.Load("exec-task")
+	require.NoError(t, err)
+	assert.Equal(t, []string{TranscriptPrompt, TranscriptToolCall, TranscriptResult}, transcriptKinds(tr))
+}
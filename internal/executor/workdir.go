@@ -0,0 +1,166 @@
+// Package executor - 서버가 요청한 작업별 작업 디렉토리 해석과 허용 루트 검증
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+)
+
+// 작업 디렉토리 에러 코드
+const (
+	// ErrorCodeWorkDirNotAllowed는 요청한 작업 디렉토리가 허용된 루트 밖에 있을 때 사용됩니다.
+	ErrorCodeWorkDirNotAllowed = errcode.WorkDirNotAllowed
+	// ErrorCodeWorkDirInvalid는 요청한 작업 디렉토리가 없거나 만들 수 없을 때 사용됩니다.
+	ErrorCodeWorkDirInvalid = errcode.WorkDirInvalid
+)
+
+// ErrWorkDirNotAllowed는 작업 디렉토리가 허용된 루트 밖에 있음을 나타냅니다.
+var ErrWorkDirNotAllowed = errors.New("작업 디렉토리가 허용된 루트 밖에 있습니다")
+
+// WorkDirPolicy는 서버가 요청한 작업 디렉토리를 허용된 루트 안의 실제 경로로 해석합니다.
+// 심볼릭 링크는 해석한 뒤 검사하므로 허용 루트 안의 링크로 밖을 가리킬 수 없습니다.
+type WorkDirPolicy struct {
+	// defaultDir은 work_dir이 없을 때의 디렉토리이자 상대 경로의 기준입니다.
+	defaultDir string
+	// roots는 심볼릭 링크까지 해석한 허용 루트입니다. 비어 있으면 제한하지 않습니다.
+	roots []string
+	// perExecution은 모든 작업에 실행 ID 이름의 하위 디렉토리를 만드는지 여부입니다.
+	perExecution bool
+}
+
+// NewWorkDirPolicy는 기본 작업 디렉토리(work_dir)와 설정에서 새 WorkDirPolicy를 생성합니다.
+// 허용 루트를 지정하면 기본 디렉토리도 허용 루트에 포함됩니다.
+func NewWorkDirPolicy(defaultDir string, cfg config.WorkDirsConfig) *WorkDirPolicy {
+	p := &WorkDirPolicy{perExecution: cfg.PerExecution}
+	if defaultDir != "" {
+		p.defaultDir = expandAndCleanPaths([]string{defaultDir})[0]
+	}
+	roots := cfg.AllowedRoots
+	if len(roots) > 0 && p.defaultDir != "" {
+		roots = append([]string{p.defaultDir}, roots...)
+	}
+	for _, root := range deduplicate(expandAndCleanPaths(roots)) {
+		if resolved, err := resolveSymlinks(root); err == nil {
+			root = resolved
+		}
+		p.roots = append(p.roots, root)
+	}
+	p.roots = deduplicate(p.roots)
+	return p
+}
+
+// Roots는 허용 루트 목록을 반환합니다. 제한하지 않으면 nil입니다.
+func (p *WorkDirPolicy) Roots() []string {
+	return p.roots
+}
+
+// Resolve는 실행의 작업 디렉토리를 결정합니다.
+// requested가 비어 있으면 기본 디렉토리를, 상대 경로이면 기본 디렉토리 기준 경로를 사용합니다.
+// newDir이거나 per_execution 설정이면 그 아래에 실행 ID 이름의 하위 디렉토리를 만들어 반환합니다.
+// 허용 루트 밖의 경로는 ErrWorkDirNotAllowed를 감싼 에러를 반환합니다.
+// 작업 디렉토리를 지정하지 않은 작업은 빈 문자열을 반환합니다 (프로바이더 기본 디렉토리).
+// 기본 디렉토리와 허용 루트가 모두 없으면 요청한 경로를 그대로 반환합니다 (이전 동작).
+func (p *WorkDirPolicy) Resolve(executionID, requested string, newDir bool) (string, error) {
+	newDir = newDir || p.perExecution
+	if !newDir && p.defaultDir == "" && len(p.roots) == 0 {
+		return requested, nil
+	}
+	dir := requested
+	if dir == "" {
+		dir = p.defaultDir
+	}
+	if dir == "" {
+		if !newDir {
+			return "", nil
+		}
+		if len(p.roots) == 0 {
+			return "", errors.New("새 작업 디렉토리를 만들 기준 디렉토리가 없습니다 (work_dir 또는 work_dirs.allowed_roots를 설정하세요)")
+		}
+		dir = p.roots[0]
+	}
+
+	dir = expandTilde(dir)
+	if !filepath.IsAbs(dir) {
+		if p.defaultDir == "" {
+			return "", fmt.Errorf("상대 경로 작업 디렉토리 '%s'는 work_dir이 설정되어 있어야 합니다", requested)
+		}
+		dir = filepath.Join(p.defaultDir, dir)
+	}
+	// 아직 없는 하위 경로도 존재하는 상위 경로의 링크를 해석하여 검사한다.
+	resolved, err := resolveExistingPrefix(filepath.Clean(dir))
+	if err != nil {
+		return "", err
+	}
+
+	if len(p.roots) > 0 && !p.isAllowed(resolved) {
+		return "", fmt.Errorf("%w: %s", ErrWorkDirNotAllowed, requested)
+	}
+
+	if newDir {
+		sub := filepath.Join(resolved, sanitizeTranscriptName(executionID))
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			return "", fmt.Errorf("실행별 작업 디렉토리 생성 실패: %w", err)
+		}
+		return sub, nil
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", fmt.Errorf("작업 디렉토리를 열 수 없습니다: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("작업 디렉토리가 디렉토리가 아닙니다: %s", resolved)
+	}
+	return resolved, nil
+}
+
+// isAllowed는 경로가 허용 루트 중 하나의 하위 경로인지 확인합니다.
+func (p *WorkDirPolicy) isAllowed(path string) bool {
+	for _, root := range p.roots {
+		if isSubPath(path, root) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithWorkDirPolicy는 서버가 요청한 작업 디렉토리를 허용 루트로 검증하고
+// 기본 디렉토리와 실행별 하위 디렉토리를 적용하도록 설정합니다.
+func WithWorkDirPolicy(policy *WorkDirPolicy) TaskExecutorOption {
+	return func(e *TaskExecutor) {
+		e.workDirs = policy
+	}
+}
+
+// resolveWorkDir은 작업 디렉토리 정책을 적용한 작업 디렉토리를 반환합니다.
+// 정책이 없으면 요청한 경로를 그대로 사용합니다.
+func (e *TaskExecutor) resolveWorkDir(executionID, requested string, newDir bool) (string, error) {
+	if e.workDirs == nil {
+		return requested, nil
+	}
+	dir, err := e.workDirs.Resolve(executionID, requested, newDir)
+	if err == nil {
+		return dir, nil
+	}
+	e.logger.Warn().
+		Str("execution_id", executionID).
+		Str("work_dir", requested).
+		Err(err).
+		Msg("작업 디렉토리 거부")
+	if errors.Is(err, ErrWorkDirNotAllowed) {
+		return "", &TaskError{
+			Code:            ErrorCodeWorkDirNotAllowed,
+			Message:         i18n.T("task.error.work_dir_denied", requested),
+			AllowedWorkDirs: e.workDirs.Roots(),
+		}
+	}
+	return "", &TaskError{
+		Code:    ErrorCodeWorkDirInvalid,
+		Message: i18n.T("task.error.work_dir_invalid", err),
+	}
+}
@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// realDir은 macOS의 /var -> /private/var처럼 링크가 포함된 임시 디렉토리를 실제 경로로 바꿉니다.
+func realDir(t *testing.T, dir string) string {
+	t.Helper()
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatalf("EvalSymlinks(%s) error = %v", dir, err)
+	}
+	return resolved
+}
+
+func TestWorkDirPolicy_Resolve(t *testing.T) {
+	root := realDir(t, t.TempDir())
+	outside := realDir(t, t.TempDir())
+	project := filepath.Join(root, "project")
+	if err := os.Mkdir(project, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	policy := NewWorkDirPolicy(root, config.WorkDirsConfig{AllowedRoots: []string{project}})
+
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+		wantErr   error
+	}{
+		{name: "기본 디렉토리", requested: "", want: root},
+		{name: "허용 루트 하위", requested: project, want: project},
+		{name: "상대 경로", requested: "project", want: project},
+		{name: "루트 밖", requested: outside, wantErr: ErrWorkDirNotAllowed},
+		{name: "상위 이동", requested: "../", wantErr: ErrWorkDirNotAllowed},
+		{name: "심볼릭 링크 탈출", requested: filepath.Join(root, "escape"), wantErr: ErrWorkDirNotAllowed},
+		{name: "없는 디렉토리", requested: filepath.Join(root, "missing")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Resolve("exec-1", tt.requested, false)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("Resolve() = %q, 에러 기대", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("Resolve() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkDirPolicy_NewWorkDir(t *testing.T) {
+	root := realDir(t, t.TempDir())
+
+	policy := NewWorkDirPolicy("", config.WorkDirsConfig{AllowedRoots: []string{root}})
+	got, err := policy.Resolve("exec-42", "", true)
+	if err != nil {
+		t.Fatalf("Resolve(newDir) error = %v", err)
+	}
+	if want := filepath.Join(root, "exec-42"); got != want {
+		t.Fatalf("Resolve(newDir) = %q, want %q", got, want)
+	}
+	if info, err := os.Stat(got); err != nil || !info.IsDir() {
+		t.Errorf("실행별 디렉토리가 생성되지 않았습니다: %v", err)
+	}
+
+	// per_execution이면 요청하지 않아도 실행별 디렉토리를 만든다.
+	perExec := NewWorkDirPolicy(root, config.WorkDirsConfig{PerExecution: true})
+	got, err = perExec.Resolve("exec-43", "nested", false)
+	if err != nil {
+		t.Fatalf("Resolve(per_execution) error = %v", err)
+	}
+	if want := filepath.Join(root, "nested", "exec-43"); got != want {
+		t.Errorf("Resolve(per_execution) = %q, want %q", got, want)
+	}
+
+	if _, err := NewWorkDirPolicy("", config.WorkDirsConfig{}).Resolve("exec-44", "", true); err == nil {
+		t.Error("기준 디렉토리 없이 새 작업 디렉토리를 요청하면 에러여야 합니다")
+	}
+}
+
+func TestWorkDirPolicy_Unconfigured(t *testing.T) {
+	policy := NewWorkDirPolicy("", config.WorkDirsConfig{})
+	for _, requested := range []string{"", "/any/where", "relative"} {
+		if got, err := policy.Resolve("exec-1", requested, false); err != nil || got != requested {
+			t.Errorf("Resolve(%q) = %q, %v, 그대로 반환 기대", requested, got, err)
+		}
+	}
+}
+
+func TestTaskExecutor_WorkDirNotAllowed(t *testing.T) {
+	root := realDir(t, t.TempDir())
+	registry := provider.NewRegistry()
+	var calls int
+	registry.Register(&mockProvider{
+		name: "claude",
+		executeFunc: func(ctx context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+			calls++
+			return &provider.ExecuteResponse{Output: "ok"}, nil
+		},
+	})
+	e := NewTaskExecutor(registry, newMockSender(),
+		WithWorkDirPolicy(NewWorkDirPolicy("", config.WorkDirsConfig{AllowedRoots: []string{root}})))
+
+	_, err := e.Execute(context.Background(), ws.TaskRequestPayload{
+		ExecutionID: "exec-denied",
+		Prompt:      "Hello",
+		Model:       "claude-sonnet",
+		WorkDir:     t.TempDir(),
+		Timeout:     60,
+	})
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Code != ErrorCodeWorkDirNotAllowed {
+		t.Fatalf("Execute() error = %v, want %s", err, ErrorCodeWorkDirNotAllowed)
+	}
+	if len(taskErr.WorkDirRoots()) != 1 || taskErr.WorkDirRoots()[0] != root {
+		t.Errorf("WorkDirRoots() = %v, want [%s]", taskErr.WorkDirRoots(), root)
+	}
+	if calls != 0 {
+		t.Errorf("거부된 작업이 프로바이더에서 실행되었습니다 (%d회)", calls)
+	}
+}
@@ -0,0 +1,163 @@
+package filesync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/websocket"
+)
+
+// fakeSender는 전송된 페이로드를 기록하는 테스트용 Sender입니다.
+type fakeSender struct {
+	mu       sync.Mutex
+	payloads []websocket.FileSyncPayload
+}
+
+func (f *fakeSender) SendFileSync(payload websocket.FileSyncPayload) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.payloads = append(f.payloads, payload)
+	return nil
+}
+
+func (f *fakeSender) snapshot() []websocket.FileSyncPayload {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]websocket.FileSyncPayload(nil), f.payloads...)
+}
+
+func TestConvertGitignoreLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"", nil},
+		{"# comment", nil},
+		{"!keep.txt", nil},
+		{"*.log", []string{"*.log"}},
+		{"/build", []string{"build"}},
+		{"dist/", []string{"dist", "dist/**"}},
+	}
+	for _, tt := range tests {
+		got := convertGitignoreLine(tt.line)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("convertGitignoreLine(%q) = %v, 기대값 %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestFilter_Allow(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.go")
+	big := filepath.Join(dir, "big.go")
+	if err := os.WriteFile(small, []byte("package x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(big, make([]byte, 2048), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	smallInfo, _ := os.Stat(small)
+	bigInfo, _ := os.Stat(big)
+
+	f := NewFilter([]string{"tmp/**"}, 1024, []string{"go", ".md"})
+
+	if !f.Allow("small.go", smallInfo) {
+		t.Error("허용 확장자의 작은 파일은 허용되어야 합니다")
+	}
+	if f.Allow("big.go", bigInfo) {
+		t.Error("최대 크기를 초과한 파일은 제외되어야 합니다")
+	}
+	if f.Allow("notes.txt", nil) {
+		t.Error("허용 목록에 없는 확장자는 제외되어야 합니다")
+	}
+	if f.Allow("tmp/a.go", nil) {
+		t.Error("사용자 제외 패턴에 해당하는 파일은 제외되어야 합니다")
+	}
+	if f.Allow(".env", nil) {
+		t.Error("기본 제외 패턴(.env)에 해당하는 파일은 제외되어야 합니다")
+	}
+}
+
+func TestNew_RequiresDirectories(t *testing.T) {
+	if _, err := New(&fakeSender{}, Options{}); err == nil {
+		t.Error("디렉토리가 없으면 에러가 반환되어야 합니다")
+	}
+	if _, err := New(nil, Options{Directories: []string{t.TempDir()}}); err == nil {
+		t.Error("sender가 nil이면 에러가 반환되어야 합니다")
+	}
+}
+
+func TestSyncer_SendsDebouncedDiff(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(target, []byte("line1\nline2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("ignored/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "ignored"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &fakeSender{}
+	s, err := New(sender, Options{
+		Directories:  []string{dir},
+		UseGitignore: true,
+		Debounce:     100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New 실패: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := s.Start(ctx); err != nil {
+		t.Fatalf("Start 실패: %v", err)
+	}
+	defer s.Close()
+
+	if err := os.WriteFile(target, []byte("line1\nchanged\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ignored", "skip.go"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && len(sender.snapshot()) == 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	payloads := sender.snapshot()
+	if len(payloads) == 0 {
+		t.Fatal("file_sync 페이로드가 전송되지 않았습니다")
+	}
+	var found bool
+	for _, p := range payloads {
+		for _, c := range p.Changes {
+			if strings.HasPrefix(c.Path, "ignored/") {
+				t.Errorf(".gitignore 대상 파일이 전송되었습니다: %s", c.Path)
+			}
+			if c.Path == "main.go" {
+				found = true
+				if c.ChangeType != websocket.FileChangeModify {
+					t.Errorf("change_type이 modify이어야 하나 %s입니다", c.ChangeType)
+				}
+				if !strings.Contains(c.Diff, "-line2") || !strings.Contains(c.Diff, "+changed") {
+					t.Errorf("diff에 변경 내용이 포함되어야 합니다: %q", c.Diff)
+				}
+				if c.Hash == "" {
+					t.Error("hash가 비어 있습니다")
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("main.go 변경이 전송되지 않았습니다")
+	}
+}
@@ -0,0 +1,67 @@
+package filesync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/knowledgesync"
+)
+
+// Filter는 동기화 대상 파일을 결정합니다.
+// 제외 패턴(.gitignore 포함), 최대 파일 크기, 확장자 허용 목록을 순서대로 검사합니다.
+type Filter struct {
+	patterns   []string
+	maxSize    int64
+	extensions map[string]struct{}
+}
+
+// NewFilter는 새 Filter를 생성합니다.
+// maxSize가 0 이하이면 크기 제한이 없고, extensions가 비어 있으면 모든 확장자를 허용합니다.
+func NewFilter(patterns []string, maxSize int64, extensions []string) *Filter {
+	f := &Filter{
+		patterns: knowledgesync.MergePatterns(knowledgesync.DefaultExcludePatterns, patterns),
+		maxSize:  maxSize,
+	}
+	if len(extensions) > 0 {
+		f.extensions = make(map[string]struct{}, len(extensions))
+		for _, ext := range extensions {
+			ext = strings.ToLower(strings.TrimSpace(ext))
+			if ext == "" {
+				continue
+			}
+			if !strings.HasPrefix(ext, ".") {
+				ext = "." + ext
+			}
+			f.extensions[ext] = struct{}{}
+		}
+	}
+	return f
+}
+
+// Excluded는 루트 기준 상대 경로가 제외 패턴에 해당하는지 반환합니다.
+func (f *Filter) Excluded(relPath string) bool {
+	return knowledgesync.IsExcluded(filepath.ToSlash(relPath), f.patterns)
+}
+
+// Allow는 파일이 동기화 대상인지 판단합니다.
+// info가 nil이면 크기 검사를 건너뜁니다 (삭제된 파일).
+func (f *Filter) Allow(relPath string, info os.FileInfo) bool {
+	if f.Excluded(relPath) {
+		return false
+	}
+	if info != nil {
+		if info.IsDir() {
+			return false
+		}
+		if f.maxSize > 0 && info.Size() > f.maxSize {
+			return false
+		}
+	}
+	if f.extensions != nil {
+		if _, ok := f.extensions[strings.ToLower(filepath.Ext(relPath))]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+// Package filesync는 로컬 작업 디렉토리의 변경을 감지해 백엔드로 파일 diff를 전송하는
+// 워크스페이스 컨텍스트 동기화 서브시스템입니다.
+package filesync
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadGitignore는 root/.gitignore를 읽어 knowledgesync.IsExcluded 호환 패턴으로 변환합니다.
+// 파일이 없으면 nil을 반환합니다.
+//
+// 지원 범위:
+//   - 주석(#)과 빈 줄은 무시
+//   - 선행 "/"(루트 고정)는 제거하여 상대 경로 패턴으로 처리
+//   - 후행 "/"(디렉토리 전용)는 디렉토리 이름과 하위 경로("/**") 패턴으로 확장
+//   - 부정 패턴("!")은 지원하지 않으며 무시
+func LoadGitignore(root string) []string {
+	f, err := os.Open(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		patterns = append(patterns, convertGitignoreLine(scanner.Text())...)
+	}
+	return patterns
+}
+
+// convertGitignoreLine은 .gitignore 한 줄을 0개 이상의 제외 패턴으로 변환합니다.
+func convertGitignoreLine(line string) []string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+		return nil
+	}
+
+	line = strings.TrimPrefix(line, "/")
+	dirOnly := strings.HasSuffix(line, "/")
+	line = strings.TrimSuffix(line, "/")
+	if line == "" {
+		return nil
+	}
+
+	if dirOnly {
+		return []string{line, line + "/**"}
+	}
+	return []string{line}
+}
@@ -0,0 +1,338 @@
+package filesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/rs/zerolog/log"
+
+	"github.com/insajin/autopus-bridge/internal/knowledgesync"
+	"github.com/insajin/autopus-bridge/internal/websocket"
+)
+
+// DefaultDebounce는 변경 배치 전송 전 대기 시간 기본값입니다.
+const DefaultDebounce = time.Second
+
+// diffContextLines는 unified diff에 포함할 문맥 줄 수입니다.
+const diffContextLines = 3
+
+// Sender는 파일 변경 배치를 백엔드로 전송합니다.
+// websocket.Client가 구현합니다.
+type Sender interface {
+	SendFileSync(payload websocket.FileSyncPayload) error
+}
+
+// Options는 Syncer 생성 옵션입니다.
+type Options struct {
+	// Directories는 감시할 디렉토리 목록입니다.
+	Directories []string
+	// IgnorePatterns는 추가 제외 패턴입니다.
+	IgnorePatterns []string
+	// UseGitignore가 true이면 각 디렉토리의 .gitignore 패턴을 적용합니다.
+	UseGitignore bool
+	// MaxFileSize는 동기화할 최대 파일 크기(바이트)입니다. 0 이하이면 제한이 없습니다.
+	MaxFileSize int64
+	// Extensions는 허용할 확장자 목록입니다. 비어 있으면 모든 확장자를 허용합니다.
+	Extensions []string
+	// Debounce는 변경 배치 전송 전 대기 시간입니다. 0 이하이면 DefaultDebounce가 사용됩니다.
+	Debounce time.Duration
+}
+
+// syncRoot는 감시 중인 단일 디렉토리의 상태입니다.
+type syncRoot struct {
+	path    string
+	filter  *Filter
+	watcher *knowledgesync.Watcher
+
+	mu sync.Mutex
+	// snapshots는 상대 경로 → 마지막으로 전송한 파일 내용입니다 (diff 기준).
+	snapshots map[string][]byte
+	// pending은 상대 경로 → 아직 전송하지 않은 변경 유형입니다.
+	pending map[string]string
+	timer   *time.Timer
+}
+
+// Syncer는 설정된 디렉토리를 감시하고 디바운스된 파일 diff를 백엔드로 전송합니다.
+type Syncer struct {
+	sender   Sender
+	debounce time.Duration
+	roots    []*syncRoot
+
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// New는 새 Syncer를 생성합니다. 각 디렉토리의 초기 스냅샷을 수집하지만
+// 감시는 Start 호출 시 시작됩니다.
+func New(sender Sender, opts Options) (*Syncer, error) {
+	if sender == nil {
+		return nil, fmt.Errorf("filesync: sender가 필요합니다")
+	}
+	if len(opts.Directories) == 0 {
+		return nil, fmt.Errorf("filesync: 감시할 디렉토리가 없습니다")
+	}
+
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	s := &Syncer{sender: sender, debounce: debounce}
+	for _, dir := range opts.Directories {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("filesync: 경로 해석 실패 (%s): %w", dir, err)
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return nil, fmt.Errorf("filesync: 디렉토리 확인 실패 (%s): %w", abs, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("filesync: 디렉토리가 아닙니다: %s", abs)
+		}
+
+		patterns := append([]string{}, opts.IgnorePatterns...)
+		if opts.UseGitignore {
+			patterns = append(patterns, LoadGitignore(abs)...)
+		}
+
+		root := &syncRoot{
+			path:      abs,
+			filter:    NewFilter(patterns, opts.MaxFileSize, opts.Extensions),
+			snapshots: make(map[string][]byte),
+			pending:   make(map[string]string),
+		}
+		root.loadSnapshots()
+		s.roots = append(s.roots, root)
+	}
+	return s, nil
+}
+
+// Start는 모든 디렉토리 감시를 시작합니다. ctx가 취소되면 감시를 중단합니다.
+func (s *Syncer) Start(ctx context.Context) error {
+	for _, root := range s.roots {
+		w, err := knowledgesync.NewWatcher(root.filter.patterns)
+		if err != nil {
+			s.Close()
+			return fmt.Errorf("filesync: watcher 생성 실패: %w", err)
+		}
+		if err := w.Add(root.path); err != nil {
+			_ = w.Close()
+			s.Close()
+			return fmt.Errorf("filesync: 감시 등록 실패 (%s): %w", root.path, err)
+		}
+		root.watcher = w
+
+		s.wg.Add(1)
+		go s.run(ctx, root)
+	}
+	return nil
+}
+
+// Close는 모든 감시를 중단하고 대기 중인 변경을 즉시 전송합니다.
+func (s *Syncer) Close() {
+	s.closeOnce.Do(func() {
+		for _, root := range s.roots {
+			if root.watcher != nil {
+				_ = root.watcher.Close()
+			}
+		}
+		s.wg.Wait()
+		for _, root := range s.roots {
+			s.flush(root)
+		}
+	})
+}
+
+// run은 단일 디렉토리의 watcher 이벤트를 소비합니다.
+func (s *Syncer) run(ctx context.Context, root *syncRoot) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			_ = root.watcher.Close()
+			return
+		case ev, ok := <-root.watcher.Events():
+			if !ok {
+				return
+			}
+			s.enqueue(root, ev)
+		}
+	}
+}
+
+// enqueue는 변경 이벤트를 배치에 추가하고 디바운스 타이머를 재설정합니다.
+func (s *Syncer) enqueue(root *syncRoot, ev knowledgesync.WatcherChangeEvent) {
+	rel, err := filepath.Rel(root.path, ev.FilePath)
+	if err != nil || rel == "." {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+	if root.filter.Excluded(rel) {
+		return
+	}
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.pending[rel] = ev.Type
+	if root.timer != nil {
+		root.timer.Stop()
+	}
+	root.timer = time.AfterFunc(s.debounce, func() { s.flush(root) })
+}
+
+// flush는 대기 중인 변경으로 diff 배치를 만들어 전송합니다.
+func (s *Syncer) flush(root *syncRoot) {
+	root.mu.Lock()
+	pending := root.pending
+	root.pending = make(map[string]string)
+	if root.timer != nil {
+		root.timer.Stop()
+		root.timer = nil
+	}
+	changes := root.buildChanges(pending)
+	root.mu.Unlock()
+
+	if len(changes) == 0 {
+		return
+	}
+
+	payload := websocket.FileSyncPayload{
+		Root:      root.path,
+		Changes:   changes,
+		Timestamp: time.Now().UnixMilli(),
+	}
+	if err := s.sender.SendFileSync(payload); err != nil {
+		log.Warn().Err(err).Str("root", root.path).Int("changes", len(changes)).Msg("[filesync] 파일 변경 전송 실패")
+		return
+	}
+	log.Debug().Str("root", root.path).Int("changes", len(changes)).Msg("[filesync] 파일 변경 전송")
+}
+
+// buildChanges는 대기 중인 경로의 현재 상태를 스냅샷과 비교해 변경 목록을 만듭니다.
+// 호출자가 root.mu를 보유해야 합니다.
+func (r *syncRoot) buildChanges(pending map[string]string) []websocket.FileChange {
+	paths := make([]string, 0, len(pending))
+	for rel := range pending {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	var changes []websocket.FileChange
+	for _, rel := range paths {
+		prev, hadPrev := r.snapshots[rel]
+		abs := filepath.Join(r.path, filepath.FromSlash(rel))
+
+		info, statErr := os.Stat(abs)
+		if statErr != nil || info.IsDir() {
+			if !hadPrev {
+				continue
+			}
+			delete(r.snapshots, rel)
+			changes = append(changes, websocket.FileChange{
+				Path:       rel,
+				ChangeType: websocket.FileChangeDelete,
+				Diff:       unifiedDiff(rel, prev, nil),
+			})
+			continue
+		}
+
+		if !r.filter.Allow(rel, info) {
+			continue
+		}
+		data, err := os.ReadFile(abs)
+		if err != nil {
+			continue
+		}
+		if hadPrev && string(prev) == string(data) {
+			continue
+		}
+
+		changeType := websocket.FileChangeModify
+		if !hadPrev {
+			changeType = websocket.FileChangeCreate
+		}
+		r.snapshots[rel] = data
+		changes = append(changes, websocket.FileChange{
+			Path:       rel,
+			ChangeType: changeType,
+			Diff:       unifiedDiff(rel, prev, data),
+			Hash:       hashContent(data),
+			Size:       int64(len(data)),
+		})
+	}
+	return changes
+}
+
+// loadSnapshots는 디렉토리를 순회하여 초기 스냅샷을 수집합니다.
+func (r *syncRoot) loadSnapshots() {
+	_ = filepath.WalkDir(r.path, func(current string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, relErr := filepath.Rel(r.path, current)
+		if relErr != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if r.filter.Excluded(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, infoErr := d.Info()
+		if infoErr != nil || !info.Mode().IsRegular() || !r.filter.Allow(rel, info) {
+			return nil
+		}
+		if data, readErr := os.ReadFile(current); readErr == nil {
+			r.snapshots[rel] = data
+		}
+		return nil
+	})
+}
+
+// unifiedDiff는 이전/이후 내용의 unified diff를 생성합니다.
+func unifiedDiff(path string, before, after []byte) string {
+	fromFile, toFile := "a/"+path, "b/"+path
+	if before == nil {
+		fromFile = "/dev/null"
+	}
+	if after == nil {
+		toFile = "/dev/null"
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// splitLines는 내용을 줄 단위로 나눕니다. 빈 내용은 빈 목록입니다.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(string(data))
+}
+
+// hashContent는 내용의 SHA-256 해시를 16진수 문자열로 반환합니다.
+func hashContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
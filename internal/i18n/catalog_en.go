@@ -0,0 +1,168 @@
+package i18n
+
+// en은 영어 메시지 카탈로그입니다. 다른 언어에 없는 메시지의 대체 값으로도 사용합니다.
+var en = map[string]string{
+	// cmd: 공통
+	"cmd.config.home_not_found":   "cannot find home directory: %[1]v",
+	"cmd.config.read_failed":      "failed to read config file: %[1]v",
+	"cmd.config.legacy_keys":      "warning: the config file uses legacy keys. They keep working under their new names during the transition:",
+	"cmd.config.legacy_keys_hint": "  run 'autopus config migrate' to rewrite the config file with the current keys",
+
+	// cmd: status
+	"cmd.status.collect_failed":    "failed to collect status",
+	"cmd.status.json_failed":       "failed to serialize JSON",
+	"cmd.status.title":             "Autopus Local Bridge Status",
+	"cmd.status.connected":         "Status:      connected",
+	"cmd.status.disconnected":      "Status:      disconnected",
+	"cmd.status.pid":               "Process ID:  %[1]d",
+	"cmd.status.workspace":         "Workspace:   %[1]s",
+	"cmd.status.bridge":            "Bridge:      %[1]s (%[2]s)",
+	"cmd.status.bridge_id_pending": "ID assigned on first connect",
+	"cmd.status.labels":            "Labels:      %[1]s",
+	"cmd.status.ai_mode":           "AI mode:     %[1]s",
+	"cmd.status.oauth_providers":   "OAuth:       %[1]s",
+	"cmd.status.server":            "Server:      %[1]s",
+	"cmd.status.uptime":            "Uptime:      %[1]s",
+	"cmd.status.quality":           "Quality:     %[1]d/100 (%[2]s)",
+	"cmd.status.quality_detail":    "             RTT %[1]dms · loss %[2]d%% · recent reconnects %[3]d · heartbeat %[4]ds",
+	"cmd.status.quality_good":      "good",
+	"cmd.status.quality_fair":      "fair",
+	"cmd.status.quality_poor":      "poor",
+	"cmd.status.tasks_title":       "Tasks",
+	"cmd.status.current_task":      "Current:     %[1]s (running)",
+	"cmd.status.no_current_task":   "Current:     none",
+	"cmd.status.tasks_completed":   "Completed:   %[1]d",
+	"cmd.status.tasks_failed":      "Failed:      %[1]d",
+	"cmd.status.env_title":         "Environment",
+	"cmd.status.env_set":           "  %[1]s: set",
+	"cmd.status.env_unset":         "  %[1]s: not set",
+	"cmd.status.connect_hint":      "To connect to the server:",
+	"cmd.status.oauth_title":       "OAuth connection",
+	"cmd.status.oauth_guide":       "Connect an OpenAI (ChatGPT) or Google (Gemini) subscription account\nto run AI inference directly on the server without the Bridge.",
+	"cmd.status.oauth_how":         "How to connect: Workspace settings > AI providers > OAuth",
+	"cmd.status.mode_oauth":        "OAuth (direct server HTTP)",
+	"cmd.status.mode_bridge":       "Bridge (local AI)",
+	"cmd.status.mode_byok":         "BYOK (API key)",
+	"cmd.status.mode_platform":     "Platform (provided by Autopus)",
+	"cmd.status.file_path_missing": "cannot find status file path",
+	"cmd.status.config_dir_failed": "failed to create config directory",
+	"cmd.status.file_write_failed": "failed to write status file",
+	"cmd.duration.days":            "%[1]dd %[2]dh %[3]dm",
+	"cmd.duration.hours":           "%[1]dh %[2]dm %[3]ds",
+	"cmd.duration.minutes":         "%[1]dm %[2]ds",
+	"cmd.duration.seconds":         "%[1]ds",
+
+	// mcpserver: 인자 검증
+	"mcp.validation.invalid_decode": "invalid '%[1]s' JSON: %[2]s",
+	"mcp.validation.required_if":    "%[1]s is required for '%[2]s' %[3]s",
+	"mcp.validation.at_least_one":   "either %[1]s is required",
+	"mcp.validation.enum":           "%[1]s must be one of: %[2]s",
+	"mcp.validation.json_kind":      "invalid %[1]s JSON: %[2]s",
+	"mcp.validation.between":        "%[1]s must be between %[2]s and %[3]s",
+	"mcp.validation.at_least":       "%[1]s must be at least %[2]s",
+	"mcp.validation.at_most":        "%[1]s must be at most %[2]s",
+	"mcp.validation.missing":        "required parameter '%[1]s' is missing",
+	"mcp.validation.type":           "parameter '%[1]s' must be %[2]s %[3]s",
+
+	// mcpserver: 에이전트 정의 검증
+	"mcp.agent.invalid_config":    "invalid agent config: %[1]s",
+	"mcp.agent.name_required":     "config.name is required to create an agent",
+	"mcp.agent.config_empty":      "config must set at least one field to update",
+	"mcp.agent.tool_name_missing": "config.tools[%[1]d] has no name",
+	"mcp.agent.tool_duplicate":    "config.tools lists tool %[1]q more than once",
+	"mcp.agent.parameters_object": "config.%[1]s must be a JSON schema object",
+
+	// mcpserver: 도구 권한
+	"mcp.permission.tool_disabled":   "tool '%[1]s' is disabled by configuration",
+	"mcp.permission.action_disabled": "action '%[2]s' of tool '%[1]s' is disabled by configuration",
+	"mcp.permission.read_only":       "tool '%[1]s' is read-only; %[2]s modifies state",
+
+	// mcpserver: 도구 실행
+	"mcp.tool.serialize_failed":              "Failed to serialize response",
+	"mcp.tool.execute_task_failed":           "Failed to execute task: %[1]s",
+	"mcp.tool.list_agents_failed":            "Failed to list agents: %[1]s",
+	"mcp.tool.get_agent_details_failed":      "Failed to get agent details: %[1]s",
+	"mcp.tool.get_execution_status_failed":   "Failed to get execution status: %[1]s",
+	"mcp.tool.get_execution_diff_failed":     "Failed to get execution diff: %[1]s",
+	"mcp.tool.approve_execution_failed":      "Failed to approve/reject execution: %[1]s",
+	"mcp.tool.manage_workspace_failed":       "Failed to manage workspace: %[1]s",
+	"mcp.tool.manage_agent_failed":           "Failed to manage agent: %[1]s",
+	"mcp.knowledge.offline_cached_query":     "Backend unreachable; returning cached results for the same query from %[2]s (offline result): %[1]s",
+	"mcp.knowledge.offline_lexical":          "Backend unreachable; returning approximate results ranked by word match over locally cached documents (offline result): %[1]s",
+	"mcp.knowledge.filters_ignored":          "filters were not applied to the offline search.",
+	"mcp.tool.search_knowledge_failed":       "Failed to search knowledge: %[1]s",
+	"mcp.tool.get_knowledge_document_failed": "Failed to get knowledge document: %[1]s",
+	"mcp.tool.list_knowledge_sources_failed": "Failed to list knowledge sources: %[1]s",
+	"mcp.tool.create_message_failed":         "Failed to create message: %[1]s",
+	"mcp.tool.execute_template_failed":       "Failed to execute template: %[1]s",
+	"mcp.tool.set_active_workspace_failed":   "Failed to set active workspace: %[1]s",
+	"mcp.tool.queue_timeout":                 "tool '%[1]s' is busy: no execution slot within %[2]s, retry later",
+	"mcp.tool.timeout":                       "tool '%[1]s' stopped after exceeding its %[2]s timeout (%[3]s)",
+	"mcp.template.agent_required":            "template %[1]s has neither agent_id nor agent",
+	"mcp.template.agent_not_found":           "agent for template %[1]s not found: %[2]s",
+	"mcp.template.agent_ambiguous":           "multiple agents match the agent name of template %[1]s: %[2]s",
+	"mcp.sampling.disabled":                  "Local sampling is not enabled",
+	"mcp.sampling.prompt_required":           "either 'prompt' or 'messages' is required",
+
+	// executor: 작업 진행/에러
+	"task.progress.started":              "Task started",
+	"task.progress.running":              "Task running...",
+	"task.progress.streaming":            "Streaming...",
+	"task.progress.resumed":              "Resumed from checkpoint",
+	"task.progress.completed":            "Task completed",
+	"task.error.stopped":                 "executor is stopped",
+	"task.error.sandbox_denied":          "work directory access denied: %[1]v",
+	"task.error.provider_not_found":      "no provider found for model '%[1]s': %[2]v",
+	"task.error.unsupported_model":       "pinned execution cannot be satisfied locally: %[1]s",
+	"task.error.isolation_failed":        "failed to isolate work directory: %[1]v",
+	"task.error.credentials_failed":      "failed to prepare task credentials: %[1]v",
+	"task.error.credentials_unsupported": "provider %[1]s does not start a process per task, so it cannot receive task credentials",
+	"task.error.work_dir_denied":         "work directory '%[1]s' is outside the allowed roots",
+	"task.error.work_dir_invalid":        "invalid work directory: %[1]v",
+	"task.error.empty_response":          "The AI provider returned an empty response. Please check the provider status.",
+	"task.error.timeout":                 "task execution timed out",
+	"task.error.cancelled":               "task was cancelled",
+	"task.error.rate_limited":            "API rate limit exceeded",
+	"task.error.no_api_key":              "API key is not configured",
+	"task.error.internal":                "error while executing task: %[1]v",
+
+	// eventhook: 데스크톱 알림
+	"notify.approval_required":       "Approval pending: %[1]s",
+	"notify.action_approval_pending": "Approval needed in the terminal: %[1]s",
+	"notify.task_failed":             "Task failed: %[1]s",
+	"notify.reconnect_exhausted":     "Gave up reconnecting to the server. Restart the bridge",
+
+	// errcode: 에러 코드 힌트
+	"errcode.hint_line":                        "Hint: %[1]s",
+	"errcode.hint.unknown":                     "Check the bridge log output for details.",
+	"errcode.hint.invalid_payload":             "The server sent a message this bridge cannot parse. Update the bridge (autopus update) so its protocol version matches the server.",
+	"errcode.hint.no_executor":                 "This bridge is not set up to run this kind of request. Restart it with autopus connect, or route the request to another bridge.",
+	"errcode.hint.no_handler":                  "This bridge has no handler for the request type. Enable the feature in the bridge config and reconnect.",
+	"errcode.hint.execution_error":             "The request failed while running. Check the message and the bridge logs, then retry.",
+	"errcode.hint.bridge_shutting_down":        "The bridge is shutting down. The request can be retried on another bridge or after it reconnects.",
+	"errcode.hint.bridge_auth_required":        "The bridge login expired and is waiting for re-authentication. Open the renewal link shown in the bridge terminal or run autopus login, then retry.",
+	"errcode.hint.checkpoint_not_found":        "No checkpoint is left to resume from. Reassign the task so it starts over.",
+	"errcode.hint.delegation_rejected":         "No other bridge accepted the task. Connect a bridge for the target platform or enable local fallback.",
+	"errcode.hint.replayed_message":            "The request was too old or already processed, so it was not run again. Check that the server and bridge clocks agree, or raise security.replay_protection.window_seconds.",
+	"errcode.hint.provider_not_found":          "No AI provider for this model is configured. Run autopus setup to install or log in to the provider CLI.",
+	"errcode.hint.provider_error":              "The AI provider returned an error. Check its status, API key and rate limits, then retry.",
+	"errcode.hint.timeout":                     "The task ran longer than allowed. Retry, or raise the task timeout.",
+	"errcode.hint.cancelled":                   "The task was cancelled. Submit it again if it is still needed.",
+	"errcode.hint.internal_error":              "An unexpected bridge error occurred. Check the bridge logs and report it if it persists.",
+	"errcode.hint.sandbox_violation":           "The work directory is outside the allowed paths. Add it to the sandbox allowed paths or use a project directory.",
+	"errcode.hint.unsupported_model":           "The pinned provider or model is not available on this bridge. Choose one of the listed alternatives or install the provider.",
+	"errcode.hint.isolation_failed":            "The isolated work directory could not be created. Check disk space and that the project is a clean git repository.",
+	"errcode.hint.credentials_failed":          "Task credentials could not be prepared. Check the credentials configuration and the secret store.",
+	"errcode.hint.work_dir_not_allowed":        "The requested work directory is outside the bridge's allowed roots. Use one of the listed roots or add it to work_dir.allowed_roots.",
+	"errcode.hint.work_dir_invalid":            "The requested work directory does not exist or could not be created. Check the path, or set work_dir.default for relative paths.",
+	"errcode.hint.read_only":                   "The bridge is running in read-only mode. Send the request to another bridge, or restart it without --read-only (read_only: false).",
+	"errcode.hint.prompt_too_large":            "The task prompt is larger than the bridge allows. Shorten the prompt or attach large content as files, or raise limits.max_prompt_bytes.",
+	"errcode.hint.permission_denied":           "The tool or action is disabled in the MCP permission settings. Allow it under mcp_server.tools in the config.",
+	"errcode.hint.tool_busy":                   "Too many calls are running. Retry shortly, or raise mcp_server.concurrency limits.",
+	"errcode.hint.quota_exceeded":              "The disk quota is full. Remove unused generated services or raise the disk quota.",
+	"errcode.hint.mcp_start_failed":            "The MCP server could not start. Check its command, arguments and environment in the MCP config.",
+	"errcode.hint.mcp_stop_failed":             "The MCP server did not stop cleanly. Retry with force, or stop the process manually.",
+	"errcode.hint.relay_runner_not_configured": "Coding relay is not set up on this bridge. Enable coding sessions in the bridge config and reconnect.",
+	"errcode.hint.relay_slot_exhausted":        "All coding session slots are in use. Retry after a running session finishes.",
+	"errcode.hint.relay_session_failed":        "The coding agent session failed. Check that the coding CLI is installed and logged in, then retry.",
+}
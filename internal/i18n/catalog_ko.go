@@ -0,0 +1,168 @@
+package i18n
+
+// ko는 한국어 메시지 카탈로그입니다.
+var ko = map[string]string{
+	// cmd: 공통
+	"cmd.config.home_not_found":   "홈 디렉토리를 찾을 수 없습니다: %[1]v",
+	"cmd.config.read_failed":      "설정 파일 읽기 실패: %[1]v",
+	"cmd.config.legacy_keys":      "경고: 설정 파일에 이전 버전의 키가 있습니다. 전환 기간 동안은 현재 키로 계속 적용됩니다:",
+	"cmd.config.legacy_keys_hint": "  'autopus config migrate'로 설정 파일을 현재 키로 변환하세요",
+
+	// cmd: status
+	"cmd.status.collect_failed":    "상태 수집 실패",
+	"cmd.status.json_failed":       "JSON 직렬화 실패",
+	"cmd.status.title":             "Autopus Local Bridge 상태",
+	"cmd.status.connected":         "상태:        연결됨",
+	"cmd.status.disconnected":      "상태:        연결되지 않음",
+	"cmd.status.pid":               "프로세스 ID: %[1]d",
+	"cmd.status.workspace":         "워크스페이스: %[1]s",
+	"cmd.status.bridge":            "Bridge:      %[1]s (%[2]s)",
+	"cmd.status.bridge_id_pending": "첫 연결 시 ID 생성",
+	"cmd.status.labels":            "라벨:        %[1]s",
+	"cmd.status.ai_mode":           "AI 모드:     %[1]s",
+	"cmd.status.oauth_providers":   "OAuth 연결:  %[1]s",
+	"cmd.status.server":            "서버:        %[1]s",
+	"cmd.status.uptime":            "연결 시간:   %[1]s",
+	"cmd.status.quality":           "연결 품질:   %[1]d/100 (%[2]s)",
+	"cmd.status.quality_detail":    "             RTT %[1]dms · 응답 누락 %[2]d%% · 최근 재연결 %[3]d회 · 하트비트 %[4]d초",
+	"cmd.status.quality_good":      "양호",
+	"cmd.status.quality_fair":      "불안정",
+	"cmd.status.quality_poor":      "나쁨",
+	"cmd.status.tasks_title":       "작업 통계",
+	"cmd.status.current_task":      "현재 작업:   %[1]s (실행 중)",
+	"cmd.status.no_current_task":   "현재 작업:   없음",
+	"cmd.status.tasks_completed":   "완료됨:      %[1]d",
+	"cmd.status.tasks_failed":      "실패함:      %[1]d",
+	"cmd.status.env_title":         "환경변수 상태",
+	"cmd.status.env_set":           "  %[1]s: 설정됨",
+	"cmd.status.env_unset":         "  %[1]s: 설정되지 않음",
+	"cmd.status.connect_hint":      "서버에 연결하려면:",
+	"cmd.status.oauth_title":       "OAuth 연결 안내",
+	"cmd.status.oauth_guide":       "OpenAI(ChatGPT) 또는 Google(Gemini) 구독 계정을 연결하면\nBridge 없이 서버에서 직접 AI 추론이 가능합니다.",
+	"cmd.status.oauth_how":         "연결 방법: 워크스페이스 설정 > AI 프로바이더 > OAuth 연결",
+	"cmd.status.mode_oauth":        "OAuth (서버 직접 HTTP)",
+	"cmd.status.mode_bridge":       "Bridge (로컬 AI)",
+	"cmd.status.mode_byok":         "BYOK (API 키)",
+	"cmd.status.mode_platform":     "Platform (Autopus 제공)",
+	"cmd.status.file_path_missing": "상태 파일 경로를 찾을 수 없습니다",
+	"cmd.status.config_dir_failed": "설정 디렉토리 생성 실패",
+	"cmd.status.file_write_failed": "상태 파일 저장 실패",
+	"cmd.duration.days":            "%[1]d일 %[2]d시간 %[3]d분",
+	"cmd.duration.hours":           "%[1]d시간 %[2]d분 %[3]d초",
+	"cmd.duration.minutes":         "%[1]d분 %[2]d초",
+	"cmd.duration.seconds":         "%[1]d초",
+
+	// mcpserver: 인자 검증
+	"mcp.validation.invalid_decode": "'%[1]s' 파라미터의 JSON 구조가 올바르지 않습니다",
+	"mcp.validation.required_if":    "'%[2]s' %[3]s에는 '%[1]s' 파라미터가 필요합니다",
+	"mcp.validation.at_least_one":   "%[2]s 중 하나 이상이 필요합니다",
+	"mcp.validation.enum":           "'%[1]s' 파라미터는 다음 중 하나여야 합니다: %[2]s",
+	"mcp.validation.json_kind":      "'%[1]s' 파라미터는 올바른 JSON %[3]s이어야 합니다",
+	"mcp.validation.between":        "'%[1]s' 파라미터는 %[2]s 이상 %[3]s 이하여야 합니다",
+	"mcp.validation.at_least":       "'%[1]s' 파라미터는 %[2]s 이상이어야 합니다",
+	"mcp.validation.at_most":        "'%[1]s' 파라미터는 %[2]s 이하여야 합니다",
+	"mcp.validation.missing":        "필수 파라미터 '%[1]s'가 누락되었습니다",
+	"mcp.validation.type":           "'%[1]s' 파라미터는 %[4]s 타입이어야 합니다",
+
+	// mcpserver: 에이전트 정의 검증
+	"mcp.agent.invalid_config":    "에이전트 설정이 올바르지 않습니다: %[1]s",
+	"mcp.agent.name_required":     "에이전트를 만들려면 config.name이 필요합니다",
+	"mcp.agent.config_empty":      "수정할 필드를 config에 하나 이상 지정해야 합니다",
+	"mcp.agent.tool_name_missing": "config.tools[%[1]d]에 이름이 없습니다",
+	"mcp.agent.tool_duplicate":    "config.tools에 %[1]q 도구가 중복되었습니다",
+	"mcp.agent.parameters_object": "config.%[1]s는 JSON 스키마 객체여야 합니다",
+
+	// mcpserver: 도구 권한
+	"mcp.permission.tool_disabled":   "'%[1]s' 도구는 설정에서 비활성화되었습니다",
+	"mcp.permission.action_disabled": "'%[1]s' 도구의 '%[2]s' 작업은 설정에서 비활성화되었습니다",
+	"mcp.permission.read_only":       "'%[1]s' 도구는 읽기 전용으로 설정되어 상태를 변경할 수 없습니다",
+
+	// mcpserver: 도구 실행
+	"mcp.tool.serialize_failed":              "응답 직렬화 실패",
+	"mcp.tool.execute_task_failed":           "작업 실행 실패: %[1]s",
+	"mcp.tool.list_agents_failed":            "에이전트 목록 조회 실패: %[1]s",
+	"mcp.tool.get_agent_details_failed":      "에이전트 상세 조회 실패: %[1]s",
+	"mcp.tool.get_execution_status_failed":   "실행 상태 조회 실패: %[1]s",
+	"mcp.tool.get_execution_diff_failed":     "실행 변경 사항 조회 실패: %[1]s",
+	"mcp.tool.approve_execution_failed":      "실행 승인/거부 실패: %[1]s",
+	"mcp.tool.manage_workspace_failed":       "워크스페이스 관리 실패: %[1]s",
+	"mcp.tool.manage_agent_failed":           "에이전트 관리 실패: %[1]s",
+	"mcp.knowledge.offline_cached_query":     "백엔드에 연결할 수 없어 %[2]s에 캐시된 같은 쿼리의 검색 결과를 반환합니다 (오프라인 결과): %[1]s",
+	"mcp.knowledge.offline_lexical":          "백엔드에 연결할 수 없어 로컬에 캐시된 문서에서 단어 일치로 찾은 근사 결과를 반환합니다 (오프라인 결과): %[1]s",
+	"mcp.knowledge.filters_ignored":          "오프라인 검색에는 filters가 적용되지 않았습니다.",
+	"mcp.tool.search_knowledge_failed":       "지식 검색 실패: %[1]s",
+	"mcp.tool.get_knowledge_document_failed": "지식 문서 조회 실패: %[1]s",
+	"mcp.tool.list_knowledge_sources_failed": "지식 소스 목록 조회 실패: %[1]s",
+	"mcp.tool.create_message_failed":         "메시지 생성 실패: %[1]s",
+	"mcp.tool.execute_template_failed":       "템플릿 실행 실패: %[1]s",
+	"mcp.tool.set_active_workspace_failed":   "활성 워크스페이스 변경 실패: %[1]s",
+	"mcp.tool.queue_timeout":                 "도구 '%[1]s' 호출이 많아 %[2]s 안에 실행하지 못했습니다. 잠시 후 다시 시도하세요",
+	"mcp.tool.timeout":                       "도구 '%[1]s' 실행이 %[2]s 타임아웃(%[3]s)을 넘겨 중단되었습니다",
+	"mcp.template.agent_required":            "템플릿 %[1]s에 agent_id 또는 agent가 없습니다",
+	"mcp.template.agent_not_found":           "템플릿 %[1]s의 에이전트를 찾을 수 없습니다: %[2]s",
+	"mcp.template.agent_ambiguous":           "템플릿 %[1]s의 에이전트 이름과 일치하는 에이전트가 여러 개입니다: %[2]s",
+	"mcp.sampling.disabled":                  "로컬 샘플링이 활성화되지 않았습니다",
+	"mcp.sampling.prompt_required":           "'prompt' 또는 'messages' 중 하나가 필요합니다",
+
+	// executor: 작업 진행/에러
+	"task.progress.started":              "작업 시작",
+	"task.progress.running":              "작업 실행 중...",
+	"task.progress.streaming":            "스트리밍 중...",
+	"task.progress.resumed":              "체크포인트에서 재개",
+	"task.progress.completed":            "작업 완료",
+	"task.error.stopped":                 "실행기가 중지된 상태입니다",
+	"task.error.sandbox_denied":          "작업 디렉토리 접근 거부: %[1]v",
+	"task.error.provider_not_found":      "모델 '%[1]s'에 대한 프로바이더를 찾을 수 없습니다: %[2]v",
+	"task.error.unsupported_model":       "고정 실행 조건을 로컬에서 만족할 수 없습니다: %[1]s",
+	"task.error.isolation_failed":        "작업 디렉토리 격리 실패: %[1]v",
+	"task.error.credentials_failed":      "작업 자격 증명 준비 실패: %[1]v",
+	"task.error.credentials_unsupported": "%[1]s 프로바이더는 작업마다 프로세스를 실행하지 않아 작업 자격 증명을 전달할 수 없습니다",
+	"task.error.work_dir_denied":         "작업 디렉토리 '%[1]s'가 허용된 루트 밖에 있습니다",
+	"task.error.work_dir_invalid":        "유효하지 않은 작업 디렉토리: %[1]v",
+	"task.error.empty_response":          "AI 프로바이더가 빈 응답을 반환했습니다. 프로바이더 상태를 확인해주세요.",
+	"task.error.timeout":                 "작업 실행 시간이 초과되었습니다",
+	"task.error.cancelled":               "작업이 취소되었습니다",
+	"task.error.rate_limited":            "API 레이트 리밋 초과",
+	"task.error.no_api_key":              "API 키가 설정되지 않았습니다",
+	"task.error.internal":                "작업 실행 중 오류 발생: %[1]v",
+
+	// eventhook: 데스크톱 알림
+	"notify.approval_required":       "승인 대기 중: %[1]s",
+	"notify.action_approval_pending": "터미널에서 승인이 필요합니다: %[1]s",
+	"notify.task_failed":             "작업 실패: %[1]s",
+	"notify.reconnect_exhausted":     "서버 재연결을 포기했습니다. Bridge를 다시 시작하세요",
+
+	// errcode: 에러 코드 힌트
+	"errcode.hint_line":                        "해결 방법: %[1]s",
+	"errcode.hint.unknown":                     "자세한 내용은 Bridge 로그 출력을 확인하세요.",
+	"errcode.hint.invalid_payload":             "서버가 보낸 메시지를 해석할 수 없습니다. 서버와 프로토콜 버전이 맞도록 Bridge를 업데이트하세요 (autopus update).",
+	"errcode.hint.no_executor":                 "이 Bridge는 해당 유형의 요청을 실행하도록 설정되지 않았습니다. autopus connect로 다시 시작하거나 다른 Bridge로 요청을 보내세요.",
+	"errcode.hint.no_handler":                  "이 Bridge에 해당 요청 유형의 핸들러가 없습니다. Bridge 설정에서 기능을 켜고 다시 연결하세요.",
+	"errcode.hint.execution_error":             "요청 실행 중 실패했습니다. 메시지와 Bridge 로그를 확인한 뒤 다시 시도하세요.",
+	"errcode.hint.bridge_shutting_down":        "Bridge가 종료 중입니다. 다른 Bridge에서 또는 재연결 후 다시 시도할 수 있습니다.",
+	"errcode.hint.bridge_auth_required":        "Bridge 로그인이 만료되어 재인증을 기다리는 중입니다. Bridge 터미널에 표시된 재인증 링크를 열거나 autopus login을 실행한 뒤 다시 시도하세요.",
+	"errcode.hint.checkpoint_not_found":        "재개할 체크포인트가 없습니다. 작업을 다시 할당하여 처음부터 실행하세요.",
+	"errcode.hint.delegation_rejected":         "작업을 받을 다른 Bridge가 없습니다. 대상 플랫폼의 Bridge를 연결하거나 로컬 실행 폴백을 켜세요.",
+	"errcode.hint.replayed_message":            "오래되었거나 이미 처리한 요청이어서 다시 실행하지 않았습니다. 서버와 Bridge의 시계가 맞는지 확인하거나 security.replay_protection.window_seconds를 늘리세요.",
+	"errcode.hint.provider_not_found":          "이 모델의 AI 프로바이더가 설정되지 않았습니다. autopus setup으로 프로바이더 CLI를 설치하거나 로그인하세요.",
+	"errcode.hint.provider_error":              "AI 프로바이더가 에러를 반환했습니다. 프로바이더 상태, API 키, 요청 한도를 확인한 뒤 다시 시도하세요.",
+	"errcode.hint.timeout":                     "작업이 허용 시간을 넘었습니다. 다시 시도하거나 작업 타임아웃을 늘리세요.",
+	"errcode.hint.cancelled":                   "작업이 취소되었습니다. 필요하면 다시 요청하세요.",
+	"errcode.hint.internal_error":              "예상치 못한 Bridge 에러입니다. Bridge 로그를 확인하고 계속되면 문제를 보고하세요.",
+	"errcode.hint.sandbox_violation":           "작업 디렉토리가 허용된 경로 밖에 있습니다. 샌드박스 허용 경로에 추가하거나 프로젝트 디렉토리를 사용하세요.",
+	"errcode.hint.unsupported_model":           "고정된 프로바이더나 모델을 이 Bridge에서 사용할 수 없습니다. 함께 전달된 대체 목록에서 고르거나 프로바이더를 설치하세요.",
+	"errcode.hint.isolation_failed":            "격리된 작업 디렉토리를 만들지 못했습니다. 디스크 공간과 프로젝트가 정상적인 git 저장소인지 확인하세요.",
+	"errcode.hint.credentials_failed":          "작업 자격 증명을 준비하지 못했습니다. 자격 증명 설정과 시크릿 저장소를 확인하세요.",
+	"errcode.hint.work_dir_not_allowed":        "요청한 작업 디렉토리가 Bridge의 허용 루트 밖에 있습니다. 함께 전달된 루트 중 하나를 사용하거나 work_dir.allowed_roots에 추가하세요.",
+	"errcode.hint.work_dir_invalid":            "요청한 작업 디렉토리가 없거나 만들 수 없습니다. 경로를 확인하거나, 상대 경로를 쓰려면 work_dir.default를 설정하세요.",
+	"errcode.hint.read_only":                   "Bridge가 읽기 전용 모드로 실행 중입니다. 다른 Bridge로 요청하거나 --read-only 없이(read_only: false) 다시 시작하세요.",
+	"errcode.hint.prompt_too_large":            "작업 프롬프트가 Bridge 허용 크기보다 큽니다. 프롬프트를 줄이거나 큰 내용은 파일로 첨부하세요. limits.max_prompt_bytes로 한도를 올릴 수 있습니다.",
+	"errcode.hint.permission_denied":           "MCP 권한 설정에서 비활성화된 도구 또는 작업입니다. 설정의 mcp_server.tools에서 허용하세요.",
+	"errcode.hint.tool_busy":                   "실행 중인 호출이 너무 많습니다. 잠시 후 다시 시도하거나 mcp_server.concurrency 한도를 늘리세요.",
+	"errcode.hint.quota_exceeded":              "디스크 할당량이 가득 찼습니다. 사용하지 않는 생성 서비스를 정리하거나 할당량을 늘리세요.",
+	"errcode.hint.mcp_start_failed":            "MCP 서버를 시작하지 못했습니다. MCP 설정의 명령어, 인자, 환경 변수를 확인하세요.",
+	"errcode.hint.mcp_stop_failed":             "MCP 서버가 정상적으로 중지되지 않았습니다. force로 다시 시도하거나 프로세스를 직접 종료하세요.",
+	"errcode.hint.relay_runner_not_configured": "이 Bridge에 코딩 릴레이가 설정되지 않았습니다. Bridge 설정에서 코딩 세션을 켜고 다시 연결하세요.",
+	"errcode.hint.relay_slot_exhausted":        "모든 코딩 세션 슬롯이 사용 중입니다. 실행 중인 세션이 끝난 뒤 다시 시도하세요.",
+	"errcode.hint.relay_session_failed":        "코딩 에이전트 세션이 실패했습니다. 코딩 CLI가 설치되어 있고 로그인되어 있는지 확인한 뒤 다시 시도하세요.",
+}
@@ -0,0 +1,119 @@
+// Package i18n은 CLI, MCP 서버, 작업 에러 메시지의 다국어 카탈로그를 제공합니다.
+// 언어는 --lang 플래그 > 설정 파일(language) > LC_ALL/LC_MESSAGES/LANG 환경변수 순으로 결정되며,
+// 결정할 수 없으면 DefaultLang을 사용합니다.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Lang은 메시지 언어 코드입니다.
+type Lang string
+
+const (
+	// Korean은 한국어입니다.
+	Korean Lang = "ko"
+	// English는 영어입니다.
+	English Lang = "en"
+)
+
+// DefaultLang은 언어를 결정할 수 없을 때 사용하는 언어입니다.
+const DefaultLang = Korean
+
+// catalogs는 언어별 메시지 카탈로그입니다 (메시지 키 -> fmt 형식 문자열).
+// 형식 문자열은 언어마다 어순이 다를 수 있으므로 %[n]s처럼 인자 번호를 명시합니다.
+var catalogs = map[Lang]map[string]string{
+	Korean:  ko,
+	English: en,
+}
+
+var current atomic.Value // Lang
+
+// Supported는 지원하는 언어 목록을 반환합니다.
+func Supported() []Lang {
+	return []Lang{Korean, English}
+}
+
+// SetLang은 현재 메시지 언어를 설정합니다.
+func SetLang(lang Lang) {
+	current.Store(lang)
+}
+
+// Current는 현재 메시지 언어를 반환합니다. 설정되지 않았으면 DefaultLang입니다.
+func Current() Lang {
+	if lang, ok := current.Load().(Lang); ok {
+		return lang
+	}
+	return DefaultLang
+}
+
+// Parse는 언어 코드 또는 로캘 문자열을 지원 언어로 변환합니다.
+// "ko", "ko-KR", "ko_KR.UTF-8", "en_US" 등을 인식합니다.
+func Parse(s string) (Lang, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "_-.@"); i >= 0 {
+		s = s[:i]
+	}
+	for _, lang := range Supported() {
+		if s == string(lang) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// Detect는 명시적으로 지정된 값(플래그, 설정 순)과 로캘 환경변수로 언어를 결정합니다.
+// 명시적으로 지정된 값이 지원하지 않는 언어이면 에러를 반환합니다.
+// 환경변수의 지원하지 않는 로캘(C, POSIX 등)은 무시합니다.
+func Detect(explicit ...string) (Lang, error) {
+	for _, value := range explicit {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		lang, ok := Parse(value)
+		if !ok {
+			return DefaultLang, fmt.Errorf("unsupported language %q (supported: %s)", value, supportedList())
+		}
+		return lang, nil
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang, ok := Parse(os.Getenv(env)); ok {
+			return lang, nil
+		}
+	}
+	return DefaultLang, nil
+}
+
+// T는 현재 언어로 메시지를 반환합니다.
+func T(key string, args ...any) string {
+	return Tl(Current(), key, args...)
+}
+
+// Tl은 지정한 언어로 메시지를 반환합니다.
+// 해당 언어에 메시지가 없으면 영어, 영어에도 없으면 키를 그대로 반환합니다.
+func Tl(lang Lang, key string, args ...any) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		format, ok = en[key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// supportedList는 지원 언어 목록을 쉼표로 연결합니다.
+func supportedList() string {
+	langs := Supported()
+	names := make([]string, len(langs))
+	for i, lang := range langs {
+		names[i] = string(lang)
+	}
+	return strings.Join(names, ", ")
+}
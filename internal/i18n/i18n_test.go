@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var argIndexPattern = regexp.MustCompile(`%\[(\d+)\]`)
+
+// maxArgIndex는 형식 문자열이 참조하는 가장 큰 인자 번호를 반환합니다.
+func maxArgIndex(format string) int {
+	max := 0
+	for _, m := range argIndexPattern.FindAllStringSubmatch(format, -1) {
+		if n, _ := strconv.Atoi(m[1]); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+func TestCatalogs_SameKeysAndArgs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range en {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s 카탈로그에 %q 메시지가 없습니다", lang, key)
+			}
+		}
+		for key, format := range catalog {
+			enFormat, ok := en[key]
+			if !ok {
+				t.Errorf("영어 카탈로그에 %q 메시지가 없습니다 (%s)", key, lang)
+				continue
+			}
+			// 리터럴 %%를 제외한 모든 verb는 %[n] 형식이어야 합니다.
+			if plain := strings.ReplaceAll(format, "%%", ""); strings.Count(plain, "%") != strings.Count(plain, "%[") {
+				t.Errorf("%s %q: 인자 번호 없는 verb가 있습니다: %q", lang, key, format)
+			}
+
+			// 영어와 같은 인자 목록으로 포맷해도 인자 개수/번호 오류가 없어야 합니다.
+			n := max(maxArgIndex(format), maxArgIndex(enFormat))
+			args := make([]any, n)
+			for i := range args {
+				args[i] = 1
+			}
+			if got := Tl(lang, key, args...); strings.Contains(got, "%!(") {
+				t.Errorf("%s %q 포맷 오류: %q", lang, key, got)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Lang
+		ok   bool
+	}{
+		{"ko", Korean, true},
+		{"KO", Korean, true},
+		{"ko_KR.UTF-8", Korean, true},
+		{"en-US", English, true},
+		{"en_GB@euro", English, true},
+		{"C", "", false},
+		{"fr_FR.UTF-8", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Parse(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if got, err := Detect("", ""); err != nil || got != English {
+		t.Errorf("LANG 환경변수: got %q, %v; want en", got, err)
+	}
+	if got, err := Detect("", "ko"); err != nil || got != Korean {
+		t.Errorf("설정 값이 환경변수보다 우선해야 합니다: got %q, %v", got, err)
+	}
+	if got, err := Detect("en", "ko"); err != nil || got != English {
+		t.Errorf("플래그가 설정 값보다 우선해야 합니다: got %q, %v", got, err)
+	}
+	if _, err := Detect("fr"); err == nil {
+		t.Error("지원하지 않는 언어는 에러여야 합니다")
+	}
+
+	t.Setenv("LC_ALL", "C")
+	t.Setenv("LANG", "C.UTF-8")
+	if got, err := Detect(); err != nil || got != DefaultLang {
+		t.Errorf("로캘을 알 수 없으면 기본 언어여야 합니다: got %q, %v", got, err)
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLang(Current())
+
+	SetLang(English)
+	if got := T("task.error.provider_not_found", "gpt-x", "missing"); got != "no provider found for model 'gpt-x': missing" {
+		t.Errorf("영어 메시지: %q", got)
+	}
+	SetLang(Korean)
+	if got := T("mcp.validation.required_if", "id", "get", "action"); got != "'get' action에는 'id' 파라미터가 필요합니다" {
+		t.Errorf("한국어 어순 메시지: %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("없는 키는 키를 그대로 반환해야 합니다: %q", got)
+	}
+}
@@ -0,0 +1,183 @@
+// Package identity는 여러 Bridge를 운영하는 조직이 서버에서 Bridge를 구분하고
+// 지정할 수 있도록 Bridge 식별 정보(고정 ID, 이름, 사용자 정의 라벨)를 관리합니다.
+package identity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+const (
+	// idFileName은 생성한 Bridge ID를 보관하는 파일 이름입니다.
+	idFileName = "bridge-id"
+	// MaxLabels는 Bridge 하나에 붙일 수 있는 최대 라벨 수입니다.
+	MaxLabels = 32
+	// maxLabelLength는 라벨 키와 값의 최대 길이입니다.
+	maxLabelLength = 63
+)
+
+var (
+	// labelKeyPattern은 라벨 키 형식입니다 (소문자, 숫자로 시작하고 소문자, 숫자, '.', '_', '-', '/'로 구성).
+	labelKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._/-]*$`)
+	// labelValuePattern은 라벨 값 형식입니다 (영문자, 숫자, '.', '_', '-'로 구성).
+	labelValuePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// DefaultPath는 Bridge ID 파일의 기본 경로(~/.config/autopus/bridge-id)를 반환합니다.
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", idFileName)
+}
+
+// Load는 path에 저장된 Bridge ID를 읽습니다. 아직 생성되지 않았으면 빈 문자열을 반환합니다.
+func Load(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("Bridge ID 읽기 실패: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// LoadOrCreate는 path의 Bridge ID를 반환하고, 없으면 새로 생성해 저장합니다.
+// 재시작과 재로그인에도 같은 ID를 유지하므로 서버가 같은 머신의 Bridge를 하나로 식별할 수 있습니다.
+// 파일이 비어 있으면(이전 버전이 쓰는 도중 중단된 경우) 새 ID로 교체합니다.
+func LoadOrCreate(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("Bridge ID 파일 경로를 알 수 없습니다")
+	}
+	id, err := Load(path)
+	if err != nil || id != "" {
+		return id, err
+	}
+
+	id, err = newID()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("Bridge ID 디렉토리 생성 실패: %w", err)
+	}
+
+	// 다른 프로세스가 쓰는 도중의 빈 파일을 읽지 않도록 임시 파일에 다 쓴 뒤 제자리에 연결합니다.
+	tmp, err := os.CreateTemp(dir, idFileName+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("Bridge ID 저장 실패: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.WriteString(id + "\n"); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("Bridge ID 저장 실패: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("Bridge ID 저장 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("Bridge ID 저장 실패: %w", err)
+	}
+
+	// 두 프로세스가 동시에 생성해도 먼저 연결한 ID를 쓰도록 기존 파일을 덮어쓰지 않는 os.Link를 사용합니다.
+	err = os.Link(tmp.Name(), path)
+	switch {
+	case err == nil:
+		return id, nil
+	case errors.Is(err, os.ErrExist):
+		existing, loadErr := Load(path)
+		if loadErr != nil || existing != "" {
+			return existing, loadErr
+		}
+	}
+	// 빈 파일이 남아 있거나 하드 링크를 지원하지 않는 파일 시스템이면 이름 바꾸기로 교체합니다.
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("Bridge ID 저장 실패: %w", err)
+	}
+	// 동시에 교체한 프로세스가 있으면 마지막에 남은 ID를 사용합니다.
+	return Load(path)
+}
+
+// newID는 "br-" 접두사가 붙은 무작위 128비트 ID를 생성합니다.
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("Bridge ID 생성 실패: %w", err)
+	}
+	return "br-" + hex.EncodeToString(b), nil
+}
+
+// ParseLabels는 "key=value" 형식의 라벨 목록을 맵으로 변환합니다.
+// 키는 소문자로 정규화하며, 같은 키가 여러 번 나오면 마지막 값을 사용합니다.
+func ParseLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("유효하지 않은 라벨 %q: key=value 형식이어야 합니다", pair)
+		}
+		labels[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// ValidateLabels는 라벨 키와 값 형식, 개수를 검증합니다.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("라벨은 최대 %d개까지 지정할 수 있습니다 (현재 %d개)", MaxLabels, len(labels))
+	}
+	for key, value := range labels {
+		if len(key) > maxLabelLength || !labelKeyPattern.MatchString(key) {
+			return fmt.Errorf("유효하지 않은 라벨 키 %q: 소문자, 숫자, '.', '_', '-', '/'로 %d자 이하여야 합니다", key, maxLabelLength)
+		}
+		if len(value) > maxLabelLength || !labelValuePattern.MatchString(value) {
+			return fmt.Errorf("유효하지 않은 라벨 값 %s=%q: 영문자, 숫자, '.', '_', '-'로 %d자 이하여야 합니다", key, value, maxLabelLength)
+		}
+	}
+	return nil
+}
+
+// New는 Bridge 식별 정보를 만듭니다. name이 비어 있으면 호스트 이름을 사용합니다.
+// 서로 다른 출처의 라벨을 합칠 때는 뒤의 라벨이 앞의 라벨을 덮어씁니다 (예: 설정 파일 다음 --label 플래그).
+func New(id, name string, labels ...map[string]string) *ws.BridgeIdentity {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+	merged := make(map[string]string)
+	for _, l := range labels {
+		for key, value := range l {
+			merged[key] = value
+		}
+	}
+	if len(merged) == 0 {
+		merged = nil
+	}
+	return &ws.BridgeIdentity{ID: id, Name: name, Labels: merged}
+}
+
+// FormatLabels는 라벨을 키 순서대로 "key=value" 목록으로 변환합니다 (상태 출력용).
+func FormatLabels(labels map[string]string) []string {
+	out := make([]string, 0, len(labels))
+	for key, value := range labels {
+		out = append(out, key+"="+value)
+	}
+	sort.Strings(out)
+	return out
+}
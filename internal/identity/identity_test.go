@@ -0,0 +1,112 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLoadOrCreate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "autopus", "bridge-id")
+
+	if id, err := Load(path); err != nil || id != "" {
+		t.Fatalf("생성 전 Load() = (%q, %v), want 빈 ID", id, err)
+	}
+
+	id, err := LoadOrCreate(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreate() error = %v", err)
+	}
+	if !strings.HasPrefix(id, "br-") || len(id) != len("br-")+32 {
+		t.Errorf("id = %q, want br- 접두사와 32자리 16진수", id)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("ID 파일이 없습니다: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("ID 파일 권한 = %v, want 0600", info.Mode().Perm())
+	}
+
+	again, err := LoadOrCreate(path)
+	if err != nil || again != id {
+		t.Errorf("두 번째 LoadOrCreate() = (%q, %v), want 같은 ID %q", again, err, id)
+	}
+}
+
+func TestLoadOrCreate_EmptyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bridge-id")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := LoadOrCreate(path)
+	if err != nil || !strings.HasPrefix(id, "br-") {
+		t.Fatalf("LoadOrCreate() = (%q, %v), want 새 ID", id, err)
+	}
+	if stored, err := Load(path); err != nil || stored != id {
+		t.Errorf("Load() = (%q, %v), want %q", stored, err, id)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("임시 파일이 남아 있습니다: %v", entries)
+	}
+}
+
+func TestLoadOrCreate_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bridge-id")
+
+	const n = 8
+	ids := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], errs[i] = LoadOrCreate(path)
+		}()
+	}
+	wg.Wait()
+
+	for i := range n {
+		if errs[i] != nil || ids[i] == "" || ids[i] != ids[0] {
+			t.Errorf("LoadOrCreate() #%d = (%q, %v), want 모두 같은 ID %q", i, ids[i], errs[i], ids[0])
+		}
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels([]string{"Team=backend", "os=mac", "team=infra"})
+	if err != nil {
+		t.Fatalf("ParseLabels() error = %v", err)
+	}
+	if want := map[string]string{"team": "infra", "os": "mac"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("ParseLabels() = %v, want %v", labels, want)
+	}
+
+	for _, bad := range []string{"team", "=backend", "team=", "team=back end", "-team=x"} {
+		if _, err := ParseLabels([]string{bad}); err == nil {
+			t.Errorf("ParseLabels(%q) error = nil, want 에러", bad)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	got := New("br-1", "", map[string]string{"team": "backend", "os": "linux"}, map[string]string{"os": "mac"})
+	hostname, _ := os.Hostname()
+	if got.ID != "br-1" || got.Name != hostname {
+		t.Errorf("New() = %+v, want 호스트 이름 %q", got, hostname)
+	}
+	if want := []string{"os=mac", "team=backend"}; !reflect.DeepEqual(FormatLabels(got.Labels), want) {
+		t.Errorf("labels = %v, want %v", FormatLabels(got.Labels), want)
+	}
+
+	if got := New("br-1", "build-mac"); got.Name != "build-mac" || got.Labels != nil {
+		t.Errorf("New() = %+v", got)
+	}
+}
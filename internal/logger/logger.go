@@ -8,6 +8,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/insajin/autopus-bridge/internal/config"
@@ -40,6 +41,49 @@ func (w *maskedWriter) Write(p []byte) (n int, err error) {
 	return w.underlying.Write([]byte(masked))
 }
 
+// recentLogLimit은 크래시 리포트용으로 메모리에 보관하는 최근 로그 줄 수입니다.
+const recentLogLimit = 500
+
+// recentLogs는 마스킹된 최근 로그 줄을 보관하는 링 버퍼입니다.
+var recentLogs = &lineBuffer{limit: recentLogLimit}
+
+// lineBuffer는 최근 limit개의 로그 줄만 보관하는 io.Writer입니다.
+type lineBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	limit int
+}
+
+// Write는 로그를 줄 단위로 보관하고, limit을 넘는 오래된 줄은 버립니다.
+func (b *lineBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		b.lines = append(b.lines, line)
+	}
+	if over := len(b.lines) - b.limit; over > 0 {
+		b.lines = append([]string(nil), b.lines[over:]...)
+	}
+	return len(p), nil
+}
+
+// snapshot은 보관 중인 로그 줄의 복사본을 반환합니다.
+func (b *lineBuffer) snapshot() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.lines...)
+}
+
+// RecentLogs는 Setup 이후 기록된 최근 로그 줄(민감 정보 마스킹 적용)을 반환합니다.
+// 크래시 리포트 번들에 포함하는 용도입니다.
+func RecentLogs() []string {
+	return recentLogs.snapshot()
+}
+
 // Setup은 로거를 초기화합니다.
 func Setup(cfg config.LoggingConfig) {
 	// 로그 레벨 설정
@@ -51,6 +95,7 @@ func Setup(cfg config.LoggingConfig) {
 
 	// 출력 대상 설정
 	var output io.Writer = os.Stdout
+	toFile := false
 	if cfg.File != "" {
 		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
 		if err != nil {
@@ -58,24 +103,36 @@ func Setup(cfg config.LoggingConfig) {
 			log.Warn().Err(err).Str("file", cfg.File).Msg("로그 파일을 열 수 없어 stdout을 사용합니다")
 		} else {
 			output = file
+			toFile = true
 		}
 	}
 
-	// 민감 정보 마스킹 Writer 래핑
-	maskedOutput := &maskedWriter{underlying: output}
+	// 민감 정보 마스킹 Writer 래핑 (크래시 리포트용 최근 로그 버퍼에도 기록)
+	maskedOutput := &maskedWriter{underlying: io.MultiWriter(output, recentLogs)}
 
 	// 포맷 설정
+	var writer io.Writer = maskedOutput
 	if cfg.Format == "text" {
 		// 콘솔 포맷 (개발 시 가독성)
-		consoleWriter := zerolog.ConsoleWriter{
+		writer = zerolog.ConsoleWriter{
 			Out:        maskedOutput,
 			TimeFormat: time.RFC3339,
 		}
-		log.Logger = zerolog.New(consoleWriter).With().Timestamp().Caller().Logger()
-	} else {
-		// JSON 포맷 (기본값, REQ-U-01)
-		log.Logger = zerolog.New(maskedOutput).With().Timestamp().Caller().Logger()
 	}
+	// JSON 포맷이 기본값 (REQ-U-01)
+	log.Logger = zerolog.New(writer).With().Timestamp().Caller().Logger()
+
+	// 로그 파일을 사용하면 표준 log 출력도 같은 파일에 구조화해 남긴다 (autopus-bridge logs local).
+	// 호출 위치는 stdLogWriter가 source 필드로 기록하므로 Caller는 붙이지 않는다.
+	if toFile {
+		redirectStdLog(zerolog.New(writer).With().Timestamp().Logger())
+	}
+}
+
+// SetLevel은 실행 중에 전역 로그 레벨을 변경합니다.
+// 설정 hot-reload에서 logging.level 변경을 재시작 없이 반영할 때 사용합니다.
+func SetLevel(level string) {
+	zerolog.SetGlobalLevel(parseLevel(level))
 }
 
 // parseLevel은 문자열 레벨을 zerolog.Level로 변환합니다.
@@ -1,7 +1,11 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
+
+	"github.com/rs/zerolog"
 )
 
 // TestMaskSensitive는 민감 정보 마스킹 기능을 테스트합니다.
@@ -102,3 +106,59 @@ func TestMaskValue(t *testing.T) {
 		})
 	}
 }
+
+// TestSetLevel은 실행 중 전역 로그 레벨 변경을 테스트합니다.
+func TestSetLevel(t *testing.T) {
+	original := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(original)
+
+	SetLevel("debug")
+	if got := zerolog.GlobalLevel(); got != zerolog.DebugLevel {
+		t.Errorf("SetLevel(debug) 후 레벨 = %v, 기대값 debug", got)
+	}
+
+	SetLevel("unknown")
+	if got := zerolog.GlobalLevel(); got != zerolog.InfoLevel {
+		t.Errorf("알 수 없는 레벨은 info로 처리되어야 하나 %v", got)
+	}
+}
+
+// TestLineBuffer는 최근 로그 링 버퍼가 마지막 limit개 줄만 보관하는지 테스트합니다.
+func TestLineBuffer(t *testing.T) {
+	b := &lineBuffer{limit: 2}
+	_, _ = b.Write([]byte("one\ntwo\n"))
+	_, _ = b.Write([]byte("three\n"))
+
+	got := b.snapshot()
+	if len(got) != 2 || got[0] != "two" || got[1] != "three" {
+		t.Errorf("snapshot() = %v, want [two three]", got)
+	}
+}
+
+// TestStdLogWriter는 표준 log 출력이 component와 레벨이 있는 구조화 로그로 변환되는지 테스트합니다.
+func TestStdLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &stdLogWriter{logger: zerolog.New(&buf)}
+
+	_, _ = w.Write([]byte("/src/autopus-bridge/internal/websocket/handler.go:42: [task-request] 실행 실패: boom\n"))
+
+	var entry map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("JSON 파싱 실패: %v (%s)", err, buf.String())
+	}
+	if entry["component"] != "websocket" || entry["source"] != "handler.go:42" {
+		t.Errorf("component/source = %q/%q", entry["component"], entry["source"])
+	}
+	if entry["level"] != "warn" || entry["message"] != "[task-request] 실행 실패: boom" {
+		t.Errorf("level/message = %q/%q", entry["level"], entry["message"])
+	}
+
+	buf.Reset()
+	_, _ = w.Write([]byte("plain message\n"))
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("JSON 파싱 실패: %v", err)
+	}
+	if entry["component"] != "stdlog" || entry["level"] != "info" {
+		t.Errorf("component/level = %q/%q, want stdlog/info", entry["component"], entry["level"])
+	}
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	stdlog "log"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// stdLogWarnMarkers는 표준 log 메시지를 warn 레벨로 기록할 단서 문자열입니다.
+// 표준 log에는 레벨이 없으므로 실패/에러 메시지를 구분해 logs --level 필터가 동작하도록 합니다.
+var stdLogWarnMarkers = []string{"실패", "에러", "오류", "error", "panic", "타임아웃"}
+
+// stdLogWriter는 표준 log 패키지 출력을 구조화된 zerolog 로그로 변환하는 io.Writer입니다.
+// log.Llongfile 플래그로 기록된 "파일:줄: 메시지"에서 패키지 디렉토리 이름을 component로 사용합니다.
+type stdLogWriter struct {
+	logger zerolog.Logger
+}
+
+// redirectStdLog는 표준 log 출력을 구조화된 로그로 보냅니다.
+// websocket 등 표준 log를 사용하는 패키지의 로그도 로그 파일에 component와 함께 남깁니다.
+func redirectStdLog(l zerolog.Logger) {
+	stdlog.SetPrefix("")
+	stdlog.SetFlags(stdlog.Llongfile)
+	stdlog.SetOutput(&stdLogWriter{logger: l})
+}
+
+// Write는 표준 log 한 줄을 구조화된 로그 이벤트로 기록합니다.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+	component, caller, message := splitStdLogLine(line)
+
+	event := w.logger.Info()
+	lower := strings.ToLower(message)
+	for _, marker := range stdLogWarnMarkers {
+		if strings.Contains(lower, marker) {
+			event = w.logger.Warn()
+			break
+		}
+	}
+	event.Str("component", component).Str("source", caller).Msg(message)
+	return len(p), nil
+}
+
+// splitStdLogLine은 "경로/패키지/파일.go:줄: 메시지"를 component, 호출 위치, 메시지로 나눕니다.
+// 형식이 다르면 component는 "stdlog"입니다.
+func splitStdLogLine(line string) (component, caller, message string) {
+	file, rest, ok := strings.Cut(line, ".go:")
+	if !ok {
+		return "stdlog", "", line
+	}
+	lineNo, msg, ok := strings.Cut(rest, ": ")
+	if !ok {
+		return "stdlog", "", line
+	}
+	file += ".go"
+	return filepath.Base(filepath.Dir(file)), filepath.Base(file) + ":" + lineNo, msg
+}
@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkPolicy는 배포 아카이브에 포함된 심볼릭 링크 처리 방식입니다.
+type SymlinkPolicy string
+
+const (
+	// SymlinkReject는 심볼릭 링크가 있으면 배포를 실패시킵니다 (기본값).
+	SymlinkReject SymlinkPolicy = "reject"
+	// SymlinkSkip은 심볼릭 링크를 건너뜁니다.
+	SymlinkSkip SymlinkPolicy = "skip"
+	// SymlinkAllow는 서비스 디렉토리 안을 가리키는 상대 경로 링크만 생성합니다.
+	SymlinkAllow SymlinkPolicy = "allow"
+)
+
+// ArchiveOptions는 배포 아카이브 압축 해제 옵션입니다.
+type ArchiveOptions struct {
+	// Symlinks는 심볼릭 링크 처리 방식입니다 (비어 있으면 SymlinkReject).
+	Symlinks SymlinkPolicy
+	// MaxFiles는 최대 항목 수입니다 (0이면 제한 없음).
+	MaxFiles int
+	// CheckSize는 기록할 파일 크기의 합으로 기록 전에 호출되며, 에러를 반환하면 압축 해제를 중단합니다.
+	// 압축 폭탄이 디스크를 채우지 않도록 파일 하나를 쓰는 도중에도 호출됩니다.
+	CheckSize func(total int64) error
+}
+
+// ExtractStats는 압축 해제 결과입니다.
+type ExtractStats struct {
+	Files    int   // 기록한 일반 파일 수
+	Dirs     int   // 생성한 디렉토리 수
+	Symlinks int   // 생성한 심볼릭 링크 수
+	Bytes    int64 // 기록한 파일 크기의 합
+}
+
+// ExtractTarGz는 gzip으로 압축된 tar 아카이브를 dest에 풉니다.
+//
+// 절대 경로나 ".."로 dest 밖을 가리키는 항목은 거부하고, 파일 권한은 특수 비트와
+// 그룹/기타 쓰기 권한을 제외하고 유지합니다. 심볼릭 링크는 opts.Symlinks에 따라 처리하며,
+// 허용하는 경우에도 모든 파일을 기록한 뒤 마지막에 생성하여 링크를 통해 dest 밖에 쓰지 않도록 합니다.
+// 하드 링크와 장치/FIFO 항목은 거부합니다.
+func ExtractTarGz(r io.Reader, dest string, opts ArchiveOptions) (ExtractStats, error) {
+	var stats ExtractStats
+	if opts.Symlinks == "" {
+		opts.Symlinks = SymlinkReject
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return stats, fmt.Errorf("아카이브 gzip 헤더 읽기 실패: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	type pendingLink struct{ name, target string }
+	var links []pendingLink
+	// 이미 만든 디렉토리는 다시 MkdirAll하지 않는다 (파일 수천 개 처리 시 stat 호출 절감).
+	madeDirs := map[string]bool{".": true}
+	ensureDir := func(rel string) error {
+		if madeDirs[rel] {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Join(dest, filepath.FromSlash(rel)), 0755); err != nil {
+			return fmt.Errorf("디렉토리 생성 실패 %q: %w", rel, err)
+		}
+		for d := rel; d != "." && !madeDirs[d]; d = path.Dir(d) {
+			madeDirs[d] = true
+		}
+		return nil
+	}
+
+	tr := tar.NewReader(gz)
+	for entries := 0; ; entries++ {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("아카이브 읽기 실패: %w", err)
+		}
+		if opts.MaxFiles > 0 && entries >= opts.MaxFiles {
+			return stats, fmt.Errorf("아카이브 항목 수가 제한(%d개)을 넘습니다", opts.MaxFiles)
+		}
+
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		name, err := archiveEntryPath(hdr.Name)
+		if err != nil {
+			return stats, err
+		}
+		if name == "." {
+			continue
+		}
+		target := filepath.Join(dest, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := ensureDir(name); err != nil {
+				return stats, err
+			}
+			if err := os.Chmod(target, archivePerm(hdr.Mode, 0700)); err != nil {
+				return stats, fmt.Errorf("디렉토리 권한 설정 실패 %q: %w", name, err)
+			}
+			stats.Dirs++
+
+		case tar.TypeReg:
+			if err := ensureDir(path.Dir(name)); err != nil {
+				return stats, err
+			}
+			n, err := writeArchiveFile(target, tr, archivePerm(hdr.Mode, 0600), stats.Bytes, opts.CheckSize)
+			stats.Bytes += n
+			if err != nil {
+				var quotaErr *sizeCheckError
+				if errors.As(err, &quotaErr) {
+					return stats, quotaErr.err
+				}
+				return stats, fmt.Errorf("파일 기록 실패 %q: %w", name, err)
+			}
+			stats.Files++
+
+		case tar.TypeSymlink:
+			switch opts.Symlinks {
+			case SymlinkSkip:
+				continue
+			case SymlinkAllow:
+				if err := checkSymlinkTarget(name, hdr.Linkname); err != nil {
+					return stats, err
+				}
+				links = append(links, pendingLink{name: name, target: hdr.Linkname})
+			default:
+				return stats, fmt.Errorf("아카이브에 심볼릭 링크가 있습니다: %q", name)
+			}
+
+		default:
+			return stats, fmt.Errorf("지원하지 않는 아카이브 항목 %q (type %q)", name, string(hdr.Typeflag))
+		}
+	}
+
+	linkNames := make(map[string]bool, len(links))
+	for _, l := range links {
+		linkNames[l.name] = true
+	}
+	for _, l := range links {
+		// 대상 경로가 다른 링크를 거치면 그 링크가 풀린 위치에서 ".."가 적용되어 dest 밖을 가리킬 수 있다.
+		if err := checkSymlinkChain(l.name, l.target, linkNames); err != nil {
+			return stats, err
+		}
+	}
+	for _, l := range links {
+		if err := ensureDir(path.Dir(l.name)); err != nil {
+			return stats, err
+		}
+		// 앞서 만든 링크를 경로 중간에 두면 링크 기준 상대 경로가 달라져 dest 밖을 가리킬 수 있다.
+		if err := checkNoSymlinkParents(dest, l.name); err != nil {
+			return stats, err
+		}
+		if err := os.Symlink(filepath.FromSlash(l.target), filepath.Join(dest, filepath.FromSlash(l.name))); err != nil {
+			return stats, fmt.Errorf("심볼릭 링크 생성 실패 %q: %w", l.name, err)
+		}
+		stats.Symlinks++
+	}
+
+	return stats, nil
+}
+
+// archiveEntryPath는 아카이브 항목 이름을 정규화된 슬래시 상대 경로로 변환합니다.
+// 절대 경로, 드라이브 문자, dest 밖을 가리키는 ".." 경로는 거부합니다.
+func archiveEntryPath(name string) (string, error) {
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(slashed, "/") || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("아카이브 항목이 절대 경로입니다: %q", name)
+	}
+	cleaned := path.Clean(slashed)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("아카이브 항목이 서비스 디렉토리 밖을 가리킵니다: %q", name)
+	}
+	return cleaned, nil
+}
+
+// checkSymlinkTarget은 링크 대상이 서비스 디렉토리 안의 상대 경로인지 확인합니다.
+func checkSymlinkTarget(name, target string) error {
+	if target == "" || strings.HasPrefix(target, "/") || strings.Contains(target, "\\") || filepath.VolumeName(target) != "" {
+		return fmt.Errorf("허용되지 않는 심볼릭 링크 %q -> %q", name, target)
+	}
+	resolved := path.Clean(path.Join(path.Dir(name), target))
+	if resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Errorf("심볼릭 링크가 서비스 디렉토리 밖을 가리킵니다: %q -> %q", name, target)
+	}
+	return nil
+}
+
+// checkSymlinkChain은 링크 대상 경로가 중간에 아카이브의 다른 심볼릭 링크를 거치지 않는지 확인합니다.
+// checkSymlinkTarget은 경로를 문자 그대로만 정리하므로, l2 -> "."와 l1 -> "l2/.."처럼 링크를 거친 뒤
+// ".."로 올라가는 경로는 통과하지만 실제로는 dest의 상위 디렉토리로 풀립니다.
+func checkSymlinkChain(name, target string, links map[string]bool) error {
+	cur := path.Dir(name)
+	parts := strings.Split(target, "/")
+	for i, part := range parts {
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			cur = path.Dir(cur)
+			continue
+		}
+		cur = path.Join(cur, part)
+		// 마지막 구성 요소가 링크인 것은 그 링크의 대상도 같은 검사를 거치므로 허용한다.
+		if i < len(parts)-1 && links[cur] {
+			return fmt.Errorf("심볼릭 링크가 다른 심볼릭 링크를 거칩니다: %q -> %q", name, target)
+		}
+	}
+	return nil
+}
+
+// checkNoSymlinkParents는 dest 아래 rel의 상위 경로에 심볼릭 링크가 없는지 확인합니다.
+func checkNoSymlinkParents(dest, rel string) error {
+	for d := path.Dir(rel); d != "."; d = path.Dir(d) {
+		info, err := os.Lstat(filepath.Join(dest, filepath.FromSlash(d)))
+		if err != nil {
+			return fmt.Errorf("경로 확인 실패 %q: %w", d, err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("심볼릭 링크 아래에는 항목을 만들 수 없습니다: %q", rel)
+		}
+	}
+	return nil
+}
+
+// archivePerm은 tar 헤더 모드에서 권한 비트만 남기고 그룹/기타 쓰기 권한을 제거합니다.
+// 소유자에게는 최소한 ownerMin 권한을 보장합니다 (재배포 시 덮어쓰기/삭제 가능하도록).
+func archivePerm(mode int64, ownerMin os.FileMode) os.FileMode {
+	return (os.FileMode(mode).Perm() &^ 0022) | ownerMin
+}
+
+// sizeCheckError는 ArchiveOptions.CheckSize가 반환한 에러를 파일 기록 에러와 구분합니다.
+type sizeCheckError struct{ err error }
+
+func (e *sizeCheckError) Error() string { return e.err.Error() }
+
+// sizeCheckedWriter는 기록 전에 누적 크기를 check로 확인하는 writer입니다.
+type sizeCheckedWriter struct {
+	w     io.Writer
+	total int64
+	check func(total int64) error
+}
+
+func (s *sizeCheckedWriter) Write(p []byte) (int, error) {
+	if err := s.check(s.total + int64(len(p))); err != nil {
+		return 0, &sizeCheckError{err: err}
+	}
+	n, err := s.w.Write(p)
+	s.total += int64(n)
+	return n, err
+}
+
+// writeArchiveFile은 r의 내용을 perm 권한의 새 파일로 기록하고 기록한 바이트 수를 반환합니다.
+// check가 있으면 written(이전 파일까지의 누적 크기)에 이어서 크기를 확인합니다.
+func writeArchiveFile(target string, r io.Reader, perm os.FileMode, written int64, check func(int64) error) (int64, error) {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return 0, err
+	}
+	var w io.Writer = f
+	if check != nil {
+		w = &sizeCheckedWriter{w: f, total: written, check: check}
+	}
+	n, err := io.Copy(w, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, err
+	}
+	// 기존 파일을 덮어쓴 경우 OpenFile의 perm이 적용되지 않으므로 다시 설정한다.
+	return n, os.Chmod(target, perm)
+}
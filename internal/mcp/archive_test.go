@@ -0,0 +1,228 @@
+package mcp
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/diskquota"
+)
+
+// tarEntry는 테스트 아카이브 항목입니다.
+type tarEntry struct {
+	name     string
+	typeflag byte
+	mode     int64
+	body     string
+	linkname string
+}
+
+// buildTarGz는 entries로 tar.gz 아카이브를 생성합니다.
+func buildTarGz(t *testing.T, entries ...tarEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		typeflag := e.typeflag
+		if typeflag == 0 {
+			typeflag = tar.TypeReg
+		}
+		mode := e.mode
+		if mode == 0 {
+			mode = 0644
+		}
+		hdr := &tar.Header{Name: e.name, Typeflag: typeflag, Mode: mode, Size: int64(len(e.body)), Linkname: e.linkname}
+		if typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader(%s): %v", e.name, err)
+		}
+		if typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatalf("Write(%s): %v", e.name, err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractTarGz_FilesAndPermissions(t *testing.T) {
+	dest := t.TempDir()
+	archive := buildTarGz(t,
+		tarEntry{name: "src/", typeflag: tar.TypeDir, mode: 0755},
+		tarEntry{name: "src/index.ts", body: "console.log(1)"},
+		tarEntry{name: "bin/run.sh", mode: 04777, body: "#!/bin/sh"},
+		tarEntry{name: "./package.json", body: "{}"},
+	)
+
+	stats, err := ExtractTarGz(bytes.NewReader(archive), dest, ArchiveOptions{})
+	if err != nil {
+		t.Fatalf("ExtractTarGz() error: %v", err)
+	}
+	if stats.Files != 3 || stats.Dirs != 1 {
+		t.Errorf("stats = %+v, want 3 files, 1 dir", stats)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "src", "index.ts"))
+	if err != nil || string(data) != "console.log(1)" {
+		t.Errorf("src/index.ts = %q, %v", data, err)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(dest, "bin", "run.sh"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode(); got != 0755 {
+			t.Errorf("run.sh mode = %v, want -rwxr-xr-x (setuid, 그룹/기타 쓰기 제거)", got)
+		}
+	}
+}
+
+func TestExtractTarGz_RejectsUnsafeEntries(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry tarEntry
+	}{
+		{"상위 디렉토리", tarEntry{name: "../evil.sh", body: "x"}},
+		{"중간 상위 디렉토리", tarEntry{name: "a/../../evil.sh", body: "x"}},
+		{"절대 경로", tarEntry{name: "/etc/evil", body: "x"}},
+		{"하드 링크", tarEntry{name: "link", typeflag: tar.TypeLink, linkname: "package.json"}},
+		{"FIFO", tarEntry{name: "fifo", typeflag: tar.TypeFifo}},
+		{"심볼릭 링크 기본 거부", tarEntry{name: "link", typeflag: tar.TypeSymlink, linkname: "package.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			dest := filepath.Join(parent, "svc")
+			if _, err := ExtractTarGz(bytes.NewReader(buildTarGz(t, tt.entry)), dest, ArchiveOptions{}); err == nil {
+				t.Fatal("ExtractTarGz() expected error, got nil")
+			}
+			if _, err := os.Stat(filepath.Join(parent, "evil.sh")); err == nil {
+				t.Error("dest 밖에 파일이 기록되었습니다")
+			}
+		})
+	}
+}
+
+func TestExtractTarGz_SymlinkPolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("심볼릭 링크 생성 권한이 필요합니다")
+	}
+	safe := []tarEntry{
+		{name: "lib/real.js", body: "x"},
+		{name: "lib/alias.js", typeflag: tar.TypeSymlink, linkname: "real.js"},
+	}
+
+	dest := t.TempDir()
+	stats, err := ExtractTarGz(bytes.NewReader(buildTarGz(t, safe...)), dest, ArchiveOptions{Symlinks: SymlinkSkip})
+	if err != nil || stats.Symlinks != 0 {
+		t.Fatalf("skip: stats=%+v err=%v", stats, err)
+	}
+	if _, err := os.Lstat(filepath.Join(dest, "lib", "alias.js")); err == nil {
+		t.Error("skip 정책에서 링크가 생성되었습니다")
+	}
+
+	dest = t.TempDir()
+	if _, err := ExtractTarGz(bytes.NewReader(buildTarGz(t, safe...)), dest, ArchiveOptions{Symlinks: SymlinkAllow}); err != nil {
+		t.Fatalf("allow: %v", err)
+	}
+	if target, err := os.Readlink(filepath.Join(dest, "lib", "alias.js")); err != nil || target != "real.js" {
+		t.Errorf("alias.js -> %q, %v", target, err)
+	}
+
+	escapes := [][]tarEntry{
+		{{name: "out", typeflag: tar.TypeSymlink, linkname: "../outside"}},
+		{{name: "abs", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"}},
+		// 링크를 경로 중간에 두고 그 아래에 링크를 만들어 밖으로 나가는 시도
+		{{name: "dir", typeflag: tar.TypeSymlink, linkname: "."}, {name: "dir/x", typeflag: tar.TypeSymlink, linkname: "../up"}},
+		// 다른 링크를 거친 뒤 ".."로 올라가는 연결 링크 (문자 그대로 정리하면 dest 안이지만 실제로는 상위 디렉토리)
+		{{name: "l2", typeflag: tar.TypeSymlink, linkname: "."}, {name: "l1", typeflag: tar.TypeSymlink, linkname: "l2/.."}},
+		{{name: "l1", typeflag: tar.TypeSymlink, linkname: "sub/l2/../.."}, {name: "sub/l2", typeflag: tar.TypeSymlink, linkname: ".."}},
+	}
+	for i, entries := range escapes {
+		if _, err := ExtractTarGz(bytes.NewReader(buildTarGz(t, entries...)), t.TempDir(), ArchiveOptions{Symlinks: SymlinkAllow}); err == nil {
+			t.Errorf("escape[%d]: expected error, got nil", i)
+		}
+	}
+}
+
+func TestExtractTarGz_LimitsAndManyFiles(t *testing.T) {
+	var entries []tarEntry
+	for i := range 2000 {
+		entries = append(entries, tarEntry{name: fmt.Sprintf("pkg%d/file%d.js", i%50, i), body: "module.exports = 1"})
+	}
+	archive := buildTarGz(t, entries...)
+
+	stats, err := ExtractTarGz(bytes.NewReader(archive), t.TempDir(), ArchiveOptions{})
+	if err != nil || stats.Files != 2000 {
+		t.Fatalf("stats=%+v err=%v", stats, err)
+	}
+
+	if _, err := ExtractTarGz(bytes.NewReader(archive), t.TempDir(), ArchiveOptions{MaxFiles: 100}); err == nil {
+		t.Error("MaxFiles 초과 시 에러여야 합니다")
+	}
+
+	_, err = ExtractTarGz(bytes.NewReader(archive), t.TempDir(), ArchiveOptions{
+		CheckSize: func(total int64) error { return diskquota.Check("서비스", "svc", total, 1024) },
+	})
+	if !errors.Is(err, diskquota.ErrQuotaExceeded) {
+		t.Errorf("할당량 초과 시 ErrQuotaExceeded여야 합니다: %v", err)
+	}
+}
+
+func TestDeployer_DeployArchive_ReplacesServiceDir(t *testing.T) {
+	baseDir := t.TempDir()
+	d := NewDeployer(baseDir, NewManager(newTestConfig(nil)))
+	serviceDir := filepath.Join(baseDir, "svc")
+	if err := os.MkdirAll(serviceDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(serviceDir, "stale.js"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 압축 해제에 실패하면 기존 배포가 유지된다
+	bad := buildTarGz(t, tarEntry{name: "../evil", body: "x"})
+	if _, err := d.DeployArchive(t.Context(), "svc", bytes.NewReader(bad), nil); err == nil {
+		t.Fatal("DeployArchive() expected error for unsafe archive")
+	}
+	if _, err := os.Stat(filepath.Join(serviceDir, "stale.js")); err != nil {
+		t.Errorf("실패한 배포가 기존 파일을 지웠습니다: %v", err)
+	}
+
+	good := buildTarGz(t, tarEntry{name: "package.json", body: `{"name":"svc"}`})
+	// Manager.Start는 npm 실행에 실패할 수 있지만 파일 배포는 완료된다.
+	path, _ := d.DeployArchive(t.Context(), "svc", bytes.NewReader(good), map[string]string{"KEY": "v"})
+	if path != serviceDir {
+		t.Errorf("path = %q, want %q", path, serviceDir)
+	}
+	if _, err := os.Stat(filepath.Join(serviceDir, "stale.js")); !os.IsNotExist(err) {
+		t.Error("아카이브 배포는 서비스 디렉토리를 교체해야 합니다")
+	}
+	if data, _ := os.ReadFile(filepath.Join(serviceDir, ".env")); !strings.Contains(string(data), "KEY=v") {
+		t.Errorf(".env = %q", data)
+	}
+
+	services, err := d.ListDeployed()
+	if err != nil || len(services) != 1 || services[0] != "svc" {
+		t.Errorf("ListDeployed() = %v, %v (임시 디렉토리가 남으면 안 됨)", services, err)
+	}
+
+	if _, err := d.DeployArchive(t.Context(), "../svc", bytes.NewReader(good), nil); err == nil {
+		t.Error("경로가 포함된 서비스 이름은 거부해야 합니다")
+	}
+}
@@ -3,10 +3,12 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/insajin/autopus-bridge/internal/diskquota"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,14 +23,60 @@ type DeployFile struct {
 type Deployer struct {
 	baseDir string   // MCP 서버 기본 디렉토리 (~/.acos/mcp-servers/)
 	manager *Manager // MCP 서버 등록 및 시작용
+	quota   DeployQuota
+	// symlinks는 아카이브 배포 시 심볼릭 링크 처리 방식입니다.
+	symlinks SymlinkPolicy
+}
+
+// 배포 디렉토리 할당량 기본값
+const (
+	DefaultDeployMaxTotalBytes   int64 = 2 << 30   // 2GiB
+	DefaultDeployMaxServiceBytes int64 = 200 << 20 // 200MiB
+)
+
+// MaxDeployArchiveEntries는 배포 아카이브의 최대 항목 수입니다.
+const MaxDeployArchiveEntries = 100000
+
+// DeployQuota는 배포 디렉토리 디스크 사용량 제한입니다.
+// 각 값이 0이면 해당 제한을 적용하지 않습니다.
+type DeployQuota struct {
+	MaxTotalBytes   int64 // 배포된 전체 서비스의 최대 크기
+	MaxServiceBytes int64 // 서비스 하나의 최대 크기
+}
+
+// DeployerOption은 Deployer 설정 옵션입니다.
+type DeployerOption func(*Deployer)
+
+// WithDeployQuota는 배포 디렉토리 할당량을 설정합니다.
+func WithDeployQuota(quota DeployQuota) DeployerOption {
+	return func(d *Deployer) {
+		d.quota = quota
+	}
+}
+
+// WithSymlinkPolicy는 아카이브 배포 시 심볼릭 링크 처리 방식을 설정합니다 (기본값 SymlinkReject).
+func WithSymlinkPolicy(policy SymlinkPolicy) DeployerOption {
+	return func(d *Deployer) {
+		d.symlinks = policy
+	}
 }
 
 // NewDeployer는 새로운 Deployer를 생성합니다.
-func NewDeployer(baseDir string, manager *Manager) *Deployer {
-	return &Deployer{
+// 할당량을 지정하지 않으면 기본 할당량(전체 2GiB, 서비스당 200MiB)을 사용합니다.
+func NewDeployer(baseDir string, manager *Manager, opts ...DeployerOption) *Deployer {
+	d := &Deployer{
 		baseDir: baseDir,
 		manager: manager,
+		quota: DeployQuota{
+			MaxTotalBytes:   DefaultDeployMaxTotalBytes,
+			MaxServiceBytes: DefaultDeployMaxServiceBytes,
+		},
+		symlinks: SymlinkReject,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // Deploy는 MCP 서버 코드를 디스크에 기록하고 Manager에 등록합니다.
@@ -43,6 +91,11 @@ func (d *Deployer) Deploy(ctx context.Context, serviceName string, files []Deplo
 
 	serviceDir := filepath.Join(d.baseDir, serviceName)
 
+	// 0. 디스크 할당량 확인 (파일 기록 전)
+	if err := d.checkQuota(serviceDir, deploySize(files, envVars)); err != nil {
+		return "", err
+	}
+
 	// 1. 서비스 디렉토리 생성
 	if err := os.MkdirAll(serviceDir, 0755); err != nil {
 		return "", fmt.Errorf("서비스 디렉토리 생성 실패 %q: %w", serviceDir, err)
@@ -55,26 +108,99 @@ func (d *Deployer) Deploy(ctx context.Context, serviceName string, files []Deplo
 		Msg("[mcp-deployer] 배포 시작")
 
 	// 2. 파일 기록
+	if err := writeDeployFiles(serviceDir, files); err != nil {
+		return "", err
+	}
+
+	return d.finishDeploy(ctx, serviceName, serviceDir, envVars)
+}
+
+// DeployArchive는 gzip으로 압축된 tar 아카이브로 MCP 서버 코드를 배포하고 Manager에 등록합니다.
+// 아카이브는 서비스 디렉토리 옆의 임시 디렉토리에 푼 뒤 기존 서비스 디렉토리와 교체하므로,
+// 압축 해제에 실패하면 기존 배포가 그대로 유지됩니다.
+// 할당량은 압축 해제 중에 확인하며, 심볼릭 링크는 WithSymlinkPolicy 설정을 따릅니다.
+func (d *Deployer) DeployArchive(ctx context.Context, serviceName string, archive io.Reader, envVars map[string]string) (string, error) {
+	if err := validateServiceName(serviceName); err != nil {
+		return "", err
+	}
+
+	serviceDir := filepath.Join(d.baseDir, serviceName)
+	checkSize, err := d.archiveSizeCheck(serviceDir, envVars)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(d.baseDir, 0755); err != nil {
+		return "", fmt.Errorf("배포 디렉토리 생성 실패 %q: %w", d.baseDir, err)
+	}
+	stagingDir, err := os.MkdirTemp(d.baseDir, "."+serviceName+"-staging-")
+	if err != nil {
+		return "", fmt.Errorf("임시 디렉토리 생성 실패: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(stagingDir) }()
+
+	log.Info().
+		Str("service", serviceName).
+		Str("dir", serviceDir).
+		Str("symlinks", string(d.symlinks)).
+		Msg("[mcp-deployer] 아카이브 배포 시작")
+
+	stats, err := ExtractTarGz(archive, stagingDir, ArchiveOptions{
+		Symlinks:  d.symlinks,
+		MaxFiles:  MaxDeployArchiveEntries,
+		CheckSize: checkSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("아카이브 압축 해제 실패: %w", err)
+	}
+
+	if err := os.RemoveAll(serviceDir); err != nil {
+		return "", fmt.Errorf("기존 서비스 디렉토리 삭제 실패 %q: %w", serviceDir, err)
+	}
+	if err := os.Rename(stagingDir, serviceDir); err != nil {
+		return "", fmt.Errorf("서비스 디렉토리 교체 실패 %q: %w", serviceDir, err)
+	}
+	// MkdirTemp는 0700으로 만들므로 일반 배포와 같은 권한으로 맞춘다.
+	if err := os.Chmod(serviceDir, 0755); err != nil {
+		return "", fmt.Errorf("서비스 디렉토리 권한 설정 실패: %w", err)
+	}
+
+	log.Info().
+		Str("service", serviceName).
+		Int("files", stats.Files).
+		Int("symlinks", stats.Symlinks).
+		Int64("bytes", stats.Bytes).
+		Msg("[mcp-deployer] 아카이브 압축 해제 완료")
+
+	return d.finishDeploy(ctx, serviceName, serviceDir, envVars)
+}
+
+// writeDeployFiles는 files를 dir 아래에 기록합니다 (하위 디렉토리 자동 생성).
+func writeDeployFiles(dir string, files []DeployFile) error {
 	for _, f := range files {
-		filePath := filepath.Join(serviceDir, f.Path)
+		filePath := filepath.Join(dir, f.Path)
 
 		// 하위 디렉토리 자동 생성
-		dir := filepath.Dir(filePath)
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return "", fmt.Errorf("디렉토리 생성 실패 %q: %w", dir, err)
+		subDir := filepath.Dir(filePath)
+		if err := os.MkdirAll(subDir, 0755); err != nil {
+			return fmt.Errorf("디렉토리 생성 실패 %q: %w", subDir, err)
 		}
 
 		if err := os.WriteFile(filePath, []byte(f.Content), 0644); err != nil {
-			return "", fmt.Errorf("파일 기록 실패 %q: %w", filePath, err)
+			return fmt.Errorf("파일 기록 실패 %q: %w", filePath, err)
 		}
 
 		log.Debug().
-			Str("service", serviceName).
+			Str("dir", dir).
 			Str("file", f.Path).
 			Msg("[mcp-deployer] 파일 기록 완료")
 	}
+	return nil
+}
 
-	// 3. 환경 변수 .env 파일 기록
+// finishDeploy는 .env 파일을 기록하고 Manager에 서버를 등록/시작합니다.
+func (d *Deployer) finishDeploy(ctx context.Context, serviceName, serviceDir string, envVars map[string]string) (string, error) {
+	// 환경 변수 .env 파일 기록
 	if len(envVars) > 0 {
 		envPath := filepath.Join(serviceDir, ".env")
 		if err := writeEnvFile(envPath, envVars); err != nil {
@@ -87,7 +213,7 @@ func (d *Deployer) Deploy(ctx context.Context, serviceName string, files []Deplo
 			Msg("[mcp-deployer] .env 파일 기록 완료")
 	}
 
-	// 4. Manager에 ServerConfig 등록 및 시작
+	// Manager에 ServerConfig 등록 및 시작
 	cfg := d.buildServerConfig(serviceName, serviceDir, envVars)
 
 	_, err := d.manager.Start(ctx, serviceName, &cfg)
@@ -147,13 +273,75 @@ func (d *Deployer) ListDeployed() ([]string, error) {
 
 	var services []string
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// 아카이브 배포 중 남은 임시 디렉토리(.<service>-staging-*)는 제외
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 			services = append(services, entry.Name())
 		}
 	}
 	return services, nil
 }
 
+// checkQuota는 incoming 바이트를 serviceDir에 배포했을 때 할당량을 넘는지 확인합니다.
+// 같은 서비스를 재배포하면 기존 파일은 덮어쓰므로 전체 사용량에서 기존 크기를 제외합니다.
+func (d *Deployer) checkQuota(serviceDir string, incoming int64) error {
+	if err := diskquota.Check("서비스", serviceDir, incoming, d.quota.MaxServiceBytes); err != nil {
+		return err
+	}
+	if d.quota.MaxTotalBytes <= 0 {
+		return nil
+	}
+
+	total, err := diskquota.DirSize(d.baseDir)
+	if err != nil {
+		return err
+	}
+	existing, err := diskquota.DirSize(serviceDir)
+	if err != nil {
+		return err
+	}
+	return diskquota.Check("배포 전체", d.baseDir, total-existing+incoming, d.quota.MaxTotalBytes)
+}
+
+// archiveSizeCheck는 아카이브 압축 해제 중 할당량을 확인하는 함수를 반환합니다.
+// 아카이브는 서비스 디렉토리를 통째로 교체하므로 기존 서비스 크기는 전체 사용량에서 제외합니다.
+func (d *Deployer) archiveSizeCheck(serviceDir string, envVars map[string]string) (func(int64) error, error) {
+	envSize := deploySize(nil, envVars)
+	var others int64
+	if d.quota.MaxTotalBytes > 0 {
+		total, err := diskquota.DirSize(d.baseDir)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := diskquota.DirSize(serviceDir)
+		if err != nil {
+			return nil, err
+		}
+		others = total - existing
+	}
+	return func(written int64) error {
+		incoming := written + envSize
+		if err := diskquota.Check("서비스", serviceDir, incoming, d.quota.MaxServiceBytes); err != nil {
+			return err
+		}
+		if d.quota.MaxTotalBytes <= 0 {
+			return nil
+		}
+		return diskquota.Check("배포 전체", d.baseDir, others+incoming, d.quota.MaxTotalBytes)
+	}, nil
+}
+
+// deploySize는 배포할 파일과 .env의 대략적인 크기(바이트)를 계산합니다.
+func deploySize(files []DeployFile, envVars map[string]string) int64 {
+	var size int64
+	for _, f := range files {
+		size += int64(len(f.Content))
+	}
+	for k, v := range envVars {
+		size += int64(len(k) + len(v) + 2) // KEY=VALUE\n
+	}
+	return size
+}
+
 // buildServerConfig는 서비스 디렉토리 내용을 기반으로 ServerConfig를 생성합니다.
 // package.json이 있으면 "npm start", 아니면 "npx tsx src/index.ts"를 사용합니다.
 func (d *Deployer) buildServerConfig(serviceName, serviceDir string, envVars map[string]string) ServerConfig {
@@ -1,10 +1,13 @@
 package mcp
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/diskquota"
 )
 
 func TestNewDeployer(t *testing.T) {
@@ -348,3 +351,38 @@ func TestDeployer_BuildServerConfig_EnvVars(t *testing.T) {
 		t.Errorf("Name = %q, want %q", sc.Name, "svc")
 	}
 }
+
+func TestDeployer_Deploy_ServiceQuotaExceeded(t *testing.T) {
+	baseDir := t.TempDir()
+	d := NewDeployer(baseDir, NewManager(newTestConfig(nil)), WithDeployQuota(DeployQuota{MaxServiceBytes: 16}))
+
+	files := []DeployFile{{Path: "src/index.ts", Content: strings.Repeat("x", 32)}}
+	_, err := d.Deploy(t.Context(), "big-svc", files, nil)
+	if !errors.Is(err, diskquota.ErrQuotaExceeded) {
+		t.Fatalf("Deploy() error = %v, want ErrQuotaExceeded", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(baseDir, "big-svc")); !os.IsNotExist(statErr) {
+		t.Error("no files should be written when the quota is exceeded")
+	}
+}
+
+func TestDeployer_Deploy_TotalQuotaExceeded(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(baseDir, "existing"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(baseDir, "existing", "index.js"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d := NewDeployer(baseDir, NewManager(newTestConfig(nil)), WithDeployQuota(DeployQuota{MaxTotalBytes: 120}))
+
+	files := []DeployFile{{Path: "index.js", Content: strings.Repeat("x", 50)}}
+	if _, err := d.Deploy(t.Context(), "new-svc", files, nil); !errors.Is(err, diskquota.ErrQuotaExceeded) {
+		t.Fatalf("Deploy() error = %v, want ErrQuotaExceeded", err)
+	}
+
+	// Redeploying an existing service replaces its files, so only the new size counts.
+	if err := d.checkQuota(filepath.Join(baseDir, "existing"), 110); err != nil {
+		t.Errorf("checkQuota() for redeploy = %v, want nil", err)
+	}
+}
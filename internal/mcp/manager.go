@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 	"github.com/rs/zerolog/log"
 )
 
@@ -15,6 +16,8 @@ type Manager struct {
 	config    *LocalConfig
 	processes map[string]*ProcessInfo
 	mu        sync.RWMutex
+	// sink는 MCP 서버 알림을 받는 함수입니다. nil이면 알림을 로그로만 남깁니다.
+	sink NotificationSink
 }
 
 // NewManager는 새로운 MCP Manager를 생성합니다.
@@ -25,6 +28,13 @@ func NewManager(config *LocalConfig) *Manager {
 	}
 }
 
+// SetNotificationSink는 이후 시작하는 MCP 서버의 알림(로그, 리소스 변경, 프로세스 종료)을 받을 함수를 설정합니다.
+func (m *Manager) SetNotificationSink(sink NotificationSink) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sink = sink
+}
+
 // Start는 이름으로 MCP 서버를 시작합니다.
 // 서버 설정은 로컬 config 또는 서버에서 전달받은 설정을 사용합니다.
 func (m *Manager) Start(ctx context.Context, name string, overrideCfg *ServerConfig) (*ProcessInfo, error) {
@@ -48,7 +58,7 @@ func (m *Manager) Start(ctx context.Context, name string, overrideCfg *ServerCon
 		return nil, fmt.Errorf("MCP 서버 %q 설정을 찾을 수 없음", name)
 	}
 
-	proc, err := startProcess(ctx, cfg)
+	proc, err := startProcess(ctx, cfg, m.sink)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +101,7 @@ func (m *Manager) ForceStop(name string) error {
 	}
 
 	if proc.cmd != nil && proc.cmd.Process != nil {
-		_ = proc.cmd.Process.Kill()
+		_ = procgroup.Kill(proc.cmd)
 	}
 	proc.cleanup()
 
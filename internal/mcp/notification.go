@@ -0,0 +1,388 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+// 알림 종류. 프로토콜의 MCPServerEvent* 값과 같습니다.
+const (
+	NotificationLog             = "log"
+	NotificationResourceUpdated = "resource_updated"
+	NotificationListChanged     = "list_changed"
+	NotificationProgress        = "progress"
+	NotificationExited          = "exited"
+	NotificationOther           = "other"
+)
+
+const (
+	// maxNotificationMessage는 알림 메시지의 최대 길이(문자)입니다. 초과분은 잘라냅니다.
+	maxNotificationMessage = 256
+	// maxStdioLineBytes는 stdout 한 줄의 최대 크기입니다. 초과하는 줄은 줄 끝까지 버립니다.
+	maxStdioLineBytes = 1 << 20
+)
+
+// Notification은 MCP 서버가 stdout으로 보낸 JSON-RPC 알림 하나 또는 프로세스 종료입니다.
+type Notification struct {
+	Server  string
+	Kind    string
+	Method  string
+	Level   string
+	Logger  string
+	URI     string
+	Message string
+	At      time.Time
+}
+
+// NotificationSink는 MCP 서버 알림을 받는 함수입니다.
+// 프로세스 출력을 읽는 고루틴에서 호출되므로 블로킹하지 않아야 합니다.
+type NotificationSink func(Notification)
+
+// stdioSupervisor는 MCP 서버의 stdout을 줄 단위로 읽어 JSON-RPC 알림을 sink로 전달합니다.
+// 알림이 아닌 출력은 이전처럼 로그로 남깁니다.
+type stdioSupervisor struct {
+	name       string
+	sink       NotificationSink
+	mu         sync.Mutex
+	buf        []byte
+	discarding bool
+}
+
+// Write는 출력을 버퍼에 쌓고 완성된 줄을 처리합니다.
+func (s *stdioSupervisor) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf = append(s.buf, p...)
+	for {
+		i := bytes.IndexByte(s.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if s.discarding {
+			s.discarding = false
+		} else {
+			s.handleLine(s.buf[:i])
+		}
+		s.buf = s.buf[i+1:]
+	}
+	if len(s.buf) > maxStdioLineBytes {
+		log.Warn().Str("mcp", s.name).Int("bytes", len(s.buf)).Msg("[mcp] stdout 줄이 너무 길어 버립니다")
+		s.buf = s.buf[:0]
+		s.discarding = true
+	}
+	return len(p), nil
+}
+
+// handleLine은 stdout 한 줄을 처리합니다.
+func (s *stdioSupervisor) handleLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+	if n, ok := parseNotification(s.name, line, time.Now()); ok {
+		log.Debug().Str("mcp", s.name).Str("method", n.Method).Msg("[mcp] 서버 알림 수신")
+		if s.sink != nil {
+			s.sink(n)
+		}
+		return
+	}
+	log.Debug().Str("mcp", s.name).Msg(string(line))
+}
+
+// parseNotification은 JSON-RPC 알림(id 없는 요청) 줄을 Notification으로 변환합니다.
+func parseNotification(server string, line []byte, now time.Time) (Notification, bool) {
+	if line[0] != '{' {
+		return Notification{}, false
+	}
+	var msg struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(line, &msg); err != nil || msg.JSONRPC != "2.0" || msg.Method == "" || msg.ID != nil {
+		return Notification{}, false
+	}
+
+	n := Notification{Server: server, Method: msg.Method, At: now}
+	switch msg.Method {
+	case "notifications/message":
+		var params struct {
+			Level  string          `json:"level"`
+			Logger string          `json:"logger"`
+			Data   json.RawMessage `json:"data"`
+		}
+		_ = json.Unmarshal(msg.Params, &params)
+		n.Kind = NotificationLog
+		n.Level = params.Level
+		n.Logger = params.Logger
+		n.Message = rawText(params.Data)
+	case "notifications/resources/updated":
+		var params struct {
+			URI string `json:"uri"`
+		}
+		_ = json.Unmarshal(msg.Params, &params)
+		n.Kind = NotificationResourceUpdated
+		n.URI = params.URI
+	case "notifications/tools/list_changed", "notifications/resources/list_changed", "notifications/prompts/list_changed":
+		n.Kind = NotificationListChanged
+	case "notifications/progress":
+		var params struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(msg.Params, &params)
+		n.Kind = NotificationProgress
+		// 진행률 값은 알림마다 달라 요약하면 같은 이벤트로 묶이지 않으므로 메시지만 남긴다.
+		n.Message = params.Message
+	default:
+		n.Kind = NotificationOther
+	}
+	n.Message = truncateMessage(n.Message)
+	return n, true
+}
+
+// rawText는 JSON 값을 메시지 문자열로 변환합니다. 문자열이면 따옴표 없이 반환합니다.
+func rawText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err == nil {
+		return compact.String()
+	}
+	return string(raw)
+}
+
+// truncateMessage는 메시지를 maxNotificationMessage 문자로 자릅니다.
+func truncateMessage(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if utf8.RuneCountInString(msg) <= maxNotificationMessage {
+		return msg
+	}
+	runes := []rune(msg)
+	return string(runes[:maxNotificationMessage]) + "…"
+}
+
+// logLevelRank는 MCP 로그 레벨(RFC 5424)의 순위입니다. 값이 클수록 심각합니다.
+var logLevelRank = map[string]int{
+	"debug":     0,
+	"info":      1,
+	"notice":    2,
+	"warning":   3,
+	"error":     4,
+	"critical":  5,
+	"alert":     6,
+	"emergency": 7,
+}
+
+// ForwarderConfig는 NotificationForwarder 설정입니다.
+type ForwarderConfig struct {
+	// Interval은 요약한 알림을 전송하는 주기입니다. 0이면 5초입니다.
+	Interval time.Duration
+	// MaxEvents는 서버별 한 번에 전송할 최대 요약 이벤트 수입니다. 초과분은 Dropped로 집계합니다. 0이면 50입니다.
+	MaxEvents int
+	// MinLevel은 전달할 최소 로그 레벨입니다. 비어 있으면 "info"입니다.
+	MinLevel string
+}
+
+// EventSummary는 같은 내용의 알림을 묶은 요약입니다.
+type EventSummary struct {
+	Kind    string
+	Method  string
+	Level   string
+	Logger  string
+	URI     string
+	Message string
+	Count   int
+	FirstAt time.Time
+	LastAt  time.Time
+}
+
+// NotificationBatch는 서버 하나의 전송 단위입니다.
+type NotificationBatch struct {
+	Server  string
+	Events  []EventSummary
+	Dropped int
+}
+
+// pendingBatch는 전송을 기다리는 서버별 요약입니다.
+type pendingBatch struct {
+	events  []*EventSummary
+	index   map[string]*EventSummary
+	dropped int
+}
+
+// NotificationForwarder는 MCP 서버 알림을 서버별로 요약하여 주기적으로 전송합니다.
+// 같은 내용의 알림은 하나로 묶어 횟수를 세고, 프로세스 종료는 즉시 전송합니다.
+type NotificationForwarder struct {
+	interval  time.Duration
+	maxEvents int
+	minRank   int
+	send      func(NotificationBatch)
+
+	mu      sync.Mutex
+	pending map[string]*pendingBatch
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewNotificationForwarder는 새로운 NotificationForwarder를 생성합니다.
+// send는 요약된 알림을 백엔드로 보내는 함수입니다.
+func NewNotificationForwarder(cfg ForwarderConfig, send func(NotificationBatch)) *NotificationForwarder {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	if cfg.MaxEvents <= 0 {
+		cfg.MaxEvents = 50
+	}
+	minRank, ok := logLevelRank[strings.ToLower(cfg.MinLevel)]
+	if !ok {
+		minRank = logLevelRank["info"]
+	}
+	return &NotificationForwarder{
+		interval:  cfg.Interval,
+		maxEvents: cfg.MaxEvents,
+		minRank:   minRank,
+		send:      send,
+		pending:   make(map[string]*pendingBatch),
+	}
+}
+
+// Handle은 알림 하나를 요약에 추가합니다. NotificationSink로 사용합니다.
+func (f *NotificationForwarder) Handle(n Notification) {
+	if n.Kind == NotificationLog {
+		if rank, ok := logLevelRank[strings.ToLower(n.Level)]; ok && rank < f.minRank {
+			return
+		}
+	}
+
+	f.mu.Lock()
+	batch, ok := f.pending[n.Server]
+	if !ok {
+		batch = &pendingBatch{index: make(map[string]*EventSummary)}
+		f.pending[n.Server] = batch
+	}
+	key := strings.Join([]string{n.Kind, n.Method, n.Level, n.Logger, n.URI, n.Message}, "\x00")
+	if ev, ok := batch.index[key]; ok {
+		ev.Count++
+		ev.LastAt = n.At
+	} else if len(batch.events) >= f.maxEvents {
+		batch.dropped++
+	} else {
+		ev := &EventSummary{
+			Kind:    n.Kind,
+			Method:  n.Method,
+			Level:   n.Level,
+			Logger:  n.Logger,
+			URI:     n.URI,
+			Message: n.Message,
+			Count:   1,
+			FirstAt: n.At,
+			LastAt:  n.At,
+		}
+		batch.events = append(batch.events, ev)
+		batch.index[key] = ev
+	}
+	f.mu.Unlock()
+
+	// 프로세스 종료는 헬스 변화이므로 다음 주기를 기다리지 않는다.
+	if n.Kind == NotificationExited {
+		f.flushServer(n.Server)
+	}
+}
+
+// Start는 주기적인 전송 루프를 시작합니다.
+// context 취소 또는 Stop() 호출로 종료되며, 종료 시 남은 알림을 전송합니다.
+func (f *NotificationForwarder) Start(ctx context.Context) {
+	loopCtx, cancel := context.WithCancel(ctx)
+	f.cancel = cancel
+	f.done = make(chan struct{})
+
+	go func() {
+		defer close(f.done)
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-loopCtx.Done():
+				f.Flush()
+				return
+			case <-ticker.C:
+				f.Flush()
+			}
+		}
+	}()
+}
+
+// Stop은 전송 루프를 종료하고 남은 알림을 전송합니다.
+func (f *NotificationForwarder) Stop() {
+	if f.cancel != nil {
+		f.cancel()
+		<-f.done
+		f.cancel = nil
+	}
+}
+
+// Flush는 모든 서버의 대기 중인 요약을 전송합니다.
+func (f *NotificationForwarder) Flush() {
+	f.mu.Lock()
+	servers := make([]string, 0, len(f.pending))
+	for server := range f.pending {
+		servers = append(servers, server)
+	}
+	f.mu.Unlock()
+
+	for _, server := range servers {
+		f.flushServer(server)
+	}
+}
+
+// flushServer는 서버 하나의 대기 중인 요약을 전송합니다.
+func (f *NotificationForwarder) flushServer(server string) {
+	f.mu.Lock()
+	batch, ok := f.pending[server]
+	delete(f.pending, server)
+	f.mu.Unlock()
+	if !ok || (len(batch.events) == 0 && batch.dropped == 0) {
+		return
+	}
+
+	out := NotificationBatch{Server: server, Dropped: batch.dropped}
+	out.Events = make([]EventSummary, len(batch.events))
+	for i, ev := range batch.events {
+		out.Events[i] = *ev
+	}
+	if batch.dropped > 0 {
+		log.Warn().Str("mcp", server).Int("dropped", batch.dropped).Msg("[mcp] 서버 알림이 많아 일부를 버렸습니다")
+	}
+	if f.send != nil {
+		f.send(out)
+	}
+}
+
+// exitNotification은 예기치 않은 프로세스 종료 알림을 생성합니다.
+func exitNotification(server string, waitErr error, now time.Time) Notification {
+	msg := "exit status 0"
+	if waitErr != nil {
+		msg = waitErr.Error()
+	}
+	return Notification{
+		Server:  server,
+		Kind:    NotificationExited,
+		Message: truncateMessage(msg),
+		At:      now,
+	}
+}
@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseNotification(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		line string
+		want Notification
+		ok   bool
+	}{
+		{
+			name: "로그 메시지",
+			line: `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"error","logger":"db","data":"connection refused"}}`,
+			want: Notification{Kind: NotificationLog, Method: "notifications/message", Level: "error", Logger: "db", Message: "connection refused"},
+			ok:   true,
+		},
+		{
+			name: "구조화된 로그 데이터",
+			line: `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","data":{"rows": 3}}}`,
+			want: Notification{Kind: NotificationLog, Method: "notifications/message", Level: "info", Message: `{"rows":3}`},
+			ok:   true,
+		},
+		{
+			name: "리소스 변경",
+			line: `{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"file:///tmp/a.csv"}}`,
+			want: Notification{Kind: NotificationResourceUpdated, Method: "notifications/resources/updated", URI: "file:///tmp/a.csv"},
+			ok:   true,
+		},
+		{
+			name: "도구 목록 변경",
+			line: `{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`,
+			want: Notification{Kind: NotificationListChanged, Method: "notifications/tools/list_changed"},
+			ok:   true,
+		},
+		{
+			name: "진행률",
+			line: `{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":1,"progress":5,"total":10,"message":"indexing"}}`,
+			want: Notification{Kind: NotificationProgress, Method: "notifications/progress", Message: "indexing"},
+			ok:   true,
+		},
+		{
+			name: "기타 알림",
+			line: `{"jsonrpc":"2.0","method":"notifications/custom"}`,
+			want: Notification{Kind: NotificationOther, Method: "notifications/custom"},
+			ok:   true,
+		},
+		{name: "응답", line: `{"jsonrpc":"2.0","id":1,"result":{}}`},
+		{name: "요청", line: `{"jsonrpc":"2.0","id":2,"method":"sampling/createMessage"}`},
+		{name: "일반 출력", line: `server listening on stdio`},
+		{name: "잘못된 JSON", line: `{"jsonrpc":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseNotification("srv", []byte(tt.line), now)
+			if ok != tt.ok {
+				t.Fatalf("parseNotification() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			tt.want.Server = "srv"
+			tt.want.At = now
+			if got != tt.want {
+				t.Errorf("parseNotification() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	long := `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info","data":"` + strings.Repeat("가", 300) + `"}}`
+	got, _ := parseNotification("srv", []byte(long), now)
+	if n := len([]rune(got.Message)); n != maxNotificationMessage+1 {
+		t.Errorf("긴 메시지 길이 = %d, want %d", n, maxNotificationMessage+1)
+	}
+}
+
+func TestStdioSupervisor_SplitsLines(t *testing.T) {
+	var got []Notification
+	s := &stdioSupervisor{name: "srv", sink: func(n Notification) { got = append(got, n) }}
+
+	// 한 줄이 여러 Write로 나뉘어 들어와도 줄 단위로 처리한다.
+	chunks := []string{
+		`{"jsonrpc":"2.0","method":"notifications/tools/`,
+		"list_changed\"}\nplain output\n",
+		`{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"a"}}` + "\n",
+	}
+	for _, c := range chunks {
+		if _, err := s.Write([]byte(c)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if len(got) != 2 || got[0].Kind != NotificationListChanged || got[1].URI != "a" {
+		t.Fatalf("알림 = %+v", got)
+	}
+
+	// 너무 긴 줄은 줄 끝까지 버린다.
+	_, _ = s.Write([]byte(strings.Repeat("x", maxStdioLineBytes+1)))
+	_, _ = s.Write([]byte(`{"jsonrpc":"2.0","method":"notifications/custom"}` + "\n"))
+	if len(got) != 2 {
+		t.Errorf("버린 줄의 나머지가 알림으로 처리되었습니다: %+v", got[2:])
+	}
+}
+
+// batchRecorder는 전송된 NotificationBatch를 기록합니다.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []NotificationBatch
+}
+
+func (r *batchRecorder) send(b NotificationBatch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, b)
+}
+
+func (r *batchRecorder) get() []NotificationBatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]NotificationBatch(nil), r.batches...)
+}
+
+func TestNotificationForwarder_Summarizes(t *testing.T) {
+	rec := &batchRecorder{}
+	f := NewNotificationForwarder(ForwarderConfig{MaxEvents: 2, MinLevel: "warning"}, rec.send)
+	base := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	logAt := func(level, msg string, at time.Time) Notification {
+		return Notification{Server: "srv", Kind: NotificationLog, Method: "notifications/message", Level: level, Message: msg, At: at}
+	}
+	f.Handle(logAt("error", "db down", base))
+	f.Handle(logAt("error", "db down", base.Add(2*time.Second)))
+	f.Handle(logAt("info", "request handled", base)) // MinLevel 미만
+	f.Handle(Notification{Server: "srv", Kind: NotificationListChanged, Method: "notifications/tools/list_changed", At: base})
+	f.Handle(logAt("warning", "slow query", base)) // MaxEvents 초과
+	f.Handle(Notification{Server: "other", Kind: NotificationOther, Method: "notifications/custom", At: base})
+	f.Flush()
+
+	batches := rec.get()
+	if len(batches) != 2 {
+		t.Fatalf("batches = %+v, 서버별 2개 기대", batches)
+	}
+	var srv NotificationBatch
+	for _, b := range batches {
+		if b.Server == "srv" {
+			srv = b
+		}
+	}
+	if len(srv.Events) != 2 || srv.Dropped != 1 {
+		t.Fatalf("srv batch = %+v", srv)
+	}
+	if ev := srv.Events[0]; ev.Count != 2 || !ev.FirstAt.Equal(base) || !ev.LastAt.Equal(base.Add(2*time.Second)) {
+		t.Errorf("묶인 이벤트 = %+v", ev)
+	}
+
+	// 보낸 뒤에는 비워지므로 다시 Flush해도 전송하지 않는다.
+	f.Flush()
+	if n := len(rec.get()); n != 2 {
+		t.Errorf("빈 Flush 후 batches = %d, want 2", n)
+	}
+}
+
+func TestNotificationForwarder_ExitFlushesImmediately(t *testing.T) {
+	rec := &batchRecorder{}
+	f := NewNotificationForwarder(ForwarderConfig{Interval: time.Hour}, rec.send)
+	f.Start(context.Background())
+	defer f.Stop()
+
+	f.Handle(Notification{Server: "srv", Kind: NotificationLog, Level: "error", Message: "panic", At: time.Now()})
+	f.Handle(exitNotification("srv", nil, time.Now()))
+
+	batches := rec.get()
+	if len(batches) != 1 || len(batches[0].Events) != 2 || batches[0].Events[1].Kind != NotificationExited {
+		t.Fatalf("batches = %+v, 종료 시 즉시 전송 기대", batches)
+	}
+}
+
+func TestNotificationForwarder_StopFlushes(t *testing.T) {
+	rec := &batchRecorder{}
+	f := NewNotificationForwarder(ForwarderConfig{Interval: time.Hour}, rec.send)
+	f.Start(context.Background())
+	f.Handle(Notification{Server: "srv", Kind: NotificationOther, Method: "notifications/custom", At: time.Now()})
+	f.Stop()
+
+	if batches := rec.get(); len(batches) != 1 {
+		t.Errorf("Stop() 후 batches = %+v, 남은 알림 전송 기대", batches)
+	}
+}
+
+func TestManager_ForwardsNotifications(t *testing.T) {
+	m := NewManager(newTestConfig(nil))
+	got := make(chan Notification, 4)
+	m.SetNotificationSink(func(n Notification) { got <- n })
+
+	script := `echo '{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"error","data":"boom"}}'; exit 3`
+	if _, err := m.Start(context.Background(), "crashy", &ServerConfig{Command: "sh", Args: []string{"-c", script}}); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer func() { _ = m.ForceStop("crashy") }()
+
+	want := []string{NotificationLog, NotificationExited}
+	for _, kind := range want {
+		select {
+		case n := <-got:
+			if n.Kind != kind || n.Server != "crashy" {
+				t.Fatalf("알림 = %+v, want kind %s", n, kind)
+			}
+			if kind == NotificationExited && !strings.Contains(n.Message, "3") {
+				t.Errorf("종료 메시지 = %q, 종료 코드 포함 기대", n.Message)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s 알림을 받지 못했습니다", kind)
+		}
+	}
+}
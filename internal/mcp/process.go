@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 	"github.com/rs/zerolog/log"
 )
 
@@ -81,7 +82,7 @@ func (p *ProcessInfo) Stop() error {
 		log.Info().Str("name", p.Name).Msg("[mcp] 프로세스 정상 종료")
 	case <-time.After(StopGracePeriod):
 		log.Warn().Str("name", p.Name).Msg("[mcp] 강제 종료 (Kill)")
-		_ = p.cmd.Process.Kill()
+		_ = procgroup.Kill(p.cmd)
 	}
 
 	p.cleanup()
@@ -96,7 +97,8 @@ func (p *ProcessInfo) cleanup() {
 }
 
 // startProcess는 MCP 서버 프로세스를 시작합니다.
-func startProcess(ctx context.Context, cfg ServerConfig) (*ProcessInfo, error) {
+// sink가 있으면 stdout의 JSON-RPC 알림과 예기치 않은 프로세스 종료를 sink로 전달합니다.
+func startProcess(ctx context.Context, cfg ServerConfig, sink NotificationSink) (*ProcessInfo, error) {
 	// 바이너리 존재 확인
 	if cfg.RequiredBinary != "" {
 		if _, err := exec.LookPath(cfg.RequiredBinary); err != nil {
@@ -122,11 +124,8 @@ func startProcess(ctx context.Context, cfg ServerConfig) (*ProcessInfo, error) {
 		cmd.Dir = cfg.WorkingDir
 	}
 
-	// 플랫폼별 프로세스 속성 설정 (Unix: 프로세스 그룹, Windows: no-op)
-	cmd.SysProcAttr = setSysProcAttr()
-
-	// stdout/stderr를 로그로 전달
-	cmd.Stdout = &logWriter{name: cfg.Name, level: "info"}
+	// stdout의 알림은 sink로, 나머지 출력과 stderr는 로그로 전달
+	cmd.Stdout = &stdioSupervisor{name: cfg.Name, sink: sink}
 	cmd.Stderr = &logWriter{name: cfg.Name, level: "error"}
 
 	log.Info().
@@ -135,7 +134,8 @@ func startProcess(ctx context.Context, cfg ServerConfig) (*ProcessInfo, error) {
 		Strs("args", cfg.Args).
 		Msg("[mcp] 프로세스 시작")
 
-	if err := cmd.Start(); err != nil {
+	// 별도 프로세스 그룹(Windows: Job Object)에서 시작해 종료 시 하위 프로세스까지 정리한다.
+	if err := procgroup.Start(cmd); err != nil {
 		cancel()
 		return nil, fmt.Errorf("MCP 서버 %q 시작 실패: %w", cfg.Name, err)
 	}
@@ -151,13 +151,18 @@ func startProcess(ctx context.Context, cfg ServerConfig) (*ProcessInfo, error) {
 
 	// 프로세스 종료 감시 (비동기)
 	go func() {
-		if waitErr := cmd.Wait(); waitErr != nil {
+		waitErr := procgroup.Wait(cmd)
+		if waitErr != nil {
 			log.Warn().
 				Str("name", cfg.Name).
 				Int("pid", info.PID).
 				Err(waitErr).
 				Msg("[mcp] 프로세스 종료됨")
 		}
+		// Stop/ForceStop이나 Bridge 종료로 취소된 경우는 의도한 종료이므로 알리지 않는다.
+		if sink != nil && procCtx.Err() == nil {
+			sink(exitNotification(cfg.Name, waitErr, time.Now()))
+		}
 	}()
 
 	return info, nil
@@ -36,7 +36,7 @@ func TestProcessInfo_Stop_RealProcess(t *testing.T) {
 		Args:    []string{"60"},
 	}
 
-	p, err := startProcess(context.Background(), cfg)
+	p, err := startProcess(context.Background(), cfg, nil)
 	if err != nil {
 		t.Fatalf("startProcess() error: %v", err)
 	}
@@ -79,7 +79,7 @@ func TestStartProcess_CommandNotFound(t *testing.T) {
 		Name:    "bad-server",
 		Command: "nonexistent-binary-xyz-12345",
 	}
-	_, err := startProcess(context.Background(), cfg)
+	_, err := startProcess(context.Background(), cfg, nil)
 	if err == nil {
 		t.Fatal("startProcess() expected error for missing command, got nil")
 	}
@@ -91,7 +91,7 @@ func TestStartProcess_RequiredBinaryNotFound(t *testing.T) {
 		Command:        "echo",
 		RequiredBinary: "nonexistent-required-binary-xyz",
 	}
-	_, err := startProcess(context.Background(), cfg)
+	_, err := startProcess(context.Background(), cfg, nil)
 	if err == nil {
 		t.Fatal("startProcess() expected error for missing required binary, got nil")
 	}
@@ -105,7 +105,7 @@ func TestStartProcess_Success(t *testing.T) {
 		Env:     map[string]string{"TEST_VAR": "hello"},
 	}
 
-	proc, err := startProcess(context.Background(), cfg)
+	proc, err := startProcess(context.Background(), cfg, nil)
 	if err != nil {
 		t.Fatalf("startProcess() error: %v", err)
 	}
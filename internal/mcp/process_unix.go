@@ -7,14 +7,12 @@ import (
 	"syscall"
 )
 
-// setSysProcAttr는 프로세스 그룹을 설정합니다 (Unix).
-// 자식 프로세스도 함께 종료되도록 Setpgid를 활성화합니다.
-func setSysProcAttr() *syscall.SysProcAttr {
-	return &syscall.SysProcAttr{Setpgid: true}
-}
-
-// sendTermSignal은 프로세스에 SIGTERM을 전송합니다 (Unix).
+// sendTermSignal은 프로세스 그룹 전체에 SIGTERM을 전송합니다 (Unix).
+// 프로세스는 procgroup으로 시작되어 PID와 같은 프로세스 그룹의 리더입니다.
 func sendTermSignal(process *os.Process) error {
+	if err := syscall.Kill(-process.Pid, syscall.SIGTERM); err == nil {
+		return nil
+	}
 	return process.Signal(syscall.SIGTERM)
 }
 
@@ -0,0 +1,532 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultSmokeTimeout은 스모크 시작에서 서버 응답(initialize, ping)을 기다리는 기본 시간입니다.
+// npx가 패키지를 내려받는 시간을 고려해 넉넉하게 잡습니다.
+const DefaultSmokeTimeout = 30 * time.Second
+
+// 검증 결과 항목의 심각도
+const (
+	SeverityError   = "error"   // 이대로 배포하면 실패하거나 동작하지 않음
+	SeverityWarning = "warning" // 배포는 가능하지만 확인이 필요함
+)
+
+// 검증 항목 종류
+const (
+	CheckLayout     = "layout"     // 진입점/파일 경로 구성
+	CheckSyntax     = "syntax"     // JSON/JavaScript 문법
+	CheckDependency = "dependency" // import한 패키지의 package.json 선언 여부
+	CheckCommand    = "command"    // 시작 명령어 존재 여부
+	CheckSmoke      = "smoke"      // 서버 시작 후 initialize/ping 응답
+)
+
+// VerifyOptions는 배포 검증 옵션입니다.
+type VerifyOptions struct {
+	// SmokeTest가 true이면 정적 검사를 통과한 경우 스테이징 디렉토리에서 서버를 시작해 ping을 보내봅니다.
+	SmokeTest bool
+	// SmokeTimeout은 스모크 시작 응답 대기 시간입니다 (0 이하이면 DefaultSmokeTimeout).
+	SmokeTimeout time.Duration
+}
+
+// VerifyFinding은 배포 검증에서 발견한 문제 하나입니다.
+type VerifyFinding struct {
+	Severity string // SeverityError 또는 SeverityWarning
+	Check    string // CheckLayout 등 검증 항목
+	File     string // 관련 파일 (서비스 디렉토리 기준 상대 경로, 없으면 빈 문자열)
+	Message  string
+}
+
+// VerifyReport는 배포 검증 결과입니다.
+type VerifyReport struct {
+	ServiceName string
+	// Passed는 SeverityError 항목이 없으면 true입니다.
+	Passed bool
+	// SmokeTested는 스모크 시작을 실제로 수행했는지 여부입니다.
+	SmokeTested bool
+	Findings    []VerifyFinding
+}
+
+// add는 검증 항목을 추가합니다.
+func (r *VerifyReport) add(severity, check, file, format string, args ...any) {
+	r.Findings = append(r.Findings, VerifyFinding{
+		Severity: severity,
+		Check:    check,
+		File:     file,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// hasErrors는 SeverityError 항목이 있는지 확인합니다.
+func (r *VerifyReport) hasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify는 Deploy와 같은 파일을 배포 경로 대신 임시 스테이징 디렉토리에 기록하고 검증합니다.
+// 배포된 서비스 디렉토리와 Manager 등록 상태는 건드리지 않으며, 스테이징 디렉토리는 검증 후 삭제합니다.
+// 파일 내용의 문제는 VerifyReport의 항목으로 보고하고, 할당량 초과 등 검증 자체를 할 수 없으면 에러를 반환합니다.
+func (d *Deployer) Verify(ctx context.Context, serviceName string, files []DeployFile, envVars map[string]string, opts VerifyOptions) (*VerifyReport, error) {
+	if err := validateServiceName(serviceName); err != nil {
+		return nil, err
+	}
+	if err := d.checkQuota(filepath.Join(d.baseDir, serviceName), deploySize(files, envVars)); err != nil {
+		return nil, err
+	}
+
+	report := &VerifyReport{ServiceName: serviceName}
+	valid := make([]DeployFile, 0, len(files))
+	for _, f := range files {
+		if !filepath.IsLocal(f.Path) {
+			report.add(SeverityError, CheckLayout, f.Path, "서비스 디렉토리 밖을 가리키는 파일 경로")
+			continue
+		}
+		valid = append(valid, f)
+	}
+
+	stagingDir, err := d.makeVerifyDir(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(stagingDir) }()
+
+	if err := writeDeployFiles(stagingDir, valid); err != nil {
+		return nil, err
+	}
+	return d.verifyStaged(ctx, report, stagingDir, envVars, opts), nil
+}
+
+// VerifyArchive는 DeployArchive와 같은 아카이브를 임시 스테이징 디렉토리에 풀어 검증합니다.
+// 압축 해제 규칙(할당량, 심볼릭 링크 정책)은 DeployArchive와 같으며, 압축 해제에 실패하면 에러를 반환합니다.
+func (d *Deployer) VerifyArchive(ctx context.Context, serviceName string, archive io.Reader, envVars map[string]string, opts VerifyOptions) (*VerifyReport, error) {
+	if err := validateServiceName(serviceName); err != nil {
+		return nil, err
+	}
+	checkSize, err := d.archiveSizeCheck(filepath.Join(d.baseDir, serviceName), envVars)
+	if err != nil {
+		return nil, err
+	}
+
+	stagingDir, err := d.makeVerifyDir(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(stagingDir) }()
+
+	if _, err := ExtractTarGz(archive, stagingDir, ArchiveOptions{
+		Symlinks:  d.symlinks,
+		MaxFiles:  MaxDeployArchiveEntries,
+		CheckSize: checkSize,
+	}); err != nil {
+		return nil, fmt.Errorf("아카이브 압축 해제 실패: %w", err)
+	}
+	return d.verifyStaged(ctx, &VerifyReport{ServiceName: serviceName}, stagingDir, envVars, opts), nil
+}
+
+// validateServiceName은 서비스 이름이 baseDir 바로 아래 디렉토리 이름으로 쓸 수 있는지 확인합니다.
+func validateServiceName(serviceName string) error {
+	if serviceName == "" {
+		return fmt.Errorf("서비스 이름이 비어있음")
+	}
+	if serviceName != filepath.Base(serviceName) || serviceName == "." || serviceName == ".." {
+		return fmt.Errorf("유효하지 않은 서비스 이름: %q", serviceName)
+	}
+	return nil
+}
+
+// makeVerifyDir은 baseDir 아래에 검증용 스테이징 디렉토리(.<service>-verify-*)를 만듭니다.
+// 점으로 시작하므로 ListDeployed에 나타나지 않습니다.
+func (d *Deployer) makeVerifyDir(serviceName string) (string, error) {
+	if err := os.MkdirAll(d.baseDir, 0755); err != nil {
+		return "", fmt.Errorf("배포 디렉토리 생성 실패 %q: %w", d.baseDir, err)
+	}
+	dir, err := os.MkdirTemp(d.baseDir, "."+serviceName+"-verify-")
+	if err != nil {
+		return "", fmt.Errorf("검증 디렉토리 생성 실패: %w", err)
+	}
+	return dir, nil
+}
+
+// verifyStaged는 스테이징 디렉토리에 기록된 서비스를 검사하고 report를 완성합니다.
+func (d *Deployer) verifyStaged(ctx context.Context, report *VerifyReport, dir string, envVars map[string]string, opts VerifyOptions) *VerifyReport {
+	cfg := d.buildServerConfig(report.ServiceName, dir, envVars)
+
+	pkg := checkPackageJSON(report, dir, cfg)
+	checkSources(ctx, report, dir, pkg)
+	if _, err := exec.LookPath(cfg.Command); err != nil {
+		report.add(SeverityError, CheckCommand, "", "시작 명령어 %q를 찾을 수 없음", cfg.Command)
+	}
+
+	if opts.SmokeTest {
+		if report.hasErrors() {
+			report.add(SeverityWarning, CheckSmoke, "", "정적 검사에서 오류가 발견되어 스모크 시작을 건너뜀")
+		} else {
+			timeout := opts.SmokeTimeout
+			if timeout <= 0 {
+				timeout = DefaultSmokeTimeout
+			}
+			report.SmokeTested = true
+			if err := smokeStart(ctx, cfg, timeout); err != nil {
+				report.add(SeverityError, CheckSmoke, "", "%v", err)
+			}
+		}
+	}
+
+	report.Passed = !report.hasErrors()
+	log.Info().
+		Str("service", report.ServiceName).
+		Bool("passed", report.Passed).
+		Bool("smoke", report.SmokeTested).
+		Int("findings", len(report.Findings)).
+		Msg("[mcp-deployer] 배포 검증 완료")
+	return report
+}
+
+// packageManifest는 검증에 필요한 package.json 필드입니다.
+type packageManifest struct {
+	Scripts              map[string]string `json:"scripts"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// declares는 name 패키지가 package.json에 선언되어 있는지 확인합니다.
+func (p *packageManifest) declares(name string) bool {
+	for _, deps := range []map[string]string{p.Dependencies, p.DevDependencies, p.PeerDependencies, p.OptionalDependencies} {
+		if _, ok := deps[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPackageJSON은 시작 명령어의 진입점을 확인하고, package.json이 있으면 파싱해 반환합니다.
+// package.json이 없거나 파싱할 수 없으면 nil을 반환합니다.
+func checkPackageJSON(report *VerifyReport, dir string, cfg ServerConfig) *packageManifest {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		// package.json이 없으면 buildServerConfig가 npx tsx src/index.ts로 시작한다.
+		if _, err := os.Stat(filepath.Join(dir, "src", "index.ts")); err != nil {
+			report.add(SeverityError, CheckLayout, "src/index.ts", "package.json이 없으면 src/index.ts로 시작하지만 파일이 없음")
+		}
+		return nil
+	}
+
+	var pkg packageManifest
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		report.add(SeverityError, CheckSyntax, "package.json", "JSON 파싱 실패: %v", err)
+		return nil
+	}
+	if cfg.Command == "npm" && pkg.Scripts["start"] == "" {
+		report.add(SeverityError, CheckLayout, "package.json", "npm start로 시작하지만 scripts.start가 없음")
+	}
+	if len(pkg.Dependencies) > 0 {
+		if _, err := os.Stat(filepath.Join(dir, "node_modules")); err != nil {
+			report.add(SeverityWarning, CheckDependency, "package.json", "node_modules가 없어 시작 전에 npm install이 필요함")
+		}
+	}
+	return &pkg
+}
+
+// importPattern은 JavaScript/TypeScript 소스의 모듈 지정자를 찾습니다
+// (import ... from "x", import "x", import("x"), export ... from "x", require("x")).
+var importPattern = regexp.MustCompile(`(?:\bfrom\s*|\bimport\s*\(?\s*|\brequire\s*\(\s*)["']([^"'\s]+)["']`)
+
+// nodeBuiltins는 package.json 선언 없이 쓸 수 있는 Node.js 내장 모듈입니다.
+var nodeBuiltins = map[string]bool{
+	"assert": true, "async_hooks": true, "buffer": true, "child_process": true, "cluster": true,
+	"crypto": true, "dgram": true, "dns": true, "events": true, "fs": true, "http": true,
+	"http2": true, "https": true, "module": true, "net": true, "os": true, "path": true,
+	"perf_hooks": true, "process": true, "querystring": true, "readline": true, "stream": true,
+	"string_decoder": true, "timers": true, "tls": true, "tty": true, "url": true, "util": true,
+	"v8": true, "vm": true, "worker_threads": true, "zlib": true,
+}
+
+// packageName은 모듈 지정자에서 패키지 이름을 추출합니다. 상대/절대 경로와 내장 모듈이면 빈 문자열입니다.
+func packageName(spec string) string {
+	if strings.HasPrefix(spec, ".") || strings.HasPrefix(spec, "/") || strings.HasPrefix(spec, "node:") {
+		return ""
+	}
+	parts := strings.Split(spec, "/")
+	name := parts[0]
+	if strings.HasPrefix(name, "@") && len(parts) > 1 {
+		name += "/" + parts[1]
+	}
+	if nodeBuiltins[name] {
+		return ""
+	}
+	return name
+}
+
+// checkSources는 JSON 파일 문법과 JavaScript 문법(node --check),
+// 소스가 import하는 패키지의 package.json 선언 여부를 검사합니다. node_modules는 건너뜁니다.
+func checkSources(ctx context.Context, report *VerifyReport, dir string, pkg *packageManifest) {
+	_, nodeErr := exec.LookPath("node")
+	nodeMissing := false
+	undeclared := make(map[string]string) // 패키지 이름 → 처음 import한 파일
+
+	_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if entry.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+
+		switch ext := filepath.Ext(path); ext {
+		case ".json":
+			if rel == "package.json" {
+				return nil // checkPackageJSON에서 확인
+			}
+			data, err := os.ReadFile(path)
+			if err == nil && !json.Valid(data) {
+				report.add(SeverityError, CheckSyntax, rel, "JSON 문법 오류")
+			}
+		case ".js", ".mjs", ".cjs", ".ts", ".mts", ".cts":
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			for _, m := range importPattern.FindAllSubmatch(data, -1) {
+				name := packageName(string(m[1]))
+				if name == "" || (pkg != nil && pkg.declares(name)) {
+					continue
+				}
+				if _, seen := undeclared[name]; !seen {
+					undeclared[name] = rel
+				}
+			}
+			if ext == ".js" || ext == ".mjs" || ext == ".cjs" {
+				if nodeErr != nil {
+					nodeMissing = true
+					return nil
+				}
+				if msg := nodeCheck(ctx, path); msg != "" {
+					report.add(SeverityError, CheckSyntax, rel, "%s", msg)
+				}
+			}
+		}
+		return nil
+	})
+
+	names := make([]string, 0, len(undeclared))
+	for name := range undeclared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		report.add(SeverityError, CheckDependency, undeclared[name], "패키지 %q를 import하지만 package.json에 선언되지 않음", name)
+	}
+	if nodeMissing {
+		report.add(SeverityWarning, CheckSyntax, "", "node를 찾을 수 없어 JavaScript 문법 검사를 건너뜀")
+	}
+}
+
+// nodeCheck는 node --check로 JavaScript 파일 문법을 검사하고, 오류가 있으면 메시지를 반환합니다.
+func nodeCheck(ctx context.Context, path string) string {
+	checkCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(checkCtx, "node", "--check", path)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := procgroup.Run(cmd); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return msg
+		}
+		return fmt.Sprintf("node --check 실패: %v", err)
+	}
+	return ""
+}
+
+// smokeStart는 cfg로 서버를 시작해 stdio로 initialize와 ping을 보내고 응답을 확인한 뒤 종료합니다.
+func smokeStart(ctx context.Context, cfg ServerConfig, timeout time.Duration) error {
+	smokeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(smokeCtx, cfg.Command, cfg.Args...)
+	cmd.Dir = cfg.WorkingDir
+	cmd.Env = os.Environ()
+	for k, v := range cfg.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("stdin 파이프 생성 실패: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("stdout 파이프 생성 실패: %w", err)
+	}
+	stderr := &tailBuffer{max: 2048}
+	cmd.Stderr = stderr
+
+	if err := procgroup.Start(cmd); err != nil {
+		return fmt.Errorf("서버 시작 실패: %w", err)
+	}
+
+	method, err := smokeHandshake(smokeCtx, stdin, stdout)
+	// 프로세스를 회수한 뒤 stderr를 읽어야 출력이 모두 기록되어 있다.
+	cancel()
+	_ = procgroup.Wait(cmd)
+	if err == nil {
+		return nil
+	}
+	if tail := strings.TrimSpace(stderr.String()); tail != "" {
+		return fmt.Errorf("스모크 시작 %s 실패: %v (stderr: %s)", method, err, tail)
+	}
+	return fmt.Errorf("스모크 시작 %s 실패: %v", method, err)
+}
+
+// smokeHandshake는 initialize와 ping을 차례로 보내 응답을 확인합니다.
+// 실패하면 실패한 메서드 이름과 에러를 반환합니다.
+func smokeHandshake(ctx context.Context, stdin io.Writer, stdout io.Reader) (string, error) {
+	responses := make(chan json.RawMessage, 4)
+	go func() {
+		defer close(responses)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1<<20)
+		for scanner.Scan() {
+			var msg struct {
+				ID json.RawMessage `json:"id"`
+			}
+			// stdout에 섞인 로그 줄은 무시하고 id가 있는 JSON-RPC 응답만 전달한다.
+			if json.Unmarshal(scanner.Bytes(), &msg) != nil || len(msg.ID) == 0 {
+				continue
+			}
+			select {
+			case responses <- append(json.RawMessage(nil), scanner.Bytes()...):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	steps := []struct {
+		method string
+		params any
+	}{
+		{"initialize", map[string]any{
+			"protocolVersion": "2024-11-05",
+			"capabilities":    map[string]any{},
+			"clientInfo":      map[string]string{"name": "autopus-bridge-verify", "version": "1.0.0"},
+		}},
+		{"ping", nil},
+	}
+	for i, step := range steps {
+		id := i + 1
+		if err := writeJSONRPC(stdin, id, step.method, step.params); err != nil {
+			return step.method, fmt.Errorf("요청 전송 실패: %w", err)
+		}
+		if err := awaitResponse(ctx, responses, id); err != nil {
+			return step.method, err
+		}
+		if step.method == "initialize" {
+			if err := writeJSONRPC(stdin, 0, "notifications/initialized", nil); err != nil {
+				return step.method, fmt.Errorf("initialized 알림 전송 실패: %w", err)
+			}
+		}
+	}
+	return "", nil
+}
+
+// writeJSONRPC는 줄 단위 JSON-RPC 메시지를 씁니다. id가 0이면 알림으로 보냅니다.
+func writeJSONRPC(w io.Writer, id int, method string, params any) error {
+	msg := map[string]any{"jsonrpc": "2.0", "method": method}
+	if id != 0 {
+		msg["id"] = id
+	}
+	if params != nil {
+		msg["params"] = params
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// awaitResponse는 id에 대한 JSON-RPC 응답을 기다리고, 에러 응답이면 에러를 반환합니다.
+func awaitResponse(ctx context.Context, responses <-chan json.RawMessage, id int) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.New("응답 대기 시간 초과")
+		case raw, ok := <-responses:
+			if !ok {
+				return errors.New("응답 전에 서버가 종료됨")
+			}
+			var resp struct {
+				ID    int `json:"id"`
+				Error *struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if json.Unmarshal(raw, &resp) != nil || resp.ID != id {
+				continue
+			}
+			if resp.Error != nil {
+				return fmt.Errorf("에러 응답 (code=%d): %s", resp.Error.Code, resp.Error.Message)
+			}
+			return nil
+		}
+	}
+}
+
+// tailBuffer는 마지막 max 바이트만 보관하는 동시 사용 안전 버퍼입니다.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stdioEchoServer는 id가 있는 모든 JSON-RPC 요청에 빈 결과로 응답하는 Node.js MCP 서버입니다.
+const stdioEchoServer = `const rl = require("readline").createInterface({ input: process.stdin });
+console.log("starting");
+rl.on("line", (line) => {
+  const msg = JSON.parse(line);
+  if (msg.id !== undefined) console.log(JSON.stringify({ jsonrpc: "2.0", id: msg.id, result: {} }));
+});
+`
+
+// findingsOf는 check 항목의 메시지 목록을 반환합니다.
+func findingsOf(report *VerifyReport, check string) []string {
+	var msgs []string
+	for _, f := range report.Findings {
+		if f.Check == check {
+			msgs = append(msgs, f.File+": "+f.Message)
+		}
+	}
+	return msgs
+}
+
+func TestDeployer_Verify_ReportsFindingsWithoutDeploying(t *testing.T) {
+	baseDir := t.TempDir()
+	m := NewManager(newTestConfig(nil))
+	d := NewDeployer(baseDir, m)
+
+	files := []DeployFile{
+		{Path: "package.json", Content: `{"name":"svc","dependencies":{"zod":"^3"}}`},
+		{Path: "src/index.ts", Content: "import { Server } from \"@modelcontextprotocol/sdk/server/index.js\";\nimport { z } from 'zod';\nimport path from \"node:path\";\nimport { helper } from \"./helper\";\n"},
+		{Path: "config/settings.json", Content: `{"broken":`},
+		{Path: "../escape.txt", Content: "x"},
+	}
+	report, err := d.Verify(t.Context(), "svc", files, nil, VerifyOptions{SmokeTest: true})
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if report.Passed {
+		t.Fatal("Passed = true, want false")
+	}
+	if report.SmokeTested {
+		t.Error("정적 검사 오류가 있으면 스모크 시작을 하지 않아야 합니다")
+	}
+
+	if got := findingsOf(report, CheckLayout); len(got) != 2 {
+		// 경로 탈출 + scripts.start 없음
+		t.Errorf("layout findings = %v, want 2", got)
+	}
+	if got := findingsOf(report, CheckSyntax); len(got) != 1 || !strings.HasPrefix(got[0], "config/settings.json") {
+		t.Errorf("syntax findings = %v", got)
+	}
+	deps := findingsOf(report, CheckDependency)
+	if len(deps) != 2 || !strings.Contains(strings.Join(deps, "\n"), `"@modelcontextprotocol/sdk"`) || !strings.Contains(strings.Join(deps, "\n"), "node_modules") {
+		t.Errorf("dependency findings = %v", deps)
+	}
+
+	// 배포 경로와 스테이징 디렉토리가 남지 않아야 한다
+	entries, _ := os.ReadDir(baseDir)
+	if len(entries) != 0 {
+		t.Errorf("baseDir에 남은 항목: %v", entries)
+	}
+	if _, ok := m.processes["svc"]; ok {
+		t.Error("검증 모드에서 서버가 등록되었습니다")
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "..", "escape.txt")); err == nil {
+		t.Error("서비스 디렉토리 밖에 파일이 기록되었습니다")
+	}
+}
+
+func TestDeployer_Verify_MissingEntryPoint(t *testing.T) {
+	d := NewDeployer(t.TempDir(), NewManager(newTestConfig(nil)))
+
+	report, err := d.Verify(t.Context(), "svc", []DeployFile{{Path: "README.md", Content: "hi"}}, nil, VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got := findingsOf(report, CheckLayout); len(got) != 1 || !strings.HasPrefix(got[0], "src/index.ts") {
+		t.Errorf("layout findings = %v", got)
+	}
+}
+
+func TestDeployer_Verify_InvalidServiceName(t *testing.T) {
+	d := NewDeployer(t.TempDir(), NewManager(newTestConfig(nil)))
+	for _, name := range []string{"", "..", "a/b"} {
+		if _, err := d.Verify(t.Context(), name, nil, nil, VerifyOptions{}); err == nil {
+			t.Errorf("Verify(%q) expected error", name)
+		}
+	}
+}
+
+func TestDeployer_Verify_SmokeStart(t *testing.T) {
+	if _, err := exec.LookPath("npm"); err != nil {
+		t.Skip("npm이 없어 스모크 시작 테스트를 건너뜁니다")
+	}
+	d := NewDeployer(t.TempDir(), NewManager(newTestConfig(nil)))
+
+	files := []DeployFile{
+		{Path: "package.json", Content: `{"name":"svc","scripts":{"start":"node server.js"}}`},
+		{Path: "server.js", Content: stdioEchoServer},
+	}
+	report, err := d.Verify(t.Context(), "svc", files, nil, VerifyOptions{SmokeTest: true, SmokeTimeout: 20 * time.Second})
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !report.Passed || !report.SmokeTested {
+		t.Fatalf("report = %+v, want passed with smoke test", report)
+	}
+
+	// JavaScript 문법 오류는 스모크 시작 전에 발견된다
+	files[1].Content = "const x = ;"
+	report, err = d.Verify(t.Context(), "svc", files, nil, VerifyOptions{SmokeTest: true})
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got := findingsOf(report, CheckSyntax); len(got) != 1 || !strings.HasPrefix(got[0], "server.js") {
+		t.Errorf("syntax findings = %v", got)
+	}
+	if report.SmokeTested {
+		t.Error("문법 오류가 있으면 스모크 시작을 하지 않아야 합니다")
+	}
+}
+
+func TestSmokeStart_Failures(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"exits before response", "echo boom >&2; exit 1", "boom"},
+		{"error response", `read l; echo '{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"bad"}}'`, "bad"},
+		{"no ping response", `read l; echo '{"jsonrpc":"2.0","id":1,"result":{}}'; sleep 5`, "ping"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ServerConfig{Name: "smoke", Command: "sh", Args: []string{"-c", tt.script}, WorkingDir: t.TempDir()}
+			err := smokeStart(t.Context(), cfg, 500*time.Millisecond)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("smokeStart() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"zod":                            "zod",
+		"@modelcontextprotocol/sdk/x.js": "@modelcontextprotocol/sdk",
+		"lodash/fp":                      "lodash",
+		"./local":                        "",
+		"node:fs":                        "",
+		"fs":                             "",
+		"/abs/path":                      "",
+	}
+	for spec, want := range tests {
+		if got := packageName(spec); got != want {
+			t.Errorf("packageName(%q) = %q, want %q", spec, got, want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// manageAgentSpec은 에이전트 정의(프롬프트, 도구, 모델)를 생성/수정/복제/삭제하는 도구 선언입니다.
+var manageAgentSpec = ToolSpec{
+	Name:        "manage_agent",
+	Description: "Manage Autopus agent definitions. Supports creating, updating, cloning, and deleting agents. Use get_agent_details to read the current definition before updating.",
+	Params: []Param{
+		{Name: "action", Type: ParamString, Required: true, Enum: []string{"create", "update", "clone", "delete"}, Description: "Action to perform"},
+		{
+			Name:        "agent_id",
+			Type:        ParamString,
+			RequiredIf:  &Condition{Param: "action", Values: []string{"update", "clone", "delete"}},
+			Description: "Agent ID (required for update/clone/delete; for clone, the agent to copy)",
+		},
+		{Name: "workspace_id", Type: ParamString, Description: "Workspace ID the agent belongs to (optional, defaults to the active workspace, see set_active_workspace)"},
+		{
+			Name:        "config",
+			Type:        ParamString,
+			JSON:        JSONObject,
+			RequiredIf:  &Condition{Param: "action", Values: []string{"create", "update"}},
+			Description: "Agent definition as JSON string (required for create/update, optional overrides for clone). Fields: name, description, system_prompt, provider, model, tools (tool names or {name, description, parameters}), parameters (JSON schema object), temperature (0-2), max_tokens. Update only changes the given fields.",
+		},
+	},
+}
+
+// AgentConfig는 manage_agent로 생성/수정하는 에이전트 정의입니다.
+// 수정(update)과 복제(clone)에서는 지정한 필드만 변경됩니다.
+type AgentConfig struct {
+	Name         string          `json:"name,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	SystemPrompt string          `json:"system_prompt,omitempty"`
+	Provider     string          `json:"provider,omitempty"`
+	Model        string          `json:"model,omitempty"`
+	Tools        []AgentTool     `json:"tools,omitempty"`
+	Parameters   json.RawMessage `json:"parameters,omitempty"`
+	Temperature  *float64        `json:"temperature,omitempty"`
+	MaxTokens    *int            `json:"max_tokens,omitempty"`
+}
+
+// parseAgentConfig는 config JSON을 AgentConfig로 디코딩하고 백엔드에 보내기 전에 검증합니다.
+// 알 수 없는 필드는 오타로 보고 거부합니다. config가 없으면 nil을 반환합니다.
+func parseAgentConfig(action, raw string) (*AgentConfig, *ValidationError) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var cfg AgentConfig
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, newValidationError("config", "mcp.agent.invalid_config", err.Error())
+	}
+
+	if action == "create" && strings.TrimSpace(cfg.Name) == "" {
+		return nil, newValidationError("config", "mcp.agent.name_required")
+	}
+	if action == "update" && isEmptyAgentConfig(cfg) {
+		return nil, newValidationError("config", "mcp.agent.config_empty")
+	}
+
+	seen := make(map[string]bool, len(cfg.Tools))
+	for i, tool := range cfg.Tools {
+		name := strings.TrimSpace(tool.Name)
+		if name == "" {
+			return nil, newValidationError("config", "mcp.agent.tool_name_missing", i)
+		}
+		if seen[name] {
+			return nil, newValidationError("config", "mcp.agent.tool_duplicate", name)
+		}
+		seen[name] = true
+		if len(tool.Parameters) > 0 && !isJSONObject(tool.Parameters) {
+			return nil, newValidationError("config", "mcp.agent.parameters_object", "tools["+name+"].parameters")
+		}
+	}
+
+	if len(cfg.Parameters) > 0 && !isJSONObject(cfg.Parameters) {
+		return nil, newValidationError("config", "mcp.agent.parameters_object", "parameters")
+	}
+	if cfg.Temperature != nil && (*cfg.Temperature < 0 || *cfg.Temperature > 2) {
+		return nil, newValidationError("config", "mcp.validation.between", "config.temperature", "0", "2")
+	}
+	if cfg.MaxTokens != nil && *cfg.MaxTokens < 1 {
+		return nil, newValidationError("config", "mcp.validation.at_least", "config.max_tokens", "1")
+	}
+	return &cfg, nil
+}
+
+// isEmptyAgentConfig는 변경할 필드가 하나도 없는지 반환합니다.
+func isEmptyAgentConfig(cfg AgentConfig) bool {
+	return cfg.Name == "" && cfg.Description == "" && cfg.SystemPrompt == "" &&
+		cfg.Provider == "" && cfg.Model == "" && cfg.Tools == nil && len(cfg.Parameters) == 0 &&
+		cfg.Temperature == nil && cfg.MaxTokens == nil
+}
+
+// isJSONObject는 raw가 JSON 객체인지 반환합니다.
+func isJSONObject(raw json.RawMessage) bool {
+	var obj map[string]json.RawMessage
+	return bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) && json.Unmarshal(raw, &obj) == nil
+}
+
+// handleManageAgent는 manage_agent 도구 핸들러입니다.
+// 에이전트 정의를 관리합니다 (생성, 수정, 복제, 삭제).
+func (s *Server) handleManageAgent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// agent_id와 config 필수 여부, config JSON 형식은 선언에서, config 필드는 parseAgentConfig에서 검증된다.
+	args, verr := manageAgentSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	action := args.String("action")
+	agentID := args.String("agent_id")
+	workspaceID := s.workspaceArg(args)
+	config, verr := parseAgentConfig(action, args.String("config"))
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	s.logger.Info().
+		Str("action", action).
+		Str("agent_id", agentID).
+		Str("workspace_id", workspaceID).
+		Msg("에이전트 관리 요청")
+
+	resp, err := s.client.ManageAgent(ctx, &ManageAgentRequest{
+		Action:      action,
+		AgentID:     agentID,
+		WorkspaceID: workspaceID,
+		Config:      config,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("에이전트 관리 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.manage_agent_failed", err.Error())), nil
+	}
+	// 에이전트 카탈로그 리소스가 바뀐 정의를 반영하도록 캐시를 버린다.
+	s.cache.Delete(cacheKeyAgents)
+
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
@@ -0,0 +1,106 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/testbackend"
+)
+
+// TestParseAgentConfig는 manage_agent config의 클라이언트 측 검증을 테스트합니다.
+func TestParseAgentConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		raw     string
+		wantErr string
+	}{
+		{name: "생성", action: "create", raw: `{"name":"Reviewer","model":"claude-sonnet-4","tools":["read_file",{"name":"search","parameters":{"type":"object"}}]}`},
+		{name: "생성에 이름 누락", action: "create", raw: `{"model":"gpt-5"}`, wantErr: "config.name is required"},
+		{name: "알 수 없는 필드", action: "update", raw: `{"system_promt":"typo"}`, wantErr: "system_promt"},
+		{name: "타입 불일치", action: "update", raw: `{"model":3}`, wantErr: "invalid agent config"},
+		{name: "빈 수정", action: "update", raw: `{}`, wantErr: "at least one field"},
+		{name: "도구 이름 누락", action: "update", raw: `{"tools":[{"description":"x"}]}`, wantErr: "config.tools[0] has no name"},
+		{name: "도구 중복", action: "update", raw: `{"tools":["a","a"]}`, wantErr: "more than once"},
+		{name: "파라미터 스키마가 객체가 아님", action: "update", raw: `{"parameters":[1]}`, wantErr: "config.parameters must be a JSON schema object"},
+		{name: "temperature 범위", action: "update", raw: `{"temperature":3}`, wantErr: "config.temperature must be between 0 and 2"},
+		{name: "max_tokens 범위", action: "update", raw: `{"max_tokens":0}`, wantErr: "config.max_tokens must be at least 1"},
+		{name: "복제 덮어쓰기", action: "clone", raw: `{"model":"gpt-5"}`},
+		{name: "복제 설정 없음", action: "clone", raw: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, verr := parseAgentConfig(tt.action, tt.raw)
+			if tt.wantErr == "" {
+				if verr != nil {
+					t.Fatalf("예상하지 않은 검증 에러: %v", verr)
+				}
+				return
+			}
+			if verr == nil || !strings.Contains(verr.Message, tt.wantErr) {
+				t.Errorf("검증 에러 %v, %q 포함 기대", verr, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestHandleManageAgent는 manage_agent 액션별 백엔드 호출을 테스트합니다.
+func TestHandleManageAgent(t *testing.T) {
+	mock := testbackend.NewAPI()
+	defer mock.Close()
+
+	srv := newTestServer(mock.URL())
+	ctx := context.Background()
+	call := func(args map[string]interface{}) (string, bool) {
+		t.Helper()
+		result, err := srv.handleManageAgent(ctx, makeCallToolRequest("manage_agent", args))
+		if err != nil {
+			t.Fatalf("handleManageAgent 에러: %v", err)
+		}
+		return extractTextFromToolResult(t, result), result.IsError
+	}
+
+	text, isErr := call(map[string]interface{}{"action": "create", "config": `{"name":"Reviewer","tools":["read_file"]}`})
+	if isErr {
+		t.Fatalf("생성 실패: %s", text)
+	}
+	var resp ManageAgentResponse
+	if err := json.Unmarshal([]byte(text), &resp); err != nil || resp.Agent == nil || resp.Agent.Name != "Reviewer" {
+		t.Fatalf("생성 응답 = %s (%v)", text, err)
+	}
+	reqs := mock.RequestsTo(http.MethodPost, "/api/v1/workspaces/ws-1/agents")
+	if len(reqs) != 1 || !strings.Contains(string(reqs[0].Body), `"tools":[{"name":"read_file"}]`) {
+		t.Errorf("생성 요청이 활성 워크스페이스로 전달되지 않았습니다: %+v", reqs)
+	}
+
+	if text, isErr := call(map[string]interface{}{"action": "update", "agent_id": "agent-1", "workspace_id": "ws-2", "config": `{"model":"gpt-5"}`}); isErr {
+		t.Fatalf("수정 실패: %s", text)
+	}
+	if len(mock.RequestsTo(http.MethodPut, "/api/v1/workspaces/ws-2/agents/agent-1")) != 1 {
+		t.Error("수정 요청 경로가 올바르지 않습니다")
+	}
+
+	text, isErr = call(map[string]interface{}{"action": "clone", "agent_id": "agent-1"})
+	if isErr || !strings.Contains(text, "agent-1-copy") {
+		t.Fatalf("복제 응답 = %s", text)
+	}
+
+	if text, isErr := call(map[string]interface{}{"action": "delete", "agent_id": "agent-1"}); isErr || !strings.Contains(text, "Agent deleted") {
+		t.Fatalf("삭제 응답 = %s", text)
+	}
+
+	// 검증에 실패한 요청은 백엔드로 보내지 않는다.
+	mock.Reset()
+	if text, isErr := call(map[string]interface{}{"action": "update", "config": `{"model":"gpt-5"}`}); !isErr || !strings.Contains(text, "agent_id is required") {
+		t.Errorf("agent_id 누락 에러 기대: %s", text)
+	}
+	if text, isErr := call(map[string]interface{}{"action": "create", "config": `{"name":"x","temperature":5}`}); !isErr || !strings.Contains(text, "temperature") {
+		t.Errorf("temperature 범위 에러 기대: %s", text)
+	}
+	if n := len(mock.Requests()); n != 0 {
+		t.Errorf("검증 실패 요청이 백엔드로 전달되었습니다: %d", n)
+	}
+}
@@ -0,0 +1,353 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// ResourceBridgeConfig는 민감 값을 가린 로컬 Bridge 설정 리소스 URI입니다.
+	ResourceBridgeConfig = "autopus://bridge/config"
+	// ResourceBridgeCapabilities는 로컬 프로바이더, 실행기 가용성, 버전 정보 리소스 URI입니다.
+	ResourceBridgeCapabilities = "autopus://bridge/capabilities"
+)
+
+// redactedValue는 가린 설정 값 대신 표시하는 문자열입니다.
+const redactedValue = "[REDACTED]"
+
+// BridgeInfo는 autopus://bridge/* 리소스가 보여주는 로컬 Bridge 환경 정보의 출처입니다.
+// IDE 에이전트가 작업을 제안하기 전에 로컬 환경에서 무엇을 할 수 있는지 판단하는 데 사용합니다.
+type BridgeInfo struct {
+	// Version은 Bridge 빌드 정보입니다. MCP 서버 버전, 프로토콜 버전, 플랫폼은 자동으로 채웁니다.
+	Version BridgeVersion
+	// Settings는 현재 설정 값을 반환합니다 (보통 viper.AllSettings). 응답 전에 민감 값을 가립니다.
+	// nil이면 설정 리소스는 빈 객체를 반환합니다.
+	Settings func() map[string]any
+	// LoadConfig는 프로바이더와 실행기 가용성을 판단할 설정을 읽습니다 (보통 config.Load).
+	// nil이면 프로바이더와 실행기 목록이 비어 있습니다.
+	LoadConfig func() (*config.Config, error)
+}
+
+// BridgeVersion은 Bridge 버전 정보입니다.
+type BridgeVersion struct {
+	Bridge          string `json:"bridge"`
+	Commit          string `json:"commit,omitempty"`
+	BuildDate       string `json:"build_date,omitempty"`
+	MCPServer       string `json:"mcp_server"`
+	ProtocolVersion string `json:"protocol_version"`
+	GoVersion       string `json:"go_version"`
+	OS              string `json:"os"`
+	Arch            string `json:"arch"`
+}
+
+// ProviderCapability는 로컬 AI 프로바이더 하나의 감지 결과입니다.
+type ProviderCapability struct {
+	Name         string `json:"name"`
+	Enabled      bool   `json:"enabled"`
+	Available    bool   `json:"available"`
+	Mode         string `json:"mode,omitempty"`
+	DefaultModel string `json:"default_model,omitempty"`
+	// CLIInstalled는 CLI 바이너리가 PATH에 있는지 여부입니다 (HTTP API 프로바이더는 생략).
+	CLIInstalled *bool `json:"cli_installed,omitempty"`
+	// HasAPIKey는 API 키 환경 변수가 설정되어 있는지 여부입니다. 키 값은 포함하지 않습니다.
+	HasAPIKey bool `json:"has_api_key"`
+}
+
+// ExecutorCapability는 Bridge 실행기 하나의 가용성입니다.
+type ExecutorCapability struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	// Reason은 사용할 수 없는 이유 또는 가용성 세부 정보입니다.
+	Reason string `json:"reason,omitempty"`
+}
+
+// BridgeCapabilities는 autopus://bridge/capabilities 리소스 응답입니다.
+type BridgeCapabilities struct {
+	Version   BridgeVersion        `json:"version"`
+	Providers []ProviderCapability `json:"providers"`
+	Executors []ExecutorCapability `json:"executors"`
+	// ConfigError는 설정을 읽지 못했을 때의 에러입니다.
+	ConfigError string `json:"config_error,omitempty"`
+}
+
+// bridgeInfoHolder는 SetBridgeInfo로 설정한 정보를 보호합니다.
+type bridgeInfoHolder struct {
+	mu   sync.RWMutex
+	info BridgeInfo
+}
+
+// SetBridgeInfo는 autopus://bridge/config, autopus://bridge/capabilities 리소스의 정보 출처를 설정합니다.
+func (s *Server) SetBridgeInfo(info BridgeInfo) {
+	s.bridgeInfo.mu.Lock()
+	defer s.bridgeInfo.mu.Unlock()
+	s.bridgeInfo.info = info
+}
+
+// currentBridgeInfo는 현재 정보 출처를 반환합니다.
+func (s *Server) currentBridgeInfo() BridgeInfo {
+	s.bridgeInfo.mu.RLock()
+	defer s.bridgeInfo.mu.RUnlock()
+	return s.bridgeInfo.info
+}
+
+// handleBridgeConfigResource는 autopus://bridge/config 리소스 핸들러입니다.
+// API 키, 토큰, 웹훅 헤더 등 민감 값을 가린 설정을 반환합니다.
+func (s *Server) handleBridgeConfigResource(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	settings := map[string]any{}
+	if info := s.currentBridgeInfo(); info.Settings != nil {
+		settings = SanitizeSettings(info.Settings())
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("설정 직렬화 실패: %w", err)
+	}
+	return []mcp.ResourceContents{
+		newTextResource(request.Params.URI, string(data), "application/json"),
+	}, nil
+}
+
+// handleBridgeCapabilitiesResource는 autopus://bridge/capabilities 리소스 핸들러입니다.
+// 감지된 프로바이더, 실행기 가용성, 버전 정보를 반환합니다.
+func (s *Server) handleBridgeCapabilitiesResource(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	caps := s.bridgeCapabilities()
+
+	data, err := json.MarshalIndent(caps, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("기능 정보 직렬화 실패: %w", err)
+	}
+	return []mcp.ResourceContents{
+		newTextResource(request.Params.URI, string(data), "application/json"),
+	}, nil
+}
+
+// bridgeCapabilities는 현재 설정으로 기능 정보를 만듭니다.
+func (s *Server) bridgeCapabilities() BridgeCapabilities {
+	info := s.currentBridgeInfo()
+
+	version := info.Version
+	if version.Bridge == "" {
+		version.Bridge = "dev"
+	}
+	version.MCPServer = ServerVersion
+	version.ProtocolVersion = ws.AgentProtocolVersion
+	version.GoVersion = runtime.Version()
+	version.OS = runtime.GOOS
+	version.Arch = runtime.GOARCH
+
+	caps := BridgeCapabilities{
+		Version:   version,
+		Providers: []ProviderCapability{},
+		Executors: []ExecutorCapability{},
+	}
+	if info.LoadConfig == nil {
+		return caps
+	}
+	cfg, err := info.LoadConfig()
+	if err != nil {
+		caps.ConfigError = err.Error()
+		return caps
+	}
+
+	caps.Providers = detectProviders(cfg)
+	caps.Executors = s.detectExecutors(cfg, caps.Providers)
+	return caps
+}
+
+// detectProviders는 설정과 PATH로 프로바이더 가용성을 판단합니다.
+func detectProviders(cfg *config.Config) []ProviderCapability {
+	providers := make([]ProviderCapability, 0, 4)
+	for _, p := range []struct {
+		name string
+		cfg  config.ProviderConfig
+	}{
+		{"claude", cfg.Providers.Claude},
+		{"gemini", cfg.Providers.Gemini},
+		{"codex", cfg.Providers.Codex},
+	} {
+		installed := p.cfg.IsCLIAvailable()
+		providers = append(providers, ProviderCapability{
+			Name:         p.name,
+			Enabled:      p.cfg.IsEnabled(),
+			Available:    p.cfg.IsAvailable(),
+			Mode:         p.cfg.GetMode(),
+			DefaultModel: p.cfg.DefaultModel,
+			CLIInstalled: &installed,
+			HasAPIKey:    p.cfg.HasAPIKey(),
+		})
+	}
+
+	compat := cfg.Providers.OpenAICompat
+	if compat.Enabled {
+		providers = append(providers, ProviderCapability{
+			Name:         compat.GetName(),
+			Enabled:      true,
+			Available:    compat.IsAvailable(),
+			Mode:         "api",
+			DefaultModel: compat.DefaultModel,
+			HasAPIKey:    compat.GetAPIKey() != "",
+		})
+	}
+	return providers
+}
+
+// detectExecutors는 Bridge 실행기별 가용성을 판단합니다.
+func (s *Server) detectExecutors(cfg *config.Config, providers []ProviderCapability) []ExecutorCapability {
+	var available []string
+	for _, p := range providers {
+		if p.Available {
+			available = append(available, p.Name)
+		}
+	}
+	task := ExecutorCapability{Name: "task", Available: len(available) > 0}
+	if task.Available {
+		task.Reason = "providers: " + strings.Join(available, ", ")
+	} else {
+		task.Reason = "no AI provider is available"
+	}
+
+	git := ExecutorCapability{Name: "git", Available: lookPath("git")}
+	if !git.Available {
+		git.Reason = "git is not installed"
+	}
+
+	computerUse := ExecutorCapability{Name: "computer_use", Available: true, Reason: "isolation: " + cfg.ComputerUse.Isolation}
+	if cfg.ComputerUse.Isolation == "container" && !lookPath("docker") {
+		computerUse.Available = false
+		computerUse.Reason = "container isolation requires docker"
+	}
+
+	customTools := ExecutorCapability{Name: "custom_tools", Available: len(cfg.CustomTools) > 0}
+	if customTools.Available {
+		names := make([]string, 0, len(cfg.CustomTools))
+		for _, t := range cfg.CustomTools {
+			names = append(names, t.Name)
+		}
+		customTools.Reason = "tools: " + strings.Join(names, ", ")
+	} else {
+		customTools.Reason = "no custom tools configured"
+	}
+
+	sampling := ExecutorCapability{Name: "sampling", Available: s.sampling != nil}
+	if !sampling.Available {
+		sampling.Reason = "mcp_server.sampling.enabled is false"
+	}
+
+	return []ExecutorCapability{task, git, computerUse, customTools, sampling}
+}
+
+// lookPath는 실행 파일이 PATH에 있는지 확인합니다.
+func lookPath(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// sensitiveKeyParts는 값을 가릴 설정 키에 포함되는 단어입니다.
+var sensitiveKeyParts = []string{"api_key", "apikey", "token", "secret", "password", "passwd", "credential", "private_key", "authorization", "cookie"}
+
+// referenceKeySuffixes는 비밀 값 자체가 아니라 출처(환경 변수 이름, 파일 경로)를 가리키는 키 접미사입니다.
+var referenceKeySuffixes = []string{"_env", "_file", "_path", "_dir"}
+
+// opaqueMapKeys는 값 전체를 가릴 맵 키입니다 (임의 이름의 헤더/환경 변수).
+var opaqueMapKeys = map[string]bool{"headers": true, "env": true}
+
+// SanitizeSettings는 설정 값에서 API 키, 토큰, 비밀번호, 웹훅 헤더, 환경 변수 값, URL의 사용자 정보를 가린 사본을 반환합니다.
+// 환경 변수 이름(api_key_env)이나 파일 경로(token_file)처럼 비밀의 출처를 가리키는 값은 유지합니다.
+func SanitizeSettings(settings map[string]any) map[string]any {
+	sanitized, _ := sanitizeValue("", settings).(map[string]any)
+	if sanitized == nil {
+		sanitized = map[string]any{}
+	}
+	return sanitized
+}
+
+// sanitizeValue는 key 아래의 값을 재귀적으로 가립니다.
+func sanitizeValue(key string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[k] = sanitizeChild(key, k, child)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			out[k] = sanitizeChild(key, k, child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			out[i] = sanitizeValue(key, child)
+		}
+		return out
+	case string:
+		return sanitizeURL(v)
+	default:
+		return v
+	}
+}
+
+// sanitizeChild는 parent 맵의 k 항목을 가립니다.
+func sanitizeChild(parent, k string, child any) any {
+	if opaqueMapKeys[strings.ToLower(parent)] || isSensitiveKey(k) {
+		if isEmptyValue(child) {
+			return child
+		}
+		return redactedValue
+	}
+	return sanitizeValue(k, child)
+}
+
+// isSensitiveKey는 키가 비밀 값을 담는지 판단합니다.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range referenceKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return false
+		}
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue는 가릴 필요가 없는 빈 값인지 확인합니다. 설정되지 않았다는 정보는 유지합니다.
+func isEmptyValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+// sanitizeURL은 URL에 포함된 비밀번호를 가립니다. URL이 아니면 그대로 반환합니다.
+func sanitizeURL(s string) string {
+	if !strings.Contains(s, "://") || !strings.Contains(s, "@") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		u.User = url.UserPassword(u.User.Username(), "REDACTED")
+	}
+	return u.String()
+}
@@ -64,6 +64,12 @@ func (c *Cache) GetStale(key string) (interface{}, time.Time, bool) {
 
 // Set은 캐시에 값을 저장합니다.
 func (c *Cache) Set(key string, data interface{}) {
+	c.SetWithTTL(key, data, c.ttl)
+}
+
+// SetWithTTL은 캐시 기본 TTL 대신 지정한 TTL로 값을 저장합니다.
+// 리소스별로 신선도 기준이 다를 때 사용합니다.
+func (c *Cache) SetWithTTL(key string, data interface{}, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -71,7 +77,7 @@ func (c *Cache) Set(key string, data interface{}) {
 	c.items[key] = &cacheItem{
 		data:      data,
 		storedAt:  now,
-		expiredAt: now.Add(c.ttl),
+		expiredAt: now.Add(ttl),
 	}
 }
 
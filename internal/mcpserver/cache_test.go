@@ -259,3 +259,21 @@ func TestCache_StructValue(t *testing.T) {
 		t.Errorf("예상 BackendURL http://localhost:8080, 실제: %s", retrieved.BackendURL)
 	}
 }
+
+// TestCache_SetWithTTL은 항목별 TTL 저장을 테스트합니다.
+func TestCache_SetWithTTL(t *testing.T) {
+	c := NewCache(time.Minute)
+
+	c.SetWithTTL("short", "value", 0)
+	if _, _, ok := c.Get("short"); ok {
+		t.Error("TTL 0 항목은 즉시 만료되어야 합니다")
+	}
+	if _, _, ok := c.GetStale("short"); !ok {
+		t.Error("만료된 항목도 GetStale로 조회되어야 합니다")
+	}
+
+	c.SetWithTTL("long", "value", time.Hour)
+	if _, _, ok := c.Get("long"); !ok {
+		t.Error("TTL 이내 항목은 조회되어야 합니다")
+	}
+}
@@ -6,13 +6,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/insajin/autopus-agent-protocol"
 	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/tracing"
 	"github.com/rs/zerolog"
 )
 
@@ -57,19 +62,32 @@ type BackendClient struct {
 	httpClient   *http.Client
 	logger       zerolog.Logger
 	tokenRefresh *auth.TokenRefresher
+	// stats는 백엔드 호출 횟수와 지연 시간을 기록하는 로컬 텔레메트리입니다.
+	stats *Stats
+	// retry는 멱등 요청의 재시도 정책입니다.
+	retry RetryPolicy
+	// breaker는 백엔드 서킷 브레이커입니다 (비활성화 시 nil).
+	breaker *circuitBreaker
+	// rateLimitedUntil은 백엔드가 Retry-After로 알린 대기 종료 시각입니다 (UnixNano, 0이면 없음).
+	// 429를 받으면 동시에 진행 중인 다른 호출도 이 시각까지 요청을 보내지 않습니다.
+	rateLimitedUntil atomic.Int64
 }
 
 // NewBackendClient는 새 BackendClient를 생성합니다.
 // baseURL은 백엔드 API의 기본 URL입니다 (예: https://api.autopus.co).
 // tokenRefresher는 브릿지의 인증 시스템에서 재사용하는 TokenRefresher입니다.
+// HTTP 연결은 모든 BackendClient가 공유하는 커넥션 풀을 사용합니다 (ConfigureTransport 참조).
 func NewBackendClient(baseURL string, tokenRefresher *auth.TokenRefresher, timeout time.Duration, logger zerolog.Logger) *BackendClient {
 	return &BackendClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: tracing.NewTransport(sharedRoundTripper{}),
 		},
 		logger:       logger.With().Str("component", "mcpserver.client").Logger(),
 		tokenRefresh: tokenRefresher,
+		stats:        NewStats(),
+		retry:        RetryPolicy{MaxAttempts: 1},
 	}
 }
 
@@ -83,26 +101,150 @@ type apiResponse struct {
 
 // Do는 인증된 HTTP 요청을 실행합니다.
 // TokenRefresher에서 현재 유효한 JWT 토큰을 가져와 Authorization 헤더에 추가합니다.
+// 멱등 요청은 재시도 정책에 따라 일시적 오류 시 지수 백오프로 재시도하며,
+// 서킷 브레이커가 열려 있으면 백엔드를 호출하지 않고 ErrCircuitOpen을 반환합니다.
+// 요청 한도 초과(429)는 메서드와 관계없이 Retry-After(없으면 지수 백오프)만큼 기다린 뒤 재시도합니다.
 func (c *BackendClient) Do(ctx context.Context, method, path string, body interface{}) (*apiResponse, error) {
-	var reqBody io.Reader
+	var data []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		var err error
+		data, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("요청 본문 직렬화 실패: %w", err)
 		}
+	}
+
+	attempts := 1
+	if c.retry.MaxAttempts > 1 {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := c.waitRateLimit(ctx); err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+		if c.breaker != nil && !c.breaker.Allow() {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, ErrCircuitOpen
+		}
+
+		resp, outcome, err := c.doOnce(ctx, method, path, data)
+		if c.breaker != nil {
+			switch {
+			case outcome == outcomeOK || outcome == outcomeClientError || outcome == outcomeRateLimited:
+				c.breaker.Success()
+			case ctx.Err() == nil:
+				c.breaker.Failure()
+			}
+		}
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		retryable := outcome == outcomeRateLimited || (outcome == outcomeTransient && isIdempotentMethod(method))
+		if !retryable || attempt >= attempts || ctx.Err() != nil {
+			return nil, err
+		}
+
+		delay := c.retry.backoff(attempt)
+		var limited *rateLimitedError
+		if errors.As(err, &limited) {
+			if limited.retryAfter > c.retry.retryAfterLimit() {
+				return nil, err
+			}
+			delay = max(delay, limited.retryAfter)
+		}
+		c.logger.Debug().
+			Err(err).
+			Str("method", method).
+			Str("path", path).
+			Int("attempt", attempt).
+			Dur("backoff", delay).
+			Msg("일시적 백엔드 오류, 재시도 대기")
+		c.stats.RecordRetry()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// noteRateLimit은 Retry-After 대기 종료 시각을 기록합니다 (상한은 RetryPolicy.MaxRetryAfter).
+// 이미 더 늦은 시각이 기록되어 있으면 유지합니다.
+func (c *BackendClient) noteRateLimit(retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		return
+	}
+	until := time.Now().Add(min(retryAfter, c.retry.retryAfterLimit())).UnixNano()
+	for {
+		cur := c.rateLimitedUntil.Load()
+		if cur >= until || c.rateLimitedUntil.CompareAndSwap(cur, until) {
+			return
+		}
+	}
+}
+
+// waitRateLimit은 백엔드가 알린 Retry-After 대기 시간이 남아 있으면 끝날 때까지 기다립니다.
+func (c *BackendClient) waitRateLimit(ctx context.Context) error {
+	wait := time.Until(time.Unix(0, c.rateLimitedUntil.Load()))
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// attemptOutcome은 단일 요청 시도의 결과 분류입니다.
+type attemptOutcome int
+
+const (
+	// outcomeOK는 성공한 요청입니다.
+	outcomeOK attemptOutcome = iota
+	// outcomeClientError는 요청/인증/4xx 오류로, 백엔드 장애가 아닙니다.
+	outcomeClientError
+	// outcomeServerError는 재시도로 회복되지 않는 5xx 오류입니다.
+	outcomeServerError
+	// outcomeTransient는 네트워크 오류 또는 502/503/504로, 재시도 대상입니다.
+	outcomeTransient
+	// outcomeRateLimited는 429 요청 한도 초과로, 처리되지 않은 요청이므로 메서드와 관계없이 재시도 대상입니다.
+	outcomeRateLimited
+)
+
+// doOnce는 요청을 한 번 전송하고 결과를 분류합니다.
+// 요청 전송 이후의 지연 시간과 실패 여부는 통계(autopus://stats)에 기록됩니다.
+func (c *BackendClient) doOnce(ctx context.Context, method, path string, data []byte) (_ *apiResponse, outcome attemptOutcome, err error) {
+	var reqBody io.Reader
+	if data != nil {
 		reqBody = bytes.NewReader(data)
 	}
 
 	url := c.baseURL + path
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
+		return nil, outcomeClientError, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
 	}
 
 	// TokenRefresher에서 유효한 토큰 가져오기
 	token, err := c.tokenRefresh.GetToken()
 	if err != nil {
-		return nil, fmt.Errorf("인증 토큰 획득 실패: %w", err)
+		return nil, outcomeClientError, fmt.Errorf("인증 토큰 획득 실패: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -114,24 +256,37 @@ func (c *BackendClient) Do(ctx context.Context, method, path string, body interf
 		Str("path", path).
 		Msg("API 요청 전송")
 
+	start := time.Now()
+	defer func() { c.stats.RecordBackend(method, path, time.Since(start), err) }()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("백엔드 통신 실패 (서버에 연결할 수 없습니다): %w", err)
+		return nil, outcomeTransient, &unavailableError{fmt.Errorf("백엔드 통신 실패 (서버에 연결할 수 없습니다): %w", err)}
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패: %w", err)
+		return nil, outcomeTransient, &unavailableError{fmt.Errorf("응답 읽기 실패: %w", err)}
 	}
 
 	if resp.StatusCode >= 500 {
-		return nil, fmt.Errorf("백엔드 서버 오류 (HTTP %d): %s", resp.StatusCode, string(respBody))
+		outcome = outcomeServerError
+		if isRetryableStatus(resp.StatusCode) {
+			outcome = outcomeTransient
+		}
+		return nil, outcome, &unavailableError{fmt.Errorf("백엔드 서버 오류 (HTTP %d): %s", resp.StatusCode, string(respBody))}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		c.noteRateLimit(retryAfter)
+		return nil, outcomeRateLimited, &rateLimitedError{retryAfter: retryAfter, body: string(respBody)}
 	}
 
 	var apiResp apiResponse
 	if err := json.Unmarshal(respBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("응답 파싱 실패 (HTTP %d): %w", resp.StatusCode, err)
+		return nil, outcomeClientError, fmt.Errorf("응답 파싱 실패 (HTTP %d): %w", resp.StatusCode, err)
 	}
 
 	if resp.StatusCode >= 400 {
@@ -142,10 +297,23 @@ func (c *BackendClient) Do(ctx context.Context, method, path string, body interf
 		if errMsg == "" {
 			errMsg = apiErrorMessage(fmt.Sprintf("HTTP %d", resp.StatusCode))
 		}
-		return nil, fmt.Errorf("API 오류: %s", errMsg)
+		return nil, outcomeClientError, fmt.Errorf("API 오류: %s", errMsg)
 	}
 
-	return &apiResp, nil
+	return &apiResp, outcomeOK, nil
+}
+
+// SetRetryPolicy는 멱등 요청의 재시도 정책을 설정합니다.
+// 기본값은 재시도하지 않음(MaxAttempts 1)입니다.
+func (c *BackendClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// EnableCircuitBreaker는 백엔드 서킷 브레이커를 활성화합니다.
+// 연속 실패가 FailureThreshold에 도달하면 OpenTimeout 동안 호출을 즉시 거부하여
+// 리소스 핸들러가 백엔드 타임아웃을 기다리지 않고 캐시를 반환하도록 합니다.
+func (c *BackendClient) EnableCircuitBreaker(cfg CircuitBreakerConfig) {
+	c.breaker = newCircuitBreaker(cfg)
 }
 
 // ExecuteTaskRequest는 태스크 실행 요청 파라미터입니다.
@@ -230,9 +398,16 @@ type AgentInfo struct {
 }
 
 // ListAgentsResponse는 에이전트 목록 응답입니다.
+// 페이지 단위 응답이면 NextCursor 또는 HasMore로 다음 페이지 존재 여부를 나타냅니다.
 type ListAgentsResponse struct {
-	Agents []AgentInfo `json:"agents"`
-	Total  int         `json:"total"`
+	Agents     []AgentInfo `json:"agents"`
+	Total      int         `json:"total"`
+	Offset     int         `json:"offset,omitempty"`
+	Limit      int         `json:"limit,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more,omitempty"`
+	// Truncated는 자동 페이지 조회가 최대 개수 제한으로 중단되었음을 나타냅니다 (Bridge가 설정).
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 func (r *ListAgentsResponse) UnmarshalJSON(data []byte) error {
@@ -262,9 +437,40 @@ func (r *ListAgentsResponse) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("에이전트 목록 응답 파싱 실패: %s", string(data))
 }
 
+// hasNextPage는 현재 페이지 뒤에 조회할 에이전트가 남아 있는지 반환합니다.
+// 페이지 정보를 주지 않는 레거시 백엔드는 한 번에 전체를 반환하므로 false입니다.
+func (r *ListAgentsResponse) hasNextPage(offset int) bool {
+	if len(r.Agents) == 0 {
+		return false
+	}
+	return r.NextCursor != "" || r.HasMore || r.Total > offset+len(r.Agents)
+}
+
+// ListAgentsRequest는 에이전트 목록 조회 파라미터입니다.
+// Limit/Offset이 0이거나 Cursor가 비어 있으면 해당 파라미터를 보내지 않습니다 (백엔드 기본값).
+type ListAgentsRequest struct {
+	WorkspaceID string
+	// Filter는 서버 측에서 이름/기능으로 거르는 부분 일치 문자열입니다.
+	Filter string
+	Limit  int
+	Offset int
+	// Cursor는 이전 응답의 NextCursor입니다. 지정하면 Offset보다 우선합니다.
+	Cursor string
+}
+
 // ListAgents는 사용 가능한 에이전트 목록을 조회합니다.
 // opts는 선택적 파라미터입니다: 첫 번째 값은 filter 문자열로 사용됩니다.
 func (c *BackendClient) ListAgents(ctx context.Context, workspaceID string, opts ...string) (*ListAgentsResponse, error) {
+	req := &ListAgentsRequest{WorkspaceID: workspaceID}
+	if len(opts) > 0 {
+		req.Filter = opts[0]
+	}
+	return c.ListAgentsPage(ctx, req)
+}
+
+// ListAgentsPage는 에이전트 목록의 한 페이지를 조회합니다.
+func (c *BackendClient) ListAgentsPage(ctx context.Context, req *ListAgentsRequest) (*ListAgentsResponse, error) {
+	workspaceID := req.WorkspaceID
 	if workspaceID == "" && c.tokenRefresh != nil {
 		workspaceID = c.tokenRefresh.GetWorkspaceID()
 	}
@@ -274,8 +480,16 @@ func (c *BackendClient) ListAgents(ctx context.Context, workspaceID string, opts
 	if workspaceID != "" {
 		path = "/api/v1/workspaces/" + url.PathEscape(workspaceID) + "/agents"
 	}
-	if len(opts) > 0 && opts[0] != "" {
-		query.Set("filter", opts[0])
+	if req.Filter != "" {
+		query.Set("filter", req.Filter)
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.Cursor != "" {
+		query.Set("cursor", req.Cursor)
+	} else if req.Offset > 0 {
+		query.Set("offset", strconv.Itoa(req.Offset))
 	}
 	if encoded := query.Encode(); encoded != "" {
 		path += "?" + encoded
@@ -293,6 +507,140 @@ func (c *BackendClient) ListAgents(ctx context.Context, workspaceID string, opts
 	return &result, nil
 }
 
+// ListAllAgents는 req부터 시작해 다음 페이지가 없을 때까지 에이전트 목록을 모아 반환합니다.
+// maxAgents개를 넘으면 그 지점에서 중단하고 Truncated를 설정합니다 (0 이하면 제한 없음).
+// 백엔드가 커서를 주면 커서를, 아니면 offset을 이어서 조회합니다.
+func (c *BackendClient) ListAllAgents(ctx context.Context, req *ListAgentsRequest, maxAgents int) (*ListAgentsResponse, error) {
+	page := *req
+	all := &ListAgentsResponse{Agents: []AgentInfo{}, Offset: req.Offset}
+
+	for {
+		resp, err := c.ListAgentsPage(ctx, &page)
+		if err != nil {
+			return nil, err
+		}
+		all.Agents = append(all.Agents, resp.Agents...)
+		if resp.Total > all.Total {
+			all.Total = resp.Total
+		}
+
+		more := resp.hasNextPage(page.Offset)
+		if maxAgents > 0 && len(all.Agents) >= maxAgents {
+			if len(all.Agents) > maxAgents || more {
+				all.Agents = all.Agents[:maxAgents]
+				all.Truncated = true
+			}
+			break
+		}
+		if !more {
+			break
+		}
+
+		page.Offset += len(resp.Agents)
+		page.Cursor = resp.NextCursor
+	}
+
+	if all.Total < all.Offset+len(all.Agents) {
+		all.Total = all.Offset + len(all.Agents)
+	}
+	return all, nil
+}
+
+// AgentTool은 에이전트에 설정된 도구와 입력 스키마입니다.
+type AgentTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+// UnmarshalJSON은 도구 이름 문자열만 내려주는 레거시 응답도 허용합니다.
+func (t *AgentTool) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = AgentTool{Name: name}
+		return nil
+	}
+
+	type agentToolWire struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Parameters  json.RawMessage `json:"parameters,omitempty"`
+		InputSchema json.RawMessage `json:"input_schema,omitempty"`
+	}
+	var wire agentToolWire
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return fmt.Errorf("에이전트 도구 파싱 실패: %w", err)
+	}
+	params := wire.Parameters
+	if len(params) == 0 {
+		params = wire.InputSchema
+	}
+	*t = AgentTool{Name: wire.Name, Description: wire.Description, Parameters: params}
+	return nil
+}
+
+// AgentWorkflowStep은 에이전트 워크플로우의 단일 단계입니다.
+type AgentWorkflowStep struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// AgentExecutionStats는 에이전트의 최근 실행 통계입니다.
+type AgentExecutionStats struct {
+	TotalExecutions int     `json:"total_executions"`
+	SuccessCount    int     `json:"success_count"`
+	FailureCount    int     `json:"failure_count"`
+	SuccessRate     float64 `json:"success_rate,omitempty"`
+	AvgDurationMs   int64   `json:"avg_duration_ms,omitempty"`
+	LastExecutedAt  string  `json:"last_executed_at,omitempty"`
+}
+
+// AgentDetails는 execute_task 호출 구성을 위한 에이전트 상세 정보입니다.
+type AgentDetails struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	Description   string               `json:"description,omitempty"`
+	Provider      string               `json:"provider,omitempty"`
+	Model         string               `json:"model,omitempty"`
+	Tools         []AgentTool          `json:"tools,omitempty"`
+	Parameters    json.RawMessage      `json:"parameters,omitempty"`
+	WorkflowSteps []AgentWorkflowStep  `json:"workflow_steps,omitempty"`
+	Stats         *AgentExecutionStats `json:"stats,omitempty"`
+}
+
+// GetAgent는 에이전트의 도구/파라미터 스키마, 모델, 워크플로우, 최근 실행 통계를 조회합니다.
+func (c *BackendClient) GetAgent(ctx context.Context, workspaceID, agentID string) (*AgentDetails, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent_id is required")
+	}
+	if workspaceID == "" && c.tokenRefresh != nil {
+		workspaceID = c.tokenRefresh.GetWorkspaceID()
+	}
+
+	path := "/api/v1/agents/" + url.PathEscape(agentID)
+	if workspaceID != "" {
+		path = "/api/v1/workspaces/" + url.PathEscape(workspaceID) + "/agents/" + url.PathEscape(agentID)
+	}
+	path += "?include=tools,parameters,workflow,stats"
+
+	resp, err := c.Do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AgentDetails
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("에이전트 상세 응답 파싱 실패: %w", err)
+	}
+	if result.ID == "" {
+		result.ID = agentID
+	}
+	return &result, nil
+}
+
 // ExecutionStatus는 실행 상태 정보입니다.
 type ExecutionStatus struct {
 	ExecutionID string          `json:"execution_id"`
@@ -369,6 +717,49 @@ func normalizeExecutionStatus(data json.RawMessage) (*ExecutionStatus, error) {
 	}, nil
 }
 
+// ExecutionDiff는 실행이 만든 파일 변경 사항입니다.
+// 격리(work_dir 복사본) 실행의 결과로 백엔드에 기록된 workspace_changes에서 가져옵니다.
+type ExecutionDiff struct {
+	ExecutionID string `json:"execution_id"`
+	Status      string `json:"status"`
+	// HasChanges는 실행 기록에 파일 변경 정보가 있는지 여부입니다.
+	HasChanges    bool                     `json:"has_changes"`
+	Files         []ws.WorkspaceFileChange `json:"files,omitempty"`
+	Diff          string                   `json:"diff,omitempty"`
+	DiffTruncated bool                     `json:"diff_truncated,omitempty"`
+	Applied       bool                     `json:"applied"`
+	Conflicts     []string                 `json:"conflicts,omitempty"`
+	IsolatedDir   string                   `json:"isolated_dir,omitempty"`
+}
+
+// GetExecutionDiff는 실행 기록에서 파일 변경 사항과 unified diff를 조회합니다.
+// 격리 없이 실행되었거나 변경이 없으면 HasChanges가 false입니다.
+func (c *BackendClient) GetExecutionDiff(ctx context.Context, executionID string) (*ExecutionDiff, error) {
+	status, err := c.GetExecutionStatus(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecutionDiff{ExecutionID: status.ExecutionID, Status: status.Status}
+	var payload struct {
+		WorkspaceChanges *ws.WorkspaceChanges `json:"workspace_changes"`
+	}
+	// 결과가 객체가 아니면(문자열 출력 등) 변경 정보가 없는 것으로 본다.
+	if len(status.Result) == 0 || json.Unmarshal(status.Result, &payload) != nil || payload.WorkspaceChanges == nil {
+		return result, nil
+	}
+
+	changes := payload.WorkspaceChanges
+	result.HasChanges = len(changes.Files) > 0
+	result.Files = changes.Files
+	result.Diff = changes.Diff
+	result.DiffTruncated = changes.DiffTruncated
+	result.Applied = changes.Applied
+	result.Conflicts = changes.Conflicts
+	result.IsolatedDir = changes.IsolatedDir
+	return result, nil
+}
+
 // ApproveExecutionRequest는 실행 승인/거부 요청입니다.
 type ApproveExecutionRequest struct {
 	ExecutionID string `json:"execution_id"`
@@ -462,6 +853,70 @@ func (c *BackendClient) ManageWorkspace(ctx context.Context, req *ManageWorkspac
 	return &result, nil
 }
 
+// ManageAgentRequest는 에이전트 관리 요청입니다.
+type ManageAgentRequest struct {
+	Action      string       `json:"action"` // "create", "update", "clone", "delete"
+	AgentID     string       `json:"agent_id,omitempty"`
+	WorkspaceID string       `json:"workspace_id,omitempty"`
+	Config      *AgentConfig `json:"config,omitempty"`
+}
+
+// ManageAgentResponse는 에이전트 관리 응답입니다.
+type ManageAgentResponse struct {
+	Agent   *AgentDetails `json:"agent,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+// ManageAgent는 에이전트 정의를 생성, 수정, 복제, 삭제합니다.
+// 워크스페이스 ID가 없으면 인증 정보의 워크스페이스를 사용합니다.
+func (c *BackendClient) ManageAgent(ctx context.Context, req *ManageAgentRequest) (*ManageAgentResponse, error) {
+	workspaceID := req.WorkspaceID
+	if workspaceID == "" && c.tokenRefresh != nil {
+		workspaceID = c.tokenRefresh.GetWorkspaceID()
+	}
+
+	base := "/api/v1/agents"
+	if workspaceID != "" {
+		base = "/api/v1/workspaces/" + url.PathEscape(workspaceID) + "/agents"
+	}
+	if req.Action != "create" {
+		if req.AgentID == "" {
+			return nil, fmt.Errorf("agent_id is required")
+		}
+		base += "/" + url.PathEscape(req.AgentID)
+	}
+
+	var method, path string
+	switch req.Action {
+	case "create":
+		method, path = http.MethodPost, base
+	case "update":
+		method, path = http.MethodPut, base
+	case "clone":
+		method, path = http.MethodPost, base+"/clone"
+	case "delete":
+		method, path = http.MethodDelete, base
+	default:
+		return nil, fmt.Errorf("알 수 없는 에이전트 액션: %s", req.Action)
+	}
+
+	var body interface{}
+	if method != http.MethodDelete {
+		body = req
+	}
+
+	resp, err := c.Do(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ManageAgentResponse
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("에이전트 관리 응답 파싱 실패: %w", err)
+	}
+	return &result, nil
+}
+
 // SearchKnowledgeRequest는 지식 검색 요청입니다.
 type SearchKnowledgeRequest struct {
 	Query       string                 `json:"query"`
@@ -478,13 +933,25 @@ type KnowledgeResult struct {
 	Score    float64                `json:"score,omitempty"`
 	Source   string                 `json:"source,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// LocalMatches는 local_rerank 재정렬에서 이 결과에 일치한 기술 스택과 최근 작업 파일입니다.
+	LocalMatches []string `json:"local_matches,omitempty"`
 }
 
 // SearchKnowledgeResponse는 지식 검색 응답입니다.
+// 백엔드 미연결 시 로컬 캐시로 만든 결과이면 Offline이 true이고, 만들어진 방식은 OfflineMatch에 표시됩니다.
 type SearchKnowledgeResponse struct {
 	Results []KnowledgeResult `json:"results"`
 	Total   int               `json:"total"`
 	Query   string            `json:"query"`
+
+	Offline      bool   `json:"offline,omitempty"`
+	OfflineMatch string `json:"offline_match,omitempty"`
+	CachedAt     string `json:"cached_at,omitempty"`
+	Message      string `json:"message,omitempty"`
+
+	// LocalReranked는 결과를 현재 프로젝트 정보로 재정렬했는지 나타냅니다 (local_rerank).
+	LocalReranked bool `json:"local_reranked,omitempty"`
 }
 
 // SearchKnowledge는 지식 베이스를 검색합니다.
@@ -500,3 +967,97 @@ func (c *BackendClient) SearchKnowledge(ctx context.Context, req *SearchKnowledg
 	}
 	return &result, nil
 }
+
+// KnowledgeDocument는 지식 베이스 문서 전체 내용입니다.
+type KnowledgeDocument struct {
+	ID         string                 `json:"id"`
+	Title      string                 `json:"title"`
+	Content    string                 `json:"content"`
+	Category   string                 `json:"category,omitempty"`
+	SourceType string                 `json:"source_type,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  string                 `json:"created_at,omitempty"`
+	UpdatedAt  string                 `json:"updated_at,omitempty"`
+}
+
+// GetKnowledgeDocument는 검색 결과의 ID로 지식 문서 전체를 조회합니다.
+func (c *BackendClient) GetKnowledgeDocument(ctx context.Context, workspaceID, documentID string) (*KnowledgeDocument, error) {
+	if documentID == "" {
+		return nil, fmt.Errorf("document_id is required")
+	}
+	if workspaceID == "" && c.tokenRefresh != nil {
+		workspaceID = c.tokenRefresh.GetWorkspaceID()
+	}
+
+	path := "/api/v1/knowledge/" + url.PathEscape(documentID)
+	if workspaceID != "" {
+		path = "/api/v1/workspaces/" + url.PathEscape(workspaceID) + "/knowledge/" + url.PathEscape(documentID)
+	}
+
+	resp, err := c.Do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result KnowledgeDocument
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("지식 문서 응답 파싱 실패: %w", err)
+	}
+	if result.ID == "" {
+		result.ID = documentID
+	}
+	return &result, nil
+}
+
+// KnowledgeSource는 지식 베이스에 동기화된 소스(폴더) 정보입니다.
+type KnowledgeSource struct {
+	ID           string `json:"id"`
+	Name         string `json:"name,omitempty"`
+	Path         string `json:"path,omitempty"`
+	Status       string `json:"status,omitempty"`
+	FileCount    int    `json:"file_count,omitempty"`
+	LastSyncedAt string `json:"last_synced_at,omitempty"`
+	CreatedAt    string `json:"created_at,omitempty"`
+}
+
+// ListKnowledgeSourcesResponse는 지식 소스 목록 응답입니다.
+type ListKnowledgeSourcesResponse struct {
+	WorkspaceID string            `json:"workspace_id"`
+	Sources     []KnowledgeSource `json:"sources"`
+	Total       int               `json:"total"`
+}
+
+// ListKnowledgeSources는 워크스페이스의 지식 소스 목록을 조회합니다.
+// 워크스페이스 ID가 없으면 인증 정보의 기본 워크스페이스를 사용합니다.
+func (c *BackendClient) ListKnowledgeSources(ctx context.Context, workspaceID string) (*ListKnowledgeSourcesResponse, error) {
+	if workspaceID == "" && c.tokenRefresh != nil {
+		workspaceID = c.tokenRefresh.GetWorkspaceID()
+	}
+	if workspaceID == "" {
+		return nil, fmt.Errorf("workspace_id is required")
+	}
+
+	resp, err := c.Do(ctx, http.MethodGet, "/api/v1/workspaces/"+url.PathEscape(workspaceID)+"/knowledge/folders", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var folders struct {
+		Folders []KnowledgeSource `json:"folders"`
+	}
+	if err := json.Unmarshal(resp.Data, &folders); err != nil {
+		return nil, fmt.Errorf("지식 소스 목록 응답 파싱 실패: %w", err)
+	}
+
+	sources := folders.Folders
+	if sources == nil {
+		sources = []KnowledgeSource{}
+	}
+	return &ListKnowledgeSourcesResponse{
+		WorkspaceID: workspaceID,
+		Sources:     sources,
+		Total:       len(sources),
+	}, nil
+}
@@ -3,8 +3,10 @@ package mcpserver
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -284,6 +286,285 @@ func TestListAgents_WithWorkspaceID(t *testing.T) {
 	}
 }
 
+// TestListAgentsPage_QueryParams는 필터/페이지 파라미터가 쿼리로 전달되는지 테스트합니다.
+func TestListAgentsPage_QueryParams(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("filter") != "search" || q.Get("limit") != "20" || q.Get("cursor") != "c-2" {
+			t.Errorf("예상하지 못한 쿼리: %s", r.URL.RawQuery)
+		}
+		if q.Has("offset") {
+			t.Errorf("cursor가 있으면 offset을 보내지 않아야 합니다: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(apiResponse{
+			Success: true,
+			Data:    json.RawMessage(`{"agents":[{"id":"agent-021","name":"A"}],"total":45,"next_cursor":"c-3"}`),
+		})
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListAgentsPage(context.Background(), &ListAgentsRequest{
+		Filter: "search", Limit: 20, Offset: 20, Cursor: "c-2",
+	})
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if result.NextCursor != "c-3" || result.Total != 45 {
+		t.Errorf("예상 next_cursor c-3/total 45, 실제: %q/%d", result.NextCursor, result.Total)
+	}
+}
+
+// pagedAgentsHandler는 offset/limit으로 total개의 에이전트를 나눠 반환하는 테스트 핸들러입니다.
+// 요청마다 requests를 증가시킵니다.
+func pagedAgentsHandler(t *testing.T, total int, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			t.Errorf("limit이 전달되어야 합니다: %s", r.URL.RawQuery)
+			limit = total
+		}
+		page := ListAgentsResponse{Agents: []AgentInfo{}, Total: total, Offset: offset, Limit: limit}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page.Agents = append(page.Agents, AgentInfo{ID: fmt.Sprintf("agent-%03d", i)})
+		}
+		data, _ := json.Marshal(page)
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: data})
+	}
+}
+
+// TestListAllAgents_AggregatesPages는 total을 기준으로 offset을 넘기며 모든 페이지를 모으는지 테스트합니다.
+func TestListAllAgents_AggregatesPages(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(pagedAgentsHandler(t, 25, &requests))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListAllAgents(context.Background(), &ListAgentsRequest{Limit: 10}, 0)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("예상 요청 수 3, 실제: %d", requests)
+	}
+	if len(result.Agents) != 25 || result.Total != 25 || result.Truncated {
+		t.Errorf("예상 25개/total 25/truncated false, 실제: %d/%d/%v", len(result.Agents), result.Total, result.Truncated)
+	}
+	if result.Agents[24].ID != "agent-024" {
+		t.Errorf("예상 마지막 에이전트 agent-024, 실제: %s", result.Agents[24].ID)
+	}
+}
+
+// TestListAllAgents_TruncatesAtMax는 최대 개수에 도달하면 조회를 멈추고 Truncated를 설정하는지 테스트합니다.
+func TestListAllAgents_TruncatesAtMax(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(pagedAgentsHandler(t, 100, &requests))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListAllAgents(context.Background(), &ListAgentsRequest{Limit: 10}, 15)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("예상 요청 수 2, 실제: %d", requests)
+	}
+	if len(result.Agents) != 15 || !result.Truncated || result.Total != 100 {
+		t.Errorf("예상 15개/truncated true/total 100, 실제: %d/%v/%d", len(result.Agents), result.Truncated, result.Total)
+	}
+}
+
+// TestListAllAgents_FollowsCursor는 커서 기반 응답에서 next_cursor를 따라가는지 테스트합니다.
+func TestListAllAgents_FollowsCursor(t *testing.T) {
+	pages := map[string]string{
+		"":    `{"agents":[{"id":"a1"},{"id":"a2"}],"next_cursor":"c-2"}`,
+		"c-2": `{"agents":[{"id":"a3"}]}`,
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("offset") {
+			t.Errorf("커서 기반 조회에 offset을 보내지 않아야 합니다: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(pages[r.URL.Query().Get("cursor")])})
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListAllAgents(context.Background(), &ListAgentsRequest{}, 0)
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if len(result.Agents) != 3 || result.Agents[2].ID != "a3" || result.Total != 3 {
+		t.Errorf("예상 3개 (마지막 a3), 실제: %+v (total %d)", result.Agents, result.Total)
+	}
+}
+
+// TestGetAgent는 에이전트 상세 조회와 도구 스키마 정규화를 테스트합니다.
+func TestGetAgent(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws-test-001/agents/agent-001" {
+			t.Errorf("예상 경로 /api/v1/workspaces/ws-test-001/agents/agent-001, 실제: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("include") == "" {
+			t.Error("include 쿼리 파라미터가 필요합니다")
+		}
+
+		resp := apiResponse{
+			Success: true,
+			Data: json.RawMessage(`{
+				"id":"agent-001",
+				"name":"Researcher",
+				"model":"claude-sonnet-4-6",
+				"tools":["search",{"name":"browser","description":"Web browser","input_schema":{"type":"object"}}],
+				"parameters":{"type":"object","properties":{"depth":{"type":"integer"}}},
+				"workflow_steps":[{"id":"s1","name":"collect","type":"tool"}],
+				"stats":{"total_executions":10,"success_count":9,"failure_count":1,"success_rate":0.9}
+			}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetAgent(context.Background(), "", "agent-001")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if result.Model != "claude-sonnet-4-6" {
+		t.Errorf("예상 model claude-sonnet-4-6, 실제: %s", result.Model)
+	}
+	if len(result.Tools) != 2 {
+		t.Fatalf("예상 도구 수 2, 실제: %d", len(result.Tools))
+	}
+	if result.Tools[0].Name != "search" || len(result.Tools[0].Parameters) != 0 {
+		t.Errorf("문자열 도구가 이름만으로 정규화되어야 합니다: %+v", result.Tools[0])
+	}
+	if result.Tools[1].Name != "browser" || string(result.Tools[1].Parameters) != `{"type":"object"}` {
+		t.Errorf("input_schema가 parameters로 정규화되어야 합니다: %+v", result.Tools[1])
+	}
+	if len(result.WorkflowSteps) != 1 || result.WorkflowSteps[0].Name != "collect" {
+		t.Errorf("워크플로우 단계 파싱 실패: %+v", result.WorkflowSteps)
+	}
+	if result.Stats == nil || result.Stats.TotalExecutions != 10 {
+		t.Errorf("실행 통계 파싱 실패: %+v", result.Stats)
+	}
+}
+
+// TestGetAgent_MissingID는 agent_id 누락을 테스트합니다.
+func TestGetAgent_MissingID(t *testing.T) {
+	client := newTestClient("http://localhost:1")
+	if _, err := client.GetAgent(context.Background(), "", ""); err == nil {
+		t.Fatal("agent_id 누락 시 오류가 반환되어야 합니다")
+	}
+}
+
+// TestGetKnowledgeDocument는 지식 문서 전체 조회를 테스트합니다.
+func TestGetKnowledgeDocument(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("예상 메서드 GET, 실제: %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/workspaces/ws-test-001/knowledge/doc-001" {
+			t.Errorf("예상 경로 /api/v1/workspaces/ws-test-001/knowledge/doc-001, 실제: %s", r.URL.Path)
+		}
+
+		resp := apiResponse{
+			Success: true,
+			Data:    json.RawMessage(`{"title":"Deploy guide","content":"full body","tags":["ops"]}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.GetKnowledgeDocument(context.Background(), "", "doc-001")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if result.ID != "doc-001" {
+		t.Errorf("응답에 ID가 없으면 요청 ID를 사용해야 합니다, 실제: %s", result.ID)
+	}
+	if result.Content != "full body" {
+		t.Errorf("예상 content 'full body', 실제: %s", result.Content)
+	}
+	if len(result.Tags) != 1 || result.Tags[0] != "ops" {
+		t.Errorf("태그 파싱 실패: %v", result.Tags)
+	}
+}
+
+// TestGetKnowledgeDocument_MissingID는 document_id 누락을 테스트합니다.
+func TestGetKnowledgeDocument_MissingID(t *testing.T) {
+	client := newTestClient("http://localhost:1")
+	if _, err := client.GetKnowledgeDocument(context.Background(), "", ""); err == nil {
+		t.Fatal("document_id 누락 시 오류가 반환되어야 합니다")
+	}
+}
+
+// TestListKnowledgeSources는 지식 소스 목록 조회를 테스트합니다.
+func TestListKnowledgeSources(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws-002/knowledge/folders" {
+			t.Errorf("예상 경로 /api/v1/workspaces/ws-002/knowledge/folders, 실제: %s", r.URL.Path)
+		}
+
+		resp := apiResponse{
+			Success: true,
+			Data: json.RawMessage(`{"folders":[
+				{"id":"f-1","name":"docs","path":"/repo/docs","status":"synced","file_count":12},
+				{"id":"f-2","name":"specs","path":"/repo/specs","status":"syncing"}
+			]}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListKnowledgeSources(context.Background(), "ws-002")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if result.WorkspaceID != "ws-002" {
+		t.Errorf("예상 workspace_id ws-002, 실제: %s", result.WorkspaceID)
+	}
+	if result.Total != 2 || len(result.Sources) != 2 {
+		t.Fatalf("예상 소스 수 2, 실제: total=%d len=%d", result.Total, len(result.Sources))
+	}
+	if result.Sources[0].FileCount != 12 || result.Sources[0].Path != "/repo/docs" {
+		t.Errorf("소스 파싱 실패: %+v", result.Sources[0])
+	}
+}
+
+// TestListKnowledgeSources_Empty는 소스가 없을 때 빈 목록을 반환하는지 테스트합니다.
+func TestListKnowledgeSources_Empty(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{}`)})
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	result, err := client.ListKnowledgeSources(context.Background(), "")
+	if err != nil {
+		t.Fatalf("예상하지 못한 오류: %v", err)
+	}
+	if result.WorkspaceID != "ws-test-001" {
+		t.Errorf("기본 워크스페이스를 사용해야 합니다, 실제: %s", result.WorkspaceID)
+	}
+	if result.Sources == nil || result.Total != 0 {
+		t.Errorf("빈 목록이어야 합니다: %+v", result)
+	}
+}
+
 // TestGetExecutionStatus는 실행 상태 조회를 테스트합니다.
 func TestGetExecutionStatus(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,63 @@
+package mcpserver
+
+import (
+	"path"
+	"strings"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// filterFileChanges는 path에 해당하는 변경 파일만 남깁니다.
+func filterFileChanges(files []ws.WorkspaceFileChange, filePath string) []ws.WorkspaceFileChange {
+	want := normalizeDiffPath(filePath)
+	var out []ws.WorkspaceFileChange
+	for _, f := range files {
+		if normalizeDiffPath(f.Path) == want {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// filterUnifiedDiff는 여러 파일의 unified diff에서 path 파일의 구간만 남깁니다.
+// 구간은 "--- "/"+++ " 헤더 쌍 또는 "Binary files " 줄로 시작합니다.
+func filterUnifiedDiff(diff, filePath string) string {
+	want := normalizeDiffPath(filePath)
+	lines := strings.SplitAfter(diff, "\n")
+
+	var b strings.Builder
+	keep := false
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			keep = diffHeaderPath(line[4:]) == want || diffHeaderPath(lines[i+1][4:]) == want
+		case strings.HasPrefix(line, "Binary files "):
+			from, to, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(line), "Binary files "), " differ"), " and ")
+			keep = diffHeaderPath(from) == want || diffHeaderPath(to) == want
+		}
+		if keep {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
+
+// diffHeaderPath는 diff 헤더의 파일 이름(a/x, b/x)에서 접두사와 타임스탬프를 제거합니다.
+func diffHeaderPath(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.IndexByte(name, '\t'); i >= 0 {
+		name = name[:i]
+	}
+	if name == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+		name = name[2:]
+	}
+	return normalizeDiffPath(name)
+}
+
+// normalizeDiffPath는 비교용으로 경로를 슬래시 구분의 정리된 상대 경로로 만듭니다.
+func normalizeDiffPath(p string) string {
+	return strings.TrimPrefix(path.Clean(strings.ReplaceAll(p, "\\", "/")), "./")
+}
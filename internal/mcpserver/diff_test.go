@@ -0,0 +1,50 @@
+package mcpserver
+
+import (
+	"testing"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+const sampleExecutionDiff = `--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+--- old comment
++// new comment
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++hello
+Binary files a/assets/logo.png and b/assets/logo.png differ
+`
+
+func TestFilterUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"main.go", "--- a/main.go\n+++ b/main.go\n@@ -1,3 +1,3 @@\n package main\n--- old comment\n+// new comment\n"},
+		{"./docs/new.md", "--- /dev/null\n+++ b/docs/new.md\n@@ -0,0 +1 @@\n+hello\n"},
+		{"assets/logo.png", "Binary files a/assets/logo.png and b/assets/logo.png differ\n"},
+		{"missing.go", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := filterUnifiedDiff(sampleExecutionDiff, tt.path); got != tt.want {
+				t.Errorf("filterUnifiedDiff(%q) =\n%s\nwant\n%s", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterFileChanges(t *testing.T) {
+	files := []ws.WorkspaceFileChange{
+		{Path: "main.go", Status: ws.WorkspaceFileModified},
+		{Path: "docs/new.md", Status: ws.WorkspaceFileAdded},
+	}
+	got := filterFileChanges(files, "docs/./new.md")
+	if len(got) != 1 || got[0].Path != "docs/new.md" {
+		t.Errorf("filterFileChanges() = %+v", got)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/insajin/autopus-bridge/internal/auth"
+	"github.com/insajin/autopus-bridge/internal/testbackend"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/rs/zerolog"
 )
@@ -20,7 +21,7 @@ import (
 // --- 테스트 헬퍼 ---
 
 // testToken은 테스트에서 사용하는 고정 JWT 토큰입니다.
-const testToken = "test-jwt-token-abc123"
+const testToken = testbackend.DefaultToken
 
 // newTestTokenRefresher는 항상 고정 토큰을 반환하는 TokenRefresher를 생성합니다.
 // 실제 Supabase 갱신 없이 auth.TokenRefresher를 사용할 수 있도록
@@ -92,164 +93,6 @@ func extractTextFromResourceResult(t *testing.T, contents []mcp.ResourceContents
 	return tc.Text
 }
 
-// newMockBackend는 API 라우트별로 응답을 반환하는 mock HTTP 서버를 생성합니다.
-// handler는 각 요청의 (method, path)를 기반으로 응답을 결정합니다.
-func newMockBackend(t *testing.T, handler http.HandlerFunc) *httptest.Server {
-	t.Helper()
-	return httptest.NewServer(handler)
-}
-
-// standardMockHandler는 모든 API 엔드포인트에 성공 응답을 반환하는 표준 mock 핸들러입니다.
-// authHeader를 검증하고, 각 경로에 따라 적절한 apiResponse를 반환합니다.
-func standardMockHandler(t *testing.T) http.HandlerFunc {
-	t.Helper()
-	return func(w http.ResponseWriter, r *http.Request) {
-		// JWT 인증 헤더 검증
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			writeAPIError(w, http.StatusUnauthorized, "missing authorization header")
-			return
-		}
-		if authHeader != "Bearer "+testToken {
-			writeAPIError(w, http.StatusUnauthorized, "invalid token")
-			return
-		}
-
-		path := r.URL.Path
-		method := r.Method
-
-		switch {
-		// POST /api/v1/workspaces/{id}/execute - execute_task
-		case method == http.MethodPost && strings.HasPrefix(path, "/api/v1/workspaces/") && strings.HasSuffix(path, "/execute"):
-			var reqBody ExecuteTaskRequest
-			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-				writeAPIError(w, http.StatusBadRequest, "invalid request body")
-				return
-			}
-			writeAPISuccess(w, ExecuteTaskResponse{
-				ExecutionID: "exec-001",
-				Status:      "running",
-				Message:     fmt.Sprintf("Task submitted to agent %s", reqBody.AgentID),
-			})
-
-		// GET /api/v1/workspaces/{id}/agents - list_agents
-		case method == http.MethodGet && strings.HasPrefix(path, "/api/v1/workspaces/") && strings.HasSuffix(path, "/agents"):
-			writeAPISuccess(w, ListAgentsResponse{
-				Agents: []AgentInfo{
-					{ID: "agent-1", Name: "Code Reviewer", Description: "Automated code review"},
-					{ID: "agent-2", Name: "Test Generator", Description: "Generate test cases"},
-				},
-				Total: 2,
-			})
-
-		// GET /api/v1/executions/{id} - get_execution_status
-		case method == http.MethodGet && strings.HasPrefix(path, "/api/v1/executions/"):
-			executionID := strings.TrimPrefix(path, "/api/v1/executions/")
-			// approve 경로인 경우 무시
-			if strings.Contains(executionID, "/") {
-				break
-			}
-			writeAPISuccess(w, ExecutionStatus{
-				ExecutionID: executionID,
-				Status:      "completed",
-				Result:      json.RawMessage(`{"output":"task done"}`),
-				CreatedAt:   "2025-01-01T00:00:00Z",
-				UpdatedAt:   "2025-01-01T00:01:00Z",
-			})
-
-		// POST /api/v1/executions/{id}/approve - approve_execution
-		case method == http.MethodPost && strings.Contains(path, "/approve"):
-			var reqBody ApproveExecutionRequest
-			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-				writeAPIError(w, http.StatusBadRequest, "invalid request body")
-				return
-			}
-			writeAPISuccess(w, ApproveExecutionResponse{
-				ExecutionID: reqBody.ExecutionID,
-				Status:      "approved",
-				Message:     "Execution approved",
-			})
-
-		// GET /api/v1/workspaces - list workspaces
-		case method == http.MethodGet && path == "/api/v1/workspaces":
-			writeAPISuccess(w, ManageWorkspaceResponse{
-				Workspaces: []WorkspaceInfo{
-					{ID: "ws-1", Name: "Default", Slug: "default"},
-					{ID: "ws-2", Name: "Production", Slug: "prod"},
-				},
-			})
-
-		// POST /api/v1/workspaces - create workspace
-		case method == http.MethodPost && path == "/api/v1/workspaces":
-			writeAPISuccess(w, ManageWorkspaceResponse{
-				Workspace: &WorkspaceInfo{ID: "ws-new", Name: "New Workspace"},
-				Message:   "Workspace created",
-			})
-
-		// GET /api/v1/workspaces/{id}
-		case method == http.MethodGet && strings.HasPrefix(path, "/api/v1/workspaces/"):
-			wsID := strings.TrimPrefix(path, "/api/v1/workspaces/")
-			writeAPISuccess(w, ManageWorkspaceResponse{
-				Workspace: &WorkspaceInfo{ID: wsID, Name: "Workspace " + wsID},
-			})
-
-		// PUT /api/v1/workspaces/{id}
-		case method == http.MethodPut && strings.HasPrefix(path, "/api/v1/workspaces/"):
-			wsID := strings.TrimPrefix(path, "/api/v1/workspaces/")
-			writeAPISuccess(w, ManageWorkspaceResponse{
-				Workspace: &WorkspaceInfo{ID: wsID, Name: "Updated"},
-				Message:   "Workspace updated",
-			})
-
-		// DELETE /api/v1/workspaces/{id}
-		case method == http.MethodDelete && strings.HasPrefix(path, "/api/v1/workspaces/"):
-			writeAPISuccess(w, ManageWorkspaceResponse{
-				Message: "Workspace deleted",
-			})
-
-		// POST /api/v1/knowledge/search - search_knowledge
-		case method == http.MethodPost && path == "/api/v1/knowledge/search":
-			var reqBody SearchKnowledgeRequest
-			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
-				writeAPIError(w, http.StatusBadRequest, "invalid request body")
-				return
-			}
-			writeAPISuccess(w, SearchKnowledgeResponse{
-				Results: []KnowledgeResult{
-					{ID: "k-1", Title: "Setup Guide", Content: "How to set up...", Score: 0.95},
-				},
-				Total: 1,
-				Query: reqBody.Query,
-			})
-
-		default:
-			writeAPIError(w, http.StatusNotFound, "endpoint not found")
-		}
-	}
-}
-
-// writeAPISuccess는 성공 apiResponse를 JSON으로 작성합니다.
-func writeAPISuccess(w http.ResponseWriter, data interface{}) {
-	dataBytes, _ := json.Marshal(data)
-	resp := apiResponse{
-		Success: true,
-		Data:    json.RawMessage(dataBytes),
-	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
-}
-
-// writeAPIError는 에러 apiResponse를 JSON으로 작성합니다.
-func writeAPIError(w http.ResponseWriter, statusCode int, errMsg string) {
-	resp := apiResponse{
-		Success: false,
-		Error:   apiErrorMessage(errMsg),
-	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(resp)
-}
-
 // =====================================
 // Scenario 4: BackendClient를 통한 도구 호출 + 인증
 // =====================================
@@ -257,10 +100,10 @@ func writeAPIError(w http.ResponseWriter, statusCode int, errMsg string) {
 func TestIntegration_ExecuteTask(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -358,10 +201,10 @@ func TestIntegration_ExecuteTask(t *testing.T) {
 func TestIntegration_ListAgents(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeCallToolRequest("list_agents", map[string]interface{}{
@@ -394,10 +237,10 @@ func TestIntegration_ListAgents(t *testing.T) {
 func TestIntegration_GetExecutionStatus(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -465,10 +308,10 @@ func TestIntegration_GetExecutionStatus(t *testing.T) {
 func TestIntegration_ApproveExecution(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -564,10 +407,10 @@ func TestIntegration_ApproveExecution(t *testing.T) {
 func TestIntegration_ManageWorkspace(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -686,10 +529,10 @@ func TestIntegration_ManageWorkspace(t *testing.T) {
 func TestIntegration_SearchKnowledge(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -791,22 +634,10 @@ func TestIntegration_SearchKnowledge(t *testing.T) {
 func TestIntegration_AuthHeaderVerification(t *testing.T) {
 	t.Parallel()
 
-	var capturedAuthHeader string
-	var capturedContentType string
-
-	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		capturedAuthHeader = r.Header.Get("Authorization")
-		capturedContentType = r.Header.Get("Content-Type")
-
-		agentsData, _ := json.Marshal(ListAgentsResponse{
-			Agents: []AgentInfo{{ID: "a1", Name: "Agent1"}},
-			Total:  1,
-		})
-		writeAPISuccess(w, json.RawMessage(agentsData))
-	}))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeCallToolRequest("list_agents", map[string]interface{}{})
@@ -815,6 +646,13 @@ func TestIntegration_AuthHeaderVerification(t *testing.T) {
 		t.Fatalf("handleListAgents 에러: %v", err)
 	}
 
+	requests := mock.Requests()
+	if len(requests) == 0 {
+		t.Fatal("백엔드가 요청을 받지 못했습니다")
+	}
+	capturedAuthHeader := requests[0].Header.Get("Authorization")
+	capturedContentType := requests[0].Header.Get("Content-Type")
+
 	// 인증 헤더 검증
 	expectedAuth := "Bearer " + testToken
 	if capturedAuthHeader != expectedAuth {
@@ -837,25 +675,10 @@ func TestIntegration_AuthHeaderVerification(t *testing.T) {
 func TestIntegration_RequestParametersPassed(t *testing.T) {
 	t.Parallel()
 
-	var capturedBody ExecuteTaskRequest
-	var capturedPath string
-
-	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		capturedPath = r.URL.Path
-
-		if r.Method == http.MethodPost {
-			json.NewDecoder(r.Body).Decode(&capturedBody)
-		}
-
-		execData, _ := json.Marshal(ExecuteTaskResponse{
-			ExecutionID: "exec-test",
-			Status:      "running",
-		})
-		writeAPISuccess(w, json.RawMessage(execData))
-	}))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeCallToolRequest("execute_task", map[string]interface{}{
@@ -875,6 +698,16 @@ func TestIntegration_RequestParametersPassed(t *testing.T) {
 		t.Fatalf("예상하지 않은 에러: %s", text)
 	}
 
+	requests := mock.RequestsTo(http.MethodPost, "/api/v1/workspaces/ws-99/execute")
+	if len(requests) != 1 {
+		t.Fatalf("execute 요청 수 = %d, want 1 (전체 요청: %+v)", len(requests), mock.Requests())
+	}
+	capturedPath := requests[0].Path
+	var capturedBody ExecuteTaskRequest
+	if err := requests[0].JSON(&capturedBody); err != nil {
+		t.Fatalf("요청 본문 파싱 실패: %v", err)
+	}
+
 	// 요청 경로 검증
 	if capturedPath != "/api/v1/workspaces/ws-99/execute" {
 		t.Errorf("요청 경로 = %q, want %q", capturedPath, "/api/v1/workspaces/ws-99/execute")
@@ -905,10 +738,10 @@ func TestIntegration_RequestParametersPassed(t *testing.T) {
 func TestIntegration_StatusResource(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeReadResourceRequest("autopus://status")
@@ -933,8 +766,8 @@ func TestIntegration_StatusResource(t *testing.T) {
 	if status.Version != ServerVersion {
 		t.Errorf("Version = %q, want %q", status.Version, ServerVersion)
 	}
-	if status.BackendURL != mock.URL {
-		t.Errorf("BackendURL = %q, want %q", status.BackendURL, mock.URL)
+	if status.BackendURL != mock.URL() {
+		t.Errorf("BackendURL = %q, want %q", status.BackendURL, mock.URL())
 	}
 	if status.Message != "Connected to Autopus backend" {
 		t.Errorf("Message = %q, want %q", status.Message, "Connected to Autopus backend")
@@ -944,10 +777,10 @@ func TestIntegration_StatusResource(t *testing.T) {
 func TestIntegration_WorkspacesResource(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeReadResourceRequest("autopus://workspaces")
@@ -977,10 +810,10 @@ func TestIntegration_WorkspacesResource(t *testing.T) {
 func TestIntegration_AgentsResource(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeReadResourceRequest("autopus://agents")
@@ -1010,10 +843,10 @@ func TestIntegration_AgentsResource(t *testing.T) {
 func TestIntegration_ExecutionResource(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	req := makeReadResourceRequest("autopus://executions/exec-abc")
@@ -1044,8 +877,8 @@ func TestIntegration_ExecutionResource(t *testing.T) {
 func TestIntegration_GracefulDegradation_StatusCache(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
-	srv := newTestServer(mock.URL, 10*time.Minute) // 긴 TTL로 캐시 만료 방지
+	mock := testbackend.NewAPI()
+	srv := newTestServer(mock.URL(), 10*time.Minute) // 긴 TTL로 캐시 만료 방지
 	ctx := context.Background()
 
 	// Step 1: 성공적인 리소스 호출 (데이터가 캐시됨)
@@ -1100,8 +933,8 @@ func TestIntegration_GracefulDegradation_StatusCache(t *testing.T) {
 func TestIntegration_GracefulDegradation_WorkspacesCache(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
-	srv := newTestServer(mock.URL, 10*time.Minute)
+	mock := testbackend.NewAPI()
+	srv := newTestServer(mock.URL(), 10*time.Minute)
 	ctx := context.Background()
 
 	// Step 1: 성공적인 호출로 캐시 채우기
@@ -1123,7 +956,8 @@ func TestIntegration_GracefulDegradation_WorkspacesCache(t *testing.T) {
 	// Step 2: 백엔드 종료
 	mock.Close()
 
-	// Step 3: 캐시 폴백 확인
+	// Step 3: refresh로 신선한 캐시를 건너뛰고 백엔드 조회 - 실패 시 캐시 폴백 확인
+	req.Params.Arguments = map[string]any{"refresh": true}
 	contents, err = srv.handleWorkspacesResource(ctx, req)
 	if err != nil {
 		t.Fatalf("캐시 폴백 호출 에러: %v", err)
@@ -1149,8 +983,8 @@ func TestIntegration_GracefulDegradation_WorkspacesCache(t *testing.T) {
 func TestIntegration_GracefulDegradation_AgentsCache(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
-	srv := newTestServer(mock.URL, 10*time.Minute)
+	mock := testbackend.NewAPI()
+	srv := newTestServer(mock.URL(), 10*time.Minute)
 	ctx := context.Background()
 
 	// Step 1: 성공적인 호출
@@ -1163,7 +997,8 @@ func TestIntegration_GracefulDegradation_AgentsCache(t *testing.T) {
 	// Step 2: 백엔드 종료
 	mock.Close()
 
-	// Step 3: 캐시 폴백 확인
+	// Step 3: refresh로 신선한 캐시를 건너뛰고 백엔드 조회 - 실패 시 캐시 폴백 확인
+	req.Params.Arguments = map[string]any{"refresh": true}
 	contents, err := srv.handleAgentsResource(ctx, req)
 	if err != nil {
 		t.Fatalf("캐시 폴백 호출 에러: %v", err)
@@ -1184,8 +1019,8 @@ func TestIntegration_GracefulDegradation_NoCacheAvailable(t *testing.T) {
 	t.Parallel()
 
 	// 이미 종료된 서버 URL 사용 (캐시 없이 바로 실패)
-	mock := newMockBackend(t, standardMockHandler(t))
-	mockURL := mock.URL
+	mock := testbackend.NewAPI()
+	mockURL := mock.URL()
 	mock.Close() // 즉시 종료
 
 	srv := newTestServer(mockURL)
@@ -1215,8 +1050,8 @@ func TestIntegration_GracefulDegradation_NoCacheAvailable(t *testing.T) {
 func TestIntegration_GracefulDegradation_ServerContinuesRunning(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
-	srv := newTestServer(mock.URL, 10*time.Minute)
+	mock := testbackend.NewAPI()
+	srv := newTestServer(mock.URL(), 10*time.Minute)
 	ctx := context.Background()
 
 	// Step 1: 정상 호출로 캐시 채우기
@@ -1261,7 +1096,7 @@ func TestIntegration_AuthFailure_401(t *testing.T) {
 
 	// 401 Unauthorized만 반환하는 mock 서버
 	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		writeAPIError(w, http.StatusUnauthorized, "Invalid or expired token")
+		testbackend.WriteError(w, http.StatusUnauthorized, "Invalid or expired token")
 	}))
 	defer mock.Close()
 
@@ -1359,11 +1194,11 @@ func TestIntegration_AuthFailure_ServerContinuesRunning(t *testing.T) {
 		count := callCount.Add(1)
 		if count <= 2 {
 			// 처음 2번은 401
-			writeAPIError(w, http.StatusUnauthorized, "Invalid token")
+			testbackend.WriteError(w, http.StatusUnauthorized, "Invalid token")
 			return
 		}
 		// 그 후에는 정상 응답
-		writeAPISuccess(w, ListAgentsResponse{
+		testbackend.WriteSuccess(w, ListAgentsResponse{
 			Agents: []AgentInfo{{ID: "a1", Name: "Agent1"}},
 			Total:  1,
 		})
@@ -1410,10 +1245,10 @@ func TestIntegration_AuthFailure_ServerContinuesRunning(t *testing.T) {
 func TestIntegration_ConcurrentToolCalls(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	const numGoroutines = 10
@@ -1531,10 +1366,10 @@ func TestIntegration_ConcurrentToolCalls(t *testing.T) {
 func TestIntegration_ConcurrentResourceAndToolCalls(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
+	mock := testbackend.NewAPI()
 	defer mock.Close()
 
-	srv := newTestServer(mock.URL)
+	srv := newTestServer(mock.URL())
 	ctx := context.Background()
 
 	const numGoroutines = 10
@@ -1618,8 +1453,8 @@ func TestIntegration_ConcurrentResourceAndToolCalls(t *testing.T) {
 func TestIntegration_ConcurrentCacheAccess(t *testing.T) {
 	t.Parallel()
 
-	mock := newMockBackend(t, standardMockHandler(t))
-	srv := newTestServer(mock.URL, 10*time.Minute)
+	mock := testbackend.NewAPI()
+	srv := newTestServer(mock.URL(), 10*time.Minute)
 	ctx := context.Background()
 
 	const numGoroutines = 10
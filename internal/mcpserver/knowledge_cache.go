@@ -0,0 +1,416 @@
+package mcpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+const (
+	// DefaultKnowledgeCacheQueries는 보관할 최근 검색 쿼리 수 기본값입니다.
+	DefaultKnowledgeCacheQueries = 200
+	// DefaultKnowledgeCacheDocuments는 오프라인 검색에 사용할 문서 수 기본값입니다.
+	DefaultKnowledgeCacheDocuments = 2000
+)
+
+// 오프라인 검색 결과가 만들어진 방식 (SearchKnowledgeResponse.OfflineMatch)
+const (
+	// OfflineMatchCachedQuery는 같은 쿼리의 이전 검색 결과를 그대로 반환한 경우입니다.
+	OfflineMatchCachedQuery = "cached_query"
+	// OfflineMatchLexical은 캐시된 문서를 어휘 기반(BM25)으로 순위를 매겨 반환한 경우입니다.
+	OfflineMatchLexical = "lexical"
+)
+
+// BM25 파라미터
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// titleWeight는 제목 토큰의 빈도 가중치입니다.
+	titleWeight = 2
+)
+
+// KnowledgeCache는 search_knowledge의 최근 쿼리 결과와 결과 문서를 로컬에 보관합니다.
+// 백엔드에 연결할 수 없을 때 같은 쿼리의 결과를 반환하거나,
+// 보관된 문서의 단어 빈도 벡터로 근사 결과를 만들어 오프라인 결과로 제공합니다.
+// path가 지정되면 JSON 파일(0600)로 저장하여 MCP 서버 재시작 후에도 유지합니다.
+type KnowledgeCache struct {
+	mu   sync.Mutex
+	path string
+
+	maxQueries   int
+	maxDocuments int
+
+	queries   map[string]*cachedKnowledgeQuery
+	documents map[string]*cachedKnowledgeDocument
+}
+
+// cachedKnowledgeQuery는 검색 쿼리 하나의 결과입니다.
+type cachedKnowledgeQuery struct {
+	WorkspaceID string    `json:"workspace_id"`
+	Query       string    `json:"query"`
+	ResultIDs   []string  `json:"result_ids"`
+	Total       int       `json:"total"`
+	StoredAt    time.Time `json:"stored_at"`
+}
+
+// cachedKnowledgeDocument는 검색 결과로 받은 문서와 미리 계산한 단어 빈도입니다.
+type cachedKnowledgeDocument struct {
+	WorkspaceID string          `json:"workspace_id"`
+	Result      KnowledgeResult `json:"result"`
+	StoredAt    time.Time       `json:"stored_at"`
+
+	terms  map[string]int
+	length int
+}
+
+// knowledgeCacheFile은 디스크 저장 형식입니다.
+type knowledgeCacheFile struct {
+	Queries   []*cachedKnowledgeQuery    `json:"queries"`
+	Documents []*cachedKnowledgeDocument `json:"documents"`
+}
+
+// NewKnowledgeCache는 지식 검색 캐시를 생성합니다.
+// path가 비어 있으면 메모리에만 보관하고, 파일이 있으면 저장된 캐시를 불러옵니다.
+// maxQueries, maxDocuments가 0 이하이면 기본값을 사용합니다.
+func NewKnowledgeCache(path string, maxQueries, maxDocuments int) (*KnowledgeCache, error) {
+	if maxQueries <= 0 {
+		maxQueries = DefaultKnowledgeCacheQueries
+	}
+	if maxDocuments <= 0 {
+		maxDocuments = DefaultKnowledgeCacheDocuments
+	}
+	c := &KnowledgeCache{
+		path:         path,
+		maxQueries:   maxQueries,
+		maxDocuments: maxDocuments,
+		queries:      make(map[string]*cachedKnowledgeQuery),
+		documents:    make(map[string]*cachedKnowledgeDocument),
+	}
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, fmt.Errorf("지식 검색 캐시 읽기 실패: %w", err)
+	}
+	var file knowledgeCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return c, fmt.Errorf("지식 검색 캐시 파싱 실패: %w", err)
+	}
+	for _, q := range file.Queries {
+		c.queries[knowledgeQueryKey(q.WorkspaceID, q.Query)] = q
+	}
+	for _, d := range file.Documents {
+		d.index()
+		c.documents[knowledgeDocumentKey(d.WorkspaceID, d.Result.ID)] = d
+	}
+	c.evictLocked()
+	return c, nil
+}
+
+// knowledgeQueryKey는 워크스페이스와 정규화한 쿼리로 캐시 키를 만듭니다.
+func knowledgeQueryKey(workspaceID, query string) string {
+	return workspaceID + "\x00" + strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// knowledgeDocumentKey는 워크스페이스와 문서 ID로 캐시 키를 만듭니다.
+func knowledgeDocumentKey(workspaceID, id string) string {
+	return workspaceID + "\x00" + id
+}
+
+// Store는 온라인 검색 결과를 저장합니다. ID가 없는 결과는 문서로 보관하지 않습니다.
+// 필터가 있는 검색은 결과 문서만 보관하고 쿼리 결과로는 저장하지 않습니다.
+func (c *KnowledgeCache) Store(req *SearchKnowledgeRequest, resp *SearchKnowledgeResponse) error {
+	if c == nil || req == nil || resp == nil {
+		return nil
+	}
+	now := time.Now()
+
+	c.mu.Lock()
+	ids := make([]string, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		if result.ID == "" {
+			continue
+		}
+		doc := &cachedKnowledgeDocument{WorkspaceID: req.WorkspaceID, Result: result, StoredAt: now}
+		doc.index()
+		c.documents[knowledgeDocumentKey(req.WorkspaceID, result.ID)] = doc
+		ids = append(ids, result.ID)
+	}
+	if len(req.Filters) == 0 {
+		c.queries[knowledgeQueryKey(req.WorkspaceID, req.Query)] = &cachedKnowledgeQuery{
+			WorkspaceID: req.WorkspaceID,
+			Query:       req.Query,
+			ResultIDs:   ids,
+			Total:       resp.Total,
+			StoredAt:    now,
+		}
+	}
+	c.evictLocked()
+	file := c.snapshotLocked()
+	c.mu.Unlock()
+
+	return c.save(file)
+}
+
+// Search는 캐시에서 오프라인 검색 결과를 만듭니다.
+// 같은 쿼리(필터 없음)의 결과가 있으면 그대로, 없으면 같은 워크스페이스 문서를 BM25로 순위를 매겨 반환합니다.
+// 캐시된 문서가 없거나 일치하는 문서가 없으면 false를 반환합니다.
+func (c *KnowledgeCache) Search(req *SearchKnowledgeRequest) (*SearchKnowledgeResponse, bool) {
+	if c == nil || req == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(req.Filters) == 0 {
+		if q, ok := c.queries[knowledgeQueryKey(req.WorkspaceID, req.Query)]; ok {
+			var results []KnowledgeResult
+			for _, id := range q.ResultIDs {
+				if doc, ok := c.documents[knowledgeDocumentKey(req.WorkspaceID, id)]; ok {
+					results = append(results, doc.Result)
+				}
+			}
+			if len(results) > 0 || len(q.ResultIDs) == 0 {
+				if req.Limit > 0 && len(results) > req.Limit {
+					results = results[:req.Limit]
+				}
+				return &SearchKnowledgeResponse{
+					Results:      results,
+					Total:        q.Total,
+					Query:        req.Query,
+					Offline:      true,
+					OfflineMatch: OfflineMatchCachedQuery,
+					CachedAt:     q.StoredAt.Format(time.RFC3339),
+				}, true
+			}
+		}
+	}
+
+	results := c.rankLocked(req.WorkspaceID, req.Query, req.Limit)
+	if len(results) == 0 {
+		return nil, false
+	}
+	return &SearchKnowledgeResponse{
+		Results:      results,
+		Total:        len(results),
+		Query:        req.Query,
+		Offline:      true,
+		OfflineMatch: OfflineMatchLexical,
+	}, true
+}
+
+// rankLocked는 워크스페이스의 캐시 문서를 BM25 점수 순으로 limit개 반환합니다.
+func (c *KnowledgeCache) rankLocked(workspaceID, query string, limit int) []KnowledgeResult {
+	queryTerms := tokenizeKnowledge(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	var docs []*cachedKnowledgeDocument
+	totalLength := 0
+	for _, doc := range c.documents {
+		if doc.WorkspaceID != workspaceID {
+			continue
+		}
+		docs = append(docs, doc)
+		totalLength += doc.length
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	avgLength := float64(totalLength) / float64(len(docs))
+
+	// 쿼리 단어의 문서 빈도 (IDF 계산용)
+	df := make(map[string]int)
+	for term := range uniqueTerms(queryTerms) {
+		for _, doc := range docs {
+			if doc.terms[term] > 0 {
+				df[term]++
+			}
+		}
+	}
+
+	type scored struct {
+		doc   *cachedKnowledgeDocument
+		score float64
+	}
+	var ranked []scored
+	n := float64(len(docs))
+	for _, doc := range docs {
+		score := 0.0
+		for term := range uniqueTerms(queryTerms) {
+			tf := float64(doc.terms[term])
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-float64(df[term])+0.5)/(float64(df[term])+0.5))
+			norm := tf + bm25K1*(1-bm25B+bm25B*float64(doc.length)/avgLength)
+			score += idf * tf * (bm25K1 + 1) / norm
+		}
+		if score > 0 {
+			ranked = append(ranked, scored{doc: doc, score: score})
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].doc.Result.ID < ranked[j].doc.Result.ID
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	results := make([]KnowledgeResult, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.doc.Result
+		results[i].Score = math.Round(r.score*1000) / 1000
+	}
+	return results
+}
+
+// evictLocked는 보관 한도를 넘으면 오래된 쿼리와 문서부터 삭제합니다.
+func (c *KnowledgeCache) evictLocked() {
+	if over := len(c.queries) - c.maxQueries; over > 0 {
+		keys := make([]string, 0, len(c.queries))
+		for key := range c.queries {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return c.queries[keys[i]].StoredAt.Before(c.queries[keys[j]].StoredAt) })
+		for _, key := range keys[:over] {
+			delete(c.queries, key)
+		}
+	}
+	if over := len(c.documents) - c.maxDocuments; over > 0 {
+		keys := make([]string, 0, len(c.documents))
+		for key := range c.documents {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return c.documents[keys[i]].StoredAt.Before(c.documents[keys[j]].StoredAt) })
+		for _, key := range keys[:over] {
+			delete(c.documents, key)
+		}
+	}
+}
+
+// snapshotLocked는 디스크에 저장할 캐시 내용을 복사합니다. 메모리 전용 캐시이면 nil입니다.
+func (c *KnowledgeCache) snapshotLocked() *knowledgeCacheFile {
+	if c.path == "" {
+		return nil
+	}
+	file := &knowledgeCacheFile{
+		Queries:   make([]*cachedKnowledgeQuery, 0, len(c.queries)),
+		Documents: make([]*cachedKnowledgeDocument, 0, len(c.documents)),
+	}
+	for _, q := range c.queries {
+		file.Queries = append(file.Queries, q)
+	}
+	for _, d := range c.documents {
+		file.Documents = append(file.Documents, d)
+	}
+	return file
+}
+
+// save는 캐시를 임시 파일에 쓴 뒤 교체하여 저장합니다.
+func (c *KnowledgeCache) save(file *knowledgeCacheFile) error {
+	if file == nil {
+		return nil
+	}
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("지식 검색 캐시 직렬화 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("지식 검색 캐시 디렉토리 생성 실패: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("지식 검색 캐시 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("지식 검색 캐시 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// index는 제목과 본문의 단어 빈도 벡터를 계산합니다. 제목 단어는 titleWeight배로 셉니다.
+func (d *cachedKnowledgeDocument) index() {
+	d.terms = make(map[string]int)
+	d.length = 0
+	for _, term := range tokenizeKnowledge(d.Result.Title) {
+		d.terms[term] += titleWeight
+		d.length += titleWeight
+	}
+	for _, term := range tokenizeKnowledge(d.Result.Content) {
+		d.terms[term]++
+		d.length++
+	}
+}
+
+// tokenizeKnowledge는 텍스트를 소문자 단어로 나눕니다.
+// 한글 단어는 조사가 붙어도 일치하도록 두 글자 단위(bigram)도 함께 만듭니다.
+func tokenizeKnowledge(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var terms []string
+	for _, word := range words {
+		runes := []rune(word)
+		if len(runes) > 2 && containsHangul(runes) {
+			for i := 0; i+2 <= len(runes); i++ {
+				terms = append(terms, string(runes[i:i+2]))
+			}
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// containsHangul은 한글 음절이 포함되어 있는지 반환합니다.
+func containsHangul(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueTerms는 단어 목록의 중복을 제거한 집합을 반환합니다.
+func uniqueTerms(terms []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(terms))
+	for _, term := range terms {
+		set[term] = struct{}{}
+	}
+	return set
+}
+
+// SetKnowledgeCache는 search_knowledge 결과 캐시를 교체합니다. nil이면 캐시와 오프라인 폴백을 끕니다.
+func (s *Server) SetKnowledgeCache(cache *KnowledgeCache) {
+	s.knowledgeMu.Lock()
+	defer s.knowledgeMu.Unlock()
+	s.knowledge = cache
+}
+
+// knowledgeCache는 현재 지식 검색 캐시를 반환합니다.
+func (s *Server) knowledgeCache() *KnowledgeCache {
+	s.knowledgeMu.RLock()
+	defer s.knowledgeMu.RUnlock()
+	return s.knowledge
+}
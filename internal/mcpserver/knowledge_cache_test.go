@@ -0,0 +1,170 @@
+package mcpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// sampleKnowledgeResults는 테스트용 검색 결과입니다.
+func sampleKnowledgeResults() []KnowledgeResult {
+	return []KnowledgeResult{
+		{ID: "doc-deploy", Title: "배포 가이드", Content: "스테이징 배포와 프로덕션 배포 절차를 설명합니다. rollback 방법 포함.", Score: 0.9},
+		{ID: "doc-oncall", Title: "On-call runbook", Content: "Incident response steps and escalation policy.", Score: 0.7},
+		{ID: "doc-style", Title: "Go style guide", Content: "Naming, error handling and package layout conventions.", Score: 0.5},
+	}
+}
+
+// TestKnowledgeCache_CachedQuery는 같은 쿼리를 오프라인으로 다시 검색하면 이전 결과를 그대로 반환하는지 테스트합니다.
+func TestKnowledgeCache_CachedQuery(t *testing.T) {
+	cache, err := NewKnowledgeCache("", 0, 0)
+	if err != nil {
+		t.Fatalf("NewKnowledgeCache 에러: %v", err)
+	}
+	req := &SearchKnowledgeRequest{Query: "Deploy  Guide", WorkspaceID: "ws-1", Limit: 10}
+	if err := cache.Store(req, &SearchKnowledgeResponse{Results: sampleKnowledgeResults()[:2], Total: 2}); err != nil {
+		t.Fatalf("Store 에러: %v", err)
+	}
+
+	resp, ok := cache.Search(&SearchKnowledgeRequest{Query: "deploy guide", WorkspaceID: "ws-1", Limit: 1})
+	if !ok {
+		t.Fatal("같은 쿼리는 캐시에서 찾아야 합니다")
+	}
+	if !resp.Offline || resp.OfflineMatch != OfflineMatchCachedQuery || resp.CachedAt == "" {
+		t.Errorf("오프라인 표시가 잘못되었습니다: %+v", resp)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ID != "doc-deploy" || resp.Total != 2 {
+		t.Errorf("결과 = %+v, limit 1의 첫 결과여야 합니다", resp)
+	}
+
+	if _, ok := cache.Search(&SearchKnowledgeRequest{Query: "deploy guide", WorkspaceID: "ws-2"}); ok {
+		t.Error("다른 워크스페이스의 캐시를 반환하면 안 됩니다")
+	}
+}
+
+// TestKnowledgeCache_LexicalRanking은 처음 보는 쿼리를 캐시된 문서의 단어 일치로 순위를 매기는지 테스트합니다.
+func TestKnowledgeCache_LexicalRanking(t *testing.T) {
+	cache, _ := NewKnowledgeCache("", 0, 0)
+	_ = cache.Store(&SearchKnowledgeRequest{Query: "docs"}, &SearchKnowledgeResponse{Results: sampleKnowledgeResults(), Total: 3})
+
+	tests := []struct {
+		query  string
+		wantID string
+	}{
+		{"incident escalation", "doc-oncall"},
+		{"배포 롤백", "doc-deploy"},
+		{"프로덕션배포", "doc-deploy"},
+		{"error handling", "doc-style"},
+	}
+	for _, tt := range tests {
+		resp, ok := cache.Search(&SearchKnowledgeRequest{Query: tt.query, Limit: 10})
+		if !ok {
+			t.Errorf("%q: 결과가 없습니다", tt.query)
+			continue
+		}
+		if resp.OfflineMatch != OfflineMatchLexical {
+			t.Errorf("%q: OfflineMatch = %s, want %s", tt.query, resp.OfflineMatch, OfflineMatchLexical)
+		}
+		if resp.Results[0].ID != tt.wantID {
+			t.Errorf("%q: 첫 결과 = %s, want %s", tt.query, resp.Results[0].ID, tt.wantID)
+		}
+	}
+
+	if _, ok := cache.Search(&SearchKnowledgeRequest{Query: "kubernetes"}); ok {
+		t.Error("일치하는 단어가 없으면 결과가 없어야 합니다")
+	}
+}
+
+// TestKnowledgeCache_PersistAndEvict는 디스크 저장/복원과 보관 한도를 테스트합니다.
+func TestKnowledgeCache_PersistAndEvict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "knowledge-cache.json")
+	cache, err := NewKnowledgeCache(path, 1, 2)
+	if err != nil {
+		t.Fatalf("NewKnowledgeCache 에러: %v", err)
+	}
+	results := sampleKnowledgeResults()
+	_ = cache.Store(&SearchKnowledgeRequest{Query: "first"}, &SearchKnowledgeResponse{Results: results[:1]})
+	_ = cache.Store(&SearchKnowledgeRequest{Query: "second"}, &SearchKnowledgeResponse{Results: results[1:]})
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("캐시 파일이 없습니다: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("캐시 파일 권한 = %v, want 0600", info.Mode().Perm())
+	}
+
+	reloaded, err := NewKnowledgeCache(path, 1, 2)
+	if err != nil {
+		t.Fatalf("캐시 복원 에러: %v", err)
+	}
+	if len(reloaded.queries) != 1 || len(reloaded.documents) != 2 {
+		t.Errorf("복원된 캐시 크기 = 쿼리 %d, 문서 %d, want 1, 2", len(reloaded.queries), len(reloaded.documents))
+	}
+	if resp, ok := reloaded.Search(&SearchKnowledgeRequest{Query: "second"}); !ok || resp.OfflineMatch != OfflineMatchCachedQuery {
+		t.Errorf("최근 쿼리는 복원되어야 합니다: %+v", resp)
+	}
+	if resp, ok := reloaded.Search(&SearchKnowledgeRequest{Query: "escalation"}); !ok || resp.Results[0].ID != "doc-oncall" {
+		t.Errorf("복원된 문서로 어휘 검색이 되어야 합니다: %+v", resp)
+	}
+}
+
+// TestHandleSearchKnowledge_OfflineFallback은 백엔드 장애 시 캐시 결과를 오프라인 결과로 반환하는지 테스트합니다.
+func TestHandleSearchKnowledge_OfflineFallback(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := int(status.Load())
+		w.WriteHeader(code)
+		if code != http.StatusOK {
+			json.NewEncoder(w).Encode(apiResponse{Success: false, Error: "unavailable"})
+			return
+		}
+		data, _ := json.Marshal(SearchKnowledgeResponse{Results: sampleKnowledgeResults(), Total: 3, Query: "deploy"})
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: data})
+	}))
+	defer mockServer.Close()
+
+	srv := NewServer(newTestClient(mockServer.URL), zerolog.Nop())
+
+	// 온라인 검색 결과가 캐시에 저장됨
+	if isError, text, _ := callToolViaMessage(t, srv, "search_knowledge", map[string]any{"query": "deploy"}); isError {
+		t.Fatalf("온라인 검색 실패: %s", text)
+	}
+
+	// 백엔드 장애: 캐시된 문서로 근사 결과
+	status.Store(http.StatusServiceUnavailable)
+	isError, text, _ := callToolViaMessage(t, srv, "search_knowledge", map[string]any{"query": "incident escalation"})
+	if isError {
+		t.Fatalf("오프라인 폴백이어야 합니다: %s", text)
+	}
+	var resp SearchKnowledgeResponse
+	if err := json.Unmarshal([]byte(text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v (%s)", err, text)
+	}
+	if !resp.Offline || resp.OfflineMatch != OfflineMatchLexical || resp.Message == "" {
+		t.Errorf("오프라인 결과로 표시되어야 합니다: %+v", resp)
+	}
+	if len(resp.Results) == 0 || resp.Results[0].ID != "doc-oncall" {
+		t.Errorf("결과 = %+v", resp.Results)
+	}
+
+	// 4xx 같은 요청 오류는 폴백하지 않음
+	status.Store(http.StatusBadRequest)
+	if isError, _, _ := callToolViaMessage(t, srv, "search_knowledge", map[string]any{"query": "deploy"}); !isError {
+		t.Error("요청 오류는 에러로 반환해야 합니다")
+	}
+
+	// 캐시를 끄면 백엔드 장애도 에러
+	srv.SetKnowledgeCache(nil)
+	status.Store(http.StatusServiceUnavailable)
+	if isError, _, _ := callToolViaMessage(t, srv, "search_knowledge", map[string]any{"query": "deploy"}); !isError {
+		t.Error("캐시가 없으면 에러로 반환해야 합니다")
+	}
+}
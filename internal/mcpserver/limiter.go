@@ -0,0 +1,292 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrCodeToolBusy는 동시 실행 한도로 대기하다 시간이 초과된 도구 호출의 에러 코드입니다.
+const ErrCodeToolBusy = errcode.ToolBusy
+
+// ErrQueueTimeout은 도구 호출이 실행 슬롯을 기다리다 QueueTimeout을 넘겼음을 나타냅니다.
+var ErrQueueTimeout = errors.New("도구 호출 대기 시간 초과")
+
+// ConcurrencyConfig는 MCP 도구 호출 동시 실행 한도입니다.
+// 클라이언트가 한꺼번에 많은 도구를 호출해도 백엔드로 나가는 요청 수를 제한하여
+// 백엔드 요청 한도(429)로 인한 연쇄 실패를 막습니다.
+type ConcurrencyConfig struct {
+	// MaxConcurrent는 모든 도구를 합친 최대 동시 실행 수입니다 (0 이하이면 제한 없음).
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+	// PerTool은 도구 하나의 기본 최대 동시 실행 수입니다 (0 이하이면 MaxConcurrent까지).
+	PerTool int `mapstructure:"per_tool"`
+	// Tools는 도구별 최대 동시 실행 수입니다. PerTool보다 우선합니다.
+	Tools map[string]int `mapstructure:"tools"`
+	// QueueTimeout은 실행 슬롯을 기다리는 최대 시간입니다 (0 이하이면 요청 컨텍스트가 끝날 때까지).
+	QueueTimeout time.Duration `mapstructure:"queue_timeout"`
+}
+
+// DefaultConcurrencyConfig는 기본 동시 실행 한도입니다 (전체 8, 도구당 4, 대기 30초).
+func DefaultConcurrencyConfig() ConcurrencyConfig {
+	return ConcurrencyConfig{
+		MaxConcurrent: 8,
+		PerTool:       4,
+		QueueTimeout:  30 * time.Second,
+	}
+}
+
+// ConcurrencySnapshot은 동시 실행 한도 상태 스냅샷입니다 (autopus://stats의 concurrency).
+type ConcurrencySnapshot struct {
+	Active   int                          `json:"active"`
+	Queued   int                          `json:"queued"`
+	Timeouts int64                        `json:"timeouts"`
+	Tools    map[string]ToolQueueSnapshot `json:"tools,omitempty"`
+}
+
+// ToolQueueSnapshot은 도구별 실행/대기 수입니다.
+type ToolQueueSnapshot struct {
+	Active int `json:"active"`
+	Queued int `json:"queued"`
+}
+
+// toolWaiter는 실행 슬롯을 기다리는 도구 호출입니다. 슬롯을 받으면 ready가 닫힙니다.
+type toolWaiter struct {
+	ready chan struct{}
+}
+
+// concurrencyLimiter는 전체/도구별 동시 실행 수를 제한합니다.
+// 전체 슬롯이 비면 대기 중인 도구를 돌아가며 하나씩 깨워,
+// 한 도구의 대량 호출이 다른 도구 호출을 굶기지 않도록 합니다.
+type concurrencyLimiter struct {
+	cfg ConcurrencyConfig
+
+	mu       sync.Mutex
+	active   int
+	perTool  map[string]int
+	queues   map[string][]*toolWaiter
+	lastTool string
+	timeouts int64
+}
+
+// newConcurrencyLimiter는 cfg로 동시 실행 제한기를 생성합니다.
+func newConcurrencyLimiter(cfg ConcurrencyConfig) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		cfg:     cfg,
+		perTool: make(map[string]int),
+		queues:  make(map[string][]*toolWaiter),
+	}
+}
+
+// toolLimit은 도구의 최대 동시 실행 수입니다 (0이면 제한 없음).
+func (l *concurrencyLimiter) toolLimit(tool string) int {
+	if n, ok := l.cfg.Tools[tool]; ok && n > 0 {
+		return n
+	}
+	if l.cfg.PerTool > 0 {
+		return l.cfg.PerTool
+	}
+	return 0
+}
+
+// canRunLocked는 도구 호출 하나를 지금 실행할 수 있는지 확인합니다. 호출자가 mu를 잡고 있어야 합니다.
+func (l *concurrencyLimiter) canRunLocked(tool string) bool {
+	if l.cfg.MaxConcurrent > 0 && l.active >= l.cfg.MaxConcurrent {
+		return false
+	}
+	limit := l.toolLimit(tool)
+	return limit <= 0 || l.perTool[tool] < limit
+}
+
+// grantLocked는 도구 실행 슬롯을 점유합니다. 호출자가 mu를 잡고 있어야 합니다.
+func (l *concurrencyLimiter) grantLocked(tool string) {
+	l.active++
+	l.perTool[tool]++
+	l.lastTool = tool
+}
+
+// Acquire는 tool의 실행 슬롯을 얻을 때까지 기다리고, 슬롯을 반납하는 함수를 반환합니다.
+// 대기열이 비어 있고 한도에 여유가 있으면 바로 반환합니다.
+// QueueTimeout을 넘기면 ErrQueueTimeout을, ctx가 끝나면 ctx.Err()를 반환합니다.
+func (l *concurrencyLimiter) Acquire(ctx context.Context, tool string) (func(), error) {
+	l.mu.Lock()
+	if len(l.queues[tool]) == 0 && l.canRunLocked(tool) {
+		l.grantLocked(tool)
+		l.mu.Unlock()
+		return l.releaseFunc(tool), nil
+	}
+	w := &toolWaiter{ready: make(chan struct{})}
+	l.queues[tool] = append(l.queues[tool], w)
+	l.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if l.cfg.QueueTimeout > 0 {
+		timer := time.NewTimer(l.cfg.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-w.ready:
+		return l.releaseFunc(tool), nil
+	case <-ctx.Done():
+		return nil, l.abandon(tool, w, ctx.Err())
+	case <-timeout:
+		return nil, l.abandon(tool, w, ErrQueueTimeout)
+	}
+}
+
+// abandon은 대기를 포기한 호출을 대기열에서 뺍니다.
+// 그사이 슬롯을 이미 받았다면 반납해 다음 대기자에게 넘깁니다.
+func (l *concurrencyLimiter) abandon(tool string, w *toolWaiter, err error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		l.releaseLocked(tool)
+	default:
+		queue := l.queues[tool]
+		for i, q := range queue {
+			if q == w {
+				l.queues[tool] = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+		if len(l.queues[tool]) == 0 {
+			delete(l.queues, tool)
+		}
+	}
+	if errors.Is(err, ErrQueueTimeout) {
+		l.timeouts++
+	}
+	return err
+}
+
+// releaseFunc는 한 번만 슬롯을 반납하는 함수를 반환합니다.
+func (l *concurrencyLimiter) releaseFunc(tool string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.releaseLocked(tool)
+		})
+	}
+}
+
+// releaseLocked는 슬롯을 반납하고 대기자를 깨웁니다. 호출자가 mu를 잡고 있어야 합니다.
+func (l *concurrencyLimiter) releaseLocked(tool string) {
+	l.active--
+	l.perTool[tool]--
+	if l.perTool[tool] <= 0 {
+		delete(l.perTool, tool)
+	}
+	l.dispatchLocked()
+}
+
+// dispatchLocked는 실행할 수 있는 대기자를 도구 이름 순으로 돌아가며 깨웁니다.
+// 마지막으로 슬롯을 받은 도구의 다음 도구부터 살펴 도구 간 공평하게 나눕니다.
+func (l *concurrencyLimiter) dispatchLocked() {
+	for {
+		tools := make([]string, 0, len(l.queues))
+		for tool := range l.queues {
+			tools = append(tools, tool)
+		}
+		if len(tools) == 0 {
+			return
+		}
+		sort.Strings(tools)
+		start := sort.SearchStrings(tools, l.lastTool)
+		if start < len(tools) && tools[start] == l.lastTool {
+			start++
+		}
+
+		granted := false
+		for i := range tools {
+			tool := tools[(start+i)%len(tools)]
+			if !l.canRunLocked(tool) {
+				continue
+			}
+			queue := l.queues[tool]
+			w := queue[0]
+			if len(queue) == 1 {
+				delete(l.queues, tool)
+			} else {
+				l.queues[tool] = queue[1:]
+			}
+			l.grantLocked(tool)
+			close(w.ready)
+			granted = true
+			break
+		}
+		if !granted {
+			return
+		}
+	}
+}
+
+// Snapshot은 현재 실행/대기 상태를 반환합니다.
+func (l *concurrencyLimiter) Snapshot() ConcurrencySnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	snap := ConcurrencySnapshot{
+		Active:   l.active,
+		Timeouts: l.timeouts,
+		Tools:    make(map[string]ToolQueueSnapshot),
+	}
+	for tool, n := range l.perTool {
+		s := snap.Tools[tool]
+		s.Active = n
+		snap.Tools[tool] = s
+	}
+	for tool, queue := range l.queues {
+		s := snap.Tools[tool]
+		s.Queued = len(queue)
+		snap.Tools[tool] = s
+		snap.Queued += len(queue)
+	}
+	return snap
+}
+
+// SetConcurrencyLimits는 도구 호출 동시 실행 한도를 설정합니다.
+// MaxConcurrent와 PerTool, Tools가 모두 0 이하이면 제한을 끕니다.
+// 이미 실행 중이거나 대기 중인 호출은 이전 한도로 끝까지 처리됩니다.
+func (s *Server) SetConcurrencyLimits(cfg ConcurrencyConfig) {
+	var limiter *concurrencyLimiter
+	if cfg.MaxConcurrent > 0 || cfg.PerTool > 0 || len(cfg.Tools) > 0 {
+		limiter = newConcurrencyLimiter(cfg)
+	}
+	s.limiter.Store(limiter)
+}
+
+// limitedToolHandler는 동시 실행 한도 안에서 도구 핸들러를 실행합니다.
+// 대기 시간이 초과되면 TOOL_BUSY 에러 결과를 반환합니다.
+func (s *Server) limitedToolHandler(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		limiter := s.limiter.Load()
+		if limiter == nil {
+			return handler(ctx, req)
+		}
+		release, err := limiter.Acquire(ctx, name)
+		if err != nil {
+			if !errors.Is(err, ErrQueueTimeout) {
+				return nil, err
+			}
+			s.logger.Warn().
+				Str("tool", name).
+				Dur("queue_timeout", limiter.cfg.QueueTimeout).
+				Msg("MCP 도구 호출 대기 시간 초과")
+			return mcp.NewToolResultError(errcode.Format(ErrCodeToolBusy, i18n.T("mcp.tool.queue_timeout", name, limiter.cfg.QueueTimeout))), nil
+		}
+		defer release()
+		return handler(ctx, req)
+	}
+}
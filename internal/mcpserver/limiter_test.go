@@ -0,0 +1,163 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// waitLimiter는 제한기 상태가 cond를 만족할 때까지 기다립니다.
+func waitLimiter(t *testing.T, l *concurrencyLimiter, cond func(ConcurrencySnapshot) bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond(l.Snapshot()) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("제한기 상태가 기대와 다릅니다: %+v", l.Snapshot())
+}
+
+// acquireAsync는 tool 슬롯을 비동기로 요청하고, 슬롯을 받으면 tool 이름을 got으로 보냅니다.
+func acquireAsync(l *concurrencyLimiter, tool string, got chan<- string, releases chan<- func()) {
+	go func() {
+		release, err := l.Acquire(context.Background(), tool)
+		if err != nil {
+			got <- "error:" + err.Error()
+			return
+		}
+		releases <- release
+		got <- tool
+	}()
+}
+
+// TestConcurrencyLimiter_GlobalAndPerToolLimits는 전체/도구별 한도를 넘는 호출이 대기하는지 테스트합니다.
+func TestConcurrencyLimiter_GlobalAndPerToolLimits(t *testing.T) {
+	l := newConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 3, PerTool: 2})
+
+	relA1, _ := l.Acquire(context.Background(), "list_agents")
+	relA2, _ := l.Acquire(context.Background(), "list_agents")
+
+	got := make(chan string, 4)
+	releases := make(chan func(), 4)
+	acquireAsync(l, "list_agents", got, releases)
+	waitLimiter(t, l, func(s ConcurrencySnapshot) bool { return s.Queued == 1 })
+
+	// 도구당 한도는 찼지만 전체 한도에 여유가 있으므로 다른 도구는 바로 실행된다
+	relB, err := l.Acquire(context.Background(), "search_knowledge")
+	if err != nil {
+		t.Fatalf("다른 도구 Acquire 실패: %v", err)
+	}
+	if snap := l.Snapshot(); snap.Active != 3 || snap.Tools["list_agents"].Active != 2 {
+		t.Fatalf("snapshot = %+v, want active 3 (list_agents 2)", snap)
+	}
+
+	relA1()
+	relA1() // 두 번 반납해도 한 번만 반영된다
+	if tool := <-got; tool != "list_agents" {
+		t.Fatalf("대기자 = %q, want list_agents", tool)
+	}
+	if snap := l.Snapshot(); snap.Active != 3 || snap.Queued != 0 {
+		t.Errorf("snapshot = %+v, want active 3, queued 0", snap)
+	}
+
+	relA2()
+	relB()
+	(<-releases)()
+	if snap := l.Snapshot(); snap.Active != 0 || len(snap.Tools) != 0 {
+		t.Errorf("모두 반납한 뒤 snapshot = %+v", snap)
+	}
+}
+
+// TestConcurrencyLimiter_Fairness는 전체 슬롯이 비면 대기 중인 도구를 돌아가며 깨우는지 테스트합니다.
+func TestConcurrencyLimiter_Fairness(t *testing.T) {
+	l := newConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1})
+	release, _ := l.Acquire(context.Background(), "execute_task")
+
+	got := make(chan string, 4)
+	releases := make(chan func(), 4)
+	for i := 0; i < 3; i++ {
+		acquireAsync(l, "execute_task", got, releases)
+	}
+	waitLimiter(t, l, func(s ConcurrencySnapshot) bool { return s.Queued == 3 })
+	acquireAsync(l, "get_execution_status", got, releases)
+	waitLimiter(t, l, func(s ConcurrencySnapshot) bool { return s.Queued == 4 })
+
+	release()
+	var order []string
+	for i := 0; i < 4; i++ {
+		order = append(order, <-got)
+		(<-releases)()
+	}
+	// execute_task가 먼저 대기했어도 get_execution_status가 execute_task 한 번 뒤에 실행된다
+	want := []string{"get_execution_status", "execute_task", "execute_task", "execute_task"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("실행 순서 = %v, want %v", order, want)
+	}
+}
+
+// TestConcurrencyLimiter_QueueTimeout은 대기 시간이 초과되면 대기열에서 빠지고 ErrQueueTimeout을 반환하는지 테스트합니다.
+func TestConcurrencyLimiter_QueueTimeout(t *testing.T) {
+	l := newConcurrencyLimiter(ConcurrencyConfig{MaxConcurrent: 1, QueueTimeout: 20 * time.Millisecond})
+	release, _ := l.Acquire(context.Background(), "list_agents")
+	defer release()
+
+	if _, err := l.Acquire(context.Background(), "list_agents"); !errors.Is(err, ErrQueueTimeout) {
+		t.Fatalf("err = %v, want ErrQueueTimeout", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Acquire(ctx, "list_agents"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if snap := l.Snapshot(); snap.Queued != 0 || snap.Timeouts != 1 {
+		t.Errorf("snapshot = %+v, want queued 0, timeouts 1", snap)
+	}
+}
+
+// TestServer_ConcurrencyLimitToolBusy는 한도를 넘은 도구 호출이 대기 시간 초과 시 TOOL_BUSY를 반환하는지 테스트합니다.
+func TestServer_ConcurrencyLimitToolBusy(t *testing.T) {
+	unblock := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{"execution_id":"exec-1","status":"running"}`)})
+	}))
+	defer backend.Close()
+	defer close(unblock)
+
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop())
+	srv.SetConcurrencyLimits(ConcurrencyConfig{MaxConcurrent: 1, QueueTimeout: 20 * time.Millisecond})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		callToolViaMessage(t, srv, "get_execution_status", map[string]any{"execution_id": "exec-1"})
+	}()
+	waitLimiter(t, srv.limiter.Load(), func(s ConcurrencySnapshot) bool { return s.Active == 1 })
+
+	isError, text, _ := callToolViaMessage(t, srv, "get_execution_status", map[string]any{"execution_id": "exec-2"})
+	if !isError || !strings.HasPrefix(text, ErrCodeToolBusy) {
+		t.Errorf("한도 초과 호출 = (%v, %q), want TOOL_BUSY 에러", isError, text)
+	}
+
+	snap := readStats(t, srv)
+	if snap.Concurrency == nil || snap.Concurrency.Active != 1 || snap.Concurrency.Timeouts != 1 {
+		t.Errorf("stats concurrency = %+v", snap.Concurrency)
+	}
+
+	unblock <- struct{}{}
+	<-done
+
+	srv.SetConcurrencyLimits(ConcurrencyConfig{})
+	if snap := readStats(t, srv); snap.Concurrency != nil {
+		t.Errorf("제한 해제 후 concurrency는 생략되어야 합니다: %+v", snap.Concurrency)
+	}
+}
@@ -0,0 +1,14 @@
+package mcpserver
+
+import (
+	"os"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+)
+
+// TestMain은 환경의 LANG과 관계없이 에러 메시지를 영어로 고정하고 테스트를 실행합니다.
+func TestMain(m *testing.M) {
+	i18n.SetLang(i18n.English)
+	os.Exit(m.Run())
+}
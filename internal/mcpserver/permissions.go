@@ -0,0 +1,199 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrCodePermissionDenied는 권한 설정으로 거부된 도구 호출의 에러 코드입니다.
+const ErrCodePermissionDenied = errcode.PermissionDenied
+
+// ToolPermission은 MCP 도구 하나의 사용 권한입니다 (mcpserver.tools.<도구 이름>).
+type ToolPermission struct {
+	// Disabled가 true이면 도구를 등록하지 않습니다.
+	Disabled bool `mapstructure:"disabled" yaml:"disabled" json:"disabled,omitempty"`
+	// ReadOnly가 true이면 상태를 변경하는 호출(action)을 거부합니다.
+	ReadOnly bool `mapstructure:"read_only" yaml:"read_only" json:"read_only,omitempty"`
+	// DisabledActions는 거부할 action 값 목록입니다 (예: manage_workspace의 "delete").
+	DisabledActions []string `mapstructure:"disabled_actions" yaml:"disabled_actions" json:"disabled_actions,omitempty"`
+}
+
+// ToolPermissions는 도구 이름별 사용 권한입니다.
+type ToolPermissions map[string]ToolPermission
+
+// knownToolSpecs는 권한을 설정할 수 있는 모든 도구 선언입니다.
+func knownToolSpecs() []ToolSpec {
+	return []ToolSpec{
+		executeTaskSpec,
+		listAgentsSpec,
+		getExecutionStatusSpec,
+		approveExecutionSpec,
+		manageWorkspaceSpec,
+		searchKnowledgeSpec,
+		getAgentDetailsSpec,
+		getKnowledgeDocumentSpec,
+		listKnowledgeSourcesSpec,
+		createMessageSpec,
+		setActiveWorkspaceSpec,
+		manageAgentSpec,
+	}
+}
+
+// ReadOnlyAll은 p를 복사해 알려진 모든 도구에 ReadOnly를 적용한 권한을 반환합니다.
+// Bridge 읽기 전용 모드에서 조회 도구만 허용할 때 사용합니다. 기존 Disabled/DisabledActions 설정은 유지합니다.
+func (p ToolPermissions) ReadOnlyAll() ToolPermissions {
+	perms := make(ToolPermissions, len(p))
+	for name, perm := range p {
+		perms[name] = perm
+	}
+	for _, spec := range knownToolSpecs() {
+		perm := perms[spec.Name]
+		perm.ReadOnly = true
+		perms[spec.Name] = perm
+	}
+	return perms
+}
+
+// Validate는 알 수 없는 도구 이름이나 action 값이 있는지 확인합니다.
+// 오타로 인해 의도한 제한이 적용되지 않는 일을 막기 위해 사용합니다.
+func (p ToolPermissions) Validate() error {
+	specs := make(map[string]ToolSpec)
+	for _, spec := range knownToolSpecs() {
+		specs[spec.Name] = spec
+	}
+
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec, ok := specs[name]
+		if !ok {
+			return fmt.Errorf("unknown MCP tool in permissions: %s", name)
+		}
+		actions := spec.actions()
+		for _, action := range p[name].DisabledActions {
+			if !slices.Contains(actions, action) {
+				return fmt.Errorf("unknown action %q for MCP tool %s (allowed: %s)", action, name, strings.Join(actions, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// actions는 도구의 action 파라미터 허용 값 목록을 반환합니다. action 파라미터가 없으면 nil입니다.
+func (t ToolSpec) actions() []string {
+	for _, p := range t.Params {
+		if p.Name == "action" {
+			return p.Enum
+		}
+	}
+	return nil
+}
+
+// SetToolPermissions는 도구 사용 권한을 적용합니다.
+// 비활성화된 도구는 등록을 해제하고, 이후 등록되는 도구(create_message 등)에도 같은 권한을 적용합니다.
+func (s *Server) SetToolPermissions(perms ToolPermissions) error {
+	if err := perms.Validate(); err != nil {
+		return err
+	}
+
+	s.permMu.Lock()
+	s.permissions = perms
+	s.permMu.Unlock()
+
+	var disabled []string
+	for name, perm := range perms {
+		if perm.Disabled {
+			disabled = append(disabled, name)
+		}
+	}
+	if len(disabled) > 0 {
+		sort.Strings(disabled)
+		s.mcpServer.DeleteTools(disabled...)
+		s.logger.Info().Strs("tools", disabled).Msg("설정에서 비활성화된 MCP 도구 등록 해제")
+	}
+	return nil
+}
+
+// toolPermission은 도구의 사용 권한을 반환합니다. 설정이 없으면 모두 허용입니다.
+func (s *Server) toolPermission(name string) ToolPermission {
+	s.permMu.RLock()
+	defer s.permMu.RUnlock()
+	return s.permissions[name]
+}
+
+// permittedToolHandler는 호출 시점의 권한 설정을 확인하고, 거부되면 PERMISSION_DENIED 에러 결과를 반환합니다.
+func (s *Server) permittedToolHandler(spec ToolSpec, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if reason := s.deniedReason(spec, req.GetString("action", "")); reason != nil {
+			s.logger.Warn().Str("tool", spec.Name).Str("reason", reason.Message).Msg("MCP 도구 호출 거부")
+			return reason.ToolResult(), nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PermissionError는 권한 설정으로 거부된 도구 호출입니다.
+// ValidationError와 같이 영어, 한국어 메시지를 함께 보관하고 Error()는 현재 언어로 반환합니다.
+type PermissionError struct {
+	Tool      string
+	Action    string
+	Message   string
+	MessageKo string
+
+	key  string
+	args []any
+}
+
+// newPermissionError는 i18n 카탈로그 메시지로 권한 에러를 생성합니다.
+func newPermissionError(tool, action, key string, args ...any) *PermissionError {
+	return &PermissionError{
+		Tool:      tool,
+		Action:    action,
+		Message:   i18n.Tl(i18n.English, key, args...),
+		MessageKo: i18n.Tl(i18n.Korean, key, args...),
+		key:       key,
+		args:      args,
+	}
+}
+
+// Error는 "PERMISSION_DENIED: 메시지" 형식의 현재 언어 에러 문자열을 반환합니다.
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrCodePermissionDenied, i18n.T(e.key, e.args...))
+}
+
+// ToolResult는 권한 에러를 해결 방법이 포함된 MCP 에러 결과로 변환합니다.
+func (e *PermissionError) ToolResult() *mcp.CallToolResult {
+	return mcp.NewToolResultError(errcode.Format(ErrCodePermissionDenied, i18n.T(e.key, e.args...)))
+}
+
+// deniedReason은 도구 호출이 권한 설정으로 거부되면 사유를, 허용되면 nil을 반환합니다.
+func (s *Server) deniedReason(spec ToolSpec, action string) *PermissionError {
+	perm := s.toolPermission(spec.Name)
+
+	if perm.Disabled {
+		return newPermissionError(spec.Name, "", "mcp.permission.tool_disabled", spec.Name)
+	}
+	if action != "" && slices.Contains(perm.DisabledActions, action) {
+		return newPermissionError(spec.Name, action, "mcp.permission.action_disabled", spec.Name, action)
+	}
+	if perm.ReadOnly && !spec.ReadOnly && !slices.Contains(spec.ReadOnlyActions, action) {
+		target := spec.Name
+		if action != "" {
+			target = fmt.Sprintf("%s (action '%s')", spec.Name, action)
+		}
+		return newPermissionError(spec.Name, action, "mcp.permission.read_only", spec.Name, target)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// callToolViaMessage는 tools/call JSON-RPC 메시지로 도구를 호출하고 결과를 반환합니다.
+func callToolViaMessage(t *testing.T, srv *Server, name string, args map[string]any) (isError bool, text string, rpcErr string) {
+	t.Helper()
+	params, _ := json.Marshal(map[string]any{"name": name, "arguments": args})
+	msg := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":%s}`, params)
+	resp, err := srv.HandleMessage(context.Background(), json.RawMessage(msg))
+	if err != nil {
+		t.Fatalf("HandleMessage 에러: %v", err)
+	}
+	var decoded struct {
+		Result struct {
+			IsError bool `json:"isError"`
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(resp, &decoded); err != nil {
+		t.Fatalf("응답 파싱 실패: %v (%s)", err, resp)
+	}
+	if decoded.Error != nil {
+		return false, "", decoded.Error.Message
+	}
+	if len(decoded.Result.Content) > 0 {
+		text = decoded.Result.Content[0].Text
+	}
+	return decoded.Result.IsError, text, ""
+}
+
+// listToolNames는 tools/list 응답의 도구 이름 목록을 반환합니다.
+func listToolNames(t *testing.T, srv *Server) map[string]bool {
+	t.Helper()
+	resp, err := srv.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	if err != nil {
+		t.Fatalf("HandleMessage 에러: %v", err)
+	}
+	var decoded struct {
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &decoded); err != nil {
+		t.Fatalf("응답 파싱 실패: %v (%s)", err, resp)
+	}
+	names := make(map[string]bool)
+	for _, tool := range decoded.Result.Tools {
+		names[tool.Name] = true
+	}
+	return names
+}
+
+// TestSetToolPermissions_DisabledToolNotRegistered는 비활성화된 도구가 목록에서 제외되는지 테스트합니다.
+func TestSetToolPermissions_DisabledToolNotRegistered(t *testing.T) {
+	srv := NewServer(newTestClient("http://localhost:1"), zerolog.Nop())
+	if err := srv.SetToolPermissions(ToolPermissions{
+		"approve_execution": {Disabled: true},
+		"create_message":    {Disabled: true},
+	}); err != nil {
+		t.Fatalf("SetToolPermissions 에러: %v", err)
+	}
+
+	// 권한 적용 이후 등록되는 도구에도 적용되어야 합니다.
+	srv.EnableLocalSampling(NewSamplingHandler(nil, zerolog.Nop()))
+
+	names := listToolNames(t, srv)
+	if names["approve_execution"] || names["create_message"] {
+		t.Errorf("비활성화된 도구가 등록되어 있습니다: %v", names)
+	}
+	if !names["execute_task"] {
+		t.Error("설정되지 않은 도구는 등록되어 있어야 합니다")
+	}
+}
+
+// TestSetToolPermissions_DisabledActionDenied는 비활성화된 action 호출이 PERMISSION_DENIED로 거부되는지 테스트합니다.
+func TestSetToolPermissions_DisabledActionDenied(t *testing.T) {
+	var deleteCalled bool
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleteCalled = true
+		}
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{}`)})
+	}))
+	defer mockServer.Close()
+
+	srv := NewServer(newTestClient(mockServer.URL), zerolog.Nop())
+	if err := srv.SetToolPermissions(ToolPermissions{
+		"manage_workspace": {DisabledActions: []string{"delete"}},
+	}); err != nil {
+		t.Fatalf("SetToolPermissions 에러: %v", err)
+	}
+
+	isError, text, _ := callToolViaMessage(t, srv, "manage_workspace", map[string]any{"action": "delete", "workspace_id": "ws-1"})
+	if !isError || !strings.HasPrefix(text, ErrCodePermissionDenied) {
+		t.Errorf("delete는 PERMISSION_DENIED여야 합니다: isError=%v text=%q", isError, text)
+	}
+	if deleteCalled {
+		t.Error("거부된 호출이 백엔드에 전달되었습니다")
+	}
+
+	if isError, text, _ := callToolViaMessage(t, srv, "manage_workspace", map[string]any{"action": "list"}); isError {
+		t.Errorf("list는 허용되어야 합니다: %q", text)
+	}
+}
+
+// TestSetToolPermissions_ReadOnly는 읽기 전용 도구에서 상태 변경 호출만 거부되는지 테스트합니다.
+func TestSetToolPermissions_ReadOnly(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`[]`)})
+	}))
+	defer mockServer.Close()
+
+	srv := NewServer(newTestClient(mockServer.URL), zerolog.Nop())
+	if err := srv.SetToolPermissions(ToolPermissions{
+		"manage_workspace": {ReadOnly: true},
+		"execute_task":     {ReadOnly: true},
+		"list_agents":      {ReadOnly: true},
+	}); err != nil {
+		t.Fatalf("SetToolPermissions 에러: %v", err)
+	}
+
+	tests := []struct {
+		tool   string
+		args   map[string]any
+		denied bool
+	}{
+		{"manage_workspace", map[string]any{"action": "get", "workspace_id": "ws-1"}, false},
+		{"manage_workspace", map[string]any{"action": "create"}, true},
+		{"execute_task", map[string]any{"agent_id": "a-1", "prompt": "hi"}, true},
+		{"list_agents", map[string]any{}, false},
+	}
+	for _, tt := range tests {
+		isError, text, _ := callToolViaMessage(t, srv, tt.tool, tt.args)
+		denied := isError && strings.HasPrefix(text, ErrCodePermissionDenied)
+		if denied != tt.denied {
+			t.Errorf("%s %v: denied = %v, want %v (text=%q)", tt.tool, tt.args, denied, tt.denied, text)
+		}
+	}
+}
+
+// TestToolPermissions_ReadOnlyAll은 모든 도구에 읽기 전용을 적용하고 기존 설정은 유지하는지 테스트합니다.
+func TestToolPermissions_ReadOnlyAll(t *testing.T) {
+	orig := ToolPermissions{"manage_workspace": {DisabledActions: []string{"delete"}}}
+	perms := orig.ReadOnlyAll()
+
+	for _, spec := range knownToolSpecs() {
+		if !perms[spec.Name].ReadOnly {
+			t.Errorf("%s: ReadOnly = false, want true", spec.Name)
+		}
+	}
+	if got := perms["manage_workspace"].DisabledActions; len(got) != 1 || got[0] != "delete" {
+		t.Errorf("DisabledActions = %v, want [delete]", got)
+	}
+	if orig["manage_workspace"].ReadOnly {
+		t.Error("원본 권한이 변경되면 안 됩니다")
+	}
+	if err := perms.Validate(); err != nil {
+		t.Errorf("Validate 에러: %v", err)
+	}
+}
+
+// TestToolPermissions_Validate는 알 수 없는 도구/action 설정을 거부하는지 테스트합니다.
+func TestToolPermissions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		perms   ToolPermissions
+		wantErr bool
+	}{
+		{"빈 설정", nil, false},
+		{"정상 설정", ToolPermissions{"manage_workspace": {DisabledActions: []string{"delete", "update"}}}, false},
+		{"알 수 없는 도구", ToolPermissions{"manage_workspaces": {Disabled: true}}, true},
+		{"알 수 없는 action", ToolPermissions{"manage_workspace": {DisabledActions: []string{"remove"}}}, true},
+		{"action 없는 도구", ToolPermissions{"execute_task": {DisabledActions: []string{"delete"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.perms.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	srv := NewServer(newTestClient("http://localhost:1"), zerolog.Nop())
+	if err := srv.SetToolPermissions(ToolPermissions{"unknown_tool": {Disabled: true}}); err == nil {
+		t.Error("알 수 없는 도구 설정은 에러여야 합니다")
+	}
+}
@@ -0,0 +1,196 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+const (
+	// DefaultProjectContextMaxFiles는 로컬 컨텍스트에 나열할 변경 파일의 기본 최대 개수입니다.
+	DefaultProjectContextMaxFiles = 20
+	// DefaultProjectContextMaxCommits는 로컬 컨텍스트에 나열할 최근 커밋의 기본 개수입니다.
+	DefaultProjectContextMaxCommits = 5
+	// projectContextTimeout은 로컬 컨텍스트 수집(git 명령 전체)의 최대 시간입니다.
+	projectContextTimeout = 3 * time.Second
+)
+
+// ProjectContextConfig는 execute_task 프롬프트에 덧붙이는 로컬 프로젝트 컨텍스트 설정입니다.
+// mcp_server.project_context 섹션에 대응합니다.
+type ProjectContextConfig struct {
+	// Enabled는 include_local_context 인자를 생략했을 때의 기본값입니다.
+	Enabled bool `mapstructure:"enabled"`
+	// Dir은 컨텍스트를 수집할 프로젝트 디렉토리입니다. 비어 있으면 MCP 서버의 현재 디렉토리입니다.
+	Dir string `mapstructure:"dir"`
+	// MaxFiles는 나열할 변경 파일 최대 개수입니다. 0 이하이면 DefaultProjectContextMaxFiles.
+	MaxFiles int `mapstructure:"max_files"`
+	// MaxCommits는 나열할 최근 커밋 개수입니다. 0 이하이면 DefaultProjectContextMaxCommits.
+	MaxCommits int `mapstructure:"max_commits"`
+}
+
+// DefaultProjectContextConfig는 기본 로컬 컨텍스트 설정을 반환합니다 (기본 비활성화).
+func DefaultProjectContextConfig() ProjectContextConfig {
+	return ProjectContextConfig{
+		MaxFiles:   DefaultProjectContextMaxFiles,
+		MaxCommits: DefaultProjectContextMaxCommits,
+	}
+}
+
+// ProjectAnalyzer는 프로젝트 기술 스택을 감지합니다 (project.Analyzer).
+type ProjectAnalyzer interface {
+	Analyze(rootDir string) (*ws.ProjectContextPayload, error)
+}
+
+// gitCommandFunc는 dir에서 git 명령을 실행하고 표준 출력을 반환합니다 (테스트에서 교체).
+type gitCommandFunc func(ctx context.Context, dir string, args ...string) (string, error)
+
+// projectContext는 로컬 프로젝트 상태를 프롬프트에 덧붙일 컨텍스트 블록으로 만듭니다.
+type projectContext struct {
+	cfg      ProjectContextConfig
+	analyzer ProjectAnalyzer
+	git      gitCommandFunc
+}
+
+// SetProjectContext는 execute_task의 로컬 컨텍스트 설정과 기술 스택 분석기를 지정합니다.
+// analyzer가 nil이면 기술 스택은 생략합니다.
+func (s *Server) SetProjectContext(cfg ProjectContextConfig, analyzer ProjectAnalyzer) {
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = DefaultProjectContextMaxFiles
+	}
+	if cfg.MaxCommits <= 0 {
+		cfg.MaxCommits = DefaultProjectContextMaxCommits
+	}
+	s.projectContext.Store(&projectContext{cfg: cfg, analyzer: analyzer, git: runGitCommand})
+}
+
+// augmentPrompt는 include_local_context 인자가 true이면 prompt 뒤에 로컬 컨텍스트 블록을 덧붙입니다.
+// include 인자를 생략하면 설정의 기본값(Enabled)을 따릅니다.
+// 컨텍스트를 수집하지 못하면 원래 prompt를 그대로 반환합니다.
+func (s *Server) augmentPrompt(ctx context.Context, prompt string, args Args) string {
+	pc := s.projectContext.Load()
+	if pc == nil {
+		pc = &projectContext{cfg: DefaultProjectContextConfig(), git: runGitCommand}
+	}
+	include := pc.cfg.Enabled
+	if args.Has("include_local_context") {
+		include = args.Bool("include_local_context")
+	}
+	if !include {
+		return prompt
+	}
+
+	block := pc.build(ctx)
+	if block == "" {
+		s.logger.Debug().Msg("로컬 프로젝트 컨텍스트를 수집하지 못해 프롬프트를 그대로 전달")
+		return prompt
+	}
+	return prompt + "\n\n" + block
+}
+
+// build는 현재 브랜치, 변경 파일, 최근 커밋, 기술 스택을 담은 컨텍스트 블록을 만듭니다.
+// git 저장소가 아니면 기술 스택만 담고, 아무 정보도 없으면 빈 문자열을 반환합니다.
+func (pc *projectContext) build(ctx context.Context) string {
+	dir := pc.cfg.Dir
+	if dir == "" {
+		if wd, err := os.Getwd(); err == nil {
+			dir = wd
+		}
+	}
+	if dir == "" {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, projectContextTimeout)
+	defer cancel()
+
+	var lines []string
+	if branch, err := pc.git(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "" {
+		lines = append(lines, "Branch: "+branch)
+
+		if status, err := pc.git(ctx, dir, "status", "--porcelain"); err == nil {
+			changed := nonEmptyLines(status)
+			if len(changed) == 0 {
+				lines = append(lines, "Working tree: clean")
+			} else {
+				lines = append(lines, fmt.Sprintf("Changed files (%d):", len(changed)))
+				for i, line := range changed {
+					if i == pc.cfg.MaxFiles {
+						lines = append(lines, fmt.Sprintf("  ... and %d more", len(changed)-i))
+						break
+					}
+					lines = append(lines, "  "+line)
+				}
+			}
+		}
+
+		if log, err := pc.git(ctx, dir, "log", fmt.Sprintf("-%d", pc.cfg.MaxCommits), "--format=%h %s"); err == nil {
+			if commits := nonEmptyLines(log); len(commits) > 0 {
+				lines = append(lines, "Recent commits:")
+				for _, commit := range commits {
+					lines = append(lines, "  "+commit)
+				}
+			}
+		}
+	}
+
+	if pc.analyzer != nil {
+		if payload, err := pc.analyzer.Analyze(dir); err == nil && payload != nil {
+			if stack := formatTechStack(payload.TechStack); stack != "" {
+				lines = append(lines, "Stack: "+stack)
+			}
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return "<local_context>\n" + strings.Join(lines, "\n") + "\n</local_context>"
+}
+
+// formatTechStack은 감지된 기술 스택을 한 줄로 요약합니다.
+func formatTechStack(stack ws.TechStack) string {
+	var parts []string
+	for _, group := range []struct {
+		label string
+		items []string
+	}{
+		{"languages", stack.Languages},
+		{"frameworks", stack.Frameworks},
+		{"databases", stack.Databases},
+		{"build", stack.BuildTools},
+		{"tests", stack.TestFrameworks},
+	} {
+		if len(group.items) > 0 {
+			parts = append(parts, group.label+" "+strings.Join(group.items, ", "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// nonEmptyLines는 출력의 빈 줄을 제외한 줄 목록을 반환합니다.
+func nonEmptyLines(out string) []string {
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, "\r"))
+		}
+	}
+	return lines
+}
+
+// runGitCommand는 dir에서 git 명령을 실행하고 끝의 줄바꿈을 제거한 표준 출력을 반환합니다.
+// git status --porcelain의 줄 앞 공백은 상태 표시이므로 유지합니다.
+func runGitCommand(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
@@ -0,0 +1,125 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/testbackend"
+)
+
+// fakeProjectAnalyzer는 고정된 기술 스택을 반환하는 테스트용 분석기입니다.
+type fakeProjectAnalyzer struct{}
+
+func (fakeProjectAnalyzer) Analyze(rootDir string) (*ws.ProjectContextPayload, error) {
+	return &ws.ProjectContextPayload{
+		ProjectRoot: rootDir,
+		TechStack:   ws.TechStack{Languages: []string{"go"}, TestFrameworks: []string{"go-test"}},
+	}, nil
+}
+
+// fakeGit은 명령별로 고정된 출력을 반환합니다. 등록되지 않은 명령은 에러입니다.
+func fakeGit(outputs map[string]string) gitCommandFunc {
+	return func(ctx context.Context, dir string, args ...string) (string, error) {
+		out, ok := outputs[args[0]]
+		if !ok {
+			return "", errors.New("not a git repository")
+		}
+		return out, nil
+	}
+}
+
+func TestProjectContext_Build(t *testing.T) {
+	pc := &projectContext{
+		cfg:      ProjectContextConfig{Dir: t.TempDir(), MaxFiles: 2, MaxCommits: 5},
+		analyzer: fakeProjectAnalyzer{},
+		git: fakeGit(map[string]string{
+			"rev-parse": "feature/login",
+			"status":    " M cmd/root.go\n?? notes.md\nA  internal/auth/login.go\n",
+			"log":       "abc1234 Add login flow\ndef5678 Fix token refresh",
+		}),
+	}
+
+	want := strings.Join([]string{
+		"<local_context>",
+		"Branch: feature/login",
+		"Changed files (3):",
+		"   M cmd/root.go",
+		"  ?? notes.md",
+		"  ... and 1 more",
+		"Recent commits:",
+		"  abc1234 Add login flow",
+		"  def5678 Fix token refresh",
+		"Stack: languages go; tests go-test",
+		"</local_context>",
+	}, "\n")
+	if got := pc.build(context.Background()); got != want {
+		t.Errorf("컨텍스트 블록 =\n%s\nwant\n%s", got, want)
+	}
+
+	// git 저장소가 아니면 기술 스택만 담는다.
+	pc.git = fakeGit(nil)
+	if got := pc.build(context.Background()); got != "<local_context>\nStack: languages go; tests go-test\n</local_context>" {
+		t.Errorf("git 없는 컨텍스트 블록 = %q", got)
+	}
+
+	// 아무 정보도 없으면 빈 문자열이다.
+	pc.analyzer = nil
+	if got := pc.build(context.Background()); got != "" {
+		t.Errorf("정보가 없으면 빈 문자열이어야 합니다: %q", got)
+	}
+}
+
+func TestHandleExecuteTask_LocalContext(t *testing.T) {
+	mock := testbackend.NewAPI()
+	defer mock.Close()
+
+	srv := newTestServer(mock.URL())
+	srv.SetProjectContext(ProjectContextConfig{Dir: t.TempDir()}, fakeProjectAnalyzer{})
+	ctx := context.Background()
+	execPath := "/api/v1/workspaces/ws-1/execute"
+
+	lastPrompt := func() string {
+		t.Helper()
+		reqs := mock.RequestsTo(http.MethodPost, execPath)
+		if len(reqs) == 0 {
+			t.Fatal("execute 요청이 없습니다")
+		}
+		var body ExecuteTaskRequest
+		if err := json.Unmarshal(reqs[len(reqs)-1].Body, &body); err != nil {
+			t.Fatalf("execute 요청 파싱 실패: %v", err)
+		}
+		return body.Prompt
+	}
+	run := func(args map[string]interface{}) {
+		t.Helper()
+		args["agent_id"] = "agent-1"
+		args["prompt"] = "Fix the failing test"
+		result, err := srv.handleExecuteTask(ctx, makeCallToolRequest("execute_task", args))
+		if err != nil || result.IsError {
+			t.Fatalf("execute_task 실패: %v %+v", err, result)
+		}
+	}
+
+	// 설정이 비활성화되어 있으면 인자가 없을 때 컨텍스트를 덧붙이지 않는다.
+	run(map[string]interface{}{})
+	if strings.Contains(lastPrompt(), "local_context") {
+		t.Errorf("기본 비활성화인데 컨텍스트가 추가되었습니다: %s", lastPrompt())
+	}
+
+	run(map[string]interface{}{"include_local_context": true})
+	if body := lastPrompt(); !strings.HasPrefix(body, "Fix the failing test\n\n<local_context>\n") || !strings.Contains(body, "Stack: languages go") {
+		t.Errorf("컨텍스트가 프롬프트 뒤에 추가되지 않았습니다: %s", body)
+	}
+
+	// 설정으로 기본 활성화해도 인자로 끌 수 있다.
+	srv.SetProjectContext(ProjectContextConfig{Enabled: true, Dir: t.TempDir()}, fakeProjectAnalyzer{})
+	run(map[string]interface{}{"include_local_context": false})
+	if strings.Contains(lastPrompt(), "local_context") {
+		t.Errorf("include_local_context=false인데 컨텍스트가 추가되었습니다: %s", lastPrompt())
+	}
+}
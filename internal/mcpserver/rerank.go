@@ -0,0 +1,200 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// rerankStackBoost는 결과에 프로젝트 기술 스택(언어, 프레임워크 등) 하나가 언급될 때마다 더하는 가중치입니다.
+	rerankStackBoost = 0.15
+	// rerankFileBoost는 결과에 최근 작업한 파일 하나가 언급될 때마다 더하는 가중치입니다.
+	rerankFileBoost = 0.25
+	// rerankMaxStackMatches와 rerankMaxFileMatches는 가중치에 반영하는 최대 일치 개수입니다.
+	// 스택 이름을 나열한 문서가 관련 문서를 밀어내지 않도록 제한합니다.
+	rerankMaxStackMatches = 3
+	rerankMaxFileMatches  = 2
+	// rerankMinFileName은 파일 이름 일치로 인정하는 최소 길이입니다 (a.go 같은 짧은 이름의 우연한 일치 방지).
+	rerankMinFileName = 5
+)
+
+// rerankSignals는 로컬 재정렬에 쓰는 현재 프로젝트 정보입니다.
+type rerankSignals struct {
+	// stack은 소문자로 바꾼 기술 스택 이름입니다.
+	stack []string
+	// files는 최근 작업한 파일의 소문자 경로입니다.
+	files []string
+}
+
+// empty는 재정렬에 쓸 정보가 없는지 반환합니다.
+func (sig rerankSignals) empty() bool {
+	return len(sig.stack) == 0 && len(sig.files) == 0
+}
+
+// rerankSignals는 ProjectAnalyzer가 감지한 기술 스택과 최근 작업한 파일(작업 트리 변경 파일과
+// 최근 커밋의 파일)을 수집합니다. 에디터의 열린 파일은 알 수 없으므로 git 기록으로 근사합니다.
+func (pc *projectContext) rerankSignals(ctx context.Context) rerankSignals {
+	var sig rerankSignals
+	dir := pc.cfg.Dir
+	if dir == "" {
+		if wd, err := os.Getwd(); err == nil {
+			dir = wd
+		}
+	}
+	if dir == "" {
+		return sig
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, projectContextTimeout)
+	defer cancel()
+
+	if pc.analyzer != nil {
+		if payload, err := pc.analyzer.Analyze(dir); err == nil && payload != nil {
+			stack := payload.TechStack
+			for _, group := range [][]string{stack.Languages, stack.Frameworks, stack.Databases, stack.BuildTools, stack.TestFrameworks} {
+				for _, item := range group {
+					if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+						sig.stack = append(sig.stack, item)
+					}
+				}
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	addFile := func(file string) {
+		file = strings.ToLower(strings.Trim(strings.TrimSpace(file), `"`))
+		if file == "" || seen[file] || len(sig.files) >= pc.cfg.MaxFiles {
+			return
+		}
+		seen[file] = true
+		sig.files = append(sig.files, file)
+	}
+	if status, err := pc.git(ctx, dir, "status", "--porcelain"); err == nil {
+		for _, line := range nonEmptyLines(status) {
+			// porcelain 형식: "XY path" 또는 "XY old -> new"
+			if len(line) < 4 {
+				continue
+			}
+			file := line[3:]
+			if i := strings.Index(file, " -> "); i >= 0 {
+				file = file[i+len(" -> "):]
+			}
+			addFile(file)
+		}
+	}
+	if log, err := pc.git(ctx, dir, "log", fmt.Sprintf("-%d", pc.cfg.MaxCommits), "--name-only", "--format="); err == nil {
+		for _, file := range nonEmptyLines(log) {
+			addFile(file)
+		}
+	}
+	return sig
+}
+
+// localRerank는 search_knowledge 결과를 현재 프로젝트 정보로 재정렬한 새 응답을 반환합니다.
+// 백엔드 점수(없으면 순위)에 기술 스택과 최근 작업한 파일이 언급된 만큼 가중치를 곱해 다시 정렬하며,
+// 가중치가 같으면 원래 순서를 유지합니다. 원래 응답은 캐시에 보관되므로 수정하지 않습니다.
+func localRerank(resp *SearchKnowledgeResponse, sig rerankSignals) *SearchKnowledgeResponse {
+	if resp == nil || len(resp.Results) == 0 || sig.empty() {
+		return resp
+	}
+
+	hasScores := false
+	for _, r := range resp.Results {
+		if r.Score > 0 {
+			hasScores = true
+			break
+		}
+	}
+
+	type ranked struct {
+		result KnowledgeResult
+		score  float64
+	}
+	items := make([]ranked, len(resp.Results))
+	for i, r := range resp.Results {
+		base := r.Score
+		if !hasScores {
+			base = 1 - float64(i)/float64(len(resp.Results))
+		}
+		matches := rerankMatches(r, sig)
+		r.LocalMatches = matches.all()
+		items[i] = ranked{result: r, score: base * (1 + matches.boost())}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].score > items[j].score })
+
+	out := *resp
+	out.Results = make([]KnowledgeResult, len(items))
+	for i, item := range items {
+		out.Results[i] = item.result
+	}
+	out.LocalReranked = true
+	return &out
+}
+
+// rerankMatch는 결과 하나에서 찾은 기술 스택과 파일 일치입니다.
+type rerankMatch struct {
+	stack []string
+	files []string
+}
+
+// boost는 일치 개수로 계산한 가중치를 반환합니다.
+func (m rerankMatch) boost() float64 {
+	return rerankStackBoost*float64(min(len(m.stack), rerankMaxStackMatches)) +
+		rerankFileBoost*float64(min(len(m.files), rerankMaxFileMatches))
+}
+
+// all은 응답에 표시할 일치 항목을 반환합니다.
+func (m rerankMatch) all() []string {
+	if len(m.stack) == 0 && len(m.files) == 0 {
+		return nil
+	}
+	return append(append([]string(nil), m.stack...), m.files...)
+}
+
+// rerankMatches는 결과의 제목, 내용, 출처, 메타데이터에서 기술 스택과 최근 파일을 찾습니다.
+// 기술 스택은 단어 단위로 비교하므로 "go"가 "good"에 일치하지 않습니다.
+// 파일은 경로 전체 또는 충분히 긴 파일 이름이 포함되면 일치로 봅니다.
+func rerankMatches(r KnowledgeResult, sig rerankSignals) rerankMatch {
+	var b strings.Builder
+	b.WriteString(r.Title)
+	b.WriteByte('\n')
+	b.WriteString(r.Content)
+	b.WriteByte('\n')
+	b.WriteString(r.Source)
+	for _, v := range r.Metadata {
+		if s, ok := v.(string); ok {
+			b.WriteByte('\n')
+			b.WriteString(s)
+		}
+	}
+	text := strings.ToLower(b.String())
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(text, isWordSeparator) {
+		words[strings.Trim(word, ".")] = true
+	}
+
+	var m rerankMatch
+	for _, term := range sig.stack {
+		if words[term] {
+			m.stack = append(m.stack, term)
+		}
+	}
+	for _, file := range sig.files {
+		base := path.Base(file)
+		if strings.Contains(text, file) || (len(base) >= rerankMinFileName && strings.Contains(text, base)) {
+			m.files = append(m.files, file)
+		}
+	}
+	return m
+}
+
+// isWordSeparator는 기술 스택 이름(c++, c#, next.js, go-test)에 쓰이지 않는 문자를 구분자로 봅니다.
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("+#.-_", r)
+}
@@ -0,0 +1,82 @@
+package mcpserver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestProjectContext_RerankSignals(t *testing.T) {
+	pc := &projectContext{
+		cfg:      ProjectContextConfig{Dir: t.TempDir(), MaxFiles: 3, MaxCommits: 5},
+		analyzer: fakeProjectAnalyzer{},
+		git: fakeGit(map[string]string{
+			"status": " M cmd/root.go\nR  old.go -> internal/auth/login.go\n",
+			"log":    "internal/auth/login.go\nREADME.md\ndocs/setup.md\n",
+		}),
+	}
+
+	sig := pc.rerankSignals(context.Background())
+	if want := []string{"go", "go-test"}; !reflect.DeepEqual(sig.stack, want) {
+		t.Errorf("stack = %v, want %v", sig.stack, want)
+	}
+	// 중복 제거, 이름 변경은 새 경로, MaxFiles로 제한
+	if want := []string{"cmd/root.go", "internal/auth/login.go", "readme.md"}; !reflect.DeepEqual(sig.files, want) {
+		t.Errorf("files = %v, want %v", sig.files, want)
+	}
+}
+
+func TestLocalRerank(t *testing.T) {
+	resp := &SearchKnowledgeResponse{
+		Query: "how to add auth",
+		Total: 3,
+		Results: []KnowledgeResult{
+			{ID: "doc-1", Title: "Good auth practices", Content: "General advice", Score: 0.85},
+			{ID: "doc-2", Title: "Auth in Go services", Content: "Token refresh with go-test examples", Score: 0.8},
+			{ID: "doc-3", Title: "Login flow", Content: "See internal/auth/login.go", Score: 0.7},
+		},
+	}
+	sig := rerankSignals{stack: []string{"go", "go-test"}, files: []string{"internal/auth/login.go"}}
+
+	got := localRerank(resp, sig)
+	var ids []string
+	for _, r := range got.Results {
+		ids = append(ids, r.ID)
+	}
+	// doc-2: 0.8 * 1.30 = 1.04, doc-3: 0.7 * 1.25 = 0.875, doc-1: "good"은 "go"와 일치하지 않음
+	if want := []string{"doc-2", "doc-3", "doc-1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("순서 = %v, want %v", ids, want)
+	}
+	if !got.LocalReranked {
+		t.Error("LocalReranked = false, want true")
+	}
+	if want := []string{"go", "go-test"}; !reflect.DeepEqual(got.Results[0].LocalMatches, want) {
+		t.Errorf("LocalMatches = %v, want %v", got.Results[0].LocalMatches, want)
+	}
+	if got.Results[2].LocalMatches != nil {
+		t.Errorf("일치 없는 결과의 LocalMatches = %v, want nil", got.Results[2].LocalMatches)
+	}
+
+	// 캐시에 보관되는 원래 응답은 바뀌지 않아야 함
+	if resp.Results[0].ID != "doc-1" || resp.LocalReranked || resp.Results[1].LocalMatches != nil {
+		t.Errorf("원래 응답이 수정됨: %+v", resp)
+	}
+}
+
+func TestLocalRerank_NoScoresUsesRank(t *testing.T) {
+	resp := &SearchKnowledgeResponse{Results: []KnowledgeResult{
+		{ID: "a", Title: "Deploying"},
+		{ID: "b", Title: "Deploying"},
+		{ID: "c", Title: "Deploying with docker", Metadata: map[string]interface{}{"tags": "Docker"}},
+	}}
+
+	got := localRerank(resp, rerankSignals{stack: []string{"docker"}})
+	// 순위 점수 a=1, b=0.67, c=0.33*1.15 — 가중치 하나로는 관련도 순서를 뒤집지 않음
+	if got.Results[0].ID != "a" || got.Results[2].ID != "c" {
+		t.Errorf("순서 = %+v", got.Results)
+	}
+
+	if same := localRerank(resp, rerankSignals{}); same != resp {
+		t.Error("정보가 없으면 원래 응답을 그대로 반환해야 합니다")
+	}
+}
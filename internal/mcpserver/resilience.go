@@ -0,0 +1,281 @@
+package mcpserver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen은 서킷 브레이커가 열려 백엔드 호출을 즉시 거부했음을 나타냅니다.
+// 리소스 핸들러는 이 에러를 받으면 백엔드를 기다리지 않고 캐시 폴백을 반환합니다.
+var ErrCircuitOpen = errors.New("백엔드 서킷 브레이커가 열려 있습니다 (백엔드 장애로 호출 일시 중단)")
+
+// unavailableError는 네트워크 오류나 5xx처럼 백엔드 장애로 실패한 요청의 에러입니다.
+// 메시지는 원래 에러를 그대로 사용합니다.
+type unavailableError struct {
+	err error
+}
+
+func (e *unavailableError) Error() string { return e.err.Error() }
+
+func (e *unavailableError) Unwrap() error { return e.err }
+
+// IsBackendUnavailable은 에러가 백엔드 장애(연결 실패, 5xx, 요청 한도 초과, 서킷 브레이커 열림)로 인한 것인지 반환합니다.
+// 그 밖의 요청/인증 오류(4xx)는 false입니다. 로컬 캐시로 폴백할지 결정할 때 사용합니다.
+func IsBackendUnavailable(err error) bool {
+	var unavailable *unavailableError
+	return errors.Is(err, ErrCircuitOpen) || errors.As(err, &unavailable) || IsRateLimited(err)
+}
+
+// defaultMaxRetryAfter는 RetryPolicy.MaxRetryAfter가 0일 때 따르는 Retry-After 상한입니다.
+const defaultMaxRetryAfter = 30 * time.Second
+
+// rateLimitedError는 백엔드가 HTTP 429로 요청 한도 초과를 알린 에러입니다.
+type rateLimitedError struct {
+	// retryAfter는 Retry-After 헤더로 받은 대기 시간입니다 (헤더가 없으면 0).
+	retryAfter time.Duration
+	body       string
+}
+
+func (e *rateLimitedError) Error() string {
+	if e.retryAfter > 0 {
+		return fmt.Sprintf("백엔드 요청 한도 초과 (HTTP 429, %s 후 재시도 가능): %s", e.retryAfter, e.body)
+	}
+	return fmt.Sprintf("백엔드 요청 한도 초과 (HTTP 429): %s", e.body)
+}
+
+// IsRateLimited는 에러가 백엔드 요청 한도 초과(HTTP 429)로 인한 것인지 반환합니다.
+func IsRateLimited(err error) bool {
+	var limited *rateLimitedError
+	return errors.As(err, &limited)
+}
+
+// parseRetryAfter는 Retry-After 헤더 값(초 또는 HTTP 날짜)을 대기 시간으로 변환합니다.
+// 값이 없거나 유효하지 않거나 이미 지난 시각이면 0을 반환합니다.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// RetryPolicy는 BackendClient의 재시도 정책입니다.
+// 멱등 요청(GET, HEAD, OPTIONS, PUT, DELETE)만 네트워크 오류와 일시적 서버 오류(502/503/504)에 대해 재시도합니다.
+// 요청 한도 초과(429)는 백엔드가 요청을 처리하지 않은 것이므로 모든 메서드를 재시도하며,
+// Retry-After 헤더가 있으면 그 시간만큼 기다립니다.
+type RetryPolicy struct {
+	// MaxAttempts는 첫 시도를 포함한 최대 시도 횟수입니다 (1 이하이면 재시도하지 않음).
+	MaxAttempts int
+	// InitialBackoff는 첫 재시도 전 대기 시간입니다.
+	InitialBackoff time.Duration
+	// MaxBackoff는 재시도 대기 시간의 상한입니다.
+	MaxBackoff time.Duration
+	// Multiplier는 지수 백오프 배수입니다.
+	Multiplier float64
+	// MaxRetryAfter는 따를 Retry-After의 상한입니다 (0이면 30초).
+	// 백엔드가 이보다 오래 기다리라고 하면 재시도하지 않고 바로 실패합니다.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy는 기본 재시도 정책입니다 (최대 3회, 200ms부터 2배씩, 최대 2초, Retry-After 최대 30초).
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2.0,
+		MaxRetryAfter:  defaultMaxRetryAfter,
+	}
+}
+
+// retryAfterLimit은 따를 Retry-After의 상한입니다.
+func (p RetryPolicy) retryAfterLimit() time.Duration {
+	if p.MaxRetryAfter > 0 {
+		return p.MaxRetryAfter
+	}
+	return defaultMaxRetryAfter
+}
+
+// backoff는 retry번째 재시도(1부터) 전 대기 시간을 반환합니다.
+// delay = InitialBackoff * (Multiplier ^ (retry - 1)), MaxBackoff를 넘지 않습니다.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	delay := time.Duration(float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1)))
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// isIdempotentMethod는 재시도해도 안전한 HTTP 메서드인지 확인합니다.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus는 재시도로 회복될 수 있는 일시적 서버 오류 상태 코드인지 확인합니다.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// CircuitState는 서킷 브레이커 상태입니다.
+type CircuitState string
+
+// 서킷 브레이커 상태
+const (
+	// CircuitClosed는 정상 상태로 모든 호출을 허용합니다.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen은 연속 실패로 호출을 즉시 거부하는 상태입니다.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen은 OpenTimeout 이후 시험 호출 하나만 허용하는 상태입니다.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerConfig는 서킷 브레이커 설정입니다.
+type CircuitBreakerConfig struct {
+	// FailureThreshold는 브레이커를 여는 연속 실패 횟수입니다.
+	FailureThreshold int
+	// OpenTimeout은 열린 뒤 시험 호출을 허용하기까지 기다리는 시간입니다.
+	OpenTimeout time.Duration
+}
+
+// DefaultCircuitBreakerConfig는 기본 서킷 브레이커 설정입니다 (연속 5회 실패 시 30초 차단).
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// CircuitSnapshot은 서킷 브레이커 상태 스냅샷입니다 (autopus://stats의 backend.circuit).
+type CircuitSnapshot struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	Opens               int64        `json:"opens"`
+	Rejected            int64        `json:"rejected"`
+	OpenedAt            string       `json:"opened_at,omitempty"`
+}
+
+// circuitBreaker는 백엔드가 지속적으로 실패할 때 호출을 차단하는 서킷 브레이커입니다.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	cfg      CircuitBreakerConfig
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// probing은 half-open 상태에서 시험 호출이 진행 중인지 나타냅니다.
+	probing  bool
+	opens    int64
+	rejected int64
+	now      func() time.Time
+}
+
+// newCircuitBreaker는 닫힌 상태의 서킷 브레이커를 생성합니다.
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	defaults := DefaultCircuitBreakerConfig()
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaults.FailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaults.OpenTimeout
+	}
+	return &circuitBreaker{
+		cfg:   cfg,
+		state: CircuitClosed,
+		now:   time.Now,
+	}
+}
+
+// Allow는 호출을 진행해도 되는지 확인합니다.
+// 열린 상태에서 OpenTimeout이 지나면 half-open으로 전환하고 시험 호출 하나만 허용합니다.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			b.rejected++
+			return false
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			b.rejected++
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+// Success는 호출 성공을 기록하고 브레이커를 닫습니다.
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure는 호출 실패를 기록합니다.
+// 연속 실패가 FailureThreshold에 도달하거나 시험 호출이 실패하면 브레이커를 엽니다.
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		if b.state != CircuitOpen {
+			b.opens++
+		}
+		b.state = CircuitOpen
+		b.openedAt = b.now()
+		b.probing = false
+	}
+}
+
+// Snapshot은 현재 브레이커 상태를 반환합니다.
+func (b *circuitBreaker) Snapshot() CircuitSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snap := CircuitSnapshot{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		Opens:               b.opens,
+		Rejected:            b.rejected,
+	}
+	if b.state != CircuitClosed {
+		snap.OpenedAt = b.openedAt.UTC().Format(time.RFC3339)
+	}
+	return snap
+}
@@ -0,0 +1,268 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// newFlakyServer는 처음 failures번은 503을, 이후에는 성공 응답을 반환하는 백엔드를 생성합니다.
+func newFlakyServer(t *testing.T, failures int32, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{}`)})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// fastRetryPolicy는 테스트용 짧은 백오프 재시도 정책입니다.
+func fastRetryPolicy(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+}
+
+// TestDo_RetriesIdempotentRequest는 GET 요청이 일시적 오류 후 재시도로 성공하는지 테스트합니다.
+func TestDo_RetriesIdempotentRequest(t *testing.T) {
+	var calls atomic.Int32
+	server := newFlakyServer(t, 2, &calls)
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(fastRetryPolicy(3))
+
+	if _, err := client.Do(context.Background(), http.MethodGet, "/api/v1/agents", nil); err != nil {
+		t.Fatalf("재시도 후 성공해야 합니다: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("호출 횟수 = %d, want 3", got)
+	}
+	if got := client.stats.Snapshot().Backend.Retries; got != 2 {
+		t.Errorf("retries = %d, want 2", got)
+	}
+}
+
+// TestDo_DoesNotRetryNonIdempotentRequest는 POST 요청은 재시도하지 않는지 테스트합니다.
+func TestDo_DoesNotRetryNonIdempotentRequest(t *testing.T) {
+	var calls atomic.Int32
+	server := newFlakyServer(t, 2, &calls)
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(fastRetryPolicy(3))
+
+	if _, err := client.Do(context.Background(), http.MethodPost, "/api/v1/tasks", map[string]string{"prompt": "hi"}); err == nil {
+		t.Fatal("503 응답인데 에러가 반환되지 않았습니다")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("POST 호출 횟수 = %d, want 1", got)
+	}
+}
+
+// TestDo_DoesNotRetryClientError는 4xx 오류는 재시도하지 않는지 테스트합니다.
+func TestDo_DoesNotRetryClientError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(apiResponse{Success: false, Error: "not found"})
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(fastRetryPolicy(3))
+	client.EnableCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Do(context.Background(), http.MethodGet, "/api/v1/agents/x", nil); err == nil {
+			t.Fatal("404 응답인데 에러가 반환되지 않았습니다")
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("호출 횟수 = %d, want 2 (4xx는 재시도/차단 대상이 아님)", got)
+	}
+	if state := client.breaker.Snapshot().State; state != CircuitClosed {
+		t.Errorf("state = %s, want closed", state)
+	}
+}
+
+// TestDo_CircuitBreakerOpens는 연속 실패 후 브레이커가 열려 백엔드를 호출하지 않는지 테스트합니다.
+func TestDo_CircuitBreakerOpens(t *testing.T) {
+	var calls atomic.Int32
+	server := newFlakyServer(t, 100, &calls)
+	client := newTestClient(server.URL)
+	client.EnableCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Do(context.Background(), http.MethodGet, "/api/v1/agents", nil); err == nil {
+			t.Fatal("503 응답인데 에러가 반환되지 않았습니다")
+		}
+	}
+
+	_, err := client.Do(context.Background(), http.MethodGet, "/api/v1/agents", nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("호출 횟수 = %d, want 2 (열린 브레이커는 백엔드를 호출하지 않아야 함)", got)
+	}
+
+	snap := client.breaker.Snapshot()
+	if snap.State != CircuitOpen || snap.Opens != 1 || snap.Rejected != 1 {
+		t.Errorf("circuit = %+v", snap)
+	}
+}
+
+// TestCircuitBreaker_HalfOpen은 OpenTimeout 이후 시험 호출 결과에 따라 브레이커가 닫히거나 다시 열리는지 테스트합니다.
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Second})
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	if b.Allow() {
+		t.Fatal("열린 브레이커가 호출을 허용했습니다")
+	}
+
+	now = now.Add(11 * time.Second)
+	if !b.Allow() {
+		t.Fatal("OpenTimeout 이후 시험 호출이 허용되어야 합니다")
+	}
+	if b.Allow() {
+		t.Error("half-open 상태에서는 시험 호출 하나만 허용해야 합니다")
+	}
+
+	// 시험 호출 실패 → 다시 열림
+	b.Failure()
+	if got := b.Snapshot(); got.State != CircuitOpen || got.Opens != 2 {
+		t.Errorf("circuit = %+v, want open with 2 opens", got)
+	}
+
+	// 시험 호출 성공 → 닫힘
+	now = now.Add(11 * time.Second)
+	if !b.Allow() {
+		t.Fatal("OpenTimeout 이후 시험 호출이 허용되어야 합니다")
+	}
+	b.Success()
+	if got := b.Snapshot(); got.State != CircuitClosed || got.ConsecutiveFailures != 0 {
+		t.Errorf("circuit = %+v, want closed", got)
+	}
+}
+
+// TestStatsResource_CircuitState는 autopus://stats에 서킷 브레이커 상태가 포함되는지 테스트합니다.
+func TestStatsResource_CircuitState(t *testing.T) {
+	client := newTestClient("http://localhost:1")
+	srv := NewServer(client, zerolog.Nop())
+	if snap := readStats(t, srv); snap.Backend.Circuit != nil {
+		t.Errorf("브레이커 비활성화 시 circuit은 생략되어야 합니다: %+v", snap.Backend.Circuit)
+	}
+
+	client.EnableCircuitBreaker(DefaultCircuitBreakerConfig())
+	snap := readStats(t, srv)
+	if snap.Backend.Circuit == nil || snap.Backend.Circuit.State != CircuitClosed {
+		t.Errorf("circuit = %+v, want closed", snap.Backend.Circuit)
+	}
+}
+
+// TestRetryPolicy_Backoff는 지수 백오프와 상한을 테스트합니다.
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := p.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+// TestDo_RetriesRateLimitedRequest는 429 응답을 Retry-After만큼 기다린 뒤 POST도 재시도하는지 테스트합니다.
+func TestDo_RetriesRateLimitedRequest(t *testing.T) {
+	var calls atomic.Int32
+	var first, second time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		second = time.Now()
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{}`)})
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL)
+	client.SetRetryPolicy(fastRetryPolicy(3))
+	client.EnableCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	if _, err := client.Do(context.Background(), http.MethodPost, "/api/v1/tasks", map[string]string{"prompt": "hi"}); err != nil {
+		t.Fatalf("429 후 재시도로 성공해야 합니다: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("호출 횟수 = %d, want 2", got)
+	}
+	if wait := second.Sub(first); wait < 900*time.Millisecond {
+		t.Errorf("Retry-After 대기 = %v, want >= 1s", wait)
+	}
+	if state := client.breaker.Snapshot().State; state != CircuitClosed {
+		t.Errorf("429는 서킷 브레이커 실패로 세지 않아야 합니다: %s", state)
+	}
+}
+
+// TestDo_RateLimitExceedsMaxRetryAfter는 Retry-After가 상한을 넘으면 재시도하지 않고 바로 실패하는지 테스트합니다.
+func TestDo_RateLimitExceedsMaxRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL)
+	policy := fastRetryPolicy(3)
+	policy.MaxRetryAfter = time.Second
+	client.SetRetryPolicy(policy)
+
+	_, err := client.Do(context.Background(), http.MethodGet, "/api/v1/agents", nil)
+	if !IsRateLimited(err) || !IsBackendUnavailable(err) {
+		t.Fatalf("err = %v, want 요청 한도 초과", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("호출 횟수 = %d, want 1", got)
+	}
+
+	// 다른 호출은 상한(1초)까지만 기다린 뒤 요청을 보낸다
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Do(ctx, http.MethodGet, "/api/v1/agents", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Retry-After 대기 중 호출 err = %v, want context.DeadlineExceeded", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("대기 중에는 백엔드를 호출하지 않아야 합니다: 호출 횟수 = %d", got)
+	}
+}
+
+// TestParseRetryAfter는 초 단위와 HTTP 날짜 형식의 Retry-After를 테스트합니다.
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultStaleWhileRevalidate는 TTL이 지난 캐시를 즉시 반환하면서 백그라운드로 갱신하는 기본 시간입니다.
+	DefaultStaleWhileRevalidate = 5 * time.Minute
+	// backgroundRefreshTimeout은 백그라운드 캐시 갱신 요청의 타임아웃입니다.
+	backgroundRefreshTimeout = 30 * time.Second
+)
+
+// 캐시 정책을 지정할 수 있는 리소스 이름 (SetResourceCachePolicy 인자)
+const (
+	ResourceStatus     = "status"
+	ResourceWorkspaces = "workspaces"
+	ResourceAgents     = "agents"
+)
+
+// CachePolicy는 리소스별 캐시 정책입니다.
+//
+//   - 저장 후 TTL 이내: 백엔드 조회 없이 캐시를 반환합니다.
+//   - TTL 이후 StaleWhileRevalidate 이내: 캐시를 즉시 반환하고 백그라운드로 갱신합니다.
+//   - 그 이후: 백엔드를 조회하고, 실패하면 만료된 캐시를 폴백으로 반환합니다.
+type CachePolicy struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+}
+
+// resourceCacheKey는 리소스 이름의 캐시 키를 반환합니다.
+func resourceCacheKey(resource string) string {
+	return "resource:" + resource
+}
+
+// defaultCachePolicies는 리소스별 기본 캐시 정책을 반환합니다.
+// 상태 리소스는 연결 확인이 목적이므로 항상 백엔드를 조회하고 장애 시에만 캐시를 사용합니다.
+func defaultCachePolicies(ttl time.Duration) map[string]CachePolicy {
+	return map[string]CachePolicy{
+		cacheKeyStatus:     {},
+		cacheKeyWorkspaces: {TTL: ttl, StaleWhileRevalidate: DefaultStaleWhileRevalidate},
+		cacheKeyAgents:     {TTL: ttl, StaleWhileRevalidate: DefaultStaleWhileRevalidate},
+	}
+}
+
+// SetResourceCachePolicy는 리소스(status, workspaces, agents)의 캐시 정책을 변경합니다.
+func (s *Server) SetResourceCachePolicy(resource string, policy CachePolicy) error {
+	key := resourceCacheKey(resource)
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if _, ok := s.cachePolicies[key]; !ok {
+		return fmt.Errorf("unknown cacheable resource: %s", resource)
+	}
+	s.cachePolicies[key] = policy
+	return nil
+}
+
+// cachePolicy는 캐시 키의 정책을 반환합니다.
+func (s *Server) cachePolicy(key string) CachePolicy {
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	return s.cachePolicies[key]
+}
+
+// readCachedResource는 캐시 정책에 따라 캐시 또는 fetch 결과를 반환합니다.
+// refresh가 true이면 캐시를 건너뛰고 백엔드를 조회합니다.
+// fetch가 실패하면 에러를 반환하며, 만료된 캐시 폴백은 호출자가 처리합니다.
+func (s *Server) readCachedResource(ctx context.Context, key string, refresh bool, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	policy := s.cachePolicy(key)
+
+	if !refresh {
+		if data, storedAt, ok := s.cache.GetStale(key); ok {
+			age := time.Since(storedAt)
+			switch {
+			case age < policy.TTL:
+				s.stats.RecordCache(true)
+				return data, nil
+			case age < policy.TTL+policy.StaleWhileRevalidate:
+				s.stats.RecordCache(true)
+				s.revalidateInBackground(key, policy, fetch)
+				return data, nil
+			}
+		}
+	}
+	s.stats.RecordCache(false)
+
+	data, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.SetWithTTL(key, data, policy.TTL)
+	return data, nil
+}
+
+// revalidateInBackground는 캐시 키를 백그라운드에서 갱신합니다.
+// 같은 키의 갱신이 이미 진행 중이면 새로 시작하지 않습니다.
+func (s *Server) revalidateInBackground(key string, policy CachePolicy, fetch func(ctx context.Context) (interface{}, error)) {
+	s.cacheMu.Lock()
+	if s.refreshing[key] {
+		s.cacheMu.Unlock()
+		return
+	}
+	s.refreshing[key] = true
+	s.cacheMu.Unlock()
+
+	s.refreshWG.Add(1)
+	go func() {
+		defer s.refreshWG.Done()
+		defer func() {
+			s.cacheMu.Lock()
+			delete(s.refreshing, key)
+			s.cacheMu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundRefreshTimeout)
+		defer cancel()
+
+		data, err := fetch(ctx)
+		if err != nil {
+			s.logger.Warn().Err(err).Str("key", key).Msg("백그라운드 캐시 갱신 실패, 기존 캐시 유지")
+			return
+		}
+		s.cache.SetWithTTL(key, data, policy.TTL)
+		s.logger.Debug().Str("key", key).Msg("백그라운드 캐시 갱신 완료")
+	}()
+}
+
+// refreshRequested는 리소스 읽기 요청의 refresh 인자가 참인지 확인합니다.
+func refreshRequested(request mcp.ReadResourceRequest) bool {
+	switch v := request.Params.Arguments["refresh"].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
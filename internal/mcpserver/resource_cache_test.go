@@ -0,0 +1,138 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+)
+
+// newCountingAgentsServer는 호출 횟수를 total로 반환하는 에이전트 목록 백엔드를 생성합니다.
+func newCountingAgentsServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		json.NewEncoder(w).Encode(apiResponse{
+			Success: true,
+			Data:    json.RawMessage(fmt.Sprintf(`{"agents":[],"total":%d}`, n)),
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// readAgentsTotal은 autopus://agents 리소스를 읽고 total 값을 반환합니다.
+func readAgentsTotal(t *testing.T, srv *Server, args map[string]any) int {
+	t.Helper()
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = "autopus://agents"
+	req.Params.Arguments = args
+
+	contents, err := srv.handleAgentsResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("리소스 핸들러 오류: %v", err)
+	}
+	var resp ListAgentsResponse
+	if err := json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	return resp.Total
+}
+
+// TestReadCachedResource_FreshHit은 TTL 이내에는 백엔드를 조회하지 않는지 테스트합니다.
+func TestReadCachedResource_FreshHit(t *testing.T) {
+	var calls atomic.Int32
+	backend := newCountingAgentsServer(t, &calls)
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop(), time.Minute)
+
+	if got := readAgentsTotal(t, srv, nil); got != 1 {
+		t.Fatalf("첫 조회 total = %d, want 1", got)
+	}
+	if got := readAgentsTotal(t, srv, nil); got != 1 {
+		t.Errorf("TTL 이내 조회는 캐시를 반환해야 합니다: total = %d", got)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("백엔드 호출 수 = %d, want 1", calls.Load())
+	}
+
+	// refresh 인자는 캐시를 건너뛴다.
+	if got := readAgentsTotal(t, srv, map[string]any{"refresh": "true"}); got != 2 {
+		t.Errorf("refresh 조회는 백엔드 결과를 반환해야 합니다: total = %d", got)
+	}
+}
+
+// TestReadCachedResource_StaleWhileRevalidate는 TTL 이후 캐시를 즉시 반환하고 백그라운드로 갱신하는지 테스트합니다.
+func TestReadCachedResource_StaleWhileRevalidate(t *testing.T) {
+	var calls atomic.Int32
+	backend := newCountingAgentsServer(t, &calls)
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop())
+	if err := srv.SetResourceCachePolicy(ResourceAgents, CachePolicy{StaleWhileRevalidate: time.Minute}); err != nil {
+		t.Fatalf("정책 설정 실패: %v", err)
+	}
+
+	if got := readAgentsTotal(t, srv, nil); got != 1 {
+		t.Fatalf("첫 조회 total = %d, want 1", got)
+	}
+	if got := readAgentsTotal(t, srv, nil); got != 1 {
+		t.Errorf("stale 구간에서는 기존 캐시를 즉시 반환해야 합니다: total = %d", got)
+	}
+
+	srv.refreshWG.Wait()
+	if calls.Load() != 2 {
+		t.Fatalf("백그라운드 갱신이 실행되어야 합니다: 호출 수 = %d", calls.Load())
+	}
+	if got := readAgentsTotal(t, srv, nil); got != 2 {
+		t.Errorf("갱신된 캐시를 반환해야 합니다: total = %d", got)
+	}
+}
+
+// TestReadCachedResource_ExpiredFetchesSynchronously는 stale 구간이 지나면 동기 조회하는지 테스트합니다.
+func TestReadCachedResource_ExpiredFetchesSynchronously(t *testing.T) {
+	var calls atomic.Int32
+	backend := newCountingAgentsServer(t, &calls)
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop())
+	if err := srv.SetResourceCachePolicy(ResourceAgents, CachePolicy{}); err != nil {
+		t.Fatalf("정책 설정 실패: %v", err)
+	}
+
+	readAgentsTotal(t, srv, nil)
+	if got := readAgentsTotal(t, srv, nil); got != 2 {
+		t.Errorf("정책이 없으면 매번 백엔드를 조회해야 합니다: total = %d", got)
+	}
+}
+
+// TestSetResourceCachePolicy_UnknownResource는 알 수 없는 리소스 정책 설정을 테스트합니다.
+func TestSetResourceCachePolicy_UnknownResource(t *testing.T) {
+	srv := NewServer(newTestClient("http://localhost:1"), zerolog.Nop())
+	if err := srv.SetResourceCachePolicy("executions", CachePolicy{TTL: time.Minute}); err == nil {
+		t.Error("알 수 없는 리소스는 에러를 반환해야 합니다")
+	}
+}
+
+// TestRefreshRequested는 refresh 인자 해석을 테스트합니다.
+func TestRefreshRequested(t *testing.T) {
+	tests := []struct {
+		args map[string]any
+		want bool
+	}{
+		{nil, false},
+		{map[string]any{"refresh": true}, true},
+		{map[string]any{"refresh": "1"}, true},
+		{map[string]any{"refresh": "false"}, false},
+		{map[string]any{"refresh": 1}, false},
+	}
+	for _, tc := range tests {
+		req := mcp.ReadResourceRequest{}
+		req.Params.Arguments = tc.args
+		if got := refreshRequested(req); got != tc.want {
+			t.Errorf("refreshRequested(%v) = %v, want %v", tc.args, got, tc.want)
+		}
+	}
+}
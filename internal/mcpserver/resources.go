@@ -12,9 +12,9 @@ import (
 
 // 리소스 캐시 키 상수
 const (
-	cacheKeyStatus     = "resource:status"
-	cacheKeyWorkspaces = "resource:workspaces"
-	cacheKeyAgents     = "resource:agents"
+	cacheKeyStatus     = "resource:" + ResourceStatus
+	cacheKeyWorkspaces = "resource:" + ResourceWorkspaces
+	cacheKeyAgents     = "resource:" + ResourceAgents
 )
 
 // PlatformStatus는 플랫폼 상태 정보입니다.
@@ -56,7 +56,15 @@ func (s *Server) handleStatusResource(ctx context.Context, request mcp.ReadResou
 	}
 
 	// 백엔드 연결 확인 (에이전트 목록 API를 헬스체크로 활용)
-	_, err := s.client.ListAgents(ctx, "", "")
+	checked, err := s.readCachedResource(ctx, cacheKeyStatus, refreshRequested(request), func(ctx context.Context) (interface{}, error) {
+		if _, err := s.client.ListAgents(ctx, "", ""); err != nil {
+			return nil, err
+		}
+		connected := status
+		connected.Connected = true
+		connected.Message = "Connected to Autopus backend"
+		return &connected, nil
+	})
 	if err != nil {
 		s.logger.Warn().Err(err).Msg("백엔드 연결 상태 확인 실패")
 
@@ -82,12 +90,8 @@ func (s *Server) handleStatusResource(ctx context.Context, request mcp.ReadResou
 		// 캐시도 없으면 기본 에러 응답
 		status.Connected = false
 		status.Message = fmt.Sprintf("Backend unreachable: %s", err.Error())
-	} else {
-		status.Connected = true
-		status.Message = "Connected to Autopus backend"
-
-		// 성공 시 캐시에 저장
-		s.cache.Set(cacheKeyStatus, &status)
+	} else if current, ok := checked.(*PlatformStatus); ok {
+		status = *current
 	}
 
 	data, err := json.MarshalIndent(status, "", "  ")
@@ -141,12 +145,14 @@ func (s *Server) handleExecutionResource(ctx context.Context, request mcp.ReadRe
 
 // handleWorkspacesResource는 autopus://workspaces 리소스 핸들러입니다.
 // 접근 가능한 워크스페이스 목록을 반환합니다.
-// 백엔드 미연결 시 캐시된 워크스페이스 목록을 폴백으로 반환합니다.
+// 캐시 정책에 따라 캐시를 우선 반환하며, 백엔드 미연결 시 캐시된 워크스페이스 목록을 폴백으로 반환합니다.
 func (s *Server) handleWorkspacesResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	s.logger.Debug().Msg("워크스페이스 목록 리소스 조회")
 
-	resp, err := s.client.ManageWorkspace(ctx, &ManageWorkspaceRequest{
-		Action: "list",
+	resp, err := s.readCachedResource(ctx, cacheKeyWorkspaces, refreshRequested(request), func(ctx context.Context) (interface{}, error) {
+		return s.client.ManageWorkspace(ctx, &ManageWorkspaceRequest{
+			Action: "list",
+		})
 	})
 	if err != nil {
 		s.logger.Warn().Err(err).Msg("워크스페이스 목록 조회 실패")
@@ -177,9 +183,6 @@ func (s *Server) handleWorkspacesResource(ctx context.Context, request mcp.ReadR
 		}, nil
 	}
 
-	// 성공 시 캐시에 저장
-	s.cache.Set(cacheKeyWorkspaces, resp)
-
 	data, err := json.MarshalIndent(resp, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("워크스페이스 목록 직렬화 실패: %w", err)
@@ -191,12 +194,14 @@ func (s *Server) handleWorkspacesResource(ctx context.Context, request mcp.ReadR
 }
 
 // handleAgentsResource는 autopus://agents 리소스 핸들러입니다.
-// 사용 가능한 에이전트 카탈로그를 반환합니다.
-// 백엔드 미연결 시 캐시된 에이전트 카탈로그를 폴백으로 반환합니다.
+// 활성 워크스페이스에서 사용 가능한 에이전트 카탈로그를 반환합니다.
+// 캐시 정책에 따라 캐시를 우선 반환하며, 백엔드 미연결 시 캐시된 에이전트 카탈로그를 폴백으로 반환합니다.
 func (s *Server) handleAgentsResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	s.logger.Debug().Msg("에이전트 카탈로그 리소스 조회")
 
-	resp, err := s.client.ListAgents(ctx, "", "")
+	resp, err := s.readCachedResource(ctx, cacheKeyAgents, refreshRequested(request), func(ctx context.Context) (interface{}, error) {
+		return s.client.ListAgents(ctx, s.ActiveWorkspaceID(), "")
+	})
 	if err != nil {
 		s.logger.Warn().Err(err).Msg("에이전트 카탈로그 조회 실패")
 
@@ -226,9 +231,6 @@ func (s *Server) handleAgentsResource(ctx context.Context, request mcp.ReadResou
 		}, nil
 	}
 
-	// 성공 시 캐시에 저장
-	s.cache.Set(cacheKeyAgents, resp)
-
 	data, err := json.MarshalIndent(resp, "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("에이전트 카탈로그 직렬화 실패: %w", err)
@@ -0,0 +1,275 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// DefaultSamplingMaxTokens는 maxTokens가 지정되지 않은 샘플링 요청의 기본 최대 토큰 수입니다.
+const DefaultSamplingMaxTokens = 4096
+
+// SamplingRegistry는 샘플링 요청을 처리할 로컬 프로바이더를 선택합니다.
+// provider.Registry가 구현합니다.
+type SamplingRegistry interface {
+	GetForModel(model string) (provider.Provider, error)
+	GetFirstAvailable() (provider.Provider, error)
+}
+
+// SamplingHandler는 MCP sampling(createMessage) 요청을 로컬 프로바이더로 처리합니다.
+// 별도 API 키 없이 사용자의 로컬 Claude/Codex CLI 인증을 사용해 LLM 호출을 수행합니다.
+// mcp-go client.SamplingHandler 인터페이스를 만족합니다.
+type SamplingHandler struct {
+	registry SamplingRegistry
+	logger   zerolog.Logger
+}
+
+// NewSamplingHandler는 새 SamplingHandler를 생성합니다.
+func NewSamplingHandler(registry SamplingRegistry, logger zerolog.Logger) *SamplingHandler {
+	return &SamplingHandler{
+		registry: registry,
+		logger:   logger.With().Str("component", "mcp-sampling").Logger(),
+	}
+}
+
+// CreateMessage는 샘플링 요청을 로컬 프로바이더로 실행하고 어시스턴트 메시지를 반환합니다.
+// ModelPreferences.Hints를 순서대로 평가하여 첫 번째로 매칭되는 프로바이더를 사용하고,
+// 매칭되는 힌트가 없으면 첫 번째 사용 가능한 프로바이더로 폴백합니다.
+func (h *SamplingHandler) CreateMessage(ctx context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	params := request.CreateMessageParams
+	if len(params.Messages) == 0 {
+		return nil, fmt.Errorf("sampling: messages가 비어 있습니다")
+	}
+
+	prompt, err := buildSamplingPrompt(params.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	p, model, err := h.selectProvider(params.ModelPreferences)
+	if err != nil {
+		return nil, err
+	}
+
+	maxTokens := params.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultSamplingMaxTokens
+	}
+
+	h.logger.Info().
+		Str("provider", p.Name()).
+		Str("model", model).
+		Int("messages", len(params.Messages)).
+		Int("max_tokens", maxTokens).
+		Msg("샘플링 요청 처리")
+
+	resp, err := p.Execute(ctx, provider.ExecuteRequest{
+		Prompt:       prompt,
+		Model:        model,
+		MaxTokens:    maxTokens,
+		SystemPrompt: params.SystemPrompt,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("provider", p.Name()).Msg("샘플링 실행 실패")
+		return nil, fmt.Errorf("sampling: %s 실행 실패: %w", p.Name(), err)
+	}
+	if resp.Output == "" && resp.Error != "" {
+		return nil, fmt.Errorf("sampling: %s", resp.Error)
+	}
+
+	resultModel := resp.Model
+	if resultModel == "" {
+		resultModel = model
+	}
+	if resultModel == "" {
+		resultModel = p.Name()
+	}
+
+	return &mcp.CreateMessageResult{
+		SamplingMessage: mcp.SamplingMessage{
+			Role:    mcp.RoleAssistant,
+			Content: mcp.NewTextContent(resp.Output),
+		},
+		Model:      resultModel,
+		StopReason: samplingStopReason(resp.StopReason),
+	}, nil
+}
+
+// selectProvider는 모델 힌트에 맞는 프로바이더와 모델명을 반환합니다.
+// 힌트로 선택된 경우 힌트 이름을 모델로 전달하고, 폴백 시에는 프로바이더 기본 모델을 사용합니다.
+func (h *SamplingHandler) selectProvider(prefs *mcp.ModelPreferences) (provider.Provider, string, error) {
+	if prefs != nil {
+		for _, hint := range prefs.Hints {
+			name := strings.TrimSpace(hint.Name)
+			if name == "" {
+				continue
+			}
+			if p, err := h.registry.GetForModel(name); err == nil {
+				return p, name, nil
+			}
+			h.logger.Debug().Str("hint", name).Msg("모델 힌트에 맞는 프로바이더 없음")
+		}
+	}
+
+	p, err := h.registry.GetFirstAvailable()
+	if err != nil {
+		return nil, "", fmt.Errorf("sampling: 사용 가능한 로컬 프로바이더가 없습니다: %w", err)
+	}
+	return p, "", nil
+}
+
+// buildSamplingPrompt는 샘플링 메시지 목록을 단일 프롬프트로 변환합니다.
+// 단일 사용자 메시지는 그대로 사용하고, 여러 메시지는 역할 레이블이 붙은 대화록으로 만듭니다.
+func buildSamplingPrompt(messages []mcp.SamplingMessage) (string, error) {
+	texts := make([]string, 0, len(messages))
+	for i, msg := range messages {
+		text, err := samplingText(msg.Content)
+		if err != nil {
+			return "", fmt.Errorf("sampling: messages[%d]: %w", i, err)
+		}
+		texts = append(texts, text)
+	}
+
+	if len(messages) == 1 && messages[0].Role == mcp.RoleUser {
+		return texts[0], nil
+	}
+
+	var b strings.Builder
+	for i, msg := range messages {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		label := "User"
+		if msg.Role == mcp.RoleAssistant {
+			label = "Assistant"
+		}
+		b.WriteString(label)
+		b.WriteString(": ")
+		b.WriteString(texts[i])
+	}
+	return b.String(), nil
+}
+
+// samplingText는 샘플링 메시지 콘텐츠에서 텍스트를 추출합니다.
+// 로컬 프로바이더는 텍스트 프롬프트만 지원하므로 이미지/오디오 콘텐츠는 거부합니다.
+// JSON 디코딩된 요청은 콘텐츠가 map으로 전달되므로 두 형태를 모두 처리합니다.
+func samplingText(content any) (string, error) {
+	switch c := content.(type) {
+	case mcp.TextContent:
+		return c.Text, nil
+	case *mcp.TextContent:
+		return c.Text, nil
+	case string:
+		return c, nil
+	case map[string]any:
+		contentType, _ := c["type"].(string)
+		if contentType != "" && contentType != "text" {
+			return "", fmt.Errorf("지원하지 않는 콘텐츠 유형입니다: %s", contentType)
+		}
+		text, ok := c["text"].(string)
+		if !ok {
+			return "", fmt.Errorf("텍스트 콘텐츠가 없습니다")
+		}
+		return text, nil
+	case mcp.ImageContent, *mcp.ImageContent:
+		return "", fmt.Errorf("지원하지 않는 콘텐츠 유형입니다: image")
+	case mcp.AudioContent, *mcp.AudioContent:
+		return "", fmt.Errorf("지원하지 않는 콘텐츠 유형입니다: audio")
+	default:
+		return "", fmt.Errorf("지원하지 않는 콘텐츠 형식입니다: %T", content)
+	}
+}
+
+// samplingStopReason은 프로바이더 종료 사유를 MCP 종료 사유로 변환합니다.
+func samplingStopReason(reason string) string {
+	switch reason {
+	case "", "end_turn", "stop", "completed":
+		return "endTurn"
+	case "max_tokens", "length":
+		return "maxTokens"
+	case "stop_sequence":
+		return "stopSequence"
+	default:
+		return reason
+	}
+}
+
+// createMessageSpec은 로컬 샘플링 도구(create_message)의 선언입니다.
+var createMessageSpec = ToolSpec{
+	Name:        "create_message",
+	Description: "Generate an LLM completion using the user's locally configured AI provider (Claude/Codex/Gemini CLI credentials). Equivalent to MCP sampling/createMessage.",
+	Params: []Param{
+		{Name: "prompt", Type: ParamString, Description: "Single user prompt (use either prompt or messages)"},
+		{Name: "messages", Type: ParamString, JSON: JSONArray, Description: "Conversation as JSON array of sampling messages (e.g. '[{\"role\":\"user\",\"content\":{\"type\":\"text\",\"text\":\"Hi\"}}]')"},
+		{Name: "system_prompt", Type: ParamString, Description: "System prompt (optional)"},
+		{Name: "max_tokens", Type: ParamInteger, Min: floatPtr(1), Description: "Maximum number of tokens to generate (default: 4096)"},
+		{Name: "model", Type: ParamString, Description: "Preferred model name hint (optional, e.g. 'claude-sonnet-4', 'gpt-5'). Falls back to the first available provider."},
+	},
+	AtLeastOne: []string{"prompt", "messages"},
+	// 로컬 LLM 호출만 수행하고 백엔드 상태를 변경하지 않는다.
+	ReadOnly: true,
+}
+
+// EnableLocalSampling은 로컬 프로바이더 기반 create_message 도구를 등록합니다.
+// sampling을 직접 지원하지 않는 MCP 클라이언트도 이 도구로 로컬 CLI 인증을 통한 LLM 호출을 할 수 있습니다.
+func (s *Server) EnableLocalSampling(handler *SamplingHandler) {
+	s.sampling = handler
+	s.addTool(createMessageSpec, s.handleCreateMessage)
+
+	s.logger.Info().Msg("로컬 샘플링 도구(create_message) 등록 완료")
+}
+
+// handleCreateMessage는 create_message 도구 핸들러입니다.
+// 도구 인자를 샘플링 요청으로 변환하여 SamplingHandler로 실행합니다.
+func (s *Server) handleCreateMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if s.sampling == nil {
+		return mcp.NewToolResultError(i18n.T("mcp.sampling.disabled")), nil
+	}
+
+	args, verr := createMessageSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	var messages []mcp.SamplingMessage
+	if err := args.Decode("messages", &messages); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if prompt := args.String("prompt"); prompt != "" {
+		messages = append(messages, mcp.SamplingMessage{
+			Role:    mcp.RoleUser,
+			Content: mcp.NewTextContent(prompt),
+		})
+	}
+	if len(messages) == 0 {
+		return mcp.NewToolResultError(i18n.T("mcp.sampling.prompt_required")), nil
+	}
+
+	var samplingReq mcp.CreateMessageRequest
+	samplingReq.Messages = messages
+	samplingReq.SystemPrompt = args.String("system_prompt")
+	samplingReq.MaxTokens = args.Int("max_tokens")
+	if model := args.String("model"); model != "" {
+		samplingReq.ModelPreferences = &mcp.ModelPreferences{
+			Hints: []mcp.ModelHint{{Name: model}},
+		}
+	}
+
+	result, err := s.sampling.CreateMessage(ctx, samplingReq)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.create_message_failed", err.Error())), nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
@@ -0,0 +1,212 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+
+	"github.com/insajin/autopus-bridge/internal/provider"
+)
+
+// stubSamplingProvider는 마지막 요청을 기록하는 테스트용 프로바이더입니다.
+type stubSamplingProvider struct {
+	name    string
+	prefix  string
+	output  string
+	err     error
+	lastReq provider.ExecuteRequest
+}
+
+func (p *stubSamplingProvider) Name() string { return p.name }
+
+func (p *stubSamplingProvider) Execute(_ context.Context, req provider.ExecuteRequest) (*provider.ExecuteResponse, error) {
+	p.lastReq = req
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &provider.ExecuteResponse{
+		Output:     p.output,
+		Model:      req.Model,
+		Provider:   p.name,
+		StopReason: "end_turn",
+	}, nil
+}
+
+func (p *stubSamplingProvider) ValidateConfig() error { return nil }
+
+func (p *stubSamplingProvider) Supports(model string) bool {
+	return strings.HasPrefix(model, p.prefix)
+}
+
+func newSamplingRegistry(providers ...provider.Provider) *provider.Registry {
+	registry := provider.NewRegistry()
+	for _, p := range providers {
+		registry.Register(p)
+	}
+	return registry
+}
+
+func textMessage(role mcp.Role, text string) mcp.SamplingMessage {
+	return mcp.SamplingMessage{Role: role, Content: mcp.NewTextContent(text)}
+}
+
+// TestSamplingHandler_CreateMessage_UsesModelHint는 모델 힌트로 프로바이더를 선택하는지 테스트합니다.
+func TestSamplingHandler_CreateMessage_UsesModelHint(t *testing.T) {
+	claude := &stubSamplingProvider{name: "claude", prefix: "claude-", output: "from claude"}
+	codex := &stubSamplingProvider{name: "codex", prefix: "gpt-", output: "from codex"}
+	handler := NewSamplingHandler(newSamplingRegistry(claude, codex), zerolog.Nop())
+
+	var req mcp.CreateMessageRequest
+	req.Messages = []mcp.SamplingMessage{textMessage(mcp.RoleUser, "hello")}
+	req.SystemPrompt = "be brief"
+	req.MaxTokens = 256
+	req.ModelPreferences = &mcp.ModelPreferences{
+		Hints: []mcp.ModelHint{{Name: "unknown-model"}, {Name: "claude-sonnet-4"}},
+	}
+
+	result, err := handler.CreateMessage(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateMessage 실패: %v", err)
+	}
+	if result.Role != mcp.RoleAssistant {
+		t.Errorf("역할 = %q, want assistant", result.Role)
+	}
+	text, ok := result.Content.(mcp.TextContent)
+	if !ok || text.Text != "from claude" {
+		t.Errorf("콘텐츠 = %#v, want claude 응답", result.Content)
+	}
+	if result.Model != "claude-sonnet-4" {
+		t.Errorf("모델 = %q, want claude-sonnet-4", result.Model)
+	}
+	if result.StopReason != "endTurn" {
+		t.Errorf("종료 사유 = %q, want endTurn", result.StopReason)
+	}
+	if claude.lastReq.Prompt != "hello" || claude.lastReq.SystemPrompt != "be brief" || claude.lastReq.MaxTokens != 256 {
+		t.Errorf("프로바이더 요청이 올바르지 않습니다: %+v", claude.lastReq)
+	}
+}
+
+// TestSamplingHandler_CreateMessage_FallbackProvider는 힌트가 없을 때 첫 번째 프로바이더로 폴백하는지 테스트합니다.
+func TestSamplingHandler_CreateMessage_FallbackProvider(t *testing.T) {
+	codex := &stubSamplingProvider{name: "codex", prefix: "gpt-", output: "ok"}
+	handler := NewSamplingHandler(newSamplingRegistry(codex), zerolog.Nop())
+
+	var req mcp.CreateMessageRequest
+	req.Messages = []mcp.SamplingMessage{
+		textMessage(mcp.RoleUser, "question"),
+		textMessage(mcp.RoleAssistant, "answer"),
+		textMessage(mcp.RoleUser, "follow-up"),
+	}
+
+	result, err := handler.CreateMessage(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateMessage 실패: %v", err)
+	}
+	if result.Model != "codex" {
+		t.Errorf("모델 = %q, want 프로바이더 이름 폴백", result.Model)
+	}
+	want := "User: question\n\nAssistant: answer\n\nUser: follow-up"
+	if codex.lastReq.Prompt != want {
+		t.Errorf("프롬프트 = %q, want %q", codex.lastReq.Prompt, want)
+	}
+	if codex.lastReq.MaxTokens != DefaultSamplingMaxTokens {
+		t.Errorf("MaxTokens = %d, want 기본값 %d", codex.lastReq.MaxTokens, DefaultSamplingMaxTokens)
+	}
+}
+
+// TestSamplingHandler_CreateMessage_Errors는 에러 경로를 테스트합니다.
+func TestSamplingHandler_CreateMessage_Errors(t *testing.T) {
+	t.Run("빈 메시지", func(t *testing.T) {
+		handler := NewSamplingHandler(newSamplingRegistry(&stubSamplingProvider{name: "claude"}), zerolog.Nop())
+		if _, err := handler.CreateMessage(context.Background(), mcp.CreateMessageRequest{}); err == nil {
+			t.Fatal("빈 메시지는 에러여야 합니다")
+		}
+	})
+
+	t.Run("이미지 콘텐츠", func(t *testing.T) {
+		handler := NewSamplingHandler(newSamplingRegistry(&stubSamplingProvider{name: "claude"}), zerolog.Nop())
+		var req mcp.CreateMessageRequest
+		req.Messages = []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewImageContent("AAAA", "image/png")}}
+		if _, err := handler.CreateMessage(context.Background(), req); err == nil {
+			t.Fatal("이미지 콘텐츠는 에러여야 합니다")
+		}
+	})
+
+	t.Run("프로바이더 없음", func(t *testing.T) {
+		handler := NewSamplingHandler(newSamplingRegistry(), zerolog.Nop())
+		var req mcp.CreateMessageRequest
+		req.Messages = []mcp.SamplingMessage{textMessage(mcp.RoleUser, "hi")}
+		if _, err := handler.CreateMessage(context.Background(), req); err == nil {
+			t.Fatal("프로바이더가 없으면 에러여야 합니다")
+		}
+	})
+
+	t.Run("실행 실패", func(t *testing.T) {
+		failing := &stubSamplingProvider{name: "claude", err: errors.New("cli exited")}
+		handler := NewSamplingHandler(newSamplingRegistry(failing), zerolog.Nop())
+		var req mcp.CreateMessageRequest
+		req.Messages = []mcp.SamplingMessage{textMessage(mcp.RoleUser, "hi")}
+		if _, err := handler.CreateMessage(context.Background(), req); err == nil || !strings.Contains(err.Error(), "cli exited") {
+			t.Fatalf("실행 에러가 전달되어야 합니다: %v", err)
+		}
+	})
+}
+
+// TestToolHandler_CreateMessage는 create_message 도구가 JSON 메시지를 처리하는지 테스트합니다.
+func TestToolHandler_CreateMessage(t *testing.T) {
+	claude := &stubSamplingProvider{name: "claude", prefix: "claude-", output: "pong"}
+	srv := NewServer(newTestClient("http://localhost:1"), zerolog.Nop())
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "create_message"
+	req.Params.Arguments = map[string]interface{}{"prompt": "ping"}
+
+	result, err := srv.handleCreateMessage(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러가 에러를 반환하면 안됩니다: %v", err)
+	}
+	if !result.IsError {
+		t.Error("샘플링이 비활성화되면 에러 응답이어야 합니다")
+	}
+
+	srv.EnableLocalSampling(NewSamplingHandler(newSamplingRegistry(claude), zerolog.Nop()))
+
+	req.Params.Arguments = map[string]interface{}{
+		"messages":   `[{"role":"user","content":{"type":"text","text":"ping"}}]`,
+		"max_tokens": float64(64),
+		"model":      "claude-haiku",
+	}
+	result, err = srv.handleCreateMessage(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러가 에러를 반환하면 안됩니다: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("에러 응답이면 안됩니다: %+v", result.Content)
+	}
+
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("텍스트 콘텐츠여야 합니다: %T", result.Content[0])
+	}
+	var decoded struct {
+		Role    string `json:"role"`
+		Model   string `json:"model"`
+		Content struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal([]byte(text.Text), &decoded); err != nil {
+		t.Fatalf("응답 JSON 파싱 실패: %v", err)
+	}
+	if decoded.Role != "assistant" || decoded.Content.Text != "pong" || decoded.Model != "claude-haiku" {
+		t.Errorf("응답 = %+v", decoded)
+	}
+	if claude.lastReq.Prompt != "ping" || claude.lastReq.MaxTokens != 64 {
+		t.Errorf("프로바이더 요청이 올바르지 않습니다: %+v", claude.lastReq)
+	}
+}
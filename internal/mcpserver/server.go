@@ -1,11 +1,22 @@
 package mcpserver
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/insajin/autopus-bridge/internal/tracing"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -15,6 +26,8 @@ const (
 	ServerVersion = "0.1.0"
 	// DefaultCacheTTL은 리소스 캐시의 기본 TTL입니다.
 	DefaultCacheTTL = 30 * time.Second
+	// DefaultListAgentsMaxResults는 list_agents 자동 페이지 조회가 모으는 최대 에이전트 수입니다.
+	DefaultListAgentsMaxResults = 500
 )
 
 // Server는 Autopus MCP 서버입니다.
@@ -24,11 +37,62 @@ type Server struct {
 	client    *BackendClient
 	cache     *Cache
 	logger    zerolog.Logger
+	// stats는 autopus://stats 리소스로 제공되는 로컬 텔레메트리입니다 (BackendClient와 공유).
+	stats *Stats
+
+	// cacheMu는 cachePolicies와 refreshing을 보호합니다.
+	cacheMu sync.RWMutex
+	// cachePolicies는 캐시 키별 캐시 정책입니다.
+	cachePolicies map[string]CachePolicy
+	// refreshing은 백그라운드 갱신이 진행 중인 캐시 키입니다.
+	refreshing map[string]bool
+	// refreshWG는 진행 중인 백그라운드 갱신을 추적합니다.
+	refreshWG sync.WaitGroup
+
+	// sampling은 create_message 도구를 처리하는 로컬 샘플링 핸들러입니다 (비활성화 시 nil).
+	sampling *SamplingHandler
+
+	// knowledgeMu는 knowledge를 보호합니다.
+	knowledgeMu sync.RWMutex
+	// knowledge는 search_knowledge 결과 캐시입니다 (오프라인 폴백용, nil이면 비활성화).
+	knowledge *KnowledgeCache
+
+	// templatesMu는 templates를 보호합니다.
+	templatesMu sync.RWMutex
+	// templates는 list_templates/execute_template 도구가 사용하는 로컬 작업 템플릿입니다.
+	templates *tasktemplate.Registry
+
+	// permMu는 permissions를 보호합니다.
+	permMu sync.RWMutex
+	// permissions는 도구 이름별 사용 권한입니다 (nil이면 모두 허용).
+	permissions ToolPermissions
+
+	// workspaceMu는 activeWorkspace를 보호합니다.
+	workspaceMu sync.RWMutex
+	// activeWorkspace는 set_active_workspace로 지정한 세션 기본 워크스페이스입니다 (비어 있으면 인증 정보의 워크스페이스).
+	activeWorkspace string
+
+	// listAgentsMax는 list_agents all=true가 모으는 최대 에이전트 수입니다.
+	listAgentsMax atomic.Int64
+
+	// limiter는 도구 호출 동시 실행 제한기입니다 (nil이면 제한 없음).
+	limiter atomic.Pointer[concurrencyLimiter]
+
+	// timeouts는 도구 호출 타임아웃 계층입니다 (nil이면 호출의 _meta 값만 사용).
+	timeouts atomic.Pointer[timeouts.Policy]
+
+	// bridgeInfo는 autopus://bridge/* 리소스의 로컬 Bridge 환경 정보 출처입니다.
+	bridgeInfo bridgeInfoHolder
+
+	// projectContext는 execute_task 프롬프트에 덧붙이는 로컬 프로젝트 컨텍스트 설정입니다 (nil이면 기본값).
+	projectContext atomic.Pointer[projectContext]
 }
 
 // NewServer는 새 MCP 서버를 생성합니다.
 // BackendClient를 통해 Autopus 백엔드와 통신합니다.
 // cacheTTL이 0이면 DefaultCacheTTL(30초)을 사용합니다.
+// workspaces/agents 리소스는 cacheTTL 동안 캐시를 그대로 반환하고,
+// 이후 DefaultStaleWhileRevalidate 동안은 캐시를 반환하면서 백그라운드로 갱신합니다.
 func NewServer(client *BackendClient, logger zerolog.Logger, cacheTTL ...time.Duration) *Server {
 	ttl := DefaultCacheTTL
 	if len(cacheTTL) > 0 && cacheTTL[0] > 0 {
@@ -36,10 +100,16 @@ func NewServer(client *BackendClient, logger zerolog.Logger, cacheTTL ...time.Du
 	}
 
 	s := &Server{
-		client: client,
-		cache:  NewCache(ttl),
-		logger: logger.With().Str("component", "mcpserver").Logger(),
+		client:        client,
+		cache:         NewCache(ttl),
+		stats:         client.stats,
+		logger:        logger.With().Str("component", "mcpserver").Logger(),
+		cachePolicies: defaultCachePolicies(ttl),
+		refreshing:    make(map[string]bool),
 	}
+	s.listAgentsMax.Store(DefaultListAgentsMaxResults)
+	// 기본은 메모리 전용 캐시 (경로를 지정하지 않으면 읽기 에러가 없음)
+	s.knowledge, _ = NewKnowledgeCache("", 0, 0)
 
 	// MCP 서버 생성
 	s.mcpServer = server.NewMCPServer(
@@ -69,108 +139,217 @@ func (s *Server) Start() error {
 	return server.ServeStdio(s.mcpServer)
 }
 
+// HandleMessage는 JSON-RPC 메시지 하나를 stdio 없이 처리하고 응답을 반환합니다.
+// WebSocket 터널(embedded 모드)처럼 트랜스포트를 호출자가 담당할 때 사용합니다.
+// 알림(notification)처럼 응답이 없는 메시지는 nil을 반환합니다.
+func (s *Server) HandleMessage(ctx context.Context, message json.RawMessage) (json.RawMessage, error) {
+	resp := s.mcpServer.HandleMessage(ctx, message)
+	if resp == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("MCP 응답 직렬화 실패: %w", err)
+	}
+	return data, nil
+}
+
+// 도구 선언. 입력 스키마와 핸들러 인자 검증이 같은 선언에서 생성됩니다.
+var (
+	executeTaskSpec = ToolSpec{
+		Name:        "execute_task",
+		Description: "Execute an Autopus agent task. Sends a prompt to a specified agent for processing.",
+		Params: []Param{
+			{Name: "agent_id", Type: ParamString, Required: true, Description: "ID of the agent to execute the task"},
+			{Name: "prompt", Type: ParamString, Required: true, Description: "The prompt/instruction for the agent to process"},
+			{Name: "workspace_id", Type: ParamString, Description: "Target workspace ID (optional, defaults to the active workspace, see set_active_workspace)"},
+			{Name: "tools", Type: ParamString, Description: "Comma-separated list of tools to enable for the agent (optional, e.g. 'search,calculator,browser')"},
+			{Name: "model", Type: ParamString, Description: "AI model to use (optional, uses agent's default model if not specified)"},
+			{Name: "include_local_context", Type: ParamBoolean, Description: "Append a compact block with the local project state (current branch, changed files, recent commits, detected stack) to the prompt (optional, defaults to mcp_server.project_context.enabled)"},
+		},
+	}
+
+	listTemplatesSpec = ToolSpec{
+		Name:        "list_templates",
+		Description: "List locally defined task templates. Returns each template's agent, prompt placeholders, tools and model.",
+		ReadOnly:    true,
+	}
+
+	executeTemplateSpec = ToolSpec{
+		Name:        "execute_template",
+		Description: "Execute a locally defined task template. Fills the prompt placeholders with the given variables and submits the task to the template's agent.",
+		Params: []Param{
+			{Name: "template", Type: ParamString, Required: true, Description: "Template name (see list_templates)"},
+			{Name: "vars", Type: ParamString, JSON: JSONObject, Description: "Placeholder values as JSON object string (optional, e.g. '{\"env\":\"prod\"}'; template defaults apply to omitted variables)"},
+			{Name: "workspace_id", Type: ParamString, Description: "Target workspace ID (optional, defaults to the active workspace, see set_active_workspace)"},
+		},
+	}
+
+	listAgentsSpec = ToolSpec{
+		Name:        "list_agents",
+		Description: "List available Autopus agents. Returns agents accessible in the specified workspace.",
+		Params: []Param{
+			{Name: "workspace_id", Type: ParamString, Description: "Workspace ID to list agents for (optional, defaults to the active workspace, see set_active_workspace)"},
+			{Name: "filter", Type: ParamString, Description: "Filter agents by name or capability (optional, case-insensitive partial match)"},
+			{Name: "limit", Type: ParamInteger, Min: floatPtr(1), Description: "Maximum number of agents per page (optional, backend default if omitted)"},
+			{Name: "offset", Type: ParamInteger, Min: floatPtr(0), Description: "Number of agents to skip (optional, ignored when cursor is set)"},
+			{Name: "cursor", Type: ParamString, Description: "Pagination cursor from a previous response's next_cursor (optional)"},
+			{Name: "all", Type: ParamBoolean, Description: "Fetch all pages and aggregate them (optional, stops at the configured maximum and sets truncated)"},
+		},
+		ReadOnly: true,
+	}
+
+	getExecutionStatusSpec = ToolSpec{
+		Name:        "get_execution_status",
+		Description: "Get the status of a task execution. Returns current state, result, or error information.",
+		Params: []Param{
+			{Name: "execution_id", Type: ParamString, Required: true, Description: "The execution ID returned from execute_task"},
+		},
+		ReadOnly: true,
+	}
+
+	getExecutionDiffSpec = ToolSpec{
+		Name:        "get_execution_diff",
+		Description: "Get the file changes an execution made in its isolated work directory as a unified diff. Use this to review agent changes before approve_execution.",
+		Params: []Param{
+			{Name: "execution_id", Type: ParamString, Required: true, Description: "The execution ID returned from execute_task"},
+			{Name: "path", Type: ParamString, Description: "Only include changes to this file path, relative to the work directory (optional)"},
+		},
+		ReadOnly: true,
+	}
+
+	approveExecutionSpec = ToolSpec{
+		Name:        "approve_execution",
+		Description: "Approve or reject a pending task execution that requires human review.",
+		Params: []Param{
+			{Name: "execution_id", Type: ParamString, Required: true, Description: "The execution ID to approve or reject"},
+			{Name: "decision", Type: ParamString, Required: true, Enum: []string{"approve", "reject"}, Description: "Decision: 'approve' or 'reject'"},
+			{Name: "reason", Type: ParamString, Description: "Reason for the decision (recommended for rejections)"},
+		},
+	}
+
+	manageWorkspaceSpec = ToolSpec{
+		Name:        "manage_workspace",
+		Description: "Manage Autopus workspaces. Supports getting, listing, creating, updating, and deleting workspaces.",
+		Params: []Param{
+			{Name: "action", Type: ParamString, Required: true, Enum: []string{"get", "list", "create", "update", "delete"}, Description: "Action to perform"},
+			{
+				Name:        "workspace_id",
+				Type:        ParamString,
+				RequiredIf:  &Condition{Param: "action", Values: []string{"get", "update", "delete"}},
+				Description: "Workspace ID (required for get/update/delete)",
+			},
+			{Name: "config", Type: ParamString, JSON: JSONObject, Description: "Workspace configuration as JSON string (optional, used for create/update)"},
+		},
+		ReadOnlyActions: []string{"get", "list"},
+	}
+
+	searchKnowledgeSpec = ToolSpec{
+		Name:        "search_knowledge",
+		Description: "Search the Autopus knowledge base. Finds relevant documents and information.",
+		Params: []Param{
+			{Name: "query", Type: ParamString, Required: true, Description: "Search query string"},
+			{Name: "workspace_id", Type: ParamString, Description: "Workspace ID to search within (optional, defaults to the active workspace, see set_active_workspace)"},
+			{
+				Name:        "limit",
+				Type:        ParamInteger,
+				Min:         floatPtr(1),
+				Max:         floatPtr(50),
+				Clamp:       true,
+				Default:     10,
+				Description: "Maximum number of results to return (default: 10, max: 50)",
+			},
+			{Name: "filters", Type: ParamString, JSON: JSONObject, Description: "Filter criteria as JSON string (optional, e.g. '{\"source\":\"docs\",\"type\":\"article\"}')"},
+			{Name: "local_rerank", Type: ParamBoolean, Description: "Rerank results on the client, boosting those that mention the local project's languages/frameworks or recently changed files (optional, default: false). Useful for code-related queries."},
+		},
+		ReadOnly: true,
+	}
+
+	getAgentDetailsSpec = ToolSpec{
+		Name:        "get_agent_details",
+		Description: "Get detailed information about an Autopus agent, including configured tools with parameter schemas, model, workflow steps, and recent execution stats. Use this before execute_task to build correct calls.",
+		Params: []Param{
+			{Name: "agent_id", Type: ParamString, Required: true, Description: "ID of the agent to inspect"},
+			{Name: "workspace_id", Type: ParamString, Description: "Workspace ID the agent belongs to (optional, defaults to the active workspace, see set_active_workspace)"},
+		},
+		ReadOnly: true,
+	}
+
+	getKnowledgeDocumentSpec = ToolSpec{
+		Name:        "get_knowledge_document",
+		Description: "Get the full content of an Autopus knowledge document by ID. Use this after search_knowledge to read a matching document in full.",
+		Params: []Param{
+			{Name: "document_id", Type: ParamString, Required: true, Description: "ID of the knowledge document (the id field of a search_knowledge result)"},
+			{Name: "workspace_id", Type: ParamString, Description: "Workspace ID the document belongs to (optional, defaults to the active workspace, see set_active_workspace)"},
+		},
+		ReadOnly: true,
+	}
+
+	listKnowledgeSourcesSpec = ToolSpec{
+		Name:        "list_knowledge_sources",
+		Description: "List the knowledge sources (synced folders) of an Autopus workspace, including their sync status and file counts.",
+		Params: []Param{
+			{Name: "workspace_id", Type: ParamString, Description: "Workspace ID to list sources for (optional, defaults to the active workspace, see set_active_workspace)"},
+		},
+		ReadOnly: true,
+	}
+)
+
 // registerTools는 모든 MCP 도구를 등록합니다.
 func (s *Server) registerTools() {
-	// 1. execute_task - Autopus 에이전트 태스크 실행
-	executeTaskTool := mcp.NewTool("execute_task",
-		mcp.WithDescription("Execute an Autopus agent task. Sends a prompt to a specified agent for processing."),
-		mcp.WithString("agent_id",
-			mcp.Required(),
-			mcp.Description("ID of the agent to execute the task"),
-		),
-		mcp.WithString("prompt",
-			mcp.Required(),
-			mcp.Description("The prompt/instruction for the agent to process"),
-		),
-		mcp.WithString("workspace_id",
-			mcp.Description("Target workspace ID (optional, uses default workspace if not specified)"),
-		),
-		mcp.WithString("tools",
-			mcp.Description("Comma-separated list of tools to enable for the agent (optional, e.g. 'search,calculator,browser')"),
-		),
-		mcp.WithString("model",
-			mcp.Description("AI model to use (optional, uses agent's default model if not specified)"),
-		),
-	)
-	s.mcpServer.AddTool(executeTaskTool, s.handleExecuteTask)
-
-	// 2. list_agents - 사용 가능한 에이전트 목록
-	listAgentsTool := mcp.NewTool("list_agents",
-		mcp.WithDescription("List available Autopus agents. Returns agents accessible in the specified workspace."),
-		mcp.WithString("workspace_id",
-			mcp.Description("Workspace ID to filter agents (optional, lists all accessible agents if not specified)"),
-		),
-		mcp.WithString("filter",
-			mcp.Description("Filter agents by name or capability (optional, case-insensitive partial match)"),
-		),
-	)
-	s.mcpServer.AddTool(listAgentsTool, s.handleListAgents)
-
-	// 3. get_execution_status - 태스크 실행 상태 조회
-	getStatusTool := mcp.NewTool("get_execution_status",
-		mcp.WithDescription("Get the status of a task execution. Returns current state, result, or error information."),
-		mcp.WithString("execution_id",
-			mcp.Required(),
-			mcp.Description("The execution ID returned from execute_task"),
-		),
-	)
-	s.mcpServer.AddTool(getStatusTool, s.handleGetExecutionStatus)
-
-	// 4. approve_execution - 실행 승인/거부
-	approveExecutionTool := mcp.NewTool("approve_execution",
-		mcp.WithDescription("Approve or reject a pending task execution that requires human review."),
-		mcp.WithString("execution_id",
-			mcp.Required(),
-			mcp.Description("The execution ID to approve or reject"),
-		),
-		mcp.WithString("decision",
-			mcp.Required(),
-			mcp.Description("Decision: 'approve' or 'reject'"),
-			mcp.Enum("approve", "reject"),
-		),
-		mcp.WithString("reason",
-			mcp.Description("Reason for the decision (recommended for rejections)"),
-		),
-	)
-	s.mcpServer.AddTool(approveExecutionTool, s.handleApproveExecution)
-
-	// 5. manage_workspace - 워크스페이스 관리
-	manageWorkspaceTool := mcp.NewTool("manage_workspace",
-		mcp.WithDescription("Manage Autopus workspaces. Supports getting, listing, creating, updating, and deleting workspaces."),
-		mcp.WithString("action",
-			mcp.Required(),
-			mcp.Description("Action to perform"),
-			mcp.Enum("get", "list", "create", "update", "delete"),
-		),
-		mcp.WithString("workspace_id",
-			mcp.Description("Workspace ID (required for get/update/delete)"),
-		),
-		mcp.WithString("config",
-			mcp.Description("Workspace configuration as JSON string (optional, used for create/update)"),
-		),
-	)
-	s.mcpServer.AddTool(manageWorkspaceTool, s.handleManageWorkspace)
-
-	// 6. search_knowledge - 지식 베이스 검색
-	searchKnowledgeTool := mcp.NewTool("search_knowledge",
-		mcp.WithDescription("Search the Autopus knowledge base. Finds relevant documents and information."),
-		mcp.WithString("query",
-			mcp.Required(),
-			mcp.Description("Search query string"),
-		),
-		mcp.WithString("workspace_id",
-			mcp.Description("Workspace ID to search within (optional)"),
-		),
-		mcp.WithNumber("limit",
-			mcp.Description("Maximum number of results to return (default: 10, max: 50)"),
-		),
-		mcp.WithString("filters",
-			mcp.Description("Filter criteria as JSON string (optional, e.g. '{\"source\":\"docs\",\"type\":\"article\"}')"),
-		),
-	)
-	s.mcpServer.AddTool(searchKnowledgeTool, s.handleSearchKnowledge)
+	s.addTool(executeTaskSpec, s.handleExecuteTask)
+	s.addTool(listAgentsSpec, s.handleListAgents)
+	s.addTool(listTemplatesSpec, s.handleListTemplates)
+	s.addTool(executeTemplateSpec, s.handleExecuteTemplate)
+	s.addTool(getExecutionStatusSpec, s.handleGetExecutionStatus)
+	s.addTool(getExecutionDiffSpec, s.handleGetExecutionDiff)
+	s.addTool(approveExecutionSpec, s.handleApproveExecution)
+	s.addTool(manageWorkspaceSpec, s.handleManageWorkspace)
+	s.addTool(searchKnowledgeSpec, s.handleSearchKnowledge)
+	s.addTool(getAgentDetailsSpec, s.handleGetAgentDetails)
+	s.addTool(getKnowledgeDocumentSpec, s.handleGetKnowledgeDocument)
+	s.addTool(listKnowledgeSourcesSpec, s.handleListKnowledgeSources)
+	s.addTool(setActiveWorkspaceSpec, s.handleSetActiveWorkspace)
+	s.addTool(manageAgentSpec, s.handleManageAgent)
+
+	s.logger.Debug().Msg("MCP 도구 14개 등록 완료")
+}
 
-	s.logger.Debug().Msg("MCP 도구 6개 등록 완료")
+// addTool은 도구 호출마다 권한 확인, 동시 실행 제한, 트레이싱 스팬, 통계 기록을 하도록 핸들러를 감싸 등록합니다.
+// 설정에서 비활성화된 도구는 등록하지 않습니다.
+func (s *Server) addTool(spec ToolSpec, handler server.ToolHandlerFunc) {
+	if s.toolPermission(spec.Name).Disabled {
+		s.logger.Info().Str("tool", spec.Name).Msg("설정에서 비활성화된 MCP 도구, 등록 생략")
+		return
+	}
+	s.mcpServer.AddTool(spec.Tool(), tracedToolHandler(spec.Name, s.countedToolHandler(spec.Name, s.permittedToolHandler(spec, s.limitedToolHandler(spec.Name, s.timedToolHandler(spec.Name, handler))))))
+}
+
+// countedToolHandler는 도구 호출 횟수, 지연 시간, 에러를 통계에 기록합니다.
+func (s *Server) countedToolHandler(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := handler(ctx, req)
+		s.stats.RecordToolCall(name, time.Since(start), toolResultError(result, err))
+		return result, err
+	}
+}
+
+// tracedToolHandler는 MCP 도구 핸들러 실행을 스팬으로 감쌉니다.
+// 핸들러가 에러 결과(IsError)를 반환하면 스팬 상태도 에러로 기록합니다.
+func tracedToolHandler(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, span := tracing.Start(ctx, "mcp.tool "+name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("mcp.tool.name", name)),
+		)
+		result, err := handler(ctx, req)
+		if err == nil && result != nil && result.IsError {
+			span.SetStatus(codes.Error, "tool returned an error result")
+		}
+		tracing.End(span, err)
+		return result, err
+	}
 }
 
 // registerResources는 모든 MCP 리소스를 등록합니다.
@@ -211,5 +390,32 @@ func (s *Server) registerResources() {
 	)
 	s.mcpServer.AddResource(agentsResource, s.handleAgentsResource)
 
-	s.logger.Debug().Msg("MCP 리소스 4개 등록 완료")
+	// 5. autopus://stats - 로컬 텔레메트리
+	statsResource := mcp.NewResource(
+		ResourceStats,
+		"Local Stats",
+		mcp.WithResourceDescription("Local telemetry: tool call counts, error rates, cache hit rate, average backend latency, and recent errors"),
+		mcp.WithMIMEType("application/json"),
+	)
+	s.mcpServer.AddResource(statsResource, s.handleStatsResource)
+
+	// 6. autopus://bridge/config - 민감 값을 가린 로컬 Bridge 설정
+	bridgeConfigResource := mcp.NewResource(
+		ResourceBridgeConfig,
+		"Bridge Config",
+		mcp.WithResourceDescription("Local bridge configuration with API keys, tokens and other secrets redacted"),
+		mcp.WithMIMEType("application/json"),
+	)
+	s.mcpServer.AddResource(bridgeConfigResource, s.handleBridgeConfigResource)
+
+	// 7. autopus://bridge/capabilities - 로컬 프로바이더, 실행기 가용성, 버전 정보
+	bridgeCapabilitiesResource := mcp.NewResource(
+		ResourceBridgeCapabilities,
+		"Bridge Capabilities",
+		mcp.WithResourceDescription("What the local environment can do: detected AI providers, executor availability, and bridge/protocol version info"),
+		mcp.WithMIMEType("application/json"),
+	)
+	s.mcpServer.AddResource(bridgeCapabilitiesResource, s.handleBridgeCapabilitiesResource)
+
+	s.logger.Debug().Msg("MCP 리소스 7개 등록 완료")
 }
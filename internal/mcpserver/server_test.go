@@ -29,6 +29,43 @@ func TestNewServer(t *testing.T) {
 	}
 }
 
+// TestServer_HandleMessage는 stdio 없이 JSON-RPC 메시지를 처리하는지 테스트합니다.
+func TestServer_HandleMessage(t *testing.T) {
+	srv := NewServer(newTestClient("http://localhost:1"), zerolog.Nop())
+	ctx := context.Background()
+
+	resp, err := srv.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	if err != nil {
+		t.Fatalf("HandleMessage 에러: %v", err)
+	}
+	var decoded struct {
+		ID     int `json:"id"`
+		Result struct {
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &decoded); err != nil {
+		t.Fatalf("응답 파싱 실패: %v (%s)", err, resp)
+	}
+	if decoded.ID != 1 {
+		t.Errorf("응답 id = %d, want 1", decoded.ID)
+	}
+	if len(decoded.Result.Tools) == 0 {
+		t.Error("tools/list 응답에 도구가 있어야 합니다")
+	}
+
+	// 알림은 응답이 없습니다.
+	resp, err = srv.HandleMessage(ctx, json.RawMessage(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	if err != nil {
+		t.Fatalf("알림 처리 에러: %v", err)
+	}
+	if resp != nil {
+		t.Errorf("알림에는 응답이 없어야 합니다: %s", resp)
+	}
+}
+
 // TestToolHandler_ExecuteTask_MissingParams는 필수 파라미터 누락을 테스트합니다.
 func TestToolHandler_ExecuteTask_MissingParams(t *testing.T) {
 	logger := zerolog.Nop()
@@ -116,6 +153,160 @@ func TestToolHandler_ListAgents(t *testing.T) {
 	}
 }
 
+// TestToolHandler_ListAgents_AllPages는 all=true가 설정된 최대 개수까지 페이지를 모으는지 테스트합니다.
+func TestToolHandler_ListAgents_AllPages(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(pagedAgentsHandler(t, 30, &requests))
+	defer server.Close()
+
+	srv := NewServer(newTestClient(server.URL), zerolog.Nop())
+	srv.SetListAgentsMaxResults(12)
+
+	req := makeCallToolRequest("list_agents", map[string]interface{}{"limit": float64(5), "all": true})
+	result, err := srv.handleListAgents(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("성공 응답이어야 합니다: %+v", result.Content)
+	}
+
+	var resp ListAgentsResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	if len(resp.Agents) != 12 || !resp.Truncated || resp.Total != 30 {
+		t.Errorf("예상 12개/truncated true/total 30, 실제: %d/%v/%d", len(resp.Agents), resp.Truncated, resp.Total)
+	}
+	if requests != 3 {
+		t.Errorf("예상 요청 수 3, 실제: %d", requests)
+	}
+}
+
+// TestToolHandler_GetAgentDetails는 에이전트 상세 조회를 테스트합니다.
+func TestToolHandler_GetAgentDetails(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws-001/agents/agent-001" {
+			t.Errorf("예상하지 못한 경로: %s", r.URL.Path)
+		}
+		resp := apiResponse{
+			Success: true,
+			Data:    json.RawMessage(`{"id":"agent-001","name":"Test","tools":[{"name":"search"}]}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	srv := NewServer(newTestClient(server.URL), zerolog.Nop())
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "get_agent_details"
+	req.Params.Arguments = map[string]interface{}{
+		"agent_id":     "agent-001",
+		"workspace_id": "ws-001",
+	}
+
+	result, err := srv.handleGetAgentDetails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if result.IsError {
+		t.Errorf("성공 응답이어야 합니다")
+	}
+
+	// agent_id 누락
+	req.Params.Arguments = map[string]interface{}{}
+	result, err = srv.handleGetAgentDetails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러가 에러를 반환하면 안됩니다: %v", err)
+	}
+	if !result.IsError {
+		t.Error("필수 파라미터 누락 시 에러 응답이어야 합니다")
+	}
+}
+
+// TestToolHandler_GetKnowledgeDocument는 지식 문서 조회를 테스트합니다.
+func TestToolHandler_GetKnowledgeDocument(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws-001/knowledge/doc-001" {
+			t.Errorf("예상하지 못한 경로: %s", r.URL.Path)
+		}
+		resp := apiResponse{
+			Success: true,
+			Data:    json.RawMessage(`{"id":"doc-001","title":"Guide","content":"full body"}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	srv := NewServer(newTestClient(server.URL), zerolog.Nop())
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "get_knowledge_document"
+	req.Params.Arguments = map[string]interface{}{
+		"document_id":  "doc-001",
+		"workspace_id": "ws-001",
+	}
+
+	result, err := srv.handleGetKnowledgeDocument(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if result.IsError {
+		t.Errorf("성공 응답이어야 합니다")
+	}
+
+	// document_id 누락
+	req.Params.Arguments = map[string]interface{}{}
+	result, err = srv.handleGetKnowledgeDocument(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러가 에러를 반환하면 안됩니다: %v", err)
+	}
+	if !result.IsError {
+		t.Error("필수 파라미터 누락 시 에러 응답이어야 합니다")
+	}
+}
+
+// TestToolHandler_ListKnowledgeSources는 지식 소스 목록 조회를 테스트합니다.
+func TestToolHandler_ListKnowledgeSources(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/workspaces/ws-test-001/knowledge/folders" {
+			t.Errorf("예상하지 못한 경로: %s", r.URL.Path)
+		}
+		resp := apiResponse{
+			Success: true,
+			Data:    json.RawMessage(`{"folders":[{"id":"f-1","name":"docs"}]}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	srv := NewServer(newTestClient(server.URL), zerolog.Nop())
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "list_knowledge_sources"
+
+	result, err := srv.handleListKnowledgeSources(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("성공 응답이어야 합니다")
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	var resp ListKnowledgeSourcesResponse
+	if err := json.Unmarshal([]byte(text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	if resp.Total != 1 || resp.Sources[0].ID != "f-1" {
+		t.Errorf("예상하지 못한 응답: %+v", resp)
+	}
+}
+
 // TestToolHandler_GetExecutionStatus_MissingID는 execution_id 누락을 테스트합니다.
 func TestToolHandler_GetExecutionStatus_MissingID(t *testing.T) {
 	logger := zerolog.Nop()
@@ -632,6 +823,89 @@ func TestToolHandler_GetExecutionStatus_BackendError(t *testing.T) {
 	}
 }
 
+// TestToolHandler_GetExecutionDiff_Success는 실행 기록의 변경 사항 조회와 경로 필터를 테스트합니다.
+func TestToolHandler_GetExecutionDiff_Success(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/executions/exec-001" {
+			t.Errorf("예상하지 못한 경로: %s", r.URL.Path)
+		}
+		resp := apiResponse{
+			Success: true,
+			Data: json.RawMessage(`{"execution_id":"exec-001","status":"pending_approval","result":{"output":"done","workspace_changes":{` +
+				`"files":[{"path":"main.go","status":"modified"},{"path":"README.md","status":"modified"}],` +
+				`"diff":"--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-a\n+b\n--- a/README.md\n+++ b/README.md\n@@ -1 +1 @@\n-x\n+y\n",` +
+				`"applied":false,"isolated_dir":"/tmp/iso"}}}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	mockServer := httptest.NewServer(handler)
+	defer mockServer.Close()
+
+	srv := NewServer(newTestClient(mockServer.URL), zerolog.Nop())
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "get_execution_diff"
+	req.Params.Arguments = map[string]interface{}{
+		"execution_id": "exec-001",
+		"path":         "main.go",
+	}
+
+	result, err := srv.handleGetExecutionDiff(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("성공 응답이어야 합니다: %+v", result.Content)
+	}
+	var resp ExecutionDiff
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	if !resp.HasChanges || len(resp.Files) != 1 || resp.Files[0].Path != "main.go" {
+		t.Errorf("예상하지 못한 변경 파일: %+v", resp)
+	}
+	if resp.Diff != "--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-a\n+b\n" {
+		t.Errorf("예상하지 못한 diff: %q", resp.Diff)
+	}
+	if resp.Applied || resp.IsolatedDir != "/tmp/iso" || resp.Status != "pending_approval" {
+		t.Errorf("예상하지 못한 응답: %+v", resp)
+	}
+}
+
+// TestToolHandler_GetExecutionDiff_NoChanges는 변경 정보가 없는 실행을 테스트합니다.
+func TestToolHandler_GetExecutionDiff_NoChanges(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := apiResponse{
+			Success: true,
+			Data:    json.RawMessage(`{"execution_id":"exec-002","status":"completed","result":"plain output"}`),
+		}
+		json.NewEncoder(w).Encode(resp)
+	})
+	mockServer := httptest.NewServer(handler)
+	defer mockServer.Close()
+
+	srv := NewServer(newTestClient(mockServer.URL), zerolog.Nop())
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "get_execution_diff"
+	req.Params.Arguments = map[string]interface{}{"execution_id": "exec-002"}
+
+	result, err := srv.handleGetExecutionDiff(context.Background(), req)
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if result.IsError {
+		t.Fatal("변경이 없어도 성공 응답이어야 합니다")
+	}
+	var resp ExecutionDiff
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	if resp.HasChanges || resp.Diff != "" {
+		t.Errorf("변경 정보가 없어야 합니다: %+v", resp)
+	}
+}
+
 // TestToolHandler_ApproveExecution_Success는 실행 승인 성공을 테스트합니다.
 func TestToolHandler_ApproveExecution_Success(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
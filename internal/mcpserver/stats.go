@@ -0,0 +1,268 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ResourceStats는 로컬 텔레메트리 리소스 URI입니다.
+const ResourceStats = "autopus://stats"
+
+// maxRecentErrors는 통계에 보관하는 최근 에러 개수입니다.
+const maxRecentErrors = 20
+
+// Stats는 MCP 서버의 로컬 텔레메트리를 수집합니다.
+// 도구 호출 수/에러율, 캐시 적중률, 백엔드 지연 시간, 최근 에러를 메모리에만 보관하며
+// 외부로 전송하지 않습니다.
+type Stats struct {
+	mu        sync.Mutex
+	startedAt time.Time
+
+	tools map[string]*toolStats
+
+	cacheHits   int64
+	cacheMisses int64
+
+	backendRequests int64
+	backendErrors   int64
+	backendRetries  int64
+	backendLatency  time.Duration
+	backendMax      time.Duration
+
+	// recentErrors는 최근 에러의 링 버퍼이며 next가 다음 쓰기 위치입니다.
+	recentErrors []StatsError
+	next         int
+}
+
+// toolStats는 도구별 누적 통계입니다.
+type toolStats struct {
+	calls    int64
+	errors   int64
+	duration time.Duration
+}
+
+// StatsError는 최근 발생한 에러 정보입니다.
+type StatsError struct {
+	Time    string `json:"time"`
+	Source  string `json:"source"`
+	Message string `json:"message"`
+}
+
+// ToolStatsSnapshot은 도구별 통계 스냅샷입니다.
+type ToolStatsSnapshot struct {
+	Calls        int64   `json:"calls"`
+	Errors       int64   `json:"errors"`
+	ErrorRate    float64 `json:"error_rate"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// CacheStatsSnapshot은 리소스 캐시 통계 스냅샷입니다.
+type CacheStatsSnapshot struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// BackendStatsSnapshot은 백엔드 API 호출 통계 스냅샷입니다.
+type BackendStatsSnapshot struct {
+	Requests     int64   `json:"requests"`
+	Errors       int64   `json:"errors"`
+	ErrorRate    float64 `json:"error_rate"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+	Retries      int64   `json:"retries"`
+	// Circuit은 서킷 브레이커 상태입니다 (비활성화 시 생략).
+	Circuit *CircuitSnapshot `json:"circuit,omitempty"`
+}
+
+// StatsSnapshot은 autopus://stats 리소스 응답입니다.
+type StatsSnapshot struct {
+	StartedAt     string                       `json:"started_at"`
+	UptimeSeconds int64                        `json:"uptime_seconds"`
+	Tools         map[string]ToolStatsSnapshot `json:"tools"`
+	Cache         CacheStatsSnapshot           `json:"cache"`
+	Backend       BackendStatsSnapshot         `json:"backend"`
+	RecentErrors  []StatsError                 `json:"recent_errors"`
+	// Concurrency는 도구 호출 동시 실행 한도 상태입니다 (비활성화 시 생략).
+	Concurrency *ConcurrencySnapshot `json:"concurrency,omitempty"`
+}
+
+// NewStats는 빈 통계 수집기를 생성합니다.
+func NewStats() *Stats {
+	return &Stats{
+		startedAt: time.Now(),
+		tools:     make(map[string]*toolStats),
+	}
+}
+
+// RecordToolCall은 도구 호출 결과를 기록합니다.
+// errMsg가 비어 있지 않으면 에러로 집계하고 최근 에러에 추가합니다.
+func (s *Stats) RecordToolCall(name string, duration time.Duration, errMsg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ts, ok := s.tools[name]
+	if !ok {
+		ts = &toolStats{}
+		s.tools[name] = ts
+	}
+	ts.calls++
+	ts.duration += duration
+	if errMsg != "" {
+		ts.errors++
+		s.addErrorLocked("tool:"+name, errMsg)
+	}
+}
+
+// RecordCache는 리소스 캐시 조회 결과를 기록합니다.
+func (s *Stats) RecordCache(hit bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if hit {
+		s.cacheHits++
+	} else {
+		s.cacheMisses++
+	}
+}
+
+// RecordBackend는 백엔드 API 호출 지연 시간과 결과를 기록합니다.
+func (s *Stats) RecordBackend(method, path string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.backendRequests++
+	s.backendLatency += duration
+	if duration > s.backendMax {
+		s.backendMax = duration
+	}
+	if err != nil {
+		s.backendErrors++
+		s.addErrorLocked(fmt.Sprintf("backend:%s %s", method, path), err.Error())
+	}
+}
+
+// RecordRetry는 백엔드 요청 재시도를 기록합니다.
+func (s *Stats) RecordRetry() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backendRetries++
+}
+
+// addErrorLocked는 최근 에러 링 버퍼에 에러를 추가합니다. 호출자가 mu를 잡고 있어야 합니다.
+func (s *Stats) addErrorLocked(source, message string) {
+	e := StatsError{
+		Time:    time.Now().UTC().Format(time.RFC3339),
+		Source:  source,
+		Message: message,
+	}
+	if len(s.recentErrors) < maxRecentErrors {
+		s.recentErrors = append(s.recentErrors, e)
+		return
+	}
+	s.recentErrors[s.next] = e
+	s.next = (s.next + 1) % maxRecentErrors
+}
+
+// Snapshot은 현재 통계의 복사본을 반환합니다.
+// 최근 에러는 최신 항목이 먼저 오도록 정렬됩니다.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := StatsSnapshot{
+		StartedAt:     s.startedAt.UTC().Format(time.RFC3339),
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		Tools:         make(map[string]ToolStatsSnapshot, len(s.tools)),
+		Cache: CacheStatsSnapshot{
+			Hits:    s.cacheHits,
+			Misses:  s.cacheMisses,
+			HitRate: ratio(s.cacheHits, s.cacheHits+s.cacheMisses),
+		},
+		Backend: BackendStatsSnapshot{
+			Requests:     s.backendRequests,
+			Errors:       s.backendErrors,
+			ErrorRate:    ratio(s.backendErrors, s.backendRequests),
+			AvgLatencyMs: avgMillis(s.backendLatency, s.backendRequests),
+			MaxLatencyMs: float64(s.backendMax) / float64(time.Millisecond),
+			Retries:      s.backendRetries,
+		},
+		RecentErrors: make([]StatsError, 0, len(s.recentErrors)),
+	}
+
+	for name, ts := range s.tools {
+		snap.Tools[name] = ToolStatsSnapshot{
+			Calls:        ts.calls,
+			Errors:       ts.errors,
+			ErrorRate:    ratio(ts.errors, ts.calls),
+			AvgLatencyMs: avgMillis(ts.duration, ts.calls),
+		}
+	}
+
+	// 링 버퍼의 마지막 쓰기 위치(next-1)부터 거꾸로 읽어 최신 순으로 만든다.
+	n := len(s.recentErrors)
+	for i := 0; i < n; i++ {
+		snap.RecentErrors = append(snap.RecentErrors, s.recentErrors[(s.next-1-i+n)%n])
+	}
+
+	return snap
+}
+
+// ratio는 total이 0이면 0을, 아니면 part/total을 반환합니다.
+func ratio(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// avgMillis는 누적 시간의 평균을 밀리초 단위로 반환합니다.
+func avgMillis(total time.Duration, count int64) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count) / float64(time.Millisecond)
+}
+
+// toolResultError는 도구 핸들러 결과에서 에러 메시지를 추출합니다.
+// 에러가 아니면 빈 문자열을 반환합니다.
+func toolResultError(result *mcp.CallToolResult, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if result == nil || !result.IsError {
+		return ""
+	}
+	for _, content := range result.Content {
+		if text, ok := mcp.AsTextContent(content); ok && text.Text != "" {
+			return text.Text
+		}
+	}
+	return "tool returned an error result"
+}
+
+// handleStatsResource는 autopus://stats 리소스 핸들러입니다.
+// 도구 호출, 캐시, 백엔드 지연 시간 통계, 서킷 브레이커와 동시 실행 한도 상태, 최근 에러를 반환합니다.
+func (s *Server) handleStatsResource(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	snap := s.stats.Snapshot()
+	if s.client.breaker != nil {
+		circuit := s.client.breaker.Snapshot()
+		snap.Backend.Circuit = &circuit
+	}
+	if limiter := s.limiter.Load(); limiter != nil {
+		concurrency := limiter.Snapshot()
+		snap.Concurrency = &concurrency
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("통계 직렬화 실패: %w", err)
+	}
+	return []mcp.ResourceContents{
+		newTextResource(request.Params.URI, string(data), "application/json"),
+	}, nil
+}
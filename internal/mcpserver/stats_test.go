@@ -0,0 +1,129 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+)
+
+// readStats는 autopus://stats 리소스를 읽어 스냅샷으로 반환합니다.
+func readStats(t *testing.T, srv *Server) StatsSnapshot {
+	t.Helper()
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = ResourceStats
+
+	contents, err := srv.handleStatsResource(context.Background(), req)
+	if err != nil {
+		t.Fatalf("리소스 핸들러 오류: %v", err)
+	}
+	var snap StatsSnapshot
+	if err := json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &snap); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	return snap
+}
+
+// TestStatsResource_ToolCalls는 도구 호출 수와 에러율 집계를 테스트합니다.
+func TestStatsResource_ToolCalls(t *testing.T) {
+	srv := NewServer(newTestClient("http://localhost:1"), zerolog.Nop())
+
+	handler := srv.countedToolHandler("list_agents", func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if req.Params.Name == "fail" {
+			return mcp.NewToolResultError("agent not found"), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+	for _, name := range []string{"ok", "ok", "ok", "fail"} {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = name
+		if _, err := handler(context.Background(), req); err != nil {
+			t.Fatalf("핸들러 오류: %v", err)
+		}
+	}
+
+	snap := readStats(t, srv)
+	tool, ok := snap.Tools["list_agents"]
+	if !ok {
+		t.Fatalf("list_agents 통계가 없습니다: %+v", snap.Tools)
+	}
+	if tool.Calls != 4 || tool.Errors != 1 {
+		t.Errorf("calls = %d, errors = %d, want 4, 1", tool.Calls, tool.Errors)
+	}
+	if tool.ErrorRate != 0.25 {
+		t.Errorf("error_rate = %v, want 0.25", tool.ErrorRate)
+	}
+	if len(snap.RecentErrors) != 1 || snap.RecentErrors[0].Message != "agent not found" || snap.RecentErrors[0].Source != "tool:list_agents" {
+		t.Errorf("recent_errors = %+v", snap.RecentErrors)
+	}
+}
+
+// TestStatsResource_CacheAndBackend는 캐시 적중률과 백엔드 지연 시간 집계를 테스트합니다.
+func TestStatsResource_CacheAndBackend(t *testing.T) {
+	var calls atomic.Int32
+	backend := newCountingAgentsServer(t, &calls)
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop(), time.Minute)
+
+	readAgentsTotal(t, srv, nil)
+	readAgentsTotal(t, srv, nil)
+	readAgentsTotal(t, srv, nil)
+
+	snap := readStats(t, srv)
+	if snap.Cache.Hits != 2 || snap.Cache.Misses != 1 {
+		t.Errorf("cache = %+v, want 2 hits, 1 miss", snap.Cache)
+	}
+	if snap.Backend.Requests != 1 || snap.Backend.Errors != 0 {
+		t.Errorf("backend = %+v, want 1 request, 0 errors", snap.Backend)
+	}
+	if snap.Backend.AvgLatencyMs <= 0 {
+		t.Errorf("avg_latency_ms = %v, want > 0", snap.Backend.AvgLatencyMs)
+	}
+}
+
+// TestStatsResource_BackendError는 백엔드 에러가 최근 에러에 기록되는지 테스트합니다.
+func TestStatsResource_BackendError(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("bad gateway"))
+	}))
+	defer backend.Close()
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop())
+
+	if _, err := srv.client.GetExecutionStatus(context.Background(), "exec-1"); err == nil {
+		t.Fatal("502 응답인데 에러가 반환되지 않았습니다")
+	}
+
+	snap := readStats(t, srv)
+	if snap.Backend.Requests != 1 || snap.Backend.ErrorRate != 1 {
+		t.Errorf("backend = %+v", snap.Backend)
+	}
+	if len(snap.RecentErrors) != 1 || snap.RecentErrors[0].Source != "backend:GET /api/v1/executions/exec-1" {
+		t.Errorf("recent_errors = %+v", snap.RecentErrors)
+	}
+}
+
+// TestStats_RecentErrorsRing은 최근 에러가 최신 순으로 최대 maxRecentErrors개 유지되는지 테스트합니다.
+func TestStats_RecentErrorsRing(t *testing.T) {
+	stats := NewStats()
+	for i := 0; i < maxRecentErrors+5; i++ {
+		stats.RecordBackend("GET", "/x", time.Millisecond, fmt.Errorf("err-%d", i))
+	}
+
+	snap := stats.Snapshot()
+	if len(snap.RecentErrors) != maxRecentErrors {
+		t.Fatalf("recent_errors 길이 = %d, want %d", len(snap.RecentErrors), maxRecentErrors)
+	}
+	if got := snap.RecentErrors[0].Message; got != fmt.Sprintf("err-%d", maxRecentErrors+4) {
+		t.Errorf("첫 항목 = %q, 최신 에러여야 합니다", got)
+	}
+	if got := snap.RecentErrors[maxRecentErrors-1].Message; got != "err-5" {
+		t.Errorf("마지막 항목 = %q, want err-5", got)
+	}
+}
@@ -0,0 +1,147 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TemplateInfo는 list_templates 도구가 반환하는 템플릿 정보입니다.
+type TemplateInfo struct {
+	tasktemplate.Template
+	// Placeholders는 프롬프트의 자리표시자 이름입니다 (execute_template의 vars 키).
+	Placeholders []string `json:"placeholders,omitempty"`
+}
+
+// ListTemplatesResponse는 list_templates 도구 응답입니다.
+type ListTemplatesResponse struct {
+	Templates []TemplateInfo `json:"templates"`
+	Total     int            `json:"total"`
+}
+
+// SetTemplates는 list_templates/execute_template 도구가 사용할 작업 템플릿을 교체합니다.
+// nil이면 템플릿이 없는 것으로 취급합니다.
+func (s *Server) SetTemplates(registry *tasktemplate.Registry) {
+	s.templatesMu.Lock()
+	defer s.templatesMu.Unlock()
+	s.templates = registry
+}
+
+// taskTemplates는 현재 작업 템플릿을 반환합니다.
+func (s *Server) taskTemplates() *tasktemplate.Registry {
+	s.templatesMu.RLock()
+	defer s.templatesMu.RUnlock()
+	return s.templates
+}
+
+// handleListTemplates는 list_templates 도구 핸들러입니다.
+// 로컬에 정의된 작업 템플릿 목록을 반환합니다.
+func (s *Server) handleListTemplates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if _, verr := listTemplatesSpec.Validate(request); verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	templates := s.taskTemplates().List()
+	resp := ListTemplatesResponse{Templates: make([]TemplateInfo, 0, len(templates)), Total: len(templates)}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, TemplateInfo{Template: t, Placeholders: t.Placeholders()})
+	}
+
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// handleExecuteTemplate는 execute_template 도구 핸들러입니다.
+// 템플릿 프롬프트를 렌더링하여 템플릿의 에이전트에 작업을 제출합니다.
+func (s *Server) handleExecuteTemplate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := executeTemplateSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	var vars map[string]string
+	if err := args.Decode("vars", &vars); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	workspaceID := s.workspaceArg(args)
+
+	tmpl, err := s.taskTemplates().Get(args.String("template"))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.execute_template_failed", err.Error())), nil
+	}
+	prompt, err := tmpl.Render(vars)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.execute_template_failed", err.Error())), nil
+	}
+
+	agentID, err := s.resolveTemplateAgent(ctx, tmpl, workspaceID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.execute_template_failed", err.Error())), nil
+	}
+
+	s.logger.Info().
+		Str("template", tmpl.Name).
+		Str("agent_id", agentID).
+		Str("workspace_id", workspaceID).
+		Strs("tools", tmpl.Tools).
+		Msg("템플릿 실행 요청")
+
+	resp, err := s.client.ExecuteTask(ctx, &ExecuteTaskRequest{
+		AgentID:     agentID,
+		Prompt:      prompt,
+		WorkspaceID: workspaceID,
+		Provider:    tmpl.Provider,
+		Tools:       tmpl.Tools,
+		Model:       tmpl.Model,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Str("template", tmpl.Name).Msg("템플릿 실행 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.execute_template_failed", err.Error())), nil
+	}
+
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// resolveTemplateAgent는 템플릿의 에이전트 ID를 반환합니다.
+// agent_id가 없으면 워크스페이스 에이전트 중 agent 이름이 (대소문자 무시) 일치하는 에이전트를 찾습니다.
+func (s *Server) resolveTemplateAgent(ctx context.Context, tmpl tasktemplate.Template, workspaceID string) (string, error) {
+	if tmpl.AgentID != "" {
+		return tmpl.AgentID, nil
+	}
+	if tmpl.Agent == "" {
+		return "", errors.New(i18n.T("mcp.template.agent_required", tmpl.Name))
+	}
+
+	resp, err := s.client.ListAgents(ctx, workspaceID, tmpl.Agent)
+	if err != nil {
+		return "", err
+	}
+	var matches []AgentInfo
+	for _, agent := range resp.Agents {
+		if strings.EqualFold(agent.Name, tmpl.Agent) {
+			matches = append(matches, agent)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", errors.New(i18n.T("mcp.template.agent_not_found", tmpl.Name, tmpl.Agent))
+	case 1:
+		return matches[0].ID, nil
+	default:
+		return "", errors.New(i18n.T("mcp.template.agent_ambiguous", tmpl.Name, tmpl.Agent))
+	}
+}
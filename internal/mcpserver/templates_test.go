@@ -0,0 +1,113 @@
+package mcpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+	"github.com/rs/zerolog"
+)
+
+// newTemplateTestServer는 에이전트 목록과 작업 실행을 흉내 내는 백엔드와 템플릿이 설정된 MCP 서버를 생성합니다.
+// 실행 요청 본문은 executed에 기록됩니다.
+func newTemplateTestServer(t *testing.T, executed *map[string]any) *Server {
+	t.Helper()
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/agents"):
+			data, _ := json.Marshal(ListAgentsResponse{Agents: []AgentInfo{
+				{ID: "agent-deployer", Name: "Deployer"},
+				{ID: "agent-deployer-2", Name: "Deployer Staging"},
+			}})
+			json.NewEncoder(w).Encode(apiResponse{Success: true, Data: data})
+		case strings.HasSuffix(r.URL.Path, "/execute"):
+			_ = json.NewDecoder(r.Body).Decode(executed)
+			data, _ := json.Marshal(ExecuteTaskResponse{ExecutionID: "exec-1", Status: "queued"})
+			json.NewEncoder(w).Encode(apiResponse{Success: true, Data: data})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(mockServer.Close)
+
+	registry, err := tasktemplate.Load([]config.TemplateConfig{
+		{Name: "deploy-check", Description: "배포 상태 점검", Agent: "deployer", Prompt: "{{env}} 환경 {{service}} 배포 점검",
+			Tools: []string{"kubectl"}, Model: "sonnet", Vars: map[string]string{"service": "api"}},
+		{Name: "no-agent", Prompt: "에이전트 없음"},
+	}, "")
+	if err != nil {
+		t.Fatalf("tasktemplate.Load 에러: %v", err)
+	}
+
+	srv := NewServer(newTestClient(mockServer.URL), zerolog.Nop())
+	srv.SetTemplates(registry)
+	return srv
+}
+
+// TestHandleListTemplates는 템플릿 목록과 자리표시자를 반환하는지 테스트합니다.
+func TestHandleListTemplates(t *testing.T) {
+	srv := newTemplateTestServer(t, new(map[string]any))
+
+	isError, text, _ := callToolViaMessage(t, srv, "list_templates", map[string]any{})
+	if isError {
+		t.Fatalf("list_templates 실패: %s", text)
+	}
+	var resp ListTemplatesResponse
+	if err := json.Unmarshal([]byte(text), &resp); err != nil {
+		t.Fatalf("응답 파싱 실패: %v (%s)", err, text)
+	}
+	if resp.Total != 2 || resp.Templates[0].Name != "deploy-check" {
+		t.Fatalf("템플릿 목록 = %+v", resp)
+	}
+	if got := strings.Join(resp.Templates[0].Placeholders, ","); got != "env,service" {
+		t.Errorf("Placeholders = %s, want env,service", got)
+	}
+
+	srv.SetTemplates(nil)
+	if _, text, _ := callToolViaMessage(t, srv, "list_templates", map[string]any{}); !strings.Contains(text, `"total":0`) {
+		t.Errorf("템플릿이 없으면 빈 목록이어야 합니다: %s", text)
+	}
+}
+
+// TestHandleExecuteTemplate는 프롬프트 렌더링, 에이전트 이름 조회, 에러 처리를 테스트합니다.
+func TestHandleExecuteTemplate(t *testing.T) {
+	executed := map[string]any{}
+	srv := newTemplateTestServer(t, &executed)
+
+	isError, text, _ := callToolViaMessage(t, srv, "execute_template", map[string]any{
+		"template":     "deploy-check",
+		"vars":         `{"env":"prod"}`,
+		"workspace_id": "ws-1",
+	})
+	if isError {
+		t.Fatalf("execute_template 실패: %s", text)
+	}
+	if !strings.Contains(text, "exec-1") {
+		t.Errorf("실행 응답 = %s", text)
+	}
+	if executed["agent_id"] != "agent-deployer" {
+		t.Errorf("agent_id = %v, 이름이 정확히 일치하는 에이전트여야 합니다", executed["agent_id"])
+	}
+	if executed["prompt"] != "prod 환경 api 배포 점검" || executed["model"] != "sonnet" {
+		t.Errorf("실행 요청 = %v", executed)
+	}
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"없는 템플릿", map[string]any{"template": "missing", "workspace_id": "ws-1"}},
+		{"변수 누락", map[string]any{"template": "deploy-check", "workspace_id": "ws-1"}},
+		{"문자열이 아닌 변수", map[string]any{"template": "deploy-check", "vars": `{"env":1}`, "workspace_id": "ws-1"}},
+		{"에이전트 미지정", map[string]any{"template": "no-agent", "workspace_id": "ws-1"}},
+	}
+	for _, tt := range tests {
+		if isError, text, _ := callToolViaMessage(t, srv, "execute_template", tt.args); !isError {
+			t.Errorf("%s: 에러여야 합니다: %s", tt.name, text)
+		}
+	}
+}
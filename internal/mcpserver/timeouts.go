@@ -0,0 +1,59 @@
+package mcpserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TimeoutMetaKey는 도구 호출 _meta에서 호출별 타임아웃(초)을 지정하는 키입니다.
+const TimeoutMetaKey = "timeout_seconds"
+
+// SetTimeoutPolicy는 도구 호출 타임아웃 계층을 설정합니다.
+// 호출의 _meta.timeout_seconds, 도구 이름별 값, 기본값 순으로 적용하며 nil이면 타임아웃을 두지 않습니다.
+func (s *Server) SetTimeoutPolicy(policy *timeouts.Policy) {
+	s.timeouts.Store(policy)
+}
+
+// timedToolHandler는 타임아웃 계층에 따라 도구 핸들러의 실행 시간을 제한합니다.
+// 동시 실행 슬롯을 얻은 뒤에 적용되므로 대기 시간은 포함하지 않습니다.
+// 타임아웃이 만료되면 다른 실패와 구분되는 TIMEOUT 에러 결과를 반환합니다.
+func (s *Server) timedToolHandler(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		resolved := s.timeouts.Load().Resolve(name, requestedTimeout(req), 0)
+		if resolved.Timeout <= 0 {
+			return handler(ctx, req)
+		}
+		ctx, cancel := timeouts.WithTimeout(ctx, resolved)
+		defer cancel()
+
+		result, err := handler(ctx, req)
+		if te, ok := timeouts.FromContext(ctx); ok {
+			s.logger.Warn().
+				Str("tool", name).
+				Dur("timeout", te.Timeout).
+				Str("source", te.Source).
+				Msg("MCP 도구 호출 타임아웃")
+			return mcp.NewToolResultError(errcode.Format(errcode.Timeout, i18n.T("mcp.tool.timeout", name, te.Timeout, te.Source))), nil
+		}
+		return result, err
+	}
+}
+
+// requestedTimeout은 도구 호출 _meta에 지정된 타임아웃을 반환합니다 (없으면 0).
+func requestedTimeout(req mcp.CallToolRequest) time.Duration {
+	if req.Params.Meta == nil {
+		return 0
+	}
+	// JSON 숫자는 float64로 디코딩됩니다.
+	seconds, ok := req.Params.Meta.AdditionalFields[TimeoutMetaKey].(float64)
+	if !ok || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,59 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog"
+)
+
+// TestServer_ToolTimeout은 도구별 타임아웃이 만료되면 다른 실패와 구분되는 TIMEOUT 에러 결과를 반환하는지 테스트합니다.
+func TestServer_ToolTimeout(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{"execution_id":"exec-1","status":"running"}`)})
+	}))
+	defer backend.Close()
+
+	srv := NewServer(newTestClient(backend.URL), zerolog.Nop())
+	srv.SetTimeoutPolicy(&timeouts.Policy{PerKind: map[string]time.Duration{"get_execution_status": 30 * time.Millisecond}})
+
+	isError, text, _ := callToolViaMessage(t, srv, "get_execution_status", map[string]any{"execution_id": "exec-1"})
+	if !isError || !strings.HasPrefix(text, errcode.Timeout) {
+		t.Errorf("타임아웃 호출 = (%v, %q), want TIMEOUT 에러", isError, text)
+	}
+}
+
+// TestTimedToolHandler_RequestOverride는 호출의 _meta.timeout_seconds가 도구별 값보다 우선하는지 테스트합니다.
+func TestTimedToolHandler_RequestOverride(t *testing.T) {
+	srv := NewServer(newTestClient("http://127.0.0.1:0"), zerolog.Nop())
+	srv.SetTimeoutPolicy(&timeouts.Policy{PerKind: map[string]time.Duration{"slow": time.Hour}})
+
+	var got time.Duration
+	handler := srv.timedToolHandler("slow", func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		deadline, _ := ctx.Deadline()
+		got = time.Until(deadline)
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	var req mcp.CallToolRequest
+	req.Params.Meta = &mcp.Meta{AdditionalFields: map[string]any{TimeoutMetaKey: float64(5)}}
+	result, err := handler(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("handler = (%+v, %v)", result, err)
+	}
+	if got <= 0 || got > 5*time.Second {
+		t.Errorf("적용된 타임아웃 = %s, want 5초 이하", got)
+	}
+}
@@ -3,39 +3,26 @@ package mcpserver
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"strings"
 
+	"github.com/insajin/autopus-bridge/internal/i18n"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // handleExecuteTask는 execute_task 도구 핸들러입니다.
 // Autopus 에이전트에 태스크를 전달하여 실행합니다.
 func (s *Server) handleExecuteTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	agentID, err := request.RequireString("agent_id")
-	if err != nil {
-		return mcp.NewToolResultError("required parameter 'agent_id' is missing or invalid"), nil
-	}
-
-	prompt, err := request.RequireString("prompt")
-	if err != nil {
-		return mcp.NewToolResultError("required parameter 'prompt' is missing or invalid"), nil
+	args, verr := executeTaskSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
 	}
 
-	workspaceID := request.GetString("workspace_id", "")
-	toolsStr := request.GetString("tools", "")
-	model := request.GetString("model", "")
-
+	agentID := args.String("agent_id")
+	// 서버 측 에이전트가 추가 왕복 없이 로컬 상태를 알 수 있도록 설정/인자에 따라 컨텍스트를 덧붙인다.
+	prompt := s.augmentPrompt(ctx, args.String("prompt"), args)
+	workspaceID := s.workspaceArg(args)
+	model := args.String("model")
 	// 쉼표로 구분된 tools 문자열을 슬라이스로 변환
-	var tools []string
-	if toolsStr != "" {
-		for _, t := range strings.Split(toolsStr, ",") {
-			trimmed := strings.TrimSpace(t)
-			if trimmed != "" {
-				tools = append(tools, trimmed)
-			}
-		}
-	}
+	tools := args.List("tools")
 
 	s.logger.Info().
 		Str("agent_id", agentID).
@@ -52,12 +39,12 @@ func (s *Server) handleExecuteTask(ctx context.Context, request mcp.CallToolRequ
 	})
 	if err != nil {
 		s.logger.Error().Err(err).Msg("태스크 실행 실패")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to execute task: %s", err.Error())), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.execute_task_failed", err.Error())), nil
 	}
 
 	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize response"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
@@ -66,23 +53,87 @@ func (s *Server) handleExecuteTask(ctx context.Context, request mcp.CallToolRequ
 // handleListAgents는 list_agents 도구 핸들러입니다.
 // 사용 가능한 Autopus 에이전트 목록을 반환합니다.
 func (s *Server) handleListAgents(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	workspaceID := request.GetString("workspace_id", "")
-	filter := request.GetString("filter", "")
+	args, verr := listAgentsSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	req := &ListAgentsRequest{
+		WorkspaceID: s.workspaceArg(args),
+		Filter:      args.String("filter"),
+		Limit:       args.Int("limit"),
+		Offset:      args.Int("offset"),
+		Cursor:      args.String("cursor"),
+	}
+	all := args.Bool("all")
 
 	s.logger.Info().
-		Str("workspace_id", workspaceID).
-		Str("filter", filter).
+		Str("workspace_id", req.WorkspaceID).
+		Str("filter", req.Filter).
+		Int("limit", req.Limit).
+		Int("offset", req.Offset).
+		Bool("all", all).
 		Msg("에이전트 목록 조회")
 
-	resp, err := s.client.ListAgents(ctx, workspaceID, filter)
+	var resp *ListAgentsResponse
+	var err error
+	if all {
+		resp, err = s.client.ListAllAgents(ctx, req, s.ListAgentsMaxResults())
+	} else {
+		resp, err = s.client.ListAgentsPage(ctx, req)
+	}
 	if err != nil {
 		s.logger.Error().Err(err).Msg("에이전트 목록 조회 실패")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list agents: %s", err.Error())), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.list_agents_failed", err.Error())), nil
 	}
 
 	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize response"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// SetListAgentsMaxResults는 list_agents all=true가 모으는 최대 에이전트 수를 설정합니다.
+// 0 이하이면 DefaultListAgentsMaxResults를 사용합니다.
+func (s *Server) SetListAgentsMaxResults(n int) {
+	if n <= 0 {
+		n = DefaultListAgentsMaxResults
+	}
+	s.listAgentsMax.Store(int64(n))
+}
+
+// ListAgentsMaxResults는 list_agents 자동 페이지 조회의 최대 에이전트 수를 반환합니다.
+func (s *Server) ListAgentsMaxResults() int {
+	return int(s.listAgentsMax.Load())
+}
+
+// handleGetAgentDetails는 get_agent_details 도구 핸들러입니다.
+// 에이전트의 도구/파라미터 스키마와 최근 실행 통계를 반환합니다.
+func (s *Server) handleGetAgentDetails(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := getAgentDetailsSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	agentID := args.String("agent_id")
+	workspaceID := s.workspaceArg(args)
+
+	s.logger.Info().
+		Str("agent_id", agentID).
+		Str("workspace_id", workspaceID).
+		Msg("에이전트 상세 조회")
+
+	resp, err := s.client.GetAgent(ctx, workspaceID, agentID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("에이전트 상세 조회 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.get_agent_details_failed", err.Error())), nil
+	}
+
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
@@ -91,11 +142,13 @@ func (s *Server) handleListAgents(ctx context.Context, request mcp.CallToolReque
 // handleGetExecutionStatus는 get_execution_status 도구 핸들러입니다.
 // 태스크 실행 상태를 조회합니다.
 func (s *Server) handleGetExecutionStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	executionID, err := request.RequireString("execution_id")
-	if err != nil {
-		return mcp.NewToolResultError("required parameter 'execution_id' is missing or invalid"), nil
+	args, verr := getExecutionStatusSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
 	}
 
+	executionID := args.String("execution_id")
+
 	s.logger.Info().
 		Str("execution_id", executionID).
 		Msg("실행 상태 조회")
@@ -103,35 +156,63 @@ func (s *Server) handleGetExecutionStatus(ctx context.Context, request mcp.CallT
 	resp, err := s.client.GetExecutionStatus(ctx, executionID)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("실행 상태 조회 실패")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get execution status: %s", err.Error())), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.get_execution_status_failed", err.Error())), nil
 	}
 
 	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize response"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-// handleApproveExecution은 approve_execution 도구 핸들러입니다.
-// 대기 중인 태스크 실행을 승인하거나 거부합니다.
-func (s *Server) handleApproveExecution(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	executionID, err := request.RequireString("execution_id")
+// handleGetExecutionDiff는 get_execution_diff 도구 핸들러입니다.
+// 실행이 만든 파일 변경 사항을 unified diff로 반환합니다.
+func (s *Server) handleGetExecutionDiff(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := getExecutionDiffSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	executionID := args.String("execution_id")
+	path := args.String("path")
+
+	s.logger.Info().
+		Str("execution_id", executionID).
+		Str("path", path).
+		Msg("실행 변경 사항 조회")
+
+	resp, err := s.client.GetExecutionDiff(ctx, executionID)
 	if err != nil {
-		return mcp.NewToolResultError("required parameter 'execution_id' is missing or invalid"), nil
+		s.logger.Error().Err(err).Msg("실행 변경 사항 조회 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.get_execution_diff_failed", err.Error())), nil
+	}
+	if path != "" {
+		resp.Files = filterFileChanges(resp.Files, path)
+		resp.Diff = filterUnifiedDiff(resp.Diff, path)
+		resp.HasChanges = len(resp.Files) > 0
 	}
 
-	decision, err := request.RequireString("decision")
+	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("required parameter 'decision' is missing or invalid"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
-	if decision != "approve" && decision != "reject" {
-		return mcp.NewToolResultError("decision must be 'approve' or 'reject'"), nil
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// handleApproveExecution은 approve_execution 도구 핸들러입니다.
+// 대기 중인 태스크 실행을 승인하거나 거부합니다.
+func (s *Server) handleApproveExecution(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := approveExecutionSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
 	}
 
-	reason := request.GetString("reason", "")
+	executionID := args.String("execution_id")
+	decision := args.String("decision")
+	reason := args.String("reason")
 
 	s.logger.Info().
 		Str("execution_id", executionID).
@@ -145,12 +226,12 @@ func (s *Server) handleApproveExecution(ctx context.Context, request mcp.CallToo
 	})
 	if err != nil {
 		s.logger.Error().Err(err).Msg("승인/거부 실패")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to approve/reject execution: %s", err.Error())), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.approve_execution_failed", err.Error())), nil
 	}
 
 	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize response"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
@@ -159,31 +240,15 @@ func (s *Server) handleApproveExecution(ctx context.Context, request mcp.CallToo
 // handleManageWorkspace는 manage_workspace 도구 핸들러입니다.
 // 워크스페이스를 관리합니다 (목록, 생성, 수정, 삭제).
 func (s *Server) handleManageWorkspace(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	action, err := request.RequireString("action")
-	if err != nil {
-		return mcp.NewToolResultError("required parameter 'action' is missing or invalid"), nil
-	}
-
-	validActions := map[string]bool{"get": true, "list": true, "create": true, "update": true, "delete": true}
-	if !validActions[action] {
-		return mcp.NewToolResultError("action must be one of: get, list, create, update, delete"), nil
+	// get, update, delete의 workspace_id 필수 여부와 config JSON 형식은 선언에서 검증된다.
+	args, verr := manageWorkspaceSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
 	}
 
-	workspaceID := request.GetString("workspace_id", "")
-	configStr := request.GetString("config", "")
-
-	// get, update, delete에는 workspace_id 필수
-	if (action == "get" || action == "update" || action == "delete") && workspaceID == "" {
-		return mcp.NewToolResultError(fmt.Sprintf("workspace_id is required for '%s' action", action)), nil
-	}
-
-	// config JSON 문자열을 파싱
-	var config map[string]interface{}
-	if configStr != "" {
-		if err := json.Unmarshal([]byte(configStr), &config); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("invalid config JSON: %s", err.Error())), nil
-		}
-	}
+	action := args.String("action")
+	workspaceID := args.String("workspace_id")
+	config := args.Object("config")
 
 	s.logger.Info().
 		Str("action", action).
@@ -197,12 +262,12 @@ func (s *Server) handleManageWorkspace(ctx context.Context, request mcp.CallTool
 	})
 	if err != nil {
 		s.logger.Error().Err(err).Msg("워크스페이스 관리 실패")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to manage workspace: %s", err.Error())), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.manage_workspace_failed", err.Error())), nil
 	}
 
 	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize response"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
@@ -211,50 +276,143 @@ func (s *Server) handleManageWorkspace(ctx context.Context, request mcp.CallTool
 // handleSearchKnowledge는 search_knowledge 도구 핸들러입니다.
 // 지식 베이스를 검색합니다.
 func (s *Server) handleSearchKnowledge(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	query, err := request.RequireString("query")
-	if err != nil {
-		return mcp.NewToolResultError("required parameter 'query' is missing or invalid"), nil
-	}
-
-	workspaceID := request.GetString("workspace_id", "")
-	filtersStr := request.GetString("filters", "")
-
-	limit := request.GetInt("limit", 10)
-	if limit <= 0 {
-		limit = 10
-	}
-	if limit > 50 {
-		limit = 50
+	// limit은 선언에 따라 1~50으로 보정되며, 없거나 0 이하이면 기본값 10이 사용된다.
+	args, verr := searchKnowledgeSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
 	}
 
-	// filters JSON 문자열을 파싱
-	var filters map[string]interface{}
-	if filtersStr != "" {
-		if err := json.Unmarshal([]byte(filtersStr), &filters); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("invalid filters JSON: %s", err.Error())), nil
-		}
-	}
+	query := args.String("query")
+	workspaceID := s.workspaceArg(args)
+	limit := args.Int("limit")
+	filters := args.Object("filters")
+	rerank := args.Bool("local_rerank")
 
 	s.logger.Info().
 		Str("query", query).
 		Str("workspace_id", workspaceID).
 		Int("limit", limit).
+		Bool("local_rerank", rerank).
 		Msg("지식 검색 요청")
 
-	resp, err := s.client.SearchKnowledge(ctx, &SearchKnowledgeRequest{
+	req := &SearchKnowledgeRequest{
 		Query:       query,
 		WorkspaceID: workspaceID,
 		Limit:       limit,
 		Filters:     filters,
-	})
+	}
+	knowledge := s.knowledgeCache()
+	resp, err := s.client.SearchKnowledge(ctx, req)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("지식 검색 실패")
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search knowledge: %s", err.Error())), nil
+
+		// Graceful degradation: 백엔드 장애 시 로컬 캐시로 근사 결과 반환
+		if IsBackendUnavailable(err) {
+			if offline, ok := knowledge.Search(req); ok {
+				s.logger.Info().
+					Str("match", offline.OfflineMatch).
+					Int("results", len(offline.Results)).
+					Msg("캐시된 지식 검색 결과를 오프라인 결과로 반환")
+				offline.Message = i18n.T("mcp.knowledge.offline_"+offline.OfflineMatch, err.Error(), offline.CachedAt)
+				if len(filters) > 0 && offline.OfflineMatch == OfflineMatchLexical {
+					offline.Message += " " + i18n.T("mcp.knowledge.filters_ignored")
+				}
+				if rerank {
+					offline = s.rerankKnowledge(ctx, offline)
+				}
+				result, marshalErr := json.Marshal(offline)
+				if marshalErr != nil {
+					return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+				}
+				return mcp.NewToolResultText(string(result)), nil
+			}
+		}
+		return mcp.NewToolResultError(i18n.T("mcp.tool.search_knowledge_failed", err.Error())), nil
+	}
+
+	if err := knowledge.Store(req, resp); err != nil {
+		s.logger.Warn().Err(err).Msg("지식 검색 캐시 저장 실패")
+	}
+	if rerank {
+		resp = s.rerankKnowledge(ctx, resp)
+	}
+
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// rerankKnowledge는 search_knowledge 결과를 로컬 프로젝트의 기술 스택과 최근 작업 파일로 재정렬합니다.
+// 프로젝트 정보를 수집하지 못하면 결과를 그대로 반환합니다.
+func (s *Server) rerankKnowledge(ctx context.Context, resp *SearchKnowledgeResponse) *SearchKnowledgeResponse {
+	pc := s.projectContext.Load()
+	if pc == nil {
+		pc = &projectContext{cfg: DefaultProjectContextConfig(), git: runGitCommand}
+	}
+	sig := pc.rerankSignals(ctx)
+	if sig.empty() {
+		s.logger.Debug().Msg("로컬 프로젝트 정보를 수집하지 못해 지식 검색 결과를 재정렬하지 않음")
+		return resp
+	}
+	return localRerank(resp, sig)
+}
+
+// handleGetKnowledgeDocument는 get_knowledge_document 도구 핸들러입니다.
+// 검색 결과로 찾은 지식 문서의 전체 내용을 반환합니다.
+func (s *Server) handleGetKnowledgeDocument(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := getKnowledgeDocumentSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	documentID := args.String("document_id")
+	workspaceID := s.workspaceArg(args)
+
+	s.logger.Info().
+		Str("document_id", documentID).
+		Str("workspace_id", workspaceID).
+		Msg("지식 문서 조회")
+
+	resp, err := s.client.GetKnowledgeDocument(ctx, workspaceID, documentID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("지식 문서 조회 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.get_knowledge_document_failed", err.Error())), nil
+	}
+
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// handleListKnowledgeSources는 list_knowledge_sources 도구 핸들러입니다.
+// 워크스페이스에 연결된 지식 소스 목록을 반환합니다.
+func (s *Server) handleListKnowledgeSources(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := listKnowledgeSourcesSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	workspaceID := s.workspaceArg(args)
+
+	s.logger.Info().
+		Str("workspace_id", workspaceID).
+		Msg("지식 소스 목록 조회")
+
+	resp, err := s.client.ListKnowledgeSources(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("지식 소스 목록 조회 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.list_knowledge_sources_failed", err.Error())), nil
 	}
 
 	result, err := json.Marshal(resp)
 	if err != nil {
-		return mcp.NewToolResultError("Failed to serialize response"), nil
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
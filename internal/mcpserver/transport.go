@@ -0,0 +1,126 @@
+package mcpserver
+
+import (
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// TransportConfig는 백엔드 API 호출에 사용하는 HTTP 커넥션 풀 설정입니다.
+// 모든 BackendClient는 하나의 공유 Transport를 사용하므로, 동시 도구 호출이 많아도
+// 호스트당 유휴 연결을 재사용하여 TCP/TLS 핸드셰이크를 반복하지 않습니다.
+type TransportConfig struct {
+	// MaxIdleConns는 전체 유휴 연결 최대 수입니다.
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
+	// MaxIdleConnsPerHost는 호스트당 유휴 연결 최대 수입니다.
+	// net/http 기본값(2)은 동시 호출 시 연결을 계속 새로 맺게 만듭니다.
+	MaxIdleConnsPerHost int `mapstructure:"max_idle_conns_per_host"`
+	// MaxConnsPerHost는 호스트당 최대 연결 수입니다 (0이면 제한 없음).
+	MaxConnsPerHost int `mapstructure:"max_conns_per_host"`
+	// IdleConnTimeout은 유휴 연결을 닫기 전까지의 시간입니다.
+	IdleConnTimeout time.Duration `mapstructure:"idle_conn_timeout"`
+	// DialTimeout은 TCP 연결 수립 타임아웃입니다.
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+	// KeepAlive는 TCP keep-alive 프로브 간격입니다 (음수이면 비활성화).
+	KeepAlive time.Duration `mapstructure:"keep_alive"`
+	// TLSHandshakeTimeout은 TLS 핸드셰이크 타임아웃입니다.
+	TLSHandshakeTimeout time.Duration `mapstructure:"tls_handshake_timeout"`
+	// DisableHTTP2는 HTTP/2 사용을 끕니다 (HTTP/2를 지원하지 않는 프록시용).
+	DisableHTTP2 bool `mapstructure:"disable_http2"`
+}
+
+// DefaultTransportConfig는 기본 커넥션 풀 설정을 반환합니다.
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 32,
+		IdleConnTimeout:     90 * time.Second,
+		DialTimeout:         10 * time.Second,
+		KeepAlive:           30 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// withDefaults는 0 이하인 값을 기본값으로 채운 설정을 반환합니다.
+// MaxConnsPerHost(0은 제한 없음)와 KeepAlive(음수는 비활성화)는 그대로 둡니다.
+func (c TransportConfig) withDefaults() TransportConfig {
+	def := DefaultTransportConfig()
+	if c.MaxIdleConns <= 0 {
+		c.MaxIdleConns = def.MaxIdleConns
+	}
+	if c.MaxIdleConnsPerHost <= 0 {
+		c.MaxIdleConnsPerHost = def.MaxIdleConnsPerHost
+	}
+	if c.MaxConnsPerHost < 0 {
+		c.MaxConnsPerHost = 0
+	}
+	if c.IdleConnTimeout <= 0 {
+		c.IdleConnTimeout = def.IdleConnTimeout
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = def.DialTimeout
+	}
+	if c.KeepAlive == 0 {
+		c.KeepAlive = def.KeepAlive
+	}
+	if c.TLSHandshakeTimeout <= 0 {
+		c.TLSHandshakeTimeout = def.TLSHandshakeTimeout
+	}
+	return c
+}
+
+// NewHTTPTransport는 cfg로 튜닝한 http.Transport를 생성합니다.
+// 0 이하인 값은 DefaultTransportConfig 값을 사용합니다.
+func NewHTTPTransport(cfg TransportConfig) *http.Transport {
+	cfg = cfg.withDefaults()
+	dialer := &net.Dialer{
+		Timeout:   cfg.DialTimeout,
+		KeepAlive: cfg.KeepAlive,
+	}
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+		// DialContext를 직접 지정하면 HTTP/2가 자동으로 켜지지 않으므로 명시적으로 시도한다.
+		ForceAttemptHTTP2: !cfg.DisableHTTP2,
+	}
+	if cfg.DisableHTTP2 {
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP1(true)
+	}
+	return t
+}
+
+// sharedTransport는 모든 BackendClient가 공유하는 현재 Transport입니다.
+var sharedTransport atomic.Pointer[http.Transport]
+
+func init() {
+	sharedTransport.Store(NewHTTPTransport(DefaultTransportConfig()))
+}
+
+// ConfigureTransport는 모든 BackendClient가 공유하는 Transport를 cfg로 교체합니다.
+// 이미 생성된 클라이언트도 다음 요청부터 새 Transport를 사용하며, 이전 Transport의 유휴 연결은 닫습니다.
+func ConfigureTransport(cfg TransportConfig) {
+	if old := sharedTransport.Swap(NewHTTPTransport(cfg)); old != nil {
+		old.CloseIdleConnections()
+	}
+}
+
+// sharedRoundTripper는 요청마다 현재 공유 Transport로 위임하는 RoundTripper입니다.
+type sharedRoundTripper struct{}
+
+// RoundTrip은 http.RoundTripper 인터페이스를 구현합니다.
+func (sharedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return sharedTransport.Load().RoundTrip(req)
+}
+
+// CloseIdleConnections는 공유 Transport의 유휴 연결을 닫습니다.
+func (sharedRoundTripper) CloseIdleConnections() {
+	sharedTransport.Load().CloseIdleConnections()
+}
@@ -0,0 +1,168 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingServer는 새로 맺어진 TCP 연결 수를 세는 백엔드 목 서버를 생성합니다.
+// useTLS이면 TLS 서버로 시작하고, http2이면 HTTP/2도 지원합니다.
+func newCountingServer(tb testing.TB, delay time.Duration, useTLS, http2 bool) (*httptest.Server, *atomic.Int64) {
+	tb.Helper()
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(`{}`)})
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	if useTLS {
+		server.EnableHTTP2 = http2
+		server.StartTLS()
+	} else {
+		server.Start()
+	}
+	tb.Cleanup(server.Close)
+	return server, &conns
+}
+
+// useTransport는 테스트 동안 공유 Transport를 t로 교체합니다.
+func useTransport(tb testing.TB, t *http.Transport) {
+	tb.Helper()
+	prev := sharedTransport.Swap(t)
+	tb.Cleanup(func() {
+		sharedTransport.Load().CloseIdleConnections()
+		sharedTransport.Store(prev)
+	})
+}
+
+// TestNewHTTPTransport는 기본값 적용과 HTTP/2 설정을 테스트합니다.
+func TestNewHTTPTransport(t *testing.T) {
+	tr := NewHTTPTransport(TransportConfig{MaxIdleConnsPerHost: 8, MaxConnsPerHost: -1})
+	if tr.MaxIdleConnsPerHost != 8 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 8", tr.MaxIdleConnsPerHost)
+	}
+	if tr.MaxIdleConns != 100 || tr.IdleConnTimeout != 90*time.Second || tr.MaxConnsPerHost != 0 {
+		t.Errorf("기본값이 적용되지 않았습니다: %+v", tr)
+	}
+	if !tr.ForceAttemptHTTP2 || tr.Protocols != nil {
+		t.Error("기본적으로 HTTP/2를 시도해야 합니다")
+	}
+
+	tr = NewHTTPTransport(TransportConfig{DisableHTTP2: true})
+	if tr.ForceAttemptHTTP2 || tr.Protocols == nil || tr.Protocols.HTTP2() || !tr.Protocols.HTTP1() {
+		t.Error("DisableHTTP2이면 HTTP/1.1만 사용해야 합니다")
+	}
+}
+
+// TestSharedTransport_ReusesConnections는 여러 BackendClient가 커넥션 풀을 공유하여
+// 동시 호출 뒤에도 연결을 재사용하는지 테스트합니다.
+func TestSharedTransport_ReusesConnections(t *testing.T) {
+	useTransport(t, NewHTTPTransport(DefaultTransportConfig()))
+	server, conns := newCountingServer(t, 5*time.Millisecond, false, false)
+
+	clients := []*BackendClient{newTestClient(server.URL), newTestClient(server.URL)}
+	const concurrency = 8
+	for round := 0; round < 5; round++ {
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func(c *BackendClient) {
+				defer wg.Done()
+				if _, err := c.Do(context.Background(), http.MethodGet, "/api/v1/ping", nil); err != nil {
+					t.Errorf("Do 에러: %v", err)
+				}
+			}(clients[i%len(clients)])
+		}
+		wg.Wait()
+	}
+
+	if got := conns.Load(); got > concurrency {
+		t.Errorf("새 연결 수 = %d, 동시 호출 수(%d) 이하로 재사용해야 합니다", got, concurrency)
+	}
+}
+
+// TestConfigureTransport는 이미 생성된 클라이언트도 교체된 Transport를 사용하는지 테스트합니다.
+func TestConfigureTransport(t *testing.T) {
+	useTransport(t, NewHTTPTransport(DefaultTransportConfig()))
+	server, _ := newCountingServer(t, 0, false, false)
+	client := newTestClient(server.URL)
+
+	ConfigureTransport(TransportConfig{MaxIdleConnsPerHost: 4})
+	if got := sharedTransport.Load().MaxIdleConnsPerHost; got != 4 {
+		t.Fatalf("MaxIdleConnsPerHost = %d, want 4", got)
+	}
+	if _, err := client.Do(context.Background(), http.MethodGet, "/api/v1/ping", nil); err != nil {
+		t.Fatalf("교체 후 Do 에러: %v", err)
+	}
+}
+
+// BenchmarkBackendClient_Concurrent는 동시 도구 호출 상황에서 net/http 기본 Transport와
+// 튜닝한 공유 Transport의 지연 시간을 비교합니다.
+// 백엔드는 TLS이며, HTTP/1.1만 지원하는 경우(프록시/로드밸런서 뒤)와 HTTP/2를 지원하는 경우를 모두 측정합니다.
+// p95-ms(요청 지연 95 백분위)와 conns(새로 맺은 연결 수) 지표를 함께 보고합니다.
+//
+//	go test ./internal/mcpserver -run '^$' -bench BackendClient_Concurrent
+func BenchmarkBackendClient_Concurrent(b *testing.B) {
+	transports := []struct {
+		name string
+		new  func() *http.Transport
+	}{
+		{"default", func() *http.Transport { return http.DefaultTransport.(*http.Transport).Clone() }},
+		{"tuned", func() *http.Transport { return NewHTTPTransport(DefaultTransportConfig()) }},
+	}
+	for _, proto := range []string{"http1", "http2"} {
+		for _, tt := range transports {
+			b.Run(proto+"/"+tt.name, func(b *testing.B) {
+				benchmarkBackendClient(b, proto == "http2", tt.new())
+			})
+		}
+	}
+}
+
+// benchmarkBackendClient는 tr을 공유 Transport로 사용하여 동시 요청 지연 시간을 측정합니다.
+func benchmarkBackendClient(b *testing.B, http2 bool, tr *http.Transport) {
+	server, conns := newCountingServer(b, time.Millisecond, true, http2)
+	tr.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	useTransport(b, tr)
+	client := newTestClient(server.URL)
+
+	var mu sync.Mutex
+	latencies := make([]time.Duration, 0, b.N)
+	b.SetParallelism(16)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			start := time.Now()
+			if _, err := client.Do(context.Background(), http.MethodGet, "/api/v1/ping", nil); err != nil {
+				b.Error(err)
+				return
+			}
+			elapsed := time.Since(start)
+			mu.Lock()
+			latencies = append(latencies, elapsed)
+			mu.Unlock()
+		}
+	})
+	b.StopTimer()
+
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		p95 := latencies[len(latencies)*95/100]
+		b.ReportMetric(float64(p95.Microseconds())/1000, "p95-ms")
+	}
+	b.ReportMetric(float64(conns.Load()), "conns")
+}
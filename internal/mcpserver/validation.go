@@ -0,0 +1,479 @@
+package mcpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ParamType은 도구 파라미터의 값 타입입니다.
+type ParamType string
+
+const (
+	// ParamString은 문자열 파라미터입니다.
+	ParamString ParamType = "string"
+	// ParamNumber는 실수 파라미터입니다.
+	ParamNumber ParamType = "number"
+	// ParamInteger는 정수 파라미터입니다.
+	ParamInteger ParamType = "integer"
+	// ParamBoolean은 불리언 파라미터입니다.
+	ParamBoolean ParamType = "boolean"
+)
+
+// JSONKind는 문자열 파라미터에 담긴 JSON 값의 종류입니다.
+// MCP 클라이언트 호환성을 위해 구조화된 값은 JSON 문자열로 전달받습니다.
+type JSONKind string
+
+const (
+	// JSONObject는 JSON 객체 문자열입니다.
+	JSONObject JSONKind = "object"
+	// JSONArray는 JSON 배열 문자열입니다.
+	JSONArray JSONKind = "array"
+)
+
+// Condition은 다른 파라미터 값에 따른 조건입니다.
+type Condition struct {
+	// Param은 조건을 확인할 파라미터 이름입니다.
+	Param string
+	// Values 중 하나와 일치하면 조건을 만족합니다.
+	Values []string
+}
+
+// Param은 도구 파라미터 하나의 선언입니다.
+// 같은 선언으로 MCP 입력 스키마와 핸들러 검증을 모두 생성합니다.
+type Param struct {
+	Name        string
+	Type        ParamType
+	Description string
+	// Required는 항상 필요한 파라미터인지 여부입니다. 빈 문자열은 누락으로 취급합니다.
+	Required bool
+	// RequiredIf는 조건을 만족할 때만 필요한 파라미터를 선언합니다.
+	RequiredIf *Condition
+	// Enum은 허용되는 문자열 값 목록입니다.
+	Enum []string
+	// Min, Max는 숫자 파라미터의 허용 범위입니다.
+	Min *float64
+	Max *float64
+	// Clamp가 true이면 범위를 벗어난 값을 거부하지 않고 보정합니다.
+	// Max보다 크면 Max로, Min보다 작으면 Default(없으면 Min)로 대체합니다.
+	Clamp bool
+	// Default는 파라미터가 없을 때 사용할 값입니다.
+	Default any
+	// JSON이 설정되면 문자열 값이 해당 종류의 JSON인지 검증합니다.
+	JSON JSONKind
+}
+
+// ToolSpec은 MCP 도구의 선언적 정의입니다.
+type ToolSpec struct {
+	Name        string
+	Description string
+	Params      []Param
+	// AtLeastOne은 이 중 최소 하나는 있어야 하는 파라미터 이름 목록입니다.
+	AtLeastOne []string
+	// ReadOnly는 도구가 상태를 변경하지 않는지 여부입니다. 읽기 전용 권한에서도 호출할 수 있습니다.
+	ReadOnly bool
+	// ReadOnlyActions는 action 파라미터로 동작을 고르는 도구에서 상태를 변경하지 않는 action 목록입니다.
+	ReadOnlyActions []string
+}
+
+// floatPtr는 Param.Min/Max 선언용 헬퍼입니다.
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// ValidationError는 도구 인자 검증 실패입니다.
+// Message와 MessageKo는 각각 영어, 한국어 메시지이며 Error()는 현재 언어(i18n)로 반환합니다.
+type ValidationError struct {
+	Param     string
+	Message   string
+	MessageKo string
+
+	// key, args는 i18n 카탈로그 메시지입니다.
+	key  string
+	args []any
+}
+
+// newValidationError는 i18n 카탈로그 메시지로 검증 에러를 생성합니다.
+func newValidationError(param, key string, args ...any) *ValidationError {
+	return &ValidationError{
+		Param:     param,
+		Message:   i18n.Tl(i18n.English, key, args...),
+		MessageKo: i18n.Tl(i18n.Korean, key, args...),
+		key:       key,
+		args:      args,
+	}
+}
+
+// Error는 현재 언어의 에러 메시지를 반환합니다.
+// 카탈로그 키 없이 생성된 에러는 "영어 메시지 (한국어 메시지)" 형식입니다.
+func (e *ValidationError) Error() string {
+	if e.key != "" {
+		return i18n.T(e.key, e.args...)
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.MessageKo)
+}
+
+// ToolResult는 검증 에러를 MCP 에러 결과로 변환합니다.
+func (e *ValidationError) ToolResult() *mcp.CallToolResult {
+	return mcp.NewToolResultError(e.Error())
+}
+
+// Tool은 선언으로부터 MCP 도구 정의(입력 스키마 포함)를 생성합니다.
+func (t ToolSpec) Tool() mcp.Tool {
+	opts := []mcp.ToolOption{mcp.WithDescription(t.Description)}
+	for _, p := range t.Params {
+		opts = append(opts, p.toolOption())
+	}
+	return mcp.NewTool(t.Name, opts...)
+}
+
+// toolOption은 파라미터 선언을 mcp-go 스키마 옵션으로 변환합니다.
+func (p Param) toolOption() mcp.ToolOption {
+	props := []mcp.PropertyOption{mcp.Description(p.Description)}
+	if p.Required {
+		props = append(props, mcp.Required())
+	}
+	if len(p.Enum) > 0 {
+		props = append(props, mcp.Enum(p.Enum...))
+	}
+	if p.Min != nil {
+		props = append(props, mcp.Min(*p.Min))
+	}
+	if p.Max != nil {
+		props = append(props, mcp.Max(*p.Max))
+	}
+
+	switch p.Type {
+	case ParamNumber, ParamInteger:
+		if d, ok := toFloat(p.Default); ok {
+			props = append(props, mcp.DefaultNumber(d))
+		}
+		if p.Type == ParamInteger {
+			props = append(props, func(schema map[string]any) {
+				schema["type"] = "integer"
+			})
+		}
+		return mcp.WithNumber(p.Name, props...)
+	case ParamBoolean:
+		if d, ok := p.Default.(bool); ok {
+			props = append(props, mcp.DefaultBool(d))
+		}
+		return mcp.WithBoolean(p.Name, props...)
+	default:
+		if d, ok := p.Default.(string); ok {
+			props = append(props, mcp.DefaultString(d))
+		}
+		return mcp.WithString(p.Name, props...)
+	}
+}
+
+// Args는 검증을 통과한 도구 인자입니다.
+// 선언된 기본값이 적용되어 있으며, 없는 선택 파라미터는 타입별 영값을 반환합니다.
+type Args struct {
+	values map[string]any
+}
+
+// Has는 파라미터가 전달되었거나 기본값이 있는지 반환합니다.
+func (a Args) Has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+// String은 문자열 파라미터 값을 반환합니다.
+func (a Args) String(name string) string {
+	s, _ := a.values[name].(string)
+	return s
+}
+
+// Float은 숫자 파라미터 값을 반환합니다.
+func (a Args) Float(name string) float64 {
+	f, _ := a.values[name].(float64)
+	return f
+}
+
+// Int는 정수 파라미터 값을 반환합니다.
+func (a Args) Int(name string) int {
+	return int(a.Float(name))
+}
+
+// Bool은 불리언 파라미터 값을 반환합니다.
+func (a Args) Bool(name string) bool {
+	b, _ := a.values[name].(bool)
+	return b
+}
+
+// List는 쉼표로 구분된 문자열 파라미터를 공백을 제거한 목록으로 반환합니다.
+func (a Args) List(name string) []string {
+	var items []string
+	for _, item := range strings.Split(a.String(name), ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
+
+// Object는 JSON 객체 파라미터를 디코딩한 값을 반환합니다. 없으면 nil입니다.
+func (a Args) Object(name string) map[string]interface{} {
+	raw := a.String(name)
+	if raw == "" {
+		return nil
+	}
+	var obj map[string]interface{}
+	_ = json.Unmarshal([]byte(raw), &obj)
+	return obj
+}
+
+// Decode는 JSON 파라미터를 v로 디코딩합니다. 파라미터가 없으면 아무것도 하지 않습니다.
+func (a Args) Decode(name string, v any) error {
+	raw := a.String(name)
+	if raw == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		return newValidationError(name, "mcp.validation.invalid_decode", name, err.Error())
+	}
+	return nil
+}
+
+// Validate는 요청 인자를 선언에 따라 검증하고 정규화된 Args를 반환합니다.
+// 파라미터는 선언 순서대로 검증되며 첫 번째 실패만 보고합니다.
+func (t ToolSpec) Validate(request mcp.CallToolRequest) (Args, *ValidationError) {
+	raw := request.GetArguments()
+	args := Args{values: make(map[string]any, len(t.Params))}
+
+	for _, p := range t.Params {
+		value, present := raw[p.Name]
+		if present && isEmptyArg(value) {
+			present = false
+		}
+
+		if !present {
+			if p.Required {
+				return Args{}, missingParamError(p.Name)
+			}
+			if p.Default != nil {
+				args.values[p.Name] = normalizeDefault(p)
+			}
+			continue
+		}
+
+		normalized, err := p.coerce(value)
+		if err != nil {
+			return Args{}, err
+		}
+		args.values[p.Name] = normalized
+	}
+
+	// 조건부 필수 파라미터는 모든 값이 정규화된 뒤에 확인한다.
+	for _, p := range t.Params {
+		if p.RequiredIf == nil || args.Has(p.Name) {
+			continue
+		}
+		if trigger := args.String(p.RequiredIf.Param); containsValue(p.RequiredIf.Values, trigger) {
+			return Args{}, newValidationError(p.Name, "mcp.validation.required_if", p.Name, trigger, p.RequiredIf.Param)
+		}
+	}
+
+	if len(t.AtLeastOne) > 0 {
+		found := false
+		for _, name := range t.AtLeastOne {
+			if args.Has(name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			quoted := make([]string, len(t.AtLeastOne))
+			for i, name := range t.AtLeastOne {
+				quoted[i] = "'" + name + "'"
+			}
+			return Args{}, newValidationError(strings.Join(t.AtLeastOne, ","), "mcp.validation.at_least_one",
+				strings.Join(quoted, " or "), strings.Join(quoted, ", "))
+		}
+	}
+
+	return args, nil
+}
+
+// coerce는 값의 타입/열거값/범위/JSON 형식을 검증하고 정규화된 값을 반환합니다.
+func (p Param) coerce(value any) (any, *ValidationError) {
+	switch p.Type {
+	case ParamNumber, ParamInteger:
+		f, ok := toFloat(value)
+		if !ok || (p.Type == ParamInteger && f != math.Trunc(f)) {
+			return nil, typeError(p)
+		}
+		return p.checkRange(f)
+
+	case ParamBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+		return nil, typeError(p)
+
+	default:
+		s, ok := value.(string)
+		if !ok {
+			return nil, typeError(p)
+		}
+		if len(p.Enum) > 0 && !containsValue(p.Enum, s) {
+			return nil, newValidationError(p.Name, "mcp.validation.enum", p.Name, strings.Join(p.Enum, ", "))
+		}
+		if p.JSON != "" {
+			if err := checkJSONKind(s, p.JSON); err != nil {
+				return nil, newValidationError(p.Name, "mcp.validation.json_kind", p.Name, err.Error(), jsonKindKo(p.JSON))
+			}
+		}
+		return s, nil
+	}
+}
+
+// checkRange는 숫자 값의 범위를 확인하고, Clamp가 설정되어 있으면 보정합니다.
+func (p Param) checkRange(f float64) (any, *ValidationError) {
+	below := p.Min != nil && f < *p.Min
+	above := p.Max != nil && f > *p.Max
+	if !below && !above {
+		return f, nil
+	}
+
+	if p.Clamp {
+		if above {
+			return *p.Max, nil
+		}
+		if d, ok := toFloat(p.Default); ok {
+			return d, nil
+		}
+		return *p.Min, nil
+	}
+
+	switch {
+	case p.Min != nil && p.Max != nil:
+		return nil, newValidationError(p.Name, "mcp.validation.between", p.Name, formatNumber(*p.Min), formatNumber(*p.Max))
+	case p.Min != nil:
+		return nil, newValidationError(p.Name, "mcp.validation.at_least", p.Name, formatNumber(*p.Min))
+	default:
+		return nil, newValidationError(p.Name, "mcp.validation.at_most", p.Name, formatNumber(*p.Max))
+	}
+}
+
+// missingParamError는 필수 파라미터 누락 에러를 생성합니다.
+func missingParamError(name string) *ValidationError {
+	return newValidationError(name, "mcp.validation.missing", name)
+}
+
+// typeError는 타입 불일치 에러를 생성합니다.
+func typeError(p Param) *ValidationError {
+	article := "a"
+	if p.Type == ParamInteger {
+		article = "an"
+	}
+	return newValidationError(p.Name, "mcp.validation.type", p.Name, article, string(p.Type), paramTypeKo(p.Type))
+}
+
+// normalizeDefault는 선언된 기본값을 Args 내부 표현으로 변환합니다.
+func normalizeDefault(p Param) any {
+	if p.Type == ParamNumber || p.Type == ParamInteger {
+		if f, ok := toFloat(p.Default); ok {
+			return f
+		}
+	}
+	return p.Default
+}
+
+// isEmptyArg는 누락으로 취급할 값(null, 공백 문자열)인지 확인합니다.
+func isEmptyArg(value any) bool {
+	if value == nil {
+		return true
+	}
+	s, ok := value.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+// toFloat는 JSON 디코딩 결과 또는 Go 숫자/숫자 문자열을 float64로 변환합니다.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// checkJSONKind는 문자열이 지정된 종류의 JSON 값인지 확인합니다.
+func checkJSONKind(s string, kind JSONKind) error {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return err
+	}
+	switch kind {
+	case JSONObject:
+		if _, ok := v.(map[string]any); !ok {
+			return fmt.Errorf("expected a JSON object")
+		}
+	case JSONArray:
+		if _, ok := v.([]any); !ok {
+			return fmt.Errorf("expected a JSON array")
+		}
+	}
+	return nil
+}
+
+// containsValue는 목록에 값이 있는지 확인합니다.
+func containsValue(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
+
+// formatNumber는 정수 값은 소수점 없이 표시합니다.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// paramTypeKo는 파라미터 타입의 한국어 이름을 반환합니다.
+func paramTypeKo(t ParamType) string {
+	switch t {
+	case ParamNumber:
+		return "숫자"
+	case ParamInteger:
+		return "정수"
+	case ParamBoolean:
+		return "불리언"
+	default:
+		return "문자열"
+	}
+}
+
+// jsonKindKo는 JSON 종류의 한국어 이름을 반환합니다.
+func jsonKindKo(kind JSONKind) string {
+	if kind == JSONArray {
+		return "배열"
+	}
+	return "객체"
+}
@@ -0,0 +1,228 @@
+package mcpserver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func newValidationRequest(args map[string]interface{}) mcp.CallToolRequest {
+	var req mcp.CallToolRequest
+	req.Params.Arguments = args
+	return req
+}
+
+var testSpec = ToolSpec{
+	Name:        "test_tool",
+	Description: "test tool",
+	Params: []Param{
+		{Name: "action", Type: ParamString, Required: true, Enum: []string{"get", "list"}, Description: "action"},
+		{Name: "id", Type: ParamString, RequiredIf: &Condition{Param: "action", Values: []string{"get"}}, Description: "id"},
+		{Name: "limit", Type: ParamInteger, Min: floatPtr(1), Max: floatPtr(50), Clamp: true, Default: 10, Description: "limit"},
+		{Name: "ratio", Type: ParamNumber, Min: floatPtr(0), Max: floatPtr(1), Description: "ratio"},
+		{Name: "verbose", Type: ParamBoolean, Description: "verbose"},
+		{Name: "config", Type: ParamString, JSON: JSONObject, Description: "config"},
+		{Name: "tags", Type: ParamString, Description: "tags"},
+	},
+}
+
+func TestToolSpec_Validate_Defaults(t *testing.T) {
+	args, verr := testSpec.Validate(newValidationRequest(map[string]interface{}{
+		"action": "list",
+		"tags":   " a, ,b ",
+	}))
+	if verr != nil {
+		t.Fatalf("예상치 못한 검증 오류: %v", verr)
+	}
+	if got := args.Int("limit"); got != 10 {
+		t.Errorf("limit 기본값: got %d, want 10", got)
+	}
+	if args.Has("ratio") {
+		t.Error("전달되지 않은 ratio가 존재하면 안 됩니다")
+	}
+	if args.Bool("verbose") {
+		t.Error("verbose 기본값은 false여야 합니다")
+	}
+	if got := args.List("tags"); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("tags: got %v, want [a b]", got)
+	}
+	if args.Object("config") != nil {
+		t.Error("config가 없으면 nil이어야 합니다")
+	}
+}
+
+func TestToolSpec_Validate_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantParam string
+		wantMsg   string
+	}{
+		{"missing required", map[string]interface{}{}, "action", "required parameter 'action' is missing"},
+		{"blank required", map[string]interface{}{"action": "  "}, "action", "required parameter 'action' is missing"},
+		{"enum", map[string]interface{}{"action": "drop"}, "action", "action must be one of: get, list"},
+		{"required if", map[string]interface{}{"action": "get"}, "id", "id is required for 'get' action"},
+		{"integer type", map[string]interface{}{"action": "list", "limit": 2.5}, "limit", "parameter 'limit' must be an integer"},
+		{"number type", map[string]interface{}{"action": "list", "ratio": "high"}, "ratio", "parameter 'ratio' must be a number"},
+		{"range", map[string]interface{}{"action": "list", "ratio": 1.5}, "ratio", "ratio must be between 0 and 1"},
+		{"boolean type", map[string]interface{}{"action": "list", "verbose": "maybe"}, "verbose", "parameter 'verbose' must be a boolean"},
+		{"json kind", map[string]interface{}{"action": "list", "config": "[1,2]"}, "config", "invalid config JSON"},
+		{"invalid json", map[string]interface{}{"action": "list", "config": "{bad"}, "config", "invalid config JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, verr := testSpec.Validate(newValidationRequest(tt.args))
+			if verr == nil {
+				t.Fatal("검증 오류가 반환되어야 합니다")
+			}
+			if verr.Param != tt.wantParam {
+				t.Errorf("Param: got %q, want %q", verr.Param, tt.wantParam)
+			}
+			if !strings.Contains(verr.Message, tt.wantMsg) {
+				t.Errorf("메시지 %q에 %q가 포함되어야 합니다", verr.Message, tt.wantMsg)
+			}
+			if verr.MessageKo == "" {
+				t.Error("한국어 메시지가 비어 있으면 안 됩니다")
+			}
+			if !verr.ToolResult().IsError {
+				t.Error("ToolResult는 에러 결과여야 합니다")
+			}
+		})
+	}
+}
+
+func TestValidationError_Language(t *testing.T) {
+	defer i18n.SetLang(i18n.Current())
+
+	_, verr := testSpec.Validate(newValidationRequest(map[string]interface{}{"action": "get"}))
+	if verr == nil {
+		t.Fatal("검증 오류가 반환되어야 합니다")
+	}
+
+	i18n.SetLang(i18n.English)
+	if got := verr.Error(); got != "id is required for 'get' action" {
+		t.Errorf("영어 메시지: got %q", got)
+	}
+	i18n.SetLang(i18n.Korean)
+	if got := verr.Error(); got != verr.MessageKo || !strings.Contains(got, "파라미터가 필요합니다") {
+		t.Errorf("한국어 메시지: got %q", got)
+	}
+}
+
+func TestToolSpec_Validate_Coercion(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     interface{}
+		wantLimit int
+	}{
+		{"in range", float64(20), 20},
+		{"above max clamps to max", float64(100), 50},
+		{"below min falls back to default", float64(0), 10},
+		{"numeric string", "5", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, verr := testSpec.Validate(newValidationRequest(map[string]interface{}{
+				"action": "list",
+				"limit":  tt.limit,
+			}))
+			if verr != nil {
+				t.Fatalf("예상치 못한 검증 오류: %v", verr)
+			}
+			if got := args.Int("limit"); got != tt.wantLimit {
+				t.Errorf("limit: got %d, want %d", got, tt.wantLimit)
+			}
+		})
+	}
+
+	args, verr := testSpec.Validate(newValidationRequest(map[string]interface{}{
+		"action":  "get",
+		"id":      "ws-1",
+		"verbose": "true",
+		"config":  `{"name":"x"}`,
+	}))
+	if verr != nil {
+		t.Fatalf("예상치 못한 검증 오류: %v", verr)
+	}
+	if !args.Bool("verbose") {
+		t.Error("문자열 'true'는 true로 변환되어야 합니다")
+	}
+	if got := args.Object("config"); got["name"] != "x" {
+		t.Errorf("config: got %v", got)
+	}
+}
+
+func TestToolSpec_Validate_AtLeastOne(t *testing.T) {
+	_, verr := createMessageSpec.Validate(newValidationRequest(map[string]interface{}{
+		"system_prompt": "be brief",
+	}))
+	if verr == nil {
+		t.Fatal("prompt와 messages가 모두 없으면 오류여야 합니다")
+	}
+	if !strings.Contains(verr.Message, "either 'prompt' or 'messages' is required") {
+		t.Errorf("unexpected message: %s", verr.Message)
+	}
+
+	if _, verr := createMessageSpec.Validate(newValidationRequest(map[string]interface{}{
+		"prompt": "hi",
+	})); verr != nil {
+		t.Fatalf("prompt만 있어도 통과해야 합니다: %v", verr)
+	}
+}
+
+func TestArgs_Decode(t *testing.T) {
+	args, verr := createMessageSpec.Validate(newValidationRequest(map[string]interface{}{
+		"messages": `[{"role":"user","content":{"type":"text","text":"Hi"}}]`,
+	}))
+	if verr != nil {
+		t.Fatalf("예상치 못한 검증 오류: %v", verr)
+	}
+
+	var messages []map[string]interface{}
+	if err := args.Decode("messages", &messages); err != nil {
+		t.Fatalf("Decode 실패: %v", err)
+	}
+	if len(messages) != 1 || messages[0]["role"] != "user" {
+		t.Errorf("messages: got %v", messages)
+	}
+
+	var ignored []string
+	if err := args.Decode("prompt", &ignored); err != nil {
+		t.Errorf("없는 파라미터는 오류 없이 무시되어야 합니다: %v", err)
+	}
+}
+
+func TestToolSpec_Tool_Schema(t *testing.T) {
+	tool := testSpec.Tool()
+	if tool.Name != "test_tool" {
+		t.Errorf("Name: got %q", tool.Name)
+	}
+	if len(tool.InputSchema.Required) != 1 || tool.InputSchema.Required[0] != "action" {
+		t.Errorf("Required: got %v, want [action]", tool.InputSchema.Required)
+	}
+
+	action, _ := tool.InputSchema.Properties["action"].(map[string]any)
+	if enum, _ := action["enum"].([]string); len(enum) != 2 {
+		t.Errorf("action enum: got %v", action["enum"])
+	}
+
+	limit, _ := tool.InputSchema.Properties["limit"].(map[string]any)
+	if limit["type"] != "integer" {
+		t.Errorf("limit type: got %v, want integer", limit["type"])
+	}
+	if limit["minimum"] != float64(1) || limit["maximum"] != float64(50) {
+		t.Errorf("limit range: got %v..%v", limit["minimum"], limit["maximum"])
+	}
+	if limit["default"] != float64(10) {
+		t.Errorf("limit default: got %v", limit["default"])
+	}
+
+	verbose, _ := tool.InputSchema.Properties["verbose"].(map[string]any)
+	if verbose["type"] != "boolean" {
+		t.Errorf("verbose type: got %v", verbose["type"])
+	}
+}
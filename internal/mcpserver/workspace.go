@@ -0,0 +1,92 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/insajin/autopus-bridge/internal/i18n"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// setActiveWorkspaceSpec은 세션의 기본 워크스페이스를 바꾸는 도구 선언입니다.
+var setActiveWorkspaceSpec = ToolSpec{
+	Name:        "set_active_workspace",
+	Description: "Set the active workspace for this session. Tools called without workspace_id (execute_task, list_agents, search_knowledge, ...) use the active workspace, which defaults to the workspace selected at login.",
+	Params: []Param{
+		{Name: "workspace_id", Type: ParamString, Required: true, Description: "ID of the workspace to make active"},
+	},
+}
+
+// ActiveWorkspaceResult는 set_active_workspace 도구 응답입니다.
+type ActiveWorkspaceResult struct {
+	WorkspaceID         string `json:"workspace_id"`
+	WorkspaceName       string `json:"workspace_name,omitempty"`
+	PreviousWorkspaceID string `json:"previous_workspace_id,omitempty"`
+}
+
+// ActiveWorkspaceID는 workspace_id를 생략한 도구 호출에 사용할 워크스페이스 ID를 반환합니다.
+// set_active_workspace로 지정한 값이 없으면 인증 정보에서 선택한 워크스페이스를 사용합니다.
+func (s *Server) ActiveWorkspaceID() string {
+	s.workspaceMu.RLock()
+	active := s.activeWorkspace
+	s.workspaceMu.RUnlock()
+	if active != "" {
+		return active
+	}
+	if s.client.tokenRefresh != nil {
+		return s.client.tokenRefresh.GetWorkspaceID()
+	}
+	return ""
+}
+
+// SetActiveWorkspace는 세션의 기본 워크스페이스를 지정합니다.
+// 워크스페이스 스코프로 시작한 MCP serve 인스턴스가 생성 시점에 사용합니다.
+func (s *Server) SetActiveWorkspace(workspaceID string) {
+	s.workspaceMu.Lock()
+	s.activeWorkspace = workspaceID
+	s.workspaceMu.Unlock()
+}
+
+// workspaceArg는 workspace_id 인자를 반환하고, 생략되었으면 활성 워크스페이스 ID를 주입합니다.
+func (s *Server) workspaceArg(args Args) string {
+	if workspaceID := args.String("workspace_id"); workspaceID != "" {
+		return workspaceID
+	}
+	return s.ActiveWorkspaceID()
+}
+
+// handleSetActiveWorkspace는 set_active_workspace 도구 핸들러입니다.
+// 워크스페이스에 접근할 수 있는지 확인한 뒤 세션의 기본 워크스페이스로 지정합니다.
+func (s *Server) handleSetActiveWorkspace(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, verr := setActiveWorkspaceSpec.Validate(request)
+	if verr != nil {
+		return verr.ToolResult(), nil
+	}
+
+	workspaceID := args.String("workspace_id")
+	resp, err := s.client.ManageWorkspace(ctx, &ManageWorkspaceRequest{Action: "get", WorkspaceID: workspaceID})
+	if err != nil {
+		s.logger.Error().Err(err).Str("workspace_id", workspaceID).Msg("활성 워크스페이스 변경 실패")
+		return mcp.NewToolResultError(i18n.T("mcp.tool.set_active_workspace_failed", err.Error())), nil
+	}
+
+	previous := s.ActiveWorkspaceID()
+	s.SetActiveWorkspace(workspaceID)
+	// 에이전트 카탈로그는 활성 워크스페이스 기준이므로 이전 워크스페이스의 캐시를 버린다.
+	s.cache.Delete(cacheKeyAgents)
+
+	s.logger.Info().
+		Str("workspace_id", workspaceID).
+		Str("previous_workspace_id", previous).
+		Msg("활성 워크스페이스 변경")
+
+	out := ActiveWorkspaceResult{WorkspaceID: workspaceID, PreviousWorkspaceID: previous}
+	if resp.Workspace != nil {
+		out.WorkspaceName = resp.Workspace.Name
+	}
+	result, err := json.Marshal(out)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.T("mcp.tool.serialize_failed")), nil
+	}
+	return mcp.NewToolResultText(string(result)), nil
+}
@@ -0,0 +1,88 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestSetActiveWorkspace는 workspace_id를 생략한 도구 호출에 활성 워크스페이스가 주입되는지 테스트합니다.
+func TestSetActiveWorkspace(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		data := `{"agents":[],"total":0}`
+		switch r.URL.Path {
+		case "/api/v1/workspaces/ws-002":
+			data = `{"workspace":{"id":"ws-002","name":"Second"}}`
+		case "/api/v1/workspaces/ws-missing":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(apiResponse{Success: false, Error: "workspace not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(apiResponse{Success: true, Data: json.RawMessage(data)})
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	srv := NewServer(newTestClient(server.URL), zerolog.Nop())
+	ctx := context.Background()
+	lastPath := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return paths[len(paths)-1]
+	}
+
+	if got := srv.ActiveWorkspaceID(); got != "ws-test-001" {
+		t.Fatalf("기본 활성 워크스페이스 = %q, 인증 정보의 워크스페이스여야 합니다", got)
+	}
+
+	// 접근할 수 없는 워크스페이스는 지정할 수 없다.
+	result, err := srv.handleSetActiveWorkspace(ctx, makeCallToolRequest("set_active_workspace", map[string]interface{}{"workspace_id": "ws-missing"}))
+	if err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if !result.IsError || srv.ActiveWorkspaceID() != "ws-test-001" {
+		t.Fatalf("존재하지 않는 워크스페이스가 활성화되었습니다: %q", srv.ActiveWorkspaceID())
+	}
+
+	result, err = srv.handleSetActiveWorkspace(ctx, makeCallToolRequest("set_active_workspace", map[string]interface{}{"workspace_id": "ws-002"}))
+	if err != nil || result.IsError {
+		t.Fatalf("활성 워크스페이스 변경 실패: %v %+v", err, result)
+	}
+	var out ActiveWorkspaceResult
+	if err := json.Unmarshal([]byte(extractTextFromToolResult(t, result)), &out); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	if out != (ActiveWorkspaceResult{WorkspaceID: "ws-002", WorkspaceName: "Second", PreviousWorkspaceID: "ws-test-001"}) {
+		t.Errorf("예상하지 못한 응답: %+v", out)
+	}
+
+	// workspace_id를 생략하면 활성 워크스페이스를, 지정하면 지정한 워크스페이스를 사용한다.
+	if _, err := srv.handleListAgents(ctx, makeCallToolRequest("list_agents", map[string]interface{}{})); err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if got := lastPath(); got != "/api/v1/workspaces/ws-002/agents" {
+		t.Errorf("list_agents 경로 = %s, 활성 워크스페이스를 사용해야 합니다", got)
+	}
+	if _, err := srv.handleListAgents(ctx, makeCallToolRequest("list_agents", map[string]interface{}{"workspace_id": "ws-003"})); err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if got := lastPath(); got != "/api/v1/workspaces/ws-003/agents" {
+		t.Errorf("list_agents 경로 = %s, 지정한 워크스페이스를 사용해야 합니다", got)
+	}
+	if _, err := srv.handleExecuteTask(ctx, makeCallToolRequest("execute_task", map[string]interface{}{"agent_id": "agent-001", "prompt": "hi"})); err != nil {
+		t.Fatalf("핸들러 오류: %v", err)
+	}
+	if got := lastPath(); got != "/api/v1/workspaces/ws-002/execute" {
+		t.Errorf("execute_task 경로 = %s, 활성 워크스페이스를 사용해야 합니다", got)
+	}
+}
@@ -35,6 +35,10 @@ type Metrics struct {
 	avgLatencyNs  atomic.Int64
 	latencyCount  atomic.Int64
 
+	// Connection quality (score 0-100 and heartbeat round-trip time)
+	connectionQuality atomic.Int64
+	heartbeatRTTNs    atomic.Int64
+
 	mu sync.RWMutex
 }
 
@@ -55,6 +59,8 @@ type MetricsSnapshot struct {
 	TasksTimedOut       int64     `json:"tasks_timed_out"`
 	AvgLatencyMs        float64   `json:"avg_latency_ms"`
 	LastHeartbeat       string    `json:"last_heartbeat,omitempty"`
+	ConnectionQuality   int64     `json:"connection_quality"`
+	HeartbeatRTTMs      float64   `json:"heartbeat_rtt_ms"`
 }
 
 // NewMetrics creates a new Metrics instance with the start time set to now.
@@ -62,6 +68,7 @@ func NewMetrics() *Metrics {
 	m := &Metrics{
 		startTime: time.Now(),
 	}
+	m.connectionQuality.Store(100)
 	return m
 }
 
@@ -91,6 +98,13 @@ func (m *Metrics) RecordHeartbeat() {
 	m.lastHeartbeat.Store(time.Now())
 }
 
+// RecordConnectionQuality records the latest connection quality score (0-100)
+// and average heartbeat round-trip time.
+func (m *Metrics) RecordConnectionQuality(score int, rtt time.Duration) {
+	m.connectionQuality.Store(int64(score))
+	m.heartbeatRTTNs.Store(rtt.Nanoseconds())
+}
+
 // Uptime returns the duration since the metrics instance was created.
 func (m *Metrics) Uptime() time.Duration {
 	return time.Since(m.startTime)
@@ -120,6 +134,8 @@ func (m *Metrics) Snapshot() MetricsSnapshot {
 		TasksFailed:         m.TasksFailed.Load(),
 		TasksTimedOut:       m.TasksTimedOut.Load(),
 		AvgLatencyMs:        float64(m.avgLatencyNs.Load()) / float64(time.Millisecond),
+		ConnectionQuality:   m.connectionQuality.Load(),
+		HeartbeatRTTMs:      float64(m.heartbeatRTTNs.Load()) / float64(time.Millisecond),
 	}
 
 	if v := m.lastHeartbeat.Load(); v != nil {
@@ -152,6 +168,8 @@ func (m *Metrics) Reset() {
 	m.TasksTimedOut.Store(0)
 	m.avgLatencyNs.Store(0)
 	m.latencyCount.Store(0)
+	m.connectionQuality.Store(100)
+	m.heartbeatRTTNs.Store(0)
 
 	m.mu.Lock()
 	m.startTime = time.Now()
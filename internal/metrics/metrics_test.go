@@ -145,6 +145,28 @@ func TestMetrics_RecordHeartbeat(t *testing.T) {
 	}
 }
 
+// TestMetrics_RecordConnectionQuality verifies the quality score and RTT in snapshots.
+func TestMetrics_RecordConnectionQuality(t *testing.T) {
+	m := NewMetrics()
+	if got := m.Snapshot().ConnectionQuality; got != 100 {
+		t.Errorf("initial ConnectionQuality = %d, want 100", got)
+	}
+
+	m.RecordConnectionQuality(62, 250*time.Millisecond)
+	snap := m.Snapshot()
+	if snap.ConnectionQuality != 62 {
+		t.Errorf("ConnectionQuality = %d, want 62", snap.ConnectionQuality)
+	}
+	if snap.HeartbeatRTTMs != 250 {
+		t.Errorf("HeartbeatRTTMs = %f, want 250", snap.HeartbeatRTTMs)
+	}
+
+	m.Reset()
+	if snap := m.Snapshot(); snap.ConnectionQuality != 100 || snap.HeartbeatRTTMs != 0 {
+		t.Errorf("after Reset, quality = %d, rtt = %f, want 100, 0", snap.ConnectionQuality, snap.HeartbeatRTTMs)
+	}
+}
+
 // TestMetrics_Uptime verifies uptime calculation.
 func TestMetrics_Uptime(t *testing.T) {
 	m := NewMetrics()
@@ -299,7 +321,7 @@ func TestMetrics_ToJSON_ValidStructure(t *testing.T) {
 		"connection_attempts", "connection_successes", "connection_failures", "reconnections",
 		"messages_sent", "messages_received", "message_errors",
 		"tasks_received", "tasks_completed", "tasks_failed", "tasks_timed_out",
-		"avg_latency_ms",
+		"avg_latency_ms", "connection_quality", "heartbeat_rtt_ms",
 	}
 
 	for _, field := range expectedFields {
@@ -0,0 +1,208 @@
+// Package procgroup은 Bridge가 실행하는 자식 프로세스(npm, docker, AI CLI 등)를 별도 프로세스 그룹
+// (Windows: Job Object)에 두고, 취소/타임아웃/종료 시 자식이 만든 하위 프로세스까지 함께 정리합니다.
+// 실행 중인 프로세스는 레지스트리에 등록되어 Bridge 종료 시 한 번에 정리됩니다.
+package procgroup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// DefaultWaitDelay는 프로세스 트리를 종료한 뒤 Wait가 stdout/stderr 파이프를 기다리는 최대 시간입니다.
+// 그룹 밖으로 빠져나간 손자 프로세스가 파이프를 잡고 있어도 Wait가 끝나도록 합니다.
+const DefaultWaitDelay = 5 * time.Second
+
+// ErrShutdown은 레지스트리가 종료되어 새 프로세스를 시작할 수 없음을 나타냅니다.
+var ErrShutdown = errors.New("프로세스 레지스트리 종료됨")
+
+// Registry는 실행 중인 자식 프로세스와 그 프로세스 그룹을 추적합니다.
+type Registry struct {
+	mu       sync.Mutex
+	procs    map[*exec.Cmd]*group
+	closed   bool
+	released chan struct{}
+}
+
+// NewRegistry는 빈 프로세스 레지스트리를 생성합니다.
+func NewRegistry() *Registry {
+	return &Registry{
+		procs:    make(map[*exec.Cmd]*group),
+		released: make(chan struct{}, 1),
+	}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default는 Bridge 전역 프로세스 레지스트리를 반환합니다.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// prepare는 cmd가 자신의 프로세스 그룹에서 시작되고, 컨텍스트가 취소되면 트리 전체가 종료되도록 설정합니다.
+func (r *Registry) prepare(cmd *exec.Cmd) {
+	setGroupAttr(cmd)
+	// CommandContext로 만든 Cmd만 Cancel이 설정되어 있다. 직접 만든 Cmd에 Cancel을 설정하면 Start가 실패한다.
+	if cmd.Cancel != nil {
+		cmd.Cancel = func() error { return killTree(cmd, r.groupOf(cmd)) }
+	}
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = DefaultWaitDelay
+	}
+}
+
+// Start는 cmd를 자신의 프로세스 그룹에서 시작하고 레지스트리에 등록합니다.
+// 시작에 성공하면 반드시 Wait를 호출해야 합니다.
+func (r *Registry) Start(cmd *exec.Cmd) error {
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return ErrShutdown
+	}
+
+	r.prepare(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	g := newGroup(cmd)
+
+	r.mu.Lock()
+	r.procs[cmd] = g
+	closed = r.closed
+	r.mu.Unlock()
+	if closed {
+		// Start와 Shutdown이 겹쳤으면 바로 정리한다. 호출자의 Wait가 상태를 회수한다.
+		_ = killTree(cmd, g)
+	}
+	return nil
+}
+
+// Wait는 cmd 종료를 기다려 상태를 회수(좀비 방지)하고 레지스트리에서 제거합니다.
+func (r *Registry) Wait(cmd *exec.Cmd) error {
+	err := cmd.Wait()
+
+	r.mu.Lock()
+	g, ok := r.procs[cmd]
+	delete(r.procs, cmd)
+	r.mu.Unlock()
+	if ok {
+		g.close()
+		select {
+		case r.released <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+// Run은 cmd를 시작하고 종료를 기다립니다 (exec.Cmd.Run 대체).
+func (r *Registry) Run(cmd *exec.Cmd) error {
+	if err := r.Start(cmd); err != nil {
+		return err
+	}
+	return r.Wait(cmd)
+}
+
+// Output은 cmd를 실행하고 stdout을 반환합니다 (exec.Cmd.Output 대체).
+// Stderr가 설정되지 않았으면 *exec.ExitError의 Stderr에 stderr를 담습니다.
+func (r *Registry) Output(cmd *exec.Cmd) ([]byte, error) {
+	if cmd.Stdout != nil {
+		return nil, errors.New("exec: Stdout already set")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	captureErr := cmd.Stderr == nil
+	if captureErr {
+		cmd.Stderr = &stderr
+	}
+	err := r.Run(cmd)
+	var exitErr *exec.ExitError
+	if captureErr && errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
+}
+
+// Kill은 cmd와 그 하위 프로세스 트리 전체를 종료합니다. 상태 회수는 Wait가 담당합니다.
+func (r *Registry) Kill(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return errors.New("exec: not started")
+	}
+	g := r.groupOf(cmd)
+	if g == nil && cmd.ProcessState != nil {
+		// 이미 회수된 프로세스는 그룹 ID가 재사용되었을 수 있으므로 건드리지 않는다.
+		return os.ErrProcessDone
+	}
+	return killTree(cmd, g)
+}
+
+// Len은 실행 중인 프로세스 수를 반환합니다.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.procs)
+}
+
+// KillAll은 등록된 모든 프로세스 트리를 종료하고 종료 요청한 프로세스 수를 반환합니다.
+func (r *Registry) KillAll() int {
+	r.mu.Lock()
+	procs := make(map[*exec.Cmd]*group, len(r.procs))
+	for cmd, g := range r.procs {
+		procs[cmd] = g
+	}
+	r.mu.Unlock()
+
+	for cmd, g := range procs {
+		_ = killTree(cmd, g)
+	}
+	return len(procs)
+}
+
+// Shutdown은 새 프로세스 시작을 막고 모든 프로세스 트리를 종료한 뒤,
+// 각 프로세스의 Wait가 상태를 회수할 때까지 ctx 만료 전까지 기다립니다.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	r.KillAll()
+	for r.Len() > 0 {
+		select {
+		case <-r.released:
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// groupOf는 cmd의 프로세스 그룹을 반환합니다. 등록 전이면 nil입니다.
+func (r *Registry) groupOf(cmd *exec.Cmd) *group {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.procs[cmd]
+}
+
+// Start는 기본 레지스트리로 cmd를 시작합니다.
+func Start(cmd *exec.Cmd) error { return defaultRegistry.Start(cmd) }
+
+// Wait는 기본 레지스트리에서 cmd 종료를 기다립니다.
+func Wait(cmd *exec.Cmd) error { return defaultRegistry.Wait(cmd) }
+
+// Run은 기본 레지스트리로 cmd를 실행합니다.
+func Run(cmd *exec.Cmd) error { return defaultRegistry.Run(cmd) }
+
+// Output은 기본 레지스트리로 cmd를 실행하고 stdout을 반환합니다.
+func Output(cmd *exec.Cmd) ([]byte, error) { return defaultRegistry.Output(cmd) }
+
+// Kill은 기본 레지스트리의 cmd 프로세스 트리를 종료합니다.
+func Kill(cmd *exec.Cmd) error { return defaultRegistry.Kill(cmd) }
+
+// Shutdown은 기본 레지스트리의 모든 프로세스 트리를 종료합니다.
+func Shutdown(ctx context.Context) error { return defaultRegistry.Shutdown(ctx) }
@@ -0,0 +1,45 @@
+//go:build !windows
+
+package procgroup
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// group은 Unix 프로세스 그룹입니다. 그룹 ID는 리더(cmd.Process)의 PID와 같습니다.
+type group struct{}
+
+// setGroupAttr는 cmd가 새 프로세스 그룹의 리더로 시작되도록 설정합니다.
+func setGroupAttr(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// newGroup은 시작된 cmd의 프로세스 그룹을 반환합니다.
+func newGroup(cmd *exec.Cmd) *group {
+	return &group{}
+}
+
+// close는 그룹 리소스를 해제합니다. Unix에서는 해제할 리소스가 없습니다.
+func (g *group) close() {}
+
+// killTree는 cmd의 프로세스 그룹 전체에 SIGKILL을 보냅니다.
+func killTree(cmd *exec.Cmd, _ *group) error {
+	if cmd.Process == nil {
+		return os.ErrProcessDone
+	}
+	err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	if errors.Is(err, syscall.ESRCH) {
+		return os.ErrProcessDone
+	}
+	if err != nil {
+		// 그룹을 만들지 못한 경우 리더만이라도 종료한다.
+		return cmd.Process.Kill()
+	}
+	return nil
+}
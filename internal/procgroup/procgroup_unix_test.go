@@ -0,0 +1,155 @@
+//go:build !windows
+
+package procgroup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processGone은 pid 프로세스가 종료되었는지 확인한다. 회수되지 않은 좀비도 종료된 것으로 본다.
+func processGone(pid int) bool {
+	if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+		return true
+	}
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return false
+	}
+	// /proc/<pid>/stat: "pid (comm) state ..."
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	return len(fields) > 0 && fields[0] == "Z"
+}
+
+func waitGone(t *testing.T, pid int) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !processGone(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("하위 프로세스 %d가 종료되지 않았습니다", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// startTree는 백그라운드 손자 프로세스를 만드는 셸을 시작하고 손자 PID를 반환한다.
+func startTree(t *testing.T, r *Registry, ctx context.Context) (*exec.Cmd, int) {
+	t.Helper()
+	cmd := exec.CommandContext(ctx, "sh", "-c", "sleep 30 & echo $!; wait")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() error: %v", err)
+	}
+	if err := r.Start(cmd); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("손자 PID 읽기 실패: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		t.Fatalf("손자 PID 파싱 실패: %q", line)
+	}
+	return cmd, pid
+}
+
+func TestStart_CancelKillsProcessTree(t *testing.T) {
+	r := NewRegistry()
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd, grandchild := startTree(t, r, ctx)
+	if r.Len() != 1 {
+		t.Fatalf("Len() = %d; want 1", r.Len())
+	}
+
+	cancel()
+	if err := r.Wait(cmd); err == nil {
+		t.Error("Wait() = nil; want error after cancel")
+	}
+	waitGone(t, grandchild)
+	if r.Len() != 0 {
+		t.Errorf("Len() = %d; want 0 after Wait", r.Len())
+	}
+}
+
+func TestRun_TimeoutDoesNotHangOnInheritedPipes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// 손자 프로세스가 stdout을 물려받아도 그룹 전체가 종료되므로 Run이 곧바로 끝나야 한다.
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", "sleep 30 & sleep 30")
+	cmd.Stdout = &out
+	start := time.Now()
+	if err := NewRegistry().Run(cmd); err == nil {
+		t.Fatal("Run() = nil; want timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Run() took %s; want prompt return after timeout", elapsed)
+	}
+}
+
+func TestRegistry_ShutdownKillsTrackedTrees(t *testing.T) {
+	r := NewRegistry()
+	cmd, grandchild := startTree(t, r, context.Background())
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- r.Wait(cmd) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := r.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error: %v", err)
+	}
+	if r.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", r.Len())
+	}
+	if err := <-waitErr; err == nil {
+		t.Error("Wait() = nil; want error for killed process")
+	}
+	waitGone(t, grandchild)
+
+	if err := r.Start(exec.Command("true")); !errors.Is(err, ErrShutdown) {
+		t.Errorf("Start() after Shutdown = %v; want ErrShutdown", err)
+	}
+}
+
+func TestOutput_CapturesStdoutAndStderr(t *testing.T) {
+	r := NewRegistry()
+	out, err := r.Output(exec.Command("sh", "-c", "echo out; echo err >&2; exit 3"))
+	if string(out) != "out\n" {
+		t.Errorf("Output() stdout = %q; want %q", out, "out\n")
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Output() error = %v; want *exec.ExitError", err)
+	}
+	if exitErr.ExitCode() != 3 || string(exitErr.Stderr) != "err\n" {
+		t.Errorf("exit code = %d, stderr = %q; want 3, %q", exitErr.ExitCode(), exitErr.Stderr, "err\n")
+	}
+	if r.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", r.Len())
+	}
+}
+
+func TestKill_AfterExitIsNoop(t *testing.T) {
+	r := NewRegistry()
+	cmd := exec.Command("true")
+	if err := r.Run(cmd); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if err := r.Kill(cmd); !errors.Is(err, os.ErrProcessDone) {
+		t.Errorf("Kill() after exit = %v; want os.ErrProcessDone", err)
+	}
+	if err := r.Kill(exec.Command("true")); err == nil {
+		t.Error("Kill() before Start = nil; want error")
+	}
+}
@@ -0,0 +1,85 @@
+//go:build windows
+
+package procgroup
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// group은 프로세스 트리를 묶는 Windows Job Object입니다.
+// JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE가 설정되어 있어 Bridge가 비정상 종료해 핸들이 닫혀도
+// Job에 속한 프로세스가 모두 종료됩니다.
+type group struct {
+	mu  sync.Mutex
+	job windows.Handle
+}
+
+// setGroupAttr는 cmd가 새 프로세스 그룹으로 시작되도록 설정합니다.
+func setGroupAttr(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= windows.CREATE_NEW_PROCESS_GROUP
+}
+
+// newGroup은 Job Object를 만들어 시작된 cmd를 배정합니다.
+// 실패하면 job이 없는 그룹을 반환하며, 이 경우 리더 프로세스만 종료할 수 있습니다.
+// 배정 전에 cmd가 만든 하위 프로세스는 Job에 포함되지 않습니다.
+func newGroup(cmd *exec.Cmd) *group {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return &group{}
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		_ = windows.CloseHandle(job)
+		return &group{}
+	}
+	proc, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(cmd.Process.Pid))
+	if err != nil {
+		_ = windows.CloseHandle(job)
+		return &group{}
+	}
+	defer windows.CloseHandle(proc)
+	if err := windows.AssignProcessToJobObject(job, proc); err != nil {
+		_ = windows.CloseHandle(job)
+		return &group{}
+	}
+	return &group{job: job}
+}
+
+// close는 Job 핸들을 닫습니다. Job에 남아 있던 하위 프로세스도 함께 종료됩니다.
+func (g *group) close() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.job != 0 {
+		_ = windows.CloseHandle(g.job)
+		g.job = 0
+	}
+}
+
+// killTree는 Job에 속한 프로세스를 모두 종료합니다. Job이 없으면 리더만 종료합니다.
+func killTree(cmd *exec.Cmd, g *group) error {
+	if cmd.Process == nil {
+		return os.ErrProcessDone
+	}
+	if g != nil {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.job != 0 {
+			return windows.TerminateJobObject(g.job, 1)
+		}
+	}
+	return cmd.Process.Kill()
+}
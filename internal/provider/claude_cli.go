@@ -13,6 +13,8 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 )
 
 // CLI 관련 에러 정의
@@ -127,6 +129,11 @@ func (p *ClaudeCLIProvider) ValidateConfig() error {
 	return p.checkCLI()
 }
 
+// SupportsExecutionEnv는 실행마다 CLI 프로세스를 띄워 ExecuteRequest.Env를 전달하므로 true를 반환합니다.
+func (p *ClaudeCLIProvider) SupportsExecutionEnv() bool {
+	return true
+}
+
 // Supports는 주어진 모델명을 지원하는지 확인합니다.
 // CLI 모드에서는 claude- 접두사를 가진 모든 모델을 지원합니다.
 func (p *ClaudeCLIProvider) Supports(model string) bool {
@@ -176,6 +183,11 @@ func (p *ClaudeCLIProvider) Execute(ctx context.Context, req ExecuteRequest) (*E
 		args = append(args, "--allowedTools", strings.Join(req.Tools, ","))
 	}
 
+	// 체크포인트에서 재개하는 경우 기존 세션을 이어서 사용
+	if req.ResumeSessionID != "" {
+		args = append(args, "--resume", req.ResumeSessionID)
+	}
+
 	// 프롬프트 추가
 	args = append(args, req.Prompt)
 
@@ -197,9 +209,9 @@ func (p *ClaudeCLIProvider) Execute(ctx context.Context, req ExecuteRequest) (*E
 	// Claude Code 세션 감지 환경변수를 제거하여 하위 Claude CLI 실행을 허용한다.
 	// 브릿지가 Claude Code 세션 내에서 실행될 때 이 변수들이 설정되어 있으면
 	// 하위 프로세스에서 "cannot be launched inside another Claude Code session" 에러가 발생한다.
-	cmd.Env = filterEnv(os.Environ(), "CLAUDECODE", "CLAUDE_CODE_ENTRYPOINT")
+	cmd.Env = withExtraEnv(filterEnv(os.Environ(), "CLAUDECODE", "CLAUDE_CODE_ENTRYPOINT"), req.Env)
 
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 
 	// 컨텍스트 취소 확인
 	if execCtx.Err() == context.DeadlineExceeded {
@@ -316,6 +328,7 @@ type StreamCallback func(textDelta string, accumulatedText string)
 
 // ExecuteStreaming은 claude CLI를 stream-json 모드로 실행하여 실시간 스트리밍을 제공합니다.
 // onDelta 콜백은 블록 스트리밍 로직에 따라 텍스트 청크가 준비될 때 호출됩니다.
+// CLI가 실행한 tool_use 블록은 응답의 ToolCalls로 기록됩니다.
 func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteRequest, onDelta StreamCallback) (*ExecuteResponse, error) {
 	startTime := time.Now()
 
@@ -333,10 +346,12 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 
 	// stream-json 모드로 CLI 명령 구성
 	// Claude Code CLI 2.1.x에서는 --print + stream-json 조합에 --verbose가 필요합니다.
+	// --include-partial-messages가 있어야 완성된 메시지 대신 토큰 단위 델타가 전달됩니다.
 	args := []string{
 		"--print",
 		"--verbose",
 		"--output-format", "stream-json",
+		"--include-partial-messages",
 		"--model", model,
 	}
 
@@ -348,6 +363,10 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 		args = append(args, "--allowedTools", strings.Join(req.Tools, ","))
 	}
 
+	if req.ResumeSessionID != "" {
+		args = append(args, "--resume", req.ResumeSessionID)
+	}
+
 	args = append(args, req.Prompt)
 
 	cmd := exec.CommandContext(execCtx, p.cliPath, args...)
@@ -359,7 +378,7 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 	}
 
 	// Claude Code 세션 감지 환경변수를 제거하여 하위 Claude CLI 실행을 허용한다.
-	cmd.Env = filterEnv(os.Environ(), "CLAUDECODE", "CLAUDE_CODE_ENTRYPOINT")
+	cmd.Env = withExtraEnv(filterEnv(os.Environ(), "CLAUDECODE", "CLAUDE_CODE_ENTRYPOINT"), req.Env)
 
 	// StdoutPipe로 실시간 출력 읽기
 	stdoutPipe, err := cmd.StdoutPipe()
@@ -370,13 +389,19 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	if err := cmd.Start(); err != nil {
+	if err := procgroup.Start(cmd); err != nil {
 		return nil, fmt.Errorf("%w: 프로세스 시작 실패: %v", ErrCLIExecution, err)
 	}
 
 	// StreamAccumulator로 블록 스트리밍
 	accumulator := NewStreamAccumulator()
+	toolUses := NewToolUseCollector()
 	var resultLine *StreamLine
+	// 부분 델타를 받지 못한 경우(구버전 CLI) 완성된 assistant 메시지의 텍스트를 사용한다.
+	sawTextDelta := false
+	// 체크포인트용 세션 ID와 완료된 어시스턴트 턴 수
+	sessionID := ""
+	turns := 0
 
 	// 타임아웃 기반 플러시를 위한 goroutine
 	flushCtx, flushCancel := context.WithCancel(execCtx)
@@ -414,8 +439,30 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 			continue
 		}
 
+		for _, call := range toolUses.Add(parsed) {
+			log.Printf("[ClaudeCLI] stream-json: tool_use %s (id=%s)", call.Name, call.ID)
+		}
+
+		if req.OnSession != nil {
+			if sessionID == "" && parsed.SessionID != "" {
+				sessionID = parsed.SessionID
+				req.OnSession(sessionID, turns)
+			}
+			if parsed.IsAssistantMessage() && sessionID != "" {
+				turns++
+				req.OnSession(sessionID, turns)
+			}
+		}
+
+		text := ""
 		if parsed.IsTextDelta() {
-			accumulator.Add(parsed.Delta.Text)
+			sawTextDelta = true
+			text = parsed.Delta.Text
+		} else if parsed.IsAssistantMessage() && !sawTextDelta {
+			text = parsed.MessageText()
+		}
+		if text != "" {
+			accumulator.Add(text)
 			// ShouldFlush 체크는 ticker goroutine에서도 하지만, 즉시 체크도 수행
 			if accumulator.ShouldFlush() {
 				delta := accumulator.Flush()
@@ -443,7 +490,7 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 	}
 
 	// 프로세스 종료 대기
-	waitErr := cmd.Wait()
+	waitErr := procgroup.Wait(cmd)
 
 	if execCtx.Err() == context.DeadlineExceeded {
 		return nil, fmt.Errorf("%w: %v초 후 타임아웃", ErrCLITimeout, p.timeout.Seconds())
@@ -487,6 +534,7 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 			DurationMs: durationMs,
 			Model:      model,
 			StopReason: "end_turn",
+			ToolCalls:  toolUses.ToolCalls(),
 		}, nil
 	}
 
@@ -505,6 +553,7 @@ func (p *ClaudeCLIProvider) ExecuteStreaming(ctx context.Context, req ExecuteReq
 			DurationMs: time.Since(startTime).Milliseconds(),
 			Model:      model,
 			StopReason: "end_turn",
+			ToolCalls:  toolUses.ToolCalls(),
 		}, nil
 	}
 
@@ -540,3 +589,18 @@ func filterEnv(env []string, keys ...string) []string {
 	}
 	return filtered
 }
+
+// withExtraEnv는 env에서 extra와 같은 키를 제거한 뒤 extra를 추가합니다.
+// 실행 범위 환경 변수(ExecuteRequest.Env)를 CLI 프로세스에 적용할 때 사용합니다.
+func withExtraEnv(env, extra []string) []string {
+	if len(extra) == 0 {
+		return env
+	}
+	keys := make([]string, 0, len(extra))
+	for _, kv := range extra {
+		if key, _, ok := strings.Cut(kv, "="); ok {
+			keys = append(keys, key)
+		}
+	}
+	return append(filterEnv(env, keys...), extra...)
+}
@@ -82,3 +82,63 @@ func TestClaudeCLIProvider_ExecuteStreaming_UsesVerboseFlag(t *testing.T) {
 		t.Fatalf("expected --verbose in CLI args, got: %s", string(argsBytes))
 	}
 }
+
+func TestClaudeCLIProvider_ExecuteStreaming_MapsToolUse(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script based test is not supported on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	cliPath := filepath.Join(tmpDir, "fake-claude")
+
+	// --include-partial-messages 출력: 부분 이벤트는 stream_event로 감싸지고,
+	// 완성된 assistant 메시지에도 같은 tool_use 블록이 다시 포함된다.
+	lines := []string{
+		`{"type":"system","subtype":"init","session_id":"s1"}`,
+		`{"type":"stream_event","event":{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}}`,
+		`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Listing files.\n"}}}`,
+		`{"type":"stream_event","event":{"type":"content_block_stop","index":0}}`,
+		`{"type":"stream_event","event":{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"Bash","input":{}}}}`,
+		`{"type":"stream_event","event":{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"command\":"}}}`,
+		`{"type":"stream_event","event":{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"ls\"}"}}}`,
+		`{"type":"stream_event","event":{"type":"content_block_stop","index":1}}`,
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"Listing files.\n"},{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"command":"ls"}}]}}`,
+		`{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"a.go"}]}}`,
+		`{"type":"stream_event","event":{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Done."}}}`,
+		`{"type":"result","subtype":"success","result":"Done.","duration_ms":1}`,
+	}
+	script := "#!/bin/sh\ncat <<'EOF'\n" + strings.Join(lines, "\n") + "\nEOF\n"
+	if err := os.WriteFile(cliPath, []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to create fake claude CLI: %v", err)
+	}
+
+	p, err := NewClaudeCLIProvider(WithCLIPath(cliPath), WithCLITimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	var accumulated string
+	resp, err := p.ExecuteStreaming(context.Background(), ExecuteRequest{
+		Prompt: "list files",
+		Model:  "claude-sonnet-4-20250514",
+	}, func(_, acc string) {
+		accumulated = acc
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStreaming failed: %v", err)
+	}
+
+	if accumulated != "Listing files.\nDone." {
+		t.Errorf("accumulated text = %q; assistant 메시지 텍스트가 중복되면 안 됩니다", accumulated)
+	}
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("tool calls = %d, want 1: %+v", len(resp.ToolCalls), resp.ToolCalls)
+	}
+	call := resp.ToolCalls[0]
+	if call.ID != "toolu_1" || call.Name != "Bash" || string(call.Input) != `{"command":"ls"}` {
+		t.Errorf("unexpected tool call: id=%s name=%s input=%s", call.ID, call.Name, call.Input)
+	}
+	if resp.StopReason != "end_turn" {
+		t.Errorf("stop reason = %q, want end_turn", resp.StopReason)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/creack/pty"
@@ -39,8 +40,11 @@ type InteractiveClaudeCLIProvider struct {
 	hookServerPort int
 	// approvalTimeout은 승인 대기 타임아웃입니다.
 	approvalTimeout time.Duration
-	// approvalHandler는 외부에서 주입된 승인 핸들러입니다.
+	// approvalHandler는 SetApprovalHandler로 주입된 기본 승인 핸들러입니다.
+	// 실행별 핸들러(ExecuteRequest.ApprovalHandler)가 있으면 그것을 우선 사용합니다.
 	approvalHandler approval.ApprovalHandler
+	// handlerMu는 approvalHandler를 보호합니다.
+	handlerMu sync.RWMutex
 	// logger는 구조화된 로거입니다.
 	logger zerolog.Logger
 }
@@ -134,6 +138,11 @@ func (p *InteractiveClaudeCLIProvider) ValidateConfig() error {
 	return p.validateCLI()
 }
 
+// SupportsExecutionEnv는 실행마다 CLI 프로세스를 띄워 ExecuteRequest.Env를 전달하므로 true를 반환합니다.
+func (p *InteractiveClaudeCLIProvider) SupportsExecutionEnv() bool {
+	return true
+}
+
 // Supports는 주어진 모델명을 지원하는지 확인합니다.
 // claude- 접두사를 가진 모든 모델을 지원합니다.
 func (p *InteractiveClaudeCLIProvider) Supports(model string) bool {
@@ -146,12 +155,25 @@ func (p *InteractiveClaudeCLIProvider) SupportsApproval() bool {
 	return true
 }
 
-// SetApprovalHandler는 승인 요청을 처리할 핸들러를 등록합니다.
-// 이 핸들러는 PreToolUse 훅이 수신될 때 호출됩니다.
+// SetApprovalHandler는 승인 요청을 처리할 기본 핸들러를 등록합니다.
+// 이 핸들러는 ExecuteRequest.ApprovalHandler가 없는 실행에서 PreToolUse 훅이 수신될 때 호출됩니다.
 func (p *InteractiveClaudeCLIProvider) SetApprovalHandler(handler approval.ApprovalHandler) {
+	p.handlerMu.Lock()
+	defer p.handlerMu.Unlock()
 	p.approvalHandler = handler
 }
 
+// approvalHandlerFor는 실행에 사용할 승인 핸들러를 반환합니다.
+// 동시에 실행되는 작업이 서로의 핸들러를 덮어쓰지 않도록 실행별 핸들러를 우선합니다.
+func (p *InteractiveClaudeCLIProvider) approvalHandlerFor(req ExecuteRequest) approval.ApprovalHandler {
+	if req.ApprovalHandler != nil {
+		return req.ApprovalHandler
+	}
+	p.handlerMu.RLock()
+	defer p.handlerMu.RUnlock()
+	return p.approvalHandler
+}
+
 // Execute는 Claude CLI를 인터랙티브 모드(PTY)로 실행하고 결과를 반환합니다.
 //
 // 실행 흐름:
@@ -184,7 +206,7 @@ func (p *InteractiveClaudeCLIProvider) Execute(ctx context.Context, req ExecuteR
 	approvalMgr := hook.NewApprovalManager(p.approvalTimeout, p.logger)
 
 	// OnApproval 콜백: 훅 핸들러에서 PreToolUse 수신 시 승인 흐름 브릿지
-	onApproval := p.createApprovalBridge(ctx, approvalMgr)
+	onApproval := p.createApprovalBridge(ctx, approvalMgr, p.approvalHandlerFor(req))
 
 	// 훅 핸들러 생성
 	hookHandler := hook.NewHookHandler(
@@ -252,7 +274,7 @@ func (p *InteractiveClaudeCLIProvider) Execute(ctx context.Context, req ExecuteR
 	cmd := exec.CommandContext(execCtx, p.cliPath, args...)
 
 	// 환경변수: CLAUDE_CONFIG_DIR을 세션 디렉토리로 설정
-	cmd.Env = append(os.Environ(), "CLAUDE_CONFIG_DIR="+sessionDir)
+	cmd.Env = withExtraEnv(append(os.Environ(), "CLAUDE_CONFIG_DIR="+sessionDir), req.Env)
 
 	// 작업 디렉토리 설정
 	if req.WorkDir != "" {
@@ -342,16 +364,17 @@ func (p *InteractiveClaudeCLIProvider) Execute(ctx context.Context, req ExecuteR
 // 흐름:
 //  1. HookHandler가 PreToolUse를 수신하면 OnApproval 콜백을 고루틴에서 호출
 //  2. 콜백이 hook.HookRequest를 approval.ToolApprovalRequest로 변환
-//  3. approvalHandler를 호출하여 ToolApprovalDecision을 받음
+//  3. handler를 호출하여 ToolApprovalDecision을 받음
 //  4. ToolApprovalDecision을 hook.ApprovalDecision으로 변환
 //  5. ApprovalManager.DeliverDecision으로 결과를 훅 핸들러에 전달
 func (p *InteractiveClaudeCLIProvider) createApprovalBridge(
 	ctx context.Context,
 	approvalMgr *hook.ApprovalManager,
+	handler approval.ApprovalHandler,
 ) hook.OnApprovalFunc {
 	return func(_ context.Context, req hook.HookRequest) {
-		// approvalHandler가 설정되지 않은 경우 자동 허용
-		if p.approvalHandler == nil {
+		// 승인 핸들러가 설정되지 않은 경우 자동 허용
+		if handler == nil {
 			p.logger.Debug().
 				Str("approval_id", req.ApprovalID).
 				Str("tool_name", req.ToolName).
@@ -380,7 +403,7 @@ func (p *InteractiveClaudeCLIProvider) createApprovalBridge(
 			Msg("승인 핸들러로 승인 요청 전달")
 
 		// 승인 핸들러 호출 (ApprovalRouter로 라우팅됨)
-		decision, err := p.approvalHandler(ctx, approvalReq)
+		decision, err := handler(ctx, approvalReq)
 
 		// approval.ToolApprovalDecision → hook.ApprovalDecision 변환
 		var hookDecision hook.ApprovalDecision
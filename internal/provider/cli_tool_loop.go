@@ -235,6 +235,7 @@ func buildToolLoopExecuteRequest(req ExecuteRequest) ExecuteRequest {
 		WorkDir:      req.WorkDir,
 		SystemPrompt: "", // buildCLIToolLoopPrompt에서 이미 포함됨
 		ResponseMode: "", // 재귀 방지: tool_loop 모드 제거
+		Env:          req.Env,
 	}
 }
 
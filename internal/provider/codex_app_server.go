@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -76,8 +77,25 @@ var (
 	ErrHandshakeTimeout = fmt.Errorf("초기화 핸드셰이크 타임아웃")
 	// ErrProcessNotRunning은 App Server 프로세스가 실행 중이 아닐 때 반환됩니다.
 	ErrProcessNotRunning = fmt.Errorf("App Server 프로세스가 실행 중이 아닙니다")
+	// ErrTurnInterrupted는 턴 진행 중 App Server 스트림이 끊겼을 때 반환됩니다 (재시도 가능).
+	ErrTurnInterrupted = fmt.Errorf("턴 진행 중 App Server 연결 종료")
 )
 
+// appServerRequestTimeout은 App Server JSON-RPC 요청 하나의 기본 응답 대기 한도입니다.
+// turn/start 등은 즉시 응답하고 결과는 알림으로 오므로, 이 한도를 넘으면 프로세스가 멈춘 것으로 봅니다.
+const appServerRequestTimeout = 2 * time.Minute
+
+const (
+	// defaultAppServerTurnRetries는 스트림이 끊긴 턴을 다시 시도하는 기본 횟수입니다.
+	defaultAppServerTurnRetries = 2
+	// appServerTurnRetryDelay는 턴 재시도 전 대기 시간의 단위입니다 (재시도 횟수에 비례).
+	appServerTurnRetryDelay = 500 * time.Millisecond
+)
+
+// appServerContinuePrompt는 중단된 턴을 기존 스레드에서 이어서 진행하도록 요청하는 프롬프트입니다.
+const appServerContinuePrompt = "The previous response was interrupted by a connection failure. " +
+	"Continue exactly where you left off without repeating what you already wrote."
+
 // AppServerProcess는 Codex App Server 프로세스를 관리합니다.
 // exec.Cmd를 사용하여 하위 프로세스를 시작하고, stdin/stdout 파이프를 통해
 // JSON-RPC 2.0 프로토콜로 통신합니다.
@@ -90,6 +108,8 @@ type AppServerProcess struct {
 	mu           sync.Mutex
 	running      atomic.Bool
 	logger       zerolog.Logger
+	// framer는 JSON-RPC 메시지 프레이밍입니다. nil이면 줄바꿈 구분 JSON을 사용합니다.
+	framer client.Framer
 
 	// lastStderrError는 stderr에서 캡처한 마지막 에러 메시지입니다.
 	// "ERROR:" 접두사가 포함된 줄을 저장하며, 빈 응답 원인 진단에 사용됩니다.
@@ -112,7 +132,11 @@ func NewAppServerProcess(cliPath string, logger zerolog.Logger) *AppServerProces
 func (p *AppServerProcess) Start(ctx context.Context) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.startLocked(ctx)
+}
 
+// startLocked는 p.mu를 잡은 상태에서 프로세스를 시작합니다.
+func (p *AppServerProcess) startLocked(ctx context.Context) error {
 	// 프로세스 커맨드 생성
 	p.cmd = exec.Command(p.cliPath, "app-server")
 
@@ -142,13 +166,14 @@ func (p *AppServerProcess) Start(ctx context.Context) error {
 	p.running.Store(true)
 
 	// 공유 JSON-RPC 클라이언트 생성 (zerologAdapter 사용)
-	p.client = client.NewJSONRPCClient(stdinPipe, stdoutPipe, zerologAdapter{p.logger})
+	p.client = client.NewJSONRPCClient(stdinPipe, stdoutPipe, zerologAdapter{p.logger},
+		client.WithRequestTimeout(appServerRequestTimeout), client.WithFraming(p.framer))
 
 	// stderr 로거 고루틴 시작
 	go p.logStderr(stderrPipe)
 
 	// 프로세스 모니터 고루틴 시작
-	go p.monitor()
+	go p.monitor(p.cmd)
 
 	// 초기화 핸드셰이크 수행
 	if err := p.initialize(ctx); err != nil {
@@ -172,7 +197,11 @@ func (p *AppServerProcess) Start(ctx context.Context) error {
 func (p *AppServerProcess) Stop() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	return p.stopLocked()
+}
 
+// stopLocked는 p.mu를 잡은 상태에서 프로세스를 중지합니다.
+func (p *AppServerProcess) stopLocked() error {
 	if !p.running.Load() {
 		return nil
 	}
@@ -216,6 +245,13 @@ func (p *AppServerProcess) Stop() error {
 // Restart는 프로세스를 재시작합니다.
 // 최대 재시작 횟수를 초과하면 ErrMaxRestartsExceeded를 반환합니다.
 func (p *AppServerProcess) Restart(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.restartLocked(ctx)
+}
+
+// restartLocked는 p.mu를 잡은 상태에서 프로세스를 재시작합니다.
+func (p *AppServerProcess) restartLocked(ctx context.Context) error {
 	p.restartCount++
 	if p.restartCount >= p.maxRestarts {
 		return ErrMaxRestartsExceeded
@@ -226,11 +262,29 @@ func (p *AppServerProcess) Restart(ctx context.Context) error {
 		Int("maxRestarts", p.maxRestarts).
 		Msg("App Server 프로세스 재시작")
 
-	if err := p.Stop(); err != nil {
+	if err := p.stopLocked(); err != nil {
 		p.logger.Warn().Err(err).Msg("프로세스 중지 실패 (재시작 계속 시도)")
 	}
 
-	return p.Start(ctx)
+	return p.startLocked(ctx)
+}
+
+// Recover는 prev 클라이언트의 연결이 끊긴 뒤 App Server를 다시 사용할 수 있게 합니다.
+// monitor가 이미 새 프로세스로 교체했으면 그대로 두고, 아니면 직접 재시작합니다.
+// 턴 재시도용이므로 monitor의 자동 재시작 횟수(maxRestarts)에는 포함하지 않습니다.
+func (p *AppServerProcess) Recover(ctx context.Context, prev *client.Client) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running.Load() && p.client != nil && p.client != prev {
+		return nil
+	}
+
+	p.logger.Info().Msg("턴 재시도를 위해 App Server 프로세스 재시작")
+	if err := p.stopLocked(); err != nil {
+		p.logger.Warn().Err(err).Msg("프로세스 중지 실패 (재시작 계속 시도)")
+	}
+	return p.startLocked(ctx)
 }
 
 // Client는 JSON-RPC 클라이언트를 반환합니다.
@@ -324,28 +378,38 @@ func (p *AppServerProcess) ClearLastError() {
 
 // monitor는 프로세스 종료를 감시하는 고루틴입니다.
 // 프로세스가 예기치 않게 종료되면 자동 재시작을 시도합니다.
-func (p *AppServerProcess) monitor() {
-	if p.cmd == nil {
+func (p *AppServerProcess) monitor(cmd *exec.Cmd) {
+	if cmd == nil {
 		return
 	}
 
 	// cmd.Wait()은 프로세스가 종료될 때까지 블록
-	err := p.cmd.Wait()
+	err := cmd.Wait()
 
-	// running이 여전히 true이면 예기치 않은 종료
-	if p.running.Load() {
-		p.logger.Error().
-			Err(err).
-			Msg("App Server 프로세스 예기치 않게 종료, 재시작 시도")
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-		p.running.Store(false)
+	// 의도적으로 중지했거나 Recover가 이미 새 프로세스로 교체했으면 재시작하지 않는다.
+	if !p.running.Load() || p.cmd != cmd {
+		return
+	}
 
-		// 백그라운드 컨텍스트로 재시작 시도
-		if restartErr := p.Restart(context.Background()); restartErr != nil {
-			p.logger.Error().
-				Err(restartErr).
-				Msg("App Server 프로세스 재시작 실패")
-		}
+	inFlight := 0
+	if p.client != nil {
+		inFlight = p.client.InFlightCount()
+	}
+	p.logger.Error().
+		Err(err).
+		Int("inFlight", inFlight).
+		Msg("App Server 프로세스 예기치 않게 종료, 재시작 시도")
+
+	p.running.Store(false)
+
+	// 백그라운드 컨텍스트로 재시작 시도
+	if restartErr := p.restartLocked(context.Background()); restartErr != nil {
+		p.logger.Error().
+			Err(restartErr).
+			Msg("App Server 프로세스 재시작 실패")
 	}
 }
 
@@ -363,6 +427,16 @@ type CodexAppServerProvider struct {
 	authAccountID  string // chatgptAuthTokens 전용 account ID
 	rpcRelay       *approval.RPCRelay
 	logger         zerolog.Logger
+	// turnRetries는 스트림이 끊긴 턴을 다시 시도하는 최대 횟수입니다 (0이면 재시도 안 함).
+	turnRetries int
+	// framer는 App Server와 주고받는 JSON-RPC 메시지 프레이밍입니다 (nil이면 줄바꿈 구분 JSON).
+	framer client.Framer
+	// recoverProcess는 끊긴 연결 대신 쓸 App Server를 준비합니다 (nil이면 process.Recover + 인증).
+	recoverProcess func(ctx context.Context, prev *client.Client) error
+	// suspended는 유휴 절전으로 프로세스를 내린 상태입니다. 다음 실행 전에 Warmup으로 다시 기동합니다.
+	suspended atomic.Bool
+	// warmupMu는 절전 복귀 시 동시에 들어온 Warmup이 프로세스를 중복 기동하지 않도록 직렬화합니다.
+	warmupMu sync.Mutex
 }
 
 // CodexAppServerOption은 CodexAppServerProvider 설정 옵션입니다.
@@ -390,6 +464,25 @@ func WithAppServerDefaultModel(model string) CodexAppServerOption {
 	}
 }
 
+// WithAppServerTurnRetries는 턴 진행 중 App Server 스트림이 끊겼을 때 턴을 다시 시도하는 최대 횟수를 설정합니다.
+// 0이면 재시도하지 않고 바로 에러를 반환합니다.
+func WithAppServerTurnRetries(n int) CodexAppServerOption {
+	return func(p *CodexAppServerProvider) {
+		if n < 0 {
+			n = 0
+		}
+		p.turnRetries = n
+	}
+}
+
+// WithAppServerFraming은 App Server와 주고받는 JSON-RPC 메시지 프레이밍을 설정합니다.
+// Content-Length 헤더를 쓰는 LSP 방식 CLI는 client.ContentLengthFraming{}을 사용합니다.
+func WithAppServerFraming(framer client.Framer) CodexAppServerOption {
+	return func(p *CodexAppServerProvider) {
+		p.framer = framer
+	}
+}
+
 // WithAppServerAuth는 인증 방식을 설정합니다.
 // method:
 //   - "apiKey"            : key=API Key
@@ -411,6 +504,7 @@ func NewCodexAppServerProvider(cliPath string, opts ...CodexAppServerOption) (*C
 		authMethod:     "apiKey",
 		rpcRelay:       approval.NewRPCRelay("codex"),
 		logger:         zerolog.New(os.Stderr).With().Timestamp().Logger(),
+		turnRetries:    defaultAppServerTurnRetries,
 	}
 
 	// 옵션 적용
@@ -420,6 +514,7 @@ func NewCodexAppServerProvider(cliPath string, opts ...CodexAppServerOption) (*C
 
 	// AppServerProcess 생성 및 시작
 	p.process = NewAppServerProcess(cliPath, p.logger)
+	p.process.framer = p.framer
 	if err := p.process.Start(context.Background()); err != nil {
 		return nil, fmt.Errorf("App Server 프로세스 시작 실패: %w", err)
 	}
@@ -448,9 +543,9 @@ func (p *CodexAppServerProvider) Supports(model string) bool {
 }
 
 // ValidateConfig는 프로바이더 설정의 유효성을 검사합니다.
-// 프로세스 실행 상태와 인증 키를 확인합니다.
+// 프로세스 실행 상태와 인증 키를 확인합니다. 절전으로 중단된 프로세스는 실행 중으로 간주합니다.
 func (p *CodexAppServerProvider) ValidateConfig() error {
-	if !p.process.IsRunning() {
+	if !p.process.IsRunning() && !p.suspended.Load() {
 		return ErrProcessNotRunning
 	}
 	if p.authKey == "" {
@@ -459,6 +554,37 @@ func (p *CodexAppServerProvider) ValidateConfig() error {
 	return nil
 }
 
+// Warmup은 App Server 프로세스가 중지된 경우 다시 시작하고 인증을 수행합니다.
+// 이미 실행 중이면 아무 작업도 하지 않습니다.
+func (p *CodexAppServerProvider) Warmup(ctx context.Context) error {
+	p.warmupMu.Lock()
+	defer p.warmupMu.Unlock()
+	if p.process.IsRunning() {
+		p.suspended.Store(false)
+		return nil
+	}
+
+	if err := p.process.Start(ctx); err != nil {
+		return fmt.Errorf("App Server 프로세스 시작 실패: %w", err)
+	}
+	if err := p.authenticate(ctx); err != nil {
+		_ = p.process.Stop()
+		return fmt.Errorf("인증 실패: %w", err)
+	}
+	p.suspended.Store(false)
+	return nil
+}
+
+// Suspend는 유휴 절전을 위해 App Server 프로세스를 중지합니다.
+// 다음 실행 요청이나 Warmup에서 프로세스를 다시 시작하고 인증합니다.
+func (p *CodexAppServerProvider) Suspend() error {
+	if !p.process.IsRunning() {
+		return nil
+	}
+	p.suspended.Store(true)
+	return p.process.Stop()
+}
+
 // Execute는 프롬프트를 실행하고 결과를 반환합니다.
 // 스트리밍 콜백 없이 executeInternal을 호출합니다.
 func (p *CodexAppServerProvider) Execute(ctx context.Context, req ExecuteRequest) (*ExecuteResponse, error) {
@@ -511,18 +637,138 @@ func (p *CodexAppServerProvider) authenticate(ctx context.Context) error {
 	return nil
 }
 
-// executeInternal은 실제 실행 로직을 구현합니다.
-// 1. thread/start로 스레드 생성
+// appServerTurnState는 턴 재시도 사이에 유지되는 실행 상태입니다.
+type appServerTurnState struct {
+	mu        sync.Mutex // output과 toolCalls 보호용 뮤텍스
+	output    strings.Builder
+	toolCalls []ToolCall
+	// accumulator는 스트리밍 모드의 델타 누적기입니다. 재시도해도 이미 전달한 텍스트는 유지됩니다.
+	accumulator *StreamAccumulator
+
+	// client는 마지막 시도가 사용한 JSON-RPC 클라이언트입니다.
+	client *client.Client
+	// threadID는 마지막 시도가 사용한 스레드입니다. 재시도 시 thread/resume으로 이어서 사용합니다.
+	threadID string
+	// started는 turn/start가 한 번이라도 수락되었는지 여부입니다.
+	started bool
+	// continuation이 설정되면 원래 프롬프트 대신 이 프롬프트로 턴을 시작합니다.
+	continuation string
+	// resumeFailed는 재시도 중 thread/resume에 실패해 새 스레드로 다시 시작했는지 여부입니다.
+	resumeFailed bool
+}
+
+// prepareRetry는 중단된 시도의 상태로 다음 시도를 준비하고 재시도 방식을 retry에 기록합니다.
+// 스레드가 있으면 이어서 사용하고, 턴이 이미 시작되었으면 처음부터가 아니라 이어서 진행합니다.
+func (st *appServerTurnState) prepareRetry(retry *RetryAttempt) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	retry.Resumed = st.threadID != ""
+	if retry.Resumed && st.started {
+		st.continuation = appServerContinuePrompt
+		retry.Continued = true
+		return
+	}
+	st.reset()
+}
+
+// reset은 부분 결과를 버리고 원래 프롬프트로 턴을 다시 시작하도록 합니다. st.mu를 잡고 호출합니다.
+func (st *appServerTurnState) reset() {
+	st.output.Reset()
+	st.toolCalls = nil
+	st.started = false
+	st.continuation = ""
+}
+
+// executeInternal은 턴을 실행하고, 턴 진행 중 App Server 스트림이 끊기면
+// 프로세스를 복구한 뒤 기존 스레드를 이어서 최대 turnRetries번 다시 시도합니다.
+func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req ExecuteRequest, onDelta StreamCallback) (*ExecuteResponse, error) {
+	if p.suspended.Load() {
+		if err := p.Warmup(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	st := &appServerTurnState{}
+	if onDelta != nil {
+		st.accumulator = NewStreamAccumulator()
+	}
+
+	var retries []RetryAttempt
+	for {
+		resp, err := p.runTurn(ctx, req, onDelta, st)
+		if st.resumeFailed && len(retries) > 0 {
+			retries[len(retries)-1].Resumed = false
+			retries[len(retries)-1].Continued = false
+		}
+		st.resumeFailed = false
+		if err == nil {
+			resp.Retries = retries
+			return resp, nil
+		}
+		if !errors.Is(err, ErrTurnInterrupted) || len(retries) >= p.turnRetries || ctx.Err() != nil {
+			if len(retries) > 0 {
+				return nil, fmt.Errorf("%w (턴 재시도 %d회 후 실패)", err, len(retries))
+			}
+			return nil, err
+		}
+
+		retry := RetryAttempt{Attempt: len(retries) + 1, Reason: err.Error()}
+		p.logger.Warn().
+			Err(err).
+			Int("attempt", retry.Attempt).
+			Int("max_retries", p.turnRetries).
+			Str("thread_id", st.threadID).
+			Msg("App Server 스트림 중단, 턴 재시도")
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("실행 타임아웃: %w", ctx.Err())
+		case <-time.After(time.Duration(retry.Attempt) * appServerTurnRetryDelay):
+		}
+		if recoverErr := p.recover(ctx, st.client); recoverErr != nil {
+			return nil, fmt.Errorf("턴 재시도를 위한 App Server 복구 실패: %w (원인: %v)", recoverErr, err)
+		}
+		st.prepareRetry(&retry)
+		retries = append(retries, retry)
+	}
+}
+
+// recover는 prev 연결이 끊긴 App Server를 재시작(필요 시)하고 다시 인증합니다.
+func (p *CodexAppServerProvider) recover(ctx context.Context, prev *client.Client) error {
+	if p.recoverProcess != nil {
+		return p.recoverProcess(ctx, prev)
+	}
+	if err := p.process.Recover(ctx, prev); err != nil {
+		return err
+	}
+	return p.authenticate(ctx)
+}
+
+// streamClosed는 rpcClient의 스트림이 끊겼는지 확인합니다.
+func streamClosed(rpcClient *client.Client) bool {
+	select {
+	case <-rpcClient.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// runTurn은 턴 한 번을 실행합니다.
+// 1. thread/start로 스레드 생성 (재시도 시 thread/resume으로 기존 스레드 재개)
 // 2. 알림 핸들러 등록 (메시지 델타, 아이템 완료, 승인 요청, Turn 완료)
 // 3. turn/start로 턴 시작
 // 4. Turn 완료 대기
 // 5. 결과 조립 및 반환
-func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req ExecuteRequest, onDelta StreamCallback) (*ExecuteResponse, error) {
+// 스트림이 끊기면 ErrTurnInterrupted를 감싼 에러를 반환하며, 진행 상태는 st에 남습니다.
+func (p *CodexAppServerProvider) runTurn(ctx context.Context, req ExecuteRequest, onDelta StreamCallback, st *appServerTurnState) (*ExecuteResponse, error) {
 	// 1. 클라이언트 확인
 	rpcClient := p.process.Client()
 	if rpcClient == nil || !p.process.IsRunning() {
 		return nil, ErrProcessNotRunning
 	}
+	st.client = rpcClient
 
 	// 2. 승인 정책 결정 (Codex App Server 호환 매핑)
 	approvalPolicy := p.approvalPolicy
@@ -571,40 +817,77 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		p.logger.Info().Int("tool_count", len(dynamicTools)).Msg("tool_loop: dynamicTools 네이티브 등록")
 	}
 
-	threadResult, err := rpcClient.Call(ctx, protocol.MethodThreadStart, protocol.ThreadStartParams{
-		Model:          model,
-		Cwd:            cwd,
-		ApprovalPolicy: approvalPolicy,
-		DynamicTools:   dynamicTools,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("thread/start 실패: %w", err)
-	}
-
-	// thread/start 결과 파싱
-	// Codex App Server는 {"thread":{"id":"..."},...} 형태로 응답하므로
-	// 중첩된 thread.id를 추출한다.
+	// 체크포인트에서 재개하거나 턴을 재시도하는 경우 새 Thread 대신 기존 Thread를 이어서 사용한다.
 	var threadID string
-	if threadResult != nil {
-		// 먼저 중첩 구조 시도: {"thread":{"id":"..."}}
-		var nested struct {
-			Thread struct {
-				ID string `json:"id"`
-			} `json:"thread"`
-		}
-		if err := json.Unmarshal(*threadResult, &nested); err == nil && nested.Thread.ID != "" {
-			threadID = nested.Thread.ID
+	resumeID := req.ResumeSessionID
+	retrying := st.threadID != ""
+	if retrying {
+		resumeID = st.threadID
+	}
+	if resumeID != "" {
+		if _, err := rpcClient.Call(ctx, protocol.MethodThreadResume, protocol.ThreadResumeParams{
+			ThreadID: resumeID,
+		}); err != nil {
+			if streamClosed(rpcClient) {
+				return nil, fmt.Errorf("%w: thread/resume 실패: %v", ErrTurnInterrupted, err)
+			}
+			if !retrying {
+				return nil, fmt.Errorf("thread/resume 실패: %w", err)
+			}
+			// 재시작된 App Server가 스레드를 복원하지 못하면 새 스레드에서 턴을 처음부터 다시 시작한다.
+			p.logger.Warn().Err(err).Str("thread_id", resumeID).Msg("재시도 중 thread/resume 실패, 새 스레드로 다시 시작")
+			st.mu.Lock()
+			st.reset()
+			st.mu.Unlock()
+			st.resumeFailed = true
+			resumeID = ""
 		} else {
-			// 폴백: 플랫 구조 {"threadId":"..."}
-			var flat protocol.ThreadStartResult
-			if err := json.Unmarshal(*threadResult, &flat); err == nil {
-				threadID = flat.ThreadID
+			threadID = resumeID
+			p.logger.Info().Str("thread_id", threadID).Msg("thread/resume 완료")
+		}
+	}
+	if resumeID == "" {
+		threadResult, err := rpcClient.Call(ctx, protocol.MethodThreadStart, protocol.ThreadStartParams{
+			Model:          model,
+			Cwd:            cwd,
+			ApprovalPolicy: approvalPolicy,
+			DynamicTools:   dynamicTools,
+		})
+		if err != nil {
+			if streamClosed(rpcClient) {
+				return nil, fmt.Errorf("%w: thread/start 실패: %v", ErrTurnInterrupted, err)
 			}
+			return nil, fmt.Errorf("thread/start 실패: %w", err)
+		}
+
+		// thread/start 결과 파싱
+		// Codex App Server는 {"thread":{"id":"..."},...} 형태로 응답하므로
+		// 중첩된 thread.id를 추출한다.
+		if threadResult != nil {
+			// 먼저 중첩 구조 시도: {"thread":{"id":"..."}}
+			var nested struct {
+				Thread struct {
+					ID string `json:"id"`
+				} `json:"thread"`
+			}
+			if err := json.Unmarshal(*threadResult, &nested); err == nil && nested.Thread.ID != "" {
+				threadID = nested.Thread.ID
+			} else {
+				// 폴백: 플랫 구조 {"threadId":"..."}
+				var flat protocol.ThreadStartResult
+				if err := json.Unmarshal(*threadResult, &flat); err == nil {
+					threadID = flat.ThreadID
+				}
+			}
+			p.logger.Debug().Str("thread_id", threadID).Msg("thread/start 파싱 완료")
+		} else {
+			p.logger.Warn().Msg("thread/start 결과가 nil")
 		}
-		p.logger.Debug().Str("thread_id", threadID).Msg("thread/start 파싱 완료")
-	} else {
-		p.logger.Warn().Msg("thread/start 결과가 nil")
 	}
+	if req.OnSession != nil && threadID != "" && threadID != st.threadID {
+		req.OnSession(threadID, 0)
+	}
+	st.threadID = threadID
 	// thread 변수를 기존 코드와 호환되게 유지
 	thread := protocol.ThreadStartResult{ThreadID: threadID}
 
@@ -620,18 +903,16 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		})
 	}
 
-	var toolCalls []ToolCall
-	var mu sync.Mutex // outputBuilder와 toolCalls 보호용 뮤텍스
-	var outputBuilder strings.Builder
+	mu := &st.mu
+	outputBuilder := &st.output
+	// prefixLen은 이전 시도에서 이어받은 출력 길이입니다.
+	prefixLen := outputBuilder.Len()
 	// providerErrorMsg는 프로바이더가 에러 이벤트를 통해 전달한 사용자 대면 에러 메시지입니다.
 	// 빈 출력과 함께 반환될 경우 호출자에게 전달됩니다.
 	var providerErrorMsg string
 
-	// StreamAccumulator 생성 (스트리밍 모드일 때)
-	var accumulator *StreamAccumulator
-	if onDelta != nil {
-		accumulator = NewStreamAccumulator()
-	}
+	// StreamAccumulator (스트리밍 모드일 때, 재시도 사이에 공유)
+	accumulator := st.accumulator
 
 	// appendDelta는 텍스트 증분을 outputBuilder에 추가하고 스트리밍 콜백을 처리한다.
 	// 구버전/신버전 이벤트 핸들러가 공통으로 사용한다.
@@ -719,9 +1000,9 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		if text == "" {
 			return
 		}
-		// 델타 누적이 없었던 경우에만 전체 메시지를 사용한다.
+		// 이번 시도에서 델타 누적이 없었던 경우에만 전체 메시지를 사용한다.
 		mu.Lock()
-		if outputBuilder.Len() == 0 {
+		if outputBuilder.Len() == prefixLen {
 			outputBuilder.WriteString(text)
 		}
 		mu.Unlock()
@@ -750,7 +1031,7 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 			})
 
 			mu.Lock()
-			toolCalls = append(toolCalls, ToolCall{
+			st.toolCalls = append(st.toolCalls, ToolCall{
 				ID:    item.ItemID,
 				Name:  "command_execution",
 				Input: inputData,
@@ -766,7 +1047,7 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 
 			inputData, _ := json.Marshal(map[string]string{"input": mcpData.Input})
 			mu.Lock()
-			toolCalls = append(toolCalls, ToolCall{
+			st.toolCalls = append(st.toolCalls, ToolCall{
 				ID:    item.ItemID,
 				Name:  mcpData.ToolName,
 				Input: inputData,
@@ -784,7 +1065,7 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 				return
 			}
 			mu.Lock()
-			toolCalls = append(toolCalls, ToolCall{
+			st.toolCalls = append(st.toolCalls, ToolCall{
 				ID:    item.ItemID,
 				Name:  tcData.Tool,
 				Input: tcData.Arguments,
@@ -825,7 +1106,7 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		}
 
 		mu.Lock()
-		toolCalls = append(toolCalls, ToolCall{
+		st.toolCalls = append(st.toolCalls, ToolCall{
 			ID:    callID,
 			Name:  dtcReq.Tool,
 			Input: dtcReq.Arguments,
@@ -856,7 +1137,7 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		decision := "accept"
 		if p.rpcRelay != nil && p.rpcRelay.SupportsApproval() {
 			toolInput, _ := json.Marshal(approvalReq)
-			d, err := p.rpcRelay.HandleRPCApproval(context.Background(), thread.ThreadID, "command_execution", toolInput)
+			d, err := p.rpcRelay.HandleRPCApprovalWith(context.Background(), req.ApprovalHandler, thread.ThreadID, "command_execution", toolInput)
 			if err != nil || d.Decision == "deny" {
 				decision = "decline"
 			}
@@ -886,7 +1167,7 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		decision := "accept"
 		if p.rpcRelay != nil && p.rpcRelay.SupportsApproval() {
 			toolInput, _ := json.Marshal(approvalReq)
-			d, err := p.rpcRelay.HandleRPCApproval(context.Background(), thread.ThreadID, "file_change", toolInput)
+			d, err := p.rpcRelay.HandleRPCApprovalWith(context.Background(), req.ApprovalHandler, thread.ThreadID, "file_change", toolInput)
 			if err != nil || d.Decision == "deny" {
 				decision = "decline"
 			}
@@ -940,8 +1221,12 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 	if req.ResponseMode == "tool_loop" && len(req.ToolLoopMessages) > 0 {
 		turnPrompt = buildAppServerTurnPrompt(req)
 	}
+	// 중단된 턴을 이어가는 재시도이면 이어서 진행하도록 요청한다.
+	if st.continuation != "" {
+		turnPrompt = st.continuation
+	}
 
-	_, err = rpcClient.Call(ctx, protocol.MethodTurnStart, protocol.TurnStartParams{
+	_, err := rpcClient.Call(ctx, protocol.MethodTurnStart, protocol.TurnStartParams{
 		ThreadID: thread.ThreadID,
 		Input: []protocol.TurnInput{
 			{
@@ -951,9 +1236,15 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		},
 	})
 	if err != nil {
+		if streamClosed(rpcClient) {
+			return nil, fmt.Errorf("%w: turn/start 실패: %v", ErrTurnInterrupted, err)
+		}
 		return nil, fmt.Errorf("turn/start 실패: %w", err)
 	}
 	close(turnStarted) // turn/start 완료 → 이제부터 turn/completed 수신 허용
+	st.mu.Lock()
+	st.started = true
+	st.mu.Unlock()
 
 	// 6. Turn 완료 또는 컨텍스트 취소 대기
 	// resolveExecuteTimeout으로 계산한 타임아웃을 적용하여 행(hang) 방지
@@ -965,6 +1256,9 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 		// 정상 완료
 	case <-ctx.Done():
 		return nil, fmt.Errorf("실행 타임아웃: %w", ctx.Err())
+	case <-rpcClient.Done():
+		// 턴 진행 중 App Server 프로세스가 종료되면 turn/completed가 오지 않으므로 즉시 실패 처리 (재시도 가능)
+		return nil, fmt.Errorf("%w: %v", ErrTurnInterrupted, rpcClient.Err())
 	case <-turnTimeout:
 		p.logger.Warn().
 			Str("response_mode", req.ResponseMode).
@@ -989,8 +1283,8 @@ func (p *CodexAppServerProvider) executeInternal(ctx context.Context, req Execut
 	// 8. 결과 조립 및 반환
 	mu.Lock()
 	output := outputBuilder.String()
-	resultToolCalls := make([]ToolCall, len(toolCalls))
-	copy(resultToolCalls, toolCalls)
+	resultToolCalls := make([]ToolCall, len(st.toolCalls))
+	copy(resultToolCalls, st.toolCalls)
 	mu.Unlock()
 
 	// 디버그: tool_loop 실행 결과 로깅
@@ -1192,3 +1486,4 @@ func (p *CodexAppServerProvider) SetApprovalHandler(handler approval.ApprovalHan
 
 // compile-time 인터페이스 구현 확인
 var _ approval.ApprovalRelay = (*CodexAppServerProvider)(nil)
+var _ Warmer = (*CodexAppServerProvider)(nil)
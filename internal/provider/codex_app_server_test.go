@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -654,6 +655,34 @@ func TestCodexAppServerProvider_ValidateConfig(t *testing.T) {
 	})
 }
 
+// TestCodexAppServerProvider_Suspend는 절전 중단 후에도 준비 상태로 보고되고,
+// 다음 실행에서 프로세스 재기동을 시도하는지 검증합니다.
+func TestCodexAppServerProvider_Suspend(t *testing.T) {
+	mock := newMockAppServer()
+	c := mock.createClient()
+	defer mock.close()
+	prov := createMockProvider(c, "auto-approve")
+
+	if err := prov.Suspend(); err != nil {
+		t.Fatalf("Suspend 실패: %v", err)
+	}
+	if prov.process.IsRunning() {
+		t.Fatal("Suspend 후 프로세스가 실행 중입니다")
+	}
+	if err := prov.ValidateConfig(); err != nil {
+		t.Errorf("절전 중 ValidateConfig: got %v, want nil", err)
+	}
+	if err := prov.Suspend(); err != nil {
+		t.Errorf("두 번째 Suspend: got %v, want nil", err)
+	}
+
+	// 테스트 프로세스에는 실행 파일 경로가 없으므로 재기동이 실패해야 한다
+	_, err := prov.Execute(context.Background(), ExecuteRequest{Prompt: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "App Server 프로세스 시작 실패") {
+		t.Errorf("절전 후 Execute: got %v, want 재기동 실패", err)
+	}
+}
+
 // TestCodexAppServerProvider_ExecuteInternal_ProcessNotRunning은
 // 프로세스가 실행 중이 아닐 때 에러를 반환하는지 검증합니다.
 func TestCodexAppServerProvider_ExecuteInternal_ProcessNotRunning(t *testing.T) {
@@ -946,6 +975,24 @@ func TestCodexAppServerProvider_MaxRestarts(t *testing.T) {
 	}
 }
 
+// TestCodexAppServerProvider_Framing은
+// 설정한 프레이밍이 적용되고 알 수 없는 프레이밍은 프로세스 시작 전에 거부되는지 검증합니다.
+func TestCodexAppServerProvider_Framing(t *testing.T) {
+	p := &CodexAppServerProvider{}
+	WithAppServerFraming(client.ContentLengthFraming{})(p)
+	if _, ok := p.framer.(client.ContentLengthFraming); !ok {
+		t.Errorf("framer: got %T, want client.ContentLengthFraming", p.framer)
+	}
+
+	_, err := initializeCodexAppServerProvider(RegistryConfig{
+		CodexAPIKey:  "sk-test",
+		CodexFraming: "websocket",
+	}, "/nonexistent/codex", zerolog.Nop())
+	if err == nil || !strings.Contains(err.Error(), "프레이밍") {
+		t.Errorf("알 수 없는 프레이밍: got %v, want 프레이밍 에러", err)
+	}
+}
+
 // TestCodexAppServerProvider_ContextCancellation은
 // 컨텍스트 취소 시 실행이 중단되는지 검증합니다.
 func TestCodexAppServerProvider_ContextCancellation(t *testing.T) {
@@ -1393,3 +1440,133 @@ func TestCodexAppServerProvider_DynamicToolsNativeRegistration(t *testing.T) {
 
 // Unused import 방지용 (lint 경고 방지)
 var _ = fmt.Sprintf
+
+// TestCodexAppServerProvider_TurnRetry_ContinuesAfterStreamDrop은
+// 턴 진행 중 스트림이 끊기면 App Server를 복구하고 기존 스레드에서 턴을 이어가는지 검증합니다.
+func TestCodexAppServerProvider_TurnRetry_ContinuesAfterStreamDrop(t *testing.T) {
+	first := newMockAppServer()
+	second := newMockAppServer()
+	c1 := first.createClient()
+	c2 := second.createClient()
+	defer c2.Close()
+	prov := createMockProvider(c1, "auto-approve")
+	prov.turnRetries = 2
+
+	var recovered []*client.Client
+	prov.recoverProcess = func(ctx context.Context, prev *client.Client) error {
+		recovered = append(recovered, prev)
+		prov.process.mu.Lock()
+		prov.process.client = c2
+		prov.process.mu.Unlock()
+		return nil
+	}
+
+	// 첫 번째 서버: 턴 시작 후 델타 하나를 보내고 연결을 끊는다.
+	go func() {
+		remaining := make([]byte, 0, 4096)
+		req, err := first.readRequest(&remaining)
+		if err != nil {
+			return
+		}
+		_ = first.sendResponse(req.ID, protocol.ThreadStartResult{ThreadID: "thread-retry"})
+		req, err = first.readRequest(&remaining)
+		if err != nil {
+			return
+		}
+		_ = first.sendResponse(req.ID, protocol.TurnStartResult{TurnID: "turn-1"})
+		time.Sleep(20 * time.Millisecond)
+		_ = first.sendNotification(protocol.MethodAgentMessageDelta, protocol.AgentMessageDelta{Delta: "Hello, "})
+		time.Sleep(20 * time.Millisecond)
+		first.close()
+	}()
+
+	// 두 번째 서버: 같은 스레드를 재개하고 이어가기 프롬프트로 턴을 완료한다.
+	resumeCh := make(chan protocol.ThreadResumeParams, 1)
+	turnCh := make(chan protocol.TurnStartParams, 1)
+	go func() {
+		remaining := make([]byte, 0, 4096)
+		req, err := second.readRequest(&remaining)
+		if err != nil || req.Method != protocol.MethodThreadResume {
+			return
+		}
+		var resume protocol.ThreadResumeParams
+		_ = json.Unmarshal(req.Params, &resume)
+		resumeCh <- resume
+		_ = second.sendResponse(req.ID, map[string]string{})
+
+		req, err = second.readRequest(&remaining)
+		if err != nil || req.Method != protocol.MethodTurnStart {
+			return
+		}
+		var turn protocol.TurnStartParams
+		_ = json.Unmarshal(req.Params, &turn)
+		turnCh <- turn
+		_ = second.sendResponse(req.ID, protocol.TurnStartResult{TurnID: "turn-2"})
+		time.Sleep(20 * time.Millisecond)
+		_ = second.sendNotification(protocol.MethodAgentMessageDelta, protocol.AgentMessageDelta{Delta: "world!"})
+		time.Sleep(20 * time.Millisecond)
+		_ = second.sendNotification(protocol.MethodTurnCompleted, protocol.TurnCompletedParams{ThreadID: "thread-retry"})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := prov.Execute(ctx, ExecuteRequest{Prompt: "재시도 테스트", Model: "gpt-5-codex"})
+	second.close()
+	if err != nil {
+		t.Fatalf("Execute 실패: %v", err)
+	}
+
+	if len(recovered) != 1 || recovered[0] != c1 {
+		t.Errorf("recoverProcess 호출: got %d회, want 끊긴 클라이언트로 1회", len(recovered))
+	}
+	if resume := <-resumeCh; resume.ThreadID != "thread-retry" {
+		t.Errorf("thread/resume ThreadID: got %q, want %q", resume.ThreadID, "thread-retry")
+	}
+	if turn := <-turnCh; len(turn.Input) != 1 || turn.Input[0].Text != appServerContinuePrompt {
+		t.Errorf("재시도 turn/start 입력: got %+v, want 이어가기 프롬프트", turn.Input)
+	}
+	if resp.Output != "Hello, world!" {
+		t.Errorf("Output: got %q, want %q", resp.Output, "Hello, world!")
+	}
+	if len(resp.Retries) != 1 {
+		t.Fatalf("Retries: got %d, want 1", len(resp.Retries))
+	}
+	retry := resp.Retries[0]
+	if retry.Attempt != 1 || !retry.Resumed || !retry.Continued || retry.Reason == "" {
+		t.Errorf("Retries[0]: got %+v, want attempt 1, resumed, continued, reason", retry)
+	}
+}
+
+// TestCodexAppServerProvider_TurnRetry_Disabled는
+// turnRetries가 0이면 스트림 중단을 재시도 없이 ErrTurnInterrupted로 반환하는지 검증합니다.
+func TestCodexAppServerProvider_TurnRetry_Disabled(t *testing.T) {
+	mock := newMockAppServer()
+	c := mock.createClient()
+	defer c.Close()
+	prov := createMockProvider(c, "auto-approve")
+	prov.recoverProcess = func(ctx context.Context, prev *client.Client) error {
+		t.Error("재시도가 비활성화되었는데 recoverProcess가 호출되었습니다")
+		return nil
+	}
+
+	go func() {
+		remaining := make([]byte, 0, 4096)
+		req, err := mock.readRequest(&remaining)
+		if err != nil {
+			return
+		}
+		_ = mock.sendResponse(req.ID, protocol.ThreadStartResult{ThreadID: "thread-no-retry"})
+		if _, err := mock.readRequest(&remaining); err != nil {
+			return
+		}
+		// turn/start에 응답하기 전에 연결을 끊는다.
+		mock.close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := prov.Execute(ctx, ExecuteRequest{Prompt: "중단 테스트", Model: "gpt-5-codex"})
+	if !errors.Is(err, ErrTurnInterrupted) {
+		t.Fatalf("Execute 에러: got %v, want ErrTurnInterrupted", err)
+	}
+}
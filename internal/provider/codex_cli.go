@@ -10,6 +10,8 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 )
 
 // CodexCLILine는 codex CLI의 JSONL 출력 한 줄의 구조입니다.
@@ -131,6 +133,11 @@ func (p *CodexCLIProvider) ValidateConfig() error {
 	return p.checkCLI()
 }
 
+// SupportsExecutionEnv는 실행마다 CLI 프로세스를 띄워 ExecuteRequest.Env를 전달하므로 true를 반환합니다.
+func (p *CodexCLIProvider) SupportsExecutionEnv() bool {
+	return true
+}
+
 // Supports는 주어진 모델명을 지원하는지 확인합니다.
 // OpenRouter 형식(openai/o3-mini)과 레거시 형식 모두 지원합니다.
 // CLI 모드에서는 gpt-, o4-, o3- 접두사를 가진 모든 모델을 지원합니다.
@@ -199,12 +206,15 @@ func (p *CodexCLIProvider) Execute(ctx context.Context, req ExecuteRequest) (*Ex
 
 	// 명령 실행
 	cmd := exec.CommandContext(execCtx, p.cliPath, args...)
+	if len(req.Env) > 0 {
+		cmd.Env = withExtraEnv(os.Environ(), req.Env)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 
 	// 컨텍스트 취소 확인
 	if execCtx.Err() == context.DeadlineExceeded {
@@ -10,6 +10,8 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/insajin/autopus-bridge/internal/procgroup"
 )
 
 // GeminiCLIResponse는 gemini CLI의 JSON 출력 구조입니다.
@@ -127,6 +129,11 @@ func (p *GeminiCLIProvider) ValidateConfig() error {
 	return p.checkCLI()
 }
 
+// SupportsExecutionEnv는 실행마다 CLI 프로세스를 띄워 ExecuteRequest.Env를 전달하므로 true를 반환합니다.
+func (p *GeminiCLIProvider) SupportsExecutionEnv() bool {
+	return true
+}
+
 // Supports는 주어진 모델명을 지원하는지 확인합니다.
 // CLI 모드에서는 gemini- 접두사를 가진 모든 모델을 지원하며,
 // gemini.go에 정의된 geminiSupportedModels 목록도 참조합니다.
@@ -197,6 +204,9 @@ func (p *GeminiCLIProvider) Execute(ctx context.Context, req ExecuteRequest) (*E
 
 	// 명령 실행
 	cmd := exec.CommandContext(execCtx, p.cliPath, args...)
+	if len(req.Env) > 0 {
+		cmd.Env = withExtraEnv(os.Environ(), req.Env)
+	}
 
 	// 작업 디렉토리 설정 (존재하는 경우에만)
 	// 서버가 Docker 컨테이너 내부 경로를 보내는 경우 호스트에 없을 수 있음
@@ -210,7 +220,7 @@ func (p *GeminiCLIProvider) Execute(ctx context.Context, req ExecuteRequest) (*E
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err := procgroup.Run(cmd)
 
 	// 컨텍스트 취소 확인
 	if execCtx.Err() == context.DeadlineExceeded {
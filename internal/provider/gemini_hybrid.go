@@ -109,6 +109,12 @@ func (p *HybridGeminiProvider) ValidateConfig() error {
 	return nil
 }
 
+// SupportsExecutionEnv는 CLI를 사용할 수 있으면 true를 반환합니다.
+// Env가 있는 실행은 API로 보내지 않습니다.
+func (p *HybridGeminiProvider) SupportsExecutionEnv() bool {
+	return p.cli != nil
+}
+
 // Supports는 주어진 모델명을 지원하는지 확인합니다.
 func (p *HybridGeminiProvider) Supports(model string) bool {
 	if p.cli != nil && p.cli.Supports(model) {
@@ -126,7 +132,7 @@ func (p *HybridGeminiProvider) Execute(ctx context.Context, req ExecuteRequest)
 	startTime := time.Now()
 
 	// tool_loop 모드: CLI는 네이티브 도구 호출을 지원하지 않으므로 API로 직행
-	if req.ResponseMode == "tool_loop" && p.api != nil {
+	if req.ResponseMode == "tool_loop" && p.api != nil && len(req.Env) == 0 {
 		p.logger.Debug().
 			Str("model", req.Model).
 			Int("tool_defs", len(req.ToolDefinitions)).
@@ -155,6 +161,13 @@ func (p *HybridGeminiProvider) Execute(ctx context.Context, req ExecuteRequest)
 		}
 
 		atomic.AddUint64(&p.cliFailed, 1)
+		// 실행 범위 환경 변수(자격 증명)는 API 요청에 전달할 수 없으므로 폴백하지 않는다.
+		if len(req.Env) > 0 {
+			p.logger.Warn().
+				Err(err).
+				Msg("Gemini CLI 실행 실패, 실행 범위 환경 변수가 있어 API로 폴백하지 않음")
+			return nil, err
+		}
 		p.logger.Warn().
 			Err(err).
 			Int64("duration_ms", time.Since(startTime).Milliseconds()).
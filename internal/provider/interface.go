@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
 )
 
 // 프로바이더 관련 에러 정의
@@ -50,6 +51,20 @@ type Provider interface {
 	Supports(model string) bool
 }
 
+// ExecutionEnvProvider는 작업마다 CLI 프로세스를 새로 실행하여 ExecuteRequest.Env를
+// 그 프로세스에만 전달하는 프로바이더가 구현하는 선택적 인터페이스입니다.
+// 구현하지 않은 프로바이더(API, Codex app-server)는 Env를 무시하므로 실행 범위 자격 증명을 받을 수 없습니다.
+type ExecutionEnvProvider interface {
+	// SupportsExecutionEnv는 실행마다 Env를 적용한 프로세스를 띄우는지 반환합니다.
+	SupportsExecutionEnv() bool
+}
+
+// SupportsExecutionEnv는 p가 ExecuteRequest.Env를 실행별 프로세스에 전달하는지 반환합니다.
+func SupportsExecutionEnv(p Provider) bool {
+	e, ok := p.(ExecutionEnvProvider)
+	return ok && e.SupportsExecutionEnv()
+}
+
 // ExecuteRequest는 AI 프로바이더 실행 요청입니다.
 type ExecuteRequest struct {
 	// Prompt는 AI에게 전달할 프롬프트입니다.
@@ -84,8 +99,31 @@ type ExecuteRequest struct {
 	// 0이면 기본값(120초)이 사용됩니다.
 	// 최소 30초, 최대 600초로 클램핑됩니다.
 	Timeout int
+
+	// ResumeSessionID는 재개할 프로바이더 세션(Claude 세션 ID, Codex Thread ID)입니다.
+	// 체크포인트에서 작업을 재개할 때 설정되며, 지원하지 않는 프로바이더는 무시합니다.
+	ResumeSessionID string
+
+	// Env는 이 실행의 CLI 프로세스에만 추가할 환경 변수입니다 (KEY=VALUE, 선택적).
+	// 실행 범위 자격 증명 전달에 사용되며, 같은 키의 기존 환경 변수를 대체합니다.
+	// 작업마다 프로세스를 실행하지 않는 프로바이더(API, Codex app-server)는 무시하므로,
+	// 호출자는 SupportsExecutionEnv로 먼저 확인해야 합니다.
+	Env []string
+
+	// ApprovalHandler는 이 실행의 도구 승인 요청을 처리할 핸들러입니다 (선택적).
+	// 승인 흐름을 지원하는 프로바이더(approval.ApprovalRelay)만 사용하며,
+	// SetApprovalHandler로 등록한 공유 핸들러보다 우선합니다.
+	ApprovalHandler approval.ApprovalHandler
+
+	// OnSession은 프로바이더 세션 ID가 확인되거나 턴이 완료될 때 호출됩니다 (선택적).
+	// 작업 체크포인트에 세션 ID와 단계 번호를 기록하는 데 사용됩니다.
+	OnSession SessionCallback
 }
 
+// SessionCallback은 프로바이더 세션 진행 상태를 전달받는 콜백입니다.
+// sessionID는 프로바이더 세션/Thread ID, turn은 완료된 어시스턴트 턴 수입니다.
+type SessionCallback func(sessionID string, turn int)
+
 // ExecuteResponse는 AI 프로바이더 실행 결과입니다.
 type ExecuteResponse struct {
 	// Output은 AI의 응답 텍스트입니다.
@@ -115,6 +153,22 @@ type ExecuteResponse struct {
 	// Output이 비어있고 Error가 설정된 경우, 호출자는 이 메시지를 사용자에게 전달해야 합니다.
 	// 예: 사용량 한도 초과, 인증 실패 등의 프로바이더 오류
 	Error string
+
+	// Retries는 일시적 오류(스트림 중단 등)로 프로바이더 내부에서 다시 시도한 기록입니다.
+	// 재시도 없이 성공하면 비어 있습니다.
+	Retries []RetryAttempt
+}
+
+// RetryAttempt는 프로바이더 내부 재시도 1회의 기록입니다.
+type RetryAttempt struct {
+	// Attempt는 재시도 순번입니다 (1부터).
+	Attempt int
+	// Reason은 재시도를 일으킨 오류 메시지입니다.
+	Reason string
+	// Resumed는 기존 세션(스레드) 컨텍스트를 이어서 재시도했는지 여부입니다.
+	Resumed bool
+	// Continued는 중단된 턴을 처음부터가 아니라 이어서 진행했는지 여부입니다.
+	Continued bool
 }
 
 // ToolCall은 모델이 요청한 단일 도구 호출입니다.
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -277,6 +278,34 @@ func TestJSONRPCClient_ConnectionClosed(t *testing.T) {
 	t.Logf("연결 종료 에러 (예상됨): %v", err)
 }
 
+// TestJSONRPCClient_ServerExitMidRequest는 응답 대기 중 App Server가 종료되면 즉시 에러가 전파되는지 검증합니다.
+func TestJSONRPCClient_ServerExitMidRequest(t *testing.T) {
+	clientStdinR, clientStdinW := io.Pipe()
+	serverStdoutR, serverStdoutW := io.Pipe()
+
+	c := newTestJSONRPCClient(clientStdinW, serverStdoutR)
+	defer c.Close()
+
+	// mock 서버: 요청을 읽은 뒤 응답 없이 종료
+	go func() {
+		buf := make([]byte, 4096)
+		_, _ = clientStdinR.Read(buf)
+		serverStdoutW.Close()
+	}()
+
+	start := time.Now()
+	_, err := c.Call(context.Background(), protocol.MethodTurnStart, nil)
+	if !errors.Is(err, client.ErrConnectionLost) {
+		t.Fatalf("ErrConnectionLost를 기대했지만 %v를 받았습니다", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("서버 종료 후 Call 반환이 지연됨: %v", elapsed)
+	}
+	if c.InFlightCount() != 0 {
+		t.Errorf("in-flight 요청이 남아 있습니다: %+v", c.InFlight())
+	}
+}
+
 // TestJSONRPCClient_ErrorResponse는 에러 응답 처리를 검증합니다.
 func TestJSONRPCClient_ErrorResponse(t *testing.T) {
 	clientStdinR, clientStdinW := io.Pipe()
@@ -0,0 +1,616 @@
+// Package provider는 AI 프로바이더 통합 레이어를 제공합니다.
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OpenAICompatProviderName은 OpenAI 호환 프로바이더의 기본 식별자입니다.
+const OpenAICompatProviderName = "openai-compat"
+
+// openAICompatMaxSSELineSize는 스트리밍 응답의 SSE 한 줄 최대 크기입니다 (1MB).
+const openAICompatMaxSSELineSize = 1024 * 1024
+
+// openAIStreamOptions는 스트리밍 요청 옵션입니다.
+// include_usage를 설정하면 마지막 청크에 토큰 사용량이 포함됩니다.
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// openAIChatStreamRequest는 스트리밍 Chat Completions 요청 구조입니다.
+type openAIChatStreamRequest struct {
+	openAIChatRequest
+	Stream        bool                 `json:"stream"`
+	StreamOptions *openAIStreamOptions `json:"stream_options,omitempty"`
+}
+
+// openAIChatStreamChunk는 스트리밍 응답의 단일 청크입니다.
+type openAIChatStreamChunk struct {
+	Model   string                   `json:"model"`
+	Choices []openAIChatStreamChoice `json:"choices"`
+	Usage   *openAIUsage             `json:"usage,omitempty"`
+	Error   *openAIErrorResponse     `json:"error,omitempty"`
+}
+
+// openAIChatStreamChoice는 스트리밍 청크의 선택지입니다.
+type openAIChatStreamChoice struct {
+	Index        int                   `json:"index"`
+	Delta        openAIChatStreamDelta `json:"delta"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+// openAIChatStreamDelta는 스트리밍 청크의 증분 메시지입니다.
+type openAIChatStreamDelta struct {
+	Content   string                    `json:"content"`
+	ToolCalls []openAIChatToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+// openAIChatToolCallDelta는 스트리밍 중 조각으로 전달되는 도구 호출입니다.
+// 같은 Index의 조각을 이어 붙여 완전한 도구 호출을 만듭니다.
+type openAIChatToolCallDelta struct {
+	Index    int                    `json:"index"`
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Function openAIChatFunctionCall `json:"function"`
+}
+
+// OpenAICompatProvider는 OpenAI 호환 Chat Completions 엔드포인트와 직접 통신하는 프로바이더입니다.
+// 로컬 CLI 없이 호스팅 또는 자체 호스팅 모델(Ollama, vLLM, LM Studio 등)로 작업을 실행할 수 있습니다.
+type OpenAICompatProvider struct {
+	httpClient *http.Client
+	config     ProviderConfig
+	name       string
+	baseURL    string
+	apiKeyEnv  string
+	models     []string
+}
+
+// OpenAICompatProviderOption은 OpenAICompatProvider 설정 옵션입니다.
+type OpenAICompatProviderOption func(*OpenAICompatProvider)
+
+// WithOpenAICompatName은 프로바이더 식별자를 설정합니다.
+func WithOpenAICompatName(name string) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		if name != "" {
+			p.name = name
+		}
+	}
+}
+
+// WithOpenAICompatBaseURL은 API 기본 URL을 설정합니다 (예: "http://localhost:11434/v1").
+func WithOpenAICompatBaseURL(baseURL string) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		p.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithOpenAICompatAPIKey는 API 키를 설정합니다.
+func WithOpenAICompatAPIKey(apiKey string) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		p.config.APIKey = apiKey
+	}
+}
+
+// WithOpenAICompatAPIKeyEnv는 API 키를 가져올 환경변수 이름을 설정합니다.
+func WithOpenAICompatAPIKeyEnv(env string) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		p.apiKeyEnv = env
+	}
+}
+
+// WithOpenAICompatDefaultModel은 기본 모델을 설정합니다.
+func WithOpenAICompatDefaultModel(model string) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		p.config.DefaultModel = model
+	}
+}
+
+// WithOpenAICompatModels는 이 프로바이더로 라우팅할 모델 목록을 설정합니다.
+func WithOpenAICompatModels(models []string) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		p.models = append([]string(nil), models...)
+	}
+}
+
+// WithOpenAICompatMaxRetries는 최대 재시도 횟수를 설정합니다.
+func WithOpenAICompatMaxRetries(retries int) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		p.config.MaxRetries = retries
+	}
+}
+
+// WithOpenAICompatTimeout은 HTTP 요청 타임아웃을 설정합니다.
+func WithOpenAICompatTimeout(timeout time.Duration) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		if timeout > 0 {
+			p.httpClient.Timeout = timeout
+		}
+	}
+}
+
+// WithOpenAICompatHTTPClient는 HTTP 클라이언트를 설정합니다 (테스트용).
+func WithOpenAICompatHTTPClient(client *http.Client) OpenAICompatProviderOption {
+	return func(p *OpenAICompatProvider) {
+		if client != nil {
+			p.httpClient = client
+		}
+	}
+}
+
+// NewOpenAICompatProvider는 새로운 OpenAICompatProvider를 생성합니다.
+// API 키는 선택 사항이며, 설정되지 않으면 apiKeyEnv 환경변수에서 가져옵니다 (REQ-N-01).
+// 로컬 Ollama/vLLM처럼 인증이 없는 엔드포인트는 API 키 없이 사용할 수 있습니다.
+func NewOpenAICompatProvider(opts ...OpenAICompatProviderOption) (*OpenAICompatProvider, error) {
+	p := &OpenAICompatProvider{
+		httpClient: &http.Client{
+			Timeout: 5 * time.Minute,
+		},
+		config: ProviderConfig{
+			MaxRetries:   3,
+			RetryDelayMs: 1000,
+		},
+		name: OpenAICompatProviderName,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.config.APIKey == "" && p.apiKeyEnv != "" {
+		p.config.APIKey = os.Getenv(p.apiKeyEnv)
+	}
+
+	if err := p.ValidateConfig(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Name은 프로바이더 식별자를 반환합니다.
+func (p *OpenAICompatProvider) Name() string {
+	return p.name
+}
+
+// ValidateConfig는 프로바이더 설정의 유효성을 검사합니다.
+func (p *OpenAICompatProvider) ValidateConfig() error {
+	if p.baseURL == "" {
+		return fmt.Errorf("OpenAI 호환 프로바이더: base_url이 설정되지 않았습니다")
+	}
+	if !strings.HasPrefix(p.baseURL, "http://") && !strings.HasPrefix(p.baseURL, "https://") {
+		return fmt.Errorf("OpenAI 호환 프로바이더: base_url은 http:// 또는 https://로 시작해야 합니다: %s", p.baseURL)
+	}
+	if p.config.DefaultModel == "" && len(p.models) == 0 {
+		return fmt.Errorf("OpenAI 호환 프로바이더: default_model 또는 models 중 하나는 설정해야 합니다")
+	}
+	if p.apiKeyEnv != "" && p.config.APIKey == "" {
+		return fmt.Errorf("%w: %s 환경변수를 설정하세요", ErrNoAPIKey, p.apiKeyEnv)
+	}
+	return nil
+}
+
+// Models는 이 프로바이더로 라우팅되는 모델 목록을 반환합니다.
+func (p *OpenAICompatProvider) Models() []string {
+	models := append([]string(nil), p.models...)
+	if p.config.DefaultModel != "" && !containsString(models, p.config.DefaultModel) {
+		models = append(models, p.config.DefaultModel)
+	}
+	sort.Strings(models)
+	return models
+}
+
+// Supports는 주어진 모델명을 지원하는지 확인합니다.
+// 설정된 모델 목록(또는 기본 모델)과 정확히 일치하는 모델만 지원합니다.
+// "<프로바이더 이름>/<모델>" 형식도 허용합니다.
+func (p *OpenAICompatProvider) Supports(model string) bool {
+	model = p.stripNamePrefix(model)
+	if model == "" {
+		return false
+	}
+	if model == p.config.DefaultModel {
+		return true
+	}
+	return containsString(p.models, model)
+}
+
+// stripNamePrefix는 "<프로바이더 이름>/" 접두사를 제거합니다.
+// Ollama 모델명(예: "library/llama3")처럼 다른 "/"는 유지합니다.
+func (p *OpenAICompatProvider) stripNamePrefix(model string) string {
+	return strings.TrimPrefix(model, p.name+"/")
+}
+
+// resolveModel은 요청 모델을 결정하고 지원 여부를 확인합니다.
+func (p *OpenAICompatProvider) resolveModel(reqModel string) (string, error) {
+	model := p.stripNamePrefix(reqModel)
+	if model == "" {
+		model = p.config.DefaultModel
+	}
+	if model == "" && len(p.models) > 0 {
+		model = p.models[0]
+	}
+	if !p.Supports(model) {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedModel, model)
+	}
+	return model, nil
+}
+
+// buildRequest는 실행 요청을 Chat Completions 요청으로 변환합니다.
+// tool_loop 모드면 대화 이력을 그대로 전달하고, ToolDefinitions가 있으면 function 도구로 노출합니다.
+func (p *OpenAICompatProvider) buildRequest(req ExecuteRequest) (openAIChatRequest, error) {
+	model, err := p.resolveModel(req.Model)
+	if err != nil {
+		return openAIChatRequest{}, err
+	}
+
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 4096
+	}
+
+	var messages []openAIChatMessage
+	if req.ResponseMode == "tool_loop" {
+		messages = buildOpenAIToolLoopMessages(req)
+	} else {
+		if req.SystemPrompt != "" {
+			messages = append(messages, openAIChatMessage{
+				Role:    "system",
+				Content: req.SystemPrompt,
+			})
+		}
+		messages = append(messages, openAIChatMessage{
+			Role:    "user",
+			Content: req.Prompt,
+		})
+	}
+
+	chatReq := openAIChatRequest{
+		Model:     model,
+		Messages:  messages,
+		MaxTokens: maxTokens,
+		Tools:     buildOpenAITools(req.ToolDefinitions),
+	}
+	if len(chatReq.Tools) > 0 {
+		chatReq.ToolChoice = "auto"
+	}
+	return chatReq, nil
+}
+
+// Execute는 프롬프트를 실행하고 결과를 반환합니다.
+// 모델이 도구 호출을 요청하면 ToolCalls에 그대로 전달합니다.
+func (p *OpenAICompatProvider) Execute(ctx context.Context, req ExecuteRequest) (*ExecuteResponse, error) {
+	startTime := time.Now()
+
+	chatReq, err := p.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var chatResp *openAIChatResponse
+	err = p.withRetry(ctx, func() error {
+		var reqErr error
+		chatResp, reqErr = p.doRequest(ctx, chatReq)
+		return reqErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var outputText string
+	var toolCalls []ToolCall
+	if len(chatResp.Choices) > 0 {
+		msg := chatResp.Choices[0].Message
+		outputText = messageContentString(msg.Content)
+		for _, call := range msg.ToolCalls {
+			toolCalls = append(toolCalls, ToolCall{
+				ID:    call.ID,
+				Name:  call.Function.Name,
+				Input: toolCallArguments(call.Function.Arguments),
+			})
+		}
+	}
+
+	model := chatResp.Model
+	if model == "" {
+		model = chatReq.Model
+	}
+
+	return &ExecuteResponse{
+		Output: outputText,
+		TokenUsage: TokenUsage{
+			InputTokens:  chatResp.Usage.PromptTokens,
+			OutputTokens: chatResp.Usage.CompletionTokens,
+			TotalTokens:  chatResp.Usage.TotalTokens,
+		},
+		DurationMs: time.Since(startTime).Milliseconds(),
+		Model:      model,
+		Provider:   p.Name(),
+		StopReason: mapCodexFinishReason(chatResp),
+		ToolCalls:  toolCalls,
+	}, nil
+}
+
+// ExecuteStreaming은 SSE 스트리밍으로 프롬프트를 실행합니다.
+// 텍스트 델타마다 onDelta가 호출되며, 도구 호출 조각은 누적하여 최종 응답의 ToolCalls로 반환합니다.
+func (p *OpenAICompatProvider) ExecuteStreaming(ctx context.Context, req ExecuteRequest, onDelta StreamCallback) (*ExecuteResponse, error) {
+	startTime := time.Now()
+
+	chatReq, err := p.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	streamReq := openAIChatStreamRequest{
+		openAIChatRequest: chatReq,
+		Stream:            true,
+		StreamOptions:     &openAIStreamOptions{IncludeUsage: true},
+	}
+
+	var result *openAIStreamResult
+	err = p.withRetry(ctx, func() error {
+		resp, reqErr := p.send(ctx, streamReq)
+		if reqErr != nil {
+			return reqErr
+		}
+		defer func() { _ = resp.Body.Close() }()
+		// 스트림이 시작된 뒤에는 재시도하지 않는다 (이미 전달된 델타가 중복되므로).
+		result, reqErr = readOpenAIStream(resp.Body, onDelta)
+		if reqErr != nil {
+			return fmt.Errorf("%w: %v", errStreamAborted, reqErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	model := result.model
+	if model == "" {
+		model = chatReq.Model
+	}
+
+	return &ExecuteResponse{
+		Output:     result.text.String(),
+		TokenUsage: result.usage,
+		DurationMs: time.Since(startTime).Milliseconds(),
+		Model:      model,
+		Provider:   p.Name(),
+		StopReason: mapOpenAIFinishReason(result.finishReason),
+		ToolCalls:  result.toolCalls(),
+	}, nil
+}
+
+// errStreamAborted는 스트림 수신 도중 실패했음을 나타냅니다. 재시도하지 않습니다.
+var errStreamAborted = errors.New("스트리밍 응답 수신 실패")
+
+// withRetry는 레이트 리밋과 일시적 서버 에러에 대해 시도 횟수에 비례한 지연 후 재시도합니다.
+func (p *OpenAICompatProvider) withRetry(ctx context.Context, fn func() error) error {
+	var lastErr error
+	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+			case <-time.After(time.Duration(p.config.RetryDelayMs*attempt) * time.Millisecond):
+			}
+		}
+
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+
+		if errors.Is(lastErr, context.Canceled) || errors.Is(lastErr, context.DeadlineExceeded) {
+			return fmt.Errorf("%w: %v", ErrContextCanceled, lastErr)
+		}
+		if errors.Is(lastErr, errStreamAborted) {
+			break
+		}
+		if isCodexRateLimitError(lastErr) {
+			lastErr = fmt.Errorf("%w: %v", ErrRateLimited, lastErr)
+			continue
+		}
+		if !isCodexRetryableError(lastErr) {
+			break
+		}
+	}
+	return fmt.Errorf("%s API 호출 실패: %w", p.Name(), lastErr)
+}
+
+// send는 Chat Completions 엔드포인트에 요청을 보내고 2xx 응답을 반환합니다.
+// 호출자가 응답 본문을 닫아야 합니다.
+func (p *OpenAICompatProvider) send(ctx context.Context, payload any) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("요청 직렬화 실패: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP 요청 생성 실패: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP 요청 실패: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		var errResp openAIChatResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error != nil {
+			return nil, fmt.Errorf("API 에러 (HTTP %d): [%s] %s", resp.StatusCode, errResp.Error.Type, errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("API 에러 (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return resp, nil
+}
+
+// doRequest는 비스트리밍 Chat Completions 요청을 보내고 응답을 파싱합니다.
+func (p *OpenAICompatProvider) doRequest(ctx context.Context, chatReq openAIChatRequest) (*openAIChatResponse, error) {
+	resp, err := p.send(ctx, chatReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var chatResp openAIChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResp); err != nil {
+		return nil, fmt.Errorf("응답 파싱 실패: %w", err)
+	}
+	if chatResp.Error != nil {
+		return nil, fmt.Errorf("API 에러: [%s] %s", chatResp.Error.Type, chatResp.Error.Message)
+	}
+	return &chatResp, nil
+}
+
+// openAIStreamResult는 스트리밍 응답을 누적한 결과입니다.
+type openAIStreamResult struct {
+	text         strings.Builder
+	model        string
+	finishReason string
+	usage        TokenUsage
+	calls        map[int]*openAIChatToolCall
+}
+
+// toolCalls는 누적된 도구 호출 조각을 인덱스 순서대로 ToolCall로 변환합니다.
+func (r *openAIStreamResult) toolCalls() []ToolCall {
+	if len(r.calls) == 0 {
+		return nil
+	}
+	indexes := make([]int, 0, len(r.calls))
+	for i := range r.calls {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	calls := make([]ToolCall, 0, len(indexes))
+	for _, i := range indexes {
+		call := r.calls[i]
+		calls = append(calls, ToolCall{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Input: toolCallArguments(call.Function.Arguments),
+		})
+	}
+	return calls
+}
+
+// readOpenAIStream은 SSE 스트림을 읽어 텍스트와 도구 호출을 누적합니다.
+func readOpenAIStream(r io.Reader, onDelta StreamCallback) (*openAIStreamResult, error) {
+	result := &openAIStreamResult{calls: make(map[int]*openAIChatToolCall)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), openAICompatMaxSSELineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			// 빈 줄, 주석(":"), event/id 필드는 무시
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return result, nil
+		}
+
+		var chunk openAIChatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("스트림 청크 파싱 실패: %w", err)
+		}
+		if chunk.Error != nil {
+			return nil, fmt.Errorf("API 에러: [%s] %s", chunk.Error.Type, chunk.Error.Message)
+		}
+		if chunk.Model != "" {
+			result.model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			result.usage = TokenUsage{
+				InputTokens:  chunk.Usage.PromptTokens,
+				OutputTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:  chunk.Usage.TotalTokens,
+			}
+		}
+
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 {
+				continue
+			}
+			if choice.FinishReason != "" {
+				result.finishReason = choice.FinishReason
+			}
+			if delta := choice.Delta.Content; delta != "" {
+				result.text.WriteString(delta)
+				if onDelta != nil {
+					onDelta(delta, result.text.String())
+				}
+			}
+			for _, part := range choice.Delta.ToolCalls {
+				call, exists := result.calls[part.Index]
+				if !exists {
+					call = &openAIChatToolCall{Type: "function"}
+					result.calls[part.Index] = call
+				}
+				if part.ID != "" {
+					call.ID = part.ID
+				}
+				if part.Function.Name != "" {
+					call.Function.Name = part.Function.Name
+				}
+				call.Function.Arguments += part.Function.Arguments
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// 일부 서버는 [DONE] 없이 스트림을 종료한다.
+	return result, nil
+}
+
+// toolCallArguments는 도구 호출 인자 문자열을 JSON으로 변환합니다.
+// 인자가 비어 있으면 빈 객체를, 유효하지 않은 JSON이면 문자열 값으로 감싸서 반환합니다.
+func toolCallArguments(args string) json.RawMessage {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		return json.RawMessage("{}")
+	}
+	if json.Valid([]byte(args)) {
+		return json.RawMessage(args)
+	}
+	quoted, _ := json.Marshal(args)
+	return json.RawMessage(quoted)
+}
+
+// mapOpenAIFinishReason은 finish_reason 문자열을 내부 stop reason으로 매핑합니다.
+func mapOpenAIFinishReason(reason string) string {
+	return mapCodexFinishReason(&openAIChatResponse{
+		Choices: []openAIChatChoice{{FinishReason: reason}},
+	})
+}
+
+// containsString은 목록에 값이 있는지 확인합니다.
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,323 @@
+// Package provider는 AI 프로바이더 통합 레이어를 제공합니다.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+// newTestOpenAICompatProvider는 테스트 서버를 가리키는 OpenAI 호환 프로바이더를 생성합니다.
+func newTestOpenAICompatProvider(t *testing.T, handler http.HandlerFunc, opts ...OpenAICompatProviderOption) *OpenAICompatProvider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	base := []OpenAICompatProviderOption{
+		WithOpenAICompatBaseURL(server.URL + "/v1/"),
+		WithOpenAICompatDefaultModel("llama3.1"),
+		WithOpenAICompatModels([]string{"qwen2.5-coder", "gpt-oss-20b"}),
+		WithOpenAICompatMaxRetries(0),
+	}
+	p, err := NewOpenAICompatProvider(append(base, opts...)...)
+	if err != nil {
+		t.Fatalf("NewOpenAICompatProvider 실패: %v", err)
+	}
+	return p
+}
+
+// TestOpenAICompatProvider_Config는 생성자 설정 검증을 테스트합니다.
+func TestOpenAICompatProvider_Config(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []OpenAICompatProviderOption
+		wantErr error
+	}{
+		{
+			name: "base_url 없음",
+			opts: []OpenAICompatProviderOption{WithOpenAICompatDefaultModel("llama3.1")},
+		},
+		{
+			name: "모델 없음",
+			opts: []OpenAICompatProviderOption{WithOpenAICompatBaseURL("http://localhost:11434/v1")},
+		},
+		{
+			name: "잘못된 scheme",
+			opts: []OpenAICompatProviderOption{
+				WithOpenAICompatBaseURL("localhost:11434/v1"),
+				WithOpenAICompatDefaultModel("llama3.1"),
+			},
+		},
+		{
+			name: "API 키 환경변수 미설정",
+			opts: []OpenAICompatProviderOption{
+				WithOpenAICompatBaseURL("https://api.example.com/v1"),
+				WithOpenAICompatDefaultModel("llama3.1"),
+				WithOpenAICompatAPIKeyEnv("AUTOPUS_TEST_COMPAT_KEY_UNSET"),
+			},
+			wantErr: ErrNoAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOpenAICompatProvider(tt.opts...)
+			if err == nil {
+				t.Fatal("에러가 반환되어야 합니다")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("API 키 없는 로컬 엔드포인트", func(t *testing.T) {
+		p, err := NewOpenAICompatProvider(
+			WithOpenAICompatBaseURL("http://localhost:11434/v1"),
+			WithOpenAICompatDefaultModel("llama3.1"),
+		)
+		if err != nil {
+			t.Fatalf("API 키 없이 생성되어야 합니다: %v", err)
+		}
+		if p.Name() != OpenAICompatProviderName {
+			t.Errorf("Name() = %q, want %q", p.Name(), OpenAICompatProviderName)
+		}
+	})
+}
+
+// TestOpenAICompatProvider_Supports는 설정된 모델 목록 기반 지원 여부를 테스트합니다.
+func TestOpenAICompatProvider_Supports(t *testing.T) {
+	p, err := NewOpenAICompatProvider(
+		WithOpenAICompatName("ollama"),
+		WithOpenAICompatBaseURL("http://localhost:11434/v1"),
+		WithOpenAICompatDefaultModel("llama3.1"),
+		WithOpenAICompatModels([]string{"qwen2.5-coder"}),
+	)
+	if err != nil {
+		t.Fatalf("NewOpenAICompatProvider 실패: %v", err)
+	}
+
+	tests := []struct {
+		model    string
+		expected bool
+	}{
+		{"llama3.1", true},
+		{"qwen2.5-coder", true},
+		{"ollama/qwen2.5-coder", true},
+		{"gpt-5.4", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := p.Supports(tt.model); got != tt.expected {
+			t.Errorf("Supports(%q) = %v, want %v", tt.model, got, tt.expected)
+		}
+	}
+
+	want := []string{"llama3.1", "qwen2.5-coder"}
+	if got := p.Models(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Models() = %v, want %v", got, want)
+	}
+}
+
+// TestOpenAICompatProvider_Execute는 비스트리밍 실행과 인증 헤더를 테스트합니다.
+func TestOpenAICompatProvider_Execute(t *testing.T) {
+	t.Setenv("AUTOPUS_TEST_COMPAT_KEY", "sk-test")
+
+	var gotReq openAIChatRequest
+	var gotAuth, gotPath string
+	p := newTestOpenAICompatProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_, _ = io.WriteString(w, `{"model":"qwen2.5-coder","choices":[{"index":0,"message":{"role":"assistant","content":"hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":2,"total_tokens":7}}`)
+	}, WithOpenAICompatAPIKeyEnv("AUTOPUS_TEST_COMPAT_KEY"))
+
+	resp, err := p.Execute(context.Background(), ExecuteRequest{
+		Prompt:       "hi",
+		SystemPrompt: "be brief",
+		Model:        "qwen2.5-coder",
+	})
+	if err != nil {
+		t.Fatalf("Execute 실패: %v", err)
+	}
+
+	if gotPath != "/v1/chat/completions" {
+		t.Errorf("요청 경로 = %q, want /v1/chat/completions", gotPath)
+	}
+	if gotAuth != "Bearer sk-test" {
+		t.Errorf("Authorization = %q, want Bearer sk-test", gotAuth)
+	}
+	if len(gotReq.Messages) != 2 || gotReq.Messages[0].Role != "system" || gotReq.MaxTokens != 4096 {
+		t.Errorf("요청 본문이 올바르지 않습니다: %+v", gotReq)
+	}
+	if resp.Output != "hello" || resp.StopReason != "end_turn" || resp.Provider != OpenAICompatProviderName {
+		t.Errorf("응답 = %+v", resp)
+	}
+	if resp.TokenUsage.TotalTokens != 7 {
+		t.Errorf("TotalTokens = %d, want 7", resp.TokenUsage.TotalTokens)
+	}
+}
+
+// TestOpenAICompatProvider_Execute_ToolCalls는 도구 정의 전달과 도구 호출 반환을 테스트합니다.
+func TestOpenAICompatProvider_Execute_ToolCalls(t *testing.T) {
+	var gotReq openAIChatRequest
+	var gotAuth string
+	p := newTestOpenAICompatProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_, _ = io.WriteString(w, `{"model":"llama3.1","choices":[{"index":0,"message":{"role":"assistant","tool_calls":[{"id":"call_1","type":"function","function":{"name":"search","arguments":"{\"q\":\"go\"}"}}]},"finish_reason":"tool_calls"}]}`)
+	})
+
+	resp, err := p.Execute(context.Background(), ExecuteRequest{
+		ResponseMode: "tool_loop",
+		ToolLoopMessages: []ws.ToolLoopMessage{
+			{Role: "user", Content: "search go"},
+		},
+		ToolDefinitions: []ws.ToolDefinition{
+			{Name: "search", Description: "Search", InputSchema: json.RawMessage(`{"type":"object"}`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute 실패: %v", err)
+	}
+
+	if gotAuth != "" {
+		t.Errorf("API 키가 없으면 Authorization 헤더가 없어야 합니다: %q", gotAuth)
+	}
+	if gotReq.Model != "llama3.1" {
+		t.Errorf("기본 모델이 사용되어야 합니다: %q", gotReq.Model)
+	}
+	if len(gotReq.Tools) != 1 || gotReq.Tools[0].Function.Name != "search" || gotReq.ToolChoice != "auto" {
+		t.Errorf("도구 정의가 전달되어야 합니다: %+v", gotReq.Tools)
+	}
+	if resp.StopReason != "tool_use" {
+		t.Errorf("StopReason = %q, want tool_use", resp.StopReason)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].ID != "call_1" || string(resp.ToolCalls[0].Input) != `{"q":"go"}` {
+		t.Errorf("ToolCalls = %+v", resp.ToolCalls)
+	}
+}
+
+// TestOpenAICompatProvider_Execute_Errors는 HTTP 에러 처리와 재시도를 테스트합니다.
+func TestOpenAICompatProvider_Execute_Errors(t *testing.T) {
+	t.Run("지원하지 않는 모델", func(t *testing.T) {
+		p := newTestOpenAICompatProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("요청이 전송되면 안됩니다")
+		})
+		_, err := p.Execute(context.Background(), ExecuteRequest{Prompt: "hi", Model: "claude-sonnet-4"})
+		if !errors.Is(err, ErrUnsupportedModel) {
+			t.Errorf("err = %v, want ErrUnsupportedModel", err)
+		}
+	})
+
+	t.Run("5xx 재시도", func(t *testing.T) {
+		calls := 0
+		p := newTestOpenAICompatProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = io.WriteString(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`)
+		}, WithOpenAICompatMaxRetries(1))
+		p.config.RetryDelayMs = 1
+
+		resp, err := p.Execute(context.Background(), ExecuteRequest{Prompt: "hi"})
+		if err != nil {
+			t.Fatalf("재시도 후 성공해야 합니다: %v", err)
+		}
+		if calls != 2 || resp.Output != "ok" || resp.Model != "llama3.1" {
+			t.Errorf("calls=%d resp=%+v", calls, resp)
+		}
+	})
+
+	t.Run("4xx 에러 메시지", func(t *testing.T) {
+		p := newTestOpenAICompatProvider(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"message":"model not found","type":"invalid_request_error"}}`)
+		})
+		_, err := p.Execute(context.Background(), ExecuteRequest{Prompt: "hi"})
+		if err == nil || !strings.Contains(err.Error(), "model not found") {
+			t.Errorf("err = %v, want model not found", err)
+		}
+	})
+}
+
+// TestOpenAICompatProvider_ExecuteStreaming은 SSE 텍스트 델타와 도구 호출 조각 누적을 테스트합니다.
+func TestOpenAICompatProvider_ExecuteStreaming(t *testing.T) {
+	var gotStream map[string]any
+	p := newTestOpenAICompatProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&gotStream)
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunks := []string{
+			`{"model":"qwen2.5-coder","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+			`{"choices":[{"index":0,"delta":{"content":"lo"}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"read_file","arguments":"{\"pa"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"th\":\"a.go\"}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+			`{"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}`,
+		}
+		_, _ = io.WriteString(w, ": keep-alive\n\n")
+		for _, c := range chunks {
+			_, _ = io.WriteString(w, "data: "+c+"\n\n")
+		}
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	})
+
+	var deltas []string
+	var lastAccumulated string
+	resp, err := p.ExecuteStreaming(context.Background(), ExecuteRequest{Prompt: "hi", Model: "qwen2.5-coder"}, func(delta, accumulated string) {
+		deltas = append(deltas, delta)
+		lastAccumulated = accumulated
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStreaming 실패: %v", err)
+	}
+
+	if gotStream["stream"] != true {
+		t.Errorf("stream=true가 전송되어야 합니다: %v", gotStream)
+	}
+	if strings.Join(deltas, "|") != "Hel|lo" || lastAccumulated != "Hello" {
+		t.Errorf("deltas = %v, accumulated = %q", deltas, lastAccumulated)
+	}
+	if resp.Output != "Hello" || resp.StopReason != "tool_use" || resp.Model != "qwen2.5-coder" {
+		t.Errorf("응답 = %+v", resp)
+	}
+	if resp.TokenUsage.TotalTokens != 7 {
+		t.Errorf("TotalTokens = %d, want 7", resp.TokenUsage.TotalTokens)
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "read_file" || string(resp.ToolCalls[0].Input) != `{"path":"a.go"}` {
+		t.Errorf("ToolCalls = %+v", resp.ToolCalls)
+	}
+}
+
+// TestRegistry_GetForModel_OpenAICompat은 명시적으로 설정된 모델이 OpenAI 호환 프로바이더로 라우팅되는지 테스트합니다.
+func TestRegistry_GetForModel_OpenAICompat(t *testing.T) {
+	compat, err := NewOpenAICompatProvider(
+		WithOpenAICompatBaseURL("http://localhost:8000/v1"),
+		WithOpenAICompatModels([]string{"gpt-oss-20b"}),
+	)
+	if err != nil {
+		t.Fatalf("NewOpenAICompatProvider 실패: %v", err)
+	}
+
+	r := NewRegistry()
+	r.Register(&mockProvider{name: "codex", supportedModel: "gpt-5.4"})
+	r.Register(compat)
+
+	p, err := r.GetForModel("gpt-oss-20b")
+	if err != nil || p.Name() != OpenAICompatProviderName {
+		t.Errorf("gpt-oss-20b는 openai-compat으로 라우팅되어야 합니다: %v, %v", p, err)
+	}
+	p, err = r.GetForModel("gpt-5.4")
+	if err != nil || p.Name() != "codex" {
+		t.Errorf("gpt-5.4는 codex로 라우팅되어야 합니다: %v, %v", p, err)
+	}
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/insajin/autopus-bridge/internal/approval"
+)
+
+// ResolutionSourcePinned는 서버가 고정(pin)한 provider/model이 그대로 해석된 경우입니다.
+const ResolutionSourcePinned = "pinned"
+
+// ModelLister는 실행 가능한 모델 목록을 알려주는 프로바이더가 구현합니다.
+type ModelLister interface {
+	Models() []string
+}
+
+// codexModelPatterns는 Codex 프로바이더가 받는 모델명 접두사입니다 (CodexProvider.Supports와 동일).
+var codexModelPatterns = []string{"gpt-*", "o3-*", "o4-*"}
+
+// ModelAlternative는 고정 실행에 사용할 수 있는 로컬 프로바이더입니다.
+type ModelAlternative struct {
+	// Provider는 프로바이더 이름입니다.
+	Provider string
+	// Models는 알려진 모델 목록입니다. "*"로 끝나는 항목은 모델명 접두사입니다.
+	Models []string
+	// SupportsApproval은 agent-approve/human-approve/deny-all 정책을 적용할 수 있는지 여부입니다.
+	SupportsApproval bool
+}
+
+// PinError는 서버가 고정한 provider/model/승인 정책을 로컬에서 만족할 수 없을 때 반환됩니다.
+// errors.Is(err, ErrUnsupportedModel)로 확인할 수 있습니다.
+type PinError struct {
+	Provider       string
+	Model          string
+	ApprovalPolicy string
+	// Reason은 고정을 만족할 수 없는 이유입니다.
+	Reason string
+	// Alternatives는 로컬에서 사용 가능한 프로바이더 목록입니다.
+	Alternatives []ModelAlternative
+}
+
+// Error는 에러 메시지를 반환합니다.
+func (e *PinError) Error() string {
+	return fmt.Sprintf("%v: %s (provider=%q, model=%q, approval_policy=%q)",
+		ErrUnsupportedModel, e.Reason, e.Provider, e.Model, e.ApprovalPolicy)
+}
+
+// Unwrap은 ErrUnsupportedModel을 반환합니다.
+func (e *PinError) Unwrap() error {
+	return ErrUnsupportedModel
+}
+
+// ResolvePinned는 서버가 고정한 provider/model/승인 정책을 폴백 없이 해석합니다.
+// ResolveForTask와 달리 요청한 프로바이더가 없거나 모델을 지원하지 않으면
+// override나 다른 프로바이더로 대체하지 않고 *PinError를 반환합니다.
+// provider와 model이 모두 비어 있으면 고정할 대상이 없으므로 ResolveForTask와 같습니다.
+func (r *Registry) ResolvePinned(providerName, model, approvalPolicy string) (*ModelResolution, error) {
+	if providerName == "" && model == "" {
+		return r.ResolveForTask(providerName, model)
+	}
+	fail := func(format string, args ...any) error {
+		return &PinError{
+			Provider:       providerName,
+			Model:          model,
+			ApprovalPolicy: approvalPolicy,
+			Reason:         fmt.Sprintf(format, args...),
+			Alternatives:   r.Alternatives(),
+		}
+	}
+
+	var prov Provider
+	if providerName != "" {
+		prov = r.Get(providerName)
+		if prov == nil {
+			prov = r.Get(ToInternalName(providerName))
+		}
+		if prov == nil {
+			return nil, fail("프로바이더 %q가 로컬에 등록되지 않았습니다", providerName)
+		}
+		if model != "" && !prov.Supports(model) && !prov.Supports(StripProviderPrefix(model)) {
+			return nil, fail("프로바이더 %q가 모델 %q를 지원하지 않습니다", prov.Name(), model)
+		}
+	} else {
+		p, err := r.GetForModel(model)
+		if err != nil {
+			return nil, fail("모델 %q를 지원하는 프로바이더가 없습니다", model)
+		}
+		prov = p
+	}
+
+	if approvalPolicy != "" {
+		policy := approval.ApprovalPolicy(approvalPolicy)
+		if !policy.Valid() {
+			return nil, fail("알 수 없는 승인 정책 %q", approvalPolicy)
+		}
+		if policy.RequiresRelay() && !supportsApproval(prov) {
+			return nil, fail("프로바이더 %q가 승인 정책 %q를 적용할 수 없습니다", prov.Name(), approvalPolicy)
+		}
+	}
+
+	return &ModelResolution{
+		Provider: prov,
+		Model:    StripProviderPrefix(model),
+		Source:   ResolutionSourcePinned,
+	}, nil
+}
+
+// Alternatives는 등록된 프로바이더와 알려진 모델 목록을 이름 순으로 반환합니다.
+func (r *Registry) Alternatives() []ModelAlternative {
+	providers := r.ListProviders()
+	alternatives := make([]ModelAlternative, 0, len(providers))
+	for _, p := range providers {
+		alternatives = append(alternatives, ModelAlternative{
+			Provider:         p.Name(),
+			Models:           knownModels(p),
+			SupportsApproval: supportsApproval(p),
+		})
+	}
+	sort.Slice(alternatives, func(i, j int) bool { return alternatives[i].Provider < alternatives[j].Provider })
+	return alternatives
+}
+
+// knownModels는 프로바이더가 실행할 수 있는 것으로 알려진 모델 목록입니다.
+func knownModels(p Provider) []string {
+	if lister, ok := p.(ModelLister); ok {
+		return append([]string(nil), lister.Models()...)
+	}
+	switch p.Name() {
+	case "claude":
+		return append([]string(nil), claudeSupportedModels...)
+	case "gemini":
+		return append([]string(nil), geminiSupportedModels...)
+	case "codex":
+		return append([]string(nil), codexModelPatterns...)
+	}
+	return nil
+}
+
+// supportsApproval은 프로바이더가 ApprovalRelay로 도구 호출 승인을 받을 수 있는지 확인합니다.
+func supportsApproval(p Provider) bool {
+	relay, ok := p.(approval.ApprovalRelay)
+	return ok && relay.SupportsApproval()
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/approval"
+)
+
+// approvalMockProvider는 ApprovalRelay를 지원하는 테스트용 목 프로바이더입니다.
+type approvalMockProvider struct {
+	mockProvider
+}
+
+func (m *approvalMockProvider) SupportsApproval() bool { return true }
+
+func (m *approvalMockProvider) SetApprovalHandler(approval.ApprovalHandler) {}
+
+// newPinTestRegistry는 codex(o4-mini, 승인 지원)와 claude가 등록된 레지스트리를 생성합니다.
+func newPinTestRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(&approvalMockProvider{mockProvider{name: "codex", supportedModel: "o4-mini"}})
+	r.Register(&mockProvider{name: "claude", supportedModel: "claude-sonnet-4-20250514"})
+	r.SetOverride(OverrideConfig{ProviderName: "claude"})
+	return r
+}
+
+// TestResolvePinned_Satisfied는 고정을 만족하면 요청한 프로바이더/모델 그대로 해석되는지 테스트합니다.
+func TestResolvePinned_Satisfied(t *testing.T) {
+	r := newPinTestRegistry()
+
+	tests := []struct {
+		name, provider, model, policy string
+		wantProvider, wantModel       string
+	}{
+		{"provider and model", "codex", "o4-mini", "deny-all", "codex", "o4-mini"},
+		{"canonical provider name", "openai", "openai/o4-mini", "", "codex", "o4-mini"},
+		{"model only", "", "claude-sonnet-4-20250514", "auto-execute", "claude", "claude-sonnet-4-20250514"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := r.ResolvePinned(tt.provider, tt.model, tt.policy)
+			if err != nil {
+				t.Fatalf("ResolvePinned() error = %v", err)
+			}
+			if res.Provider.Name() != tt.wantProvider || res.Model != tt.wantModel || res.Source != ResolutionSourcePinned {
+				t.Errorf("resolution = (%s, %s, %s), want (%s, %s, pinned)",
+					res.Provider.Name(), res.Model, res.Source, tt.wantProvider, tt.wantModel)
+			}
+		})
+	}
+}
+
+// TestResolvePinned_Unsatisfiable는 고정을 만족할 수 없으면 폴백 없이 PinError를 반환하는지 테스트합니다.
+func TestResolvePinned_Unsatisfiable(t *testing.T) {
+	r := newPinTestRegistry()
+
+	tests := []struct {
+		name, provider, model, policy string
+		wantReason                    string
+	}{
+		{"provider not registered", "gemini", "gemini-2.0-flash", "", "등록되지 않았습니다"},
+		{"model not supported", "codex", "o3-pro", "", "지원하지 않습니다"},
+		{"no provider for model", "", "llama-3", "", "프로바이더가 없습니다"},
+		{"unknown policy", "codex", "o4-mini", "allow-everything", "알 수 없는 승인 정책"},
+		{"policy needs relay", "claude", "claude-sonnet-4-20250514", "deny-all", "적용할 수 없습니다"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.ResolvePinned(tt.provider, tt.model, tt.policy)
+			if !errors.Is(err, ErrUnsupportedModel) {
+				t.Fatalf("error = %v, want ErrUnsupportedModel", err)
+			}
+			var pinErr *PinError
+			if !errors.As(err, &pinErr) {
+				t.Fatalf("error type = %T, want *PinError", err)
+			}
+			if !strings.Contains(pinErr.Reason, tt.wantReason) {
+				t.Errorf("Reason = %q, want containing %q", pinErr.Reason, tt.wantReason)
+			}
+			if len(pinErr.Alternatives) != 2 || pinErr.Alternatives[0].Provider != "claude" || pinErr.Alternatives[1].Provider != "codex" {
+				t.Errorf("Alternatives = %+v", pinErr.Alternatives)
+			}
+		})
+	}
+
+	// 고정하지 않으면 같은 요청이 override로 폴백된다
+	res, err := r.ResolveForTask("gemini", "gemini-2.0-flash")
+	if err != nil || res.Source != ResolutionSourceOverride {
+		t.Errorf("ResolveForTask() = %+v, %v, want override fallback", res, err)
+	}
+}
+
+// TestRegistryAlternatives는 대체 프로바이더 목록에 알려진 모델과 승인 지원 여부가 포함되는지 테스트합니다.
+func TestRegistryAlternatives(t *testing.T) {
+	r := newPinTestRegistry()
+	compat, err := NewOpenAICompatProvider(WithOpenAICompatBaseURL("http://localhost:8000/v1"), WithOpenAICompatModels([]string{"gpt-oss-20b"}))
+	if err != nil {
+		t.Fatalf("NewOpenAICompatProvider() error = %v", err)
+	}
+	r.Register(compat)
+
+	alts := r.Alternatives()
+	if len(alts) != 3 {
+		t.Fatalf("Alternatives() = %+v, want 3", alts)
+	}
+	byName := make(map[string]ModelAlternative)
+	for _, a := range alts {
+		byName[a.Provider] = a
+	}
+	if a := byName["codex"]; !a.SupportsApproval || strings.Join(a.Models, ",") != "gpt-*,o3-*,o4-*" {
+		t.Errorf("codex = %+v", a)
+	}
+	if a := byName["claude"]; a.SupportsApproval || len(a.Models) != len(claudeSupportedModels) {
+		t.Errorf("claude = %+v", a)
+	}
+	if a := byName[OpenAICompatProviderName]; strings.Join(a.Models, ",") != "gpt-oss-20b" {
+		t.Errorf("openai-compat = %+v", a)
+	}
+}
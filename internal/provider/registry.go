@@ -14,6 +14,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-codex-rpc/client"
 )
 
 // OverrideConfig는 모든 task_request를 특정 프로바이더/모델로 강제하는 설정입니다.
@@ -64,6 +65,13 @@ func (r *Registry) GetForModel(model string) (Provider, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	// 0. OpenAI 호환 프로바이더에 명시적으로 설정된 모델 우선 (예: vLLM의 "gpt-oss-20b")
+	for _, provider := range r.providers {
+		if compat, ok := provider.(*OpenAICompatProvider); ok && compat.Supports(model) {
+			return compat, nil
+		}
+	}
+
 	// 1. OpenRouter 형식 우선 확인 (예: "openai/o3-mini", "anthropic/claude-sonnet-4-6")
 	if IsOpenRouterFormat(model) {
 		prefix, _ := ParseOpenRouterID(model)
@@ -262,6 +270,25 @@ type RegistryConfig struct {
 	CodexApprovalPolicy string
 	// CodexChatGPTAuthEnv는 ChatGPT 인증 토큰 환경변수명입니다.
 	CodexChatGPTAuthEnv string
+	// CodexTurnRetries는 App Server 모드의 턴 재시도 횟수입니다. nil이면 프로바이더 기본값(2)을 사용합니다.
+	CodexTurnRetries *int
+	// CodexFraming은 App Server 모드의 JSON-RPC 프레이밍입니다 ("line", "content-length"). 비어 있으면 "line"입니다.
+	CodexFraming string
+
+	// OpenAICompatEnabled는 OpenAI 호환 HTTP API 프로바이더 활성화 여부입니다. 기본값 false입니다.
+	OpenAICompatEnabled bool
+	// OpenAICompatName은 OpenAI 호환 프로바이더 이름입니다. 기본값: "openai-compat".
+	OpenAICompatName string
+	// OpenAICompatBaseURL은 Chat Completions API 기본 URL입니다 (예: "http://localhost:11434/v1").
+	OpenAICompatBaseURL string
+	// OpenAICompatAPIKey는 API 키입니다. 비어 있으면 인증 헤더 없이 요청합니다.
+	OpenAICompatAPIKey string
+	// OpenAICompatDefaultModel은 OpenAI 호환 프로바이더 기본 모델입니다.
+	OpenAICompatDefaultModel string
+	// OpenAICompatModels는 OpenAI 호환 프로바이더로 라우팅할 모델 목록입니다.
+	OpenAICompatModels []string
+	// OpenAICompatTimeout은 요청 타임아웃(초)입니다. 기본값: 300.
+	OpenAICompatTimeout int
 }
 
 // InitializeRegistry는 설정에 따라 프로바이더를 초기화하고 레지스트리에 등록합니다.
@@ -315,9 +342,25 @@ func InitializeRegistryWithLogger(ctx context.Context, cfg RegistryConfig, logge
 		}
 	}
 
+	// OpenAI 호환 HTTP API 프로바이더 초기화 (명시적으로 활성화된 경우에만)
+	if cfg.OpenAICompatEnabled {
+		compatProvider, err := initializeOpenAICompatProvider(cfg)
+		if err != nil {
+			logger.Warn().Err(err).Msg("OpenAI 호환 프로바이더 초기화 실패")
+		} else {
+			registry.Register(compatProvider)
+			providerCount++
+			logger.Info().
+				Str("provider", compatProvider.Name()).
+				Str("base_url", cfg.OpenAICompatBaseURL).
+				Strs("models", compatProvider.Models()).
+				Msg("OpenAI 호환 프로바이더 등록")
+		}
+	}
+
 	// REQ-S-05: 설정된 AI 프로바이더가 없으면 에러
 	if providerCount == 0 {
-		return nil, fmt.Errorf("AI 프로바이더가 설정되지 않았습니다. ANTHROPIC_API_KEY, GEMINI_API_KEY, 또는 OPENAI_API_KEY 환경변수를 설정하거나, claude/gemini/codex CLI를 설치하세요")
+		return nil, fmt.Errorf("AI 프로바이더가 설정되지 않았습니다. ANTHROPIC_API_KEY, GEMINI_API_KEY, 또는 OPENAI_API_KEY 환경변수를 설정하거나, claude/gemini/codex CLI를 설치하거나, providers.openai_compat을 설정하세요")
 	}
 
 	return registry, nil
@@ -700,6 +743,21 @@ func initializeGeminiHybridProvider(ctx context.Context, cfg RegistryConfig, cli
 	)
 }
 
+// initializeOpenAICompatProvider는 OpenAI 호환 HTTP API 프로바이더를 초기화합니다.
+func initializeOpenAICompatProvider(cfg RegistryConfig) (*OpenAICompatProvider, error) {
+	opts := []OpenAICompatProviderOption{
+		WithOpenAICompatName(cfg.OpenAICompatName),
+		WithOpenAICompatBaseURL(cfg.OpenAICompatBaseURL),
+		WithOpenAICompatAPIKey(cfg.OpenAICompatAPIKey),
+		WithOpenAICompatDefaultModel(cfg.OpenAICompatDefaultModel),
+		WithOpenAICompatModels(cfg.OpenAICompatModels),
+	}
+	if cfg.OpenAICompatTimeout > 0 {
+		opts = append(opts, WithOpenAICompatTimeout(time.Duration(cfg.OpenAICompatTimeout)*time.Second))
+	}
+	return NewOpenAICompatProvider(opts...)
+}
+
 // initializeCodexProvider는 설정된 모드에 따라 Codex 프로바이더를 초기화합니다.
 func initializeCodexProvider(cfg RegistryConfig, logger zerolog.Logger) (Provider, error) {
 	mode := cfg.CodexMode
@@ -846,6 +904,14 @@ func initializeCodexAppServerProvider(cfg RegistryConfig, cliPath string, logger
 	if cfg.CodexDefaultModel != "" {
 		opts = append(opts, WithAppServerDefaultModel(cfg.CodexDefaultModel))
 	}
+	if cfg.CodexTurnRetries != nil {
+		opts = append(opts, WithAppServerTurnRetries(*cfg.CodexTurnRetries))
+	}
+	framer, err := client.ParseFraming(cfg.CodexFraming)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, WithAppServerFraming(framer))
 
 	return NewCodexAppServerProvider(cliPath, opts...)
 }
@@ -597,3 +597,26 @@ func isGeminiModel(model string) bool {
 	}
 	return model[:7] == "gemini-"
 }
+
+// TestSupportsExecutionEnv는 실행마다 프로세스를 띄우는 프로바이더만 Env 전달을 지원한다고 보고하는지 검증합니다.
+func TestSupportsExecutionEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Provider
+		want bool
+	}{
+		{name: "claude CLI", p: &ClaudeCLIProvider{}, want: true},
+		{name: "claude interactive", p: &InteractiveClaudeCLIProvider{}, want: true},
+		{name: "codex CLI", p: &CodexCLIProvider{}, want: true},
+		{name: "gemini CLI", p: &GeminiCLIProvider{}, want: true},
+		{name: "hybrid CLI 사용 가능", p: &HybridClaudeProvider{cli: &ClaudeCLIProvider{}}, want: true},
+		{name: "hybrid API만", p: &HybridCodexProvider{api: &CodexProvider{}}, want: false},
+		{name: "claude API", p: &ClaudeProvider{}, want: false},
+		{name: "codex app-server", p: &CodexAppServerProvider{}, want: false},
+	}
+	for _, tt := range tests {
+		if got := SupportsExecutionEnv(tt.p); got != tt.want {
+			t.Errorf("%s: SupportsExecutionEnv() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/store"
+)
+
+// ReplayMode는 프로바이더 실행 기록/재생 모드입니다.
+type ReplayMode string
+
+const (
+	// ReplayOff는 기록/재생을 하지 않습니다 (기본값).
+	ReplayOff ReplayMode = "off"
+	// ReplayRecord는 프로바이더를 실행하고 성공한 응답을 요청 해시별로 기록합니다.
+	ReplayRecord ReplayMode = "record"
+	// ReplayReplay는 프로바이더를 실행하지 않고 기록된 응답을 반환합니다.
+	// 기록이 없으면 ErrReplayMiss를 반환합니다.
+	ReplayReplay ReplayMode = "replay"
+)
+
+// ErrReplayMiss는 재생 모드에서 요청에 해당하는 기록이 없을 때 반환됩니다.
+var ErrReplayMiss = errors.New("재생 모드: 기록된 프로바이더 응답이 없습니다")
+
+// ParseReplayMode는 설정 값을 ReplayMode로 변환합니다. 빈 문자열은 ReplayOff입니다.
+func ParseReplayMode(s string) (ReplayMode, error) {
+	switch ReplayMode(s) {
+	case "", ReplayOff:
+		return ReplayOff, nil
+	case ReplayRecord, ReplayReplay:
+		return ReplayMode(s), nil
+	default:
+		return "", fmt.Errorf("유효하지 않은 재생 모드: %s (off, record, replay 중 하나)", s)
+	}
+}
+
+// replayKeyInput은 기록 키를 만드는 요청 필드입니다.
+// 실행마다 달라지는 WorkDir, Timeout, Env, ResumeSessionID는 제외해 같은 요청이 같은 키를 갖게 합니다.
+type replayKeyInput struct {
+	Provider         string               `json:"provider"`
+	Model            string               `json:"model"`
+	SystemPrompt     string               `json:"system_prompt"`
+	Prompt           string               `json:"prompt"`
+	MaxTokens        int                  `json:"max_tokens"`
+	Tools            []string             `json:"tools"`
+	ResponseMode     string               `json:"response_mode"`
+	ToolLoopMessages []ws.ToolLoopMessage `json:"tool_loop_messages"`
+	ToolDefinitions  []ws.ToolDefinition  `json:"tool_definitions"`
+}
+
+// ReplayEntry는 기록된 요청/응답 한 쌍입니다. 파일에는 사람이 읽을 수 있는 JSON으로 저장됩니다.
+type ReplayEntry struct {
+	Key          string    `json:"key"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	SystemPrompt string    `json:"system_prompt,omitempty"`
+	Prompt       string    `json:"prompt"`
+
+	Output       string     `json:"output"`
+	ResponseMode string     `json:"response_mode,omitempty"`
+	StopReason   string     `json:"stop_reason,omitempty"`
+	ToolCalls    []ToolCall `json:"tool_calls,omitempty"`
+	RespModel    string     `json:"response_model,omitempty"`
+	DurationMs   int64      `json:"duration_ms,omitempty"`
+	InputTokens  int        `json:"input_tokens,omitempty"`
+	OutputTokens int        `json:"output_tokens,omitempty"`
+	TotalTokens  int        `json:"total_tokens,omitempty"`
+}
+
+// Response는 기록된 응답을 ExecuteResponse로 변환합니다.
+func (e *ReplayEntry) Response() *ExecuteResponse {
+	return &ExecuteResponse{
+		Output:     e.Output,
+		DurationMs: e.DurationMs,
+		Model:      e.RespModel,
+		Provider:   e.Provider,
+		StopReason: e.StopReason,
+		ToolCalls:  e.ToolCalls,
+		TokenUsage: TokenUsage{
+			InputTokens:  e.InputTokens,
+			OutputTokens: e.OutputTokens,
+			TotalTokens:  e.TotalTokens,
+		},
+	}
+}
+
+// ReplayStore는 프로바이더 요청/응답 쌍을 요청 해시별로 보관합니다.
+// 상태 저장소 버킷이 있으면 버킷에(암호화 설정 시 암호화), 없으면 디렉토리의 JSON 파일에 저장합니다.
+// 기록 모드로 실행한 결과를 재생 모드에서 그대로 돌려주어 결정적인 통합 테스트와 오프라인 데모에 사용합니다.
+type ReplayStore struct {
+	dir string
+	// bucket은 상태 저장소 버킷입니다. 설정되면 dir 대신 사용합니다.
+	bucket *store.Bucket
+	// now는 현재 시각을 반환합니다 (테스트에서 교체).
+	now func() time.Time
+}
+
+// NewReplayStore는 dir에 기록하는 ReplayStore를 생성합니다.
+func NewReplayStore(dir string) *ReplayStore {
+	return &ReplayStore{dir: dir, now: time.Now}
+}
+
+// NewStoreReplayStore는 상태 저장소 버킷에 기록하는 ReplayStore를 생성합니다.
+func NewStoreReplayStore(bucket *store.Bucket) *ReplayStore {
+	return &ReplayStore{bucket: bucket, now: time.Now}
+}
+
+// Dir은 기록 디렉토리를 반환합니다. 상태 저장소 버킷에 기록하면 빈 문자열입니다.
+func (s *ReplayStore) Dir() string {
+	return s.dir
+}
+
+// Key는 프로바이더 이름과 요청으로 기록 키(SHA-256 16진수)를 계산합니다.
+func (s *ReplayStore) Key(providerName string, req ExecuteRequest) string {
+	data, _ := json.Marshal(replayKeyInput{
+		Provider:         providerName,
+		Model:            req.Model,
+		SystemPrompt:     req.SystemPrompt,
+		Prompt:           req.Prompt,
+		MaxTokens:        req.MaxTokens,
+		Tools:            req.Tools,
+		ResponseMode:     req.ResponseMode,
+		ToolLoopMessages: req.ToolLoopMessages,
+		ToolDefinitions:  req.ToolDefinitions,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// path는 기록 키의 파일 경로를 반환합니다.
+func (s *ReplayStore) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+// Load는 기록 키의 응답을 읽습니다. 기록이 없으면 ErrReplayMiss를 감싼 에러를 반환합니다.
+func (s *ReplayStore) Load(key string) (*ReplayEntry, error) {
+	if s.bucket != nil {
+		var entry ReplayEntry
+		ok, err := s.bucket.GetJSON(key, &entry)
+		if err != nil {
+			return nil, fmt.Errorf("재생 기록 읽기 실패: %w", err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w (key=%s)", ErrReplayMiss, key)
+		}
+		return &entry, nil
+	}
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w (key=%s)", ErrReplayMiss, key)
+		}
+		return nil, fmt.Errorf("재생 기록 읽기 실패: %w", err)
+	}
+	var entry ReplayEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("재생 기록 파싱 실패 (key=%s): %w", key, err)
+	}
+	return &entry, nil
+}
+
+// Save는 요청과 응답을 기록 키로 저장합니다. 같은 키의 기존 기록은 덮어씁니다.
+func (s *ReplayStore) Save(key, providerName string, req ExecuteRequest, resp *ExecuteResponse) error {
+	if resp == nil {
+		return errors.New("기록할 응답이 없습니다")
+	}
+	entry := ReplayEntry{
+		Key:          key,
+		RecordedAt:   s.now().UTC(),
+		Provider:     providerName,
+		Model:        req.Model,
+		SystemPrompt: req.SystemPrompt,
+		Prompt:       req.Prompt,
+		Output:       resp.Output,
+		ResponseMode: req.ResponseMode,
+		StopReason:   resp.StopReason,
+		ToolCalls:    resp.ToolCalls,
+		RespModel:    resp.Model,
+		DurationMs:   resp.DurationMs,
+		InputTokens:  resp.TokenUsage.InputTokens,
+		OutputTokens: resp.TokenUsage.OutputTokens,
+		TotalTokens:  resp.TokenUsage.TotalTokens,
+	}
+	if s.bucket != nil {
+		if err := s.bucket.PutJSON(key, entry); err != nil {
+			return fmt.Errorf("재생 기록 저장 실패: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("재생 기록 직렬화 실패: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("재생 기록 디렉토리 생성 실패: %w", err)
+	}
+
+	// 같은 키를 동시에 기록해도 읽는 쪽이 잘린 파일을 보지 않도록 임시 파일에 쓰고 이름을 바꿉니다.
+	tmp, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("재생 기록 파일 생성 실패: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("재생 기록 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("재생 기록 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("재생 기록 저장 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/store"
+)
+
+func TestReplayStore_Key(t *testing.T) {
+	s := NewReplayStore(t.TempDir())
+	req := ExecuteRequest{Prompt: "hello", Model: "claude-sonnet", SystemPrompt: "sys"}
+	key := s.Key("claude", req)
+
+	// 실행마다 달라지는 필드는 키에 포함하지 않는다.
+	volatile := req
+	volatile.WorkDir = "/tmp/exec-1"
+	volatile.Timeout = 300
+	volatile.Env = []string{"TOKEN=x"}
+	volatile.ResumeSessionID = "session-1"
+	if got := s.Key("claude", volatile); got != key {
+		t.Errorf("volatile fields changed key: %s != %s", got, key)
+	}
+
+	changed := req
+	changed.Prompt = "hello!"
+	if s.Key("claude", changed) == key {
+		t.Error("다른 프롬프트는 다른 키여야 합니다")
+	}
+	if s.Key("codex", req) == key {
+		t.Error("다른 프로바이더는 다른 키여야 합니다")
+	}
+}
+
+func TestReplayStore_SaveLoad(t *testing.T) {
+	s := NewReplayStore(t.TempDir())
+	req := ExecuteRequest{Prompt: "hello", Model: "claude-sonnet"}
+	key := s.Key("claude", req)
+
+	if _, err := s.Load(key); !errors.Is(err, ErrReplayMiss) {
+		t.Fatalf("Load before Save err = %v, want ErrReplayMiss", err)
+	}
+
+	resp := &ExecuteResponse{
+		Output:     "world",
+		Model:      "claude-sonnet",
+		StopReason: "end_turn",
+		DurationMs: 1200,
+		TokenUsage: TokenUsage{InputTokens: 3, OutputTokens: 5, TotalTokens: 8},
+		ToolCalls:  []ToolCall{{ID: "t1", Name: "computer", Input: []byte(`{"action":"click"}`)}},
+	}
+	if err := s.Save(key, "claude", req, resp); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	entry, err := s.Load(key)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if entry.Prompt != "hello" || entry.Provider != "claude" {
+		t.Errorf("entry = %+v", entry)
+	}
+	got := entry.Response()
+	if got.Output != "world" || got.StopReason != "end_turn" || got.Provider != "claude" || got.TokenUsage.TotalTokens != 8 {
+		t.Errorf("Response() = %+v", got)
+	}
+	if len(got.ToolCalls) != 1 || got.ToolCalls[0].Name != "computer" {
+		t.Fatalf("ToolCalls = %+v", got.ToolCalls)
+	}
+	var input bytes.Buffer
+	if err := json.Compact(&input, got.ToolCalls[0].Input); err != nil || input.String() != `{"action":"click"}` {
+		t.Errorf("ToolCalls[0].Input = %s (err=%v)", got.ToolCalls[0].Input, err)
+	}
+}
+
+func TestParseReplayMode(t *testing.T) {
+	for in, want := range map[string]ReplayMode{"": ReplayOff, "off": ReplayOff, "record": ReplayRecord, "replay": ReplayReplay} {
+		got, err := ParseReplayMode(in)
+		if err != nil || got != want {
+			t.Errorf("ParseReplayMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseReplayMode("playback"); err == nil {
+		t.Error("알 수 없는 모드는 에러여야 합니다")
+	}
+}
+
+func TestReplayStore_StoreBucket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	st, err := store.Open(path, store.WithEncryptionKey(bytes.Repeat([]byte{7}, store.KeySize)))
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	s := NewStoreReplayStore(st.Bucket("provider_replay"))
+	req := ExecuteRequest{Prompt: "hello", Model: "claude-sonnet"}
+	key := s.Key("claude", req)
+
+	if _, err := s.Load(key); !errors.Is(err, ErrReplayMiss) {
+		t.Fatalf("Load before Save err = %v, want ErrReplayMiss", err)
+	}
+	if err := s.Save(key, "claude", req, &ExecuteResponse{Output: "world"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// 암호화된 저장소 파일에는 프롬프트와 출력이 평문으로 남지 않는다.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if bytes.Contains(data, []byte("hello")) || bytes.Contains(data, []byte("world")) {
+		t.Error("저장소 파일에 평문 기록이 있습니다")
+	}
+
+	reopened, err := store.Open(path, store.WithEncryptionKey(bytes.Repeat([]byte{7}, store.KeySize)))
+	if err != nil {
+		t.Fatalf("store.Open: %v", err)
+	}
+	defer reopened.Close()
+	entry, err := NewStoreReplayStore(reopened.Bucket("provider_replay")).Load(key)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if entry.Prompt != "hello" || entry.Response().Output != "world" {
+		t.Errorf("entry = %+v", entry)
+	}
+}
@@ -18,6 +18,18 @@ const (
 	StreamEventMessageDelta      = "message_delta"
 	StreamEventMessageStop       = "message_stop"
 	StreamEventResult            = "result"
+
+	// Claude Code CLI 래퍼 이벤트
+	// --include-partial-messages 사용 시 API 원시 이벤트는 stream_event로 감싸져 전달되고,
+	// 완성된 어시스턴트 메시지는 assistant 이벤트로 전달됩니다.
+	StreamEventStreamEvent = "stream_event"
+	StreamEventAssistant   = "assistant"
+)
+
+// 콘텐츠 블록 타입
+const (
+	streamBlockText    = "text"
+	streamBlockToolUse = "tool_use"
 )
 
 // StreamLine은 Claude CLI stream-json의 NDJSON 한 줄을 파싱한 결과입니다.
@@ -28,6 +40,12 @@ type StreamLine struct {
 	// content_block_delta 전용
 	Delta *StreamDelta `json:"delta,omitempty"`
 
+	// content_block_start 전용
+	ContentBlock *StreamContentBlock `json:"content_block,omitempty"`
+
+	// assistant 전용 (완성된 메시지)
+	Message *StreamMessage `json:"message,omitempty"`
+
 	// result 전용 (최종 결과)
 	Result            string  `json:"result,omitempty"`
 	Subtype           string  `json:"subtype,omitempty"`
@@ -41,17 +59,39 @@ type StreamLine struct {
 
 // StreamDelta는 content_block_delta 이벤트의 delta 필드입니다.
 type StreamDelta struct {
-	Type string `json:"type"` // "text_delta", "input_json_delta", etc.
-	Text string `json:"text,omitempty"`
+	Type        string `json:"type"` // "text_delta", "input_json_delta", etc.
+	Text        string `json:"text,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+}
+
+// StreamContentBlock은 메시지 콘텐츠 블록입니다 (text, tool_use 등).
+type StreamContentBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text,omitempty"`
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+// StreamMessage는 assistant 이벤트의 message 필드입니다.
+type StreamMessage struct {
+	Content []StreamContentBlock `json:"content"`
 }
 
 // ParseStreamLine은 NDJSON 한 줄을 StreamLine으로 파싱합니다.
+// stream_event로 감싼 이벤트는 내부 이벤트를 꺼내 반환합니다.
 func ParseStreamLine(line []byte) (*StreamLine, error) {
-	var sl StreamLine
+	var sl struct {
+		StreamLine
+		Event *StreamLine `json:"event,omitempty"`
+	}
 	if err := json.Unmarshal(line, &sl); err != nil {
 		return nil, err
 	}
-	return &sl, nil
+	if sl.Type == StreamEventStreamEvent && sl.Event != nil {
+		return sl.Event, nil
+	}
+	return &sl.StreamLine, nil
 }
 
 // IsTextDelta는 이 이벤트가 텍스트 델타인지 확인합니다.
@@ -67,6 +107,113 @@ func (sl *StreamLine) IsResult() bool {
 	return sl.Type == StreamEventResult
 }
 
+// IsAssistantMessage는 이 이벤트가 완성된 어시스턴트 메시지인지 확인합니다.
+func (sl *StreamLine) IsAssistantMessage() bool {
+	return sl.Type == StreamEventAssistant && sl.Message != nil
+}
+
+// MessageText는 어시스턴트 메시지의 텍스트 블록을 이어 붙여 반환합니다.
+func (sl *StreamLine) MessageText() string {
+	if sl.Message == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, block := range sl.Message.Content {
+		if block.Type == streamBlockText {
+			b.WriteString(block.Text)
+		}
+	}
+	return b.String()
+}
+
+// ToolUseCollector는 stream-json 이벤트에서 tool_use 블록을 모아 ToolCall 목록을 만듭니다.
+// 부분 이벤트(content_block_start/input_json_delta/content_block_stop)와
+// 완성된 assistant 메시지를 모두 처리하며, 같은 ID의 호출은 한 번만 기록합니다.
+type ToolUseCollector struct {
+	pending map[int]*pendingToolUse
+	seen    map[string]bool
+	calls   []ToolCall
+}
+
+// pendingToolUse는 입력 JSON을 수신 중인 tool_use 블록입니다.
+type pendingToolUse struct {
+	id    string
+	name  string
+	input strings.Builder
+}
+
+// NewToolUseCollector는 새로운 ToolUseCollector를 생성합니다.
+func NewToolUseCollector() *ToolUseCollector {
+	return &ToolUseCollector{
+		pending: make(map[int]*pendingToolUse),
+		seen:    make(map[string]bool),
+	}
+}
+
+// Add는 이벤트를 처리하고 새로 완성된 도구 호출이 있으면 반환합니다.
+func (c *ToolUseCollector) Add(sl *StreamLine) []ToolCall {
+	switch sl.Type {
+	case StreamEventContentBlockStart:
+		if sl.ContentBlock != nil && sl.ContentBlock.Type == streamBlockToolUse {
+			c.pending[sl.Index] = &pendingToolUse{id: sl.ContentBlock.ID, name: sl.ContentBlock.Name}
+		}
+	case StreamEventContentBlockDelta:
+		if pt, ok := c.pending[sl.Index]; ok && sl.Delta != nil && sl.Delta.Type == "input_json_delta" {
+			pt.input.WriteString(sl.Delta.PartialJSON)
+		}
+	case StreamEventContentBlockStop:
+		pt, ok := c.pending[sl.Index]
+		if !ok {
+			return nil
+		}
+		delete(c.pending, sl.Index)
+		input := json.RawMessage(pt.input.String())
+		if len(input) == 0 || !json.Valid(input) {
+			input = json.RawMessage("{}")
+		}
+		return c.record(ToolCall{ID: pt.id, Name: pt.name, Input: input})
+	case StreamEventAssistant:
+		if sl.Message == nil {
+			return nil
+		}
+		var added []ToolCall
+		for _, block := range sl.Message.Content {
+			if block.Type != streamBlockToolUse {
+				continue
+			}
+			input := block.Input
+			if len(input) == 0 {
+				input = json.RawMessage("{}")
+			}
+			added = append(added, c.record(ToolCall{ID: block.ID, Name: block.Name, Input: input})...)
+		}
+		return added
+	}
+	return nil
+}
+
+// record는 처음 보는 도구 호출만 기록합니다.
+func (c *ToolUseCollector) record(call ToolCall) []ToolCall {
+	if call.ID != "" {
+		if c.seen[call.ID] {
+			return nil
+		}
+		c.seen[call.ID] = true
+	}
+	c.calls = append(c.calls, call)
+	return []ToolCall{call}
+}
+
+// ToolCalls는 지금까지 기록된 도구 호출을 순서대로 반환합니다.
+func (c *ToolUseCollector) ToolCalls() []ToolCall {
+	if len(c.calls) == 0 {
+		return nil
+	}
+	calls := make([]ToolCall, len(c.calls))
+	copy(calls, c.calls)
+	return calls
+}
+
 // StreamAccumulator는 텍스트 토큰을 누적하고 블록 스트리밍 로직을 구현합니다.
 // 문장 경계, 줄바꿈, 시간 제한, 또는 버퍼 크기에 따라 플러시합니다.
 type StreamAccumulator struct {
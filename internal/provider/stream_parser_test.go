@@ -0,0 +1,47 @@
+package provider
+
+import "testing"
+
+func TestToolUseCollector_AssistantMessageOnly(t *testing.T) {
+	// --include-partial-messages를 지원하지 않는 CLI는 완성된 assistant 메시지만 출력한다.
+	line, err := ParseStreamLine([]byte(`{"type":"assistant","message":{"content":[` +
+		`{"type":"text","text":"Reading."},` +
+		`{"type":"tool_use","id":"toolu_1","name":"Read","input":{"file_path":"main.go"}},` +
+		`{"type":"tool_use","id":"toolu_2","name":"Grep"}]}}`))
+	if err != nil {
+		t.Fatalf("ParseStreamLine 실패: %v", err)
+	}
+	if !line.IsAssistantMessage() || line.MessageText() != "Reading." {
+		t.Fatalf("assistant 메시지 파싱 실패: %+v", line)
+	}
+
+	c := NewToolUseCollector()
+	if added := c.Add(line); len(added) != 2 {
+		t.Fatalf("added = %d, want 2", len(added))
+	}
+	// 같은 메시지가 다시 와도 중복 기록하지 않는다.
+	if added := c.Add(line); len(added) != 0 {
+		t.Errorf("중복 tool_use가 기록되었습니다: %+v", added)
+	}
+
+	calls := c.ToolCalls()
+	if len(calls) != 2 {
+		t.Fatalf("calls = %d, want 2", len(calls))
+	}
+	if string(calls[0].Input) != `{"file_path":"main.go"}` {
+		t.Errorf("calls[0].Input = %s", calls[0].Input)
+	}
+	if string(calls[1].Input) != "{}" {
+		t.Errorf("입력이 없는 호출은 {}여야 합니다: %s", calls[1].Input)
+	}
+}
+
+func TestParseStreamLine_UnwrapsStreamEvent(t *testing.T) {
+	line, err := ParseStreamLine([]byte(`{"type":"stream_event","event":{"type":"content_block_delta","index":2,"delta":{"type":"text_delta","text":"hi"}}}`))
+	if err != nil {
+		t.Fatalf("ParseStreamLine 실패: %v", err)
+	}
+	if !line.IsTextDelta() || line.Index != 2 || line.Delta.Text != "hi" {
+		t.Errorf("stream_event가 언랩되지 않았습니다: %+v", line)
+	}
+}
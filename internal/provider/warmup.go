@@ -0,0 +1,121 @@
+// Package provider는 AI 프로바이더 통합 레이어를 제공합니다.
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultWarmupTimeout은 프로바이더 warm-up 기본 타임아웃입니다.
+const DefaultWarmupTimeout = 30 * time.Second
+
+// Warmer는 연결 시점에 미리 기동할 수 있는 프로바이더가 구현하는 선택적 인터페이스입니다.
+// App Server처럼 프로세스 기동 비용이 큰 프로바이더는 Warmup에서 프로세스를 시작하고
+// 인증까지 마쳐 첫 작업의 콜드 스타트 지연을 줄입니다.
+type Warmer interface {
+	// Warmup은 프로바이더를 즉시 실행 가능한 상태로 준비합니다.
+	// 이미 준비된 경우 아무 작업도 하지 않고 nil을 반환해야 합니다.
+	Warmup(ctx context.Context) error
+}
+
+// WarmupResult는 개별 프로바이더의 warm-up 결과입니다.
+type WarmupResult struct {
+	// Provider는 프로바이더 내부 이름입니다.
+	Provider string
+	// Ready는 warm-up과 ValidateConfig를 모두 통과했는지 여부입니다.
+	Ready bool
+	// Duration은 warm-up에 걸린 시간입니다.
+	Duration time.Duration
+	// Err는 실패 원인입니다. Ready가 true이면 nil입니다.
+	Err error
+}
+
+// WarmupAll은 등록된 모든 프로바이더를 병렬로 warm-up합니다.
+// Warmer를 구현한 프로바이더는 Warmup을 먼저 호출하고,
+// 모든 프로바이더에 대해 ValidateConfig로 준비 상태를 확인합니다.
+// timeout이 0 이하이면 DefaultWarmupTimeout이 사용됩니다.
+func (r *Registry) WarmupAll(ctx context.Context, timeout time.Duration) map[string]WarmupResult {
+	if timeout <= 0 {
+		timeout = DefaultWarmupTimeout
+	}
+
+	providers := r.ListProviders()
+	results := make(map[string]WarmupResult, len(providers))
+
+	warmupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, p := range providers {
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+			result := warmupProvider(warmupCtx, p)
+
+			mu.Lock()
+			results[result.Provider] = result
+			mu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// warmupProvider는 단일 프로바이더를 warm-up하고 결과를 반환합니다.
+func warmupProvider(ctx context.Context, p Provider) WarmupResult {
+	start := time.Now()
+	result := WarmupResult{Provider: p.Name()}
+
+	if w, ok := p.(Warmer); ok {
+		errCh := make(chan error, 1)
+		go func() { errCh <- w.Warmup(ctx) }()
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				result.Err = err
+			}
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+		}
+	}
+
+	if result.Err == nil {
+		result.Err = p.ValidateConfig()
+	}
+
+	result.Ready = result.Err == nil
+	result.Duration = time.Since(start)
+	return result
+}
+
+// Suspender는 유휴 절전 모드에서 상주 프로세스를 내릴 수 있는 프로바이더가 구현하는 선택적 인터페이스입니다.
+// 중단된 프로바이더는 다음 Warmup 또는 실행 요청에서 다시 기동되어야 합니다.
+type Suspender interface {
+	// Suspend는 상주 프로세스를 중지합니다. 이미 중지된 경우 nil을 반환해야 합니다.
+	Suspend() error
+}
+
+// SuspendAll은 Suspender를 구현한 모든 프로바이더를 중단하고,
+// 실패한 프로바이더의 에러를 이름별로 반환합니다. 모두 성공하면 nil입니다.
+func (r *Registry) SuspendAll() map[string]error {
+	var errs map[string]error
+	for _, p := range r.ListProviders() {
+		s, ok := p.(Suspender)
+		if !ok {
+			continue
+		}
+		if err := s.Suspend(); err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[p.Name()] = err
+		}
+	}
+	return errs
+}
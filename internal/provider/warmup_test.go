@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// warmableMockProvider는 Warmer를 구현하는 테스트용 목 프로바이더입니다.
+type warmableMockProvider struct {
+	mockProvider
+	warmupErr   error
+	warmupDelay time.Duration
+	warmups     atomic.Int32
+}
+
+func (m *warmableMockProvider) Warmup(ctx context.Context) error {
+	m.warmups.Add(1)
+	select {
+	case <-time.After(m.warmupDelay):
+		return m.warmupErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TestWarmupAll_ReportsReadiness는 warm-up 결과가 프로바이더별로 보고되는지 검증합니다.
+func TestWarmupAll_ReportsReadiness(t *testing.T) {
+	r := NewRegistry()
+	codex := &warmableMockProvider{mockProvider: mockProvider{name: "codex"}}
+	claude := &mockProvider{name: "claude"}
+	gemini := &mockProvider{name: "gemini", validateErr: ErrNoAPIKey}
+	r.Register(codex)
+	r.Register(claude)
+	r.Register(gemini)
+
+	results := r.WarmupAll(context.Background(), time.Second)
+
+	if len(results) != 3 {
+		t.Fatalf("결과 수가 3이어야 하나 %d입니다", len(results))
+	}
+	if codex.warmups.Load() != 1 {
+		t.Errorf("codex Warmup 호출 횟수가 1이어야 하나 %d입니다", codex.warmups.Load())
+	}
+	if !results["codex"].Ready || !results["claude"].Ready {
+		t.Errorf("codex/claude는 준비 상태여야 합니다: %+v", results)
+	}
+	if results["gemini"].Ready || !errors.Is(results["gemini"].Err, ErrNoAPIKey) {
+		t.Errorf("gemini는 ErrNoAPIKey로 실패해야 합니다: %+v", results["gemini"])
+	}
+}
+
+// TestWarmupAll_WarmupErrorSkipsValidation은 Warmup 실패 시 준비되지 않음으로 보고되는지 검증합니다.
+func TestWarmupAll_WarmupErrorSkipsValidation(t *testing.T) {
+	r := NewRegistry()
+	warmErr := errors.New("spawn failed")
+	r.Register(&warmableMockProvider{
+		mockProvider: mockProvider{name: "codex"},
+		warmupErr:    warmErr,
+	})
+
+	results := r.WarmupAll(context.Background(), time.Second)
+
+	if results["codex"].Ready {
+		t.Fatal("Warmup 실패 시 Ready가 false여야 합니다")
+	}
+	if !errors.Is(results["codex"].Err, warmErr) {
+		t.Errorf("Warmup 에러가 전달되어야 하나 %v입니다", results["codex"].Err)
+	}
+}
+
+// TestWarmupAll_RunsInParallel는 warm-up이 병렬로 실행되고 타임아웃이 적용되는지 검증합니다.
+func TestWarmupAll_RunsInParallel(t *testing.T) {
+	r := NewRegistry()
+	for _, name := range []string{"codex", "claude", "gemini"} {
+		r.Register(&warmableMockProvider{
+			mockProvider: mockProvider{name: name},
+			warmupDelay:  100 * time.Millisecond,
+		})
+	}
+
+	start := time.Now()
+	results := r.WarmupAll(context.Background(), time.Second)
+	elapsed := time.Since(start)
+
+	if elapsed >= 250*time.Millisecond {
+		t.Errorf("병렬 실행 시 250ms 미만이어야 하나 %v 걸렸습니다", elapsed)
+	}
+	for name, result := range results {
+		if !result.Ready {
+			t.Errorf("%s는 준비 상태여야 합니다: %v", name, result.Err)
+		}
+	}
+
+	slow := NewRegistry()
+	slow.Register(&warmableMockProvider{
+		mockProvider: mockProvider{name: "codex"},
+		warmupDelay:  time.Second,
+	})
+	results = slow.WarmupAll(context.Background(), 50*time.Millisecond)
+	if results["codex"].Ready || !errors.Is(results["codex"].Err, context.DeadlineExceeded) {
+		t.Errorf("타임아웃 시 DeadlineExceeded여야 하나 %+v입니다", results["codex"])
+	}
+}
+
+// suspendableMockProvider는 Suspender를 구현하는 테스트용 목 프로바이더입니다.
+type suspendableMockProvider struct {
+	mockProvider
+	suspendErr error
+	suspends   atomic.Int32
+}
+
+func (m *suspendableMockProvider) Suspend() error {
+	m.suspends.Add(1)
+	return m.suspendErr
+}
+
+// TestSuspendAll은 Suspender를 구현한 프로바이더만 중단하고 실패를 이름별로 보고하는지 검증합니다.
+func TestSuspendAll(t *testing.T) {
+	r := NewRegistry()
+	codex := &suspendableMockProvider{mockProvider: mockProvider{name: "codex"}}
+	broken := &suspendableMockProvider{mockProvider: mockProvider{name: "gemini"}, suspendErr: errors.New("stop failed")}
+	r.Register(codex)
+	r.Register(broken)
+	r.Register(&mockProvider{name: "claude"})
+
+	errs := r.SuspendAll()
+
+	if codex.suspends.Load() != 1 || broken.suspends.Load() != 1 {
+		t.Errorf("Suspend 호출 횟수: codex=%d, gemini=%d, 각각 1이어야 합니다", codex.suspends.Load(), broken.suspends.Load())
+	}
+	if len(errs) != 1 || errs["gemini"] == nil {
+		t.Errorf("gemini 실패만 보고되어야 하나 %v입니다", errs)
+	}
+
+	r2 := NewRegistry()
+	r2.Register(&suspendableMockProvider{mockProvider: mockProvider{name: "codex"}})
+	if errs := r2.SuspendAll(); errs != nil {
+		t.Errorf("모두 성공하면 nil이어야 하나 %v입니다", errs)
+	}
+}
@@ -0,0 +1,342 @@
+// Package sizeguard는 작업 입력과 실행 결과의 크기를 제한합니다.
+// 작업 프롬프트가 한도를 넘으면 실행 전에 거절하고, 작업 출력과 CLI stdout/stderr가 한도를 넘으면
+// 앞뒤를 남기고 가운데를 줄인 뒤 전체 내용을 로컬 파일에 보관하고 그 참조(ws.OverflowRef)를 결과에 싣습니다.
+// 장황한 명령 하나가 WebSocket 메시지 한도(1MB)를 넘어 결과 전송이 실패하는 것을 막습니다.
+package sizeguard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+const (
+	// minFieldBytes는 결과 크기를 맞추려고 필드를 줄일 때 남기는 최소 크기입니다.
+	minFieldBytes = 4 * 1024
+	// maxFitAttempts는 결과 크기를 맞추려고 필드를 다시 줄이는 최대 횟수입니다.
+	maxFitAttempts = 4
+	// lineAlignWindow는 잘라낸 경계를 줄 경계로 옮길 때 찾아보는 최대 거리입니다.
+	lineAlignWindow = 512
+)
+
+// Limits는 크기 한도입니다. 0이면 그 한도를 적용하지 않습니다.
+type Limits struct {
+	// MaxPromptBytes는 작업 프롬프트(시스템 프롬프트 포함)의 최대 크기입니다.
+	MaxPromptBytes int
+	// MaxOutputBytes는 결과 필드(작업 출력, stdout, stderr) 하나의 최대 크기입니다.
+	MaxOutputBytes int
+	// MaxResultBytes는 직렬화한 결과 페이로드의 최대 크기입니다.
+	MaxResultBytes int
+}
+
+// Guard는 크기 한도를 적용하고 넘친 내용을 보관합니다. nil Guard는 아무것도 제한하지 않습니다.
+type Guard struct {
+	limits Limits
+	// dir은 넘친 내용을 보관할 디렉토리입니다. 비어 있으면 보관하지 않고 줄이기만 합니다.
+	dir string
+	// maxAge는 보관한 내용을 유지하는 기간입니다 (Prune).
+	maxAge time.Duration
+}
+
+// New는 Guard를 생성합니다. dir이 비어 있으면 넘친 내용은 보관하지 않습니다.
+func New(limits Limits, dir string, maxAge time.Duration) *Guard {
+	return &Guard{limits: limits, dir: dir, maxAge: maxAge}
+}
+
+// Limits는 적용 중인 한도를 반환합니다.
+func (g *Guard) Limits() Limits {
+	if g == nil {
+		return Limits{}
+	}
+	return g.limits
+}
+
+// PromptTooLargeError는 작업 프롬프트가 한도를 넘었음을 나타냅니다.
+// 같은 요청을 다시 보내도 결과가 같으므로 재시도할 수 없습니다.
+type PromptTooLargeError struct {
+	Size  int
+	Limit int
+}
+
+// Error는 에러 메시지를 반환합니다.
+func (e *PromptTooLargeError) Error() string {
+	return fmt.Sprintf("프롬프트 크기 %d바이트가 최대 %d바이트를 넘습니다", e.Size, e.Limit)
+}
+
+// ErrorCode는 task_error 코드를 반환합니다.
+func (e *PromptTooLargeError) ErrorCode() string {
+	return errcode.PromptTooLarge
+}
+
+// IsRetryable은 재시도 가능 여부를 반환합니다.
+func (e *PromptTooLargeError) IsRetryable() bool {
+	return false
+}
+
+// CheckPrompt는 프롬프트와 시스템 프롬프트를 합친 크기가 한도를 넘으면 *PromptTooLargeError를 반환합니다.
+func (g *Guard) CheckPrompt(prompt, systemPrompt string) error {
+	if g == nil || g.limits.MaxPromptBytes <= 0 {
+		return nil
+	}
+	if size := len(prompt) + len(systemPrompt); size > g.limits.MaxPromptBytes {
+		return &PromptTooLargeError{Size: size, Limit: g.limits.MaxPromptBytes}
+	}
+	return nil
+}
+
+// field는 크기를 제한할 결과 필드 하나입니다.
+type field struct {
+	name string
+	// value는 결과 페이로드의 필드를 가리킵니다.
+	value *string
+	// full은 줄이기 전의 전체 내용입니다.
+	full string
+	ref  *ws.OverflowRef
+}
+
+// TaskResult는 작업 결과의 출력을 한도에 맞게 줄입니다.
+// 줄였으면 OutputTruncated를 설정하고, 전체 출력을 보관했으면 Overflow에 참조를 추가합니다.
+func (g *Guard) TaskResult(result *ws.TaskResultPayload) {
+	if g == nil || result == nil {
+		return
+	}
+	truncated, refs := g.output(result.ExecutionID, &result.Output, func() int { return jsonSize(result) })
+	result.OutputTruncated = result.OutputTruncated || truncated
+	result.Overflow = append(result.Overflow, refs...)
+}
+
+// AgentResponse는 agent_response_complete의 출력을 TaskResult와 같은 방식으로 줄입니다.
+func (g *Guard) AgentResponse(result *ws.AgentResponseCompletePayload) {
+	if g == nil || result == nil {
+		return
+	}
+	truncated, refs := g.output(result.ExecutionID, &result.Output, func() int { return jsonSize(result) })
+	result.OutputTruncated = result.OutputTruncated || truncated
+	result.Overflow = append(result.Overflow, refs...)
+}
+
+// output은 출력 필드 하나를 한도에 맞게 줄이고 줄였는지와 보관한 참조를 반환합니다.
+func (g *Guard) output(id string, output *string, size func() int) (bool, []ws.OverflowRef) {
+	f := &field{name: ws.OverflowFieldOutput, value: output}
+	refs := g.fit(id, []*field{f}, size)
+	return f.full != "", refs
+}
+
+// CLIResult는 CLI 결과의 stdout/stderr를 한도에 맞게 줄입니다.
+// requestID는 보관 파일을 구분하는 cli_request 메시지 ID입니다.
+func (g *Guard) CLIResult(requestID string, result *ws.CLIResultPayload) {
+	if g == nil || result == nil {
+		return
+	}
+	fields := []*field{
+		{name: ws.OverflowFieldStdout, value: &result.Stdout},
+		{name: ws.OverflowFieldStderr, value: &result.Stderr},
+	}
+	refs := g.fit(requestID, fields, func() int { return jsonSize(result) })
+	if fields[0].full != "" {
+		result.StdoutTruncated = true
+	}
+	if fields[1].full != "" {
+		result.StderrTruncated = true
+	}
+	result.Overflow = append(result.Overflow, refs...)
+}
+
+// fit은 각 필드를 MaxOutputBytes로 줄인 뒤, 직렬화한 결과가 MaxResultBytes를 넘으면
+// 가장 큰 필드부터 넘친 만큼 더 줄입니다. 줄인 필드의 전체 내용은 한 번만 보관합니다.
+// 줄인 필드는 full에 원래 내용이 남고, 보관한 참조 목록을 반환합니다.
+func (g *Guard) fit(id string, fields []*field, size func() int) []ws.OverflowRef {
+	for _, f := range fields {
+		if g.limits.MaxOutputBytes > 0 && len(*f.value) > g.limits.MaxOutputBytes {
+			g.shrink(id, f, g.limits.MaxOutputBytes)
+		}
+	}
+
+	if g.limits.MaxResultBytes > 0 {
+		for attempt := 0; attempt < maxFitAttempts; attempt++ {
+			excess := size() - g.limits.MaxResultBytes
+			if excess <= 0 {
+				break
+			}
+			largest := fields[0]
+			for _, f := range fields[1:] {
+				if len(*f.value) > len(*largest.value) {
+					largest = f
+				}
+			}
+			// JSON 이스케이프로 늘어나는 크기를 고려해 넘친 양보다 조금 더 줄인다.
+			target := max(len(*largest.value)-excess-excess/8-256, minFieldBytes)
+			if target >= len(*largest.value) {
+				log.Printf("[sizeguard] 결과 크기를 한도 안으로 줄이지 못함: id=%s size=%d limit=%d", id, size(), g.limits.MaxResultBytes)
+				break
+			}
+			g.shrink(id, largest, target)
+		}
+	}
+
+	var refs []ws.OverflowRef
+	for _, f := range fields {
+		if f.ref != nil {
+			refs = append(refs, *f.ref)
+		}
+	}
+	return refs
+}
+
+// shrink는 필드를 limit 바이트 이하로 줄입니다. 처음 줄일 때 전체 내용을 보관하고,
+// 다시 줄일 때는 같은 전체 내용에서 다시 자릅니다.
+func (g *Guard) shrink(id string, f *field, limit int) {
+	if f.full == "" {
+		f.full = *f.value
+		ref, err := g.store(id, f.name, f.full)
+		if err != nil {
+			log.Printf("[sizeguard] 넘친 내용 보관 실패: id=%s field=%s err=%v", id, f.name, err)
+		}
+		f.ref = ref
+	}
+	path := ""
+	if f.ref != nil {
+		path = f.ref.Path
+	}
+	*f.value = Truncate(f.full, limit, path)
+}
+
+// Truncate는 s를 limit 바이트 이하로 줄입니다. 앞부분과 뒷부분을 절반씩 남기고 가운데에
+// 생략 표시를 넣으며, 잘라낸 경계는 가능하면 줄 경계로, 항상 UTF-8 문자 경계로 맞춥니다.
+// path가 있으면 생략 표시에 전체 내용의 위치를 함께 적습니다.
+func Truncate(s string, limit int, path string) string {
+	if len(s) <= limit {
+		return s
+	}
+	// 생략 바이트 수는 원래 크기보다 자릿수가 많지 않으므로 원래 크기로 표시 길이를 잡는다.
+	budget := limit - len(truncationNote(len(s), path))
+	if budget <= 0 {
+		note := truncationNote(len(s), path)
+		return note[:min(limit, len(note))]
+	}
+
+	headEnd := alignHead(s, budget/2)
+	tailStart := alignTail(s, len(s)-(budget-headEnd))
+	return s[:headEnd] + truncationNote(tailStart-headEnd, path) + s[tailStart:]
+}
+
+// truncationNote는 n바이트를 생략했다는 표시를 만듭니다. path가 있으면 전체 내용의 위치를 함께 적습니다.
+func truncationNote(n int, path string) string {
+	if path == "" {
+		return fmt.Sprintf("\n... [%d bytes truncated] ...\n", n)
+	}
+	return fmt.Sprintf("\n... [%d bytes truncated, full content: %s] ...\n", n, path)
+}
+
+// alignHead는 앞부분의 끝을 n 이하의 가까운 줄 끝(없으면 문자 경계)으로 옮깁니다.
+// 줄바꿈은 생략 표시가 대신하므로 앞부분에 포함하지 않습니다.
+func alignHead(s string, n int) int {
+	if i := strings.LastIndexByte(s[max(n-lineAlignWindow, 0):n], '\n'); i >= 0 {
+		return max(n-lineAlignWindow, 0) + i
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return n
+}
+
+// alignTail은 뒷부분의 시작을 n 이상의 가까운 줄 시작(없으면 문자 경계)으로 옮깁니다.
+func alignTail(s string, n int) int {
+	if i := strings.IndexByte(s[n:min(n+lineAlignWindow, len(s))], '\n'); i >= 0 {
+		return n + i + 1
+	}
+	for n < len(s) && !utf8.RuneStart(s[n]) {
+		n++
+	}
+	return n
+}
+
+// store는 넘친 필드의 전체 내용을 <dir>/<id>/<field>.txt에 보관하고 참조를 반환합니다.
+func (g *Guard) store(id, name, content string) (*ws.OverflowRef, error) {
+	if g.dir == "" {
+		return nil, nil
+	}
+	dir := filepath.Join(g.dir, sanitizeName(id))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("보관 디렉토리 생성 실패: %w", err)
+	}
+	path := filepath.Join(dir, name+".txt")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return nil, fmt.Errorf("보관 파일 쓰기 실패: %w", err)
+	}
+	sum := sha256.Sum256([]byte(content))
+	return &ws.OverflowRef{
+		Field:     name,
+		Path:      path,
+		SizeBytes: int64(len(content)),
+		SHA256:    hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Prune은 maxAge보다 오래 수정되지 않은 보관 디렉토리를 삭제하고 삭제한 수를 반환합니다.
+func (g *Guard) Prune(now time.Time) (int, error) {
+	if g == nil || g.dir == "" || g.maxAge <= 0 {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(g.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("보관 디렉토리 읽기 실패: %w", err)
+	}
+	removed := 0
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) <= g.maxAge {
+			continue
+		}
+		if os.RemoveAll(filepath.Join(g.dir, entry.Name())) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// sanitizeName은 실행 ID나 요청 ID를 디렉토리 이름으로 쓸 수 있게 변환합니다.
+// "."이나 ".."처럼 점으로만 된 이름은 보관 디렉토리 자신이나 상위 디렉토리를 가리키므로 밑줄로 바꿉니다.
+func sanitizeName(id string) string {
+	if id == "" {
+		return "unknown"
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, id)
+	if strings.Trim(name, ".") == "" || filepath.Clean(name) != name {
+		return strings.Repeat("_", len(name))
+	}
+	return name
+}
+
+// jsonSize는 v를 직렬화한 크기를 반환합니다.
+func jsonSize(v any) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
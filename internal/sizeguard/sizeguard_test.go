@@ -0,0 +1,231 @@
+package sizeguard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+func TestCheckPrompt(t *testing.T) {
+	g := New(Limits{MaxPromptBytes: 10}, "", 0)
+
+	if err := g.CheckPrompt("hello", "world"); err != nil {
+		t.Fatalf("한도 이하 프롬프트가 거절됨: %v", err)
+	}
+	err := g.CheckPrompt("hello", "world!")
+	var tooLarge *PromptTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("PromptTooLargeError를 기대했으나 %v", err)
+	}
+	if tooLarge.Size != 11 || tooLarge.Limit != 10 {
+		t.Errorf("Size/Limit = %d/%d, want 11/10", tooLarge.Size, tooLarge.Limit)
+	}
+	if tooLarge.ErrorCode() != errcode.PromptTooLarge || tooLarge.IsRetryable() {
+		t.Errorf("ErrorCode/IsRetryable = %s/%v", tooLarge.ErrorCode(), tooLarge.IsRetryable())
+	}
+
+	var nilGuard *Guard
+	if err := nilGuard.CheckPrompt(strings.Repeat("x", 1<<20), ""); err != nil {
+		t.Errorf("nil Guard는 제한하지 않아야 함: %v", err)
+	}
+	if err := New(Limits{}, "", 0).CheckPrompt(strings.Repeat("x", 1<<20), ""); err != nil {
+		t.Errorf("한도 0은 제한하지 않아야 함: %v", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	var lines []string
+	for i := 0; i < 200; i++ {
+		lines = append(lines, strings.Repeat("라인", 10))
+	}
+	s := strings.Join(lines, "\n")
+
+	if got := Truncate("short", 100, ""); got != "short" {
+		t.Errorf("한도 이하 문자열이 바뀜: %q", got)
+	}
+
+	got := Truncate(s, 1000, "/tmp/full.txt")
+	if len(got) > 1000 {
+		t.Errorf("len = %d, want <= 1000", len(got))
+	}
+	if !utf8.ValidString(got) {
+		t.Error("잘린 결과가 유효한 UTF-8이 아님")
+	}
+	if !strings.Contains(got, "bytes truncated, full content: /tmp/full.txt") {
+		t.Errorf("생략 표시가 없음: %q", got)
+	}
+	head, tail, _ := strings.Cut(got, "\n... [")
+	if !strings.HasPrefix(s, head) || !strings.HasSuffix(head, lines[0]) {
+		t.Errorf("앞부분이 줄 경계에서 잘리지 않음: %q", head)
+	}
+	_, tail, _ = strings.Cut(tail, "] ...\n")
+	if !strings.HasSuffix(s, tail) || !strings.HasPrefix(tail, lines[0]) {
+		t.Errorf("뒷부분이 줄 경계에서 시작하지 않음: %q", tail)
+	}
+
+	// 줄바꿈이 없어도 UTF-8 문자 경계를 지킨다.
+	got = Truncate(strings.Repeat("가", 1000), 500, "")
+	if len(got) > 500 || !utf8.ValidString(got) {
+		t.Errorf("len = %d, valid = %v", len(got), utf8.ValidString(got))
+	}
+}
+
+func TestTruncate_PercentInPath(t *testing.T) {
+	path := "/tmp/100%d/full%s.txt"
+	got := Truncate(strings.Repeat("x\n", 1000), 200, path)
+	if len(got) > 200 {
+		t.Errorf("len = %d, want <= 200", len(got))
+	}
+	if !strings.Contains(got, "full content: "+path+"]") || strings.Contains(got, "%!") {
+		t.Errorf("생략 표시가 경로를 그대로 담지 않음: %q", got)
+	}
+}
+
+func TestTaskResult_Overflow(t *testing.T) {
+	dir := t.TempDir()
+	g := New(Limits{MaxOutputBytes: 1024}, dir, 0)
+	output := strings.Repeat("0123456789\n", 500)
+	result := &ws.TaskResultPayload{ExecutionID: "exec/1", Output: output}
+
+	g.TaskResult(result)
+
+	if len(result.Output) > 1024 || !result.OutputTruncated {
+		t.Fatalf("len = %d, truncated = %v", len(result.Output), result.OutputTruncated)
+	}
+	if len(result.Overflow) != 1 {
+		t.Fatalf("Overflow = %+v, want 1", result.Overflow)
+	}
+	ref := result.Overflow[0]
+	if ref.Field != ws.OverflowFieldOutput || ref.SizeBytes != int64(len(output)) {
+		t.Errorf("ref = %+v", ref)
+	}
+	if want := filepath.Join(dir, "exec_1", "output.txt"); ref.Path != want {
+		t.Errorf("Path = %s, want %s", ref.Path, want)
+	}
+	data, err := os.ReadFile(ref.Path)
+	if err != nil || string(data) != output {
+		t.Fatalf("보관 파일 내용이 원래 출력과 다름: err=%v", err)
+	}
+	sum := sha256.Sum256(data)
+	if ref.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("SHA256 불일치")
+	}
+	if !strings.Contains(result.Output, ref.Path) {
+		t.Errorf("생략 표시에 보관 경로가 없음")
+	}
+}
+
+func TestTaskResult_DotExecutionID(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "overflow")
+	g := New(Limits{MaxOutputBytes: 1024}, dir, 0)
+	for _, id := range []string{"..", ".", "..."} {
+		result := &ws.TaskResultPayload{ExecutionID: id, Output: strings.Repeat("x", 4096)}
+		g.TaskResult(result)
+		if len(result.Overflow) != 1 {
+			t.Fatalf("%q: Overflow = %+v, want 1", id, result.Overflow)
+		}
+		// 보관 파일은 항상 보관 디렉토리의 하위 디렉토리에 있어야 한다.
+		if rel, err := filepath.Rel(dir, filepath.Dir(result.Overflow[0].Path)); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			t.Errorf("%q: Path = %s, 보관 디렉토리 %s의 하위가 아님", id, result.Overflow[0].Path, dir)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "output.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("보관 디렉토리의 상위에 파일이 생김: %v", err)
+	}
+}
+
+func TestTaskResult_WithinLimits(t *testing.T) {
+	g := New(Limits{MaxOutputBytes: 1024, MaxResultBytes: 4096}, t.TempDir(), 0)
+	result := &ws.TaskResultPayload{ExecutionID: "exec-1", Output: "ok"}
+
+	g.TaskResult(result)
+
+	if result.Output != "ok" || result.OutputTruncated || result.Overflow != nil {
+		t.Errorf("한도 이하 결과가 바뀜: %+v", result)
+	}
+}
+
+func TestCLIResult_FitsResultLimit(t *testing.T) {
+	dir := t.TempDir()
+	// 필드 하나는 한도 이하지만 합치면 결과 한도를 넘는다.
+	g := New(Limits{MaxOutputBytes: 64 * 1024, MaxResultBytes: 80 * 1024}, dir, 0)
+	result := &ws.CLIResultPayload{
+		Stdout: strings.Repeat("out line\n", 7000),
+		Stderr: strings.Repeat("err\n", 5000),
+	}
+
+	g.CLIResult("req-1", result)
+
+	data, _ := json.Marshal(result)
+	if len(data) > 80*1024 {
+		t.Errorf("결과 크기 %d가 한도를 넘음", len(data))
+	}
+	if !result.StdoutTruncated {
+		t.Error("StdoutTruncated가 설정되지 않음")
+	}
+	fields := make(map[string]bool)
+	for _, ref := range result.Overflow {
+		fields[ref.Field] = true
+		if _, err := os.Stat(ref.Path); err != nil {
+			t.Errorf("보관 파일 없음: %v", err)
+		}
+	}
+	if !fields[ws.OverflowFieldStdout] {
+		t.Errorf("stdout 참조가 없음: %+v", result.Overflow)
+	}
+	if result.StderrTruncated != fields[ws.OverflowFieldStderr] {
+		t.Errorf("StderrTruncated=%v, stderr 참조=%v", result.StderrTruncated, fields[ws.OverflowFieldStderr])
+	}
+}
+
+func TestCLIResult_NoDir(t *testing.T) {
+	g := New(Limits{MaxOutputBytes: 100}, "", 0)
+	result := &ws.CLIResultPayload{Stdout: strings.Repeat("x", 1000)}
+
+	g.CLIResult("req-1", result)
+
+	if len(result.Stdout) > 100 || !result.StdoutTruncated || result.Overflow != nil {
+		t.Errorf("보관 없이 줄이기만 해야 함: len=%d overflow=%+v", len(result.Stdout), result.Overflow)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	g := New(Limits{}, dir, 24*time.Hour)
+
+	for name, age := range map[string]time.Duration{"old": 48 * time.Hour, "new": time.Hour} {
+		path := filepath.Join(dir, name)
+		if err := os.Mkdir(path, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := g.Prune(now)
+	if err != nil || removed != 1 {
+		t.Fatalf("Prune = %d, %v; want 1", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old")); !os.IsNotExist(err) {
+		t.Error("오래된 디렉토리가 남아 있음")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new")); err != nil {
+		t.Error("최근 디렉토리가 삭제됨")
+	}
+
+	if removed, err := New(Limits{}, filepath.Join(dir, "missing"), time.Hour).Prune(now); err != nil || removed != 0 {
+		t.Errorf("없는 디렉토리: %d, %v", removed, err)
+	}
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Bucket은 하위 시스템 하나의 키 공간입니다 (예: "outbox", "result_cache").
+type Bucket struct {
+	store *Store
+	name  string
+}
+
+// Name은 버킷 이름을 반환합니다.
+func (b *Bucket) Name() string {
+	return b.name
+}
+
+// Get은 key의 값을 반환합니다. 값이 없으면 ok가 false입니다.
+func (b *Bucket) Get(key string) (value []byte, ok bool) {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+	v, ok := b.store.data[b.name][key]
+	if !ok {
+		return nil, false
+	}
+	return bytes.Clone(v), true
+}
+
+// Put은 key에 value를 저장합니다.
+func (b *Bucket) Put(key string, value []byte) error {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+	return b.store.writeLocked(record{Op: "put", Bucket: b.name, Key: key, Value: bytes.Clone(value)})
+}
+
+// Delete는 key를 삭제합니다. 없는 키는 무시합니다.
+func (b *Bucket) Delete(key string) error {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+	if _, ok := b.store.data[b.name][key]; !ok {
+		return nil
+	}
+	return b.store.writeLocked(record{Op: "del", Bucket: b.name, Key: key})
+}
+
+// Len은 버킷의 키 수를 반환합니다.
+func (b *Bucket) Len() int {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+	return len(b.store.data[b.name])
+}
+
+// ForEach는 키 순서대로 모든 값에 fn을 호출합니다. fn이 에러를 반환하면 중단합니다.
+// fn 실행 중에는 저장소 잠금을 보유하지 않으므로 fn에서 버킷을 수정할 수 있습니다.
+func (b *Bucket) ForEach(fn func(key string, value []byte) error) error {
+	b.store.mu.Lock()
+	src := b.store.data[b.name]
+	keys := sortedKeys(src)
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = bytes.Clone(src[k])
+	}
+	b.store.mu.Unlock()
+
+	for i, k := range keys {
+		if err := fn(k, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replace는 버킷 내용을 entries로 교체합니다. 바뀐 키만 기록하므로
+// 전체 상태를 한 번에 저장하는 하위 시스템(아웃박스 등)도 로그가 불필요하게 커지지 않습니다.
+func (b *Bucket) Replace(entries map[string][]byte) error {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+
+	current := b.store.data[b.name]
+	var recs []record
+	for _, k := range sortedKeys(current) {
+		if _, ok := entries[k]; !ok {
+			recs = append(recs, record{Op: "del", Bucket: b.name, Key: k})
+		}
+	}
+	for _, k := range sortedKeys(entries) {
+		if old, ok := current[k]; ok && bytes.Equal(old, entries[k]) {
+			continue
+		}
+		recs = append(recs, record{Op: "put", Bucket: b.name, Key: k, Value: bytes.Clone(entries[k])})
+	}
+	return b.store.writeLocked(recs...)
+}
+
+// GetJSON은 key의 값을 v로 디코딩합니다. 값이 없으면 false를 반환합니다.
+func (b *Bucket) GetJSON(key string, v any) (bool, error) {
+	data, ok := b.Get(key)
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return true, fmt.Errorf("%s/%s 값 파싱 실패: %w", b.name, key, err)
+	}
+	return true, nil
+}
+
+// PutJSON은 v를 JSON으로 인코딩하여 key에 저장합니다.
+func (b *Bucket) PutJSON(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%s/%s 값 직렬화 실패: %w", b.name, key, err)
+	}
+	return b.Put(key, data)
+}
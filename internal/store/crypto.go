@@ -0,0 +1,121 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// fileMagic은 상태 저장소 파일의 시작 표식입니다.
+var fileMagic = []byte("APSTATE1")
+
+const (
+	flagPlain     byte = 0
+	flagEncrypted byte = 1
+
+	// KeySize는 암호화 키 크기(AES-256)입니다.
+	KeySize = 32
+)
+
+// keyCheck는 암호화 키 확인용 평문입니다. 헤더에 암호화하여 저장하고 열 때 복호화해 봅니다.
+var keyCheck = []byte("autopus-state-store")
+
+// sealer는 레코드를 AES-256-GCM으로 암호화/복호화합니다.
+type sealer struct {
+	aead cipher.AEAD
+}
+
+// newSealer는 32바이트 키로 sealer를 생성합니다.
+func newSealer(key []byte) (*sealer, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("상태 저장소 암호화 키는 %d바이트여야 합니다 (현재 %d바이트)", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("상태 저장소 암호화 초기화 실패: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("상태 저장소 암호화 초기화 실패: %w", err)
+	}
+	return &sealer{aead: aead}, nil
+}
+
+// seal은 plain을 암호화하여 nonce||ciphertext를 반환합니다.
+func (s *sealer) seal(plain []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plain)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("nonce 생성 실패: %w", err)
+	}
+	return s.aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+// open은 seal로 암호화된 data를 복호화합니다.
+func (s *sealer) open(data []byte) ([]byte, error) {
+	if len(data) < s.aead.NonceSize() {
+		return nil, errors.New("암호문이 잘렸습니다")
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	return s.aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// encodeHeader는 파일 헤더를 만듭니다. 암호화 저장소는 키 확인 블록을 포함합니다.
+func encodeHeader(s *sealer) ([]byte, error) {
+	header := append([]byte{}, fileMagic...)
+	if s == nil {
+		return append(header, flagPlain), nil
+	}
+	check, err := s.seal(keyCheck)
+	if err != nil {
+		return nil, err
+	}
+	header = append(header, flagEncrypted)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(check)))
+	return append(header, check...), nil
+}
+
+// readHeader는 파일 헤더를 읽고 파일이 암호화되어 있으면 key로 만든 sealer를 반환합니다.
+// 빈 파일이면 io.EOF를 반환합니다.
+func readHeader(r io.Reader, key []byte) (*sealer, int64, error) {
+	prefix := make([]byte, len(fileMagic)+1)
+	n, err := io.ReadFull(r, prefix)
+	if n == 0 && err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	if err != nil || string(prefix[:len(fileMagic)]) != string(fileMagic) {
+		return nil, 0, errors.New("상태 저장소 파일 형식을 알 수 없습니다")
+	}
+	size := int64(len(prefix))
+	if prefix[len(fileMagic)] == flagPlain {
+		return nil, size, nil
+	}
+
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, 0, errors.New("상태 저장소 헤더가 잘렸습니다")
+	}
+	check := make([]byte, binary.BigEndian.Uint32(lenBuf[:]))
+	if len(check) > 1024 {
+		return nil, 0, errors.New("상태 저장소 헤더가 손상되었습니다")
+	}
+	if _, err := io.ReadFull(r, check); err != nil {
+		return nil, 0, errors.New("상태 저장소 헤더가 잘렸습니다")
+	}
+	size += int64(len(lenBuf) + len(check))
+
+	if key == nil {
+		return nil, 0, ErrEncrypted
+	}
+	s, err := newSealer(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if plain, err := s.open(check); err != nil || string(plain) != string(keyCheck) {
+		return nil, 0, ErrWrongKey
+	}
+	return s, size, nil
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/procgroup"
+)
+
+const (
+	// KeychainService는 OS 키체인에 저장하는 상태 저장소 키의 서비스 이름입니다.
+	KeychainService = "autopus-bridge"
+	// KeychainAccount는 OS 키체인에 저장하는 상태 저장소 키의 계정 이름입니다.
+	KeychainAccount = "state-store"
+)
+
+// ErrKeychainUnavailable은 이 환경에서 OS 키체인을 사용할 수 없음을 나타냅니다.
+var ErrKeychainUnavailable = errors.New("OS 키체인을 사용할 수 없습니다")
+
+// runKeychainCommand는 키체인 도구를 실행하고 stdout을 반환합니다 (테스트에서 교체).
+var runKeychainCommand = func(stdin string, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	return procgroup.Output(cmd)
+}
+
+// KeyFromEnv는 환경 변수 name의 값을 SHA-256으로 32바이트 키로 변환합니다.
+func KeyFromEnv(name string) ([]byte, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, fmt.Errorf("암호화 키 환경 변수 %s가 설정되지 않았습니다", name)
+	}
+	sum := sha256.Sum256([]byte(value))
+	return sum[:], nil
+}
+
+// KeychainKey는 OS 키체인(macOS Keychain, Linux Secret Service)에서 상태 저장소 키를 가져옵니다.
+// 항목이 없다고 확인된 경우에만 무작위 키를 만들어 저장합니다. 키체인이 잠겨 있거나 접근이 거부되는 등
+// 항목 유무를 알 수 없으면 기존 키를 덮어쓰지 않도록 ErrKeychainUnavailable을 반환합니다.
+func KeychainKey(service, account string) ([]byte, error) {
+	encoded, err := keychainLookup(service, account)
+	if err != nil {
+		return nil, err
+	}
+	if encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != KeySize {
+			return nil, fmt.Errorf("키체인의 상태 저장소 키 형식이 올바르지 않습니다 (%s/%s)", service, account)
+		}
+		return key, nil
+	}
+
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("상태 저장소 키 생성 실패: %w", err)
+	}
+	if err := keychainStore(service, account, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// keychainLookup은 키체인에 저장된 값을 반환합니다. 항목이 없으면 빈 문자열입니다.
+func keychainLookup(service, account string) (string, error) {
+	var out []byte
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		out, err = runKeychainCommand("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		out, err = runKeychainCommand("", "secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("%w: %s 미지원", ErrKeychainUnavailable, runtime.GOOS)
+	}
+	if err != nil {
+		if keychainItemMissing(runtime.GOOS, out, err) {
+			return "", nil
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+			return "", fmt.Errorf("%w: %v: %s", ErrKeychainUnavailable, err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("%w: %v", ErrKeychainUnavailable, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// keychainItemMissing은 키체인 조회 실패가 항목이 없다는 뜻인지 반환합니다.
+// security는 항목이 없으면 44(errSecItemNotFound)로, secret-tool은 아무것도 출력하지 않고 1로 종료합니다.
+// 잠긴 키체인, 거부하거나 취소한 승인 창, 연결할 수 없는 Secret Service는 다른 코드나 stderr로 구분됩니다.
+func keychainItemMissing(goos string, out []byte, err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || len(bytes.TrimSpace(out)) > 0 {
+		return false
+	}
+	switch goos {
+	case "darwin":
+		return exitErr.ExitCode() == 44
+	case "linux":
+		return exitErr.ExitCode() == 1 && len(bytes.TrimSpace(exitErr.Stderr)) == 0
+	default:
+		return false
+	}
+}
+
+// keychainStore는 값을 키체인에 새 항목으로 저장합니다.
+// macOS에서는 -U 없이 추가하므로 그사이 항목이 생겼으면 덮어쓰지 않고 실패합니다.
+func keychainStore(service, account, secret string) error {
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		// -w를 값 없이 마지막에 두면 security가 값과 확인 값을 표준 입력에서 읽으므로 프로세스 인자에 노출되지 않는다.
+		_, err = runKeychainCommand(secret+"\n"+secret+"\n", "security", "add-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		// secret-tool은 값을 표준 입력으로 받으므로 프로세스 인자에 노출되지 않는다.
+		_, err = runKeychainCommand(secret, "secret-tool", "store", "--label=Autopus Bridge state store", "service", service, "account", account)
+	default:
+		return fmt.Errorf("%w: %s 미지원", ErrKeychainUnavailable, runtime.GOOS)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: 키 저장 실패: %v", ErrKeychainUnavailable, err)
+	}
+	return nil
+}
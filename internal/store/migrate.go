@@ -0,0 +1,78 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// schemaVersionKey는 MetaBucket에 저장하는 마지막으로 적용한 마이그레이션 버전의 키입니다.
+const schemaVersionKey = "schema_version"
+
+// Migration은 저장소 데이터 변경 하나입니다 (버킷 이름 변경, 값 형식 변환, 이전 파일 가져오기 등).
+type Migration struct {
+	// Version은 1부터 증가하는 마이그레이션 번호입니다.
+	Version int
+	// Name은 로그와 에러에 표시할 설명입니다.
+	Name string
+	// Apply는 저장소를 변경합니다. 에러를 반환하면 버전을 올리지 않으므로 다음 실행에서 다시 시도합니다.
+	Apply func(s *Store) error
+}
+
+// SchemaVersion은 마지막으로 적용한 마이그레이션 버전을 반환합니다. 적용한 적이 없으면 0입니다.
+func (s *Store) SchemaVersion() int {
+	v, ok := s.Bucket(MetaBucket).Get(schemaVersionKey)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.Atoi(string(v))
+	return n
+}
+
+// Migrate는 아직 적용하지 않은 마이그레이션을 버전 순서대로 적용하고 적용한 개수를 반환합니다.
+// 마이그레이션마다 성공하면 바로 버전을 기록하므로, 중간에 실패해도 앞선 변경은 다시 적용되지 않습니다.
+func (s *Store) Migrate(migrations []Migration) (int, error) {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	current := s.SchemaVersion()
+	applied := 0
+	for _, m := range sorted {
+		if m.Version <= current {
+			continue
+		}
+		if err := m.Apply(s); err != nil {
+			return applied, fmt.Errorf("상태 저장소 마이그레이션 %d(%s) 실패: %w", m.Version, m.Name, err)
+		}
+		if err := s.Bucket(MetaBucket).Put(schemaVersionKey, []byte(strconv.Itoa(m.Version))); err != nil {
+			return applied, err
+		}
+		current = m.Version
+		applied++
+	}
+	return applied, nil
+}
+
+// ImportFile은 하위 시스템이 예전에 쓰던 파일을 저장소로 가져올 때 사용합니다.
+// path가 있으면 내용을 fn에 전달하고, fn이 성공하면 파일을 삭제합니다. 파일이 없으면 false를 반환합니다.
+func ImportFile(path string, fn func(data []byte) error) (bool, error) {
+	if path == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s 읽기 실패: %w", path, err)
+	}
+	if err := fn(data); err != nil {
+		return false, err
+	}
+	if err := os.Remove(path); err != nil {
+		return true, fmt.Errorf("가져온 파일 %s 삭제 실패: %w", path, err)
+	}
+	return true, nil
+}
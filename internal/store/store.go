@@ -0,0 +1,479 @@
+// Package store는 Bridge 하위 시스템(아웃박스, 실행 캐시, 사용량, 트랜스크립트 등)이 함께 쓰는
+// 로컬 상태 저장소입니다. 하위 시스템별 버킷으로 키 공간을 나누고, 선택적으로 AES-256-GCM으로
+// 암호화하여 저장합니다.
+//
+// 저장 형식은 추가 전용(append-only) 로그입니다. 모든 변경은 파일 끝에 레코드로 추가되고,
+// 열 때 로그를 재생하여 메모리 인덱스를 만듭니다. 덮어쓰인 레코드가 쌓이면 Compact가
+// 살아 있는 값만 새 파일로 다시 씁니다. 크래시로 잘린 마지막 레코드는 열 때 버리고,
+// 실행 중 쓰기가 실패하면 쓰기 전 길이로 되돌립니다.
+//
+// bbolt나 SQLite 대신 자체 로그 형식을 쓰는 이유: 저장하는 상태가 작고 한 프로세스만 쓰므로
+// 트랜잭션과 쿼리가 필요 없고, 레코드 단위 AEAD 암호화를 파일 형식에 바로 넣을 수 있으며,
+// cgo나 새 의존성 없이 모든 배포 대상으로 교차 컴파일됩니다.
+//
+// 하나의 저장소 파일은 한 프로세스만 열어야 합니다 (connect 잠금과 같은 워크스페이스 범위를 사용).
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// MetaBucket은 저장소 자체의 메타데이터(스키마 버전 등)를 보관하는 버킷입니다.
+const MetaBucket = "_meta"
+
+const (
+	// recordHeaderSize는 레코드 앞의 길이(4바이트)와 CRC32(4바이트) 크기입니다.
+	recordHeaderSize = 8
+	// maxRecordSize는 레코드 하나의 최대 크기입니다. 손상된 길이 필드로 큰 메모리를 할당하지 않도록 제한합니다.
+	maxRecordSize = 64 << 20
+	// defaultCompactMinGarbage는 자동 압축을 고려하기 시작하는 최소 불필요 레코드 수입니다.
+	defaultCompactMinGarbage = 1000
+)
+
+var (
+	// ErrClosed는 닫힌 저장소를 사용했음을 나타냅니다.
+	ErrClosed = errors.New("상태 저장소가 닫혔습니다")
+	// ErrEncrypted는 암호화된 저장소를 키 없이 열었음을 나타냅니다.
+	ErrEncrypted = errors.New("상태 저장소가 암호화되어 있지만 키가 없습니다")
+	// ErrWrongKey는 저장소를 암호화한 키와 다른 키로 열었음을 나타냅니다.
+	ErrWrongKey = errors.New("상태 저장소 암호화 키가 일치하지 않습니다")
+	// ErrReadOnly는 읽기 전용으로 연 저장소에 쓰려고 했음을 나타냅니다.
+	ErrReadOnly = errors.New("상태 저장소가 읽기 전용으로 열렸습니다")
+	// ErrFailed는 실패한 쓰기를 되돌리지 못해 저장소가 더 이상 쓰기를 받지 않음을 나타냅니다.
+	ErrFailed = errors.New("상태 저장소 쓰기를 되돌리지 못해 더 이상 쓸 수 없습니다")
+)
+
+// appendFile은 레코드 바이트열을 파일 끝에 추가합니다 (테스트에서 부분 쓰기를 흉내 내기 위해 교체).
+var appendFile = func(f *os.File, data []byte) (int, error) {
+	return f.Write(data)
+}
+
+// record는 로그 레코드 하나입니다.
+type record struct {
+	Op     string `json:"op"` // "put" 또는 "del"
+	Bucket string `json:"b"`
+	Key    string `json:"k"`
+	Value  []byte `json:"v,omitempty"`
+}
+
+// Option은 Store 설정을 위한 함수형 옵션입니다.
+type Option func(*Store)
+
+// WithEncryptionKey는 저장소를 key로 AES-256-GCM 암호화합니다. key는 32바이트여야 합니다.
+// 평문 저장소를 키와 함께 열면 즉시 암호화된 형식으로 다시 씁니다.
+func WithEncryptionKey(key []byte) Option {
+	return func(s *Store) {
+		s.key = key
+	}
+}
+
+// WithCompactMinGarbage는 자동 압축을 시작하는 최소 불필요 레코드 수를 설정합니다.
+// 불필요 레코드가 이 값 이상이고 살아 있는 레코드보다 많으면 압축합니다. 0 이하이면 기본값입니다.
+func WithCompactMinGarbage(n int) Option {
+	return func(s *Store) {
+		if n > 0 {
+			s.compactMinGarbage = n
+		}
+	}
+}
+
+// Store는 버킷으로 나뉜 키-값 상태 저장소입니다.
+type Store struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+	key  []byte
+	aead *sealer
+
+	data map[string]map[string][]byte
+	// live는 살아 있는 키 수, records는 파일에 있는 레코드 수입니다. 차이가 압축으로 회수할 레코드입니다.
+	live    int
+	records int
+
+	compactMinGarbage int
+	closed            bool
+	// failed는 실패한 쓰기를 되돌리지 못했는지 나타냅니다. 이후 쓰기는 ErrFailed입니다.
+	failed bool
+	// readOnly는 파일을 수정하지 않고 읽기만 하는 저장소인지 나타냅니다 (OpenReadOnly).
+	readOnly bool
+}
+
+// Open은 path의 저장소를 열거나 새로 만듭니다.
+// 파일 끝의 잘린 레코드는 버리고, 필요하면 압축하거나 암호화 형식으로 다시 씁니다.
+func Open(path string, opts ...Option) (*Store, error) {
+	s := &Store{
+		path:              path,
+		data:              make(map[string]map[string][]byte),
+		compactMinGarbage: defaultCompactMinGarbage,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.key != nil {
+		aead, err := newSealer(s.key)
+		if err != nil {
+			return nil, err
+		}
+		s.aead = aead
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("상태 저장소 디렉토리 생성 실패: %w", err)
+	}
+
+	rewrite, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	if rewrite || s.shouldCompactLocked() {
+		if err := s.compactLocked(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("상태 저장소 열기 실패: %w", err)
+	}
+	s.file = f
+	return s, nil
+}
+
+// OpenReadOnly는 path의 저장소를 파일을 수정하지 않고 읽기 전용으로 엽니다.
+// 다른 프로세스(connect)가 쓰고 있는 저장소를 CLI에서 조회할 때 사용합니다.
+// 파일 끝의 잘린 레코드는 무시만 하고, 파일이 없으면 빈 저장소를 반환합니다. 쓰기는 ErrReadOnly입니다.
+func OpenReadOnly(path string, opts ...Option) (*Store, error) {
+	s := &Store{
+		path:              path,
+		data:              make(map[string]map[string][]byte),
+		compactMinGarbage: defaultCompactMinGarbage,
+		readOnly:          true,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.key != nil {
+		aead, err := newSealer(s.key)
+		if err != nil {
+			return nil, err
+		}
+		s.aead = aead
+	}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load는 파일을 읽어 인덱스를 만듭니다. 파일을 새 형식으로 다시 써야 하면 true를 반환합니다.
+func (s *Store) load() (bool, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("상태 저장소 열기 실패: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	fileSealer, headerSize, err := readHeader(r, s.key)
+	if err == io.EOF {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// 평문 파일을 키와 함께 열었으면 암호화 형식으로 다시 쓴다.
+	rewrite := fileSealer == nil && s.aead != nil
+
+	offset := headerSize
+	for {
+		body, n, err := readRecord(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// 마지막 레코드가 잘렸거나 손상되었으면 그 앞까지만 유지한다.
+			// 읽기 전용이면 쓰는 중인 레코드일 수 있으므로 파일은 그대로 둔다.
+			if s.readOnly {
+				break
+			}
+			if truncErr := os.Truncate(s.path, offset); truncErr != nil {
+				return false, fmt.Errorf("손상된 상태 저장소 복구 실패: %w", truncErr)
+			}
+			break
+		}
+		offset += int64(n)
+		if fileSealer != nil {
+			if body, err = fileSealer.open(body); err != nil {
+				return false, fmt.Errorf("상태 저장소 레코드 복호화 실패: %w", err)
+			}
+		}
+		var rec record
+		if err := json.Unmarshal(body, &rec); err != nil {
+			return false, fmt.Errorf("상태 저장소 레코드 파싱 실패: %w", err)
+		}
+		s.apply(rec)
+		s.records++
+	}
+	return rewrite, nil
+}
+
+// apply는 레코드를 메모리 인덱스에 반영합니다.
+func (s *Store) apply(rec record) {
+	b := s.data[rec.Bucket]
+	_, existed := b[rec.Key]
+	switch rec.Op {
+	case "put":
+		if b == nil {
+			b = make(map[string][]byte)
+			s.data[rec.Bucket] = b
+		}
+		b[rec.Key] = rec.Value
+		if !existed {
+			s.live++
+		}
+	case "del":
+		if existed {
+			delete(b, rec.Key)
+			s.live--
+			if len(b) == 0 {
+				delete(s.data, rec.Bucket)
+			}
+		}
+	}
+}
+
+// readRecord는 레코드 하나를 읽어 본문과 파일에서 차지한 바이트 수를 반환합니다.
+func readRecord(r io.Reader) ([]byte, int, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return nil, 0, io.EOF
+		}
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	size := binary.BigEndian.Uint32(header[:4])
+	if size > maxRecordSize {
+		return nil, 0, errors.New("레코드 크기가 올바르지 않습니다")
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, 0, errors.New("레코드 체크섬 불일치")
+	}
+	return body, recordHeaderSize + int(size), nil
+}
+
+// encodeRecord는 레코드를 (암호화 후) 길이와 체크섬을 붙인 바이트열로 만듭니다.
+func (s *Store) encodeRecord(rec record) ([]byte, error) {
+	body, err := json.Marshal(rec)
+	if err != nil {
+		return nil, fmt.Errorf("상태 저장소 레코드 직렬화 실패: %w", err)
+	}
+	if s.aead != nil {
+		if body, err = s.aead.seal(body); err != nil {
+			return nil, err
+		}
+	}
+	out := make([]byte, recordHeaderSize+len(body))
+	binary.BigEndian.PutUint32(out[:4], uint32(len(body)))
+	binary.BigEndian.PutUint32(out[4:8], crc32.ChecksumIEEE(body))
+	copy(out[recordHeaderSize:], body)
+	return out, nil
+}
+
+// writeLocked는 레코드들을 한 번의 쓰기로 파일 끝에 추가하고 디스크에 동기화한 뒤 인덱스에 반영합니다.
+// 동기화하지 않으면 전원이 꺼졌을 때 이미 성공을 반환한 레코드가 사라질 수 있습니다.
+// 쓰기나 동기화가 실패하면 쓰기 전 길이로 파일을 되돌립니다. 부분 레코드가 남으면 다음에 열 때
+// 그 뒤에 추가한 레코드까지 모두 버려지기 때문입니다. 되돌리지 못하면 이후 쓰기를 거부합니다.
+func (s *Store) writeLocked(recs ...record) error {
+	if s.closed {
+		return ErrClosed
+	}
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if s.failed {
+		return ErrFailed
+	}
+	if len(recs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, rec := range recs {
+		data, err := s.encodeRecord(rec)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	info, err := s.file.Stat()
+	if err != nil {
+		return fmt.Errorf("상태 저장소 쓰기 실패: %w", err)
+	}
+	if _, err := appendFile(s.file, buf.Bytes()); err != nil {
+		return s.rollbackLocked(info.Size(), fmt.Errorf("상태 저장소 쓰기 실패: %w", err))
+	}
+	if err := s.file.Sync(); err != nil {
+		return s.rollbackLocked(info.Size(), fmt.Errorf("상태 저장소 동기화 실패: %w", err))
+	}
+	for _, rec := range recs {
+		s.apply(rec)
+		s.records++
+	}
+	if s.shouldCompactLocked() {
+		return s.compactLocked()
+	}
+	return nil
+}
+
+// rollbackLocked는 실패한 쓰기의 부분 레코드를 지우려고 파일을 size로 되돌리고 cause를 반환합니다.
+// 되돌리지 못하면 저장소를 실패 상태로 표시합니다.
+func (s *Store) rollbackLocked(size int64, cause error) error {
+	if err := s.file.Truncate(size); err != nil {
+		s.failed = true
+		return fmt.Errorf("%w (%w: %v)", cause, ErrFailed, err)
+	}
+	if err := s.file.Sync(); err != nil {
+		s.failed = true
+		return fmt.Errorf("%w (%w: %v)", cause, ErrFailed, err)
+	}
+	return cause
+}
+
+// shouldCompactLocked는 불필요 레코드가 충분히 쌓였는지 반환합니다.
+func (s *Store) shouldCompactLocked() bool {
+	garbage := s.records - s.live
+	return garbage >= s.compactMinGarbage && garbage > s.live
+}
+
+// Compact는 살아 있는 값만 새 파일로 다시 써서 덮어쓰이거나 삭제된 레코드를 회수합니다.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	return s.compactLocked()
+}
+
+// compactLocked는 임시 파일에 현재 상태를 쓰고 원자적으로 교체합니다.
+func (s *Store) compactLocked() error {
+	tmp := s.path + ".compact"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("상태 저장소 압축 파일 생성 실패: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	writeErr := func() error {
+		header, err := encodeHeader(s.aead)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(header); err != nil {
+			return err
+		}
+		for _, bucket := range sortedKeys(s.data) {
+			for _, key := range sortedKeys(s.data[bucket]) {
+				data, err := s.encodeRecord(record{Op: "put", Bucket: bucket, Key: key, Value: s.data[bucket][key]})
+				if err != nil {
+					return err
+				}
+				if _, err := w.Write(data); err != nil {
+					return err
+				}
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		return f.Sync()
+	}()
+	if closeErr := f.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("상태 저장소 압축 실패: %w", writeErr)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("상태 저장소 압축 파일 교체 실패: %w", err)
+	}
+
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		s.closed = true
+		return fmt.Errorf("상태 저장소 다시 열기 실패: %w", err)
+	}
+	s.records = s.live
+	// 새 파일에는 부분 레코드가 없으므로 다시 쓸 수 있다.
+	s.failed = false
+	return nil
+}
+
+// Encrypted는 저장소가 암호화되어 있는지 반환합니다.
+func (s *Store) Encrypted() bool {
+	return s.aead != nil
+}
+
+// Path는 저장소 파일 경로를 반환합니다.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Buckets는 값이 있는 버킷 이름을 정렬하여 반환합니다.
+func (s *Store) Buckets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedKeys(s.data)
+}
+
+// Bucket은 name 버킷 핸들을 반환합니다. 버킷은 첫 Put에서 만들어집니다.
+func (s *Store) Bucket(name string) *Bucket {
+	return &Bucket{store: s, name: name}
+}
+
+// Close는 저장소 파일을 닫습니다. 여러 번 호출해도 안전합니다.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// sortedKeys는 맵의 키를 정렬하여 반환합니다.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
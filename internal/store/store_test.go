@@ -0,0 +1,527 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testKey(seed string) []byte {
+	sum := sha256.Sum256([]byte(seed))
+	return sum[:]
+}
+
+func openTestStore(t *testing.T, path string, opts ...Option) *Store {
+	t.Helper()
+	s, err := Open(path, opts...)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestStore_PersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s := openTestStore(t, path)
+
+	outbox := s.Bucket("outbox")
+	if err := outbox.Put("a", []byte("1")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := outbox.Put("b", []byte("2")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := outbox.Delete("a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := s.Bucket("cache").PutJSON("k", map[string]int{"n": 3}); err != nil {
+		t.Fatalf("PutJSON() error = %v", err)
+	}
+	_ = s.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("파일 권한 = %v, want 0600", perm)
+	}
+
+	reopened := openTestStore(t, path)
+	if _, ok := reopened.Bucket("outbox").Get("a"); ok {
+		t.Error("삭제한 키가 남아 있음")
+	}
+	if v, ok := reopened.Bucket("outbox").Get("b"); !ok || string(v) != "2" {
+		t.Errorf("Get(b) = %q, %v", v, ok)
+	}
+	var got map[string]int
+	if ok, err := reopened.Bucket("cache").GetJSON("k", &got); !ok || err != nil || got["n"] != 3 {
+		t.Errorf("GetJSON(k) = %v, %v, %v", got, ok, err)
+	}
+	if buckets := reopened.Buckets(); strings.Join(buckets, ",") != "cache,outbox" {
+		t.Errorf("Buckets() = %v", buckets)
+	}
+}
+
+func TestBucket_ReplaceWritesOnlyChanges(t *testing.T) {
+	s := openTestStore(t, filepath.Join(t.TempDir(), "state.db"))
+	b := s.Bucket("outbox")
+
+	if err := b.Replace(map[string][]byte{"a": []byte("1"), "b": []byte("2")}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if err := b.Replace(map[string][]byte{"b": []byte("2"), "c": []byte("3")}); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	// 첫 Replace: put a, put b / 두 번째: del a, put c (b는 그대로)
+	if s.records != 4 {
+		t.Errorf("records = %d, want 4", s.records)
+	}
+
+	var keys []string
+	_ = b.ForEach(func(key string, _ []byte) error {
+		keys = append(keys, key)
+		return nil
+	})
+	if strings.Join(keys, ",") != "b,c" {
+		t.Errorf("keys = %v, want [b c]", keys)
+	}
+}
+
+func TestStore_CompactReclaimsGarbage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s := openTestStore(t, path, WithCompactMinGarbage(10))
+	b := s.Bucket("usage")
+
+	for i := 0; i < 10; i++ {
+		if err := b.Put("counter", bytes.Repeat([]byte("x"), 100)); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	// 불필요 레코드 9개: 아직 최소값 미만
+	if s.records != 10 {
+		t.Fatalf("records = %d, want 10", s.records)
+	}
+	if err := b.Put("counter", []byte("final")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	// 불필요 레코드 10개 > 살아 있는 1개: 자동 압축
+	if s.records != 1 {
+		t.Errorf("자동 압축 후 records = %d, want 1", s.records)
+	}
+	info, _ := os.Stat(path)
+	if info.Size() > 200 {
+		t.Errorf("압축 후 파일 크기 = %d, 너무 큼", info.Size())
+	}
+
+	// 압축 후에도 계속 추가 가능
+	if err := b.Put("other", []byte("v")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	_ = s.Close()
+
+	reopened := openTestStore(t, path)
+	if v, _ := reopened.Bucket("usage").Get("counter"); string(v) != "final" {
+		t.Errorf("Get(counter) = %q, want final", v)
+	}
+	if reopened.Bucket("usage").Len() != 2 {
+		t.Errorf("Len() = %d, want 2", reopened.Bucket("usage").Len())
+	}
+}
+
+func TestStore_RecoversTruncatedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s := openTestStore(t, path)
+	b := s.Bucket("outbox")
+	_ = b.Put("a", []byte("1"))
+	_ = b.Put("b", []byte("2"))
+	_ = s.Close()
+
+	// 마지막 레코드 중간에서 잘린 상황 (쓰기 중 크래시)
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatalf("Truncate() error = %v", err)
+	}
+
+	reopened := openTestStore(t, path)
+	rb := reopened.Bucket("outbox")
+	if _, ok := rb.Get("a"); !ok {
+		t.Error("온전한 레코드가 사라짐")
+	}
+	if _, ok := rb.Get("b"); ok {
+		t.Error("잘린 레코드가 복구됨")
+	}
+	// 잘린 꼬리를 버렸으므로 이어서 쓴 레코드도 다시 열 때 읽혀야 한다.
+	if err := rb.Put("c", []byte("3")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	_ = reopened.Close()
+
+	again := openTestStore(t, path)
+	if v, ok := again.Bucket("outbox").Get("c"); !ok || string(v) != "3" {
+		t.Errorf("Get(c) = %q, %v", v, ok)
+	}
+}
+
+func TestStore_PartialWriteIsRolledBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s := openTestStore(t, path)
+	b := s.Bucket("outbox")
+	if err := b.Put("a", []byte("1")); err != nil {
+		t.Fatalf("Put(a) error = %v", err)
+	}
+
+	// 레코드 절반만 쓰고 실패하는 상황 (디스크 가득 참 등)
+	orig := appendFile
+	appendFile = func(f *os.File, data []byte) (int, error) {
+		n, _ := f.Write(data[:len(data)/2])
+		return n, errors.New("no space left on device")
+	}
+	err := b.Put("b", []byte("2"))
+	appendFile = orig
+	if err == nil {
+		t.Fatal("부분 쓰기에 에러가 없음")
+	}
+	if _, ok := b.Get("b"); ok {
+		t.Error("실패한 레코드가 인덱스에 반영됨")
+	}
+
+	// 부분 레코드를 되돌렸으므로 이어서 쓴 레코드는 다시 열 때 읽혀야 한다.
+	if err := b.Put("c", []byte("3")); err != nil {
+		t.Fatalf("Put(c) error = %v", err)
+	}
+	_ = s.Close()
+
+	reopened := openTestStore(t, path)
+	rb := reopened.Bucket("outbox")
+	if _, ok := rb.Get("a"); !ok {
+		t.Error("Get(a) 레코드가 사라짐")
+	}
+	if v, ok := rb.Get("c"); !ok || string(v) != "3" {
+		t.Errorf("Get(c) = %q, %v; 실패 뒤에 쓴 레코드가 사라짐", v, ok)
+	}
+}
+
+func TestStore_Encryption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	key := testKey("secret")
+
+	s := openTestStore(t, path, WithEncryptionKey(key))
+	if !s.Encrypted() {
+		t.Fatal("Encrypted() = false")
+	}
+	if err := s.Bucket("transcript").Put("exec-1", []byte("very-sensitive-output")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	_ = s.Close()
+
+	raw, _ := os.ReadFile(path)
+	if bytes.Contains(raw, []byte("very-sensitive-output")) || bytes.Contains(raw, []byte("transcript")) {
+		t.Error("암호화된 파일에 평문이 보임")
+	}
+
+	if _, err := Open(path); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("키 없이 Open() error = %v, want ErrEncrypted", err)
+	}
+	if _, err := Open(path, WithEncryptionKey(testKey("other"))); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("다른 키로 Open() error = %v, want ErrWrongKey", err)
+	}
+	if _, err := Open(path, WithEncryptionKey([]byte("short"))); err == nil {
+		t.Error("짧은 키로 Open()이 성공함")
+	}
+
+	reopened := openTestStore(t, path, WithEncryptionKey(key))
+	if v, ok := reopened.Bucket("transcript").Get("exec-1"); !ok || string(v) != "very-sensitive-output" {
+		t.Errorf("Get() = %q, %v", v, ok)
+	}
+}
+
+func TestStore_UpgradesPlainFileToEncrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	plain := openTestStore(t, path)
+	_ = plain.Bucket("outbox").Put("a", []byte("plain-value"))
+	_ = plain.Close()
+
+	key := testKey("secret")
+	s := openTestStore(t, path, WithEncryptionKey(key))
+	if v, ok := s.Bucket("outbox").Get("a"); !ok || string(v) != "plain-value" {
+		t.Errorf("Get() = %q, %v", v, ok)
+	}
+	_ = s.Close()
+
+	raw, _ := os.ReadFile(path)
+	if bytes.Contains(raw, []byte("plain-value")) {
+		t.Error("평문 파일이 암호화 형식으로 다시 쓰이지 않음")
+	}
+	if _, err := Open(path); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("키 없이 Open() error = %v, want ErrEncrypted", err)
+	}
+}
+
+func TestStore_ClosedStoreRejectsWrites(t *testing.T) {
+	s := openTestStore(t, filepath.Join(t.TempDir(), "state.db"))
+	_ = s.Close()
+	if err := s.Bucket("x").Put("k", []byte("v")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Put() error = %v, want ErrClosed", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("두 번째 Close() error = %v", err)
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	// 파일이 없으면 만들지 않고 빈 저장소를 반환한다.
+	empty, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly() error = %v", err)
+	}
+	if empty.Bucket("history").Len() != 0 {
+		t.Error("없는 파일인데 값이 있음")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("읽기 전용 열기가 파일을 만들었습니다: %v", err)
+	}
+
+	// 다른 프로세스가 쓰는 중인 저장소 (마지막 레코드가 아직 다 쓰이지 않음)
+	writer := openTestStore(t, path)
+	_ = writer.Bucket("history").Put("a", []byte("1"))
+	_ = writer.Bucket("history").Put("b", []byte("2"))
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatalf("Truncate() error = %v", err)
+	}
+
+	ro, err := OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly() error = %v", err)
+	}
+	defer ro.Close()
+	if v, ok := ro.Bucket("history").Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v", v, ok)
+	}
+	if after, _ := os.Stat(path); after.Size() != info.Size()-3 {
+		t.Errorf("읽기 전용 열기가 파일을 수정했습니다: %d -> %d", info.Size()-3, after.Size())
+	}
+	if err := ro.Bucket("history").Put("c", []byte("3")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Put() error = %v, want ErrReadOnly", err)
+	}
+	if err := ro.Compact(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Compact() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestStore_Migrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	s := openTestStore(t, path)
+	_ = s.Bucket("old_cache").Put("k", []byte("v"))
+
+	var calls []int
+	migrations := []Migration{
+		{Version: 2, Name: "drop old cache", Apply: func(s *Store) error {
+			calls = append(calls, 2)
+			return s.Bucket("old_cache").Delete("k")
+		}},
+		{Version: 1, Name: "rename cache", Apply: func(s *Store) error {
+			calls = append(calls, 1)
+			v, _ := s.Bucket("old_cache").Get("k")
+			return s.Bucket("cache").Put("k", v)
+		}},
+	}
+
+	n, err := s.Migrate(migrations)
+	if err != nil || n != 2 {
+		t.Fatalf("Migrate() = %d, %v", n, err)
+	}
+	if len(calls) != 2 || calls[0] != 1 || calls[1] != 2 {
+		t.Errorf("적용 순서 = %v, want [1 2]", calls)
+	}
+	if s.SchemaVersion() != 2 {
+		t.Errorf("SchemaVersion() = %d, want 2", s.SchemaVersion())
+	}
+	_ = s.Close()
+
+	reopened := openTestStore(t, path)
+	calls = nil
+	failing := append(migrations, Migration{Version: 3, Name: "broken", Apply: func(*Store) error {
+		return errors.New("boom")
+	}})
+	n, err = reopened.Migrate(failing)
+	if err == nil || n != 0 {
+		t.Errorf("Migrate() = %d, %v; want 0, error", n, err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("이미 적용한 마이그레이션이 다시 실행됨: %v", calls)
+	}
+	if reopened.SchemaVersion() != 2 {
+		t.Errorf("실패한 마이그레이션 후 SchemaVersion() = %d, want 2", reopened.SchemaVersion())
+	}
+}
+
+func TestImportFile(t *testing.T) {
+	dir := t.TempDir()
+	if ok, err := ImportFile(filepath.Join(dir, "missing.json"), nil); ok || err != nil {
+		t.Errorf("없는 파일 ImportFile() = %v, %v", ok, err)
+	}
+
+	path := filepath.Join(dir, "legacy.json")
+	_ = os.WriteFile(path, []byte(`{"x":1}`), 0600)
+
+	if _, err := ImportFile(path, func([]byte) error { return errors.New("bad") }); err == nil {
+		t.Error("fn 실패가 전달되지 않음")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("fn이 실패했는데 파일이 삭제됨")
+	}
+
+	var got string
+	ok, err := ImportFile(path, func(data []byte) error {
+		got = string(data)
+		return nil
+	})
+	if !ok || err != nil || got != `{"x":1}` {
+		t.Errorf("ImportFile() = %v, %v, data %q", ok, err, got)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("가져온 파일이 삭제되지 않음")
+	}
+}
+
+func TestKeyFromEnv(t *testing.T) {
+	t.Setenv("AUTOPUS_TEST_STATE_KEY", "passphrase")
+	key, err := KeyFromEnv("AUTOPUS_TEST_STATE_KEY")
+	if err != nil || len(key) != KeySize {
+		t.Fatalf("KeyFromEnv() = %d bytes, %v", len(key), err)
+	}
+	t.Setenv("AUTOPUS_TEST_STATE_KEY", "")
+	if _, err := KeyFromEnv("AUTOPUS_TEST_STATE_KEY"); err == nil {
+		t.Error("빈 환경 변수에 에러가 없음")
+	}
+}
+
+func TestKeychainKey_CreatesThenReuses(t *testing.T) {
+	secrets := map[string]string{}
+	var stored []string
+	orig := runKeychainCommand
+	t.Cleanup(func() { runKeychainCommand = orig })
+	runKeychainCommand = func(stdin string, name string, args ...string) ([]byte, error) {
+		joined := name + " " + strings.Join(args, " ")
+		switch {
+		case strings.Contains(joined, "find-generic-password"), strings.Contains(joined, "secret-tool lookup"):
+			if v, ok := secrets["key"]; ok {
+				return []byte(v + "\n"), nil
+			}
+			return nil, keychainNotFoundError(t)
+		case strings.Contains(joined, "add-generic-password"):
+			value, confirm, _ := strings.Cut(strings.TrimSuffix(stdin, "\n"), "\n")
+			if args[len(args)-1] != "-w" || value != confirm || strings.Contains(joined, value) {
+				t.Errorf("security 인자에 키가 노출되거나 표준 입력 형식이 잘못됨: %s", joined)
+			}
+			if slices.Contains(args, "-U") {
+				t.Errorf("기존 항목을 덮어쓰는 -U를 사용함: %s", joined)
+			}
+			secrets["key"] = value
+			stored = append(stored, joined)
+			return nil, nil
+		case strings.Contains(joined, "secret-tool store"):
+			if strings.Contains(joined, stdin) {
+				t.Error("secret-tool 인자에 키가 노출됨")
+			}
+			secrets["key"] = stdin
+			stored = append(stored, joined)
+			return nil, nil
+		}
+		t.Fatalf("예상하지 못한 명령: %s", joined)
+		return nil, nil
+	}
+
+	first, err := KeychainKey(KeychainService, KeychainAccount)
+	if errors.Is(err, ErrKeychainUnavailable) {
+		t.Skipf("이 OS에서는 키체인 미지원: %v", err)
+	}
+	if err != nil || len(first) != KeySize {
+		t.Fatalf("KeychainKey() = %d bytes, %v", len(first), err)
+	}
+	second, err := KeychainKey(KeychainService, KeychainAccount)
+	if err != nil || !bytes.Equal(first, second) {
+		t.Errorf("두 번째 KeychainKey()가 다른 키를 반환함: %v", err)
+	}
+	if len(stored) != 1 {
+		t.Errorf("키 저장 횟수 = %d, want 1", len(stored))
+	}
+
+	secrets["key"] = base64.StdEncoding.EncodeToString([]byte("short"))
+	if _, err := KeychainKey(KeychainService, KeychainAccount); err == nil {
+		t.Error("잘못된 형식의 키에 에러가 없음")
+	}
+}
+
+// exitError는 code로 종료하고 stderr를 출력한 실제 프로세스의 *exec.ExitError를 반환합니다.
+func exitError(t *testing.T, code int, stderr string) error {
+	t.Helper()
+	_, err := exec.Command("sh", "-c", fmt.Sprintf("printf %%s '%s' >&2; exit %d", stderr, code)).Output()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("종료 에러를 만들지 못함: %v", err)
+	}
+	return err
+}
+
+// keychainNotFoundError는 현재 OS의 키체인 도구가 항목이 없을 때 반환하는 에러입니다.
+func keychainNotFoundError(t *testing.T) error {
+	if runtime.GOOS == "darwin" {
+		return exitError(t, 44, "")
+	}
+	return exitError(t, 1, "")
+}
+
+func TestKeychainItemMissing(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		out  string
+		err  error
+		want bool
+	}{
+		{name: "security 항목 없음", goos: "darwin", err: exitError(t, 44, "security: SecKeychainSearchCopyNext: The specified item could not be found in the keychain."), want: true},
+		{name: "security 키체인 잠김", goos: "darwin", err: exitError(t, 36, "")},
+		{name: "security 승인 취소", goos: "darwin", err: exitError(t, 128, "")},
+		{name: "secret-tool 항목 없음", goos: "linux", err: exitError(t, 1, ""), want: true},
+		{name: "secret-tool Secret Service 연결 실패", goos: "linux", err: exitError(t, 1, "Cannot autolaunch D-Bus without X11 $DISPLAY")},
+		{name: "출력이 있는 실패", goos: "linux", out: "partial", err: exitError(t, 1, "")},
+		{name: "종료 에러가 아님", goos: "linux", err: exec.ErrNotFound},
+	}
+	for _, tt := range tests {
+		if got := keychainItemMissing(tt.goos, []byte(tt.out), tt.err); got != tt.want {
+			t.Errorf("%s: keychainItemMissing() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKeychainKey_UnavailableDoesNotOverwrite(t *testing.T) {
+	orig := runKeychainCommand
+	t.Cleanup(func() { runKeychainCommand = orig })
+	runKeychainCommand = func(stdin string, name string, args ...string) ([]byte, error) {
+		joined := name + " " + strings.Join(args, " ")
+		if strings.Contains(joined, "find-generic-password") || strings.Contains(joined, "secret-tool lookup") {
+			// 잠긴 키체인 또는 연결할 수 없는 Secret Service
+			return nil, exitError(t, 1, "locked")
+		}
+		t.Errorf("키체인을 읽지 못했는데 키를 저장함: %s", joined)
+		return nil, nil
+	}
+	if _, err := KeychainKey(KeychainService, KeychainAccount); !errors.Is(err, ErrKeychainUnavailable) {
+		t.Errorf("KeychainKey() error = %v, want ErrKeychainUnavailable", err)
+	}
+}
@@ -0,0 +1,236 @@
+// Package tasktemplate는 이름으로 반복 실행하는 로컬 작업 템플릿을 제공합니다.
+// 설정 파일의 templates 항목과 템플릿 디렉토리의 YAML 파일을 읽고,
+// 프롬프트의 {{변수}} 자리표시자를 실행 시 전달한 값으로 치환합니다.
+package tasktemplate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotFound는 이름에 해당하는 템플릿이 없음을 나타냅니다.
+var ErrNotFound = errors.New("템플릿을 찾을 수 없습니다")
+
+// SourceConfig는 설정 파일에 정의된 템플릿의 출처 표시입니다.
+const SourceConfig = "config"
+
+// placeholderPattern은 {{ env }} 형태의 자리표시자입니다.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// namePattern은 템플릿 이름 규칙입니다 (영문자/숫자로 시작, 영문자/숫자/-/_, 최대 64자).
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// Template은 에이전트, 프롬프트, 도구, 모델을 묶은 작업 템플릿입니다.
+type Template struct {
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description,omitempty" yaml:"description"`
+	AgentID     string            `json:"agent_id,omitempty" yaml:"agent_id"`
+	Agent       string            `json:"agent,omitempty" yaml:"agent"`
+	Prompt      string            `json:"prompt" yaml:"prompt"`
+	Tools       []string          `json:"tools,omitempty" yaml:"tools"`
+	Model       string            `json:"model,omitempty" yaml:"model"`
+	Provider    string            `json:"provider,omitempty" yaml:"provider"`
+	Vars        map[string]string `json:"vars,omitempty" yaml:"vars"`
+	// Source는 템플릿을 읽은 곳입니다 ("config" 또는 파일 경로).
+	Source string `json:"source" yaml:"-"`
+}
+
+// Placeholders는 프롬프트에 나오는 자리표시자 이름을 처음 나온 순서대로 반환합니다.
+func (t Template) Placeholders() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(t.Prompt, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// Render는 vars와 Vars 기본값으로 자리표시자를 치환한 프롬프트를 반환합니다.
+// vars가 기본값보다 우선합니다. 값이 없는 자리표시자나 프롬프트에 없는 변수가 있으면 에러를 반환합니다.
+func (t Template) Render(vars map[string]string) (string, error) {
+	placeholders := t.Placeholders()
+	known := make(map[string]bool, len(placeholders))
+	for _, name := range placeholders {
+		known[name] = true
+	}
+
+	var unknown []string
+	for name := range vars {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", fmt.Errorf("템플릿 %s에 없는 변수입니다: %s (사용 가능: %s)",
+			t.Name, strings.Join(unknown, ", "), strings.Join(placeholders, ", "))
+	}
+
+	values := make(map[string]string, len(placeholders))
+	var missing []string
+	for _, name := range placeholders {
+		if v, ok := vars[name]; ok {
+			values[name] = v
+		} else if v, ok := t.Vars[name]; ok {
+			values[name] = v
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("템플릿 %s의 변수 값이 없습니다: %s (--var 이름=값으로 지정하세요)",
+			t.Name, strings.Join(missing, ", "))
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(t.Prompt, func(m string) string {
+		return values[placeholderPattern.FindStringSubmatch(m)[1]]
+	}), nil
+}
+
+// validate는 템플릿 이름과 프롬프트를 검사합니다.
+func (t Template) validate() error {
+	if !namePattern.MatchString(t.Name) {
+		return fmt.Errorf("유효하지 않은 템플릿 이름: %q", t.Name)
+	}
+	if strings.TrimSpace(t.Prompt) == "" {
+		return fmt.Errorf("템플릿 %s의 prompt가 비어 있습니다", t.Name)
+	}
+	return nil
+}
+
+// Registry는 이름으로 조회하는 템플릿 모음입니다.
+type Registry struct {
+	templates map[string]Template
+}
+
+// Load는 설정의 템플릿과 dir의 템플릿 파일(*.yaml, *.yml)을 읽어 Registry를 생성합니다.
+// 파일에 name이 없으면 확장자를 뺀 파일 이름을 사용합니다.
+// dir이 비어 있거나 존재하지 않으면 설정의 템플릿만 사용합니다.
+// 이름이 중복되거나 잘못된 템플릿이 있으면 에러를 반환합니다.
+func Load(cfgs []config.TemplateConfig, dir string) (*Registry, error) {
+	r := &Registry{templates: make(map[string]Template)}
+	for _, c := range cfgs {
+		if err := r.add(fromConfig(c)); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir == "" {
+		return r, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("템플릿 디렉토리 읽기 실패: %w", err)
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		t, err := loadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := r.add(t); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// fromConfig는 설정 항목을 템플릿으로 변환합니다.
+func fromConfig(c config.TemplateConfig) Template {
+	return Template{
+		Name:        c.Name,
+		Description: c.Description,
+		AgentID:     c.AgentID,
+		Agent:       c.Agent,
+		Prompt:      c.Prompt,
+		Tools:       c.Tools,
+		Model:       c.Model,
+		Provider:    c.Provider,
+		Vars:        c.Vars,
+		Source:      SourceConfig,
+	}
+}
+
+// loadFile은 템플릿 파일 하나를 읽습니다.
+func loadFile(path string) (Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Template{}, fmt.Errorf("템플릿 파일 읽기 실패: %w", err)
+	}
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return Template{}, fmt.Errorf("템플릿 파일 파싱 실패 (%s): %w", path, err)
+	}
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	t.Source = path
+	return t, nil
+}
+
+// add는 템플릿을 검사하여 등록합니다.
+func (r *Registry) add(t Template) error {
+	if err := t.validate(); err != nil {
+		return fmt.Errorf("%w (%s)", err, t.Source)
+	}
+	if existing, ok := r.templates[t.Name]; ok {
+		return fmt.Errorf("템플릿 이름이 중복됩니다: %s (%s, %s)", t.Name, existing.Source, t.Source)
+	}
+	r.templates[t.Name] = t
+	return nil
+}
+
+// Get은 이름에 해당하는 템플릿을 반환합니다. 없으면 ErrNotFound를 감싼 에러를 반환합니다.
+func (r *Registry) Get(name string) (Template, error) {
+	if r != nil {
+		if t, ok := r.templates[name]; ok {
+			return t, nil
+		}
+	}
+	return Template{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// List는 이름순으로 정렬한 템플릿 목록을 반환합니다.
+func (r *Registry) List() []Template {
+	if r == nil {
+		return nil
+	}
+	list := make([]Template, 0, len(r.templates))
+	for _, t := range r.templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// ParseVars는 "이름=값" 형식의 --var 인자를 맵으로 변환합니다.
+// 값에는 '='가 포함될 수 있으며, 같은 이름을 여러 번 지정하면 마지막 값을 사용합니다.
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("잘못된 변수 형식: %q (이름=값 형식이어야 합니다)", pair)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
@@ -0,0 +1,121 @@
+package tasktemplate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+)
+
+// TestTemplate_Render는 자리표시자 치환, 기본값, 누락/알 수 없는 변수 처리를 테스트합니다.
+func TestTemplate_Render(t *testing.T) {
+	tmpl := Template{
+		Name:   "deploy-check",
+		Prompt: "{{env}} 환경의 {{ service }} 배포 상태를 확인하세요. 대상: {{env}}",
+		Vars:   map[string]string{"service": "api"},
+	}
+
+	if got := tmpl.Placeholders(); !reflect.DeepEqual(got, []string{"env", "service"}) {
+		t.Errorf("Placeholders() = %v", got)
+	}
+
+	got, err := tmpl.Render(map[string]string{"env": "prod"})
+	if err != nil {
+		t.Fatalf("Render 에러: %v", err)
+	}
+	if want := "prod 환경의 api 배포 상태를 확인하세요. 대상: prod"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got, _ = tmpl.Render(map[string]string{"env": "stg", "service": "web"})
+	if !strings.Contains(got, "web 배포") {
+		t.Errorf("전달한 값이 기본값보다 우선해야 합니다: %q", got)
+	}
+
+	if _, err := tmpl.Render(nil); err == nil || !strings.Contains(err.Error(), "env") {
+		t.Errorf("값이 없는 변수는 에러여야 합니다: %v", err)
+	}
+	if _, err := tmpl.Render(map[string]string{"env": "prod", "region": "kr"}); err == nil || !strings.Contains(err.Error(), "region") {
+		t.Errorf("프롬프트에 없는 변수는 에러여야 합니다: %v", err)
+	}
+}
+
+// TestLoad는 설정과 디렉토리의 템플릿을 함께 읽는지 테스트합니다.
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "nightly-report.yaml", "description: 야간 리포트\nagent: reporter\nprompt: \"{{date}} 리포트\"\ntools: [search]\n")
+	writeTemplate(t, dir, "notes.txt", "무시되는 파일")
+
+	reg, err := Load([]config.TemplateConfig{{Name: "deploy-check", AgentID: "agent-1", Prompt: "{{env}} 배포 확인", Model: "sonnet"}}, dir)
+	if err != nil {
+		t.Fatalf("Load 에러: %v", err)
+	}
+
+	list := reg.List()
+	if len(list) != 2 || list[0].Name != "deploy-check" || list[1].Name != "nightly-report" {
+		t.Fatalf("List() = %+v", list)
+	}
+	if list[0].Source != SourceConfig || list[1].Source != filepath.Join(dir, "nightly-report.yaml") {
+		t.Errorf("Source = %q, %q", list[0].Source, list[1].Source)
+	}
+	if list[1].Agent != "reporter" || !reflect.DeepEqual(list[1].Tools, []string{"search"}) {
+		t.Errorf("파일 템플릿 = %+v", list[1])
+	}
+
+	if _, err := reg.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) 에러 = %v, want ErrNotFound", err)
+	}
+
+	if reg, err := Load(nil, filepath.Join(dir, "none")); err != nil || len(reg.List()) != 0 {
+		t.Errorf("없는 디렉토리는 빈 목록이어야 합니다: %v", err)
+	}
+}
+
+// TestLoad_Invalid는 중복 이름, 빈 프롬프트, 잘못된 이름을 거부하는지 테스트합니다.
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "deploy-check.yml", "prompt: 중복\n")
+
+	tests := []struct {
+		name string
+		cfgs []config.TemplateConfig
+		dir  string
+	}{
+		{"중복 이름", []config.TemplateConfig{{Name: "deploy-check", Prompt: "확인"}}, dir},
+		{"빈 프롬프트", []config.TemplateConfig{{Name: "empty"}}, ""},
+		{"잘못된 이름", []config.TemplateConfig{{Name: "bad name", Prompt: "확인"}}, ""},
+	}
+	for _, tt := range tests {
+		if _, err := Load(tt.cfgs, tt.dir); err == nil {
+			t.Errorf("%s: 에러가 발생해야 합니다", tt.name)
+		}
+	}
+}
+
+// TestParseVars는 --var 인자 파싱을 테스트합니다.
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"env=prod", "query=a=b", "env=stg"})
+	if err != nil {
+		t.Fatalf("ParseVars 에러: %v", err)
+	}
+	if want := map[string]string{"env": "stg", "query": "a=b"}; !reflect.DeepEqual(vars, want) {
+		t.Errorf("ParseVars() = %v, want %v", vars, want)
+	}
+
+	for _, bad := range []string{"env", "=prod"} {
+		if _, err := ParseVars([]string{bad}); err == nil {
+			t.Errorf("%q: 에러가 발생해야 합니다", bad)
+		}
+	}
+}
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,348 @@
+// Package teamconfig는 팀원이 같은 Bridge 동작을 공유하기 위한 서명된 팀 설정 번들을 제공합니다.
+// 번들은 고정 도구 버전, 보안 정책, 작업 템플릿, MCP 도구 권한을 담고 ed25519 키로 서명됩니다.
+// init-team 명령이 번들을 만들고, up --team-config가 검증한 뒤 설정 파일에 반영합니다.
+package teamconfig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+)
+
+// Format은 번들 형식 식별자입니다.
+const Format = "autopus-team-config/v1"
+
+// ErrKeyMismatch는 번들 서명 키가 신뢰하는 키와 다름을 나타냅니다.
+var ErrKeyMismatch = errors.New("팀 설정 서명 키가 신뢰하는 키와 다릅니다")
+
+// PolicyKeys는 번들로 공유할 수 있는 정책 설정 섹션입니다.
+// 인증 정보나 서버 주소처럼 개인별로 달라야 하는 설정은 공유하지 않습니다.
+var PolicyKeys = []string{
+	"security.sandbox",
+	"security.action_approval",
+	"security.provider_sandbox",
+	"security.redaction",
+}
+
+// Bundle은 팀 설정 번들의 내용입니다.
+type Bundle struct {
+	// Format은 번들 형식 식별자입니다 (Format 상수).
+	Format string `json:"format"`
+	// Team은 팀 이름입니다.
+	Team string `json:"team"`
+	// CreatedAt은 번들 생성 시각입니다.
+	CreatedAt time.Time `json:"created_at"`
+	// CreatedBy는 번들을 만든 사용자입니다 (선택).
+	CreatedBy string `json:"created_by,omitempty"`
+	// ToolVersions는 팀이 고정한 도구 버전입니다 (도구 이름 → 버전 문자열).
+	ToolVersions map[string]string `json:"tool_versions,omitempty"`
+	// Policy는 PolicyKeys 섹션별 설정 값입니다.
+	Policy map[string]any `json:"policy,omitempty"`
+	// Templates는 팀이 공유하는 작업 템플릿입니다.
+	Templates []tasktemplate.Template `json:"templates,omitempty"`
+	// MCPToolPermissions는 MCP 도구별 사용 권한입니다 (mcp_server.tools).
+	MCPToolPermissions mcpserver.ToolPermissions `json:"mcp_tool_permissions,omitempty"`
+}
+
+// signedFile은 번들 파일 형식입니다. 서명은 공백을 제거한 payload에 대해 계산하므로
+// 파일을 다시 들여쓰기해도 검증에 영향이 없습니다.
+type signedFile struct {
+	Payload   json.RawMessage `json:"payload"`
+	PublicKey string          `json:"public_key"`
+	Signature string          `json:"signature"`
+}
+
+// Validate는 번들 형식, 팀 이름, 정책 키, 템플릿, MCP 도구 권한을 검사합니다.
+func (b *Bundle) Validate() error {
+	if b.Format != Format {
+		return fmt.Errorf("지원하지 않는 팀 설정 형식입니다: %q (지원: %s)", b.Format, Format)
+	}
+	if strings.TrimSpace(b.Team) == "" {
+		return errors.New("팀 이름이 비어 있습니다")
+	}
+	for key := range b.Policy {
+		if !isPolicyKey(key) {
+			return fmt.Errorf("공유할 수 없는 정책 키입니다: %s (허용: %s)", key, strings.Join(PolicyKeys, ", "))
+		}
+	}
+	if len(b.Templates) > 0 {
+		if _, err := tasktemplate.Load(templateConfigs(b.Templates), ""); err != nil {
+			return fmt.Errorf("팀 템플릿 오류: %w", err)
+		}
+	}
+	if err := b.MCPToolPermissions.Validate(); err != nil {
+		return fmt.Errorf("MCP 도구 권한 오류: %w", err)
+	}
+	return nil
+}
+
+// Sign은 번들을 검사하고 key로 서명한 파일 내용을 반환합니다.
+func Sign(b Bundle, key ed25519.PrivateKey) ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	b.Templates = append([]tasktemplate.Template(nil), b.Templates...)
+	for i := range b.Templates {
+		b.Templates[i].Source = ""
+	}
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("팀 설정 직렬화 실패: %w", err)
+	}
+	file := signedFile{
+		Payload:   payload,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, payload)),
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("팀 설정 직렬화 실패: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Verify는 번들 파일의 서명을 검증하고 번들과 서명 키 지문을 반환합니다.
+// trustedFingerprint가 비어 있지 않으면 서명 키 지문이 일치해야 합니다.
+func Verify(data []byte, trustedFingerprint string) (*Bundle, string, error) {
+	var file signedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, "", fmt.Errorf("팀 설정 파일 파싱 실패: %w", err)
+	}
+	pub, err := base64.StdEncoding.DecodeString(file.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, "", errors.New("팀 설정 파일의 공개 키가 올바르지 않습니다")
+	}
+	sig, err := base64.StdEncoding.DecodeString(file.Signature)
+	if err != nil {
+		return nil, "", errors.New("팀 설정 파일의 서명이 올바르지 않습니다")
+	}
+	var payload bytes.Buffer
+	if err := json.Compact(&payload, file.Payload); err != nil {
+		return nil, "", fmt.Errorf("팀 설정 파일 파싱 실패: %w", err)
+	}
+	if !ed25519.Verify(pub, payload.Bytes(), sig) {
+		return nil, "", errors.New("팀 설정 서명 검증 실패: 파일이 변조되었거나 손상되었습니다")
+	}
+
+	fingerprint := Fingerprint(pub)
+	if trustedFingerprint != "" && !strings.EqualFold(trustedFingerprint, fingerprint) {
+		return nil, fingerprint, fmt.Errorf("%w (신뢰: %s, 번들: %s)", ErrKeyMismatch, trustedFingerprint, fingerprint)
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(payload.Bytes(), &b); err != nil {
+		return nil, fingerprint, fmt.Errorf("팀 설정 파싱 실패: %w", err)
+	}
+	if err := b.Validate(); err != nil {
+		return nil, fingerprint, err
+	}
+	return &b, fingerprint, nil
+}
+
+// Fingerprint는 공개 키의 SHA-256 지문(앞 16바이트, 16진수)을 반환합니다.
+func Fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:16])
+}
+
+// DefaultKeyPath는 기본 서명 키 경로(~/.config/autopus/team-signing.key)를 반환합니다.
+func DefaultKeyPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "autopus", "team-signing.key")
+}
+
+// LoadOrCreateKey는 path의 서명 키를 읽고, 없으면 새로 만들어 0600 권한으로 저장합니다.
+// 새로 만들었으면 created가 true입니다.
+func LoadOrCreateKey(path string) (key ed25519.PrivateKey, created bool, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		seed, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if decodeErr != nil || len(seed) != ed25519.SeedSize {
+			return nil, false, fmt.Errorf("서명 키 파일 형식이 올바르지 않습니다: %s", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), false, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, false, fmt.Errorf("서명 키 읽기 실패: %w", err)
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, false, fmt.Errorf("서명 키 생성 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, false, fmt.Errorf("서명 키 디렉토리 생성 실패: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key.Seed()) + "\n"
+	if err := os.WriteFile(path, []byte(encoded), 0600); err != nil {
+		return nil, false, fmt.Errorf("서명 키 저장 실패: %w", err)
+	}
+	return key, true, nil
+}
+
+// Apply는 번들을 설정 문서에 반영하고 변경한 키 목록을 반환합니다.
+// 정책 섹션과 mcp_server.tools는 번들 값으로 바꾸고, 템플릿은 이름 기준으로 병합하여
+// 같은 이름의 로컬 템플릿을 팀 템플릿으로 덮어씁니다. team 섹션에 출처와 고정 버전을 기록합니다.
+func (b *Bundle) Apply(doc map[string]any, fingerprint string) ([]string, error) {
+	var changes []string
+
+	for _, key := range sortedKeys(b.Policy) {
+		value, err := toDocumentValue(b.Policy[key])
+		if err != nil {
+			return nil, err
+		}
+		config.SetDocumentValue(doc, key, value)
+		changes = append(changes, key)
+	}
+
+	if len(b.Templates) > 0 {
+		merged, err := mergeTemplates(doc, b.Templates)
+		if err != nil {
+			return nil, err
+		}
+		config.SetDocumentValue(doc, "templates", merged)
+		changes = append(changes, fmt.Sprintf("templates (%d)", len(b.Templates)))
+	}
+
+	if len(b.MCPToolPermissions) > 0 {
+		value, err := toDocumentValue(b.MCPToolPermissions)
+		if err != nil {
+			return nil, err
+		}
+		config.SetDocumentValue(doc, "mcp_server.tools", value)
+		changes = append(changes, "mcp_server.tools")
+	}
+
+	team := map[string]any{
+		"name":            b.Team,
+		"key_fingerprint": fingerprint,
+	}
+	if len(b.ToolVersions) > 0 {
+		versions := make(map[string]any, len(b.ToolVersions))
+		for name, version := range b.ToolVersions {
+			versions[name] = version
+		}
+		team["tool_versions"] = versions
+	}
+	config.SetDocumentValue(doc, "team", team)
+	changes = append(changes, "team")
+	return changes, nil
+}
+
+// VersionMismatches는 고정 버전과 설치된 버전(installed)이 다른 도구를 설명하는 문장을 반환합니다.
+// 설치되지 않은 도구는 빈 버전으로 전달합니다. 고정 버전이 설치된 버전 문자열에 포함되면 일치로 봅니다.
+func (b *Bundle) VersionMismatches(installed map[string]string) []string {
+	var mismatches []string
+	for _, name := range sortedKeys(b.ToolVersions) {
+		want := b.ToolVersions[name]
+		got := installed[name]
+		switch {
+		case got == "":
+			mismatches = append(mismatches, fmt.Sprintf("%s: 설치되지 않음 (팀 버전 %s)", name, want))
+		case !strings.Contains(got, want):
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s (팀 버전 %s)", name, got, want))
+		}
+	}
+	return mismatches
+}
+
+// mergeTemplates는 문서의 기존 템플릿에 팀 템플릿을 이름 기준으로 병합합니다.
+func mergeTemplates(doc map[string]any, teamTemplates []tasktemplate.Template) ([]any, error) {
+	teamByName := make(map[string]bool, len(teamTemplates))
+	for _, t := range teamTemplates {
+		teamByName[t.Name] = true
+	}
+
+	var merged []any
+	if existing, ok := config.GetDocumentValue(doc, "templates"); ok {
+		list, _ := existing.([]any)
+		for _, item := range list {
+			entry, _ := item.(map[string]any)
+			if name, _ := entry["name"].(string); teamByName[name] {
+				continue
+			}
+			merged = append(merged, item)
+		}
+	}
+	for _, t := range teamTemplates {
+		t.Source = ""
+		value, err := toDocumentValue(t)
+		if err != nil {
+			return nil, err
+		}
+		entry := value.(map[string]any)
+		delete(entry, "source")
+		merged = append(merged, entry)
+	}
+	return merged, nil
+}
+
+// templateConfigs는 템플릿을 검증용 설정 항목으로 변환합니다.
+func templateConfigs(templates []tasktemplate.Template) []config.TemplateConfig {
+	configs := make([]config.TemplateConfig, 0, len(templates))
+	for _, t := range templates {
+		configs = append(configs, config.TemplateConfig{
+			Name:        t.Name,
+			Description: t.Description,
+			AgentID:     t.AgentID,
+			Agent:       t.Agent,
+			Prompt:      t.Prompt,
+			Tools:       t.Tools,
+			Model:       t.Model,
+			Provider:    t.Provider,
+			Vars:        t.Vars,
+		})
+	}
+	return configs
+}
+
+// toDocumentValue는 값을 JSON 왕복으로 설정 문서(YAML)에 넣을 수 있는 맵/슬라이스 값으로 변환합니다.
+func toDocumentValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("팀 설정 값 변환 실패: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("팀 설정 값 변환 실패: %w", err)
+	}
+	return value, nil
+}
+
+// isPolicyKey는 key가 공유 가능한 정책 섹션인지 반환합니다.
+func isPolicyKey(key string) bool {
+	for _, k := range PolicyKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys는 맵의 키를 정렬하여 반환합니다.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
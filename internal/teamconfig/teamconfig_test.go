@@ -0,0 +1,217 @@
+package teamconfig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/config"
+	"github.com/insajin/autopus-bridge/internal/mcpserver"
+	"github.com/insajin/autopus-bridge/internal/tasktemplate"
+)
+
+// testBundle은 모든 항목을 채운 번들을 반환합니다.
+func testBundle() Bundle {
+	return Bundle{
+		Format:       Format,
+		Team:         "platform",
+		CreatedAt:    time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		ToolVersions: map[string]string{"claude": "2.1.0", "jq": "1.7"},
+		Policy: map[string]any{
+			"security.provider_sandbox": map[string]any{"enabled": true, "allowed_write_roots": []any{"/srv/shared"}},
+		},
+		Templates: []tasktemplate.Template{
+			{Name: "review", Prompt: "Review {{branch}}", Vars: map[string]string{"branch": "main"}, Source: "/home/me/templates/review.yaml"},
+		},
+		MCPToolPermissions: mcpserver.ToolPermissions{
+			"manage_workspace": {DisabledActions: []string{"delete"}},
+		},
+	}
+}
+
+func testKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	key, _, err := LoadOrCreateKey(filepath.Join(t.TempDir(), "team.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSignVerify_RoundTrip(t *testing.T) {
+	key := testKey(t)
+	data, err := Sign(testBundle(), key)
+	if err != nil {
+		t.Fatalf("Sign 실패: %v", err)
+	}
+
+	b, fingerprint, err := Verify(data, "")
+	if err != nil {
+		t.Fatalf("Verify 실패: %v", err)
+	}
+	if fingerprint != Fingerprint(key.Public().(ed25519.PublicKey)) {
+		t.Errorf("지문 = %s", fingerprint)
+	}
+	if b.Team != "platform" || b.ToolVersions["jq"] != "1.7" || len(b.Templates) != 1 {
+		t.Errorf("번들 = %+v", b)
+	}
+	if b.Templates[0].Source != "" {
+		t.Errorf("템플릿 출처는 번들에 포함하지 않아야 합니다: %q", b.Templates[0].Source)
+	}
+
+	// 다시 들여쓰기해도 서명은 유효해야 합니다.
+	var reindented bytes.Buffer
+	if err := json.Indent(&reindented, data, "", "\t"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Verify(reindented.Bytes(), fingerprint); err != nil {
+		t.Errorf("들여쓰기 변경 후 검증 실패: %v", err)
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	key := testKey(t)
+	data, err := Sign(testBundle(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := bytes.Replace(data, []byte(`platform`), []byte(`attacker`), 1)
+	if _, _, err := Verify(tampered, ""); err == nil || !strings.Contains(err.Error(), "서명 검증 실패") {
+		t.Errorf("변조된 번들은 거부해야 합니다: %v", err)
+	}
+
+	if _, _, err := Verify(data, "0000"); !errors.Is(err, ErrKeyMismatch) {
+		t.Errorf("신뢰하지 않는 키는 ErrKeyMismatch여야 합니다: %v", err)
+	}
+
+	if _, _, err := Verify([]byte("not json"), ""); err == nil {
+		t.Error("잘못된 파일은 에러를 반환해야 합니다")
+	}
+}
+
+func TestBundle_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Bundle)
+		want   string
+	}{
+		{name: "형식", mutate: func(b *Bundle) { b.Format = "v0" }, want: "형식"},
+		{name: "팀 이름", mutate: func(b *Bundle) { b.Team = " " }, want: "팀 이름"},
+		{name: "정책 키", mutate: func(b *Bundle) { b.Policy["auth"] = map[string]any{} }, want: "정책 키"},
+		{name: "템플릿", mutate: func(b *Bundle) { b.Templates[0].Prompt = "" }, want: "템플릿"},
+		{name: "MCP 권한", mutate: func(b *Bundle) { b.MCPToolPermissions["no_such_tool"] = mcpserver.ToolPermission{} }, want: "MCP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := testBundle()
+			tt.mutate(&b)
+			if _, err := Sign(b, testKey(t)); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("에러 = %v, want %q 포함", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundle_Apply(t *testing.T) {
+	doc := map[string]any{
+		"server": map[string]any{"ws_url": "wss://example.com"},
+		"security": map[string]any{
+			"sandbox":          map[string]any{"enabled": true},
+			"provider_sandbox": map[string]any{"enabled": false},
+		},
+		"templates": []any{
+			map[string]any{"name": "review", "prompt": "local review"},
+			map[string]any{"name": "local-only", "prompt": "keep me"},
+		},
+	}
+	b := testBundle()
+	changes, err := b.Apply(doc, "abcd")
+	if err != nil {
+		t.Fatalf("Apply 실패: %v", err)
+	}
+	if want := []string{"security.provider_sandbox", "templates (1)", "mcp_server.tools", "team"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("변경 = %v, want %v", changes, want)
+	}
+
+	if v, _ := config.GetDocumentValue(doc, "security.sandbox.enabled"); v != true {
+		t.Error("번들에 없는 정책 섹션은 유지해야 합니다")
+	}
+	if v, _ := config.GetDocumentValue(doc, "security.provider_sandbox.enabled"); v != true {
+		t.Error("번들의 정책 섹션으로 바꿔야 합니다")
+	}
+	if v, _ := config.GetDocumentValue(doc, "server.ws_url"); v != "wss://example.com" {
+		t.Error("정책 외 설정은 유지해야 합니다")
+	}
+
+	templates, _ := config.GetDocumentValue(doc, "templates")
+	var prompts []string
+	for _, item := range templates.([]any) {
+		entry := item.(map[string]any)
+		if _, ok := entry["source"]; ok {
+			t.Errorf("템플릿 출처를 설정에 기록하지 않아야 합니다: %v", entry)
+		}
+		prompts = append(prompts, entry["prompt"].(string))
+	}
+	if want := []string{"keep me", "Review {{branch}}"}; !reflect.DeepEqual(prompts, want) {
+		t.Errorf("템플릿 = %v, want %v", prompts, want)
+	}
+
+	if v, _ := config.GetDocumentValue(doc, "mcp_server.tools.manage_workspace.disabled_actions"); !reflect.DeepEqual(v, []any{"delete"}) {
+		t.Errorf("mcp_server.tools = %v", v)
+	}
+	if v, _ := config.GetDocumentValue(doc, "team.key_fingerprint"); v != "abcd" {
+		t.Errorf("team.key_fingerprint = %v", v)
+	}
+	if v, _ := config.GetDocumentValue(doc, "team.tool_versions.claude"); v != "2.1.0" {
+		t.Errorf("team.tool_versions.claude = %v", v)
+	}
+	if issues := config.ValidateDocument(doc); len(issues) != 0 {
+		t.Errorf("반영한 설정이 스키마 검사를 통과해야 합니다: %+v", issues)
+	}
+}
+
+func TestBundle_VersionMismatches(t *testing.T) {
+	b := testBundle()
+	got := b.VersionMismatches(map[string]string{"claude": "2.1.0 (Claude Code)", "jq": "jq-1.6"})
+	if want := []string{"jq: jq-1.6 (팀 버전 1.7)"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("불일치 = %v, want %v", got, want)
+	}
+	got = b.VersionMismatches(nil)
+	if len(got) != 2 || !strings.Contains(got[0], "설치되지 않음") {
+		t.Errorf("미설치 도구 = %v", got)
+	}
+}
+
+func TestLoadOrCreateKey_Reuses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "team.key")
+	first, created, err := LoadOrCreateKey(path)
+	if err != nil || !created {
+		t.Fatalf("키 생성 실패: created=%v err=%v", created, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("키 파일 권한 = %o, want 0600", info.Mode().Perm())
+	}
+	second, created, err := LoadOrCreateKey(path)
+	if err != nil || created || !first.Equal(second) {
+		t.Errorf("기존 키를 재사용해야 합니다: created=%v err=%v", created, err)
+	}
+
+	if err := os.WriteFile(path, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadOrCreateKey(path); err == nil {
+		t.Error("잘못된 키 파일은 에러를 반환해야 합니다")
+	}
+}
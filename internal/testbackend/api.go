@@ -0,0 +1,347 @@
+// Package testbackend는 통합 테스트용 가짜 Autopus 백엔드를 제공합니다.
+//
+// API는 실제 백엔드와 같은 {"success", "data", "error"} 응답 형식을 쓰는 HTTP 서버이고,
+// WSServer는 agent_connect/connect_ack 핸드셰이크를 수행하는 WebSocket 서버입니다.
+// 기본 라우트는 MCP 도구가 호출하는 엔드포인트에 고정 픽스처를 반환하며,
+// Handle로 테스트마다 라우트를 바꾸거나 추가할 수 있습니다.
+package testbackend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// DefaultToken은 API가 기본으로 요구하는 Bearer 토큰입니다.
+const DefaultToken = "test-jwt-token-abc123"
+
+// 기본 라우트가 반환하는 픽스처 값입니다.
+const (
+	// ExecutionID는 execute 라우트가 반환하는 실행 ID입니다.
+	ExecutionID = "exec-001"
+	// ExecutionOutput은 실행 상태 라우트의 result.output 값입니다.
+	ExecutionOutput = "task done"
+)
+
+// Request는 API가 받은 요청의 기록입니다.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// JSON은 요청 본문을 v로 디코딩합니다.
+func (r Request) JSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// APIOption은 API 설정 옵션입니다.
+type APIOption func(*API)
+
+// WithToken은 요구할 Bearer 토큰을 설정합니다. 빈 문자열이면 인증을 검사하지 않습니다.
+func WithToken(token string) APIOption {
+	return func(a *API) {
+		a.token = token
+	}
+}
+
+// WithoutDefaultRoutes는 기본 라우트를 등록하지 않습니다.
+// Handle로 등록한 라우트 외의 요청은 404 에러 응답을 받습니다.
+func WithoutDefaultRoutes() APIOption {
+	return func(a *API) {
+		a.skipDefaults = true
+	}
+}
+
+// API는 가짜 Autopus REST API 서버입니다.
+type API struct {
+	server       *httptest.Server
+	mux          *http.ServeMux
+	token        string
+	skipDefaults bool
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []Request
+}
+
+// NewAPI는 가짜 API 서버를 시작합니다. 사용 후 Close를 호출해야 합니다.
+func NewAPI(opts ...APIOption) *API {
+	a := &API{
+		mux:      http.NewServeMux(),
+		token:    DefaultToken,
+		handlers: make(map[string]http.HandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, http.StatusNotFound, "endpoint not found")
+	})
+	if !a.skipDefaults {
+		a.registerDefaults()
+	}
+	a.server = httptest.NewServer(http.HandlerFunc(a.serveHTTP))
+	return a
+}
+
+// URL은 서버의 기본 URL입니다 (예: http://127.0.0.1:PORT).
+func (a *API) URL() string {
+	return a.server.URL
+}
+
+// Token은 API가 요구하는 Bearer 토큰입니다.
+func (a *API) Token() string {
+	return a.token
+}
+
+// Close는 서버를 종료합니다.
+func (a *API) Close() {
+	a.server.Close()
+}
+
+// Handle은 pattern("METHOD /path/{id}" 형식의 http.ServeMux 패턴)의 핸들러를 등록합니다.
+// 같은 패턴이 이미 있으면 교체하므로 기본 라우트의 응답을 바꿀 때 사용합니다.
+func (a *API) Handle(pattern string, handler http.HandlerFunc) {
+	a.mu.Lock()
+	_, exists := a.handlers[pattern]
+	a.handlers[pattern] = handler
+	a.mu.Unlock()
+	if exists {
+		return
+	}
+	a.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		h := a.handlers[pattern]
+		a.mu.Unlock()
+		h(w, r)
+	})
+}
+
+// HandleJSON은 pattern 요청에 data를 성공 응답으로 반환하도록 등록합니다.
+func (a *API) HandleJSON(pattern string, data interface{}) {
+	a.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		WriteSuccess(w, data)
+	})
+}
+
+// HandleError는 pattern 요청에 statusCode와 에러 메시지를 반환하도록 등록합니다.
+func (a *API) HandleError(pattern string, statusCode int, errMsg string) {
+	a.Handle(pattern, func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, statusCode, errMsg)
+	})
+}
+
+// Requests는 지금까지 받은 요청의 복사본을 반환합니다. 인증에 실패한 요청도 포함됩니다.
+func (a *API) Requests() []Request {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Request(nil), a.requests...)
+}
+
+// RequestsTo는 method와 path가 일치하는 요청만 반환합니다.
+func (a *API) RequestsTo(method, path string) []Request {
+	var out []Request
+	for _, r := range a.Requests() {
+		if r.Method == method && r.Path == path {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Reset은 기록된 요청을 지웁니다.
+func (a *API) Reset() {
+	a.mu.Lock()
+	a.requests = nil
+	a.mu.Unlock()
+}
+
+// serveHTTP는 요청을 기록하고 인증을 검사한 뒤 라우트로 넘깁니다.
+func (a *API) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	a.mu.Lock()
+	a.requests = append(a.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	a.mu.Unlock()
+
+	if a.token != "" {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			WriteError(w, http.StatusUnauthorized, "missing authorization header")
+			return
+		}
+		if authHeader != "Bearer "+a.token {
+			WriteError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+	}
+	a.mux.ServeHTTP(w, r)
+}
+
+// registerDefaults는 MCP 도구가 호출하는 엔드포인트의 기본 라우트를 등록합니다.
+func (a *API) registerDefaults() {
+	a.Handle("POST /api/v1/workspaces/{id}/execute", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AgentID string `json:"agent_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		WriteSuccess(w, map[string]interface{}{
+			"execution_id": ExecutionID,
+			"status":       "running",
+			"message":      fmt.Sprintf("Task submitted to agent %s", req.AgentID),
+		})
+	})
+
+	a.HandleJSON("GET /api/v1/workspaces/{id}/agents", map[string]interface{}{
+		"agents": []map[string]interface{}{
+			{"id": "agent-1", "name": "Code Reviewer", "description": "Automated code review"},
+			{"id": "agent-2", "name": "Test Generator", "description": "Generate test cases"},
+		},
+		"total": 2,
+	})
+
+	a.Handle("POST /api/v1/workspaces/{id}/agents", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Config struct {
+				Name string `json:"name"`
+			} `json:"config"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		WriteSuccess(w, map[string]interface{}{
+			"agent":   map[string]interface{}{"id": "agent-new", "name": req.Config.Name},
+			"message": "Agent created",
+		})
+	})
+
+	a.Handle("PUT /api/v1/workspaces/{id}/agents/{agent}", func(w http.ResponseWriter, r *http.Request) {
+		WriteSuccess(w, map[string]interface{}{
+			"agent":   map[string]interface{}{"id": r.PathValue("agent"), "name": "Updated"},
+			"message": "Agent updated",
+		})
+	})
+
+	a.Handle("POST /api/v1/workspaces/{id}/agents/{agent}/clone", func(w http.ResponseWriter, r *http.Request) {
+		WriteSuccess(w, map[string]interface{}{
+			"agent":   map[string]interface{}{"id": r.PathValue("agent") + "-copy", "name": "Copy"},
+			"message": "Agent cloned",
+		})
+	})
+
+	a.HandleJSON("DELETE /api/v1/workspaces/{id}/agents/{agent}", map[string]interface{}{
+		"message": "Agent deleted",
+	})
+
+	a.Handle("GET /api/v1/executions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		WriteSuccess(w, map[string]interface{}{
+			"execution_id": r.PathValue("id"),
+			"status":       "completed",
+			"result":       map[string]string{"output": ExecutionOutput},
+			"created_at":   "2025-01-01T00:00:00Z",
+			"updated_at":   "2025-01-01T00:01:00Z",
+		})
+	})
+
+	a.Handle("POST /api/v1/executions/{id}/approve", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ExecutionID string `json:"execution_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		WriteSuccess(w, map[string]interface{}{
+			"execution_id": req.ExecutionID,
+			"status":       "approved",
+			"message":      "Execution approved",
+		})
+	})
+
+	a.HandleJSON("GET /api/v1/workspaces", map[string]interface{}{
+		"workspaces": []map[string]interface{}{
+			{"id": "ws-1", "name": "Default", "slug": "default"},
+			{"id": "ws-2", "name": "Production", "slug": "prod"},
+		},
+	})
+
+	a.HandleJSON("POST /api/v1/workspaces", map[string]interface{}{
+		"workspace": map[string]interface{}{"id": "ws-new", "name": "New Workspace"},
+		"message":   "Workspace created",
+	})
+
+	a.Handle("GET /api/v1/workspaces/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		WriteSuccess(w, map[string]interface{}{
+			"workspace": map[string]interface{}{"id": id, "name": "Workspace " + id},
+		})
+	})
+
+	a.Handle("PUT /api/v1/workspaces/{id}", func(w http.ResponseWriter, r *http.Request) {
+		WriteSuccess(w, map[string]interface{}{
+			"workspace": map[string]interface{}{"id": r.PathValue("id"), "name": "Updated"},
+			"message":   "Workspace updated",
+		})
+	})
+
+	a.HandleJSON("DELETE /api/v1/workspaces/{id}", map[string]interface{}{
+		"message": "Workspace deleted",
+	})
+
+	a.Handle("POST /api/v1/knowledge/search", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		WriteSuccess(w, map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"id": "k-1", "title": "Setup Guide", "content": "How to set up...", "score": 0.95},
+			},
+			"total": 1,
+			"query": req.Query,
+		})
+	})
+}
+
+// apiResponse는 Autopus API의 공통 응답 형식입니다.
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// WriteSuccess는 data를 담은 성공 응답을 작성합니다.
+func WriteSuccess(w http.ResponseWriter, data interface{}) {
+	dataBytes, _ := json.Marshal(data)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(apiResponse{Success: true, Data: dataBytes})
+}
+
+// WriteError는 statusCode와 에러 메시지를 담은 실패 응답을 작성합니다.
+func WriteError(w http.ResponseWriter, statusCode int, errMsg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(apiResponse{Success: false, Error: errMsg})
+}
@@ -0,0 +1,199 @@
+package testbackend
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+func doAPI(t *testing.T, a *API, method, path, token, body string) (int, apiResponse) {
+	t.Helper()
+	req, err := http.NewRequest(method, a.URL()+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("요청 생성 실패: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("요청 실패: %v", err)
+	}
+	defer resp.Body.Close()
+	var out apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("응답 파싱 실패: %v", err)
+	}
+	return resp.StatusCode, out
+}
+
+func TestAPI_기본라우트(t *testing.T) {
+	a := NewAPI()
+	defer a.Close()
+
+	status, resp := doAPI(t, a, http.MethodPost, "/api/v1/workspaces/ws-1/execute", DefaultToken, `{"agent_id":"agent-1","prompt":"hi"}`)
+	if status != http.StatusOK || !resp.Success {
+		t.Fatalf("execute: status=%d resp=%+v", status, resp)
+	}
+	var exec struct {
+		ExecutionID string `json:"execution_id"`
+		Message     string `json:"message"`
+	}
+	_ = json.Unmarshal(resp.Data, &exec)
+	if exec.ExecutionID != ExecutionID || !strings.Contains(exec.Message, "agent-1") {
+		t.Errorf("execute data = %+v", exec)
+	}
+
+	_, resp = doAPI(t, a, http.MethodGet, "/api/v1/executions/exec-9", DefaultToken, "")
+	var st struct {
+		ExecutionID string `json:"execution_id"`
+		Result      struct {
+			Output string `json:"output"`
+		} `json:"result"`
+	}
+	_ = json.Unmarshal(resp.Data, &st)
+	if st.ExecutionID != "exec-9" || st.Result.Output != ExecutionOutput {
+		t.Errorf("status data = %+v", st)
+	}
+
+	status, resp = doAPI(t, a, http.MethodGet, "/api/v1/unknown", DefaultToken, "")
+	if status != http.StatusNotFound || resp.Success || resp.Error == "" {
+		t.Errorf("unknown: status=%d resp=%+v", status, resp)
+	}
+}
+
+func TestAPI_인증(t *testing.T) {
+	a := NewAPI()
+	defer a.Close()
+
+	if status, _ := doAPI(t, a, http.MethodGet, "/api/v1/workspaces", "", ""); status != http.StatusUnauthorized {
+		t.Errorf("토큰 없음: status = %d, want 401", status)
+	}
+	if status, _ := doAPI(t, a, http.MethodGet, "/api/v1/workspaces", "wrong", ""); status != http.StatusUnauthorized {
+		t.Errorf("잘못된 토큰: status = %d, want 401", status)
+	}
+
+	open := NewAPI(WithToken(""))
+	defer open.Close()
+	if status, _ := doAPI(t, open, http.MethodGet, "/api/v1/workspaces", "", ""); status != http.StatusOK {
+		t.Errorf("인증 비활성: status = %d, want 200", status)
+	}
+}
+
+func TestAPI_라우트교체와요청기록(t *testing.T) {
+	a := NewAPI()
+	defer a.Close()
+
+	a.HandleError("GET /api/v1/executions/{id}", http.StatusServiceUnavailable, "backend down")
+	a.HandleJSON("GET /api/v1/custom", map[string]string{"ok": "yes"})
+
+	if status, resp := doAPI(t, a, http.MethodGet, "/api/v1/executions/e1", DefaultToken, ""); status != http.StatusServiceUnavailable || resp.Error != "backend down" {
+		t.Errorf("교체된 라우트: status=%d resp=%+v", status, resp)
+	}
+	if status, resp := doAPI(t, a, http.MethodGet, "/api/v1/custom", DefaultToken, ""); status != http.StatusOK || string(resp.Data) != `{"ok":"yes"}` {
+		t.Errorf("추가된 라우트: status=%d resp=%+v", status, resp)
+	}
+	doAPI(t, a, http.MethodPost, "/api/v1/knowledge/search", DefaultToken, `{"query":"setup"}`)
+
+	reqs := a.RequestsTo(http.MethodPost, "/api/v1/knowledge/search")
+	if len(reqs) != 1 {
+		t.Fatalf("기록된 search 요청 수 = %d, want 1", len(reqs))
+	}
+	var body struct {
+		Query string `json:"query"`
+	}
+	if err := reqs[0].JSON(&body); err != nil || body.Query != "setup" {
+		t.Errorf("기록된 본문 = %q (%v)", reqs[0].Body, err)
+	}
+	if len(a.Requests()) != 3 {
+		t.Errorf("전체 기록 수 = %d, want 3", len(a.Requests()))
+	}
+	a.Reset()
+	if len(a.Requests()) != 0 {
+		t.Error("Reset 후에도 기록이 남아 있습니다")
+	}
+}
+
+func dialWS(t *testing.T, s *WSServer) (*websocket.Conn, ws.ConnectAckPayload) {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(s.URL(), nil)
+	if err != nil {
+		t.Fatalf("Dial 실패: %v", err)
+	}
+	payload, _ := json.Marshal(ws.AgentConnectPayload{Version: "1.0.0", Token: "tok"})
+	if err := conn.WriteJSON(ws.AgentMessage{Type: ws.AgentMsgConnect, Payload: payload}); err != nil {
+		t.Fatalf("agent_connect 전송 실패: %v", err)
+	}
+	var ackMsg ws.AgentMessage
+	if err := conn.ReadJSON(&ackMsg); err != nil {
+		t.Fatalf("connect_ack 수신 실패: %v", err)
+	}
+	if ackMsg.Type != ws.AgentMsgConnectAck {
+		t.Fatalf("첫 메시지 타입 = %q, want %q", ackMsg.Type, ws.AgentMsgConnectAck)
+	}
+	var ack ws.ConnectAckPayload
+	_ = json.Unmarshal(ackMsg.Payload, &ack)
+	return conn, ack
+}
+
+func TestWSServer_핸드셰이크와메시지(t *testing.T) {
+	s := NewWSServer()
+	defer s.Close()
+
+	conn, ack := dialWS(t, s)
+	defer conn.Close()
+	if !ack.Success {
+		t.Fatalf("ack = %+v, want success", ack)
+	}
+	if got := s.Connects(); len(got) != 1 || got[0].Token != "tok" {
+		t.Errorf("Connects = %+v", got)
+	}
+
+	for _, typ := range []string{ws.AgentMsgHeartbeat, ws.AgentMsgTaskResult} {
+		if err := conn.WriteJSON(ws.AgentMessage{Type: typ}); err != nil {
+			t.Fatalf("전송 실패: %v", err)
+		}
+	}
+	if _, err := s.WaitFor(ws.AgentMsgTaskResult, 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.WaitFor(ws.AgentMsgTaskResult, 50*time.Millisecond); err == nil {
+		t.Error("소비된 메시지를 다시 반환했습니다")
+	}
+	if len(s.Messages()) != 2 {
+		t.Errorf("Messages 수 = %d, want 2", len(s.Messages()))
+	}
+
+	if err := s.Send(ws.AgentMsgTaskReq, ws.TaskRequestPayload{ExecutionID: "exec-1"}); err != nil {
+		t.Fatalf("Send 실패: %v", err)
+	}
+	var got ws.AgentMessage
+	if err := conn.ReadJSON(&got); err != nil || got.Type != ws.AgentMsgTaskReq {
+		t.Fatalf("서버 메시지 = %+v (%v)", got, err)
+	}
+
+	s.DropConnection()
+	if err := s.Send(ws.AgentMsgTaskReq, nil); err != ErrNotConnected {
+		t.Errorf("연결 해제 후 Send 에러 = %v, want ErrNotConnected", err)
+	}
+}
+
+func TestWSServer_인증실패Ack(t *testing.T) {
+	s := NewWSServer(WithConnectAck(ws.ConnectAckPayload{Success: false, ErrorCode: "token_invalid"}))
+	defer s.Close()
+
+	conn, ack := dialWS(t, s)
+	defer conn.Close()
+	if ack.Success || ack.ErrorCode != "token_invalid" {
+		t.Errorf("ack = %+v", ack)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := conn.ReadMessage(); err == nil {
+		t.Error("실패 ack 후에도 연결이 유지됩니다")
+	}
+}
@@ -0,0 +1,219 @@
+package testbackend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// ErrNotConnected는 연결된 클라이언트가 없을 때 Send가 반환합니다.
+var ErrNotConnected = errors.New("testbackend: no connected client")
+
+// WSOption은 WSServer 설정 옵션입니다.
+type WSOption func(*WSServer)
+
+// WithConnectAck는 agent_connect에 대한 connect_ack 페이로드를 설정합니다.
+// Success가 false이면 ack를 보낸 뒤 연결을 닫습니다.
+func WithConnectAck(ack ws.ConnectAckPayload) WSOption {
+	return func(s *WSServer) {
+		s.ack = ack
+	}
+}
+
+// WSServer는 Bridge 클라이언트가 접속하는 가짜 Autopus WebSocket 서버입니다.
+// 접속마다 agent_connect를 읽고 connect_ack를 보낸 뒤, 이후 받은 메시지를 기록합니다.
+type WSServer struct {
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+	ack      ws.ConnectAckPayload
+	msgSeq   atomic.Int64
+
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	writeMu  sync.Mutex
+	connects []ws.AgentConnectPayload
+	messages []ws.AgentMessage
+	pending  []ws.AgentMessage
+	notify   chan struct{}
+}
+
+// NewWSServer는 가짜 WebSocket 서버를 시작합니다. 사용 후 Close를 호출해야 합니다.
+func NewWSServer(opts ...WSOption) *WSServer {
+	s := &WSServer{
+		upgrader: websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+		ack:      ws.ConnectAckPayload{Success: true, Message: "authenticated"},
+		notify:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveWS))
+	return s
+}
+
+// URL은 클라이언트가 접속할 WebSocket URL입니다 (예: ws://127.0.0.1:PORT/ws).
+func (s *WSServer) URL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http") + "/ws"
+}
+
+// Close는 현재 연결과 서버를 종료합니다.
+func (s *WSServer) Close() {
+	s.DropConnection()
+	s.server.Close()
+}
+
+// DropConnection은 현재 연결을 끊습니다. 재연결 동작을 테스트할 때 사용합니다.
+func (s *WSServer) DropConnection() {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.mu.Unlock()
+	if conn != nil {
+		_ = conn.Close()
+	}
+}
+
+// Connects는 지금까지 받은 agent_connect 페이로드를 접속 순서대로 반환합니다.
+func (s *WSServer) Connects() []ws.AgentConnectPayload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ws.AgentConnectPayload(nil), s.connects...)
+}
+
+// Messages는 핸드셰이크 이후 받은 모든 메시지를 수신 순서대로 반환합니다.
+func (s *WSServer) Messages() []ws.AgentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ws.AgentMessage(nil), s.messages...)
+}
+
+// WaitFor는 msgType 메시지가 올 때까지 최대 timeout 동안 기다립니다.
+// 반환한 메시지는 소비되어 다음 WaitFor 호출에서는 그다음 메시지를 반환합니다.
+func (s *WSServer) WaitFor(msgType string, timeout time.Duration) (ws.AgentMessage, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		s.mu.Lock()
+		for i, msg := range s.pending {
+			if msg.Type == msgType {
+				s.pending = append(s.pending[:i], s.pending[i+1:]...)
+				s.mu.Unlock()
+				return msg, nil
+			}
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-deadline.C:
+			return ws.AgentMessage{}, fmt.Errorf("testbackend: timed out after %s waiting for %s", timeout, msgType)
+		}
+	}
+}
+
+// Send는 현재 연결된 클라이언트에 msgType 메시지를 보냅니다.
+func (s *WSServer) Send(msgType string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("testbackend: marshal %s payload: %w", msgType, err)
+	}
+	return s.SendMessage(ws.AgentMessage{
+		Type:      msgType,
+		ID:        fmt.Sprintf("srv-%d", s.msgSeq.Add(1)),
+		Timestamp: time.Now(),
+		Payload:   data,
+	})
+}
+
+// SendMessage는 현재 연결된 클라이언트에 msg를 그대로 보냅니다.
+func (s *WSServer) SendMessage(msg ws.AgentMessage) error {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil {
+		return ErrNotConnected
+	}
+	return s.write(conn, msg)
+}
+
+// write는 conn에 msg를 씁니다. gorilla 연결은 동시 쓰기를 허용하지 않으므로 직렬화합니다.
+func (s *WSServer) write(conn *websocket.Conn, msg ws.AgentMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// serveWS는 핸드셰이크를 처리하고 연결이 끊길 때까지 메시지를 기록합니다.
+func (s *WSServer) serveWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return
+	}
+	var connectMsg ws.AgentMessage
+	if err := json.Unmarshal(data, &connectMsg); err != nil || connectMsg.Type != ws.AgentMsgConnect {
+		return
+	}
+	var connect ws.AgentConnectPayload
+	_ = json.Unmarshal(connectMsg.Payload, &connect)
+
+	s.mu.Lock()
+	s.connects = append(s.connects, connect)
+	s.mu.Unlock()
+
+	ackPayload, _ := json.Marshal(s.ack)
+	ack := ws.AgentMessage{
+		Type:      ws.AgentMsgConnectAck,
+		ID:        fmt.Sprintf("ack-%d", s.msgSeq.Add(1)),
+		Timestamp: time.Now(),
+		Payload:   ackPayload,
+	}
+	if err := s.write(conn, ack); err != nil || !s.ack.Success {
+		return
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			s.mu.Lock()
+			if s.conn == conn {
+				s.conn = nil
+			}
+			s.mu.Unlock()
+			return
+		}
+		var msg ws.AgentMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		s.mu.Lock()
+		s.messages = append(s.messages, msg)
+		s.pending = append(s.pending, msg)
+		close(s.notify)
+		s.notify = make(chan struct{})
+		s.mu.Unlock()
+	}
+}
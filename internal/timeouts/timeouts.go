@@ -0,0 +1,140 @@
+// Package timeouts는 Bridge 실행 타임아웃의 계층을 정의합니다.
+// 요청에 지정된 값, 메시지 타입(또는 MCP 도구)별 값, 전역 기본값 순으로 적용하며,
+// 만료된 타임아웃이 어느 단계에서 왔는지 context 원인(cause)으로 남겨 다른 에러와 구분해 보고합니다.
+package timeouts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+// Policy는 타임아웃 계층 설정입니다. nil Policy는 요청 값과 호출자 기본값만 사용합니다.
+type Policy struct {
+	// Default는 전역 기본 타임아웃입니다. 0이면 호출자의 기본값을 사용합니다.
+	Default time.Duration
+	// PerKind는 메시지 타입(task_request 등) 또는 MCP 도구 이름별 타임아웃입니다.
+	PerKind map[string]time.Duration
+	// Max는 요청에 지정된 타임아웃의 상한입니다. 0이면 제한하지 않습니다.
+	Max time.Duration
+}
+
+// Resolved는 한 요청에 적용할 타임아웃과 그 출처입니다.
+type Resolved struct {
+	// Kind는 메시지 타입 또는 MCP 도구 이름입니다.
+	Kind string
+	// Timeout은 적용할 타임아웃입니다. 0이면 타임아웃이 없습니다.
+	Timeout time.Duration
+	// Source는 타임아웃을 정한 단계입니다 (ws.TimeoutSource*).
+	Source string
+}
+
+// Resolve는 kind 요청에 적용할 타임아웃을 계산합니다.
+// requested(요청 값) > PerKind[kind] > Default > fallback(호출자 기본값) 순이며, 요청 값은 Max로 제한합니다.
+func (p *Policy) Resolve(kind string, requested, fallback time.Duration) Resolved {
+	r := Resolved{Kind: kind}
+	switch {
+	case requested > 0:
+		r.Timeout, r.Source = requested, ws.TimeoutSourceRequest
+		if p != nil && p.Max > 0 && requested > p.Max {
+			r.Timeout = p.Max
+		}
+	case p != nil && p.PerKind[kind] > 0:
+		r.Timeout, r.Source = p.PerKind[kind], ws.TimeoutSourceMessageType
+	case p != nil && p.Default > 0:
+		r.Timeout, r.Source = p.Default, ws.TimeoutSourceDefault
+	case fallback > 0:
+		r.Timeout, r.Source = fallback, ws.TimeoutSourceDefault
+	}
+	return r
+}
+
+// ForRequest는 정책 없이 요청 값(초)과 호출자 기본값으로 타임아웃을 계산합니다.
+// 라우터가 정책으로 정한 값을 요청에 실어 보내는 실행기에서 사용합니다.
+func ForRequest(kind string, requestedSeconds int, fallback time.Duration) Resolved {
+	var p *Policy
+	return p.Resolve(kind, time.Duration(requestedSeconds)*time.Second, fallback)
+}
+
+// Seconds는 타임아웃을 요청 페이로드의 초 단위 값으로 변환합니다 (1초 미만은 올림).
+func (r Resolved) Seconds() int {
+	return int((r.Timeout + time.Second - 1) / time.Second)
+}
+
+// Detail은 타임아웃을 에러 페이로드에 싣는 형태로 변환합니다.
+func (r Resolved) Detail() *ws.TimeoutDetail {
+	return &ws.TimeoutDetail{Kind: r.Kind, Source: r.Source, TimeoutSeconds: r.Seconds()}
+}
+
+// Error는 만료된 타임아웃입니다. context.DeadlineExceeded를 감싸므로 errors.Is로도 확인할 수 있습니다.
+type Error struct {
+	Resolved
+}
+
+// Error는 에러 메시지를 반환합니다.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s 타임아웃: %s 초과 (%s)", e.Kind, e.Timeout, sourceLabel(e.Source))
+}
+
+// Unwrap은 context.DeadlineExceeded를 반환합니다.
+func (e *Error) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// sourceLabel은 타임아웃 출처를 사람이 읽을 수 있는 설명으로 바꿉니다.
+func sourceLabel(source string) string {
+	switch source {
+	case ws.TimeoutSourceRequest:
+		return "요청 지정 값"
+	case ws.TimeoutSourceMessageType:
+		return "메시지 타입별 설정"
+	default:
+		return "기본값"
+	}
+}
+
+// WithTimeout은 r.Timeout 후 만료되는 context를 반환합니다. 만료 원인은 *Error입니다.
+// r.Timeout이 0이면 취소만 가능한 context를 반환합니다.
+func WithTimeout(ctx context.Context, r Resolved) (context.Context, context.CancelFunc) {
+	if r.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, r.Timeout, &Error{Resolved: r})
+}
+
+// FromContext는 ctx가 WithTimeout의 타임아웃으로 만료되었으면 그 *Error를 반환합니다.
+// 상위 context의 타임아웃으로 만료된 경우에도 원인이 전파되므로 가장 먼저 만료된 단계를 반환합니다.
+func FromContext(ctx context.Context) (*Error, bool) {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, false
+	}
+	var te *Error
+	if errors.As(context.Cause(ctx), &te) {
+		return te, true
+	}
+	return nil, false
+}
+
+// FromSeconds는 초 단위 설정 값으로 Policy를 만듭니다. 모든 값이 0이면 nil을 반환합니다.
+func FromSeconds(defaultSeconds, maxSeconds int, perKind map[string]int) *Policy {
+	p := &Policy{
+		Default: time.Duration(defaultSeconds) * time.Second,
+		Max:     time.Duration(maxSeconds) * time.Second,
+	}
+	for kind, seconds := range perKind {
+		if seconds <= 0 {
+			continue
+		}
+		if p.PerKind == nil {
+			p.PerKind = make(map[string]time.Duration, len(perKind))
+		}
+		p.PerKind[kind] = time.Duration(seconds) * time.Second
+	}
+	if p.Default <= 0 && p.Max <= 0 && len(p.PerKind) == 0 {
+		return nil
+	}
+	return p
+}
@@ -0,0 +1,89 @@
+package timeouts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+func TestPolicy_Resolve(t *testing.T) {
+	p := &Policy{
+		Default: 5 * time.Minute,
+		PerKind: map[string]time.Duration{ws.AgentMsgTaskReq: 30 * time.Minute},
+		Max:     time.Hour,
+	}
+	tests := []struct {
+		name      string
+		policy    *Policy
+		kind      string
+		requested time.Duration
+		fallback  time.Duration
+		want      Resolved
+	}{
+		{"요청 값 우선", p, ws.AgentMsgTaskReq, 10 * time.Second, 0, Resolved{ws.AgentMsgTaskReq, 10 * time.Second, ws.TimeoutSourceRequest}},
+		{"요청 값은 max로 제한", p, ws.AgentMsgTaskReq, 2 * time.Hour, 0, Resolved{ws.AgentMsgTaskReq, time.Hour, ws.TimeoutSourceRequest}},
+		{"메시지 타입별 값", p, ws.AgentMsgTaskReq, 0, time.Minute, Resolved{ws.AgentMsgTaskReq, 30 * time.Minute, ws.TimeoutSourceMessageType}},
+		{"전역 기본값", p, "cli_request", 0, time.Minute, Resolved{"cli_request", 5 * time.Minute, ws.TimeoutSourceDefault}},
+		{"nil 정책은 호출자 기본값", nil, "cli_request", 0, time.Minute, Resolved{"cli_request", time.Minute, ws.TimeoutSourceDefault}},
+		{"타임아웃 없음", nil, "cli_request", 0, 0, Resolved{Kind: "cli_request"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Resolve(tt.kind, tt.requested, tt.fallback); got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTimeout_CauseSurvivesChildContexts(t *testing.T) {
+	r := Resolved{Kind: "build_request", Timeout: 20 * time.Millisecond, Source: ws.TimeoutSourceMessageType}
+	parent, cancel := WithTimeout(context.Background(), r)
+	defer cancel()
+	// 하위 실행기가 더 긴 자체 타임아웃을 걸어도 먼저 만료된 단계가 원인으로 남는다.
+	child, childCancel := WithTimeout(parent, Resolved{Kind: "build_request", Timeout: time.Hour, Source: ws.TimeoutSourceDefault})
+	defer childCancel()
+	<-child.Done()
+
+	te, ok := FromContext(child)
+	if !ok {
+		t.Fatalf("FromContext() ok = false, cause = %v", context.Cause(child))
+	}
+	if te.Resolved != r {
+		t.Errorf("원인 = %+v, want %+v", te.Resolved, r)
+	}
+	if !errors.Is(te, context.DeadlineExceeded) {
+		t.Error("Error는 context.DeadlineExceeded를 감싸야 함")
+	}
+	if d := te.Detail(); d.Kind != "build_request" || d.Source != ws.TimeoutSourceMessageType || d.TimeoutSeconds != 1 {
+		t.Errorf("Detail() = %+v", d)
+	}
+}
+
+func TestFromContext_NotTimeout(t *testing.T) {
+	ctx, cancel := WithTimeout(context.Background(), Resolved{Kind: "cli_request", Timeout: time.Hour})
+	cancel()
+	if _, ok := FromContext(ctx); ok {
+		t.Error("취소된 context는 타임아웃이 아님")
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if _, ok := FromContext(ctx); ok {
+		t.Error("WithTimeout으로 만들지 않은 타임아웃은 출처를 알 수 없음")
+	}
+}
+
+func TestFromSeconds(t *testing.T) {
+	if p := FromSeconds(0, 0, map[string]int{"task_request": 0}); p != nil {
+		t.Errorf("FromSeconds(0, 0, 0값) = %+v, want nil", p)
+	}
+	p := FromSeconds(60, 600, map[string]int{"build_request": 1800})
+	if p.Default != time.Minute || p.Max != 10*time.Minute || p.PerKind["build_request"] != 30*time.Minute {
+		t.Errorf("FromSeconds() = %+v", p)
+	}
+}
@@ -0,0 +1,221 @@
+// Package tracing은 Local Agent Bridge의 OpenTelemetry 트레이싱을 제공합니다.
+// 트레이싱이 비활성화되어 있으면 전역 no-op TracerProvider가 사용되어 스팬 생성 비용이 거의 없습니다.
+// 서버가 보낸 메시지의 metadata(W3C traceparent/tracestate)에서 컨텍스트를 이어받고,
+// 백엔드 HTTP 요청에는 같은 컨텍스트를 헤더로 전파합니다.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName은 브리지 스팬의 계측 스코프 이름입니다.
+const instrumentationName = "github.com/insajin/autopus-bridge"
+
+// DefaultServiceName은 service.name 리소스 속성의 기본값입니다.
+const DefaultServiceName = "autopus-bridge"
+
+// shutdownTimeout은 종료 시 남은 스팬을 내보내는 최대 대기 시간입니다.
+const shutdownTimeout = 5 * time.Second
+
+// Config는 트레이싱 설정입니다.
+type Config struct {
+	// Enabled가 false이면 Setup은 아무 작업도 하지 않습니다.
+	Enabled bool
+	// Endpoint는 OTLP/HTTP 컬렉터 주소입니다 ("host:port" 또는 URL).
+	Endpoint string
+	// Insecure는 TLS 없이 전송할지 여부입니다.
+	Insecure bool
+	// Headers는 컬렉터 요청에 추가할 헤더입니다.
+	Headers map[string]string
+	// ServiceName은 service.name 리소스 속성입니다. 비어있으면 DefaultServiceName을 사용합니다.
+	ServiceName string
+	// ServiceVersion은 service.version 리소스 속성입니다.
+	ServiceVersion string
+	// SampleRatio는 루트 스팬 샘플링 비율(0~1)입니다. 0 이하 또는 1 초과이면 모두 샘플링합니다.
+	SampleRatio float64
+}
+
+// ShutdownFunc는 남은 스팬을 내보내고 TracerProvider를 종료합니다.
+type ShutdownFunc func(ctx context.Context) error
+
+func init() {
+	// 트레이싱이 비활성화되어 있어도 서버가 보낸 컨텍스트를 백엔드 요청으로 전파할 수 있도록
+	// 전파기는 항상 설정한다.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Setup은 OTLP/HTTP 익스포터로 전역 TracerProvider를 설정합니다.
+// 비활성화되어 있으면 아무 작업도 하지 않는 ShutdownFunc를 반환합니다.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	noop := func(context.Context) error { return nil }
+	if !cfg.Enabled {
+		return noop, nil
+	}
+
+	opts, err := exporterOptions(cfg)
+	if err != nil {
+		return noop, err
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return noop, fmt.Errorf("OTLP 익스포터 생성 실패: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	attrs := []attribute.KeyValue{attribute.String("service.name", serviceName)}
+	if cfg.ServiceVersion != "" {
+		attrs = append(attrs, attribute.String("service.version", cfg.ServiceVersion))
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+		sdktrace.WithSampler(sampler(cfg.SampleRatio)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		return tp.Shutdown(ctx)
+	}, nil
+}
+
+// exporterOptions는 설정을 OTLP/HTTP 익스포터 옵션으로 변환합니다.
+func exporterOptions(cfg Config) ([]otlptracehttp.Option, error) {
+	endpoint := strings.TrimSpace(cfg.Endpoint)
+	if endpoint == "" {
+		return nil, fmt.Errorf("tracing.endpoint가 설정되지 않았습니다")
+	}
+
+	var opts []otlptracehttp.Option
+	if strings.Contains(endpoint, "://") {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		if strings.HasPrefix(endpoint, "http://") {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+	} else {
+		opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	return opts, nil
+}
+
+// sampler는 상위 컨텍스트의 샘플링 결정을 따르고, 루트 스팬만 비율로 샘플링하는 Sampler를 반환합니다.
+func sampler(ratio float64) sdktrace.Sampler {
+	if ratio <= 0 || ratio >= 1 {
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+}
+
+// Tracer는 브리지 계측용 Tracer를 반환합니다.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start는 새 스팬을 시작합니다.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End는 err가 있으면 스팬에 에러를 기록한 뒤 스팬을 종료합니다.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract는 메시지 metadata에 담긴 트레이스 컨텍스트를 ctx로 가져옵니다.
+func Extract(ctx context.Context, metadata map[string]string) context.Context {
+	if len(metadata) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(metadata))
+}
+
+// Inject는 ctx의 트레이스 컨텍스트를 metadata에 기록합니다.
+// metadata가 nil이고 전파할 컨텍스트가 있으면 새 맵을 만들어 반환합니다.
+func Inject(ctx context.Context, metadata map[string]string) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]string, len(carrier))
+	}
+	for k, v := range carrier {
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// transport는 HTTP 요청마다 클라이언트 스팬을 만들고 트레이스 컨텍스트를 헤더로 전파합니다.
+type transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport는 base를 감싸 백엔드 HTTP 호출을 계측하는 RoundTripper를 반환합니다.
+// base가 nil이면 http.DefaultTransport를 사용합니다.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+// RoundTrip은 http.RoundTripper 인터페이스를 구현합니다.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+
+	// RoundTripper는 원본 요청을 수정하면 안 되므로 복제한 요청에 헤더를 기록한다.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		End(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 500 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	span.End()
+	return resp, nil
+}
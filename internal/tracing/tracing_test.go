@@ -0,0 +1,133 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useRecorder는 테스트 동안 스팬을 메모리에 기록하는 TracerProvider를 설정합니다.
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = tp.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Enabled: false})
+	if err != nil {
+		t.Fatalf("Setup() = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() = %v", err)
+	}
+}
+
+func TestSetup_MissingEndpoint(t *testing.T) {
+	if _, err := Setup(context.Background(), Config{Enabled: true}); err == nil {
+		t.Error("Setup() = nil; want error for empty endpoint")
+	}
+}
+
+func TestInjectExtract_RoundTrip(t *testing.T) {
+	useRecorder(t)
+
+	ctx, span := Start(context.Background(), "parent")
+	defer span.End()
+
+	metadata := Inject(ctx, nil)
+	if metadata["traceparent"] == "" {
+		t.Fatalf("Inject() = %v; want traceparent", metadata)
+	}
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), metadata))
+	if extracted.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("extracted trace id = %s; want %s", extracted.TraceID(), span.SpanContext().TraceID())
+	}
+	if !extracted.IsRemote() {
+		t.Error("extracted span context is not remote")
+	}
+}
+
+func TestInject_NoSpan(t *testing.T) {
+	if got := Inject(context.Background(), nil); got != nil {
+		t.Errorf("Inject() = %v; want nil without an active span", got)
+	}
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	recorder := useRecorder(t)
+
+	_, span := Start(context.Background(), "failing")
+	End(span, errors.New("boom"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d; want 1", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("status = %v; want Error", spans[0].Status().Code)
+	}
+}
+
+func TestTransport_PropagatesContext(t *testing.T) {
+	recorder := useRecorder(t)
+
+	var gotTraceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	ctx, parent := Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/agents", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: NewTransport(nil)}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() = %v", err)
+	}
+	_ = resp.Body.Close()
+	parent.End()
+
+	if gotTraceparent == "" {
+		t.Fatal("traceparent header was not sent")
+	}
+	if req.Header.Get("traceparent") != "" {
+		t.Error("original request was modified")
+	}
+
+	var httpSpan sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "HTTP GET" {
+			httpSpan = s
+		}
+	}
+	if httpSpan == nil {
+		t.Fatal("HTTP client span was not recorded")
+	}
+	if httpSpan.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("HTTP span is not a child of the caller span")
+	}
+	if httpSpan.Status().Code != codes.Error {
+		t.Errorf("status = %v; want Error for 5xx", httpSpan.Status().Code)
+	}
+}
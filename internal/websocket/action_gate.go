@@ -0,0 +1,42 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 서버 주도 작업(cli_request, mcp_deploy, computer_use)의 로컬 승인 게이트 연동.
+package websocket
+
+import (
+	"context"
+
+	"github.com/insajin/autopus-bridge/internal/approval"
+)
+
+// cliExitCodeDenied는 로컬 승인 게이트가 cli_request를 거부했을 때 보고하는 종료 코드입니다.
+// 셸의 "실행 권한 없음" 관례(126)를 따릅니다.
+const cliExitCodeDenied = 126
+
+// ActionGate는 서버가 요청한 위험 작업을 실행 전에 로컬에서 승인/거부합니다.
+// nil을 반환하면 실행을 허용하고, 에러를 반환하면 실행하지 않고 서버에 실패를 보고합니다.
+type ActionGate interface {
+	Check(ctx context.Context, action approval.LocalAction) error
+}
+
+// WithActionGate는 서버 주도 작업의 로컬 승인 게이트를 설정합니다.
+func WithActionGate(gate ActionGate) RouterOption {
+	return func(r *Router) {
+		r.actionGate = gate
+	}
+}
+
+// checkAction은 승인 게이트가 설정된 경우 작업 실행 허용 여부를 확인합니다.
+// 읽기 전용 모드에서는 승인 게이트와 무관하게 항상 거부합니다.
+func (r *Router) checkAction(ctx context.Context, actionType, target, detail string) error {
+	if r.readOnly {
+		return errReadOnly
+	}
+	if r.actionGate == nil {
+		return nil
+	}
+	return r.actionGate.Check(ctx, approval.LocalAction{
+		Type:   actionType,
+		Target: target,
+		Detail: detail,
+	})
+}
@@ -0,0 +1,75 @@
+// Package websocket - 로컬 승인 게이트 연동 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDeployer는 Deploy 호출 횟수를 기록하는 테스트용 MCPDeployExecutor입니다.
+type countingDeployer struct {
+	calls atomic.Int32
+}
+
+func (d *countingDeployer) Deploy(_ context.Context, _ string, _ []mcp.DeployFile, _ map[string]string) (string, error) {
+	d.calls.Add(1)
+	return "/tmp/deployed", nil
+}
+
+func (d *countingDeployer) DeployArchive(_ context.Context, _ string, _ io.Reader, _ map[string]string) (string, error) {
+	d.calls.Add(1)
+	return "/tmp/deployed", nil
+}
+
+func (d *countingDeployer) Verify(_ context.Context, serviceName string, _ []mcp.DeployFile, _ map[string]string, _ mcp.VerifyOptions) (*mcp.VerifyReport, error) {
+	d.calls.Add(1)
+	return &mcp.VerifyReport{ServiceName: serviceName, Passed: true}, nil
+}
+
+func (d *countingDeployer) VerifyArchive(_ context.Context, serviceName string, _ io.Reader, _ map[string]string, _ mcp.VerifyOptions) (*mcp.VerifyReport, error) {
+	d.calls.Add(1)
+	return &mcp.VerifyReport{ServiceName: serviceName, Passed: true}, nil
+}
+
+func sendMCPDeploy(t *testing.T, router *Router, serviceName string) {
+	t.Helper()
+	payload, err := json.Marshal(ws.MCPDeployPayload{ServiceName: serviceName})
+	require.NoError(t, err)
+	require.NoError(t, router.handleMCPDeploy(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgMCPDeploy,
+		ID:      "msg-1",
+		Payload: payload,
+	}))
+}
+
+// TestHandleMCPDeploy_DeniedByActionGate는 승인 게이트가 거부하면 배포가 실행되지 않는지 검증합니다.
+func TestHandleMCPDeploy_DeniedByActionGate(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	deployer := &countingDeployer{}
+	gate := approval.NewActionGate(approval.ActionGateConfig{
+		Modes:     map[string]approval.GateMode{approval.ActionMCPDeploy: approval.GateModeDeny},
+		Allowlist: map[string][]string{approval.ActionMCPDeploy: {"trusted-*"}},
+	}, nil, zerolog.Nop())
+	router := NewRouter(client, WithMCPDeployer(deployer), WithActionGate(gate))
+
+	sendMCPDeploy(t, router, "untrusted-svc")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), deployer.calls.Load(), "거부된 배포는 실행되면 안 됨")
+
+	sendMCPDeploy(t, router, "trusted-svc")
+	assert.Eventually(t, func() bool { return deployer.calls.Load() == 1 }, time.Second, 10*time.Millisecond,
+		"허용 목록의 서비스는 배포되어야 함")
+}
@@ -429,3 +429,59 @@ func newConnectedClient(t *testing.T, serverURL string) *Client {
 
 	return client
 }
+
+// TestProviderReadiness_IncludedInHeartbeat는 warm-up 준비 상태가 하트비트 페이로드에 포함되는지 검증합니다.
+func TestProviderReadiness_IncludedInHeartbeat(t *testing.T) {
+	client := NewClient("ws://localhost:0/ws", "test-token", "1.0.0",
+		WithProviderReadiness(map[string]bool{"openai": true}),
+	)
+
+	data, err := json.Marshal(client.buildHeartbeatPayload())
+	if err != nil {
+		t.Fatalf("하트비트 직렬화 실패: %v", err)
+	}
+	var payload struct {
+		Timestamp         time.Time       `json:"timestamp"`
+		ProviderReadiness map[string]bool `json:"provider_readiness"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("하트비트 파싱 실패: %v", err)
+	}
+	if payload.Timestamp.IsZero() {
+		t.Error("timestamp가 설정되어야 합니다")
+	}
+	if !payload.ProviderReadiness["openai"] {
+		t.Errorf("openai 준비 상태가 true여야 하나 %v입니다", payload.ProviderReadiness)
+	}
+
+	client.UpdateProviderReadiness(map[string]bool{"openai": false, "anthropic": true})
+	readiness := client.ProviderReadiness()
+	if readiness["openai"] || !readiness["anthropic"] {
+		t.Errorf("갱신된 준비 상태가 반영되어야 합니다: %v", readiness)
+	}
+
+	// warm-up 비활성화 시 provider_readiness는 생략되어야 합니다.
+	plain := NewClient("ws://localhost:0/ws", "test-token", "1.0.0")
+	data, _ = json.Marshal(plain.buildHeartbeatPayload())
+	if strings.Contains(string(data), "provider_readiness") {
+		t.Errorf("provider_readiness가 생략되어야 하나 포함되었습니다: %s", data)
+	}
+}
+
+// TestIdentity_IncludedInHeartbeat는 Bridge 식별 정보가 하트비트 페이로드에 포함되는지 검증합니다.
+func TestIdentity_IncludedInHeartbeat(t *testing.T) {
+	identity := &ws.BridgeIdentity{ID: "br-1", Name: "build-mac", Labels: map[string]string{"team": "backend"}}
+	client := NewClient("ws://localhost:0/ws", "test-token", "1.0.0", WithIdentity(identity))
+
+	data, err := json.Marshal(client.buildHeartbeatPayload())
+	if err != nil {
+		t.Fatalf("하트비트 직렬화 실패: %v", err)
+	}
+	want := `"identity":{"id":"br-1","name":"build-mac","labels":{"team":"backend"}}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("하트비트 = %s, want %s 포함", data, want)
+	}
+	if client.Identity() != identity {
+		t.Error("Identity()가 설정한 식별 정보를 반환해야 합니다")
+	}
+}
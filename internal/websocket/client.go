@@ -12,6 +12,7 @@ import (
 	"maps"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,6 +21,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/eventhook"
 )
 
 // Sentinel errors for authentication failures.
@@ -40,6 +43,9 @@ const (
 	// AgentMsgCapabilityUpdate는 Bridge가 백엔드로 전송하는 capabilities 업데이트 메시지 타입입니다.
 	// SPEC-HOTSWAP-001: 인증 파일 변경 시 연결 끊김 없이 capabilities를 업데이트합니다.
 	AgentMsgCapabilityUpdate = "capability_update"
+
+	// AgentMsgFileSync는 Bridge가 백엔드로 전송하는 워크스페이스 파일 변경(diff) 메시지 타입입니다.
+	AgentMsgFileSync = "file_sync"
 )
 
 // KnowledgeSourceBinding is the bridge runtime view of a Knowledge Hub source binding.
@@ -179,15 +185,49 @@ type Client struct {
 	capabilities []string
 	// providerCapabilities는 지원하는 프로바이더 목록입니다 (SPEC-BRIDGE-GATEWAY-001).
 	providerCapabilities map[string]bool
-
-	// conn은 WebSocket 연결입니다.
-	conn *websocket.Conn
+	// providerReadiness는 warm-up이 완료되어 즉시 실행 가능한 프로바이더 목록입니다.
+	// warm-up이 비활성화된 경우 nil이며, agent_connect/heartbeat에서 생략됩니다.
+	providerReadiness map[string]bool
+	// customTools는 agent_connect로 알리는 사용자 정의 로컬 도구 목록입니다.
+	customTools []ws.CustomToolDefinition
+	// readOnly는 agent_connect로 읽기 전용 모드임을 알릴지 여부입니다.
+	readOnly bool
+	// identity는 agent_connect와 하트비트로 보내는 Bridge 식별 정보입니다 (nil이면 생략).
+	identity *ws.BridgeIdentity
+	// eventHooks는 수명 주기 이벤트 훅 실행기입니다 (nil이면 비활성화).
+	eventHooks *eventhook.Dispatcher
+
+	// conn은 현재 연결의 전송 계층입니다 (WebSocket 또는 HTTP 롱폴링).
+	conn Transport
+	// transportMode는 전송 방식 설정입니다 (TransportAuto, TransportWebSocket, TransportHTTP).
+	transportMode string
+	// httpFallbackURL은 HTTP 롱폴링 엔드포인트 주소입니다. 비어 있으면 서버 URL에서 유도합니다.
+	httpFallbackURL string
+	// httpClient는 HTTP 롱폴링 요청에 사용하는 클라이언트입니다.
+	httpClient *http.Client
+	// sender는 현재 연결의 우선순위 송신 큐입니다. conn과 함께 connMu로 보호됩니다.
+	sender *messageSender
 	// connMu는 연결 접근을 보호하는 뮤텍스입니다.
 	connMu sync.RWMutex
 
-	// capMu는 providerCapabilities 접근을 보호하는 뮤텍스입니다.
+	// capMu는 providerCapabilities/providerReadiness 접근을 보호하는 뮤텍스입니다.
 	// SPEC-HOTSWAP-001: UpdateProviderCapabilities와 sendConnect의 동시 접근 보호
 	capMu sync.RWMutex
+	// mcpServeStatus는 하트비트로 알릴 내장 MCP 서버 상태입니다 (string, 비어 있으면 생략).
+	mcpServeStatus atomic.Value
+	// mcpServeInstances는 하트비트로 알릴 실행 중인 MCP serve 인스턴스 목록입니다 ([]ws.MCPServeInstanceStatus).
+	mcpServeInstances atomic.Value
+	// configRevision은 하트비트로 알릴 마지막 config_update revision입니다 (string).
+	configRevision atomic.Value
+	// credentialStatus는 하트비트로 알릴 자격 증명 상태입니다 (nil이면 생략).
+	credentialStatus atomic.Pointer[ws.CredentialStatus]
+	// concurrencySource는 하트비트로 알릴 동시 실행 한도/사용량을 반환합니다 (nil이면 생략).
+	concurrencySource atomic.Pointer[func() *ws.ConcurrencyStatus]
+	// protocolVersion은 agent_connect_ack로 협상된 프로토콜 버전입니다 (string).
+	protocolVersion atomic.Value
+	// protocolShim은 서버가 이전 마이너 버전을 선택했을 때의 메시지 변환기입니다 (현재 버전이면 nil).
+	protocolShim atomic.Pointer[protocolShim]
+
 	// runtimeMu는 bridge runtime context 접근을 보호합니다.
 	runtimeMu sync.RWMutex
 	// runtimeContext는 로컬 workspace root 와 Knowledge Hub binding 상태입니다.
@@ -209,7 +249,7 @@ type Client struct {
 	// lastExecIDMu는 lastExecID 접근을 보호하는 뮤텍스입니다.
 	lastExecIDMu sync.RWMutex
 
-	// writeMu는 WebSocket 쓰기 접근을 보호하는 뮤텍스입니다.
+	// writeMu는 전송 계층 쓰기 접근을 보호하는 뮤텍스입니다.
 	// gorilla/websocket은 동시 쓰기를 지원하지 않으므로 모든 WriteMessage 호출을 직렬화합니다.
 	writeMu sync.Mutex
 
@@ -223,20 +263,37 @@ type Client struct {
 	// lastHeartbeatMu는 lastHeartbeat 접근을 보호하는 뮤텍스입니다.
 	lastHeartbeatMu sync.RWMutex
 
+	// quality는 하트비트 RTT/누락/재연결로 연결 품질을 평가하고 하트비트 간격을 조정합니다.
+	quality *ConnectionQuality
+	// idle은 유휴 절전 모드 상태입니다 (nil이면 비활성화).
+	idle *idleMonitor
+
+	// outbox는 전송이 끝나지 않은 결과 메시지 보관소입니다 (nil이면 비활성화).
+	outbox *Outbox
+
 	// handler는 메시지 핸들러입니다.
 	handler MessageHandler
 
+	// uploadLimiter는 스크린샷, 아티팩트, 대용량 결과 전송의 공유 대역폭 제한입니다. nil이면 제한하지 않습니다.
+	uploadLimiter *UploadLimiter
 	// signer는 HMAC-SHA256 메시지 서명기입니다 (SEC-P2-02).
 	signer *MessageSigner
 
 	// taskTracker는 진행 중인 태스크를 추적합니다 (FR-P2-04).
 	taskTracker *TaskTracker
+	// leaseRenewInterval은 활성 작업 리스 갱신 간격입니다. 0 이하이면 갱신하지 않습니다.
+	leaseRenewInterval time.Duration
 
 	// tokenRefreshFn은 재연결 전 토큰을 갱신하는 콜백입니다.
 	tokenRefreshFn func() (string, error)
 
 	// onAuthFailureFn은 인증 실패로 재연결이 중단될 때 호출되는 콜백입니다.
 	onAuthFailureFn func(error)
+
+	// enableCompression은 permessage-deflate 협상 활성화 여부입니다.
+	enableCompression bool
+	// payloadGzipThreshold는 애플리케이션 레벨 gzip 압축 임계값(바이트)입니다. 0 이하이면 비활성화.
+	payloadGzipThreshold int
 }
 
 // ClientOption은 Client 설정 옵션입니다.
@@ -263,6 +320,13 @@ func WithProviderCapabilities(caps map[string]bool) ClientOption {
 	}
 }
 
+// WithProviderReadiness는 warm-up 결과에 따른 프로바이더 준비 상태를 설정합니다.
+func WithProviderReadiness(readiness map[string]bool) ClientOption {
+	return func(c *Client) {
+		c.providerReadiness = maps.Clone(readiness)
+	}
+}
+
 // WithRuntimeContext sets the local workspace runtime context included in capability snapshots.
 func WithRuntimeContext(runtime *BridgeRuntimeContext) ClientOption {
 	return func(c *Client) {
@@ -277,6 +341,26 @@ func WithWorkspaceID(workspaceID string) ClientOption {
 	}
 }
 
+// WithReadOnlyMode는 agent_connect에 읽기 전용 모드임을 알립니다.
+// 실제 거절은 Router의 WithReadOnly가 처리합니다.
+func WithReadOnlyMode(readOnly bool) ClientOption {
+	return func(c *Client) {
+		c.readOnly = readOnly
+	}
+}
+
+// WithIdentity는 agent_connect와 하트비트에 실을 Bridge 식별 정보(고정 ID, 이름, 라벨)를 설정합니다.
+func WithIdentity(identity *ws.BridgeIdentity) ClientOption {
+	return func(c *Client) {
+		c.identity = identity
+	}
+}
+
+// Identity는 Bridge 식별 정보를 반환합니다 (설정되지 않았으면 nil).
+func (c *Client) Identity() *ws.BridgeIdentity {
+	return c.identity
+}
+
 // WithMessageHandler는 메시지 핸들러를 설정합니다.
 func WithMessageHandler(handler MessageHandler) ClientOption {
 	return func(c *Client) {
@@ -298,15 +382,19 @@ func (c *Client) Signer() *MessageSigner {
 // NewClient는 새로운 WebSocket 클라이언트를 생성합니다.
 func NewClient(serverURL, token, version string, opts ...ClientOption) *Client {
 	c := &Client{
-		serverURL:         serverURL,
-		token:             token,
-		version:           version,
-		capabilities:      []string{"claude"},
-		messages:          make(chan ws.AgentMessage, 500),
-		done:              make(chan struct{}),
-		reconnectStrategy: DefaultReconnectStrategy(),
-		signer:            NewMessageSigner(), // SEC-P2-02
-		taskTracker:       NewTaskTracker(),   // FR-P2-04
+		serverURL:          serverURL,
+		token:              token,
+		version:            version,
+		capabilities:       []string{"claude"},
+		messages:           make(chan ws.AgentMessage, 500),
+		done:               make(chan struct{}),
+		reconnectStrategy:  DefaultReconnectStrategy(),
+		signer:             NewMessageSigner(), // SEC-P2-02
+		taskTracker:        NewTaskTracker(),   // FR-P2-04
+		leaseRenewInterval: DefaultLeaseRenewInterval,
+		quality:            NewConnectionQuality(HeartbeatInterval),
+		transportMode:      TransportAuto,
+		httpClient:         defaultHTTPTransportClient,
 	}
 
 	for _, opt := range opts {
@@ -320,6 +408,7 @@ func NewClient(serverURL, token, version string, opts ...ClientOption) *Client {
 // Connect는 WebSocket 서버에 연결합니다.
 // REQ-E-01: connect 커맨드 시 WebSocket 연결
 // FR-P2-02: 메시지 기반 JWT 인증 (URL에서 토큰 제거)
+// WebSocket이 차단된 네트워크에서는 HTTP 롱폴링으로 전환합니다 (dialTransport 참고).
 //
 // State flow: Disconnected -> Connecting -> Authenticating -> Connected
 func (c *Client) Connect(ctx context.Context) error {
@@ -333,36 +422,12 @@ func (c *Client) Connect(ctx context.Context) error {
 	connectCtx, cancel := context.WithTimeout(ctx, ConnectTimeout)
 	defer cancel()
 
-	// FR-P2-02: 토큰을 URL에 포함하지 않음
-	u, err := url.Parse(c.serverURL)
+	conn, err := c.dialTransport(connectCtx)
 	if err != nil {
 		c.state.Store(int32(StateDisconnected))
-		return fmt.Errorf("서버 URL 파싱 실패: %w", err)
-	}
-
-	// WebSocket 다이얼
-	dialer := websocket.Dialer{
-		HandshakeTimeout: ConnectTimeout,
+		return err
 	}
 
-	conn, _, err := dialer.DialContext(connectCtx, u.String(), nil)
-	if err != nil {
-		c.state.Store(int32(StateDisconnected))
-		return fmt.Errorf("WebSocket 연결 실패: %w", err)
-	}
-
-	// 연결 설정
-	conn.SetReadLimit(MaxMessageSize)
-
-	// 서버 PING 메시지 처리 - PONG 응답 전송 및 연결 활성 상태 유지
-	conn.SetPingHandler(func(appData string) error {
-		_ = conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
-		if err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(WriteTimeout)); err != nil {
-			return err
-		}
-		return nil
-	})
-
 	c.connMu.Lock()
 	c.conn = conn
 	c.connMu.Unlock()
@@ -384,6 +449,9 @@ func (c *Client) Connect(ctx context.Context) error {
 		return fmt.Errorf("인증 실패: %w", err)
 	}
 
+	// 연결 상태로 전환하기 전에 송신 루프를 시작하여 Send가 항상 큐를 사용할 수 있게 한다.
+	c.startSender(conn)
+
 	c.state.Store(int32(StateConnected))
 	c.reconnectStrategy.Reset()
 
@@ -399,6 +467,14 @@ func (c *Client) Connect(ctx context.Context) error {
 	}()
 	go c.readLoop(readCtx)
 
+	// 이전 연결이나 이전 프로세스에서 전송하지 못한 결과 재전송
+	go c.flushOutbox()
+
+	c.fireEvent(eventhook.EventConnected, map[string]string{
+		"server_url":   c.serverURL,
+		"workspace_id": c.workspaceID,
+		"transport":    conn.Name(),
+	})
 	return nil
 }
 
@@ -416,7 +492,7 @@ func (c *Client) waitForAuthAck() error {
 	// 인증 응답 대기 타임아웃 설정
 	_ = conn.SetReadDeadline(time.Now().Add(AuthTimeout))
 
-	_, data, err := conn.ReadMessage()
+	data, err := conn.ReadMessage()
 	if err != nil {
 		return fmt.Errorf("인증 응답 수신 실패: %w", err)
 	}
@@ -461,11 +537,8 @@ func (c *Client) waitForAuthAck() error {
 			return fmt.Errorf("HMAC 시크릿 설정 실패: %w", err)
 		}
 	}
-	if ackPayload.ProtocolVersion != "" && !ws.IsCompatibleProtocolVersion(ackPayload.ProtocolVersion) {
-		return fmt.Errorf("server protocol version mismatch: server=%s client=%s", ackPayload.ProtocolVersion, ws.AgentProtocolVersion)
-	}
 
-	return nil
+	return c.negotiateProtocol(ackPayload.ProtocolVersion)
 }
 
 // sendConnect는 연결 메시지를 전송합니다.
@@ -479,6 +552,7 @@ func (c *Client) sendConnect() error {
 	// SPEC-HOTSWAP-001: capMu로 보호된 최신 providerCapabilities 읽기
 	c.capMu.RLock()
 	providerCaps := c.providerCapabilities
+	providerReadiness := maps.Clone(c.providerReadiness)
 	c.capMu.RUnlock()
 	c.runtimeMu.RLock()
 	runtimeCtx := cloneRuntimeContext(c.runtimeContext)
@@ -486,18 +560,24 @@ func (c *Client) sendConnect() error {
 
 	payload := struct {
 		ws.AgentConnectPayload
-		RuntimeContext *BridgeRuntimeContext `json:"runtime_context,omitempty"`
+		ProviderReadiness map[string]bool       `json:"provider_readiness,omitempty"`
+		RuntimeContext    *BridgeRuntimeContext `json:"runtime_context,omitempty"`
 	}{
 		AgentConnectPayload: ws.AgentConnectPayload{
-			Version:              c.version,
-			ProtocolVersion:      ws.AgentProtocolVersion,
-			Capabilities:         c.capabilities,
-			ProviderCapabilities: providerCaps,
-			WorkspaceID:          c.workspaceID,
-			LastExecID:           lastExecID,
-			Token:                c.token,
+			Version:                   c.version,
+			ProtocolVersion:           ws.AgentProtocolVersion,
+			SupportedProtocolVersions: ws.SupportedAgentProtocolVersions(),
+			Capabilities:              c.capabilities,
+			ProviderCapabilities:      providerCaps,
+			WorkspaceID:               c.workspaceID,
+			CustomTools:               c.customTools,
+			LastExecID:                lastExecID,
+			Token:                     c.token,
+			ReadOnly:                  c.readOnly,
+			Identity:                  c.identity,
 		},
-		RuntimeContext: runtimeCtx,
+		ProviderReadiness: providerReadiness,
+		RuntimeContext:    runtimeCtx,
 	}
 
 	// sendMessage 대신 직접 전송 (연결 과정 중이므로)
@@ -521,14 +601,14 @@ func (c *Client) sendConnect() error {
 		return errors.New("연결이 없습니다")
 	}
 
-	data, err := json.Marshal(msg)
+	// 서명은 원본 페이로드 기준이며, 수신 측은 압축 해제 후 검증합니다.
+	data, err := c.encodeMessage(msg)
 	if err != nil {
 		return fmt.Errorf("메시지 직렬화 실패: %w", err)
 	}
 
 	c.writeMu.Lock()
-	_ = conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
-	err = conn.WriteMessage(websocket.TextMessage, data)
+	err = conn.WriteMessage(data)
 	c.writeMu.Unlock()
 
 	if err != nil {
@@ -557,6 +637,7 @@ func (c *Client) Disconnect(reason string) error {
 
 	// 연결 종료
 	c.closeConnection()
+	c.fireEvent(eventhook.EventDisconnected, map[string]string{"reason": reason})
 
 	// done 채널 닫기
 	select {
@@ -569,37 +650,81 @@ func (c *Client) Disconnect(reason string) error {
 	return nil
 }
 
-// closeConnection은 WebSocket 연결을 닫습니다.
+// closeConnection은 현재 연결을 닫습니다.
 func (c *Client) closeConnection() {
 	c.connMu.Lock()
 	defer c.connMu.Unlock()
 
+	// 송신 루프를 먼저 멈춰 대기 중인 메시지가 닫히는 연결에 기록되지 않게 한다.
+	if c.sender != nil {
+		c.sender.stop()
+		c.sender = nil
+	}
+
 	if c.conn != nil {
 		c.writeMu.Lock()
-		_ = c.conn.WriteMessage(
-			websocket.CloseMessage,
-			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
-		)
-		c.writeMu.Unlock()
 		_ = c.conn.Close()
+		c.writeMu.Unlock()
 		c.conn = nil
 	}
 }
 
+// startSender는 연결에 대한 우선순위 송신 루프를 시작합니다.
+// 이전 연결의 송신 루프가 남아 있으면 종료합니다.
+func (c *Client) startSender(conn Transport) {
+	sender := newMessageSender()
+
+	c.connMu.Lock()
+	if c.sender != nil {
+		c.sender.stop()
+	}
+	c.sender = sender
+	c.connMu.Unlock()
+
+	go sender.run(func(data []byte) error {
+		c.writeMu.Lock()
+		err := conn.WriteMessage(data)
+		c.writeMu.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("메시지 전송 실패: %w", err)
+		}
+		return nil
+	})
+}
+
 // Send는 메시지를 서버로 전송합니다.
+// 메시지는 타입과 크기에 따른 우선순위 큐를 거쳐 송신 루프에서 기록되며,
+// 실제 전송이 끝날 때까지 대기합니다.
+// 아웃박스가 설정되어 있으면 작업 결과 메시지는 전송에 실패해도 보관했다가 다음 연결에서 다시 전송합니다.
 func (c *Client) Send(msg ws.AgentMessage) error {
+	if c.outbox != nil && isDurableMessage(msg.Type) {
+		return c.sendDurable(msg)
+	}
+	return c.send(msg)
+}
+
+// send는 메시지를 서명/직렬화하여 현재 연결의 송신 큐로 전송합니다.
+func (c *Client) send(msg ws.AgentMessage) error {
 	if c.State() != StateConnected {
 		return errors.New("연결되지 않은 상태입니다")
 	}
 
 	c.connMu.RLock()
 	conn := c.conn
+	sender := c.sender
 	c.connMu.RUnlock()
 
-	if conn == nil {
+	if conn == nil || sender == nil {
 		return errors.New("연결이 없습니다")
 	}
 
+	// 서버가 이전 마이너 버전을 선택했으면 해당 버전 형식으로 변환하고, 처리하지 못하는 메시지는 보내지 않습니다.
+	msg, ok := c.applyOutboundShim(msg)
+	if !ok {
+		return nil
+	}
+
 	// SEC-P2-02: 중요 메시지에 HMAC-SHA256 서명 추가
 	if c.signer != nil {
 		if err := c.signer.Sign(&msg); err != nil {
@@ -607,21 +732,18 @@ func (c *Client) Send(msg ws.AgentMessage) error {
 		}
 	}
 
-	data, err := json.Marshal(msg)
+	// 서명은 원본 페이로드 기준이며, 수신 측은 압축 해제 후 검증합니다.
+	data, err := c.encodeMessage(msg)
 	if err != nil {
 		return fmt.Errorf("메시지 직렬화 실패: %w", err)
 	}
 
-	c.writeMu.Lock()
-	_ = conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
-	err = conn.WriteMessage(websocket.TextMessage, data)
-	c.writeMu.Unlock()
-
-	if err != nil {
-		return fmt.Errorf("메시지 전송 실패: %w", err)
+	// 스크린샷, 아티팩트, 대용량 결과는 공유 업로드 대역폭을 확보한 뒤 큐에 넣는다.
+	if err := c.waitUploadBandwidth(sender, msg.Type, len(data)); err != nil {
+		return err
 	}
 
-	return nil
+	return sender.send(messagePriority(msg.Type, len(data)), data)
 }
 
 // sendMessage는 타입과 페이로드로 메시지를 생성하여 전송합니다.
@@ -670,6 +792,7 @@ func (c *Client) State() ConnectionState {
 
 // StartHeartbeat는 하트비트 전송을 시작합니다.
 // REQ-E-06: 30초 간격 하트비트
+// 활성 작업의 리스 갱신 루프도 같은 컨텍스트로 함께 시작합니다.
 func (c *Client) StartHeartbeat(ctx context.Context) {
 	c.heartbeatMu.Lock()
 	if c.heartbeatCancel != nil {
@@ -685,6 +808,7 @@ func (c *Client) StartHeartbeat(ctx context.Context) {
 	c.lastHeartbeatMu.Unlock()
 
 	go c.heartbeatLoop(heartbeatCtx)
+	go c.leaseLoop(heartbeatCtx)
 }
 
 // stopHeartbeat는 하트비트 전송을 중지합니다.
@@ -699,9 +823,11 @@ func (c *Client) stopHeartbeat() {
 }
 
 // heartbeatLoop는 주기적으로 하트비트를 전송합니다.
+// 간격은 연결 품질과 유휴 절전 상태에 따라 매 전송마다 다시 정하며,
+// 절전 중 메시지를 받아 깨어나면 기다리지 않고 바로 하트비트를 보냅니다.
 func (c *Client) heartbeatLoop(ctx context.Context) {
-	ticker := time.NewTicker(HeartbeatInterval)
-	defer ticker.Stop()
+	timer := time.NewTimer(c.heartbeatInterval())
+	defer timer.Stop()
 
 	for {
 		select {
@@ -709,35 +835,85 @@ func (c *Client) heartbeatLoop(ctx context.Context) {
 			return
 		case <-c.done:
 			return
-		case <-ticker.C:
-			if c.State() != StateConnected {
-				continue
+		case <-c.idleWake():
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
 			}
+		case <-timer.C:
+		}
 
-			// 하트비트 타임아웃 확인
-			c.lastHeartbeatMu.RLock()
-			lastHeartbeat := c.lastHeartbeat
-			c.lastHeartbeatMu.RUnlock()
+		if c.idle != nil {
+			c.idle.check(c.taskTracker.GetActiveTaskCount())
+		}
+		interval := c.heartbeatInterval()
+		timer.Reset(interval)
+		if c.State() != StateConnected {
+			continue
+		}
 
-			if !lastHeartbeat.IsZero() && time.Since(lastHeartbeat) > HeartbeatTimeout {
-				// 하트비트 타임아웃 - 재연결 시도
-				go c.handleDisconnect(ctx, "하트비트 타임아웃")
-				return
-			}
+		// 하트비트 타임아웃 확인
+		c.lastHeartbeatMu.RLock()
+		lastHeartbeat := c.lastHeartbeat
+		c.lastHeartbeatMu.RUnlock()
 
-			// 하트비트 전송
-			payload := ws.AgentHeartbeatPayload{
-				Timestamp: time.Now(),
-			}
-			if err := c.sendMessage(ws.AgentMsgHeartbeat, payload); err != nil {
-				// 전송 실패 시 재연결 시도
-				go c.handleDisconnect(ctx, fmt.Sprintf("하트비트 전송 실패: %v", err))
-				return
-			}
+		if !lastHeartbeat.IsZero() && time.Since(lastHeartbeat) > heartbeatTimeout(interval) {
+			// 하트비트 타임아웃 - 재연결 시도
+			go c.handleDisconnect(ctx, "하트비트 타임아웃")
+			return
+		}
+
+		// 하트비트 전송
+		c.quality.RecordHeartbeatSent()
+		if err := c.sendMessage(ws.AgentMsgHeartbeat, c.buildHeartbeatPayload()); err != nil {
+			// 전송 실패 시 재연결 시도
+			go c.handleDisconnect(ctx, fmt.Sprintf("하트비트 전송 실패: %v", err))
+			return
 		}
 	}
 }
 
+// heartbeatPayload는 프로바이더 준비 상태와 유휴 절전 상태가 추가된 하트비트 페이로드입니다.
+type heartbeatPayload struct {
+	ws.AgentHeartbeatPayload
+	ProviderReadiness map[string]bool `json:"provider_readiness,omitempty"`
+	// Idle은 유휴 절전 모드 여부입니다. 서버는 NextHeartbeatSec 동안 다음 하트비트를 기다려야 합니다.
+	Idle bool `json:"idle,omitempty"`
+	// NextHeartbeatSec은 다음 하트비트까지의 간격(초)입니다.
+	NextHeartbeatSec int `json:"next_heartbeat_sec,omitempty"`
+}
+
+// buildHeartbeatPayload는 현재 상태로 하트비트 페이로드를 구성합니다.
+func (c *Client) buildHeartbeatPayload() heartbeatPayload {
+	c.capMu.RLock()
+	readiness := maps.Clone(c.providerReadiness)
+	c.capMu.RUnlock()
+	status, _ := c.mcpServeStatus.Load().(string)
+	instances, _ := c.mcpServeInstances.Load().([]ws.MCPServeInstanceStatus)
+	revision, _ := c.configRevision.Load().(string)
+	var concurrency *ws.ConcurrencyStatus
+	if fn := c.concurrencySource.Load(); fn != nil {
+		concurrency = (*fn)()
+	}
+
+	return heartbeatPayload{
+		AgentHeartbeatPayload: ws.AgentHeartbeatPayload{
+			Timestamp:         time.Now(),
+			MCPServeStatus:    status,
+			MCPServeInstances: instances,
+			ConfigRevision:    revision,
+			Credentials:       c.credentialStatus.Load(),
+			Concurrency:       concurrency,
+			Identity:          c.identity,
+		},
+		ProviderReadiness: readiness,
+		Idle:              c.IsIdle(),
+		NextHeartbeatSec:  int(c.heartbeatInterval() / time.Second),
+	}
+}
+
 // readLoop는 메시지를 지속적으로 수신합니다.
 // gorilla/websocket은 ReadMessage() 에러 후 재시도 시 panic하므로,
 // 에러 발생 시 즉시 루프를 종료하고 재연결을 시도합니다.
@@ -769,7 +945,7 @@ func (c *Client) readLoop(ctx context.Context) {
 		// gorilla/websocket은 ReadMessage() 에러 후 동일 conn에서 재시도하면 panic합니다.
 		// ReadDeadline을 사용하지 않고 blocking read를 하되,
 		// 종료 시 conn.Close()로 ReadMessage()를 unblock합니다.
-		_, data, err := conn.ReadMessage()
+		data, err := conn.ReadMessage()
 		if err != nil {
 			// 동일 사용자의 새 브리지 연결로 교체된 경우에는 재연결하지 않고 종료합니다.
 			if isReplacedByNewConnectionClose(err) {
@@ -783,8 +959,8 @@ func (c *Client) readLoop(ctx context.Context) {
 			return
 		}
 
-		var msg ws.AgentMessage
-		if err := json.Unmarshal(data, &msg); err != nil {
+		msg, err := decodeMessage(data)
+		if err != nil {
 			continue
 		}
 
@@ -799,9 +975,13 @@ func (c *Client) readLoop(ctx context.Context) {
 			c.lastHeartbeatMu.Lock()
 			c.lastHeartbeat = time.Now()
 			c.lastHeartbeatMu.Unlock()
+			c.quality.RecordHeartbeatAck()
 			continue
 		}
 
+		// 하트비트 외 수신 메시지는 활동으로 기록하고 절전 중이면 즉시 깨어납니다.
+		c.recordInboundActivity()
+
 		// SEC-P2-02: 수신된 중요 메시지의 HMAC-SHA256 서명 검증
 		if c.signer != nil && !c.signer.Verify(&msg) {
 			// 서명 검증 실패 시 메시지 무시
@@ -873,6 +1053,21 @@ func (c *Client) UpdateProviderCapabilities(caps map[string]bool) {
 	}
 }
 
+// UpdateProviderReadiness는 프로바이더 준비 상태를 갱신합니다.
+// 변경된 값은 다음 하트비트와 재연결 시 agent_connect에 반영됩니다.
+func (c *Client) UpdateProviderReadiness(readiness map[string]bool) {
+	c.capMu.Lock()
+	defer c.capMu.Unlock()
+	c.providerReadiness = maps.Clone(readiness)
+}
+
+// ProviderReadiness는 현재 프로바이더 준비 상태의 복사본을 반환합니다.
+func (c *Client) ProviderReadiness() map[string]bool {
+	c.capMu.RLock()
+	defer c.capMu.RUnlock()
+	return maps.Clone(c.providerReadiness)
+}
+
 // UpdateRuntimeContext updates the workspace-root aware runtime snapshot.
 func (c *Client) UpdateRuntimeContext(runtime *BridgeRuntimeContext) {
 	c.runtimeMu.Lock()
@@ -954,6 +1149,16 @@ func (c *Client) SetTokenRefreshFunc(fn func() (string, error)) {
 	c.tokenRefreshFn = fn
 }
 
+// ConnectionQuality는 현재 연결 품질을 반환합니다.
+func (c *Client) ConnectionQuality() QualitySnapshot {
+	return c.quality.Snapshot()
+}
+
+// SetOnQualityChange는 연결 품질 점수나 등급이 바뀔 때 호출되는 콜백을 설정합니다.
+func (c *Client) SetOnQualityChange(fn func(QualitySnapshot)) {
+	c.quality.SetOnChange(fn)
+}
+
 // SetOnAuthFailure는 인증 실패로 재연결이 중단될 때 호출되는 콜백을 설정합니다.
 func (c *Client) SetOnAuthFailure(fn func(error)) {
 	c.onAuthFailureFn = fn
@@ -970,7 +1175,11 @@ func (c *Client) handleDisconnect(ctx context.Context, reason string) {
 	}
 
 	log.Printf("[STABILITY] 연결 끊김 감지: %s", reason)
+	if c.quality != nil {
+		c.quality.RecordReconnect()
+	}
 	c.closeConnection()
+	c.fireEvent(eventhook.EventDisconnected, map[string]string{"reason": reason, "reconnecting": "true"})
 
 	// 재연결 시도
 	for c.reconnectStrategy.CanRetry() {
@@ -1041,6 +1250,36 @@ func (c *Client) handleDisconnect(ctx context.Context, reason string) {
 	// 재연결 실패
 	log.Printf("[STABILITY] 최대 재연결 시도 소진 - 연결 종료")
 	c.state.Store(int32(StateDisconnected))
+	c.fireEvent(eventhook.EventReconnectExhausted, map[string]string{
+		"reason":   reason,
+		"attempts": strconv.Itoa(c.reconnectStrategy.MaxAttempts()),
+	})
+}
+
+// setMCPServeInstances는 하트비트로 알릴 MCP serve 인스턴스 목록을 설정합니다.
+// 하나라도 실행 중이면 mcp_serve_status는 running, 모두 중지되면 stopped입니다.
+func (c *Client) setMCPServeInstances(instances []ws.MCPServeInstanceStatus) {
+	status := mcpServeStatusStopped
+	if len(instances) > 0 {
+		status = mcpServeStatusRunning
+	}
+	c.mcpServeInstances.Store(instances)
+	c.mcpServeStatus.Store(status)
+}
+
+// setConfigRevision은 하트비트로 알릴 마지막 config_update revision을 설정합니다.
+func (c *Client) setConfigRevision(revision string) {
+	c.configRevision.Store(revision)
+}
+
+// SetCredentialStatus는 하트비트로 알릴 자격 증명 상태를 설정합니다. nil이면 하트비트에서 생략합니다.
+func (c *Client) SetCredentialStatus(status *ws.CredentialStatus) {
+	c.credentialStatus.Store(status)
+}
+
+// setConcurrencySource는 하트비트로 알릴 동시 실행 한도/사용량 조회 함수를 설정합니다.
+func (c *Client) setConcurrencySource(fn func() *ws.ConcurrencyStatus) {
+	c.concurrencySource.Store(&fn)
 }
 
 // SetLastExecID는 마지막으로 처리한 실행 ID를 설정합니다.
@@ -1071,7 +1310,7 @@ func (c *Client) SendTaskResult(payload ws.TaskResultPayload) error {
 // SendTaskError는 작업 오류를 서버로 전송합니다.
 func (c *Client) SendTaskError(payload ws.TaskErrorPayload) error {
 	c.SetLastExecID(payload.ExecutionID)
-	return c.sendMessage(ws.AgentMsgTaskError, payload)
+	return c.sendMessage(ws.AgentMsgTaskError, errcode.TaskError(payload))
 }
 
 // SendBuildResult는 빌드 결과를 서버로 전송합니다 (FR-P3-01).
@@ -1098,6 +1337,11 @@ func (c *Client) SendComputerResult(payload ws.ComputerResultPayload) error {
 	return c.sendMessage(ws.AgentMsgComputerResult, payload)
 }
 
+// SendCLIOutput은 CLI 실행 중 출력 배치를 cli_request 메시지 ID로 전송합니다.
+func (c *Client) SendCLIOutput(msgID string, payload ws.CLIOutputPayload) error {
+	return c.sendMessageWithID(ws.AgentMsgCLIOutput, msgID, payload)
+}
+
 // SendMCPCodegenProgress는 코드 생성 진행 상황을 서버로 전송합니다 (SPEC-SELF-EXPAND-001).
 func (c *Client) SendMCPCodegenProgress(msgID string, payload ws.MCPCodegenProgressPayload) error {
 	return c.sendMessageWithID(ws.AgentMsgMCPCodegenProgress, msgID, payload)
@@ -1118,8 +1362,19 @@ func (c *Client) SendMCPHealthReport(payload ws.MCPHealthReportPayload) error {
 	return c.sendMessage(ws.AgentMsgMCPHealthReport, payload)
 }
 
+// SendMCPServerEvent는 MCP 서버 알림 요약을 서버로 전송합니다.
+func (c *Client) SendMCPServerEvent(payload ws.MCPServerEventPayload) error {
+	return c.sendMessage(ws.AgentMsgMCPServerEvent, payload)
+}
+
 // SendToolApprovalRequest는 도구 승인 요청을 서버로 전송합니다 (SPEC-INTERACTIVE-CLI-001).
 func (c *Client) SendToolApprovalRequest(payload ws.ToolApprovalRequestPayload) error {
+	c.fireEvent(eventhook.EventApprovalRequired, map[string]string{
+		"execution_id": payload.ExecutionID,
+		"approval_id":  payload.ApprovalID,
+		"provider":     payload.ProviderName,
+		"tool":         payload.ToolName,
+	})
 	return c.sendMessage(ws.AgentMsgToolApprovalReq, payload)
 }
 
@@ -1137,7 +1392,7 @@ func (c *Client) SendAgentResponseComplete(payload ws.AgentResponseCompletePaylo
 // SendAgentResponseError는 에이전트 응답 에러를 서버로 전송합니다 (SPEC-BRIDGE-GATEWAY-001).
 func (c *Client) SendAgentResponseError(payload ws.AgentResponseErrorPayload) error {
 	c.SetLastExecID(payload.ExecutionID)
-	return c.sendMessage(ws.AgentMsgAgentResponseError, payload)
+	return c.sendMessage(ws.AgentMsgAgentResponseError, errcode.AgentResponseError(payload))
 }
 
 // sendMessageWithID는 특정 ID를 가진 메시지를 생성하여 전송합니다.
@@ -0,0 +1,120 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 대용량 페이로드(작업 결과, 코드 생성 결과 등)의 전송량을 줄이기 위한 압축 로직.
+package websocket
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// PayloadEncodingGzip은 gzip+base64로 인코딩된 페이로드를 나타내는 envelope encoding 값입니다.
+const PayloadEncodingGzip = "gzip"
+
+// encodedAgentMessage는 애플리케이션 레벨 압축 여부를 표시하는 encoding 필드가 추가된 envelope입니다.
+// Encoding이 "gzip"이면 Payload는 gzip 압축 후 base64 인코딩한 JSON 문자열입니다.
+type encodedAgentMessage struct {
+	ws.AgentMessage
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// WithCompression은 permessage-deflate 협상 활성화 여부를 설정합니다.
+// 서버가 확장을 지원하지 않으면 압축 없이 연결됩니다.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.enableCompression = enabled
+	}
+}
+
+// WithPayloadGzipThreshold는 애플리케이션 레벨 gzip 압축 임계값(바이트)을 설정합니다.
+// 페이로드가 임계값을 초과하면 gzip으로 압축하고 envelope에 encoding을 표시합니다.
+// 0 이하이면 비활성화됩니다.
+func WithPayloadGzipThreshold(threshold int) ClientOption {
+	return func(c *Client) {
+		c.payloadGzipThreshold = threshold
+	}
+}
+
+// encodeMessage는 전송할 메시지를 직렬화합니다.
+// 페이로드가 임계값을 초과하고 압축 결과가 더 작을 때만 gzip envelope으로 전송합니다.
+func (c *Client) encodeMessage(msg ws.AgentMessage) ([]byte, error) {
+	if c.payloadGzipThreshold <= 0 || len(msg.Payload) <= c.payloadGzipThreshold {
+		return json.Marshal(msg)
+	}
+
+	compressed, err := gzipPayload(msg.Payload)
+	if err != nil || len(compressed) >= len(msg.Payload) {
+		return json.Marshal(msg)
+	}
+
+	env := encodedAgentMessage{AgentMessage: msg, Encoding: PayloadEncodingGzip}
+	env.Payload = compressed
+	return json.Marshal(env)
+}
+
+// decodeMessage는 수신한 원시 데이터를 메시지로 역직렬화하고 압축된 페이로드를 해제합니다.
+func decodeMessage(data []byte) (ws.AgentMessage, error) {
+	var env encodedAgentMessage
+	if err := json.Unmarshal(data, &env); err != nil {
+		return ws.AgentMessage{}, err
+	}
+
+	switch env.Encoding {
+	case "":
+		return env.AgentMessage, nil
+	case PayloadEncodingGzip:
+		payload, err := gunzipPayload(env.Payload)
+		if err != nil {
+			return ws.AgentMessage{}, err
+		}
+		msg := env.AgentMessage
+		msg.Payload = payload
+		return msg, nil
+	default:
+		return ws.AgentMessage{}, fmt.Errorf("지원하지 않는 페이로드 encoding: %s", env.Encoding)
+	}
+}
+
+// gzipPayload는 페이로드를 gzip 압축한 뒤 base64 JSON 문자열로 반환합니다.
+func gzipPayload(payload []byte) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, fmt.Errorf("페이로드 압축 실패: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("페이로드 압축 실패: %w", err)
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// gunzipPayload는 base64 JSON 문자열로 인코딩된 gzip 페이로드를 해제합니다.
+func gunzipPayload(raw json.RawMessage) (json.RawMessage, error) {
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err != nil {
+		return nil, fmt.Errorf("압축 페이로드 형식 오류: %w", err)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("압축 페이로드 base64 디코딩 실패: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("압축 페이로드 해제 실패: %w", err)
+	}
+	defer zr.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(zr, MaxMessageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("압축 페이로드 해제 실패: %w", err)
+	}
+	if len(payload) > MaxMessageSize {
+		return nil, fmt.Errorf("압축 해제된 페이로드가 최대 크기(%d)를 초과합니다", MaxMessageSize)
+	}
+	return payload, nil
+}
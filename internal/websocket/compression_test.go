@@ -0,0 +1,86 @@
+// Package websocket - 메시지 압축 테스트
+package websocket
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressionTestMessage(t *testing.T, output string) ws.AgentMessage {
+	t.Helper()
+	payload, err := json.Marshal(ws.TaskResultPayload{ExecutionID: "exec-1", Output: output})
+	require.NoError(t, err)
+	return ws.AgentMessage{
+		Type:      ws.AgentMsgTaskResult,
+		ID:        "msg-1",
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+}
+
+// TestEncodeMessage_BelowThreshold는 임계값 이하 페이로드는 압축하지 않는지 검증합니다.
+func TestEncodeMessage_BelowThreshold(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0", WithPayloadGzipThreshold(1024))
+	msg := newCompressionTestMessage(t, "short")
+
+	data, err := client.encodeMessage(msg)
+	require.NoError(t, err)
+
+	var env encodedAgentMessage
+	require.NoError(t, json.Unmarshal(data, &env))
+	assert.Empty(t, env.Encoding, "임계값 이하 페이로드는 encoding이 없어야 함")
+	assert.JSONEq(t, string(msg.Payload), string(env.Payload))
+}
+
+// TestEncodeMessage_GzipRoundTrip은 임계값 초과 페이로드가 gzip으로 압축되고 복원되는지 검증합니다.
+func TestEncodeMessage_GzipRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0", WithPayloadGzipThreshold(1024))
+	msg := newCompressionTestMessage(t, strings.Repeat("autopus bridge output line\n", 500))
+
+	data, err := client.encodeMessage(msg)
+	require.NoError(t, err)
+	assert.Less(t, len(data), len(msg.Payload), "압축된 메시지가 원본 페이로드보다 작아야 함")
+
+	var env encodedAgentMessage
+	require.NoError(t, json.Unmarshal(data, &env))
+	assert.Equal(t, PayloadEncodingGzip, env.Encoding)
+
+	decoded, err := decodeMessage(data)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Type, decoded.Type)
+	assert.Equal(t, msg.ID, decoded.ID)
+	assert.JSONEq(t, string(msg.Payload), string(decoded.Payload))
+}
+
+// TestEncodeMessage_Disabled는 임계값이 0이면 압축하지 않는지 검증합니다.
+func TestEncodeMessage_Disabled(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	msg := newCompressionTestMessage(t, strings.Repeat("x", 10000))
+
+	data, err := client.encodeMessage(msg)
+	require.NoError(t, err)
+
+	var env encodedAgentMessage
+	require.NoError(t, json.Unmarshal(data, &env))
+	assert.Empty(t, env.Encoding)
+}
+
+// TestDecodeMessage_UnknownEncoding은 알 수 없는 encoding이 에러를 반환하는지 검증합니다.
+func TestDecodeMessage_UnknownEncoding(t *testing.T) {
+	t.Parallel()
+
+	_, err := decodeMessage([]byte(`{"type":"task_request","id":"1","payload":"abc","encoding":"br"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+// Package websocket - 서버 주도 설정 변경(config_update)과 작업 동시 실행 제한
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+// ConfigUpdater는 서버가 보낸 config_update를 로컬 허용 범위 안에서 적용합니다.
+type ConfigUpdater interface {
+	// ApplyConfigUpdate는 허용된 설정을 적용하고 적용/거부 결과와 유효 값을 반환합니다.
+	ApplyConfigUpdate(update ws.ConfigUpdatePayload) ws.ConfigUpdateAckPayload
+}
+
+// WithConfigUpdater는 config_update 메시지를 적용할 ConfigUpdater를 설정합니다.
+// 설정하지 않으면 모든 config_update 설정을 거부합니다.
+func WithConfigUpdater(updater ConfigUpdater) RouterOption {
+	return func(r *Router) {
+		r.configUpdater = updater
+	}
+}
+
+// handleConfigUpdate는 서버의 config_update를 적용하고 config_update_ack로 응답합니다.
+func (r *Router) handleConfigUpdate(ctx context.Context, msg ws.AgentMessage) error {
+	var update ws.ConfigUpdatePayload
+	if err := json.Unmarshal(msg.Payload, &update); err != nil {
+		return fmt.Errorf("config_update 페이로드 파싱 실패: %w", err)
+	}
+
+	var ack ws.ConfigUpdateAckPayload
+	if r.configUpdater != nil {
+		ack = r.configUpdater.ApplyConfigUpdate(update)
+	} else {
+		ack = rejectConfigUpdate(update, "이 Bridge는 원격 설정 변경을 허용하지 않습니다")
+	}
+	ack.Revision = update.Revision
+	r.client.setConfigRevision(update.Revision)
+
+	if len(ack.Rejected) > 0 {
+		log.Printf("[config-update] 일부 설정 거부: revision=%s applied=%v rejected=%v", update.Revision, ack.Applied, ack.Rejected)
+	} else {
+		log.Printf("[config-update] 설정 적용: revision=%s applied=%v", update.Revision, ack.Applied)
+	}
+	return r.client.sendMessageWithID(ws.AgentMsgConfigUpdateAck, msg.ID, ack)
+}
+
+// rejectConfigUpdate는 update에 포함된 모든 설정을 reason으로 거부한 응답을 만듭니다.
+func rejectConfigUpdate(update ws.ConfigUpdatePayload, reason string) ws.ConfigUpdateAckPayload {
+	var ack ws.ConfigUpdateAckPayload
+	reject := func(setting string) {
+		ack.Rejected = append(ack.Rejected, ws.ConfigUpdateRejection{Setting: setting, Reason: reason})
+	}
+	if update.MaxConcurrentTasks != nil {
+		reject(ws.ConfigSettingMaxConcurrentTasks)
+	}
+	if update.AllowedTools != nil {
+		reject(ws.ConfigSettingAllowedTools)
+	}
+	if update.LogLevel != "" {
+		reject(ws.ConfigSettingLogLevel)
+	}
+	return ack
+}
+
+// SetMaxConcurrentTasks는 동시에 실행할 task/agent_response/build/test 요청 수를 제한합니다.
+// 0이면 제한하지 않습니다. 실행 중인 작업은 중단하지 않으며, 제한을 낮추면 새 작업이 우선순위 순서로 대기합니다.
+func (r *Router) SetMaxConcurrentTasks(limit int) {
+	r.taskSlots.setLimit(limit)
+}
+
+// SetWorkspaceConcurrency는 워크스페이스별 동시 실행 한도를 설정합니다.
+// perWorkspace는 모든 워크스페이스의 기본 한도이고 quotas는 워크스페이스 ID별 재정의입니다 (0 = 제한 없음).
+// 한 워크스페이스가 한도에 걸리면 다른 워크스페이스의 대기 요청이 먼저 빈 슬롯을 받습니다.
+func (r *Router) SetWorkspaceConcurrency(perWorkspace int, quotas map[string]int) {
+	r.taskSlots.setWorkspaceLimits(perWorkspace, quotas)
+}
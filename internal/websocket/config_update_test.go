@@ -0,0 +1,122 @@
+// Package websocket - 서버 주도 설정 변경과 작업 동시 실행 제한 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConfigUpdater는 동시 작업 수만 적용하는 테스트용 ConfigUpdater입니다.
+type fakeConfigUpdater struct {
+	router *Router
+}
+
+func (f *fakeConfigUpdater) ApplyConfigUpdate(update ws.ConfigUpdatePayload) ws.ConfigUpdateAckPayload {
+	ack := rejectConfigUpdate(ws.ConfigUpdatePayload{LogLevel: update.LogLevel}, "not allowed")
+	if update.MaxConcurrentTasks != nil {
+		f.router.SetMaxConcurrentTasks(*update.MaxConcurrentTasks)
+		ack.Applied = append(ack.Applied, ws.ConfigSettingMaxConcurrentTasks)
+		ack.Effective.MaxConcurrentTasks = *update.MaxConcurrentTasks
+	}
+	return ack
+}
+
+func receiveConfigAck(t *testing.T, srv *testCapabilityServer) ws.ConfigUpdateAckPayload {
+	t.Helper()
+	msg := receiveMessageOfType(t, srv, ws.AgentMsgConfigUpdateAck)
+	assert.Equal(t, "msg-1", msg.ID)
+	var ack ws.ConfigUpdateAckPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &ack))
+	return ack
+}
+
+// TestHandleConfigUpdate는 config_update 적용/거부 결과가 ack로 전송되고
+// 처리한 revision이 하트비트에 포함되는지 검증합니다.
+func TestHandleConfigUpdate(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	limit := 2
+	update := ws.ConfigUpdatePayload{Revision: "rev-1", MaxConcurrentTasks: &limit, LogLevel: "debug"}
+
+	// ConfigUpdater가 없으면 모든 설정을 거부한다.
+	routeMessage(t, NewRouter(client), ws.AgentMsgConfigUpdate, update)
+	ack := receiveConfigAck(t, srv)
+	assert.Equal(t, "rev-1", ack.Revision)
+	assert.Empty(t, ack.Applied)
+	assert.Len(t, ack.Rejected, 2)
+
+	router := NewRouter(client)
+	WithConfigUpdater(&fakeConfigUpdater{router: router})(router)
+	update.Revision = "rev-2"
+	routeMessage(t, router, ws.AgentMsgConfigUpdate, update)
+	ack = receiveConfigAck(t, srv)
+	assert.Equal(t, []string{ws.ConfigSettingMaxConcurrentTasks}, ack.Applied)
+	require.Len(t, ack.Rejected, 1)
+	assert.Equal(t, ws.ConfigSettingLogLevel, ack.Rejected[0].Setting)
+	assert.Equal(t, 2, ack.Effective.MaxConcurrentTasks)
+	assert.Equal(t, 2, router.taskSlots.limit)
+
+	assert.Equal(t, "rev-2", client.buildHeartbeatPayload().ConfigRevision)
+}
+
+// TestTaskLimiter는 동시 실행 한도와 한도 변경 시 대기 작업이 깨어나는지 검증합니다.
+func TestTaskLimiter(t *testing.T) {
+	var l taskLimiter
+	l.setLimit(1)
+
+	release, err := l.acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "한도에 도달하면 대기해야 함")
+
+	var acquired atomic.Int32
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	// hold가 닫힐 때까지 대기 작업이 슬롯을 쥐고 있으므로, 두 번째 작업은 release()로만 깨어날 수 있다.
+	hold := make(chan struct{})
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := l.acquire(context.Background())
+			if err == nil {
+				acquired.Add(1)
+				defer r()
+			}
+			done <- struct{}{}
+			<-hold
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Zero(t, acquired.Load())
+
+	// 한도를 늘리면 대기 중인 작업 하나가 실행되고, 슬롯을 반환하면 나머지도 실행된다.
+	l.setLimit(2)
+	<-done
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), acquired.Load(), "슬롯을 반환하기 전에는 하나만 실행되어야 함")
+	release()
+	release() // 중복 반환은 무시
+	<-done
+	assert.Equal(t, int32(2), acquired.Load())
+
+	close(hold)
+	wg.Wait()
+	l.mu.Lock()
+	assert.Zero(t, l.active)
+	l.mu.Unlock()
+}
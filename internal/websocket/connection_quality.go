@@ -0,0 +1,317 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// connection_quality.go는 하트비트 왕복 시간, 응답 누락, 재연결 빈도로 연결 품질을 측정하고
+// 불안정한 네트워크에서 하트비트 간격을 조정합니다.
+package websocket
+
+import (
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-bridge/internal/logger"
+)
+
+const (
+	// MinHeartbeatInterval은 연결 품질이 나쁠 때 사용하는 가장 짧은 하트비트 간격입니다.
+	MinHeartbeatInterval = 10 * time.Second
+
+	// qualitySampleWindow는 품질 평가에 사용하는 최근 하트비트 표본 수입니다.
+	qualitySampleWindow = 20
+	// qualityReconnectWindow는 재연결 빈도를 셀 때 보는 기간입니다.
+	qualityReconnectWindow = 10 * time.Minute
+
+	// qualityRTTGood 이하의 왕복 시간은 감점하지 않습니다.
+	qualityRTTGood = 300 * time.Millisecond
+	// qualityRTTBad 이상의 왕복 시간은 최대로 감점합니다.
+	qualityRTTBad = 2 * time.Second
+
+	// 항목별 최대 감점
+	qualityRTTPenalty       = 40
+	qualityLossPenalty      = 40
+	qualityReconnectPenalty = 30
+	// qualityPenaltyPerReconnect는 최근 재연결 1회당 감점입니다.
+	qualityPenaltyPerReconnect = 10
+)
+
+// QualityLevel은 연결 품질 등급입니다.
+type QualityLevel string
+
+const (
+	// QualityGood은 안정적인 연결입니다 (80점 이상).
+	QualityGood QualityLevel = "good"
+	// QualityFair는 지연이나 간헐적 누락이 있는 연결입니다 (50점 이상).
+	QualityFair QualityLevel = "fair"
+	// QualityPoor는 작업 실행에 지장을 줄 수 있는 불안정한 연결입니다.
+	QualityPoor QualityLevel = "poor"
+)
+
+// rank는 등급 비교용 순위를 반환합니다 (높을수록 나쁨).
+func (l QualityLevel) rank() int {
+	switch l {
+	case QualityFair:
+		return 1
+	case QualityPoor:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// QualitySnapshot은 특정 시점의 연결 품질입니다.
+type QualitySnapshot struct {
+	// Score는 0~100 사이의 품질 점수입니다.
+	Score int
+	// Level은 점수에 따른 등급입니다.
+	Level QualityLevel
+	// AvgRTT는 최근 하트비트 왕복 시간의 평균입니다. 표본이 없으면 0입니다.
+	AvgRTT time.Duration
+	// LossRate는 최근 하트비트 중 응답이 없었던 비율(0~1)입니다.
+	LossRate float64
+	// Samples는 평가에 사용한 하트비트 표본 수입니다.
+	Samples int
+	// RecentReconnects는 최근 10분간의 연결 끊김 횟수입니다.
+	RecentReconnects int
+	// HeartbeatInterval은 현재 적용 중인 하트비트 간격입니다.
+	HeartbeatInterval time.Duration
+}
+
+// heartbeatSample은 하트비트 한 번의 결과입니다.
+type heartbeatSample struct {
+	rtt  time.Duration
+	lost bool
+}
+
+// ConnectionQuality는 하트비트 결과와 재연결 이력으로 연결 품질을 평가합니다.
+// 품질이 떨어지면 하트비트 간격을 줄여 끊긴 연결을 빨리 감지하고,
+// 회복되면 기본 간격으로 돌아갑니다.
+type ConnectionQuality struct {
+	mu sync.Mutex
+
+	// baseInterval은 품질이 좋을 때의 하트비트 간격입니다.
+	baseInterval time.Duration
+	// interval은 현재 하트비트 간격입니다.
+	interval time.Duration
+
+	// pendingSince는 응답을 기다리는 하트비트의 전송 시각입니다 (없으면 zero).
+	pendingSince time.Time
+	// samples는 최근 하트비트 결과입니다 (오래된 순).
+	samples []heartbeatSample
+	// reconnects는 최근 연결 끊김 시각입니다.
+	reconnects []time.Time
+
+	// level은 마지막으로 평가한 등급입니다 (등급 변화 시에만 로그를 남기기 위함).
+	level QualityLevel
+	// lastScore는 마지막으로 알린 점수입니다.
+	lastScore int
+
+	// onChange는 점수나 등급이 바뀌면 호출되는 콜백입니다.
+	onChange func(QualitySnapshot)
+	// now는 현재 시각을 반환합니다 (테스트에서 주입).
+	now func() time.Time
+}
+
+// NewConnectionQuality는 기본 하트비트 간격으로 품질 모니터를 생성합니다.
+func NewConnectionQuality(baseInterval time.Duration) *ConnectionQuality {
+	if baseInterval <= 0 {
+		baseInterval = HeartbeatInterval
+	}
+	return &ConnectionQuality{
+		baseInterval: baseInterval,
+		interval:     baseInterval,
+		level:        QualityGood,
+		lastScore:    100,
+		now:          time.Now,
+	}
+}
+
+// SetOnChange는 점수나 등급이 바뀔 때 호출할 콜백을 설정합니다.
+func (q *ConnectionQuality) SetOnChange(fn func(QualitySnapshot)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.onChange = fn
+}
+
+// HeartbeatInterval은 현재 품질에 맞춘 하트비트 간격을 반환합니다.
+func (q *ConnectionQuality) HeartbeatInterval() time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.interval
+}
+
+// RecordHeartbeatSent는 하트비트 전송을 기록합니다.
+// 이전 하트비트에 응답이 없었다면 누락으로 기록합니다.
+func (q *ConnectionQuality) RecordHeartbeatSent() {
+	q.mu.Lock()
+	lost := !q.pendingSince.IsZero()
+	if lost {
+		q.addSampleLocked(heartbeatSample{lost: true})
+	}
+	q.pendingSince = q.now()
+	q.mu.Unlock()
+
+	if lost {
+		q.evaluate()
+	}
+}
+
+// RecordHeartbeatAck는 하트비트 응답 수신을 기록하고 왕복 시간을 측정합니다.
+// 대기 중인 하트비트가 없으면 (서버가 먼저 보낸 하트비트) 무시합니다.
+func (q *ConnectionQuality) RecordHeartbeatAck() {
+	q.mu.Lock()
+	if q.pendingSince.IsZero() {
+		q.mu.Unlock()
+		return
+	}
+	q.addSampleLocked(heartbeatSample{rtt: q.now().Sub(q.pendingSince)})
+	q.pendingSince = time.Time{}
+	q.mu.Unlock()
+
+	q.evaluate()
+}
+
+// RecordReconnect는 연결 끊김(재연결 시작)을 기록합니다.
+// 끊긴 연결에서 보낸 하트비트는 누락으로 세지 않습니다.
+func (q *ConnectionQuality) RecordReconnect() {
+	q.mu.Lock()
+	q.reconnects = append(q.reconnects, q.now())
+	q.pendingSince = time.Time{}
+	q.mu.Unlock()
+
+	q.evaluate()
+}
+
+// Snapshot은 현재 연결 품질을 반환합니다.
+func (q *ConnectionQuality) Snapshot() QualitySnapshot {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	snap := q.snapshotLocked()
+	snap.HeartbeatInterval = q.interval
+	return snap
+}
+
+// addSampleLocked는 최근 표본에 결과를 추가합니다. 호출자가 mu를 보유해야 합니다.
+func (q *ConnectionQuality) addSampleLocked(s heartbeatSample) {
+	q.samples = append(q.samples, s)
+	if len(q.samples) > qualitySampleWindow {
+		q.samples = q.samples[len(q.samples)-qualitySampleWindow:]
+	}
+}
+
+// snapshotLocked는 현재 표본으로 점수를 계산합니다. 호출자가 mu를 보유해야 합니다.
+func (q *ConnectionQuality) snapshotLocked() QualitySnapshot {
+	cutoff := q.now().Add(-qualityReconnectWindow)
+	recent := q.reconnects[:0]
+	for _, t := range q.reconnects {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	q.reconnects = recent
+
+	snap := QualitySnapshot{Samples: len(q.samples), RecentReconnects: len(recent)}
+
+	var rttSum time.Duration
+	var acked, lost int
+	for _, s := range q.samples {
+		if s.lost {
+			lost++
+			continue
+		}
+		rttSum += s.rtt
+		acked++
+	}
+	if acked > 0 {
+		snap.AvgRTT = rttSum / time.Duration(acked)
+	}
+	if len(q.samples) > 0 {
+		snap.LossRate = float64(lost) / float64(len(q.samples))
+	}
+
+	penalty := 0
+	if snap.AvgRTT > qualityRTTGood {
+		ratio := float64(snap.AvgRTT-qualityRTTGood) / float64(qualityRTTBad-qualityRTTGood)
+		penalty += int(min(ratio, 1) * qualityRTTPenalty)
+	}
+	// 하트비트 20%가 누락되면 최대 감점
+	penalty += int(min(snap.LossRate*5, 1) * qualityLossPenalty)
+	penalty += min(snap.RecentReconnects*qualityPenaltyPerReconnect, qualityReconnectPenalty)
+
+	snap.Score = max(100-penalty, 0)
+	switch {
+	case snap.Score >= 80:
+		snap.Level = QualityGood
+	case snap.Score >= 50:
+		snap.Level = QualityFair
+	default:
+		snap.Level = QualityPoor
+	}
+	return snap
+}
+
+// intervalFor는 등급에 맞는 하트비트 간격을 반환합니다.
+func (q *ConnectionQuality) intervalFor(level QualityLevel) time.Duration {
+	switch level {
+	case QualityFair:
+		return max(q.baseInterval/2, MinHeartbeatInterval)
+	case QualityPoor:
+		return min(MinHeartbeatInterval, q.baseInterval)
+	default:
+		return q.baseInterval
+	}
+}
+
+// evaluate는 품질을 다시 계산하여 하트비트 간격을 조정하고,
+// 등급이 바뀌면 로그를, 점수가 바뀌면 콜백을 호출합니다.
+func (q *ConnectionQuality) evaluate() {
+	q.mu.Lock()
+	snap := q.snapshotLocked()
+	q.interval = q.intervalFor(snap.Level)
+	snap.HeartbeatInterval = q.interval
+
+	prevLevel := q.level
+	changed := snap.Score != q.lastScore || snap.Level != prevLevel
+	q.level = snap.Level
+	q.lastScore = snap.Score
+	onChange := q.onChange
+	q.mu.Unlock()
+
+	switch {
+	case snap.Level.rank() > prevLevel.rank():
+		logger.Warn().
+			Int("score", snap.Score).
+			Str("level", string(snap.Level)).
+			Dur("avg_rtt", snap.AvgRTT).
+			Float64("loss_rate", snap.LossRate).
+			Int("recent_reconnects", snap.RecentReconnects).
+			Dur("heartbeat_interval", snap.HeartbeatInterval).
+			Strs("hints", qualityHints(snap)).
+			Msg("연결 품질 저하 감지")
+	case snap.Level.rank() < prevLevel.rank():
+		logger.Info().
+			Int("score", snap.Score).
+			Str("level", string(snap.Level)).
+			Dur("heartbeat_interval", snap.HeartbeatInterval).
+			Msg("연결 품질 회복")
+	}
+
+	if changed && onChange != nil {
+		onChange(snap)
+	}
+}
+
+// qualityHints는 품질 저하 원인별 진단 안내를 반환합니다.
+func qualityHints(snap QualitySnapshot) []string {
+	var hints []string
+	if snap.AvgRTT > qualityRTTGood {
+		hints = append(hints, "왕복 지연이 큽니다: VPN/프록시 경유 여부와 서버 리전을 확인하세요")
+	}
+	if snap.LossRate > 0 {
+		hints = append(hints, "하트비트 응답이 누락됩니다: 방화벽이나 프록시의 유휴 연결 종료 설정을 확인하세요")
+	}
+	if snap.RecentReconnects > 0 {
+		hints = append(hints, "재연결이 잦습니다: Wi-Fi 신호, 절전 모드, 네트워크 전환 여부를 확인하세요")
+	}
+	if len(hints) == 0 {
+		hints = append(hints, "원인을 특정하지 못했습니다: 'autopus status'로 상태를 계속 확인하세요")
+	}
+	return hints
+}
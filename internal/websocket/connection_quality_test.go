@@ -0,0 +1,101 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestQuality는 시각을 직접 조정할 수 있는 품질 모니터를 생성합니다.
+func newTestQuality() (*ConnectionQuality, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := NewConnectionQuality(HeartbeatInterval)
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+// heartbeatRoundTrip은 지정한 왕복 시간으로 하트비트 한 번을 기록합니다.
+func heartbeatRoundTrip(q *ConnectionQuality, now *time.Time, rtt time.Duration) {
+	q.RecordHeartbeatSent()
+	*now = now.Add(rtt)
+	q.RecordHeartbeatAck()
+	*now = now.Add(q.HeartbeatInterval())
+}
+
+func TestConnectionQuality_StableConnection(t *testing.T) {
+	q, now := newTestQuality()
+	for range 5 {
+		heartbeatRoundTrip(q, now, 50*time.Millisecond)
+	}
+
+	snap := q.Snapshot()
+	assert.Equal(t, 100, snap.Score)
+	assert.Equal(t, QualityGood, snap.Level)
+	assert.Equal(t, 50*time.Millisecond, snap.AvgRTT)
+	assert.Equal(t, 5, snap.Samples)
+	assert.Equal(t, HeartbeatInterval, snap.HeartbeatInterval)
+}
+
+func TestConnectionQuality_LossShortensInterval(t *testing.T) {
+	q, now := newTestQuality()
+	var changes []QualitySnapshot
+	q.SetOnChange(func(s QualitySnapshot) { changes = append(changes, s) })
+
+	for range 8 {
+		heartbeatRoundTrip(q, now, 100*time.Millisecond)
+	}
+	// 응답 없는 하트비트 2번: 다음 전송 시 누락으로 기록
+	q.RecordHeartbeatSent()
+	q.RecordHeartbeatSent()
+	q.RecordHeartbeatSent()
+
+	snap := q.Snapshot()
+	assert.InDelta(t, 0.2, snap.LossRate, 0.001)
+	assert.Equal(t, 60, snap.Score)
+	assert.Equal(t, QualityFair, snap.Level)
+	assert.Equal(t, HeartbeatInterval/2, q.HeartbeatInterval())
+	require.NotEmpty(t, changes)
+	assert.Equal(t, QualityFair, changes[len(changes)-1].Level)
+}
+
+func TestConnectionQuality_ReconnectsAndRecovery(t *testing.T) {
+	q, now := newTestQuality()
+	heartbeatRoundTrip(q, now, 1150*time.Millisecond) // RTT 감점 20
+	q.RecordReconnect()
+	q.RecordReconnect()
+	q.RecordReconnect()
+
+	snap := q.Snapshot()
+	assert.Equal(t, 3, snap.RecentReconnects)
+	assert.Equal(t, 50, snap.Score)
+	assert.Equal(t, QualityFair, snap.Level)
+
+	// 재연결 중 보낸 하트비트는 누락으로 세지 않음
+	q.RecordHeartbeatSent()
+	q.RecordReconnect()
+	assert.Zero(t, q.Snapshot().LossRate)
+
+	// 새 연결에서 응답 누락이 더해지면 poor
+	q.RecordHeartbeatSent()
+	q.RecordHeartbeatSent()
+	assert.Equal(t, QualityPoor, q.Snapshot().Level)
+	assert.Equal(t, MinHeartbeatInterval, q.HeartbeatInterval())
+
+	// 재연결 기록이 오래되고 지연이 줄면 기본 간격으로 복귀
+	*now = now.Add(qualityReconnectWindow + time.Minute)
+	for range qualitySampleWindow {
+		heartbeatRoundTrip(q, now, 80*time.Millisecond)
+	}
+	snap = q.Snapshot()
+	assert.Equal(t, QualityGood, snap.Level)
+	assert.Zero(t, snap.RecentReconnects)
+	assert.Equal(t, HeartbeatInterval, q.HeartbeatInterval())
+}
+
+func TestQualityHints(t *testing.T) {
+	hints := qualityHints(QualitySnapshot{AvgRTT: time.Second, LossRate: 0.1, RecentReconnects: 2})
+	assert.Len(t, hints, 3)
+	assert.Len(t, qualityHints(QualitySnapshot{}), 1)
+}
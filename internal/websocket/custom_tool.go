@@ -0,0 +1,110 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 서버의 custom_tool_request를 로컬 승인 후 CustomToolExecutor에 전달하고 custom_tool_result로 응답합니다.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+)
+
+// CustomToolExecutor는 사용자가 설정한 로컬 도구를 실행하는 인터페이스입니다.
+type CustomToolExecutor interface {
+	Execute(ctx context.Context, req ws.CustomToolRequestPayload) ws.CustomToolResultPayload
+}
+
+// WithCustomToolExecutor는 사용자 정의 도구 실행기를 설정합니다.
+func WithCustomToolExecutor(executor CustomToolExecutor) RouterOption {
+	return func(r *Router) {
+		r.customToolExecutor = executor
+	}
+}
+
+// WithCustomTools는 agent_connect로 알릴 사용자 정의 도구 목록을 설정합니다.
+func WithCustomTools(tools []ws.CustomToolDefinition) ClientOption {
+	return func(c *Client) {
+		c.customTools = tools
+	}
+}
+
+// handleCustomToolRequest는 사용자 정의 도구 실행 요청을 처리합니다.
+// 실행 전 승인 게이트(custom_tool)를 거치며, 스크립트는 오래 걸릴 수 있으므로 비동기로 실행합니다.
+func (r *Router) handleCustomToolRequest(ctx context.Context, msg ws.AgentMessage) error {
+	var req ws.CustomToolRequestPayload
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return fmt.Errorf("custom_tool_request 페이로드 파싱 실패: %w", err)
+	}
+
+	log.Printf("[custom-tool] 실행 요청 수신: request_id=%s tool=%s", req.RequestID, req.Tool)
+
+	if r.customToolExecutor == nil {
+		return r.sendCustomToolResult(msg.ID, ws.CustomToolResultPayload{
+			RequestID:     req.RequestID,
+			Tool:          req.Tool,
+			ExitCode:      -1,
+			Error:         "사용자 정의 도구가 설정되지 않았습니다",
+			CorrelationID: req.CorrelationID,
+		})
+	}
+
+	go func() {
+		var result ws.CustomToolResultPayload
+		if err := r.checkAction(ctx, approval.ActionCustomTool, req.Tool, customToolDetail(req.Arguments)); err != nil {
+			log.Printf("[custom-tool] 실행 거부: tool=%s err=%v", req.Tool, err)
+			result = ws.CustomToolResultPayload{
+				RequestID:     req.RequestID,
+				Tool:          req.Tool,
+				ExitCode:      cliExitCodeDenied,
+				Error:         err.Error(),
+				CorrelationID: req.CorrelationID,
+			}
+		} else {
+			result = r.customToolExecutor.Execute(ctx, req)
+		}
+
+		if err := r.sendCustomToolResult(msg.ID, result); err != nil {
+			log.Printf("[custom-tool] 결과 전송 실패: request_id=%s err=%v", req.RequestID, err)
+		}
+	}()
+
+	return nil
+}
+
+// customToolDetail은 승인 프롬프트에 보여줄 도구 인자 요약을 만듭니다.
+func customToolDetail(args map[string]interface{}) string {
+	if len(args) == 0 {
+		return "인자 없음"
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Sprintf("%v", args)
+	}
+	return "args=" + string(data)
+}
+
+// sendCustomToolResult는 도구 실행 결과를 요청 메시지 ID로 서버에 전송합니다.
+func (r *Router) sendCustomToolResult(requestMsgID string, result ws.CustomToolResultPayload) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("custom_tool_result 직렬화 실패: %w", err)
+	}
+
+	msg := ws.AgentMessage{
+		Type:      ws.AgentMsgCustomToolResult,
+		ID:        requestMsgID, // 원본 요청 ID를 그대로 사용하여 매칭
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+
+	if err := r.client.Send(msg); err != nil {
+		return fmt.Errorf("custom_tool_result 전송 실패: %w", err)
+	}
+
+	log.Printf("[custom-tool] 결과 전송 완료: request_id=%s tool=%s exit_code=%d", result.RequestID, result.Tool, result.ExitCode)
+	return nil
+}
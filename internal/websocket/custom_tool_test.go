@@ -0,0 +1,118 @@
+// Package websocket - custom_tool_request 핸들러 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCustomToolExecutor는 호출 횟수를 기록하고 고정된 결과를 반환하는 테스트용 CustomToolExecutor입니다.
+type stubCustomToolExecutor struct {
+	calls atomic.Int32
+}
+
+func (e *stubCustomToolExecutor) Execute(_ context.Context, req ws.CustomToolRequestPayload) ws.CustomToolResultPayload {
+	e.calls.Add(1)
+	return ws.CustomToolResultPayload{RequestID: req.RequestID, Tool: req.Tool, Success: true, Stdout: "deployed"}
+}
+
+// receiveCustomToolResult는 테스트 서버가 수신한 custom_tool_result 메시지를 반환합니다.
+func receiveCustomToolResult(t *testing.T, srv *testCapabilityServer) (ws.AgentMessage, ws.CustomToolResultPayload) {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-srv.received:
+			if msg.Type != ws.AgentMsgCustomToolResult {
+				continue
+			}
+			var result ws.CustomToolResultPayload
+			require.NoError(t, json.Unmarshal(msg.Payload, &result))
+			return msg, result
+		case <-deadline:
+			t.Fatal("custom_tool_result 수신 타임아웃")
+			return ws.AgentMessage{}, ws.CustomToolResultPayload{}
+		}
+	}
+}
+
+func sendCustomToolRequest(t *testing.T, router *Router, req ws.CustomToolRequestPayload) {
+	t.Helper()
+	payload, err := json.Marshal(req)
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgCustomToolRequest,
+		ID:      "msg-" + req.RequestID,
+		Payload: payload,
+	}))
+}
+
+// TestHandleCustomToolRequest_SendsResult는 실행 결과가 요청 메시지 ID로 custom_tool_result 전송되는지 검증합니다.
+func TestHandleCustomToolRequest_SendsResult(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	executor := &stubCustomToolExecutor{}
+	router := NewRouter(client, WithCustomToolExecutor(executor))
+
+	sendCustomToolRequest(t, router, ws.CustomToolRequestPayload{RequestID: "ct-1", Tool: "deploy_preview"})
+
+	msg, result := receiveCustomToolResult(t, srv)
+	assert.Equal(t, "msg-ct-1", msg.ID)
+	assert.True(t, result.Success)
+	assert.Equal(t, "deployed", result.Stdout)
+	assert.Equal(t, int32(1), executor.calls.Load())
+}
+
+// TestHandleCustomToolRequest_DeniedByActionGate는 승인 게이트가 거부하면 도구가 실행되지 않는지 검증합니다.
+func TestHandleCustomToolRequest_DeniedByActionGate(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	executor := &stubCustomToolExecutor{}
+	gate := approval.NewActionGate(approval.ActionGateConfig{
+		Modes:     map[string]approval.GateMode{approval.ActionCustomTool: approval.GateModeDeny},
+		Allowlist: map[string][]string{approval.ActionCustomTool: {"lint_*"}},
+	}, nil, zerolog.Nop())
+	router := NewRouter(client, WithCustomToolExecutor(executor), WithActionGate(gate))
+
+	sendCustomToolRequest(t, router, ws.CustomToolRequestPayload{RequestID: "ct-1", Tool: "deploy_preview"})
+	_, result := receiveCustomToolResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Equal(t, cliExitCodeDenied, result.ExitCode)
+	assert.NotEmpty(t, result.Error)
+	assert.Equal(t, int32(0), executor.calls.Load(), "거부된 도구는 실행되면 안 됨")
+
+	sendCustomToolRequest(t, router, ws.CustomToolRequestPayload{RequestID: "ct-2", Tool: "lint_go"})
+	_, result = receiveCustomToolResult(t, srv)
+	assert.True(t, result.Success, "허용 목록의 도구는 실행되어야 함")
+}
+
+// TestHandleCustomToolRequest_NoExecutor는 실행기가 없으면 실패 결과로 응답하는지 검증합니다.
+func TestHandleCustomToolRequest_NoExecutor(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client)
+	sendCustomToolRequest(t, router, ws.CustomToolRequestPayload{RequestID: "ct-1", Tool: "deploy_preview"})
+
+	_, result := receiveCustomToolResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Equal(t, "ct-1", result.RequestID)
+	assert.NotEmpty(t, result.Error)
+}
@@ -0,0 +1,342 @@
+// Package websocket - 이기종 플릿의 Bridge 간 작업 위임
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+// 위임 대상 작업 유형
+const (
+	DelegationTaskTypeTask  = "task"
+	DelegationTaskTypeBuild = "build"
+	DelegationTaskTypeTest  = "test"
+	DelegationTaskTypeQA    = "qa"
+	DelegationTaskTypeCLI   = "cli"
+)
+
+// defaultDelegationTimeout은 서버가 위임을 수락할 때까지 기다리는 기본 시간입니다.
+const defaultDelegationTimeout = 30 * time.Second
+
+// DelegationRule은 다른 Bridge로 위임할 작업 조건입니다.
+type DelegationRule struct {
+	// TaskTypes는 대상 작업 유형입니다 (task, build, test, qa, cli). 비어 있으면 모든 유형입니다.
+	TaskTypes []string
+	// Commands는 대상 명령 목록입니다. "*"로 끝나면 접두사 일치. 비어 있으면 명령과 무관하게 적용합니다.
+	Commands []string
+	// TargetPlatform은 작업을 실행할 Bridge의 OS(GOOS)입니다. 현재 OS와 같으면 규칙을 적용하지 않습니다.
+	TargetPlatform string
+}
+
+// DelegationPolicy는 작업 위임 정책입니다.
+type DelegationPolicy struct {
+	// Rules는 위임 규칙입니다. 처음 일치한 규칙을 사용합니다.
+	Rules []DelegationRule
+	// FallbackLocal이 true이면 위임이 거절되거나 시간 초과될 때 로컬에서 실행합니다.
+	// false이면 재시도 가능한 task_error로 응답합니다.
+	FallbackLocal bool
+	// Timeout은 서버가 위임을 수락할 때까지 기다리는 시간입니다. 0이면 30초입니다.
+	Timeout time.Duration
+}
+
+// delegationRequest는 위임 규칙 판단에 필요한 요청 정보입니다.
+type delegationRequest struct {
+	executionID string
+	taskType    string
+	commands    []string
+}
+
+// pendingDelegation은 서버 응답을 기다리거나 대상 Bridge에서 실행 중인 위임입니다.
+type pendingDelegation struct {
+	ctx            context.Context
+	msg            ws.AgentMessage
+	next           HandlerFunc
+	targetPlatform string
+	accepted       bool
+	timer          *time.Timer
+}
+
+// delegator는 위임 정책과 진행 중인 위임을 관리합니다.
+type delegator struct {
+	policy   DelegationPolicy
+	platform string
+
+	mu      sync.Mutex
+	pending map[string]*pendingDelegation
+}
+
+// WithDelegationPolicy는 작업 위임 정책을 설정합니다. nil이거나 규칙이 없으면 위임하지 않습니다.
+func WithDelegationPolicy(policy *DelegationPolicy) RouterOption {
+	return func(r *Router) {
+		if policy == nil || len(policy.Rules) == 0 {
+			return
+		}
+		if policy.Timeout <= 0 {
+			policy.Timeout = defaultDelegationTimeout
+		}
+		r.delegator = &delegator{
+			policy:   *policy,
+			platform: runtime.GOOS,
+			pending:  make(map[string]*pendingDelegation),
+		}
+	}
+}
+
+// delegationRequestFor는 위임할 수 있는 메시지에서 요청 정보를 추출합니다.
+func delegationRequestFor(msg ws.AgentMessage) (delegationRequest, bool) {
+	switch msg.Type {
+	case ws.AgentMsgTaskReq:
+		var p ws.TaskRequestPayload
+		if json.Unmarshal(msg.Payload, &p) != nil {
+			return delegationRequest{}, false
+		}
+		return delegationRequest{executionID: p.ExecutionID, taskType: DelegationTaskTypeTask}, true
+	case ws.AgentMsgBuildReq:
+		var p ws.BuildRequestPayload
+		if json.Unmarshal(msg.Payload, &p) != nil {
+			return delegationRequest{}, false
+		}
+		return delegationRequest{executionID: p.ExecutionID, taskType: DelegationTaskTypeBuild, commands: []string{p.Command}}, true
+	case ws.AgentMsgTestReq:
+		var p ws.TestRequestPayload
+		if json.Unmarshal(msg.Payload, &p) != nil {
+			return delegationRequest{}, false
+		}
+		return delegationRequest{executionID: p.ExecutionID, taskType: DelegationTaskTypeTest, commands: []string{p.Command}}, true
+	case ws.AgentMsgQAReq:
+		var p ws.QARequestPayload
+		if json.Unmarshal(msg.Payload, &p) != nil {
+			return delegationRequest{}, false
+		}
+		return delegationRequest{executionID: p.ExecutionID, taskType: DelegationTaskTypeQA, commands: []string{p.BuildCommand, p.TestCommand}}, true
+	case ws.AgentMsgCLIRequest:
+		var p ws.CLIRequestPayload
+		if json.Unmarshal(msg.Payload, &p) != nil {
+			return delegationRequest{}, false
+		}
+		return delegationRequest{executionID: msg.ID, taskType: DelegationTaskTypeCLI, commands: []string{p.Command}}, true
+	}
+	return delegationRequest{}, false
+}
+
+// match는 요청에 적용할 규칙을 반환합니다. 대상 OS가 현재 OS와 같은 규칙은 건너뜁니다.
+func (d *delegator) match(req delegationRequest) (DelegationRule, bool) {
+	for _, rule := range d.policy.Rules {
+		if rule.TargetPlatform != "" && strings.EqualFold(rule.TargetPlatform, d.platform) {
+			continue
+		}
+		if len(rule.TaskTypes) > 0 && !containsFold(rule.TaskTypes, req.taskType) {
+			continue
+		}
+		if len(rule.Commands) > 0 && !matchesAnyCommand(rule.Commands, req.commands) {
+			continue
+		}
+		return rule, true
+	}
+	return DelegationRule{}, false
+}
+
+// containsFold는 values에 target이 대소문자 구분 없이 포함되어 있는지 반환합니다.
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), target) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyCommand는 commands 중 하나가 patterns 중 하나와 일치하는지 반환합니다.
+// 패턴이 "*"로 끝나면 접두사 일치입니다.
+func matchesAnyCommand(patterns, commands []string) bool {
+	for _, command := range commands {
+		command = strings.TrimSpace(command)
+		if command == "" {
+			continue
+		}
+		for _, pattern := range patterns {
+			if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+				if strings.HasPrefix(command, prefix) {
+					return true
+				}
+			} else if command == pattern {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// delegationMiddleware는 위임 규칙에 일치하는 요청을 로컬에서 실행하지 않고 서버에 위임을 요청합니다.
+// 서버가 이전 프로토콜 버전이면 위임 메시지를 처리하지 못하므로 그대로 로컬에서 실행합니다.
+func (r *Router) delegationMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			req, ok := delegationRequestFor(msg)
+			if !ok || req.executionID == "" || r.IsDraining() {
+				return next(ctx, msg)
+			}
+			if r.client != nil && r.client.protocolShim.Load() != nil {
+				return next(ctx, msg)
+			}
+			rule, ok := r.delegator.match(req)
+			if !ok {
+				return next(ctx, msg)
+			}
+			return r.delegate(ctx, msg, next, req, rule)
+		}
+	}
+}
+
+// delegate는 task_delegate를 전송하고 서버 응답을 기다립니다.
+// Timeout 안에 수락되지 않으면 거절된 것으로 처리합니다.
+func (r *Router) delegate(ctx context.Context, msg ws.AgentMessage, next HandlerFunc, req delegationRequest, rule DelegationRule) error {
+	d := r.delegator
+	d.mu.Lock()
+	if _, exists := d.pending[req.executionID]; exists {
+		d.mu.Unlock()
+		log.Printf("[delegation] 위임 중인 작업의 중복 요청 무시: execution_id=%s", req.executionID)
+		return nil
+	}
+	p := &pendingDelegation{ctx: ctx, msg: msg, next: next, targetPlatform: rule.TargetPlatform}
+	d.pending[req.executionID] = p
+	p.timer = time.AfterFunc(d.policy.Timeout, func() {
+		r.resolveDelegation(ws.TaskDelegateStatusPayload{
+			ExecutionID: req.executionID,
+			State:       ws.DelegationRejected,
+			Message:     fmt.Sprintf("%s 안에 위임이 수락되지 않았습니다", d.policy.Timeout),
+		}, true)
+	})
+	d.mu.Unlock()
+
+	payload, err := json.Marshal(ws.TaskDelegatePayload{
+		ExecutionID:    req.executionID,
+		MessageType:    msg.Type,
+		Payload:        msg.Payload,
+		TargetPlatform: rule.TargetPlatform,
+		SourcePlatform: d.platform,
+		Reason:         fmt.Sprintf("%s 작업은 %s Bridge에서 실행하도록 설정되어 있습니다", req.taskType, rule.TargetPlatform),
+	})
+	if err == nil {
+		err = r.client.Send(ws.AgentMessage{Type: ws.AgentMsgTaskDelegate, ID: msg.ID, Payload: payload})
+	}
+	if err != nil {
+		log.Printf("[delegation] task_delegate 전송 실패: execution_id=%s err=%v", req.executionID, err)
+		r.resolveDelegation(ws.TaskDelegateStatusPayload{
+			ExecutionID: req.executionID,
+			State:       ws.DelegationRejected,
+			Message:     fmt.Sprintf("위임 요청 전송 실패: %v", err),
+		}, false)
+		return nil
+	}
+
+	log.Printf("[delegation] 위임 요청: execution_id=%s type=%s target=%s", req.executionID, msg.Type, rule.TargetPlatform)
+	r.reportDelegation(req.executionID, "다른 Bridge로 위임 요청", ws.DelegationStatus{
+		State:          ws.DelegationRequested,
+		TargetPlatform: rule.TargetPlatform,
+	})
+	return nil
+}
+
+// handleTaskDelegateStatus는 서버의 위임 진행 상태를 처리합니다.
+func (r *Router) handleTaskDelegateStatus(_ context.Context, msg ws.AgentMessage) error {
+	var status ws.TaskDelegateStatusPayload
+	if err := json.Unmarshal(msg.Payload, &status); err != nil {
+		return fmt.Errorf("task_delegate_status 페이로드 파싱 실패: %w", err)
+	}
+	if r.delegator == nil {
+		log.Printf("[delegation] 위임 정책 없이 상태 수신: execution_id=%s state=%s", status.ExecutionID, status.State)
+		return nil
+	}
+	r.resolveDelegation(status, false)
+	return nil
+}
+
+// resolveDelegation은 위임 상태 변경을 반영합니다.
+// timeout이 true이면 이미 수락된 위임에는 적용하지 않습니다.
+func (r *Router) resolveDelegation(status ws.TaskDelegateStatusPayload, timeout bool) {
+	d := r.delegator
+	d.mu.Lock()
+	p, ok := d.pending[status.ExecutionID]
+	if !ok || (timeout && p.accepted) {
+		d.mu.Unlock()
+		if !ok {
+			log.Printf("[delegation] 알 수 없는 위임 상태 무시: execution_id=%s state=%s", status.ExecutionID, status.State)
+		}
+		return
+	}
+	switch status.State {
+	case ws.DelegationAccepted:
+		p.accepted = true
+		p.timer.Stop()
+	case ws.DelegationRejected, ws.DelegationCompleted, ws.DelegationFailed:
+		p.timer.Stop()
+		delete(d.pending, status.ExecutionID)
+	default:
+		d.mu.Unlock()
+		log.Printf("[delegation] 알 수 없는 위임 상태: execution_id=%s state=%s", status.ExecutionID, status.State)
+		return
+	}
+	d.mu.Unlock()
+
+	delegation := ws.DelegationStatus{
+		State:          status.State,
+		TargetPlatform: p.targetPlatform,
+		TargetBridgeID: status.TargetBridgeID,
+	}
+	switch status.State {
+	case ws.DelegationAccepted:
+		r.reportDelegation(status.ExecutionID, "다른 Bridge가 작업을 수락했습니다", delegation)
+	case ws.DelegationCompleted:
+		r.reportDelegation(status.ExecutionID, "위임한 작업이 완료되었습니다", delegation)
+	case ws.DelegationFailed:
+		r.reportDelegation(status.ExecutionID, "위임한 작업이 실패했습니다: "+status.Message, delegation)
+	case ws.DelegationRejected:
+		r.fallbackDelegation(status, p)
+	}
+}
+
+// fallbackDelegation은 거절된 위임을 정책에 따라 로컬에서 실행하거나 에러로 응답합니다.
+func (r *Router) fallbackDelegation(status ws.TaskDelegateStatusPayload, p *pendingDelegation) {
+	log.Printf("[delegation] 위임 거절: execution_id=%s reason=%s", status.ExecutionID, status.Message)
+	if !r.delegator.policy.FallbackLocal {
+		r.reportDelegation(status.ExecutionID, "위임이 거절되었습니다: "+status.Message, ws.DelegationStatus{
+			State:          ws.DelegationRejected,
+			TargetPlatform: p.targetPlatform,
+		})
+		_ = r.getTaskSender().SendTaskError(ws.TaskErrorPayload{
+			ExecutionID: status.ExecutionID,
+			Code:        errcode.DelegationRejected,
+			Message:     fmt.Sprintf("%s Bridge로 작업을 위임하지 못했습니다: %s", p.targetPlatform, status.Message),
+			Retryable:   true,
+		})
+		return
+	}
+
+	r.reportDelegation(status.ExecutionID, "위임이 거절되어 로컬에서 실행합니다", ws.DelegationStatus{
+		State:          ws.DelegationLocalFallback,
+		TargetPlatform: p.targetPlatform,
+	})
+	if err := p.next(p.ctx, p.msg); err != nil && r.onError != nil {
+		r.onError(fmt.Errorf("메시지 처리 실패 (type=%s): %w", p.msg.Type, err))
+	}
+}
+
+// reportDelegation은 위임 상태를 task_progress로 보고합니다.
+func (r *Router) reportDelegation(executionID, message string, status ws.DelegationStatus) {
+	_ = r.getTaskSender().SendTaskProgress(ws.TaskProgressPayload{
+		ExecutionID: executionID,
+		Message:     message,
+		Type:        "delegation",
+		Delegation:  &status,
+	})
+}
@@ -0,0 +1,162 @@
+// Package websocket - Bridge 간 작업 위임 테스트
+package websocket
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// otherPlatform은 현재 OS가 아닌 위임 대상 OS를 반환합니다.
+func otherPlatform() string {
+	if runtime.GOOS == "darwin" {
+		return "linux"
+	}
+	return "darwin"
+}
+
+// progressDelegationStates는 전송된 task_progress의 위임 상태를 순서대로 반환합니다.
+func progressDelegationStates(sender *stubTaskMessageSender) []string {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	var states []string
+	for _, p := range sender.progress {
+		if p.Delegation != nil {
+			states = append(states, p.Delegation.State)
+		}
+	}
+	return states
+}
+
+func TestDelegator_Match(t *testing.T) {
+	d := &delegator{platform: "linux", policy: DelegationPolicy{Rules: []DelegationRule{
+		{TaskTypes: []string{"build"}, Commands: []string{"xcodebuild*"}, TargetPlatform: "darwin"},
+		{TaskTypes: []string{"test"}, TargetPlatform: "linux"},
+		{TaskTypes: []string{"cli"}, Commands: []string{"msbuild"}, TargetPlatform: "windows"},
+	}}}
+
+	tests := []struct {
+		name   string
+		req    delegationRequest
+		target string
+		ok     bool
+	}{
+		{name: "command prefix", req: delegationRequest{taskType: "build", commands: []string{"xcodebuild -scheme App"}}, target: "darwin", ok: true},
+		{name: "other command", req: delegationRequest{taskType: "build", commands: []string{"go build ./..."}}},
+		{name: "same platform skipped", req: delegationRequest{taskType: "test", commands: []string{"go test ./..."}}},
+		{name: "exact command", req: delegationRequest{taskType: "cli", commands: []string{"msbuild"}}, target: "windows", ok: true},
+		{name: "task without command", req: delegationRequest{taskType: "task"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := d.match(tt.req)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.target, rule.TargetPlatform)
+		})
+	}
+}
+
+func TestWithDelegationPolicy_NoRulesDisables(t *testing.T) {
+	router := NewRouter(nil, WithDelegationPolicy(&DelegationPolicy{}))
+	assert.Nil(t, router.delegator)
+
+	router = NewRouter(nil, WithDelegationPolicy(&DelegationPolicy{Rules: []DelegationRule{{TargetPlatform: "darwin"}}}))
+	require.NotNil(t, router.delegator)
+	assert.Equal(t, defaultDelegationTimeout, router.delegator.policy.Timeout)
+}
+
+func TestDelegation_AcceptedAndCompleted(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	sender := &stubTaskMessageSender{}
+	target := otherPlatform()
+	router := NewRouter(client, WithTaskMessageSender(sender), WithDelegationPolicy(&DelegationPolicy{
+		Rules: []DelegationRule{{TaskTypes: []string{"build"}, Commands: []string{"xcodebuild*"}, TargetPlatform: target}},
+	}))
+
+	routeMessage(t, router, ws.AgentMsgBuildReq, ws.BuildRequestPayload{ExecutionID: "exec-ios", Command: "xcodebuild -scheme App"})
+
+	msg := receiveMessageOfType(t, srv, ws.AgentMsgTaskDelegate)
+	var delegate ws.TaskDelegatePayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &delegate))
+	assert.Equal(t, "exec-ios", delegate.ExecutionID)
+	assert.Equal(t, ws.AgentMsgBuildReq, delegate.MessageType)
+	assert.Equal(t, target, delegate.TargetPlatform)
+	assert.Equal(t, runtime.GOOS, delegate.SourcePlatform)
+	var original ws.BuildRequestPayload
+	require.NoError(t, json.Unmarshal(delegate.Payload, &original))
+	assert.Equal(t, "xcodebuild -scheme App", original.Command)
+
+	// 같은 실행의 재전송은 다시 위임하지 않는다.
+	routeMessage(t, router, ws.AgentMsgBuildReq, ws.BuildRequestPayload{ExecutionID: "exec-ios", Command: "xcodebuild -scheme App"})
+
+	routeMessage(t, router, ws.AgentMsgTaskDelegateStatus, ws.TaskDelegateStatusPayload{ExecutionID: "exec-ios", State: ws.DelegationAccepted, TargetBridgeID: "mac-1"})
+	routeMessage(t, router, ws.AgentMsgTaskDelegateStatus, ws.TaskDelegateStatusPayload{ExecutionID: "exec-ios", State: ws.DelegationCompleted, TargetBridgeID: "mac-1"})
+
+	assert.Equal(t, []string{ws.DelegationRequested, ws.DelegationAccepted, ws.DelegationCompleted}, progressDelegationStates(sender))
+	sender.mu.Lock()
+	assert.Equal(t, "mac-1", sender.progress[len(sender.progress)-1].Delegation.TargetBridgeID)
+	sender.mu.Unlock()
+	router.delegator.mu.Lock()
+	assert.Empty(t, router.delegator.pending)
+	router.delegator.mu.Unlock()
+}
+
+func TestDelegation_RejectedFallsBackToLocal(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	sender := &stubTaskMessageSender{}
+	executor := &stubTaskExecutor{result: ws.TaskResultPayload{ExecutionID: "exec-local", Output: "done"}}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(sender), WithDelegationPolicy(&DelegationPolicy{
+		Rules:         []DelegationRule{{TaskTypes: []string{"task"}, TargetPlatform: otherPlatform()}},
+		FallbackLocal: true,
+	}))
+
+	routeMessage(t, router, ws.AgentMsgTaskReq, ws.TaskRequestPayload{ExecutionID: "exec-local", Prompt: "hi"})
+	receiveMessageOfType(t, srv, ws.AgentMsgTaskDelegate)
+	routeMessage(t, router, ws.AgentMsgTaskDelegateStatus, ws.TaskDelegateStatusPayload{ExecutionID: "exec-local", State: ws.DelegationRejected, Message: "no bridge"})
+
+	require.Eventually(t, func() bool {
+		sender.mu.Lock()
+		defer sender.mu.Unlock()
+		return len(sender.results) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{ws.DelegationRequested, ws.DelegationLocalFallback}, progressDelegationStates(sender))
+}
+
+func TestDelegation_TimeoutWithoutFallbackReportsError(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	sender := &stubTaskMessageSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender), WithDelegationPolicy(&DelegationPolicy{
+		Rules:   []DelegationRule{{TaskTypes: []string{"cli"}, TargetPlatform: otherPlatform()}},
+		Timeout: 50 * time.Millisecond,
+	}))
+
+	routeMessage(t, router, ws.AgentMsgCLIRequest, ws.CLIRequestPayload{Command: "xcrun simctl list"})
+
+	require.Eventually(t, func() bool {
+		sender.mu.Lock()
+		defer sender.mu.Unlock()
+		return len(sender.errors) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	assert.Equal(t, "msg-1", sender.errors[0].ExecutionID)
+	assert.Equal(t, "DELEGATION_REJECTED", sender.errors[0].Code)
+	assert.True(t, sender.errors[0].Retryable)
+}
@@ -0,0 +1,120 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 정상 종료 시 진행 중인 작업을 드레이닝하는 로직.
+package websocket
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+const (
+	// DefaultDrainGracePeriod는 종료 시 활성 작업 완료를 기다리는 기본 유예 시간입니다.
+	DefaultDrainGracePeriod = 30 * time.Second
+
+	// ErrCodeBridgeShuttingDown은 드레이닝 중 수신/중단된 작업에 사용하는 에러 코드입니다.
+	ErrCodeBridgeShuttingDown = errcode.BridgeShuttingDown
+
+	// drainPollInterval은 활성 작업 완료 여부를 확인하는 주기입니다.
+	drainPollInterval = 100 * time.Millisecond
+)
+
+// StartDraining은 라우터를 드레이닝 상태로 전환합니다.
+// 이후 수신되는 작업 요청은 재시도 가능한 에러로 거절됩니다.
+func (r *Router) StartDraining() {
+	r.draining.Store(true)
+}
+
+// IsDraining은 라우터가 드레이닝 상태인지 반환합니다.
+func (r *Router) IsDraining() bool {
+	return r.draining.Load()
+}
+
+// Drain은 새 작업 수신을 중단하고 활성 작업(TaskTracker)이 끝나기를 최대 grace 동안 기다립니다.
+// 유예 시간 내에 끝나지 않은 작업은 재시도 가능한 에러로 서버에 보고한 뒤 추적 목록에서 제거합니다.
+// 유예 시간 초과로 중단 보고된 실행 ID 목록을 반환합니다.
+func (r *Router) Drain(ctx context.Context, grace time.Duration) []string {
+	if grace <= 0 {
+		grace = DefaultDrainGracePeriod
+	}
+	r.StartDraining()
+
+	tracker := r.client.TaskTracker()
+	if tracker.GetActiveTaskCount() > 0 {
+		log.Printf("[drain] 활성 작업 %d개 완료 대기 (최대 %s)", tracker.GetActiveTaskCount(), grace)
+	}
+
+	deadline := time.NewTimer(grace)
+	defer deadline.Stop()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for tracker.GetActiveTaskCount() > 0 {
+		select {
+		case <-ctx.Done():
+			return r.abortActiveTasks("종료가 취소되어 작업이 중단되었습니다")
+		case <-deadline.C:
+			return r.abortActiveTasks("Bridge 종료 유예 시간 내에 작업이 완료되지 않았습니다")
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// abortActiveTasks는 남아 있는 활성 작업마다 중단 에러를 전송하고 추적을 종료합니다.
+func (r *Router) abortActiveTasks(message string) []string {
+	tracker := r.client.TaskTracker()
+	tasks := tracker.GetActiveTaskInfos()
+
+	aborted := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		if err := r.sendShutdownError(t.ExecutionID, t.TaskType, message); err != nil {
+			log.Printf("[drain] 중단 에러 전송 실패: execution_id=%s err=%v", t.ExecutionID, err)
+		}
+		tracker.Complete(t.ExecutionID)
+		aborted = append(aborted, t.ExecutionID)
+	}
+	if len(aborted) > 0 {
+		log.Printf("[drain] 미완료 작업 %d개 중단 보고", len(aborted))
+	}
+	return aborted
+}
+
+// rejectWhileDraining은 드레이닝 중 수신된 작업 요청을 재시도 가능한 에러로 거절합니다.
+func (r *Router) rejectWhileDraining(executionID, taskType string) error {
+	log.Printf("[drain] 종료 중 작업 요청 거절: execution_id=%s type=%s", executionID, taskType)
+	return r.sendShutdownError(executionID, taskType, "Bridge가 종료 중이므로 새 작업을 받을 수 없습니다")
+}
+
+// sendShutdownError는 작업 유형에 맞는 메시지 타입으로 재시도 가능한 종료 에러를 전송합니다.
+// agent_response는 agent_response_error로, 그 외 작업은 task_error로 보고합니다.
+func (r *Router) sendShutdownError(executionID, taskType, message string) error {
+	return r.sendRetryableError(executionID, taskType, ErrCodeBridgeShuttingDown, message)
+}
+
+// sendRetryableError는 작업 유형에 맞는 메시지 타입으로 재시도 가능한 에러를 전송합니다.
+func (r *Router) sendRetryableError(executionID, taskType, code, message string) error {
+	return r.sendIntakeError(executionID, taskType, code, message, true)
+}
+
+// sendIntakeError는 수신 단계에서 거절한 작업 요청의 에러를 작업 유형에 맞는 메시지 타입으로 전송합니다.
+// agent_response는 agent_response_error로, 그 외 작업은 task_error로 보고합니다.
+func (r *Router) sendIntakeError(executionID, taskType, code, message string, retryable bool) error {
+	if taskType == "agent_response" {
+		return r.client.SendAgentResponseError(ws.AgentResponseErrorPayload{
+			ExecutionID: executionID,
+			Code:        code,
+			Message:     message,
+			Retryable:   retryable,
+		})
+	}
+	return r.getTaskSender().SendTaskError(ws.TaskErrorPayload{
+		ExecutionID: executionID,
+		Code:        code,
+		Message:     message,
+		Retryable:   retryable,
+	})
+}
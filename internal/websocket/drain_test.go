@@ -0,0 +1,128 @@
+// Package websocket - 종료 드레이닝 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTaskSender는 전송된 task_result와 task_error를 기록하는 테스트용 TaskMessageSender입니다.
+type recordingTaskSender struct {
+	mu      sync.Mutex
+	results []ws.TaskResultPayload
+	errors  []ws.TaskErrorPayload
+}
+
+func (s *recordingTaskSender) SendTaskProgress(ws.TaskProgressPayload) error { return nil }
+func (s *recordingTaskSender) SendTaskResult(payload ws.TaskResultPayload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results = append(s.results, payload)
+	return nil
+}
+func (s *recordingTaskSender) SendTaskError(payload ws.TaskErrorPayload) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, payload)
+	return nil
+}
+
+func (s *recordingTaskSender) taskErrors() []ws.TaskErrorPayload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ws.TaskErrorPayload(nil), s.errors...)
+}
+
+func (s *recordingTaskSender) taskResults() []ws.TaskResultPayload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ws.TaskResultPayload(nil), s.results...)
+}
+
+// TestDrain_NoActiveTasks는 활성 작업이 없으면 즉시 반환하는지 검증합니다.
+func TestDrain_NoActiveTasks(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client)
+
+	start := time.Now()
+	aborted := router.Drain(context.Background(), time.Second)
+
+	assert.Empty(t, aborted)
+	assert.True(t, router.IsDraining(), "Drain 호출 후 드레이닝 상태여야 함")
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "활성 작업이 없으면 즉시 반환해야 함")
+}
+
+// TestDrain_WaitsForCompletion은 유예 시간 내 완료된 작업은 중단 보고하지 않는지 검증합니다.
+func TestDrain_WaitsForCompletion(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender))
+
+	client.TaskTracker().Track("exec-done", "task")
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		client.TaskTracker().Complete("exec-done")
+	}()
+
+	aborted := router.Drain(context.Background(), 2*time.Second)
+
+	assert.Empty(t, aborted)
+	assert.Empty(t, sender.taskErrors(), "완료된 작업에 대해 에러가 전송되면 안 됨")
+}
+
+// TestDrain_AbortsAfterGracePeriod는 유예 시간 초과 시 남은 작업을 에러로 보고하는지 검증합니다.
+func TestDrain_AbortsAfterGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender))
+
+	client.TaskTracker().Track("exec-stuck", "task")
+
+	aborted := router.Drain(context.Background(), 150*time.Millisecond)
+
+	assert.Equal(t, []string{"exec-stuck"}, aborted)
+	assert.Equal(t, 0, client.TaskTracker().GetActiveTaskCount(), "중단된 작업은 추적 목록에서 제거되어야 함")
+
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "exec-stuck", errs[0].ExecutionID)
+	assert.Equal(t, ErrCodeBridgeShuttingDown, errs[0].Code)
+	assert.True(t, errs[0].Retryable, "종료로 인한 중단은 재시도 가능해야 함")
+}
+
+// TestHandleTaskRequest_RejectedWhileDraining은 드레이닝 중 새 작업이 거절되는지 검증합니다.
+func TestHandleTaskRequest_RejectedWhileDraining(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender))
+	router.StartDraining()
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{ExecutionID: "exec-new"})
+	require.NoError(t, err)
+
+	err = router.handleTaskRequest(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskReq,
+		Payload: payload,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, client.TaskTracker().IsActive("exec-new"), "거절된 작업은 추적되면 안 됨")
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, ErrCodeBridgeShuttingDown, errs[0].Code)
+}
@@ -0,0 +1,49 @@
+// Package websocket - 수명 주기 이벤트 훅 연동
+package websocket
+
+import (
+	"strconv"
+
+	"github.com/insajin/autopus-bridge/internal/eventhook"
+)
+
+// WithEventHooks는 연결/작업/Computer Use 세션 이벤트에 실행할 훅을 설정합니다.
+func WithEventHooks(dispatcher *eventhook.Dispatcher) ClientOption {
+	return func(c *Client) {
+		c.eventHooks = dispatcher
+	}
+}
+
+// fireEvent는 설정된 이벤트 훅을 실행합니다. 훅이 없으면 아무 동작도 하지 않습니다.
+func (c *Client) fireEvent(eventType string, data map[string]string) {
+	if c == nil {
+		return
+	}
+	c.eventHooks.Fire(eventType, data)
+}
+
+// taskEventData는 작업 이벤트 훅에 전달할 공통 정보를 만듭니다.
+func taskEventData(executionID, provider, model string) map[string]string {
+	data := map[string]string{"execution_id": executionID}
+	if provider != "" {
+		data["provider"] = provider
+	}
+	if model != "" {
+		data["model"] = model
+	}
+	return data
+}
+
+// withTaskResult는 task_completed 이벤트 정보에 실행 시간과 종료 코드를 추가합니다.
+func withTaskResult(data map[string]string, durationMs int64, exitCode int) map[string]string {
+	data["duration_ms"] = strconv.FormatInt(durationMs, 10)
+	data["exit_code"] = strconv.Itoa(exitCode)
+	return data
+}
+
+// withTaskError는 task_failed 이벤트 정보에 에러 코드와 메시지를 추가합니다.
+func withTaskError(data map[string]string, code string, err error) map[string]string {
+	data["error_code"] = code
+	data["error"] = err.Error()
+	return data
+}
@@ -0,0 +1,98 @@
+// Package websocket - 수명 주기 이벤트 훅 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/eventhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hookRecorder는 웹훅으로 받은 이벤트를 기록한다.
+type hookRecorder struct {
+	mu     sync.Mutex
+	events []eventhook.Event
+}
+
+func newHookDispatcher(t *testing.T) (*eventhook.Dispatcher, *hookRecorder) {
+	t.Helper()
+	rec := &hookRecorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev eventhook.Event
+		if json.NewDecoder(r.Body).Decode(&ev) == nil {
+			rec.mu.Lock()
+			rec.events = append(rec.events, ev)
+			rec.mu.Unlock()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	d, err := eventhook.New([]eventhook.Hook{{URL: srv.URL}})
+	require.NoError(t, err)
+	return d, rec
+}
+
+func (h *hookRecorder) find(eventType string) (eventhook.Event, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, ev := range h.events {
+		if ev.Type == eventType {
+			return ev, true
+		}
+	}
+	return eventhook.Event{}, false
+}
+
+func waitEvent(t *testing.T, d *eventhook.Dispatcher, rec *hookRecorder, eventType string) eventhook.Event {
+	t.Helper()
+	var ev eventhook.Event
+	require.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = d.Wait(ctx)
+		var ok bool
+		ev, ok = rec.find(eventType)
+		return ok
+	}, 3*time.Second, 20*time.Millisecond, "%s 이벤트 훅이 실행되지 않았습니다", eventType)
+	return ev
+}
+
+func TestEventHooks_ConnectionAndTaskLifecycle(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	d, rec := newHookDispatcher(t)
+
+	client := NewClient(srv.URL, "test-token", "1.0.0", WithEventHooks(d))
+	require.NoError(t, client.Connect(testContext(t)))
+	connected := waitEvent(t, d, rec, eventhook.EventConnected)
+	assert.Equal(t, srv.URL, connected.Data["server_url"])
+
+	sender := &stubTaskMessageSender{}
+	executor := &stubTaskExecutor{result: ws.TaskResultPayload{ExecutionID: "exec-1", Duration: 1500}}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(sender))
+	routeMessage(t, router, ws.AgentMsgTaskReq, ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "hi", Provider: "claude", Model: "sonnet"})
+
+	started := waitEvent(t, d, rec, eventhook.EventTaskStarted)
+	assert.Equal(t, map[string]string{"execution_id": "exec-1", "provider": "claude", "model": "sonnet"}, started.Data)
+	completed := waitEvent(t, d, rec, eventhook.EventTaskCompleted)
+	assert.Equal(t, "1500", completed.Data["duration_ms"])
+
+	executor.err = errors.New("boom")
+	routeMessage(t, router, ws.AgentMsgTaskReq, ws.TaskRequestPayload{ExecutionID: "exec-2", Prompt: "hi"})
+	failed := waitEvent(t, d, rec, eventhook.EventTaskFailed)
+	assert.Equal(t, "exec-2", failed.Data["execution_id"])
+	assert.Equal(t, "EXECUTION_ERROR", failed.Data["error_code"])
+	assert.Equal(t, "boom", failed.Data["error"])
+
+	require.NoError(t, client.Disconnect("user_shutdown"))
+	disconnected := waitEvent(t, d, rec, eventhook.EventDisconnected)
+	assert.Equal(t, "user_shutdown", disconnected.Data["reason"])
+}
@@ -0,0 +1,38 @@
+package websocket
+
+// 파일 변경 유형 상수입니다.
+const (
+	FileChangeCreate = "create"
+	FileChangeModify = "modify"
+	FileChangeDelete = "delete"
+)
+
+// FileChange는 단일 파일의 변경 내용입니다.
+type FileChange struct {
+	// Path는 동기화 루트 기준 상대 경로입니다 (슬래시 구분).
+	Path string `json:"path"`
+	// ChangeType은 "create", "modify", "delete" 중 하나입니다.
+	ChangeType string `json:"change_type"`
+	// Diff는 이전 스냅샷 대비 unified diff입니다. 삭제 시에는 비어 있을 수 있습니다.
+	Diff string `json:"diff,omitempty"`
+	// Hash는 변경 후 파일 내용의 SHA-256 해시입니다. 삭제 시 비어 있습니다.
+	Hash string `json:"hash,omitempty"`
+	// Size는 변경 후 파일 크기(바이트)입니다.
+	Size int64 `json:"size"`
+}
+
+// FileSyncPayload는 file_sync 메시지의 페이로드입니다.
+// 디바운스 윈도우 동안 모인 변경을 하나의 배치로 전송합니다.
+type FileSyncPayload struct {
+	// Root는 감시 중인 디렉토리의 절대 경로입니다.
+	Root string `json:"root"`
+	// Changes는 배치에 포함된 파일 변경 목록입니다.
+	Changes []FileChange `json:"changes"`
+	// Timestamp는 배치 생성 시각(Unix 밀리초)입니다.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// SendFileSync는 워크스페이스 파일 변경 배치를 서버로 전송합니다.
+func (c *Client) SendFileSync(payload FileSyncPayload) error {
+	return c.sendMessage(AgentMsgFileSync, payload)
+}
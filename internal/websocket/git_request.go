@@ -0,0 +1,128 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 서버의 git_request를 GitRequestExecutor에 전달하고 git_result로 응답합니다.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+)
+
+// GitRequestExecutor는 작업 디렉토리 안에서 git 작업을 실행하는 인터페이스입니다.
+type GitRequestExecutor interface {
+	Execute(ctx context.Context, req ws.GitRequestPayload) (ws.GitResultPayload, error)
+}
+
+// WithGitRequestExecutor는 git_request 작업 실행기를 설정합니다.
+func WithGitRequestExecutor(executor GitRequestExecutor) RouterOption {
+	return func(r *Router) {
+		r.gitExecutor = executor
+	}
+}
+
+// handleGitRequest는 git 작업 요청을 처리합니다.
+// clone/push 같은 네트워크 작업은 오래 걸릴 수 있으므로 비동기로 실행합니다.
+func (r *Router) handleGitRequest(ctx context.Context, msg ws.AgentMessage) error {
+	var req ws.GitRequestPayload
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return fmt.Errorf("git_request 페이로드 파싱 실패: %w", err)
+	}
+
+	log.Printf("[git] 작업 요청 수신: request_id=%s operation=%s repo=%s", req.RequestID, req.Operation, req.Repo)
+
+	if r.gitExecutor == nil {
+		return r.sendGitResult(ws.GitResultPayload{
+			RequestID:     req.RequestID,
+			Operation:     req.Operation,
+			Repo:          req.Repo,
+			Success:       false,
+			Error:         "Git 실행기가 설정되지 않았습니다",
+			CorrelationID: req.CorrelationID,
+		})
+	}
+
+	if r.readOnly {
+		log.Printf("[git] 읽기 전용 모드, 작업 거절: request_id=%s operation=%s", req.RequestID, req.Operation)
+		return r.sendGitResult(ws.GitResultPayload{
+			RequestID:     req.RequestID,
+			Operation:     req.Operation,
+			Repo:          req.Repo,
+			Success:       false,
+			Error:         errReadOnly.Error(),
+			CorrelationID: req.CorrelationID,
+		})
+	}
+
+	go func() {
+		if req.Operation == ws.GitOpCommit || req.Operation == ws.GitOpPush {
+			if err := r.checkAction(ctx, approval.ActionGitRequest, req.Repo, gitRequestDetail(req)); err != nil {
+				log.Printf("[git] 작업 거부: request_id=%s operation=%s err=%v", req.RequestID, req.Operation, err)
+				if sendErr := r.sendGitResult(ws.GitResultPayload{
+					RequestID:     req.RequestID,
+					Operation:     req.Operation,
+					Repo:          req.Repo,
+					Success:       false,
+					Error:         err.Error(),
+					CorrelationID: req.CorrelationID,
+				}); sendErr != nil {
+					log.Printf("[git] 결과 전송 실패: request_id=%s err=%v", req.RequestID, sendErr)
+				}
+				return
+			}
+		}
+
+		result, err := r.gitExecutor.Execute(ctx, req)
+		if err != nil {
+			log.Printf("[git] 작업 실패: request_id=%s operation=%s err=%v", req.RequestID, req.Operation, err)
+			if result.Error == "" {
+				result.Error = err.Error()
+			}
+			if result.RequestID == "" {
+				result.RequestID = req.RequestID
+				result.Operation = req.Operation
+				result.CorrelationID = req.CorrelationID
+			}
+		}
+		if sendErr := r.sendGitResult(result); sendErr != nil {
+			log.Printf("[git] 결과 전송 실패: request_id=%s err=%v", req.RequestID, sendErr)
+		}
+	}()
+
+	return nil
+}
+
+// gitRequestDetail은 승인 프롬프트에 보여줄 commit/push 요약을 만듭니다.
+func gitRequestDetail(req ws.GitRequestPayload) string {
+	if req.Operation == ws.GitOpCommit {
+		return fmt.Sprintf("commit: %s (파일 %d개)", req.Message, len(req.Files))
+	}
+	remote := req.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	return fmt.Sprintf("push: %s %s", remote, req.Branch)
+}
+
+// sendGitResult는 git 작업 결과를 서버에 전송합니다.
+func (r *Router) sendGitResult(result ws.GitResultPayload) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("git_result 직렬화 실패: %w", err)
+	}
+
+	msg := ws.AgentMessage{
+		Type:    ws.AgentMsgGitResult,
+		Payload: payload,
+	}
+
+	if err := r.client.Send(msg); err != nil {
+		return fmt.Errorf("git_result 전송 실패: %w", err)
+	}
+
+	log.Printf("[git] 결과 전송 완료: request_id=%s operation=%s success=%v", result.RequestID, result.Operation, result.Success)
+	return nil
+}
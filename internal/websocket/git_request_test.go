@@ -0,0 +1,152 @@
+// Package websocket - git_request 핸들러 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubGitRequestExecutor는 고정된 결과를 반환하는 테스트용 GitRequestExecutor입니다.
+type stubGitRequestExecutor struct {
+	result ws.GitResultPayload
+	err    error
+	calls  atomic.Int32
+}
+
+func (e *stubGitRequestExecutor) Execute(_ context.Context, _ ws.GitRequestPayload) (ws.GitResultPayload, error) {
+	e.calls.Add(1)
+	return e.result, e.err
+}
+
+// receiveGitResult는 테스트 서버가 수신한 git_result 메시지를 반환합니다.
+func receiveGitResult(t *testing.T, srv *testCapabilityServer) ws.GitResultPayload {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-srv.received:
+			if msg.Type != ws.AgentMsgGitResult {
+				continue
+			}
+			var result ws.GitResultPayload
+			require.NoError(t, json.Unmarshal(msg.Payload, &result))
+			return result
+		case <-deadline:
+			t.Fatal("git_result 수신 타임아웃")
+			return ws.GitResultPayload{}
+		}
+	}
+}
+
+func sendGitRequest(t *testing.T, router *Router, req ws.GitRequestPayload) {
+	t.Helper()
+	payload, err := json.Marshal(req)
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgGitRequest,
+		Payload: payload,
+	}))
+}
+
+// TestHandleGitRequest_SendsResult는 실행 결과가 git_result로 전송되는지 검증합니다.
+func TestHandleGitRequest_SendsResult(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client, WithGitRequestExecutor(&stubGitRequestExecutor{
+		result: ws.GitResultPayload{
+			RequestID:    "git-1",
+			Operation:    ws.GitOpCommit,
+			Success:      true,
+			CommitSHA:    "abc123",
+			ChangedFiles: []string{"main.go"},
+		},
+	}))
+
+	sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-1", Operation: ws.GitOpCommit, Repo: "repo"})
+
+	result := receiveGitResult(t, srv)
+	assert.True(t, result.Success)
+	assert.Equal(t, "abc123", result.CommitSHA)
+	assert.Equal(t, []string{"main.go"}, result.ChangedFiles)
+}
+
+// TestHandleGitRequest_ExecutorError는 실행기가 빈 결과와 에러를 반환해도 요청 정보가 채워진 실패 결과가 전송되는지 검증합니다.
+func TestHandleGitRequest_ExecutorError(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client, WithGitRequestExecutor(&stubGitRequestExecutor{err: errors.New("boom")}))
+
+	sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-2", Operation: ws.GitOpPush, CorrelationID: "corr-1"})
+
+	result := receiveGitResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Equal(t, "git-2", result.RequestID)
+	assert.Equal(t, ws.GitOpPush, result.Operation)
+	assert.Equal(t, "corr-1", result.CorrelationID)
+	assert.Equal(t, "boom", result.Error)
+}
+
+// TestHandleGitRequest_DeniedByActionGate는 승인 게이트가 commit/push만 검사하고,
+// 거부된 작업은 실행하지 않고 실패 결과로 응답하는지 검증합니다.
+func TestHandleGitRequest_DeniedByActionGate(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	executor := &stubGitRequestExecutor{result: ws.GitResultPayload{Success: true}}
+	gate := approval.NewActionGate(approval.ActionGateConfig{
+		Modes:     map[string]approval.GateMode{approval.ActionGitRequest: approval.GateModeDeny},
+		Allowlist: map[string][]string{approval.ActionGitRequest: {"team/*"}},
+	}, nil, zerolog.Nop())
+	router := NewRouter(client, WithGitRequestExecutor(executor), WithActionGate(gate))
+
+	for _, op := range []string{ws.GitOpCommit, ws.GitOpPush} {
+		sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-" + op, Operation: op, Repo: "other", CorrelationID: "corr-1"})
+		result := receiveGitResult(t, srv)
+		assert.False(t, result.Success, op)
+		assert.Equal(t, "git-"+op, result.RequestID)
+		assert.Equal(t, "corr-1", result.CorrelationID)
+		assert.NotEmpty(t, result.Error)
+	}
+	assert.Equal(t, int32(0), executor.calls.Load(), "거부된 commit/push는 실행되면 안 됨")
+
+	sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-fetch", Operation: ws.GitOpFetch, Repo: "other"})
+	assert.True(t, receiveGitResult(t, srv).Success, "fetch는 승인 대상이 아님")
+
+	sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-push", Operation: ws.GitOpPush, Repo: "team/app", Branch: "agent/x"})
+	assert.True(t, receiveGitResult(t, srv).Success, "허용 목록의 레포는 push되어야 함")
+	assert.Equal(t, int32(2), executor.calls.Load())
+}
+
+// TestHandleGitRequest_NoExecutor는 실행기가 없으면 실패 결과로 응답하는지 검증합니다.
+func TestHandleGitRequest_NoExecutor(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client)
+	sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-3", Operation: ws.GitOpFetch})
+
+	result := receiveGitResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Equal(t, "git-3", result.RequestID)
+	assert.NotEmpty(t, result.Error)
+}
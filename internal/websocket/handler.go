@@ -14,13 +14,20 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/insajin/autopus-agent-protocol"
 	"github.com/insajin/autopus-bridge/internal/agentbrowser"
+	"github.com/insajin/autopus-bridge/internal/approval"
 	"github.com/insajin/autopus-bridge/internal/codegen"
 	"github.com/insajin/autopus-bridge/internal/computeruse"
+	"github.com/insajin/autopus-bridge/internal/diskquota"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/eventhook"
 	"github.com/insajin/autopus-bridge/internal/mcp"
+	"github.com/insajin/autopus-bridge/internal/sizeguard"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
 )
 
 // MessageHandler는 WebSocket 메시지를 처리하는 인터페이스입니다.
@@ -53,11 +60,34 @@ type QAExecutor interface {
 	Execute(ctx context.Context, req ws.QARequestPayload) *ws.QAResultPayload
 }
 
+// StepProgressBuildExecutor는 단계별 진행 상황을 보고하는 BuildExecutor입니다 (선택적).
+// 구현하면 빌드 단계가 task_progress의 step으로 전송됩니다.
+type StepProgressBuildExecutor interface {
+	ExecuteWithProgress(ctx context.Context, req ws.BuildRequestPayload, onStep func(ws.ProgressStep)) *ws.BuildResultPayload
+}
+
+// StepProgressTestExecutor는 단계별 진행 상황을 보고하는 TestExecutor입니다 (선택적).
+type StepProgressTestExecutor interface {
+	ExecuteWithProgress(ctx context.Context, req ws.TestRequestPayload, onStep func(ws.ProgressStep)) *ws.TestResultPayload
+}
+
+// StepProgressQAExecutor는 QA 스테이지를 단계별로 보고하는 QAExecutor입니다 (선택적).
+type StepProgressQAExecutor interface {
+	ExecuteWithProgress(ctx context.Context, req ws.QARequestPayload, onStep func(ws.ProgressStep)) *ws.QAResultPayload
+}
+
 // CLIExecutor는 CLI 명령어 실행을 담당하는 인터페이스입니다 (SPEC-SKILL-V2-001 Block C).
 type CLIExecutor interface {
 	Execute(ctx context.Context, req *ws.CLIRequestPayload) *ws.CLIResultPayload
 }
 
+// CLIStreamingExecutor는 실행 중 출력 줄을 배치로 전달할 수 있는 CLIExecutor입니다.
+// 구현하면 출력이 cli_output 메시지로 스트리밍됩니다.
+type CLIStreamingExecutor interface {
+	CLIExecutor
+	ExecuteStreaming(ctx context.Context, req *ws.CLIRequestPayload, onOutput func(ws.CLIOutputPayload)) *ws.CLIResultPayload
+}
+
 // TaskMessageSender sends task lifecycle messages back to the server.
 type TaskMessageSender interface {
 	SendTaskProgress(payload ws.TaskProgressPayload) error
@@ -84,6 +114,11 @@ type CodegenExecutor interface {
 // MCPDeployExecutor는 MCP 서버 배포를 담당하는 인터페이스입니다 (SPEC-SELF-EXPAND-001).
 type MCPDeployExecutor interface {
 	Deploy(ctx context.Context, serviceName string, files []mcp.DeployFile, envVars map[string]string) (string, error)
+	// DeployArchive는 tar.gz 아카이브로 서비스 디렉토리를 교체하여 배포합니다.
+	DeployArchive(ctx context.Context, serviceName string, archive io.Reader, envVars map[string]string) (string, error)
+	// Verify와 VerifyArchive는 배포 경로를 건드리지 않고 스테이징 디렉토리에서 검증만 수행합니다 (verify_only).
+	Verify(ctx context.Context, serviceName string, files []mcp.DeployFile, envVars map[string]string, opts mcp.VerifyOptions) (*mcp.VerifyReport, error)
+	VerifyArchive(ctx context.Context, serviceName string, archive io.Reader, envVars map[string]string, opts mcp.VerifyOptions) (*mcp.VerifyReport, error)
 }
 
 // CodeOpsExecutor는 에이전트 코드 수정 워크플로우를 실행하는 인터페이스입니다 (SPEC-CODEOPS-001).
@@ -107,8 +142,10 @@ type HandlerFunc func(ctx context.Context, msg ws.AgentMessage) error
 type Router struct {
 	// handlers는 메시지 타입별 핸들러 맵입니다.
 	handlers map[string]HandlerFunc
-	// handlersMu는 handlers 맵 접근을 보호하는 뮤텍스입니다.
+	// handlersMu는 handlers 맵과 middlewares 접근을 보호하는 뮤텍스입니다.
 	handlersMu sync.RWMutex
+	// middlewares는 모든 핸들러를 감싸는 사용자 미들웨어 목록입니다 (등록 순서대로 바깥쪽).
+	middlewares []Middleware
 
 	// client는 WebSocket 클라이언트입니다 (응답 전송용).
 	client *Client
@@ -139,12 +176,29 @@ type Router struct {
 	codegenExecutor CodegenExecutor
 	// mcpDeployer는 MCP 서버 배포를 담당합니다 (SPEC-SELF-EXPAND-001).
 	mcpDeployer MCPDeployExecutor
+	// deployTransfers는 청크로 수신 중인 MCP 배포 아카이브입니다 (transfer_id 기준).
+	deployTransfers   map[string]*deployArchiveTransfer
+	deployTransfersMu sync.Mutex
 	// codegenSandboxBaseDir는 코드 생성 샌드박스 기본 디렉토리입니다.
 	codegenSandboxBaseDir string
+	// codegenSandboxQuota는 코드 생성 샌드박스 디스크 할당량입니다. nil이면 기본 할당량을 사용합니다.
+	codegenSandboxQuota *codegen.SandboxQuota
 
 	// codeOpsWorker는 에이전트 코드 수정 워크플로우 실행기입니다 (SPEC-CODEOPS-001).
 	codeOpsWorker CodeOpsExecutor
 
+	// gitExecutor는 git_request 작업 실행기입니다. nil이면 git_request에 실패 결과로 응답합니다.
+	gitExecutor GitRequestExecutor
+
+	// customToolExecutor는 사용자 정의 로컬 도구 실행기입니다. nil이면 custom_tool_request에 실패 결과로 응답합니다.
+	customToolExecutor CustomToolExecutor
+
+	// embeddedMCPFactory는 embedded 모드 MCP 서버 생성 함수입니다. nil이면 mcp_serve_start에 에러로 응답합니다.
+	embeddedMCPFactory EmbeddedMCPFactory
+	// mcpServes는 이름별로 실행 중인 내장 MCP 서버 인스턴스입니다 (mcpServeMu로 보호).
+	mcpServes  map[string]*mcpServeInstance
+	mcpServeMu sync.RWMutex
+
 	// codingRelayRunner는 코딩 릴레이 루프 실행기입니다 (SPEC-CODING-RELAY-001).
 	codingRelayRunner CodingRelayRunner
 
@@ -156,6 +210,32 @@ type Router struct {
 	// SPEC-DOMAIN-PARALLEL-001 AC-9: Bridge 온보딩 — OAuth 연결 상태 변경 알림
 	onAIOAuthStatusChange func(payload ws.AIOAuthStatusChangePayload)
 
+	// actionGate는 서버 주도 위험 작업의 로컬 승인 게이트입니다. nil이면 모두 자동 실행합니다.
+	actionGate ActionGate
+
+	// resultCache는 완료된 작업 결과 캐시입니다. nil이면 재전송 요청도 다시 실행합니다.
+	resultCache *ResultCache
+	// replayGuard는 재전송된 중요 메시지를 거부합니다. nil이면 검사하지 않습니다.
+	replayGuard *ReplayGuard
+
+	// draining은 정상 종료 드레이닝 중 여부입니다. true이면 새 작업 요청을 거절합니다.
+	draining atomic.Bool
+	// intakePause는 새 작업 수신을 멈춘 사유입니다. nil이면 정상 수신합니다 (예: 재인증 대기).
+	intakePause atomic.Pointer[string]
+	// readOnly가 true이면 변경 작업(작업 실행, CLI, 배포, 컴퓨터 사용, git 등)을 READ_ONLY로 거절합니다.
+	readOnly bool
+	// timeouts는 메시지 타입별 실행 타임아웃 계층입니다 (nil이면 요청 값과 실행기 기본값만 사용).
+	timeouts *timeouts.Policy
+	// sizeGuard는 작업 프롬프트와 결과 페이로드의 크기 제한입니다 (nil이면 제한하지 않음).
+	sizeGuard *sizeguard.Guard
+
+	// configUpdater는 서버의 config_update를 적용합니다. nil이면 모든 변경을 거부합니다.
+	configUpdater ConfigUpdater
+	// taskSlots는 task_request/agent_response_request 동시 실행 제한입니다 (기본: 제한 없음).
+	taskSlots taskLimiter
+	// delegator는 다른 Bridge로의 작업 위임을 관리합니다. nil이면 모든 작업을 로컬에서 실행합니다.
+	delegator *delegator
+
 	// onError는 에러 발생 시 호출되는 콜백입니다.
 	onError func(err error)
 }
@@ -261,6 +341,13 @@ func WithCodegenSandboxBaseDir(dir string) RouterOption {
 	}
 }
 
+// WithCodegenSandboxQuota는 코드 생성 샌드박스 디스크 할당량을 설정합니다.
+func WithCodegenSandboxQuota(quota codegen.SandboxQuota) RouterOption {
+	return func(r *Router) {
+		r.codegenSandboxQuota = &quota
+	}
+}
+
 // WithCodeOpsWorker는 에이전트 코드 수정 워크플로우 실행기를 설정합니다 (SPEC-CODEOPS-001).
 func WithCodeOpsWorker(worker CodeOpsExecutor) RouterOption {
 	return func(r *Router) {
@@ -293,6 +380,9 @@ func NewRouter(client *Client, opts ...RouterOption) *Router {
 	for _, opt := range opts {
 		opt(r)
 	}
+	if client != nil {
+		client.setConcurrencySource(r.taskSlots.snapshot)
+	}
 
 	// 기본 핸들러 등록
 	r.registerDefaultHandlers()
@@ -308,9 +398,24 @@ func (r *Router) registerDefaultHandlers() {
 	// 작업 요청 핸들러
 	r.RegisterHandler(ws.AgentMsgTaskReq, r.handleTaskRequest)
 
+	// 작업 리스 회수 핸들러
+	r.RegisterHandler(ws.AgentMsgTaskLeaseRevoke, r.handleTaskLeaseRevoke)
+
+	// 체크포인트 재개/폐기 핸들러
+	r.RegisterHandler(ws.AgentMsgTaskResume, r.handleTaskResume)
+
+	// Bridge 간 작업 위임 상태 핸들러
+	r.RegisterHandler(ws.AgentMsgTaskDelegateStatus, r.handleTaskDelegateStatus)
+
 	// CodeOps 요청 핸들러 (SPEC-CODEOPS-001)
 	r.RegisterHandler(ws.AgentMsgCodeOpsRequest, r.handleCodeOpsRequest)
 
+	// Git 작업 요청 핸들러
+	r.RegisterHandler(ws.AgentMsgGitRequest, r.handleGitRequest)
+
+	// 사용자 정의 로컬 도구 요청 핸들러
+	r.RegisterHandler(ws.AgentMsgCustomToolRequest, r.handleCustomToolRequest)
+
 	// 빌드 요청 핸들러 (FR-P3-01)
 	r.RegisterHandler(ws.AgentMsgBuildReq, r.handleBuildRequest)
 
@@ -327,6 +432,11 @@ func (r *Router) registerDefaultHandlers() {
 	r.RegisterHandler(ws.AgentMsgMCPStart, r.handleMCPStart)
 	r.RegisterHandler(ws.AgentMsgMCPStop, r.handleMCPStop)
 
+	// 내장 MCP 서버 (embedded 모드, mcp_rpc 터널링)
+	r.RegisterHandler(ws.AgentMsgMCPServeStart, r.handleMCPServeStart)
+	r.RegisterHandler(ws.AgentMsgMCPServeStop, r.handleMCPServeStop)
+	r.RegisterHandler(ws.AgentMsgMCPRPC, r.handleMCPRPC)
+
 	// Computer Use 핸들러 (SPEC-COMPUTER-USE-001)
 	r.RegisterHandler(ws.AgentMsgComputerSessionStart, r.handleComputerSessionStart)
 	r.RegisterHandler(ws.AgentMsgComputerAction, r.handleComputerAction)
@@ -340,6 +450,7 @@ func (r *Router) registerDefaultHandlers() {
 	// MCP Codegen/Deploy 핸들러 (SPEC-SELF-EXPAND-001)
 	r.RegisterHandler(ws.AgentMsgMCPCodegenRequest, r.handleMCPCodegenRequest)
 	r.RegisterHandler(ws.AgentMsgMCPDeploy, r.handleMCPDeploy)
+	r.RegisterHandler(ws.AgentMsgMCPDeployChunk, r.handleMCPDeployChunk)
 
 	// Agent Response Protocol 핸들러 (SPEC-BRIDGE-GATEWAY-001)
 	r.RegisterHandler(ws.AgentMsgAgentResponseReq, r.handleAgentResponseRequest)
@@ -350,6 +461,9 @@ func (r *Router) registerDefaultHandlers() {
 
 	// AI OAuth 상태 변경 핸들러 (SPEC-DOMAIN-PARALLEL-001 AC-9)
 	r.RegisterHandler(ws.AgentMsgAIOAuthStatusChange, r.handleAIOAuthStatusChange)
+
+	// 서버 주도 설정 변경 핸들러
+	r.RegisterHandler(ws.AgentMsgConfigUpdate, r.handleConfigUpdate)
 }
 
 // RegisterHandler는 메시지 타입에 대한 핸들러를 등록합니다.
@@ -360,10 +474,14 @@ func (r *Router) RegisterHandler(msgType string, handler HandlerFunc) {
 }
 
 // HandleMessage는 수신된 메시지를 적절한 핸들러로 라우팅합니다.
+// 핸들러는 미들웨어 체인(패닉 복구, 트레이싱, HMAC 검증, 재전송 방지, 등록된 미들웨어)을 거쳐 실행됩니다.
 // MessageHandler 인터페이스 구현.
 func (r *Router) HandleMessage(ctx context.Context, msg ws.AgentMessage) error {
 	r.handlersMu.RLock()
 	handler, exists := r.handlers[msg.Type]
+	if exists {
+		handler = r.chain(handler)
+	}
 	r.handlersMu.RUnlock()
 
 	if !exists {
@@ -395,30 +513,57 @@ func (r *Router) handleTaskRequest(ctx context.Context, msg ws.AgentMessage) err
 		// 페이로드 파싱 실패 시 에러 응답
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("작업 요청 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
 		return r.getTaskSender().SendTaskError(errPayload)
 	}
 
+	// 종료 드레이닝 중에는 새 작업을 받지 않음
+	if r.IsDraining() {
+		return r.rejectWhileDraining(task.ExecutionID, "task")
+	}
+	if reason, paused := r.IntakePaused(); paused {
+		return r.rejectWhilePaused(task.ExecutionID, "task", reason)
+	}
+	if r.readOnly {
+		return r.rejectWhileReadOnly(task.ExecutionID, "task")
+	}
+	if rejected, err := r.rejectLargePrompt(task.ExecutionID, "task", task.Prompt, task.SystemPrompt); rejected {
+		return err
+	}
+
+	// 재전송된 요청이면 완료된 결과를 재사용
+	if r.replayCachedResult(task) {
+		return nil
+	}
+
 	// FR-P2-04: 태스크 추적 시작
-	r.client.TaskTracker().Track(task.ExecutionID, "task")
+	if r.resultCache != nil && task.ExecutionID != "" {
+		// 첫 요청이 아직 실행 중이면 완료 시 결과가 전송되므로 다시 실행하지 않는다.
+		if !r.client.TaskTracker().TryTrack(task.ExecutionID, "task") {
+			log.Printf("[task-request] 실행 중인 작업의 중복 요청 무시: execution_id=%s", task.ExecutionID)
+			return nil
+		}
+	} else {
+		r.client.TaskTracker().Track(task.ExecutionID, "task")
+	}
 
 	// 작업 실행기가 없으면 에러 응답
 	if r.executor == nil {
 		r.client.TaskTracker().Complete(task.ExecutionID)
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: task.ExecutionID,
-			Code:        "NO_EXECUTOR",
+			Code:        errcode.NoExecutor,
 			Message:     "작업 실행기가 설정되지 않았습니다",
 			Retryable:   false,
 		}
 		return r.getTaskSender().SendTaskError(errPayload)
 	}
 
-	// 비동기로 작업 실행
-	go r.executeTask(ctx, task)
+	// 비동기로 작업 실행 (리스가 회수되면 컨텍스트가 취소됨)
+	go r.executeTask(r.leaseContext(ctx, task.ExecutionID), task)
 
 	return nil
 }
@@ -437,29 +582,69 @@ func (r *Router) executeTask(ctx context.Context, task ws.TaskRequestPayload) {
 		Message:     "작업 시작",
 		Type:        "text",
 	})
+	r.client.fireEvent(eventhook.EventTaskStarted, taskEventData(task.ExecutionID, task.Provider, task.Model))
 
-	// 작업 실행
-	result, err := r.executor.Execute(ctx, task)
+	// 작업 실행 (동시 실행 한도에 도달했으면 우선순위 순서로 슬롯이 빌 때까지 대기)
+	var result ws.TaskResultPayload
+	release, wait, err := r.acquireTaskSlot(ctx, "task-request", task.ExecutionID, task.WorkspaceID, task.Priority)
+	execCtx := ctx
+	if err == nil {
+		var cancel context.CancelFunc
+		execCtx, cancel, task.Timeout = r.withMessageTimeout(ctx, ws.AgentMsgTaskReq, task.Timeout)
+		result, err = r.executor.Execute(execCtx, task)
+		result.QueueWaitMs = wait.Milliseconds()
+		cancel()
+		release()
+	}
+	if r.leaseRevoked(task.ExecutionID, "task") {
+		// 다른 Bridge에 재할당되었으므로 재개할 필요가 없다.
+		r.discardCheckpoint(task.ExecutionID)
+		return
+	}
 	if err != nil {
 		log.Printf("[task-request] 실행 실패: execution_id=%s provider=%s model=%s err=%v", task.ExecutionID, task.Provider, task.Model, err)
 		// 실행 실패 시 에러 응답: TaskError의 구체적 에러 코드를 전파
-		code := "EXECUTION_ERROR"
+		code := errcode.ExecutionError
 		type codeError interface{ ErrorCode() string }
 		if ce, ok := err.(codeError); ok {
 			code = ce.ErrorCode()
 		}
+		// 타임아웃이면 다른 실행 실패와 구분되도록 TIMEOUT 코드와 만료된 단계(요청, 메시지 타입, 기본값)를 전달
+		timeout := timeoutDetail(execCtx, err)
+		if timeout != nil {
+			code = errcode.Timeout
+		}
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: task.ExecutionID,
 			Code:        code,
 			Message:     err.Error(),
 			Retryable:   isRetryableError(err),
+			Timeout:     timeout,
+		}
+		// 고정 실행 실패(UNSUPPORTED_MODEL)이면 로컬 대체 프로바이더/모델 목록을 함께 전달
+		if ae, ok := err.(alternativesError); ok {
+			errPayload.Alternatives = ae.ModelAlternatives()
+		}
+		// 작업 디렉토리가 허용 루트 밖이면 허용된 루트 목록을 함께 전달
+		if we, ok := err.(workDirRootsError); ok {
+			errPayload.AllowedWorkDirs = we.WorkDirRoots()
 		}
 		_ = sender.SendTaskError(errPayload)
+		r.client.fireEvent(eventhook.EventTaskFailed, withTaskError(taskEventData(task.ExecutionID, task.Provider, task.Model), code, err))
 		return
 	}
 
+	// 출력이 크기 한도를 넘으면 줄이고 전체 출력은 로컬 파일에 보관 (재전송 응답도 같은 결과를 사용)
+	r.sizeGuard.TaskResult(&result)
+
+	// 재전송 요청에 응답할 수 있도록 추적 해제 전에 결과를 캐시
+	if r.resultCache != nil {
+		r.resultCache.Put(task, result)
+	}
+
 	// 결과 전송
 	_ = sender.SendTaskResult(result)
+	r.client.fireEvent(eventhook.EventTaskCompleted, withTaskResult(taskEventData(task.ExecutionID, task.Provider, task.Model), result.Duration, result.ExitCode))
 }
 
 func (r *Router) getTaskSender() TaskMessageSender {
@@ -481,7 +666,7 @@ func (r *Router) handleAgentResponseRequest(ctx context.Context, msg ws.AgentMes
 		log.Printf("[agent-response] 페이로드 파싱 실패: %v", err)
 		errPayload := ws.AgentResponseErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("agent_response_request 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -490,6 +675,20 @@ func (r *Router) handleAgentResponseRequest(ctx context.Context, msg ws.AgentMes
 
 	log.Printf("[agent-response] 파싱 완료: execution_id=%s provider=%s model=%s", req.ExecutionID, req.Provider, req.Model)
 
+	// 종료 드레이닝 중에는 새 작업을 받지 않음
+	if r.IsDraining() {
+		return r.rejectWhileDraining(req.ExecutionID, "agent_response")
+	}
+	if reason, paused := r.IntakePaused(); paused {
+		return r.rejectWhilePaused(req.ExecutionID, "agent_response", reason)
+	}
+	if r.readOnly {
+		return r.rejectWhileReadOnly(req.ExecutionID, "agent_response")
+	}
+	if rejected, err := r.rejectLargePrompt(req.ExecutionID, "agent_response", req.Prompt, req.SystemPrompt); rejected {
+		return err
+	}
+
 	// 태스크 추적 시작
 	r.client.TaskTracker().Track(req.ExecutionID, "agent_response")
 
@@ -498,7 +697,7 @@ func (r *Router) handleAgentResponseRequest(ctx context.Context, msg ws.AgentMes
 		r.client.TaskTracker().Complete(req.ExecutionID)
 		errPayload := ws.AgentResponseErrorPayload{
 			ExecutionID: req.ExecutionID,
-			Code:        "NO_EXECUTOR",
+			Code:        errcode.NoExecutor,
 			Message:     "작업 실행기가 설정되지 않았습니다",
 			Retryable:   false,
 		}
@@ -507,7 +706,7 @@ func (r *Router) handleAgentResponseRequest(ctx context.Context, msg ws.AgentMes
 
 	// 비동기로 작업 실행 (응답은 agent_response_* 메시지 타입 사용)
 	log.Printf("[agent-response] 비동기 실행 시작: execution_id=%s", req.ExecutionID)
-	go r.executeAgentResponse(ctx, req)
+	go r.executeAgentResponse(r.leaseContext(ctx, req.ExecutionID), req)
 
 	return nil
 }
@@ -517,29 +716,62 @@ func (r *Router) handleAgentResponseRequest(ctx context.Context, msg ws.AgentMes
 func (r *Router) executeAgentResponse(ctx context.Context, req ws.AgentResponseRequestPayload) {
 	defer r.client.TaskTracker().Complete(req.ExecutionID)
 	log.Printf("[agent-response] 실행 시작: execution_id=%s model=%s mode=%s", req.ExecutionID, req.Model, req.ResponseMode)
+	r.client.fireEvent(eventhook.EventTaskStarted, taskEventData(req.ExecutionID, req.Provider, req.Model))
 
-	// 작업 실행
-	result, err := r.executor.ExecuteAgentResponse(ctx, req)
+	// 작업 실행 (동시 실행 한도에 도달했으면 슬롯이 빌 때까지 대기)
+	var result ws.AgentResponseCompletePayload
+	release, _, err := r.acquireTaskSlot(ctx, "agent-response", req.ExecutionID, req.WorkspaceID, ws.PriorityNormal)
+	execCtx := ctx
+	if err == nil {
+		var cancel context.CancelFunc
+		execCtx, cancel, req.Timeout = r.withMessageTimeout(ctx, ws.AgentMsgAgentResponseReq, req.Timeout)
+		result, err = r.executor.ExecuteAgentResponse(execCtx, req)
+		cancel()
+		release()
+	}
+	if r.leaseRevoked(req.ExecutionID, "agent_response") {
+		return
+	}
 	if err != nil {
 		log.Printf("[agent-response] 실행 에러: execution_id=%s err=%v", req.ExecutionID, err)
-		code := "EXECUTION_ERROR"
+		code := errcode.ExecutionError
 		type codeError interface{ ErrorCode() string }
 		if ce, ok := err.(codeError); ok {
 			code = ce.ErrorCode()
 		}
+		timeout := timeoutDetail(execCtx, err)
+		if timeout != nil {
+			code = errcode.Timeout
+		}
 		errPayload := ws.AgentResponseErrorPayload{
 			ExecutionID: req.ExecutionID,
 			Code:        code,
 			Message:     err.Error(),
 			Retryable:   isRetryableError(err),
+			Timeout:     timeout,
 		}
 		_ = r.client.SendAgentResponseError(errPayload)
+		r.client.fireEvent(eventhook.EventTaskFailed, withTaskError(taskEventData(req.ExecutionID, req.Provider, req.Model), code, err))
 		return
 	}
 
 	// 완료 응답 전송
+	r.sizeGuard.AgentResponse(&result)
 	log.Printf("[agent-response] 실행 완료: execution_id=%s duration=%dms stop_reason=%s tool_calls=%d", req.ExecutionID, result.Duration, result.StopReason, len(result.ToolCalls))
 	_ = r.client.sendMessage(ws.AgentMsgAgentResponseComplete, result)
+	r.client.fireEvent(eventhook.EventTaskCompleted, withTaskResult(taskEventData(req.ExecutionID, req.Provider, req.Model), result.Duration, result.ExitCode))
+}
+
+// alternativesError는 고정 실행 실패 시 로컬 대체 목록을 노출하는 에러 인터페이스입니다.
+// executor.TaskError와 호환됩니다.
+type alternativesError interface {
+	ModelAlternatives() []ws.ModelAlternative
+}
+
+// workDirRootsError는 작업 디렉토리 거부 시 허용된 루트 목록을 노출하는 에러 인터페이스입니다.
+// executor.TaskError와 호환됩니다.
+type workDirRootsError interface {
+	WorkDirRoots() []string
 }
 
 // retryable은 재시도 가능 여부를 노출하는 에러 인터페이스입니다.
@@ -646,13 +878,24 @@ func (r *Router) handleBuildRequest(ctx context.Context, msg ws.AgentMessage) er
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("빌드 요청 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
 		return r.client.SendTaskError(errPayload)
 	}
 
+	// 종료 드레이닝 중에는 새 작업을 받지 않음
+	if r.IsDraining() {
+		return r.rejectWhileDraining(req.ExecutionID, "build")
+	}
+	if reason, paused := r.IntakePaused(); paused {
+		return r.rejectWhilePaused(req.ExecutionID, "build", reason)
+	}
+	if r.readOnly {
+		return r.rejectWhileReadOnly(req.ExecutionID, "build")
+	}
+
 	// FR-P2-04: 빌드 태스크 추적 시작
 	r.client.TaskTracker().Track(req.ExecutionID, "build")
 
@@ -660,7 +903,7 @@ func (r *Router) handleBuildRequest(ctx context.Context, msg ws.AgentMessage) er
 		r.client.TaskTracker().Complete(req.ExecutionID)
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: req.ExecutionID,
-			Code:        "NO_EXECUTOR",
+			Code:        errcode.NoExecutor,
 			Message:     "빌드 실행기가 설정되지 않았습니다",
 			Retryable:   false,
 		}
@@ -668,9 +911,29 @@ func (r *Router) handleBuildRequest(ctx context.Context, msg ws.AgentMessage) er
 	}
 
 	// 비동기로 빌드 실행
+	execCtx := r.leaseContext(ctx, req.ExecutionID)
 	go func() {
 		defer r.client.TaskTracker().Complete(req.ExecutionID) // FR-P2-04
-		result := r.buildExecutor.Execute(ctx, req)
+		release, wait, err := r.acquireTaskSlot(execCtx, "build", req.ExecutionID, req.WorkspaceID, req.Priority)
+		if err != nil {
+			r.sendQueueCancelled(req.ExecutionID, "build", err)
+			return
+		}
+		runCtx, cancel, timeout := r.withMessageTimeout(execCtx, ws.AgentMsgBuildReq, req.Timeout)
+		req.Timeout = timeout
+		var result *ws.BuildResultPayload
+		if stepExec, ok := r.buildExecutor.(StepProgressBuildExecutor); ok {
+			result = stepExec.ExecuteWithProgress(runCtx, req, NewProgressReporter(r.client, req.ExecutionID).StepFunc())
+		} else {
+			result = r.buildExecutor.Execute(runCtx, req)
+		}
+		result.Timeout = timeoutDetail(runCtx, nil)
+		cancel()
+		release()
+		if r.leaseRevoked(req.ExecutionID, "build") {
+			return
+		}
+		result.QueueWaitMs = wait.Milliseconds()
 		_ = r.client.SendBuildResult(*result)
 	}()
 
@@ -683,13 +946,24 @@ func (r *Router) handleTestRequest(ctx context.Context, msg ws.AgentMessage) err
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("테스트 요청 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
 		return r.client.SendTaskError(errPayload)
 	}
 
+	// 종료 드레이닝 중에는 새 작업을 받지 않음
+	if r.IsDraining() {
+		return r.rejectWhileDraining(req.ExecutionID, "test")
+	}
+	if reason, paused := r.IntakePaused(); paused {
+		return r.rejectWhilePaused(req.ExecutionID, "test", reason)
+	}
+	if r.readOnly {
+		return r.rejectWhileReadOnly(req.ExecutionID, "test")
+	}
+
 	// FR-P2-04: 테스트 태스크 추적 시작
 	r.client.TaskTracker().Track(req.ExecutionID, "test")
 
@@ -697,7 +971,7 @@ func (r *Router) handleTestRequest(ctx context.Context, msg ws.AgentMessage) err
 		r.client.TaskTracker().Complete(req.ExecutionID)
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: req.ExecutionID,
-			Code:        "NO_EXECUTOR",
+			Code:        errcode.NoExecutor,
 			Message:     "테스트 실행기가 설정되지 않았습니다",
 			Retryable:   false,
 		}
@@ -705,9 +979,29 @@ func (r *Router) handleTestRequest(ctx context.Context, msg ws.AgentMessage) err
 	}
 
 	// 비동기로 테스트 실행
+	execCtx := r.leaseContext(ctx, req.ExecutionID)
 	go func() {
 		defer r.client.TaskTracker().Complete(req.ExecutionID) // FR-P2-04
-		result := r.testExecutor.Execute(ctx, req)
+		release, wait, err := r.acquireTaskSlot(execCtx, "test", req.ExecutionID, req.WorkspaceID, req.Priority)
+		if err != nil {
+			r.sendQueueCancelled(req.ExecutionID, "test", err)
+			return
+		}
+		runCtx, cancel, timeout := r.withMessageTimeout(execCtx, ws.AgentMsgTestReq, req.Timeout)
+		req.Timeout = timeout
+		var result *ws.TestResultPayload
+		if stepExec, ok := r.testExecutor.(StepProgressTestExecutor); ok {
+			result = stepExec.ExecuteWithProgress(runCtx, req, NewProgressReporter(r.client, req.ExecutionID).StepFunc())
+		} else {
+			result = r.testExecutor.Execute(runCtx, req)
+		}
+		result.Timeout = timeoutDetail(runCtx, nil)
+		cancel()
+		release()
+		if r.leaseRevoked(req.ExecutionID, "test") {
+			return
+		}
+		result.QueueWaitMs = wait.Milliseconds()
 		_ = r.client.SendTestResult(*result)
 	}()
 
@@ -720,13 +1014,24 @@ func (r *Router) handleQARequest(ctx context.Context, msg ws.AgentMessage) error
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("QA 요청 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
 		return r.client.SendTaskError(errPayload)
 	}
 
+	// 종료 드레이닝 중에는 새 작업을 받지 않음
+	if r.IsDraining() {
+		return r.rejectWhileDraining(req.ExecutionID, "qa")
+	}
+	if reason, paused := r.IntakePaused(); paused {
+		return r.rejectWhilePaused(req.ExecutionID, "qa", reason)
+	}
+	if r.readOnly {
+		return r.rejectWhileReadOnly(req.ExecutionID, "qa")
+	}
+
 	// FR-P2-04: QA 태스크 추적 시작
 	r.client.TaskTracker().Track(req.ExecutionID, "qa")
 
@@ -734,7 +1039,7 @@ func (r *Router) handleQARequest(ctx context.Context, msg ws.AgentMessage) error
 		r.client.TaskTracker().Complete(req.ExecutionID)
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: req.ExecutionID,
-			Code:        "NO_EXECUTOR",
+			Code:        errcode.NoExecutor,
 			Message:     "QA 실행기가 설정되지 않았습니다",
 			Retryable:   false,
 		}
@@ -742,9 +1047,22 @@ func (r *Router) handleQARequest(ctx context.Context, msg ws.AgentMessage) error
 	}
 
 	// 비동기로 QA 파이프라인 실행
+	execCtx := r.leaseContext(ctx, req.ExecutionID)
 	go func() {
 		defer r.client.TaskTracker().Complete(req.ExecutionID) // FR-P2-04
-		result := r.qaExecutor.Execute(ctx, req)
+		runCtx, cancel, timeout := r.withMessageTimeout(execCtx, ws.AgentMsgQAReq, req.Timeout)
+		defer cancel()
+		req.Timeout = timeout
+		var result *ws.QAResultPayload
+		if stepExec, ok := r.qaExecutor.(StepProgressQAExecutor); ok {
+			result = stepExec.ExecuteWithProgress(runCtx, req, NewProgressReporter(r.client, req.ExecutionID).StepFunc())
+		} else {
+			result = r.qaExecutor.Execute(runCtx, req)
+		}
+		result.Timeout = timeoutDetail(runCtx, nil)
+		if r.leaseRevoked(req.ExecutionID, "qa") {
+			return
+		}
 		_ = r.client.SendQAResult(*result)
 	}()
 
@@ -757,7 +1075,7 @@ func (r *Router) handleComputerSessionStart(ctx context.Context, msg ws.AgentMes
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("computer session start 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -767,24 +1085,54 @@ func (r *Router) handleComputerSessionStart(ctx context.Context, msg ws.AgentMes
 	if r.computerUseHandler == nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: payload.ExecutionID,
-			Code:        "NO_HANDLER",
+			Code:        errcode.NoHandler,
 			Message:     "Computer Use 핸들러가 설정되지 않았습니다",
 			Retryable:   false,
 		}
 		return r.client.SendTaskError(errPayload)
 	}
 
-	if err := r.computerUseHandler.HandleSessionStart(ctx, payload); err != nil {
-		result := ws.ComputerResultPayload{
+	if err := r.checkAction(ctx, approval.ActionComputerUse, payload.URL, fmt.Sprintf("session=%s", payload.SessionID)); err != nil {
+		log.Printf("[computer-use] 세션 시작 거부 (session=%s): %v", payload.SessionID, err)
+		return r.client.SendComputerResult(ws.ComputerResultPayload{
 			ExecutionID: payload.ExecutionID,
 			SessionID:   payload.SessionID,
 			Success:     false,
 			Error:       err.Error(),
-			DurationMs:  0,
-		}
-		return r.client.SendComputerResult(result)
+		})
 	}
 
+	// 동시 세션 한도에 걸리면 대기열에서 기다리므로 비동기로 실행하고,
+	// 대기 순번이 바뀔 때마다 서버에 알린다.
+	go func() {
+		onQueued := func(position int) {
+			_ = r.client.SendComputerResult(ws.ComputerResultPayload{
+				ExecutionID:   payload.ExecutionID,
+				SessionID:     payload.SessionID,
+				Success:       true,
+				Queued:        true,
+				QueuePosition: position,
+			})
+		}
+
+		if err := r.computerUseHandler.HandleSessionStartQueued(ctx, payload, onQueued); err != nil {
+			result := ws.ComputerResultPayload{
+				ExecutionID: payload.ExecutionID,
+				SessionID:   payload.SessionID,
+				Success:     false,
+				Error:       err.Error(),
+				DurationMs:  0,
+			}
+			_ = r.client.SendComputerResult(result)
+			return
+		}
+		r.client.fireEvent(eventhook.EventComputerSessionStarted, map[string]string{
+			"execution_id": payload.ExecutionID,
+			"session_id":   payload.SessionID,
+			"url":          payload.URL,
+		})
+	}()
+
 	return nil
 }
 
@@ -794,7 +1142,7 @@ func (r *Router) handleComputerAction(ctx context.Context, msg ws.AgentMessage)
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("computer action 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -856,7 +1204,7 @@ func (r *Router) handleComputerSessionEnd(ctx context.Context, msg ws.AgentMessa
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("computer session end 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -883,6 +1231,9 @@ func (r *Router) handleComputerSessionEnd(ctx context.Context, msg ws.AgentMessa
 //
 // Implements the ReconnectionHandler interface.
 func (r *Router) OnReconnected(ctx context.Context) error {
+	// 재연결 중 끊긴 offer를 서버가 놓쳤을 수 있으므로 남은 체크포인트를 다시 알린다.
+	r.SendTaskResumeOffers()
+
 	// Computer Use 세션 복원
 	if r.computerUseHandler != nil {
 		sessions := r.computerUseHandler.GetActiveSessions()
@@ -898,12 +1249,13 @@ func (r *Router) OnReconnected(ctx context.Context) error {
 
 				// 서버에 활성 세션 알림
 				sessionPayload := ws.ComputerSessionPayload{
-					ExecutionID: session.ExecutionID,
-					SessionID:   session.ID,
-					URL:         session.URL,
-					ViewportW:   session.ViewportW,
-					ViewportH:   session.ViewportH,
-					Headless:    session.Headless,
+					ExecutionID:    session.ExecutionID,
+					SessionID:      session.ID,
+					URL:            session.URL,
+					ViewportW:      session.ViewportW,
+					ViewportH:      session.ViewportH,
+					Headless:       session.Headless,
+					PersistProfile: session.PersistProfile,
 				}
 				if err := r.client.sendMessage(ws.AgentMsgComputerSessionStart, sessionPayload); err != nil {
 					log.Printf("[computer-use] failed to restore session %s: %v", session.ID, err)
@@ -985,7 +1337,7 @@ func (r *Router) handleBrowserSessionStart(ctx context.Context, msg ws.AgentMess
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("browser session start 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -995,13 +1347,22 @@ func (r *Router) handleBrowserSessionStart(ctx context.Context, msg ws.AgentMess
 	if r.agentBrowserHandler == nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: payload.ExecutionID,
-			Code:        "NO_HANDLER",
+			Code:        errcode.NoHandler,
 			Message:     "Agent Browser 핸들러가 설정되지 않았습니다",
 			Retryable:   false,
 		}
 		return r.client.SendTaskError(errPayload)
 	}
 
+	if r.readOnly {
+		return r.client.SendTaskError(ws.TaskErrorPayload{
+			ExecutionID: payload.ExecutionID,
+			Code:        ErrCodeReadOnly,
+			Message:     readOnlyMessage,
+			Retryable:   false,
+		})
+	}
+
 	result, err := r.agentBrowserHandler.HandleSessionStart(ctx, payload)
 	if err != nil {
 		// not_available 또는 에러 응답
@@ -1028,7 +1389,7 @@ func (r *Router) handleBrowserAction(ctx context.Context, msg ws.AgentMessage) e
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("browser action 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -1088,7 +1449,7 @@ func (r *Router) handleBrowserSessionEnd(ctx context.Context, msg ws.AgentMessag
 	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("browser session end 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -1112,12 +1473,17 @@ func (r *Router) handleBrowserSessionEnd(ctx context.Context, msg ws.AgentMessag
 func (r *Router) handleMCPCodegenRequest(ctx context.Context, msg ws.AgentMessage) error {
 	var req ws.MCPCodegenRequestPayload
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
-		return r.sendMCPError(msg.ID, req.ServiceName, fmt.Sprintf("mcp_codegen_request 페이로드 파싱 실패: %v", err), true)
+		return r.sendMCPError(msg.ID, req.ServiceName, errcode.InvalidPayload, fmt.Sprintf("mcp_codegen_request 페이로드 파싱 실패: %v", err), true)
 	}
 
 	if r.codegenExecutor == nil {
 		log.Printf("[self-expand] codegen executor가 설정되지 않음, 요청 무시: %s", req.ServiceName)
-		return r.sendMCPError(msg.ID, req.ServiceName, "코드 생성기가 설정되지 않음", true)
+		return r.sendMCPError(msg.ID, req.ServiceName, errcode.NoHandler, "코드 생성기가 설정되지 않음", true)
+	}
+
+	if r.readOnly {
+		log.Printf("[self-expand] 읽기 전용 모드, 코드 생성 거절: %s", req.ServiceName)
+		return r.sendMCPError(msg.ID, req.ServiceName, ErrCodeReadOnly, readOnlyMessage, false)
 	}
 
 	// 비동기로 코드 생성 실행
@@ -1128,11 +1494,15 @@ func (r *Router) handleMCPCodegenRequest(ctx context.Context, msg ws.AgentMessag
 			homeDir, _ := os.UserHomeDir()
 			sandboxBase = filepath.Join(homeDir, ".acos", "codegen-sandbox")
 		}
-		sandbox := codegen.NewSandbox(sandboxBase, nil)
+		var sandboxOpts []codegen.SandboxOption
+		if r.codegenSandboxQuota != nil {
+			sandboxOpts = append(sandboxOpts, codegen.WithQuota(*r.codegenSandboxQuota))
+		}
+		sandbox := codegen.NewSandbox(sandboxBase, nil, sandboxOpts...)
 		outputDir, cleanup, err := sandbox.Create(req.ServiceName)
 		if err != nil {
 			log.Printf("[self-expand] 샌드박스 생성 실패 (service=%s): %v", req.ServiceName, err)
-			r.sendCodegenError(msg.ID, err.Error())
+			r.sendCodegenError(msg.ID, err)
 			return
 		}
 		defer cleanup()
@@ -1143,6 +1513,7 @@ func (r *Router) handleMCPCodegenRequest(ctx context.Context, msg ws.AgentMessag
 				Phase:    phase,
 				Progress: progress,
 				Message:  message,
+				Step:     codegenStep(phase, progress, message),
 			})
 		}
 
@@ -1159,13 +1530,20 @@ func (r *Router) handleMCPCodegenRequest(ctx context.Context, msg ws.AgentMessag
 		result, err := r.codegenExecutor.Generate(ctx, genReq, progressFn)
 		if err != nil {
 			log.Printf("[self-expand] 코드 생성 실패 (service=%s): %v", req.ServiceName, err)
-			r.sendCodegenError(msg.ID, err.Error())
+			r.sendCodegenError(msg.ID, err)
 			return
 		}
 
 		// 에러가 있는 결과
 		if result.Error != "" {
-			r.sendCodegenError(msg.ID, result.Error)
+			r.sendCodegenError(msg.ID, errors.New(result.Error))
+			return
+		}
+
+		// 생성된 코드가 샌드박스 할당량 이내인지 확인
+		if err := sandbox.CheckQuota(outputDir); err != nil {
+			log.Printf("[self-expand] 샌드박스 할당량 확인 실패 (service=%s): %v", req.ServiceName, err)
+			r.sendCodegenError(msg.ID, err)
 			return
 		}
 
@@ -1197,18 +1575,29 @@ func (r *Router) handleMCPCodegenRequest(ctx context.Context, msg ws.AgentMessag
 }
 
 // sendCodegenError는 코드 생성 에러 결과를 서버로 전송합니다.
-func (r *Router) sendCodegenError(msgID, errMsg string) {
+// 디스크 할당량 초과는 QUOTA_EXCEEDED 에러 코드와 해결 방법으로 보고합니다.
+func (r *Router) sendCodegenError(msgID string, err error) {
 	_ = r.client.SendMCPCodegenResult(msgID, ws.MCPCodegenResultPayload{
-		Status: "error",
-		Error:  errMsg,
+		Status:    "error",
+		Error:     err.Error(),
+		ErrorCode: mcpErrorCode(err),
+		ErrorHint: errcode.ResultHint(mcpErrorCode(err)),
 	})
 }
 
+// mcpErrorCode는 코드 생성/배포 에러에 해당하는 결과 페이로드 에러 코드를 반환합니다.
+func mcpErrorCode(err error) string {
+	if errors.Is(err, diskquota.ErrQuotaExceeded) {
+		return errcode.QuotaExceeded
+	}
+	return ""
+}
+
 // handleMCPDeploy는 서버로부터 수신한 MCP 배포 요청을 처리합니다 (SPEC-SELF-EXPAND-001).
 func (r *Router) handleMCPDeploy(ctx context.Context, msg ws.AgentMessage) error {
 	var req ws.MCPDeployPayload
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
-		return r.sendMCPError(msg.ID, "", fmt.Sprintf("mcp_deploy 페이로드 파싱 실패: %v", err), true)
+		return r.sendMCPError(msg.ID, "", errcode.InvalidPayload, fmt.Sprintf("mcp_deploy 페이로드 파싱 실패: %v", err), true)
 	}
 
 	if r.mcpDeployer == nil {
@@ -1221,38 +1610,93 @@ func (r *Router) handleMCPDeploy(ctx context.Context, msg ws.AgentMessage) error
 		return nil
 	}
 
-	// 비동기로 배포 실행
+	// 아카이브 모드: 서비스 파일을 tar.gz로 받아 압축 해제
+	if req.Archive != nil {
+		r.startMCPDeployArchive(ctx, msg.ID, req)
+		return nil
+	}
+
+	// ws 파일을 mcp.DeployFile로 변환
+	files := make([]mcp.DeployFile, 0, len(req.Files))
+	for _, f := range req.Files {
+		files = append(files, mcp.DeployFile{
+			Path:    f.Path,
+			Content: f.Content,
+		})
+	}
+
+	detail := fmt.Sprintf("files=%d", len(req.Files))
+	if req.VerifyOnly {
+		r.runMCPVerify(ctx, msg.ID, req.ServiceName, detail, func() (*mcp.VerifyReport, error) {
+			return r.mcpDeployer.Verify(ctx, req.ServiceName, files, req.EnvVars, mcpVerifyOptions(req))
+		})
+		return nil
+	}
+	r.runMCPDeploy(ctx, msg.ID, req.ServiceName, detail, func() (string, error) {
+		return r.mcpDeployer.Deploy(ctx, req.ServiceName, files, req.EnvVars)
+	})
+	return nil
+}
+
+// runMCPDeploy는 액션 게이트 승인 후 deploy를 비동기로 실행하고 배포 결과를 전송합니다.
+func (r *Router) runMCPDeploy(ctx context.Context, msgID, serviceName, detail string, deploy func() (string, error)) {
 	go func() {
-		// ws 파일을 mcp.DeployFile로 변환
-		files := make([]mcp.DeployFile, 0, len(req.Files))
-		for _, f := range req.Files {
-			files = append(files, mcp.DeployFile{
-				Path:    f.Path,
-				Content: f.Content,
+		if err := r.checkAction(ctx, approval.ActionMCPDeploy, serviceName, detail); err != nil {
+			log.Printf("[self-expand] MCP 배포 거부 (service=%s): %v", serviceName, err)
+			_ = r.client.SendMCPDeployResult(msgID, ws.MCPDeployResultPayload{
+				ServiceName: serviceName,
+				Success:     false,
+				Error:       err.Error(),
 			})
+			return
 		}
 
-		deployPath, err := r.mcpDeployer.Deploy(ctx, req.ServiceName, files, req.EnvVars)
+		deployPath, err := deploy()
 		if err != nil {
-			log.Printf("[self-expand] MCP 배포 실패 (service=%s): %v", req.ServiceName, err)
-			_ = r.client.SendMCPDeployResult(msg.ID, ws.MCPDeployResultPayload{
-				ServiceName: req.ServiceName,
-				Success:     false,
-				Error:       err.Error(),
-			})
+			log.Printf("[self-expand] MCP 배포 실패 (service=%s): %v", serviceName, err)
+			r.sendMCPDeployError(msgID, serviceName, err)
 			return
 		}
 
-		_ = r.client.SendMCPDeployResult(msg.ID, ws.MCPDeployResultPayload{
-			ServiceName: req.ServiceName,
+		_ = r.client.SendMCPDeployResult(msgID, ws.MCPDeployResultPayload{
+			ServiceName: serviceName,
 			Success:     true,
 			DeployPath:  deployPath,
 		})
 
-		log.Printf("[self-expand] MCP 배포 완료 (service=%s, path=%s)", req.ServiceName, deployPath)
+		log.Printf("[self-expand] MCP 배포 완료 (service=%s, path=%s)", serviceName, deployPath)
 	}()
+}
 
-	return nil
+// codegenStep은 코드 생성 단계를 task_progress와 같은 단계 모델로 변환합니다.
+// 마지막 보고(progress 100)에서 단계를 완료로 표시하며, 알 수 없는 단계면 nil입니다.
+func codegenStep(phase string, progress int, message string) *ws.ProgressStep {
+	index := codegen.PhaseIndex(phase)
+	if index < 0 {
+		return nil
+	}
+	status := ws.ProgressStepRunning
+	if progress >= 100 {
+		status = ws.ProgressStepCompleted
+	}
+	return &ws.ProgressStep{
+		Name:   phase,
+		Index:  index,
+		Total:  len(codegen.Phases),
+		Status: status,
+		Detail: message,
+	}
+}
+
+// sendMCPDeployError는 배포 실패 결과를 전송합니다.
+func (r *Router) sendMCPDeployError(msgID, serviceName string, err error) {
+	_ = r.client.SendMCPDeployResult(msgID, ws.MCPDeployResultPayload{
+		ServiceName: serviceName,
+		Success:     false,
+		Error:       err.Error(),
+		ErrorCode:   mcpErrorCode(err),
+		ErrorHint:   errcode.ResultHint(mcpErrorCode(err)),
+	})
 }
 
 // ProgressReporter는 작업 진행 상황을 보고하는 헬퍼입니다.
@@ -1289,6 +1733,29 @@ func (p *ProgressReporter) ReportToolUse(progress int, message string) error {
 	return p.Report(progress, message, "tool_use")
 }
 
+// ReportStep은 구조화된 단계 진행 상황을 보고합니다.
+// 진행률은 단계 위치(Index/Total)로 계산하고, 메시지는 단계 이름과 설명입니다.
+func (p *ProgressReporter) ReportStep(step ws.ProgressStep) error {
+	message := step.Name + ": " + step.Status
+	if step.Detail != "" {
+		message += " (" + step.Detail + ")"
+	}
+	return p.client.SendTaskProgress(ws.TaskProgressPayload{
+		ExecutionID: p.executionID,
+		Progress:    step.Percent(),
+		Message:     message,
+		Type:        ws.ProgressTypeStep,
+		Step:        &step,
+	})
+}
+
+// StepFunc는 실행기에 넘길 단계 콜백을 반환합니다. 전송 실패는 무시합니다.
+func (p *ProgressReporter) StepFunc() func(ws.ProgressStep) {
+	return func(step ws.ProgressStep) {
+		_ = p.ReportStep(step)
+	}
+}
+
 // TaskHandler는 작업 요청을 처리하는 함수 타입입니다.
 type TaskHandler func(ctx context.Context, task ws.TaskRequestPayload, reporter *ProgressReporter) (ws.TaskResultPayload, error)
 
@@ -1319,7 +1786,7 @@ func (r *Router) handleCLIRequest(ctx context.Context, msg ws.AgentMessage) erro
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
 		errPayload := ws.TaskErrorPayload{
 			ExecutionID: "",
-			Code:        "INVALID_PAYLOAD",
+			Code:        errcode.InvalidPayload,
 			Message:     fmt.Sprintf("cli_request 페이로드 파싱 실패: %v", err),
 			Retryable:   false,
 		}
@@ -1333,7 +1800,32 @@ func (r *Router) handleCLIRequest(ctx context.Context, msg ws.AgentMessage) erro
 
 	// 비동기로 CLI 실행 (블로킹 방지)
 	go func() {
-		result := r.cliExecutor.Execute(ctx, &req)
+		var result *ws.CLIResultPayload
+		if err := r.checkAction(ctx, approval.ActionCLIRequest, req.Command, req.WorkingDir); err != nil {
+			log.Printf("[skill-v2] CLI 요청 거부: command=%q err=%v", req.Command, err)
+			result = &ws.CLIResultPayload{
+				ExitCode: cliExitCodeDenied,
+				Stderr:   err.Error(),
+			}
+		} else {
+			// 승인 대기 시간은 타임아웃에 포함하지 않는다.
+			runCtx, cancel, timeout := r.withMessageTimeout(ctx, ws.AgentMsgCLIRequest, req.TimeoutSeconds)
+			req.TimeoutSeconds = timeout
+			if streaming, ok := r.cliExecutor.(CLIStreamingExecutor); ok {
+				result = streaming.ExecuteStreaming(runCtx, &req, func(out ws.CLIOutputPayload) {
+					if err := r.client.SendCLIOutput(msg.ID, out); err != nil {
+						log.Printf("[skill-v2] CLI 출력 전송 실패: seq=%d err=%v", out.Seq, err)
+					}
+				})
+			} else {
+				result = r.cliExecutor.Execute(runCtx, &req)
+			}
+			result.Timeout = timeoutDetail(runCtx, nil)
+			cancel()
+		}
+
+		// stdout/stderr가 크기 한도를 넘으면 줄이고 전체 출력은 로컬 파일에 보관
+		r.sizeGuard.CLIResult(msg.ID, result)
 
 		// 결과를 cli_result 메시지로 전송
 		resultPayload, err := json.Marshal(result)
@@ -1361,12 +1853,17 @@ func (r *Router) handleCLIRequest(ctx context.Context, msg ws.AgentMessage) erro
 func (r *Router) handleMCPStart(ctx context.Context, msg ws.AgentMessage) error {
 	var req ws.MCPStartPayload
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
-		return r.sendMCPError(msg.ID, "", fmt.Sprintf("mcp_start 페이로드 파싱 실패: %v", err), true)
+		return r.sendMCPError(msg.ID, "", errcode.InvalidPayload, fmt.Sprintf("mcp_start 페이로드 파싱 실패: %v", err), true)
 	}
 
 	if r.mcpStarter == nil {
 		log.Printf("[mcp] MCP starter가 설정되지 않음, 요청 무시: %s", req.ServerName)
-		return r.sendMCPError(msg.ID, req.ServerName, "MCP 관리자가 설정되지 않음", true)
+		return r.sendMCPError(msg.ID, req.ServerName, errcode.NoHandler, "MCP 관리자가 설정되지 않음", true)
+	}
+
+	if r.readOnly {
+		log.Printf("[mcp] 읽기 전용 모드, MCP 서버 시작 거절: %s", req.ServerName)
+		return r.sendMCPError(msg.ID, req.ServerName, ErrCodeReadOnly, readOnlyMessage, false)
 	}
 
 	// 비동기로 MCP 서버 시작 (블로킹 방지)
@@ -1374,7 +1871,7 @@ func (r *Router) handleMCPStart(ctx context.Context, msg ws.AgentMessage) error
 		pid, err := r.mcpStarter.StartServer(ctx, req.ServerName, req.Command, req.Args, req.Env, req.WorkingDir)
 		if err != nil {
 			log.Printf("[mcp] MCP 서버 시작 실패 (name=%s): %v", req.ServerName, err)
-			if sendErr := r.sendMCPError(msg.ID, req.ServerName, err.Error(), true); sendErr != nil {
+			if sendErr := r.sendMCPError(msg.ID, req.ServerName, errcode.MCPStartFailed, err.Error(), true); sendErr != nil {
 				log.Printf("[mcp] MCP 에러 메시지 전송 실패: %v", sendErr)
 			}
 			return
@@ -1415,7 +1912,7 @@ func (r *Router) handleMCPStart(ctx context.Context, msg ws.AgentMessage) error
 func (r *Router) handleMCPStop(ctx context.Context, msg ws.AgentMessage) error {
 	var req ws.MCPStopPayload
 	if err := json.Unmarshal(msg.Payload, &req); err != nil {
-		return r.sendMCPError(msg.ID, "", fmt.Sprintf("mcp_stop 페이로드 파싱 실패: %v", err), true)
+		return r.sendMCPError(msg.ID, "", errcode.InvalidPayload, fmt.Sprintf("mcp_stop 페이로드 파싱 실패: %v", err), true)
 	}
 
 	if r.mcpStarter == nil {
@@ -1425,20 +1922,21 @@ func (r *Router) handleMCPStop(ctx context.Context, msg ws.AgentMessage) error {
 
 	if err := r.mcpStarter.StopServer(req.ServerName, req.Force); err != nil {
 		log.Printf("[mcp] MCP 서버 중지 실패 (name=%s): %v", req.ServerName, err)
-		return r.sendMCPError(msg.ID, req.ServerName, err.Error(), false)
+		return r.sendMCPError(msg.ID, req.ServerName, errcode.MCPStopFailed, err.Error(), false)
 	}
 
 	log.Printf("[mcp] MCP 서버 중지 완료 (name=%s, force=%v)", req.ServerName, req.Force)
 	return nil
 }
 
-// sendMCPError는 MCP 에러 메시지를 서버로 전송합니다 (SPEC-SKILL-V2-001 Block D).
-func (r *Router) sendMCPError(msgID, serverName, errMsg string, isFatal bool) error {
-	errPayload := ws.MCPErrorPayload{
+// sendMCPError는 MCP 에러 메시지를 에러 코드의 심각도, 해결 방법과 함께 서버로 전송합니다 (SPEC-SKILL-V2-001 Block D).
+func (r *Router) sendMCPError(msgID, serverName, code, errMsg string, isFatal bool) error {
+	errPayload := errcode.MCPError(ws.MCPErrorPayload{
 		ServerName: serverName,
 		Error:      errMsg,
 		IsFatal:    isFatal,
-	}
+		Code:       code,
+	})
 
 	payload, err := json.Marshal(errPayload)
 	if err != nil {
@@ -1512,6 +2010,17 @@ func (r *Router) handleCodeOpsRequest(ctx context.Context, msg ws.AgentMessage)
 		return r.sendCodeOpsResult(result)
 	}
 
+	if r.readOnly {
+		log.Printf("[codeops] 읽기 전용 모드, 코드 수정 거절: request_id=%s", req.RequestID)
+		return r.sendCodeOpsResult(ws.CodeOpsResultPayload{
+			RequestID:     req.RequestID,
+			WorkspaceID:   req.WorkspaceID,
+			Success:       false,
+			Error:         errReadOnly.Error(),
+			CorrelationID: req.CorrelationID,
+		})
+	}
+
 	// 비동기로 CodeOps 실행
 	go func() {
 		result, err := r.codeOpsWorker.Execute(ctx, req)
@@ -1565,7 +2074,17 @@ func (r *Router) handleCodingRelayRequest(ctx context.Context, msg ws.AgentMessa
 		_ = r.sendCodingRelayError(ws.CodingRelayErrorPayload{
 			RequestID: req.RequestID,
 			Error:     "CodingRelayRunner가 설정되지 않았습니다",
-			Code:      "runner_not_configured",
+			Code:      errcode.RelayRunnerNotConfigured,
+		})
+		return nil
+	}
+
+	if r.readOnly {
+		log.Printf("[coding-relay] 읽기 전용 모드, 릴레이 거절: request_id=%s", req.RequestID)
+		_ = r.sendCodingRelayError(ws.CodingRelayErrorPayload{
+			RequestID: req.RequestID,
+			Error:     readOnlyMessage,
+			Code:      ErrCodeReadOnly,
 		})
 		return nil
 	}
@@ -1627,7 +2146,7 @@ func (r *Router) handleCodingRelayFeedback(_ context.Context, msg ws.AgentMessag
 
 // sendCodingRelayError는 릴레이 에러를 서버에 전송합니다.
 func (r *Router) sendCodingRelayError(payload ws.CodingRelayErrorPayload) error {
-	data, err := json.Marshal(payload)
+	data, err := json.Marshal(errcode.CodingRelayError(payload))
 	if err != nil {
 		return fmt.Errorf("coding_relay_error 직렬화 실패: %w", err)
 	}
@@ -437,6 +437,73 @@ func TestHandleTaskRequest_UsesCustomTaskSender(t *testing.T) {
 	}
 }
 
+// pinnedTaskError는 executor.TaskError처럼 코드와 대체 목록을 노출하는 테스트용 에러입니다.
+type pinnedTaskError struct {
+	alternatives []ws.ModelAlternative
+}
+
+func (e *pinnedTaskError) Error() string     { return "unsupported model" }
+func (e *pinnedTaskError) ErrorCode() string { return ws.TaskErrorUnsupportedModel }
+func (e *pinnedTaskError) ModelAlternatives() []ws.ModelAlternative {
+	return e.alternatives
+}
+
+func TestHandleTaskRequest_PinnedErrorIncludesAlternatives(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	taskSender := &stubTaskMessageSender{}
+	alternatives := []ws.ModelAlternative{{Provider: "claude", Models: []string{"claude-sonnet-4-20250514"}}}
+	router := NewRouter(
+		client,
+		WithTaskExecutor(&stubTaskExecutor{err: &pinnedTaskError{alternatives: alternatives}}),
+		WithTaskMessageSender(taskSender),
+	)
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{
+		ExecutionID: "exec-pinned",
+		Provider:    "codex",
+		Model:       "o4-mini",
+		Pinned:      true,
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	msg := ws.AgentMessage{
+		Type:      ws.AgentMsgTaskReq,
+		ID:        "msg-task-pinned",
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+	if err := router.HandleMessage(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMessage() error = %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		taskSender.mu.Lock()
+		n := len(taskSender.errors)
+		taskSender.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	taskSender.mu.Lock()
+	defer taskSender.mu.Unlock()
+
+	if len(taskSender.errors) != 1 {
+		t.Fatalf("len(errors) = %d, want 1", len(taskSender.errors))
+	}
+	got := taskSender.errors[0]
+	if got.Code != ws.TaskErrorUnsupportedModel {
+		t.Errorf("Code = %q, want %q", got.Code, ws.TaskErrorUnsupportedModel)
+	}
+	if len(got.Alternatives) != 1 || got.Alternatives[0].Provider != "claude" {
+		t.Errorf("Alternatives = %+v", got.Alternatives)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Tests: isRetryableError 에러 분류기
 // ---------------------------------------------------------------------------
@@ -19,6 +19,8 @@ var criticalMessageTypes = map[string]bool{
 	ws.AgentMsgTaskReq:     true,
 	ws.AgentMsgTaskResult:  true,
 	ws.AgentMsgTaskError:   true,
+	ws.AgentMsgTaskLeaseRevoke: true, // 작업 리스 회수 (로컬 실행 취소)
+	ws.AgentMsgTaskResume:      true, // 체크포인트 재개/폐기 (로컬 실행 재개)
 	ws.AgentMsgBuildReq:    true,
 	ws.AgentMsgBuildResult: true,
 	ws.AgentMsgTestReq:     true,
@@ -31,8 +33,15 @@ var criticalMessageTypes = map[string]bool{
 	ws.AgentMsgMCPReady:    true, // SPEC-SKILL-V2-001 Block D: MCP 서버 준비 완료
 	ws.AgentMsgMCPStop:     true, // SPEC-SKILL-V2-001 Block D: MCP 서버 중지 요청
 	ws.AgentMsgMCPError:          true, // SPEC-SKILL-V2-001 Block D: MCP 서버 에러
+	ws.AgentMsgMCPServeStart:     true, // 내장 MCP 서버 시작 요청
+	ws.AgentMsgMCPRPC:            true, // 내장 MCP 서버 JSON-RPC 터널 (도구 호출 포함)
 	ws.AgentMsgToolApprovalReq:  true, // SPEC-INTERACTIVE-CLI-001: 도구 승인 요청 서명 필수
 	ws.AgentMsgToolApprovalResp: true, // SPEC-INTERACTIVE-CLI-001: 도구 승인 응답 서명 필수
+	ws.AgentMsgCustomToolRequest: true, // 사용자 정의 로컬 도구 실행 요청
+	ws.AgentMsgCustomToolResult:  true, // 사용자 정의 로컬 도구 실행 결과
+	ws.AgentMsgGitRequest:        true, // git 작업 요청 (자격 증명으로 clone/commit/push)
+	ws.AgentMsgConfigUpdate:      true, // 서버 주도 설정 변경
+	ws.AgentMsgConfigUpdateAck:   true, // 서버 주도 설정 변경 결과
 }
 
 // MessageSigner는 HMAC-SHA256 기반 메시지 서명 및 검증을 담당합니다 (SEC-P2-02).
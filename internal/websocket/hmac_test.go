@@ -111,6 +111,7 @@ func TestMessageSigner_IsCriticalMessage(t *testing.T) {
 		ws.AgentMsgTestResult,
 		ws.AgentMsgQAReq,
 		ws.AgentMsgQAResult,
+		ws.AgentMsgGitRequest,
 	}
 
 	for _, msgType := range criticalTypes {
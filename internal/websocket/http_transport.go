@@ -0,0 +1,316 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// WebSocket이 차단된 네트워크에서 사용하는 HTTP 롱폴링/SSE 전송 계층.
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// httpSessionHeader는 HTTP 폴백 세션 ID를 전달하는 요청 헤더입니다.
+	httpSessionHeader = "X-Bridge-Session"
+	// httpIncomingBuffer는 폴링으로 받아 아직 읽지 않은 메시지 버퍼 크기입니다.
+	httpIncomingBuffer = 64
+	// maxPollResponseSize는 롱폴링 응답 하나의 최대 크기입니다.
+	maxPollResponseSize = 16 * MaxMessageSize
+)
+
+// httpTransport는 HTTP 롱폴링(또는 SSE)으로 WebSocket과 같은 메시지를 주고받는 Transport입니다.
+//
+// 엔드포인트 (기준 주소 base):
+//   - POST   base/session: 세션 생성, 응답 {"session_id": "..."}
+//   - GET    base/poll:    수신 대기. 200 + JSON 배열 또는 text/event-stream, 메시지가 없으면 204
+//   - POST   base/send:    메시지 하나 전송 (본문은 WebSocket 텍스트 프레임과 같은 JSON)
+//   - DELETE base/session: 세션 종료
+//
+// 세션 생성 외 요청은 X-Bridge-Session 헤더로 세션을 지정합니다.
+// 인증은 WebSocket과 같이 첫 메시지인 agent_connect의 토큰으로 수행합니다.
+// 서버가 409를 반환하면 동일 사용자의 새 연결로 교체된 것으로 처리합니다.
+type httpTransport struct {
+	client    *http.Client
+	baseURL   string
+	sessionID string
+
+	// incoming은 폴링 루프가 받은 메시지입니다.
+	incoming chan []byte
+	// failed는 폴링 루프가 에러로 끝나면 닫힙니다. err는 그 이후에만 읽습니다.
+	failed chan struct{}
+	err    error
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+
+	deadlineMu sync.Mutex
+	deadline   time.Time
+}
+
+// dialHTTPTransport는 HTTP 폴백 세션을 만들고 폴링 루프를 시작합니다.
+func dialHTTPTransport(ctx context.Context, client *http.Client, baseURL string) (*httpTransport, error) {
+	if client == nil {
+		client = defaultHTTPTransportClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/session", nil)
+	if err != nil {
+		return nil, fmt.Errorf("세션 요청 생성 실패: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("세션 생성 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("세션 생성 실패: %s", resp.Status)
+	}
+	var session struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxMessageSize)).Decode(&session); err != nil {
+		return nil, fmt.Errorf("세션 응답 파싱 실패: %w", err)
+	}
+	if session.SessionID == "" {
+		return nil, errors.New("세션 응답에 session_id가 없습니다")
+	}
+
+	// 폴링은 연결 타임아웃 컨텍스트가 끝난 뒤에도 계속되어야 하므로 별도 컨텍스트를 사용합니다.
+	pollCtx, cancel := context.WithCancel(context.Background())
+	t := &httpTransport{
+		client:    client,
+		baseURL:   baseURL,
+		sessionID: session.SessionID,
+		incoming:  make(chan []byte, httpIncomingBuffer),
+		failed:    make(chan struct{}),
+		ctx:       pollCtx,
+		cancel:    cancel,
+	}
+	go t.pollLoop()
+	return t, nil
+}
+
+// Name은 전송 방식 이름을 반환합니다.
+func (t *httpTransport) Name() string { return TransportHTTP }
+
+// ReadMessage는 폴링으로 받은 다음 메시지를 반환합니다.
+// 폴링 루프가 끝나기 전에 받은 메시지는 에러보다 먼저 전달합니다.
+func (t *httpTransport) ReadMessage() ([]byte, error) {
+	select {
+	case data := <-t.incoming:
+		return data, nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if deadline := t.readDeadline(); !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case data := <-t.incoming:
+		return data, nil
+	case <-t.failed:
+		select {
+		case data := <-t.incoming:
+			return data, nil
+		default:
+		}
+		return nil, t.err
+	case <-timeout:
+		return nil, fmt.Errorf("HTTP 폴링 수신: %w", os.ErrDeadlineExceeded)
+	}
+}
+
+// WriteMessage는 메시지 하나를 POST로 전송합니다.
+func (t *httpTransport) WriteMessage(data []byte) error {
+	ctx, cancel := context.WithTimeout(t.ctx, WriteTimeout)
+	defer cancel()
+
+	resp, err := t.do(ctx, http.MethodPost, "/send", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	return statusError(resp, "메시지 전송")
+}
+
+// SetReadDeadline은 ReadMessage의 대기 기한을 설정합니다.
+func (t *httpTransport) SetReadDeadline(deadline time.Time) error {
+	t.deadlineMu.Lock()
+	defer t.deadlineMu.Unlock()
+	t.deadline = deadline
+	return nil
+}
+
+// readDeadline은 설정된 읽기 기한을 반환합니다.
+func (t *httpTransport) readDeadline() time.Time {
+	t.deadlineMu.Lock()
+	defer t.deadlineMu.Unlock()
+	return t.deadline
+}
+
+// Ping은 세션이 서버에 아직 살아 있는지 확인합니다.
+func (t *httpTransport) Ping() error {
+	ctx, cancel := context.WithTimeout(t.ctx, PingTimeout)
+	defer cancel()
+
+	resp, err := t.do(ctx, http.MethodGet, "/session", nil)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	return statusError(resp, "세션 확인")
+}
+
+// Close는 폴링을 멈추고 서버에 세션 종료를 알립니다.
+func (t *httpTransport) Close() error {
+	t.closeOnce.Do(func() {
+		t.cancel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), WriteTimeout)
+		defer cancel()
+		resp, err := t.do(ctx, http.MethodDelete, "/session", nil)
+		if err == nil {
+			drainAndClose(resp.Body)
+		}
+	})
+	return nil
+}
+
+// pollLoop는 세션이 닫히거나 에러가 날 때까지 수신 요청을 반복합니다.
+// 모든 에러는 연결 끊김으로 처리되어 Client가 새 세션으로 재연결합니다.
+func (t *httpTransport) pollLoop() {
+	for {
+		err := t.poll()
+		if err == nil {
+			continue
+		}
+		if t.ctx.Err() != nil {
+			err = net.ErrClosed
+		}
+		t.err = err
+		close(t.failed)
+		return
+	}
+}
+
+// poll은 수신 요청 하나를 처리합니다.
+// 서버는 메시지가 생길 때까지 응답을 보류하며, text/event-stream으로 응답하면 스트림이 끝날 때까지 읽습니다.
+func (t *httpTransport) poll() error {
+	resp, err := t.do(t.ctx, http.MethodGet, "/poll", nil)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := statusError(resp, "HTTP 폴링"); err != nil {
+		return err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		return t.readEventStream(resp.Body)
+	}
+
+	var messages []json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPollResponseSize)).Decode(&messages); err != nil {
+		return fmt.Errorf("HTTP 폴링 응답 파싱 실패: %w", err)
+	}
+	for _, data := range messages {
+		if err := t.deliver(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEventStream은 SSE 스트림의 data 필드를 메시지로 전달합니다. 이벤트 하나가 메시지 하나입니다.
+func (t *httpTransport) readEventStream(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxMessageSize)
+
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) > 0 {
+				if err := t.deliver(data); err != nil {
+					return err
+				}
+				data = nil
+			}
+		case strings.HasPrefix(line, "data:"):
+			if len(data) > 0 {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")...)
+		}
+		// 주석(":")과 event/id/retry 필드는 사용하지 않습니다.
+	}
+	return scanner.Err()
+}
+
+// deliver는 받은 메시지를 ReadMessage로 넘깁니다.
+func (t *httpTransport) deliver(data []byte) error {
+	select {
+	case t.incoming <- data:
+		return nil
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	}
+}
+
+// do는 세션 헤더를 붙여 요청을 보냅니다.
+func (t *httpTransport) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("요청 생성 실패: %w", err)
+	}
+	req.Header.Set(httpSessionHeader, t.sessionID)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return t.client.Do(req)
+}
+
+// statusError는 2xx가 아닌 응답을 에러로 변환합니다.
+// 409는 WebSocket의 연결 교체 close 코드와 같이 처리되도록 CloseError로 반환합니다.
+func statusError(resp *http.Response, action string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return &websocket.CloseError{
+			Code: closeCodeReplacedByNewConnection,
+			Text: closeReasonReplacedByNewConnection,
+		}
+	}
+	return fmt.Errorf("%s 실패: %s", action, resp.Status)
+}
+
+// drainAndClose는 연결을 재사용할 수 있도록 남은 본문을 버리고 닫습니다.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, MaxMessageSize))
+	_ = body.Close()
+}
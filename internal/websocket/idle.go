@@ -0,0 +1,142 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// idle.go는 작업이 없는 동안 하트비트 간격을 늘리고 상주 리소스를 내리는 유휴 절전 모드를 구현합니다.
+package websocket
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// IdleModeOptions는 유휴 절전 모드 설정입니다.
+type IdleModeOptions struct {
+	// After는 진행 중인 작업과 수신 메시지 없이 이 시간이 지나면 절전 모드로 전환합니다.
+	After time.Duration
+	// HeartbeatInterval은 절전 중 하트비트 간격입니다.
+	// 연결 품질에 따른 간격보다 짧으면 품질 간격을 그대로 사용합니다.
+	HeartbeatInterval time.Duration
+	// OnIdle은 절전 모드로 전환될 때 별도 고루틴에서 호출됩니다 (프로바이더/샌드박스 중단 등).
+	OnIdle func()
+	// OnWake는 절전 중 메시지를 수신해 깨어날 때 별도 고루틴에서 호출됩니다.
+	OnWake func()
+}
+
+// WithIdleMode는 유휴 절전 모드를 활성화합니다.
+// After 또는 HeartbeatInterval이 0 이하이면 무시합니다.
+func WithIdleMode(opts IdleModeOptions) ClientOption {
+	return func(c *Client) {
+		if opts.After <= 0 || opts.HeartbeatInterval <= 0 {
+			return
+		}
+		c.idle = newIdleMonitor(opts)
+	}
+}
+
+// idleMonitor는 마지막 활동 시각을 추적하고 절전/기상 전환을 관리합니다.
+type idleMonitor struct {
+	opts IdleModeOptions
+	// lastActivity는 마지막 활동 시각입니다 (UnixNano).
+	lastActivity atomic.Int64
+	// sleeping은 절전 모드 여부입니다.
+	sleeping atomic.Bool
+	// wake는 기상 시 하트비트 루프를 즉시 깨우는 채널입니다.
+	wake chan struct{}
+	// now는 테스트에서 시간을 대체하기 위한 함수입니다.
+	now func() time.Time
+}
+
+// newIdleMonitor는 현재 시각을 마지막 활동으로 하는 idleMonitor를 생성합니다.
+func newIdleMonitor(opts IdleModeOptions) *idleMonitor {
+	m := &idleMonitor{
+		opts: opts,
+		wake: make(chan struct{}, 1),
+		now:  time.Now,
+	}
+	m.lastActivity.Store(m.now().UnixNano())
+	return m
+}
+
+// touch는 활동을 기록하고, 절전 중이었다면 기상 처리 후 true를 반환합니다.
+func (m *idleMonitor) touch() bool {
+	m.lastActivity.Store(m.now().UnixNano())
+	if !m.sleeping.CompareAndSwap(true, false) {
+		return false
+	}
+	if m.opts.OnWake != nil {
+		go m.opts.OnWake()
+	}
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// check는 activeTasks가 있으면 활동으로 기록하고, 유휴 시간이 After를 넘으면 절전 모드로 전환합니다.
+// 이번 호출로 절전 모드에 들어갔으면 true를 반환합니다.
+func (m *idleMonitor) check(activeTasks int) bool {
+	if activeTasks > 0 {
+		m.touch()
+		return false
+	}
+	last := time.Unix(0, m.lastActivity.Load())
+	if m.now().Sub(last) < m.opts.After {
+		return false
+	}
+	if !m.sleeping.CompareAndSwap(false, true) {
+		return false
+	}
+	if m.opts.OnIdle != nil {
+		go m.opts.OnIdle()
+	}
+	return true
+}
+
+// interval은 절전 중이면 base와 절전 간격 중 긴 값을, 아니면 base를 반환합니다.
+func (m *idleMonitor) interval(base time.Duration) time.Duration {
+	if m.sleeping.Load() && m.opts.HeartbeatInterval > base {
+		return m.opts.HeartbeatInterval
+	}
+	return base
+}
+
+// IsIdle은 유휴 절전 모드 여부를 반환합니다. 절전 모드가 비활성화되어 있으면 false입니다.
+func (c *Client) IsIdle() bool {
+	return c.idle != nil && c.idle.sleeping.Load()
+}
+
+// heartbeatInterval은 연결 품질과 절전 상태를 반영한 현재 하트비트 간격입니다.
+func (c *Client) heartbeatInterval() time.Duration {
+	base := c.quality.HeartbeatInterval()
+	if c.idle == nil {
+		return base
+	}
+	return c.idle.interval(base)
+}
+
+// heartbeatTimeout은 interval 간격에서 서버 하트비트 응답을 기다리는 최대 시간입니다.
+// 절전으로 간격이 늘어나면 두 번의 간격만큼 기다립니다.
+func heartbeatTimeout(interval time.Duration) time.Duration {
+	if 2*interval > HeartbeatTimeout {
+		return 2 * interval
+	}
+	return HeartbeatTimeout
+}
+
+// idleWake는 절전 기상 알림 채널입니다. 절전 모드가 비활성화되어 있으면 nil입니다.
+func (c *Client) idleWake() <-chan struct{} {
+	if c.idle == nil {
+		return nil
+	}
+	return c.idle.wake
+}
+
+// recordInboundActivity는 서버 메시지 수신을 활동으로 기록합니다.
+// 절전 중이었다면 수신 자체가 연결이 살아 있다는 증거이므로 하트비트 타임아웃 기준도 갱신합니다.
+func (c *Client) recordInboundActivity() {
+	if c.idle == nil || !c.idle.touch() {
+		return
+	}
+	c.lastHeartbeatMu.Lock()
+	c.lastHeartbeat = time.Now()
+	c.lastHeartbeatMu.Unlock()
+}
@@ -0,0 +1,120 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestIdleClient는 시각을 직접 조정할 수 있는 유휴 절전 모드 클라이언트를 생성합니다.
+func newTestIdleClient(t *testing.T) (*Client, *time.Time, chan string) {
+	t.Helper()
+	events := make(chan string, 4)
+	client := NewClient("ws://localhost:0/ws", "test-token", "1.0.0", WithIdleMode(IdleModeOptions{
+		After:             10 * time.Minute,
+		HeartbeatInterval: 5 * time.Minute,
+		OnIdle:            func() { events <- "idle" },
+		OnWake:            func() { events <- "wake" },
+	}))
+	require.NotNil(t, client.idle)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.idle.now = func() time.Time { return now }
+	client.idle.lastActivity.Store(now.UnixNano())
+	return client, &now, events
+}
+
+// waitIdleEvent는 콜백 이벤트를 기다립니다.
+func waitIdleEvent(t *testing.T, events chan string) string {
+	t.Helper()
+	select {
+	case ev := <-events:
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("콜백이 호출되지 않았습니다")
+		return ""
+	}
+}
+
+func TestWithIdleMode_InvalidOptionsDisable(t *testing.T) {
+	client := NewClient("ws://localhost:0/ws", "test-token", "1.0.0", WithIdleMode(IdleModeOptions{After: time.Minute}))
+	assert.Nil(t, client.idle)
+	assert.False(t, client.IsIdle())
+	assert.Nil(t, client.idleWake())
+	assert.Equal(t, HeartbeatInterval, client.heartbeatInterval())
+}
+
+func TestIdleMode_SleepsAfterInactivity(t *testing.T) {
+	client, now, events := newTestIdleClient(t)
+
+	*now = now.Add(9 * time.Minute)
+	assert.False(t, client.idle.check(0))
+	assert.Equal(t, HeartbeatInterval, client.heartbeatInterval())
+
+	*now = now.Add(time.Minute)
+	assert.True(t, client.idle.check(0))
+	assert.Equal(t, "idle", waitIdleEvent(t, events))
+	assert.True(t, client.IsIdle())
+	assert.Equal(t, 5*time.Minute, client.heartbeatInterval())
+	assert.False(t, client.idle.check(0), "이미 절전 중이면 다시 전환하지 않아야 합니다")
+
+	payload := client.buildHeartbeatPayload()
+	assert.True(t, payload.Idle)
+	assert.Equal(t, 300, payload.NextHeartbeatSec)
+}
+
+func TestIdleMode_ActiveTasksPreventSleep(t *testing.T) {
+	client, now, _ := newTestIdleClient(t)
+
+	*now = now.Add(time.Hour)
+	assert.False(t, client.idle.check(1))
+	assert.False(t, client.IsIdle())
+
+	// 작업이 끝난 시점부터 다시 유휴 시간을 센다
+	*now = now.Add(5 * time.Minute)
+	assert.False(t, client.idle.check(0))
+}
+
+func TestIdleMode_InboundMessageWakes(t *testing.T) {
+	client, now, events := newTestIdleClient(t)
+
+	*now = now.Add(10 * time.Minute)
+	require.True(t, client.idle.check(0))
+	assert.Equal(t, "idle", waitIdleEvent(t, events))
+
+	client.lastHeartbeatMu.Lock()
+	client.lastHeartbeat = time.Now().Add(-6 * time.Minute)
+	client.lastHeartbeatMu.Unlock()
+
+	client.recordInboundActivity()
+	assert.Equal(t, "wake", waitIdleEvent(t, events))
+	assert.False(t, client.IsIdle())
+	assert.Equal(t, HeartbeatInterval, client.heartbeatInterval())
+
+	select {
+	case <-client.idleWake():
+	default:
+		t.Fatal("하트비트 루프 기상 알림이 없습니다")
+	}
+
+	client.lastHeartbeatMu.RLock()
+	last := client.lastHeartbeat
+	client.lastHeartbeatMu.RUnlock()
+	assert.Less(t, time.Since(last), time.Minute, "기상 시 하트비트 타임아웃 기준이 갱신되어야 합니다")
+
+	// 깨어 있는 동안의 수신은 콜백을 호출하지 않는다
+	client.recordInboundActivity()
+	select {
+	case ev := <-events:
+		t.Fatalf("불필요한 콜백: %s", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHeartbeatTimeout_ScalesWithInterval(t *testing.T) {
+	assert.Equal(t, HeartbeatTimeout, heartbeatTimeout(HeartbeatInterval))
+	assert.Equal(t, HeartbeatTimeout, heartbeatTimeout(MinHeartbeatInterval))
+	assert.Equal(t, 10*time.Minute, heartbeatTimeout(5*time.Minute))
+}
@@ -0,0 +1,38 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 자격 증명 만료 후 재인증을 기다리는 동안 새 작업 수신을 일시 중지하는 로직.
+package websocket
+
+import (
+	"log"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+// ErrCodeBridgeAuthRequired는 재인증 대기 중 거절한 작업에 사용하는 에러 코드입니다.
+const ErrCodeBridgeAuthRequired = errcode.BridgeAuthRequired
+
+// PauseIntake는 새 작업 수신을 일시 중지합니다.
+// 실행 중인 작업은 계속 진행되고, 이후 수신되는 작업 요청은 reason과 함께 재시도 가능한 에러로 거절됩니다.
+func (r *Router) PauseIntake(reason string) {
+	r.intakePause.Store(&reason)
+}
+
+// ResumeIntake는 일시 중지된 작업 수신을 재개합니다.
+func (r *Router) ResumeIntake() {
+	r.intakePause.Store(nil)
+}
+
+// IntakePaused는 작업 수신이 일시 중지되었는지와 그 사유를 반환합니다.
+func (r *Router) IntakePaused() (string, bool) {
+	reason := r.intakePause.Load()
+	if reason == nil {
+		return "", false
+	}
+	return *reason, true
+}
+
+// rejectWhilePaused는 수신 중지 중 받은 작업 요청을 재시도 가능한 에러로 거절합니다.
+func (r *Router) rejectWhilePaused(executionID, taskType, reason string) error {
+	log.Printf("[intake] 작업 수신 중지 중 요청 거절: execution_id=%s type=%s reason=%s", executionID, taskType, reason)
+	return r.sendRetryableError(executionID, taskType, ErrCodeBridgeAuthRequired, reason)
+}
@@ -0,0 +1,67 @@
+// Package websocket - 작업 수신 일시 중지 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleTaskRequest_RejectedWhilePaused는 수신 중지 중 작업이 재인증 에러로 거절되고,
+// 재개 후에는 다시 받는지 검증합니다.
+func TestHandleTaskRequest_RejectedWhilePaused(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender))
+
+	router.PauseIntake("재인증 대기")
+	reason, paused := router.IntakePaused()
+	assert.True(t, paused)
+	assert.Equal(t, "재인증 대기", reason)
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{ExecutionID: "exec-paused"})
+	require.NoError(t, err)
+	require.NoError(t, router.handleTaskRequest(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskReq,
+		Payload: payload,
+	}))
+
+	assert.False(t, client.TaskTracker().IsActive("exec-paused"), "거절된 작업은 추적되면 안 됨")
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, ErrCodeBridgeAuthRequired, errs[0].Code)
+	assert.Equal(t, "재인증 대기", errs[0].Message)
+	assert.True(t, errs[0].Retryable)
+
+	router.ResumeIntake()
+	_, paused = router.IntakePaused()
+	assert.False(t, paused)
+}
+
+// TestHeartbeat_CredentialStatus는 자격 증명 상태가 하트비트에 포함되는지 검증합니다.
+func TestHeartbeat_CredentialStatus(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	assert.Nil(t, client.buildHeartbeatPayload().Credentials)
+
+	expiresAt := time.Now().Add(time.Hour)
+	client.SetCredentialStatus(&ws.CredentialStatus{
+		State:            ws.CredentialStateExpiring,
+		RefreshExpiresAt: &expiresAt,
+	})
+	status := client.buildHeartbeatPayload().Credentials
+	require.NotNil(t, status)
+	assert.Equal(t, ws.CredentialStateExpiring, status.State)
+	assert.False(t, status.IntakePaused)
+
+	client.SetCredentialStatus(nil)
+	assert.Nil(t, client.buildHeartbeatPayload().Credentials)
+}
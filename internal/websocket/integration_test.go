@@ -18,6 +18,8 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/testbackend"
 )
 
 // upgrader is a shared WebSocket upgrader for mock servers.
@@ -28,74 +30,11 @@ var upgrader = websocket.Upgrader{
 // TestIntegration_FullMessageRoundTrip verifies a complete message round-trip
 // between client and mock server.
 func TestIntegration_FullMessageRoundTrip(t *testing.T) {
-	// Create a mock WebSocket server.
-	serverReceived := make(chan ws.AgentMessage, 10)
-	serverDone := make(chan struct{})
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		conn, err := upgrader.Upgrade(w, r, nil)
-		if err != nil {
-			t.Logf("upgrade error: %v", err)
-			return
-		}
-		defer conn.Close()
-
-		// Read the agent_connect message.
-		_, data, err := conn.ReadMessage()
-		if err != nil {
-			t.Logf("read connect error: %v", err)
-			return
-		}
-
-		var connectMsg ws.AgentMessage
-		if err := json.Unmarshal(data, &connectMsg); err != nil {
-			t.Logf("unmarshal connect error: %v", err)
-			return
-		}
-
-		if connectMsg.Type != ws.AgentMsgConnect {
-			t.Logf("expected agent_connect, got %s", connectMsg.Type)
-			return
-		}
-
-		// Send connect_ack.
-		ackPayload, _ := json.Marshal(ws.ConnectAckPayload{
-			Success: true,
-			Message: "authenticated",
-		})
-		ackMsg := ws.AgentMessage{
-			Type:      ws.AgentMsgConnectAck,
-			ID:        "ack-001",
-			Timestamp: time.Now(),
-			Payload:   ackPayload,
-		}
-		ackData, _ := json.Marshal(ackMsg)
-		if err := conn.WriteMessage(websocket.TextMessage, ackData); err != nil {
-			t.Logf("write ack error: %v", err)
-			return
-		}
-
-		// Read subsequent messages and forward to channel.
-		for {
-			_, msgData, err := conn.ReadMessage()
-			if err != nil {
-				close(serverDone)
-				return
-			}
-			var msg ws.AgentMessage
-			if err := json.Unmarshal(msgData, &msg); err != nil {
-				continue
-			}
-			serverReceived <- msg
-		}
-	}))
+	server := testbackend.NewWSServer()
 	defer server.Close()
 
-	// Convert HTTP URL to WebSocket URL.
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
-
 	// Create client and connect.
-	client := NewClient(wsURL, "test-token", "1.0.0")
+	client := NewClient(server.URL(), "test-token", "1.0.0")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -109,15 +48,11 @@ func TestIntegration_FullMessageRoundTrip(t *testing.T) {
 	if client.State() != StateConnected {
 		t.Errorf("state = %v, want Connected", client.State())
 	}
+	if connects := server.Connects(); len(connects) != 1 || connects[0].Version != "1.0.0" {
+		t.Errorf("agent_connect payloads = %+v, want one with version 1.0.0", connects)
+	}
 
 	// Send a task result message.
-	resultPayload, _ := json.Marshal(ws.TaskResultPayload{
-		ExecutionID: "exec-roundtrip-001",
-		Output:      "round trip test output",
-		ExitCode:    0,
-		Duration:    100,
-	})
-
 	if err := client.SendTaskResult(ws.TaskResultPayload{
 		ExecutionID: "exec-roundtrip-001",
 		Output:      "round trip test output",
@@ -126,16 +61,18 @@ func TestIntegration_FullMessageRoundTrip(t *testing.T) {
 	}); err != nil {
 		t.Fatalf("SendTaskResult failed: %v", err)
 	}
-	_ = resultPayload
 
 	// Wait for server to receive the message.
-	select {
-	case msg := <-serverReceived:
-		if msg.Type != ws.AgentMsgTaskResult {
-			t.Errorf("server received type = %q, want %q", msg.Type, ws.AgentMsgTaskResult)
-		}
-	case <-time.After(3 * time.Second):
-		t.Fatal("timeout waiting for server to receive message")
+	msg, err := server.WaitFor(ws.AgentMsgTaskResult, 3*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result ws.TaskResultPayload
+	if err := json.Unmarshal(msg.Payload, &result); err != nil {
+		t.Fatalf("unmarshal task result: %v", err)
+	}
+	if result.ExecutionID != "exec-roundtrip-001" {
+		t.Errorf("execution_id = %q, want %q", result.ExecutionID, "exec-roundtrip-001")
 	}
 }
 
@@ -319,7 +256,7 @@ func TestIntegration_ConcurrentTaskTrackingWithReconnect(t *testing.T) {
 // TestIntegration_ServerSendTaskRequest verifies that a mock server can send
 // a task_request and the client router handles it.
 func TestIntegration_ServerSendTaskRequest(t *testing.T) {
-	taskHandled := make(chan string, 1)
+	taskHandled := make(chan ws.TaskErrorPayload, 1)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
@@ -378,7 +315,7 @@ func TestIntegration_ServerSendTaskRequest(t *testing.T) {
 			if msg.Type == ws.AgentMsgTaskError {
 				var errPayload ws.TaskErrorPayload
 				if err := json.Unmarshal(msg.Payload, &errPayload); err == nil {
-					taskHandled <- errPayload.ExecutionID
+					taskHandled <- errPayload
 				}
 			}
 		}
@@ -402,9 +339,19 @@ func TestIntegration_ServerSendTaskRequest(t *testing.T) {
 
 	// Wait for the task to be handled.
 	select {
-	case execID := <-taskHandled:
-		if execID != "server-task-001" {
-			t.Errorf("handled execution_id = %q, want %q", execID, "server-task-001")
+	case errPayload := <-taskHandled:
+		if errPayload.ExecutionID != "server-task-001" {
+			t.Errorf("handled execution_id = %q, want %q", errPayload.ExecutionID, "server-task-001")
+		}
+		// The error payload carries the catalog severity and hint for its code.
+		if errPayload.Code != errcode.NoExecutor {
+			t.Errorf("code = %q, want %q", errPayload.Code, errcode.NoExecutor)
+		}
+		if errPayload.Severity != ws.ErrorSeverityCritical {
+			t.Errorf("severity = %q, want %q", errPayload.Severity, ws.ErrorSeverityCritical)
+		}
+		if errPayload.Hint == "" || errPayload.Hint != errcode.Hint(errcode.NoExecutor) {
+			t.Errorf("hint = %q, want the NO_EXECUTOR catalog hint", errPayload.Hint)
 		}
 	case <-time.After(5 * time.Second):
 		t.Fatal("timeout waiting for task to be handled")
@@ -0,0 +1,225 @@
+// Package websocket - MCP 배포 아카이브(tar.gz) 수신 처리
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+)
+
+const (
+	// maxMCPDeployArchiveBytes는 수신할 수 있는 배포 아카이브(압축 상태)의 최대 크기입니다.
+	maxMCPDeployArchiveBytes = 512 << 20 // 512MiB
+	// mcpDeployTransferTimeout 동안 청크가 오지 않으면 분할 전송을 실패 처리합니다.
+	mcpDeployTransferTimeout = 5 * time.Minute
+)
+
+// deployArchiveTransfer는 청크로 수신 중인 배포 아카이브입니다.
+type deployArchiveTransfer struct {
+	msgID   string
+	req     ws.MCPDeployPayload
+	file    *os.File
+	hash    hash.Hash
+	size    int64
+	next    int
+	updated time.Time
+}
+
+// close는 임시 파일을 닫고 삭제합니다.
+func (t *deployArchiveTransfer) close() {
+	_ = t.file.Close()
+	_ = os.Remove(t.file.Name())
+}
+
+// startMCPDeployArchive는 아카이브 모드 mcp_deploy를 처리합니다.
+// 인라인 아카이브는 바로 배포하고, 분할 전송이면 mcp_deploy_chunk를 기다립니다.
+func (r *Router) startMCPDeployArchive(ctx context.Context, msgID string, req ws.MCPDeployPayload) {
+	archive := req.Archive
+	if archive.Format != "" && archive.Format != ws.MCPDeployArchiveFormatTarGz {
+		r.sendMCPDeployError(msgID, req.ServiceName, fmt.Errorf("지원하지 않는 배포 아카이브 형식: %q", archive.Format))
+		return
+	}
+
+	if archive.TransferID == "" {
+		if base64.StdEncoding.DecodedLen(len(archive.Data)) > maxMCPDeployArchiveBytes {
+			r.sendMCPDeployError(msgID, req.ServiceName, fmt.Errorf("배포 아카이브가 최대 크기(%dMiB)를 넘습니다", maxMCPDeployArchiveBytes>>20))
+			return
+		}
+		detail := fmt.Sprintf("archive=%s bytes=%d", ws.MCPDeployArchiveFormatTarGz, base64.StdEncoding.DecodedLen(len(archive.Data)))
+		r.runMCPDeployArchive(ctx, msgID, req, detail, func() (io.Reader, error) {
+			data, err := base64.StdEncoding.DecodeString(archive.Data)
+			if err != nil {
+				return nil, fmt.Errorf("배포 아카이브 base64 디코딩 실패: %w", err)
+			}
+			sum := sha256.Sum256(data)
+			if err := verifyArchiveChecksum(archive.SHA256, sum[:]); err != nil {
+				return nil, err
+			}
+			return bytes.NewReader(data), nil
+		}, func() {})
+		return
+	}
+
+	if archive.TotalChunks <= 0 {
+		r.sendMCPDeployError(msgID, req.ServiceName, fmt.Errorf("분할 전송 청크 수가 유효하지 않음: %d", archive.TotalChunks))
+		return
+	}
+	file, err := os.CreateTemp("", "autopus-mcp-deploy-*.tar.gz")
+	if err != nil {
+		r.sendMCPDeployError(msgID, req.ServiceName, fmt.Errorf("배포 아카이브 임시 파일 생성 실패: %w", err))
+		return
+	}
+
+	r.deployTransfersMu.Lock()
+	r.pruneDeployTransfersLocked()
+	if r.deployTransfers == nil {
+		r.deployTransfers = make(map[string]*deployArchiveTransfer)
+	}
+	if prev, ok := r.deployTransfers[archive.TransferID]; ok {
+		prev.close()
+		r.sendMCPDeployError(prev.msgID, prev.req.ServiceName, fmt.Errorf("같은 transfer_id로 새 배포가 시작되어 이전 전송을 취소했습니다"))
+	}
+	r.deployTransfers[archive.TransferID] = &deployArchiveTransfer{
+		msgID:   msgID,
+		req:     req,
+		file:    file,
+		hash:    sha256.New(),
+		updated: time.Now(),
+	}
+	r.deployTransfersMu.Unlock()
+
+	log.Printf("[self-expand] MCP 배포 아카이브 분할 수신 시작 (service=%s, transfer=%s, chunks=%d)",
+		req.ServiceName, archive.TransferID, archive.TotalChunks)
+}
+
+// handleMCPDeployChunk는 분할 전송된 배포 아카이브 청크를 처리합니다.
+// 마지막 청크를 받으면 체크섬을 확인하고 배포를 시작합니다.
+func (r *Router) handleMCPDeployChunk(ctx context.Context, msg ws.AgentMessage) error {
+	var chunk ws.MCPDeployChunkPayload
+	if err := json.Unmarshal(msg.Payload, &chunk); err != nil {
+		return fmt.Errorf("mcp_deploy_chunk 페이로드 파싱 실패: %w", err)
+	}
+
+	r.deployTransfersMu.Lock()
+	r.pruneDeployTransfersLocked()
+	t, ok := r.deployTransfers[chunk.TransferID]
+	if !ok {
+		r.deployTransfersMu.Unlock()
+		log.Printf("[self-expand] 알 수 없는 배포 아카이브 청크 무시 (transfer=%s, index=%d)", chunk.TransferID, chunk.Index)
+		return nil
+	}
+	err := t.write(chunk)
+	done := err == nil && t.next == t.req.Archive.TotalChunks
+	if err != nil || done {
+		delete(r.deployTransfers, chunk.TransferID)
+	}
+	r.deployTransfersMu.Unlock()
+
+	if err != nil {
+		t.close()
+		r.sendMCPDeployError(t.msgID, t.req.ServiceName, err)
+		return nil
+	}
+	if !done {
+		return nil
+	}
+
+	if err := verifyArchiveChecksum(t.req.Archive.SHA256, t.hash.Sum(nil)); err != nil {
+		t.close()
+		r.sendMCPDeployError(t.msgID, t.req.ServiceName, err)
+		return nil
+	}
+	detail := fmt.Sprintf("archive=%s bytes=%d chunks=%d", ws.MCPDeployArchiveFormatTarGz, t.size, t.next)
+	r.runMCPDeployArchive(ctx, t.msgID, t.req, detail, func() (io.Reader, error) {
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("배포 아카이브 읽기 실패: %w", err)
+		}
+		return t.file, nil
+	}, t.close)
+	return nil
+}
+
+// runMCPDeployArchive는 open으로 얻은 아카이브를 배포하거나, verify_only이면 검증만 합니다.
+// done은 배포/검증이 아카이브를 다 읽은 뒤 호출됩니다.
+func (r *Router) runMCPDeployArchive(ctx context.Context, msgID string, req ws.MCPDeployPayload, detail string, open func() (io.Reader, error), done func()) {
+	if req.VerifyOnly {
+		r.runMCPVerify(ctx, msgID, req.ServiceName, detail, func() (*mcp.VerifyReport, error) {
+			defer done()
+			archive, err := open()
+			if err != nil {
+				return nil, err
+			}
+			return r.mcpDeployer.VerifyArchive(ctx, req.ServiceName, archive, req.EnvVars, mcpVerifyOptions(req))
+		})
+		return
+	}
+	r.runMCPDeploy(ctx, msgID, req.ServiceName, detail, func() (string, error) {
+		defer done()
+		archive, err := open()
+		if err != nil {
+			return "", err
+		}
+		return r.mcpDeployer.DeployArchive(ctx, req.ServiceName, archive, req.EnvVars)
+	})
+}
+
+// write는 순서대로 도착한 청크를 임시 파일에 기록합니다.
+func (t *deployArchiveTransfer) write(chunk ws.MCPDeployChunkPayload) error {
+	if chunk.Index != t.next {
+		return fmt.Errorf("배포 아카이브 청크 순서 오류: %d번째를 기다렸으나 %d번째 수신", t.next, chunk.Index)
+	}
+	data, err := base64.StdEncoding.DecodeString(chunk.Data)
+	if err != nil {
+		return fmt.Errorf("배포 아카이브 청크 %d base64 디코딩 실패: %w", chunk.Index, err)
+	}
+	if t.size+int64(len(data)) > maxMCPDeployArchiveBytes {
+		return fmt.Errorf("배포 아카이브가 최대 크기(%dMiB)를 넘습니다", maxMCPDeployArchiveBytes>>20)
+	}
+	if _, err := t.file.Write(data); err != nil {
+		return fmt.Errorf("배포 아카이브 임시 파일 기록 실패: %w", err)
+	}
+	t.hash.Write(data)
+	t.size += int64(len(data))
+	t.next++
+	t.updated = time.Now()
+	return nil
+}
+
+// pruneDeployTransfersLocked는 오래 청크가 오지 않은 분할 전송을 실패 처리합니다.
+// 호출자가 deployTransfersMu를 보유해야 합니다.
+func (r *Router) pruneDeployTransfersLocked() {
+	cutoff := time.Now().Add(-mcpDeployTransferTimeout)
+	for id, t := range r.deployTransfers {
+		if t.updated.After(cutoff) {
+			continue
+		}
+		delete(r.deployTransfers, id)
+		t.close()
+		r.sendMCPDeployError(t.msgID, t.req.ServiceName,
+			fmt.Errorf("배포 아카이브 청크 수신 시간 초과 (%d/%d)", t.next, t.req.Archive.TotalChunks))
+	}
+}
+
+// verifyArchiveChecksum은 expected(hex)가 지정된 경우 실제 SHA-256과 일치하는지 확인합니다.
+func verifyArchiveChecksum(expected string, actual []byte) error {
+	if expected == "" {
+		return nil
+	}
+	if got := hex.EncodeToString(actual); !strings.EqualFold(got, expected) {
+		return fmt.Errorf("배포 아카이브 체크섬 불일치: expected %s, got %s", expected, got)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Package websocket - MCP 배포 아카이브 수신 테스트
+package websocket
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveRecorder는 DeployArchive/VerifyArchive로 받은 아카이브 내용을 기록하는 테스트용 MCPDeployExecutor입니다.
+// VerifyArchive는 report를 반환합니다.
+type archiveRecorder struct {
+	mu       sync.Mutex
+	archive  []byte
+	deployed bool
+	opts     mcp.VerifyOptions
+	report   *mcp.VerifyReport
+}
+
+func (d *archiveRecorder) Deploy(_ context.Context, _ string, _ []mcp.DeployFile, _ map[string]string) (string, error) {
+	return "/tmp/deployed", nil
+}
+
+func (d *archiveRecorder) DeployArchive(_ context.Context, _ string, archive io.Reader, _ map[string]string) (string, error) {
+	data, err := io.ReadAll(archive)
+	d.mu.Lock()
+	d.archive = data
+	d.deployed = true
+	d.mu.Unlock()
+	return "/tmp/deployed", err
+}
+
+func (d *archiveRecorder) Verify(_ context.Context, _ string, _ []mcp.DeployFile, _ map[string]string, opts mcp.VerifyOptions) (*mcp.VerifyReport, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.opts = opts
+	return d.report, nil
+}
+
+func (d *archiveRecorder) VerifyArchive(_ context.Context, _ string, archive io.Reader, _ map[string]string, opts mcp.VerifyOptions) (*mcp.VerifyReport, error) {
+	data, err := io.ReadAll(archive)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.archive = data
+	d.opts = opts
+	return d.report, err
+}
+
+func routeMessage(t *testing.T, router *Router, msgType string, payload interface{}) {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{Type: msgType, ID: "msg-1", Payload: data}))
+}
+
+func receiveDeployResult(t *testing.T, srv *testCapabilityServer) ws.MCPDeployResultPayload {
+	t.Helper()
+	msg := receiveMessageOfType(t, srv, ws.AgentMsgMCPDeployResult)
+	var result ws.MCPDeployResultPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &result))
+	return result
+}
+
+// TestHandleMCPDeploy_InlineArchive는 인라인 base64 아카이브가 체크섬 확인 후 배포되는지 검증합니다.
+func TestHandleMCPDeploy_InlineArchive(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	deployer := &archiveRecorder{}
+	router := NewRouter(client, WithMCPDeployer(deployer))
+	archive := []byte("fake tar.gz bytes")
+	sum := sha256.Sum256(archive)
+
+	routeMessage(t, router, ws.AgentMsgMCPDeploy, ws.MCPDeployPayload{
+		ServiceName: "svc",
+		Archive: &ws.MCPDeployArchive{
+			Format: ws.MCPDeployArchiveFormatTarGz,
+			Data:   base64.StdEncoding.EncodeToString(archive),
+			SHA256: hex.EncodeToString(sum[:]),
+		},
+	})
+	result := receiveDeployResult(t, srv)
+	require.True(t, result.Success, result.Error)
+	deployer.mu.Lock()
+	assert.Equal(t, archive, deployer.archive)
+	deployer.mu.Unlock()
+
+	routeMessage(t, router, ws.AgentMsgMCPDeploy, ws.MCPDeployPayload{
+		ServiceName: "svc",
+		Archive:     &ws.MCPDeployArchive{Data: base64.StdEncoding.EncodeToString(archive), SHA256: "00"},
+	})
+	result = receiveDeployResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "체크섬")
+}
+
+// TestHandleMCPDeploy_ChunkedArchive는 청크로 나뉜 아카이브를 모아 배포하고,
+// 순서가 어긋난 청크는 전송을 실패 처리하는지 검증합니다.
+func TestHandleMCPDeploy_ChunkedArchive(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	deployer := &archiveRecorder{}
+	router := NewRouter(client, WithMCPDeployer(deployer))
+	chunks := [][]byte{[]byte("part-1/"), []byte("part-2/"), []byte("part-3")}
+	sum := sha256.Sum256([]byte("part-1/part-2/part-3"))
+
+	routeMessage(t, router, ws.AgentMsgMCPDeploy, ws.MCPDeployPayload{
+		ServiceName: "svc",
+		Archive: &ws.MCPDeployArchive{
+			Format:      ws.MCPDeployArchiveFormatTarGz,
+			TransferID:  "tx-1",
+			TotalChunks: len(chunks),
+			SHA256:      hex.EncodeToString(sum[:]),
+		},
+	})
+	for i, c := range chunks {
+		routeMessage(t, router, ws.AgentMsgMCPDeployChunk, ws.MCPDeployChunkPayload{
+			TransferID: "tx-1", Index: i, Data: base64.StdEncoding.EncodeToString(c),
+		})
+	}
+	result := receiveDeployResult(t, srv)
+	require.True(t, result.Success, result.Error)
+	deployer.mu.Lock()
+	assert.Equal(t, "part-1/part-2/part-3", string(deployer.archive))
+	deployer.mu.Unlock()
+
+	routeMessage(t, router, ws.AgentMsgMCPDeploy, ws.MCPDeployPayload{
+		ServiceName: "svc",
+		Archive:     &ws.MCPDeployArchive{TransferID: "tx-2", TotalChunks: 2},
+	})
+	routeMessage(t, router, ws.AgentMsgMCPDeployChunk, ws.MCPDeployChunkPayload{
+		TransferID: "tx-2", Index: 1, Data: base64.StdEncoding.EncodeToString([]byte("x")),
+	})
+	result = receiveDeployResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.Error, "순서")
+
+	router.deployTransfersMu.Lock()
+	assert.Empty(t, router.deployTransfers, "실패한 전송은 정리되어야 함")
+	router.deployTransfersMu.Unlock()
+}
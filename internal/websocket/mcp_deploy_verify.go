@@ -0,0 +1,81 @@
+// Package websocket - MCP 배포 검증(verify_only) 처리
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/approval"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+)
+
+// mcpVerifyOptions는 mcp_deploy 요청의 검증 옵션을 변환합니다.
+func mcpVerifyOptions(req ws.MCPDeployPayload) mcp.VerifyOptions {
+	return mcp.VerifyOptions{SmokeTest: req.SmokeTest}
+}
+
+// runMCPVerify는 액션 게이트 승인 후 verify를 비동기로 실행하고 검증 결과를 전송합니다.
+// 스모크 시작은 생성된 코드를 실행하므로 배포와 같은 승인 게이트를 거칩니다.
+func (r *Router) runMCPVerify(ctx context.Context, msgID, serviceName, detail string, verify func() (*mcp.VerifyReport, error)) {
+	go func() {
+		if err := r.checkAction(ctx, approval.ActionMCPDeploy, serviceName, detail+" verify_only=true"); err != nil {
+			log.Printf("[self-expand] MCP 배포 검증 거부 (service=%s): %v", serviceName, err)
+			_ = r.client.SendMCPDeployResult(msgID, ws.MCPDeployResultPayload{
+				ServiceName: serviceName,
+				Success:     false,
+				Error:       err.Error(),
+			})
+			return
+		}
+
+		report, err := verify()
+		if err != nil {
+			log.Printf("[self-expand] MCP 배포 검증 실패 (service=%s): %v", serviceName, err)
+			r.sendMCPDeployError(msgID, serviceName, err)
+			return
+		}
+
+		result := ws.MCPDeployResultPayload{
+			ServiceName:  serviceName,
+			Success:      report.Passed,
+			Verification: deployVerification(report),
+		}
+		if !report.Passed {
+			result.Error = fmt.Sprintf("배포 검증에서 오류 %d건 발견", countVerifyErrors(report))
+		}
+		_ = r.client.SendMCPDeployResult(msgID, result)
+
+		log.Printf("[self-expand] MCP 배포 검증 완료 (service=%s, passed=%v, findings=%d)",
+			serviceName, report.Passed, len(report.Findings))
+	}()
+}
+
+// deployVerification은 검증 결과를 프로토콜 페이로드로 변환합니다.
+func deployVerification(report *mcp.VerifyReport) *ws.MCPDeployVerification {
+	v := &ws.MCPDeployVerification{
+		Passed:      report.Passed,
+		SmokeTested: report.SmokeTested,
+	}
+	for _, f := range report.Findings {
+		v.Findings = append(v.Findings, ws.MCPDeployFinding{
+			Severity: f.Severity,
+			Check:    f.Check,
+			File:     f.File,
+			Message:  f.Message,
+		})
+	}
+	return v
+}
+
+// countVerifyErrors는 SeverityError 항목 수를 셉니다.
+func countVerifyErrors(report *mcp.VerifyReport) int {
+	n := 0
+	for _, f := range report.Findings {
+		if f.Severity == mcp.SeverityError {
+			n++
+		}
+	}
+	return n
+}
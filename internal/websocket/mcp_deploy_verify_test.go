@@ -0,0 +1,68 @@
+// Package websocket - MCP 배포 검증(verify_only) 테스트
+package websocket
+
+import (
+	"encoding/base64"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleMCPDeploy_VerifyOnly는 verify_only 요청이 배포 대신 검증을 실행하고 결과를 보고하는지 검증합니다.
+func TestHandleMCPDeploy_VerifyOnly(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	deployer := &archiveRecorder{report: &mcp.VerifyReport{
+		ServiceName: "svc",
+		SmokeTested: true,
+		Findings: []mcp.VerifyFinding{
+			{Severity: mcp.SeverityError, Check: mcp.CheckDependency, File: "src/index.ts", Message: "undeclared"},
+			{Severity: mcp.SeverityWarning, Check: mcp.CheckDependency, File: "package.json", Message: "npm install"},
+		},
+	}}
+	router := NewRouter(client, WithMCPDeployer(deployer))
+
+	routeMessage(t, router, ws.AgentMsgMCPDeploy, ws.MCPDeployPayload{
+		ServiceName: "svc",
+		Files:       []ws.MCPGeneratedFile{{Path: "src/index.ts", Content: "import 'x';"}},
+		VerifyOnly:  true,
+		SmokeTest:   true,
+	})
+	result := receiveDeployResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Empty(t, result.DeployPath)
+	assert.Contains(t, result.Error, "1건")
+	require.NotNil(t, result.Verification)
+	assert.True(t, result.Verification.SmokeTested)
+	require.Len(t, result.Verification.Findings, 2)
+	assert.Equal(t, "src/index.ts", result.Verification.Findings[0].File)
+
+	deployer.mu.Lock()
+	assert.True(t, deployer.opts.SmokeTest)
+	assert.False(t, deployer.deployed, "verify_only는 배포하면 안 됨")
+	deployer.report = &mcp.VerifyReport{ServiceName: "svc", Passed: true}
+	deployer.mu.Unlock()
+
+	archive := []byte("fake tar.gz bytes")
+	routeMessage(t, router, ws.AgentMsgMCPDeploy, ws.MCPDeployPayload{
+		ServiceName: "svc",
+		Archive:     &ws.MCPDeployArchive{Data: base64.StdEncoding.EncodeToString(archive)},
+		VerifyOnly:  true,
+	})
+	result = receiveDeployResult(t, srv)
+	require.True(t, result.Success, result.Error)
+	require.NotNil(t, result.Verification)
+	assert.True(t, result.Verification.Passed)
+
+	deployer.mu.Lock()
+	assert.Equal(t, archive, deployer.archive)
+	assert.False(t, deployer.opts.SmokeTest)
+	assert.False(t, deployer.deployed)
+	deployer.mu.Unlock()
+}
@@ -0,0 +1,61 @@
+// Package websocket - 코드 생성/배포 디스크 할당량 에러 코드 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/diskquota"
+	"github.com/insajin/autopus-bridge/internal/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// quotaExceededDeployer는 항상 할당량 초과 에러를 반환하는 테스트용 MCPDeployExecutor입니다.
+type quotaExceededDeployer struct{}
+
+func (quotaExceededDeployer) Deploy(_ context.Context, serviceName string, _ []mcp.DeployFile, _ map[string]string) (string, error) {
+	return "", diskquota.Check("서비스", "/tmp/"+serviceName, 2048, 1024)
+}
+
+func (quotaExceededDeployer) DeployArchive(_ context.Context, serviceName string, _ io.Reader, _ map[string]string) (string, error) {
+	return "", diskquota.Check("서비스", "/tmp/"+serviceName, 2048, 1024)
+}
+
+func (quotaExceededDeployer) Verify(_ context.Context, serviceName string, _ []mcp.DeployFile, _ map[string]string, _ mcp.VerifyOptions) (*mcp.VerifyReport, error) {
+	return nil, diskquota.Check("서비스", "/tmp/"+serviceName, 2048, 1024)
+}
+
+func (quotaExceededDeployer) VerifyArchive(_ context.Context, serviceName string, _ io.Reader, _ map[string]string, _ mcp.VerifyOptions) (*mcp.VerifyReport, error) {
+	return nil, diskquota.Check("서비스", "/tmp/"+serviceName, 2048, 1024)
+}
+
+// TestMCPErrorCode는 할당량 초과 에러만 QUOTA_EXCEEDED로 분류되는지 검증합니다.
+func TestMCPErrorCode(t *testing.T) {
+	quotaErr := fmt.Errorf("배포 실패: %w", diskquota.Check("배포 전체", "/tmp", 10, 5))
+	assert.Equal(t, ws.MCPErrorCodeQuotaExceeded, mcpErrorCode(quotaErr))
+	assert.Empty(t, mcpErrorCode(errors.New("서버 시작 실패")))
+}
+
+// TestHandleMCPDeploy_QuotaExceeded는 배포 할당량 초과가 결과 페이로드의 에러 코드로 보고되는지 검증합니다.
+func TestHandleMCPDeploy_QuotaExceeded(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client, WithMCPDeployer(quotaExceededDeployer{}))
+	sendMCPDeploy(t, router, "big-svc")
+
+	msg := receiveMessageOfType(t, srv, ws.AgentMsgMCPDeployResult)
+	var result ws.MCPDeployResultPayload
+	require.NoError(t, json.Unmarshal(msg.Payload, &result))
+	assert.False(t, result.Success)
+	assert.Equal(t, ws.MCPErrorCodeQuotaExceeded, result.ErrorCode)
+	assert.Contains(t, result.Error, "할당량")
+}
@@ -0,0 +1,279 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// mcp_serve_start/mcp_serve_stop으로 이름 붙은 내장 MCP 서버 인스턴스들을 관리하고,
+// embedded 모드에서는 MCP JSON-RPC 메시지를 mcp_rpc로 WebSocket 위에 터널링합니다.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// MCP serve 상태 (하트비트 mcp_serve_status 값).
+const (
+	mcpServeStatusRunning = "running"
+	mcpServeStatusStopped = "stopped"
+)
+
+// EmbeddedMCPServer는 트랜스포트 없이 JSON-RPC 메시지를 처리하는 MCP 서버입니다.
+// 응답이 없는 알림 메시지는 nil을 반환합니다.
+type EmbeddedMCPServer interface {
+	HandleMessage(ctx context.Context, message json.RawMessage) (json.RawMessage, error)
+}
+
+// EmbeddedMCPOptions는 mcp_serve_start 요청에서 내장 MCP 서버 인스턴스 생성에 필요한 값입니다.
+type EmbeddedMCPOptions struct {
+	// Instance는 인스턴스 이름입니다 (비어 있으면 ws.MCPServeDefaultInstance).
+	Instance string
+	// BackendURL은 Autopus 백엔드 API URL입니다 (비어 있으면 로그인한 서버 기준).
+	BackendURL string
+	// WorkspaceID는 인스턴스의 워크스페이스 스코프입니다 (비어 있으면 인증 정보의 워크스페이스).
+	WorkspaceID string
+}
+
+// EmbeddedMCPFactory는 mcp_serve_start 요청의 옵션으로 내장 MCP 서버를 생성합니다.
+type EmbeddedMCPFactory func(opts EmbeddedMCPOptions) (EmbeddedMCPServer, error)
+
+// WithEmbeddedMCPServer는 embedded 모드 MCP 서버 생성 함수를 설정합니다.
+// 설정하지 않으면 mcp_serve_start에 에러 결과로 응답합니다.
+func WithEmbeddedMCPServer(factory EmbeddedMCPFactory) RouterOption {
+	return func(r *Router) {
+		r.embeddedMCPFactory = factory
+	}
+}
+
+// mcpServeInstance는 실행 중인 내장 MCP 서버 인스턴스 하나입니다.
+type mcpServeInstance struct {
+	opts      EmbeddedMCPOptions
+	server    EmbeddedMCPServer
+	startedAt time.Time
+}
+
+// mcpServeInstanceName은 비어 있는 인스턴스 이름을 기본 인스턴스로 바꿉니다.
+func mcpServeInstanceName(name string) string {
+	if name == "" {
+		return ws.MCPServeDefaultInstance
+	}
+	return name
+}
+
+// handleMCPServeStart는 내장 MCP 서버 시작 요청을 처리합니다.
+// WebSocket으로 연결된 프로세스의 stdin/stdout은 MCP 클라이언트와 연결되어 있지 않으므로
+// embedded 모드만 지원하며, 이후 MCP 메시지는 mcp_rpc로 주고받습니다.
+// 인스턴스 이름이 다르면 백엔드 URL이나 워크스페이스 스코프가 다른 서버를 함께 실행할 수 있습니다.
+func (r *Router) handleMCPServeStart(ctx context.Context, msg ws.AgentMessage) error {
+	var req ws.MCPServeStartPayload
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return r.sendMCPServeResult(msg.ID, ws.MCPServeResultPayload{
+			Status: "error",
+			Error:  fmt.Sprintf("mcp_serve_start 페이로드 파싱 실패: %v", err),
+		})
+	}
+	opts := EmbeddedMCPOptions{
+		Instance:    mcpServeInstanceName(req.Instance),
+		BackendURL:  req.BackendURL,
+		WorkspaceID: req.WorkspaceID,
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = ws.MCPServeModeEmbedded
+	}
+	if mode != ws.MCPServeModeEmbedded {
+		return r.sendMCPServeResult(msg.ID, ws.MCPServeResultPayload{
+			Status:   "error",
+			Error:    fmt.Sprintf("지원하지 않는 MCP serve 모드입니다: %s (WebSocket 연결에서는 %s 모드를 사용하세요)", mode, ws.MCPServeModeEmbedded),
+			Instance: opts.Instance,
+		})
+	}
+
+	if r.embeddedMCPFactory == nil {
+		return r.sendMCPServeResult(msg.ID, ws.MCPServeResultPayload{
+			Status:   "error",
+			Error:    "내장 MCP 서버가 설정되지 않았습니다",
+			Instance: opts.Instance,
+		})
+	}
+
+	r.mcpServeMu.Lock()
+	if running, ok := r.mcpServes[opts.Instance]; ok {
+		r.mcpServeMu.Unlock()
+		if running.opts != opts {
+			return r.sendMCPServeResult(msg.ID, ws.MCPServeResultPayload{
+				Status:   "error",
+				Error:    fmt.Sprintf("MCP serve 인스턴스 %q가 다른 설정(backend=%s, workspace=%s)으로 이미 실행 중입니다", opts.Instance, running.opts.BackendURL, running.opts.WorkspaceID),
+				Instance: opts.Instance,
+			})
+		}
+		log.Printf("[mcp-serve] 내장 MCP 서버가 이미 실행 중입니다 (instance=%s)", opts.Instance)
+		return r.sendMCPServeReady(msg.ID, opts.Instance)
+	}
+	srv, err := r.embeddedMCPFactory(opts)
+	if err != nil {
+		r.mcpServeMu.Unlock()
+		log.Printf("[mcp-serve] 내장 MCP 서버 생성 실패 (instance=%s): %v", opts.Instance, err)
+		return r.sendMCPServeResult(msg.ID, ws.MCPServeResultPayload{
+			Status:   "error",
+			Error:    err.Error(),
+			Instance: opts.Instance,
+		})
+	}
+	if r.mcpServes == nil {
+		r.mcpServes = make(map[string]*mcpServeInstance)
+	}
+	r.mcpServes[opts.Instance] = &mcpServeInstance{opts: opts, server: srv, startedAt: time.Now()}
+	r.mcpServeMu.Unlock()
+
+	r.publishMCPServeStatus()
+	log.Printf("[mcp-serve] 내장 MCP 서버 시작 (instance=%s, mode=%s, backend=%s, workspace=%s)", opts.Instance, mode, opts.BackendURL, opts.WorkspaceID)
+	return r.sendMCPServeReady(msg.ID, opts.Instance)
+}
+
+// handleMCPServeStop은 내장 MCP 서버 중지 요청을 처리합니다.
+// 인스턴스를 지정하지 않으면 실행 중인 모든 인스턴스를 중지하며, 실행 중이 아니어도 stopped로 응답합니다.
+func (r *Router) handleMCPServeStop(ctx context.Context, msg ws.AgentMessage) error {
+	var req ws.MCPServeStopPayload
+	if len(msg.Payload) > 0 {
+		if err := json.Unmarshal(msg.Payload, &req); err != nil {
+			return r.sendMCPServeResult(msg.ID, ws.MCPServeResultPayload{
+				Status: "error",
+				Error:  fmt.Sprintf("mcp_serve_stop 페이로드 파싱 실패: %v", err),
+			})
+		}
+	}
+
+	var stopped []string
+	r.mcpServeMu.Lock()
+	if req.Instance != "" {
+		if _, ok := r.mcpServes[req.Instance]; ok {
+			delete(r.mcpServes, req.Instance)
+			stopped = append(stopped, req.Instance)
+		}
+	} else {
+		for name := range r.mcpServes {
+			stopped = append(stopped, name)
+		}
+		clear(r.mcpServes)
+	}
+	r.mcpServeMu.Unlock()
+	sort.Strings(stopped)
+
+	r.publishMCPServeStatus()
+	log.Printf("[mcp-serve] 내장 MCP 서버 중지 (instance=%s, stopped=%v, reason=%s)", req.Instance, stopped, req.Reason)
+
+	result := ws.MCPServeResultPayload{Status: "stopped", Instance: req.Instance, Stopped: stopped}
+	if len(stopped) == 0 {
+		result.Message = "실행 중인 MCP 서버가 없습니다"
+	}
+	return r.sendMCPServeResult(msg.ID, result)
+}
+
+// handleMCPRPC는 서버가 터널링한 MCP JSON-RPC 메시지를 지정한 인스턴스의 내장 MCP 서버로 전달합니다.
+// 도구 호출은 백엔드 API를 거쳐 오래 걸릴 수 있으므로 비동기로 처리하며,
+// 응답은 요청과 같은 메시지 ID의 mcp_rpc로 전송합니다.
+func (r *Router) handleMCPRPC(ctx context.Context, msg ws.AgentMessage) error {
+	var req ws.MCPRPCPayload
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return r.sendMCPRPC(msg.ID, ws.MCPRPCPayload{Error: fmt.Sprintf("mcp_rpc 페이로드 파싱 실패: %v", err)})
+	}
+	instance := mcpServeInstanceName(req.Instance)
+
+	r.mcpServeMu.RLock()
+	running := r.mcpServes[instance]
+	r.mcpServeMu.RUnlock()
+	if running == nil {
+		return r.sendMCPRPC(msg.ID, ws.MCPRPCPayload{
+			Error:    fmt.Sprintf("내장 MCP 서버 인스턴스 %q가 실행 중이 아닙니다", instance),
+			Instance: req.Instance,
+		})
+	}
+
+	go func() {
+		resp, err := running.server.HandleMessage(ctx, req.Message)
+		if err != nil {
+			log.Printf("[mcp-serve] MCP 메시지 처리 실패: id=%s instance=%s err=%v", msg.ID, instance, err)
+			if sendErr := r.sendMCPRPC(msg.ID, ws.MCPRPCPayload{Error: err.Error(), Instance: req.Instance}); sendErr != nil {
+				log.Printf("[mcp-serve] mcp_rpc 에러 전송 실패: %v", sendErr)
+			}
+			return
+		}
+		if resp == nil {
+			return // 알림에는 응답하지 않습니다.
+		}
+		if err := r.sendMCPRPC(msg.ID, ws.MCPRPCPayload{Message: resp, Instance: req.Instance}); err != nil {
+			log.Printf("[mcp-serve] mcp_rpc 응답 전송 실패: id=%s err=%v", msg.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+// MCPServeInstances는 실행 중인 내장 MCP 서버 인스턴스 상태를 이름순으로 반환합니다.
+func (r *Router) MCPServeInstances() []ws.MCPServeInstanceStatus {
+	r.mcpServeMu.RLock()
+	defer r.mcpServeMu.RUnlock()
+
+	instances := make([]ws.MCPServeInstanceStatus, 0, len(r.mcpServes))
+	for name, inst := range r.mcpServes {
+		instances = append(instances, ws.MCPServeInstanceStatus{
+			Instance:    name,
+			Status:      mcpServeStatusRunning,
+			Mode:        ws.MCPServeModeEmbedded,
+			BackendURL:  inst.opts.BackendURL,
+			WorkspaceID: inst.opts.WorkspaceID,
+			StartedAt:   inst.startedAt,
+		})
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Instance < instances[j].Instance })
+	return instances
+}
+
+// publishMCPServeStatus는 인스턴스 레지스트리 상태를 하트비트에 반영합니다.
+func (r *Router) publishMCPServeStatus() {
+	if r.client != nil {
+		r.client.setMCPServeInstances(r.MCPServeInstances())
+	}
+}
+
+// sendMCPServeReady는 mcp_serve_ready 메시지를 전송합니다.
+func (r *Router) sendMCPServeReady(requestMsgID, instance string) error {
+	return r.sendMCPServeMessage(ws.AgentMsgMCPServeReady, requestMsgID, ws.MCPServeReadyPayload{
+		Mode:     ws.MCPServeModeEmbedded,
+		Instance: instance,
+	})
+}
+
+// sendMCPServeResult는 mcp_serve_result 메시지를 전송합니다.
+func (r *Router) sendMCPServeResult(requestMsgID string, result ws.MCPServeResultPayload) error {
+	return r.sendMCPServeMessage(ws.AgentMsgMCPServeResult, requestMsgID, result)
+}
+
+// sendMCPRPC는 mcp_rpc 응답 메시지를 전송합니다.
+func (r *Router) sendMCPRPC(requestMsgID string, payload ws.MCPRPCPayload) error {
+	return r.sendMCPServeMessage(ws.AgentMsgMCPRPC, requestMsgID, payload)
+}
+
+// sendMCPServeMessage는 요청 메시지 ID로 MCP serve 관련 메시지를 전송합니다.
+func (r *Router) sendMCPServeMessage(msgType, requestMsgID string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s 직렬화 실패: %w", msgType, err)
+	}
+
+	msg := ws.AgentMessage{
+		Type:      msgType,
+		ID:        requestMsgID, // 원본 요청 ID를 그대로 사용하여 매칭
+		Timestamp: time.Now(),
+		Payload:   data,
+	}
+
+	if err := r.client.Send(msg); err != nil {
+		return fmt.Errorf("%s 전송 실패: %w", msgType, err)
+	}
+	return nil
+}
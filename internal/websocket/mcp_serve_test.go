@@ -0,0 +1,241 @@
+// Package websocket - mcp_serve_start/mcp_rpc 내장 MCP 서버 터널링 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoMCPServer는 요청 원문을 result로 감싸 돌려주고 알림에는 응답하지 않는 테스트용 EmbeddedMCPServer입니다.
+type echoMCPServer struct{}
+
+func (echoMCPServer) HandleMessage(_ context.Context, message json.RawMessage) (json.RawMessage, error) {
+	var req struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(message, &req); err != nil {
+		return nil, err
+	}
+	if len(req.ID) == 0 {
+		return nil, nil
+	}
+	return json.RawMessage(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":{}}`), nil
+}
+
+// receiveMessageOfType은 테스트 서버가 수신한 msgType 메시지를 반환합니다.
+func receiveMessageOfType(t *testing.T, srv *testCapabilityServer, msgType string) ws.AgentMessage {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-srv.received:
+			if msg.Type == msgType {
+				return msg
+			}
+		case <-deadline:
+			t.Fatalf("%s 수신 타임아웃", msgType)
+			return ws.AgentMessage{}
+		}
+	}
+}
+
+func sendMCPServeMessage(t *testing.T, router *Router, msgType, id string, payload interface{}) {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    msgType,
+		ID:      id,
+		Payload: data,
+	}))
+}
+
+// TestMCPServe_EmbeddedRoundTrip은 embedded 모드 시작 후 mcp_rpc 요청/응답이 같은 메시지 ID로 터널링되는지 검증합니다.
+func TestMCPServe_EmbeddedRoundTrip(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	var gotBackendURL string
+	router := NewRouter(client, WithEmbeddedMCPServer(func(opts EmbeddedMCPOptions) (EmbeddedMCPServer, error) {
+		gotBackendURL = opts.BackendURL
+		return echoMCPServer{}, nil
+	}))
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStart, "start-1", ws.MCPServeStartPayload{BackendURL: "https://api.example.com"})
+	ready := receiveMessageOfType(t, srv, ws.AgentMsgMCPServeReady)
+	var readyPayload ws.MCPServeReadyPayload
+	require.NoError(t, json.Unmarshal(ready.Payload, &readyPayload))
+	assert.Equal(t, "start-1", ready.ID)
+	assert.Equal(t, ws.MCPServeModeEmbedded, readyPayload.Mode)
+	assert.Equal(t, "https://api.example.com", gotBackendURL)
+	assert.Equal(t, mcpServeStatusRunning, client.buildHeartbeatPayload().MCPServeStatus)
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPRPC, "rpc-1", ws.MCPRPCPayload{
+		Message: json.RawMessage(`{"jsonrpc":"2.0","id":7,"method":"tools/list"}`),
+	})
+	resp := receiveMessageOfType(t, srv, ws.AgentMsgMCPRPC)
+	var rpc ws.MCPRPCPayload
+	require.NoError(t, json.Unmarshal(resp.Payload, &rpc))
+	assert.Equal(t, "rpc-1", resp.ID)
+	assert.Empty(t, rpc.Error)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":7,"result":{}}`, string(rpc.Message))
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStop, "stop-1", ws.MCPServeStopPayload{Reason: "test"})
+	stopped := receiveMessageOfType(t, srv, ws.AgentMsgMCPServeResult)
+	var result ws.MCPServeResultPayload
+	require.NoError(t, json.Unmarshal(stopped.Payload, &result))
+	assert.Equal(t, "stopped", result.Status)
+	assert.Equal(t, mcpServeStatusStopped, client.buildHeartbeatPayload().MCPServeStatus)
+}
+
+// TestMCPServe_RPCWithoutServer는 MCP 서버 시작 전 mcp_rpc에 에러로 응답하는지 검증합니다.
+func TestMCPServe_RPCWithoutServer(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client)
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPRPC, "rpc-1", ws.MCPRPCPayload{
+		Message: json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"ping"}`),
+	})
+
+	resp := receiveMessageOfType(t, srv, ws.AgentMsgMCPRPC)
+	var rpc ws.MCPRPCPayload
+	require.NoError(t, json.Unmarshal(resp.Payload, &rpc))
+	assert.NotEmpty(t, rpc.Error)
+	assert.Empty(t, rpc.Message)
+}
+
+// TestMCPServe_StartRejected는 stdio 모드와 서버 미설정 시 mcp_serve_result 에러로 응답하는지 검증합니다.
+func TestMCPServe_StartRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []RouterOption
+		mode string
+	}{
+		{name: "stdio 모드", opts: []RouterOption{WithEmbeddedMCPServer(func(EmbeddedMCPOptions) (EmbeddedMCPServer, error) {
+			return echoMCPServer{}, nil
+		})}, mode: ws.MCPServeModeStdio},
+		{name: "서버 미설정", mode: ws.MCPServeModeEmbedded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestCapabilityServer(t)
+			defer srv.Close()
+			client := newConnectedClient(t, srv.URL)
+			defer client.Disconnect("test")
+
+			router := NewRouter(client, tt.opts...)
+			sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStart, "start-1", ws.MCPServeStartPayload{Mode: tt.mode})
+
+			msg := receiveMessageOfType(t, srv, ws.AgentMsgMCPServeResult)
+			var result ws.MCPServeResultPayload
+			require.NoError(t, json.Unmarshal(msg.Payload, &result))
+			assert.Equal(t, "error", result.Status)
+			assert.NotEmpty(t, result.Error)
+		})
+	}
+}
+
+// namedMCPServer는 응답 result에 인스턴스 이름을 담는 테스트용 EmbeddedMCPServer입니다.
+type namedMCPServer string
+
+func (n namedMCPServer) HandleMessage(_ context.Context, _ json.RawMessage) (json.RawMessage, error) {
+	return json.RawMessage(`{"jsonrpc":"2.0","id":1,"result":{"instance":"` + string(n) + `"}}`), nil
+}
+
+// TestMCPServe_MultipleInstances는 이름이 다른 인스턴스를 함께 실행하고 mcp_rpc/mcp_serve_stop이 인스턴스별로 동작하는지 검증합니다.
+func TestMCPServe_MultipleInstances(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	var created []EmbeddedMCPOptions
+	router := NewRouter(client, WithEmbeddedMCPServer(func(opts EmbeddedMCPOptions) (EmbeddedMCPServer, error) {
+		created = append(created, opts)
+		return namedMCPServer(opts.Instance), nil
+	}))
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStart, "start-1", ws.MCPServeStartPayload{BackendURL: "https://a.example.com"})
+	ready := receiveMessageOfType(t, srv, ws.AgentMsgMCPServeReady)
+	var readyPayload ws.MCPServeReadyPayload
+	require.NoError(t, json.Unmarshal(ready.Payload, &readyPayload))
+	assert.Equal(t, ws.MCPServeDefaultInstance, readyPayload.Instance)
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStart, "start-2", ws.MCPServeStartPayload{
+		Instance: "team-b", BackendURL: "https://b.example.com", WorkspaceID: "ws-b",
+	})
+	ready = receiveMessageOfType(t, srv, ws.AgentMsgMCPServeReady)
+	require.NoError(t, json.Unmarshal(ready.Payload, &readyPayload))
+	assert.Equal(t, "team-b", readyPayload.Instance)
+	require.Len(t, created, 2)
+	assert.Equal(t, EmbeddedMCPOptions{Instance: "team-b", BackendURL: "https://b.example.com", WorkspaceID: "ws-b"}, created[1])
+
+	// 같은 이름을 다른 스코프로 시작하면 거절하고, 같은 설정이면 기존 인스턴스로 응답합니다.
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStart, "start-3", ws.MCPServeStartPayload{Instance: "team-b", WorkspaceID: "ws-other"})
+	conflict := receiveMessageOfType(t, srv, ws.AgentMsgMCPServeResult)
+	var result ws.MCPServeResultPayload
+	require.NoError(t, json.Unmarshal(conflict.Payload, &result))
+	assert.Equal(t, "error", result.Status)
+	assert.Equal(t, "team-b", result.Instance)
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStart, "start-4", ws.MCPServeStartPayload{BackendURL: "https://a.example.com"})
+	receiveMessageOfType(t, srv, ws.AgentMsgMCPServeReady)
+	assert.Len(t, created, 2)
+
+	heartbeat := client.buildHeartbeatPayload()
+	assert.Equal(t, mcpServeStatusRunning, heartbeat.MCPServeStatus)
+	require.Len(t, heartbeat.MCPServeInstances, 2)
+	assert.Equal(t, ws.MCPServeDefaultInstance, heartbeat.MCPServeInstances[0].Instance)
+	assert.Equal(t, "team-b", heartbeat.MCPServeInstances[1].Instance)
+	assert.Equal(t, "ws-b", heartbeat.MCPServeInstances[1].WorkspaceID)
+	assert.False(t, heartbeat.MCPServeInstances[1].StartedAt.IsZero())
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPRPC, "rpc-1", ws.MCPRPCPayload{
+		Instance: "team-b",
+		Message:  json.RawMessage(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`),
+	})
+	resp := receiveMessageOfType(t, srv, ws.AgentMsgMCPRPC)
+	var rpc ws.MCPRPCPayload
+	require.NoError(t, json.Unmarshal(resp.Payload, &rpc))
+	assert.Equal(t, "team-b", rpc.Instance)
+	assert.Contains(t, string(rpc.Message), `"instance":"team-b"`)
+
+	// 특정 인스턴스만 중지합니다.
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStop, "stop-1", ws.MCPServeStopPayload{Instance: "team-b"})
+	stopped := receiveMessageOfType(t, srv, ws.AgentMsgMCPServeResult)
+	require.NoError(t, json.Unmarshal(stopped.Payload, &result))
+	assert.Equal(t, "stopped", result.Status)
+	assert.Equal(t, []string{"team-b"}, result.Stopped)
+	heartbeat = client.buildHeartbeatPayload()
+	assert.Equal(t, mcpServeStatusRunning, heartbeat.MCPServeStatus)
+	require.Len(t, heartbeat.MCPServeInstances, 1)
+
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPRPC, "rpc-2", ws.MCPRPCPayload{
+		Instance: "team-b",
+		Message:  json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"ping"}`),
+	})
+	resp = receiveMessageOfType(t, srv, ws.AgentMsgMCPRPC)
+	require.NoError(t, json.Unmarshal(resp.Payload, &rpc))
+	assert.Contains(t, rpc.Error, "team-b")
+
+	// 인스턴스를 지정하지 않으면 모두 중지합니다.
+	sendMCPServeMessage(t, router, ws.AgentMsgMCPServeStop, "stop-2", ws.MCPServeStopPayload{})
+	stopped = receiveMessageOfType(t, srv, ws.AgentMsgMCPServeResult)
+	result = ws.MCPServeResultPayload{}
+	require.NoError(t, json.Unmarshal(stopped.Payload, &result))
+	assert.Equal(t, []string{ws.MCPServeDefaultInstance}, result.Stopped)
+	heartbeat = client.buildHeartbeatPayload()
+	assert.Equal(t, mcpServeStatusStopped, heartbeat.MCPServeStatus)
+	assert.Empty(t, heartbeat.MCPServeInstances)
+}
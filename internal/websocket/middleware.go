@@ -0,0 +1,261 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 메시지 핸들러 미들웨어 체인을 제공합니다.
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+var (
+	// ErrHandlerPanic은 메시지 핸들러에서 패닉이 발생해 복구되었음을 나타냅니다.
+	ErrHandlerPanic = errors.New("메시지 핸들러 패닉")
+	// ErrInvalidSignature는 중요 메시지의 HMAC 서명 검증에 실패했음을 나타냅니다.
+	ErrInvalidSignature = errors.New("메시지 서명 검증 실패")
+	// ErrRateLimited는 메시지 타입별 처리 속도 제한을 초과해 메시지를 버렸음을 나타냅니다.
+	ErrRateLimited = errors.New("메시지 처리 속도 제한 초과")
+)
+
+// Middleware는 HandlerFunc를 감싸 공통 동작(로깅, 메트릭, 인증, 패닉 복구, 속도 제한 등)을 추가합니다.
+// next를 호출하지 않으면 메시지 처리를 중단합니다.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// WithMiddleware는 Router에 미들웨어를 등록합니다.
+// 먼저 등록한 미들웨어가 바깥쪽에서 실행됩니다.
+func WithMiddleware(mw ...Middleware) RouterOption {
+	return func(r *Router) {
+		r.middlewares = append(r.middlewares, mw...)
+	}
+}
+
+// Use는 런타임에 미들웨어를 등록합니다.
+// 등록 이후 수신되는 모든 메시지의 핸들러에 적용됩니다.
+func (r *Router) Use(mw ...Middleware) {
+	r.handlersMu.Lock()
+	defer r.handlersMu.Unlock()
+	r.middlewares = append(r.middlewares, mw...)
+}
+
+// chain은 기본 미들웨어(패닉 복구, 트레이싱, HMAC 검증, 재전송 방지)와 등록된 미들웨어로 handler를 감쌉니다.
+// 실행 순서: 패닉 복구 → 트레이싱 → HMAC 검증 → 재전송 방지 → 등록 순서대로 사용자 미들웨어 → 작업 위임 → handler.
+// 호출자가 handlersMu를 잡고 있어야 합니다.
+func (r *Router) chain(handler HandlerFunc) HandlerFunc {
+	h := handler
+	if r.delegator != nil {
+		h = r.delegationMiddleware()(h)
+	}
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		h = r.middlewares[i](h)
+	}
+	if r.replayGuard != nil {
+		h = r.replayGuardMiddleware()(h)
+	}
+	if r.client != nil {
+		h = HMACVerifyMiddleware(r.client.Signer())(h)
+	}
+	h = TracingMiddleware()(h)
+	return RecoveryMiddleware()(h)
+}
+
+// RecoveryMiddleware는 핸들러 패닉을 복구하고 ErrHandlerPanic으로 변환합니다.
+// 핸들러는 readLoop에서 별도 고루틴으로 실행되므로 복구하지 않으면 프로세스 전체가 종료됩니다.
+func RecoveryMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) (err error) {
+			defer func() {
+				if v := recover(); v != nil {
+					log.Printf("[handler] 패닉 복구: type=%s id=%s panic=%v\n%s", msg.Type, msg.ID, v, debug.Stack())
+					err = fmt.Errorf("%w: %v", ErrHandlerPanic, v)
+				}
+			}()
+			return next(ctx, msg)
+		}
+	}
+}
+
+// TracingMiddleware는 서버가 metadata로 보낸 트레이스 컨텍스트를 이어받아 핸들러 스팬을 기록합니다.
+// 비동기로 실행되는 작업도 이 컨텍스트를 받아 같은 트레이스에 기록됩니다.
+func TracingMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			ctx, span := tracing.Start(tracing.Extract(ctx, msg.Metadata), "ws.handle "+msg.Type,
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("messaging.message.type", msg.Type),
+					attribute.String("messaging.message.id", msg.ID),
+				),
+			)
+			err := next(ctx, msg)
+			tracing.End(span, err)
+			return err
+		}
+	}
+}
+
+// HMACVerifyMiddleware는 중요 메시지의 HMAC-SHA256 서명을 검증합니다 (SEC-P2-02).
+// readLoop 외의 경로로 Router에 전달된 메시지도 같은 검증을 거치도록 합니다.
+// 시크릿이 설정되지 않았거나 비중요 메시지이면 그대로 통과시킵니다.
+func HMACVerifyMiddleware(signer *MessageSigner) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		if signer == nil {
+			return next
+		}
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			if !signer.Verify(&msg) {
+				return fmt.Errorf("%w: type=%s id=%s", ErrInvalidSignature, msg.Type, msg.ID)
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// LoggingMiddleware는 메시지 타입, ID, 처리 시간, 에러를 기록합니다.
+// logf가 nil이면 log.Printf를 사용합니다.
+func LoggingMiddleware(logf func(format string, args ...interface{})) Middleware {
+	if logf == nil {
+		logf = log.Printf
+	}
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			start := time.Now()
+			err := next(ctx, msg)
+			if err != nil {
+				logf("[handler] type=%s id=%s duration=%s err=%v", msg.Type, msg.ID, time.Since(start), err)
+			} else {
+				logf("[handler] type=%s id=%s duration=%s", msg.Type, msg.ID, time.Since(start))
+			}
+			return err
+		}
+	}
+}
+
+// RateLimitMiddleware는 메시지 타입별 토큰 버킷으로 처리 속도를 제한합니다.
+// msgTypes가 비어 있으면 모든 메시지 타입에 각각 제한을 적용합니다.
+// 제한을 넘은 메시지는 처리하지 않고 ErrRateLimited를 반환합니다.
+func RateLimitMiddleware(limit rate.Limit, burst int, msgTypes ...string) Middleware {
+	var targets map[string]bool
+	if len(msgTypes) > 0 {
+		targets = make(map[string]bool, len(msgTypes))
+		for _, t := range msgTypes {
+			targets[t] = true
+		}
+	}
+	var (
+		mu       sync.Mutex
+		limiters = make(map[string]*rate.Limiter)
+	)
+	limiterFor := func(msgType string) *rate.Limiter {
+		mu.Lock()
+		defer mu.Unlock()
+		l, ok := limiters[msgType]
+		if !ok {
+			l = rate.NewLimiter(limit, burst)
+			limiters[msgType] = l
+		}
+		return l
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			if targets != nil && !targets[msg.Type] {
+				return next(ctx, msg)
+			}
+			if !limiterFor(msg.Type).Allow() {
+				return fmt.Errorf("%w: type=%s id=%s", ErrRateLimited, msg.Type, msg.ID)
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// HandlerMetrics는 메시지 타입별 처리 횟수, 에러 수, 처리 시간을 집계합니다.
+type HandlerMetrics struct {
+	mu    sync.Mutex
+	stats map[string]*handlerStats
+}
+
+// handlerStats는 메시지 타입별 누적 통계입니다.
+type handlerStats struct {
+	count    int64
+	errors   int64
+	duration time.Duration
+	max      time.Duration
+}
+
+// HandlerMetricsSnapshot은 메시지 타입별 통계 스냅샷입니다.
+type HandlerMetricsSnapshot struct {
+	MsgType      string  `json:"msg_type"`
+	Count        int64   `json:"count"`
+	Errors       int64   `json:"errors"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	MaxLatencyMs float64 `json:"max_latency_ms"`
+}
+
+// NewHandlerMetrics는 빈 메시지 핸들러 메트릭 수집기를 생성합니다.
+func NewHandlerMetrics() *HandlerMetrics {
+	return &HandlerMetrics{stats: make(map[string]*handlerStats)}
+}
+
+// Middleware는 핸들러 처리 결과를 m에 기록하는 미들웨어를 반환합니다.
+// 비동기 작업을 시작하는 핸들러는 작업 시작까지의 시간만 측정됩니다.
+func (m *HandlerMetrics) Middleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			start := time.Now()
+			err := next(ctx, msg)
+			m.record(msg.Type, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// record는 메시지 한 건의 처리 결과를 기록합니다.
+func (m *HandlerMetrics) record(msgType string, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.stats[msgType]
+	if !ok {
+		s = &handlerStats{}
+		m.stats[msgType] = s
+	}
+	s.count++
+	s.duration += d
+	if d > s.max {
+		s.max = d
+	}
+	if err != nil {
+		s.errors++
+	}
+}
+
+// Snapshot은 메시지 타입 이름순으로 정렬된 통계를 반환합니다.
+func (m *HandlerMetrics) Snapshot() []HandlerMetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snaps := make([]HandlerMetricsSnapshot, 0, len(m.stats))
+	for msgType, s := range m.stats {
+		snaps = append(snaps, HandlerMetricsSnapshot{
+			MsgType:      msgType,
+			Count:        s.count,
+			Errors:       s.errors,
+			AvgLatencyMs: float64(s.duration) / float64(s.count) / float64(time.Millisecond),
+			MaxLatencyMs: float64(s.max) / float64(time.Millisecond),
+		})
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].MsgType < snaps[j].MsgType })
+	return snaps
+}
@@ -0,0 +1,137 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+const testMiddlewareMsgType = "middleware_test"
+
+// recordingMiddleware는 실행 순서를 기록하는 테스트용 미들웨어입니다.
+func recordingMiddleware(name string, order *[]string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			*order = append(*order, name+":before")
+			err := next(ctx, msg)
+			*order = append(*order, name+":after")
+			return err
+		}
+	}
+}
+
+func TestRouterMiddleware_Order(t *testing.T) {
+	var order []string
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithMiddleware(recordingMiddleware("a", &order)))
+	router.Use(recordingMiddleware("b", &order))
+	router.RegisterHandler(testMiddlewareMsgType, func(ctx context.Context, msg ws.AgentMessage) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{Type: testMiddlewareMsgType}))
+	assert.Equal(t, []string{"a:before", "b:before", "handler", "b:after", "a:after"}, order)
+}
+
+func TestRouterMiddleware_ShortCircuit(t *testing.T) {
+	called := false
+	denied := errors.New("denied")
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithMiddleware(func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error { return denied }
+	}))
+	router.RegisterHandler(testMiddlewareMsgType, func(ctx context.Context, msg ws.AgentMessage) error {
+		called = true
+		return nil
+	})
+
+	err := router.HandleMessage(context.Background(), ws.AgentMessage{Type: testMiddlewareMsgType})
+	assert.ErrorIs(t, err, denied)
+	assert.False(t, called, "next를 호출하지 않으면 핸들러가 실행되지 않아야 함")
+}
+
+func TestRouterMiddleware_RecoversPanic(t *testing.T) {
+	var reported error
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithErrorHandler(func(err error) { reported = err }))
+	router.RegisterHandler(testMiddlewareMsgType, func(ctx context.Context, msg ws.AgentMessage) error {
+		panic("boom")
+	})
+
+	err := router.HandleMessage(context.Background(), ws.AgentMessage{Type: testMiddlewareMsgType})
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.ErrorIs(t, reported, ErrHandlerPanic)
+}
+
+func TestRouterMiddleware_VerifiesHMAC(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	client.Signer().SetSecret([]byte("test-secret"))
+	router := NewRouter(client)
+	calls := 0
+	router.RegisterHandler(ws.AgentMsgCustomToolRequest, func(ctx context.Context, msg ws.AgentMessage) error {
+		calls++
+		return nil
+	})
+
+	unsigned := ws.AgentMessage{Type: ws.AgentMsgCustomToolRequest, ID: "msg-1", Timestamp: time.Now(), Payload: []byte(`{}`)}
+	assert.ErrorIs(t, router.HandleMessage(context.Background(), unsigned), ErrInvalidSignature)
+	assert.Equal(t, 0, calls)
+
+	signed := unsigned
+	require.NoError(t, client.Signer().Sign(&signed))
+	assert.NoError(t, router.HandleMessage(context.Background(), signed))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithMiddleware(RateLimitMiddleware(rate.Every(time.Hour), 2, testMiddlewareMsgType)))
+	router.RegisterHandler(testMiddlewareMsgType, func(ctx context.Context, msg ws.AgentMessage) error { return nil })
+	router.RegisterHandler("other", func(ctx context.Context, msg ws.AgentMessage) error { return nil })
+
+	ctx := context.Background()
+	assert.NoError(t, router.HandleMessage(ctx, ws.AgentMessage{Type: testMiddlewareMsgType}))
+	assert.NoError(t, router.HandleMessage(ctx, ws.AgentMessage{Type: testMiddlewareMsgType}))
+	assert.ErrorIs(t, router.HandleMessage(ctx, ws.AgentMessage{Type: testMiddlewareMsgType}), ErrRateLimited)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, router.HandleMessage(ctx, ws.AgentMessage{Type: "other"}), "대상이 아닌 메시지 타입은 제한하지 않아야 함")
+	}
+}
+
+func TestHandlerMetricsAndLogging(t *testing.T) {
+	metrics := NewHandlerMetrics()
+	var logged []string
+	logf := func(format string, args ...interface{}) { logged = append(logged, fmt.Sprintf(format, args...)) }
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithMiddleware(metrics.Middleware(), LoggingMiddleware(logf)))
+	router.RegisterHandler(testMiddlewareMsgType, func(ctx context.Context, msg ws.AgentMessage) error {
+		if msg.ID == "fail" {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+
+	ctx := context.Background()
+	_ = router.HandleMessage(ctx, ws.AgentMessage{Type: testMiddlewareMsgType, ID: "ok"})
+	_ = router.HandleMessage(ctx, ws.AgentMessage{Type: testMiddlewareMsgType, ID: "fail"})
+
+	snaps := metrics.Snapshot()
+	require.Len(t, snaps, 1)
+	assert.Equal(t, testMiddlewareMsgType, snaps[0].MsgType)
+	assert.Equal(t, int64(2), snaps[0].Count)
+	assert.Equal(t, int64(1), snaps[0].Errors)
+
+	require.Len(t, logged, 2)
+	assert.Contains(t, logged[1], "id=fail")
+	assert.Contains(t, logged[1], "err=handler failed")
+}
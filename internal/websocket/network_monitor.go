@@ -11,8 +11,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/gorilla/websocket"
-
 	"github.com/insajin/autopus-bridge/internal/logger"
 )
 
@@ -199,7 +197,8 @@ func equalStringSlices(a, b []string) bool {
 	return true
 }
 
-// Ping은 WebSocket 서버에 ping 메시지를 전송하여 연결 유효성을 검증합니다.
+// Ping은 서버에 ping을 전송하여 연결 유효성을 검증합니다.
+// HTTP 롱폴링 연결이면 세션이 살아 있는지 확인합니다.
 // FR-P2-03: 네트워크 변경 시 연결 검증에 사용됩니다.
 func (c *Client) Ping() error {
 	c.connMu.RLock()
@@ -210,11 +209,7 @@ func (c *Client) Ping() error {
 		return net.ErrClosed
 	}
 
-	return conn.WriteControl(
-		websocket.PingMessage,
-		[]byte("ping"),
-		time.Now().Add(PingTimeout),
-	)
+	return conn.Ping()
 }
 
 // TriggerReconnect는 외부 컴포넌트에서 재연결을 트리거합니다.
@@ -0,0 +1,357 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 작업 결과처럼 유실되면 안 되는 송신 메시지를 디스크에 보관했다가
+// 연결이 끊겼거나 브리지가 재시작된 뒤 다시 전송하는 아웃박스.
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/store"
+)
+
+const (
+	// DefaultOutboxMaxMessages는 아웃박스에 보관하는 최대 메시지 수입니다.
+	// 초과하면 가장 오래된 메시지부터 버립니다.
+	DefaultOutboxMaxMessages = 500
+	// DefaultOutboxMaxAge는 메시지를 다시 전송할 수 있는 최대 보관 시간입니다.
+	// 서버가 이미 작업을 타임아웃 처리했을 만큼 오래된 결과는 버립니다.
+	DefaultOutboxMaxAge = 24 * time.Hour
+	// outboxMaxAttempts는 메시지 하나의 최대 전송 시도 횟수입니다.
+	outboxMaxAttempts = 5
+)
+
+// durableMessageTypes는 아웃박스를 거쳐 전송하는 메시지 타입입니다.
+// 서버가 기다리는 최종 결과/에러만 포함하며, 진행 상황과 세션에 묶인 메시지는 제외합니다.
+var durableMessageTypes = map[string]bool{
+	ws.AgentMsgTaskResult:            true,
+	ws.AgentMsgTaskError:             true,
+	ws.AgentMsgBuildResult:           true,
+	ws.AgentMsgTestResult:            true,
+	ws.AgentMsgQAResult:              true,
+	ws.AgentMsgCLIResult:             true,
+	ws.AgentMsgCodeOpsResult:         true,
+	ws.AgentMsgGitResult:             true,
+	ws.AgentMsgCustomToolResult:      true,
+	ws.AgentMsgMCPCodegenResult:      true,
+	ws.AgentMsgMCPDeployResult:       true,
+	ws.AgentMsgAgentResponseComplete: true,
+	ws.AgentMsgAgentResponseError:    true,
+	ws.AgentMsgCodingRelayComplete:   true,
+	ws.AgentMsgCodingRelayError:      true,
+}
+
+// isDurableMessage는 메시지 타입이 아웃박스 대상인지 반환합니다.
+func isDurableMessage(msgType string) bool {
+	return durableMessageTypes[msgType]
+}
+
+// outboxEntry는 전송을 기다리는 메시지입니다.
+type outboxEntry struct {
+	ID       string          `json:"id"`
+	Message  ws.AgentMessage `json:"message"`
+	QueuedAt time.Time       `json:"queued_at"`
+	Attempts int             `json:"attempts"`
+
+	// inflight는 전송 중인 메시지 표시입니다 (디스크에 저장하지 않음).
+	inflight bool
+}
+
+// outboxFile은 아웃박스 파일 형식입니다.
+type outboxFile struct {
+	Entries []outboxEntry `json:"entries"`
+}
+
+// Outbox는 전송이 끝나지 않은 결과 메시지를 파일에 보관합니다.
+// 메시지는 전송 전에 저장되고 전송에 성공하면 삭제되므로,
+// 전송 중 연결이 끊기거나 프로세스가 종료되어도 다음 연결에서 다시 전송됩니다.
+type Outbox struct {
+	mu sync.Mutex
+	// path는 아웃박스 파일 경로입니다 (비어 있으면 메모리에만 보관).
+	path string
+	// bucket은 상태 저장소 버킷입니다. 설정되면 path 대신 이 버킷에 항목별로 저장합니다.
+	bucket *store.Bucket
+	// entries는 보관 중인 메시지입니다 (오래된 순).
+	entries []outboxEntry
+	// maxMessages는 최대 보관 메시지 수입니다.
+	maxMessages int
+	// maxAge는 최대 보관 시간입니다.
+	maxAge time.Duration
+	// seq는 항목 ID 생성용 카운터입니다.
+	seq uint64
+	// now는 현재 시각을 반환합니다 (테스트에서 주입).
+	now func() time.Time
+}
+
+// NewOutbox는 path의 아웃박스를 엽니다. 파일이 있으면 이전 프로세스가 남긴 메시지를 불러옵니다.
+// maxMessages/maxAge가 0 이하이면 기본값을 사용합니다.
+// 파일이 손상되었으면 빈 아웃박스와 함께 에러를 반환합니다.
+func NewOutbox(path string, maxMessages int, maxAge time.Duration) (*Outbox, error) {
+	o := newOutbox(maxMessages, maxAge)
+	o.path = path
+	if path == "" {
+		return o, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return o, nil
+		}
+		return o, fmt.Errorf("아웃박스 읽기 실패: %w", err)
+	}
+	var file outboxFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return o, fmt.Errorf("아웃박스 파싱 실패: %w", err)
+	}
+	o.entries = file.Entries
+	o.seq = uint64(len(o.entries))
+	o.pruneLocked()
+	return o, nil
+}
+
+// NewStoreOutbox는 상태 저장소 버킷에 메시지를 보관하는 아웃박스를 엽니다.
+// legacyPath에 이전 버전의 아웃박스 파일이 있으면 버킷으로 가져온 뒤 삭제합니다.
+func NewStoreOutbox(bucket *store.Bucket, legacyPath string, maxMessages int, maxAge time.Duration) (*Outbox, error) {
+	o := newOutbox(maxMessages, maxAge)
+	o.bucket = bucket
+
+	_, importErr := store.ImportFile(legacyPath, func(data []byte) error {
+		var file outboxFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return fmt.Errorf("이전 아웃박스 파싱 실패: %w", err)
+		}
+		for _, e := range file.Entries {
+			if err := bucket.PutJSON(e.ID, e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if importErr != nil {
+		log.Printf("[OUTBOX] 이전 아웃박스 파일 가져오기 실패: %v", importErr)
+	}
+
+	err := bucket.ForEach(func(_ string, value []byte) error {
+		var e outboxEntry
+		if err := json.Unmarshal(value, &e); err != nil {
+			return fmt.Errorf("아웃박스 항목 파싱 실패: %w", err)
+		}
+		o.entries = append(o.entries, e)
+		return nil
+	})
+	if err != nil {
+		o.entries = nil
+		return o, err
+	}
+	sort.SliceStable(o.entries, func(i, j int) bool {
+		return o.entries[i].QueuedAt.Before(o.entries[j].QueuedAt)
+	})
+	o.seq = uint64(len(o.entries))
+	o.pruneLocked()
+	return o, o.saveLocked()
+}
+
+// newOutbox는 메모리 아웃박스를 생성합니다. maxMessages/maxAge가 0 이하이면 기본값을 사용합니다.
+func newOutbox(maxMessages int, maxAge time.Duration) *Outbox {
+	if maxMessages <= 0 {
+		maxMessages = DefaultOutboxMaxMessages
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultOutboxMaxAge
+	}
+	return &Outbox{maxMessages: maxMessages, maxAge: maxAge, now: time.Now}
+}
+
+// Len은 보관 중인 메시지 수를 반환합니다.
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.entries)
+}
+
+// add는 메시지를 전송 중 상태로 저장하고 항목 ID를 반환합니다.
+func (o *Outbox) add(msg ws.AgentMessage) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.seq++
+	id := strconv.FormatInt(o.now().UnixNano(), 36) + "-" + strconv.FormatUint(o.seq, 36)
+	o.entries = append(o.entries, outboxEntry{ID: id, Message: msg, QueuedAt: o.now(), inflight: true})
+	o.pruneLocked()
+	return id, o.saveLocked()
+}
+
+// next는 전송 중이 아닌 가장 오래된 메시지를 전송 중으로 표시하여 반환합니다.
+func (o *Outbox) next() (outboxEntry, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.pruneLocked()
+	for i := range o.entries {
+		if !o.entries[i].inflight {
+			o.entries[i].inflight = true
+			return o.entries[i], true
+		}
+	}
+	return outboxEntry{}, false
+}
+
+// done은 전송에 성공한 메시지를 삭제합니다.
+func (o *Outbox) done(id string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].ID == id {
+			o.entries = append(o.entries[:i], o.entries[i+1:]...)
+			break
+		}
+	}
+	if err := o.saveLocked(); err != nil {
+		log.Printf("[OUTBOX] 아웃박스 저장 실패: %v", err)
+	}
+}
+
+// failed는 전송 실패를 기록합니다. 최대 시도 횟수를 넘으면 메시지를 버리고 true를 반환합니다.
+func (o *Outbox) failed(id string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	dropped := false
+	for i := range o.entries {
+		if o.entries[i].ID != id {
+			continue
+		}
+		o.entries[i].inflight = false
+		o.entries[i].Attempts++
+		if o.entries[i].Attempts >= outboxMaxAttempts {
+			log.Printf("[OUTBOX] 최대 전송 시도 초과로 메시지 폐기: type=%s id=%s",
+				o.entries[i].Message.Type, o.entries[i].Message.ID)
+			o.entries = append(o.entries[:i], o.entries[i+1:]...)
+			dropped = true
+		}
+		break
+	}
+	if err := o.saveLocked(); err != nil {
+		log.Printf("[OUTBOX] 아웃박스 저장 실패: %v", err)
+	}
+	return dropped
+}
+
+// pruneLocked는 보관 시간이 지났거나 최대 개수를 넘는 메시지를 버립니다. 호출자가 mu를 보유해야 합니다.
+func (o *Outbox) pruneLocked() {
+	cutoff := o.now().Add(-o.maxAge)
+	kept := o.entries[:0]
+	for _, e := range o.entries {
+		if e.inflight || e.QueuedAt.After(cutoff) {
+			kept = append(kept, e)
+			continue
+		}
+		log.Printf("[OUTBOX] 보관 시간 초과로 메시지 폐기: type=%s id=%s", e.Message.Type, e.Message.ID)
+	}
+	o.entries = kept
+
+	if over := len(o.entries) - o.maxMessages; over > 0 {
+		log.Printf("[OUTBOX] 보관 한도(%d) 초과로 오래된 메시지 %d개 폐기", o.maxMessages, over)
+		o.entries = append([]outboxEntry(nil), o.entries[over:]...)
+	}
+}
+
+// saveLocked는 아웃박스를 파일에 원자적으로 저장합니다. 호출자가 mu를 보유해야 합니다.
+// 상태 저장소를 사용하면 바뀐 항목만 버킷에 기록합니다.
+func (o *Outbox) saveLocked() error {
+	if o.bucket != nil {
+		entries := make(map[string][]byte, len(o.entries))
+		for _, e := range o.entries {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("아웃박스 직렬화 실패: %w", err)
+			}
+			entries[e.ID] = data
+		}
+		if err := o.bucket.Replace(entries); err != nil {
+			return fmt.Errorf("아웃박스 저장 실패: %w", err)
+		}
+		return nil
+	}
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(outboxFile{Entries: o.entries})
+	if err != nil {
+		return fmt.Errorf("아웃박스 직렬화 실패: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(o.path), 0700); err != nil {
+		return fmt.Errorf("아웃박스 디렉토리 생성 실패: %w", err)
+	}
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("아웃박스 저장 실패: %w", err)
+	}
+	if err := os.Rename(tmp, o.path); err != nil {
+		return fmt.Errorf("아웃박스 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// WithOutbox는 결과 메시지를 보관했다가 재연결/재시작 후 다시 전송할 아웃박스를 설정합니다.
+func WithOutbox(outbox *Outbox) ClientOption {
+	return func(c *Client) {
+		c.outbox = outbox
+	}
+}
+
+// sendDurable은 메시지를 아웃박스에 저장한 뒤 전송합니다.
+// 전송에 실패하면 메시지를 보관하고 nil을 반환하며, 다음 연결에서 flushOutbox가 다시 전송합니다.
+func (c *Client) sendDurable(msg ws.AgentMessage) error {
+	id, err := c.outbox.add(msg)
+	if err != nil {
+		log.Printf("[OUTBOX] 메시지 보관 실패, 바로 전송합니다: %v", err)
+	}
+
+	if err := c.send(msg); err != nil {
+		if c.outbox.failed(id) {
+			return err
+		}
+		log.Printf("[OUTBOX] 전송 실패, 다음 연결에서 다시 전송합니다: type=%s id=%s: %v", msg.Type, msg.ID, err)
+		return nil
+	}
+	c.outbox.done(id)
+	return nil
+}
+
+// flushOutbox는 보관된 메시지를 오래된 순서로 다시 전송합니다.
+// 전송에 실패하면 남은 메시지는 다음 연결에서 다시 시도합니다.
+func (c *Client) flushOutbox() {
+	if c.outbox == nil {
+		return
+	}
+
+	sent := 0
+	for {
+		entry, ok := c.outbox.next()
+		if !ok {
+			break
+		}
+		if err := c.send(entry.Message); err != nil {
+			c.outbox.failed(entry.ID)
+			log.Printf("[OUTBOX] 재전송 실패 (남은 메시지 %d개): %v", c.outbox.Len(), err)
+			return
+		}
+		c.outbox.done(entry.ID)
+		sent++
+	}
+	if sent > 0 {
+		log.Printf("[OUTBOX] 보관된 메시지 %d개 재전송 완료", sent)
+	}
+}
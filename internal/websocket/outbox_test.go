@@ -0,0 +1,165 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gorillaWs "github.com/gorilla/websocket"
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDurableMessage(t *testing.T) {
+	assert.True(t, isDurableMessage(ws.AgentMsgTaskResult))
+	assert.True(t, isDurableMessage(ws.AgentMsgTaskError))
+	assert.False(t, isDurableMessage(ws.AgentMsgTaskProg))
+	assert.False(t, isDurableMessage(ws.AgentMsgHeartbeat))
+}
+
+func TestOutbox_PersistsAcrossRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	outbox, err := NewOutbox(path, 0, 0)
+	require.NoError(t, err)
+
+	// 연결이 없어 전송 실패: 오류 대신 보관
+	client := NewClient("ws://localhost:9999", "tok", "1.0", WithOutbox(outbox))
+	require.NoError(t, client.SendTaskResult(ws.TaskResultPayload{ExecutionID: "exec-1", Output: "done"}))
+	assert.Equal(t, 1, outbox.Len())
+
+	// 진행 상황은 보관하지 않음
+	assert.Error(t, client.SendTaskProgress(ws.TaskProgressPayload{ExecutionID: "exec-1"}))
+	assert.Equal(t, 1, outbox.Len())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// 재시작한 프로세스가 보관된 메시지를 불러와 연결 직후 재전송
+	reloaded, err := NewOutbox(path, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, reloaded.Len())
+
+	received := make(chan ws.AgentMessage, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := gorillaWs.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		ackPayload, _ := json.Marshal(ws.ConnectAckPayload{Success: true})
+		ackData, _ := json.Marshal(ws.AgentMessage{Type: ws.AgentMsgConnectAck, ID: "ack-1", Timestamp: time.Now(), Payload: ackPayload})
+		_ = conn.WriteMessage(gorillaWs.TextMessage, ackData)
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg ws.AgentMessage
+			if json.Unmarshal(data, &msg) == nil {
+				received <- msg
+			}
+		}
+	}))
+	defer server.Close()
+
+	restarted := NewClient("ws"+server.URL[4:], "tok", "1.0", WithOutbox(reloaded))
+	require.NoError(t, restarted.Connect(context.Background()))
+	defer func() { _ = restarted.Disconnect("test done") }()
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, ws.AgentMsgTaskResult, msg.Type)
+		var payload ws.TaskResultPayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		assert.Equal(t, "exec-1", payload.ExecutionID)
+	case <-time.After(3 * time.Second):
+		t.Fatal("보관된 결과가 재전송되지 않았습니다")
+	}
+	assert.Eventually(t, func() bool { return reloaded.Len() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestOutbox_LimitsAndAttempts(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	outbox, err := NewOutbox("", 2, time.Hour)
+	require.NoError(t, err)
+	outbox.now = func() time.Time { return now }
+
+	for _, id := range []string{"m1", "m2", "m3"} {
+		entryID, err := outbox.add(ws.AgentMessage{Type: ws.AgentMsgTaskResult, ID: id})
+		require.NoError(t, err)
+		outbox.failed(entryID)
+	}
+	require.Equal(t, 2, outbox.Len(), "보관 한도를 넘으면 오래된 메시지부터 버립니다")
+
+	entry, ok := outbox.next()
+	require.True(t, ok)
+	assert.Equal(t, "m2", entry.Message.ID)
+
+	// 최대 시도 횟수를 넘으면 폐기
+	dropped := false
+	for !dropped {
+		dropped = outbox.failed(entry.ID)
+	}
+	assert.Equal(t, 1, outbox.Len())
+
+	// 보관 시간이 지나면 폐기
+	now = now.Add(2 * time.Hour)
+	_, ok = outbox.next()
+	assert.False(t, ok)
+	assert.Zero(t, outbox.Len())
+}
+
+func TestStoreOutbox_PersistsAndImportsLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	legacyPath := filepath.Join(dir, "outbox.json")
+	storePath := filepath.Join(dir, "state.db")
+
+	// 이전 버전이 남긴 파일 기반 아웃박스
+	legacy, err := NewOutbox(legacyPath, 0, 0)
+	require.NoError(t, err)
+	legacyID, err := legacy.add(ws.AgentMessage{Type: ws.AgentMsgTaskResult, ID: "legacy-1"})
+	require.NoError(t, err)
+	legacy.failed(legacyID)
+
+	st, err := store.Open(storePath)
+	require.NoError(t, err)
+	outbox, err := NewStoreOutbox(st.Bucket("outbox"), legacyPath, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, outbox.Len())
+	_, err = os.Stat(legacyPath)
+	assert.True(t, os.IsNotExist(err), "가져온 아웃박스 파일은 삭제됩니다")
+
+	id, err := outbox.add(ws.AgentMessage{Type: ws.AgentMsgTaskError, ID: "new-1"})
+	require.NoError(t, err)
+	outbox.failed(id)
+	require.NoError(t, st.Close())
+
+	// 재시작 후 저장소에서 두 메시지를 보관 순서대로 불러옴
+	st, err = store.Open(storePath)
+	require.NoError(t, err)
+	defer func() { _ = st.Close() }()
+	reloaded, err := NewStoreOutbox(st.Bucket("outbox"), legacyPath, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, reloaded.Len())
+
+	first, ok := reloaded.next()
+	require.True(t, ok)
+	assert.Equal(t, "legacy-1", first.Message.ID)
+	assert.Equal(t, 1, first.Attempts)
+	reloaded.done(first.ID)
+	assert.Equal(t, 1, st.Bucket("outbox").Len())
+}
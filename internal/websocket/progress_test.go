@@ -0,0 +1,80 @@
+// Package websocket - 단계 진행 상황 라우팅 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubStepBuildExecutor는 단계 두 개를 보고하는 빌드 실행기입니다.
+type stubStepBuildExecutor struct{}
+
+func (stubStepBuildExecutor) Execute(ctx context.Context, req ws.BuildRequestPayload) *ws.BuildResultPayload {
+	return &ws.BuildResultPayload{ExecutionID: req.ExecutionID, Success: true}
+}
+
+func (s stubStepBuildExecutor) ExecuteWithProgress(ctx context.Context, req ws.BuildRequestPayload, onStep func(ws.ProgressStep)) *ws.BuildResultPayload {
+	onStep(ws.ProgressStep{Name: "prepare", Index: 0, Total: 2, Status: ws.ProgressStepCompleted})
+	onStep(ws.ProgressStep{Name: "build", Index: 1, Total: 2, Status: ws.ProgressStepRunning,
+		Tools: []ws.ProgressToolEvent{{Name: "linux/amd64", Status: ws.ProgressStepRunning}}})
+	return s.Execute(ctx, req)
+}
+
+func TestBuildRequest_RoutesSteps(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client, WithBuildExecutor(stubStepBuildExecutor{}))
+	routeMessage(t, router, ws.AgentMsgBuildReq, ws.BuildRequestPayload{ExecutionID: "exec-build", Command: "make"})
+
+	// 결과와 진행 메시지의 전송 순서는 보장되지 않으므로 둘 다 받을 때까지 모은다.
+	var steps []ws.TaskProgressPayload
+	gotResult := false
+	deadline := time.After(3 * time.Second)
+	for !gotResult || len(steps) < 2 {
+		select {
+		case msg := <-srv.received:
+			switch msg.Type {
+			case ws.AgentMsgTaskProg:
+				var progress ws.TaskProgressPayload
+				require.NoError(t, json.Unmarshal(msg.Payload, &progress))
+				steps = append(steps, progress)
+			case ws.AgentMsgBuildResult:
+				gotResult = true
+			}
+		case <-deadline:
+			t.Fatalf("수신 타임아웃: result=%v, progress=%d", gotResult, len(steps))
+		}
+	}
+
+	assert.Equal(t, ws.ProgressTypeStep, steps[0].Type)
+	assert.Equal(t, "exec-build", steps[0].ExecutionID)
+	assert.Equal(t, 50, steps[0].Progress)
+	assert.Equal(t, "prepare: completed", steps[0].Message)
+	require.NotNil(t, steps[1].Step)
+	assert.Equal(t, "build", steps[1].Step.Name)
+	assert.Equal(t, 50, steps[1].Progress)
+	require.Len(t, steps[1].Step.Tools, 1)
+	assert.Equal(t, "linux/amd64", steps[1].Step.Tools[0].Name)
+}
+
+func TestCodegenStep(t *testing.T) {
+	step := codegenStep("generating", 30, "Claude CLI로 코드 생성 중")
+	require.NotNil(t, step)
+	assert.Equal(t, ws.ProgressStep{Name: "generating", Index: 1, Total: 3, Status: ws.ProgressStepRunning, Detail: "Claude CLI로 코드 생성 중"}, *step)
+
+	done := codegenStep("collecting", 100, "완료")
+	require.NotNil(t, done)
+	assert.Equal(t, ws.ProgressStepCompleted, done.Status)
+	assert.Equal(t, 100, done.Percent())
+
+	assert.Nil(t, codegenStep("unknown", 10, ""))
+}
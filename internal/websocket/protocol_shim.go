@@ -0,0 +1,94 @@
+// Package websocket - 프로토콜 버전 협상과 이전 마이너 버전 서버 호환 shim
+package websocket
+
+import (
+	"fmt"
+	"log"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+)
+
+// protocolShim은 서버가 이전 마이너 버전을 선택했을 때 Bridge -> Server 메시지를 해당 버전 형식으로 변환합니다.
+type protocolShim struct {
+	// version은 shim이 대상으로 하는 "major.minor" 버전입니다.
+	version string
+	// outbound는 전송할 메시지를 변환합니다. ok가 false이면 메시지를 전송하지 않습니다.
+	outbound func(msg ws.AgentMessage) (out ws.AgentMessage, ok bool)
+}
+
+// protocolShims는 이전 마이너 버전별 shim입니다. 현재 버전은 변환하지 않습니다.
+var protocolShims = map[string]*protocolShim{
+	"1.0": {version: "1.0", outbound: dropMessageTypes(
+		// 1.0 서버는 아래 메시지를 처리하지 않으며, 결과는 기존 메시지(cli_result, task_result)로 전달됩니다.
+		ws.AgentMsgCLIOutput,
+		ws.AgentMsgTaskLeaseRenew,
+		ws.AgentMsgTaskResume,
+		ws.AgentMsgTaskDelegate,
+	)},
+}
+
+// dropMessageTypes는 지정한 타입의 메시지를 전송하지 않는 변환 함수를 반환합니다.
+func dropMessageTypes(types ...string) func(ws.AgentMessage) (ws.AgentMessage, bool) {
+	dropped := make(map[string]bool, len(types))
+	for _, t := range types {
+		dropped[t] = true
+	}
+	return func(msg ws.AgentMessage) (ws.AgentMessage, bool) {
+		return msg, !dropped[msg.Type]
+	}
+}
+
+// shimForProtocolVersion은 서버가 선택한 버전에 맞는 shim을 반환합니다.
+// 현재 버전이면 nil을, 지원하지 않는 버전이면 에러를 반환합니다.
+func shimForProtocolVersion(version string) (*protocolShim, error) {
+	if !ws.IsSupportedProtocolVersion(version) {
+		return nil, fmt.Errorf("server protocol version mismatch: server=%s client=%s (supported: %v)",
+			version, ws.AgentProtocolVersion, ws.SupportedAgentProtocolVersions())
+	}
+	if ws.IsCompatibleProtocolVersion(version) {
+		return nil, nil
+	}
+	minor, err := ws.ProtocolMinorVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	shim, ok := protocolShims[minor]
+	if !ok {
+		return nil, fmt.Errorf("프로토콜 버전 %s의 호환 shim이 없습니다", version)
+	}
+	return shim, nil
+}
+
+// negotiateProtocol은 agent_connect_ack의 버전으로 이 연결의 프로토콜 버전과 shim을 결정합니다.
+// 버전을 보내지 않는 서버는 협상 이전 서버로 보고 현재 버전으로 통신합니다.
+func (c *Client) negotiateProtocol(ackVersion string) error {
+	version := ackVersion
+	if version == "" {
+		version = ws.AgentProtocolVersion
+	}
+	shim, err := shimForProtocolVersion(version)
+	if err != nil {
+		return err
+	}
+	if shim != nil {
+		log.Printf("[protocol] 서버가 이전 프로토콜 버전 %s를 선택했습니다. 호환 모드로 통신합니다 (bridge=%s)", version, ws.AgentProtocolVersion)
+	}
+	c.protocolVersion.Store(version)
+	c.protocolShim.Store(shim)
+	return nil
+}
+
+// ProtocolVersion은 현재 연결에서 협상된 프로토콜 버전을 반환합니다. 연결 전에는 빈 문자열입니다.
+func (c *Client) ProtocolVersion() string {
+	version, _ := c.protocolVersion.Load().(string)
+	return version
+}
+
+// applyOutboundShim은 협상된 버전에 맞게 메시지를 변환합니다. ok가 false이면 전송하지 않습니다.
+func (c *Client) applyOutboundShim(msg ws.AgentMessage) (ws.AgentMessage, bool) {
+	shim := c.protocolShim.Load()
+	if shim == nil {
+		return msg, true
+	}
+	return shim.outbound(msg)
+}
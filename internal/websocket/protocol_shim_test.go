@@ -0,0 +1,143 @@
+// Package websocket - 프로토콜 버전 협상과 호환 shim 테스트
+package websocket
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startVersionedServer는 agent_connect를 받으면 ackVersion으로 응답하고 이후 수신한 메시지 타입을 전달하는 서버를 시작합니다.
+func startVersionedServer(t *testing.T, ackVersion string) (url string, connect <-chan ws.AgentConnectPayload, received <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	connectCh := make(chan ws.AgentConnectPayload, 1)
+	receivedCh := make(chan string, 16)
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg ws.AgentMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return
+		}
+		var payload ws.AgentConnectPayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return
+		}
+		connectCh <- payload
+
+		ackPayload, _ := json.Marshal(ws.ConnectAckPayload{Success: true, ProtocolVersion: ackVersion})
+		ackMsg, _ := json.Marshal(ws.AgentMessage{Type: ws.AgentMsgConnectAck, Payload: ackPayload})
+		if err := conn.WriteMessage(websocket.TextMessage, ackMsg); err != nil {
+			return
+		}
+
+		for {
+			_, raw, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var msg ws.AgentMessage
+			if err := json.Unmarshal(raw, &msg); err == nil {
+				receivedCh <- msg.Type
+			}
+		}
+	})}
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	return "ws://" + listener.Addr().String() + "/ws", connectCh, receivedCh
+}
+
+func TestConnect_AdvertisesSupportedProtocolVersions(t *testing.T) {
+	url, connect, _ := startVersionedServer(t, ws.AgentProtocolVersion)
+
+	client := NewClient(url, "test-token", "1.0.0")
+	defer client.Disconnect("test")
+	require.NoError(t, client.Connect(testContext(t)))
+
+	select {
+	case payload := <-connect:
+		assert.Equal(t, ws.AgentProtocolVersion, payload.ProtocolVersion)
+		assert.Equal(t, ws.SupportedAgentProtocolVersions(), payload.SupportedProtocolVersions)
+	case <-time.After(2 * time.Second):
+		t.Fatal("agent_connect payload not received")
+	}
+	assert.Equal(t, ws.AgentProtocolVersion, client.ProtocolVersion())
+	assert.Nil(t, client.protocolShim.Load())
+}
+
+func TestConnect_PreviousProtocolVersionUsesShim(t *testing.T) {
+	url, _, received := startVersionedServer(t, ws.PreviousAgentProtocolVersion)
+
+	client := NewClient(url, "test-token", "1.0.0")
+	defer client.Disconnect("test")
+	require.NoError(t, client.Connect(testContext(t)))
+	assert.Equal(t, ws.PreviousAgentProtocolVersion, client.ProtocolVersion())
+
+	// 1.0 서버가 처리하지 않는 cli_output은 전송하지 않고, 기존 메시지는 그대로 전송합니다.
+	require.NoError(t, client.SendCLIOutput("cli-1", ws.CLIOutputPayload{Lines: []ws.CLIOutputLine{{Stream: "stdout", Text: "hi"}}}))
+	require.NoError(t, client.SendTaskProgress(ws.TaskProgressPayload{ExecutionID: "exec-1", Progress: 50}))
+
+	select {
+	case msgType := <-received:
+		assert.Equal(t, ws.AgentMsgTaskProg, msgType)
+	case <-time.After(2 * time.Second):
+		t.Fatal("task_progress not received")
+	}
+}
+
+func TestConnect_NoAckVersionAssumesCurrent(t *testing.T) {
+	url, _, _ := startVersionedServer(t, "")
+
+	client := NewClient(url, "test-token", "1.0.0")
+	defer client.Disconnect("test")
+	require.NoError(t, client.Connect(testContext(t)))
+	assert.Equal(t, ws.AgentProtocolVersion, client.ProtocolVersion())
+}
+
+func TestConnect_RejectsUnsupportedAckVersion(t *testing.T) {
+	url, _, _ := startVersionedServer(t, "0.9.0")
+
+	client := NewClient(url, "test-token", "1.0.0")
+	err := client.Connect(testContext(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "protocol version mismatch")
+}
+
+func TestShimForProtocolVersion(t *testing.T) {
+	shim, err := shimForProtocolVersion(ws.AgentProtocolVersion)
+	require.NoError(t, err)
+	assert.Nil(t, shim)
+
+	shim, err = shimForProtocolVersion("1.0.3")
+	require.NoError(t, err)
+	require.NotNil(t, shim)
+	for _, msgType := range []string{ws.AgentMsgCLIOutput, ws.AgentMsgTaskLeaseRenew, ws.AgentMsgTaskResume} {
+		_, ok := shim.outbound(ws.AgentMessage{Type: msgType})
+		assert.False(t, ok, msgType)
+	}
+	_, ok := shim.outbound(ws.AgentMessage{Type: ws.AgentMsgTaskResult})
+	assert.True(t, ok)
+
+	_, err = shimForProtocolVersion("2.0.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 연결과 조회 요청은 처리하되 변경 작업은 모두 거절하는 읽기 전용 모드.
+package websocket
+
+import (
+	"errors"
+	"log"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+// ErrCodeReadOnly는 읽기 전용 모드에서 거절한 변경 요청에 사용하는 에러 코드입니다.
+const ErrCodeReadOnly = errcode.ReadOnly
+
+// readOnlyMessage는 읽기 전용 모드에서 거절한 요청에 보내는 메시지입니다.
+const readOnlyMessage = "Bridge가 읽기 전용 모드로 실행 중이므로 변경 작업을 수행하지 않습니다"
+
+// errReadOnly는 결과 페이로드의 에러 문자열로 보고하는 읽기 전용 거절 에러입니다.
+var errReadOnly = errors.New(ErrCodeReadOnly + ": " + readOnlyMessage)
+
+// WithReadOnly는 읽기 전용 모드를 설정합니다.
+// 읽기 전용 모드에서는 하트비트, 설정 갱신, 프로젝트 컨텍스트, 내장 MCP 서버 조회는 그대로 처리하고
+// 작업 실행, CLI, git, CodeOps, 코딩 릴레이, MCP 시작/코드 생성/배포, 컴퓨터/브라우저 세션은 거절합니다.
+func WithReadOnly(readOnly bool) RouterOption {
+	return func(r *Router) {
+		r.readOnly = readOnly
+	}
+}
+
+// IsReadOnly는 라우터가 읽기 전용 모드인지 반환합니다.
+func (r *Router) IsReadOnly() bool {
+	return r.readOnly
+}
+
+// rejectWhileReadOnly는 읽기 전용 모드에서 받은 작업 요청을 재시도 불가능한 에러로 거절합니다.
+// 같은 Bridge에 다시 보내도 결과가 같으므로 retryable=false로 보고합니다.
+func (r *Router) rejectWhileReadOnly(executionID, taskType string) error {
+	log.Printf("[read-only] 변경 작업 요청 거절: execution_id=%s type=%s", executionID, taskType)
+	return r.sendIntakeError(executionID, taskType, ErrCodeReadOnly, readOnlyMessage, false)
+}
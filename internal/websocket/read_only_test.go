@@ -0,0 +1,124 @@
+// Package websocket - 읽기 전용 모드 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleTaskRequest_RejectedWhileReadOnly는 읽기 전용 모드에서 작업이 재시도 불가능한 READ_ONLY 에러로 거절되는지 검증합니다.
+func TestHandleTaskRequest_RejectedWhileReadOnly(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender), WithReadOnly(true))
+	assert.True(t, router.IsReadOnly())
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{ExecutionID: "exec-ro"})
+	require.NoError(t, err)
+	require.NoError(t, router.handleTaskRequest(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskReq,
+		Payload: payload,
+	}))
+
+	assert.False(t, client.TaskTracker().IsActive("exec-ro"), "거절된 작업은 추적되면 안 됨")
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, ErrCodeReadOnly, errs[0].Code)
+	assert.False(t, errs[0].Retryable)
+}
+
+// TestHandleGitRequest_RejectedWhileReadOnly는 읽기 전용 모드에서 git 작업을 실행하지 않고 거절하는지 검증합니다.
+func TestHandleGitRequest_RejectedWhileReadOnly(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	router := NewRouter(client,
+		WithGitRequestExecutor(&stubGitRequestExecutor{result: ws.GitResultPayload{RequestID: "git-ro", Success: true}}),
+		WithReadOnly(true),
+	)
+
+	sendGitRequest(t, router, ws.GitRequestPayload{RequestID: "git-ro", Operation: ws.GitOpPush, Repo: "repo"})
+
+	result := receiveGitResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Equal(t, "git-ro", result.RequestID)
+	assert.Contains(t, result.Error, ErrCodeReadOnly)
+}
+
+// TestCheckAction_ReadOnly는 읽기 전용 모드에서 승인 게이트 대상 작업(사용자 정의 도구)이 거부되는지 검증합니다.
+func TestCheckAction_ReadOnly(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	executor := &stubCustomToolExecutor{}
+	router := NewRouter(client, WithCustomToolExecutor(executor), WithReadOnly(true))
+
+	sendCustomToolRequest(t, router, ws.CustomToolRequestPayload{RequestID: "ct-ro", Tool: "lint_go"})
+	_, result := receiveCustomToolResult(t, srv)
+	assert.False(t, result.Success)
+	assert.Equal(t, cliExitCodeDenied, result.ExitCode)
+	assert.Contains(t, result.Error, ErrCodeReadOnly)
+	assert.Equal(t, int32(0), executor.calls.Load(), "읽기 전용 모드에서는 실행되면 안 됨")
+}
+
+// stubMCPStarter는 시작 요청 수를 기록하는 테스트용 MCP 서버 관리자입니다.
+type stubMCPStarter struct {
+	starts int
+}
+
+func (s *stubMCPStarter) StartServer(context.Context, string, string, []string, map[string]string, string) (int, error) {
+	s.starts++
+	return 1, nil
+}
+
+func (s *stubMCPStarter) StopServer(string, bool) error { return nil }
+
+// TestHandleMCPStart_RejectedWhileReadOnly는 읽기 전용 모드의 mcp_start 거절이 task 거절과 같이
+// 재시도 불가능한 READ_ONLY 에러(등록된 심각도, is_fatal=false)로 보고되는지 검증합니다.
+func TestHandleMCPStart_RejectedWhileReadOnly(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	starter := &stubMCPStarter{}
+	router := NewRouter(client, WithMCPStarter(starter), WithReadOnly(true))
+
+	payload, err := json.Marshal(ws.MCPStartPayload{ServerName: "fs", Command: "npx"})
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgMCPStart,
+		Payload: payload,
+	}))
+
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case msg := <-srv.received:
+			if msg.Type != ws.AgentMsgMCPError {
+				continue
+			}
+			var errPayload ws.MCPErrorPayload
+			require.NoError(t, json.Unmarshal(msg.Payload, &errPayload))
+			assert.Equal(t, ErrCodeReadOnly, errPayload.Code)
+			assert.False(t, errPayload.IsFatal)
+			assert.Equal(t, ws.ErrorSeverityError, errPayload.Severity)
+			assert.Zero(t, starter.starts, "읽기 전용 모드에서는 시작하면 안 됨")
+			return
+		case <-deadline:
+			t.Fatal("mcp_error 수신 타임아웃")
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 이 파일은 서명된 수신 메시지의 재전송(replay) 방지를 제공합니다.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+const (
+	// DefaultReplayWindow는 메시지 타임스탬프 허용 범위의 기본값입니다.
+	DefaultReplayWindow = 5 * time.Minute
+	// DefaultReplayMaxEntries는 기억할 메시지 ID 최대 개수의 기본값입니다.
+	DefaultReplayMaxEntries = 10000
+
+	// ReplayActionReject는 재전송으로 판단한 메시지를 처리하지 않습니다 (기본값).
+	ReplayActionReject = "reject"
+	// ReplayActionWarn은 재전송으로 판단한 메시지를 경고만 기록하고 처리합니다.
+	ReplayActionWarn = "warn"
+
+	// ErrCodeReplayedMessage는 재전송으로 거절한 작업 요청에 사용하는 에러 코드입니다.
+	ErrCodeReplayedMessage = errcode.ReplayedMessage
+)
+
+// replayIntakeTaskTypes는 재전송으로 거절했을 때 서버에 intake 에러를 보내는 메시지 타입과 작업 유형입니다.
+var replayIntakeTaskTypes = map[string]string{
+	ws.AgentMsgTaskReq:  "task",
+	ws.AgentMsgBuildReq: "build",
+	ws.AgentMsgTestReq:  "test",
+	ws.AgentMsgQAReq:    "qa",
+}
+
+// ErrReplayedMessage는 오래되었거나 이미 처리한 메시지를 거부했음을 나타냅니다.
+var ErrReplayedMessage = errors.New("재전송된 메시지")
+
+// ReplayGuardConfig는 ReplayGuard 설정입니다.
+type ReplayGuardConfig struct {
+	// Window는 타임스탬프 허용 범위입니다. 메시지 ID는 그 두 배 동안 기억합니다. 0 이하이면 DefaultReplayWindow.
+	Window time.Duration
+	// Action은 ReplayActionReject 또는 ReplayActionWarn입니다. 비어 있으면 ReplayActionReject.
+	Action string
+	// MaxEntries는 기억할 메시지 ID 최대 개수입니다. 0 이하이면 DefaultReplayMaxEntries.
+	MaxEntries int
+}
+
+// replaySeen은 처리한 메시지 ID와 처음 본 시각입니다.
+type replaySeen struct {
+	key    string
+	seenAt time.Time
+}
+
+// ReplayGuard는 최근 처리한 메시지 ID와 타임스탬프로 재전송된 메시지를 찾습니다.
+// HMAC 서명이 ID와 타임스탬프를 보호하므로, 서명 검증 뒤에 검사해야 변조로 우회할 수 없습니다.
+// 서명 대상인 중요 메시지(작업 요청 등 부작용이 있는 메시지)만 검사합니다.
+type ReplayGuard struct {
+	mu  sync.Mutex
+	cfg ReplayGuardConfig
+	// seen은 메시지 키(타입|ID) → 처음 본 시각입니다.
+	seen map[string]time.Time
+	// order는 처음 본 순서의 메시지 키입니다 (만료/초과 시 앞에서부터 제거).
+	order    []replaySeen
+	rejected int64
+	// now는 테스트에서 시간을 고정하기 위한 함수입니다.
+	now func() time.Time
+}
+
+// NewReplayGuard는 새로운 ReplayGuard를 생성합니다.
+func NewReplayGuard(cfg ReplayGuardConfig) *ReplayGuard {
+	return &ReplayGuard{
+		cfg:  normalizeReplayConfig(cfg),
+		seen: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Reconfigure는 실행 중에 허용 범위, 처리 방식, 최대 개수를 변경합니다.
+// 이미 기억한 메시지 ID는 유지됩니다.
+func (g *ReplayGuard) Reconfigure(cfg ReplayGuardConfig) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cfg = normalizeReplayConfig(cfg)
+	g.pruneLocked(g.now())
+}
+
+// Rejected는 재전송으로 판단한 메시지 수를 반환합니다 (warn 모드에서 처리한 메시지 포함).
+func (g *ReplayGuard) Rejected() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.rejected
+}
+
+// Check는 메시지가 허용 범위 밖이거나 이미 처리한 것이면 ErrReplayedMessage를 반환합니다.
+// 처음 본 메시지는 기억합니다. 중요 메시지가 아니면 검사하지 않습니다.
+// 타임스탬프가 없는 메시지는 ID를 잊은 뒤 다시 보낼 수 있으므로 거부하고, ID가 없으면 타임스탬프만 검사합니다.
+func (g *ReplayGuard) Check(msg *ws.AgentMessage) error {
+	return g.check(msg, true)
+}
+
+// CheckTimestamp는 메시지 ID 중복은 검사하지 않고 타임스탬프 허용 범위만 검사합니다.
+// 중복 요청을 결과 캐시로 처리하는 메시지(task_request)에 사용합니다.
+func (g *ReplayGuard) CheckTimestamp(msg *ws.AgentMessage) error {
+	return g.check(msg, false)
+}
+
+// check는 Check와 CheckTimestamp의 공통 구현입니다. checkID가 false이면 ID를 검사하거나 기억하지 않습니다.
+func (g *ReplayGuard) check(msg *ws.AgentMessage, checkID bool) error {
+	if !criticalMessageTypes[msg.Type] {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.pruneLocked(now)
+
+	if msg.Timestamp.IsZero() {
+		return g.rejectLocked(msg, "타임스탬프 없음")
+	}
+	age := now.Sub(msg.Timestamp)
+	if age > g.cfg.Window {
+		return g.rejectLocked(msg, fmt.Sprintf("허용 범위(%s)보다 오래됨: %s", g.cfg.Window, age.Round(time.Second)))
+	}
+	if -age > g.cfg.Window {
+		return g.rejectLocked(msg, fmt.Sprintf("타임스탬프가 허용 범위(%s)보다 미래임: %s", g.cfg.Window, (-age).Round(time.Second)))
+	}
+
+	if msg.ID == "" || !checkID {
+		return nil
+	}
+	key := msg.Type + "|" + msg.ID
+	if first, ok := g.seen[key]; ok {
+		return g.rejectLocked(msg, fmt.Sprintf("이미 처리한 메시지 (처음 수신: %s)", first.Format(time.RFC3339)))
+	}
+	g.seen[key] = now
+	g.order = append(g.order, replaySeen{key: key, seenAt: now})
+	return nil
+}
+
+// rejectLocked는 재전송을 기록하고 처리 방식에 따라 에러를 반환합니다. 호출자가 mu를 잡고 있어야 합니다.
+func (g *ReplayGuard) rejectLocked(msg *ws.AgentMessage, reason string) error {
+	g.rejected++
+	if g.cfg.Action == ReplayActionWarn {
+		log.Printf("[replay] 재전송 의심 메시지를 처리합니다 (action=warn): type=%s id=%s reason=%s", msg.Type, msg.ID, reason)
+		return nil
+	}
+	log.Printf("[replay] 재전송 메시지 거부: type=%s id=%s reason=%s", msg.Type, msg.ID, reason)
+	return fmt.Errorf("%w: type=%s id=%s: %s", ErrReplayedMessage, msg.Type, msg.ID, reason)
+}
+
+// pruneLocked는 보관 시간(Window의 두 배)이 지났거나 최대 개수를 넘은 메시지 ID를 잊습니다.
+// 타임스탬프는 처음 본 시각보다 최대 Window만큼 미래일 수 있으므로, 메시지가 타임스탬프 검사를 통과하는
+// 마지막 시각은 처음 본 시각 + 2×Window입니다. 그 뒤에는 타임스탬프 검사로 거부되므로 ID를 기억할 필요가 없습니다.
+// 호출자가 mu를 잡고 있어야 합니다.
+func (g *ReplayGuard) pruneLocked(now time.Time) {
+	retention := 2 * g.cfg.Window
+	drop := 0
+	for drop < len(g.order) {
+		entry := g.order[drop]
+		if now.Sub(entry.seenAt) <= retention && len(g.order)-drop <= g.cfg.MaxEntries {
+			break
+		}
+		delete(g.seen, entry.key)
+		drop++
+	}
+	if drop > 0 {
+		g.order = append(g.order[:0], g.order[drop:]...)
+	}
+}
+
+// normalizeReplayConfig는 비어 있는 설정 값을 기본값으로 채웁니다.
+func normalizeReplayConfig(cfg ReplayGuardConfig) ReplayGuardConfig {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultReplayWindow
+	}
+	switch cfg.Action {
+	case ReplayActionWarn:
+	default:
+		cfg.Action = ReplayActionReject
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = DefaultReplayMaxEntries
+	}
+	return cfg
+}
+
+// WithReplayGuard는 Router에 재전송 방지 검사기를 설정합니다.
+// 검사는 HMAC 서명 검증 바로 뒤에서 실행됩니다.
+func WithReplayGuard(guard *ReplayGuard) RouterOption {
+	return func(r *Router) {
+		r.replayGuard = guard
+	}
+}
+
+// replayGuardMiddleware는 재전송된 중요 메시지를 핸들러에 전달하지 않습니다.
+// 서명 시크릿이 없으면 ID와 타임스탬프를 위조할 수 있으므로 검사하지 않습니다.
+// 결과 캐시가 있으면 task_request의 중복 ID는 거절하지 않고 replayCachedResult가 처리하게 합니다.
+// 작업 요청을 거절하면 서버가 기다리지 않도록 intake 에러를 보냅니다.
+func (r *Router) replayGuardMiddleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg ws.AgentMessage) error {
+			if r.client == nil || !r.client.Signer().HasSecret() {
+				return next(ctx, msg)
+			}
+			var err error
+			if msg.Type == ws.AgentMsgTaskReq && r.resultCache != nil {
+				err = r.replayGuard.CheckTimestamp(&msg)
+			} else {
+				err = r.replayGuard.Check(&msg)
+			}
+			if err != nil {
+				r.sendReplayIntakeError(msg, err)
+				return err
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// sendReplayIntakeError는 재전송으로 거절한 작업 요청의 에러를 서버에 보고합니다.
+// execution_id가 있는 작업 요청(task/build/test/qa)만 보고합니다.
+func (r *Router) sendReplayIntakeError(msg ws.AgentMessage, cause error) {
+	taskType, ok := replayIntakeTaskTypes[msg.Type]
+	if !ok {
+		return
+	}
+	var req struct {
+		ExecutionID string `json:"execution_id"`
+	}
+	if err := json.Unmarshal(msg.Payload, &req); err != nil || req.ExecutionID == "" {
+		return
+	}
+	if err := r.sendIntakeError(req.ExecutionID, taskType, ErrCodeReplayedMessage, cause.Error(), false); err != nil {
+		log.Printf("[replay] 거절 에러 전송 실패: execution_id=%s err=%v", req.ExecutionID, err)
+	}
+}
@@ -0,0 +1,168 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestReplayGuard는 시간을 고정한 ReplayGuard와 시계를 반환합니다.
+func newTestReplayGuard(cfg ReplayGuardConfig) (*ReplayGuard, *time.Time) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	guard := NewReplayGuard(cfg)
+	guard.now = func() time.Time { return now }
+	return guard, &now
+}
+
+func TestReplayGuard_Check(t *testing.T) {
+	guard, now := newTestReplayGuard(ReplayGuardConfig{Window: time.Minute, Action: ReplayActionReject})
+	msg := func(id string, ts time.Time) *ws.AgentMessage {
+		return &ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: id, Timestamp: ts}
+	}
+
+	assert.NoError(t, guard.Check(msg("m1", *now)))
+	assert.ErrorIs(t, guard.Check(msg("m1", *now)), ErrReplayedMessage, "같은 ID는 거부해야 함")
+	assert.NoError(t, guard.Check(&ws.AgentMessage{Type: ws.AgentMsgTestReq, ID: "m1", Timestamp: *now}), "다른 타입의 같은 ID는 별개")
+
+	assert.ErrorIs(t, guard.Check(msg("old", now.Add(-2*time.Minute))), ErrReplayedMessage, "허용 범위보다 오래된 메시지")
+	assert.ErrorIs(t, guard.Check(msg("future", now.Add(2*time.Minute))), ErrReplayedMessage, "허용 범위보다 미래의 메시지")
+	assert.NoError(t, guard.Check(msg("skewed", now.Add(30*time.Second))), "허용 범위 안의 시계 차이")
+
+	assert.NoError(t, guard.Check(msg("", *now)), "ID가 없으면 타임스탬프만 검사")
+	assert.NoError(t, guard.Check(msg("", *now)))
+	assert.ErrorIs(t, guard.Check(msg("no-ts", time.Time{})), ErrReplayedMessage, "타임스탬프가 없는 메시지는 거부")
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, guard.Check(&ws.AgentMessage{Type: ws.AgentMsgHeartbeat, ID: "hb", Timestamp: now.Add(-time.Hour)}), "중요 메시지가 아니면 검사하지 않음")
+	}
+	assert.Equal(t, int64(4), guard.Rejected())
+
+	// 미래 타임스탬프의 메시지는 처음 본 뒤 Window가 지나도 타임스탬프 검사를 통과하므로 ID를 계속 기억한다.
+	original := *now
+	assert.NoError(t, guard.Check(msg("ahead", original.Add(50*time.Second))))
+	*now = original.Add(90 * time.Second)
+	assert.ErrorIs(t, guard.Check(msg("ahead", original.Add(50*time.Second))), ErrReplayedMessage, "Window가 지나도 ID 중복은 거부")
+
+	// 보관 시간(2×Window)이 지나면 ID를 잊지만, 그 메시지는 타임스탬프 검사로 거부된다.
+	*now = original.Add(2*time.Minute + time.Second)
+	assert.ErrorIs(t, guard.Check(msg("m1", original)), ErrReplayedMessage)
+	assert.Empty(t, guard.seen)
+}
+
+func TestReplayGuard_MaxEntries(t *testing.T) {
+	guard, now := newTestReplayGuard(ReplayGuardConfig{Window: time.Minute, MaxEntries: 2, Action: ReplayActionReject})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, guard.Check(&ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: fmt.Sprintf("m%d", i), Timestamp: *now}))
+	}
+	assert.NoError(t, guard.Check(&ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: "m0", Timestamp: *now}), "최대 개수를 넘으면 오래된 ID부터 잊음")
+	assert.ErrorIs(t, guard.Check(&ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: "m2", Timestamp: *now}), ErrReplayedMessage)
+	assert.LessOrEqual(t, len(guard.order), 2)
+}
+
+func TestReplayGuard_WarnAndReconfigure(t *testing.T) {
+	guard, now := newTestReplayGuard(ReplayGuardConfig{Window: time.Minute, Action: ReplayActionWarn})
+	m := &ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: "m1", Timestamp: *now}
+	require.NoError(t, guard.Check(m))
+	assert.NoError(t, guard.Check(m), "warn 모드는 처리를 계속함")
+	assert.Equal(t, int64(1), guard.Rejected())
+
+	guard.Reconfigure(ReplayGuardConfig{Window: time.Minute, Action: "unknown"})
+	assert.ErrorIs(t, guard.Check(m), ErrReplayedMessage, "알 수 없는 처리 방식은 reject")
+
+	guard.Reconfigure(ReplayGuardConfig{Window: time.Minute})
+	assert.ErrorIs(t, guard.Check(m), ErrReplayedMessage, "처리 방식을 지정하지 않으면 reject")
+}
+
+func TestRouterMiddleware_RejectsReplayedTask(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	client.Signer().SetSecret([]byte("test-secret"))
+	router := NewRouter(client, WithReplayGuard(NewReplayGuard(ReplayGuardConfig{Action: ReplayActionReject})))
+	calls := 0
+	router.RegisterHandler(ws.AgentMsgCustomToolRequest, func(ctx context.Context, msg ws.AgentMessage) error {
+		calls++
+		return nil
+	})
+
+	signed := ws.AgentMessage{Type: ws.AgentMsgCustomToolRequest, ID: "msg-1", Timestamp: time.Now(), Payload: []byte(`{}`)}
+	require.NoError(t, client.Signer().Sign(&signed))
+	assert.NoError(t, router.HandleMessage(context.Background(), signed))
+	assert.ErrorIs(t, router.HandleMessage(context.Background(), signed), ErrReplayedMessage)
+	assert.Equal(t, 1, calls, "재전송된 요청은 한 번만 실행되어야 함")
+
+	// 서명이 틀린 메시지는 ID를 기억하지 않아 이후 정상 메시지를 막지 않는다.
+	forged := ws.AgentMessage{Type: ws.AgentMsgCustomToolRequest, ID: "msg-2", Timestamp: time.Now(), Payload: []byte(`{}`), Signature: "bad"}
+	assert.ErrorIs(t, router.HandleMessage(context.Background(), forged), ErrInvalidSignature)
+	genuine := forged
+	genuine.Signature = ""
+	require.NoError(t, client.Signer().Sign(&genuine))
+	assert.NoError(t, router.HandleMessage(context.Background(), genuine))
+	assert.Equal(t, 2, calls)
+}
+
+// TestRouterMiddleware_ReplayRequiresSecret는 서명 시크릿이 없으면 재전송 검사를 하지 않는지 검증합니다.
+func TestRouterMiddleware_ReplayRequiresSecret(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithReplayGuard(NewReplayGuard(ReplayGuardConfig{Action: ReplayActionReject})))
+	calls := 0
+	router.RegisterHandler(ws.AgentMsgCustomToolRequest, func(ctx context.Context, msg ws.AgentMessage) error {
+		calls++
+		return nil
+	})
+
+	msg := ws.AgentMessage{Type: ws.AgentMsgCustomToolRequest, ID: "msg-1", Timestamp: time.Now().Add(-time.Hour), Payload: []byte(`{}`)}
+	assert.NoError(t, router.HandleMessage(context.Background(), msg))
+	assert.NoError(t, router.HandleMessage(context.Background(), msg))
+	assert.Equal(t, 2, calls, "시크릿이 없으면 ID와 타임스탬프를 신뢰할 수 없어 검사하지 않음")
+}
+
+// TestRouterMiddleware_ReplayedTaskSendsIntakeError는 재전송으로 거절한 작업 요청을
+// 재시도 불가능한 REPLAYED_MESSAGE 에러로 보고하는지 검증합니다.
+func TestRouterMiddleware_ReplayedTaskSendsIntakeError(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	client.Signer().SetSecret([]byte("test-secret"))
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskMessageSender(sender), WithReplayGuard(NewReplayGuard(ReplayGuardConfig{Action: ReplayActionReject})))
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{ExecutionID: "exec-old"})
+	require.NoError(t, err)
+	stale := ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: "msg-1", Timestamp: time.Now().Add(-time.Hour), Payload: payload}
+	require.NoError(t, client.Signer().Sign(&stale))
+	assert.ErrorIs(t, router.HandleMessage(context.Background(), stale), ErrReplayedMessage)
+
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "exec-old", errs[0].ExecutionID)
+	assert.Equal(t, ErrCodeReplayedMessage, errs[0].Code)
+	assert.False(t, errs[0].Retryable)
+	assert.False(t, client.TaskTracker().IsActive("exec-old"), "거절된 작업은 추적되면 안 됨")
+}
+
+// TestRouterMiddleware_DuplicateTaskUsesResultCache는 결과 캐시가 있으면 task_request의 중복 ID를
+// 거절하지 않고 캐시된 결과로 응답하는지 검증합니다.
+func TestRouterMiddleware_DuplicateTaskUsesResultCache(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	client.Signer().SetSecret([]byte("test-secret"))
+	sender := &recordingTaskSender{}
+	cache := NewResultCache(time.Minute, false)
+	router := NewRouter(client, WithTaskMessageSender(sender), WithResultCache(cache),
+		WithReplayGuard(NewReplayGuard(ReplayGuardConfig{Action: ReplayActionReject})))
+
+	task := ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "p"}
+	cache.Put(task, ws.TaskResultPayload{ExecutionID: "exec-1", Output: "cached"})
+	payload, err := json.Marshal(task)
+	require.NoError(t, err)
+	msg := ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: "msg-1", Timestamp: time.Now(), Payload: payload}
+	require.NoError(t, client.Signer().Sign(&msg))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, router.HandleMessage(context.Background(), msg))
+	}
+	assert.Empty(t, sender.taskErrors())
+	assert.Len(t, sender.taskResults(), 2, "중복 요청도 캐시된 결과로 응답해야 함")
+}
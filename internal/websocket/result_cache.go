@@ -0,0 +1,149 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 서버가 타임아웃 후 같은 task_request를 재전송할 때 완료된 결과를 재사용하기 위한 캐시.
+package websocket
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// DefaultResultCacheWindow는 완료된 작업 결과를 보관하는 기본 시간입니다.
+const DefaultResultCacheWindow = 10 * time.Minute
+
+// resultCacheEntry는 캐시된 작업 결과입니다.
+type resultCacheEntry struct {
+	result     ws.TaskResultPayload
+	promptHash string
+	storedAt   time.Time
+}
+
+// ResultCache는 완료된 TaskResultPayload를 ExecutionID 기준으로 일정 시간 보관합니다.
+// matchPrompt가 true이면 같은 ExecutionID라도 프롬프트 해시가 다르면 캐시를 사용하지 않습니다.
+type ResultCache struct {
+	mu          sync.Mutex
+	entries     map[string]resultCacheEntry
+	window      time.Duration
+	matchPrompt bool
+	// now는 테스트에서 시간을 고정하기 위한 함수입니다.
+	now func() time.Time
+}
+
+// NewResultCache는 새로운 결과 캐시를 생성합니다.
+// window가 0 이하이면 DefaultResultCacheWindow를 사용합니다.
+func NewResultCache(window time.Duration, matchPrompt bool) *ResultCache {
+	if window <= 0 {
+		window = DefaultResultCacheWindow
+	}
+	return &ResultCache{
+		entries:     make(map[string]resultCacheEntry),
+		window:      window,
+		matchPrompt: matchPrompt,
+		now:         time.Now,
+	}
+}
+
+// Reconfigure는 실행 중에 보관 시간과 프롬프트 해시 비교 여부를 변경합니다.
+// 이미 저장된 결과는 유지되며, 새 보관 시간 기준으로 만료 여부가 판단됩니다.
+func (c *ResultCache) Reconfigure(window time.Duration, matchPrompt bool) {
+	if window <= 0 {
+		window = DefaultResultCacheWindow
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.window = window
+	c.matchPrompt = matchPrompt
+}
+
+// Get은 재전송된 작업 요청에 대한 캐시된 결과를 반환합니다.
+func (c *ResultCache) Get(task ws.TaskRequestPayload) (ws.TaskResultPayload, bool) {
+	if task.ExecutionID == "" {
+		return ws.TaskResultPayload{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[task.ExecutionID]
+	if !ok {
+		return ws.TaskResultPayload{}, false
+	}
+	if c.now().Sub(entry.storedAt) > c.window {
+		delete(c.entries, task.ExecutionID)
+		return ws.TaskResultPayload{}, false
+	}
+	if c.matchPrompt && entry.promptHash != taskPromptHash(task) {
+		return ws.TaskResultPayload{}, false
+	}
+	return entry.result, true
+}
+
+// Put은 완료된 작업 결과를 저장하고 만료된 항목을 정리합니다.
+func (c *ResultCache) Put(task ws.TaskRequestPayload, result ws.TaskResultPayload) {
+	if task.ExecutionID == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for id, entry := range c.entries {
+		if now.Sub(entry.storedAt) > c.window {
+			delete(c.entries, id)
+		}
+	}
+
+	c.entries[task.ExecutionID] = resultCacheEntry{
+		result:     result,
+		promptHash: taskPromptHash(task),
+		storedAt:   now,
+	}
+}
+
+// Len은 캐시된 항목 수를 반환합니다 (만료되었지만 아직 정리되지 않은 항목 포함).
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// taskPromptHash는 작업 요청의 프롬프트와 실행 대상(프로바이더/모델)으로 SHA-256 해시를 계산합니다.
+func taskPromptHash(task ws.TaskRequestPayload) string {
+	h := sha256.New()
+	h.Write([]byte(task.Provider))
+	h.Write([]byte{0})
+	h.Write([]byte(task.Model))
+	h.Write([]byte{0})
+	h.Write([]byte(task.Prompt))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// WithResultCache는 완료된 작업 결과 캐시를 설정합니다.
+// 설정하면 같은 ExecutionID의 재전송 요청에 캐시된 결과로 응답하고,
+// 아직 실행 중인 요청의 중복 수신은 무시합니다.
+func WithResultCache(cache *ResultCache) RouterOption {
+	return func(r *Router) {
+		r.resultCache = cache
+	}
+}
+
+// replayCachedResult는 재전송된 작업 요청에 캐시된 결과가 있으면 이를 전송하고 true를 반환합니다.
+func (r *Router) replayCachedResult(task ws.TaskRequestPayload) bool {
+	if r.resultCache == nil {
+		return false
+	}
+
+	result, ok := r.resultCache.Get(task)
+	if !ok {
+		return false
+	}
+	log.Printf("[task-request] 캐시된 결과로 응답: execution_id=%s", task.ExecutionID)
+	_ = r.getTaskSender().SendTaskResult(result)
+	return true
+}
@@ -0,0 +1,201 @@
+// Package websocket - 작업 결과 캐시 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTaskExecutor는 Execute 호출 횟수를 기록하고 release가 닫힐 때까지 대기하는 테스트용 실행기입니다.
+type countingTaskExecutor struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (e *countingTaskExecutor) Execute(_ context.Context, task ws.TaskRequestPayload) (ws.TaskResultPayload, error) {
+	e.calls.Add(1)
+	if e.release != nil {
+		<-e.release
+	}
+	return ws.TaskResultPayload{ExecutionID: task.ExecutionID, Output: "done: " + task.Prompt}, nil
+}
+
+func (e *countingTaskExecutor) ExecuteAgentResponse(context.Context, ws.AgentResponseRequestPayload) (ws.AgentResponseCompletePayload, error) {
+	return ws.AgentResponseCompletePayload{}, nil
+}
+
+func sendTaskRequest(t *testing.T, router *Router, task ws.TaskRequestPayload) {
+	t.Helper()
+	payload, err := json.Marshal(task)
+	require.NoError(t, err)
+	require.NoError(t, router.handleTaskRequest(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskReq,
+		Payload: payload,
+	}))
+}
+
+// TestResultCache_GetPut은 저장된 결과가 ExecutionID로 조회되는지 검증합니다.
+func TestResultCache_GetPut(t *testing.T) {
+	t.Parallel()
+
+	cache := NewResultCache(time.Minute, false)
+	task := ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "hello"}
+
+	_, ok := cache.Get(task)
+	assert.False(t, ok, "저장 전에는 캐시 미스여야 함")
+
+	cache.Put(task, ws.TaskResultPayload{ExecutionID: "exec-1", Output: "world"})
+
+	result, ok := cache.Get(ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "changed"})
+	require.True(t, ok, "프롬프트 해시 비교가 꺼져 있으면 ExecutionID만으로 조회되어야 함")
+	assert.Equal(t, "world", result.Output)
+
+	_, ok = cache.Get(ws.TaskRequestPayload{Prompt: "hello"})
+	assert.False(t, ok, "ExecutionID가 없는 요청은 캐시를 사용하지 않아야 함")
+}
+
+// TestResultCache_MatchPromptHash는 프롬프트가 다르면 캐시를 사용하지 않는지 검증합니다.
+func TestResultCache_MatchPromptHash(t *testing.T) {
+	t.Parallel()
+
+	cache := NewResultCache(time.Minute, true)
+	task := ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "hello", Model: "claude-sonnet-4"}
+	cache.Put(task, ws.TaskResultPayload{ExecutionID: "exec-1", Output: "world"})
+
+	_, ok := cache.Get(task)
+	assert.True(t, ok, "같은 요청은 캐시 히트여야 함")
+
+	_, ok = cache.Get(ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "other", Model: "claude-sonnet-4"})
+	assert.False(t, ok, "프롬프트가 다르면 캐시 미스여야 함")
+
+	_, ok = cache.Get(ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "hello", Model: "gpt-5"})
+	assert.False(t, ok, "모델이 다르면 캐시 미스여야 함")
+}
+
+// TestResultCache_Expiry는 보관 시간이 지난 결과가 제거되는지 검증합니다.
+func TestResultCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cache := NewResultCache(time.Minute, false)
+	cache.now = func() time.Time { return now }
+
+	cache.Put(ws.TaskRequestPayload{ExecutionID: "exec-old"}, ws.TaskResultPayload{ExecutionID: "exec-old"})
+
+	now = now.Add(2 * time.Minute)
+	_, ok := cache.Get(ws.TaskRequestPayload{ExecutionID: "exec-old"})
+	assert.False(t, ok, "만료된 결과는 캐시 미스여야 함")
+
+	cache.Put(ws.TaskRequestPayload{ExecutionID: "exec-a"}, ws.TaskResultPayload{ExecutionID: "exec-a"})
+	now = now.Add(2 * time.Minute)
+	cache.Put(ws.TaskRequestPayload{ExecutionID: "exec-b"}, ws.TaskResultPayload{ExecutionID: "exec-b"})
+	assert.Equal(t, 1, cache.Len(), "Put 시 만료된 항목이 정리되어야 함")
+}
+
+// TestResultCache_Reconfigure는 실행 중 보관 시간/프롬프트 비교 변경이 기존 항목에 반영되는지 검증합니다.
+func TestResultCache_Reconfigure(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cache := NewResultCache(10*time.Minute, true)
+	cache.now = func() time.Time { return now }
+
+	task := ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "hello"}
+	cache.Put(task, ws.TaskResultPayload{ExecutionID: "exec-1"})
+
+	cache.Reconfigure(10*time.Minute, false)
+	_, ok := cache.Get(ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "other"})
+	assert.True(t, ok, "프롬프트 비교를 끄면 ExecutionID만으로 조회되어야 함")
+
+	now = now.Add(2 * time.Minute)
+	cache.Reconfigure(time.Minute, false)
+	_, ok = cache.Get(task)
+	assert.False(t, ok, "줄어든 보관 시간 기준으로 만료되어야 함")
+}
+
+// TestHandleTaskRequest_ReplaysCachedResult는 재전송된 작업 요청이 다시 실행되지 않고 캐시로 응답되는지 검증합니다.
+func TestHandleTaskRequest_ReplaysCachedResult(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	executor := &countingTaskExecutor{}
+	sender := &stubTaskMessageSender{}
+	router := NewRouter(client,
+		WithTaskExecutor(executor),
+		WithTaskMessageSender(sender),
+		WithResultCache(NewResultCache(time.Minute, true)),
+	)
+
+	task := ws.TaskRequestPayload{ExecutionID: "exec-retry", Prompt: "build it"}
+	sendTaskRequest(t, router, task)
+	require.Eventually(t, func() bool {
+		return !client.TaskTracker().IsActive("exec-retry")
+	}, time.Second, 10*time.Millisecond)
+
+	sendTaskRequest(t, router, task)
+
+	assert.Equal(t, int32(1), executor.calls.Load(), "재전송된 요청은 다시 실행되면 안 됨")
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	require.Len(t, sender.results, 2)
+	assert.Equal(t, sender.results[0], sender.results[1], "캐시된 결과가 그대로 재전송되어야 함")
+}
+
+// TestHandleTaskRequest_IgnoresInFlightDuplicate는 실행 중인 작업의 중복 요청이 무시되는지 검증합니다.
+func TestHandleTaskRequest_IgnoresInFlightDuplicate(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	executor := &countingTaskExecutor{release: make(chan struct{})}
+	sender := &stubTaskMessageSender{}
+	router := NewRouter(client,
+		WithTaskExecutor(executor),
+		WithTaskMessageSender(sender),
+		WithResultCache(NewResultCache(time.Minute, true)),
+	)
+
+	task := ws.TaskRequestPayload{ExecutionID: "exec-slow", Prompt: "long task"}
+	sendTaskRequest(t, router, task)
+	require.Eventually(t, func() bool { return executor.calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	sendTaskRequest(t, router, task)
+	close(executor.release)
+
+	require.Eventually(t, func() bool {
+		return !client.TaskTracker().IsActive("exec-slow")
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), executor.calls.Load(), "실행 중인 작업은 중복 실행되면 안 됨")
+
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	assert.Len(t, sender.results, 1)
+}
+
+// TestHandleTaskRequest_NoCacheReexecutes는 캐시가 없으면 재전송 요청도 다시 실행되는지 검증합니다.
+func TestHandleTaskRequest_NoCacheReexecutes(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	executor := &countingTaskExecutor{}
+	router := NewRouter(client,
+		WithTaskExecutor(executor),
+		WithTaskMessageSender(&stubTaskMessageSender{}),
+	)
+
+	task := ws.TaskRequestPayload{ExecutionID: "exec-nocache", Prompt: "build it"}
+	for i := 0; i < 2; i++ {
+		sendTaskRequest(t, router, task)
+		require.Eventually(t, func() bool {
+			return !client.TaskTracker().IsActive("exec-nocache")
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	assert.Equal(t, int32(2), executor.calls.Load())
+}
@@ -0,0 +1,191 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 송신 메시지를 우선순위별 큐로 나누어 하트비트/에러가 대용량 결과 전송에 밀리지 않도록 합니다.
+package websocket
+
+import (
+	"errors"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+// SendPriority는 송신 메시지의 우선순위입니다. 값이 작을수록 먼저 전송됩니다.
+type SendPriority int
+
+const (
+	// PriorityControl은 하트비트, 연결 해제, capability 업데이트 등 제어 메시지입니다.
+	PriorityControl SendPriority = iota
+	// PriorityResult는 작업 결과/에러 메시지입니다.
+	PriorityResult
+	// PriorityProgress는 진행 상황, 스트리밍 청크 등 중간 메시지입니다.
+	PriorityProgress
+	// PriorityBulk는 파일 동기화나 대용량 페이로드 메시지입니다.
+	PriorityBulk
+
+	numSendPriorities = int(PriorityBulk) + 1
+)
+
+// BulkPayloadThreshold는 결과 메시지를 PriorityBulk로 강등하는 직렬화 크기 임계값(바이트)입니다.
+// 에러 메시지는 크기와 관계없이 강등하지 않습니다.
+const BulkPayloadThreshold = 64 * 1024
+
+// 우선순위별 큐 용량. 큐가 가득 차면 송신자는 공간이 생길 때까지 대기합니다.
+var sendQueueCapacity = [numSendPriorities]int{
+	PriorityControl:  32,
+	PriorityResult:   128,
+	PriorityProgress: 256,
+	PriorityBulk:     32,
+}
+
+// errSenderStopped는 메시지가 전송되기 전에 연결의 송신 루프가 종료되었음을 나타냅니다.
+var errSenderStopped = errors.New("송신 루프가 종료되었습니다")
+
+// String은 SendPriority의 문자열 표현을 반환합니다.
+func (p SendPriority) String() string {
+	switch p {
+	case PriorityControl:
+		return "control"
+	case PriorityResult:
+		return "result"
+	case PriorityProgress:
+		return "progress"
+	case PriorityBulk:
+		return "bulk"
+	default:
+		return "unknown"
+	}
+}
+
+// messagePriority는 메시지 타입과 직렬화 크기로 송신 우선순위를 결정합니다.
+func messagePriority(msgType string, size int) SendPriority {
+	switch msgType {
+	case ws.AgentMsgHeartbeat, ws.AgentMsgDisconnect, AgentMsgCapabilityUpdate,
+		ws.AgentMsgToolApprovalReq:
+		return PriorityControl
+
+	case ws.AgentMsgTaskError, ws.AgentMsgAgentResponseError, ws.AgentMsgBrowserError,
+		ws.AgentMsgCodingRelayError, ws.AgentMsgMCPError:
+		// 에러는 작업 실패를 서버에 즉시 알려야 하므로 크기와 관계없이 결과 우선순위를 유지한다.
+		return PriorityResult
+
+	case ws.AgentMsgTaskProg, ws.AgentMsgAgentResponseStream, ws.AgentMsgMCPCodegenProgress,
+		ws.AgentMsgCodingRelayProgress, ws.AgentMsgMCPHealthReport, ws.AgentMsgMCPServerEvent,
+		ws.AgentMsgCLIOutput:
+		return PriorityProgress
+
+	case AgentMsgFileSync:
+		return PriorityBulk
+	}
+
+	if size > BulkPayloadThreshold {
+		return PriorityBulk
+	}
+	return PriorityResult
+}
+
+// outboundMessage는 송신 큐에 대기 중인 직렬화된 메시지입니다.
+type outboundMessage struct {
+	data   []byte
+	result chan error
+}
+
+// messageSender는 연결 하나에 대한 우선순위 송신 큐와 송신 루프입니다.
+// gorilla/websocket은 동시 쓰기를 지원하지 않으므로 모든 일반 메시지는 이 루프에서만 기록됩니다.
+type messageSender struct {
+	queues [numSendPriorities]chan *outboundMessage
+	// notify는 큐에 메시지가 추가되었음을 송신 루프에 알립니다.
+	notify chan struct{}
+	// quit은 송신 루프 종료 요청 채널입니다.
+	quit chan struct{}
+	// stopped는 송신 루프가 종료되면 닫힙니다.
+	stopped chan struct{}
+}
+
+// newMessageSender는 비어 있는 송신 큐를 생성합니다.
+func newMessageSender() *messageSender {
+	s := &messageSender{
+		notify:  make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	for i := range s.queues {
+		s.queues[i] = make(chan *outboundMessage, sendQueueCapacity[i])
+	}
+	return s
+}
+
+// send는 메시지를 우선순위 큐에 넣고 실제 전송 결과를 기다립니다.
+func (s *messageSender) send(priority SendPriority, data []byte) error {
+	out := &outboundMessage{data: data, result: make(chan error, 1)}
+
+	select {
+	case s.queues[priority] <- out:
+	case <-s.stopped:
+		return errSenderStopped
+	}
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+
+	select {
+	case err := <-out.result:
+		return err
+	case <-s.stopped:
+		// 종료 직전에 전송이 끝났을 수 있으므로 결과를 한 번 더 확인한다.
+		select {
+		case err := <-out.result:
+			return err
+		default:
+			return errSenderStopped
+		}
+	}
+}
+
+// run은 종료될 때까지 가장 높은 우선순위의 메시지부터 write로 전송합니다.
+func (s *messageSender) run(write func([]byte) error) {
+	defer close(s.stopped)
+
+	for {
+		out := s.next()
+		if out == nil {
+			return
+		}
+		out.result <- write(out.data)
+	}
+}
+
+// next는 대기 중인 메시지 중 우선순위가 가장 높은 것을 반환합니다.
+// 종료 요청을 받으면 nil을 반환합니다.
+func (s *messageSender) next() *outboundMessage {
+	for {
+		select {
+		case <-s.quit:
+			return nil
+		default:
+		}
+
+		for _, q := range s.queues {
+			select {
+			case out := <-q:
+				return out
+			default:
+			}
+		}
+
+		select {
+		case <-s.quit:
+			return nil
+		case <-s.notify:
+		}
+	}
+}
+
+// stop은 송신 루프에 종료를 요청합니다. 이미 종료 요청된 경우 아무것도 하지 않습니다.
+func (s *messageSender) stop() {
+	select {
+	case <-s.quit:
+	default:
+		close(s.quit)
+	}
+}
@@ -0,0 +1,147 @@
+// Package websocket - 우선순위 송신 큐 테스트
+package websocket
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMessagePriority는 메시지 타입과 크기별 우선순위 분류를 검증합니다.
+func TestMessagePriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		msgType string
+		size    int
+		want    SendPriority
+	}{
+		{"heartbeat", ws.AgentMsgHeartbeat, 100, PriorityControl},
+		{"disconnect", ws.AgentMsgDisconnect, 100, PriorityControl},
+		{"capability update", AgentMsgCapabilityUpdate, 100, PriorityControl},
+		{"task result", ws.AgentMsgTaskResult, 100, PriorityResult},
+		{"large task result", ws.AgentMsgTaskResult, BulkPayloadThreshold + 1, PriorityBulk},
+		{"large task error stays result", ws.AgentMsgTaskError, BulkPayloadThreshold + 1, PriorityResult},
+		{"task progress", ws.AgentMsgTaskProg, 100, PriorityProgress},
+		{"response stream", ws.AgentMsgAgentResponseStream, 100, PriorityProgress},
+		{"file sync", AgentMsgFileSync, 100, PriorityBulk},
+		{"large codegen result", ws.AgentMsgMCPCodegenResult, BulkPayloadThreshold * 2, PriorityBulk},
+		{"unknown type", "custom_message", 100, PriorityResult},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, messagePriority(tt.msgType, tt.size))
+		})
+	}
+}
+
+// TestMessageSender_DrainsByPriority는 대기 중인 메시지가 우선순위 순서로 전송되는지 검증합니다.
+func TestMessageSender_DrainsByPriority(t *testing.T) {
+	t.Parallel()
+
+	sender := newMessageSender()
+	defer sender.stop()
+
+	// 송신 루프 시작 전에 낮은 우선순위부터 큐에 쌓는다.
+	order := []struct {
+		priority SendPriority
+		data     string
+	}{
+		{PriorityBulk, "bulk"},
+		{PriorityProgress, "progress"},
+		{PriorityResult, "result"},
+		{PriorityControl, "heartbeat"},
+	}
+
+	var wg sync.WaitGroup
+	for _, item := range order {
+		wg.Add(1)
+		go func(priority SendPriority, data string) {
+			defer wg.Done()
+			assert.NoError(t, sender.send(priority, []byte(data)))
+		}(item.priority, item.data)
+	}
+
+	require.Eventually(t, func() bool {
+		total := 0
+		for _, q := range sender.queues {
+			total += len(q)
+		}
+		return total == len(order)
+	}, time.Second, 5*time.Millisecond)
+
+	var mu sync.Mutex
+	var written []string
+	go sender.run(func(data []byte) error {
+		mu.Lock()
+		written = append(written, string(data))
+		mu.Unlock()
+		return nil
+	})
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"heartbeat", "result", "progress", "bulk"}, written)
+}
+
+// TestMessageSender_WriteErrorPropagates는 전송 실패가 송신자에게 반환되는지 검증합니다.
+func TestMessageSender_WriteErrorPropagates(t *testing.T) {
+	t.Parallel()
+
+	sender := newMessageSender()
+	defer sender.stop()
+
+	writeErr := errors.New("broken pipe")
+	go sender.run(func([]byte) error { return writeErr })
+
+	err := sender.send(PriorityResult, []byte("result"))
+	assert.ErrorIs(t, err, writeErr)
+}
+
+// TestMessageSender_StopUnblocksPending는 송신 루프 종료 시 대기 중인 송신자가 에러를 받는지 검증합니다.
+func TestMessageSender_StopUnblocksPending(t *testing.T) {
+	t.Parallel()
+
+	sender := newMessageSender()
+	release := make(chan struct{})
+	go sender.run(func([]byte) error {
+		<-release
+		return nil
+	})
+
+	// 첫 메시지가 쓰기에서 막힌 동안 두 번째 메시지는 큐에서 대기한다.
+	firstDone := make(chan error, 1)
+	go func() { firstDone <- sender.send(PriorityBulk, []byte("bulk")) }()
+	require.Eventually(t, func() bool { return len(sender.queues[PriorityBulk]) == 0 }, time.Second, 5*time.Millisecond)
+
+	secondDone := make(chan error, 1)
+	go func() { secondDone <- sender.send(PriorityProgress, []byte("progress")) }()
+	require.Eventually(t, func() bool { return len(sender.queues[PriorityProgress]) == 1 }, time.Second, 5*time.Millisecond)
+
+	sender.stop()
+	close(release)
+
+	select {
+	case err := <-firstDone:
+		assert.NoError(t, err, "이미 기록 중인 메시지는 결과를 받아야 함")
+	case <-time.After(time.Second):
+		t.Fatal("첫 번째 송신이 반환되지 않음")
+	}
+	select {
+	case err := <-secondDone:
+		assert.ErrorIs(t, err, errSenderStopped)
+	case <-time.After(time.Second):
+		t.Fatal("대기 중인 송신이 반환되지 않음")
+	}
+
+	assert.ErrorIs(t, sender.send(PriorityControl, []byte("late")), errSenderStopped)
+}
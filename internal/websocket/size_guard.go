@@ -0,0 +1,28 @@
+// Package websocket - 작업 입력/결과 크기 제한
+package websocket
+
+import (
+	"log"
+
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/sizeguard"
+)
+
+// WithSizeGuard는 작업 프롬프트와 결과 페이로드의 크기 제한을 설정합니다.
+// 한도를 넘는 프롬프트는 PROMPT_TOO_LARGE로 거절하고, 작업 출력과 CLI stdout/stderr가 한도를 넘으면
+// 줄여서 보내며 전체 내용은 로컬 파일에 보관해 결과의 overflow 참조로 알립니다.
+func WithSizeGuard(guard *sizeguard.Guard) RouterOption {
+	return func(r *Router) {
+		r.sizeGuard = guard
+	}
+}
+
+// rejectLargePrompt는 프롬프트가 한도를 넘으면 재시도 불가능한 PROMPT_TOO_LARGE 에러로 거절하고 true를 반환합니다.
+func (r *Router) rejectLargePrompt(executionID, taskType, prompt, systemPrompt string) (bool, error) {
+	err := r.sizeGuard.CheckPrompt(prompt, systemPrompt)
+	if err == nil {
+		return false, nil
+	}
+	log.Printf("[size-guard] 프롬프트 크기 초과로 거절: execution_id=%s type=%s err=%v", executionID, taskType, err)
+	return true, r.sendIntakeError(executionID, taskType, errcode.PromptTooLarge, err.Error(), false)
+}
@@ -0,0 +1,67 @@
+// Package websocket - 작업 입력/결과 크기 제한 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/sizeguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHandleTaskRequest_PromptTooLarge는 한도를 넘는 프롬프트를 실행하지 않고 PROMPT_TOO_LARGE로 거절하는지 검증합니다.
+func TestHandleTaskRequest_PromptTooLarge(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskExecutor(&stubTaskExecutor{}), WithTaskMessageSender(sender),
+		WithSizeGuard(sizeguard.New(sizeguard.Limits{MaxPromptBytes: 16}, "", 0)))
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{ExecutionID: "exec-large", Prompt: strings.Repeat("x", 17)})
+	require.NoError(t, err)
+	require.NoError(t, router.handleTaskRequest(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskReq,
+		Payload: payload,
+	}))
+
+	assert.False(t, client.TaskTracker().IsActive("exec-large"), "거절된 작업은 추적되면 안 됨")
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, errcode.PromptTooLarge, errs[0].Code)
+	assert.Equal(t, "exec-large", errs[0].ExecutionID)
+	assert.False(t, errs[0].Retryable)
+}
+
+// TestExecuteTask_OutputOverflow는 한도를 넘는 출력을 줄여 보내고 전체 출력을 보관 파일로 알리는지 검증합니다.
+func TestExecuteTask_OutputOverflow(t *testing.T) {
+	t.Parallel()
+
+	output := strings.Repeat("verbose line\n", 1000)
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &stubTaskMessageSender{}
+	router := NewRouter(client,
+		WithTaskExecutor(&stubTaskExecutor{result: ws.TaskResultPayload{ExecutionID: "exec-big", Output: output}}),
+		WithTaskMessageSender(sender),
+		WithSizeGuard(sizeguard.New(sizeguard.Limits{MaxOutputBytes: 1024}, t.TempDir(), 0)))
+
+	router.executeTask(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-big"})
+
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+	require.Len(t, sender.results, 1)
+	result := sender.results[0]
+	assert.LessOrEqual(t, len(result.Output), 1024)
+	assert.True(t, result.OutputTruncated)
+	require.Len(t, result.Overflow, 1)
+	assert.Equal(t, ws.OverflowFieldOutput, result.Overflow[0].Field)
+	data, err := os.ReadFile(result.Overflow[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, output, string(data))
+}
@@ -0,0 +1,112 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 장시간 실행 작업의 리스 갱신/회수를 처리합니다.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+)
+
+const (
+	// DefaultLeaseRenewInterval은 작업 리스 갱신 메시지 전송 간격입니다.
+	DefaultLeaseRenewInterval = 60 * time.Second
+	// leaseDurationFactor는 갱신 간격 대비 요청하는 리스 기간 배수입니다.
+	// 갱신 메시지 한두 개가 늦어져도 리스가 만료되지 않도록 여유를 둡니다.
+	leaseDurationFactor = 3
+)
+
+// WithLeaseRenewInterval은 작업 리스 갱신 간격을 설정합니다.
+// 0 이하이면 리스 갱신을 보내지 않습니다.
+func WithLeaseRenewInterval(interval time.Duration) ClientOption {
+	return func(c *Client) {
+		c.leaseRenewInterval = interval
+	}
+}
+
+// leaseLoop는 TaskTracker의 활성 작업마다 주기적으로 task_lease_renew를 전송합니다.
+// 하트비트와 같은 컨텍스트로 시작되어 재연결 시 함께 재시작됩니다.
+func (c *Client) leaseLoop(ctx context.Context) {
+	if c.leaseRenewInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.leaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if c.State() != StateConnected {
+				continue
+			}
+			c.renewLeases()
+		}
+	}
+}
+
+// renewLeases는 회수되지 않은 활성 작업의 리스를 갱신합니다.
+func (c *Client) renewLeases() {
+	leaseSeconds := int((c.leaseRenewInterval * leaseDurationFactor).Seconds())
+	now := time.Now()
+
+	for _, task := range c.taskTracker.GetActiveTaskInfos() {
+		if task.Revoked || task.ExecutionID == "" {
+			continue
+		}
+		payload := ws.TaskLeaseRenewPayload{
+			ExecutionID:  task.ExecutionID,
+			TaskType:     task.TaskType,
+			LeaseSeconds: leaseSeconds,
+			ElapsedMs:    now.Sub(task.StartedAt).Milliseconds(),
+		}
+		if err := c.sendMessage(ws.AgentMsgTaskLeaseRenew, payload); err != nil {
+			log.Printf("[task-lease] 리스 갱신 전송 실패: execution_id=%s err=%v", task.ExecutionID, err)
+		}
+	}
+}
+
+// leaseContext는 작업 실행 컨텍스트를 만들고 취소 함수를 TaskTracker에 연결합니다.
+// 서버가 리스를 회수하면 반환된 컨텍스트가 취소됩니다.
+// 취소 함수는 TaskTracker.Complete에서 해제되므로 호출자가 따로 호출할 필요가 없습니다.
+func (r *Router) leaseContext(ctx context.Context, executionID string) context.Context {
+	leaseCtx, cancel := context.WithCancel(ctx)
+	if !r.client.TaskTracker().BindCancel(executionID, cancel) {
+		cancel()
+		return ctx
+	}
+	return leaseCtx
+}
+
+// leaseRevoked는 실행 결과를 보고하기 전에 리스 회수 여부를 확인합니다.
+// 회수된 작업은 다른 Bridge에 재할당되었을 수 있으므로 결과를 보내지 않습니다.
+func (r *Router) leaseRevoked(executionID, taskType string) bool {
+	if !r.client.TaskTracker().IsRevoked(executionID) {
+		return false
+	}
+	log.Printf("[task-lease] 리스가 회수된 작업의 결과 보고 생략: execution_id=%s type=%s", executionID, taskType)
+	return true
+}
+
+// handleTaskLeaseRevoke는 서버의 리스 회수 메시지를 처리합니다.
+// 해당 작업의 로컬 실행 컨텍스트를 취소합니다.
+func (r *Router) handleTaskLeaseRevoke(_ context.Context, msg ws.AgentMessage) error {
+	var payload ws.TaskLeaseRevokePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("task_lease_revoke 페이로드 파싱 실패: %w", err)
+	}
+
+	if !r.client.TaskTracker().Revoke(payload.ExecutionID) {
+		log.Printf("[task-lease] 활성 작업이 아닌 리스 회수 무시: execution_id=%s", payload.ExecutionID)
+		return nil
+	}
+	log.Printf("[task-lease] 리스 회수로 작업 취소: execution_id=%s reason=%s", payload.ExecutionID, payload.Reason)
+	return nil
+}
@@ -0,0 +1,158 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTaskExecutor는 컨텍스트가 취소될 때까지 실행을 멈추는 테스트용 TaskExecutor입니다.
+type blockingTaskExecutor struct {
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func newBlockingTaskExecutor() *blockingTaskExecutor {
+	return &blockingTaskExecutor{started: make(chan struct{}), cancelled: make(chan struct{})}
+}
+
+func (e *blockingTaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload) (ws.TaskResultPayload, error) {
+	close(e.started)
+	<-ctx.Done()
+	close(e.cancelled)
+	return ws.TaskResultPayload{}, ctx.Err()
+}
+
+func (e *blockingTaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentResponseRequestPayload) (ws.AgentResponseCompletePayload, error) {
+	return ws.AgentResponseCompletePayload{}, nil
+}
+
+func TestTaskTracker_RevokeCancelsBoundContext(t *testing.T) {
+	tracker := NewTaskTracker()
+	tracker.Track("exec-1", "task")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.True(t, tracker.BindCancel("exec-1", cancel))
+	assert.False(t, tracker.BindCancel("exec-missing", cancel))
+
+	assert.True(t, tracker.Revoke("exec-1"))
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.True(t, tracker.IsRevoked("exec-1"))
+	assert.True(t, tracker.IsActive("exec-1"), "회수된 작업도 실행 고루틴이 끝날 때까지 추적되어야 함")
+
+	tracker.Complete("exec-1")
+	assert.False(t, tracker.IsRevoked("exec-1"))
+	assert.False(t, tracker.Revoke("exec-1"))
+}
+
+func TestTaskTracker_CompleteReleasesContext(t *testing.T) {
+	tracker := NewTaskTracker()
+	tracker.Track("exec-1", "build")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.True(t, tracker.BindCancel("exec-1", cancel))
+
+	tracker.Complete("exec-1")
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+// TestRenewLeases_SendsPerTaskRenewal은 회수되지 않은 활성 작업마다 task_lease_renew가 전송되는지 검증합니다.
+func TestRenewLeases_SendsPerTaskRenewal(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	client.TaskTracker().Track("exec-1", "task")
+	client.TaskTracker().Track("exec-2", "build")
+	client.TaskTracker().Track("exec-3", "qa")
+	client.TaskTracker().Revoke("exec-3")
+
+	client.renewLeases()
+
+	renewed := make(map[string]ws.TaskLeaseRenewPayload)
+	deadline := time.After(3 * time.Second)
+	for len(renewed) < 2 {
+		select {
+		case msg := <-srv.received:
+			if msg.Type != ws.AgentMsgTaskLeaseRenew {
+				continue
+			}
+			var payload ws.TaskLeaseRenewPayload
+			require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+			renewed[payload.ExecutionID] = payload
+		case <-deadline:
+			t.Fatalf("task_lease_renew 수신 타임아웃: %v", renewed)
+		}
+	}
+
+	assert.Equal(t, "build", renewed["exec-2"].TaskType)
+	assert.Equal(t, int((DefaultLeaseRenewInterval * leaseDurationFactor).Seconds()), renewed["exec-1"].LeaseSeconds)
+	assert.NotContains(t, renewed, "exec-3", "회수된 작업은 리스를 갱신하지 않아야 함")
+}
+
+// TestHandleTaskLeaseRevoke_CancelsExecution은 리스 회수 시 실행이 취소되고 결과를 보고하지 않는지 검증합니다.
+func TestHandleTaskLeaseRevoke_CancelsExecution(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	executor := newBlockingTaskExecutor()
+	taskSender := &stubTaskMessageSender{}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(taskSender))
+
+	payload, err := json.Marshal(ws.TaskRequestPayload{ExecutionID: "exec-1", Prompt: "long running"})
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskReq,
+		ID:      "msg-task-1",
+		Payload: payload,
+	}))
+
+	select {
+	case <-executor.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("작업이 시작되지 않음")
+	}
+
+	revoke, err := json.Marshal(ws.TaskLeaseRevokePayload{ExecutionID: "exec-1", Reason: "reassigned"})
+	require.NoError(t, err)
+	require.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskLeaseRevoke,
+		ID:      "msg-revoke-1",
+		Payload: revoke,
+	}))
+
+	select {
+	case <-executor.cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("리스 회수 후 실행 컨텍스트가 취소되지 않음")
+	}
+
+	assert.Eventually(t, func() bool {
+		return !client.TaskTracker().IsActive("exec-1")
+	}, 2*time.Second, 10*time.Millisecond)
+
+	taskSender.mu.Lock()
+	defer taskSender.mu.Unlock()
+	assert.Empty(t, taskSender.errors, "회수된 작업은 에러를 보고하지 않아야 함")
+	assert.Empty(t, taskSender.results)
+}
+
+func TestHandleTaskLeaseRevoke_UnknownExecutionIgnored(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client)
+
+	revoke, err := json.Marshal(ws.TaskLeaseRevokePayload{ExecutionID: "exec-unknown"})
+	require.NoError(t, err)
+	assert.NoError(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskLeaseRevoke,
+		Payload: revoke,
+	}))
+	assert.Error(t, router.HandleMessage(context.Background(), ws.AgentMessage{
+		Type:    ws.AgentMsgTaskLeaseRevoke,
+		Payload: json.RawMessage(`{"execution_id":`),
+	}))
+}
@@ -0,0 +1,349 @@
+// Package websocket - 우선순위별 대기열을 가진 작업 동시 실행 제한
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+// 작업 우선순위 순위. 값이 클수록 먼저 실행됩니다.
+const (
+	taskRankLow = iota
+	taskRankNormal
+	taskRankHigh
+
+	numTaskRanks = taskRankHigh + 1
+)
+
+// taskPriorityRank는 요청의 priority 필드를 대기열 순위로 변환합니다. 알 수 없는 값은 normal입니다.
+func taskPriorityRank(priority string) int {
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case ws.PriorityHigh:
+		return taskRankHigh
+	case ws.PriorityLow:
+		return taskRankLow
+	default:
+		return taskRankNormal
+	}
+}
+
+// taskLimiter는 실행 중에 한도를 바꿀 수 있는 세마포어입니다. 제로 값은 제한이 없습니다.
+// 한도에 도달하면 요청은 우선순위별 대기열에서 기다리고, 슬롯이 비면 높은 우선순위의
+// 가장 오래된 요청부터 실행합니다. 따라서 나중에 도착한 high 요청이 대기 중인 low 요청을 앞지릅니다.
+//
+// 워크스페이스 한도가 설정되면 워크스페이스마다 동시 실행 수를 따로 제한합니다. 한도에 걸린
+// 워크스페이스의 요청은 다른 워크스페이스의 요청이 먼저 실행되도록 비켜 기다립니다. 같은 우선순위
+// 안에서는 실행 중인 작업이 적은 워크스페이스, 그다음 가장 오래전에 슬롯을 받은 워크스페이스를
+// 먼저 실행해 한 워크스페이스가 몰아서 보낸 요청이 다른 워크스페이스를 굶기지 않게 합니다.
+type taskLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	active int
+	// waiting은 순위별 대기열입니다 (도착 순서). 실행할 수 있는 요청은 대기열에 남지 않습니다.
+	waiting [numTaskRanks][]*taskWaiter
+
+	// workspaceLimit은 워크스페이스별 기본 동시 실행 한도입니다. 0이면 워크스페이스별로 제한하지 않습니다.
+	workspaceLimit int
+	// workspaceLimits는 워크스페이스별 한도 재정의입니다. 0이면 그 워크스페이스는 제한하지 않습니다.
+	workspaceLimits map[string]int
+	// workspaces는 워크스페이스별 실행 상태입니다.
+	workspaces map[string]*workspaceSlots
+	// grants는 슬롯 배정 순번입니다. 워크스페이스 간 라운드 로빈에 사용합니다.
+	grants uint64
+}
+
+// workspaceSlots는 워크스페이스 하나의 실행 상태입니다.
+type workspaceSlots struct {
+	active int
+	// lastGrant는 마지막으로 슬롯을 받은 배정 순번입니다.
+	lastGrant uint64
+}
+
+// taskWaiter는 슬롯을 기다리는 요청 하나입니다.
+type taskWaiter struct {
+	// ready는 슬롯이 배정되면 닫힙니다.
+	ready     chan struct{}
+	granted   bool
+	workspace string
+}
+
+// acquire는 normal 우선순위로 실행 슬롯을 얻을 때까지 기다린 뒤 반환 함수를 돌려줍니다.
+func (l *taskLimiter) acquire(ctx context.Context) (release func(), err error) {
+	release, _, err = l.acquirePriority(ctx, ws.PriorityNormal)
+	return release, err
+}
+
+// acquirePriority는 워크스페이스 구분 없이 priority 대기열에서 실행 슬롯을 기다립니다.
+func (l *taskLimiter) acquirePriority(ctx context.Context, priority string) (release func(), wait time.Duration, err error) {
+	return l.acquireWorkspace(ctx, "", priority)
+}
+
+// acquireWorkspace는 workspace의 요청으로 priority 대기열에서 실행 슬롯을 기다립니다.
+// 반환 함수로 슬롯을 반환하며, wait는 슬롯을 얻기까지 대기한 시간입니다.
+func (l *taskLimiter) acquireWorkspace(ctx context.Context, workspace, priority string) (release func(), wait time.Duration, err error) {
+	start := time.Now()
+	rank := taskPriorityRank(priority)
+
+	l.mu.Lock()
+	if l.hasSlotLocked() && l.workspaceHasSlotLocked(workspace) {
+		l.takeSlotLocked(workspace)
+		l.mu.Unlock()
+		return l.releaseFunc(workspace), 0, nil
+	}
+	w := &taskWaiter{ready: make(chan struct{}), workspace: workspace}
+	l.waiting[rank] = append(l.waiting[rank], w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return l.releaseFunc(workspace), time.Since(start), nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		if w.granted {
+			// 취소와 배정이 겹쳤으면 받은 슬롯을 다음 요청에 넘긴다.
+			l.returnSlotLocked(workspace)
+			l.grantLocked()
+		} else {
+			l.removeWaiterLocked(rank, w)
+		}
+		l.mu.Unlock()
+		return nil, time.Since(start), ctx.Err()
+	}
+}
+
+// releaseFunc는 한 번만 동작하는 슬롯 반환 함수를 만듭니다.
+func (l *taskLimiter) releaseFunc(workspace string) func() {
+	var once sync.Once
+	return func() { once.Do(func() { l.release(workspace) }) }
+}
+
+// release는 실행 슬롯을 반환하고 대기 중인 다음 요청에 배정합니다.
+func (l *taskLimiter) release(workspace string) {
+	l.mu.Lock()
+	l.returnSlotLocked(workspace)
+	l.grantLocked()
+	l.mu.Unlock()
+}
+
+// setLimit은 동시 실행 한도를 바꿉니다. 한도를 늘리면 대기 중인 요청을 바로 실행합니다.
+func (l *taskLimiter) setLimit(limit int) {
+	l.mu.Lock()
+	l.limit = max(limit, 0)
+	l.grantLocked()
+	l.mu.Unlock()
+}
+
+// setWorkspaceLimits는 워크스페이스별 기본 한도와 재정의를 바꿉니다. 한도를 늘리면 대기 중인 요청을 바로 실행합니다.
+func (l *taskLimiter) setWorkspaceLimits(defaultLimit int, overrides map[string]int) {
+	l.mu.Lock()
+	l.workspaceLimit = max(defaultLimit, 0)
+	l.workspaceLimits = make(map[string]int, len(overrides))
+	for workspace, limit := range overrides {
+		l.workspaceLimits[workspace] = max(limit, 0)
+	}
+	l.grantLocked()
+	l.mu.Unlock()
+}
+
+// hasSlotLocked는 전체 한도에 빈 슬롯이 있는지 반환합니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) hasSlotLocked() bool {
+	return l.limit <= 0 || l.active < l.limit
+}
+
+// workspaceLimitLocked는 워크스페이스의 유효 한도를 반환합니다 (0 = 제한 없음). 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) workspaceLimitLocked(workspace string) int {
+	if limit, ok := l.workspaceLimits[workspace]; ok {
+		return limit
+	}
+	return l.workspaceLimit
+}
+
+// workspaceHasSlotLocked는 워크스페이스 한도에 빈 슬롯이 있는지 반환합니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) workspaceHasSlotLocked(workspace string) bool {
+	limit := l.workspaceLimitLocked(workspace)
+	return limit <= 0 || l.workspaceActiveLocked(workspace) < limit
+}
+
+// workspaceActiveLocked는 워크스페이스에서 실행 중인 요청 수를 반환합니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) workspaceActiveLocked(workspace string) int {
+	if slots := l.workspaces[workspace]; slots != nil {
+		return slots.active
+	}
+	return 0
+}
+
+// takeSlotLocked는 workspace에 슬롯 하나를 배정합니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) takeSlotLocked(workspace string) {
+	if l.workspaces == nil {
+		l.workspaces = make(map[string]*workspaceSlots)
+	}
+	slots := l.workspaces[workspace]
+	if slots == nil {
+		slots = &workspaceSlots{}
+		l.workspaces[workspace] = slots
+	}
+	l.grants++
+	slots.active++
+	slots.lastGrant = l.grants
+	l.active++
+}
+
+// returnSlotLocked는 workspace의 슬롯 하나를 반환합니다. 호출자가 mu를 보유해야 합니다.
+// 실행 중인 요청도 대기 중인 요청도 없는 워크스페이스는 상태를 지워 라운드 로빈 순서를 초기화합니다.
+func (l *taskLimiter) returnSlotLocked(workspace string) {
+	l.active--
+	slots := l.workspaces[workspace]
+	if slots == nil {
+		return
+	}
+	slots.active--
+	if slots.active <= 0 && !l.hasWaiterLocked(workspace) {
+		delete(l.workspaces, workspace)
+	}
+}
+
+// hasWaiterLocked는 workspace의 대기 중인 요청이 있는지 반환합니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) hasWaiterLocked(workspace string) bool {
+	for _, q := range l.waiting {
+		for _, w := range q {
+			if w.workspace == workspace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// grantLocked는 빈 슬롯을 대기 중인 요청에 배정합니다. 호출자가 mu를 보유해야 합니다.
+// 높은 순위부터, 같은 순위에서는 nextWaiterLocked가 고른 워크스페이스의 가장 오래된 요청부터 배정합니다.
+func (l *taskLimiter) grantLocked() {
+	for l.hasSlotLocked() {
+		rank, i := l.nextWaiterLocked()
+		if i < 0 {
+			return
+		}
+		w := l.waiting[rank][i]
+		l.waiting[rank] = append(l.waiting[rank][:i:i], l.waiting[rank][i+1:]...)
+		w.granted = true
+		l.takeSlotLocked(w.workspace)
+		close(w.ready)
+	}
+}
+
+// nextWaiterLocked는 다음에 실행할 대기 요청의 순위와 위치를 반환합니다. 없으면 i가 -1입니다.
+// 워크스페이스 한도에 걸린 요청은 건너뛰고, 가장 높은 순위 안에서 실행 중인 작업이 적은 워크스페이스,
+// 그다음 가장 오래전에 슬롯을 받은 워크스페이스, 그다음 먼저 도착한 요청을 고릅니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) nextWaiterLocked() (rank, i int) {
+	for rank = numTaskRanks - 1; rank >= 0; rank-- {
+		best := -1
+		var bestActive int
+		var bestGrant uint64
+		for j, w := range l.waiting[rank] {
+			if !l.workspaceHasSlotLocked(w.workspace) {
+				continue
+			}
+			active := l.workspaceActiveLocked(w.workspace)
+			var lastGrant uint64
+			if slots := l.workspaces[w.workspace]; slots != nil {
+				lastGrant = slots.lastGrant
+			}
+			if best < 0 || active < bestActive || (active == bestActive && lastGrant < bestGrant) {
+				best, bestActive, bestGrant = j, active, lastGrant
+			}
+		}
+		if best >= 0 {
+			return rank, best
+		}
+	}
+	return 0, -1
+}
+
+// removeWaiterLocked는 취소된 요청을 대기열에서 뺍니다. 호출자가 mu를 보유해야 합니다.
+func (l *taskLimiter) removeWaiterLocked(rank int, w *taskWaiter) {
+	q := l.waiting[rank]
+	for i, other := range q {
+		if other == w {
+			l.waiting[rank] = append(q[:i:i], q[i+1:]...)
+			break
+		}
+	}
+	if slots := l.workspaces[w.workspace]; slots != nil && slots.active <= 0 && !l.hasWaiterLocked(w.workspace) {
+		delete(l.workspaces, w.workspace)
+	}
+}
+
+// snapshot은 하트비트로 보고할 한도와 사용량을 반환합니다. 한도가 하나도 없으면 nil입니다.
+func (l *taskLimiter) snapshot() *ws.ConcurrencyStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 && l.workspaceLimit <= 0 && len(l.workspaceLimits) == 0 {
+		return nil
+	}
+	status := &ws.ConcurrencyStatus{Limit: l.limit, Active: l.active}
+	queued := make(map[string]int)
+	for _, q := range l.waiting {
+		status.Queued += len(q)
+		for _, w := range q {
+			queued[w.workspace]++
+		}
+	}
+
+	ids := make(map[string]struct{}, len(l.workspaces)+len(l.workspaceLimits))
+	for id := range l.workspaces {
+		ids[id] = struct{}{}
+	}
+	for id := range l.workspaceLimits {
+		ids[id] = struct{}{}
+	}
+	for _, id := range slices.Sorted(maps.Keys(ids)) {
+		status.Workspaces = append(status.Workspaces, ws.WorkspaceConcurrency{
+			WorkspaceID: id,
+			Limit:       l.workspaceLimitLocked(id),
+			Active:      l.workspaceActiveLocked(id),
+			Queued:      queued[id],
+		})
+	}
+	return status
+}
+
+// taskWorkspace는 요청의 워크스페이스 ID를 반환합니다. 비어 있으면 세션 워크스페이스로 간주합니다.
+func (r *Router) taskWorkspace(workspaceID string) string {
+	if workspaceID = strings.TrimSpace(workspaceID); workspaceID != "" || r.client == nil {
+		return workspaceID
+	}
+	return r.client.workspaceID
+}
+
+// acquireTaskSlot은 요청 우선순위의 대기열에서 실행 슬롯을 기다립니다. 대기했으면 대기 시간을 로그로 남깁니다.
+func (r *Router) acquireTaskSlot(ctx context.Context, kind, executionID, workspaceID, priority string) (release func(), wait time.Duration, err error) {
+	workspaceID = r.taskWorkspace(workspaceID)
+	release, wait, err = r.taskSlots.acquireWorkspace(ctx, workspaceID, priority)
+	if wait > 0 {
+		log.Printf("[%s] 실행 슬롯 대기: execution_id=%s workspace=%s priority=%s wait=%dms", kind, executionID, workspaceID, priority, wait.Milliseconds())
+	}
+	return release, wait, err
+}
+
+// sendQueueCancelled는 슬롯을 기다리던 중 취소된 빌드/테스트 요청의 에러를 전송합니다.
+// 리스가 회수되어 취소된 경우에는 다른 Bridge가 실행하므로 보내지 않습니다.
+func (r *Router) sendQueueCancelled(executionID, kind string, err error) {
+	if r.leaseRevoked(executionID, kind) {
+		return
+	}
+	_ = r.client.SendTaskError(ws.TaskErrorPayload{
+		ExecutionID: executionID,
+		Code:        errcode.Cancelled,
+		Message:     fmt.Sprintf("실행 슬롯 대기 중 취소되었습니다: %v", err),
+		Retryable:   true,
+	})
+}
@@ -0,0 +1,217 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitQueued는 대기열의 요청 수가 n이 될 때까지 기다립니다.
+func waitQueued(t *testing.T, l *taskLimiter, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		total := 0
+		for _, q := range l.waiting {
+			total += len(q)
+		}
+		return total == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestTaskPriorityRank(t *testing.T) {
+	assert.Equal(t, taskRankHigh, taskPriorityRank("HIGH"))
+	assert.Equal(t, taskRankLow, taskPriorityRank(ws.PriorityLow))
+	assert.Equal(t, taskRankNormal, taskPriorityRank(""))
+	assert.Equal(t, taskRankNormal, taskPriorityRank("urgent"), "알 수 없는 값은 normal")
+}
+
+// TestTaskLimiter_HighPriorityOvertakesQueuedWork는 대기 중인 low 요청보다 나중에 온 high 요청이 먼저 실행되는지 검증합니다.
+func TestTaskLimiter_HighPriorityOvertakesQueuedWork(t *testing.T) {
+	var l taskLimiter
+	l.setLimit(1)
+
+	running, err := l.acquire(context.Background())
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	requests := []struct{ name, priority string }{
+		{"batch-1", ws.PriorityLow},
+		{"default", ""},
+		{"batch-2", ws.PriorityLow},
+		{"interactive", ws.PriorityHigh},
+	}
+	for i, req := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, wait, err := l.acquirePriority(context.Background(), req.priority)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Positive(t, wait, "대기한 요청은 대기 시간을 보고해야 함")
+			mu.Lock()
+			order = append(order, req.name)
+			mu.Unlock()
+			release()
+		}()
+		// 도착 순서를 고정한다.
+		waitQueued(t, &l, i+1)
+	}
+
+	running()
+	wg.Wait()
+	assert.Equal(t, []string{"interactive", "default", "batch-1", "batch-2"}, order)
+}
+
+// TestTaskLimiter_CancelledWaiterLeavesQueue는 취소된 대기 요청이 슬롯을 차지하지 않는지 검증합니다.
+func TestTaskLimiter_CancelledWaiterLeavesQueue(t *testing.T) {
+	var l taskLimiter
+	l.setLimit(1)
+
+	running, err := l.acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, wait, err := l.acquirePriority(ctx, ws.PriorityHigh)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, wait, 30*time.Millisecond)
+	waitQueued(t, &l, 0)
+
+	running()
+	release, wait, err := l.acquirePriority(context.Background(), ws.PriorityLow)
+	require.NoError(t, err)
+	assert.Zero(t, wait, "빈 슬롯이 있으면 대기하지 않음")
+	release()
+
+	l.mu.Lock()
+	assert.Zero(t, l.active)
+	l.mu.Unlock()
+}
+
+// TestTaskLimiter_WorkspaceQuota는 한도에 걸린 워크스페이스의 요청이 다른 워크스페이스를 막지 않는지 검증합니다.
+func TestTaskLimiter_WorkspaceQuota(t *testing.T) {
+	var l taskLimiter
+	l.setLimit(3)
+	l.setWorkspaceLimits(1, map[string]int{"ws-big": 2})
+
+	runningA, _, err := l.acquireWorkspace(context.Background(), "ws-a", ws.PriorityNormal)
+	require.NoError(t, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, wait, err := l.acquireWorkspace(context.Background(), "ws-a", ws.PriorityHigh)
+		if assert.NoError(t, err) {
+			assert.Positive(t, wait)
+			close(acquired)
+			release()
+		}
+	}()
+	waitQueued(t, &l, 1)
+
+	// ws-a가 한도에 걸려 있어도 전체 슬롯이 남았으므로 다른 워크스페이스는 바로 실행된다.
+	releaseB, wait, err := l.acquireWorkspace(context.Background(), "ws-b", ws.PriorityLow)
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+
+	status := l.snapshot()
+	require.NotNil(t, status)
+	assert.Equal(t, 3, status.Limit)
+	assert.Equal(t, 2, status.Active)
+	assert.Equal(t, 1, status.Queued)
+	assert.Equal(t, []ws.WorkspaceConcurrency{
+		{WorkspaceID: "ws-a", Limit: 1, Active: 1, Queued: 1},
+		{WorkspaceID: "ws-b", Limit: 1, Active: 1},
+		{WorkspaceID: "ws-big", Limit: 2},
+	}, status.Workspaces)
+
+	runningA()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("ws-a 슬롯이 빈 뒤에도 대기 요청이 실행되지 않음")
+	}
+	releaseB()
+	waitQueued(t, &l, 0)
+
+	l.mu.Lock()
+	assert.Zero(t, l.active)
+	assert.Empty(t, l.workspaces, "실행도 대기도 없는 워크스페이스 상태는 지워져야 함")
+	l.mu.Unlock()
+}
+
+// TestTaskLimiter_WorkspaceRoundRobin은 같은 우선순위에서 한 워크스페이스가 먼저 몰아 보낸 요청이
+// 나중에 온 다른 워크스페이스의 요청을 굶기지 않는지 검증합니다.
+func TestTaskLimiter_WorkspaceRoundRobin(t *testing.T) {
+	var l taskLimiter
+	l.setLimit(1)
+
+	running, _, err := l.acquireWorkspace(context.Background(), "ws-a", ws.PriorityNormal)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	requests := []struct{ name, workspace string }{
+		{"a-1", "ws-a"},
+		{"a-2", "ws-a"},
+		{"a-3", "ws-a"},
+		{"b-1", "ws-b"},
+		{"c-1", "ws-c"},
+	}
+	for i, req := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, _, err := l.acquireWorkspace(context.Background(), req.workspace, ws.PriorityNormal)
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			order = append(order, req.name)
+			mu.Unlock()
+			release()
+		}()
+		waitQueued(t, &l, i+1)
+	}
+
+	running()
+	wg.Wait()
+	assert.Equal(t, []string{"b-1", "c-1", "a-1", "a-2", "a-3"}, order)
+}
+
+// TestTaskLimiter_SnapshotWithoutLimits는 한도가 없으면 하트비트에 동시 실행 정보를 싣지 않는지 검증합니다.
+func TestTaskLimiter_SnapshotWithoutLimits(t *testing.T) {
+	var l taskLimiter
+	assert.Nil(t, l.snapshot())
+}
+
+// TestHeartbeatPayload_Concurrency는 라우터의 워크스페이스 한도와 사용량이 하트비트에 실리는지 검증합니다.
+func TestHeartbeatPayload_Concurrency(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0", WithWorkspaceID("ws-session"))
+	router := NewRouter(client)
+	router.SetMaxConcurrentTasks(4)
+	router.SetWorkspaceConcurrency(2, nil)
+
+	// 워크스페이스가 없는 요청은 세션 워크스페이스 몫으로 센다.
+	release, _, err := router.acquireTaskSlot(context.Background(), "task-request", "exec-1", "", ws.PriorityNormal)
+	require.NoError(t, err)
+	defer release()
+
+	status := client.buildHeartbeatPayload().Concurrency
+	require.NotNil(t, status)
+	assert.Equal(t, 4, status.Limit)
+	assert.Equal(t, 1, status.Active)
+	assert.Equal(t, []ws.WorkspaceConcurrency{
+		{WorkspaceID: "ws-session", Limit: 2, Active: 1},
+	}, status.Workspaces)
+}
@@ -0,0 +1,116 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// Bridge 재시작으로 중단된 작업의 체크포인트 재개를 서버와 조율합니다.
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+)
+
+// TaskCheckpointer는 작업 체크포인트를 저장하는 TaskExecutor가 구현하는 선택적 인터페이스입니다.
+type TaskCheckpointer interface {
+	// PendingCheckpoints는 실행 중이지 않은(이전 프로세스에서 중단된) 체크포인트를 반환합니다.
+	PendingCheckpoints() []ws.TaskResumePayload
+	// CheckpointedTask는 체크포인트에서 재개할 작업 요청을 반환합니다.
+	CheckpointedTask(executionID string) (ws.TaskRequestPayload, bool)
+	// DiscardCheckpoint는 체크포인트를 삭제합니다.
+	DiscardCheckpoint(executionID string) error
+}
+
+// checkpointer는 작업 실행기가 체크포인트를 지원하면 반환합니다.
+func (r *Router) checkpointer() (TaskCheckpointer, bool) {
+	if r.executor == nil {
+		return nil, false
+	}
+	cp, ok := r.executor.(TaskCheckpointer)
+	return cp, ok
+}
+
+// SendTaskResumeOffers는 남아 있는 체크포인트마다 task_resume offer를 전송합니다.
+// 연결 직후와 재연결 후 호출되며, 서버는 resume 또는 discard로 응답합니다.
+func (r *Router) SendTaskResumeOffers() {
+	cp, ok := r.checkpointer()
+	if !ok {
+		return
+	}
+	for _, offer := range cp.PendingCheckpoints() {
+		if r.client.TaskTracker().IsActive(offer.ExecutionID) {
+			continue
+		}
+		if err := r.client.sendMessage(ws.AgentMsgTaskResume, offer); err != nil {
+			log.Printf("[task-resume] offer 전송 실패: execution_id=%s err=%v", offer.ExecutionID, err)
+			continue
+		}
+		log.Printf("[task-resume] 재개 가능한 작업 알림: execution_id=%s step=%d", offer.ExecutionID, offer.StepIndex)
+	}
+}
+
+// handleTaskResume은 서버의 task_resume 지시를 처리합니다.
+// resume이면 체크포인트의 작업을 재개 실행하고, discard이면 체크포인트를 삭제합니다.
+func (r *Router) handleTaskResume(ctx context.Context, msg ws.AgentMessage) error {
+	var payload ws.TaskResumePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("task_resume 페이로드 파싱 실패: %w", err)
+	}
+
+	cp, ok := r.checkpointer()
+	if !ok {
+		log.Printf("[task-resume] 체크포인트 미지원 실행기, 무시: execution_id=%s", payload.ExecutionID)
+		return nil
+	}
+
+	switch payload.Action {
+	case ws.TaskResumeActionDiscard:
+		r.discardCheckpoint(payload.ExecutionID)
+		return nil
+	case ws.TaskResumeActionResume:
+	default:
+		log.Printf("[task-resume] 알 수 없는 action 무시: execution_id=%s action=%s", payload.ExecutionID, payload.Action)
+		return nil
+	}
+
+	task, found := cp.CheckpointedTask(payload.ExecutionID)
+	if !found {
+		// 체크포인트가 없으면 재개할 수 없으므로 서버가 재할당하도록 재시도 가능한 에러로 보고한다.
+		return r.getTaskSender().SendTaskError(ws.TaskErrorPayload{
+			ExecutionID: payload.ExecutionID,
+			Code:        errcode.CheckpointNotFound,
+			Message:     "재개할 작업 체크포인트가 없습니다",
+			Retryable:   true,
+		})
+	}
+
+	if r.IsDraining() {
+		return r.rejectWhileDraining(task.ExecutionID, "task")
+	}
+	if reason, paused := r.IntakePaused(); paused {
+		return r.rejectWhilePaused(task.ExecutionID, "task", reason)
+	}
+	if r.readOnly {
+		return r.rejectWhileReadOnly(task.ExecutionID, "task")
+	}
+	if !r.client.TaskTracker().TryTrack(task.ExecutionID, "task") {
+		log.Printf("[task-resume] 이미 실행 중인 작업의 재개 요청 무시: execution_id=%s", task.ExecutionID)
+		return nil
+	}
+
+	log.Printf("[task-resume] 체크포인트에서 작업 재개: execution_id=%s", task.ExecutionID)
+	go r.executeTask(r.leaseContext(ctx, task.ExecutionID), task)
+	return nil
+}
+
+// discardCheckpoint는 작업 실행기가 체크포인트를 지원하면 해당 체크포인트를 삭제합니다.
+func (r *Router) discardCheckpoint(executionID string) {
+	cp, ok := r.checkpointer()
+	if !ok {
+		return
+	}
+	if err := cp.DiscardCheckpoint(executionID); err != nil {
+		log.Printf("[task-resume] 체크포인트 삭제 실패: execution_id=%s err=%v", executionID, err)
+	}
+}
@@ -0,0 +1,156 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkpointingTaskExecutor는 체크포인트를 메모리에 보관하는 테스트용 TaskExecutor입니다.
+type checkpointingTaskExecutor struct {
+	mu          sync.Mutex
+	checkpoints map[string]ws.TaskRequestPayload
+	executed    chan ws.TaskRequestPayload
+	discarded   []string
+}
+
+func newCheckpointingTaskExecutor(ids ...string) *checkpointingTaskExecutor {
+	e := &checkpointingTaskExecutor{
+		checkpoints: make(map[string]ws.TaskRequestPayload),
+		executed:    make(chan ws.TaskRequestPayload, 1),
+	}
+	for _, id := range ids {
+		e.checkpoints[id] = ws.TaskRequestPayload{ExecutionID: id, Prompt: "prompt " + id}
+	}
+	return e
+}
+
+func (e *checkpointingTaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload) (ws.TaskResultPayload, error) {
+	e.executed <- task
+	return ws.TaskResultPayload{ExecutionID: task.ExecutionID, Output: "resumed"}, nil
+}
+
+func (e *checkpointingTaskExecutor) ExecuteAgentResponse(ctx context.Context, req ws.AgentResponseRequestPayload) (ws.AgentResponseCompletePayload, error) {
+	return ws.AgentResponseCompletePayload{}, nil
+}
+
+func (e *checkpointingTaskExecutor) PendingCheckpoints() []ws.TaskResumePayload {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var offers []ws.TaskResumePayload
+	for id := range e.checkpoints {
+		offers = append(offers, ws.TaskResumePayload{ExecutionID: id, Action: ws.TaskResumeActionOffer, StepIndex: 2})
+	}
+	return offers
+}
+
+func (e *checkpointingTaskExecutor) CheckpointedTask(executionID string) (ws.TaskRequestPayload, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	task, ok := e.checkpoints[executionID]
+	task.ResumeFromCheckpoint = ok
+	return task, ok
+}
+
+func (e *checkpointingTaskExecutor) DiscardCheckpoint(executionID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.checkpoints, executionID)
+	e.discarded = append(e.discarded, executionID)
+	return nil
+}
+
+func taskResumeMessage(t *testing.T, payload ws.TaskResumePayload) ws.AgentMessage {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return ws.AgentMessage{Type: ws.AgentMsgTaskResume, ID: "msg-resume", Payload: data}
+}
+
+func TestSendTaskResumeOffers(t *testing.T) {
+	srv := newTestCapabilityServer(t)
+	defer srv.Close()
+	client := newConnectedClient(t, srv.URL)
+	defer client.Disconnect("test")
+
+	executor := newCheckpointingTaskExecutor("exec-1", "exec-running")
+	router := NewRouter(client, WithTaskExecutor(executor))
+	client.TaskTracker().Track("exec-running", "task")
+
+	router.SendTaskResumeOffers()
+
+	select {
+	case msg := <-srv.received:
+		require.Equal(t, ws.AgentMsgTaskResume, msg.Type)
+		var offer ws.TaskResumePayload
+		require.NoError(t, json.Unmarshal(msg.Payload, &offer))
+		assert.Equal(t, "exec-1", offer.ExecutionID)
+		assert.Equal(t, ws.TaskResumeActionOffer, offer.Action)
+		assert.Equal(t, 2, offer.StepIndex)
+	case <-time.After(3 * time.Second):
+		t.Fatal("task_resume offer 수신 타임아웃")
+	}
+
+	select {
+	case msg := <-srv.received:
+		t.Fatalf("실행 중인 작업은 offer하지 않아야 함: %s", msg.Payload)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHandleTaskResume_Resume(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	executor := newCheckpointingTaskExecutor("exec-1")
+	taskSender := &stubTaskMessageSender{}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(taskSender))
+
+	require.NoError(t, router.HandleMessage(context.Background(), taskResumeMessage(t, ws.TaskResumePayload{
+		ExecutionID: "exec-1",
+		Action:      ws.TaskResumeActionResume,
+	})))
+
+	select {
+	case task := <-executor.executed:
+		assert.Equal(t, "exec-1", task.ExecutionID)
+		assert.Equal(t, "prompt exec-1", task.Prompt)
+		assert.True(t, task.ResumeFromCheckpoint)
+	case <-time.After(2 * time.Second):
+		t.Fatal("재개 작업이 실행되지 않음")
+	}
+
+	assert.Eventually(t, func() bool {
+		taskSender.mu.Lock()
+		defer taskSender.mu.Unlock()
+		return len(taskSender.results) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestHandleTaskResume_DiscardAndMissing(t *testing.T) {
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	executor := newCheckpointingTaskExecutor("exec-1")
+	taskSender := &stubTaskMessageSender{}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(taskSender))
+
+	require.NoError(t, router.HandleMessage(context.Background(), taskResumeMessage(t, ws.TaskResumePayload{
+		ExecutionID: "exec-1",
+		Action:      ws.TaskResumeActionDiscard,
+	})))
+	assert.Equal(t, []string{"exec-1"}, executor.discarded)
+
+	require.NoError(t, router.HandleMessage(context.Background(), taskResumeMessage(t, ws.TaskResumePayload{
+		ExecutionID: "exec-1",
+		Action:      ws.TaskResumeActionResume,
+	})))
+	taskSender.mu.Lock()
+	defer taskSender.mu.Unlock()
+	require.Len(t, taskSender.errors, 1)
+	assert.Equal(t, "CHECKPOINT_NOT_FOUND", taskSender.errors[0].Code)
+	assert.True(t, taskSender.errors[0].Retryable)
+	assert.Empty(t, executor.executed)
+}
@@ -3,6 +3,7 @@
 package websocket
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -15,6 +16,12 @@ type TrackedTask struct {
 	TaskType string
 	// StartedAt은 작업 시작 시각입니다.
 	StartedAt time.Time
+	// Revoked는 서버가 작업 리스를 회수했는지 여부입니다.
+	// 회수된 작업은 취소되며 결과를 서버에 보고하지 않습니다.
+	Revoked bool
+
+	// cancel은 작업 실행 컨텍스트를 취소하는 함수입니다 (BindCancel로 등록).
+	cancel context.CancelFunc
 }
 
 // TaskTracker는 진행 중인 태스크를 추적하여 재연결 시 멱등적 재실행을 지원합니다 (FR-P2-04).
@@ -47,13 +54,77 @@ func (t *TaskTracker) Track(executionID, taskType string) {
 	}
 }
 
-// Complete은 작업을 완료 처리하고 활성 목록에서 제거합니다.
-// 작업 실행 완료(성공/실패 무관) 시 호출합니다.
-func (t *TaskTracker) Complete(executionID string) {
+// TryTrack은 작업이 활성 목록에 없을 때만 등록하고 등록 여부를 반환합니다.
+// 같은 실행 ID의 중복 요청이 동시에 실행되지 않도록 할 때 사용합니다.
+func (t *TaskTracker) TryTrack(executionID, taskType string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.activeTasks[executionID]; exists {
+		return false
+	}
+	t.activeTasks[executionID] = &TrackedTask{
+		ExecutionID: executionID,
+		TaskType:    taskType,
+		StartedAt:   time.Now(),
+	}
+	return true
+}
+
+// BindCancel은 활성 작업에 실행 컨텍스트 취소 함수를 연결합니다.
+// 서버가 리스를 회수하면 Revoke가 이 함수를 호출해 로컬 실행을 중단합니다.
+// 작업이 활성 목록에 없으면 false를 반환합니다.
+func (t *TaskTracker) BindCancel(executionID string, cancel context.CancelFunc) bool {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	task, exists := t.activeTasks[executionID]
+	if !exists {
+		return false
+	}
+	task.cancel = cancel
+	return true
+}
+
+// Revoke는 서버의 리스 회수에 따라 작업을 회수 상태로 표시하고 실행 컨텍스트를 취소합니다.
+// 작업은 실행 고루틴이 Complete를 호출할 때까지 활성 목록에 남아 있습니다.
+// 작업이 활성 목록에 없으면 false를 반환합니다.
+func (t *TaskTracker) Revoke(executionID string) bool {
+	t.mu.Lock()
+	task, exists := t.activeTasks[executionID]
+	var cancel context.CancelFunc
+	if exists {
+		task.Revoked = true
+		cancel = task.cancel
+	}
+	t.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return exists
+}
+
+// IsRevoked는 해당 실행 ID의 리스가 회수되었는지 확인합니다.
+func (t *TaskTracker) IsRevoked(executionID string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	task, exists := t.activeTasks[executionID]
+	return exists && task.Revoked
+}
+
+// Complete은 작업을 완료 처리하고 활성 목록에서 제거합니다.
+// 작업 실행 완료(성공/실패 무관) 시 호출하며, 연결된 실행 컨텍스트도 해제합니다.
+func (t *TaskTracker) Complete(executionID string) {
+	t.mu.Lock()
+	task, exists := t.activeTasks[executionID]
 	delete(t.activeTasks, executionID)
+	t.mu.Unlock()
+
+	if exists && task.cancel != nil {
+		task.cancel()
+	}
 }
 
 // GetActiveTasks는 미완료 작업의 실행 ID 목록을 반환합니다.
@@ -69,6 +140,19 @@ func (t *TaskTracker) GetActiveTasks() []string {
 	return ids
 }
 
+// GetActiveTaskInfos는 미완료 작업 정보의 복사본 목록을 반환합니다.
+// 종료 드레이닝 시 작업 유형별 중단 보고에 사용됩니다.
+func (t *TaskTracker) GetActiveTaskInfos() []TrackedTask {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := make([]TrackedTask, 0, len(t.activeTasks))
+	for _, task := range t.activeTasks {
+		tasks = append(tasks, *task)
+	}
+	return tasks
+}
+
 // GetActiveTaskCount는 활성 작업 수를 반환합니다.
 func (t *TaskTracker) GetActiveTaskCount() int {
 	t.mu.RLock()
@@ -139,6 +139,22 @@ func TestTaskTracker_DuplicateTrack(t *testing.T) {
 	}
 }
 
+func TestTaskTracker_TryTrack(t *testing.T) {
+	tracker := NewTaskTracker()
+
+	if !tracker.TryTrack("exec-001", "task") {
+		t.Fatal("처음 등록하는 작업은 TryTrack이 true를 반환해야 합니다")
+	}
+	if tracker.TryTrack("exec-001", "task") {
+		t.Error("이미 활성인 작업은 TryTrack이 false를 반환해야 합니다")
+	}
+
+	tracker.Complete("exec-001")
+	if !tracker.TryTrack("exec-001", "task") {
+		t.Error("완료된 작업은 다시 등록할 수 있어야 합니다")
+	}
+}
+
 func TestTaskTracker_ConcurrentAccess(t *testing.T) {
 	tracker := NewTaskTracker()
 
@@ -0,0 +1,46 @@
+// Package websocket - 메시지 타입별 실행 타임아웃 계층
+package websocket
+
+import (
+	"context"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+)
+
+// WithTimeoutPolicy는 task/agent_response/build/test/QA/CLI 요청의 타임아웃 계층을 설정합니다.
+// 요청의 timeout 값, 메시지 타입별 값, 전역 기본값 순으로 적용합니다.
+func WithTimeoutPolicy(policy *timeouts.Policy) RouterOption {
+	return func(r *Router) {
+		r.timeouts = policy
+	}
+}
+
+// withMessageTimeout은 msgType 요청에 타임아웃 계층을 적용한 context를 반환합니다.
+// 실행기가 같은 값을 쓰도록 적용한 타임아웃을 초 단위로 함께 반환합니다. 정책도 요청 값도 없으면
+// 타임아웃 없이 0을 반환하며, 실행기가 자체 기본값을 사용합니다.
+// 슬롯 대기 시간이 포함되지 않도록 실행 슬롯을 얻은 뒤에 호출합니다.
+func (r *Router) withMessageTimeout(ctx context.Context, msgType string, requestedSeconds int) (context.Context, context.CancelFunc, int) {
+	resolved := r.timeouts.Resolve(msgType, time.Duration(requestedSeconds)*time.Second, 0)
+	ctx, cancel := timeouts.WithTimeout(ctx, resolved)
+	return ctx, cancel, resolved.Seconds()
+}
+
+// timeoutDetailError는 타임아웃 에러의 만료 단계를 노출하는 에러 인터페이스입니다.
+// executor.TaskError와 호환됩니다.
+type timeoutDetailError interface {
+	TimeoutDetail() *ws.TimeoutDetail
+}
+
+// timeoutDetail은 실행이 타임아웃으로 끝났으면 만료된 타임아웃과 그 출처를 반환합니다.
+// ctx의 만료 원인을 먼저 보고, 없으면 실행기 에러가 보고한 값을 사용합니다.
+func timeoutDetail(ctx context.Context, err error) *ws.TimeoutDetail {
+	if te, ok := timeouts.FromContext(ctx); ok {
+		return te.Detail()
+	}
+	if de, ok := err.(timeoutDetailError); ok {
+		return de.TimeoutDetail()
+	}
+	return nil
+}
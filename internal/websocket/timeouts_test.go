@@ -0,0 +1,84 @@
+// Package websocket - 실행 타임아웃 계층 테스트
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/insajin/autopus-bridge/internal/errcode"
+	"github.com/insajin/autopus-bridge/internal/timeouts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deadlineTaskExecutor는 context가 끝날 때까지 기다렸다가 context 에러를 반환하는 테스트용 실행기입니다.
+type deadlineTaskExecutor struct {
+	stubTaskExecutor
+	timeout int
+}
+
+func (e *deadlineTaskExecutor) Execute(ctx context.Context, task ws.TaskRequestPayload) (ws.TaskResultPayload, error) {
+	e.timeout = task.Timeout
+	<-ctx.Done()
+	return ws.TaskResultPayload{}, ctx.Err()
+}
+
+// TestExecuteTask_MessageTypeTimeout은 메시지 타입별 타임아웃이 만료되면 TIMEOUT 코드와 출처를 보고하는지 검증합니다.
+func TestExecuteTask_MessageTypeTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	executor := &deadlineTaskExecutor{}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(sender),
+		WithTimeoutPolicy(&timeouts.Policy{PerKind: map[string]time.Duration{ws.AgentMsgTaskReq: 50 * time.Millisecond}}))
+
+	router.executeTask(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-timeout"})
+
+	assert.Equal(t, 1, executor.timeout, "적용한 타임아웃을 요청에 실어 실행기에 전달해야 함")
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, errcode.Timeout, errs[0].Code)
+	require.NotNil(t, errs[0].Timeout)
+	assert.Equal(t, ws.AgentMsgTaskReq, errs[0].Timeout.Kind)
+	assert.Equal(t, ws.TimeoutSourceMessageType, errs[0].Timeout.Source)
+	assert.True(t, errs[0].Retryable)
+}
+
+// TestExecuteTask_RequestTimeoutCapped는 요청에 지정된 타임아웃이 정책 상한으로 제한되는지 검증합니다.
+func TestExecuteTask_RequestTimeoutCapped(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	executor := &deadlineTaskExecutor{}
+	router := NewRouter(client, WithTaskExecutor(executor), WithTaskMessageSender(sender),
+		WithTimeoutPolicy(&timeouts.Policy{Max: 20 * time.Millisecond}))
+
+	router.executeTask(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-capped", Timeout: 3600})
+
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	require.NotNil(t, errs[0].Timeout)
+	assert.Equal(t, ws.TimeoutSourceRequest, errs[0].Timeout.Source)
+	assert.Equal(t, 1, errs[0].Timeout.TimeoutSeconds)
+}
+
+// TestExecuteTask_NonTimeoutErrorHasNoDetail은 타임아웃이 아닌 실패에는 타임아웃 정보를 붙이지 않는지 검증합니다.
+func TestExecuteTask_NonTimeoutErrorHasNoDetail(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	sender := &recordingTaskSender{}
+	router := NewRouter(client, WithTaskExecutor(&stubTaskExecutor{err: context.Canceled}), WithTaskMessageSender(sender),
+		WithTimeoutPolicy(&timeouts.Policy{Default: time.Minute}))
+
+	router.executeTask(context.Background(), ws.TaskRequestPayload{ExecutionID: "exec-failed"})
+
+	errs := sender.taskErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, errcode.ExecutionError, errs[0].Code)
+	assert.Nil(t, errs[0].Timeout)
+}
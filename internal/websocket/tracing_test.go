@@ -0,0 +1,54 @@
+package websocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestRouter_HandleMessage_ContinuesTraceFromMetadata는 메시지 metadata의 traceparent를
+// 이어받아 핸들러 컨텍스트에 같은 트레이스의 스팬이 설정되는지 검증합니다.
+func TestRouter_HandleMessage_ContinuesTraceFromMetadata(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(prev)
+
+	client := NewClient("ws://localhost:9999/ws", "test-token", "1.0.0")
+	router := NewRouter(client, WithErrorHandler(func(err error) {}))
+
+	var handlerSpan trace.SpanContext
+	router.RegisterHandler("trace_test", func(ctx context.Context, msg ws.AgentMessage) error {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	msg := ws.AgentMessage{
+		Type:      "trace_test",
+		ID:        "msg-trace-001",
+		Timestamp: time.Now(),
+		Metadata:  map[string]string{"traceparent": "00-" + traceID + "-00f067aa0ba902b7-01"},
+	}
+	if err := router.HandleMessage(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMessage() = %v", err)
+	}
+
+	if got := handlerSpan.TraceID().String(); got != traceID {
+		t.Errorf("handler trace id = %s; want %s", got, traceID)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "ws.handle trace_test" {
+		t.Fatalf("ended spans = %d; want one ws.handle span", len(spans))
+	}
+	if got := spans[0].Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span id = %s; want remote parent from metadata", got)
+	}
+}
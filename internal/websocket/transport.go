@@ -0,0 +1,206 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// WebSocket이 차단된 네트워크를 위한 전송 계층 추상화와 자동 폴백 협상.
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 전송 방식 이름입니다. server.transport 설정 값과 같습니다.
+const (
+	// TransportAuto는 WebSocket을 먼저 시도하고 차단되면 HTTP 롱폴링으로 전환합니다.
+	TransportAuto = "auto"
+	// TransportWebSocket은 WebSocket만 사용합니다.
+	TransportWebSocket = "websocket"
+	// TransportHTTP는 WebSocket을 시도하지 않고 HTTP 롱폴링/SSE만 사용합니다.
+	TransportHTTP = "http"
+)
+
+// Transport는 브리지와 서버 사이에서 메시지 프레임을 주고받는 전송 계층입니다.
+// Client는 이 인터페이스만 사용하므로 agent_connect 인증, 하트비트, HMAC 서명,
+// 우선순위 송신 큐 등 메시지 의미는 전송 방식과 관계없이 같습니다.
+type Transport interface {
+	// Name은 전송 방식 이름입니다 (TransportWebSocket 또는 TransportHTTP).
+	Name() string
+	// ReadMessage는 다음 메시지를 받을 때까지 대기합니다.
+	// 에러를 반환한 전송 계층은 다시 사용할 수 없습니다.
+	ReadMessage() ([]byte, error)
+	// WriteMessage는 메시지 하나를 전송합니다. 동시 호출은 Client가 직렬화합니다.
+	WriteMessage(data []byte) error
+	// SetReadDeadline은 ReadMessage의 대기 기한을 설정합니다. 0이면 기한이 없습니다.
+	SetReadDeadline(t time.Time) error
+	// Ping은 연결이 살아 있는지 확인합니다.
+	Ping() error
+	// Close는 서버에 종료를 알리고 연결을 닫습니다.
+	Close() error
+}
+
+// WithTransport는 전송 방식을 설정합니다 (TransportAuto, TransportWebSocket, TransportHTTP).
+// 알 수 없는 값이면 TransportAuto로 동작합니다.
+func WithTransport(mode string) ClientOption {
+	return func(c *Client) {
+		c.transportMode = mode
+	}
+}
+
+// WithHTTPFallbackURL은 HTTP 롱폴링 엔드포인트 주소를 설정합니다.
+// 비어 있으면 서버 URL의 스킴을 http(s)로 바꾸고 경로 끝에 "/http"를 붙인 주소를 사용합니다.
+func WithHTTPFallbackURL(rawURL string) ClientOption {
+	return func(c *Client) {
+		c.httpFallbackURL = strings.TrimSpace(rawURL)
+	}
+}
+
+// TransportName은 현재 연결의 전송 방식 이름을 반환합니다. 연결이 없으면 빈 문자열입니다.
+func (c *Client) TransportName() string {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	if c.conn == nil {
+		return ""
+	}
+	return c.conn.Name()
+}
+
+// dialTransport는 설정된 전송 방식으로 서버에 연결합니다.
+// auto이면 WebSocket을 먼저 시도하고, 프록시가 업그레이드를 거부하는 등
+// 차단으로 판단되는 에러일 때만 HTTP 롱폴링으로 전환합니다.
+func (c *Client) dialTransport(ctx context.Context) (Transport, error) {
+	if c.transportMode == TransportHTTP {
+		t, err := dialHTTPTransport(ctx, c.httpClient, c.httpFallbackBaseURL())
+		if err != nil {
+			return nil, fmt.Errorf("HTTP 폴백 연결 실패: %w", err)
+		}
+		return t, nil
+	}
+
+	t, wsErr := c.dialWebSocket(ctx)
+	if wsErr == nil {
+		return t, nil
+	}
+	if c.transportMode == TransportWebSocket || !isWebSocketBlocked(wsErr) {
+		return nil, fmt.Errorf("WebSocket 연결 실패: %w", wsErr)
+	}
+
+	log.Printf("[STABILITY] WebSocket 연결이 차단되어 HTTP 롱폴링으로 전환합니다: %v", wsErr)
+	ht, err := dialHTTPTransport(ctx, c.httpClient, c.httpFallbackBaseURL())
+	if err != nil {
+		return nil, fmt.Errorf("WebSocket 연결 실패: %w (HTTP 폴백 실패: %v)", wsErr, err)
+	}
+	return ht, nil
+}
+
+// dialWebSocket은 WebSocket 서버에 연결합니다.
+// FR-P2-02: 토큰을 URL에 포함하지 않음
+func (c *Client) dialWebSocket(ctx context.Context) (Transport, error) {
+	u, err := url.Parse(c.serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("서버 URL 파싱 실패: %w", err)
+	}
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout:  ConnectTimeout,
+		EnableCompression: c.enableCompression,
+	}
+
+	conn, _, err := dialer.DialContext(ctx, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 연결 설정
+	conn.SetReadLimit(MaxMessageSize)
+	conn.EnableWriteCompression(c.enableCompression)
+
+	// 서버 PING 메시지 처리 - PONG 응답 전송 및 연결 활성 상태 유지
+	conn.SetPingHandler(func(appData string) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(WriteTimeout))
+	})
+
+	return &wsTransport{conn: conn}, nil
+}
+
+// httpFallbackBaseURL은 HTTP 롱폴링 엔드포인트의 기준 주소를 반환합니다.
+// 예: wss://api.autopus.co/ws/agent -> https://api.autopus.co/ws/agent/http
+func (c *Client) httpFallbackBaseURL() string {
+	if c.httpFallbackURL != "" {
+		return strings.TrimRight(c.httpFallbackURL, "/")
+	}
+	u, err := url.Parse(c.serverURL)
+	if err != nil {
+		return strings.TrimRight(wsToHTTPURL(c.serverURL), "/") + "/http"
+	}
+	switch u.Scheme {
+	case "wss":
+		u.Scheme = "https"
+	case "ws":
+		u.Scheme = "http"
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Path = strings.TrimRight(u.Path, "/") + "/http"
+	return u.String()
+}
+
+// isWebSocketBlocked는 WebSocket 연결 실패가 네트워크의 WebSocket 차단 때문인지 판단합니다.
+// 서버(또는 프록시)가 HTTP로 응답했지만 업그레이드를 거부했거나 업그레이드 도중 연결을 끊은 경우만 해당합니다.
+// DNS 실패나 연결 거부처럼 HTTP로도 닿을 수 없는 에러는 폴백하지 않습니다.
+func isWebSocketBlocked(err error) bool {
+	return errors.Is(err, websocket.ErrBadHandshake) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// wsTransport는 gorilla/websocket 연결을 Transport로 감쌉니다.
+type wsTransport struct {
+	conn *websocket.Conn
+}
+
+// Name은 전송 방식 이름을 반환합니다.
+func (t *wsTransport) Name() string { return TransportWebSocket }
+
+// ReadMessage는 다음 메시지를 읽습니다.
+// gorilla/websocket은 ReadMessage() 에러 후 같은 연결에서 다시 읽으면 panic하므로 에러 후에는 재사용하지 않습니다.
+func (t *wsTransport) ReadMessage() ([]byte, error) {
+	_, data, err := t.conn.ReadMessage()
+	return data, err
+}
+
+// WriteMessage는 텍스트 메시지를 전송합니다.
+func (t *wsTransport) WriteMessage(data []byte) error {
+	_ = t.conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+	return t.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// SetReadDeadline은 읽기 기한을 설정합니다.
+func (t *wsTransport) SetReadDeadline(deadline time.Time) error {
+	return t.conn.SetReadDeadline(deadline)
+}
+
+// Ping은 WebSocket ping 제어 프레임을 전송합니다.
+func (t *wsTransport) Ping() error {
+	return t.conn.WriteControl(websocket.PingMessage, []byte("ping"), time.Now().Add(PingTimeout))
+}
+
+// Close는 정상 종료 프레임을 보낸 뒤 연결을 닫습니다.
+func (t *wsTransport) Close() error {
+	_ = t.conn.WriteMessage(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+	)
+	return t.conn.Close()
+}
+
+// defaultHTTPTransportClient는 HTTP 롱폴링용 기본 클라이언트입니다.
+// 폴링 요청은 서버가 응답을 보류하므로 전체 타임아웃 대신 요청별 컨텍스트로 제한합니다.
+var defaultHTTPTransportClient = &http.Client{}
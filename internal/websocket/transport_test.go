@@ -0,0 +1,279 @@
+// Package websocket - HTTP 롱폴링 폴백 전송 계층 테스트
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// longPollServer는 WebSocket 업그레이드를 거부하는 프록시 뒤의 HTTP 롱폴링 서버를 흉내냅니다.
+// agent_connect를 받으면 agent_connect_ack를 폴링 응답으로 돌려주고, 그 외 메시지 타입은 received로 전달합니다.
+type longPollServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	sessions int
+	closed   int
+	pending  chan []byte
+	received chan string
+	// eventStream이 true이면 폴링 응답을 text/event-stream으로 보냅니다.
+	eventStream bool
+}
+
+func newLongPollServer(t *testing.T, eventStream bool) *longPollServer {
+	t.Helper()
+	s := &longPollServer{
+		eventStream: eventStream,
+		pending:     make(chan []byte, 16),
+		received:    make(chan string, 16),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "websocket blocked by proxy", http.StatusForbidden)
+	})
+	mux.HandleFunc("/ws/http/session", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.Method {
+		case http.MethodPost:
+			s.sessions++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{"session_id": fmt.Sprintf("sess-%d", s.sessions)})
+		case http.MethodDelete:
+			s.closed++
+		}
+	})
+	mux.HandleFunc("/ws/http/send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(httpSessionHeader) == "" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		var msg ws.AgentMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if msg.Type == ws.AgentMsgConnect {
+			ackPayload, _ := json.Marshal(ws.ConnectAckPayload{Success: true, ProtocolVersion: ws.AgentProtocolVersion})
+			s.push(ws.AgentMessage{Type: ws.AgentMsgConnectAck, Payload: ackPayload})
+		} else {
+			s.received <- msg.Type
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/ws/http/poll", func(w http.ResponseWriter, r *http.Request) {
+		var batch []json.RawMessage
+		select {
+		case data := <-s.pending:
+			batch = append(batch, data)
+		case <-time.After(200 * time.Millisecond):
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case <-r.Context().Done():
+			return
+		}
+		for more := true; more; {
+			select {
+			case data := <-s.pending:
+				batch = append(batch, data)
+			default:
+				more = false
+			}
+		}
+
+		if s.eventStream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, data := range batch {
+				fmt.Fprintf(w, ": keep-alive\nevent: message\ndata: %s\n\n", data)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(batch)
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// push는 다음 폴링 응답으로 보낼 메시지를 추가합니다.
+func (s *longPollServer) push(msg ws.AgentMessage) {
+	data, _ := json.Marshal(msg)
+	s.pending <- data
+}
+
+// wsURL은 서버의 WebSocket 주소를 반환합니다.
+func (s *longPollServer) wsURL() string {
+	return "ws" + s.URL[len("http"):] + "/ws"
+}
+
+func (s *longPollServer) counts() (sessions, closed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions, s.closed
+}
+
+// TestConnect_FallsBackToHTTPWhenWebSocketBlocked는 업그레이드가 거부되면 같은 메시지 의미로 HTTP 롱폴링에 연결하는지 검증합니다.
+func TestConnect_FallsBackToHTTPWhenWebSocketBlocked(t *testing.T) {
+	for _, eventStream := range []bool{false, true} {
+		t.Run(fmt.Sprintf("event_stream=%v", eventStream), func(t *testing.T) {
+			srv := newLongPollServer(t, eventStream)
+
+			client := NewClient(srv.wsURL(), "test-token", "1.0.0")
+			require.NoError(t, client.Connect(testContext(t)))
+			assert.Equal(t, StateConnected, client.State())
+			assert.Equal(t, TransportHTTP, client.TransportName())
+
+			// 송신: 우선순위 큐를 거쳐 POST로 전송된다
+			require.NoError(t, client.sendMessage(ws.AgentMsgHeartbeat, client.buildHeartbeatPayload()))
+			select {
+			case msgType := <-srv.received:
+				assert.Equal(t, ws.AgentMsgHeartbeat, msgType)
+			case <-time.After(2 * time.Second):
+				t.Fatal("서버가 하트비트를 받지 못했습니다")
+			}
+
+			// 수신: 폴링 응답의 메시지가 Messages 채널로 전달된다
+			srv.push(ws.AgentMessage{Type: ws.AgentMsgTaskReq, ID: "msg-1", Payload: json.RawMessage(`{"execution_id":"exec-1"}`)})
+			select {
+			case msg := <-client.Messages():
+				assert.Equal(t, ws.AgentMsgTaskReq, msg.Type)
+				assert.Equal(t, "msg-1", msg.ID)
+			case <-time.After(2 * time.Second):
+				t.Fatal("폴링으로 받은 메시지가 전달되지 않았습니다")
+			}
+
+			require.NoError(t, client.Disconnect("test"))
+			assert.Empty(t, client.TransportName())
+			sessions, closed := srv.counts()
+			assert.Equal(t, 1, sessions)
+			assert.Equal(t, 1, closed, "종료 시 세션을 닫아야 함")
+		})
+	}
+}
+
+// TestConnect_TransportModes는 websocket 모드는 폴백하지 않고 http 모드는 WebSocket을 시도하지 않는지 검증합니다.
+func TestConnect_TransportModes(t *testing.T) {
+	srv := newLongPollServer(t, false)
+
+	client := NewClient(srv.wsURL(), "test-token", "1.0.0", WithTransport(TransportWebSocket))
+	err := client.Connect(testContext(t))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	sessions, _ := srv.counts()
+	assert.Zero(t, sessions, "websocket 모드에서는 HTTP 세션을 만들면 안 됨")
+
+	client = NewClient("ws://127.0.0.1:1/unused", "test-token", "1.0.0",
+		WithTransport(TransportHTTP), WithHTTPFallbackURL(srv.URL+"/ws/http/"))
+	require.NoError(t, client.Connect(testContext(t)))
+	defer client.Disconnect("test")
+	assert.Equal(t, TransportHTTP, client.TransportName())
+}
+
+// TestConnect_UnreachableServerDoesNotFallBack은 서버에 닿지 못하는 에러는 HTTP 폴백 대상이 아닌지 검증합니다.
+func TestConnect_UnreachableServerDoesNotFallBack(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	client := NewClient("ws://"+addr+"/ws", "test-token", "1.0.0")
+	err = client.Connect(testContext(t))
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "HTTP 폴백")
+	assert.Equal(t, StateDisconnected, client.State())
+}
+
+// TestHTTPTransport_ReplacedByNewConnection은 409 응답이 연결 교체 close 에러로 처리되는지 검증합니다.
+func TestHTTPTransport_ReplacedByNewConnection(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/session":
+			_, _ = io.WriteString(w, `{"session_id":"sess-1"}`)
+		case "/poll":
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer srv.Close()
+
+	tr, err := dialHTTPTransport(context.Background(), nil, srv.URL)
+	require.NoError(t, err)
+	defer tr.Close()
+
+	_, err = tr.ReadMessage()
+	require.Error(t, err)
+	assert.True(t, isReplacedByNewConnectionClose(err), "409는 연결 교체로 처리되어야 함: %v", err)
+}
+
+// TestHTTPTransport_ReadDeadlineAndClose는 읽기 기한 초과와 종료 후 읽기 에러를 검증합니다.
+func TestHTTPTransport_ReadDeadlineAndClose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/session":
+			_, _ = io.WriteString(w, `{"session_id":"sess-1"}`)
+		case "/poll":
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	tr, err := dialHTTPTransport(context.Background(), nil, srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, tr.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, err = tr.ReadMessage()
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	require.NoError(t, tr.SetReadDeadline(time.Time{}))
+	require.NoError(t, tr.Close())
+	_, err = tr.ReadMessage()
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
+
+func TestIsWebSocketBlocked(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"bad handshake", websocket.ErrBadHandshake, true},
+		{"reset during upgrade", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{"closed during upgrade", io.EOF, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, false},
+		{"timeout", context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isWebSocketBlocked(tt.err))
+		})
+	}
+}
+
+func TestHTTPFallbackBaseURL(t *testing.T) {
+	tests := []struct {
+		serverURL, override, want string
+	}{
+		{"wss://api.autopus.co/ws/agent", "", "https://api.autopus.co/ws/agent/http"},
+		{"ws://localhost:8080/ws/?v=1", "", "http://localhost:8080/ws/http"},
+		{"wss://api.autopus.co/ws/agent", "https://poll.example.com/bridge/", "https://poll.example.com/bridge"},
+	}
+	for _, tt := range tests {
+		client := NewClient(tt.serverURL, "token", "1.0.0", WithHTTPFallbackURL(tt.override))
+		assert.Equal(t, tt.want, client.httpFallbackBaseURL(), tt.serverURL)
+	}
+}
@@ -0,0 +1,251 @@
+// Package websocket는 Local Agent Bridge의 WebSocket 통신을 담당합니다.
+// 스크린샷, 아티팩트, 대용량 결과 전송이 업링크를 점유하지 않도록 공유 대역폭 제한을 제공합니다.
+package websocket
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/insajin/autopus-agent-protocol"
+	"golang.org/x/time/rate"
+)
+
+// UploadCategory는 대역폭 제한을 받는 전송 분류입니다.
+type UploadCategory string
+
+const (
+	// UploadCategoryScreenshot은 Computer Use/브라우저 스크린샷 결과입니다.
+	UploadCategoryScreenshot UploadCategory = "screenshot"
+	// UploadCategoryResult는 BulkPayloadThreshold보다 큰 작업 결과입니다.
+	UploadCategoryResult UploadCategory = "result"
+	// UploadCategoryArtifact는 파일 동기화와 빌드/테스트/QA 결과(로그, 아티팩트)입니다.
+	UploadCategoryArtifact UploadCategory = "artifact"
+)
+
+// minUploadChunkBytes는 한 번에 확보하는 토큰(바이트)의 최솟값입니다.
+const minUploadChunkBytes = 1024
+
+// DefaultUploadPriorities는 분류별 기본 우선순위입니다. 값이 작을수록 먼저 대역폭을 받습니다.
+// 서버가 스크린샷을 기다리며 다음 동작을 정하는 Computer Use가 가장 먼저입니다.
+var DefaultUploadPriorities = map[UploadCategory]int{
+	UploadCategoryScreenshot: 0,
+	UploadCategoryResult:     1,
+	UploadCategoryArtifact:   2,
+}
+
+// UploadLimiterConfig는 UploadLimiter 설정입니다.
+type UploadLimiterConfig struct {
+	// MaxKbps는 전체 업로드 최대 속도(킬로비트/초)입니다. 0 이하이면 제한하지 않습니다.
+	MaxKbps int
+	// Priorities는 분류별 우선순위입니다. 없는 분류는 DefaultUploadPriorities를 사용합니다.
+	Priorities map[UploadCategory]int
+}
+
+// uploadWaiter는 대역폭 차례를 기다리는 전송입니다.
+type uploadWaiter struct {
+	priority int
+	seq      uint64
+	ready    chan struct{}
+}
+
+// UploadLimiter는 여러 전송이 공유하는 토큰 버킷 업로드 속도 제한기입니다.
+// 큰 전송은 버킷 크기 단위로 나누어 토큰을 확보하므로, 기다리는 동안 더 높은 우선순위의
+// 전송이 끼어들 수 있습니다. 같은 우선순위에서는 먼저 요청한 전송이 먼저입니다.
+// nil UploadLimiter는 제한하지 않습니다.
+type UploadLimiter struct {
+	bucket *rate.Limiter
+
+	mu         sync.Mutex
+	priorities map[UploadCategory]int
+	// busy는 어떤 전송이 토큰을 확보하는 중인지 여부입니다.
+	busy    bool
+	waiters []*uploadWaiter
+	seq     uint64
+	// waitedBytes는 분류별로 제한을 거친 바이트 수입니다.
+	waitedBytes map[UploadCategory]int64
+}
+
+// NewUploadLimiter는 새로운 UploadLimiter를 생성합니다.
+// MaxKbps가 0이어도 생성하여 실행 중에 Reconfigure로 제한을 켤 수 있습니다.
+func NewUploadLimiter(cfg UploadLimiterConfig) *UploadLimiter {
+	l := &UploadLimiter{
+		bucket:      rate.NewLimiter(rate.Inf, 0),
+		waitedBytes: make(map[UploadCategory]int64),
+	}
+	l.Reconfigure(cfg)
+	return l
+}
+
+// Reconfigure는 실행 중에 최대 속도와 우선순위를 변경합니다.
+func (l *UploadLimiter) Reconfigure(cfg UploadLimiterConfig) {
+	priorities := make(map[UploadCategory]int, len(DefaultUploadPriorities))
+	for category, priority := range DefaultUploadPriorities {
+		priorities[category] = priority
+	}
+	for category, priority := range cfg.Priorities {
+		priorities[category] = priority
+	}
+
+	l.mu.Lock()
+	l.priorities = priorities
+	l.mu.Unlock()
+
+	if cfg.MaxKbps <= 0 {
+		l.bucket.SetLimit(rate.Inf)
+		return
+	}
+	bytesPerSec := float64(cfg.MaxKbps) * 1000 / 8
+	l.bucket.SetBurst(max(int(bytesPerSec/4), minUploadChunkBytes))
+	l.bucket.SetLimit(rate.Limit(bytesPerSec))
+}
+
+// Limited는 속도 제한이 켜져 있는지 반환합니다.
+func (l *UploadLimiter) Limited() bool {
+	return l != nil && l.bucket.Limit() != rate.Inf
+}
+
+// Wait는 category 전송 n바이트에 필요한 대역폭을 확보할 때까지 대기합니다.
+// ctx가 취소되면 확보하지 못한 채 에러를 반환합니다.
+func (l *UploadLimiter) Wait(ctx context.Context, category UploadCategory, n int) error {
+	if !l.Limited() || n <= 0 {
+		return nil
+	}
+
+	remaining := n
+	for remaining > 0 {
+		if err := l.acquireTurn(ctx, category); err != nil {
+			return err
+		}
+		chunk := min(remaining, l.bucket.Burst())
+		err := l.bucket.WaitN(ctx, chunk)
+		l.releaseTurn()
+		if err != nil {
+			return err
+		}
+		remaining -= chunk
+		if !l.Limited() {
+			break
+		}
+	}
+
+	l.mu.Lock()
+	l.waitedBytes[category] += int64(n)
+	l.mu.Unlock()
+	return nil
+}
+
+// WaitedBytes는 분류별로 제한을 거친 바이트 수를 반환합니다.
+func (l *UploadLimiter) WaitedBytes() map[UploadCategory]int64 {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make(map[UploadCategory]int64, len(l.waitedBytes))
+	for category, n := range l.waitedBytes {
+		out[category] = n
+	}
+	return out
+}
+
+// acquireTurn은 토큰을 확보할 차례를 기다립니다.
+// 차례는 우선순위가 높은(값이 작은) 전송, 같으면 먼저 요청한 전송에게 돌아갑니다.
+func (l *UploadLimiter) acquireTurn(ctx context.Context, category UploadCategory) error {
+	l.mu.Lock()
+	if !l.busy && len(l.waiters) == 0 {
+		l.busy = true
+		l.mu.Unlock()
+		return nil
+	}
+	priority, ok := l.priorities[category]
+	if !ok {
+		priority = math.MaxInt
+	}
+	l.seq++
+	w := &uploadWaiter{priority: priority, seq: l.seq, ready: make(chan struct{})}
+	l.waiters = append(l.waiters, w)
+	sort.Slice(l.waiters, func(i, j int) bool {
+		if l.waiters[i].priority != l.waiters[j].priority {
+			return l.waiters[i].priority < l.waiters[j].priority
+		}
+		return l.waiters[i].seq < l.waiters[j].seq
+	})
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		for i, waiter := range l.waiters {
+			if waiter == w {
+				l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+				l.mu.Unlock()
+				return ctx.Err()
+			}
+		}
+		l.mu.Unlock()
+		// 취소와 동시에 차례를 받았으면 다음 전송에게 넘긴다.
+		l.releaseTurn()
+		return ctx.Err()
+	}
+}
+
+// releaseTurn은 다음 대기 전송에게 차례를 넘깁니다.
+func (l *UploadLimiter) releaseTurn() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.waiters) == 0 {
+		l.busy = false
+		return
+	}
+	next := l.waiters[0]
+	l.waiters = l.waiters[1:]
+	close(next.ready)
+}
+
+// uploadCategory는 송신 메시지의 대역폭 제한 분류를 결정합니다.
+// 제어 메시지와 에러는 제한하지 않고, 일반 결과는 BulkPayloadThreshold보다 클 때만 제한합니다.
+func uploadCategory(msgType string, size int) (UploadCategory, bool) {
+	switch msgType {
+	case ws.AgentMsgComputerResult, ws.AgentMsgBrowserResult:
+		return UploadCategoryScreenshot, true
+	case AgentMsgFileSync, ws.AgentMsgBuildResult, ws.AgentMsgTestResult, ws.AgentMsgQAResult:
+		return UploadCategoryArtifact, true
+	}
+	if messagePriority(msgType, size) == PriorityBulk {
+		return UploadCategoryResult, true
+	}
+	return "", false
+}
+
+// WithUploadLimiter는 스크린샷, 아티팩트, 대용량 결과 전송에 공유 대역폭 제한을 적용합니다.
+func WithUploadLimiter(limiter *UploadLimiter) ClientOption {
+	return func(c *Client) {
+		c.uploadLimiter = limiter
+	}
+}
+
+// waitUploadBandwidth는 메시지 전송 전에 업로드 대역폭을 확보합니다.
+// 대기 중 송신 루프가 종료되면(연결 끊김) 대기를 중단합니다.
+func (c *Client) waitUploadBandwidth(sender *messageSender, msgType string, size int) error {
+	category, ok := uploadCategory(msgType, size)
+	if !ok || !c.uploadLimiter.Limited() {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-sender.stopped:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := c.uploadLimiter.Wait(ctx, category, size); err != nil {
+		return errSenderStopped
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	ws "github.com/insajin/autopus-agent-protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadLimiter_Unlimited(t *testing.T) {
+	var nilLimiter *UploadLimiter
+	assert.False(t, nilLimiter.Limited())
+	assert.NoError(t, nilLimiter.Wait(context.Background(), UploadCategoryArtifact, 1<<20))
+
+	limiter := NewUploadLimiter(UploadLimiterConfig{})
+	assert.False(t, limiter.Limited())
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background(), UploadCategoryArtifact, 100<<20))
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "제한이 없으면 바로 반환")
+}
+
+func TestUploadLimiter_RateAndReconfigure(t *testing.T) {
+	// 800kbps = 100,000 B/s, 버킷 크기 25,000 B
+	limiter := NewUploadLimiter(UploadLimiterConfig{MaxKbps: 800})
+	require.True(t, limiter.Limited())
+
+	ctx := context.Background()
+	require.NoError(t, limiter.Wait(ctx, UploadCategoryScreenshot, 25000), "처음에는 버킷이 가득 차 있음")
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx, UploadCategoryScreenshot, 20000))
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond, "20,000 B는 약 200ms가 걸려야 함")
+	assert.Less(t, elapsed, time.Second)
+
+	limiter.Reconfigure(UploadLimiterConfig{})
+	assert.False(t, limiter.Limited(), "0으로 바꾸면 제한 해제")
+	start = time.Now()
+	require.NoError(t, limiter.Wait(ctx, UploadCategoryArtifact, 1<<20))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	assert.Equal(t, int64(45000), limiter.WaitedBytes()[UploadCategoryScreenshot])
+}
+
+func TestUploadLimiter_PriorityOrder(t *testing.T) {
+	limiter := NewUploadLimiter(UploadLimiterConfig{MaxKbps: 800})
+	ctx := context.Background()
+	require.NoError(t, limiter.Wait(ctx, UploadCategoryArtifact, 25000))
+
+	var mu sync.Mutex
+	var order []UploadCategory
+	var wg sync.WaitGroup
+	start := func(category UploadCategory) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(ctx, category, 10000))
+			mu.Lock()
+			order = append(order, category)
+			mu.Unlock()
+		}()
+	}
+
+	// 첫 아티팩트가 토큰을 기다리는 동안 아티팩트, 스크린샷 순서로 요청한다.
+	start(UploadCategoryArtifact)
+	time.Sleep(20 * time.Millisecond)
+	start(UploadCategoryArtifact)
+	time.Sleep(20 * time.Millisecond)
+	start(UploadCategoryScreenshot)
+	wg.Wait()
+
+	assert.Equal(t, []UploadCategory{UploadCategoryArtifact, UploadCategoryScreenshot, UploadCategoryArtifact}, order,
+		"나중에 요청한 스크린샷이 대기 중인 아티팩트보다 먼저 전송되어야 함")
+}
+
+func TestUploadLimiter_CancelWhileQueued(t *testing.T) {
+	limiter := NewUploadLimiter(UploadLimiterConfig{MaxKbps: 800})
+	require.NoError(t, limiter.Wait(context.Background(), UploadCategoryArtifact, 25000))
+
+	done := make(chan error, 1)
+	go func() { done <- limiter.Wait(context.Background(), UploadCategoryArtifact, 10000) }()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- limiter.Wait(ctx, UploadCategoryResult, 10000) }()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+	assert.NoError(t, <-done)
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	assert.Empty(t, limiter.waiters, "취소된 대기는 대기열에서 제거되어야 함")
+	assert.False(t, limiter.busy)
+}
+
+func TestUploadCategory(t *testing.T) {
+	tests := []struct {
+		msgType  string
+		size     int
+		expected UploadCategory
+		limited  bool
+	}{
+		{ws.AgentMsgComputerResult, 100, UploadCategoryScreenshot, true},
+		{ws.AgentMsgBrowserResult, 100, UploadCategoryScreenshot, true},
+		{AgentMsgFileSync, 100, UploadCategoryArtifact, true},
+		{ws.AgentMsgBuildResult, 100, UploadCategoryArtifact, true},
+		{ws.AgentMsgTaskResult, BulkPayloadThreshold + 1, UploadCategoryResult, true},
+		{ws.AgentMsgTaskResult, 100, "", false},
+		{ws.AgentMsgTaskError, BulkPayloadThreshold + 1, "", false},
+		{ws.AgentMsgHeartbeat, 100, "", false},
+	}
+	for _, tt := range tests {
+		category, limited := uploadCategory(tt.msgType, tt.size)
+		assert.Equal(t, tt.expected, category, tt.msgType)
+		assert.Equal(t, tt.limited, limited, tt.msgType)
+	}
+}
@@ -21,6 +21,6 @@ func main() {
 
 	// CLI 실행
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
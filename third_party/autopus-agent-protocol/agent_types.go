@@ -22,6 +22,17 @@ const (
 	AgentMsgTaskResult = "task_result"
 	AgentMsgTaskError  = "task_error"
 
+	// Task lease message types: 장시간 실행 작업의 중복 할당 방지
+	AgentMsgTaskLeaseRenew  = "task_lease_renew"  // Bridge -> Server: 실행 중인 작업의 리스 갱신
+	AgentMsgTaskLeaseRevoke = "task_lease_revoke" // Server -> Bridge: 작업 리스 회수 (로컬 실행 취소)
+
+	// Task resume message type: 중단된 장시간 작업을 체크포인트에서 재개
+	AgentMsgTaskResume = "task_resume" // Server <-> Bridge: 체크포인트 재개 제안(offer) 및 재개/폐기 지시(resume/discard)
+
+	// 이기종 플릿의 Bridge 간 작업 위임
+	AgentMsgTaskDelegate       = "task_delegate"        // Bridge -> Server: 로컬에서 실행할 수 없는 작업을 같은 워크스페이스의 다른 Bridge로 위임 요청
+	AgentMsgTaskDelegateStatus = "task_delegate_status" // Server -> Bridge: 위임 진행 상태 (accepted/rejected/completed/failed)
+
 	// Build operation message types (FR-P3-01).
 	AgentMsgBuildReq    = "build_request"
 	AgentMsgBuildResult = "build_result"
@@ -59,6 +70,11 @@ const (
 	AgentMsgProjectContext = "project_context" // Bridge -> Server: 프로젝트 기술 스택 컨텍스트
 	AgentMsgCLIRequest     = "cli_request"     // Server -> Bridge: CLI 명령어 실행 요청
 	AgentMsgCLIResult      = "cli_result"      // Bridge -> Server: CLI 실행 결과
+	AgentMsgCLIOutput      = "cli_output"      // Bridge -> Server: CLI 실행 중 출력 줄 (배치 스트리밍)
+
+	// Remote configuration (fleet management)
+	AgentMsgConfigUpdate    = "config_update"     // Server -> Bridge: 허용 범위 안의 실행 중 설정 변경
+	AgentMsgConfigUpdateAck = "config_update_ack" // Bridge -> Server: 적용/거부된 설정과 유효 값
 
 	// MCP Provisioning (SPEC-SKILL-V2-001 Phase 3)
 	AgentMsgMCPStart = "mcp_start" // Server -> Bridge: MCP server start request
@@ -71,14 +87,17 @@ const (
 	AgentMsgMCPCodegenProgress = "mcp_codegen_progress" // Bridge -> Server
 	AgentMsgMCPCodegenResult   = "mcp_codegen_result"   // Bridge -> Server
 	AgentMsgMCPDeploy          = "mcp_deploy"           // Server -> Bridge
+	AgentMsgMCPDeployChunk     = "mcp_deploy_chunk"     // Server -> Bridge: chunk of a deploy archive
 	AgentMsgMCPDeployResult    = "mcp_deploy_result"    // Bridge -> Server
 	AgentMsgMCPHealthReport    = "mcp_health_report"    // Bridge -> Server
+	AgentMsgMCPServerEvent     = "mcp_server_event"     // Bridge -> Server: summarized notifications from managed MCP servers
 
 	// MCP Server (serve) lifecycle management (SPEC-AI-003 M3)
 	AgentMsgMCPServeStart  = "mcp_serve_start"  // Server -> Bridge: MCP server 제공 시작 요청
 	AgentMsgMCPServeStop   = "mcp_serve_stop"   // Server -> Bridge: MCP server 제공 중지 요청
 	AgentMsgMCPServeReady  = "mcp_serve_ready"  // Bridge -> Server: MCP server 시작 완료 (REQ-INTEGRATION-001)
 	AgentMsgMCPServeResult = "mcp_serve_result" // Bridge -> Server: MCP server 제공 결과 (stop/error)
+	AgentMsgMCPRPC         = "mcp_rpc"          // Server <-> Bridge: embedded 모드 MCP JSON-RPC 메시지 터널링
 
 	// Tool Approval message types (SPEC-INTERACTIVE-CLI-001)
 	AgentMsgToolApprovalReq  = "tool_approval_request"  // Bridge -> Server: 도구 승인 요청
@@ -97,6 +116,14 @@ const (
 	AgentMsgCodeOpsRequest = "codeops_request" // Server -> Bridge: 코드 수정 요청
 	AgentMsgCodeOpsResult  = "codeops_result"  // Bridge -> Server: 코드 수정 결과
 
+	// Git operation message types
+	AgentMsgGitRequest = "git_request" // Server -> Bridge: 샌드박스 내 git 작업 요청 (clone/fetch/checkout/branch/commit/push)
+	AgentMsgGitResult  = "git_result"  // Bridge -> Server: git 작업 결과
+
+	// Custom local tool message types
+	AgentMsgCustomToolRequest = "custom_tool_request" // Server -> Bridge: 사용자 정의 로컬 도구 실행 요청
+	AgentMsgCustomToolResult  = "custom_tool_result"  // Bridge -> Server: 사용자 정의 로컬 도구 실행 결과
+
 	// CodingRelay message types (SPEC-CODING-RELAY-001)
 	AgentMsgCodingRelayRequest  = "coding_relay_request"  // Server -> Bridge: 코딩 릴레이 시작 요청
 	AgentMsgCodingRelayEvaluate = "coding_relay_evaluate" // Bridge -> Server: 이터레이션 결과 (Worker 평가 대기)
@@ -113,6 +140,9 @@ const (
 	// AgentProtocolVersion is the shared backend<->bridge wire contract version.
 	// Only minor/patch compatible peers should communicate on the same connection.
 	AgentProtocolVersion = "1.1.0"
+	// PreviousAgentProtocolVersion is the previous minor wire contract version.
+	// Bridges keep translation shims for it so old servers and new bridges interoperate during rollouts.
+	PreviousAgentProtocolVersion = "1.0.0"
 )
 
 // AgentMessage is the envelope for all Local Agent WebSocket messages.
@@ -122,17 +152,40 @@ type AgentMessage struct {
 	Timestamp time.Time       `json:"timestamp"`
 	Payload   json.RawMessage `json:"payload"`
 	Signature string          `json:"signature,omitempty"` // HMAC-SHA256 서명 (SEC-P2-02)
+	// Metadata carries out-of-band context such as W3C trace context (traceparent/tracestate).
+	// It is not covered by the HMAC signature and must not carry authoritative data.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // AgentConnectPayload is sent when a Local Agent connects.
 type AgentConnectPayload struct {
-	Version              string          `json:"version"`                         // Agent version
-	ProtocolVersion      string          `json:"protocol_version,omitempty"`      // Shared wire contract version
-	Capabilities         []string        `json:"capabilities"`                    // Supported CLI list
-	ProviderCapabilities map[string]bool `json:"provider_capabilities,omitempty"` // 지원하는 프로바이더 목록 (SPEC-BRIDGE-GATEWAY-001)
-	WorkspaceID          string          `json:"workspace_id,omitempty"`          // Selected workspace scope for this bridge session
-	LastExecID           string          `json:"last_exec_id"`                    // Last processed execution ID (for reconnect)
-	Token                string          `json:"token"`                           // JWT token for message-based auth (FR-P2-02)
+	Version                   string                 `json:"version"`                               // Agent version
+	ProtocolVersion           string                 `json:"protocol_version,omitempty"`            // Shared wire contract version
+	SupportedProtocolVersions []string               `json:"supported_protocol_versions,omitempty"` // Wire contract versions the agent speaks, newest first (see NegotiateProtocolVersion)
+	Capabilities              []string               `json:"capabilities"`                          // Supported CLI list
+	ProviderCapabilities      map[string]bool        `json:"provider_capabilities,omitempty"`       // 지원하는 프로바이더 목록 (SPEC-BRIDGE-GATEWAY-001)
+	WorkspaceID               string                 `json:"workspace_id,omitempty"`                // Selected workspace scope for this bridge session
+	CustomTools               []CustomToolDefinition `json:"custom_tools,omitempty"`                // User-defined local tools the server may invoke via custom_tool_request
+	LastExecID                string                 `json:"last_exec_id"`                          // Last processed execution ID (for reconnect)
+	Token                     string                 `json:"token"`                                 // JWT token for message-based auth (FR-P2-02)
+	// ReadOnly means the agent refuses mutating requests (tasks, CLI, deploys,
+	// computer use, git) with code TaskErrorReadOnly. The server should route
+	// such work to another agent.
+	ReadOnly bool `json:"read_only,omitempty"`
+	// Identity identifies this bridge among the organization's bridges.
+	Identity *BridgeIdentity `json:"identity,omitempty"`
+}
+
+// BridgeIdentity identifies one bridge in a fleet so the server can tell
+// bridges apart and target work at them by label.
+type BridgeIdentity struct {
+	// ID is generated once per machine and stays the same across restarts
+	// and re-logins.
+	ID string `json:"id"`
+	// Name is a human-readable name; it defaults to the hostname.
+	Name string `json:"name,omitempty"`
+	// Labels are user-defined key/value pairs such as team=backend or os=mac.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // ConnectAckPayload is sent from server to agent after successful authentication.
@@ -141,7 +194,7 @@ type ConnectAckPayload struct {
 	Message         string `json:"message,omitempty"`
 	ErrorCode       string `json:"error_code,omitempty"`       // "token_expired", "token_invalid", "protocol_version_mismatch"
 	HMACSecret      string `json:"hmac_secret,omitempty"`      // HMAC 공유 시크릿 (SEC-P2-02)
-	ProtocolVersion string `json:"protocol_version,omitempty"` // Shared wire contract version acknowledged by backend
+	ProtocolVersion string `json:"protocol_version,omitempty"` // Wire contract version chosen by backend for this connection
 }
 
 // AgentDisconnectPayload is sent when a Local Agent disconnects.
@@ -153,6 +206,68 @@ type AgentDisconnectPayload struct {
 type AgentHeartbeatPayload struct {
 	Timestamp      time.Time `json:"timestamp"`
 	MCPServeStatus string    `json:"mcp_serve_status,omitempty"` // "running" | "stopped" | "" (SPEC-AI-003 M3)
+	// MCPServeInstances lists the running MCP serve instances. MCPServeStatus is
+	// "running" while at least one instance runs.
+	MCPServeInstances []MCPServeInstanceStatus `json:"mcp_serve_instances,omitempty"`
+	// ConfigRevision is the revision of the last config_update the bridge processed.
+	// The server pushes config_update again when it differs from the desired revision.
+	ConfigRevision string `json:"config_revision,omitempty"`
+	// Credentials reports the bridge login state; nil when the bridge does not track it
+	// (for example when it was started with a fixed token).
+	Credentials *CredentialStatus `json:"credentials,omitempty"`
+	// Concurrency reports the bridge's task concurrency limits and utilization;
+	// nil when the bridge runs without limits.
+	Concurrency *ConcurrencyStatus `json:"concurrency,omitempty"`
+	// Identity repeats the agent_connect identity so the server keeps it
+	// current without waiting for a reconnect.
+	Identity *BridgeIdentity `json:"identity,omitempty"`
+}
+
+// ConcurrencyStatus reports how many task, agent_response, build and test
+// requests the bridge runs and queues, overall and per workspace.
+type ConcurrencyStatus struct {
+	// Limit is the bridge-wide limit; 0 means unlimited.
+	Limit  int `json:"limit"`
+	Active int `json:"active"`
+	Queued int `json:"queued"`
+	// Workspaces lists workspaces with a quota or with active or queued work.
+	Workspaces []WorkspaceConcurrency `json:"workspaces,omitempty"`
+}
+
+// WorkspaceConcurrency reports the quota and utilization of one workspace.
+type WorkspaceConcurrency struct {
+	WorkspaceID string `json:"workspace_id"`
+	// Limit is the workspace quota; 0 means only the bridge-wide limit applies.
+	Limit  int `json:"limit"`
+	Active int `json:"active"`
+	Queued int `json:"queued"`
+}
+
+// Credential states reported in CredentialStatus.State.
+const (
+	CredentialStateValid    = "valid"
+	CredentialStateExpiring = "expiring" // refresh token expires within the bridge's warning window
+	CredentialStateExpired  = "expired"  // refresh token expired or was rejected; re-authentication required
+)
+
+// CredentialStatus reports the bridge's login state in heartbeats so the server
+// can surface an upcoming re-authentication before tasks start failing.
+type CredentialStatus struct {
+	State string `json:"state"`
+	// RefreshExpiresAt is when the refresh token expires; nil when unknown.
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+	// IntakePaused is true while the bridge rejects new tasks until re-authentication completes.
+	IntakePaused bool `json:"intake_paused,omitempty"`
+}
+
+// MCPServeInstanceStatus reports one MCP serve instance in heartbeats.
+type MCPServeInstanceStatus struct {
+	Instance    string    `json:"instance"`
+	Status      string    `json:"status"` // "running"
+	Mode        string    `json:"mode"`
+	BackendURL  string    `json:"backend_url,omitempty"`
+	WorkspaceID string    `json:"workspace_id,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
 }
 
 // TaskRequestPayload is sent from server to Local Agent to request execution.
@@ -166,8 +281,67 @@ type TaskRequestPayload struct {
 	Tools          []string `json:"tools,omitempty"`
 	Timeout        int      `json:"timeout_seconds"`
 	WorkDir        string   `json:"work_dir,omitempty"`
-	ApprovalPolicy string   `json:"approval_policy,omitempty"` // SPEC-INTERACTIVE-CLI-001: "auto-execute", "auto-approve", "agent-approve", "human-approve"
+	ApprovalPolicy string   `json:"approval_policy,omitempty"` // SPEC-INTERACTIVE-CLI-001: "auto-execute", "auto-approve", "agent-approve", "human-approve", "deny-all"
 	ExecutionMode  string   `json:"execution_mode,omitempty"`  // SPEC-INTERACTIVE-CLI-001: "auto-execute", "interactive"
+	// ResumeFromCheckpoint is set when the task is resumed from a bridge-side checkpoint (task_resume).
+	ResumeFromCheckpoint bool `json:"resume_from_checkpoint,omitempty"`
+	// Credentials are short-lived cloud credentials scoped to this execution.
+	// The bridge exposes them only to the execution's process tree and wipes them on completion.
+	Credentials []TaskCredential `json:"credentials,omitempty"`
+	// ConversationID groups related tasks so they continue the same provider session
+	// (Claude session, Codex thread) instead of starting cold.
+	ConversationID string `json:"conversation_id,omitempty"`
+	// ResetConversation discards the session remembered for ConversationID and starts a new one.
+	ResetConversation bool `json:"reset_conversation,omitempty"`
+	// Priority is the scheduling priority (PriorityHigh, PriorityNormal, PriorityLow).
+	// Empty means PriorityNormal.
+	Priority string `json:"priority,omitempty"`
+	// Pinned requires the task to run on exactly Provider, Model and ApprovalPolicy.
+	// Without it the bridge falls back to another local provider when the
+	// requested one is unavailable. When a pin can't be satisfied the bridge
+	// replies with task_error code TaskErrorUnsupportedModel and lists the
+	// locally available alternatives.
+	Pinned bool `json:"pinned,omitempty"`
+	// NewWorkDir asks the bridge to create a fresh subdirectory named after
+	// ExecutionID under WorkDir (or the bridge's default work directory) and
+	// run the task there. WorkDir must be inside the bridge's allowed roots;
+	// otherwise the bridge replies with task_error code
+	// TaskErrorWorkDirNotAllowed and lists the allowed roots.
+	NewWorkDir bool `json:"new_work_dir,omitempty"`
+	// WorkspaceID is the workspace the work belongs to. The bridge applies its
+	// per-workspace concurrency quota to it; empty means the session workspace.
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+// Scheduling priorities for task, build and test requests. When the bridge is at
+// its concurrency limit, queued high priority work runs before normal and low
+// priority work; requests of the same priority run in arrival order.
+const (
+	PriorityHigh   = "high"   // interactive, user-triggered work
+	PriorityNormal = "normal" // default
+	PriorityLow    = "low"    // batch and background jobs
+)
+
+// Task credential types.
+const (
+	TaskCredentialAWS = "aws" // AWS STS temporary credentials
+	TaskCredentialGCP = "gcp" // GCP OAuth2 access token
+)
+
+// TaskCredential is a short-lived cloud credential attached to a task request.
+type TaskCredential struct {
+	Type      string     `json:"type"` // TaskCredentialAWS, TaskCredentialGCP
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// AWS (type "aws")
+	AccessKeyID     string `json:"access_key_id,omitempty"`
+	SecretAccessKey string `json:"secret_access_key,omitempty"`
+	SessionToken    string `json:"session_token,omitempty"`
+	Region          string `json:"region,omitempty"`
+
+	// GCP (type "gcp")
+	AccessToken string `json:"access_token,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
 }
 
 // TaskProgressPayload is sent from Local Agent to server for streaming updates.
@@ -178,6 +352,10 @@ type TaskProgressPayload struct {
 	Type            string `json:"type"`                       // "text", "tool_use", etc.
 	TextDelta       string `json:"text_delta,omitempty"`       // Incremental text chunk (streaming)
 	AccumulatedText string `json:"accumulated_text,omitempty"` // Full text accumulated so far (streaming)
+	// Delegation is set when the task was forwarded to another bridge instead of running locally.
+	Delegation *DelegationStatus `json:"delegation,omitempty"`
+	// Step describes the structured step the task is on (build/test/QA stages, nested tool events).
+	Step *ProgressStep `json:"step,omitempty"`
 }
 
 // TaskResultPayload is sent from Local Agent when execution completes.
@@ -188,6 +366,94 @@ type TaskResultPayload struct {
 	Duration    int64       `json:"duration_ms"`
 	TokenUsage  *TokenUsage `json:"token_usage,omitempty"`
 	Error       string      `json:"error,omitempty"`
+	// WorkspaceChanges is set when the task ran in an isolated copy of work_dir.
+	WorkspaceChanges *WorkspaceChanges `json:"workspace_changes,omitempty"`
+	// Environment describes the bridge environment the task ran in.
+	Environment *EnvironmentSnapshot `json:"environment,omitempty"`
+	// Redactions counts secrets/PII the bridge replaced in Output, by rule name.
+	// The redacted content itself is never reported.
+	Redactions map[string]int `json:"redactions,omitempty"`
+	// QueueWaitMs is how long the task waited for an execution slot before it started.
+	QueueWaitMs int64 `json:"queue_wait_ms,omitempty"`
+	// ProviderSandbox reports file writes by provider tool calls outside the
+	// execution's allowed write roots. Nil when the provider sandbox is disabled.
+	ProviderSandbox *ProviderSandboxReport `json:"provider_sandbox,omitempty"`
+	// OutputTruncated is set when Output was shortened to fit the bridge's size
+	// limits. Overflow points to the full output kept on the bridge host.
+	OutputTruncated bool          `json:"output_truncated,omitempty"`
+	Overflow        []OverflowRef `json:"overflow,omitempty"`
+}
+
+// Overflow fields reported in OverflowRef.Field.
+const (
+	OverflowFieldOutput = "output"
+	OverflowFieldStdout = "stdout"
+	OverflowFieldStderr = "stderr"
+)
+
+// OverflowRef points to the full content of a result field that the bridge
+// truncated so the message stays under MaxMessageSize. The content is stored
+// as a file on the bridge host.
+type OverflowRef struct {
+	// Field is the truncated field (OverflowField*).
+	Field string `json:"field"`
+	// Path is the file on the bridge host holding the full content.
+	Path string `json:"path"`
+	// SizeBytes is the size of the full content.
+	SizeBytes int64 `json:"size_bytes"`
+	// SHA256 is the hex digest of the full content.
+	SHA256 string `json:"sha256"`
+}
+
+// ProviderSandboxReport describes how the bridge scoped file writes of the
+// tool calls a provider ran (command_execution, file_change, Write, Edit, ...).
+type ProviderSandboxReport struct {
+	// Enforced is false when the provider does not expose its tool calls to the
+	// bridge, so writes could not be checked.
+	Enforced bool `json:"enforced"`
+	// Roots are the directories tool calls were allowed to write to.
+	Roots []string `json:"roots"`
+	// Violations lists writes outside Roots, in the order they were seen.
+	Violations []ProviderSandboxViolation `json:"violations,omitempty"`
+}
+
+// ProviderSandboxViolation is a single tool call writing outside the allowed roots.
+type ProviderSandboxViolation struct {
+	Tool string `json:"tool"`
+	Path string `json:"path"`
+	// Command is the shell command the path was found in (shell tool calls only).
+	Command string `json:"command,omitempty"`
+	// Blocked is true when the bridge denied the tool call. Shell commands are
+	// only scanned heuristically, so their violations are reported as warnings.
+	Blocked bool      `json:"blocked"`
+	At      time.Time `json:"at"`
+}
+
+// WorkspaceChanges describes file changes a task made in an isolated copy of its work_dir.
+type WorkspaceChanges struct {
+	Files []WorkspaceFileChange `json:"files"`
+	// Diff is a unified diff of the changes. Binary files are listed without content.
+	Diff          string `json:"diff,omitempty"`
+	DiffTruncated bool   `json:"diff_truncated,omitempty"`
+	// Applied reports whether the changes were copied back into work_dir.
+	Applied bool `json:"applied"`
+	// Conflicts lists files that changed in work_dir during execution and were not applied.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// IsolatedDir is the retained copy when changes were not applied.
+	IsolatedDir string `json:"isolated_dir,omitempty"`
+}
+
+// Workspace file change statuses.
+const (
+	WorkspaceFileAdded    = "added"
+	WorkspaceFileModified = "modified"
+	WorkspaceFileDeleted  = "deleted"
+)
+
+// WorkspaceFileChange is a single changed file, relative to work_dir.
+type WorkspaceFileChange struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
 }
 
 // TokenUsage tracks token consumption.
@@ -205,6 +471,80 @@ type TaskErrorPayload struct {
 	Code        string `json:"code"`
 	Message     string `json:"message"`
 	Retryable   bool   `json:"retryable"`
+	// Severity and Hint describe Code for the user; see ErrorSeverity.
+	Severity ErrorSeverity `json:"severity,omitempty"`
+	Hint     string        `json:"hint,omitempty"`
+	// Alternatives lists the locally available providers and models when
+	// Code is TaskErrorUnsupportedModel.
+	Alternatives []ModelAlternative `json:"alternatives,omitempty"`
+	// AllowedWorkDirs lists the work directory roots the bridge accepts when
+	// Code is TaskErrorWorkDirNotAllowed.
+	AllowedWorkDirs []string `json:"allowed_work_dirs,omitempty"`
+	// Timeout tells which timeout expired when Code is "TIMEOUT".
+	Timeout *TimeoutDetail `json:"timeout,omitempty"`
+}
+
+// TimeoutDetail describes an expired execution timeout and the level of the
+// timeout hierarchy it came from.
+type TimeoutDetail struct {
+	// Kind is the message type (e.g. task_request) or MCP tool that timed out.
+	Kind string `json:"kind"`
+	// Source is the level that set the timeout (TimeoutSource*).
+	Source string `json:"source"`
+	// TimeoutSeconds is the timeout that expired.
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+// Timeout sources for TimeoutDetail.Source, from most to least specific.
+const (
+	// TimeoutSourceRequest is a timeout set on the request itself.
+	TimeoutSourceRequest = "request"
+	// TimeoutSourceMessageType is the bridge's timeout for the message type or MCP tool.
+	TimeoutSourceMessageType = "message_type"
+	// TimeoutSourceDefault is the bridge's global default timeout.
+	TimeoutSourceDefault = "default"
+)
+
+// ErrorSeverity tells how serious an error payload is.
+type ErrorSeverity string
+
+// Error severities for the Severity field of error payloads.
+const (
+	// ErrorSeverityWarning is a transient or expected condition; the work can
+	// usually be retried or continued.
+	ErrorSeverityWarning ErrorSeverity = "warning"
+	// ErrorSeverityError is a failure of one request that needs a change
+	// (configuration, input, environment) before it can succeed.
+	ErrorSeverityError ErrorSeverity = "error"
+	// ErrorSeverityCritical means the bridge cannot serve this kind of request
+	// at all until an operator intervenes.
+	ErrorSeverityCritical ErrorSeverity = "critical"
+)
+
+// TaskErrorUnsupportedModel is the task_error code for a pinned task whose
+// provider, model or approval policy is not available on the bridge.
+const TaskErrorUnsupportedModel = "UNSUPPORTED_MODEL"
+
+// TaskErrorWorkDirNotAllowed is the task_error code for a task whose
+// requested work_dir is outside the bridge's allowed work directory roots.
+const TaskErrorWorkDirNotAllowed = "WORK_DIR_NOT_ALLOWED"
+
+// TaskErrorReadOnly is the error code an agent running in read-only mode
+// reports for a mutating request (see AgentConnectPayload.ReadOnly).
+const TaskErrorReadOnly = "READ_ONLY"
+
+// TaskErrorPromptTooLarge is the task_error code for a task whose prompt
+// exceeds the bridge's configured prompt size limit.
+const TaskErrorPromptTooLarge = "PROMPT_TOO_LARGE"
+
+// ModelAlternative is a provider available on the bridge for pinned execution.
+type ModelAlternative struct {
+	Provider string `json:"provider"`
+	// Models lists known models; entries ending in "*" are model name prefixes.
+	Models []string `json:"models,omitempty"`
+	// SupportsApproval reports whether approval policies other than
+	// auto-execute/auto-approve can be enforced on this provider.
+	SupportsApproval bool `json:"supports_approval"`
 }
 
 // BuildRequestPayload is sent from server to Local Agent to request a build (FR-P3-01).
@@ -214,6 +554,53 @@ type BuildRequestPayload struct {
 	Command     string   `json:"command"`
 	Env         []string `json:"env,omitempty"`
 	Timeout     int      `json:"timeout_seconds"`
+	// Matrix runs one build per target in parallel instead of a single build.
+	// Each target inherits WorkDir, Command, Env and Timeout and may override them.
+	Matrix []BuildTarget `json:"matrix,omitempty"`
+	// MaxParallel limits how many matrix targets run at once. Zero or a value
+	// above the CPU count means one target per CPU.
+	MaxParallel int `json:"max_parallel,omitempty"`
+	// Priority is the scheduling priority (PriorityHigh, PriorityNormal, PriorityLow).
+	Priority string `json:"priority,omitempty"`
+	// Isolation selects where the command runs (IsolationHost, IsolationDocker).
+	// Empty uses the bridge default.
+	Isolation string `json:"isolation,omitempty"`
+	// Image overrides the container image chosen from the detected project stack.
+	Image string `json:"image,omitempty"`
+	// WorkspaceID is the workspace the work belongs to. The bridge applies its
+	// per-workspace concurrency quota to it; empty means the session workspace.
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+// Build/test isolation modes.
+const (
+	IsolationHost   = "host"   // run directly on the bridge host
+	IsolationDocker = "docker" // run inside a per-project Docker container with work_dir mounted
+)
+
+// BuildTarget is one entry of a build matrix (e.g. a GOOS/GOARCH pair or an npm workspace).
+type BuildTarget struct {
+	// Name identifies the target in results (e.g. "linux/amd64", "packages/web").
+	Name string `json:"name"`
+	// WorkDir overrides the request work directory. Relative paths are
+	// resolved against the request work directory.
+	WorkDir string `json:"work_dir,omitempty"`
+	// Command overrides the request command.
+	Command string `json:"command,omitempty"`
+	// Env is appended to the request environment (e.g. "GOOS=linux", "GOARCH=arm64").
+	Env []string `json:"env,omitempty"`
+}
+
+// BuildTargetResult is the outcome of a single build matrix target.
+type BuildTargetResult struct {
+	Name       string `json:"name"`
+	Success    bool   `json:"success"`
+	Output     string `json:"output"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
+	// StartedAt and FinishedAt allow the server to render the parallel timeline.
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
 }
 
 // BuildResultPayload is sent from Local Agent when a build completes (FR-P3-01).
@@ -224,6 +611,20 @@ type BuildResultPayload struct {
 	ExitCode    int      `json:"exit_code"`
 	DurationMs  int64    `json:"duration_ms"`
 	Artifacts   []string `json:"artifacts,omitempty"`
+	// Environment describes the bridge environment the build ran in.
+	Environment *EnvironmentSnapshot `json:"environment,omitempty"`
+	// Targets holds per-target results of a matrix build in matrix order.
+	// Success is true only when every target succeeded, and DurationMs is the
+	// wall-clock time of the whole matrix.
+	Targets []BuildTargetResult `json:"targets,omitempty"`
+	// Parallelism is the number of matrix targets that were allowed to run at once.
+	Parallelism int `json:"parallelism,omitempty"`
+	// QueueWaitMs is how long the build waited for an execution slot before it started.
+	QueueWaitMs int64 `json:"queue_wait_ms,omitempty"`
+	// ContainerImage is the image the build ran in when it was isolated in a container.
+	ContainerImage string `json:"container_image,omitempty"`
+	// Timeout is set when the run was stopped by an expired timeout.
+	Timeout *TimeoutDetail `json:"timeout,omitempty"`
 }
 
 // TestRequestPayload is sent from server to Local Agent to request test execution (FR-P3-02).
@@ -233,6 +634,26 @@ type TestRequestPayload struct {
 	Command     string `json:"command"`
 	Pattern     string `json:"pattern,omitempty"`
 	Timeout     int    `json:"timeout_seconds"`
+	// Coverage enables coverage instrumentation for the detected test stack
+	// (go test, jest, vitest, pytest).
+	Coverage bool `json:"coverage,omitempty"`
+	// CoverageThreshold is the minimum total coverage percentage (0-100).
+	// A non-zero threshold implies Coverage; the test stage fails when total
+	// coverage is below it or could not be collected.
+	CoverageThreshold float64 `json:"coverage_threshold,omitempty"`
+	// Priority is the scheduling priority (PriorityHigh, PriorityNormal, PriorityLow).
+	Priority string `json:"priority,omitempty"`
+	// Isolation selects where the command runs (IsolationHost, IsolationDocker).
+	// Empty uses the bridge default.
+	Isolation string `json:"isolation,omitempty"`
+	// Image overrides the container image chosen from the detected project stack.
+	Image string `json:"image,omitempty"`
+	// FlakyRetries reruns failed tests up to this many times to detect flaky tests
+	// (go test, pytest, jest). Zero uses the bridge default; negative disables reruns.
+	FlakyRetries int `json:"flaky_retries,omitempty"`
+	// WorkspaceID is the workspace the work belongs to. The bridge applies its
+	// per-workspace concurrency quota to it; empty means the session workspace.
+	WorkspaceID string `json:"workspace_id,omitempty"`
 }
 
 // TestResultPayload is sent from Local Agent when test execution completes (FR-P3-02).
@@ -243,6 +664,72 @@ type TestResultPayload struct {
 	ExitCode    int         `json:"exit_code"`
 	DurationMs  int64       `json:"duration_ms"`
 	Summary     TestSummary `json:"summary"`
+	// Coverage is set when coverage was requested.
+	Coverage *TestCoverage `json:"coverage,omitempty"`
+	// QueueWaitMs is how long the test run waited for an execution slot before it started.
+	QueueWaitMs int64 `json:"queue_wait_ms,omitempty"`
+	// ContainerImage is the image the tests ran in when they were isolated in a container.
+	ContainerImage string `json:"container_image,omitempty"`
+	// Flaky is set when failed tests were rerun to detect flakiness.
+	Flaky *FlakyTestReport `json:"flaky,omitempty"`
+	// Timeout is set when the run was stopped by an expired timeout.
+	Timeout *TimeoutDetail `json:"timeout,omitempty"`
+}
+
+// FlakyTestReport describes the reruns of failed tests in a test run.
+// When every failed test passes on a rerun the run succeeds and the tests
+// are listed in Flaky; tests that fail on every attempt are listed in Failed.
+type FlakyTestReport struct {
+	// MaxRetries is the number of reruns allowed for the run.
+	MaxRetries int `json:"max_retries"`
+	// Reruns is the number of reruns performed.
+	Reruns int `json:"reruns"`
+	// Flaky lists tests that failed and then passed on a rerun.
+	Flaky []FlakyTest `json:"flaky,omitempty"`
+	// Failed lists tests that failed on every attempt.
+	Failed []FlakyTest `json:"failed,omitempty"`
+	// Error describes why failed tests could not be rerun.
+	Error string `json:"error,omitempty"`
+}
+
+// FlakyTest is a single rerun test with its local flake history for the project.
+type FlakyTest struct {
+	// Name is the test identifier reported by the framework.
+	Name string `json:"name"`
+	// Attempts is the number of runs including the first (2 = passed on the first rerun).
+	Attempts int `json:"attempts"`
+	// FlakyCount is how many runs in the local history, including this one,
+	// the test failed and then passed on a rerun.
+	FlakyCount int `json:"flaky_count"`
+	// FailCount is how many runs in the local history, including this one,
+	// the test failed on every attempt.
+	FailCount int `json:"fail_count"`
+	// LastFlakyAt is when the test was last seen flaky, before this run.
+	LastFlakyAt *time.Time `json:"last_flaky_at,omitempty"`
+}
+
+// TestCoverage contains coverage collected during a test run.
+type TestCoverage struct {
+	// Tool is the coverage source ("go", "jest", "vitest", "pytest").
+	Tool string `json:"tool,omitempty"`
+	// TotalPercent is the total statement coverage percentage.
+	TotalPercent float64 `json:"total_percent"`
+	// Packages lists per-package (Go) or per-directory (JS/Python) coverage.
+	Packages []PackageCoverage `json:"packages,omitempty"`
+	// Threshold is the requested minimum total coverage percentage.
+	Threshold float64 `json:"threshold,omitempty"`
+	// BelowThreshold is true when TotalPercent is below Threshold.
+	BelowThreshold bool `json:"below_threshold,omitempty"`
+	// Error describes why coverage could not be collected.
+	Error string `json:"error,omitempty"`
+}
+
+// PackageCoverage is the statement coverage of a single package or directory.
+type PackageCoverage struct {
+	Package    string  `json:"package"`
+	Percent    float64 `json:"percent"`
+	Covered    int     `json:"covered"`
+	Statements int     `json:"statements"`
 }
 
 // TestSummary contains aggregated test result counts.
@@ -288,6 +775,11 @@ type QAResultPayload struct {
 	Stages      []QAStageResult `json:"stages"`
 	Screenshots []string        `json:"screenshots,omitempty"`
 	Videos      []string        `json:"videos,omitempty"`
+	// Artifacts lists files saved locally when the pipeline fails
+	// (full service logs, browser test screenshots/videos/traces).
+	Artifacts []QAArtifact `json:"artifacts,omitempty"`
+	// Timeout is set when the run was stopped by an expired timeout.
+	Timeout *TimeoutDetail `json:"timeout,omitempty"`
 }
 
 // QAStageResult contains the result of a single QA pipeline stage.
@@ -297,14 +789,35 @@ type QAStageResult struct {
 	Output     string `json:"output"`
 	DurationMs int64  `json:"duration_ms"`
 	Error      string `json:"error,omitempty"`
+	// ServiceLog is the tail of the managed service's stdout/stderr, attached to failed stages.
+	ServiceLog string `json:"service_log,omitempty"`
+}
+
+// QA artifact types.
+const (
+	QAArtifactServiceLog = "service_log"
+	QAArtifactScreenshot = "screenshot"
+	QAArtifactVideo      = "video"
+	QAArtifactTrace      = "trace"
+)
+
+// QAArtifact references a file saved by the Local Agent for a failed QA pipeline.
+type QAArtifact struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes"`
 }
 
 // ComputerActionPayload represents a computer use action request from server to bridge.
 type ComputerActionPayload struct {
 	ExecutionID string                 `json:"execution_id"`
 	SessionID   string                 `json:"session_id"`
-	Action      string                 `json:"action"` // screenshot, click, type, scroll, navigate
+	Action      string                 `json:"action"` // screenshot, click, type, scroll, navigate, capture_pdf, full_page_screenshot
 	Params      map[string]interface{} `json:"params"`
+	// Annotate asks the bridge to draw the performed action on the returned screenshot
+	// (click crosshair, typed text region, scroll arrow) so humans can audit what the agent did.
+	Annotate bool `json:"annotate,omitempty"`
 }
 
 // ComputerResultPayload represents a computer use action result from bridge to server.
@@ -316,6 +829,31 @@ type ComputerResultPayload struct {
 	Error       string `json:"error,omitempty"`
 	DurationMs  int64  `json:"duration_ms"`
 	ContainerID string `json:"container_id,omitempty"` // SPEC-COMPUTER-USE-002: 컨테이너 ID
+	// Queued는 세션 시작 요청이 동시 세션 한도로 대기 중임을 나타낸다 (실패가 아니므로 Success=true).
+	Queued bool `json:"queued,omitempty"`
+	// QueuePosition은 대기열에서의 순번이다 (1부터 시작, 대기 중이 아니면 0).
+	QueuePosition int `json:"queue_position,omitempty"`
+	// Artifacts는 capture_pdf/full_page_screenshot 액션이 로컬에 저장한 파일 목록이다.
+	Artifacts []ComputerArtifact `json:"artifacts,omitempty"`
+}
+
+// Computer use artifact types.
+const (
+	ComputerArtifactPDF                = "pdf"
+	ComputerArtifactFullPageScreenshot = "full_page_screenshot"
+)
+
+// ComputerArtifact references a page capture saved by the Local Agent.
+type ComputerArtifact struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Path      string `json:"path"`
+	MimeType  string `json:"mime_type"`
+	SizeBytes int64  `json:"size_bytes"`
+	// Page is the 1-based segment number when a long page is captured in parts.
+	Page int `json:"page,omitempty"`
+	// TotalPages is the number of segments the whole page is split into.
+	TotalPages int `json:"total_pages,omitempty"`
 }
 
 // ComputerSessionPayload represents a computer use session start/end message.
@@ -327,6 +865,13 @@ type ComputerSessionPayload struct {
 	ViewportH   int    `json:"viewport_h"`
 	Headless    bool   `json:"headless"`
 	ContainerID string `json:"container_id,omitempty"` // SPEC-COMPUTER-USE-002: 컨테이너 ID
+	// MemoryLimit은 세션 컨테이너에 요청하는 메모리 제한이다 (예: "512m", 설정된 세션 상한을 넘을 수 없음).
+	MemoryLimit string `json:"memory_limit,omitempty"`
+	// CPULimit은 세션 컨테이너에 요청하는 CPU 제한이다 (예: "0.5", 설정된 세션 상한을 넘을 수 없음).
+	CPULimit string `json:"cpu_limit,omitempty"`
+	// PersistProfile은 워크스페이스별 브라우저 프로필(쿠키, localStorage)을 세션 간 유지할지 여부이다.
+	// true이면 세션 시작 시 저장된 프로필을 복원하고, 종료 시 현재 상태를 저장한다.
+	PersistProfile bool `json:"persist_profile,omitempty"`
 }
 
 // ComputerPoolStatusPayload는 컨테이너 풀 상태를 보고한다.
@@ -452,6 +997,9 @@ type AgentResponseRequestPayload struct {
 	ResponseMode     string            `json:"response_mode,omitempty"`
 	ToolLoopMessages []ToolLoopMessage `json:"tool_loop_messages,omitempty"`
 	ToolDefinitions  []ToolDefinition  `json:"tool_definitions,omitempty"`
+	// WorkspaceID is the workspace the work belongs to. The bridge applies its
+	// per-workspace concurrency quota to it; empty means the session workspace.
+	WorkspaceID string `json:"workspace_id,omitempty"`
 }
 
 // AgentResponseStreamPayload is sent from bridge to server for streaming text chunks.
@@ -474,15 +1022,22 @@ type AgentResponseCompletePayload struct {
 	Provider    string         `json:"provider,omitempty"`
 	StopReason  string         `json:"stop_reason,omitempty"`
 	ToolCalls   []ToolLoopCall `json:"tool_calls,omitempty"`
+	// OutputTruncated and Overflow have the same meaning as in TaskResultPayload.
+	OutputTruncated bool          `json:"output_truncated,omitempty"`
+	Overflow        []OverflowRef `json:"overflow,omitempty"`
 }
 
 // AgentResponseErrorPayload is sent from bridge to server when agent response fails.
 type AgentResponseErrorPayload struct {
-	Type        string `json:"type,omitempty"`
-	ExecutionID string `json:"execution_id"`
-	Code        string `json:"code"`
-	Message     string `json:"message"`
-	Retryable   bool   `json:"retryable"`
+	Type        string        `json:"type,omitempty"`
+	ExecutionID string        `json:"execution_id"`
+	Code        string        `json:"code"`
+	Message     string        `json:"message"`
+	Retryable   bool          `json:"retryable"`
+	Severity    ErrorSeverity `json:"severity,omitempty"`
+	Hint        string        `json:"hint,omitempty"`
+	// Timeout tells which timeout expired when Code is "TIMEOUT".
+	Timeout *TimeoutDetail `json:"timeout,omitempty"`
 }
 
 // Authentication error codes for ConnectAckPayload.ErrorCode.
@@ -511,6 +1066,55 @@ func IsCompatibleProtocolVersion(version string) bool {
 	return wantMajor == gotMajor && wantMinor == gotMinor
 }
 
+// SupportedAgentProtocolVersions returns the wire contract versions this module can speak, newest first.
+func SupportedAgentProtocolVersions() []string {
+	return []string{AgentProtocolVersion, PreviousAgentProtocolVersion}
+}
+
+// IsSupportedProtocolVersion reports whether the given version matches the major/minor
+// of one of SupportedAgentProtocolVersions.
+func IsSupportedProtocolVersion(version string) bool {
+	gotMajor, gotMinor, err := parseProtocolVersion(version)
+	if err != nil {
+		return false
+	}
+	for _, supported := range SupportedAgentProtocolVersions() {
+		major, minor, err := parseProtocolVersion(supported)
+		if err == nil && major == gotMajor && minor == gotMinor {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiateProtocolVersion picks the newest version in offered that is supported locally.
+// Servers call it with AgentConnectPayload.SupportedProtocolVersions, falling back to
+// the single ProtocolVersion for agents that do not advertise a list.
+func NegotiateProtocolVersion(offered []string) (string, bool) {
+	best := ""
+	bestMajor, bestMinor := -1, -1
+	for _, version := range offered {
+		if !IsSupportedProtocolVersion(version) {
+			continue
+		}
+		major, minor, _ := parseProtocolVersion(version)
+		if major > bestMajor || (major == bestMajor && minor > bestMinor) {
+			best, bestMajor, bestMinor = version, major, minor
+		}
+	}
+	return best, best != ""
+}
+
+// ProtocolMinorVersion returns the "major.minor" part of a protocol version.
+// Peers with the same minor version are wire-compatible.
+func ProtocolMinorVersion(version string) (string, error) {
+	major, minor, err := parseProtocolVersion(version)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d.%d", major, minor), nil
+}
+
 func parseProtocolVersion(version string) (int, int, error) {
 	parts := strings.Split(strings.TrimSpace(version), ".")
 	if len(parts) < 2 {
@@ -580,10 +1184,12 @@ type CodingRelayCompletePayload struct {
 
 // CodingRelayErrorPayload는 Bridge가 릴레이 세션 오류를 Server에 보고한다.
 type CodingRelayErrorPayload struct {
-	RequestID string `json:"request_id"`
-	Error     string `json:"error"`
-	Code      string `json:"code"`
-	SessionID string `json:"session_id,omitempty"`
+	RequestID string        `json:"request_id"`
+	Error     string        `json:"error"`
+	Code      string        `json:"code"`
+	SessionID string        `json:"session_id,omitempty"`
+	Severity  ErrorSeverity `json:"severity,omitempty"`
+	Hint      string        `json:"hint,omitempty"`
 }
 
 // CodingRelayProgressPayload는 Bridge가 이터레이션 진행 상황을 Server에 업데이트한다.
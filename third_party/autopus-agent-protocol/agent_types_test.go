@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestComputerMessageTypeConstants verifies constant values for computer use message types.
@@ -435,6 +436,143 @@ func TestAgentResponseCompletePayload_ToolCallsJSONRoundTrip(t *testing.T) {
 	}
 }
 
+func TestPriorityAndQueueWaitJSON(t *testing.T) {
+	var req TaskRequestPayload
+	if err := json.Unmarshal([]byte(`{"execution_id":"exec-1","priority":"high"}`), &req); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if req.Priority != PriorityHigh {
+		t.Fatalf("Priority = %q, want %q", req.Priority, PriorityHigh)
+	}
+
+	for _, v := range []any{BuildRequestPayload{}, TestRequestPayload{}, TaskResultPayload{}, BuildResultPayload{}, TestResultPayload{}} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if strings.Contains(string(data), "priority") || strings.Contains(string(data), "queue_wait_ms") {
+			t.Fatalf("priority/queue_wait_ms should be omitted when empty: %s", data)
+		}
+	}
+
+	data, err := json.Marshal(BuildResultPayload{ExecutionID: "exec-2", QueueWaitMs: 1500})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"queue_wait_ms":1500`) {
+		t.Fatalf("queue_wait_ms missing: %s", data)
+	}
+}
+
+func TestTestResultPayload_FlakyJSON(t *testing.T) {
+	data, err := json.Marshal(TestResultPayload{ExecutionID: "exec-1", Success: true})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "flaky") {
+		t.Fatalf("flaky should be omitted when reruns were not performed: %s", data)
+	}
+
+	payload := TestResultPayload{
+		ExecutionID: "exec-2",
+		Success:     true,
+		Flaky: &FlakyTestReport{
+			MaxRetries: 2,
+			Reruns:     1,
+			Flaky:      []FlakyTest{{Name: "TestRace", Attempts: 2, FlakyCount: 3}},
+		},
+	}
+	data, err = json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded TestResultPayload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Flaky == nil || len(decoded.Flaky.Flaky) != 1 || decoded.Flaky.Flaky[0].FlakyCount != 3 {
+		t.Fatalf("Flaky = %+v", decoded.Flaky)
+	}
+	if !strings.Contains(string(data), `"attempts":2`) || strings.Contains(string(data), "last_flaky_at") {
+		t.Fatalf("unexpected flaky JSON: %s", data)
+	}
+}
+
+func TestTaskResultPayload_WorkspaceChangesJSON(t *testing.T) {
+	data, err := json.Marshal(TaskResultPayload{ExecutionID: "exec-1", Output: "done"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "workspace_changes") {
+		t.Fatalf("workspace_changes should be omitted when nil: %s", data)
+	}
+
+	payload := TaskResultPayload{
+		ExecutionID: "exec-2",
+		WorkspaceChanges: &WorkspaceChanges{
+			Files:     []WorkspaceFileChange{{Path: "main.go", Status: WorkspaceFileModified}},
+			Diff:      "--- a/main.go\n+++ b/main.go\n",
+			Conflicts: []string{"go.mod"},
+		},
+	}
+	data, err = json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded TaskResultPayload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	changes := decoded.WorkspaceChanges
+	if changes == nil || len(changes.Files) != 1 || changes.Files[0].Status != WorkspaceFileModified {
+		t.Fatalf("WorkspaceChanges = %+v", changes)
+	}
+	if changes.Applied {
+		t.Fatal("Applied = true, want false")
+	}
+	if len(changes.Conflicts) != 1 || changes.Conflicts[0] != "go.mod" {
+		t.Fatalf("Conflicts = %v", changes.Conflicts)
+	}
+}
+
+func TestAgentConnectPayload_CustomToolsJSON(t *testing.T) {
+	payload := AgentConnectPayload{
+		Version: "1.0.0",
+		CustomTools: []CustomToolDefinition{{
+			Name:        "deploy_preview",
+			Description: "Deploy a preview environment",
+			InputSchema: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"branch"},
+			},
+		}},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded AgentConnectPayload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.CustomTools) != 1 || decoded.CustomTools[0].Name != "deploy_preview" {
+		t.Fatalf("CustomTools = %+v", decoded.CustomTools)
+	}
+	if decoded.CustomTools[0].InputSchema["type"] != "object" {
+		t.Fatalf("InputSchema = %v", decoded.CustomTools[0].InputSchema)
+	}
+
+	data, err = json.Marshal(AgentConnectPayload{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "custom_tools") {
+		t.Fatalf("custom_tools should be omitted when empty: %s", data)
+	}
+}
+
 func TestIsCompatibleProtocolVersion(t *testing.T) {
 	tests := []struct {
 		version string
@@ -1356,3 +1494,204 @@ func TestComputerPoolStatusPayload_Marshal(t *testing.T) {
 		}
 	})
 }
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		offered []string
+		want    string
+		ok      bool
+	}{
+		{name: "newest supported", offered: []string{PreviousAgentProtocolVersion, AgentProtocolVersion}, want: AgentProtocolVersion, ok: true},
+		{name: "previous only", offered: []string{"1.0.2"}, want: "1.0.2", ok: true},
+		{name: "skips unsupported", offered: []string{"1.9.0", PreviousAgentProtocolVersion}, want: PreviousAgentProtocolVersion, ok: true},
+		{name: "none supported", offered: []string{"0.9.0", "2.0.0"}, ok: false},
+		{name: "empty", offered: nil, ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := NegotiateProtocolVersion(tt.offered)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("%s: NegotiateProtocolVersion(%v) = (%q, %v), want (%q, %v)", tt.name, tt.offered, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsSupportedProtocolVersion(t *testing.T) {
+	for _, version := range SupportedAgentProtocolVersions() {
+		if !IsSupportedProtocolVersion(version) {
+			t.Fatalf("IsSupportedProtocolVersion(%q) = false, want true", version)
+		}
+	}
+	for _, version := range []string{"1.2.0", "0.9.0", "", "invalid"} {
+		if IsSupportedProtocolVersion(version) {
+			t.Fatalf("IsSupportedProtocolVersion(%q) = true, want false", version)
+		}
+	}
+}
+
+func TestProgressStep_Percent(t *testing.T) {
+	tests := []struct {
+		step ProgressStep
+		want int
+	}{
+		{ProgressStep{Index: 0, Total: 4, Status: ProgressStepRunning}, 0},
+		{ProgressStep{Index: 1, Total: 4, Status: ProgressStepCompleted}, 50},
+		{ProgressStep{Index: 3, Total: 4, Status: ProgressStepSkipped}, 100},
+		{ProgressStep{Index: 2, Total: 0, Status: ProgressStepFailed}, 0},
+		{ProgressStep{Index: 5, Total: 4, Status: ProgressStepCompleted}, 100},
+	}
+	for _, tt := range tests {
+		if got := tt.step.Percent(); got != tt.want {
+			t.Errorf("%+v.Percent() = %d, want %d", tt.step, got, tt.want)
+		}
+	}
+}
+
+func TestTaskProgressPayload_Step(t *testing.T) {
+	payload := TaskProgressPayload{
+		ExecutionID: "exec-1",
+		Progress:    50,
+		Type:        ProgressTypeStep,
+		Step: &ProgressStep{
+			Name:   "build",
+			Index:  1,
+			Total:  2,
+			Status: ProgressStepRunning,
+			Tools:  []ProgressToolEvent{{Name: "linux/amd64", Status: ProgressStepCompleted, DurationMs: 120}},
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `"step":{"name":"build","index":1,"total":2,"status":"running","tools":[{"name":"linux/amd64","status":"completed","duration_ms":120}]}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Marshal = %s, want to contain %s", data, want)
+	}
+
+	data, _ = json.Marshal(TaskProgressPayload{ExecutionID: "exec-2"})
+	if strings.Contains(string(data), `"step"`) {
+		t.Errorf("step should be omitted when nil: %s", data)
+	}
+}
+
+func TestAgentHeartbeatPayload_Credentials(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := AgentHeartbeatPayload{
+		Credentials: &CredentialStatus{State: CredentialStateExpired, RefreshExpiresAt: &expiresAt, IntakePaused: true},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `"credentials":{"state":"expired","refresh_expires_at":"2026-01-02T03:04:05Z","intake_paused":true}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Marshal = %s, want to contain %s", data, want)
+	}
+
+	data, _ = json.Marshal(AgentHeartbeatPayload{})
+	if strings.Contains(string(data), `"credentials"`) {
+		t.Errorf("credentials should be omitted when nil: %s", data)
+	}
+}
+
+func TestAgentConnectPayload_ReadOnly(t *testing.T) {
+	data, err := json.Marshal(AgentConnectPayload{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"read_only":true`) {
+		t.Errorf("Marshal = %s, want to contain read_only", data)
+	}
+
+	data, _ = json.Marshal(AgentConnectPayload{})
+	if strings.Contains(string(data), `"read_only"`) {
+		t.Errorf("read_only should be omitted when false: %s", data)
+	}
+}
+
+func TestAgentHeartbeatPayload_Concurrency(t *testing.T) {
+	payload := AgentHeartbeatPayload{
+		Concurrency: &ConcurrencyStatus{
+			Limit:      4,
+			Active:     3,
+			Queued:     1,
+			Workspaces: []WorkspaceConcurrency{{WorkspaceID: "ws-1", Limit: 2, Active: 2, Queued: 1}},
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `"concurrency":{"limit":4,"active":3,"queued":1,"workspaces":[{"workspace_id":"ws-1","limit":2,"active":2,"queued":1}]}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Marshal = %s, want to contain %s", data, want)
+	}
+
+	data, _ = json.Marshal(AgentHeartbeatPayload{})
+	if strings.Contains(string(data), `"concurrency"`) {
+		t.Errorf("concurrency should be omitted when nil: %s", data)
+	}
+}
+
+func TestAgentConnectPayload_Identity(t *testing.T) {
+	payload := AgentConnectPayload{
+		Identity: &BridgeIdentity{ID: "b-123", Name: "build-mac", Labels: map[string]string{"team": "backend"}},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `"identity":{"id":"b-123","name":"build-mac","labels":{"team":"backend"}}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Marshal = %s, want to contain %s", data, want)
+	}
+
+	data, _ = json.Marshal(AgentHeartbeatPayload{})
+	if strings.Contains(string(data), `"identity"`) {
+		t.Errorf("identity should be omitted when nil: %s", data)
+	}
+}
+
+func TestTaskResultPayload_Overflow(t *testing.T) {
+	payload := TaskResultPayload{
+		ExecutionID:     "exec-1",
+		OutputTruncated: true,
+		Overflow:        []OverflowRef{{Field: OverflowFieldOutput, Path: "/tmp/exec-1/output.txt", SizeBytes: 2048, SHA256: "abc"}},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `"output_truncated":true,"overflow":[{"field":"output","path":"/tmp/exec-1/output.txt","size_bytes":2048,"sha256":"abc"}]`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Marshal = %s, want to contain %s", data, want)
+	}
+
+	data, _ = json.Marshal(TaskResultPayload{})
+	if strings.Contains(string(data), `"overflow"`) || strings.Contains(string(data), `"output_truncated"`) {
+		t.Errorf("overflow fields should be omitted when unset: %s", data)
+	}
+}
+
+func TestTaskErrorPayload_Timeout(t *testing.T) {
+	payload := TaskErrorPayload{
+		ExecutionID: "exec-1",
+		Code:        "TIMEOUT",
+		Timeout:     &TimeoutDetail{Kind: AgentMsgTaskReq, Source: TimeoutSourceMessageType, TimeoutSeconds: 600},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `"timeout":{"kind":"task_request","source":"message_type","timeout_seconds":600}`
+	if !strings.Contains(string(data), want) {
+		t.Errorf("Marshal = %s, want to contain %s", data, want)
+	}
+
+	data, _ = json.Marshal(AgentResponseErrorPayload{})
+	if strings.Contains(string(data), `"timeout"`) {
+		t.Errorf("timeout should be omitted when nil: %s", data)
+	}
+}
@@ -0,0 +1,56 @@
+package ws
+
+// config_update 설정 이름
+const (
+	// ConfigSettingMaxConcurrentTasks는 동시에 실행할 작업 수 설정입니다.
+	ConfigSettingMaxConcurrentTasks = "max_concurrent_tasks"
+	// ConfigSettingAllowedTools는 승인 없이 실행할 사용자 정의 도구 목록 설정입니다.
+	ConfigSettingAllowedTools = "allowed_tools"
+	// ConfigSettingLogLevel은 Bridge 로그 레벨 설정입니다.
+	ConfigSettingLogLevel = "log_level"
+)
+
+// ConfigUpdatePayload는 서버가 실행 중인 Bridge의 설정을 변경하는 메시지입니다.
+// 생략된 설정은 변경하지 않습니다. Bridge는 로컬 설정의 허용 범위 안에 있는 설정만 적용하고
+// 결과를 config_update_ack로 응답합니다.
+// Message type: config_update (Server -> Bridge)
+type ConfigUpdatePayload struct {
+	// Revision은 서버가 부여한 설정 버전입니다. Bridge는 하트비트의 config_revision으로 알립니다.
+	Revision string `json:"revision,omitempty"`
+	// MaxConcurrentTasks는 동시에 실행할 최대 작업 수입니다.
+	MaxConcurrentTasks *int `json:"max_concurrent_tasks,omitempty"`
+	// AllowedTools는 로컬 설정에 더해 승인 없이 실행할 사용자 정의 도구 목록입니다.
+	// 생략(null)하면 변경하지 않고, 빈 배열이면 서버가 추가한 도구를 모두 제거합니다.
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+	// LogLevel은 Bridge 로그 레벨입니다 ("debug", "info", "warn", "error").
+	LogLevel string `json:"log_level,omitempty"`
+}
+
+// ConfigUpdateAckPayload는 config_update 적용 결과입니다. config_update 메시지 ID로 응답합니다.
+// Message type: config_update_ack (Bridge -> Server)
+type ConfigUpdateAckPayload struct {
+	// Revision은 적용을 시도한 config_update의 Revision입니다.
+	Revision string `json:"revision,omitempty"`
+	// Applied는 적용된 설정 이름 목록입니다.
+	Applied []string `json:"applied,omitempty"`
+	// Rejected는 허용 범위를 벗어나 거부된 설정과 사유입니다.
+	Rejected []ConfigUpdateRejection `json:"rejected,omitempty"`
+	// Effective는 적용 후 Bridge에서 유효한 설정 값입니다.
+	Effective ConfigSettings `json:"effective"`
+}
+
+// ConfigUpdateRejection은 거부된 설정과 사유입니다.
+type ConfigUpdateRejection struct {
+	Setting string `json:"setting"`
+	Reason  string `json:"reason"`
+}
+
+// ConfigSettings는 서버가 변경할 수 있는 Bridge 설정의 현재 값입니다.
+type ConfigSettings struct {
+	// MaxConcurrentTasks는 동시에 실행할 최대 작업 수입니다. 0이면 제한하지 않습니다.
+	MaxConcurrentTasks int `json:"max_concurrent_tasks"`
+	// AllowedTools는 서버가 추가한 사용자 정의 도구 허용 목록입니다.
+	AllowedTools []string `json:"allowed_tools"`
+	// LogLevel은 현재 로그 레벨입니다.
+	LogLevel string `json:"log_level"`
+}
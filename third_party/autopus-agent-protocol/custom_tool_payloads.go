@@ -0,0 +1,53 @@
+package ws
+
+// CustomToolDefinition은 사용자가 Bridge 설정에 정의한 로컬 도구의 선언입니다.
+// Bridge는 agent_connect의 custom_tools로 이 목록을 알리고,
+// 서버는 custom_tool_request로 도구 실행을 요청합니다.
+type CustomToolDefinition struct {
+	// Name은 도구 이름입니다 (소문자, 숫자, 밑줄).
+	Name string `json:"name"`
+	// Description은 에이전트에게 보여줄 도구 설명입니다.
+	Description string `json:"description,omitempty"`
+	// InputSchema는 도구 인자의 JSON Schema(object)입니다.
+	InputSchema map[string]interface{} `json:"input_schema,omitempty"`
+}
+
+// CustomToolRequestPayload는 서버가 Bridge에 보내는 사용자 정의 도구 실행 요청입니다.
+// Message type: custom_tool_request (Server -> Bridge)
+type CustomToolRequestPayload struct {
+	// RequestID는 요청 ID입니다 (서버에서 생성).
+	RequestID string `json:"request_id"`
+	// Tool은 실행할 도구 이름입니다.
+	Tool string `json:"tool"`
+	// Arguments는 도구 인자입니다. 도구의 InputSchema로 검증됩니다.
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	// CorrelationID는 실행 그래프 추적 ID입니다.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// CustomToolResultPayload는 Bridge가 서버에 보내는 사용자 정의 도구 실행 결과입니다.
+// Message type: custom_tool_result (Bridge -> Server)
+type CustomToolResultPayload struct {
+	// RequestID는 요청 ID입니다.
+	RequestID string `json:"request_id"`
+	// Tool은 실행한 도구 이름입니다.
+	Tool string `json:"tool"`
+	// Success는 명령이 종료 코드 0으로 끝났는지 여부입니다.
+	Success bool `json:"success"`
+	// ExitCode는 명령 종료 코드입니다 (실행하지 못했으면 -1).
+	ExitCode int `json:"exit_code"`
+	// Stdout은 표준 출력입니다.
+	Stdout string `json:"stdout,omitempty"`
+	// Stderr는 표준 에러 출력입니다.
+	Stderr string `json:"stderr,omitempty"`
+	// StdoutTruncated는 표준 출력이 크기 제한으로 잘렸는지 여부입니다.
+	StdoutTruncated bool `json:"stdout_truncated,omitempty"`
+	// StderrTruncated는 표준 에러 출력이 크기 제한으로 잘렸는지 여부입니다.
+	StderrTruncated bool `json:"stderr_truncated,omitempty"`
+	// DurationMs는 실행 시간(밀리초)입니다.
+	DurationMs int64 `json:"duration_ms"`
+	// Error는 인자 검증 실패, 승인 거부, 실행 실패 등의 에러 메시지입니다.
+	Error string `json:"error,omitempty"`
+	// CorrelationID는 실행 그래프 추적 ID입니다.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
@@ -0,0 +1,62 @@
+package ws
+
+import "encoding/json"
+
+// 작업 위임 상태
+const (
+	// DelegationRequested는 Bridge가 위임을 요청하고 서버 응답을 기다리는 상태입니다.
+	DelegationRequested = "requested"
+	// DelegationAccepted는 서버가 대상 Bridge에 작업을 할당한 상태입니다.
+	DelegationAccepted = "accepted"
+	// DelegationRejected는 실행할 수 있는 Bridge가 없어 위임이 거절된 상태입니다.
+	DelegationRejected = "rejected"
+	// DelegationCompleted는 대상 Bridge가 작업을 마친 상태입니다.
+	DelegationCompleted = "completed"
+	// DelegationFailed는 대상 Bridge에서 작업이 실패한 상태입니다.
+	DelegationFailed = "failed"
+	// DelegationLocalFallback은 위임이 거절되거나 시간 초과되어 로컬에서 실행하는 상태입니다.
+	DelegationLocalFallback = "local_fallback"
+)
+
+// TaskDelegatePayload는 Bridge가 로컬에서 실행할 수 없는 작업을 같은 워크스페이스의
+// 다른 Bridge로 넘겨 달라고 서버에 요청하는 메시지입니다.
+// 서버는 TargetPlatform을 만족하는 Bridge에 원본 메시지를 그대로 전달하고,
+// 결과는 대상 Bridge가 평소처럼 서버로 보고합니다.
+// Message type: task_delegate (Bridge -> Server)
+type TaskDelegatePayload struct {
+	// ExecutionID는 위임할 작업의 실행 ID입니다 (cli_request는 메시지 ID).
+	ExecutionID string `json:"execution_id"`
+	// MessageType은 원본 요청 메시지 타입입니다 (예: "build_request").
+	MessageType string `json:"message_type"`
+	// Payload는 원본 요청 페이로드입니다.
+	Payload json.RawMessage `json:"payload"`
+	// TargetPlatform은 작업을 실행할 Bridge의 OS입니다 (GOOS 값, 예: "darwin"). 비어 있으면 제한 없음.
+	TargetPlatform string `json:"target_platform,omitempty"`
+	// SourcePlatform은 위임을 요청한 Bridge의 OS입니다.
+	SourcePlatform string `json:"source_platform,omitempty"`
+	// Reason은 위임 사유입니다.
+	Reason string `json:"reason,omitempty"`
+}
+
+// TaskDelegateStatusPayload는 서버가 위임 진행 상태를 요청한 Bridge에 알리는 메시지입니다.
+// Message type: task_delegate_status (Server -> Bridge)
+type TaskDelegateStatusPayload struct {
+	// ExecutionID는 위임한 작업의 실행 ID입니다.
+	ExecutionID string `json:"execution_id"`
+	// State는 위임 상태입니다 (accepted, rejected, completed, failed).
+	State string `json:"state"`
+	// TargetBridgeID는 작업을 맡은 Bridge ID입니다.
+	TargetBridgeID string `json:"target_bridge_id,omitempty"`
+	// Message는 상태에 대한 설명입니다 (예: 거절 사유).
+	Message string `json:"message,omitempty"`
+}
+
+// DelegationStatus는 TaskProgressPayload에 포함되는 위임 상태입니다.
+type DelegationStatus struct {
+	// State는 위임 상태입니다 (requested, accepted, rejected, completed, failed, local_fallback).
+	State string `json:"state"`
+	// TargetPlatform은 위임 대상 OS입니다.
+	TargetPlatform string `json:"target_platform,omitempty"`
+	// TargetBridgeID는 작업을 맡은 Bridge ID입니다.
+	TargetBridgeID string `json:"target_bridge_id,omitempty"`
+}
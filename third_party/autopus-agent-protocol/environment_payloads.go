@@ -0,0 +1,38 @@
+package ws
+
+import "time"
+
+// EnvironmentSnapshot은 작업을 실행한 Bridge 환경 정보입니다.
+// 서버가 실패한 작업을 재현하고 디버깅할 수 있도록 TaskResultPayload와 BuildResultPayload에 첨부됩니다.
+type EnvironmentSnapshot struct {
+	// OS는 운영체제입니다 (runtime.GOOS, 예: "linux", "darwin").
+	OS string `json:"os"`
+	// OSVersion은 운영체제 배포판/버전입니다 (예: "Ubuntu 24.04 LTS", "macOS 15.1").
+	OSVersion string `json:"os_version,omitempty"`
+	// Arch는 CPU 아키텍처입니다 (runtime.GOARCH).
+	Arch string `json:"arch"`
+	// CPUs는 논리 CPU 수입니다.
+	CPUs int `json:"cpus"`
+	// MemoryTotalMB는 전체 메모리 크기(MB)입니다. 확인할 수 없으면 0입니다.
+	MemoryTotalMB int64 `json:"memory_total_mb,omitempty"`
+	// BridgeVersion은 Bridge 버전입니다.
+	BridgeVersion string `json:"bridge_version,omitempty"`
+	// Tools는 도구 이름별 버전입니다 (예: "go": "go1.25.0"). 설치되지 않은 도구는 생략됩니다.
+	Tools map[string]string `json:"tools,omitempty"`
+	// Providers는 AI 프로바이더 CLI 이름별 버전입니다 (예: "claude": "2.0.14").
+	Providers map[string]string `json:"providers,omitempty"`
+	// Git은 work_dir의 git 상태입니다. git 저장소가 아니면 nil입니다.
+	Git *GitSnapshot `json:"git,omitempty"`
+	// CollectedAt은 정적 환경 정보(OS, 도구 버전 등)를 수집한 시각입니다. 캐시된 값일 수 있습니다.
+	CollectedAt time.Time `json:"collected_at"`
+}
+
+// GitSnapshot은 작업 디렉토리의 git 상태입니다.
+type GitSnapshot struct {
+	// SHA는 HEAD 커밋 SHA입니다.
+	SHA string `json:"sha"`
+	// Branch는 현재 브랜치입니다. detached HEAD이면 "HEAD"입니다.
+	Branch string `json:"branch,omitempty"`
+	// Dirty는 추적 중인 파일에 커밋되지 않은 변경이 있는지 여부입니다.
+	Dirty bool `json:"dirty"`
+}
@@ -0,0 +1,61 @@
+package ws
+
+// Git 작업 종류 (GitRequestPayload.Operation)
+const (
+	GitOpClone    = "clone"
+	GitOpFetch    = "fetch"
+	GitOpCheckout = "checkout"
+	GitOpBranch   = "branch"
+	GitOpCommit   = "commit"
+	GitOpPush     = "push"
+)
+
+// GitRequestPayload는 서버가 Bridge에 보내는 git 작업 요청입니다.
+// 모든 작업은 Bridge에 설정된 git 작업 디렉토리(work_dir) 안에서만 수행됩니다.
+// Message type: git_request (Server -> Bridge)
+type GitRequestPayload struct {
+	// RequestID는 요청 ID입니다 (서버에서 생성).
+	RequestID string `json:"request_id"`
+	// Operation은 작업 종류입니다 (clone/fetch/checkout/branch/commit/push).
+	Operation string `json:"operation"`
+	// Repo는 work_dir 기준 레포지토리 상대 경로입니다.
+	// clone에서 비어있으면 RepoURL의 레포 이름을 사용합니다.
+	Repo string `json:"repo,omitempty"`
+	// RepoURL은 clone할 원격 레포지토리 URL입니다 (clone 전용).
+	RepoURL string `json:"repo_url,omitempty"`
+	// Remote는 fetch/push 대상 원격 이름입니다 (기본값: origin).
+	Remote string `json:"remote,omitempty"`
+	// Branch는 clone/checkout/branch/push 대상 브랜치입니다.
+	Branch string `json:"branch,omitempty"`
+	// StartPoint는 branch 작업에서 새 브랜치의 시작 지점입니다 (선택).
+	StartPoint string `json:"start_point,omitempty"`
+	// Message는 commit 메시지입니다 (commit 전용).
+	Message string `json:"message,omitempty"`
+	// Files는 commit에 포함할 레포 기준 파일 경로입니다. 비어있으면 모든 변경을 커밋합니다.
+	Files []string `json:"files,omitempty"`
+	// CorrelationID는 실행 그래프 추적 ID입니다.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// GitResultPayload는 Bridge가 서버에 보내는 git 작업 결과입니다.
+// Message type: git_result (Bridge -> Server)
+type GitResultPayload struct {
+	// RequestID는 요청 ID입니다.
+	RequestID string `json:"request_id"`
+	// Operation은 수행한 작업 종류입니다.
+	Operation string `json:"operation"`
+	// Success는 성공 여부입니다.
+	Success bool `json:"success"`
+	// Repo는 work_dir 기준 레포지토리 상대 경로입니다.
+	Repo string `json:"repo,omitempty"`
+	// Branch는 작업 후 현재 브랜치입니다.
+	Branch string `json:"branch,omitempty"`
+	// CommitSHA는 작업 후 HEAD 커밋 SHA입니다.
+	CommitSHA string `json:"commit_sha,omitempty"`
+	// ChangedFiles는 commit에 포함된 파일 목록입니다.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Error는 실패 시 에러 메시지입니다.
+	Error string `json:"error,omitempty"`
+	// CorrelationID는 실행 그래프 추적 ID입니다.
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
@@ -1,5 +1,7 @@
 package ws
 
+import "encoding/json"
+
 // MCPStartPayload is sent by the server to request MCP server startup on the bridge.
 type MCPStartPayload struct {
 	ServerName     string            `json:"server_name"`
@@ -27,16 +29,57 @@ type MCPStopPayload struct {
 
 // MCPErrorPayload is sent by the bridge when an MCP server encounters an error.
 type MCPErrorPayload struct {
-	ServerName string `json:"server_name"`
-	Error      string `json:"error"`
-	IsFatal    bool   `json:"is_fatal"`
+	ServerName string        `json:"server_name"`
+	Error      string        `json:"error"`
+	IsFatal    bool          `json:"is_fatal"`
+	Code       string        `json:"code,omitempty"`
+	Severity   ErrorSeverity `json:"severity,omitempty"`
+	Hint       string        `json:"hint,omitempty"`
 }
 
 // MCPServeStartPayload is sent by the server to request the bridge to start
 // its own MCP server (stdio transport) backed by the Autopus backend API.
 // SPEC-AI-003 M3 T-24
 type MCPServeStartPayload struct {
-	BackendURL string `json:"backend_url"` // Autopus 백엔드 API URL
+	BackendURL string `json:"backend_url"`    // Autopus 백엔드 API URL
+	Mode       string `json:"mode,omitempty"` // "stdio" | "embedded" (MCPServeMode* 상수, 비어 있으면 embedded)
+	// Instance names the MCP server instance so several can run side by side
+	// (different backend URLs or workspace scopes). Empty means MCPServeDefaultInstance.
+	Instance string `json:"instance,omitempty"`
+	// WorkspaceID scopes the instance: tools called without workspace_id use it.
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
+
+// MCPServeDefaultInstance is the instance name used when a payload omits Instance.
+const MCPServeDefaultInstance = "default"
+
+// MCP serve transport modes.
+const (
+	// MCPServeModeStdio runs the MCP server over the bridge process's stdin/stdout.
+	MCPServeModeStdio = "stdio"
+	// MCPServeModeEmbedded tunnels MCP JSON-RPC messages over the existing
+	// WebSocket connection as mcp_rpc messages.
+	MCPServeModeEmbedded = "embedded"
+)
+
+// MCPServeReadyPayload is sent by the bridge with mcp_serve_ready once the
+// MCP server accepts requests.
+type MCPServeReadyPayload struct {
+	Mode          string `json:"mode"`                     // 실제 적용된 모드
+	ServerName    string `json:"server_name,omitempty"`    // MCP 서버 이름
+	ServerVersion string `json:"server_version,omitempty"` // MCP 서버 버전
+	Instance      string `json:"instance,omitempty"`       // 시작된 인스턴스 이름
+}
+
+// MCPRPCPayload carries a single MCP JSON-RPC message in embedded mode.
+// The server sends requests/notifications; the bridge replies with the
+// JSON-RPC response using the same AgentMessage ID. Notifications get no reply.
+type MCPRPCPayload struct {
+	Message json.RawMessage `json:"message,omitempty"` // JSON-RPC 2.0 메시지 원문
+	Error   string          `json:"error,omitempty"`   // 터널 수준 에러 (MCP 서버 미시작 등)
+	// Instance routes the message to a named MCP serve instance. Empty means
+	// MCPServeDefaultInstance; the bridge echoes it in the reply.
+	Instance string `json:"instance,omitempty"`
 }
 
 // MCPServeStopPayload is sent by the server to request the bridge to stop
@@ -44,6 +87,8 @@ type MCPServeStartPayload struct {
 // SPEC-AI-003 M3 T-25
 type MCPServeStopPayload struct {
 	Reason string `json:"reason,omitempty"` // 중지 사유
+	// Instance selects the instance to stop. Empty stops every running instance.
+	Instance string `json:"instance,omitempty"`
 }
 
 // MCPServeResultPayload is sent by the bridge as a response to
@@ -54,4 +99,8 @@ type MCPServeResultPayload struct {
 	Status  string `json:"status"`            // "started", "stopped", "error"
 	Message string `json:"message,omitempty"` // 추가 메시지
 	Error   string `json:"error,omitempty"`   // 에러 메시지
+	// Instance is the instance the result refers to (empty when a stop covered all instances).
+	Instance string `json:"instance,omitempty"`
+	// Stopped lists the instances stopped by mcp_serve_stop.
+	Stopped []string `json:"stopped,omitempty"`
 }
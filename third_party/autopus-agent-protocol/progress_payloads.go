@@ -0,0 +1,70 @@
+package ws
+
+// ProgressTypeStep은 단계 정보만 담은 TaskProgressPayload의 Type 값입니다.
+const ProgressTypeStep = "step"
+
+// 진행 단계 및 도구 이벤트 상태
+const (
+	// ProgressStepRunning은 단계(또는 도구 호출)가 실행 중인 상태입니다.
+	ProgressStepRunning = "running"
+	// ProgressStepCompleted는 단계가 성공적으로 끝난 상태입니다.
+	ProgressStepCompleted = "completed"
+	// ProgressStepFailed는 단계가 실패한 상태입니다.
+	ProgressStepFailed = "failed"
+	// ProgressStepSkipped는 앞선 단계의 실패 등으로 단계를 실행하지 않은 상태입니다.
+	ProgressStepSkipped = "skipped"
+)
+
+// ProgressStep은 작업이 진행 중인 단계를 구조화해 전달합니다.
+// 서버는 Index/Total로 단계 목록을 그리고 Tools로 단계 안의 세부 작업을 펼쳐 보여줄 수 있습니다.
+// TaskProgressPayload.Step과 MCPCodegenProgressPayload.Step에 포함됩니다.
+type ProgressStep struct {
+	// Name은 단계 이름입니다 (예: "prepare", "build", "service_start").
+	Name string `json:"name"`
+	// Index는 0부터 시작하는 단계 순번입니다.
+	Index int `json:"index"`
+	// Total은 전체 단계 수입니다. 알 수 없으면 0입니다.
+	Total int `json:"total,omitempty"`
+	// Status는 단계 상태입니다 (running, completed, failed, skipped).
+	Status string `json:"status"`
+	// Detail은 단계에 대한 짧은 설명입니다 (예: 실패 사유).
+	Detail string `json:"detail,omitempty"`
+	// DurationMs는 끝난 단계의 실행 시간입니다.
+	DurationMs int64 `json:"duration_ms,omitempty"`
+	// Tools는 단계 안에서 일어난 도구 호출/하위 작업 이벤트입니다 (예: 빌드 매트릭스 대상, 실패 테스트 재실행).
+	Tools []ProgressToolEvent `json:"tools,omitempty"`
+}
+
+// ProgressToolEvent는 단계 안에서 일어난 도구 호출이나 하위 작업 하나의 상태입니다.
+type ProgressToolEvent struct {
+	// ID는 단계 안에서 이벤트를 구분하는 식별자입니다 (선택).
+	ID string `json:"id,omitempty"`
+	// Name은 도구 또는 하위 작업 이름입니다.
+	Name string `json:"name"`
+	// Status는 이벤트 상태입니다 (running, completed, failed, skipped).
+	Status string `json:"status"`
+	// Detail은 이벤트에 대한 짧은 설명입니다.
+	Detail string `json:"detail,omitempty"`
+	// DurationMs는 끝난 이벤트의 실행 시간입니다.
+	DurationMs int64 `json:"duration_ms,omitempty"`
+}
+
+// Percent는 단계 위치로 계산한 0-100 진행률을 반환합니다.
+// 끝난 단계(completed, failed, skipped)는 해당 단계까지 완료한 것으로 계산하고, Total이 0이면 0을 반환합니다.
+func (s ProgressStep) Percent() int {
+	if s.Total <= 0 {
+		return 0
+	}
+	done := s.Index
+	if s.Status != ProgressStepRunning {
+		done++
+	}
+	percent := done * 100 / s.Total
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
@@ -27,6 +27,8 @@ type MCPCodegenProgressPayload struct {
 	Phase    string `json:"phase"`    // "template_loading", "generating", "collecting"
 	Progress int    `json:"progress"` // 0-100
 	Message  string `json:"message"`
+	// Step is the phase as a structured progress step (same model as TaskProgressPayload.Step).
+	Step *ProgressStep `json:"step,omitempty"`
 }
 
 // MCPCodegenResultPayload is sent from bridge to server with generated code.
@@ -39,8 +41,17 @@ type MCPCodegenResultPayload struct {
 	GenerationDurMs  int64              `json:"generation_duration_ms"`
 	ClaudeTokensUsed int                `json:"claude_tokens_used,omitempty"`
 	Error            string             `json:"error,omitempty"`
+	ErrorCode        string             `json:"error_code,omitempty"` // e.g. MCPErrorCodeQuotaExceeded
+	ErrorHint        string             `json:"error_hint,omitempty"` // user-facing next step for ErrorCode
 }
 
+// Error codes for MCPCodegenResultPayload.ErrorCode and MCPDeployResultPayload.ErrorCode.
+const (
+	// MCPErrorCodeQuotaExceeded indicates the codegen sandbox or MCP deploy
+	// directory disk quota on the bridge was exceeded.
+	MCPErrorCodeQuotaExceeded = "QUOTA_EXCEEDED"
+)
+
 // MCPGeneratedFile represents a single generated file.
 type MCPGeneratedFile struct {
 	Path      string `json:"path"`
@@ -50,11 +61,47 @@ type MCPGeneratedFile struct {
 
 // MCPDeployPayload is sent from server to bridge to deploy approved MCP code.
 // Message type: mcp_deploy (Server -> Bridge)
+//
+// Files carries each file as JSON content. For large services the server can
+// instead set Archive, in which case Files is ignored and the service
+// directory is replaced by the archive contents.
+//
+// With VerifyOnly the bridge writes the files to a staging directory, runs
+// syntax/dependency checks (and a smoke start with SmokeTest) and reports the
+// findings in MCPDeployResultPayload.Verification without touching the live
+// deploy path.
 type MCPDeployPayload struct {
 	ServiceName      string             `json:"service_name"`
 	Files            []MCPGeneratedFile `json:"files"`
+	Archive          *MCPDeployArchive  `json:"archive,omitempty"`
 	SecurityManifest *SecurityManifest  `json:"security_manifest"`
 	EnvVars          map[string]string  `json:"env_vars,omitempty"`
+	VerifyOnly       bool               `json:"verify_only,omitempty"`
+	SmokeTest        bool               `json:"smoke_test,omitempty"` // verify_only: start the server and ping it
+}
+
+// MCPDeployArchiveFormatTarGz is the gzip-compressed tarball archive format.
+const MCPDeployArchiveFormatTarGz = "tar.gz"
+
+// MCPDeployArchive describes the archive carrying the service files of an mcp_deploy.
+//
+// The archive is sent either inline (Data) or in chunks: the server sets
+// TransferID and TotalChunks, then sends TotalChunks mcp_deploy_chunk messages
+// in order after the mcp_deploy message.
+type MCPDeployArchive struct {
+	Format      string `json:"format"`                 // MCPDeployArchiveFormatTarGz
+	Data        string `json:"data,omitempty"`         // base64-encoded archive (inline mode)
+	TransferID  string `json:"transfer_id,omitempty"`  // chunked mode transfer ID
+	TotalChunks int    `json:"total_chunks,omitempty"` // chunked mode chunk count
+	SHA256      string `json:"sha256,omitempty"`       // hex SHA-256 of the archive bytes (optional)
+}
+
+// MCPDeployChunkPayload carries one chunk of a chunked deploy archive.
+// Message type: mcp_deploy_chunk (Server -> Bridge)
+type MCPDeployChunkPayload struct {
+	TransferID string `json:"transfer_id"`
+	Index      int    `json:"index"` // 0-based, chunks must arrive in order
+	Data       string `json:"data"`  // base64-encoded chunk bytes
 }
 
 // MCPDeployResultPayload is sent from bridge to server after deployment.
@@ -64,6 +111,26 @@ type MCPDeployResultPayload struct {
 	Success     bool   `json:"success"`
 	DeployPath  string `json:"deploy_path,omitempty"`
 	Error       string `json:"error,omitempty"`
+	ErrorCode   string `json:"error_code,omitempty"` // e.g. MCPErrorCodeQuotaExceeded
+	ErrorHint   string `json:"error_hint,omitempty"` // user-facing next step for ErrorCode
+	// Verification is set for verify_only deploys. Success is true only when
+	// the verification ran and found no errors.
+	Verification *MCPDeployVerification `json:"verification,omitempty"`
+}
+
+// MCPDeployVerification is the result of a verify_only deploy.
+type MCPDeployVerification struct {
+	Passed      bool               `json:"passed"`
+	SmokeTested bool               `json:"smoke_tested"`
+	Findings    []MCPDeployFinding `json:"findings,omitempty"`
+}
+
+// MCPDeployFinding is a single problem found while verifying a deploy.
+type MCPDeployFinding struct {
+	Severity string `json:"severity"`       // "error", "warning"
+	Check    string `json:"check"`          // "layout", "syntax", "dependency", "command", "smoke"
+	File     string `json:"file,omitempty"` // path relative to the service directory
+	Message  string `json:"message"`
 }
 
 // MCPHealthReportPayload is sent periodically from bridge to server.
@@ -85,3 +152,36 @@ type MCPServerHealth struct {
 	MemoryMB      int     `json:"memory_mb"`
 	LastError     *string `json:"last_error,omitempty"`
 }
+
+// MCP server event kinds.
+const (
+	MCPServerEventLog             = "log"              // notifications/message
+	MCPServerEventResourceUpdated = "resource_updated" // notifications/resources/updated
+	MCPServerEventListChanged     = "list_changed"     // notifications/{tools,resources,prompts}/list_changed
+	MCPServerEventProgress        = "progress"         // notifications/progress
+	MCPServerEventExited          = "exited"           // the server process exited
+	MCPServerEventOther           = "other"            // any other notification method
+)
+
+// MCPServerEventPayload carries summarized notifications emitted by a bridge-managed MCP server
+// since the previous payload. Identical events are coalesced and counted.
+// Message type: mcp_server_event (Bridge -> Server)
+type MCPServerEventPayload struct {
+	Server     string           `json:"server"`
+	Events     []MCPServerEvent `json:"events"`
+	Dropped    int              `json:"dropped,omitempty"` // events discarded because the batch was full
+	ReportedAt string           `json:"reported_at"`
+}
+
+// MCPServerEvent is one summarized notification from an MCP server.
+type MCPServerEvent struct {
+	Kind    string `json:"kind"`              // see MCPServerEvent* constants
+	Method  string `json:"method,omitempty"`  // JSON-RPC notification method
+	Level   string `json:"level,omitempty"`   // log level for "log" events
+	Logger  string `json:"logger,omitempty"`  // logger name for "log" events
+	URI     string `json:"uri,omitempty"`     // resource URI for "resource_updated" events
+	Message string `json:"message,omitempty"` // truncated log text, progress message or exit reason
+	Count   int    `json:"count"`             // number of identical events coalesced
+	FirstAt string `json:"first_at"`
+	LastAt  string `json:"last_at"`
+}
@@ -505,6 +505,37 @@ func TestMCPDeployResultPayload_JSON(t *testing.T) {
 		}
 	})
 
+	t.Run("verify_only variant", func(t *testing.T) {
+		original := MCPDeployResultPayload{
+			ServiceName: "svc",
+			Verification: &MCPDeployVerification{
+				SmokeTested: true,
+				Findings: []MCPDeployFinding{
+					{Severity: "error", Check: "dependency", File: "src/index.ts", Message: "undeclared package"},
+				},
+			},
+		}
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !strings.Contains(string(data), `"verification":{"passed":false,"smoke_tested":true`) {
+			t.Errorf("unexpected verification JSON: %s", data)
+		}
+
+		var decoded MCPDeployResultPayload
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if decoded.Verification == nil || len(decoded.Verification.Findings) != 1 {
+			t.Fatalf("Verification = %+v, want 1 finding", decoded.Verification)
+		}
+		if got := decoded.Verification.Findings[0]; got.File != "src/index.ts" || got.Check != "dependency" {
+			t.Errorf("Finding = %+v", got)
+		}
+	})
+
 	t.Run("omitempty fields omitted when empty", func(t *testing.T) {
 		successPayload := MCPDeployResultPayload{
 			ServiceName: "svc",
@@ -534,6 +565,44 @@ func TestMCPDeployResultPayload_JSON(t *testing.T) {
 	})
 }
 
+// TestMCPServerEventPayload_JSON verifies JSON roundtrip and omitted optional fields for MCPServerEventPayload.
+func TestMCPServerEventPayload_JSON(t *testing.T) {
+	original := MCPServerEventPayload{
+		Server: "weather-service",
+		Events: []MCPServerEvent{
+			{Kind: MCPServerEventLog, Method: "notifications/message", Level: "error", Logger: "db", Message: "connection refused", Count: 3, FirstAt: "2026-02-18T10:30:00Z", LastAt: "2026-02-18T10:30:04Z"},
+			{Kind: MCPServerEventResourceUpdated, Method: "notifications/resources/updated", URI: "file:///tmp/report.csv", Count: 1, FirstAt: "2026-02-18T10:30:01Z", LastAt: "2026-02-18T10:30:01Z"},
+		},
+		Dropped:    2,
+		ReportedAt: "2026-02-18T10:30:05Z",
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded MCPServerEventPayload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Server != "weather-service" || decoded.Dropped != 2 || len(decoded.Events) != 2 {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+	if decoded.Events[0] != original.Events[0] || decoded.Events[1] != original.Events[1] {
+		t.Errorf("Events = %+v, want %+v", decoded.Events, original.Events)
+	}
+
+	data, err = json.Marshal(MCPServerEventPayload{Server: "s", Events: []MCPServerEvent{{Kind: MCPServerEventExited, Count: 1}}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, field := range []string{"dropped", "level", "logger", "uri", "method"} {
+		if strings.Contains(string(data), `"`+field+`"`) {
+			t.Errorf("empty %s should be omitted: %s", field, data)
+		}
+	}
+}
+
 // TestMCPHealthReportPayload_JSON verifies JSON marshal/unmarshal roundtrip for MCPHealthReportPayload.
 func TestMCPHealthReportPayload_JSON(t *testing.T) {
 	t.Run("multiple servers in report", func(t *testing.T) {
@@ -847,6 +916,8 @@ func TestAllPayloads_EmptyStruct(t *testing.T) {
 		{"MCPDeployResultPayload", MCPDeployResultPayload{}},
 		{"MCPHealthReportPayload", MCPHealthReportPayload{}},
 		{"MCPServerHealth", MCPServerHealth{}},
+		{"MCPServerEventPayload", MCPServerEventPayload{}},
+		{"MCPServerEvent", MCPServerEvent{}},
 		{"SecurityManifest", SecurityManifest{}},
 	}
 
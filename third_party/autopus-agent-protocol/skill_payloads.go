@@ -38,8 +38,44 @@ type CLIResultPayload struct {
 	DurationMs      int64            `json:"duration_ms"`
 	StdoutTruncated bool             `json:"stdout_truncated"`
 	StderrTruncated bool             `json:"stderr_truncated"`
+	// OutputMessages is the number of cli_output messages streamed before this result.
+	OutputMessages int `json:"output_messages,omitempty"`
+	// Timeout is set when the run was stopped by an expired timeout.
+	Timeout *TimeoutDetail `json:"timeout,omitempty"`
+	// Overflow points to the full stdout/stderr kept on the bridge host when
+	// they were truncated to fit the bridge's size limits.
+	Overflow []OverflowRef `json:"overflow,omitempty"`
 }
 
+// CLIOutputPayload carries a batch of output lines streamed while a CLI command runs.
+// It uses the cli_request message ID. The cli_result still contains the full
+// (truncation-policy limited) stdout/stderr.
+// Message type: cli_output (Bridge -> Server)
+type CLIOutputPayload struct {
+	// Seq starts at 0 and increases by one per cli_output message of the same request.
+	Seq   int             `json:"seq"`
+	Lines []CLIOutputLine `json:"lines,omitempty"`
+	// DroppedLines is the number of lines omitted from streaming since the previous
+	// message because of batch or stream size limits.
+	DroppedLines int `json:"dropped_lines,omitempty"`
+	// Truncated is set once the stream size limit is reached; later lines are not streamed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// CLIOutputLine is a single line of CLI output.
+type CLIOutputLine struct {
+	Stream string `json:"stream"` // "stdout" or "stderr"
+	Text   string `json:"text"`
+	// Truncated is set when the line was cut to the maximum streamed line length.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// CLI output stream names.
+const (
+	CLIStreamStdout = "stdout"
+	CLIStreamStderr = "stderr"
+)
+
 // CLIParsedResult contains structured output from CLI command parsing.
 type CLIParsedResult struct {
 	Total    int              `json:"total,omitempty"`
@@ -0,0 +1,26 @@
+package ws
+
+// TaskLeaseRenewPayload는 Bridge가 실행 중인 작업의 리스를 갱신하는 메시지입니다.
+// 작업이 활성 상태인 동안 주기적으로 전송되며, 서버는 LeaseSeconds 안에 갱신이 없으면
+// 작업을 다른 Bridge에 재할당할 수 있습니다.
+// Message type: task_lease_renew (Bridge -> Server)
+type TaskLeaseRenewPayload struct {
+	// ExecutionID는 리스를 갱신할 실행 ID입니다.
+	ExecutionID string `json:"execution_id"`
+	// TaskType은 작업 유형입니다 ("task", "agent_response", "build", "test", "qa").
+	TaskType string `json:"task_type,omitempty"`
+	// LeaseSeconds는 이번 갱신으로 연장을 요청하는 리스 기간(초)입니다.
+	LeaseSeconds int `json:"lease_seconds"`
+	// ElapsedMs는 작업 시작 후 경과 시간(밀리초)입니다.
+	ElapsedMs int64 `json:"elapsed_ms"`
+}
+
+// TaskLeaseRevokePayload는 서버가 작업 리스를 회수하는 메시지입니다.
+// Bridge는 해당 실행을 즉시 취소하고 결과를 보고하지 않습니다.
+// Message type: task_lease_revoke (Server -> Bridge)
+type TaskLeaseRevokePayload struct {
+	// ExecutionID는 리스가 회수된 실행 ID입니다.
+	ExecutionID string `json:"execution_id"`
+	// Reason은 회수 사유입니다 (예: "reassigned", "expired", "cancelled").
+	Reason string `json:"reason,omitempty"`
+}
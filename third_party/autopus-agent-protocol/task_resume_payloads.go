@@ -0,0 +1,37 @@
+package ws
+
+import "time"
+
+// task_resume 액션 값
+const (
+	// TaskResumeActionOffer는 Bridge가 재개 가능한 체크포인트를 서버에 알리는 액션입니다.
+	TaskResumeActionOffer = "offer"
+	// TaskResumeActionResume은 서버가 체크포인트에서 작업 재개를 지시하는 액션입니다.
+	TaskResumeActionResume = "resume"
+	// TaskResumeActionDiscard는 서버가 체크포인트 폐기를 지시하는 액션입니다 (재할당/취소된 작업).
+	TaskResumeActionDiscard = "discard"
+)
+
+// TaskResumePayload는 Bridge 크래시/재시작으로 중단된 작업의 재개를 조율하는 메시지입니다.
+// Bridge는 연결 직후 남아 있는 체크포인트마다 Action "offer"로 전송하고,
+// 서버는 같은 ExecutionID로 "resume" 또는 "discard"를 응답합니다.
+// Message type: task_resume (Server <-> Bridge)
+type TaskResumePayload struct {
+	// ExecutionID는 중단된 작업의 실행 ID입니다.
+	ExecutionID string `json:"execution_id"`
+	// Action은 "offer", "resume", "discard" 중 하나입니다.
+	Action string `json:"action"`
+	// Provider는 체크포인트 시점의 프로바이더 이름입니다 (offer).
+	Provider string `json:"provider,omitempty"`
+	// Model은 체크포인트 시점의 모델입니다 (offer).
+	Model string `json:"model,omitempty"`
+	// SessionID는 재개할 프로바이더 세션/Thread ID입니다 (offer).
+	// 비어 있으면 재개 시 작업을 처음부터 다시 실행합니다.
+	SessionID string `json:"session_id,omitempty"`
+	// StepIndex는 체크포인트 시점까지 완료된 단계(어시스턴트 턴) 수입니다 (offer).
+	StepIndex int `json:"step_index,omitempty"`
+	// AccumulatedOutput은 체크포인트 시점까지 누적된 출력입니다 (offer).
+	AccumulatedOutput string `json:"accumulated_output,omitempty"`
+	// CheckpointedAt은 마지막 체크포인트 저장 시각입니다 (offer).
+	CheckpointedAt time.Time `json:"checkpointed_at,omitempty"`
+}
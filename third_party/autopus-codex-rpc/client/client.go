@@ -1,13 +1,15 @@
 package client
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/insajin/autopus-codex-rpc/protocol"
 )
@@ -19,18 +21,65 @@ type NotificationHandler func(method string, params json.RawMessage)
 // id는 응답 시 사용되는 요청 식별자이며, 반환된 interface{}는 결과로 직렬화된다.
 type RequestHandler func(id int64, method string, params json.RawMessage) interface{}
 
+// ErrClientClosed는 Close()로 종료된 클라이언트에서 요청이 실패했을 때 반환된다.
+var ErrClientClosed = errors.New("JSON-RPC 클라이언트가 종료되었습니다")
+
+// ErrConnectionLost는 서버 프로세스 종료 등으로 stdout이 닫혀 요청이 실패했을 때 반환된다.
+var ErrConnectionLost = errors.New("JSON-RPC 서버 연결이 끊어졌습니다")
+
+// Option은 클라이언트 생성 옵션이다.
+type Option func(*Client)
+
+// WithRequestTimeout은 모든 Call에 적용할 기본 요청 타임아웃을 설정한다.
+// 호출자 ctx의 데드라인이 더 짧으면 ctx가 우선한다. 0 이하이면 타임아웃을 적용하지 않는다.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.requestTimeout = d
+	}
+}
+
+// WithCancelMethod는 요청 취소 시 전송할 알림 메서드를 설정한다.
+// 빈 문자열이면 취소 알림을 보내지 않는다. 기본값은 protocol.MethodCancelRequest이다.
+func WithCancelMethod(method string) Option {
+	return func(c *Client) {
+		c.cancelMethod = method
+	}
+}
+
+// InFlightRequest는 응답을 기다리고 있는 요청 정보이다.
+type InFlightRequest struct {
+	// ID는 요청 식별자이다.
+	ID int64
+	// Method는 요청 메서드 이름이다.
+	Method string
+	// StartedAt은 요청 전송 시각이다.
+	StartedAt time.Time
+}
+
+// pendingCall은 응답 대기 중인 요청이다.
+type pendingCall struct {
+	method    string
+	startedAt time.Time
+	ch        chan *protocol.JSONRPCResponse
+}
+
 // Client는 stdio 기반 JSON-RPC 2.0 클라이언트이다.
 // 동시성 안전하게 요청/응답을 관리하며, 알림을 등록된 핸들러로 디스패치한다.
+// 메시지 프레이밍은 Framer로 추상화되어 있다 (기본값: newline-delimited JSON).
 // 외부 의존성 없이 stdlib만 사용한다.
 type Client struct {
 	stdin  io.WriteCloser
-	stdout *bufio.Scanner
+	stdout FrameReader
+	framer Framer
 	logger Logger
 
 	nextID    atomic.Int64
-	pending   map[int64]chan *protocol.JSONRPCResponse
+	pending   map[int64]*pendingCall
 	pendingMu sync.Mutex
 
+	requestTimeout time.Duration
+	cancelMethod   string
+
 	notifyHandlers  map[string]NotificationHandler
 	requestHandlers map[string]RequestHandler
 	handlersMu      sync.RWMutex
@@ -40,44 +89,55 @@ type Client struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	done   chan struct{} // readLoop이 종료되면 닫힌다.
+
+	closeErr   error // 대기 중인 요청에 전달할 종료 사유
+	closeErrMu sync.Mutex
 }
 
 // NewJSONRPCClient는 새로운 JSON-RPC 클라이언트를 생성하고 readLoop을 시작한다.
 // stdin은 서버로 요청을 전송하는 WriteCloser이다.
 // stdout은 서버 응답을 읽는 Reader이다.
 // logger는 로깅 인터페이스이다. 로깅이 필요 없으면 NopLogger()를 사용한다.
-func NewJSONRPCClient(stdin io.WriteCloser, stdout io.Reader, logger Logger) *Client {
+// opts로 요청 타임아웃, 취소 알림 메서드, 프레이밍을 설정할 수 있다.
+func NewJSONRPCClient(stdin io.WriteCloser, stdout io.Reader, logger Logger, opts ...Option) *Client {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	scanner := bufio.NewScanner(stdout)
-	// 기본 버퍼를 1MB로 확장하여 큰 JSON 응답을 처리한다.
-	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
-
 	c := &Client{
 		stdin:           stdin,
-		stdout:          scanner,
+		framer:          LineFraming{},
 		logger:          logger,
-		pending:         make(map[int64]chan *protocol.JSONRPCResponse),
+		pending:         make(map[int64]*pendingCall),
+		cancelMethod:    protocol.MethodCancelRequest,
 		notifyHandlers:  make(map[string]NotificationHandler),
 		requestHandlers: make(map[string]RequestHandler),
 		ctx:             ctx,
 		cancel:          cancel,
 		done:            make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.stdout = c.framer.NewReader(stdout)
 
 	go c.readLoop()
 	return c
 }
 
 // Call은 JSON-RPC 요청을 전송하고 응답을 대기한다.
-// ctx 타임아웃 또는 취소로 중단될 수 있다.
+// ctx 타임아웃 또는 취소, WithRequestTimeout으로 설정한 기본 타임아웃으로 중단될 수 있으며,
+// 이 경우 서버에 취소 알림을 보낸다.
+// 응답 전에 서버 연결이 끊어지면 ErrConnectionLost를, Close() 후에는 ErrClientClosed를 감싼 에러를 반환한다.
 // params는 json.Marshal로 직렬화되어 전송된다.
 func (c *Client) Call(ctx context.Context, method string, params interface{}) (*json.RawMessage, error) {
 	// 클라이언트 종료 여부 확인
-	select {
-	case <-c.ctx.Done():
-		return nil, fmt.Errorf("JSON-RPC 클라이언트가 종료되었습니다")
-	default:
+	if err := c.closedErr(); err != nil {
+		return nil, fmt.Errorf("%w (method=%s)", err, method)
+	}
+
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
 	}
 
 	id := c.nextID.Add(1)
@@ -100,7 +160,7 @@ func (c *Client) Call(ctx context.Context, method string, params interface{}) (*
 	// 응답 채널 등록
 	respCh := make(chan *protocol.JSONRPCResponse, 1)
 	c.pendingMu.Lock()
-	c.pending[id] = respCh
+	c.pending[id] = &pendingCall{method: method, startedAt: time.Now(), ch: respCh}
 	c.pendingMu.Unlock()
 
 	// 요청 완료 후 채널 정리 보장
@@ -110,6 +170,11 @@ func (c *Client) Call(ctx context.Context, method string, params interface{}) (*
 		c.pendingMu.Unlock()
 	}()
 
+	// 등록 직전에 서버 연결이 끊어졌으면 failPending이 이 요청을 닫지 못하므로 다시 확인
+	if err := c.closedErr(); err != nil {
+		return nil, fmt.Errorf("%w (method=%s)", err, method)
+	}
+
 	// 요청 전송
 	if err := c.writeJSON(req); err != nil {
 		return nil, err
@@ -120,15 +185,19 @@ func (c *Client) Call(ctx context.Context, method string, params interface{}) (*
 	// 응답 대기
 	// c.ctx.Done(): Close() 호출 또는 다른 취소 시 종료
 	// ctx.Done(): 호출자 타임아웃 또는 취소
-	// respCh: 실제 응답 수신
+	// respCh: 실제 응답 수신, 서버 연결 끊김 시에는 failPending이 닫는다
 	select {
 	case <-ctx.Done():
-		return nil, fmt.Errorf("JSON-RPC 호출 타임아웃 (method=%s): %w", method, ctx.Err())
+		c.sendCancel(id, method)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("JSON-RPC 호출 타임아웃 (method=%s): %w", method, ctx.Err())
+		}
+		return nil, fmt.Errorf("JSON-RPC 호출 취소 (method=%s): %w", method, ctx.Err())
 	case <-c.ctx.Done():
-		return nil, fmt.Errorf("JSON-RPC 클라이언트가 종료되었습니다")
+		return nil, fmt.Errorf("%w (method=%s)", c.closedErr(), method)
 	case resp, ok := <-respCh:
 		if !ok || resp == nil {
-			return nil, fmt.Errorf("JSON-RPC 클라이언트가 종료되었습니다")
+			return nil, fmt.Errorf("%w (method=%s)", c.closedErr(), method)
 		}
 		if resp.Error != nil {
 			return nil, protocol.MapJSONRPCError(resp.Error)
@@ -137,13 +206,76 @@ func (c *Client) Call(ctx context.Context, method string, params interface{}) (*
 	}
 }
 
+// sendCancel은 응답을 더 이상 기다리지 않는 요청의 취소 알림을 서버에 보낸다.
+// 서버 연결이 이미 끊어졌거나 취소 메서드가 비어 있으면 보내지 않는다.
+func (c *Client) sendCancel(id int64, method string) {
+	if c.cancelMethod == "" || c.closedErr() != nil {
+		return
+	}
+	if err := c.Notify(c.cancelMethod, protocol.CancelRequestParams{ID: id}); err != nil {
+		c.logger.Debug("JSON-RPC 취소 알림 전송 실패", "id", id, "method", method, "err", err)
+		return
+	}
+	c.logger.Debug("JSON-RPC 요청 취소 알림 전송", "id", id, "method", method)
+}
+
+// InFlight는 응답을 기다리고 있는 요청 목록을 ID 순으로 반환한다.
+func (c *Client) InFlight() []InFlightRequest {
+	c.pendingMu.Lock()
+	requests := make([]InFlightRequest, 0, len(c.pending))
+	for id, call := range c.pending {
+		requests = append(requests, InFlightRequest{ID: id, Method: call.method, StartedAt: call.startedAt})
+	}
+	c.pendingMu.Unlock()
+
+	sort.Slice(requests, func(i, j int) bool { return requests[i].ID < requests[j].ID })
+	return requests
+}
+
+// InFlightCount는 응답을 기다리고 있는 요청 수를 반환한다.
+func (c *Client) InFlightCount() int {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	return len(c.pending)
+}
+
+// Err는 클라이언트가 종료된 사유를 반환한다. 아직 사용 가능하면 nil이다.
+// 서버 연결이 끊어졌으면 ErrConnectionLost, Close()로 종료했으면 ErrClientClosed를 감싼다.
+func (c *Client) Err() error {
+	return c.closedErr()
+}
+
+// closedErr는 종료 사유를 반환한다. 종료되지 않았으면 nil이다.
+func (c *Client) closedErr() error {
+	c.closeErrMu.Lock()
+	defer c.closeErrMu.Unlock()
+	return c.closeErr
+}
+
+// setCloseErr는 최초의 종료 사유만 기록한다.
+func (c *Client) setCloseErr(err error) {
+	c.closeErrMu.Lock()
+	defer c.closeErrMu.Unlock()
+	if c.closeErr == nil {
+		c.closeErr = err
+	}
+}
+
+// failPending은 대기 중인 모든 요청 채널을 닫아 종료 신호를 전달한다.
+func (c *Client) failPending() {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	for id, call := range c.pending {
+		close(call.ch)
+		delete(c.pending, id)
+	}
+}
+
 // Notify는 JSON-RPC 알림을 전송한다 (응답을 기대하지 않음).
 // params가 nil이면 params 필드 없이 전송한다.
 func (c *Client) Notify(method string, params interface{}) error {
-	select {
-	case <-c.ctx.Done():
-		return fmt.Errorf("JSON-RPC 클라이언트가 종료되었습니다")
-	default:
+	if err := c.closedErr(); err != nil {
+		return err
 	}
 
 	notif := protocol.JSONRPCNotification{
@@ -190,35 +322,27 @@ func (c *Client) OnRequest(method string, handler RequestHandler) {
 // 호출 후 모든 진행 중인 Call은 에러와 함께 반환된다.
 // stdin을 닫아 readLoop에 EOF를 전달한다.
 func (c *Client) Close() error {
+	c.setCloseErr(ErrClientClosed)
 	c.cancel()
 
 	// 대기 중인 모든 채널을 닫아 종료 신호 전달
-	c.pendingMu.Lock()
-	for id, ch := range c.pending {
-		close(ch)
-		delete(c.pending, id)
-	}
-	c.pendingMu.Unlock()
+	c.failPending()
 
 	// stdin 닫기 (서버에 EOF 신호 전달하여 readLoop 종료 유도)
 	return c.stdin.Close()
 }
 
-// writeJSON은 임의의 구조체를 JSON으로 직렬화하여 stdin에 줄바꿈과 함께 기록한다.
+// writeJSON은 임의의 구조체를 JSON으로 직렬화하여 설정된 프레이밍으로 stdin에 기록한다.
 func (c *Client) writeJSON(v interface{}) error {
 	data, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("직렬화 실패: %w", err)
 	}
 
-	// newline-delimited JSON 형식
-	data = append(data, '\n')
-
 	c.writeMu.Lock()
 	defer c.writeMu.Unlock()
 
-	_, err = c.stdin.Write(data)
-	if err != nil {
+	if err := c.framer.WriteFrame(c.stdin, data); err != nil {
 		return fmt.Errorf("전송 실패: %w", err)
 	}
 	return nil
@@ -230,8 +354,13 @@ func (c *Client) writeJSON(v interface{}) error {
 func (c *Client) readLoop() {
 	defer close(c.done)
 
-	for c.stdout.Scan() {
-		line := c.stdout.Bytes()
+	var cause error
+	for {
+		line, err := c.stdout.ReadFrame()
+		if err != nil {
+			cause = err
+			break
+		}
 		if len(line) == 0 {
 			continue
 		}
@@ -264,17 +393,16 @@ func (c *Client) readLoop() {
 		}
 	}
 
-	if err := c.stdout.Err(); err != nil {
-		c.logger.Debug("readLoop 스캐너 에러", "err", err)
+	if !errors.Is(cause, io.EOF) {
+		c.logger.Debug("readLoop 읽기 에러", "err", cause)
 	}
 
-	// 남은 대기 중인 채널 모두 닫기
-	c.pendingMu.Lock()
-	for id, ch := range c.pending {
-		close(ch)
-		delete(c.pending, id)
+	// 종료 사유를 먼저 기록한 뒤 남은 대기 중인 채널 모두 닫기
+	if inFlight := c.InFlightCount(); inFlight > 0 {
+		c.logger.Warn("응답 대기 중 서버 연결 끊김", "inFlight", inFlight, "err", cause)
 	}
-	c.pendingMu.Unlock()
+	c.setCloseErr(fmt.Errorf("%w: %v", ErrConnectionLost, cause))
+	c.failPending()
 }
 
 // handleResponse는 응답 메시지를 파싱하여 해당 pending 채널로 전달한다.
@@ -291,7 +419,7 @@ func (c *Client) handleResponse(data []byte) {
 	}
 
 	c.pendingMu.Lock()
-	ch, ok := c.pending[*resp.ID]
+	call, ok := c.pending[*resp.ID]
 	c.pendingMu.Unlock()
 
 	if !ok {
@@ -306,7 +434,7 @@ func (c *Client) handleResponse(data []byte) {
 		}
 	}()
 
-	ch <- &resp
+	call.ch <- &resp
 }
 
 // handleServerRequest는 서버에서 클라이언트로 전송된 JSON-RPC 요청을 파싱하여 등록된 핸들러를 호출하고 응답을 전송한다.
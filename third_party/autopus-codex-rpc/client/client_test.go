@@ -3,6 +3,7 @@ package client_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -661,3 +662,206 @@ func TestCall_MarshalError(t *testing.T) {
 		t.Fatal("직렬화 불가 params로 Call은 에러를 반환해야 함")
 	}
 }
+
+// readCancelNotification은 stdin에서 $/cancelRequest 알림을 찾아 취소된 요청 ID를 반환한다.
+func readCancelNotification(t *testing.T, stdin *mockPipe) int64 {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case sent := <-stdin.ch:
+			var notif protocol.JSONRPCNotification
+			if err := json.Unmarshal([]byte(strings.TrimSpace(sent)), &notif); err != nil || notif.Method != protocol.MethodCancelRequest {
+				continue
+			}
+			var params protocol.CancelRequestParams
+			if err := json.Unmarshal(notif.Params, &params); err != nil {
+				t.Fatalf("취소 알림 파라미터 파싱 실패: %v", err)
+			}
+			return params.ID
+		case <-deadline:
+			t.Fatal("취소 알림 수신 타임아웃")
+			return 0
+		}
+	}
+}
+
+// TestCall_RequestTimeout은 기본 요청 타임아웃과 취소 알림 전송을 검증한다.
+func TestCall_RequestTimeout(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+	defer stdout.Close()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger(), client.WithRequestTimeout(50*time.Millisecond))
+	defer c.Close()
+
+	start := time.Now()
+	_, err := c.Call(context.Background(), "slow/method", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DeadlineExceeded 에러가 반환되어야 함: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("기본 타임아웃이 적용되지 않음: %v", elapsed)
+	}
+	if id := readCancelNotification(t, stdin); id != 1 {
+		t.Errorf("취소 알림 ID 불일치: got %d, want 1", id)
+	}
+	if n := c.InFlightCount(); n != 0 {
+		t.Errorf("타임아웃 후 in-flight 요청이 남아 있음: %d", n)
+	}
+}
+
+// TestCall_ContextCancel은 호출자 ctx 취소 시 취소 알림 전송을 검증한다.
+func TestCall_ContextCancel(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+	defer stdout.Close()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger())
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.Call(ctx, "slow/method", nil)
+		errCh <- err
+	}()
+
+	// 요청이 전송된 뒤 취소
+	<-stdin.ch
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Canceled 에러가 반환되어야 함: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("취소 후 Call이 반환되지 않음")
+	}
+	if id := readCancelNotification(t, stdin); id != 1 {
+		t.Errorf("취소 알림 ID 불일치: got %d, want 1", id)
+	}
+}
+
+// TestCall_WithoutCancelMethod는 취소 메서드를 비우면 취소 알림을 보내지 않는지 검증한다.
+func TestCall_WithoutCancelMethod(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+	defer stdout.Close()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger(),
+		client.WithRequestTimeout(20*time.Millisecond), client.WithCancelMethod(""))
+	defer c.Close()
+
+	if _, err := c.Call(context.Background(), "slow/method", nil); err == nil {
+		t.Fatal("타임아웃 에러가 반환되어야 함")
+	}
+
+	<-stdin.ch // 요청
+	select {
+	case sent := <-stdin.ch:
+		t.Errorf("취소 알림을 보내지 않아야 함: %s", sent)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestInFlight는 응답 대기 중인 요청 목록을 검증한다.
+func TestInFlight(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+	defer stdout.Close()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger())
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, method := range []string{"first/method", "second/method"} {
+		wg.Add(1)
+		go func(method string) {
+			defer wg.Done()
+			_, _ = c.Call(ctx, method, nil)
+		}(method)
+		<-stdin.ch
+	}
+
+	requests := c.InFlight()
+	if len(requests) != 2 || c.InFlightCount() != 2 {
+		t.Fatalf("in-flight 요청 수 불일치: %+v", requests)
+	}
+	if requests[0].ID != 1 || requests[0].Method != "first/method" || requests[1].Method != "second/method" {
+		t.Errorf("in-flight 요청 정보 불일치: %+v", requests)
+	}
+	if requests[0].StartedAt.IsZero() {
+		t.Error("StartedAt이 기록되어야 함")
+	}
+
+	// 응답을 받으면 목록에서 제거
+	result := json.RawMessage(`{}`)
+	id := requests[0].ID
+	respData, _ := json.Marshal(protocol.JSONRPCResponse{JSONRPC: "2.0", ID: &id, Result: &result})
+	stdout.Send(respData)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for c.InFlightCount() != 1 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := c.InFlight(); len(got) != 1 || got[0].Method != "second/method" {
+		t.Errorf("응답 수신 후 in-flight 요청 불일치: %+v", got)
+	}
+
+	cancel()
+	wg.Wait()
+}
+
+// TestCall_ConnectionLost는 응답 대기 중 서버가 종료되면 ErrConnectionLost가 전파되는지 검증한다.
+func TestCall_ConnectionLost(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger())
+	defer c.Close()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.Call(context.Background(), "slow/method", nil)
+		errCh <- err
+	}()
+
+	// 요청이 전송된 뒤 서버 프로세스 종료 (stdout EOF)
+	<-stdin.ch
+	stdout.Close()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, client.ErrConnectionLost) {
+			t.Fatalf("ErrConnectionLost가 반환되어야 함: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("서버 종료 후 Call이 반환되지 않음")
+	}
+
+	if !errors.Is(c.Err(), client.ErrConnectionLost) {
+		t.Errorf("Err()는 ErrConnectionLost여야 함: %v", c.Err())
+	}
+	if _, err := c.Call(context.Background(), "next/method", nil); !errors.Is(err, client.ErrConnectionLost) {
+		t.Errorf("연결이 끊긴 뒤 Call은 ErrConnectionLost여야 함: %v", err)
+	}
+}
+
+// TestCall_AfterCloseErrClientClosed는 Close 후 Call이 ErrClientClosed를 반환하는지 검증한다.
+func TestCall_AfterCloseErrClientClosed(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+	defer stdout.Close()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger())
+	_ = c.Close()
+
+	if _, err := c.Call(context.Background(), "test/method", nil); !errors.Is(err, client.ErrClientClosed) {
+		t.Errorf("ErrClientClosed가 반환되어야 함: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxMessageSize는 프레이밍이 허용하는 메시지 하나의 기본 최대 크기(1MB)이다.
+const DefaultMaxMessageSize = 1024 * 1024
+
+// Framer는 JSON-RPC 메시지를 바이트 스트림에 싣는 방식(프레이밍)이다.
+// 기본값은 줄바꿈으로 구분하는 LineFraming이며, WithFraming으로 바꿀 수 있다.
+type Framer interface {
+	// NewReader는 r에서 메시지를 하나씩 읽는 FrameReader를 생성한다.
+	NewReader(r io.Reader) FrameReader
+	// WriteFrame은 메시지 하나를 w에 기록한다. 동시 기록은 호출자가 직렬화한다.
+	WriteFrame(w io.Writer, msg []byte) error
+}
+
+// FrameReader는 스트림에서 메시지를 하나씩 읽는다.
+type FrameReader interface {
+	// ReadFrame은 다음 메시지 본문을 반환한다. 스트림이 끝나면 io.EOF를 반환한다.
+	// 반환된 슬라이스는 다음 ReadFrame 호출 전까지만 유효하다.
+	ReadFrame() ([]byte, error)
+}
+
+// WithFraming은 메시지 프레이밍 방식을 설정한다. 기본값은 LineFraming{}이다.
+func WithFraming(f Framer) Option {
+	return func(c *Client) {
+		if f != nil {
+			c.framer = f
+		}
+	}
+}
+
+// ParseFraming은 이름으로 Framer를 반환한다.
+// "line"(또는 "ndjson", 빈 문자열)은 LineFraming, "content-length"(또는 "lsp")는 ContentLengthFraming이다.
+func ParseFraming(name string) (Framer, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "line", "ndjson":
+		return LineFraming{}, nil
+	case "content-length", "lsp":
+		return ContentLengthFraming{}, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 JSON-RPC 프레이밍: %q (line, content-length)", name)
+	}
+}
+
+// LineFraming은 메시지마다 줄바꿈으로 끝나는 newline-delimited JSON 프레이밍이다.
+type LineFraming struct {
+	// MaxMessageSize는 한 줄의 최대 크기이다. 0이면 DefaultMaxMessageSize이다.
+	MaxMessageSize int
+}
+
+// NewReader는 줄 단위로 메시지를 읽는 FrameReader를 생성한다.
+func (f LineFraming) NewReader(r io.Reader) FrameReader {
+	max := f.MaxMessageSize
+	if max <= 0 {
+		max = DefaultMaxMessageSize
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), max)
+	return &lineReader{scanner: scanner}
+}
+
+// WriteFrame은 메시지 뒤에 줄바꿈을 붙여 기록한다.
+func (LineFraming) WriteFrame(w io.Writer, msg []byte) error {
+	data := make([]byte, 0, len(msg)+1)
+	data = append(data, msg...)
+	data = append(data, '\n')
+	_, err := w.Write(data)
+	return err
+}
+
+// lineReader는 LineFraming의 FrameReader이다.
+type lineReader struct {
+	scanner *bufio.Scanner
+}
+
+// ReadFrame은 다음 줄을 반환한다. 빈 줄도 그대로 반환하며 걸러내는 것은 호출자의 몫이다.
+func (r *lineReader) ReadFrame() ([]byte, error) {
+	if r.scanner.Scan() {
+		return r.scanner.Bytes(), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// ContentLengthFraming은 LSP와 같은 "Content-Length: N\r\n\r\n" 헤더 기반 프레이밍이다.
+// Content-Type 등 다른 헤더는 읽을 때 무시한다.
+type ContentLengthFraming struct {
+	// MaxMessageSize는 본문의 최대 크기이다. 0이면 DefaultMaxMessageSize이다.
+	MaxMessageSize int
+}
+
+// NewReader는 헤더와 본문을 읽는 FrameReader를 생성한다.
+func (f ContentLengthFraming) NewReader(r io.Reader) FrameReader {
+	max := f.MaxMessageSize
+	if max <= 0 {
+		max = DefaultMaxMessageSize
+	}
+	return &contentLengthReader{r: bufio.NewReader(r), max: max}
+}
+
+// WriteFrame은 Content-Length 헤더와 본문을 한 번에 기록한다.
+func (ContentLengthFraming) WriteFrame(w io.Writer, msg []byte) error {
+	var buf bytes.Buffer
+	buf.Grow(len(msg) + 32)
+	fmt.Fprintf(&buf, "Content-Length: %d\r\n\r\n", len(msg))
+	buf.Write(msg)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// contentLengthReader는 ContentLengthFraming의 FrameReader이다.
+type contentLengthReader struct {
+	r   *bufio.Reader
+	max int
+	buf []byte
+}
+
+// ReadFrame은 헤더 블록을 읽고 Content-Length만큼 본문을 반환한다.
+func (r *contentLengthReader) ReadFrame() ([]byte, error) {
+	length := -1
+	sawHeader := false
+	for {
+		line, err := r.r.ReadString('\n')
+		if err != nil {
+			// 헤더를 읽기 전에 끝난 스트림은 정상 종료이다.
+			if err == io.EOF && !sawHeader && line == "" {
+				return nil, io.EOF
+			}
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if !sawHeader {
+				// 메시지 사이의 빈 줄은 무시한다.
+				continue
+			}
+			break
+		}
+		sawHeader = true
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || !isHeaderName(name) {
+			return nil, fmt.Errorf("잘못된 JSON-RPC 헤더: %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("잘못된 Content-Length: %q", value)
+			}
+			length = n
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("Content-Length 헤더가 없습니다")
+	}
+	if length > r.max {
+		return nil, fmt.Errorf("JSON-RPC 메시지가 너무 큽니다: %d > %d 바이트", length, r.max)
+	}
+
+	if cap(r.buf) < length {
+		r.buf = make([]byte, length)
+	}
+	r.buf = r.buf[:length]
+	if _, err := io.ReadFull(r.r, r.buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return r.buf, nil
+}
+
+// isHeaderName은 헤더 이름이 영문자, 숫자, '-'로만 이루어졌는지 확인한다.
+// 줄 단위 JSON을 잘못 받았을 때 헤더로 오인하지 않고 바로 실패하도록 한다.
+func isHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insajin/autopus-codex-rpc/client"
+	"github.com/insajin/autopus-codex-rpc/protocol"
+)
+
+// TestFraming_RoundTrip은 두 프레이밍이 기록한 메시지를 그대로 읽어내는지 검증한다.
+func TestFraming_RoundTrip(t *testing.T) {
+	messages := []string{`{"jsonrpc":"2.0","method":"a"}`, `{"jsonrpc":"2.0","id":1,"result":"한글"}`}
+	for _, framer := range []client.Framer{client.LineFraming{}, client.ContentLengthFraming{}} {
+		t.Run(fmt.Sprintf("%T", framer), func(t *testing.T) {
+			var buf bytes.Buffer
+			for _, m := range messages {
+				if err := framer.WriteFrame(&buf, []byte(m)); err != nil {
+					t.Fatalf("WriteFrame 실패: %v", err)
+				}
+			}
+			r := framer.NewReader(&buf)
+			for _, want := range messages {
+				got, err := r.ReadFrame()
+				if err != nil {
+					t.Fatalf("ReadFrame 실패: %v", err)
+				}
+				if string(got) != want {
+					t.Errorf("ReadFrame = %q, want %q", got, want)
+				}
+			}
+			if _, err := r.ReadFrame(); err != io.EOF {
+				t.Errorf("끝에서 ReadFrame 에러 = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+// TestContentLengthFraming_WireFormat은 LSP 형식의 헤더를 기록하는지 검증한다.
+func TestContentLengthFraming_WireFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (client.ContentLengthFraming{}).WriteFrame(&buf, []byte(`{"a":"가"}`)); err != nil {
+		t.Fatalf("WriteFrame 실패: %v", err)
+	}
+	if want := "Content-Length: 11\r\n\r\n{\"a\":\"가\"}"; buf.String() != want {
+		t.Errorf("기록 = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestContentLengthFraming_Read는 헤더 변형과 잘못된 입력 처리를 검증한다.
+func TestContentLengthFraming_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "추가 헤더와 대소문자", input: "content-length: 2\r\nContent-Type: application/vscode-jsonrpc; charset=utf-8\r\n\r\n{}", want: "{}"},
+		{name: "LF만 사용", input: "Content-Length: 2\n\n{}", want: "{}"},
+		{name: "앞의 빈 줄", input: "\r\n\r\nContent-Length: 2\r\n\r\n{}", want: "{}"},
+		{name: "길이 없음", input: "Content-Type: x\r\n\r\n{}", wantErr: "Content-Length"},
+		{name: "잘못된 길이", input: "Content-Length: abc\r\n\r\n{}", wantErr: "Content-Length"},
+		{name: "잘못된 헤더", input: "{\"jsonrpc\":\"2.0\"}\r\n\r\n", wantErr: "헤더"},
+		{name: "너무 큼", input: "Content-Length: 100\r\n\r\n{}", wantErr: "너무 큽니다"},
+		{name: "본문 잘림", input: "Content-Length: 10\r\n\r\n{}", wantErr: io.ErrUnexpectedEOF.Error()},
+		{name: "헤더 잘림", input: "Content-Length: 2\r\n", wantErr: io.ErrUnexpectedEOF.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := client.ContentLengthFraming{MaxMessageSize: 64}.NewReader(strings.NewReader(tt.input))
+			got, err := r.ReadFrame()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadFrame 에러 = %v, want %q 포함", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || string(got) != tt.want {
+				t.Fatalf("ReadFrame = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseFraming은 이름으로 프레이밍을 선택하는지 검증한다.
+func TestParseFraming(t *testing.T) {
+	for name, want := range map[string]client.Framer{
+		"":               client.LineFraming{},
+		"line":           client.LineFraming{},
+		"NDJSON":         client.LineFraming{},
+		"content-length": client.ContentLengthFraming{},
+		"lsp":            client.ContentLengthFraming{},
+	} {
+		got, err := client.ParseFraming(name)
+		if err != nil || got != want {
+			t.Errorf("ParseFraming(%q) = %T, %v, want %T", name, got, err, want)
+		}
+	}
+	if _, err := client.ParseFraming("websocket"); err == nil {
+		t.Error("알 수 없는 프레이밍은 에러여야 함")
+	}
+}
+
+// TestClient_ContentLengthFraming은 Content-Length 프레이밍으로 요청, 응답, 알림을 주고받는지 검증한다.
+func TestClient_ContentLengthFraming(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+	framer := client.ContentLengthFraming{}
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger(), client.WithFraming(framer))
+	defer c.Close()
+
+	notified := make(chan string, 1)
+	c.OnNotification("window/logMessage", func(method string, params json.RawMessage) {
+		notified <- string(params)
+	})
+
+	go func() {
+		raw, ok := <-stdin.ch
+		if !ok {
+			return
+		}
+		body, err := framer.NewReader(strings.NewReader(raw)).ReadFrame()
+		if err != nil {
+			return
+		}
+		var req protocol.JSONRPCRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return
+		}
+
+		var out bytes.Buffer
+		_ = framer.WriteFrame(&out, []byte(`{"jsonrpc":"2.0","method":"window/logMessage","params":{"message":"hi"}}`))
+		_ = framer.WriteFrame(&out, []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"ok":true}}`, req.ID)))
+		stdout.Send(out.Bytes())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := c.Call(ctx, "initialize", nil)
+	if err != nil {
+		t.Fatalf("Call 실패: %v", err)
+	}
+	if string(*result) != `{"ok":true}` {
+		t.Errorf("결과 = %s", *result)
+	}
+	select {
+	case params := <-notified:
+		if params != `{"message":"hi"}` {
+			t.Errorf("알림 params = %s", params)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("알림을 받지 못함")
+	}
+}
+
+// TestClient_FramingErrorLosesConnection은 프레이밍 에러가 연결 끊김으로 처리되는지 검증한다.
+func TestClient_FramingErrorLosesConnection(t *testing.T) {
+	stdin := newMockPipe()
+	stdout := newServerSide()
+
+	c := client.NewJSONRPCClient(stdin, stdout, client.NopLogger(), client.WithFraming(client.ContentLengthFraming{}))
+	defer c.Close()
+
+	// NDJSON 서버에 Content-Length 클라이언트를 잘못 연결한 경우
+	stdout.Send([]byte(`{"jsonrpc":"2.0","method":"x"}`))
+
+	select {
+	case <-c.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("readLoop가 종료되지 않음")
+	}
+	if err := c.Err(); !errors.Is(err, client.ErrConnectionLost) {
+		t.Errorf("Err() = %v, want ErrConnectionLost", err)
+	}
+}
@@ -112,6 +112,12 @@ const (
 	MethodExperimentalFeatureList = "experimentalFeature/list"
 )
 
+// --- JSON-RPC 요청 취소 ---
+
+// MethodCancelRequest는 진행 중인 요청의 취소를 서버에 알리는 알림 메서드이다 (클라이언트 -> 서버).
+// LSP의 $/cancelRequest 규약을 따르며, 지원하지 않는 서버는 무시한다.
+const MethodCancelRequest = "$/cancelRequest"
+
 // --- REQ-010: 알림 메서드 상수 ---
 
 const (
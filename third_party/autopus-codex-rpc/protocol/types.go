@@ -40,6 +40,12 @@ type JSONRPCNotification struct {
 	Params json.RawMessage `json:"params,omitempty"`
 }
 
+// CancelRequestParams는 $/cancelRequest 알림 파라미터이다.
+type CancelRequestParams struct {
+	// ID는 취소할 요청의 식별자이다.
+	ID int64 `json:"id"`
+}
+
 // --- Codex App Server 도메인 타입 ---
 
 // ClientInfo는 클라이언트 식별 정보이다.